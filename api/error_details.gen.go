@@ -6,4 +6,5 @@ type ErrorDetails struct {
 	RequestId     string        `json:"request_id,omitempty"`
 	ErrorId       string        `json:"error_id,omitempty"`
 	RequestFields []*FieldError `json:"request_fields,omitempty"`
+	Banner        *Banner       `json:"banner,omitempty"`
 }
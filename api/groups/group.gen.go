@@ -72,9 +72,10 @@ func (n GroupDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type GroupListResult struct {
-	Items        []*Group
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*Group
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n GroupListResult) GetItems() interface{} {
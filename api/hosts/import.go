@@ -0,0 +1,70 @@
+package hosts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+)
+
+type HostImportResult struct {
+	Item         *HostImport
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n HostImportResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n HostImportResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n HostImportResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// Import creates and updates the static hosts of a host catalog, and adds
+// them to its host sets, from a document in the format, "csv" or "json". If
+// dryRun is true the document is only checked, and the result is what
+// importing it would do.
+func (c *Client) Import(ctx context.Context, hostCatalogId, format, document string, dryRun bool, opt ...Option) (*HostImportResult, error) {
+	if hostCatalogId == "" {
+		return nil, fmt.Errorf("empty hostCatalogId value passed into Import request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"host_catalog_id": hostCatalogId,
+		"format":          format,
+		"document":        document,
+		"dry_run":         dryRun,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", "hosts:import", reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Import request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Import call: %w", err)
+	}
+
+	target := new(HostImportResult)
+	target.Item = new(HostImport)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Import response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
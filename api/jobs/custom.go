@@ -0,0 +1,114 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// The types of jobs which can be started.
+const (
+	// DeleteScopeType is the type of a job which deletes a project, or an
+	// org after each of its projects.
+	DeleteScopeType = "delete-scope"
+	// ImportSnapshotType is the type of a job which imports a snapshot as a
+	// new org.
+	ImportSnapshotType = "import-snapshot"
+)
+
+// StartDeleteScope starts a job deleting the org or project, and returns
+// the job while it's running.
+func (c *Client) StartDeleteScope(ctx context.Context, scopeId string, opt ...Option) (*JobCreateResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into StartDeleteScope request")
+	}
+	return c.start(ctx, "StartDeleteScope", map[string]interface{}{
+		"type":     DeleteScopeType,
+		"scope_id": scopeId,
+	}, opt...)
+}
+
+// StartImportSnapshot starts a job importing the snapshot, as exported from
+// an org, as a new org, and returns the job while it's running.
+func (c *Client) StartImportSnapshot(ctx context.Context, snapshot map[string]interface{}, opt ...Option) (*JobCreateResult, error) {
+	if snapshot == nil {
+		return nil, fmt.Errorf("nil snapshot value passed into StartImportSnapshot request")
+	}
+	return c.start(ctx, "StartImportSnapshot", map[string]interface{}{
+		"type":     ImportSnapshotType,
+		"snapshot": snapshot,
+	}, opt...)
+}
+
+// Cancel asks the running job to stop, and returns the job.
+func (c *Client) Cancel(ctx context.Context, jobId string, opt ...Option) (*JobUpdateResult, error) {
+	if jobId == "" {
+		return nil, fmt.Errorf("empty jobId value passed into Cancel request")
+	}
+	opts, apiOpts := getOpts(opt...)
+	resp, err := c.do(ctx, "Cancel", "POST", fmt.Sprintf("jobs/%s:cancel", jobId), map[string]interface{}{}, opts, apiOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	target := new(JobUpdateResult)
+	target.Item = new(Job)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Cancel response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
+func (c *Client) start(ctx context.Context, call string, body map[string]interface{}, opt ...Option) (*JobCreateResult, error) {
+	opts, apiOpts := getOpts(opt...)
+	resp, err := c.do(ctx, call, "POST", "jobs", body, opts, apiOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	target := new(JobCreateResult)
+	target.Item = new(Job)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s response: %w", call, err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
+func (c *Client) do(ctx context.Context, call, method, path string, body interface{}, opts options, apiOpts []api.Option) (*api.Response, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	req, err := c.client.NewRequest(ctx, method, path, body, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s request: %w", call, err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during %s call: %w", call, err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,260 @@
+package roles
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type EmergencyActivationResult struct {
+	Item         *EmergencyActivation
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n EmergencyActivationResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n EmergencyActivationResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n EmergencyActivationResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// ActivateEmergency activates the emergency role for the user of the client's
+// token, who must be a principal of the role. WithDurationSeconds sets how
+// long the activation lasts.
+func (c *Client) ActivateEmergency(ctx context.Context, roleId string, justification string, opt ...Option) (*EmergencyActivationResult, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("empty roleId value passed into ActivateEmergency request")
+	}
+	if justification == "" {
+		return nil, fmt.Errorf("empty justification value passed into ActivateEmergency request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.postMap["justification"] = justification
+
+	return c.emergency(ctx, "ActivateEmergency", fmt.Sprintf("roles/%s:activate-emergency", roleId), opts, apiOpts)
+}
+
+// RevertEmergency ends the activation of the emergency role before it
+// expires.
+func (c *Client) RevertEmergency(ctx context.Context, roleId string, opt ...Option) (*EmergencyActivationResult, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("empty roleId value passed into RevertEmergency request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	return c.emergency(ctx, "RevertEmergency", fmt.Sprintf("roles/%s:revert-emergency", roleId), opts, apiOpts)
+}
+
+func (c *Client) emergency(ctx context.Context, call, path string, opts options, apiOpts []api.Option) (*EmergencyActivationResult, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", path, opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s request: %w", call, err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during %s call: %w", call, err)
+	}
+
+	ear := new(EmergencyActivationResult)
+	ear.Item = new(EmergencyActivation)
+	apiErr, err := resp.Decode(ear.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s response: %w", call, err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	ear.responseBody = resp.Body
+	ear.responseMap = resp.Map
+	return ear, nil
+}
+
+type RoleDiffResult struct {
+	Item         *RoleDiff
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n RoleDiffResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n RoleDiffResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n RoleDiffResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// Diff returns how the role differs from the role fromRoleId: the changes
+// which would turn fromRoleId into it.
+func (c *Client) Diff(ctx context.Context, roleId string, fromRoleId string, opt ...Option) (*RoleDiffResult, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("empty roleId value passed into Diff request")
+	}
+	if fromRoleId == "" {
+		return nil, fmt.Errorf("empty fromRoleId value passed into Diff request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.queryMap["from_role_id"] = fromRoleId
+
+	return c.diff(ctx, "Diff", roleId, opts, apiOpts)
+}
+
+// DiffBetween returns how the role changed between two points in time. A zero
+// to time compares the role up to now.
+func (c *Client) DiffBetween(ctx context.Context, roleId string, from, to time.Time, opt ...Option) (*RoleDiffResult, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("empty roleId value passed into DiffBetween request")
+	}
+	if from.IsZero() {
+		return nil, fmt.Errorf("empty from value passed into DiffBetween request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.queryMap["from_time"] = from.UTC().Format(time.RFC3339Nano)
+	if !to.IsZero() {
+		opts.queryMap["to_time"] = to.UTC().Format(time.RFC3339Nano)
+	}
+
+	return c.diff(ctx, "DiffBetween", roleId, opts, apiOpts)
+}
+
+func (c *Client) diff(ctx context.Context, call, roleId string, opts options, apiOpts []api.Option) (*RoleDiffResult, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("roles/%s:diff", roleId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s request: %w", call, err)
+	}
+
+	q := url.Values{}
+	for k, v := range opts.queryMap {
+		q.Add(k, v)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during %s call: %w", call, err)
+	}
+
+	rdr := new(RoleDiffResult)
+	rdr.Item = new(RoleDiff)
+	apiErr, err := resp.Decode(rdr.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s response: %w", call, err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	rdr.responseBody = resp.Body
+	rdr.responseMap = resp.Map
+	return rdr, nil
+}
+
+// Archive archives the role, which ends its grants. An archived role can't be
+// updated until it's restored, and is only listed WithIncludeArchived.
+func (c *Client) Archive(ctx context.Context, roleId string, version uint32, opt ...Option) (*RoleUpdateResult, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("empty roleId value passed into Archive request")
+	}
+	return c.archival(ctx, "Archive", roleId, version, opt...)
+}
+
+// Restore restores the archived role. WithExpirationTime sets when the
+// restored role expires; without it the role doesn't expire.
+func (c *Client) Restore(ctx context.Context, roleId string, version uint32, opt ...Option) (*RoleUpdateResult, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("empty roleId value passed into Restore request")
+	}
+	return c.archival(ctx, "Restore", roleId, version, opt...)
+}
+
+func (c *Client) archival(ctx context.Context, call, roleId string, version uint32, opt ...Option) (*RoleUpdateResult, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, fmt.Errorf("zero version number passed into %s request", call)
+		}
+		existingTarget, existingErr := c.Read(ctx, roleId, opt...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingTarget == nil || existingTarget.Item == nil {
+			return nil, fmt.Errorf("nil resource found when performing initial check-and-set read")
+		}
+		version = existingTarget.Item.Version
+	}
+
+	opts.postMap["version"] = version
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("roles/%s:%s", roleId, strings.ToLower(call)), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s request: %w", call, err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during %s call: %w", call, err)
+	}
+
+	target := new(RoleUpdateResult)
+	target.Item = new(Role)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s response: %w", call, err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
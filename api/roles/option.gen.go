@@ -1,6 +1,9 @@
 package roles
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/hashicorp/boundary/api"
 )
 
@@ -57,6 +60,24 @@ func DefaultDescription() Option {
 	}
 }
 
+func WithDurationSeconds(inDurationSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["duration_seconds"] = inDurationSeconds
+	}
+}
+
+func WithExpirationTime(inExpirationTime time.Time) Option {
+	return func(o *options) {
+		o.postMap["expiration_time"] = inExpirationTime
+	}
+}
+
+func DefaultExpirationTime() Option {
+	return func(o *options) {
+		o.postMap["expiration_time"] = nil
+	}
+}
+
 func WithGrantScopeId(inGrantScopeId string) Option {
 	return func(o *options) {
 		o.postMap["grant_scope_id"] = inGrantScopeId
@@ -69,6 +90,12 @@ func DefaultGrantScopeId() Option {
 	}
 }
 
+func WithIncludeArchived(inIncludeArchived bool) Option {
+	return func(o *options) {
+		o.queryMap["include_archived"] = fmt.Sprintf("%v", inIncludeArchived)
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
@@ -80,3 +107,15 @@ func DefaultName() Option {
 		o.postMap["name"] = nil
 	}
 }
+
+func WithPageSize(inPageSize uint32) Option {
+	return func(o *options) {
+		o.queryMap["page_size"] = fmt.Sprintf("%v", inPageSize)
+	}
+}
+
+func WithPageToken(inPageToken string) Option {
+	return func(o *options) {
+		o.queryMap["page_token"] = fmt.Sprintf("%v", inPageToken)
+	}
+}
@@ -14,19 +14,21 @@ import (
 )
 
 type Role struct {
-	Id           string            `json:"id,omitempty"`
-	ScopeId      string            `json:"scope_id,omitempty"`
-	Scope        *scopes.ScopeInfo `json:"scope,omitempty"`
-	Name         string            `json:"name,omitempty"`
-	Description  string            `json:"description,omitempty"`
-	CreatedTime  time.Time         `json:"created_time,omitempty"`
-	UpdatedTime  time.Time         `json:"updated_time,omitempty"`
-	Version      uint32            `json:"version,omitempty"`
-	GrantScopeId string            `json:"grant_scope_id,omitempty"`
-	PrincipalIds []string          `json:"principal_ids,omitempty"`
-	Principals   []*Principal      `json:"principals,omitempty"`
-	GrantStrings []string          `json:"grant_strings,omitempty"`
-	Grants       []*Grant          `json:"grants,omitempty"`
+	Id             string            `json:"id,omitempty"`
+	ScopeId        string            `json:"scope_id,omitempty"`
+	Scope          *scopes.ScopeInfo `json:"scope,omitempty"`
+	Name           string            `json:"name,omitempty"`
+	Description    string            `json:"description,omitempty"`
+	CreatedTime    time.Time         `json:"created_time,omitempty"`
+	UpdatedTime    time.Time         `json:"updated_time,omitempty"`
+	Version        uint32            `json:"version,omitempty"`
+	GrantScopeId   string            `json:"grant_scope_id,omitempty"`
+	PrincipalIds   []string          `json:"principal_ids,omitempty"`
+	Principals     []*Principal      `json:"principals,omitempty"`
+	GrantStrings   []string          `json:"grant_strings,omitempty"`
+	Grants         []*Grant          `json:"grants,omitempty"`
+	ExpirationTime time.Time         `json:"expiration_time,omitempty"`
+	ArchivedTime   time.Time         `json:"archived_time,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -75,9 +77,10 @@ func (n RoleDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type RoleListResult struct {
-	Items        []*Role
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*Role
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n RoleListResult) GetItems() interface{} {
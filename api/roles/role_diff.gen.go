@@ -0,0 +1,19 @@
+// Code generated by "make api"; DO NOT EDIT.
+package roles
+
+import (
+	"time"
+)
+
+type RoleDiff struct {
+	RoleId            string           `json:"role_id,omitempty"`
+	FromRoleId        string           `json:"from_role_id,omitempty"`
+	FromTime          time.Time        `json:"from_time,omitempty"`
+	ToTime            time.Time        `json:"to_time,omitempty"`
+	Fields            []*RoleFieldDiff `json:"fields,omitempty"`
+	GrantsAdded       []string         `json:"grants_added,omitempty"`
+	GrantsRemoved     []string         `json:"grants_removed,omitempty"`
+	PrincipalsAdded   []string         `json:"principals_added,omitempty"`
+	PrincipalsRemoved []string         `json:"principals_removed,omitempty"`
+	Summary           []string         `json:"summary,omitempty"`
+}
@@ -0,0 +1,178 @@
+package scopes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type BannedGrantPatternReadResult struct {
+	Item         *BannedGrantPattern
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n BannedGrantPatternReadResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n BannedGrantPatternReadResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n BannedGrantPatternReadResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+type BannedGrantPatternListResult struct {
+	Items        []*BannedGrantPattern
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n BannedGrantPatternListResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n BannedGrantPatternListResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n BannedGrantPatternListResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+type BannedGrantPatternRemoveResult struct {
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n BannedGrantPatternRemoveResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n BannedGrantPatternRemoveResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// ListBannedGrantPatterns lists the grant patterns banned in the scope. The
+// patterns banned in the scopes above it aren't included.
+func (c *Client) ListBannedGrantPatterns(ctx context.Context, scopeId string, opt ...Option) (*BannedGrantPatternListResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ListBannedGrantPatterns request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	resp, err := c.do(ctx, "ListBannedGrantPatterns", "GET", fmt.Sprintf("scopes/%s:list-banned-grant-patterns", scopeId), nil, opts, apiOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	target := new(BannedGrantPatternListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListBannedGrantPatterns response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
+// AddBannedGrantPattern bans grants matching the pattern in the scope and the
+// scopes beneath it. The name is the policy reported when a grant is refused.
+// WithDescription sets the pattern's description.
+func (c *Client) AddBannedGrantPattern(ctx context.Context, scopeId string, name string, pattern string, opt ...Option) (*BannedGrantPatternReadResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into AddBannedGrantPattern request")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("empty name value passed into AddBannedGrantPattern request")
+	}
+	if pattern == "" {
+		return nil, fmt.Errorf("empty pattern value passed into AddBannedGrantPattern request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.postMap["name"] = name
+	opts.postMap["pattern"] = pattern
+
+	resp, err := c.do(ctx, "AddBannedGrantPattern", "POST", fmt.Sprintf("scopes/%s:add-banned-grant-pattern", scopeId), opts.postMap, opts, apiOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	target := new(BannedGrantPatternReadResult)
+	target.Item = new(BannedGrantPattern)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding AddBannedGrantPattern response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
+// RemoveBannedGrantPattern removes the banned grant pattern with the name from
+// the scope.
+func (c *Client) RemoveBannedGrantPattern(ctx context.Context, scopeId string, name string, opt ...Option) (*BannedGrantPatternRemoveResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into RemoveBannedGrantPattern request")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("empty name value passed into RemoveBannedGrantPattern request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	resp, err := c.do(ctx, "RemoveBannedGrantPattern", "POST", fmt.Sprintf("scopes/%s:remove-banned-grant-pattern", scopeId), map[string]interface{}{"name": name}, opts, apiOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	apiErr, err := resp.Decode(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RemoveBannedGrantPattern response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return &BannedGrantPatternRemoveResult{
+		responseBody: resp.Body,
+		responseMap:  resp.Map,
+	}, nil
+}
+
+func (c *Client) do(ctx context.Context, call, method, path string, body interface{}, opts options, apiOpts []api.Option) (*api.Response, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	req, err := c.client.NewRequest(ctx, method, path, body, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s request: %w", call, err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during %s call: %w", call, err)
+	}
+	return resp, nil
+}
@@ -71,6 +71,18 @@ func DefaultName() Option {
 	}
 }
 
+func WithPageSize(inPageSize uint32) Option {
+	return func(o *options) {
+		o.queryMap["page_size"] = fmt.Sprintf("%v", inPageSize)
+	}
+}
+
+func WithPageToken(inPageToken string) Option {
+	return func(o *options) {
+		o.queryMap["page_token"] = fmt.Sprintf("%v", inPageToken)
+	}
+}
+
 func WithSkipAdminRoleCreation(inSkipAdminRoleCreation bool) Option {
 	return func(o *options) {
 		o.queryMap["skip_admin_role_creation"] = fmt.Sprintf("%v", inSkipAdminRoleCreation)
@@ -70,9 +70,10 @@ func (n ScopeDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type ScopeListResult struct {
-	Items        []*Scope
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*Scope
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n ScopeListResult) GetItems() interface{} {
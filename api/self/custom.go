@@ -0,0 +1,103 @@
+package self
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// ListSessions lists the recent sessions of the user the client's token
+// belongs to.
+func (c *Client) ListSessions(ctx context.Context, opt ...Option) (*UserSessionListResult, error) {
+	opts, apiOpts := getOpts(opt...)
+	resp, err := c.do(ctx, "ListSessions", "GET", "self/sessions", nil, opts, apiOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	target := new(UserSessionListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListSessions response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
+// ListAuthTokens lists the auth tokens of the user the client's token
+// belongs to. The client's own token is marked current.
+func (c *Client) ListAuthTokens(ctx context.Context, opt ...Option) (*UserAuthTokenListResult, error) {
+	opts, apiOpts := getOpts(opt...)
+	resp, err := c.do(ctx, "ListAuthTokens", "GET", "self/auth-tokens", nil, opts, apiOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	target := new(UserAuthTokenListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListAuthTokens response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
+// RevokeAuthToken revokes one of the auth tokens of the user the client's
+// token belongs to.
+func (c *Client) RevokeAuthToken(ctx context.Context, authTokenId string, opt ...Option) (*UserAuthTokenDeleteResult, error) {
+	if authTokenId == "" {
+		return nil, fmt.Errorf("empty authTokenId value passed into RevokeAuthToken request")
+	}
+	opts, apiOpts := getOpts(opt...)
+	resp, err := c.do(ctx, "RevokeAuthToken", "POST", fmt.Sprintf("self/auth-tokens/%s:revoke", authTokenId), map[string]interface{}{}, opts, apiOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	apiErr, err := resp.Decode(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RevokeAuthToken response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return &UserAuthTokenDeleteResult{
+		responseBody: resp.Body,
+		responseMap:  resp.Map,
+	}, nil
+}
+
+func (c *Client) do(ctx context.Context, call, method, path string, body interface{}, opts options, apiOpts []api.Option) (*api.Response, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	req, err := c.client.NewRequest(ctx, method, path, body, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s request: %w", call, err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during %s call: %w", call, err)
+	}
+	return resp, nil
+}
@@ -57,6 +57,114 @@ func DefaultAttributes() Option {
 	}
 }
 
+func WithTcpTargetBanner(inBanner string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["banner"] = inBanner
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultTcpTargetBanner() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["banner"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithTcpTargetBannerAcknowledgmentRequired(inBannerAcknowledgmentRequired bool) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["banner_acknowledgment_required"] = inBannerAcknowledgmentRequired
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultTcpTargetBannerAcknowledgmentRequired() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["banner_acknowledgment_required"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithBannerHash(inBannerHash string) Option {
+	return func(o *options) {
+		o.postMap["banner_hash"] = inBannerHash
+	}
+}
+
+func WithChangeTicket(inChangeTicket string) Option {
+	return func(o *options) {
+		o.postMap["change_ticket"] = inChangeTicket
+	}
+}
+
+func WithTcpTargetChangeTicketSystem(inChangeTicketSystem string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["change_ticket_system"] = inChangeTicketSystem
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultTcpTargetChangeTicketSystem() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["change_ticket_system"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithTcpTargetClientCertificateLibraryId(inClientCertificateLibraryId string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["client_certificate_library_id"] = inClientCertificateLibraryId
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultTcpTargetClientCertificateLibraryId() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["client_certificate_library_id"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithTcpTargetDefaultPort(inDefaultPort uint32) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
@@ -99,6 +207,36 @@ func WithHostId(inHostId string) Option {
 	}
 }
 
+func WithJustification(inJustification string) Option {
+	return func(o *options) {
+		o.postMap["justification"] = inJustification
+	}
+}
+
+func WithTcpTargetJustificationPattern(inJustificationPattern string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["justification_pattern"] = inJustificationPattern
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultTcpTargetJustificationPattern() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["justification_pattern"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
@@ -2,5 +2,10 @@
 package targets
 
 type TcpTargetAttributes struct {
-	DefaultPort uint32 `json:"default_port,omitempty"`
+	DefaultPort                  uint32 `json:"default_port,omitempty"`
+	Banner                       string `json:"banner,omitempty"`
+	BannerAcknowledgmentRequired bool   `json:"banner_acknowledgment_required,omitempty"`
+	JustificationPattern         string `json:"justification_pattern,omitempty"`
+	ChangeTicketSystem           string `json:"change_ticket_system,omitempty"`
+	ClientCertificateLibraryId   string `json:"client_certificate_library_id,omitempty"`
 }
@@ -1,6 +1,8 @@
 package users
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/boundary/api"
 )
 
@@ -68,3 +70,15 @@ func DefaultName() Option {
 		o.postMap["name"] = nil
 	}
 }
+
+func WithPageSize(inPageSize uint32) Option {
+	return func(o *options) {
+		o.queryMap["page_size"] = fmt.Sprintf("%v", inPageSize)
+	}
+}
+
+func WithPageToken(inPageToken string) Option {
+	return func(o *options) {
+		o.queryMap["page_token"] = fmt.Sprintf("%v", inPageToken)
+	}
+}
@@ -72,9 +72,10 @@ func (n UserDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type UserListResult struct {
-	Items        []*User
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*User
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n UserListResult) GetItems() interface{} {
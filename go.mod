@@ -7,7 +7,7 @@ replace github.com/hashicorp/boundary/api => ./api
 replace github.com/hashicorp/boundary/sdk => ./sdk
 
 require (
-	github.com/armon/go-metrics v0.3.4
+	github.com/armon/go-metrics v0.3.6
 	github.com/bufbuild/buf v0.24.0
 	github.com/fatih/color v1.9.0
 	github.com/favadi/protoc-go-inject-tag v1.1.0
@@ -0,0 +1,309 @@
+// Package grantlint checks grant strings and policy documents against the
+// grant grammar and a configurable set of rules, without a database or a
+// running controller. It is meant for CI pipelines and validation hooks which
+// need to reject a policy before it is applied.
+//
+// Every grant is checked with the controller's own validation: it must parse
+// in the scope it's granted to, its actions must be ones its type supports and
+// it must not match a banned pattern. Rules are loaded from HCL or JSON:
+//
+//	forbid_wildcard_type = true
+//	forbid_wildcard_id   = false
+//	forbid_all_actions   = true
+//	forbidden_actions    = ["delete"]
+//	allowed_types        = ["host-catalog", "host-set", "target"]
+//	max_actions          = 4
+//
+//	banned_pattern "no-wildcards" {
+//	  pattern = "id=*;actions=*"
+//	}
+//
+// Banned patterns have the form of the controller's banned grant patterns.
+//
+// Policy documents use the format of the controller's declarative iam
+// configuration; only the grants of each role are linted, in the role's grant
+// scope if it sets one and otherwise in the scope the role is declared in.
+package grantlint
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/hcl"
+)
+
+// lintScopeIds are the scopes grants are parsed in for each type of scope.
+// Only the type of the scope matters until grants are evaluated, so any valid
+// id of the type will do.
+var lintScopeIds = map[scope.Type]string{
+	scope.Global:  "global",
+	scope.Org:     "o_abcd1234",
+	scope.Project: "p_abcd1234",
+}
+
+// Rule names reported in findings.
+const (
+	RuleParse           = "parse"
+	RuleWildcardType    = "wildcard-type"
+	RuleWildcardId      = "wildcard-id"
+	RuleAllActions      = "all-actions"
+	RuleForbiddenAction = "forbidden-action"
+	RuleTypeNotAllowed  = "type-not-allowed"
+	RuleTooManyActions  = "too-many-actions"
+	RuleUnsupported     = "unsupported-action"
+	RuleBannedPattern   = "banned-pattern"
+)
+
+// Rules configures the linter. The zero value only checks grants the way the
+// controller does.
+type Rules struct {
+	// ForbidWildcardType rejects grants for all types (type=*).
+	ForbidWildcardType bool `hcl:"forbid_wildcard_type"`
+	// ForbidWildcardId rejects grants for all ids (id=*).
+	ForbidWildcardId bool `hcl:"forbid_wildcard_id"`
+	// ForbidAllActions rejects grants of all actions (actions=*).
+	ForbidAllActions bool `hcl:"forbid_all_actions"`
+	// ForbiddenActions rejects grants of any of the actions.
+	ForbiddenActions []string `hcl:"forbidden_actions"`
+	// AllowedTypes, if set, rejects grants for any other type. Grants
+	// without a type are grants on a specific id and are not checked.
+	AllowedTypes []string `hcl:"allowed_types"`
+	// MaxActions, if positive, rejects grants of more actions.
+	MaxActions int `hcl:"max_actions"`
+	// BannedPatterns rejects grants matching any of the patterns.
+	BannedPatterns []*BannedPattern `hcl:"banned_pattern"`
+}
+
+// BannedPattern is a banned grant pattern, like "id=*;actions=*". Name is the
+// policy named when a grant matches it.
+type BannedPattern struct {
+	Name    string `hcl:",key"`
+	Pattern string `hcl:"pattern"`
+}
+
+// LoadRules reads Rules from an HCL or JSON file.
+func LoadRules(path string) (*Rules, error) {
+	d, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRules(string(d))
+}
+
+// ParseRules parses Rules from HCL or JSON and checks that the actions and
+// types they name exist.
+func ParseRules(d string) (*Rules, error) {
+	obj, err := hcl.Parse(d)
+	if err != nil {
+		return nil, err
+	}
+	r := &Rules{}
+	if err := hcl.DecodeObject(r, obj); err != nil {
+		return nil, err
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Validate checks that the actions and types named by the rules exist and
+// that the banned patterns parse.
+func (r *Rules) Validate() error {
+	for _, a := range r.ForbiddenActions {
+		if _, ok := action.Map[a]; !ok {
+			return fmt.Errorf("forbidden_actions: unknown action %q", a)
+		}
+	}
+	for _, t := range r.AllowedTypes {
+		if _, ok := resource.Map[t]; !ok || t == resource.Unknown.String() {
+			return fmt.Errorf("allowed_types: unknown type %q", t)
+		}
+	}
+	if r.MaxActions < 0 {
+		return fmt.Errorf("max_actions: must not be negative")
+	}
+	if _, err := r.grantPatterns(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// grantPatterns parses the banned patterns.
+func (r *Rules) grantPatterns() ([]*perms.GrantPattern, error) {
+	var patterns []*perms.GrantPattern
+	for _, b := range r.BannedPatterns {
+		p, err := perms.ParseGrantPattern(fmt.Sprintf("%q", b.Name), b.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("banned_pattern %q: %w", b.Name, err)
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+// Finding is a grant which broke a rule. Path locates the grant's role in a
+// policy document and is empty for grants linted with Lint. A finding for a
+// banned pattern which doesn't parse has no grant.
+type Finding struct {
+	Path    string
+	Grant   string
+	Rule    string
+	Message string
+}
+
+func (f *Finding) String() string {
+	if f.Path == "" {
+		return fmt.Sprintf("%q: %s (%s)", f.Grant, f.Message, f.Rule)
+	}
+	return fmt.Sprintf("%s: %q: %s (%s)", f.Path, f.Grant, f.Message, f.Rule)
+}
+
+// Lint returns the findings for the grants of a role in an org, in the order
+// of the grants. A nil Rules only checks the grants the way the controller
+// does.
+func Lint(r *Rules, grants ...string) []*Finding {
+	return LintInScope(r, lintScopeIds[scope.Org], grants...)
+}
+
+// LintInScope returns the findings for grants made to the scope, which is
+// "global" or the id of an org or project, in the order of the grants. Only
+// the type of the scope matters.
+func LintInScope(r *Rules, scopeId string, grants ...string) []*Finding {
+	if r == nil {
+		r = &Rules{}
+	}
+	patterns, err := r.grantPatterns()
+	if err != nil {
+		return []*Finding{{Rule: RuleBannedPattern, Message: err.Error()}}
+	}
+	var findings []*Finding
+	for _, g := range grants {
+		findings = append(findings, r.lint(scopeId, g, patterns)...)
+	}
+	return findings
+}
+
+func (r *Rules) lint(scopeId, grant string, patterns []*perms.GrantPattern) []*Finding {
+	finding := func(rule, format string, a ...interface{}) *Finding {
+		return &Finding{Grant: grant, Rule: rule, Message: fmt.Sprintf(format, a...)}
+	}
+	parsed, err := perms.Parse(scopeId, grant)
+	if err != nil {
+		return []*Finding{finding(RuleParse, "%v", err)}
+	}
+	// The controller's own checks of the grant.
+	if err := perms.ValidateGrant(parsed, patterns...); err != nil {
+		rule := RuleUnsupported
+		if errors.Is(err, perms.ErrGrantBanned) {
+			rule = RuleBannedPattern
+		}
+		return []*Finding{finding(rule, "%v", err)}
+	}
+	// The rules limit what grants allow; a deny grant only takes away.
+	if parsed.Effect() == perms.Deny {
+		return nil
+	}
+	var findings []*Finding
+	if r.ForbidWildcardType && parsed.Type() == resource.All {
+		findings = append(findings, finding(RuleWildcardType, "grants on all types are not allowed"))
+	}
+	if r.ForbidWildcardId && parsed.Id() == "*" {
+		findings = append(findings, finding(RuleWildcardId, "grants on all ids are not allowed"))
+	}
+	if len(r.AllowedTypes) > 0 && parsed.Type() != resource.Unknown && parsed.Type() != resource.All {
+		if !contains(r.AllowedTypes, parsed.Type().String()) {
+			findings = append(findings, finding(RuleTypeNotAllowed, "type %q is not allowed", parsed.Type().String()))
+		}
+	}
+	_, actions := parsed.Actions()
+	sort.Strings(actions)
+	var forbidden []string
+	for _, a := range actions {
+		if a == action.All.String() {
+			if r.ForbidAllActions {
+				findings = append(findings, finding(RuleAllActions, "granting all actions is not allowed"))
+			}
+			continue
+		}
+		if contains(r.ForbiddenActions, a) {
+			forbidden = append(forbidden, a)
+		}
+	}
+	if len(forbidden) > 0 {
+		findings = append(findings, finding(RuleForbiddenAction, "actions %s are not allowed", strings.Join(forbidden, ", ")))
+	}
+	if r.MaxActions > 0 && len(actions) > r.MaxActions {
+		findings = append(findings, finding(RuleTooManyActions, "%d actions are granted, at most %d are allowed", len(actions), r.MaxActions))
+	}
+	return findings
+}
+
+// document is the part of a declarative iam configuration which holds grants.
+type document struct {
+	Orgs []*struct {
+		Name     string          `hcl:",key"`
+		Roles    []*documentRole `hcl:"role"`
+		Projects []*struct {
+			Name  string          `hcl:",key"`
+			Roles []*documentRole `hcl:"role"`
+		} `hcl:"project"`
+	} `hcl:"org"`
+}
+
+type documentRole struct {
+	Name         string   `hcl:",key"`
+	GrantScopeId string   `hcl:"grant_scope_id"`
+	Grants       []string `hcl:"grants"`
+}
+
+// LintDocument returns the findings for the grants of every role in a policy
+// document, which may be HCL or JSON. An error is only returned if the
+// document can't be parsed.
+func LintDocument(r *Rules, d string) ([]*Finding, error) {
+	obj, err := hcl.Parse(d)
+	if err != nil {
+		return nil, err
+	}
+	doc := &document{}
+	if err := hcl.DecodeObject(doc, obj); err != nil {
+		return nil, err
+	}
+	var findings []*Finding
+	lintRoles := func(path string, typ scope.Type, roles []*documentRole) {
+		for _, role := range roles {
+			scopeId := role.GrantScopeId
+			if scopeId == "" {
+				scopeId = lintScopeIds[typ]
+			}
+			for _, f := range LintInScope(r, scopeId, role.Grants...) {
+				f.Path = fmt.Sprintf("%s/role %q", path, role.Name)
+				findings = append(findings, f)
+			}
+		}
+	}
+	for _, o := range doc.Orgs {
+		orgPath := fmt.Sprintf("org %q", o.Name)
+		lintRoles(orgPath, scope.Org, o.Roles)
+		for _, p := range o.Projects {
+			lintRoles(fmt.Sprintf("%s/project %q", orgPath, p.Name), scope.Project, p.Roles)
+		}
+	}
+	return findings, nil
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
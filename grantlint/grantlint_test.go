@@ -0,0 +1,204 @@
+package grantlint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRules(t *testing.T) {
+	t.Parallel()
+	t.Run("hcl", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		r, err := ParseRules(`
+forbid_wildcard_type = true
+forbidden_actions    = ["delete"]
+allowed_types        = ["target"]
+max_actions          = 2
+`)
+		require.NoError(err)
+		assert.Equal(&Rules{
+			ForbidWildcardType: true,
+			ForbiddenActions:   []string{"delete"},
+			AllowedTypes:       []string{"target"},
+			MaxActions:         2,
+		}, r)
+	})
+	t.Run("json", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		r, err := ParseRules(`{"forbid_all_actions": true}`)
+		require.NoError(err)
+		assert.Equal(&Rules{ForbidAllActions: true}, r)
+	})
+	t.Run("unknown-action", func(t *testing.T) {
+		_, err := ParseRules(`forbidden_actions = ["explode"]`)
+		assert.Error(t, err)
+	})
+	t.Run("unknown-type", func(t *testing.T) {
+		_, err := ParseRules(`allowed_types = ["spaceship"]`)
+		assert.Error(t, err)
+	})
+	t.Run("negative-max", func(t *testing.T) {
+		_, err := ParseRules(`max_actions = -1`)
+		assert.Error(t, err)
+	})
+	t.Run("banned-pattern", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		r, err := ParseRules(`
+banned_pattern "no-wildcards" {
+  pattern = "id=*;actions=*"
+}
+`)
+		require.NoError(err)
+		assert.Equal(&Rules{
+			BannedPatterns: []*BannedPattern{{Name: "no-wildcards", Pattern: "id=*;actions=*"}},
+		}, r)
+	})
+	t.Run("bad-banned-pattern", func(t *testing.T) {
+		_, err := ParseRules(`
+banned_pattern "nope" {
+  pattern = "scope=*"
+}
+`)
+		assert.Error(t, err)
+	})
+}
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+	rules := &Rules{
+		ForbidWildcardType: true,
+		ForbidAllActions:   true,
+		ForbiddenActions:   []string{"delete"},
+		AllowedTypes:       []string{"target", "host-catalog"},
+		MaxActions:         2,
+	}
+	tests := []struct {
+		name      string
+		rules     *Rules
+		grant     string
+		wantRules []string
+	}{
+		{name: "clean", rules: rules, grant: "id=*;type=target;actions=read"},
+		{name: "id-grant", rules: rules, grant: "id=ttcp_1234567890;actions=read"},
+		{name: "no-rules", grant: "id=*;type=*;actions=*"},
+		{name: "unparseable", rules: rules, grant: "id=*;type=*", wantRules: []string{RuleParse}},
+		{name: "unparseable-no-rules", grant: "actions=read", wantRules: []string{RuleParse}},
+		{name: "wildcard-type", rules: rules, grant: "id=*;type=*;actions=read", wantRules: []string{RuleWildcardType}},
+		{name: "all-actions", rules: rules, grant: "id=*;type=target;actions=*", wantRules: []string{RuleAllActions}},
+		{name: "forbidden-action", rules: rules, grant: "id=*;type=target;actions=read,delete", wantRules: []string{RuleForbiddenAction}},
+		{name: "type-not-allowed", rules: rules, grant: "id=*;type=role;actions=read", wantRules: []string{RuleTypeNotAllowed}},
+		{name: "too-many-actions", rules: rules, grant: "id=*;type=target;actions=read,update,authorize-session", wantRules: []string{RuleTooManyActions}},
+		{name: "wildcard-id", rules: &Rules{ForbidWildcardId: true}, grant: "id=*;type=target;actions=read", wantRules: []string{RuleWildcardId}},
+		{name: "deny", rules: rules, grant: "id=*;type=*;actions=*;effect=deny"},
+		{name: "bad-effect", rules: rules, grant: "id=*;type=target;actions=read;effect=block", wantRules: []string{RuleParse}},
+		{name: "unsupported-action", grant: "id=*;type=host;actions=read,add-hosts", wantRules: []string{RuleUnsupported}},
+		{name: "unsupported-deny", grant: "id=*;type=session;actions=update;effect=deny", wantRules: []string{RuleUnsupported}},
+		{
+			name:      "banned",
+			rules:     &Rules{BannedPatterns: []*BannedPattern{{Name: "no-deletes", Pattern: "type=target;actions=delete"}}},
+			grant:     "id=*;type=target;actions=*",
+			wantRules: []string{RuleBannedPattern},
+		},
+		{
+			name:  "not-banned",
+			rules: &Rules{BannedPatterns: []*BannedPattern{{Name: "no-deletes", Pattern: "type=target;actions=delete"}}},
+			grant: "id=*;type=target;actions=read",
+		},
+		{
+			name:      "several",
+			rules:     rules,
+			grant:     "id=*;type=role;actions=read,update,delete",
+			wantRules: []string{RuleTypeNotAllowed, RuleForbiddenAction, RuleTooManyActions},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			var got []string
+			for _, f := range Lint(tt.rules, tt.grant) {
+				assert.Equal(tt.grant, f.Grant)
+				assert.NotEmpty(f.Message)
+				got = append(got, f.Rule)
+			}
+			assert.Equal(tt.wantRules, got)
+		})
+	}
+}
+
+func TestLintDocument(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	rules := &Rules{ForbidWildcardType: true}
+	findings, err := LintDocument(rules, `
+org "payments" {
+  role "admins" {
+    grants = ["id=*;type=*;actions=*"]
+  }
+  project "production" {
+    role "readers" {
+      grants = ["id=*;type=target;actions=read", "not a grant"]
+    }
+  }
+}
+`)
+	require.NoError(err)
+	require.Len(findings, 2)
+	assert.Equal(`org "payments"/role "admins"`, findings[0].Path)
+	assert.Equal(RuleWildcardType, findings[0].Rule)
+	assert.Equal(`org "payments"/project "production"/role "readers"`, findings[1].Path)
+	assert.Equal("not a grant", findings[1].Grant)
+	assert.Equal(RuleParse, findings[1].Rule)
+
+	_, err = LintDocument(rules, `org "payments" {`)
+	assert.Error(err)
+}
+
+func TestLintDocument_Scopes(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	// Users only exist in orgs, so a grant on them is valid for an org role
+	// but not for a project role or one granting to a project.
+	findings, err := LintDocument(nil, `
+org "payments" {
+  role "org-users" {
+    grants = ["id=*;type=user;actions=read"]
+  }
+  role "project-users" {
+    grant_scope_id = "p_1234567890"
+    grants         = ["id=*;type=user;actions=read"]
+  }
+  project "production" {
+    role "users" {
+      grants = ["id=*;type=user;actions=read"]
+    }
+    role "targets" {
+      grants = ["id=*;type=target;actions=read,authorize-session"]
+    }
+  }
+}
+`)
+	require.NoError(err)
+	require.Len(findings, 2)
+	assert.Equal(`org "payments"/role "project-users"`, findings[0].Path)
+	assert.Equal(RuleParse, findings[0].Rule)
+	assert.Equal(`org "payments"/project "production"/role "users"`, findings[1].Path)
+	assert.Equal(RuleParse, findings[1].Rule)
+}
+
+func TestLintInScope(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	assert.Empty(LintInScope(nil, "global", "id=*;type=scope;actions=read"))
+	assert.Empty(LintInScope(nil, "p_1234567890", "id=*;type=target;actions=read"))
+	findings := LintInScope(nil, "not-a-scope", "id=*;type=target;actions=read")
+	if assert.Len(findings, 1) {
+		assert.Equal(RuleParse, findings[0].Rule)
+	}
+	findings = LintInScope(&Rules{BannedPatterns: []*BannedPattern{{Name: "bad", Pattern: "nope"}}}, "global", "id=*;type=scope;actions=read")
+	if assert.Len(findings, 1) {
+		assert.Equal(RuleBannedPattern, findings[0].Rule)
+		assert.Empty(findings[0].Grant)
+	}
+}
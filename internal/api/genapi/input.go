@@ -7,12 +7,15 @@ import (
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/accounts"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/authmethods"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/authtokens"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/clientcertificatelibraries"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/groups"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostcatalogs"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hosts"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostsets"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/jobs"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/roles"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/self"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/sessions"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/users"
@@ -86,11 +89,34 @@ type structInfo struct {
 	// createResponseTypes controls for which structs response types are created
 	createResponseTypes bool
 
+	// paginated indicates that the collection can be listed a page at a time,
+	// so its list results carry the token of the next page
+	paginated bool
+
 	// fieldFilter is a set of field names that will not result in generated API
 	// fields
 	fieldFilter []string
 }
 
+// paginationOptions are the extra options of collections which can be listed a
+// page at a time
+var paginationOptions = []fieldInfo{
+	{
+		Name:        "PageToken",
+		ProtoName:   "page_token",
+		FieldType:   "string",
+		Query:       true,
+		SkipDefault: true,
+	},
+	{
+		Name:        "PageSize",
+		ProtoName:   "page_size",
+		FieldType:   "uint32",
+		Query:       true,
+		SkipDefault: true,
+	},
+}
+
 var inputStructs = []*structInfo{
 	{
 		inProto:    &api.Error{},
@@ -107,12 +133,22 @@ var inputStructs = []*structInfo{
 		outFile:    "field_error.gen.go",
 		outputOnly: true,
 	},
+	{
+		inProto:    &api.Banner{},
+		outFile:    "banner.gen.go",
+		outputOnly: true,
+	},
 	// Scope related resources
 	{
 		inProto:    &scopes.ScopeInfo{},
 		outFile:    "scopes/scope_info.gen.go",
 		outputOnly: true,
 	},
+	{
+		inProto:    &scopes.BannedGrantPattern{},
+		outFile:    "scopes/banned_grant_pattern.gen.go",
+		outputOnly: true,
+	},
 	{
 		inProto: &scopes.Scope{},
 		outFile: "scopes/scope.gen.go",
@@ -125,7 +161,7 @@ var inputStructs = []*structInfo{
 			listTemplate,
 		},
 		pathArgs: []string{"scope"},
-		extraOptions: []fieldInfo{
+		extraOptions: append([]fieldInfo{
 			{
 				Name:      "SkipAdminRoleCreation",
 				ProtoName: "skip_admin_role_creation",
@@ -138,9 +174,10 @@ var inputStructs = []*structInfo{
 				FieldType: "bool",
 				Query:     true,
 			},
-		},
+		}, paginationOptions...),
 		versionEnabled:      true,
 		createResponseTypes: true,
+		paginated:           true,
 	},
 	// User related resources
 	{
@@ -163,8 +200,10 @@ var inputStructs = []*structInfo{
 			"Accounts": "accountIds",
 		},
 		pathArgs:            []string{"user"},
+		extraOptions:        paginationOptions,
 		versionEnabled:      true,
 		createResponseTypes: true,
+		paginated:           true,
 	},
 	// Group related resources
 	{
@@ -187,8 +226,10 @@ var inputStructs = []*structInfo{
 			"Members": "memberIds",
 		},
 		pathArgs:            []string{"group"},
+		extraOptions:        paginationOptions,
 		versionEnabled:      true,
 		createResponseTypes: true,
+		paginated:           true,
 	},
 	// Role related resources
 	{
@@ -206,6 +247,21 @@ var inputStructs = []*structInfo{
 		outFile:    "roles/grant_json.gen.go",
 		outputOnly: true,
 	},
+	{
+		inProto:    &roles.EmergencyActivation{},
+		outFile:    "roles/emergency_activation.gen.go",
+		outputOnly: true,
+	},
+	{
+		inProto:    &roles.RoleFieldDiff{},
+		outFile:    "roles/role_field_diff.gen.go",
+		outputOnly: true,
+	},
+	{
+		inProto:    &roles.RoleDiff{},
+		outFile:    "roles/role_diff.gen.go",
+		outputOnly: true,
+	},
 	{
 		inProto: &roles.Role{},
 		outFile: "roles/role.gen.go",
@@ -221,9 +277,25 @@ var inputStructs = []*structInfo{
 			"Principals": "principalIds",
 			"Grants":     "grantStrings",
 		},
-		pathArgs:            []string{"role"},
+		pathArgs: []string{"role"},
+		extraOptions: append([]fieldInfo{
+			{
+				Name:        "DurationSeconds",
+				ProtoName:   "duration_seconds",
+				FieldType:   "uint32",
+				SkipDefault: true,
+			},
+			{
+				Name:        "IncludeArchived",
+				ProtoName:   "include_archived",
+				FieldType:   "bool",
+				Query:       true,
+				SkipDefault: true,
+			},
+		}, paginationOptions...),
 		versionEnabled:      true,
 		createResponseTypes: true,
+		paginated:           true,
 	},
 	// Auth Methods related resources
 	{
@@ -320,6 +392,16 @@ var inputStructs = []*structInfo{
 		outFile:     "hosts/static_host_attributes.gen.go",
 		subtypeName: "StaticHost",
 	},
+	{
+		inProto:    &hosts.ImportedHost{},
+		outFile:    "hosts/imported_host.gen.go",
+		outputOnly: true,
+	},
+	{
+		inProto:    &hosts.HostImport{},
+		outFile:    "hosts/host_import.gen.go",
+		outputOnly: true,
+	},
 	{
 		inProto: &hostsets.HostSet{},
 		outFile: "hostsets/host_set.gen.go",
@@ -365,6 +447,24 @@ var inputStructs = []*structInfo{
 				FieldType:   "string",
 				SkipDefault: true,
 			},
+			{
+				Name:        "BannerHash",
+				ProtoName:   "banner_hash",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
+			{
+				Name:        "Justification",
+				ProtoName:   "justification",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
+			{
+				Name:        "ChangeTicket",
+				ProtoName:   "change_ticket",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
 		},
 		versionEnabled:      true,
 		typeOnCreate:        true,
@@ -395,6 +495,46 @@ var inputStructs = []*structInfo{
 		inProto: &sessions.WorkerInfo{},
 		outFile: "sessions/workers.gen.go",
 	},
+	{
+		inProto: &clientcertificatelibraries.ClientCertificateLibrary{},
+		outFile: "clientcertificatelibraries/client_certificate_library.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pathArgs:            []string{"client-certificate-library"},
+		typeOnCreate:        true,
+		versionEnabled:      true,
+		createResponseTypes: true,
+	},
+	{
+		inProto: &jobs.Job{},
+		outFile: "jobs/job.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			readTemplate,
+			listTemplate,
+		},
+		pathArgs:            []string{"job"},
+		createResponseTypes: true,
+	},
+	{
+		inProto: &self.UserSession{},
+		outFile: "self/user_session.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+		},
+		createResponseTypes: true,
+	},
+	{
+		inProto:             &self.UserAuthToken{},
+		outFile:             "self/user_auth_token.gen.go",
+		createResponseTypes: true,
+	},
 	{
 		inProto:     &targets.SessionAuthorization{},
 		outFile:     "targets/session_authorization.gen.go",
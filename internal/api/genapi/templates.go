@@ -25,6 +25,9 @@ func getArgsAndPaths(in []string, parentTypeName, action string) (colArg, resArg
 	}
 	colArg = fmt.Sprintf("%sId", strcase.ToLowerCamel(strings.ReplaceAll(strToReplace, "-", "_")))
 	colPath = fmt.Sprintf("%ss", in[len(in)-1])
+	if strings.HasSuffix(in[len(in)-1], "y") {
+		colPath = fmt.Sprintf("%sies", strings.TrimSuffix(in[len(in)-1], "y"))
+	}
 
 	if action != "" {
 		action = fmt.Sprintf(":%s", action)
@@ -48,6 +51,7 @@ type templateInput struct {
 	VersionEnabled        bool
 	TypeOnCreate          bool
 	CreateResponseTypes   bool
+	Paginated             bool
 }
 
 func fillTemplates() {
@@ -64,6 +68,7 @@ func fillTemplates() {
 			VersionEnabled:      in.versionEnabled,
 			TypeOnCreate:        in.typeOnCreate,
 			CreateResponseTypes: in.createResponseTypes,
+			Paginated:           in.paginated,
 		}
 
 		if len(in.pathArgs) > 0 {
@@ -596,7 +601,8 @@ func (n {{ .Name }}DeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type {{ .Name }}ListResult struct {
-	Items []*{{ .Name }}
+	Items []*{{ .Name }}{{ if .Paginated }}
+	NextPageToken string `+"`"+`json:"next_page_token,omitempty"`+"`"+`{{ end }}
 	responseBody *bytes.Buffer
 	responseMap map[string]interface{}
 }
@@ -0,0 +1,175 @@
+// Package adaptive requires step-up authentication for logins which don't look
+// like an account's earlier ones: logins from a device, address or location
+// the account hasn't logged in from before. How unusual a login has to be is
+// set by the Sensitivity of its auth method, and the step-up authentication
+// itself, like checking a one time code, is left to registered StepUpHooks.
+package adaptive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Sensitivity is how unusual a login has to be to require step-up
+// authentication.
+type Sensitivity string
+
+const (
+	// Off never requires step-up authentication, and doesn't record logins.
+	Off Sensitivity = "off"
+
+	// Low requires step-up authentication when every signal of the login is
+	// new for the account.
+	Low Sensitivity = "low"
+
+	// Medium requires step-up authentication when the login's device or
+	// location is new for the account. A known device on a new address
+	// doesn't.
+	Medium Sensitivity = "medium"
+
+	// High requires step-up authentication when any signal of the login is
+	// new for the account.
+	High Sensitivity = "high"
+)
+
+// ParseSensitivity returns the Sensitivity named s. An empty s is Off.
+func ParseSensitivity(s string) (Sensitivity, error) {
+	switch Sensitivity(strings.ToLower(strings.TrimSpace(s))) {
+	case "", Off:
+		return Off, nil
+	case Low:
+		return Low, nil
+	case Medium:
+		return Medium, nil
+	case High:
+		return High, nil
+	}
+	return "", fmt.Errorf("unknown sensitivity %q", s)
+}
+
+// Signal is something about a login which is compared with the account's
+// earlier logins.
+type Signal string
+
+const (
+	// SignalDevice is the fingerprint of the client's device.
+	SignalDevice Signal = "device"
+
+	// SignalAddress is the address the client connected from.
+	SignalAddress Signal = "address"
+
+	// SignalLocation is where the address is, if a Locator is registered.
+	SignalLocation Signal = "location"
+)
+
+// Login is a login being checked. Signals which aren't known are empty.
+type Login struct {
+	AuthMethodId string `json:"auth_method_id"`
+	AccountId    string `json:"account_id"`
+	UserId       string `json:"user_id,omitempty"`
+	Device       string `json:"device,omitempty"`
+	Address      string `json:"address,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+// signals returns the signals of the login which are known, and their values.
+func (l *Login) signals() map[Signal]string {
+	s := make(map[Signal]string, 3)
+	for sig, v := range map[Signal]string{
+		SignalDevice:   l.Device,
+		SignalAddress:  l.Address,
+		SignalLocation: l.Location,
+	} {
+		if v != "" {
+			s[sig] = v
+		}
+	}
+	return s
+}
+
+// DeviceFingerprint returns the fingerprint of a client's device, from the
+// headers its requests are made with, or an empty string if they're all
+// empty.
+func DeviceFingerprint(userAgent, acceptLanguage string) string {
+	if userAgent == "" && acceptLanguage == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(userAgent + "\n" + acceptLanguage))
+	return hex.EncodeToString(sum[:16])
+}
+
+// anomalies returns the signals of the login whose values the account hasn't
+// logged in with before. known holds the values of the account's earlier
+// logins; if it's empty, this is the account's first login, which sets its
+// baseline and isn't anomalous.
+func anomalies(l *Login, known map[Signal]map[string]bool) (observed, anomalous []Signal) {
+	values := l.signals()
+	for _, sig := range []Signal{SignalDevice, SignalAddress, SignalLocation} {
+		v, ok := values[sig]
+		if !ok {
+			continue
+		}
+		observed = append(observed, sig)
+		if len(known) > 0 && !known[sig][v] {
+			anomalous = append(anomalous, sig)
+		}
+	}
+	return observed, anomalous
+}
+
+// requiresStepUp returns whether a login with the anomalous signals, out of
+// the observed ones, requires step-up authentication at the sensitivity.
+func (s Sensitivity) requiresStepUp(observed, anomalous []Signal) bool {
+	if len(anomalous) == 0 {
+		return false
+	}
+	switch s {
+	case Low:
+		return len(anomalous) == len(observed)
+	case Medium:
+		for _, sig := range anomalous {
+			if sig == SignalDevice || sig == SignalLocation {
+				return true
+			}
+		}
+		return false
+	case High:
+		return true
+	}
+	return false
+}
+
+// Policy is the sensitivity of each auth method.
+type Policy struct {
+	def         Sensitivity
+	authMethods map[string]Sensitivity
+}
+
+// NewPolicy returns a Policy in which auth methods have the sensitivity named
+// def, unless perAuthMethod names another one for their id.
+func NewPolicy(def string, perAuthMethod map[string]string) (*Policy, error) {
+	p := &Policy{authMethods: make(map[string]Sensitivity, len(perAuthMethod))}
+	var err error
+	if p.def, err = ParseSensitivity(def); err != nil {
+		return nil, fmt.Errorf("new adaptive auth policy: %w", err)
+	}
+	for id, name := range perAuthMethod {
+		if p.authMethods[id], err = ParseSensitivity(name); err != nil {
+			return nil, fmt.Errorf("new adaptive auth policy: auth method %q: %w", id, err)
+		}
+	}
+	return p, nil
+}
+
+// Sensitivity returns the sensitivity of the auth method.
+func (p *Policy) Sensitivity(authMethodId string) Sensitivity {
+	if p == nil {
+		return Off
+	}
+	if s, ok := p.authMethods[authMethodId]; ok {
+		return s
+	}
+	return p.def
+}
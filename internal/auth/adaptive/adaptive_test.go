@@ -0,0 +1,114 @@
+package adaptive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSensitivity(t *testing.T) {
+	for name, want := range map[string]Sensitivity{
+		"":         Off,
+		"off":      Off,
+		"Low":      Low,
+		" medium ": Medium,
+		"HIGH":     High,
+	} {
+		got, err := ParseSensitivity(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+	_, err := ParseSensitivity("paranoid")
+	assert.Error(t, err)
+}
+
+func TestPolicy(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	p, err := NewPolicy("low", map[string]string{"ampw_1234567890": "high"})
+	require.NoError(err)
+	assert.Equal(High, p.Sensitivity("ampw_1234567890"))
+	assert.Equal(Low, p.Sensitivity("ampw_0987654321"))
+
+	var none *Policy
+	assert.Equal(Off, none.Sensitivity("ampw_1234567890"))
+
+	_, err = NewPolicy("paranoid", nil)
+	assert.Error(err)
+	_, err = NewPolicy("low", map[string]string{"ampw_1234567890": "paranoid"})
+	assert.Error(err)
+}
+
+func TestDeviceFingerprint(t *testing.T) {
+	assert := assert.New(t)
+	assert.Empty(DeviceFingerprint("", ""))
+	fp := DeviceFingerprint("curl/7.64.1", "en-US")
+	assert.Len(fp, 32)
+	assert.Equal(fp, DeviceFingerprint("curl/7.64.1", "en-US"))
+	assert.NotEqual(fp, DeviceFingerprint("curl/7.64.1", "fr-FR"))
+}
+
+func TestRequiresStepUp(t *testing.T) {
+	known := map[Signal]map[string]bool{
+		SignalDevice:   {"laptop": true},
+		SignalAddress:  {"192.0.2.1": true},
+		SignalLocation: {"NL": true},
+	}
+	tests := []struct {
+		name          string
+		login         Login
+		known         map[Signal]map[string]bool
+		wantAnomalies []Signal
+		want          map[Sensitivity]bool
+	}{
+		{
+			name:  "first-login",
+			login: Login{Device: "phone", Address: "198.51.100.1", Location: "US"},
+			want:  map[Sensitivity]bool{Low: false, Medium: false, High: false},
+		},
+		{
+			name:  "known",
+			login: Login{Device: "laptop", Address: "192.0.2.1", Location: "NL"},
+			known: known,
+			want:  map[Sensitivity]bool{Low: false, Medium: false, High: false},
+		},
+		{
+			name:          "new-address",
+			login:         Login{Device: "laptop", Address: "192.0.2.2", Location: "NL"},
+			known:         known,
+			wantAnomalies: []Signal{SignalAddress},
+			want:          map[Sensitivity]bool{Off: false, Low: false, Medium: false, High: true},
+		},
+		{
+			name:          "new-device",
+			login:         Login{Device: "phone", Address: "192.0.2.1"},
+			known:         known,
+			wantAnomalies: []Signal{SignalDevice},
+			want:          map[Sensitivity]bool{Low: false, Medium: true, High: true},
+		},
+		{
+			name:          "all-new",
+			login:         Login{Device: "phone", Address: "198.51.100.1", Location: "US"},
+			known:         known,
+			wantAnomalies: []Signal{SignalDevice, SignalAddress, SignalLocation},
+			want:          map[Sensitivity]bool{Off: false, Low: true, Medium: true, High: true},
+		},
+		{
+			name:          "only-address-known-and-new",
+			login:         Login{Address: "198.51.100.1"},
+			known:         known,
+			wantAnomalies: []Signal{SignalAddress},
+			want:          map[Sensitivity]bool{Low: true, Medium: false, High: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			observed, anomalous := anomalies(&tt.login, tt.known)
+			assert.Equal(tt.wantAnomalies, anomalous)
+			for s, want := range tt.want {
+				assert.Equal(want, s.requiresStepUp(observed, anomalous), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package adaptive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrStepUpRequired is returned when a login requires step-up authentication
+// which wasn't given, or wasn't accepted.
+var ErrStepUpRequired = errors.New("step-up authentication required")
+
+// StepUpRequest describes a login which requires step-up authentication, for
+// step-up hooks to verify.
+type StepUpRequest struct {
+	Login
+
+	// Sensitivity is the sensitivity of the login's auth method, and
+	// Anomalies the signals of the login which are new for the account.
+	Sensitivity Sensitivity `json:"sensitivity"`
+	Anomalies   []Signal    `json:"anomalies"`
+
+	// Code is the step-up credential the client sent with the login, like a
+	// one time code, or empty if it didn't send one.
+	Code string `json:"code,omitempty"`
+}
+
+// StepUpHook verifies the step-up authentication of logins which require it.
+// Returning nil accepts the login; returning an error refuses it, and the
+// error is returned to the caller wrapping ErrStepUpRequired.
+type StepUpHook interface {
+	StepUp(ctx context.Context, req *StepUpRequest) error
+}
+
+// StepUpHookFunc is a function which can be registered as a StepUpHook.
+type StepUpHookFunc func(ctx context.Context, req *StepUpRequest) error
+
+// StepUp calls f.
+func (f StepUpHookFunc) StepUp(ctx context.Context, req *StepUpRequest) error {
+	return f(ctx, req)
+}
+
+// Locator returns where an address is, like its country, for the location
+// signal of logins.
+type Locator interface {
+	Locate(ctx context.Context, addr string) (string, error)
+}
+
+// LocatorFunc is a function which can be registered as a Locator.
+type LocatorFunc func(ctx context.Context, addr string) (string, error)
+
+// Locate calls f.
+func (f LocatorFunc) Locate(ctx context.Context, addr string) (string, error) {
+	return f(ctx, addr)
+}
+
+var hooks struct {
+	sync.RWMutex
+	nextId    int
+	stepUp    map[int]StepUpHook
+	locator   Locator
+	locatorId int
+}
+
+// RegisterStepUpHook registers a hook to verify the step-up authentication of
+// logins which require it. Every registered hook has to accept a login, in
+// the order they're registered. If none are registered, logins which require
+// step-up authentication are refused. It returns a function which
+// unregisters the hook.
+func RegisterStepUpHook(h StepUpHook) (unregister func()) {
+	hooks.Lock()
+	defer hooks.Unlock()
+	if hooks.stepUp == nil {
+		hooks.stepUp = make(map[int]StepUpHook)
+	}
+	id := hooks.nextId
+	hooks.nextId++
+	hooks.stepUp[id] = h
+	return func() {
+		hooks.Lock()
+		defer hooks.Unlock()
+		delete(hooks.stepUp, id)
+	}
+}
+
+// RegisterLocator registers the locator used for the location signal of
+// logins, replacing any registered before. Without one, logins have no
+// location. It returns a function which unregisters the locator.
+func RegisterLocator(l Locator) (unregister func()) {
+	hooks.Lock()
+	defer hooks.Unlock()
+	id := hooks.nextId
+	hooks.nextId++
+	hooks.locator, hooks.locatorId = l, id
+	return func() {
+		hooks.Lock()
+		defer hooks.Unlock()
+		if hooks.locatorId == id {
+			hooks.locator = nil
+		}
+	}
+}
+
+// locate returns the location of the address, or an empty string if no
+// locator is registered.
+func locate(ctx context.Context, addr string) (string, error) {
+	hooks.RLock()
+	l := hooks.locator
+	hooks.RUnlock()
+	if l == nil || addr == "" {
+		return "", nil
+	}
+	return l.Locate(ctx, addr)
+}
+
+// stepUp consults the registered step-up hooks, returning the error of the
+// first one to refuse the login.
+func stepUp(ctx context.Context, req *StepUpRequest) error {
+	hooks.RLock()
+	ids := make([]int, 0, len(hooks.stepUp))
+	for id := range hooks.stepUp {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	stepUps := make([]StepUpHook, 0, len(ids))
+	for _, id := range ids {
+		stepUps = append(stepUps, hooks.stepUp[id])
+	}
+	hooks.RUnlock()
+	if len(stepUps) == 0 {
+		return ErrStepUpRequired
+	}
+	for _, h := range stepUps {
+		if err := h.StepUp(ctx, req); err != nil {
+			return fmt.Errorf("%v: %w", err, ErrStepUpRequired)
+		}
+	}
+	return nil
+}
+
+// DefaultHTTPStepUpHookTimeout is how long an HTTPStepUpHook waits for its
+// endpoint, unless its Client sets a timeout.
+const DefaultHTTPStepUpHookTimeout = 10 * time.Second
+
+// HTTPStepUpHook is a StepUpHook which asks an external endpoint, like an
+// identity provider's one time code verification, whether to accept logins.
+// It POSTs the StepUpRequest as JSON, and expects a 200 response whose body
+// is an object with an "allow" boolean and an optional "reason" string.
+// Logins are refused if the endpoint can't be reached or doesn't allow them.
+type HTTPStepUpHook struct {
+	// Url is the endpoint to POST requests to.
+	Url string
+
+	// Client is used to make requests. If it's nil, a client with the
+	// DefaultHTTPStepUpHookTimeout is used.
+	Client *http.Client
+}
+
+// StepUp asks the hook's endpoint whether to accept the login.
+func (h *HTTPStepUpHook) StepUp(ctx context.Context, req *StepUpRequest) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("http step-up hook: unable to encode request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.Url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("http step-up hook: unable to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	client := h.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPStepUpHookTimeout}
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("http step-up hook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http step-up hook: unexpected status %s", resp.Status)
+	}
+	var decision struct {
+		Allow  bool   `json:"allow"`
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return fmt.Errorf("http step-up hook: unable to decode response: %w", err)
+	}
+	if !decision.Allow {
+		if decision.Reason == "" {
+			decision.Reason = "not allowed"
+		}
+		return fmt.Errorf("http step-up hook: %s", decision.Reason)
+	}
+	return nil
+}
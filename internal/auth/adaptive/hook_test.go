@@ -0,0 +1,95 @@
+package adaptive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepUp(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	req := &StepUpRequest{Login: Login{AccountId: "apw_1234567890"}, Code: "123456"}
+
+	// Without hooks, step-up authentication can't be given.
+	assert.True(errors.Is(stepUp(ctx, req), ErrStepUpRequired))
+
+	var calls []string
+	unregisterFirst := RegisterStepUpHook(StepUpHookFunc(func(_ context.Context, r *StepUpRequest) error {
+		calls = append(calls, "first")
+		return nil
+	}))
+	unregisterSecond := RegisterStepUpHook(StepUpHookFunc(func(_ context.Context, r *StepUpRequest) error {
+		calls = append(calls, "second")
+		if r.Code != "123456" {
+			return errors.New("wrong code")
+		}
+		return nil
+	}))
+	assert.NoError(stepUp(ctx, req))
+	assert.Equal([]string{"first", "second"}, calls)
+
+	err := stepUp(ctx, &StepUpRequest{Code: "000000"})
+	assert.True(errors.Is(err, ErrStepUpRequired))
+	assert.Contains(err.Error(), "wrong code")
+
+	unregisterFirst()
+	unregisterSecond()
+	assert.True(errors.Is(stepUp(ctx, req), ErrStepUpRequired))
+}
+
+func TestLocate(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	loc, err := locate(ctx, "192.0.2.1")
+	assert.NoError(err)
+	assert.Empty(loc)
+
+	unregister := RegisterLocator(LocatorFunc(func(_ context.Context, addr string) (string, error) {
+		return "NL", nil
+	}))
+	loc, err = locate(ctx, "192.0.2.1")
+	assert.NoError(err)
+	assert.Equal("NL", loc)
+	loc, err = locate(ctx, "")
+	assert.NoError(err)
+	assert.Empty(loc)
+
+	unregister()
+	loc, err = locate(ctx, "192.0.2.1")
+	assert.NoError(err)
+	assert.Empty(loc)
+}
+
+func TestHTTPStepUpHook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req StepUpRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch req.Code {
+		case "123456":
+			w.Write([]byte(`{"allow": true}`))
+		case "":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte(`{"allow": false, "reason": "wrong code"}`))
+		}
+	}))
+	defer srv.Close()
+
+	h := &HTTPStepUpHook{Url: srv.URL}
+	ctx := context.Background()
+	require.NoError(t, h.StepUp(ctx, &StepUpRequest{Code: "123456", Anomalies: []Signal{SignalDevice}}))
+	err := h.StepUp(ctx, &StepUpRequest{Code: "000000"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong code")
+	assert.Error(t, h.StepUp(ctx, &StepUpRequest{}))
+}
@@ -0,0 +1,18 @@
+package adaptive
+
+const (
+	listLoginSignals = `
+select signal, value
+  from auth_account_login_signal
+ where auth_account_id = $1;
+`
+
+	upsertLoginSignal = `
+insert into auth_account_login_signal
+  (auth_account_id, signal, value)
+values
+  ($1, $2, $3)
+on conflict (auth_account_id, signal, value) do update
+   set last_seen_time = now();
+`
+)
@@ -0,0 +1,120 @@
+package adaptive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// A Repository stores the signals of the logins of accounts, and checks new
+// logins against them.
+type Repository struct {
+	reader db.Reader
+	writer db.Writer
+}
+
+// NewRepository creates a new Repository.
+func NewRepository(r db.Reader, w db.Writer) (*Repository, error) {
+	switch {
+	case r == nil:
+		return nil, fmt.Errorf("new adaptive auth repository: missing reader: %w", db.ErrInvalidParameter)
+	case w == nil:
+		return nil, fmt.Errorf("new adaptive auth repository: missing writer: %w", db.ErrInvalidParameter)
+	}
+	return &Repository{
+		reader: r,
+		writer: w,
+	}, nil
+}
+
+// CheckLogin checks a login which has passed its auth method's
+// authentication, at the sensitivity of the auth method. If the login's
+// signals are new enough for the account to require step-up authentication,
+// the registered step-up hooks are consulted with code, the step-up
+// credential the client sent, and an error wrapping ErrStepUpRequired is
+// returned unless they accept it. The signals of logins which are allowed are
+// recorded for the account.
+func (r *Repository) CheckLogin(ctx context.Context, l *Login, s Sensitivity, code string) error {
+	switch {
+	case l == nil:
+		return fmt.Errorf("check login: missing login: %w", db.ErrInvalidParameter)
+	case l.AccountId == "":
+		return fmt.Errorf("check login: missing account id: %w", db.ErrInvalidParameter)
+	}
+	if s == Off {
+		return nil
+	}
+	if l.Location == "" {
+		loc, err := locate(ctx, l.Address)
+		if err != nil {
+			return fmt.Errorf("check login: unable to locate address: %w", err)
+		}
+		l.Location = loc
+	}
+	known, err := r.knownSignals(ctx, l.AccountId)
+	if err != nil {
+		return fmt.Errorf("check login: %w", err)
+	}
+	observed, anomalous := anomalies(l, known)
+	if s.requiresStepUp(observed, anomalous) {
+		req := &StepUpRequest{Login: *l, Sensitivity: s, Anomalies: anomalous, Code: code}
+		if err := stepUp(ctx, req); err != nil {
+			return fmt.Errorf("check login: %w", err)
+		}
+	}
+	if err := r.recordSignals(ctx, l); err != nil {
+		return fmt.Errorf("check login: %w", err)
+	}
+	return nil
+}
+
+// knownSignals returns the values of each signal of the account's earlier
+// logins.
+func (r *Repository) knownSignals(ctx context.Context, accountId string) (map[Signal]map[string]bool, error) {
+	rows, err := r.reader.Query(ctx, listLoginSignals, []interface{}{accountId})
+	if err != nil {
+		return nil, fmt.Errorf("known signals: query failed: %w", err)
+	}
+	defer rows.Close()
+	known := make(map[Signal]map[string]bool)
+	for rows.Next() {
+		var sig, v string
+		if err := rows.Scan(&sig, &v); err != nil {
+			return nil, fmt.Errorf("known signals: scan row failed: %w", err)
+		}
+		if known[Signal(sig)] == nil {
+			known[Signal(sig)] = make(map[string]bool)
+		}
+		known[Signal(sig)][v] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("known signals: %w", err)
+	}
+	return known, nil
+}
+
+// recordSignals records the signals of the login for its account.
+func (r *Repository) recordSignals(ctx context.Context, l *Login) error {
+	values := l.signals()
+	if len(values) == 0 {
+		return nil
+	}
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			for sig, v := range values {
+				if _, err := w.Exec(ctx, upsertLoginSignal, []interface{}{l.AccountId, string(sig), v}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("record signals: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package adaptive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CheckLogin(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	am := password.TestAuthMethods(t, conn, org.GetPublicId(), 1)[0]
+	acct := password.TestAccounts(t, conn, am.GetPublicId(), 1)[0]
+	rw := db.New(conn)
+	repo, err := NewRepository(rw, rw)
+	require.NoError(err)
+	ctx := context.Background()
+
+	login := func(device, addr string) *Login {
+		return &Login{AuthMethodId: am.GetPublicId(), AccountId: acct.GetPublicId(), Device: device, Address: addr}
+	}
+
+	// The first login sets the account's baseline.
+	require.NoError(repo.CheckLogin(ctx, login("laptop", "192.0.2.1"), High, ""))
+	require.NoError(repo.CheckLogin(ctx, login("laptop", "192.0.2.1"), High, ""))
+
+	// Without a step-up hook, logins requiring step-up are refused, and
+	// aren't recorded.
+	err = repo.CheckLogin(ctx, login("laptop", "192.0.2.2"), High, "")
+	assert.True(errors.Is(err, ErrStepUpRequired))
+	require.NoError(repo.CheckLogin(ctx, login("laptop", "192.0.2.2"), Medium, ""))
+	require.NoError(repo.CheckLogin(ctx, login("phone", "198.51.100.1"), Off, ""))
+	err = repo.CheckLogin(ctx, login("phone", "198.51.100.1"), Low, "")
+	assert.True(errors.Is(err, ErrStepUpRequired))
+
+	var got *StepUpRequest
+	unregister := RegisterStepUpHook(StepUpHookFunc(func(_ context.Context, req *StepUpRequest) error {
+		got = req
+		if req.Code != "123456" {
+			return errors.New("wrong code")
+		}
+		return nil
+	}))
+	defer unregister()
+	err = repo.CheckLogin(ctx, login("phone", "198.51.100.1"), Low, "000000")
+	assert.True(errors.Is(err, ErrStepUpRequired))
+	require.NoError(repo.CheckLogin(ctx, login("phone", "198.51.100.1"), Low, "123456"))
+	require.NotNil(got)
+	assert.Equal(acct.GetPublicId(), got.AccountId)
+	assert.Equal(Low, got.Sensitivity)
+	assert.Equal([]Signal{SignalDevice, SignalAddress}, got.Anomalies)
+
+	// Once stepped up, the device and address are known.
+	got = nil
+	require.NoError(repo.CheckLogin(ctx, login("phone", "198.51.100.1"), High, ""))
+	assert.Nil(got)
+
+	err = repo.CheckLogin(ctx, &Login{}, High, "")
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+}
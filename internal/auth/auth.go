@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	"github.com/hashicorp/boundary/internal/gen/controller/tokens"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
@@ -83,6 +84,9 @@ type verifier struct {
 	act             action.Type
 	ctx             context.Context
 	acl             perms.ACL
+	// accountId is the account of the token's user, which grant templates
+	// refer to
+	accountId string
 }
 
 // NewVerifierContext creates a context that carries a verifier object from the
@@ -170,6 +174,16 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 	}
 
 	ret.AuthTokenId = v.requestInfo.PublicId
+	if opts.withSelfService {
+		// Users are always allowed to act on their own resources, once
+		// they're authenticated.
+		if ret.UserId == "u_anon" {
+			ret.Error = handlers.UnauthenticatedError()
+			return
+		}
+		ret.Error = nil
+		return
+	}
 	if !authResults.Allowed {
 		if v.requestInfo.DisableAuthzFailures {
 			ret.Error = nil
@@ -203,6 +217,12 @@ func (r *VerifyResults) AdditionalVerification(ctx context.Context, opt ...Optio
 	ret.AuthTokenId = r.AuthTokenId
 	ret.v = r.v
 
+	// Nothing is checked when auth is disabled entirely
+	if v.requestInfo.DisableAuthEntirely {
+		ret.Error = nil
+		return
+	}
+
 	opts := getOpts(opt...)
 
 	act := opts.withAction
@@ -218,6 +238,7 @@ func (r *VerifyResults) AdditionalVerification(ctx context.Context, opt ...Optio
 	}
 
 	// Only perform lookup if it's actually different, otherwise use cached info
+	acl := v.acl
 	if res.ScopeId != r.Scope.Id {
 		iamRepo, err := v.iamRepoFn()
 		if err != nil {
@@ -255,6 +276,16 @@ func (r *VerifyResults) AdditionalVerification(ctx context.Context, opt ...Optio
 				ParentScopeId: scp.GetParentId(),
 			}
 		}
+
+		// The ACL of the request only holds the grants which apply in its
+		// scope, so load the ones which apply in this one
+		if v.requestInfo.TokenFormat != AuthTokenTypeRecoveryKms {
+			acl, err = v.aclForScope(iamRepo, ret.UserId, res.ScopeId)
+			if err != nil {
+				v.logger.Error("additional verification: failed to get grants", "error", err)
+				return
+			}
+		}
 	}
 
 	// Always allowed
@@ -263,7 +294,7 @@ func (r *VerifyResults) AdditionalVerification(ctx context.Context, opt ...Optio
 		return
 	}
 
-	aclResults := v.acl.Allowed(res, act)
+	aclResults := acl.Allowed(res, act)
 
 	if !aclResults.Allowed {
 		if v.requestInfo.DisableAuthzFailures {
@@ -285,7 +316,18 @@ func (r *VerifyResults) AdditionalVerification(ctx context.Context, opt ...Optio
 	return
 }
 
-func (v verifier) performAuthCheck() (aclResults perms.ACLResults, userId string, scopeInfo *scopes.ScopeInfo, retAcl perms.ACL, retErr error) {
+// ListUnpaged reports whether the principal is granted the list-unpaged action
+// on the resources of the type in the scope the results are for, which exempts
+// its listings of them from the maximum page size.
+func (r *VerifyResults) ListUnpaged(ctx context.Context, typ resource.Type) bool {
+	res := r.AdditionalVerification(ctx,
+		WithScopeId(r.Scope.GetId()),
+		WithType(typ),
+		WithAction(action.ListUnpaged))
+	return res.Error == nil
+}
+
+func (v *verifier) performAuthCheck() (aclResults perms.ACLResults, userId string, scopeInfo *scopes.ScopeInfo, retAcl perms.ACL, retErr error) {
 	// Ensure we return an error by default if we forget to set this somewhere
 	retErr = errors.New("unknown")
 	// Make the linter happy
@@ -376,35 +418,40 @@ func (v verifier) performAuthCheck() (aclResults perms.ACLResults, userId string
 		return
 	}
 
-	var parsedGrants []perms.Grant
-	var grantPairs []perms.GrantPair
-
 	// Fetch and parse grants for this user ID (which may include grants for
-	// u_anon and u_auth)
-	grantPairs, err = iamRepo.GrantsForUser(v.ctx, userId)
+	// u_anon and u_auth) in the request's scope
+	v.accountId = accountId
+	retAcl, err = v.aclForScope(iamRepo, userId, v.res.ScopeId)
 	if err != nil {
-		retErr = fmt.Errorf("perform auth check: failed to query for user grants: %w", err)
+		retErr = fmt.Errorf("perform auth check: %w", err)
 		return
 	}
-	parsedGrants = make([]perms.Grant, 0, len(grantPairs))
+	aclResults = retAcl.Allowed(*v.res, v.act)
+	retErr = nil
+	return
+}
+
+// aclForScope returns the ACL of the grants which apply to the user in the
+// scope.
+func (v *verifier) aclForScope(iamRepo *iam.Repository, userId, scopeId string) (perms.ACL, error) {
+	grantPairs, err := iamRepo.GrantsForUser(v.ctx, userId, iam.WithGrantScopeId(scopeId))
+	if err != nil {
+		return perms.ACL{}, fmt.Errorf("failed to query for user grants: %w", err)
+	}
+	parsedGrants := make([]perms.Grant, 0, len(grantPairs))
 	for _, pair := range grantPairs {
 		parsed, err := perms.Parse(
 			pair.ScopeId,
 			pair.Grant,
 			perms.WithUserId(userId),
-			perms.WithAccountId(accountId),
+			perms.WithAccountId(v.accountId),
 			perms.WithSkipFinalValidation(true))
 		if err != nil {
-			retErr = fmt.Errorf("perform auth check: failed to parse grant %#v: %w", pair.Grant, err)
-			return
+			return perms.ACL{}, fmt.Errorf("failed to parse grant %#v: %w", pair.Grant, err)
 		}
 		parsedGrants = append(parsedGrants, parsed)
 	}
-
-	retAcl = perms.NewACL(parsedGrants...)
-	aclResults = retAcl.Allowed(*v.res, v.act)
-	retErr = nil
-	return
+	return perms.NewACL(parsedGrants...), nil
 }
 
 // GetTokenFromRequest pulls the token from either the Authorization header or
@@ -133,3 +133,54 @@ func TestAuthTokenAuthenticator(t *testing.T) {
 		})
 	}
 }
+
+func TestVerify_SelfService(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	logger := hclog.New(nil)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	encToken, err := authtoken.EncryptToken(context.Background(), kms, o.GetPublicId(), at.GetPublicId(), at.GetToken())
+	require.NoError(t, err)
+
+	verify := func(token string, opt ...Option) VerifyResults {
+		req := httptest.NewRequest("GET", "http://127.0.0.1/v1/self/sessions", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		requestInfo := RequestInfo{
+			Path:   req.URL.Path,
+			Method: req.Method,
+		}
+		requestInfo.PublicId, requestInfo.EncryptedToken, requestInfo.TokenFormat = GetTokenFromRequest(logger, kms, req)
+		ctx := NewVerifierContext(context.Background(), logger, iamRepoFn, tokenRepoFn, serversRepoFn, kms, requestInfo)
+		return Verify(ctx, append([]Option{WithScopeId("global")}, opt...)...)
+	}
+
+	// The user has no grants, so is only allowed their own resources.
+	res := verify(at.GetPublicId()+"_"+encToken, WithSelfService(true))
+	require.NoError(t, res.Error)
+	assert.Equal(t, at.GetIamUserId(), res.UserId)
+	assert.Equal(t, at.GetPublicId(), res.AuthTokenId)
+
+	res = verify(at.GetPublicId() + "_" + encToken)
+	require.Error(t, res.Error)
+	assert.Equal(t, handlers.ForbiddenError().Error(), res.Error.Error())
+
+	res = verify("", WithSelfService(true))
+	require.Error(t, res.Error)
+	assert.Equal(t, handlers.UnauthenticatedError().Error(), res.Error.Error())
+}
@@ -26,6 +26,8 @@ type options struct {
 	withType    resource.Type
 	withUserId  string
 	withKms     *kms.Kms
+
+	withSelfService bool
 }
 
 func getDefaultOptions() options {
@@ -76,3 +78,12 @@ func WithKms(kms *kms.Kms) Option {
 		o.withKms = kms
 	}
 }
+
+// WithSelfService allows the request of any authenticated user, without
+// checking their grants, for requests which only read or change the user's
+// own resources.
+func WithSelfService(selfService bool) Option {
+	return func(o *options) {
+		o.withSelfService = selfService
+	}
+}
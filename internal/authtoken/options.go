@@ -1,5 +1,7 @@
 package authtoken
 
+import "github.com/hashicorp/boundary/internal/clock"
+
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -16,10 +18,15 @@ type Option func(*options)
 type options struct {
 	withTokenValue bool
 	withLimit      int
+	withClock      clock.Clock
+	withReadOnly   bool
+	withSourceAddr string
 }
 
 func getDefaultOptions() options {
-	return options{}
+	return options{
+		withClock: clock.System,
+	}
 }
 
 // withTokenValue allows the auth token value to be included in the lookup response.
@@ -38,3 +45,29 @@ func WithLimit(limit int) Option {
 		o.withLimit = limit
 	}
 }
+
+// WithClock provides an option to take the current time from c, which is used
+// for token expiration and staleness, instead of from the system.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.withClock = c
+	}
+}
+
+// WithReadOnly provides an option to validate tokens without writing to the
+// database, for controllers serving reads from a replica. Expired and stale
+// tokens are still rejected, but aren't deleted, and approximate last access
+// times aren't updated.
+func WithReadOnly(readOnly bool) Option {
+	return func(o *options) {
+		o.withReadOnly = readOnly
+	}
+}
+
+// WithSourceAddress provides an option to record the address of the client
+// an auth token is created for, so the user can recognize the token later.
+func WithSourceAddress(addr string) Option {
+	return func(o *options) {
+		o.withSourceAddr = addr
+	}
+}
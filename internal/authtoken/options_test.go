@@ -2,7 +2,9 @@ package authtoken
 
 import (
 	"testing"
+	"time"
 
+	"github.com/hashicorp/boundary/internal/clock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,4 +18,29 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withTokenValue = true
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithClock", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		assert.Equal(clock.System, opts.withClock)
+
+		c := clock.NewFake(time.Now())
+		opts = getOpts(WithClock(c))
+		testOpts := getDefaultOptions()
+		testOpts.withClock = c
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithReadOnly", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithReadOnly(true))
+		testOpts := getDefaultOptions()
+		testOpts.withReadOnly = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSourceAddress", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithSourceAddress("203.0.113.7"))
+		testOpts := getDefaultOptions()
+		testOpts.withSourceAddr = "203.0.113.7"
+		assert.Equal(opts, testOpts)
+	})
 }
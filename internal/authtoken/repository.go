@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/hashicorp/boundary/internal/authtoken/store"
+	"github.com/hashicorp/boundary/internal/clock"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/iam"
@@ -28,12 +29,16 @@ type Repository struct {
 	reader db.Reader
 	writer db.Writer
 	kms    *kms.Kms
+	clock  clock.Clock
+	// readOnly means tokens are validated without writing to the db.
+	readOnly bool
 	// defaultLimit provides a default for limiting the number of results returned from the repo
 	defaultLimit int
 }
 
 // NewRepository creates a new Repository. The returned repository is not safe for concurrent go
-// routines to access it.
+// routines to access it. Supports the options: WithLimit, which sets a default
+// limit on results returned by repo operations, WithClock and WithReadOnly.
 func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*Repository, error) {
 	switch {
 	case r == nil:
@@ -53,13 +58,15 @@ func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*Repo
 		reader:       r,
 		writer:       w,
 		kms:          kms,
+		clock:        opts.withClock,
+		readOnly:     opts.withReadOnly,
 		defaultLimit: opts.withLimit,
 	}, nil
 }
 
 // CreateAuthToken inserts an Auth Token into the repository and returns a new Auth Token.  The returned auth token
 // contains the auth token value. The provided IAM User ID must be associated to the provided auth account id
-// or an error will be returned. All options are ignored.
+// or an error will be returned. Supports the WithSourceAddress option.
 func (r *Repository) CreateAuthToken(ctx context.Context, withIamUser *iam.User, withAuthAccountId string, opt ...Option) (*AuthToken, error) {
 	if withIamUser == nil {
 		return nil, fmt.Errorf("create: auth token: no user: %w", db.ErrInvalidParameter)
@@ -70,6 +77,7 @@ func (r *Repository) CreateAuthToken(ctx context.Context, withIamUser *iam.User,
 	if withAuthAccountId == "" {
 		return nil, fmt.Errorf("create: auth token: no auth account id: %w", db.ErrInvalidParameter)
 	}
+	opts := getOpts(opt...)
 
 	at := allocAuthToken()
 	at.AuthAccountId = withAuthAccountId
@@ -94,7 +102,7 @@ func (r *Repository) CreateAuthToken(ctx context.Context, withIamUser *iam.User,
 	// TODO: Allow the caller to specify something different than the default duration.
 	// We truncate the expiration time to the nearest second to make testing in different platforms with
 	// different time resolutions easier.
-	expiration, err := ptypes.TimestampProto(time.Now().Add(maxTokenDuration).Truncate(time.Second))
+	expiration, err := ptypes.TimestampProto(r.clock.Now().Add(maxTokenDuration).Truncate(time.Second))
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +135,12 @@ func (r *Repository) CreateAuthToken(ctx context.Context, withIamUser *iam.User,
 				return err
 			}
 			newAuthToken.CtToken = nil
+			if opts.withSourceAddr != "" {
+				src := &tokenSource{AuthTokenId: id, SourceAddress: opts.withSourceAddr}
+				if err := w.Create(ctx, src); err != nil {
+					return fmt.Errorf("create: auth token: source address: %w", err)
+				}
+			}
 
 			return nil
 		},
@@ -172,9 +186,9 @@ func (r *Repository) LookupAuthToken(ctx context.Context, id string, opt ...Opti
 
 // ValidateToken returns a token from storage if the auth token with the provided id and token exists.  The
 // approximate last accessed time may be updated depending on how long it has been since the last time the token
-// was validated.  If a token is returned it is guaranteed to be valid. For security reasons, the actual token
-// value is not included in the returned AuthToken. If no valid auth token is found nil, nil is returned.
-// All options are ignored.
+// was validated, unless the repository is read only.  If a token is returned it is guaranteed to be valid. For
+// security reasons, the actual token value is not included in the returned AuthToken. If no valid auth token is
+// found nil, nil is returned. All options are ignored.
 //
 // NOTE: Do not log or add the token string to any errors to avoid leaking it as it is a secret.
 func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ...Option) (*AuthToken, error) {
@@ -207,11 +221,14 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 		return nil, fmt.Errorf("validate token: last accessed time : %w", err)
 	}
 
-	now := time.Now()
+	now := r.clock.Now()
 	sinceLastAccessed := now.Sub(lastAccessed) + timeSkew
 	// TODO (jimlambrt 9/2020) - investigate the need for the timeSkew and see
 	// if it can be eliminated.
 	if now.After(exp.Add(-timeSkew)) || sinceLastAccessed >= maxStaleness {
+		if r.readOnly {
+			return nil, nil
+		}
 		// If the token has expired or has become too stale, delete it from the DB.
 		_, err = r.writer.DoTx(
 			ctx,
@@ -238,7 +255,7 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 	// retAT.Token set to empty string so the value is not returned as described in the methods' doc.
 	retAT.Token = ""
 
-	if sinceLastAccessed >= lastAccessedUpdateDuration {
+	if sinceLastAccessed >= lastAccessedUpdateDuration && !r.readOnly {
 		// To save the db from being updated too frequently, we only update the
 		// LastAccessTime if it hasn't been updated within lastAccessedUpdateDuration.
 		// TODO: Make this duration configurable.
@@ -294,6 +311,49 @@ func (r *Repository) ListAuthTokens(ctx context.Context, withOrgId string, opt .
 	return authTokens, nil
 }
 
+// ListUserAuthTokens returns the unexpired auth tokens of a user, newest
+// first, with the addresses the user authenticated from to get them, where
+// they were recorded. Supports the WithLimit option.
+func (r *Repository) ListUserAuthTokens(ctx context.Context, userId string, opt ...Option) ([]*UserAuthToken, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("list user auth tokens: missing user id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var authTokens []*AuthToken
+	if err := r.reader.SearchWhere(ctx, &authTokens, "iam_user_id = ? and expiration_time > ?", []interface{}{userId, r.clock.Now()},
+		db.WithLimit(limit), db.WithOrder("create_time desc, public_id")); err != nil {
+		return nil, fmt.Errorf("list user auth tokens: %w", err)
+	}
+	if len(authTokens) == 0 {
+		return []*UserAuthToken{}, nil
+	}
+	ids := make([]string, 0, len(authTokens))
+	for _, at := range authTokens {
+		ids = append(ids, at.GetPublicId())
+	}
+	var sources []*tokenSource
+	if err := r.reader.SearchWhere(ctx, &sources, "auth_token_id in (?)", []interface{}{ids}); err != nil {
+		return nil, fmt.Errorf("list user auth tokens: source addresses: %w", err)
+	}
+	addrs := make(map[string]string, len(sources))
+	for _, src := range sources {
+		addrs[src.AuthTokenId] = src.SourceAddress
+	}
+	userTokens := make([]*UserAuthToken, 0, len(authTokens))
+	for _, at := range authTokens {
+		at.Token = ""
+		at.CtToken = nil
+		at.KeyId = ""
+		userTokens = append(userTokens, &UserAuthToken{AuthToken: at, SourceAddress: addrs[at.GetPublicId()]})
+	}
+	return userTokens, nil
+}
+
 // DeleteAuthToken deletes the token with the provided id from the repository returning a count of the
 // number of records deleted.  All options are ignored.
 func (r *Repository) DeleteAuthToken(ctx context.Context, id string, opt ...Option) (int, error) {
@@ -10,6 +10,7 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/clock"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/stretchr/testify/assert"
@@ -51,6 +52,7 @@ func TestRepository_New(t *testing.T) {
 				reader:       rw,
 				writer:       rw,
 				kms:          kmsCache,
+				clock:        clock.System,
 				defaultLimit: db.DefaultLimit,
 			},
 		},
@@ -66,6 +68,7 @@ func TestRepository_New(t *testing.T) {
 				reader:       rw,
 				writer:       rw,
 				kms:          kmsCache,
+				clock:        clock.System,
 				defaultLimit: 5,
 			},
 		},
@@ -187,7 +190,7 @@ func TestRepository_CreateAuthToken(t *testing.T) {
 		},
 		{
 			name:       "invalid-userid",
-			iamUser:    func() *iam.User { u := u1.Clone().(*iam.User); u.PublicId = "this_is_invalid"; return u }(),
+			iamUser:    func() *iam.User { u := u1.Clone(); u.PublicId = "this_is_invalid"; return u }(),
 			authAcctId: aAcct.GetPublicId(),
 			wantErr:    true,
 		},
@@ -490,6 +493,70 @@ func TestRepository_ValidateToken_expired(t *testing.T) {
 	}
 }
 
+func TestRepository_ValidateToken_clock(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+
+	org, _ := iam.TestScopes(t, iamRepo)
+	baseAT := TestAuthToken(t, conn, kms, org.GetPublicId())
+	aAcct := allocAuthAccount()
+	aAcct.PublicId = baseAT.GetAuthAccountId()
+	require.NoError(t, rw.LookupByPublicId(context.Background(), aAcct))
+	iamUser, _, err := iamRepo.LookupUser(context.Background(), aAcct.GetIamUserId())
+	require.NoError(t, err)
+	require.NotNil(t, iamUser)
+
+	var tests = []struct {
+		name    string
+		advance time.Duration
+		want    bool
+	}{
+		{
+			name:    "not-stale-or-expired",
+			advance: time.Minute,
+			want:    true,
+		},
+		{
+			name:    "stale",
+			advance: maxStaleness,
+			want:    false,
+		},
+		{
+			name:    "expired",
+			advance: maxTokenDuration,
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			c := clock.NewFake(time.Now())
+			repo, err := NewRepository(rw, rw, kms, WithClock(c))
+			require.NoError(err)
+
+			ctx := context.Background()
+			at, err := repo.CreateAuthToken(ctx, iamUser, baseAT.GetAuthAccountId())
+			require.NoError(err)
+			exp, err := ptypes.Timestamp(at.GetExpirationTime().GetTimestamp())
+			require.NoError(err)
+			assert.Equal(c.Now().Add(maxTokenDuration).Truncate(time.Second), exp)
+
+			c.Add(tt.advance)
+			got, err := repo.ValidateToken(ctx, at.GetPublicId(), at.GetToken())
+			require.NoError(err)
+			if tt.want {
+				assert.NotNil(got)
+			} else {
+				assert.Nil(got)
+			}
+		})
+	}
+}
+
 func TestRepository_DeleteAuthToken(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
@@ -612,3 +679,56 @@ func TestRepository_ListAuthTokens(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_ListUserAuthTokens(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	am := password.TestAuthMethods(t, conn, org.GetPublicId(), 1)[0]
+	acct := password.TestAccounts(t, conn, am.GetPublicId(), 1)[0]
+	ctx := context.Background()
+	u, err := iamRepo.LookupUserWithLogin(ctx, acct.GetPublicId(), iam.WithAutoVivify(true))
+	require.NoError(err)
+	other := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	older, err := repo.CreateAuthToken(ctx, u, acct.GetPublicId())
+	require.NoError(err)
+	newer, err := repo.CreateAuthToken(ctx, u, acct.GetPublicId(), WithSourceAddress("203.0.113.7"))
+	require.NoError(err)
+
+	got, err := repo.ListUserAuthTokens(ctx, u.GetPublicId())
+	require.NoError(err)
+	require.Len(got, 2)
+	assert.Equal(newer.GetPublicId(), got[0].GetPublicId())
+	assert.Equal("203.0.113.7", got[0].SourceAddress)
+	assert.Empty(got[0].GetToken())
+	assert.Empty(got[0].GetCtToken())
+	assert.Equal(older.GetPublicId(), got[1].GetPublicId())
+	assert.Empty(got[1].SourceAddress)
+
+	got, err = repo.ListUserAuthTokens(ctx, u.GetPublicId(), WithLimit(1))
+	require.NoError(err)
+	require.Len(got, 1)
+	assert.Equal(newer.GetPublicId(), got[0].GetPublicId())
+
+	got, err = repo.ListUserAuthTokens(ctx, other.GetIamUserId())
+	require.NoError(err)
+	require.Len(got, 1)
+	assert.Equal(other.GetPublicId(), got[0].GetPublicId())
+
+	// Expired tokens aren't listed.
+	repo, err = NewRepository(rw, rw, kms, WithClock(clock.NewFake(time.Now().Add(maxTokenDuration+time.Hour))))
+	require.NoError(err)
+	got, err = repo.ListUserAuthTokens(ctx, u.GetPublicId())
+	require.NoError(err)
+	assert.Empty(got)
+
+	_, err = repo.ListUserAuthTokens(ctx, "")
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+}
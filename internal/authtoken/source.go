@@ -0,0 +1,28 @@
+package authtoken
+
+import "github.com/hashicorp/boundary/internal/db/timestamp"
+
+const (
+	defaultTokenSourceTableName = "auth_token_source"
+)
+
+// tokenSource is the address a client authenticated from to get an auth
+// token.
+type tokenSource struct {
+	AuthTokenId   string `gorm:"primary_key"`
+	SourceAddress string
+	CreateTime    *timestamp.Timestamp `gorm:"default:current_timestamp"`
+}
+
+// TableName returns the tablename to override the default gorm table name.
+func (s *tokenSource) TableName() string {
+	return defaultTokenSourceTableName
+}
+
+// UserAuthToken is an auth token as its user reviews it, with the address the
+// user authenticated from to get it. SourceAddress is empty if it wasn't
+// recorded.
+type UserAuthToken struct {
+	*AuthToken
+	SourceAddress string
+}
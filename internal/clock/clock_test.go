@@ -0,0 +1,32 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystem(t *testing.T) {
+	assert := assert.New(t)
+	before := time.Now()
+	now := System.Now()
+	assert.False(now.Before(before))
+	assert.False(now.After(time.Now()))
+}
+
+func TestFake(t *testing.T) {
+	assert := assert.New(t)
+	start := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+	assert.Equal(start, f.Now())
+
+	f.Add(time.Hour)
+	assert.Equal(start.Add(time.Hour), f.Now())
+
+	f.Add(-2 * time.Hour)
+	assert.Equal(start.Add(-time.Hour), f.Now())
+
+	f.Set(start)
+	assert.Equal(start, f.Now())
+}
@@ -59,6 +59,7 @@ type Command struct {
 	flagTLSInsecure   bool
 
 	flagFormat           string
+	flagTimeFormat       string
 	FlagToken            string
 	FlagTokenName        string
 	FlagRecoveryConfig   string
@@ -71,6 +72,8 @@ type Command struct {
 	FlagAuthMethodId  string
 	FlagHostCatalogId string
 	FlagVersion       int
+	FlagPageSize      uint
+	FlagPageToken     string
 
 	client *api.Client
 }
@@ -394,6 +397,15 @@ func (c *Command) FlagSet(bit FlagSetBit) *FlagSets {
 					Completion: complete.PredictSet("table", "json", "yaml"),
 					Usage:      "Print the output in the given format. Valid formats are \"table\" or \"json\".",
 				})
+
+				f.StringVar(&StringVar{
+					Name:       "time-format",
+					Target:     &c.flagTimeFormat,
+					Default:    TimeFormatLocal,
+					EnvVar:     EnvBoundaryCLITimeFormat,
+					Completion: complete.PredictSet(TimeFormatLocal, TimeFormatUtc, TimeFormatRelative),
+					Usage:      "Print timestamps in table output in the given format. Valid formats are \"local\", \"utc\" or \"relative\". JSON output always uses RFC 3339 timestamps.",
+				})
 			}
 		}
 
@@ -0,0 +1,92 @@
+package base
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/shared-secure-libs/reloadutil"
+)
+
+// DefaultListenerCertPollInterval is how often the certificate and key files
+// of TLS listeners are checked for changes.
+const DefaultListenerCertPollInterval = 30 * time.Second
+
+// certWatch is the files of a TLS listener's certificate and the func which
+// reloads them.
+type certWatch struct {
+	files   []string
+	reload  reloadutil.ReloadFunc
+	modTime map[string]time.Time
+}
+
+// newCertWatch returns a certWatch of the files, recording their current
+// modification times.
+func newCertWatch(reload reloadutil.ReloadFunc, files ...string) *certWatch {
+	w := &certWatch{
+		reload:  reload,
+		modTime: make(map[string]time.Time, len(files)),
+	}
+	for _, f := range files {
+		if f == "" {
+			continue
+		}
+		w.files = append(w.files, f)
+		w.modTime[f] = fileModTime(f)
+	}
+	return w
+}
+
+// changed reports whether any of the files were modified since it was last
+// called, and records their modification times.
+func (w *certWatch) changed() bool {
+	var changed bool
+	for _, f := range w.files {
+		t := fileModTime(f)
+		if !t.Equal(w.modTime[f]) {
+			w.modTime[f] = t
+			changed = true
+		}
+	}
+	return changed
+}
+
+func fileModTime(name string) time.Time {
+	fi, err := os.Stat(name)
+	if err != nil {
+		// A missing file is a change too, but not one that can be loaded, so
+		// the reload will report it.
+		return time.Time{}
+	}
+	return fi.ModTime()
+}
+
+// watchCerts checks the watches every interval until ctx is done, and reloads
+// the certificate of every watch whose files changed. Certificates being
+// rotated are often written as two files, so a reload that fails is retried
+// at the next check.
+func watchCerts(ctx context.Context, logger hclog.Logger, interval time.Duration, watches []*certWatch) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	failed := make(map[*certWatch]bool, len(watches))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, w := range watches {
+			if !w.changed() && !failed[w] {
+				continue
+			}
+			if err := w.reload(); err != nil {
+				logger.Error("error reloading listener certificate", "files", w.files, "error", err)
+				failed[w] = true
+				continue
+			}
+			delete(failed, w)
+			logger.Info("reloaded listener certificate", "files", w.files)
+		}
+	}
+}
@@ -25,6 +25,7 @@ const (
 )
 
 const (
-	EnvBoundaryCLINoColor = `BOUNDARY_CLI_NO_COLOR`
-	EnvBoundaryCLIFormat  = `BOUNDARY_CLI_FORMAT`
+	EnvBoundaryCLINoColor    = `BOUNDARY_CLI_NO_COLOR`
+	EnvBoundaryCLIFormat     = `BOUNDARY_CLI_FORMAT`
+	EnvBoundaryCLITimeFormat = `BOUNDARY_CLI_TIME_FORMAT`
 )
@@ -6,6 +6,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -177,6 +178,15 @@ func PrintApiError(in *api.Error) string {
 				)
 			}
 		}
+		if in.Details.Banner != nil {
+			ret = append(ret,
+				"",
+				fmt.Sprintf("  Banner Hash: %s", in.Details.Banner.Hash),
+				"",
+				"  Banner:",
+				in.Details.Banner.Text,
+			)
+		}
 	}
 
 	return WrapForHelpText(ret)
@@ -202,3 +212,91 @@ func Format(ui cli.Ui) string {
 
 	return format
 }
+
+const (
+	// TimeFormatLocal prints timestamps in the local time zone.
+	TimeFormatLocal = "local"
+	// TimeFormatUtc prints timestamps in UTC.
+	TimeFormatUtc = "utc"
+	// TimeFormatRelative prints timestamps relative to now, such as "5m ago".
+	TimeFormatRelative = "relative"
+)
+
+// OutputTimeFormat is how FormatTime prints timestamps in table output. It's
+// set from the -time-format flag or the BOUNDARY_CLI_TIME_FORMAT env var
+// before commands run. JSON output isn't affected.
+var OutputTimeFormat = TimeFormatLocal
+
+// ValidTimeFormat returns whether format is a known time format.
+func ValidTimeFormat(format string) bool {
+	switch format {
+	case TimeFormatLocal, TimeFormatUtc, TimeFormatRelative:
+		return true
+	}
+	return false
+}
+
+// FormatTime formats a timestamp for table output using OutputTimeFormat, so
+// all commands print timestamps the same way.
+func FormatTime(t time.Time) string {
+	switch OutputTimeFormat {
+	case TimeFormatUtc:
+		return t.UTC().Format(time.RFC1123)
+	case TimeFormatRelative:
+		if t.IsZero() {
+			return t.UTC().Format(time.RFC1123)
+		}
+		return relativeTime(t, time.Now())
+	default:
+		return t.Local().Format(time.RFC1123)
+	}
+}
+
+// relativeTime prints t relative to now, such as "5m ago" or "in 2h30m".
+func relativeTime(t, now time.Time) string {
+	d := t.Sub(now)
+	switch {
+	case d > -time.Second && d < time.Second:
+		return "now"
+	case d < 0:
+		return fmt.Sprintf("%s ago", FormatDuration(-d))
+	default:
+		return fmt.Sprintf("in %s", FormatDuration(d))
+	}
+}
+
+// FormatDuration formats a duration for table output, keeping only its two
+// most significant units, such as "2h30m" or "3d4h".
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + FormatDuration(-d)
+	}
+	units := []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	}
+	var ret string
+	var parts int
+	for _, u := range units {
+		if d < u.size && parts == 0 {
+			continue
+		}
+		n := d / u.size
+		d -= n * u.size
+		if n > 0 {
+			ret += fmt.Sprintf("%d%s", n, u.suffix)
+		}
+		if parts++; parts == 2 {
+			break
+		}
+	}
+	if ret == "" {
+		return "0s"
+	}
+	return ret
+}
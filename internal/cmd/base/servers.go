@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/boundary/globals"
@@ -62,6 +63,11 @@ type Server struct {
 	ReloadFuncsLock *sync.RWMutex
 	ReloadFuncs     map[string][]reloadutil.ReloadFunc
 
+	// ListenerCertPollInterval is how often the certificates of TLS listeners
+	// are checked for changes and reloaded. Zero disables checking, leaving
+	// certificates to be reloaded on SIGHUP.
+	ListenerCertPollInterval time.Duration
+
 	ShutdownFuncs []func() error
 
 	Listeners []*ServerListener
@@ -95,6 +101,8 @@ func NewServer(cmd *Command) *Server {
 		SecureRandomReader: rand.Reader,
 		ReloadFuncsLock:    new(sync.RWMutex),
 		ReloadFuncs:        make(map[string][]reloadutil.ReloadFunc),
+
+		ListenerCertPollInterval: DefaultListenerCertPollInterval,
 	}
 }
 
@@ -264,6 +272,7 @@ func (b *Server) SetupListeners(ui cli.Ui, config *configutil.SharedConfig, allo
 	b.ReloadFuncsLock.Lock()
 	defer b.ReloadFuncsLock.Unlock()
 
+	var certWatches []*certWatch
 	for i, lnConfig := range config.Listeners {
 		for _, purpose := range lnConfig.Purpose {
 			purpose = strings.ToLower(purpose)
@@ -272,17 +281,20 @@ func (b *Server) SetupListeners(ui cli.Ui, config *configutil.SharedConfig, allo
 			}
 		}
 
-		// Override for now
-		// TODO: Way to configure
-		lnConfig.TLSCipherSuites = []uint16{
-			// 1.3
-			tls.TLS_AES_128_GCM_SHA256,
-			tls.TLS_AES_256_GCM_SHA384,
-			tls.TLS_CHACHA20_POLY1305_SHA256,
-			// 1.2
-			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
-			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
-			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+		// Use our defaults unless the suites are set with tls_cipher_suites.
+		// The minimum version and client CA bundle are set with
+		// tls_min_version and tls_client_ca_file.
+		if len(lnConfig.TLSCipherSuites) == 0 {
+			lnConfig.TLSCipherSuites = []uint16{
+				// 1.3
+				tls.TLS_AES_128_GCM_SHA256,
+				tls.TLS_AES_256_GCM_SHA384,
+				tls.TLS_CHACHA20_POLY1305_SHA256,
+				// 1.2
+				tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+				tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+				tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+			}
 		}
 
 		lnMux, props, reloadFunc, err := NewListener(lnConfig, b.Logger, ui)
@@ -307,6 +319,7 @@ func (b *Server) SetupListeners(ui cli.Ui, config *configutil.SharedConfig, allo
 			relSlice := b.ReloadFuncs["listener|"+lnConfig.Type]
 			relSlice = append(relSlice, reloadFunc)
 			b.ReloadFuncs["listener|"+lnConfig.Type] = relSlice
+			certWatches = append(certWatches, newCertWatch(reloadFunc, lnConfig.TLSCertFile, lnConfig.TLSKeyFile))
 		}
 
 		if lnConfig.MaxRequestSize == 0 {
@@ -340,6 +353,15 @@ func (b *Server) SetupListeners(ui cli.Ui, config *configutil.SharedConfig, allo
 			"%s (%s)", lnConfig.Type, strings.Join(propsList, ", "))
 	}
 
+	if len(certWatches) > 0 && b.ListenerCertPollInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		go watchCerts(ctx, b.Logger.Named("listener-certs"), b.ListenerCertPollInterval, certWatches)
+		b.ShutdownFuncs = append(b.ShutdownFuncs, func() error {
+			cancel()
+			return nil
+		})
+	}
+
 	return nil
 }
 
@@ -428,8 +450,13 @@ func (b *Server) RunShutdownFuncs() error {
 	return mErr.ErrorOrNil()
 }
 
-func (b *Server) ConnectToDatabase(dialect string) error {
-	dbase, err := gorm.Open(dialect, b.DatabaseUrl)
+// ConnectToDatabase opens the database at DatabaseUrl. The options are passed
+// to db.Open to configure the connection pool and statement timeout.
+func (b *Server) ConnectToDatabase(dialect string, opt ...db.Option) error {
+	if dialect != db.Postgres.String() {
+		return fmt.Errorf("unable to create db object with dialect %s: unsupported dialect", dialect)
+	}
+	dbase, err := db.Open(db.Postgres, b.DatabaseUrl, opt...)
 	if err != nil {
 		return fmt.Errorf("unable to create db object with dialect %s: %w", dialect, err)
 	}
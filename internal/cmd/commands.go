@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/authenticate"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authmethods"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authtokens"
+	"github.com/hashicorp/boundary/internal/cmd/commands/clientcertificatelibraries"
 	"github.com/hashicorp/boundary/internal/cmd/commands/config"
 	"github.com/hashicorp/boundary/internal/cmd/commands/connect"
 	"github.com/hashicorp/boundary/internal/cmd/commands/database"
@@ -203,6 +204,66 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"client-certificate-libraries": func() (cli.Command, error) {
+			return &clientcertificatelibraries.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"client-certificate-libraries read": func() (cli.Command, error) {
+			return &clientcertificatelibraries.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"client-certificate-libraries delete": func() (cli.Command, error) {
+			return &clientcertificatelibraries.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"client-certificate-libraries list": func() (cli.Command, error) {
+			return &clientcertificatelibraries.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"client-certificate-libraries create": func() (cli.Command, error) {
+			return &clientcertificatelibraries.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"client-certificate-libraries create internal": func() (cli.Command, error) {
+			return &clientcertificatelibraries.InternalCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"client-certificate-libraries create vault": func() (cli.Command, error) {
+			return &clientcertificatelibraries.VaultCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"client-certificate-libraries update": func() (cli.Command, error) {
+			return &clientcertificatelibraries.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"client-certificate-libraries update internal": func() (cli.Command, error) {
+			return &clientcertificatelibraries.InternalCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"client-certificate-libraries update vault": func() (cli.Command, error) {
+			return &clientcertificatelibraries.VaultCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+
 		"config": func() (cli.Command, error) {
 			return &config.Command{
 				Command: base.NewCommand(ui),
@@ -286,6 +347,41 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"database claim-rules": func() (cli.Command, error) {
+			return &database.ClaimRulesCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database banned-grants": func() (cli.Command, error) {
+			return &database.BannedGrantsCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database role-diff": func() (cli.Command, error) {
+			return &database.RoleDiffCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database role-expiration": func() (cli.Command, error) {
+			return &database.RoleExpirationCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database emergency-roles": func() (cli.Command, error) {
+			return &database.EmergencyRolesCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database references": func() (cli.Command, error) {
+			return &database.ReferencesCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database migrate": func() (cli.Command, error) {
+			return &database.MigrateCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"groups": func() (cli.Command, error) {
 			return &groups.Command{
@@ -472,6 +568,11 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "delete",
 			}, nil
 		},
+		"hosts import": func() (cli.Command, error) {
+			return &hosts.ImportCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 		"hosts list": func() (cli.Command, error) {
 			return &hosts.Command{
 				Command: base.NewCommand(ui),
@@ -574,6 +675,36 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-grants",
 			}, nil
 		},
+		"roles activate-emergency": func() (cli.Command, error) {
+			return &roles.Command{
+				Command: base.NewCommand(ui),
+				Func:    "activate-emergency",
+			}, nil
+		},
+		"roles revert-emergency": func() (cli.Command, error) {
+			return &roles.Command{
+				Command: base.NewCommand(ui),
+				Func:    "revert-emergency",
+			}, nil
+		},
+		"roles diff": func() (cli.Command, error) {
+			return &roles.Command{
+				Command: base.NewCommand(ui),
+				Func:    "diff",
+			}, nil
+		},
+		"roles archive": func() (cli.Command, error) {
+			return &roles.Command{
+				Command: base.NewCommand(ui),
+				Func:    "archive",
+			}, nil
+		},
+		"roles restore": func() (cli.Command, error) {
+			return &roles.Command{
+				Command: base.NewCommand(ui),
+				Func:    "restore",
+			}, nil
+		},
 
 		"scopes": func() (cli.Command, error) {
 			return &scopes.Command{
@@ -610,6 +741,24 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "list",
 			}, nil
 		},
+		"scopes list-banned-grant-patterns": func() (cli.Command, error) {
+			return &scopes.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list-banned-grant-patterns",
+			}, nil
+		},
+		"scopes add-banned-grant-pattern": func() (cli.Command, error) {
+			return &scopes.Command{
+				Command: base.NewCommand(ui),
+				Func:    "add-banned-grant-pattern",
+			}, nil
+		},
+		"scopes remove-banned-grant-pattern": func() (cli.Command, error) {
+			return &scopes.Command{
+				Command: base.NewCommand(ui),
+				Func:    "remove-banned-grant-pattern",
+			}, nil
+		},
 
 		"sessions": func() (cli.Command, error) {
 			return &sessions.Command{
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/authmethods"
@@ -144,7 +143,7 @@ func (c *PasswordCommand) Run(args []string) int {
 			"Authentication information:",
 			fmt.Sprintf("  Account ID:      %s", token.AccountId),
 			fmt.Sprintf("  Auth Method ID:  %s", token.AuthMethodId),
-			fmt.Sprintf("  Expiration Time: %s", token.ExpirationTime.Local().Format(time.RFC1123)),
+			fmt.Sprintf("  Expiration Time: %s", base.FormatTime(token.ExpirationTime)),
 			fmt.Sprintf("  Token:           %s", token.Token),
 			fmt.Sprintf("  User ID:         %s", token.UserId),
 		}))
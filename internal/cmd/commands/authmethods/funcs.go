@@ -1,8 +1,6 @@
 package authmethods
 
 import (
-	"time"
-
 	"github.com/hashicorp/boundary/api/authmethods"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 )
@@ -25,8 +23,8 @@ func generateAuthMethodTableOutput(in *authmethods.AuthMethod) string {
 		"ID":           in.Id,
 		"Version":      in.Version,
 		"Type":         in.Type,
-		"Created Time": in.CreatedTime.Local().Format(time.RFC1123),
-		"Updated Time": in.UpdatedTime.Local().Format(time.RFC1123),
+		"Created Time": base.FormatTime(in.CreatedTime),
+		"Updated Time": base.FormatTime(in.UpdatedTime),
 	}
 
 	if in.Name != "" {
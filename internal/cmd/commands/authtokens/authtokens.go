@@ -3,7 +3,6 @@ package authtokens
 import (
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/authtokens"
@@ -168,11 +167,11 @@ func (c *Command) Run(args []string) int {
 				}
 				output = append(output,
 					fmt.Sprintf("  ID:                            %s", t.Id),
-					fmt.Sprintf("    Approximate Last Used Time:  %s", t.ApproximateLastUsedTime.Local().Format(time.RFC1123)),
+					fmt.Sprintf("    Approximate Last Used Time:  %s", base.FormatTime(t.ApproximateLastUsedTime)),
 					fmt.Sprintf("    Auth Method ID:              %s", t.AuthMethodId),
-					fmt.Sprintf("    Created Time:                %s", t.CreatedTime.Local().Format(time.RFC1123)),
-					fmt.Sprintf("    Expiration Time:             %s", t.ExpirationTime.Local().Format(time.RFC1123)),
-					fmt.Sprintf("    Updated Time:                %s", t.UpdatedTime.Local().Format(time.RFC1123)),
+					fmt.Sprintf("    Created Time:                %s", base.FormatTime(t.CreatedTime)),
+					fmt.Sprintf("    Expiration Time:             %s", base.FormatTime(t.ExpirationTime)),
+					fmt.Sprintf("    Updated Time:                %s", base.FormatTime(t.UpdatedTime)),
 					fmt.Sprintf("    User ID:                     %s", t.UserId),
 				)
 			}
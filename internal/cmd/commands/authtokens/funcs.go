@@ -1,8 +1,6 @@
 package authtokens
 
 import (
-	"time"
-
 	"github.com/hashicorp/boundary/api/authtokens"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 )
@@ -12,10 +10,10 @@ func generateAuthTokenTableOutput(in *authtokens.AuthToken) string {
 		"ID":                         in.Id,
 		"Auth Method ID":             in.AuthMethodId,
 		"User ID":                    in.UserId,
-		"Created Time":               in.CreatedTime.Local().Format(time.RFC1123),
-		"Updated Time":               in.UpdatedTime.Local().Format(time.RFC1123),
-		"Expiration Time":            in.ExpirationTime.Local().Format(time.RFC1123),
-		"Approximate Last Used Time": in.ApproximateLastUsedTime.Local().Format(time.RFC1123),
+		"Created Time":               base.FormatTime(in.CreatedTime),
+		"Updated Time":               base.FormatTime(in.UpdatedTime),
+		"Expiration Time":            base.FormatTime(in.ExpirationTime),
+		"Approximate Last Used Time": base.FormatTime(in.ApproximateLastUsedTime),
 	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
@@ -0,0 +1,133 @@
+package clientcertificatelibraries
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api/clientcertificatelibraries"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+)
+
+func generateClientCertificateLibraryTableOutput(in *clientcertificatelibraries.ClientCertificateLibrary) string {
+	nonAttributeMap := map[string]interface{}{
+		"ID":           in.Id,
+		"Version":      in.Version,
+		"Type":         in.Type,
+		"Created Time": base.FormatTime(in.CreatedTime),
+		"Updated Time": base.FormatTime(in.UpdatedTime),
+	}
+
+	if in.Name != "" {
+		nonAttributeMap["Name"] = in.Name
+	}
+	if in.Description != "" {
+		nonAttributeMap["Description"] = in.Description
+	}
+	if in.CommonName != "" {
+		nonAttributeMap["Common Name"] = in.CommonName
+	}
+	if in.ServerName != "" {
+		nonAttributeMap["Server Name"] = in.ServerName
+	}
+	if in.VaultAddress != "" {
+		nonAttributeMap["Vault Address"] = in.VaultAddress
+	}
+	if in.VaultPkiPath != "" {
+		nonAttributeMap["Vault PKI Path"] = in.VaultPkiPath
+	}
+	if in.VaultRole != "" {
+		nonAttributeMap["Vault Role"] = in.VaultRole
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Client Certificate Library information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+		"",
+		"  Scope:",
+		base.ScopeInfoForOutput(in.Scope, maxLength),
+	}
+
+	// The certificates are printed as they are so they can be copied
+	output := base.WrapForHelpText(ret)
+	if in.CaCert != "" {
+		output += "\n\n  CA Certificate:\n" + in.CaCert
+	}
+	if in.ServerCaCert != "" {
+		output += "\n\n  Server CA Certificate:\n" + in.ServerCaCert
+	}
+	return output
+}
+
+// settingFlags are the flags setting how the certificates of a library of any
+// type are issued and verified.
+type settingFlags struct {
+	flagCommonName   string
+	flagServerName   string
+	flagServerCaCert string
+}
+
+func (s *settingFlags) populate(f *base.FlagSet) {
+	f.StringVar(&base.StringVar{
+		Name:   "common-name",
+		Target: &s.flagCommonName,
+		Usage:  "The common name of the certificates issued. Defaults to the id of the session's user.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "server-name",
+		Target: &s.flagServerName,
+		Usage:  "The name the target's certificate is verified against. Defaults to the host of the session's endpoint.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "server-ca-cert",
+		Target: &s.flagServerCaCert,
+		Usage:  "The PEM encoded CA certificates the target's certificate is verified with. Defaults to the worker's system roots. This can refer to a file on disk (file://) from which the certificates will be read; an env var (env://) from which they will be read; or the certificates themselves.",
+	})
+}
+
+func (s *settingFlags) opts() ([]clientcertificatelibraries.Option, error) {
+	var opts []clientcertificatelibraries.Option
+
+	switch s.flagCommonName {
+	case "":
+	case "null":
+		opts = append(opts, clientcertificatelibraries.DefaultCommonName())
+	default:
+		opts = append(opts, clientcertificatelibraries.WithCommonName(s.flagCommonName))
+	}
+
+	switch s.flagServerName {
+	case "":
+	case "null":
+		opts = append(opts, clientcertificatelibraries.DefaultServerName())
+	default:
+		opts = append(opts, clientcertificatelibraries.WithServerName(s.flagServerName))
+	}
+
+	switch s.flagServerCaCert {
+	case "":
+	case "null":
+		opts = append(opts, clientcertificatelibraries.DefaultServerCaCert())
+	default:
+		caCert, err := parseValue(s.flagServerCaCert)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading server CA certificates: %w", err)
+		}
+		opts = append(opts, clientcertificatelibraries.WithServerCaCert(caCert))
+	}
+
+	return opts, nil
+}
+
+// parseValue reads a flag's value from a file or an env var if it refers to
+// one, or returns the value itself.
+func parseValue(in string) (string, error) {
+	out, err := config.ParseAddress(in)
+	if err != nil && !errors.Is(err, config.ErrNotAUrl) {
+		return "", err
+	}
+	return out, nil
+}
@@ -0,0 +1,184 @@
+package clientcertificatelibraries
+
+import (
+	"fmt"
+	"net/textproto"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/clientcertificatelibraries"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*InternalCommand)(nil)
+var _ cli.CommandAutocomplete = (*InternalCommand)(nil)
+
+type InternalCommand struct {
+	*base.Command
+
+	Func string
+
+	settingFlags
+}
+
+func (c *InternalCommand) Synopsis() string {
+	return fmt.Sprintf("%s an internal-type client certificate library", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var internalFlagsMap = map[string][]string{
+	"create": {"scope-id", "name", "description"},
+	"update": {"id", "name", "description", "version"},
+}
+
+func (c *InternalCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary client-certificate-libraries create internal [options] [args]",
+			"",
+			"  Create an internal-type client certificate library, which issues certificates from a CA generated by the controller. The targets must trust the library's CA certificate. Example:",
+			"",
+			`    $ boundary client-certificate-libraries create internal -scope-id p_1234567890 -name prodops -server-ca-cert file:///etc/prodops/ca.pem`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary client-certificate-libraries update internal [options] [args]",
+			"",
+			"  Update an internal-type client certificate library given its ID. Example:",
+			"",
+			`    $ boundary client-certificate-libraries update internal -id ccl_1234567890 -common-name prodops`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *InternalCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "internal-type client certificate library", internalFlagsMap[c.Func])
+
+	f = set.NewFlagSet("Internal Client Certificate Library Options")
+	c.settingFlags.populate(f)
+
+	return set
+}
+
+func (c *InternalCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *InternalCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *InternalCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(internalFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(internalFlagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []clientcertificatelibraries.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, clientcertificatelibraries.DefaultName())
+	default:
+		opts = append(opts, clientcertificatelibraries.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, clientcertificatelibraries.DefaultDescription())
+	default:
+		opts = append(opts, clientcertificatelibraries.WithDescription(c.FlagDescription))
+	}
+
+	settingOpts, err := c.settingFlags.opts()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	opts = append(opts, settingOpts...)
+
+	libraryClient := clientcertificatelibraries.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, clientcertificatelibraries.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = libraryClient.Create(c.Context, "internal", c.FlagScopeId, opts...)
+	case "update":
+		result, err = libraryClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "internal-type client certificate library"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	library := result.GetItem().(*clientcertificatelibraries.ClientCertificateLibrary)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateClientCertificateLibraryTableOutput(library))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(library)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
@@ -0,0 +1,234 @@
+package clientcertificatelibraries
+
+import (
+	"fmt"
+	"net/textproto"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/clientcertificatelibraries"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*VaultCommand)(nil)
+var _ cli.CommandAutocomplete = (*VaultCommand)(nil)
+
+type VaultCommand struct {
+	*base.Command
+
+	Func string
+
+	settingFlags
+
+	flagVaultAddress string
+	flagVaultPkiPath string
+	flagVaultRole    string
+	flagVaultToken   string
+}
+
+func (c *VaultCommand) Synopsis() string {
+	return fmt.Sprintf("%s a vault-type client certificate library", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var vaultFlagsMap = map[string][]string{
+	"create": {"scope-id", "name", "description"},
+	"update": {"id", "name", "description", "version"},
+}
+
+func (c *VaultCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary client-certificate-libraries create vault [options] [args]",
+			"",
+			"  Create a vault-type client certificate library, which issues certificates from a Vault PKI secrets engine. The Vault token defaults to the VAULT_TOKEN env var. Example:",
+			"",
+			`    $ boundary client-certificate-libraries create vault -scope-id p_1234567890 -name prodops -vault-address https://vault.example.com:8200 -vault-pki-path pki -vault-role boundary`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary client-certificate-libraries update vault [options] [args]",
+			"",
+			"  Update a vault-type client certificate library given its ID. Example:",
+			"",
+			`    $ boundary client-certificate-libraries update vault -id ccl_1234567890 -vault-token env://PRODOPS_VAULT_TOKEN`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *VaultCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "vault-type client certificate library", vaultFlagsMap[c.Func])
+
+	f = set.NewFlagSet("Vault Client Certificate Library Options")
+	c.settingFlags.populate(f)
+	f.StringVar(&base.StringVar{
+		Name:   "vault-address",
+		Target: &c.flagVaultAddress,
+		Usage:  "The address of the Vault server which issues the certificates.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "vault-pki-path",
+		Target: &c.flagVaultPkiPath,
+		Usage:  "The path the Vault PKI secrets engine is mounted at.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "vault-role",
+		Target: &c.flagVaultRole,
+		Usage:  "The Vault PKI role the certificates are issued with.",
+	})
+	// Only a new library picks up the token from the environment, so that
+	// updating other fields doesn't replace the library's token
+	var tokenEnvVar string
+	if c.Func == "create" {
+		tokenEnvVar = "VAULT_TOKEN"
+	}
+	f.StringVar(&base.StringVar{
+		Name:   "vault-token",
+		Target: &c.flagVaultToken,
+		EnvVar: tokenEnvVar,
+		Usage:  "The Vault token the certificates are issued with. This can refer to a file on disk (file://) from which the token will be read; an env var (env://) from which it will be read; or the token itself. When creating a library, it defaults to the VAULT_TOKEN env var.",
+	})
+
+	return set
+}
+
+func (c *VaultCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *VaultCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *VaultCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(vaultFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(vaultFlagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []clientcertificatelibraries.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, clientcertificatelibraries.DefaultName())
+	default:
+		opts = append(opts, clientcertificatelibraries.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, clientcertificatelibraries.DefaultDescription())
+	default:
+		opts = append(opts, clientcertificatelibraries.WithDescription(c.FlagDescription))
+	}
+
+	settingOpts, err := c.settingFlags.opts()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	opts = append(opts, settingOpts...)
+
+	if c.flagVaultAddress != "" {
+		opts = append(opts, clientcertificatelibraries.WithVaultAddress(c.flagVaultAddress))
+	}
+	if c.flagVaultPkiPath != "" {
+		opts = append(opts, clientcertificatelibraries.WithVaultPkiPath(c.flagVaultPkiPath))
+	}
+	if c.flagVaultRole != "" {
+		opts = append(opts, clientcertificatelibraries.WithVaultRole(c.flagVaultRole))
+	}
+	if c.flagVaultToken != "" {
+		token, err := parseValue(c.flagVaultToken)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error reading Vault token: %s", err.Error()))
+			return 1
+		}
+		opts = append(opts, clientcertificatelibraries.WithVaultToken(token))
+	}
+
+	libraryClient := clientcertificatelibraries.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, clientcertificatelibraries.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = libraryClient.Create(c.Context, "vault", c.FlagScopeId, opts...)
+	case "update":
+		result, err = libraryClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "vault-type client certificate library"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	library := result.GetItem().(*clientcertificatelibraries.ClientCertificateLibrary)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateClientCertificateLibraryTableOutput(library))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(library)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
@@ -68,6 +68,10 @@ type Command struct {
 	flagExec       string
 	flagUsername   string
 
+	flagAcknowledgeBanner bool
+	flagJustification     string
+	flagChangeTicket      string
+
 	// HTTP
 	httpFlags
 
@@ -167,6 +171,24 @@ func (c *Command) Flags() *base.FlagSets {
 		Usage:  "The ID of a specific host to connect to out of the hosts from the target's host sets. If not specified, one is chosen at random.",
 	})
 
+	f.BoolVar(&base.BoolVar{
+		Name:   "acknowledge-banner",
+		Target: &c.flagAcknowledgeBanner,
+		Usage:  "Acknowledge the target's banner without being prompted, if the target requires acknowledging it.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "justification",
+		Target: &c.flagJustification,
+		Usage:  "The reason for the session. Required if the target has a justification pattern, which it must match.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "change-ticket",
+		Target: &c.flagChangeTicket,
+		Usage:  "The change ticket for the session, like \"CHG-1234\". Required if the target has a change ticket system, which must accept it.",
+	})
+
 	f.StringVar(&base.StringVar{
 		Name:       "exec",
 		Target:     &c.flagExec,
@@ -314,8 +336,36 @@ func (c *Command) Run(args []string) (retCode int) {
 		if len(c.flagHostId) != 0 {
 			opts = append(opts, targets.WithHostId(c.flagHostId))
 		}
+		if c.flagJustification != "" {
+			opts = append(opts, targets.WithJustification(c.flagJustification))
+		}
+		if c.flagChangeTicket != "" {
+			opts = append(opts, targets.WithChangeTicket(c.flagChangeTicket))
+		}
 
 		sar, err := targetClient.AuthorizeSession(c.Context, c.flagTargetId, opts...)
+		if banner := bannerAcknowledgmentRequired(err); banner != nil {
+			// Show the banner and retry once it's acknowledged. It's
+			// acknowledged by the hash of the version shown, so the retry
+			// fails if the banner has changed since.
+			c.UI.Warn(banner.Text)
+			if !c.flagAcknowledgeBanner {
+				answer, askErr := c.UI.Ask(`Type "yes" to acknowledge the banner and connect:`)
+				if askErr != nil {
+					c.UI.Error(fmt.Sprintf("Error reading banner acknowledgment: %s", askErr))
+					return 1
+				}
+				if !strings.EqualFold(strings.TrimSpace(answer), "yes") {
+					c.UI.Error("The target's banner must be acknowledged to connect")
+					return 1
+				}
+			}
+			sar, err = targetClient.AuthorizeSession(c.Context, c.flagTargetId, append(opts, targets.WithBannerHash(banner.Hash))...)
+		} else if err == nil {
+			if banner := sar.GetItem().(*targets.SessionAuthorization).Banner; banner != "" {
+				c.UI.Warn(banner)
+			}
+		}
 		if err != nil {
 			if apiErr := api.AsServerError(err); apiErr != nil {
 				c.UI.Error(fmt.Sprintf("Error from controller when performing authorize-session against target: %s", base.PrintApiError(apiErr)))
@@ -737,3 +787,13 @@ func (c *Command) handleExec(passthroughArgs []string) {
 	}
 	c.execCmdReturnValue.Store(0)
 }
+
+// bannerAcknowledgmentRequired returns the banner of the target if err is
+// the controller refusing to authorize a session until it's acknowledged.
+func bannerAcknowledgmentRequired(err error) *api.Banner {
+	apiErr := api.AsServerError(err)
+	if apiErr == nil || apiErr.Details == nil {
+		return nil
+	}
+	return apiErr.Details.Banner
+}
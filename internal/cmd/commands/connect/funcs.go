@@ -1,8 +1,6 @@
 package connect
 
 import (
-	"time"
-
 	"github.com/hashicorp/boundary/internal/cmd/base"
 )
 
@@ -12,7 +10,7 @@ func generateSessionInfoTableOutput(in SessionInfo) string {
 		"Protocol":         in.Protocol,
 		"Address":          in.Address,
 		"Port":             in.Port,
-		"Expiration":       in.Expiration.Local().Format(time.RFC1123),
+		"Expiration":       base.FormatTime(in.Expiration),
 		"Connection Limit": in.ConnectionLimit,
 	}
 
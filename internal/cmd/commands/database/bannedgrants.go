@@ -0,0 +1,295 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*BannedGrantsCommand)(nil)
+var _ cli.CommandAutocomplete = (*BannedGrantsCommand)(nil)
+
+// BannedGrantsCommand lists, creates and deletes the banned grant patterns of
+// a scope.
+type BannedGrantsCommand struct {
+	*base.Command
+	srv *base.Server
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+
+	flagConfig      string
+	flagConfigKms   string
+	flagScopeId     string
+	flagName        string
+	flagPattern     string
+	flagDescription string
+	flagDelete      bool
+}
+
+func (c *BannedGrantsCommand) Synopsis() string {
+	return "Manage the grant patterns banned in a scope and the scopes beneath it"
+}
+
+func (c *BannedGrantsCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database banned-grants [options]",
+		"",
+		"  List the banned grant patterns of a scope. Role grants matching a pattern",
+		"  can't be written in the pattern's scope or any scope beneath it:",
+		"",
+		`    $ boundary database banned-grants -config=/etc/boundary/controller.hcl -scope-id=o_1234567890`,
+		"",
+		"  Ban a pattern, naming the policy reported when a grant is refused:",
+		"",
+		`    $ boundary database banned-grants -config=/etc/boundary/controller.hcl -scope-id=o_1234567890 -name=no-wildcards -pattern="id=*;actions=*"`,
+		"",
+		"  Or lift a ban:",
+		"",
+		`    $ boundary database banned-grants -config=/etc/boundary/controller.hcl -scope-id=o_1234567890 -name=no-wildcards -delete`,
+	}) + c.Flags().Help()
+}
+
+func (c *BannedGrantsCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file.`,
+	})
+
+	f = set.NewFlagSet("Banned Grant Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "scope-id",
+		Target: &c.flagScopeId,
+		Usage:  `The scope of the patterns, like "global" or an org.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "name",
+		Target: &c.flagName,
+		Usage:  "The name of the pattern to create or delete.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "pattern",
+		Target: &c.flagPattern,
+		Usage:  `The pattern to ban, in the form of a grant with any of its id, type and actions left out, like "id=*;actions=*". A grant matches if it has the pattern's id and type and allows at least its actions.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "description",
+		Target: &c.flagDescription,
+		Usage:  "A description of the pattern to create.",
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "delete",
+		Target: &c.flagDelete,
+		Usage:  "If set, the pattern with the name is deleted.",
+	})
+
+	return set
+}
+
+func (c *BannedGrantsCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *BannedGrantsCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *BannedGrantsCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	switch {
+	case c.flagConfig == "":
+		c.UI.Error("Must specify a config file using -config")
+		return 1
+	case c.flagScopeId == "":
+		c.UI.Error("Must specify a scope using -scope-id")
+		return 1
+	case c.flagDelete && c.flagName == "":
+		c.UI.Error("Must specify the pattern to delete using -name")
+		return 1
+	case c.flagDelete && c.flagPattern != "":
+		c.UI.Error("Cannot specify -pattern with -delete")
+		return 1
+	case c.flagPattern != "" && c.flagName == "":
+		c.UI.Error("Must specify a name for the pattern using -name")
+		return 1
+	case !c.flagDelete && c.flagName != "" && c.flagPattern == "":
+		c.UI.Error("Must specify the pattern to ban using -pattern")
+		return 1
+	}
+
+	wrapperPath := c.flagConfig
+	if c.flagConfigKms != "" {
+		wrapperPath = c.flagConfigKms
+	}
+	wrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if wrapper != nil {
+		c.configWrapper = wrapper
+		if err := wrapper.Init(c.Context); err != nil {
+			c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+			return 1
+		}
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	c.Config, err = config.LoadFile(c.flagConfig, wrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return 1
+	}
+	if c.Config.Controller == nil || c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return 1
+	}
+
+	c.srv = base.NewServer(&base.Command{UI: c.UI})
+	if err := c.srv.SetupLogging("", "", c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if err := c.srv.SetupKMSes(c.UI, c.Config); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if c.srv.RootKms == nil {
+		c.UI.Error("Root KMS not found after parsing KMS blocks")
+		return 1
+	}
+	dbaseUrl, err := config.ParseAddress(c.Config.Controller.Database.Url)
+	if err != nil && err != config.ErrNotAUrl {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return 1
+	}
+	c.srv.DatabaseUrl = strings.TrimSpace(dbaseUrl)
+	if err := c.srv.ConnectToDatabase("postgres"); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return 1
+	}
+
+	rw := db.New(c.srv.Database)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms repository: %w", err).Error())
+		return 1
+	}
+	kmsCache, err := kms.NewKms(kmsRepo, kms.WithLogger(c.srv.Logger.Named("kms")))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms cache: %w", err).Error())
+		return 1
+	}
+	if err := kmsCache.AddExternalWrappers(kms.WithRootWrapper(c.srv.RootKms)); err != nil {
+		c.UI.Error(fmt.Errorf("Error adding config keys to kms: %w", err).Error())
+		return 1
+	}
+	iamRepo, err := iam.NewRepository(rw, rw, kmsCache)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating iam repository: %w", err).Error())
+		return 1
+	}
+
+	switch {
+	case c.flagDelete:
+		deleted, err := iamRepo.DeleteBannedGrantPattern(c.Context, c.flagScopeId, c.flagName)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error deleting banned grant pattern: %w", err).Error())
+			return 1
+		}
+		if deleted == 0 {
+			c.UI.Error(fmt.Sprintf("Banned grant pattern %q not found in %s", c.flagName, c.flagScopeId))
+			return 1
+		}
+		c.UI.Output(fmt.Sprintf("Deleted banned grant pattern %q from %s.", c.flagName, c.flagScopeId))
+		return 0
+
+	case c.flagPattern != "":
+		p, err := iam.NewBannedGrantPattern(c.flagScopeId, c.flagName, c.flagPattern, iam.WithDescription(c.flagDescription))
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error creating banned grant pattern: %w", err).Error())
+			return 1
+		}
+		if _, err := iamRepo.CreateBannedGrantPattern(c.Context, p); err != nil {
+			c.UI.Error(fmt.Errorf("Error creating banned grant pattern: %w", err).Error())
+			return 1
+		}
+	}
+
+	patterns, err := iamRepo.ListBannedGrantPatterns(c.Context, c.flagScopeId)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error listing banned grant patterns: %w", err).Error())
+		return 1
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(patterns)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	case "table":
+		c.UI.Output(generateBannedGrantsTableOutput(c.flagScopeId, patterns))
+	}
+	return 0
+}
+
+func generateBannedGrantsTableOutput(scopeId string, patterns []*iam.BannedGrantPattern) string {
+	if len(patterns) == 0 {
+		return fmt.Sprintf("No grant patterns are banned in %s.", scopeId)
+	}
+	ret := []string{"", fmt.Sprintf("Banned grant patterns in %s:", scopeId)}
+	for _, p := range patterns {
+		ret = append(ret,
+			fmt.Sprintf("  Name:           %s", p.Name),
+			fmt.Sprintf("    Pattern:      %s", p.Pattern),
+		)
+		if p.Description != "" {
+			ret = append(ret, fmt.Sprintf("    Description:  %s", p.Description))
+		}
+	}
+	return base.WrapForHelpText(ret)
+}
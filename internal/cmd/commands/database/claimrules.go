@@ -0,0 +1,272 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*ClaimRulesCommand)(nil)
+var _ cli.CommandAutocomplete = (*ClaimRulesCommand)(nil)
+
+// ClaimRulesCommand previews, and optionally applies, an org's claim rules
+// for a user and a set of claims.
+type ClaimRulesCommand struct {
+	*base.Command
+	srv *base.Server
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+
+	flagConfig    string
+	flagConfigKms string
+	flagOrgId     string
+	flagUserId    string
+	flagClaims    []string
+	flagApply     bool
+}
+
+func (c *ClaimRulesCommand) Synopsis() string {
+	return "Preview the roles and groups an org's claim rules map a user into"
+}
+
+func (c *ClaimRulesCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database claim-rules [options]",
+		"",
+		"  Evaluate an org's claim rules against a set of claims for a user and",
+		"  report which roles and groups the user would be added to, or groups they",
+		"  would be removed from, at login:",
+		"",
+		`    $ boundary database claim-rules -config=/etc/boundary/controller.hcl -org-id=o_1234567890 -user-id=u_1234567890 -claim=department=payments`,
+		"",
+		"  Nothing is changed unless -apply is set.",
+	}) + c.Flags().Help()
+}
+
+func (c *ClaimRulesCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file.`,
+	})
+
+	f = set.NewFlagSet("Claim Rule Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "org-id",
+		Target: &c.flagOrgId,
+		Usage:  "The org whose claim rules are evaluated.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "user-id",
+		Target: &c.flagUserId,
+		Usage:  "The user the claims are presented for.",
+	})
+
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:   "claim",
+		Target: &c.flagClaims,
+		Usage:  `A claim in the form "name=value". May be specified multiple times, including for the same name.`,
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "apply",
+		Target: &c.flagApply,
+		Usage:  "If set, the user is added to the matched roles and groups, and removed from unmatched groups, instead of only previewing the changes.",
+	})
+
+	return set
+}
+
+func (c *ClaimRulesCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ClaimRulesCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ClaimRulesCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	switch {
+	case c.flagConfig == "":
+		c.UI.Error("Must specify a config file using -config")
+		return 1
+	case c.flagOrgId == "":
+		c.UI.Error("Must specify an org using -org-id")
+		return 1
+	case c.flagUserId == "":
+		c.UI.Error("Must specify a user using -user-id")
+		return 1
+	}
+	claims := make(map[string][]string, len(c.flagClaims))
+	for _, cl := range c.flagClaims {
+		kv := strings.SplitN(cl, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			c.UI.Error(fmt.Sprintf("Claim %q is not in the form name=value", cl))
+			return 1
+		}
+		claims[kv[0]] = append(claims[kv[0]], kv[1])
+	}
+
+	wrapperPath := c.flagConfig
+	if c.flagConfigKms != "" {
+		wrapperPath = c.flagConfigKms
+	}
+	wrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if wrapper != nil {
+		c.configWrapper = wrapper
+		if err := wrapper.Init(c.Context); err != nil {
+			c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+			return 1
+		}
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	c.Config, err = config.LoadFile(c.flagConfig, wrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return 1
+	}
+	if c.Config.Controller == nil || c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return 1
+	}
+
+	c.srv = base.NewServer(&base.Command{UI: c.UI})
+	if err := c.srv.SetupLogging("", "", c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if err := c.srv.SetupKMSes(c.UI, c.Config); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if c.srv.RootKms == nil {
+		c.UI.Error("Root KMS not found after parsing KMS blocks")
+		return 1
+	}
+	dbaseUrl, err := config.ParseAddress(c.Config.Controller.Database.Url)
+	if err != nil && err != config.ErrNotAUrl {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return 1
+	}
+	c.srv.DatabaseUrl = strings.TrimSpace(dbaseUrl)
+	if err := c.srv.ConnectToDatabase("postgres"); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return 1
+	}
+
+	rw := db.New(c.srv.Database)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms repository: %w", err).Error())
+		return 1
+	}
+	kmsCache, err := kms.NewKms(kmsRepo, kms.WithLogger(c.srv.Logger.Named("kms")))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms cache: %w", err).Error())
+		return 1
+	}
+	if err := kmsCache.AddExternalWrappers(kms.WithRootWrapper(c.srv.RootKms)); err != nil {
+		c.UI.Error(fmt.Errorf("Error adding config keys to kms: %w", err).Error())
+		return 1
+	}
+	iamRepo, err := iam.NewRepository(rw, rw, kmsCache)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating iam repository: %w", err).Error())
+		return 1
+	}
+
+	matches, err := iamRepo.ApplyClaimRules(c.Context, c.flagOrgId, c.flagUserId, claims, iam.WithDryRun(!c.flagApply))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error evaluating claim rules: %w", err).Error())
+		return 1
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(matches)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	case "table":
+		c.UI.Output(generateClaimRulesTableOutput(matches, c.flagApply))
+	}
+	return 0
+}
+
+func generateClaimRulesTableOutput(matches []*iam.ClaimRuleMatch, applied bool) string {
+	if len(matches) == 0 {
+		return "No claim rules matched."
+	}
+	ret := []string{"", "Matched claim rules:"}
+	for _, m := range matches {
+		var result string
+		switch {
+		case m.Unmatched && m.Applied:
+			result = "removed"
+		case m.Unmatched:
+			result = "would be removed"
+		case m.AlreadyPrincipal && m.GroupId != "":
+			result = "already a member"
+		case m.AlreadyPrincipal:
+			result = "already a principal"
+		case m.Applied:
+			result = "added"
+		case !applied:
+			result = "would be added"
+		}
+		ret = append(ret, fmt.Sprintf("  Rule ID:  %s", m.RuleId))
+		if m.GroupId != "" {
+			ret = append(ret, fmt.Sprintf("    Group ID: %s", m.GroupId))
+		} else {
+			ret = append(ret, fmt.Sprintf("    Role ID:  %s", m.RoleId))
+		}
+		ret = append(ret, fmt.Sprintf("    Result:   %s", result))
+	}
+	return base.WrapForHelpText(ret)
+}
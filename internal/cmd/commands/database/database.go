@@ -27,6 +27,34 @@ func (c *Command) Help() string {
 		"",
 		`      $ boundary database init`,
 		"",
+		"    Upgrade an initialized database to the latest schema:",
+		"",
+		`      $ boundary database migrate`,
+		"",
+		"    Preview the roles and groups an org's claim rules map a user into:",
+		"",
+		`      $ boundary database claim-rules -org-id=o_1234567890 -user-id=u_1234567890 -claim=department=payments`,
+		"",
+		"    Show what a resource references and what references it:",
+		"",
+		`      $ boundary database references -id=hsst_1234567890`,
+		"",
+		"    Ban grants matching a pattern in an org and its projects:",
+		"",
+		`      $ boundary database banned-grants -scope-id=o_1234567890 -name=no-wildcards -pattern="id=*;actions=*"`,
+		"",
+		"    Restore a role archived after it expired:",
+		"",
+		`      $ boundary database role-expiration -scope-id=o_1234567890 -role-id=r_1234567890 -restore`,
+		"",
+		"    Show how a role changed during January:",
+		"",
+		`      $ boundary database role-diff -role-id=r_1234567890 -from=2021-01-01T00:00:00Z -to=2021-02-01T00:00:00Z`,
+		"",
+		"    Activate a break glass role in an emergency:",
+		"",
+		`      $ boundary database emergency-roles -role-id=r_1234567890 -activate -user-id=u_1234567890 -justification="INC-1234 database outage"`,
+		"",
 		"  Please see the database subcommand help for detailed usage information.",
 	})
 }
@@ -0,0 +1,304 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*EmergencyRolesCommand)(nil)
+var _ cli.CommandAutocomplete = (*EmergencyRolesCommand)(nil)
+
+// EmergencyRolesCommand sets up emergency roles, reverts their activations
+// and lists them. Roles are activated through the API, by an authenticated
+// principal of the role.
+type EmergencyRolesCommand struct {
+	*base.Command
+	srv *base.Server
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+
+	flagConfig      string
+	flagConfigKms   string
+	flagRoleId      string
+	flagMaxDuration string
+	flagUnset       bool
+	flagRevert      bool
+}
+
+func (c *EmergencyRolesCommand) Synopsis() string {
+	return "Manage break glass roles which only apply while activated"
+}
+
+func (c *EmergencyRolesCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database emergency-roles [options]",
+		"",
+		"  List the activations of an emergency role:",
+		"",
+		`    $ boundary database emergency-roles -config=/etc/boundary/controller.hcl -role-id=r_1234567890`,
+		"",
+		"  Make a role an emergency role, which is inactive until one of its",
+		"  principals activates it, and set the longest an activation can last:",
+		"",
+		`    $ boundary database emergency-roles -config=/etc/boundary/controller.hcl -role-id=r_1234567890 -max-duration=1h`,
+		"",
+		"  A principal of the role activates it for themselves with",
+		`  "boundary roles activate-emergency". Activations revert automatically`,
+		"  when they expire, or can be reverted early:",
+		"",
+		`    $ boundary database emergency-roles -config=/etc/boundary/controller.hcl -role-id=r_1234567890 -revert`,
+	}) + c.Flags().Help()
+}
+
+func (c *EmergencyRolesCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file.`,
+	})
+
+	f = set.NewFlagSet("Emergency Role Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "role-id",
+		Target: &c.flagRoleId,
+		Usage:  "The id of the emergency role.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "max-duration",
+		Target: &c.flagMaxDuration,
+		Usage:  `If set, the role is made an emergency role whose activations last at most this long, like "1h".`,
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "unset",
+		Target: &c.flagUnset,
+		Usage:  "If set, the role is made an ordinary role again.",
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "revert",
+		Target: &c.flagRevert,
+		Usage:  "If set, the role's activation is reverted before it expires.",
+	})
+
+	return set
+}
+
+func (c *EmergencyRolesCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *EmergencyRolesCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *EmergencyRolesCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	modes := 0
+	for _, set := range []bool{c.flagMaxDuration != "", c.flagUnset, c.flagRevert} {
+		if set {
+			modes++
+		}
+	}
+	switch {
+	case c.flagConfig == "":
+		c.UI.Error("Must specify a config file using -config")
+		return 1
+	case c.flagRoleId == "":
+		c.UI.Error("Must specify a role using -role-id")
+		return 1
+	case modes > 1:
+		c.UI.Error("Can only specify one of -max-duration, -unset and -revert")
+		return 1
+	}
+	var maxDuration time.Duration
+	var err error
+	if c.flagMaxDuration != "" {
+		if maxDuration, err = time.ParseDuration(c.flagMaxDuration); err != nil {
+			c.UI.Error(fmt.Errorf("Error parsing max duration: %w", err).Error())
+			return 1
+		}
+	}
+
+	wrapperPath := c.flagConfig
+	if c.flagConfigKms != "" {
+		wrapperPath = c.flagConfigKms
+	}
+	wrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if wrapper != nil {
+		c.configWrapper = wrapper
+		if err := wrapper.Init(c.Context); err != nil {
+			c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+			return 1
+		}
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	c.Config, err = config.LoadFile(c.flagConfig, wrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return 1
+	}
+	if c.Config.Controller == nil || c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return 1
+	}
+
+	c.srv = base.NewServer(&base.Command{UI: c.UI})
+	if err := c.srv.SetupLogging("", "", c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if err := c.srv.SetupKMSes(c.UI, c.Config); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if c.srv.RootKms == nil {
+		c.UI.Error("Root KMS not found after parsing KMS blocks")
+		return 1
+	}
+	dbaseUrl, err := config.ParseAddress(c.Config.Controller.Database.Url)
+	if err != nil && err != config.ErrNotAUrl {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return 1
+	}
+	c.srv.DatabaseUrl = strings.TrimSpace(dbaseUrl)
+	if err := c.srv.ConnectToDatabase("postgres"); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return 1
+	}
+
+	rw := db.New(c.srv.Database)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms repository: %w", err).Error())
+		return 1
+	}
+	kmsCache, err := kms.NewKms(kmsRepo, kms.WithLogger(c.srv.Logger.Named("kms")))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms cache: %w", err).Error())
+		return 1
+	}
+	if err := kmsCache.AddExternalWrappers(kms.WithRootWrapper(c.srv.RootKms)); err != nil {
+		c.UI.Error(fmt.Errorf("Error adding config keys to kms: %w", err).Error())
+		return 1
+	}
+	iamRepo, err := iam.NewRepository(rw, rw, kmsCache)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating iam repository: %w", err).Error())
+		return 1
+	}
+
+	switch {
+	case c.flagMaxDuration != "":
+		if err := iamRepo.SetEmergencyRole(c.Context, c.flagRoleId, maxDuration); err != nil {
+			c.UI.Error(fmt.Errorf("Error setting emergency role: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(fmt.Sprintf("Role %s is an emergency role whose activations last at most %s.", c.flagRoleId, maxDuration))
+		return 0
+
+	case c.flagUnset:
+		unset, err := iamRepo.UnsetEmergencyRole(c.Context, c.flagRoleId)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error unsetting emergency role: %w", err).Error())
+			return 1
+		}
+		if unset == 0 {
+			c.UI.Error(fmt.Sprintf("Role %s is not an emergency role", c.flagRoleId))
+			return 1
+		}
+		c.UI.Output(fmt.Sprintf("Role %s is no longer an emergency role.", c.flagRoleId))
+		return 0
+
+	case c.flagRevert:
+		if _, err := iamRepo.RevertEmergencyRole(c.Context, c.flagRoleId); err != nil {
+			c.UI.Error(fmt.Errorf("Error reverting emergency role: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(fmt.Sprintf("Reverted the activation of role %s.", c.flagRoleId))
+		return 0
+	}
+
+	activations, err := iamRepo.ListEmergencyActivations(c.Context, c.flagRoleId)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error listing emergency role activations: %w", err).Error())
+		return 1
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(activations)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	case "table":
+		c.UI.Output(generateEmergencyRolesTableOutput(c.flagRoleId, activations))
+	}
+	return 0
+}
+
+func generateEmergencyRolesTableOutput(roleId string, activations []*iam.EmergencyActivation) string {
+	if len(activations) == 0 {
+		return fmt.Sprintf("Role %s has never been activated.", roleId)
+	}
+	ret := []string{"", fmt.Sprintf("Activations of role %s:", roleId)}
+	for _, a := range activations {
+		ret = append(ret,
+			fmt.Sprintf("  Activated:        %s", base.FormatTime(a.ActivateTime)),
+			fmt.Sprintf("    User ID:        %s", a.UserId),
+			fmt.Sprintf("    Justification:  %s", a.Justification),
+			fmt.Sprintf("    Expires:        %s", base.FormatTime(a.ExpirationTime)),
+		)
+		if a.RevertTime != nil {
+			ret = append(ret, fmt.Sprintf("    Reverted:       %s", base.FormatTime(*a.RevertTime)))
+		}
+	}
+	return base.WrapForHelpText(ret)
+}
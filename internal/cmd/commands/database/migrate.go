@@ -0,0 +1,246 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db/migrations"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*MigrateCommand)(nil)
+var _ cli.CommandAutocomplete = (*MigrateCommand)(nil)
+
+// MigrateCommand reports on, upgrades, or rolls back the schema of an
+// initialized database.
+type MigrateCommand struct {
+	*base.Command
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+
+	flagConfig       string
+	flagConfigKms    string
+	flagMigrationUrl string
+	flagStatus       bool
+	flagDown         int
+}
+
+func (c *MigrateCommand) Synopsis() string {
+	return "Upgrade or roll back Boundary's database schema"
+}
+
+func (c *MigrateCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database migrate [options]",
+		"",
+		"  Apply any pending schema migrations to Boundary's database:",
+		"",
+		`    $ boundary database migrate -config=/etc/boundary/controller.hcl`,
+		"",
+		"  Report the schema version without changing anything:",
+		"",
+		`    $ boundary database migrate -config=/etc/boundary/controller.hcl -status`,
+		"",
+		"  Roll back the last migration:",
+		"",
+		`    $ boundary database migrate -config=/etc/boundary/controller.hcl -down=1`,
+		"",
+		"  Migrations are not run against a database left dirty by a failed",
+		"  migration; its schema must be repaired by hand first.",
+	}) + c.Flags().Help()
+}
+
+func (c *MigrateCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "migration-url",
+		Target: &c.flagMigrationUrl,
+		Usage:  `If set, overrides a migration URL set in config, and specifies the URL used to connect to the database for migrations. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
+	})
+
+	f = set.NewFlagSet("Migration Options")
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "status",
+		Target: &c.flagStatus,
+		Usage:  "If set, the schema version and pending migrations are reported and nothing is changed.",
+	})
+
+	f.IntVar(&base.IntVar{
+		Name:   "down",
+		Target: &c.flagDown,
+		Usage:  "If set, the given number of the most recently applied migrations are rolled back instead of applying pending migrations.",
+	})
+
+	return set
+}
+
+func (c *MigrateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *MigrateCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *MigrateCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	switch {
+	case c.flagConfig == "":
+		c.UI.Error("Must specify a config file using -config")
+		return 1
+	case c.flagDown < 0:
+		c.UI.Error("-down must be at least 1")
+		return 1
+	case c.flagStatus && c.flagDown > 0:
+		c.UI.Error("-status and -down cannot both be set")
+		return 1
+	}
+
+	wrapperPath := c.flagConfig
+	if c.flagConfigKms != "" {
+		wrapperPath = c.flagConfigKms
+	}
+	wrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if wrapper != nil {
+		c.configWrapper = wrapper
+		if err := wrapper.Init(c.Context); err != nil {
+			c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+			return 1
+		}
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	c.Config, err = config.LoadFile(c.flagConfig, wrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return 1
+	}
+	if c.Config.Controller == nil || c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return 1
+	}
+
+	migrationUrlToParse := c.Config.Controller.Database.MigrationUrl
+	if c.flagMigrationUrl != "" {
+		migrationUrlToParse = c.flagMigrationUrl
+	}
+	// Fallback to using database URL for everything
+	if migrationUrlToParse == "" {
+		migrationUrlToParse = c.Config.Controller.Database.Url
+	}
+	if migrationUrlToParse == "" {
+		c.UI.Error(`"url" not specified in "database" config block"`)
+		return 1
+	}
+	migrationUrl, err := config.ParseAddress(migrationUrlToParse)
+	if err != nil && err != config.ErrNotAUrl {
+		c.UI.Error(fmt.Errorf("Error parsing migration url: %w", err).Error())
+		return 1
+	}
+	migrationUrl = strings.TrimSpace(migrationUrl)
+
+	switch {
+	case c.flagDown > 0:
+		if err := migrations.MigrateDown("postgres", migrationUrl, c.flagDown); err != nil {
+			c.UI.Error(migrateErrorText("Error rolling back database migrations", err))
+			return 1
+		}
+	case !c.flagStatus:
+		if _, err := migrations.MigrateUp("postgres", migrationUrl); err != nil {
+			c.UI.Error(migrateErrorText("Error running database migrations", err))
+			return 1
+		}
+	}
+
+	state, err := migrations.Status("postgres", migrationUrl)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error reading database migration status: %w", err).Error())
+		return 1
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(state)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	case "table":
+		c.UI.Output(generateMigrateTableOutput(state))
+	}
+	if state.Dirty {
+		return 2
+	}
+	return 0
+}
+
+func migrateErrorText(prefix string, err error) string {
+	if errors.Is(err, migrations.ErrDirty) {
+		return fmt.Sprintf("%s: the database is dirty from a failed migration and must be repaired by hand", prefix)
+	}
+	return fmt.Errorf("%s: %w", prefix, err).Error()
+}
+
+func generateMigrateTableOutput(state *migrations.State) string {
+	pending := "none"
+	if len(state.Pending) > 0 {
+		versions := make([]string, 0, len(state.Pending))
+		for _, v := range state.Pending {
+			versions = append(versions, fmt.Sprintf("%d", v))
+		}
+		pending = strings.Join(versions, ", ")
+	}
+	ret := []string{
+		"",
+		"Database schema:",
+		fmt.Sprintf("  Version:   %d", state.Version),
+		fmt.Sprintf("  Latest:    %d", state.Latest),
+		fmt.Sprintf("  Dirty:     %t", state.Dirty),
+		fmt.Sprintf("  Pending:   %s", pending),
+	}
+	return base.WrapForHelpText(ret)
+}
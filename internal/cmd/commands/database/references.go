@@ -0,0 +1,216 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/references"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*ReferencesCommand)(nil)
+var _ cli.CommandAutocomplete = (*ReferencesCommand)(nil)
+
+// ReferencesCommand reports what a resource references and what references
+// it.
+type ReferencesCommand struct {
+	*base.Command
+	srv *base.Server
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+
+	flagConfig    string
+	flagConfigKms string
+	flagId        string
+}
+
+func (c *ReferencesCommand) Synopsis() string {
+	return "Show what a resource references and what references it"
+}
+
+func (c *ReferencesCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database references [options]",
+		"",
+		"  Show the resources a resource references, like a target's host sets or a",
+		"  role's principals and the resources its grants name, and the resources",
+		"  which reference it, like the targets using a host set:",
+		"",
+		`    $ boundary database references -config=/etc/boundary/controller.hcl -id=hsst_1234567890`,
+		"",
+		"  Deleting a resource deletes or invalidates what references it.",
+	}) + c.Flags().Help()
+}
+
+func (c *ReferencesCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file.`,
+	})
+
+	f = set.NewFlagSet("References Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "id",
+		Target: &c.flagId,
+		Usage:  "The ID of the resource to show the references of.",
+	})
+
+	return set
+}
+
+func (c *ReferencesCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ReferencesCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ReferencesCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	switch {
+	case c.flagConfig == "":
+		c.UI.Error("Must specify a config file using -config")
+		return 1
+	case c.flagId == "":
+		c.UI.Error("Must specify a resource using -id")
+		return 1
+	}
+
+	wrapperPath := c.flagConfig
+	if c.flagConfigKms != "" {
+		wrapperPath = c.flagConfigKms
+	}
+	wrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if wrapper != nil {
+		c.configWrapper = wrapper
+		if err := wrapper.Init(c.Context); err != nil {
+			c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+			return 1
+		}
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	c.Config, err = config.LoadFile(c.flagConfig, wrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return 1
+	}
+	if c.Config.Controller == nil || c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return 1
+	}
+
+	c.srv = base.NewServer(&base.Command{UI: c.UI})
+	if err := c.srv.SetupLogging("", "", c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	dbaseUrl, err := config.ParseAddress(c.Config.Controller.Database.Url)
+	if err != nil && err != config.ErrNotAUrl {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return 1
+	}
+	c.srv.DatabaseUrl = strings.TrimSpace(dbaseUrl)
+	if err := c.srv.ConnectToDatabase("postgres"); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return 1
+	}
+
+	repo, err := references.NewRepository(db.New(c.srv.Database))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating references repository: %w", err).Error())
+		return 1
+	}
+	node, err := repo.Lookup(c.Context, c.flagId)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error looking up references: %w", err).Error())
+		return 1
+	}
+	if node == nil {
+		c.UI.Error(fmt.Sprintf("Resource %q not found", c.flagId))
+		return 1
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(node)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	case "table":
+		c.UI.Output(generateReferencesTableOutput(node))
+	}
+	return 0
+}
+
+func generateReferencesTableOutput(n *references.Node) string {
+	ret := []string{
+		"",
+		"Resource:",
+		fmt.Sprintf("  ID:    %s", n.Id),
+		fmt.Sprintf("  Type:  %s", n.TypeName),
+	}
+	for _, list := range []struct {
+		title string
+		edges []*references.Edge
+	}{
+		{"References:", n.References},
+		{"Referenced By:", n.ReferencedBy},
+	} {
+		ret = append(ret, "", list.title)
+		if len(list.edges) == 0 {
+			ret = append(ret, "  None")
+			continue
+		}
+		for _, e := range list.edges {
+			ret = append(ret,
+				fmt.Sprintf("  ID:          %s", e.Id),
+				fmt.Sprintf("    Type:      %s", e.TypeName),
+				fmt.Sprintf("    Relation:  %s", e.Relation),
+			)
+		}
+	}
+	return base.WrapForHelpText(ret)
+}
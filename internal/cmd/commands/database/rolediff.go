@@ -0,0 +1,274 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*RoleDiffCommand)(nil)
+var _ cli.CommandAutocomplete = (*RoleDiffCommand)(nil)
+
+// RoleDiffCommand shows the differences in fields, grants and principals
+// between two roles, or of one role between two points in time.
+type RoleDiffCommand struct {
+	*base.Command
+	srv *base.Server
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+
+	flagConfig      string
+	flagConfigKms   string
+	flagRoleId      string
+	flagOtherRoleId string
+	flagFrom        string
+	flagTo          string
+}
+
+func (c *RoleDiffCommand) Synopsis() string {
+	return "Show how two roles differ, or how a role changed over time"
+}
+
+func (c *RoleDiffCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database role-diff [options]",
+		"",
+		"  Show what would have to change to turn one role into another:",
+		"",
+		`    $ boundary database role-diff -config=/etc/boundary/controller.hcl -role-id=r_1234567890 -other-role-id=r_0987654321`,
+		"",
+		"  Show how a role changed between two times, from its history in the oplog.",
+		"  If -to isn't set, the role is compared up to now:",
+		"",
+		`    $ boundary database role-diff -config=/etc/boundary/controller.hcl -role-id=r_1234567890 -from=2021-01-01T00:00:00Z -to=2021-02-01T00:00:00Z`,
+	}) + c.Flags().Help()
+}
+
+func (c *RoleDiffCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file.`,
+	})
+
+	f = set.NewFlagSet("Role Diff Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "role-id",
+		Target: &c.flagRoleId,
+		Usage:  "The id of the role.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "other-role-id",
+		Target: &c.flagOtherRoleId,
+		Usage:  "The id of a role to compare the role with.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "from",
+		Target: &c.flagFrom,
+		Usage:  "The time to compare the role from, in RFC 3339 format.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "to",
+		Target: &c.flagTo,
+		Usage:  "The time to compare the role to, in RFC 3339 format. Defaults to now.",
+	})
+
+	return set
+}
+
+func (c *RoleDiffCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RoleDiffCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RoleDiffCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	switch {
+	case c.flagConfig == "":
+		c.UI.Error("Must specify a config file using -config")
+		return 1
+	case c.flagRoleId == "":
+		c.UI.Error("Must specify a role using -role-id")
+		return 1
+	case c.flagOtherRoleId != "" && (c.flagFrom != "" || c.flagTo != ""):
+		c.UI.Error("Cannot specify -from or -to with -other-role-id")
+		return 1
+	case c.flagOtherRoleId == "" && c.flagFrom == "":
+		c.UI.Error("Must specify a role to compare with using -other-role-id, or a time to compare from using -from")
+		return 1
+	}
+	var from, to time.Time
+	var err error
+	if c.flagFrom != "" {
+		if from, err = time.Parse(time.RFC3339, c.flagFrom); err != nil {
+			c.UI.Error(fmt.Errorf("Error parsing from time: %w", err).Error())
+			return 1
+		}
+		to = time.Now()
+		if c.flagTo != "" {
+			if to, err = time.Parse(time.RFC3339, c.flagTo); err != nil {
+				c.UI.Error(fmt.Errorf("Error parsing to time: %w", err).Error())
+				return 1
+			}
+		}
+	}
+
+	wrapperPath := c.flagConfig
+	if c.flagConfigKms != "" {
+		wrapperPath = c.flagConfigKms
+	}
+	wrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if wrapper != nil {
+		c.configWrapper = wrapper
+		if err := wrapper.Init(c.Context); err != nil {
+			c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+			return 1
+		}
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	c.Config, err = config.LoadFile(c.flagConfig, wrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return 1
+	}
+	if c.Config.Controller == nil || c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return 1
+	}
+
+	c.srv = base.NewServer(&base.Command{UI: c.UI})
+	if err := c.srv.SetupLogging("", "", c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if err := c.srv.SetupKMSes(c.UI, c.Config); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if c.srv.RootKms == nil {
+		c.UI.Error("Root KMS not found after parsing KMS blocks")
+		return 1
+	}
+	dbaseUrl, err := config.ParseAddress(c.Config.Controller.Database.Url)
+	if err != nil && err != config.ErrNotAUrl {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return 1
+	}
+	c.srv.DatabaseUrl = strings.TrimSpace(dbaseUrl)
+	if err := c.srv.ConnectToDatabase("postgres"); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return 1
+	}
+
+	rw := db.New(c.srv.Database)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms repository: %w", err).Error())
+		return 1
+	}
+	kmsCache, err := kms.NewKms(kmsRepo, kms.WithLogger(c.srv.Logger.Named("kms")))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms cache: %w", err).Error())
+		return 1
+	}
+	if err := kmsCache.AddExternalWrappers(kms.WithRootWrapper(c.srv.RootKms)); err != nil {
+		c.UI.Error(fmt.Errorf("Error adding config keys to kms: %w", err).Error())
+		return 1
+	}
+	iamRepo, err := iam.NewRepository(rw, rw, kmsCache)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating iam repository: %w", err).Error())
+		return 1
+	}
+
+	var diff *iam.RoleDiff
+	if c.flagOtherRoleId != "" {
+		diff, err = iamRepo.DiffRoles(c.Context, c.flagRoleId, c.flagOtherRoleId)
+	} else {
+		diff, err = iamRepo.RoleDiffBetween(c.Context, c.flagRoleId, from, to)
+	}
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error computing role diff: %w", err).Error())
+		return 1
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(diff)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	case "table":
+		c.UI.Output(generateRoleDiffTableOutput(diff))
+	}
+	return 0
+}
+
+func generateRoleDiffTableOutput(diff *iam.RoleDiff) string {
+	subject := fmt.Sprintf("Changes to role %s", diff.RoleId)
+	switch {
+	case diff.FromRoleId != "":
+		subject = fmt.Sprintf("Changes from role %s to role %s", diff.FromRoleId, diff.RoleId)
+	case diff.FromTime != nil && diff.ToTime != nil:
+		subject = fmt.Sprintf("Changes to role %s from %s to %s", diff.RoleId, base.FormatTime(*diff.FromTime), base.FormatTime(*diff.ToTime))
+	}
+	if diff.Empty() {
+		return subject + ": none."
+	}
+	ret := []string{"", subject + ":"}
+	for _, line := range diff.Summary() {
+		ret = append(ret, "  "+line)
+	}
+	return base.WrapForHelpText(ret)
+}
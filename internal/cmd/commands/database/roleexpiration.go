@@ -0,0 +1,318 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*RoleExpirationCommand)(nil)
+var _ cli.CommandAutocomplete = (*RoleExpirationCommand)(nil)
+
+// RoleExpirationCommand sets the expiration time of roles, and lists and
+// restores the roles of a scope which have been archived after expiring.
+type RoleExpirationCommand struct {
+	*base.Command
+	srv *base.Server
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+
+	flagConfig         string
+	flagConfigKms      string
+	flagScopeId        string
+	flagRoleId         string
+	flagExpirationTime string
+	flagRestore        bool
+}
+
+func (c *RoleExpirationCommand) Synopsis() string {
+	return "Set when roles expire, and list and restore the roles archived after expiring"
+}
+
+func (c *RoleExpirationCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database role-expiration [options]",
+		"",
+		"  List the archived roles of a scope. Roles are archived after their",
+		"  expiration time passes, and can be restored until they've been archived",
+		"  for the retention window, after which they're deleted:",
+		"",
+		`    $ boundary database role-expiration -config=/etc/boundary/controller.hcl -scope-id=o_1234567890`,
+		"",
+		`  Set the time a role expires, or use "none" to stop it expiring:`,
+		"",
+		`    $ boundary database role-expiration -config=/etc/boundary/controller.hcl -scope-id=o_1234567890 -role-id=r_1234567890 -expiration-time=2021-06-30T00:00:00Z`,
+		"",
+		"  Restore an archived role, optionally giving it a new expiration time:",
+		"",
+		`    $ boundary database role-expiration -config=/etc/boundary/controller.hcl -scope-id=o_1234567890 -role-id=r_1234567890 -restore`,
+	}) + c.Flags().Help()
+}
+
+func (c *RoleExpirationCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file.`,
+	})
+
+	f = set.NewFlagSet("Role Expiration Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "scope-id",
+		Target: &c.flagScopeId,
+		Usage:  `The scope of the roles, like "global" or an org.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "role-id",
+		Target: &c.flagRoleId,
+		Usage:  "The id of the role to set the expiration time of or restore.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "expiration-time",
+		Target: &c.flagExpirationTime,
+		Usage:  `The time the role expires, in RFC 3339 format, or "none" for it to not expire.`,
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "restore",
+		Target: &c.flagRestore,
+		Usage:  "If set, the archived role is restored. It doesn't expire unless -expiration-time is also set.",
+	})
+
+	return set
+}
+
+func (c *RoleExpirationCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RoleExpirationCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RoleExpirationCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	switch {
+	case c.flagConfig == "":
+		c.UI.Error("Must specify a config file using -config")
+		return 1
+	case c.flagScopeId == "":
+		c.UI.Error("Must specify a scope using -scope-id")
+		return 1
+	case c.flagRoleId == "" && (c.flagRestore || c.flagExpirationTime != ""):
+		c.UI.Error("Must specify a role using -role-id")
+		return 1
+	case c.flagRoleId != "" && !c.flagRestore && c.flagExpirationTime == "":
+		c.UI.Error("Must specify -expiration-time or -restore with -role-id")
+		return 1
+	case c.flagRestore && c.flagExpirationTime == "none":
+		c.UI.Error(`Cannot specify an expiration time of "none" with -restore`)
+		return 1
+	}
+	var expiration time.Time
+	if c.flagExpirationTime != "" && c.flagExpirationTime != "none" {
+		var err error
+		if expiration, err = time.Parse(time.RFC3339, c.flagExpirationTime); err != nil {
+			c.UI.Error(fmt.Errorf("Error parsing expiration time: %w", err).Error())
+			return 1
+		}
+	}
+
+	wrapperPath := c.flagConfig
+	if c.flagConfigKms != "" {
+		wrapperPath = c.flagConfigKms
+	}
+	wrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if wrapper != nil {
+		c.configWrapper = wrapper
+		if err := wrapper.Init(c.Context); err != nil {
+			c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+			return 1
+		}
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	c.Config, err = config.LoadFile(c.flagConfig, wrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return 1
+	}
+	if c.Config.Controller == nil || c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return 1
+	}
+
+	c.srv = base.NewServer(&base.Command{UI: c.UI})
+	if err := c.srv.SetupLogging("", "", c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if err := c.srv.SetupKMSes(c.UI, c.Config); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if c.srv.RootKms == nil {
+		c.UI.Error("Root KMS not found after parsing KMS blocks")
+		return 1
+	}
+	dbaseUrl, err := config.ParseAddress(c.Config.Controller.Database.Url)
+	if err != nil && err != config.ErrNotAUrl {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return 1
+	}
+	c.srv.DatabaseUrl = strings.TrimSpace(dbaseUrl)
+	if err := c.srv.ConnectToDatabase("postgres"); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return 1
+	}
+
+	rw := db.New(c.srv.Database)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms repository: %w", err).Error())
+		return 1
+	}
+	kmsCache, err := kms.NewKms(kmsRepo, kms.WithLogger(c.srv.Logger.Named("kms")))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms cache: %w", err).Error())
+		return 1
+	}
+	if err := kmsCache.AddExternalWrappers(kms.WithRootWrapper(c.srv.RootKms)); err != nil {
+		c.UI.Error(fmt.Errorf("Error adding config keys to kms: %w", err).Error())
+		return 1
+	}
+	iamRepo, err := iam.NewRepository(rw, rw, kmsCache)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating iam repository: %w", err).Error())
+		return 1
+	}
+
+	if c.flagRoleId != "" {
+		role, _, _, err := iamRepo.LookupRole(c.Context, c.flagRoleId)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error looking up role: %w", err).Error())
+			return 1
+		}
+		if role == nil || role.ScopeId != c.flagScopeId {
+			c.UI.Error(fmt.Sprintf("Role %s not found in %s", c.flagRoleId, c.flagScopeId))
+			return 1
+		}
+		switch {
+		case c.flagRestore:
+			var opts []iam.Option
+			if !expiration.IsZero() {
+				opts = append(opts, iam.WithExpirationTime(expiration))
+			}
+			if _, _, err := iamRepo.RestoreRole(c.Context, role.PublicId, role.Version, opts...); err != nil {
+				c.UI.Error(fmt.Errorf("Error restoring role: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(fmt.Sprintf("Restored role %s.", role.PublicId))
+		default:
+			update, err := iam.NewRole(role.ScopeId, iam.WithExpirationTime(expiration))
+			if err != nil {
+				c.UI.Error(err.Error())
+				return 1
+			}
+			update.PublicId = role.PublicId
+			if _, _, _, _, err := iamRepo.UpdateRole(c.Context, update, role.Version, []string{"ExpirationTime"}); err != nil {
+				c.UI.Error(fmt.Errorf("Error setting role expiration time: %w", err).Error())
+				return 1
+			}
+			if expiration.IsZero() {
+				c.UI.Output(fmt.Sprintf("Role %s no longer expires.", role.PublicId))
+			} else {
+				c.UI.Output(fmt.Sprintf("Role %s expires at %s.", role.PublicId, base.FormatTime(expiration)))
+			}
+		}
+	}
+
+	roles, err := iamRepo.ListRoles(c.Context, c.flagScopeId, iam.WithArchived(true), iam.WithLimit(-1))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error listing roles: %w", err).Error())
+		return 1
+	}
+	var archived []*iam.Role
+	for _, r := range roles {
+		if r.ArchiveTime != nil {
+			archived = append(archived, r)
+		}
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(archived)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	case "table":
+		c.UI.Output(generateRoleExpirationTableOutput(c.flagScopeId, archived))
+	}
+	return 0
+}
+
+func generateRoleExpirationTableOutput(scopeId string, roles []*iam.Role) string {
+	if len(roles) == 0 {
+		return fmt.Sprintf("No roles are archived in %s.", scopeId)
+	}
+	ret := []string{"", fmt.Sprintf("Archived roles in %s:", scopeId)}
+	for _, r := range roles {
+		archiveTime := r.ArchiveTime.Timestamp.AsTime()
+		ret = append(ret, fmt.Sprintf("  ID:                   %s", r.PublicId))
+		if r.Name != "" {
+			ret = append(ret, fmt.Sprintf("    Name:               %s", r.Name))
+		}
+		ret = append(ret,
+			fmt.Sprintf("    Expired:            %s", base.FormatTime(r.ExpirationTime.Timestamp.AsTime())),
+			fmt.Sprintf("    Archived:           %s", base.FormatTime(archiveTime)),
+			fmt.Sprintf("    Restorable Until:   %s", base.FormatTime(archiveTime.Add(iam.RoleArchiveRetention))),
+		)
+	}
+	return base.WrapForHelpText(ret)
+}
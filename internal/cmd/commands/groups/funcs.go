@@ -3,7 +3,6 @@ package groups
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/boundary/api/groups"
 	"github.com/hashicorp/boundary/internal/cmd/base"
@@ -80,8 +79,8 @@ func generateGroupTableOutput(in *groups.Group) string {
 	nonAttributeMap := map[string]interface{}{
 		"ID":           in.Id,
 		"Version":      in.Version,
-		"Created Time": in.CreatedTime.Local().Format(time.RFC1123),
-		"Updated Time": in.UpdatedTime.Local().Format(time.RFC1123),
+		"Created Time": base.FormatTime(in.CreatedTime),
+		"Updated Time": base.FormatTime(in.UpdatedTime),
 	}
 
 	if in.Name != "" {
@@ -1,8 +1,6 @@
 package hostcatalogs
 
 import (
-	"time"
-
 	"github.com/hashicorp/boundary/api/hostcatalogs"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 )
@@ -12,8 +10,8 @@ func generateHostCatalogTableOutput(in *hostcatalogs.HostCatalog) string {
 		"ID":           in.Id,
 		"Version":      in.Version,
 		"Type":         in.Type,
-		"Created Time": in.CreatedTime.Local().Format(time.RFC1123),
-		"Updated Time": in.UpdatedTime.Local().Format(time.RFC1123),
+		"Created Time": base.FormatTime(in.CreatedTime),
+		"Updated Time": base.FormatTime(in.UpdatedTime),
 	}
 
 	if in.Name != "" {
@@ -1,7 +1,7 @@
 package hosts
 
 import (
-	"time"
+	"fmt"
 
 	"github.com/hashicorp/boundary/api/hosts"
 	"github.com/hashicorp/boundary/internal/cmd/base"
@@ -12,8 +12,8 @@ func generateHostTableOutput(in *hosts.Host) string {
 		"ID":              in.Id,
 		"Version":         in.Version,
 		"Type":            in.Type,
-		"Created Time":    in.CreatedTime.Local().Format(time.RFC1123),
-		"Updated Time":    in.UpdatedTime.Local().Format(time.RFC1123),
+		"Created Time":    base.FormatTime(in.CreatedTime),
+		"Updated Time":    base.FormatTime(in.UpdatedTime),
 		"Host Catalog ID": in.HostCatalogId,
 	}
 
@@ -54,4 +54,38 @@ func generateHostTableOutput(in *hosts.Host) string {
 	return base.WrapForHelpText(ret)
 }
 
+func generateHostImportTableOutput(in *hosts.HostImport) string {
+	heading := "Host import:"
+	if in.DryRun {
+		heading = "Host import (dry run, nothing was changed):"
+	}
+	ret := []string{
+		"",
+		heading,
+		fmt.Sprintf("  Created:    %d", in.Created),
+		fmt.Sprintf("  Updated:    %d", in.Updated),
+		fmt.Sprintf("  Unchanged:  %d", in.Unchanged),
+	}
+	for _, h := range in.Items {
+		ret = append(ret,
+			"",
+			fmt.Sprintf("  Name:         %s", h.Name),
+			fmt.Sprintf("    Action:     %s", h.Action),
+			fmt.Sprintf("    Address:    %s", h.Address),
+		)
+		if h.Id != "" {
+			ret = append(ret,
+				fmt.Sprintf("    ID:         %s", h.Id),
+			)
+		}
+		if len(h.AddedHostSetIds) > 0 {
+			ret = append(ret,
+				"    Added to Host Sets:",
+				base.WrapSlice(6, h.AddedHostSetIds),
+			)
+		}
+	}
+	return base.WrapForHelpText(ret)
+}
+
 var keySubstMap = map[string]string{}
@@ -0,0 +1,160 @@
+package hosts
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/hosts"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*ImportCommand)(nil)
+var _ cli.CommandAutocomplete = (*ImportCommand)(nil)
+
+// ImportCommand creates and updates the static hosts of a host catalog, and
+// adds them to its host sets, from a CSV or JSON document.
+type ImportCommand struct {
+	*base.Command
+
+	flagFile   string
+	flagFormat string
+	flagDryRun bool
+}
+
+func (c *ImportCommand) Synopsis() string {
+	return "Import static hosts from a CSV or JSON document"
+}
+
+func (c *ImportCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary hosts import [options] [args]",
+		"",
+		"  Create and update the static hosts of a host catalog, and add them to its",
+		"  host sets, from a CSV or JSON document, in a single transaction. Hosts",
+		"  are matched by name: a host whose name is in the catalog has its address",
+		"  and description updated, and any other is created. Example:",
+		"",
+		`    $ boundary hosts import -host-catalog-id hcst_1234567890 -file jump-hosts.csv -dry-run`,
+		"",
+		"  A CSV document starts with a header naming its columns: name, address,",
+		"  description and host_sets, where a host's sets are names or IDs",
+		"  separated by semicolons. A JSON document is an array of objects with",
+		"  the same fields, with host_sets as an array.",
+	}) + c.Flags().Help()
+}
+
+func (c *ImportCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "static-type host", []string{"host-catalog-id"})
+
+	f = set.NewFlagSet("Import Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "file",
+		Target: &c.flagFile,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.csv"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to the document to import, or "-" to read it from stdin.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "format",
+		Target: &c.flagFormat,
+		Usage:  `The format of the document, "csv" or "json". If not set, it's taken from the extension of -file.`,
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "dry-run",
+		Target: &c.flagDryRun,
+		Usage:  "If set, the document is only checked, and what importing it would do is reported without making any changes.",
+	})
+
+	return set
+}
+
+func (c *ImportCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *ImportCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ImportCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.FlagHostCatalogId == "" {
+		c.UI.Error("Host Catalog ID must be passed in via -host-catalog-id")
+		return 1
+	}
+	if c.flagFile == "" {
+		c.UI.Error("The document to import must be passed in via -file")
+		return 1
+	}
+	format := strings.ToLower(c.flagFormat)
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(c.flagFile)), ".")
+	}
+	if format != "csv" && format != "json" {
+		c.UI.Error(`The format of the document must be "csv" or "json"; pass it in via -format`)
+		return 1
+	}
+
+	var doc []byte
+	var err error
+	if c.flagFile == "-" {
+		doc, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		doc, err = ioutil.ReadFile(c.flagFile)
+	}
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error reading document: %s", err.Error()))
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	result, err := hosts.NewClient(client).Import(c.Context, c.FlagHostCatalogId, format, string(doc), c.flagDryRun)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing import on hosts: %s", base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to import hosts: %s", err.Error()))
+		return 2
+	}
+
+	imported := result.Item
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(imported)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+
+	case "table":
+		c.UI.Output(generateHostImportTableOutput(imported))
+	}
+	return 0
+}
@@ -1,8 +1,6 @@
 package hostsets
 
 import (
-	"time"
-
 	"github.com/hashicorp/boundary/api/hostsets"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 )
@@ -12,8 +10,8 @@ func generateHostSetTableOutput(in *hostsets.HostSet) string {
 		"ID":              in.Id,
 		"Version":         in.Version,
 		"Type":            in.Type,
-		"Created Time":    in.CreatedTime.Local().Format(time.RFC1123),
-		"Updated Time":    in.UpdatedTime.Local().Format(time.RFC1123),
+		"Created Time":    base.FormatTime(in.CreatedTime),
+		"Updated Time":    base.FormatTime(in.UpdatedTime),
 		"Host Catalog ID": in.HostCatalogId,
 	}
 
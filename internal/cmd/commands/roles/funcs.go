@@ -3,7 +3,6 @@ package roles
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/boundary/api/roles"
 	"github.com/hashicorp/boundary/internal/cmd/base"
@@ -59,6 +58,58 @@ func removePrincipalsHelp() string {
 	})
 }
 
+func activateEmergencyHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary roles activate-emergency [options] [args]",
+		"",
+		`  Activates an emergency role given its ID for the authenticated user, who must be a principal of the role. The activation lasts for the given duration, or as long as the role allows, and is reported to security. Example:`,
+		"",
+		`    $ boundary roles activate-emergency -id r_1234567890 -justification "INC-1234 database outage" -duration 30m`,
+	})
+}
+
+func revertEmergencyHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary roles revert-emergency [options] [args]",
+		"",
+		`  Reverts the activation of an emergency role given its ID before it expires. Example:`,
+		"",
+		`    $ boundary roles revert-emergency -id r_1234567890`,
+	})
+}
+
+func diffHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary roles diff [options] [args]",
+		"",
+		`  Shows how a role given its ID differs from another role, or how it changed between two times, from its history in the oplog. The "to" time defaults to now. Examples:`,
+		"",
+		`    $ boundary roles diff -id r_1234567890 -from-role-id r_0987654321`,
+		"",
+		`    $ boundary roles diff -id r_1234567890 -from 2021-01-01T00:00:00Z -to 2021-02-01T00:00:00Z`,
+	})
+}
+
+func archiveHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary roles archive [options] [args]",
+		"",
+		`  Archives a role given its ID, which ends its grants. The role keeps its principals and grants, but can't be updated until it's restored, and is only listed with -include-archived. Example:`,
+		"",
+		`    $ boundary roles archive -id r_1234567890`,
+	})
+}
+
+func restoreHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary roles restore [options] [args]",
+		"",
+		`  Restores an archived role given its ID. Without an expiration time the restored role doesn't expire. Example:`,
+		"",
+		`    $ boundary roles restore -id r_1234567890 -expiration-time 2021-03-01T00:00:00Z`,
+	})
+}
+
 func populateFlags(c *Command, f *base.FlagSet, flagNames []string) {
 	common.PopulateCommonFlags(c.Command, f, resource.Role.String(), flagNames)
 
@@ -82,6 +133,48 @@ func populateFlags(c *Command, f *base.FlagSet, flagNames []string) {
 				Target: &c.flagGrants,
 				Usage:  "The grants to add, remove, or set. May be specified multiple times. Can be in compact string format or JSON (be sure to escape JSON properly).",
 			})
+		case "justification":
+			f.StringVar(&base.StringVar{
+				Name:   "justification",
+				Target: &c.flagJustification,
+				Usage:  "Why the role is being activated.",
+			})
+		case "duration":
+			f.StringVar(&base.StringVar{
+				Name:   "duration",
+				Target: &c.flagDuration,
+				Usage:  `How long the activation lasts, like "30m". Defaults to the longest the role allows.`,
+			})
+		case "fromroleid":
+			f.StringVar(&base.StringVar{
+				Name:   "from-role-id",
+				Target: &c.flagFromRoleId,
+				Usage:  "The ID of a role to compare the role with.",
+			})
+		case "from":
+			f.StringVar(&base.StringVar{
+				Name:   "from",
+				Target: &c.flagFrom,
+				Usage:  "The time to compare the role from, in RFC 3339 format.",
+			})
+		case "expiration":
+			f.StringVar(&base.StringVar{
+				Name:   "expiration-time",
+				Target: &c.flagExpiration,
+				Usage:  `When the role expires, in RFC 3339 format. An expired role grants nothing. Use "null" to clear it.`,
+			})
+		case "includearchived":
+			f.BoolVar(&base.BoolVar{
+				Name:   "include-archived",
+				Target: &c.flagArchived,
+				Usage:  "Include archived roles in the list.",
+			})
+		case "to":
+			f.StringVar(&base.StringVar{
+				Name:   "to",
+				Target: &c.flagTo,
+				Usage:  "The time to compare the role to, in RFC 3339 format. Defaults to now.",
+			})
 		}
 	}
 }
@@ -90,8 +183,8 @@ func generateRoleTableOutput(in *roles.Role) string {
 	nonAttributeMap := map[string]interface{}{
 		"ID":           in.Id,
 		"Version":      in.Version,
-		"Created Time": in.CreatedTime.Local().Format(time.RFC1123),
-		"Updated Time": in.UpdatedTime.Local().Format(time.RFC1123),
+		"Created Time": base.FormatTime(in.CreatedTime),
+		"Updated Time": base.FormatTime(in.UpdatedTime),
 	}
 
 	if in.Name != "" {
@@ -103,6 +196,12 @@ func generateRoleTableOutput(in *roles.Role) string {
 	if in.GrantScopeId != "" {
 		nonAttributeMap["Grant Scope ID"] = in.GrantScopeId
 	}
+	if !in.ExpirationTime.IsZero() {
+		nonAttributeMap["Expiration Time"] = base.FormatTime(in.ExpirationTime)
+	}
+	if !in.ArchivedTime.IsZero() {
+		nonAttributeMap["Archived Time"] = base.FormatTime(in.ArchivedTime)
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -142,3 +241,43 @@ func generateRoleTableOutput(in *roles.Role) string {
 	}
 	return base.WrapForHelpText(ret)
 }
+
+func generateEmergencyActivationTableOutput(in *roles.EmergencyActivation) string {
+	nonAttributeMap := map[string]interface{}{
+		"Role ID":         in.RoleId,
+		"User ID":         in.UserId,
+		"Justification":   in.Justification,
+		"Activated Time":  base.FormatTime(in.ActivateTime),
+		"Expiration Time": base.FormatTime(in.ExpirationTime),
+	}
+	if !in.RevertTime.IsZero() {
+		nonAttributeMap["Reverted Time"] = base.FormatTime(in.RevertTime)
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Emergency role activation information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+	return base.WrapForHelpText(ret)
+}
+
+func generateRoleDiffTableOutput(in *roles.RoleDiff) string {
+	subject := fmt.Sprintf("Changes to role %s", in.RoleId)
+	switch {
+	case in.FromRoleId != "":
+		subject = fmt.Sprintf("Changes from role %s to role %s", in.FromRoleId, in.RoleId)
+	case !in.FromTime.IsZero() && !in.ToTime.IsZero():
+		subject = fmt.Sprintf("Changes to role %s from %s to %s", in.RoleId, base.FormatTime(in.FromTime), base.FormatTime(in.ToTime))
+	}
+	if len(in.Summary) == 0 {
+		return subject + ": none."
+	}
+	ret := []string{"", subject + ":"}
+	for _, line := range in.Summary {
+		ret = append(ret, "  "+line)
+	}
+	return base.WrapForHelpText(ret)
+}
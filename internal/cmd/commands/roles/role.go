@@ -3,6 +3,8 @@ package roles
 import (
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/roles"
@@ -22,10 +24,17 @@ type Command struct {
 
 	Func string
 
-	flagScope        string
-	flagGrantScopeId string
-	flagPrincipals   []string
-	flagGrants       []string
+	flagScope         string
+	flagGrantScopeId  string
+	flagPrincipals    []string
+	flagGrants        []string
+	flagJustification string
+	flagDuration      string
+	flagFromRoleId    string
+	flagFrom          string
+	flagTo            string
+	flagExpiration    string
+	flagArchived      bool
 }
 
 func (c *Command) Synopsis() string {
@@ -36,6 +45,16 @@ func (c *Command) Synopsis() string {
 		return principalsGrantsSynopsisFunc(c.Func, true)
 	case "add-grants", "set-grants", "remove-grants":
 		return principalsGrantsSynopsisFunc(c.Func, false)
+	case "activate-emergency":
+		return "Activate an emergency role for yourself"
+	case "revert-emergency":
+		return "Revert the activation of an emergency role"
+	case "diff":
+		return "Show how a role differs from another role, or how it changed over time"
+	case "archive":
+		return "Archive a role, ending its grants"
+	case "restore":
+		return "Restore an archived role"
 	}
 	return ""
 }
@@ -48,21 +67,32 @@ var helpMap = func() map[string]func() string {
 	ret["add-grants"] = addPrincipalsHelp
 	ret["set-grants"] = setPrincipalsHelp
 	ret["remove-grants"] = removePrincipalsHelp
+	ret["activate-emergency"] = activateEmergencyHelp
+	ret["revert-emergency"] = revertEmergencyHelp
+	ret["diff"] = diffHelp
+	ret["archive"] = archiveHelp
+	ret["restore"] = restoreHelp
 	return ret
 }
 
 var flagsMap = map[string][]string{
-	"create":            {"scope-id", "name", "description", "grantscopeid"},
-	"update":            {"id", "name", "description", "grantscopeid", "version"},
+	"create":            {"scope-id", "name", "description", "grantscopeid", "expiration"},
+	"update":            {"id", "name", "description", "grantscopeid", "expiration", "version"},
 	"read":              {"id"},
 	"delete":            {"id"},
-	"list":              {"scope-id"},
+	"list":              {"scope-id", "includearchived", "page-size", "page-token"},
 	"add-principals":    {"id", "principal", "version"},
 	"set-principals":    {"id", "principal", "version"},
 	"remove-principals": {"id", "principal", "version"},
 	"add-grants":        {"id", "grant", "version"},
 	"set-grants":        {"id", "grant", "version"},
 	"remove-grants":     {"id", "grant", "version"},
+
+	"activate-emergency": {"id", "justification", "duration"},
+	"revert-emergency":   {"id"},
+	"diff":               {"id", "fromroleid", "from", "to"},
+	"archive":            {"id", "version"},
+	"restore":            {"id", "expiration", "version"},
 }
 
 func (c *Command) Help() string {
@@ -139,9 +169,25 @@ func (c *Command) Run(args []string) int {
 	default:
 		opts = append(opts, roles.WithGrantScopeId(c.flagGrantScopeId))
 	}
+	switch c.flagExpiration {
+	case "":
+	case "null":
+		opts = append(opts, roles.DefaultExpirationTime())
+	default:
+		expiration, err := time.Parse(time.RFC3339, c.flagExpiration)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error parsing expiration time: %w", err).Error())
+			return 1
+		}
+		opts = append(opts, roles.WithExpirationTime(expiration))
+	}
+	if c.flagArchived {
+		opts = append(opts, roles.WithIncludeArchived(true))
+	}
 
 	principals := c.flagPrincipals
 	grants := c.flagGrants
+	var from, to time.Time
 	switch c.Func {
 	case "add-principals", "remove-principals":
 		if len(c.flagPrincipals) == 0 {
@@ -176,6 +222,46 @@ func (c *Command) Run(args []string) int {
 				grants = nil
 			}
 		}
+
+	case "activate-emergency":
+		if strings.TrimSpace(c.flagJustification) == "" {
+			c.UI.Error("Must specify why the role is being activated using -justification")
+			return 1
+		}
+		if c.flagDuration != "" {
+			duration, err := time.ParseDuration(c.flagDuration)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error parsing duration: %w", err).Error())
+				return 1
+			}
+			if duration < time.Second {
+				c.UI.Error("Duration must be at least a second")
+				return 1
+			}
+			opts = append(opts, roles.WithDurationSeconds(uint32(duration/time.Second)))
+		}
+
+	case "diff":
+		switch {
+		case c.flagFromRoleId != "" && (c.flagFrom != "" || c.flagTo != ""):
+			c.UI.Error("Cannot specify -from or -to with -from-role-id")
+			return 1
+		case c.flagFromRoleId == "" && c.flagFrom == "":
+			c.UI.Error("Must specify a role to compare with using -from-role-id, or a time to compare from using -from")
+			return 1
+		}
+		if c.flagFrom != "" {
+			if from, err = time.Parse(time.RFC3339, c.flagFrom); err != nil {
+				c.UI.Error(fmt.Errorf("Error parsing from time: %w", err).Error())
+				return 1
+			}
+		}
+		if c.flagTo != "" {
+			if to, err = time.Parse(time.RFC3339, c.flagTo); err != nil {
+				c.UI.Error(fmt.Errorf("Error parsing to time: %w", err).Error())
+				return 1
+			}
+		}
 	}
 
 	if len(grants) > 0 {
@@ -188,12 +274,19 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
+	if c.FlagPageToken != "" {
+		opts = append(opts, roles.WithPageToken(c.FlagPageToken))
+	}
+	if c.FlagPageSize > 0 {
+		opts = append(opts, roles.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
 	roleClient := roles.NewClient(client)
 
 	// Perform check-and-set when needed
 	var version uint32
 	switch c.Func {
-	case "create", "read", "delete", "list":
+	case "create", "read", "delete", "list", "activate-emergency", "revert-emergency", "diff":
 		// These don't udpate so don't need the existing version
 	default:
 		switch c.FlagVersion {
@@ -207,6 +300,8 @@ func (c *Command) Run(args []string) int {
 	existed := true
 	var result api.GenericResult
 	var listResult api.GenericListResult
+	var ear *roles.EmergencyActivationResult
+	var rdr *roles.RoleDiffResult
 
 	switch c.Func {
 	case "create":
@@ -235,6 +330,20 @@ func (c *Command) Run(args []string) int {
 		result, err = roleClient.SetGrants(c.Context, c.FlagId, version, grants, opts...)
 	case "remove-grants":
 		result, err = roleClient.RemoveGrants(c.Context, c.FlagId, version, grants, opts...)
+	case "archive":
+		result, err = roleClient.Archive(c.Context, c.FlagId, version, opts...)
+	case "restore":
+		result, err = roleClient.Restore(c.Context, c.FlagId, version, opts...)
+	case "activate-emergency":
+		ear, err = roleClient.ActivateEmergency(c.Context, c.FlagId, c.flagJustification, opts...)
+	case "revert-emergency":
+		ear, err = roleClient.RevertEmergency(c.Context, c.FlagId, opts...)
+	case "diff":
+		if c.flagFromRoleId != "" {
+			rdr, err = roleClient.Diff(c.Context, c.FlagId, c.flagFromRoleId, opts...)
+		} else {
+			rdr, err = roleClient.DiffBetween(c.Context, c.FlagId, from, to, opts...)
+		}
 	}
 
 	plural := "role"
@@ -269,8 +378,21 @@ func (c *Command) Run(args []string) int {
 
 	case "list":
 		listedRoles := listResult.GetItems().([]*roles.Role)
+		nextPageToken := listResult.(*roles.RoleListResult).NextPageToken
 		switch base.Format(c.UI) {
 		case "json":
+			if c.FlagPageSize > 0 || c.FlagPageToken != "" {
+				b, err := base.JsonFormatter{}.Format(map[string]interface{}{
+					"items":           listedRoles,
+					"next_page_token": nextPageToken,
+				})
+				if err != nil {
+					c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+					return 1
+				}
+				c.UI.Output(string(b))
+				return 0
+			}
 			if len(listedRoles) == 0 {
 				c.UI.Output("null")
 				return 0
@@ -312,8 +434,46 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Description: %s", r.Description),
 					)
 				}
+				if !r.ArchivedTime.IsZero() {
+					output = append(output,
+						fmt.Sprintf("    Archived:    %s", base.FormatTime(r.ArchivedTime)),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
+			if nextPageToken != "" {
+				c.UI.Output(fmt.Sprintf("\nNext page token: %s", nextPageToken))
+			}
+		}
+		return 0
+
+	case "activate-emergency", "revert-emergency":
+		activation := ear.GetItem().(*roles.EmergencyActivation)
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(generateEmergencyActivationTableOutput(activation))
+		case "json":
+			b, err := base.JsonFormatter{}.Format(activation)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+		}
+		return 0
+
+	case "diff":
+		diff := rdr.GetItem().(*roles.RoleDiff)
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(generateRoleDiffTableOutput(diff))
+		case "json":
+			b, err := base.JsonFormatter{}.Format(diff)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
 		}
 		return 0
 	}
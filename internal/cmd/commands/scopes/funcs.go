@@ -1,18 +1,48 @@
 package scopes
 
 import (
-	"time"
+	"fmt"
 
 	"github.com/hashicorp/boundary/api/scopes"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 )
 
+func listBannedGrantPatternsHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary scopes list-banned-grant-patterns [options] [args]",
+		"",
+		"  Lists the grant patterns banned in a scope given its ID. Patterns banned in the scopes above it, which are also in effect in it, aren't included. Example:",
+		"",
+		`    $ boundary scopes list-banned-grant-patterns -id global`,
+	})
+}
+
+func addBannedGrantPatternHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary scopes add-banned-grant-pattern [options] [args]",
+		"",
+		"  Bans a pattern of grants in a scope given its ID and the scopes beneath it. Role grants written afterwards can't match it, and the name of the pattern is reported when one is refused. Example:",
+		"",
+		`    $ boundary scopes add-banned-grant-pattern -id global -name no-wildcards -pattern "id=*;actions=*"`,
+	})
+}
+
+func removeBannedGrantPatternHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary scopes remove-banned-grant-pattern [options] [args]",
+		"",
+		"  Removes a banned grant pattern, given its name, from a scope given its ID. Example:",
+		"",
+		`    $ boundary scopes remove-banned-grant-pattern -id global -name no-wildcards`,
+	})
+}
+
 func generateScopeTableOutput(in *scopes.Scope) string {
 	nonAttributeMap := map[string]interface{}{
 		"ID":           in.Id,
 		"Version":      in.Version,
-		"Created Time": in.CreatedTime.Local().Format(time.RFC1123),
-		"Updated Time": in.UpdatedTime.Local().Format(time.RFC1123),
+		"Created Time": base.FormatTime(in.CreatedTime),
+		"Updated Time": base.FormatTime(in.UpdatedTime),
 	}
 
 	if in.Name != "" {
@@ -35,3 +65,21 @@ func generateScopeTableOutput(in *scopes.Scope) string {
 
 	return base.WrapForHelpText(ret)
 }
+
+func generateBannedGrantPatternsTableOutput(scopeId string, patterns []*scopes.BannedGrantPattern) string {
+	if len(patterns) == 0 {
+		return fmt.Sprintf("No grant patterns are banned in %s.", scopeId)
+	}
+	ret := []string{"", fmt.Sprintf("Banned grant patterns in %s:", scopeId)}
+	for _, p := range patterns {
+		ret = append(ret,
+			fmt.Sprintf("  Name:           %s", p.Name),
+			fmt.Sprintf("    Pattern:      %s", p.Pattern),
+			fmt.Sprintf("    Created Time: %s", base.FormatTime(p.CreatedTime)),
+		)
+		if p.Description != "" {
+			ret = append(ret, fmt.Sprintf("    Description:  %s", p.Description))
+		}
+	}
+	return base.WrapForHelpText(ret)
+}
@@ -24,22 +24,42 @@ type Command struct {
 
 	flagSkipAdminRoleCreation   bool
 	flagSkipDefaultRoleCreation bool
+	flagPattern                 string
 }
 
 func (c *Command) Synopsis() string {
+	switch c.Func {
+	case "list-banned-grant-patterns":
+		return "List the grant patterns banned in a scope"
+	case "add-banned-grant-pattern":
+		return "Ban a pattern of grants in a scope"
+	case "remove-banned-grant-pattern":
+		return "Remove a banned grant pattern from a scope"
+	}
 	return common.SynopsisFunc(c.Func, "scope")
 }
 
+var helpMap = func() map[string]func() string {
+	ret := common.HelpMap("scope")
+	ret["list-banned-grant-patterns"] = listBannedGrantPatternsHelp
+	ret["add-banned-grant-pattern"] = addBannedGrantPatternHelp
+	ret["remove-banned-grant-pattern"] = removeBannedGrantPatternHelp
+	return ret
+}
+
 var flagsMap = map[string][]string{
-	"create": {"scope-id", "name", "description", "skip-admin-role-creation", "skip-default-role-creation"},
-	"update": {"id", "name", "description", "version"},
-	"read":   {"id"},
-	"delete": {"id"},
-	"list":   {"scope-id"},
+	"create":                      {"scope-id", "name", "description", "skip-admin-role-creation", "skip-default-role-creation"},
+	"update":                      {"id", "name", "description", "version"},
+	"read":                        {"id"},
+	"delete":                      {"id"},
+	"list":                        {"scope-id", "page-size", "page-token"},
+	"list-banned-grant-patterns":  {"id"},
+	"add-banned-grant-pattern":    {"id", "name", "description"},
+	"remove-banned-grant-pattern": {"id", "name"},
 }
 
 func (c *Command) Help() string {
-	helpMap := common.HelpMap("scope")
+	helpMap := helpMap()
 	if c.Func == "" {
 		return helpMap["base"]()
 	}
@@ -64,6 +84,14 @@ func (c *Command) Flags() *base.FlagSets {
 		})
 	}
 
+	if c.Func == "add-banned-grant-pattern" {
+		f.StringVar(&base.StringVar{
+			Name:   "pattern",
+			Target: &c.flagPattern,
+			Usage:  `The pattern to ban, in the form of a grant with any of its id, type and actions left out, like "id=*;actions=*". A grant matches if it has the pattern's id and type and allows at least its actions.`,
+		})
+	}
+
 	return set
 }
 
@@ -95,6 +123,17 @@ func (c *Command) Run(args []string) int {
 		c.UI.Error("Scope ID must be passed in via -scope-id")
 		return 1
 	}
+	switch c.Func {
+	case "add-banned-grant-pattern", "remove-banned-grant-pattern":
+		if c.FlagName == "" {
+			c.UI.Error("The name of the pattern must be passed in via -name")
+			return 1
+		}
+	}
+	if c.Func == "add-banned-grant-pattern" && c.flagPattern == "" {
+		c.UI.Error("The pattern to ban must be passed in via -pattern")
+		return 1
+	}
 
 	client, err := c.Client()
 	if err != nil {
@@ -127,12 +166,19 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, scopes.WithSkipDefaultRoleCreation(c.flagSkipDefaultRoleCreation))
 	}
 
+	if c.FlagPageToken != "" {
+		opts = append(opts, scopes.WithPageToken(c.FlagPageToken))
+	}
+	if c.FlagPageSize > 0 {
+		opts = append(opts, scopes.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
 	scopeClient := scopes.NewClient(client)
 
 	// Perform check-and-set when needed
 	var version uint32
 	switch c.Func {
-	case "create", "read", "delete", "list":
+	case "create", "read", "delete", "list", "list-banned-grant-patterns", "add-banned-grant-pattern", "remove-banned-grant-pattern":
 		// These don't udpate so don't need the existing version
 	default:
 		switch c.FlagVersion {
@@ -146,6 +192,8 @@ func (c *Command) Run(args []string) int {
 	existed := true
 	var result api.GenericResult
 	var listResult api.GenericListResult
+	var patternResult *scopes.BannedGrantPatternReadResult
+	var patternListResult *scopes.BannedGrantPatternListResult
 
 	switch c.Func {
 	case "create":
@@ -162,11 +210,22 @@ func (c *Command) Run(args []string) int {
 		}
 	case "list":
 		listResult, err = scopeClient.List(c.Context, c.FlagScopeId, opts...)
+	case "list-banned-grant-patterns":
+		patternListResult, err = scopeClient.ListBannedGrantPatterns(c.Context, c.FlagId, opts...)
+	case "add-banned-grant-pattern":
+		patternResult, err = scopeClient.AddBannedGrantPattern(c.Context, c.FlagId, c.FlagName, c.flagPattern, opts...)
+	case "remove-banned-grant-pattern":
+		_, err = scopeClient.RemoveBannedGrantPattern(c.Context, c.FlagId, c.FlagName, opts...)
 	}
 
 	plural := "scope"
-	if c.Func == "list" {
+	switch c.Func {
+	case "list":
 		plural = "scopes"
+	case "list-banned-grant-patterns":
+		plural = "banned grant patterns"
+	case "add-banned-grant-pattern", "remove-banned-grant-pattern":
+		plural = "banned grant pattern"
 	}
 	if err != nil {
 		if apiErr := api.AsServerError(err); apiErr != nil {
@@ -194,10 +253,65 @@ func (c *Command) Run(args []string) int {
 		}
 		return 0
 
+	case "remove-banned-grant-pattern":
+		switch base.Format(c.UI) {
+		case "json":
+			c.UI.Output("null")
+		case "table":
+			c.UI.Output("The remove operation completed successfully.")
+		}
+		return 0
+
+	case "list-banned-grant-patterns":
+		patterns := patternListResult.Items
+		switch base.Format(c.UI) {
+		case "json":
+			if len(patterns) == 0 {
+				c.UI.Output("null")
+				return 0
+			}
+			b, err := base.JsonFormatter{}.Format(patterns)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+		case "table":
+			c.UI.Output(generateBannedGrantPatternsTableOutput(c.FlagId, patterns))
+		}
+		return 0
+
+	case "add-banned-grant-pattern":
+		switch base.Format(c.UI) {
+		case "json":
+			b, err := base.JsonFormatter{}.Format(patternResult.Item)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+		case "table":
+			c.UI.Output(generateBannedGrantPatternsTableOutput(c.FlagId, []*scopes.BannedGrantPattern{patternResult.Item}))
+		}
+		return 0
+
 	case "list":
 		listedScopes := listResult.GetItems().([]*scopes.Scope)
+		nextPageToken := listResult.(*scopes.ScopeListResult).NextPageToken
 		switch base.Format(c.UI) {
 		case "json":
+			if c.FlagPageSize > 0 || c.FlagPageToken != "" {
+				b, err := base.JsonFormatter{}.Format(map[string]interface{}{
+					"items":           listedScopes,
+					"next_page_token": nextPageToken,
+				})
+				if err != nil {
+					c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+					return 1
+				}
+				c.UI.Output(string(b))
+				return 0
+			}
 			if len(listedScopes) == 0 {
 				c.UI.Output("null")
 				return 0
@@ -241,6 +355,9 @@ func (c *Command) Run(args []string) int {
 				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
+			if nextPageToken != "" {
+				c.UI.Output(fmt.Sprintf("\nNext page token: %s", nextPageToken))
+			}
 		}
 		return 0
 	}
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/servers/controller"
 	"github.com/hashicorp/boundary/internal/servers/worker"
 	"github.com/hashicorp/boundary/sdk/wrapper"
@@ -264,7 +266,12 @@ func (c *Command) Run(args []string) int {
 			return 1
 		}
 		c.DatabaseUrl = strings.TrimSpace(dbaseUrl)
-		if err := c.ConnectToDatabase("postgres"); err != nil {
+		dbOpts, err := databaseOptions(c.Config.Controller.Database, c.Config.Controller.Follower != nil)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error parsing database config: %w", err).Error())
+			return 1
+		}
+		if err := c.ConnectToDatabase("postgres", dbOpts...); err != nil {
 			c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
 			return 1
 		}
@@ -516,3 +523,31 @@ func (c *Command) Reload() error {
 
 	return reloadErrors.ErrorOrNil()
 }
+
+// databaseOptions returns the db.Open options for the pool size and timeouts
+// set in the controller's database config. Failovers are always handled, and
+// a controller which isn't a follower only connects to the primary.
+func databaseOptions(conf *config.Database, follower bool) ([]db.Option, error) {
+	opts := []db.Option{
+		db.WithMaxOpenConnections(conf.MaxOpenConnections),
+		db.WithMaxIdleConnections(conf.MaxIdleConnections),
+		db.WithFailover(true),
+		db.WithSrvRecord(conf.SrvRecord),
+		db.WithRequirePrimary(!follower),
+	}
+	if conf.ConnMaxLifetime != "" {
+		d, err := time.ParseDuration(conf.ConnMaxLifetime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid conn_max_lifetime: %w", err)
+		}
+		opts = append(opts, db.WithConnMaxLifetime(d))
+	}
+	if conf.StatementTimeout != "" {
+		d, err := time.ParseDuration(conf.StatementTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid statement_timeout: %w", err)
+		}
+		opts = append(opts, db.WithStatementTimeout(d))
+	}
+	return opts, nil
+}
@@ -3,7 +3,6 @@ package sessions
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/boundary/api/sessions"
 	"github.com/hashicorp/boundary/internal/cmd/base"
@@ -13,9 +12,9 @@ func generateSessionTableOutput(in *sessions.Session) string {
 	nonAttributeMap := map[string]interface{}{
 		"ID":              in.Id,
 		"Target ID":       in.TargetId,
-		"Created Time":    in.CreatedTime.Local().Format(time.RFC1123),
-		"Updated Time":    in.UpdatedTime.Local().Format(time.RFC1123),
-		"Expiration Time": in.ExpirationTime.Local().Format(time.RFC1123),
+		"Created Time":    base.FormatTime(in.CreatedTime),
+		"Updated Time":    base.FormatTime(in.UpdatedTime),
+		"Expiration Time": base.FormatTime(in.ExpirationTime),
 		"Version":         in.Version,
 		"Type":            in.Type,
 		"Auth Token ID":   in.AuthTokenId,
@@ -28,6 +27,13 @@ func generateSessionTableOutput(in *sessions.Session) string {
 	if len(strings.TrimSpace(in.TerminationReason)) > 0 {
 		nonAttributeMap["Termination Reason"] = in.TerminationReason
 	}
+	if in.Justification != "" {
+		nonAttributeMap["Justification"] = in.Justification
+	}
+	if in.ChangeTicket != "" {
+		nonAttributeMap["Change Ticket System"] = in.ChangeTicketSystem
+		nonAttributeMap["Change Ticket"] = in.ChangeTicket
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -36,10 +42,10 @@ func generateSessionTableOutput(in *sessions.Session) string {
 		for _, state := range in.States {
 			m := map[string]interface{}{
 				"Status":     state.Status,
-				"Start Time": state.StartTime.Local().Format(time.RFC1123),
+				"Start Time": base.FormatTime(state.StartTime),
 			}
 			if !state.EndTime.IsZero() {
-				m["End Time"] = state.EndTime.Local().Format(time.RFC1123)
+				m["End Time"] = base.FormatTime(state.EndTime)
 			}
 			statesMaps = append(statesMaps, m)
 		}
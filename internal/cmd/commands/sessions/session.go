@@ -2,7 +2,6 @@ package sessions
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/sessions"
@@ -168,9 +167,9 @@ func (c *Command) Run(args []string) int {
 				output = append(output,
 					fmt.Sprintf("  ID:                 %s", t.Id),
 					fmt.Sprintf("    Status:           %s", t.Status),
-					fmt.Sprintf("    Created Time:     %s", t.CreatedTime.Local().Format(time.RFC1123)),
-					fmt.Sprintf("    Expiration Time:  %s", t.ExpirationTime.Local().Format(time.RFC1123)),
-					fmt.Sprintf("    Updated Time:     %s", t.UpdatedTime.Local().Format(time.RFC1123)),
+					fmt.Sprintf("    Created Time:     %s", base.FormatTime(t.CreatedTime)),
+					fmt.Sprintf("    Expiration Time:  %s", base.FormatTime(t.ExpirationTime)),
+					fmt.Sprintf("    Updated Time:     %s", base.FormatTime(t.UpdatedTime)),
 					fmt.Sprintf("    User ID:          %s", t.UserId),
 					fmt.Sprintf("    Target ID:        %s", t.TargetId),
 				)
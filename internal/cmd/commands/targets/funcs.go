@@ -29,8 +29,8 @@ func generateTargetTableOutput(in *targets.Target) string {
 		"ID":                       in.Id,
 		"Version":                  in.Version,
 		"Type":                     in.Type,
-		"Created Time":             in.CreatedTime.Local().Format(time.RFC1123),
-		"Updated Time":             in.UpdatedTime.Local().Format(time.RFC1123),
+		"Created Time":             base.FormatTime(in.CreatedTime),
+		"Updated Time":             base.FormatTime(in.UpdatedTime),
 		"Session Connection Limit": in.SessionConnectionLimit,
 		"Session Max Seconds":      in.SessionMaxSeconds,
 	}
@@ -99,7 +99,7 @@ func generateAuthorizationTableOutput(in *targets.SessionAuthorization) string {
 		"Scope ID":            in.Scope.Id,
 		"User ID":             in.UserId,
 		"Host ID":             in.HostId,
-		"Created Time":        in.CreatedTime.Local().Format(time.RFC1123),
+		"Created Time":        base.FormatTime(in.CreatedTime),
 		"Type":                in.Type,
 		"Authorization Token": in.AuthorizationToken,
 	}
@@ -118,11 +118,20 @@ func generateAuthorizationTableOutput(in *targets.SessionAuthorization) string {
 		base.WrapMap(2, maxLength+2, nonAttributeMap),
 	)
 
+	if in.Banner != "" {
+		ret = append(ret, "", "Banner:", in.Banner)
+	}
+
 	return base.WrapForHelpText(ret)
 }
 
 var keySubstMap = map[string]string{
-	"default_port": "Default Port",
+	"default_port":                   "Default Port",
+	"banner":                         "Banner",
+	"banner_acknowledgment_required": "Banner Acknowledgment Required",
+	"justification_pattern":          "Justification Pattern",
+	"change_ticket_system":           "Change Ticket System",
+	"client_certificate_library_id":  "Client Certificate Library ID",
 }
 
 func exampleOutput() string {
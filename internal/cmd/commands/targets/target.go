@@ -23,8 +23,11 @@ type Command struct {
 
 	Func string
 
-	flagHostSets []string
-	flagHostId   string
+	flagHostSets      []string
+	flagHostId        string
+	flagBannerHash    string
+	flagJustification string
+	flagChangeTicket  string
 }
 
 func (c *Command) Synopsis() string {
@@ -39,7 +42,7 @@ func (c *Command) Synopsis() string {
 }
 
 var flagsMap = map[string][]string{
-	"authorize-session": {"id", "host-id"},
+	"authorize-session": {"id", "host-id", "banner-hash", "justification", "change-ticket"},
 	"read":              {"id"},
 	"delete":            {"id"},
 	"list":              {"scope-id"},
@@ -165,6 +168,24 @@ func (c *Command) Flags() *base.FlagSets {
 				Target: &c.flagHostId,
 				Usage:  "The ID of a specific host to connect to out of the hosts from the target's host sets. If not specified, one is chosen at random.",
 			})
+		case "banner-hash":
+			f.StringVar(&base.StringVar{
+				Name:   "banner-hash",
+				Target: &c.flagBannerHash,
+				Usage:  "The hash of the target's banner to acknowledge, from the error returned when acknowledging it is required. Required if the target requires acknowledging its banner.",
+			})
+		case "justification":
+			f.StringVar(&base.StringVar{
+				Name:   "justification",
+				Target: &c.flagJustification,
+				Usage:  "The reason for the session. Required if the target has a justification pattern, which it must match.",
+			})
+		case "change-ticket":
+			f.StringVar(&base.StringVar{
+				Name:   "change-ticket",
+				Target: &c.flagChangeTicket,
+				Usage:  "The change ticket for the session, like \"CHG-1234\". Required if the target has a change ticket system, which must accept it.",
+			})
 		}
 	}
 
@@ -252,6 +273,15 @@ func (c *Command) Run(args []string) int {
 		if len(c.flagHostId) != 0 {
 			opts = append(opts, targets.WithHostId(c.flagHostId))
 		}
+		if c.flagBannerHash != "" {
+			opts = append(opts, targets.WithBannerHash(c.flagBannerHash))
+		}
+		if c.flagJustification != "" {
+			opts = append(opts, targets.WithJustification(c.flagJustification))
+		}
+		if c.flagChangeTicket != "" {
+			opts = append(opts, targets.WithChangeTicket(c.flagChangeTicket))
+		}
 	}
 
 	// Perform check-and-set when needed
@@ -1,6 +1,7 @@
 package targets
 
 import (
+	"errors"
 	"fmt"
 	"net/textproto"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"github.com/hashicorp/boundary/api/targets"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/cmd/config"
 	"github.com/hashicorp/boundary/sdk/strutil"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
@@ -25,6 +27,11 @@ type TcpCommand struct {
 	flagDefaultPort            string
 	flagSessionMaxSeconds      string
 	flagSessionConnectionLimit string
+	flagBanner                 string
+	flagBannerAckRequired      string
+	flagJustificationPattern   string
+	flagChangeTicketSystem     string
+	flagClientCertLibraryId    string
 }
 
 func (c *TcpCommand) Synopsis() string {
@@ -32,8 +39,8 @@ func (c *TcpCommand) Synopsis() string {
 }
 
 var tcpFlagsMap = map[string][]string{
-	"create": {"scope-id", "name", "description", "default-port", "session-max-seconds", "session-connection-limit"},
-	"update": {"id", "name", "description", "version", "default-port", "session-max-seconds", "session-connection-limit"},
+	"create": {"scope-id", "name", "description", "default-port", "session-max-seconds", "session-connection-limit", "banner", "banner-acknowledgment-required", "justification-pattern", "change-ticket-system", "client-certificate-library-id"},
+	"update": {"id", "name", "description", "version", "default-port", "session-max-seconds", "session-connection-limit", "banner", "banner-acknowledgment-required", "justification-pattern", "change-ticket-system", "client-certificate-library-id"},
 }
 
 func (c *TcpCommand) Help() string {
@@ -89,6 +96,36 @@ func (c *TcpCommand) Flags() *base.FlagSets {
 				Target: &c.flagSessionConnectionLimit,
 				Usage:  "The maximum number of connections allowed for a session. -1 means unlimited.",
 			})
+		case "banner":
+			f.StringVar(&base.StringVar{
+				Name:   "banner",
+				Target: &c.flagBanner,
+				Usage:  `The banner shown before connecting to the target, such as a usage policy. This can refer to a file on disk (file://) from which the banner will be read; an env var (env://) from which the banner will be read; or the banner itself.`,
+			})
+		case "banner-acknowledgment-required":
+			f.StringVar(&base.StringVar{
+				Name:   "banner-acknowledgment-required",
+				Target: &c.flagBannerAckRequired,
+				Usage:  "Whether users must acknowledge the banner before a session to the target is authorized.",
+			})
+		case "justification-pattern":
+			f.StringVar(&base.StringVar{
+				Name:   "justification-pattern",
+				Target: &c.flagJustificationPattern,
+				Usage:  "A regular expression the whole justification for a session to the target must match. If set, a justification is required to authorize a session.",
+			})
+		case "change-ticket-system":
+			f.StringVar(&base.StringVar{
+				Name:   "change-ticket-system",
+				Target: &c.flagChangeTicketSystem,
+				Usage:  "The name of a change ticket system configured on the controller. If set, a change ticket which the system accepts is required to authorize a session.",
+			})
+		case "client-certificate-library-id":
+			f.StringVar(&base.StringVar{
+				Name:   "client-certificate-library-id",
+				Target: &c.flagClientCertLibraryId,
+				Usage:  "The id of a client certificate library in the target's project. If set, a client certificate is issued from it for each session, which workers present to the target.",
+			})
 		}
 	}
 
@@ -194,6 +231,56 @@ func (c *TcpCommand) Run(args []string) int {
 		opts = append(opts, targets.WithSessionConnectionLimit(int32(limit)))
 	}
 
+	switch c.flagBanner {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultTcpTargetBanner())
+	default:
+		banner, err := config.ParseAddress(c.flagBanner)
+		if err != nil && !errors.Is(err, config.ErrNotAUrl) {
+			c.UI.Error(fmt.Sprintf("Error reading banner: %s", err))
+			return 1
+		}
+		opts = append(opts, targets.WithTcpTargetBanner(banner))
+	}
+
+	switch c.flagBannerAckRequired {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultTcpTargetBannerAcknowledgmentRequired())
+	default:
+		required, err := strconv.ParseBool(c.flagBannerAckRequired)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagBannerAckRequired, err))
+			return 1
+		}
+		opts = append(opts, targets.WithTcpTargetBannerAcknowledgmentRequired(required))
+	}
+
+	switch c.flagJustificationPattern {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultTcpTargetJustificationPattern())
+	default:
+		opts = append(opts, targets.WithTcpTargetJustificationPattern(c.flagJustificationPattern))
+	}
+
+	switch c.flagChangeTicketSystem {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultTcpTargetChangeTicketSystem())
+	default:
+		opts = append(opts, targets.WithTcpTargetChangeTicketSystem(c.flagChangeTicketSystem))
+	}
+
+	switch c.flagClientCertLibraryId {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultTcpTargetClientCertificateLibraryId())
+	default:
+		opts = append(opts, targets.WithTcpTargetClientCertificateLibraryId(c.flagClientCertLibraryId))
+	}
+
 	targetClient := targets.NewClient(client)
 
 	// Perform check-and-set when needed
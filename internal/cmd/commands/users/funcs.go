@@ -3,7 +3,6 @@ package users
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/boundary/api/users"
 	"github.com/hashicorp/boundary/internal/cmd/base"
@@ -74,8 +73,8 @@ func generateUserTableOutput(in *users.User) string {
 	nonAttributeMap := map[string]interface{}{
 		"ID":           in.Id,
 		"Version":      in.Version,
-		"Created Time": in.CreatedTime.Local().Format(time.RFC1123),
-		"Updated Time": in.UpdatedTime.Local().Format(time.RFC1123),
+		"Created Time": base.FormatTime(in.CreatedTime),
+		"Updated Time": base.FormatTime(in.UpdatedTime),
 	}
 
 	if in.Name != "" {
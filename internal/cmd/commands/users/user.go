@@ -46,7 +46,7 @@ var flagsMap = map[string][]string{
 	"update":          {"id", "name", "description", "version"},
 	"read":            {"id"},
 	"delete":          {"id"},
-	"list":            {"scope-id"},
+	"list":            {"scope-id", "page-size", "page-token"},
 	"add-accounts":    {"id", "account", "version"},
 	"set-accounts":    {"id", "account", "version"},
 	"remove-accounts": {"id", "account", "version"},
@@ -141,6 +141,13 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
+	if c.FlagPageToken != "" {
+		opts = append(opts, users.WithPageToken(c.FlagPageToken))
+	}
+	if c.FlagPageSize > 0 {
+		opts = append(opts, users.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
 	userClient := users.NewClient(client)
 
 	// Perform check-and-set when needed
@@ -216,8 +223,21 @@ func (c *Command) Run(args []string) int {
 
 	case "list":
 		listedUsers := listResult.GetItems().([]*users.User)
+		nextPageToken := listResult.(*users.UserListResult).NextPageToken
 		switch base.Format(c.UI) {
 		case "json":
+			if c.FlagPageSize > 0 || c.FlagPageToken != "" {
+				b, err := base.JsonFormatter{}.Format(map[string]interface{}{
+					"items":           listedUsers,
+					"next_page_token": nextPageToken,
+				})
+				if err != nil {
+					c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+					return 1
+				}
+				c.UI.Output(string(b))
+				return 0
+			}
 			if len(listedUsers) == 0 {
 				c.UI.Output("null")
 				return 0
@@ -261,6 +281,9 @@ func (c *Command) Run(args []string) int {
 				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
+			if nextPageToken != "" {
+				c.UI.Output(fmt.Sprintf("\nNext page token: %s", nextPageToken))
+			}
 		}
 		return 0
 	}
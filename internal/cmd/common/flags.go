@@ -57,6 +57,18 @@ func PopulateCommonFlags(c *base.Command, f *base.FlagSet, resourceType string,
 				Target: &c.FlagHostCatalogId,
 				Usage:  "The host-catalog resource to use for the operation",
 			})
+		case "page-size":
+			f.UintVar(&base.UintVar{
+				Name:   "page-size",
+				Target: &c.FlagPageSize,
+				Usage:  fmt.Sprintf("If set, list the %ss a page of at most this many at a time. The page is no bigger than the controller's maximum page size. JSON output of a page is an object with the items and the next page token.", resourceType),
+			})
+		case "page-token":
+			f.StringVar(&base.StringVar{
+				Name:   "page-token",
+				Target: &c.FlagPageToken,
+				Usage:  fmt.Sprintf("The token of the page of %ss to list, shown with the previous page", resourceType),
+			})
 		}
 	}
 }
@@ -98,6 +98,147 @@ type Controller struct {
 	Name        string    `hcl:"name"`
 	Description string    `hcl:"description"`
 	Database    *Database `hcl:"database"`
+	Quotas      *Quotas   `hcl:"quotas"`
+
+	// IntegrityEnforcement makes the controller refuse role grants and
+	// principal roles whose integrity hmacs aren't valid.
+	IntegrityEnforcement bool `hcl:"integrity_enforcement"`
+
+	LengthLimits *LengthLimits `hcl:"length_limits"`
+
+	// FastReads makes the controller look up scopes, grants and group
+	// members with hand-written sql instead of gorm.
+	FastReads bool `hcl:"fast_reads"`
+
+	// WriteHookUrl is an endpoint, like an Open Policy Agent decision, which
+	// is asked whether to allow each write of a role or role grant.
+	WriteHookUrl string `hcl:"write_hook_url"`
+
+	// GrantsCacheSeconds is how long the controller caches the grants of
+	// users in scopes for authorizing requests. Zero disables the cache.
+	GrantsCacheSeconds int `hcl:"grants_cache_seconds"`
+
+	// MaxPageSize is the most scopes, users, groups and roles the controller
+	// lists at once. Zero means no maximum.
+	MaxPageSize int `hcl:"max_page_size"`
+
+	// OrgIsolation, if set, turns hard org isolation on or off when the
+	// controller starts. If it's unset, the current setting is left as it is.
+	OrgIsolation *bool `hcl:"org_isolation"`
+
+	// Follower makes the controller a read-only follower, which serves only
+	// reads, from the read replica its database url points to.
+	Follower *Follower `hcl:"follower"`
+
+	// Ui configures the admin UI the controller serves on its api
+	// listeners.
+	Ui *Ui `hcl:"ui"`
+
+	// AdaptiveAuth configures step-up authentication for logins from
+	// devices, addresses or locations which are new for the account.
+	AdaptiveAuth *AdaptiveAuth `hcl:"adaptive_auth"`
+
+	// SnapshotSigning configures the keys the controller signs the iam
+	// snapshots it exports with, and verifies the ones it imports with.
+	SnapshotSigning *SnapshotSigning `hcl:"snapshot_signing"`
+
+	// ChangeTicketSystems are the change ticket systems, like Jira or
+	// ServiceNow, which targets can require the change tickets of their
+	// sessions to be accepted by.
+	ChangeTicketSystems []*ChangeTicketSystem `hcl:"change_ticket_system"`
+}
+
+// ChangeTicketSystem configures a change ticket system, which validates change
+// tickets by POSTing them to an endpoint.
+type ChangeTicketSystem struct {
+	// Name is the name targets refer to the system by.
+	Name string `hcl:",key"`
+
+	// ValidateUrl is the endpoint which is asked whether to accept each
+	// change ticket.
+	ValidateUrl string `hcl:"validate_url"`
+
+	// SessionUrl, if set, is an endpoint which is told about each session
+	// authorized with one of the system's change tickets.
+	SessionUrl string `hcl:"session_url"`
+}
+
+// SnapshotSigning configures the keys of iam snapshots, which are shared by
+// the clusters snapshots are moved between. Keys are base64 encoded, or
+// file:// or env:// urls of base64 encoded keys.
+type SnapshotSigning struct {
+	// Signer names the controller's cluster in the signatures it makes. It
+	// defaults to the controller's name.
+	Signer string `hcl:"signer"`
+
+	// KeyId identifies Key in the signatures made with it.
+	KeyId string `hcl:"key_id"`
+
+	// Key is the key the controller signs snapshots with.
+	Key string `hcl:"key"`
+
+	// VerifyKeys are more keys, by id, the controller verifies snapshots
+	// with, like keys being rotated out.
+	VerifyKeys map[string]string `hcl:"verify_keys"`
+}
+
+// AdaptiveAuth configures the adaptive authentication of a controller.
+type AdaptiveAuth struct {
+	// Sensitivity is how unusual a login has to be to require step-up
+	// authentication: "off", "low", "medium" or "high". It defaults to
+	// "off".
+	Sensitivity string `hcl:"sensitivity"`
+
+	// AuthMethodSensitivity overrides Sensitivity for the auth methods with
+	// the given ids.
+	AuthMethodSensitivity map[string]string `hcl:"auth_method_sensitivity"`
+
+	// StepUpHookUrl is an endpoint which is asked to verify the step-up
+	// authentication, like a one time code, of logins which require it.
+	StepUpHookUrl string `hcl:"step_up_hook_url"`
+}
+
+// Ui configures the admin UI served by a controller.
+type Ui struct {
+	// Disable stops the controller serving the admin UI.
+	Disable bool `hcl:"disable"`
+
+	// Directory is a directory of built admin UI assets to serve instead of
+	// the ones bundled into the binary.
+	Directory string `hcl:"directory"`
+
+	// ContentSecurityPolicy replaces the default Content-Security-Policy
+	// header of the UI's responses.
+	ContentSecurityPolicy string `hcl:"content_security_policy"`
+}
+
+// Follower configures a read-only follower controller.
+type Follower struct {
+	// MaxStaleness is how far behind its primary, like "30s", the replica can
+	// be before the follower refuses reads. Empty means no maximum.
+	MaxStaleness string `hcl:"max_staleness"`
+
+	// LeaderAddr is the api address, like "https://10.0.0.1:9200", of a
+	// controller using the primary, to which the follower proxies
+	// authentication requests, since it can't write the auth tokens they
+	// create.
+	LeaderAddr string `hcl:"leader_addr"`
+}
+
+// Quotas limit how many roles, grants and group members can be written. Zero
+// means no limit.
+type Quotas struct {
+	MaxRolesPerScope   int `hcl:"max_roles_per_scope"`
+	MaxGrantsPerRole   int `hcl:"max_grants_per_role"`
+	MaxMembersPerGroup int `hcl:"max_members_per_group"`
+}
+
+// LengthLimits are the maximum lengths of names, descriptions and grants.
+// Zero means the default, and they can't be longer than the defaults.
+type LengthLimits struct {
+	MaxNameLength        int `hcl:"max_name_length"`
+	MaxDescriptionLength int `hcl:"max_description_length"`
+	MaxGrantLength       int `hcl:"max_grant_length"`
 }
 
 type Worker struct {
@@ -108,8 +249,16 @@ type Worker struct {
 }
 
 type Database struct {
-	Url          string `hcl:"url"`
-	MigrationUrl string `hcl:"migration_url"`
+	Url                string `hcl:"url"`
+	MigrationUrl       string `hcl:"migration_url"`
+	MaxOpenConnections int    `hcl:"max_open_connections"`
+	MaxIdleConnections int    `hcl:"max_idle_connections"`
+	// ConnMaxLifetime and StatementTimeout are durations, like "30s".
+	ConnMaxLifetime  string `hcl:"conn_max_lifetime"`
+	StatementTimeout string `hcl:"statement_timeout"`
+	// SrvRecord is the name of a DNS SRV record listing the servers to
+	// connect to, which replace the host and port of Url.
+	SrvRecord string `hcl:"srv_record"`
 }
 
 // DevWorker is a Config that is used for dev mode of Boundary
@@ -0,0 +1,10 @@
+package config
+
+// fuzzParse is the fuzz target for controller and worker configuration
+// parsing, which must reject malformed HCL and JSON without panicking.
+func fuzzParse(data []byte) int {
+	if _, err := Parse(string(data)); err != nil {
+		return 0
+	}
+	return 1
+}
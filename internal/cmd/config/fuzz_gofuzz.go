@@ -0,0 +1,8 @@
+// +build gofuzz
+
+package config
+
+// Fuzz is the go-fuzz entry point for configuration parsing, see fuzzParse.
+func Fuzz(data []byte) int {
+	return fuzzParse(data)
+}
@@ -20,20 +20,20 @@ import (
 
 // setupEnv parses args and may replace them and sets some env vars to known
 // values based on format options
-func setupEnv(args []string) (retArgs []string, format string, outputCurlString bool) {
+func setupEnv(args []string) (retArgs []string, format, timeFormat string, outputCurlString bool) {
 	// handle the workaround for autocomplete install/uninstall not being exported
 	if len(args) == 3 &&
 		args[0] == "config" &&
 		args[1] == "autocomplete" {
 		switch args[2] {
 		case "install":
-			return []string{"-autocomplete-install"}, "table", false
+			return []string{"-autocomplete-install"}, "table", base.TimeFormatLocal, false
 		case "uninstall":
-			return []string{"-autocomplete-uninstall"}, "table", false
+			return []string{"-autocomplete-uninstall"}, "table", base.TimeFormatLocal, false
 		}
 	}
 
-	var nextArgFormat bool
+	var nextArgFormat, nextArgTimeFormat bool
 
 	for _, arg := range args {
 		if nextArgFormat {
@@ -41,6 +41,11 @@ func setupEnv(args []string) (retArgs []string, format string, outputCurlString
 			format = arg
 			continue
 		}
+		if nextArgTimeFormat {
+			nextArgTimeFormat = false
+			timeFormat = arg
+			continue
+		}
 
 		if arg == "--" {
 			break
@@ -66,6 +71,13 @@ func setupEnv(args []string) (retArgs []string, format string, outputCurlString
 		if arg == "-format" {
 			nextArgFormat = true
 		}
+
+		if strings.HasPrefix(arg, "-time-format=") {
+			timeFormat = strings.TrimPrefix(arg, "-time-format=")
+		}
+		if arg == "-time-format" {
+			nextArgTimeFormat = true
+		}
 	}
 
 	envBoundaryCLIFormat := os.Getenv(base.EnvBoundaryCLIFormat)
@@ -79,7 +91,15 @@ func setupEnv(args []string) (retArgs []string, format string, outputCurlString
 		format = "table"
 	}
 
-	return args, format, outputCurlString
+	if timeFormat == "" {
+		timeFormat = os.Getenv(base.EnvBoundaryCLITimeFormat)
+	}
+	timeFormat = strings.ToLower(timeFormat)
+	if timeFormat == "" {
+		timeFormat = base.TimeFormatLocal
+	}
+
+	return args, format, timeFormat, outputCurlString
 }
 
 type RunOptions struct {
@@ -99,9 +119,9 @@ func RunCustom(args []string, runOpts *RunOptions) int {
 		runOpts = &RunOptions{}
 	}
 
-	var format string
+	var format, timeFormat string
 	var outputCurlString bool
-	args, format, outputCurlString = setupEnv(args)
+	args, format, timeFormat, outputCurlString = setupEnv(args)
 
 	// Don't use color if disabled
 	useColor := true
@@ -165,6 +185,11 @@ func RunCustom(args []string, runOpts *RunOptions) int {
 		ui.Error(fmt.Sprintf("Invalid output format: %s", format))
 		return 1
 	}
+	if !base.ValidTimeFormat(timeFormat) {
+		ui.Error(fmt.Sprintf("Invalid time format: %s", timeFormat))
+		return 1
+	}
+	base.OutputTimeFormat = timeFormat
 
 	initCommands(ui, serverCmdUi, runOpts)
 
@@ -1,9 +1,13 @@
 package db
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/hashicorp/boundary/internal/db/migrations"
@@ -33,15 +37,71 @@ func (db DbType) String() string {
 }
 
 // Open a database connection which is long-lived.
-// You need to call Close() on the returned gorm.DB
-func Open(dbType DbType, connectionUrl string) (*gorm.DB, error) {
-	db, err := gorm.Open(dbType.String(), connectionUrl)
+// You need to call Close() on the returned gorm.DB. Supports the options:
+// WithMaxOpenConnections, WithMaxIdleConnections and WithConnMaxLifetime,
+// which size the connection pool, WithStatementTimeout, and WithFailover,
+// WithSrvRecord and WithRequirePrimary, which handle failovers.
+func Open(dbType DbType, connectionUrl string, opt ...Option) (*gorm.DB, error) {
+	opts := GetOpts(opt...)
+	switch {
+	case opts.withMaxOpenConnections < 0:
+		return nil, fmt.Errorf("open: negative max open connections: %w", ErrInvalidParameter)
+	case opts.withMaxIdleConnections < 0:
+		return nil, fmt.Errorf("open: negative max idle connections: %w", ErrInvalidParameter)
+	case opts.withConnMaxLifetime < 0:
+		return nil, fmt.Errorf("open: negative connection max lifetime: %w", ErrInvalidParameter)
+	case opts.withStatementTimeout < 0:
+		return nil, fmt.Errorf("open: negative statement timeout: %w", ErrInvalidParameter)
+	}
+	if opts.withStatementTimeout > 0 {
+		var err error
+		connectionUrl, err = withConnectionParam(connectionUrl, "statement_timeout", strconv.FormatInt(opts.withStatementTimeout.Milliseconds(), 10))
+		if err != nil {
+			return nil, fmt.Errorf("open: %w", err)
+		}
+	}
+	var db *gorm.DB
+	var err error
+	if opts.withFailover {
+		sqlDb := sql.OpenDB(newFailoverConnector(connectionUrl, opts.withSrvRecord, opts.withRequirePrimary))
+		if db, err = gorm.Open(dbType.String(), sqlDb); err != nil {
+			sqlDb.Close()
+		}
+	} else {
+		db, err = gorm.Open(dbType.String(), connectionUrl)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("unable to open database: %w", err)
 	}
+	if opts.withMaxOpenConnections > 0 {
+		db.DB().SetMaxOpenConns(opts.withMaxOpenConnections)
+	}
+	if opts.withMaxIdleConnections > 0 {
+		db.DB().SetMaxIdleConns(opts.withMaxIdleConnections)
+	}
+	if opts.withConnMaxLifetime > 0 {
+		db.DB().SetConnMaxLifetime(opts.withConnMaxLifetime)
+	}
 	return db, nil
 }
 
+// withConnectionParam adds a run-time parameter, which the driver sends to
+// the server when each connection starts, to a connection url or a
+// key/value connection string.
+func withConnectionParam(connectionUrl, key, value string) (string, error) {
+	if !strings.HasPrefix(connectionUrl, "postgres://") && !strings.HasPrefix(connectionUrl, "postgresql://") {
+		return fmt.Sprintf("%s %s=%s", connectionUrl, key, value), nil
+	}
+	u, err := url.Parse(connectionUrl)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse connection url: %w", err)
+	}
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
 // Migrate a database schema
 func Migrate(connectionUrl string, migrationsDirectory string) error {
 	if connectionUrl == "" {
@@ -63,35 +123,11 @@ func Migrate(connectionUrl string, migrationsDirectory string) error {
 
 // InitStore will execute the migrations needed to initialize the store. It
 // returns true if migrations actually ran; false if we were already current.
+// It refuses to migrate a dirty database, returning migrations.ErrDirty.
 func InitStore(dialect string, cleanup func() error, url string) (bool, error) {
-	var mErr *multierror.Error
-	// run migrations
-	source, err := migrations.NewMigrationSource(dialect)
+	ran, err := migrations.MigrateUp(dialect, url)
 	if err != nil {
-		mErr = multierror.Append(mErr, fmt.Errorf("error creating migration driver: %w", err))
-		if cleanup != nil {
-			if err := cleanup(); err != nil {
-				mErr = multierror.Append(mErr, fmt.Errorf("error cleaning up from creating driver: %w", err))
-			}
-		}
-		return false, mErr.ErrorOrNil()
-	}
-	m, err := migrate.NewWithSourceInstance("httpfs", source, url)
-	if err != nil {
-		mErr = multierror.Append(mErr, fmt.Errorf("error creating migrations: %w", err))
-		if cleanup != nil {
-			if err := cleanup(); err != nil {
-				mErr = multierror.Append(mErr, fmt.Errorf("error cleaning up from creating migrations: %w", err))
-			}
-		}
-		return false, mErr.ErrorOrNil()
-
-	}
-	if err := m.Up(); err != nil {
-		if err == migrate.ErrNoChange {
-			return false, nil
-		}
-		mErr = multierror.Append(mErr, fmt.Errorf("error running migrations: %w", err))
+		mErr := multierror.Append(nil, err)
 		if cleanup != nil {
 			if err := cleanup(); err != nil {
 				mErr = multierror.Append(mErr, fmt.Errorf("error cleaning up from running migrations: %w", err))
@@ -99,7 +135,7 @@ func InitStore(dialect string, cleanup func() error, url string) (bool, error) {
 		}
 		return false, mErr.ErrorOrNil()
 	}
-	return true, mErr.ErrorOrNil()
+	return ran, nil
 }
 
 func GetGormLogFormatter(log hclog.Logger) func(values ...interface{}) (messages []interface{}) {
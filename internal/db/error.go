@@ -1,7 +1,10 @@
 package db
 
 import (
+	"database/sql/driver"
 	"errors"
+	"io"
+	"syscall"
 
 	"github.com/lib/pq"
 )
@@ -89,3 +92,47 @@ func IsNotNullError(err error) bool {
 
 	return false
 }
+
+// IsTransientError returns a boolean indicating whether the error is known
+// to be transient, so the transaction which returned it can be retried:
+// serialization failures, deadlocks, and failover errors, as reported by
+// IsFailoverError.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pqError *pq.Error
+	if errors.As(err, &pqError) {
+		switch pqError.Code.Name() {
+		case "serialization_failure", "deadlock_detected":
+			return true
+		}
+	}
+
+	return IsFailoverError(err)
+}
+
+// IsFailoverError returns a boolean indicating whether the error is known to
+// be caused by the server going away or no longer being the primary, as it
+// does during a failover: lost connections, writes refused by a server which
+// is now read-only, and servers shutting down or starting up.
+func IsFailoverError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pqError *pq.Error
+	if errors.As(err, &pqError) {
+		switch pqError.Code.Name() {
+		case "read_only_sql_transaction", "admin_shutdown", "crash_shutdown", "cannot_connect_now":
+			return true
+		}
+		return pqError.Code.Class() == "08" // connection_exception
+	}
+
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
@@ -1,6 +1,10 @@
 package db
 
 import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"syscall"
 	"testing"
 
 	"github.com/lib/pq"
@@ -124,3 +128,82 @@ func TestError_IsNotNullError(t *testing.T) {
 		})
 	}
 }
+
+func TestError_IsTransientError(t *testing.T) {
+	var tests = []struct {
+		name string
+		in   error
+		want bool
+	}{
+		{
+			name: "nil-error",
+			in:   nil,
+			want: false,
+		},
+		{
+			name: "postgres-serialization-failure",
+			in: &pq.Error{
+				Code: pq.ErrorCode("40001"),
+			},
+			want: true,
+		},
+		{
+			name: "postgres-deadlock",
+			in: &pq.Error{
+				Code: pq.ErrorCode("40P01"),
+			},
+			want: true,
+		},
+		{
+			name: "postgres-connection-failure",
+			in: &pq.Error{
+				Code: pq.ErrorCode("08006"),
+			},
+			want: true,
+		},
+		{
+			name: "postgres-unique",
+			in: &pq.Error{
+				Code: pq.ErrorCode("23505"),
+			},
+			want: false,
+		},
+		{
+			name: "postgres-read-only",
+			in: &pq.Error{
+				Code: pq.ErrorCode("25006"),
+			},
+			want: true,
+		},
+		{
+			name: "postgres-admin-shutdown",
+			in: &pq.Error{
+				Code: pq.ErrorCode("57P01"),
+			},
+			want: true,
+		},
+		{
+			name: "bad-conn",
+			in:   fmt.Errorf("update: %w", driver.ErrBadConn),
+			want: true,
+		},
+		{
+			name: "connection-reset",
+			in:   fmt.Errorf("create: %w", syscall.ECONNRESET),
+			want: true,
+		},
+		{
+			name: "other",
+			in:   errors.New("other"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got := IsTransientError(tt.in)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
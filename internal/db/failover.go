@@ -0,0 +1,303 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/lib/pq"
+)
+
+// failoverConnector connects to the database of a connection url, resolving
+// its host again for each connection, so the connections made after a
+// failover reach the new primary rather than the old one. Its connections
+// watch for failover errors: the first one seen invalidates every connection
+// made before it, so the pool drops them all instead of finding each one
+// broken in turn.
+type failoverConnector struct {
+	connectionUrl string
+
+	// srvRecord is the name of a DNS SRV record listing the servers to
+	// connect to, in order of priority, if it's set. It replaces the host
+	// and port of connectionUrl.
+	srvRecord string
+
+	// requirePrimary is whether servers which refuse writes are skipped, so
+	// connections are only made to the primary.
+	requirePrimary bool
+
+	// lookupSRV and connect are replaced in tests.
+	lookupSRV func(ctx context.Context, name string) ([]*net.SRV, error)
+	connect   func(ctx context.Context, connectionUrl string) (driver.Conn, error)
+
+	// generation is incremented by each failover error, and connections made
+	// in an earlier generation are discarded by the pool.
+	generation uint64
+}
+
+var _ driver.Connector = (*failoverConnector)(nil)
+
+func newFailoverConnector(connectionUrl, srvRecord string, requirePrimary bool) *failoverConnector {
+	return &failoverConnector{
+		connectionUrl:  connectionUrl,
+		srvRecord:      srvRecord,
+		requirePrimary: requirePrimary,
+		lookupSRV: func(ctx context.Context, name string) ([]*net.SRV, error) {
+			_, addrs, err := net.DefaultResolver.LookupSRV(ctx, "", "", name)
+			return addrs, err
+		},
+		connect: func(ctx context.Context, connectionUrl string) (driver.Conn, error) {
+			c, err := pq.NewConnector(connectionUrl)
+			if err != nil {
+				return nil, err
+			}
+			return c.Connect(ctx)
+		},
+	}
+}
+
+// Connect connects to the first server which accepts the connection, and
+// which is the primary if that's required.
+func (c *failoverConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	generation := atomic.LoadUint64(&c.generation)
+	urls, err := c.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var errs error
+	for _, u := range urls {
+		conn, err := c.connect(ctx, u)
+		if err == nil && c.requirePrimary {
+			if err = checkPrimary(ctx, conn); err != nil {
+				conn.Close()
+			}
+		}
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		return &failoverConn{Conn: conn, connector: c, generation: generation}, nil
+	}
+	return nil, errs
+}
+
+// Driver returns the postgres driver.
+func (c *failoverConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+// resolve returns the connection urls of the servers to connect to, in the
+// order to try them in.
+func (c *failoverConnector) resolve(ctx context.Context) ([]string, error) {
+	if c.srvRecord == "" {
+		return []string{c.connectionUrl}, nil
+	}
+	addrs, err := c.lookupSRV(ctx, c.srvRecord)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up srv record %q: %w", c.srvRecord, err)
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("srv record %q lists no servers", c.srvRecord)
+	}
+	urls := make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		u, err := withConnectionParam(c.connectionUrl, "host", strings.TrimSuffix(a.Target, "."))
+		if err != nil {
+			return nil, err
+		}
+		if u, err = withConnectionParam(u, "port", strconv.Itoa(int(a.Port))); err != nil {
+			return nil, err
+		}
+		urls = append(urls, u)
+	}
+	return urls, nil
+}
+
+// invalidate starts a new generation of connections, if the connection which
+// saw a failover error is of the current one.
+func (c *failoverConnector) invalidate(generation uint64) {
+	atomic.CompareAndSwapUint64(&c.generation, generation, generation+1)
+}
+
+// checkPrimary returns an error if the server of conn refuses writes.
+func checkPrimary(ctx context.Context, conn driver.Conn) error {
+	q, ok := conn.(driver.QueryerContext)
+	if !ok {
+		return nil
+	}
+	rows, err := q.QueryContext(ctx, "show transaction_read_only", nil)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		return err
+	}
+	var readOnly string
+	switch v := dest[0].(type) {
+	case string:
+		readOnly = v
+	case []byte:
+		readOnly = string(v)
+	}
+	if readOnly == "on" {
+		return errors.New("server refuses writes")
+	}
+	return nil
+}
+
+// failoverConn is a connection of a failoverConnector. It forwards everything
+// to the postgres connection it wraps, watching the errors.
+type failoverConn struct {
+	driver.Conn
+	connector  *failoverConnector
+	generation uint64
+	// bad is whether the connection saw a failover error, and inTx whether
+	// it's in a transaction. They're only used by the goroutine using the
+	// connection.
+	bad  bool
+	inTx bool
+}
+
+var (
+	_ driver.ConnBeginTx        = (*failoverConn)(nil)
+	_ driver.ConnPrepareContext = (*failoverConn)(nil)
+	_ driver.ExecerContext      = (*failoverConn)(nil)
+	_ driver.QueryerContext     = (*failoverConn)(nil)
+	_ driver.Pinger             = (*failoverConn)(nil)
+	_ driver.SessionResetter    = (*failoverConn)(nil)
+	_ driver.NamedValueChecker  = (*failoverConn)(nil)
+)
+
+// observe invalidates the connections of the connector if err is a failover
+// error. A statement refused by a read-only server had no effect, so outside
+// a transaction it's reported as driver.ErrBadConn, which makes database/sql
+// retry it on a new connection.
+func (c *failoverConn) observe(err error) error {
+	if !IsFailoverError(err) {
+		return err
+	}
+	c.bad = true
+	c.connector.invalidate(c.generation)
+	var pqError *pq.Error
+	if !c.inTx && errors.As(err, &pqError) && pqError.Code.Name() == "read_only_sql_transaction" {
+		return driver.ErrBadConn
+	}
+	return err
+}
+
+// valid returns whether the connection can still be used.
+func (c *failoverConn) valid() bool {
+	return !c.bad && c.generation == atomic.LoadUint64(&c.connector.generation)
+}
+
+func (c *failoverConn) Prepare(query string) (driver.Stmt, error) {
+	stmt, err := c.Conn.Prepare(query)
+	return stmt, c.observe(err)
+}
+
+func (c *failoverConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	p, ok := c.Conn.(driver.ConnPrepareContext)
+	if !ok {
+		return c.Prepare(query)
+	}
+	stmt, err := p.PrepareContext(ctx, query)
+	return stmt, c.observe(err)
+}
+
+func (c *failoverConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *failoverConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	var tx driver.Tx
+	var err error
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		tx, err = b.BeginTx(ctx, opts)
+	} else {
+		tx, err = c.Conn.Begin()
+	}
+	if err != nil {
+		return nil, c.observe(err)
+	}
+	c.inTx = true
+	return &failoverTx{tx: tx, conn: c}, nil
+}
+
+func (c *failoverConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	res, err := e.ExecContext(ctx, query, args)
+	return res, c.observe(err)
+}
+
+func (c *failoverConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	rows, err := q.QueryContext(ctx, query, args)
+	return rows, c.observe(err)
+}
+
+func (c *failoverConn) Ping(ctx context.Context) error {
+	if !c.valid() {
+		return driver.ErrBadConn
+	}
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return c.observe(p.Ping(ctx))
+	}
+	return nil
+}
+
+// ResetSession discards the connection before it's reused, if it's been
+// invalidated.
+func (c *failoverConn) ResetSession(ctx context.Context) error {
+	if !c.valid() {
+		return driver.ErrBadConn
+	}
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid discards the connection when it's returned to the pool, if it's
+// been invalidated.
+func (c *failoverConn) IsValid() bool {
+	return c.valid()
+}
+
+func (c *failoverConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// failoverTx is a transaction of a failoverConn.
+type failoverTx struct {
+	tx   driver.Tx
+	conn *failoverConn
+}
+
+func (t *failoverTx) Commit() error {
+	err := t.conn.observe(t.tx.Commit())
+	t.conn.inTx = false
+	return err
+}
+
+func (t *failoverTx) Rollback() error {
+	err := t.conn.observe(t.tx.Rollback())
+	t.conn.inTx = false
+	return err
+}
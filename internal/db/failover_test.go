@@ -0,0 +1,248 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFailoverConn is a connection to a fake server, which fails every
+// statement with err, if it's set.
+type testFailoverConn struct {
+	url    string
+	err    error
+	closed bool
+}
+
+func (c *testFailoverConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *testFailoverConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (c *testFailoverConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *testFailoverConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func TestFailoverConnector_Resolve(t *testing.T) {
+	srvs := []*net.SRV{
+		{Target: "db-1.example.com.", Port: 5432},
+		{Target: "db-2.example.com.", Port: 5433},
+	}
+	tests := []struct {
+		name          string
+		connectionUrl string
+		srvRecord     string
+		want          []string
+	}{
+		{
+			name:          "no-srv-record",
+			connectionUrl: "postgres://boundary@db.example.com/boundary",
+			want:          []string{"postgres://boundary@db.example.com/boundary"},
+		},
+		{
+			name:          "url",
+			connectionUrl: "postgres://boundary@db.example.com/boundary",
+			srvRecord:     "_postgresql._tcp.example.com",
+			want: []string{
+				"postgres://boundary@db.example.com/boundary?host=db-1.example.com&port=5432",
+				"postgres://boundary@db.example.com/boundary?host=db-2.example.com&port=5433",
+			},
+		},
+		{
+			name:          "key-value",
+			connectionUrl: "user=boundary dbname=boundary",
+			srvRecord:     "_postgresql._tcp.example.com",
+			want: []string{
+				"user=boundary dbname=boundary host=db-1.example.com port=5432",
+				"user=boundary dbname=boundary host=db-2.example.com port=5433",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			c := newFailoverConnector(tt.connectionUrl, tt.srvRecord, false)
+			c.lookupSRV = func(_ context.Context, name string) ([]*net.SRV, error) {
+				assert.Equal(tt.srvRecord, name)
+				return srvs, nil
+			}
+			got, err := c.resolve(context.Background())
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestFailoverConnector_Connect(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	c := newFailoverConnector("user=boundary", "_postgresql._tcp.example.com", false)
+	c.lookupSRV = func(context.Context, string) ([]*net.SRV, error) {
+		return []*net.SRV{{Target: "down", Port: 5432}, {Target: "up", Port: 5432}}, nil
+	}
+	c.connect = func(_ context.Context, u string) (driver.Conn, error) {
+		if u == "user=boundary host=down port=5432" {
+			return nil, errors.New("connection refused")
+		}
+		return &testFailoverConn{url: u}, nil
+	}
+	conn, err := c.Connect(context.Background())
+	require.NoError(err)
+	assert.Equal("user=boundary host=up port=5432", conn.(*failoverConn).Conn.(*testFailoverConn).url)
+
+	c.lookupSRV = func(context.Context, string) ([]*net.SRV, error) {
+		return nil, nil
+	}
+	_, err = c.Connect(context.Background())
+	assert.Error(err)
+}
+
+func TestFailoverConn_Invalidation(t *testing.T) {
+	assert := assert.New(t)
+	readOnly := &pq.Error{Code: pq.ErrorCode("25006")}
+	var conns []*testFailoverConn
+	c := newFailoverConnector("user=boundary", "", false)
+	c.connect = func(context.Context, string) (driver.Conn, error) {
+		conn := &testFailoverConn{}
+		conns = append(conns, conn)
+		return conn, nil
+	}
+	ctx := context.Background()
+	first, err := c.Connect(ctx)
+	assert.NoError(err)
+	second, err := c.Connect(ctx)
+	assert.NoError(err)
+	fc, sc := first.(*failoverConn), second.(*failoverConn)
+	assert.NoError(sc.ResetSession(ctx))
+
+	// A write refused by a demoted primary is retried on a new connection,
+	// and invalidates the connections made before it.
+	conns[0].err = readOnly
+	_, err = fc.ExecContext(ctx, "insert", nil)
+	assert.Equal(driver.ErrBadConn, err)
+	assert.False(fc.IsValid())
+	assert.False(sc.IsValid())
+	assert.Equal(driver.ErrBadConn, sc.ResetSession(ctx))
+
+	third, err := c.Connect(ctx)
+	assert.NoError(err)
+	tc := third.(*failoverConn)
+	assert.True(tc.IsValid())
+
+	// Within a transaction the error is returned as it is, since the
+	// transaction is aborted.
+	conns[2].err = readOnly
+	tc.inTx = true
+	_, err = tc.ExecContext(ctx, "insert", nil)
+	assert.Equal(readOnly, err)
+	assert.False(tc.IsValid())
+
+	// Other errors leave the connections alone.
+	fourth, err := c.Connect(ctx)
+	assert.NoError(err)
+	conns[3].err = &pq.Error{Code: pq.ErrorCode("23505")}
+	_, err = fourth.(*failoverConn).ExecContext(ctx, "insert", nil)
+	assert.Error(err)
+	assert.True(fourth.(*failoverConn).IsValid())
+}
+
+func TestFailoverConnector_Pool(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	var connects int
+	c := newFailoverConnector("user=boundary", "", false)
+	c.connect = func(context.Context, string) (driver.Conn, error) {
+		connects++
+		conn := &testFailoverConn{}
+		if connects == 1 {
+			// The first server has been demoted.
+			conn.err = &pq.Error{Code: pq.ErrorCode("25006")}
+		}
+		return conn, nil
+	}
+	pool := sql.OpenDB(c)
+	defer pool.Close()
+
+	res, err := pool.Exec("insert")
+	require.NoError(err)
+	n, err := res.RowsAffected()
+	require.NoError(err)
+	assert.Equal(int64(1), n)
+	assert.Equal(2, connects)
+}
+
+func TestDb_RetryRead(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name         string
+		rw           *Db
+		err          error
+		wantAttempts int
+	}{
+		{
+			name:         "success",
+			rw:           &Db{retryTransientErrors: true},
+			wantAttempts: 1,
+		},
+		{
+			name:         "transient",
+			rw:           &Db{retryTransientErrors: true},
+			err:          driver.ErrBadConn,
+			wantAttempts: 3,
+		},
+		{
+			name:         "not-transient",
+			rw:           &Db{retryTransientErrors: true},
+			err:          errors.New("other"),
+			wantAttempts: 1,
+		},
+		{
+			name:         "not-enabled",
+			rw:           &Db{},
+			err:          driver.ErrBadConn,
+			wantAttempts: 1,
+		},
+		{
+			name:         "in-transaction",
+			rw:           &Db{retryTransientErrors: true, inTx: true},
+			err:          driver.ErrBadConn,
+			wantAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			var attempts int
+			err := tt.rw.retryRead(ctx, func() error {
+				attempts++
+				// Transient errors go away after the third attempt.
+				if attempts < 3 || !IsTransientError(tt.err) {
+					return tt.err
+				}
+				return nil
+			})
+			assert.Equal(tt.wantAttempts, attempts)
+			if tt.wantAttempts == 3 || tt.err == nil {
+				assert.NoError(err)
+			} else {
+				assert.Error(err)
+			}
+		})
+	}
+}
@@ -2,26 +2,110 @@ package db
 
 import (
 	"fmt"
+	"io"
+	mathrand "math/rand"
+	"sync"
 
 	"github.com/hashicorp/vault/sdk/helper/base62"
 )
 
-func NewPrivateId(prefix string) (string, error) {
-	return newId(prefix)
+// IdGenerator returns the unique portion of an id, which is appended to the
+// id's prefix. The result must be longer than 8 characters to satisfy the
+// wt_public_id and wt_private_id domains.
+type IdGenerator func() (string, error)
+
+const (
+	// idLength is the length of the ids made by the package's generators.
+	idLength = 10
+
+	base62Charset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// Base62IdGenerator is the default IdGenerator: 10 random base62 characters.
+func Base62IdGenerator() (string, error) {
+	return base62.Random(idLength)
+}
+
+// NewReaderIdGenerator returns an IdGenerator of 10 base62 characters drawn
+// from r, so ids are reproducible when r is. The generator is safe for
+// concurrent use, but ids are only reproducible if they are generated in the
+// same order.
+func NewReaderIdGenerator(r io.Reader) IdGenerator {
+	var mu sync.Mutex
+	b := make([]byte, 1)
+	return func() (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		id := make([]byte, 0, idLength)
+		for len(id) < idLength {
+			if _, err := io.ReadFull(r, b); err != nil {
+				return "", err
+			}
+			// 248 is the largest multiple of 62 that fits in a byte; larger
+			// bytes are skipped so every character is equally likely.
+			if b[0] >= 248 {
+				continue
+			}
+			id = append(id, base62Charset[b[0]%62])
+		}
+		return string(id), nil
+	}
+}
+
+// NewSeededIdGenerator returns an IdGenerator whose ids are determined by the
+// seed. It's meant for tests and generating seed data; its ids are not
+// unpredictable.
+func NewSeededIdGenerator(seed int64) IdGenerator {
+	return NewReaderIdGenerator(mathrand.New(mathrand.NewSource(seed)))
 }
 
-// NewPublicId creates a new public id with the prefix
-func NewPublicId(prefix string) (string, error) {
-	return newId(prefix)
+var (
+	idGeneratorMu      sync.RWMutex
+	defaultIdGenerator IdGenerator = Base62IdGenerator
+)
+
+// SetDefaultIdGenerator sets the IdGenerator used by NewPublicId and
+// NewPrivateId when the WithIdGenerator option isn't given. A nil gen restores
+// Base62IdGenerator. It's intended to be called once at startup, or by tests
+// that need ids in a known format.
+func SetDefaultIdGenerator(gen IdGenerator) {
+	if gen == nil {
+		gen = Base62IdGenerator
+	}
+	idGeneratorMu.Lock()
+	defer idGeneratorMu.Unlock()
+	defaultIdGenerator = gen
+}
+
+// NewPrivateId creates a new private id with the prefix. Supports the
+// WithIdGenerator option.
+func NewPrivateId(prefix string, opt ...Option) (string, error) {
+	return newId(prefix, opt...)
 }
 
-func newId(prefix string) (string, error) {
+// NewPublicId creates a new public id with the prefix. Supports the
+// WithIdGenerator option.
+func NewPublicId(prefix string, opt ...Option) (string, error) {
+	return newId(prefix, opt...)
+}
+
+func newId(prefix string, opt ...Option) (string, error) {
 	if prefix == "" {
 		return "", fmt.Errorf("missing prefix %w", ErrInvalidParameter)
 	}
-	publicId, err := base62.Random(10)
+	opts := GetOpts(opt...)
+	gen := opts.withIdGenerator
+	if gen == nil {
+		idGeneratorMu.RLock()
+		gen = defaultIdGenerator
+		idGeneratorMu.RUnlock()
+	}
+	publicId, err := gen()
 	if err != nil {
 		return "", fmt.Errorf("unable to generate id: %w", err)
 	}
+	if publicId == "" {
+		return "", fmt.Errorf("unable to generate id: generator returned an empty id: %w", ErrInvalidParameter)
+	}
 	return fmt.Sprintf("%s_%s", prefix, publicId), nil
 }
@@ -1,6 +1,9 @@
 package db
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -94,3 +97,96 @@ func TestNewPrivateId(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPublicId_IdGenerator(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	var n int
+	counter := func() (string, error) {
+		n++
+		return fmt.Sprintf("%010d", n), nil
+	}
+
+	got, err := NewPublicId("id", WithIdGenerator(counter))
+	require.NoError(err)
+	assert.Equal("id_0000000001", got)
+	got, err = NewPrivateId("id", WithIdGenerator(counter))
+	require.NoError(err)
+	assert.Equal("id_0000000002", got)
+
+	genErr := errors.New("no entropy")
+	_, err = NewPublicId("id", WithIdGenerator(func() (string, error) { return "", genErr }))
+	assert.True(errors.Is(err, genErr))
+	_, err = NewPublicId("id", WithIdGenerator(func() (string, error) { return "", nil }))
+	assert.True(errors.Is(err, ErrInvalidParameter))
+
+	SetDefaultIdGenerator(counter)
+	got, err = NewPublicId("id")
+	SetDefaultIdGenerator(nil)
+	require.NoError(err)
+	assert.Equal("id_0000000003", got)
+
+	got, err = NewPublicId("id")
+	require.NoError(err)
+	assert.Len(got, 10+len("id_"))
+}
+
+func TestNewSeededIdGenerator(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	gen1, gen2 := NewSeededIdGenerator(42), NewSeededIdGenerator(42)
+	other := NewSeededIdGenerator(43)
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		id1, err := gen1()
+		require.NoError(err)
+		id2, err := gen2()
+		require.NoError(err)
+		id3, err := other()
+		require.NoError(err)
+		assert.Equal(id1, id2)
+		assert.NotEqual(id1, id3)
+		assert.Len(id1, idLength)
+		for _, c := range id1 {
+			assert.Contains(base62Charset, string(c))
+		}
+		assert.False(seen[id1], "duplicate id %s", id1)
+		seen[id1] = true
+	}
+}
+
+func TestNewReaderIdGenerator(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	// Bytes of 248 and above are skipped.
+	gen := NewReaderIdGenerator(bytes.NewReader([]byte{0, 255, 1, 61, 62, 248, 123, 10, 36, 9, 2, 3}))
+	id, err := gen()
+	require.NoError(err)
+	assert.Equal("01z0zAa923", id)
+
+	_, err = gen()
+	assert.Error(err)
+}
+
+func TestTestSeedIds(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	var first []string
+	for i := 0; i < 2; i++ {
+		t.Run("seeded", func(t *testing.T) {
+			TestSeedIds(t, 7)
+			var ids []string
+			for j := 0; j < 3; j++ {
+				id, err := NewPublicId("id")
+				require.NoError(err)
+				ids = append(ids, id)
+			}
+			if first == nil {
+				first = ids
+				return
+			}
+			assert.Equal(first, ids)
+		})
+	}
+	want, err := NewSeededIdGenerator(7)()
+	require.NoError(err)
+	got, err := NewPublicId("id")
+	require.NoError(err)
+	assert.NotEqual("id_"+want, got)
+}
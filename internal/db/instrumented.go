@@ -0,0 +1,279 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// ErrConcurrencyLimit is returned by an Instrumented reader/writer when the
+// context ends while waiting for a free operation slot.
+var ErrConcurrencyLimit = errors.New("db operation concurrency limit reached")
+
+var metricsPrefix = []string{"boundary", "db"}
+
+// Instrumented decorates a Reader and Writer. Every operation emits a
+// latency measurement and, on failure, an error counter to go-metrics,
+// labelled with the operation and the resource's table. Writes also record the
+// number of rows affected. If a concurrency limit is set, operations wait for
+// a free slot before running; operations made from inside DoTx run on the
+// transaction's slot and aren't limited again.
+type Instrumented struct {
+	reader  Reader
+	writer  Writer
+	metrics *metrics.Metrics
+	slots   chan struct{}
+}
+
+// ensure that Instrumented implements the interfaces of: Reader and Writer
+var _ Reader = (*Instrumented)(nil)
+var _ Writer = (*Instrumented)(nil)
+
+// NewInstrumented creates an Instrumented reader/writer. Supports the options:
+// WithMetrics, which sets the go-metrics instance to emit to instead of the
+// global one, and WithMaxConcurrency.
+func NewInstrumented(r Reader, w Writer, opt ...Option) (*Instrumented, error) {
+	if r == nil {
+		return nil, fmt.Errorf("new instrumented: nil reader: %w", ErrInvalidParameter)
+	}
+	if w == nil {
+		return nil, fmt.Errorf("new instrumented: nil writer: %w", ErrInvalidParameter)
+	}
+	opts := GetOpts(opt...)
+	if opts.withMaxConcurrency < 0 {
+		return nil, fmt.Errorf("new instrumented: negative max concurrency: %w", ErrInvalidParameter)
+	}
+	i := &Instrumented{
+		reader:  r,
+		writer:  w,
+		metrics: opts.withMetrics,
+	}
+	if i.metrics == nil {
+		i.metrics = metrics.Default()
+	}
+	if opts.withMaxConcurrency > 0 {
+		i.slots = make(chan struct{}, opts.withMaxConcurrency)
+	}
+	return i, nil
+}
+
+// acquire waits for an operation slot and returns the func that releases it.
+func (i *Instrumented) acquire(ctx context.Context) (func(), error) {
+	if i.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case i.slots <- struct{}{}:
+		return func() { <-i.slots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w: %v", ErrConcurrencyLimit, ctx.Err())
+	}
+}
+
+// observe emits the metrics for an operation that started at start.
+func (i *Instrumented) observe(op string, resource interface{}, start time.Time, rows int, err error) {
+	labels := []metrics.Label{
+		{Name: "op", Value: op},
+		{Name: "resource", Value: resourceName(resource)},
+	}
+	i.metrics.MeasureSinceWithLabels(append(metricsPrefix, "latency"), start, labels)
+	if err != nil {
+		i.metrics.IncrCounterWithLabels(append(metricsPrefix, "errors"), 1, labels)
+		return
+	}
+	if rows > 0 {
+		i.metrics.IncrCounterWithLabels(append(metricsPrefix, "rows_affected"), float32(rows), labels)
+	}
+}
+
+// resourceName returns the table name of the resource, or of the first
+// element if the resource is a slice, falling back to its Go type name.
+func resourceName(resource interface{}) string {
+	type tableNamer interface {
+		TableName() string
+	}
+	switch r := resource.(type) {
+	case nil:
+		return "none"
+	case tableNamer:
+		return r.TableName()
+	case []interface{}:
+		if len(r) == 0 {
+			return "none"
+		}
+		return resourceName(r[0])
+	}
+	t := reflect.TypeOf(resource)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if tn, ok := reflect.New(t).Interface().(tableNamer); ok {
+		return tn.TableName()
+	}
+	return t.Name()
+}
+
+// LookupById implements Reader.LookupById.
+func (i *Instrumented) LookupById(ctx context.Context, resourceWithIder interface{}, opt ...Option) (err error) {
+	release, err := i.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	defer func(start time.Time) { i.observe("lookup_by_id", resourceWithIder, start, 0, err) }(time.Now())
+	return i.reader.LookupById(ctx, resourceWithIder, opt...)
+}
+
+// LookupByPublicId implements Reader.LookupByPublicId.
+func (i *Instrumented) LookupByPublicId(ctx context.Context, resource ResourcePublicIder, opt ...Option) (err error) {
+	release, err := i.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	defer func(start time.Time) { i.observe("lookup_by_public_id", resource, start, 0, err) }(time.Now())
+	return i.reader.LookupByPublicId(ctx, resource, opt...)
+}
+
+// LookupWhere implements Reader.LookupWhere.
+func (i *Instrumented) LookupWhere(ctx context.Context, resource interface{}, where string, args ...interface{}) (err error) {
+	release, err := i.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	defer func(start time.Time) { i.observe("lookup_where", resource, start, 0, err) }(time.Now())
+	return i.reader.LookupWhere(ctx, resource, where, args...)
+}
+
+// SearchWhere implements Reader.SearchWhere.
+func (i *Instrumented) SearchWhere(ctx context.Context, resources interface{}, where string, args []interface{}, opt ...Option) (err error) {
+	release, err := i.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	defer func(start time.Time) { i.observe("search_where", resources, start, 0, err) }(time.Now())
+	return i.reader.SearchWhere(ctx, resources, where, args, opt...)
+}
+
+// Query implements Reader.Query. The slot is released when Query returns, not
+// when the rows are closed.
+func (i *Instrumented) Query(ctx context.Context, sql string, values []interface{}, opt ...Option) (rows *sql.Rows, err error) {
+	release, err := i.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	defer func(start time.Time) { i.observe("query", nil, start, 0, err) }(time.Now())
+	return i.reader.Query(ctx, sql, values, opt...)
+}
+
+// ScanRows implements Reader.ScanRows.
+func (i *Instrumented) ScanRows(rows *sql.Rows, result interface{}) error {
+	return i.reader.ScanRows(rows, result)
+}
+
+// DoTx implements Writer.DoTx. The handler is given an Instrumented reader and
+// writer for the transaction which emit metrics but share this call's slot.
+func (i *Instrumented) DoTx(ctx context.Context, retries uint, backOff Backoff, handler TxHandler) (info RetryInfo, err error) {
+	release, err := i.acquire(ctx)
+	if err != nil {
+		return RetryInfo{}, err
+	}
+	defer release()
+	defer func(start time.Time) { i.observe("tx", nil, start, 0, err) }(time.Now())
+	return i.writer.DoTx(ctx, retries, backOff, func(r Reader, w Writer) error {
+		return handler(&Instrumented{reader: r, writer: w, metrics: i.metrics}, &Instrumented{reader: r, writer: w, metrics: i.metrics})
+	})
+}
+
+// Update implements Writer.Update.
+func (i *Instrumented) Update(ctx context.Context, resource interface{}, fieldMaskPaths []string, setToNullPaths []string, opt ...Option) (rows int, err error) {
+	release, err := i.acquire(ctx)
+	if err != nil {
+		return NoRowsAffected, err
+	}
+	defer release()
+	defer func(start time.Time) { i.observe("update", resource, start, rows, err) }(time.Now())
+	return i.writer.Update(ctx, resource, fieldMaskPaths, setToNullPaths, opt...)
+}
+
+// Create implements Writer.Create.
+func (i *Instrumented) Create(ctx context.Context, resource interface{}, opt ...Option) (err error) {
+	release, err := i.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	defer func(start time.Time) { i.observe("create", resource, start, 1, err) }(time.Now())
+	return i.writer.Create(ctx, resource, opt...)
+}
+
+// CreateItems implements Writer.CreateItems.
+func (i *Instrumented) CreateItems(ctx context.Context, createItems []interface{}, opt ...Option) (err error) {
+	release, err := i.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	defer func(start time.Time) { i.observe("create_items", createItems, start, len(createItems), err) }(time.Now())
+	return i.writer.CreateItems(ctx, createItems, opt...)
+}
+
+// Delete implements Writer.Delete.
+func (i *Instrumented) Delete(ctx context.Context, resource interface{}, opt ...Option) (rows int, err error) {
+	release, err := i.acquire(ctx)
+	if err != nil {
+		return NoRowsAffected, err
+	}
+	defer release()
+	defer func(start time.Time) { i.observe("delete", resource, start, rows, err) }(time.Now())
+	return i.writer.Delete(ctx, resource, opt...)
+}
+
+// DeleteItems implements Writer.DeleteItems.
+func (i *Instrumented) DeleteItems(ctx context.Context, deleteItems []interface{}, opt ...Option) (rows int, err error) {
+	release, err := i.acquire(ctx)
+	if err != nil {
+		return NoRowsAffected, err
+	}
+	defer release()
+	defer func(start time.Time) { i.observe("delete_items", deleteItems, start, rows, err) }(time.Now())
+	return i.writer.DeleteItems(ctx, deleteItems, opt...)
+}
+
+// Exec implements Writer.Exec.
+func (i *Instrumented) Exec(ctx context.Context, sql string, values []interface{}, opt ...Option) (rows int, err error) {
+	release, err := i.acquire(ctx)
+	if err != nil {
+		return NoRowsAffected, err
+	}
+	defer release()
+	defer func(start time.Time) { i.observe("exec", nil, start, rows, err) }(time.Now())
+	return i.writer.Exec(ctx, sql, values, opt...)
+}
+
+// GetTicket implements Writer.GetTicket.
+func (i *Instrumented) GetTicket(resource interface{}) (*store.Ticket, error) {
+	return i.writer.GetTicket(resource)
+}
+
+// WriteOplogEntryWith implements Writer.WriteOplogEntryWith.
+func (i *Instrumented) WriteOplogEntryWith(ctx context.Context, wrapper wrapping.Wrapper, ticket *store.Ticket, metadata oplog.Metadata, msgs []*oplog.Message, opt ...Option) (err error) {
+	release, err := i.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	defer func(start time.Time) { i.observe("write_oplog", nil, start, len(msgs), err) }(time.Now())
+	return i.writer.WriteOplogEntryWith(ctx, wrapper, ticket, metadata, msgs, opt...)
+}
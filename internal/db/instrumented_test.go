@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/boundary/internal/db/db_test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMetrics(t *testing.T) (*metrics.Metrics, *metrics.InmemSink) {
+	t.Helper()
+	sink := metrics.NewInmemSink(time.Minute, time.Minute)
+	conf := metrics.DefaultConfig("test")
+	conf.EnableHostname = false
+	conf.EnableRuntimeMetrics = false
+	m, err := metrics.New(conf, sink)
+	require.NoError(t, err)
+	return m, sink
+}
+
+func TestNewInstrumented(t *testing.T) {
+	conn, _ := TestSetup(t, "postgres")
+	rw := New(conn)
+
+	_, err := NewInstrumented(nil, rw)
+	assert.True(t, errors.Is(err, ErrInvalidParameter))
+	_, err = NewInstrumented(rw, nil)
+	assert.True(t, errors.Is(err, ErrInvalidParameter))
+	_, err = NewInstrumented(rw, rw, WithMaxConcurrency(-1))
+	assert.True(t, errors.Is(err, ErrInvalidParameter))
+	got, err := NewInstrumented(rw, rw)
+	require.NoError(t, err)
+	assert.Nil(t, got.slots)
+}
+
+func TestInstrumented_Metrics(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := TestSetup(t, "postgres")
+	rw := New(conn)
+	m, sink := testMetrics(t)
+	i, err := NewInstrumented(rw, rw, WithMetrics(m))
+	require.NoError(err)
+	ctx := context.Background()
+
+	u := testUser(t, nil, "instrumented", "", "")
+	require.NoError(i.Create(ctx, u))
+	found := &db_test.TestUser{StoreTestUser: &db_test.StoreTestUser{PublicId: u.PublicId}}
+	require.NoError(i.LookupByPublicId(ctx, found))
+	var users []*db_test.TestUser
+	require.NoError(i.SearchWhere(ctx, &users, "public_id = ?", []interface{}{u.PublicId}))
+	assert.Len(users, 1)
+	_, err = i.DoTx(ctx, StdRetryCnt, ExpBackoff{}, func(r Reader, w Writer) error {
+		u.Name = "updated"
+		_, err := w.Update(ctx, u, []string{"Name"}, nil)
+		return err
+	})
+	require.NoError(err)
+	notFound := &db_test.TestUser{StoreTestUser: &db_test.StoreTestUser{PublicId: "not-found"}}
+	require.Error(i.LookupByPublicId(ctx, notFound))
+
+	data := sink.Data()
+	require.NotEmpty(data)
+	var keys []string
+	for k := range data[0].Samples {
+		keys = append(keys, k)
+	}
+	for k := range data[0].Counters {
+		keys = append(keys, k)
+	}
+	all := strings.Join(keys, "\n")
+	for _, want := range []string{
+		"boundary.db.latency;op=create;resource=db_test_user",
+		"boundary.db.latency;op=lookup_by_public_id;resource=db_test_user",
+		"boundary.db.latency;op=search_where;resource=db_test_user",
+		"boundary.db.latency;op=tx;resource=none",
+		"boundary.db.rows_affected;op=update;resource=db_test_user",
+		"boundary.db.errors;op=lookup_by_public_id;resource=db_test_user",
+	} {
+		assert.Contains(all, want)
+	}
+}
+
+func TestInstrumented_MaxConcurrency(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := TestSetup(t, "postgres")
+	rw := New(conn)
+	m, _ := testMetrics(t)
+	i, err := NewInstrumented(rw, rw, WithMetrics(m), WithMaxConcurrency(1))
+	require.NoError(err)
+	ctx := context.Background()
+	u := testUser(t, conn, "limited", "", "")
+
+	_, err = i.DoTx(ctx, StdRetryCnt, ExpBackoff{}, func(r Reader, w Writer) error {
+		// Operations on the transaction share its slot
+		found := &db_test.TestUser{StoreTestUser: &db_test.StoreTestUser{PublicId: u.PublicId}}
+		if err := r.LookupByPublicId(ctx, found); err != nil {
+			return err
+		}
+
+		// Anything else waits for the slot until its context ends
+		waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		err := i.LookupByPublicId(waitCtx, found)
+		assert.True(errors.Is(err, ErrConcurrencyLimit), err)
+		return nil
+	})
+	require.NoError(err)
+
+	// The slot is released afterwards
+	found := &db_test.TestUser{StoreTestUser: &db_test.StoreTestUser{PublicId: u.PublicId}}
+	require.NoError(i.LookupByPublicId(ctx, found))
+}
@@ -0,0 +1,174 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+)
+
+// ErrDirty is returned when a database was left part way through a migration
+// by an earlier failure. The schema must be repaired by hand before any more
+// migrations can be run against it.
+var ErrDirty = errors.New("database is dirty")
+
+// State describes the schema version of a database.
+type State struct {
+	// Version is the version of the last migration applied to the database,
+	// or 0 if none have been.
+	Version uint
+
+	// Dirty is true if the migration to Version failed part way through.
+	Dirty bool
+
+	// Latest is the version of the newest migration available.
+	Latest uint
+
+	// Pending are the versions of the migrations which haven't been applied
+	// to the database yet, in the order they will be applied.
+	Pending []uint
+}
+
+// Status returns the schema version of the database at url, along with the
+// migrations which are waiting to be applied to it.
+func Status(dialect, url string) (*State, error) {
+	versions, err := versions(dialect)
+	if err != nil {
+		return nil, fmt.Errorf("migration status: %w", err)
+	}
+	state := &State{}
+	err = withMigrate(dialect, url, func(m *migrate.Migrate) error {
+		var err error
+		state.Version, state.Dirty, err = version(m)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("migration status: %w", err)
+	}
+	for _, v := range versions {
+		if v > state.Version {
+			state.Pending = append(state.Pending, v)
+		}
+	}
+	if len(versions) > 0 {
+		state.Latest = versions[len(versions)-1]
+	}
+	return state, nil
+}
+
+// MigrateUp applies all pending migrations to the database at url. It returns
+// true if any migrations were applied and false if the database was already
+// up to date. It refuses to run against a dirty database, returning ErrDirty.
+func MigrateUp(dialect, url string) (bool, error) {
+	var changed bool
+	err := withMigrate(dialect, url, func(m *migrate.Migrate) error {
+		if _, dirty, err := version(m); err != nil {
+			return err
+		} else if dirty {
+			return ErrDirty
+		}
+		switch err := m.Up(); {
+		case err == migrate.ErrNoChange:
+		case err != nil:
+			return fmt.Errorf("error running migrations: %w", err)
+		default:
+			changed = true
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("migrate up: %w", err)
+	}
+	return changed, nil
+}
+
+// MigrateDown reverts the last steps migrations applied to the database at
+// url. steps must be at least 1. It refuses to run against a dirty database,
+// returning ErrDirty.
+func MigrateDown(dialect, url string, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("migrate down: steps must be at least 1, got %d", steps)
+	}
+	versions, err := versions(dialect)
+	if err != nil {
+		return fmt.Errorf("migrate down: %w", err)
+	}
+	err = withMigrate(dialect, url, func(m *migrate.Migrate) error {
+		current, dirty, err := version(m)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return ErrDirty
+		}
+		var applied int
+		for _, v := range versions {
+			if v <= current {
+				applied++
+			}
+		}
+		if steps > applied {
+			return fmt.Errorf("cannot revert %d migrations, only %d have been applied", steps, applied)
+		}
+		if err := m.Steps(-steps); err != nil {
+			return fmt.Errorf("error reverting migrations: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("migrate down: %w", err)
+	}
+	return nil
+}
+
+// withMigrate runs fn with a migrate.Migrate for the database at url, closing
+// it afterwards.
+func withMigrate(dialect, url string, fn func(*migrate.Migrate) error) error {
+	source, err := NewMigrationSource(dialect)
+	if err != nil {
+		return fmt.Errorf("error creating migration driver: %w", err)
+	}
+	m, err := migrate.NewWithSourceInstance("httpfs", source, url)
+	if err != nil {
+		return fmt.Errorf("error creating migrations: %w", err)
+	}
+	err = fn(m)
+	if srcErr, dbErr := m.Close(); err == nil && (srcErr != nil || dbErr != nil) {
+		err = fmt.Errorf("error closing migrations: %v, %v", srcErr, dbErr)
+	}
+	return err
+}
+
+// version returns the version of the last migration applied to the database
+// and whether it's dirty.
+func version(m *migrate.Migrate) (uint, bool, error) {
+	v, dirty, err := m.Version()
+	switch {
+	case err == migrate.ErrNilVersion:
+		return 0, false, nil
+	case err != nil:
+		return 0, false, fmt.Errorf("error reading schema version: %w", err)
+	}
+	return v, dirty, nil
+}
+
+// versions returns the versions of all the migrations for dialect in order.
+func versions(dialect string) ([]uint, error) {
+	source, err := NewMigrationSource(dialect)
+	if err != nil {
+		return nil, fmt.Errorf("error creating migration driver: %w", err)
+	}
+	defer source.Close()
+	var versions []uint
+	v, err := source.First()
+	for err == nil {
+		versions = append(versions, v)
+		v, err = source.Next(v)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error listing migrations: %w", err)
+	}
+	return versions, nil
+}
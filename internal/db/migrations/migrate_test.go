@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/docker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	cleanup, url, _, err := docker.StartDbInDocker("postgres")
+	require.NoError(err)
+	t.Cleanup(func() {
+		require.NoError(cleanup())
+	})
+
+	all, err := versions("postgres")
+	require.NoError(err)
+	require.NotEmpty(all)
+	latest := all[len(all)-1]
+
+	state, err := Status("postgres", url)
+	require.NoError(err)
+	assert.Equal(&State{Latest: latest, Pending: all}, state)
+
+	ran, err := MigrateUp("postgres", url)
+	require.NoError(err)
+	assert.True(ran)
+	state, err = Status("postgres", url)
+	require.NoError(err)
+	assert.Equal(&State{Version: latest, Latest: latest}, state)
+
+	ran, err = MigrateUp("postgres", url)
+	require.NoError(err)
+	assert.False(ran)
+
+	assert.Error(MigrateDown("postgres", url, 0))
+	assert.Error(MigrateDown("postgres", url, len(all)+1))
+
+	require.NoError(MigrateDown("postgres", url, 1))
+	state, err = Status("postgres", url)
+	require.NoError(err)
+	assert.Equal(&State{Version: all[len(all)-2], Latest: latest, Pending: []uint{latest}}, state)
+
+	ran, err = MigrateUp("postgres", url)
+	require.NoError(err)
+	assert.True(ran)
+
+	// Simulate a migration which failed part way through
+	d, err := sql.Open("postgres", url)
+	require.NoError(err)
+	defer d.Close()
+	_, err = d.Exec("update schema_migrations set dirty = true")
+	require.NoError(err)
+
+	state, err = Status("postgres", url)
+	require.NoError(err)
+	assert.True(state.Dirty)
+	_, err = MigrateUp("postgres", url)
+	assert.True(errors.Is(err, ErrDirty))
+	err = MigrateDown("postgres", url, 1)
+	assert.True(errors.Is(err, ErrDirty))
+}
@@ -5355,6 +5355,3340 @@ begin;
 
 commit;
 
+`),
+	},
+	"migrations/70_iam_org_isolation.down.sql": {
+		name: "70_iam_org_isolation.down.sql",
+		bytes: []byte(`
+begin;
+
+drop view iam_org_association;
+drop trigger iam_org_require_root_key on iam_scope;
+drop function iam_org_require_root_key;
+drop trigger iam_enforce_org_isolation on iam_user_role;
+drop trigger iam_enforce_org_isolation on iam_group_role;
+drop trigger iam_enforce_org_isolation on iam_group_member_user;
+drop function iam_enforce_org_isolation;
+drop function iam_org_isolation_enabled;
+drop function iam_scope_org_id;
+drop table iam_org_isolation;
+
+commit;
+
+`),
+	},
+	"migrations/70_iam_org_isolation.up.sql": {
+		name: "70_iam_org_isolation.up.sql",
+		bytes: []byte(`
+begin;
+
+-- iam_org_isolation is a single row table which holds the hard isolation
+-- setting for multi-tenant deployments. When hard_isolation is true, the
+-- triggers below reject any association which references resources from two
+-- different orgs. Principals and resources which belong to the global scope
+-- are never considered to cross an org boundary.
+create table iam_org_isolation (
+  private_id text primary key
+    constraint only_one_org_isolation_row_allowed
+    check(
+      private_id = 'iam_org_isolation'
+    ),
+  hard_isolation boolean not null default false,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on iam_org_isolation
+  for each row execute procedure update_time_column();
+
+create trigger
+  immutable_columns
+before
+update on iam_org_isolation
+  for each row execute procedure immutable_columns('private_id');
+
+insert into iam_org_isolation (private_id, hard_isolation)
+  values ('iam_org_isolation', false);
+
+-- iam_scope_org_id returns the org which contains the scope. The org of an org
+-- is itself, the org of a project is its parent and the org of the global
+-- scope is 'global'.
+create or replace function
+  iam_scope_org_id(scope_id text)
+  returns text
+as $$
+declare scope_type text;
+declare scope_parent_id text;
+begin
+  select isc.type, isc.parent_id
+    from iam_scope isc
+   where isc.public_id = scope_id
+    into scope_type, scope_parent_id;
+  if scope_type = 'project' then
+    return scope_parent_id;
+  end if;
+  if scope_type = 'org' then
+    return scope_id;
+  end if;
+  return 'global';
+end;
+$$ language plpgsql;
+
+create or replace function
+  iam_org_isolation_enabled()
+  returns boolean
+as $$
+begin
+  perform from iam_org_isolation where hard_isolation = true;
+  return found;
+end;
+$$ language plpgsql;
+
+-- iam_enforce_org_isolation rejects inserts which would associate resources
+-- from different orgs when hard isolation is enabled. tg_argv[0] is the column
+-- which references the owning resource (a role or group) and tg_argv[1] is the
+-- column which references the principal (a user or group).
+create or replace function
+  iam_enforce_org_isolation()
+  returns trigger
+as $$
+declare owner_id text;
+declare principal_id text;
+declare owner_org_id text;
+declare principal_org_id text;
+begin
+  -- Take a share lock on the setting so that enabling isolation, which locks
+  -- the row for update before checking for violations, waits for this insert
+  -- to commit and any insert after that sees the new setting.
+  perform from iam_org_isolation where hard_isolation = true for share;
+  if not found then
+    return new;
+  end if;
+  execute format('select $1.%I, $1.%I', tg_argv[0], tg_argv[1]) into owner_id, principal_id using new;
+
+  select iam_scope_org_id(r.scope_id) from iam_role r where r.public_id = owner_id into owner_org_id;
+  if owner_org_id is null then
+    select iam_scope_org_id(g.scope_id) from iam_group g where g.public_id = owner_id into owner_org_id;
+  end if;
+
+  select iam_scope_org_id(u.scope_id) from iam_user u where u.public_id = principal_id into principal_org_id;
+  if principal_org_id is null then
+    select iam_scope_org_id(g.scope_id) from iam_group g where g.public_id = principal_id into principal_org_id;
+  end if;
+
+  if owner_org_id = 'global' or principal_org_id = 'global' then
+    return new;
+  end if;
+  if owner_org_id != principal_org_id then
+    raise exception 'org isolation: % in org % cannot reference % in org %',
+      owner_id, owner_org_id, principal_id, principal_org_id
+      using errcode = 'WTI01';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_enforce_org_isolation
+before
+insert on iam_user_role
+  for each row execute procedure iam_enforce_org_isolation('role_id', 'principal_id');
+
+create trigger
+  iam_enforce_org_isolation
+before
+insert on iam_group_role
+  for each row execute procedure iam_enforce_org_isolation('role_id', 'principal_id');
+
+create trigger
+  iam_enforce_org_isolation
+before
+insert on iam_group_member_user
+  for each row execute procedure iam_enforce_org_isolation('group_id', 'member_id');
+
+-- iam_org_require_root_key ensures that every org created while hard
+-- isolation is enabled has its own root key. It runs as a deferred constraint
+-- trigger since the root key is created after the org in the same transaction.
+create or replace function
+  iam_org_require_root_key()
+  returns trigger
+as $$
+begin
+  if new.type != 'org' then
+    return null;
+  end if;
+  perform from iam_org_isolation where hard_isolation = true for share;
+  if not found then
+    return null;
+  end if;
+  perform from kms_root_key where scope_id = new.public_id;
+  if not found then
+    raise exception 'org isolation: org % does not have its own root key', new.public_id
+      using errcode = 'WTI01';
+  end if;
+  return null;
+end;
+$$ language plpgsql;
+
+create constraint trigger
+  iam_org_require_root_key
+after
+insert on iam_scope
+  deferrable initially deferred
+  for each row execute procedure iam_org_require_root_key();
+
+-- iam_org_association provides a consolidated view of the associations
+-- (principal roles and group members) along with the orgs of both sides, so
+-- existing associations which cross an org boundary can be found before hard
+-- isolation is enabled.
+create view iam_org_association as
+select
+  ur.role_id as owner_id,
+  iam_scope_org_id(r.scope_id) as owner_org_id,
+  ur.principal_id as principal_id,
+  iam_scope_org_id(u.scope_id) as principal_org_id
+from
+  iam_user_role ur,
+  iam_role r,
+  iam_user u
+where
+  ur.role_id = r.public_id and
+  ur.principal_id = u.public_id
+union
+select
+  gr.role_id as owner_id,
+  iam_scope_org_id(r.scope_id) as owner_org_id,
+  gr.principal_id as principal_id,
+  iam_scope_org_id(g.scope_id) as principal_org_id
+from
+  iam_group_role gr,
+  iam_role r,
+  iam_group g
+where
+  gr.role_id = r.public_id and
+  gr.principal_id = g.public_id
+union
+select
+  gm.group_id as owner_id,
+  iam_scope_org_id(g.scope_id) as owner_org_id,
+  gm.member_id as principal_id,
+  iam_scope_org_id(u.scope_id) as principal_org_id
+from
+  iam_group_member_user gm,
+  iam_group g,
+  iam_user u
+where
+  gm.group_id = g.public_id and
+  gm.member_id = u.public_id;
+
+commit;
+
+`),
+	},
+	"migrations/71_iam_claim_rule.down.sql": {
+		name: "71_iam_claim_rule.down.sql",
+		bytes: []byte(`
+begin;
+
+drop table iam_claim_rule;
+drop function iam_claim_rule_role_in_org;
+
+delete
+  from oplog_ticket
+ where name = 'iam_claim_rule';
+
+commit;
+
+`),
+	},
+	"migrations/71_iam_claim_rule.up.sql": {
+		name: "71_iam_claim_rule.up.sql",
+		bytes: []byte(`
+begin;
+
+-- iam_claim_rule contains rules which map identity provider claims presented
+-- at login time to roles within an org. When a user logs in with a claim named
+-- claim_name which has the value claim_value, the user is added as a principal
+-- of the rule's role. A claim_value of '*' matches any value of the claim.
+create table iam_claim_rule (
+  public_id wt_public_id primary key,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  name text,
+  description text,
+  scope_id wt_scope_id not null
+    references iam_scope_org(scope_id)
+    on delete cascade
+    on update cascade,
+  claim_name text not null
+    constraint claim_name_must_not_be_empty
+    check(
+      length(trim(claim_name)) > 0
+    ),
+  claim_value text not null
+    constraint claim_value_must_not_be_empty
+    check(
+      length(trim(claim_value)) > 0
+    ),
+  role_id wt_role_id not null
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  unique(scope_id, name),
+  unique(scope_id, claim_name, claim_value, role_id)
+);
+
+-- iam_claim_rule_role_in_org ensures that the role of a claim rule belongs to
+-- the rule's org or to one of the org's projects.
+create or replace function
+  iam_claim_rule_role_in_org()
+  returns trigger
+as $$
+begin
+  perform
+     from iam_role r
+     join iam_scope s
+       on r.scope_id = s.public_id
+    where r.public_id = new.role_id
+      and (s.public_id = new.scope_id or s.parent_id = new.scope_id);
+  if not found then
+    raise exception 'claim rule role % is not in org %', new.role_id, new.scope_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_claim_rule_role_in_org
+before
+insert or update on iam_claim_rule
+  for each row execute procedure iam_claim_rule_role_in_org();
+
+create trigger
+  update_time_column
+before update on iam_claim_rule
+  for each row execute procedure update_time_column();
+
+create trigger
+  update_version_column
+after update on iam_claim_rule
+  for each row execute procedure update_version_column();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_claim_rule
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on iam_claim_rule
+  for each row execute procedure immutable_columns('public_id', 'create_time', 'scope_id');
+
+insert into oplog_ticket (name, version)
+values
+  ('iam_claim_rule', 1);
+
+commit;
+
+`),
+	},
+	"migrations/72_iam_time_bound.down.sql": {
+		name: "72_iam_time_bound.down.sql",
+		bytes: []byte(`
+begin;
+
+drop view iam_principal_role;
+create view iam_principal_role as
+select
+	ur.create_time,
+	ur.principal_id,
+	ur.role_id,
+	u.scope_id as principal_scope_id,
+	r.scope_id as role_scope_id,
+	get_scoped_principal_id(r.scope_id, u.scope_id, ur.principal_id) as scoped_principal_id,
+	'user' as type
+from
+	iam_user_role ur,
+	iam_role r,
+	iam_user u
+where
+	ur.role_id = r.public_id and
+	u.public_id = ur.principal_id
+union
+select
+	gr.create_time,
+	gr.principal_id,
+	gr.role_id,
+	g.scope_id as principal_scope_id,
+	r.scope_id as role_scope_id,
+	get_scoped_principal_id(r.scope_id, g.scope_id, gr.principal_id) as scoped_principal_id,
+	'group' as type
+from
+	iam_group_role gr,
+	iam_role r,
+	iam_group g
+where
+	gr.role_id = r.public_id and
+	g.public_id = gr.principal_id;
+
+drop function iam_time_bound_in_effect;
+
+alter table iam_group_role
+  drop column not_before,
+  drop column not_after;
+alter table iam_user_role
+  drop column not_before,
+  drop column not_after;
+alter table iam_role_grant
+  drop column not_before,
+  drop column not_after;
+
+commit;
+
+`),
+	},
+	"migrations/72_iam_time_bound.up.sql": {
+		name: "72_iam_time_bound.up.sql",
+		bytes: []byte(`
+begin;
+
+-- Role grants and principal roles may be bound to a time window. A row with a
+-- not_before in the future or a not_after in the past still exists but isn't
+-- considered when resolving a user's grants. Rows stay immutable, so the
+-- window can only be changed by removing and re-adding the grant or principal.
+alter table iam_role_grant
+  add column not_before timestamp with time zone,
+  add column not_after timestamp with time zone,
+  add constraint not_before_must_be_before_not_after
+    check(not_before < not_after);
+
+alter table iam_user_role
+  add column not_before timestamp with time zone,
+  add column not_after timestamp with time zone,
+  add constraint not_before_must_be_before_not_after
+    check(not_before < not_after);
+
+alter table iam_group_role
+  add column not_before timestamp with time zone,
+  add column not_after timestamp with time zone,
+  add constraint not_before_must_be_before_not_after
+    check(not_before < not_after);
+
+create index iam_role_grant_not_after_ix on iam_role_grant (not_after) where not_after is not null;
+create index iam_user_role_not_after_ix on iam_user_role (not_after) where not_after is not null;
+create index iam_group_role_not_after_ix on iam_group_role (not_after) where not_after is not null;
+
+-- iam_time_bound_in_effect returns true if now is within the window.
+create or replace function
+  iam_time_bound_in_effect(not_before timestamp with time zone, not_after timestamp with time zone)
+  returns boolean
+as $$
+  select (not_before is null or not_before <= now())
+     and (not_after is null or not_after > now());
+$$ language sql stable;
+
+create or replace view iam_principal_role as
+select
+	ur.create_time,
+	ur.principal_id,
+	ur.role_id,
+	u.scope_id as principal_scope_id,
+	r.scope_id as role_scope_id,
+	get_scoped_principal_id(r.scope_id, u.scope_id, ur.principal_id) as scoped_principal_id,
+	'user' as type,
+	ur.not_before,
+	ur.not_after
+from
+	iam_user_role ur,
+	iam_role r,
+	iam_user u
+where
+	ur.role_id = r.public_id and
+	u.public_id = ur.principal_id
+union
+select
+	gr.create_time,
+	gr.principal_id,
+	gr.role_id,
+	g.scope_id as principal_scope_id,
+	r.scope_id as role_scope_id,
+	get_scoped_principal_id(r.scope_id, g.scope_id, gr.principal_id) as scoped_principal_id,
+	'group' as type,
+	gr.not_before,
+	gr.not_after
+from
+	iam_group_role gr,
+	iam_role r,
+	iam_group g
+where
+	gr.role_id = r.public_id and
+	g.public_id = gr.principal_id;
+
+commit;
+
+`),
+	},
+	"migrations/73_iam_tag.down.sql": {
+		name: "73_iam_tag.down.sql",
+		bytes: []byte(`
+begin;
+
+drop trigger iam_tag_delete_for_resource on iam_role;
+drop trigger iam_tag_delete_for_resource on iam_group;
+drop table iam_tag;
+drop function iam_tag_resource_exists;
+drop function iam_tag_delete_for_resource;
+
+delete
+  from oplog_ticket
+ where name = 'iam_tag';
+
+commit;
+
+`),
+	},
+	"migrations/73_iam_tag.up.sql": {
+		name: "73_iam_tag.up.sql",
+		bytes: []byte(`
+begin;
+
+-- iam_tag contains key/value labels on roles and groups. A resource has at
+-- most one value for a key. Tags are changed as a set through the repository,
+-- which bumps the version of the tagged resource, so rows are immutable.
+create table iam_tag (
+  create_time wt_timestamp,
+  resource_id wt_public_id not null,
+  key text not null
+    constraint key_must_not_be_empty
+    check(
+      length(trim(key)) > 0
+    )
+    constraint key_must_not_be_too_long
+    check(
+      length(key) <= 128
+    ),
+  value text not null
+    constraint value_must_not_be_too_long
+    check(
+      length(value) <= 256
+    ),
+  primary key(resource_id, key)
+);
+
+create index iam_tag_key_value_ix on iam_tag (key, value);
+
+-- iam_tag_resource_exists ensures that a tag's resource is a role or a group,
+-- since a foreign key can only reference one table.
+create or replace function
+  iam_tag_resource_exists()
+  returns trigger
+as $$
+begin
+  perform from iam_role where public_id = new.resource_id;
+  if found then
+    return new;
+  end if;
+  perform from iam_group where public_id = new.resource_id;
+  if found then
+    return new;
+  end if;
+  raise exception 'tag resource % is not a role or group', new.resource_id;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_tag_resource_exists
+before
+insert on iam_tag
+  for each row execute procedure iam_tag_resource_exists();
+
+-- iam_tag_delete_for_resource removes the tags of a deleted role or group.
+create or replace function
+  iam_tag_delete_for_resource()
+  returns trigger
+as $$
+begin
+  delete from iam_tag where resource_id = old.public_id;
+  return old;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_tag_delete_for_resource
+after
+delete on iam_role
+  for each row execute procedure iam_tag_delete_for_resource();
+
+create trigger
+  iam_tag_delete_for_resource
+after
+delete on iam_group
+  for each row execute procedure iam_tag_delete_for_resource();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_tag
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on iam_tag
+  for each row execute procedure immutable_columns('create_time', 'resource_id', 'key', 'value');
+
+insert into oplog_ticket (name, version)
+values
+  ('iam_tag', 1);
+
+commit;
+
+`),
+	},
+	"migrations/74_iam_integrity.down.sql": {
+		name: "74_iam_integrity.down.sql",
+		bytes: []byte(`
+begin;
+
+drop table iam_integrity;
+
+alter table iam_role_grant
+  drop column integrity_key_id,
+  drop column integrity_hmac;
+
+alter table iam_user_role
+  drop column integrity_key_id,
+  drop column integrity_hmac;
+
+alter table iam_group_role
+  drop column integrity_key_id,
+  drop column integrity_hmac;
+
+commit;
+
+`),
+	},
+	"migrations/74_iam_integrity.up.sql": {
+		name: "74_iam_integrity.up.sql",
+		bytes: []byte(`
+begin;
+
+-- Role grants and principal roles carry an hmac over their columns, computed
+-- by the controller with a key derived from the database key of the role's
+-- scope, so rows written to the database without going through the
+-- controller can be detected. integrity_key_id is the id of the database key
+-- version the hmac was computed with.
+alter table iam_role_grant
+  add column integrity_key_id text,
+  add column integrity_hmac bytea;
+
+alter table iam_user_role
+  add column integrity_key_id text,
+  add column integrity_hmac bytea;
+
+alter table iam_group_role
+  add column integrity_key_id text,
+  add column integrity_hmac bytea;
+
+-- iam_integrity is a single row table which records when the controller
+-- started signing rows. Rows created before then have no hmac and are still
+-- considered valid; rows created since must have one.
+create table iam_integrity (
+  private_id text primary key
+    constraint only_one_integrity_row_allowed
+    check(
+      private_id = 'iam_integrity'
+    ),
+  signed_since wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on iam_integrity
+  for each row execute procedure immutable_columns('private_id', 'signed_since');
+
+insert into iam_integrity (private_id, signed_since)
+  values ('iam_integrity', now());
+
+commit;
+
+`),
+	},
+	"migrations/75_iam_user_state.down.sql": {
+		name: "75_iam_user_state.down.sql",
+		bytes: []byte(`
+begin;
+
+drop trigger iam_group_member_user_active on iam_group_member_user;
+drop function iam_group_member_user_active;
+drop trigger iam_user_role_principal_active on iam_user_role;
+drop function iam_user_role_principal_active;
+drop trigger iam_user_deleted_is_final on iam_user;
+drop function iam_user_deleted_is_final;
+
+alter table iam_user
+  drop column state;
+
+drop table iam_user_state_enm;
+
+commit;
+
+`),
+	},
+	"migrations/75_iam_user_state.up.sql": {
+		name: "75_iam_user_state.up.sql",
+		bytes: []byte(`
+begin;
+
+-- iam_user_state_enm defines the lifecycle states of a user. Inactive and
+-- deleted users can't authenticate, have no grants of their own and can't be
+-- made role principals or group members. Deleted users are kept so the
+-- history of their access can still be followed, and can't become active
+-- again.
+create table iam_user_state_enm (
+  string text not null primary key
+    constraint only_predefined_user_states_allowed
+    check(string in ('active', 'inactive', 'deleted'))
+);
+
+insert into iam_user_state_enm (string)
+values
+  ('active'),
+  ('inactive'),
+  ('deleted');
+
+create trigger
+  immutable_columns
+before
+update on iam_user_state_enm
+  for each row execute procedure immutable_columns('string');
+
+alter table iam_user
+  add column state text not null default 'active'
+    references iam_user_state_enm(string);
+
+-- iam_user_deleted_is_final ensures that a deleted user stays deleted.
+create or replace function
+  iam_user_deleted_is_final()
+  returns trigger
+as $$
+begin
+  if old.state = 'deleted' and new.state <> 'deleted' then
+    raise exception 'user % is deleted', old.public_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_user_deleted_is_final
+before
+update of state on iam_user
+  for each row execute procedure iam_user_deleted_is_final();
+
+-- iam_user_role_principal_active ensures that only active users are made
+-- role principals.
+create or replace function
+  iam_user_role_principal_active()
+  returns trigger
+as $$
+begin
+  perform from iam_user where public_id = new.principal_id and state <> 'active';
+  if found then
+    raise exception 'user % is not active', new.principal_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_user_role_principal_active
+before
+insert on iam_user_role
+  for each row execute procedure iam_user_role_principal_active();
+
+-- iam_group_member_user_active ensures that only active users are made group
+-- members.
+create or replace function
+  iam_group_member_user_active()
+  returns trigger
+as $$
+begin
+  perform from iam_user where public_id = new.member_id and state <> 'active';
+  if found then
+    raise exception 'user % is not active', new.member_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_group_member_user_active
+before
+insert on iam_group_member_user
+  for each row execute procedure iam_group_member_user_active();
+
+commit;
+
+`),
+	},
+	"migrations/76_iam_role_include.down.sql": {
+		name: "76_iam_role_include.down.sql",
+		bytes: []byte(`
+begin;
+
+drop table iam_role_include;
+drop function iam_role_include_no_cycle;
+
+delete
+  from oplog_ticket
+ where name = 'iam_role_include';
+
+commit;
+
+`),
+	},
+	"migrations/76_iam_role_include.up.sql": {
+		name: "76_iam_role_include.up.sql",
+		bytes: []byte(`
+begin;
+
+-- iam_role_include contains the roles a role includes. A role receives the
+-- grants of the roles it includes, transitively, so common grant bundles can
+-- be defined once and composed. Includes are changed through the repository,
+-- which bumps the version of the including role, so rows are immutable.
+create table iam_role_include (
+  create_time wt_timestamp,
+  role_id wt_public_id not null
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  included_role_id wt_public_id not null
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  primary key(role_id, included_role_id),
+  constraint role_must_not_include_itself
+    check(
+      role_id <> included_role_id
+    )
+);
+
+create index iam_role_include_included_role_id_ix on iam_role_include (included_role_id);
+
+-- iam_role_include_no_cycle ensures that a role isn't included, directly or
+-- transitively, by a role it includes.
+create or replace function
+  iam_role_include_no_cycle()
+  returns trigger
+as $$
+begin
+  perform from (
+    with recursive reachable (role_id) as (
+      select included_role_id
+        from iam_role_include
+       where role_id = new.included_role_id
+       union
+      select iam_role_include.included_role_id
+        from iam_role_include,
+             reachable
+       where iam_role_include.role_id = reachable.role_id
+    )
+    select role_id from reachable where role_id = new.role_id
+  ) as cycle;
+  if found then
+    raise exception 'role % including % creates a cycle', new.role_id, new.included_role_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_role_include_no_cycle
+before
+insert on iam_role_include
+  for each row execute procedure iam_role_include_no_cycle();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_include
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on iam_role_include
+  for each row execute procedure immutable_columns('create_time', 'role_id', 'included_role_id');
+
+insert into oplog_ticket (name, version)
+values
+  ('iam_role_include', 1);
+
+commit;
+
+`),
+	},
+	"migrations/77_iam_length_limits.down.sql": {
+		name: "77_iam_length_limits.down.sql",
+		bytes: []byte(`
+begin;
+
+drop view iam_length_limit_violation;
+drop trigger iam_role_grant_length_limits on iam_role_grant;
+drop function iam_role_grant_length_limits;
+drop trigger iam_length_limits on iam_role;
+drop trigger iam_length_limits on iam_group;
+drop trigger iam_length_limits on iam_user;
+drop trigger iam_length_limits on iam_scope;
+drop function iam_length_limits;
+
+commit;
+
+`),
+	},
+	"migrations/77_iam_length_limits.up.sql": {
+		name: "77_iam_length_limits.up.sql",
+		bytes: []byte(`
+begin;
+
+-- iam_length_limits enforces the maximum lengths of names and descriptions.
+-- It only checks the columns being written, rather than being a check
+-- constraint, so rows written before the limits can still be read and have
+-- their other columns updated. The limits match the defaults in
+-- iam.DefaultLengthLimits.
+create or replace function
+  iam_length_limits()
+  returns trigger
+as $$
+begin
+  if length(new.name) > 128 then
+    raise exception 'name is longer than 128 characters';
+  end if;
+  if length(new.description) > 1024 then
+    raise exception 'description is longer than 1024 characters';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_length_limits
+before
+insert or update of name, description on iam_scope
+  for each row execute procedure iam_length_limits();
+
+create trigger
+  iam_length_limits
+before
+insert or update of name, description on iam_user
+  for each row execute procedure iam_length_limits();
+
+create trigger
+  iam_length_limits
+before
+insert or update of name, description on iam_group
+  for each row execute procedure iam_length_limits();
+
+create trigger
+  iam_length_limits
+before
+insert or update of name, description on iam_role
+  for each row execute procedure iam_length_limits();
+
+-- iam_role_grant_length_limits enforces the maximum length of grants. Role
+-- grants are immutable, so only inserts are checked.
+create or replace function
+  iam_role_grant_length_limits()
+  returns trigger
+as $$
+begin
+  if length(new.raw_grant) > 1024 or length(new.canonical_grant) > 1024 then
+    raise exception 'grant is longer than 1024 characters';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_role_grant_length_limits
+before
+insert on iam_role_grant
+  for each row execute procedure iam_role_grant_length_limits();
+
+-- iam_length_limit_violation lists the existing values which are longer than
+-- the limits, so they can be found and shortened.
+create view iam_length_limit_violation as
+select 'iam_scope' as table_name, public_id, 'name' as column_name, length(name) as length
+  from iam_scope where length(name) > 128
+ union all
+select 'iam_scope', public_id, 'description', length(description)
+  from iam_scope where length(description) > 1024
+ union all
+select 'iam_user', public_id, 'name', length(name)
+  from iam_user where length(name) > 128
+ union all
+select 'iam_user', public_id, 'description', length(description)
+  from iam_user where length(description) > 1024
+ union all
+select 'iam_group', public_id, 'name', length(name)
+  from iam_group where length(name) > 128
+ union all
+select 'iam_group', public_id, 'description', length(description)
+  from iam_group where length(description) > 1024
+ union all
+select 'iam_role', public_id, 'name', length(name)
+  from iam_role where length(name) > 128
+ union all
+select 'iam_role', public_id, 'description', length(description)
+  from iam_role where length(description) > 1024
+ union all
+select 'iam_role_grant', role_id, 'raw_grant', length(raw_grant)
+  from iam_role_grant where length(raw_grant) > 1024;
+
+commit;
+
+`),
+	},
+	"migrations/78_target_session_policy.down.sql": {
+		name: "78_target_session_policy.down.sql",
+		bytes: []byte(`
+begin;
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+update target_tcp set session_max_seconds = 28800 where session_max_seconds = 0;
+update target_tcp set session_connection_limit = 1 where session_connection_limit = 0;
+
+alter table target_tcp
+  drop column session_recording,
+  drop column session_idle_timeout_seconds,
+  drop constraint session_connection_limit_must_not_be_less_than_negative_1,
+  add constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+    check(session_connection_limit > 0 or session_connection_limit = -1),
+  alter column session_connection_limit set default 1,
+  drop constraint session_max_seconds_must_not_be_negative,
+  add constraint session_max_seconds_must_be_greater_than_0
+    check(session_max_seconds > 0),
+  alter column session_max_seconds set default 28800;
+
+drop table target_session_policy;
+drop function target_session_policy_scope_valid;
+
+delete
+  from oplog_ticket
+ where name = 'target_session_policy';
+
+commit;
+
+`),
+	},
+	"migrations/78_target_session_policy.up.sql": {
+		name: "78_target_session_policy.up.sql",
+		bytes: []byte(`
+begin;
+
+-- target_session_policy contains the default session settings of an org or
+-- project, which its targets inherit. For each setting, 0 (or 'inherit' for
+-- session_recording) means the setting isn't set, so it's inherited from the
+-- parent org or, for an org, from the built-in defaults.
+create table target_session_policy (
+  scope_id wt_scope_id primary key
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  -- max duration of a session in seconds.
+  session_max_seconds int not null default 0
+    constraint session_max_seconds_must_not_be_negative
+    check(session_max_seconds >= 0),
+  -- limit on number of session connections allowed. -1 equals no limit
+  session_connection_limit int not null default 0
+    constraint session_connection_limit_must_not_be_less_than_negative_1
+    check(session_connection_limit >= -1),
+  -- seconds a session can be idle before it's closed. -1 equals no timeout
+  session_idle_timeout_seconds int not null default 0
+    constraint session_idle_timeout_seconds_must_not_be_less_than_negative_1
+    check(session_idle_timeout_seconds >= -1),
+  session_recording text not null default 'inherit'
+    constraint only_predefined_session_recordings_allowed
+    check(session_recording in ('inherit', 'required', 'optional')),
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version
+);
+
+-- target_session_policy_scope_valid ensures that a session policy is for an
+-- org or project, the scopes targets are in and inherit from.
+create or replace function
+  target_session_policy_scope_valid()
+  returns trigger
+as $$
+begin
+  perform from iam_scope where public_id = new.scope_id and type in ('org', 'project');
+  if not found then
+    raise exception 'session policy scope % is not an org or project', new.scope_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  target_session_policy_scope_valid
+before insert on target_session_policy
+  for each row execute procedure target_session_policy_scope_valid();
+
+create trigger
+  immutable_columns
+before
+update on target_session_policy
+  for each row execute procedure immutable_columns('scope_id', 'create_time');
+
+create trigger
+  update_version_column
+after update on target_session_policy
+  for each row execute procedure update_version_column();
+
+create trigger
+  update_time_column
+before update on target_session_policy
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on target_session_policy
+  for each row execute procedure default_create_time();
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('target_session_policy', 1);
+
+-- A target's session settings override the policy of its scope. They use
+-- the same zero values, and now default to them so new targets inherit.
+-- Existing targets keep their settings.
+alter table target_tcp
+  drop constraint session_max_seconds_must_be_greater_than_0,
+  add constraint session_max_seconds_must_not_be_negative
+    check(session_max_seconds >= 0),
+  alter column session_max_seconds set default 0,
+  drop constraint session_connection_limit_must_be_greater_than_0_or_negative_1,
+  add constraint session_connection_limit_must_not_be_less_than_negative_1
+    check(session_connection_limit >= -1),
+  alter column session_connection_limit set default 0,
+  add column session_idle_timeout_seconds int not null default 0
+    constraint session_idle_timeout_seconds_must_not_be_less_than_negative_1
+    check(session_idle_timeout_seconds >= -1),
+  add column session_recording text not null default 'inherit'
+    constraint only_predefined_session_recordings_allowed
+    check(session_recording in ('inherit', 'required', 'optional'));
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+commit;
+
+`),
+	},
+	"migrations/79_target_mandatory_recording.down.sql": {
+		name: "79_target_mandatory_recording.down.sql",
+		bytes: []byte(`
+begin;
+
+drop table target_recording_exemption;
+drop table target_mandatory_recording;
+
+delete from oplog_ticket where name in ('target_mandatory_recording');
+
+commit;
+
+`),
+	},
+	"migrations/79_target_mandatory_recording.up.sql": {
+		name: "79_target_mandatory_recording.up.sql",
+		bytes: []byte(`
+begin;
+
+-- target_mandatory_recording makes recording mandatory for the sessions of
+-- the targets of a type in an org or project. Principals can only connect to
+-- those targets without recording if they hold an explicit grant of the
+-- authorize-session-unrecorded action on the target.
+create table target_mandatory_recording (
+  scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  target_type text not null
+    constraint only_predefined_target_types_allowed
+    check(target_type in ('tcp')),
+  create_time wt_timestamp,
+  primary key(scope_id, target_type)
+);
+
+-- target_session_policy_scope_valid ensures the scope is an org or project.
+create trigger
+  target_mandatory_recording_scope_valid
+before insert on target_mandatory_recording
+  for each row execute procedure target_session_policy_scope_valid();
+
+create trigger
+  immutable_columns
+before
+update on target_mandatory_recording
+  for each row execute procedure immutable_columns('scope_id', 'target_type', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on target_mandatory_recording
+  for each row execute procedure default_create_time();
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('target_mandatory_recording', 1);
+
+-- target_recording_exemption is a security event log of the sessions
+-- authorized without recording for targets whose recording is mandatory,
+-- using the exemption of the user. It's append only, and isn't tied to the
+-- lifetime of the target, user or session.
+create table target_recording_exemption (
+  session_id wt_public_id primary key,
+  target_id wt_public_id not null,
+  target_type text not null,
+  scope_id wt_scope_id not null,
+  user_id wt_user_id not null,
+  auth_token_id wt_public_id not null,
+  create_time wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on target_recording_exemption
+  for each row execute procedure immutable_columns('session_id', 'target_id', 'target_type', 'scope_id', 'user_id', 'auth_token_id', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on target_recording_exemption
+  for each row execute procedure default_create_time();
+
+commit;
+
+`),
+	},
+	"migrations/80_session_resume.down.sql": {
+		name: "80_session_resume.down.sql",
+		bytes: []byte(`
+begin;
+
+-- terminate_session_if_possible takes a session id and terminates the session
+-- if the following conditions are met:
+--    * the session is expired and all its connections are closed.
+--    * the session is canceling and all its connections are closed
+--    * the session has exhausted its connection limit and all its connections
+--      are closed.  
+--
+--      Note: this function should align closely with the domain function
+--      TerminateCompletedSessions 
+create or replace function 
+    terminate_session_if_possible(terminate_session_id text)
+    returns void
+  as $$
+  begin 
+    -- is terminate_session_id in a canceling state
+    with canceling_session(session_id) as
+    (
+      select 
+        session_id
+      from
+        session_state ss
+      where 
+        ss.session_id = terminate_session_id and
+        ss.state = 'canceling' and 
+        ss.end_time is null
+    )
+    update session us
+      set termination_reason = 
+      case 
+        -- timed out sessions
+        when now() > us.expiration_time then 'timed out'
+        -- canceling sessions
+        when us.public_id in(
+          select 
+            session_id 
+          from 
+            canceling_session cs 
+          where
+            us.public_id = cs.session_id
+          ) then 'canceled' 
+        -- default: session connection limit reached.
+        else 'connection limit'
+      end
+    where
+      -- limit update to just the terminating_session_id
+      us.public_id = terminate_session_id and
+      termination_reason is null and
+      -- session expired or connection limit reached
+      (
+        -- expired sessions...
+        now() > us.expiration_time or 
+        -- connection limit reached...
+        (
+          -- handle unlimited connections...
+          connection_limit != -1 and
+          (
+            select count (*) 
+              from session_connection sc 
+            where 
+              sc.session_id = us.public_id
+          ) >= connection_limit
+        ) or 
+        -- canceled sessions
+        us.public_id in (
+          select 
+            session_id
+          from
+            canceling_session cs
+          where 
+            us.public_id = cs.session_id 
+        )
+      ) and 
+      -- make sure there are no existing connections
+      us.public_id not in (
+        select 
+          session_id 
+        from 
+            session_connection
+          where public_id in (
+          select 
+            connection_id
+          from 
+            session_connection_state
+          where 
+            state != 'closed' and
+            end_time is null
+        )
+    );
+ end;
+  $$ language plpgsql;
+
+drop function session_connection_count;
+
+drop view session_with_state;
+create view session_with_state as
+select
+  s.public_id,
+  s.user_id,
+  s.host_id,
+  s.server_id,
+  s.server_type,
+  s.target_id,
+  s.host_set_id,
+  s.auth_token_id,
+  s.scope_id,
+  s.certificate,
+  s.expiration_time,
+  s.connection_limit,
+  s.tofu_token,
+  s.key_id,
+  s.termination_reason,
+  s.version,
+  s.create_time,
+  s.update_time,
+  s.endpoint,
+  ss.state,
+  ss.previous_end_time,
+  ss.start_time,
+  ss.end_time
+from  
+  session s,
+  session_state ss
+where 
+  s.public_id = ss.session_id;
+
+drop trigger immutable_columns on session;
+create trigger
+  immutable_columns
+before
+update on session
+  for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint');
+
+alter table session
+  drop column resume_window_seconds;
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+alter table target_tcp
+  drop column session_resume_seconds;
+
+commit;
+
+`),
+	},
+	"migrations/80_session_resume.up.sql": {
+		name: "80_session_resume.up.sql",
+		bytes: []byte(`
+begin;
+
+-- A target's session_resume_seconds is how long after one of its sessions'
+-- connections is lost to a network error the session can be resumed: a
+-- client reconnecting with the same session authorization within the window
+-- re-attaches to the session instead of authorizing a new one. 0 disables
+-- resumption.
+alter table target_tcp
+  add column session_resume_seconds int not null default 0
+    constraint session_resume_seconds_must_not_be_negative
+    check(session_resume_seconds >= 0);
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+-- resume_window_seconds is the session_resume_seconds of the session's target
+-- when the session was created.
+alter table session
+  add column resume_window_seconds int not null default 0
+    constraint resume_window_seconds_must_not_be_negative
+    check(resume_window_seconds >= 0);
+
+drop trigger immutable_columns on session;
+create trigger
+  immutable_columns
+before
+update on session
+  for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'resume_window_seconds', 'create_time', 'endpoint');
+
+drop view session_with_state;
+create view session_with_state as
+select
+  s.public_id,
+  s.user_id,
+  s.host_id,
+  s.server_id,
+  s.server_type,
+  s.target_id,
+  s.host_set_id,
+  s.auth_token_id,
+  s.scope_id,
+  s.certificate,
+  s.expiration_time,
+  s.connection_limit,
+  s.resume_window_seconds,
+  s.tofu_token,
+  s.key_id,
+  s.termination_reason,
+  s.version,
+  s.create_time,
+  s.update_time,
+  s.endpoint,
+  ss.state,
+  ss.previous_end_time,
+  ss.start_time,
+  ss.end_time
+from  
+  session s,
+  session_state ss
+where 
+  s.public_id = ss.session_id;
+
+-- session_connection_count returns the number of connections of a session
+-- which count towards its connection limit. A connection which was closed
+-- because of a network error within the session's resume window doesn't
+-- count, so the session can be resumed by reconnecting.
+create function
+  session_connection_count(sid text)
+  returns bigint
+as $$
+  select count(*)
+    from session_connection sc
+    join session s on s.public_id = sc.session_id
+   where sc.session_id = sid
+     and not (
+       s.resume_window_seconds > 0 and
+       sc.closed_reason = 'network error' and
+       exists (
+         select
+           from session_connection_state cs
+          where cs.connection_id = sc.public_id
+            and cs.state = 'closed'
+            and cs.start_time > now() - make_interval(secs => s.resume_window_seconds)
+       )
+     );
+$$ language sql stable;
+
+-- terminate_session_if_possible takes a session id and terminates the session
+-- if the following conditions are met:
+--    * the session is expired and all its connections are closed.
+--    * the session is canceling and all its connections are closed
+--    * the session has exhausted its connection limit and all its connections
+--      are closed. Connections which can still be resumed don't count
+--      towards the limit.
+--
+--      Note: this function should align closely with the domain function
+--      TerminateCompletedSessions 
+create or replace function 
+    terminate_session_if_possible(terminate_session_id text)
+    returns void
+  as $$
+  begin 
+    -- is terminate_session_id in a canceling state
+    with canceling_session(session_id) as
+    (
+      select 
+        session_id
+      from
+        session_state ss
+      where 
+        ss.session_id = terminate_session_id and
+        ss.state = 'canceling' and 
+        ss.end_time is null
+    )
+    update session us
+      set termination_reason = 
+      case 
+        -- timed out sessions
+        when now() > us.expiration_time then 'timed out'
+        -- canceling sessions
+        when us.public_id in(
+          select 
+            session_id 
+          from 
+            canceling_session cs 
+          where
+            us.public_id = cs.session_id
+          ) then 'canceled' 
+        -- default: session connection limit reached.
+        else 'connection limit'
+      end
+    where
+      -- limit update to just the terminating_session_id
+      us.public_id = terminate_session_id and
+      termination_reason is null and
+      -- session expired or connection limit reached
+      (
+        -- expired sessions...
+        now() > us.expiration_time or 
+        -- connection limit reached...
+        (
+          -- handle unlimited connections...
+          connection_limit != -1 and
+          session_connection_count(us.public_id) >= connection_limit
+        ) or 
+        -- canceled sessions
+        us.public_id in (
+          select 
+            session_id
+          from
+            canceling_session cs
+          where 
+            us.public_id = cs.session_id 
+        )
+      ) and 
+      -- make sure there are no existing connections
+      us.public_id not in (
+        select 
+          session_id 
+        from 
+            session_connection
+          where public_id in (
+          select 
+            connection_id
+          from 
+            session_connection_state
+          where 
+            state != 'closed' and
+            end_time is null
+        )
+    );
+ end;
+  $$ language plpgsql;
+
+commit;
+
+`),
+	},
+	"migrations/81_iam_claim_rule_group.down.sql": {
+		name: "81_iam_claim_rule_group.down.sql",
+		bytes: []byte(`
+begin;
+
+delete
+  from iam_claim_rule
+ where group_id is not null;
+
+alter table iam_claim_rule
+  drop constraint role_or_group_but_not_both,
+  drop constraint iam_claim_rule_role_id_check,
+  drop column group_id,
+  alter column role_id type wt_role_id,
+  alter column role_id set not null;
+
+create or replace function
+  iam_claim_rule_role_in_org()
+  returns trigger
+as $$
+begin
+  perform
+     from iam_role r
+     join iam_scope s
+       on r.scope_id = s.public_id
+    where r.public_id = new.role_id
+      and (s.public_id = new.scope_id or s.parent_id = new.scope_id);
+  if not found then
+    raise exception 'claim rule role % is not in org %', new.role_id, new.scope_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+commit;
+
+`),
+	},
+	"migrations/81_iam_claim_rule_group.up.sql": {
+		name: "81_iam_claim_rule_group.up.sql",
+		bytes: []byte(`
+begin;
+
+-- A claim rule maps a claim either to a role, as before, or to a group. When
+-- a user logs in, they're added to the group of every rule whose claim they
+-- present. Groups which are the target of a rule are managed by the identity
+-- provider: a member who logs in without presenting the claim of any of the
+-- group's rules is removed from the group.
+alter table iam_claim_rule
+  alter column role_id type text,
+  alter column role_id drop not null,
+  add column group_id wt_public_id
+    references iam_group(public_id)
+    on delete cascade
+    on update cascade,
+  add constraint role_or_group_but_not_both
+    check(
+      (role_id is null) <> (group_id is null)
+    ),
+  add constraint iam_claim_rule_role_id_check
+    check(
+      length(trim(role_id)) > 10
+    ),
+  add unique(scope_id, claim_name, claim_value, group_id);
+
+-- iam_claim_rule_role_in_org ensures that the role or group of a claim rule
+-- belongs to the rule's org or to one of the org's projects.
+create or replace function
+  iam_claim_rule_role_in_org()
+  returns trigger
+as $$
+begin
+  if new.role_id is not null then
+    perform
+       from iam_role r
+       join iam_scope s
+         on r.scope_id = s.public_id
+      where r.public_id = new.role_id
+        and (s.public_id = new.scope_id or s.parent_id = new.scope_id);
+    if not found then
+      raise exception 'claim rule role % is not in org %', new.role_id, new.scope_id;
+    end if;
+  end if;
+  if new.group_id is not null then
+    perform
+       from iam_group g
+       join iam_scope s
+         on g.scope_id = s.public_id
+      where g.public_id = new.group_id
+        and (s.public_id = new.scope_id or s.parent_id = new.scope_id);
+    if not found then
+      raise exception 'claim rule group % is not in org %', new.group_id, new.scope_id;
+    end if;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+commit;
+
+`),
+	},
+	"migrations/82_iam_idempotency_key.down.sql": {
+		name: "82_iam_idempotency_key.down.sql",
+		bytes: []byte(`
+begin;
+
+drop table iam_idempotency_key;
+
+commit;
+
+`),
+	},
+	"migrations/82_iam_idempotency_key.up.sql": {
+		name: "82_iam_idempotency_key.up.sql",
+		bytes: []byte(`
+begin;
+
+-- iam_idempotency_key remembers the client supplied idempotency keys of role
+-- creations and role grant additions, so a retried request returns the result
+-- of the first instead of writing again. Keys are unique within a scope and
+-- are deleted once they expire.
+create table iam_idempotency_key (
+  scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  idempotency_key text not null
+    constraint idempotency_key_must_not_be_empty
+    check(length(trim(idempotency_key)) > 0)
+    constraint idempotency_key_must_not_be_too_long
+    check(length(idempotency_key) <= 255),
+  operation text not null,
+  resource_id wt_public_id not null,
+  -- fingerprint is a hash of the request, so a key reused for a different
+  -- request is refused.
+  fingerprint text not null,
+  create_time wt_timestamp,
+  expiration_time timestamp with time zone not null,
+  primary key(scope_id, idempotency_key)
+);
+
+create index iam_idempotency_key_expiration_time_ix
+  on iam_idempotency_key (expiration_time);
+
+commit;
+
+`),
+	},
+	"migrations/83_iam_banned_grant_pattern.down.sql": {
+		name: "83_iam_banned_grant_pattern.down.sql",
+		bytes: []byte(`
+begin;
+
+drop table iam_banned_grant_pattern;
+
+commit;
+
+`),
+	},
+	"migrations/83_iam_banned_grant_pattern.up.sql": {
+		name: "83_iam_banned_grant_pattern.up.sql",
+		bytes: []byte(`
+begin;
+
+-- iam_banned_grant_pattern holds the grant patterns, like id=*;actions=*,
+-- which role grants can't be written to match anywhere in the pattern's scope
+-- or the scopes beneath it. The name of the pattern is the policy reported
+-- when a grant is refused.
+create table iam_banned_grant_pattern (
+  scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  name text not null
+    constraint name_must_not_be_empty
+    check(length(trim(name)) > 0),
+  pattern text not null
+    constraint pattern_must_not_be_empty
+    check(length(trim(pattern)) > 0),
+  description text,
+  create_time wt_timestamp,
+  primary key(scope_id, name)
+);
+
+commit;
+
+`),
+	},
+	"migrations/84_iam_role_expiration.down.sql": {
+		name: "84_iam_role_expiration.down.sql",
+		bytes: []byte(`
+begin;
+
+drop function iam_role_in_effect;
+
+alter table iam_role
+  drop constraint archive_time_requires_expiration_time,
+  drop column archive_time,
+  drop column expiration_time;
+
+commit;
+
+`),
+	},
+	"migrations/84_iam_role_expiration.up.sql": {
+		name: "84_iam_role_expiration.up.sql",
+		bytes: []byte(`
+begin;
+
+-- A role may carry an expiration time, after which it's no longer considered
+-- when resolving grants. Expired roles are archived by setting archive_time,
+-- and can be restored until they've been archived for the retention window,
+-- after which they're deleted.
+alter table iam_role
+  add column expiration_time timestamp with time zone,
+  add column archive_time timestamp with time zone,
+  add constraint archive_time_requires_expiration_time
+    check(archive_time is null or expiration_time is not null);
+
+create index iam_role_expiration_time_ix on iam_role (expiration_time) where expiration_time is not null;
+create index iam_role_archive_time_ix on iam_role (archive_time) where archive_time is not null;
+
+-- iam_role_in_effect returns true if a role with the expiration and archive
+-- times is considered when resolving grants.
+create or replace function
+  iam_role_in_effect(expiration_time timestamp with time zone, archive_time timestamp with time zone)
+  returns boolean
+as $$
+  select archive_time is null
+     and (expiration_time is null or expiration_time > now());
+$$ language sql stable;
+
+commit;
+
+`),
+	},
+	"migrations/85_iam_emergency_role.down.sql": {
+		name: "85_iam_emergency_role.down.sql",
+		bytes: []byte(`
+begin;
+
+drop function iam_emergency_role_active;
+drop table iam_emergency_role_activation;
+drop table iam_emergency_role;
+
+commit;
+
+`),
+	},
+	"migrations/85_iam_emergency_role.up.sql": {
+		name: "85_iam_emergency_role.up.sql",
+		bytes: []byte(`
+begin;
+
+-- iam_emergency_role marks the roles which are for emergencies. An emergency
+-- role isn't considered when resolving grants unless it has been activated,
+-- and each activation lasts at most max_duration_seconds.
+create table iam_emergency_role (
+  role_id wt_role_id primary key
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  max_duration_seconds integer not null
+    constraint max_duration_seconds_must_be_positive
+    check(max_duration_seconds > 0),
+  create_time wt_timestamp
+);
+
+-- iam_emergency_role_activation records each activation of an emergency role,
+-- who activated it and why. An activation is in effect until its
+-- expiration_time, or until it's reverted early, whichever comes first.
+-- revert_time is set once the activation is over, and a role can only have
+-- one activation which hasn't been reverted.
+create table iam_emergency_role_activation (
+  id bigint generated always as identity primary key,
+  role_id wt_role_id not null
+    references iam_emergency_role(role_id)
+    on delete cascade
+    on update cascade,
+  user_id wt_user_id not null
+    references iam_user(public_id)
+    on delete cascade
+    on update cascade,
+  justification text not null
+    constraint justification_must_not_be_empty
+    check(length(trim(justification)) > 0),
+  activate_time wt_timestamp,
+  expiration_time timestamp with time zone not null,
+  revert_time timestamp with time zone,
+  constraint activate_time_must_be_before_expiration_time
+    check(activate_time < expiration_time)
+);
+
+create unique index iam_emergency_role_activation_current_uq
+  on iam_emergency_role_activation (role_id)
+  where revert_time is null;
+
+-- iam_emergency_role_active returns true if the role isn't an emergency role,
+-- or it is one with an activation in effect.
+create or replace function
+  iam_emergency_role_active(role_id text)
+  returns boolean
+as $$
+  select not exists (
+           select from iam_emergency_role er
+            where er.role_id = iam_emergency_role_active.role_id)
+      or exists (
+           select from iam_emergency_role_activation a
+            where a.role_id = iam_emergency_role_active.role_id
+              and a.revert_time is null
+              and a.expiration_time > now());
+$$ language sql stable;
+
+commit;
+
+`),
+	},
+	"migrations/86_job.down.sql": {
+		name: "86_job.down.sql",
+		bytes: []byte(`
+begin;
+
+drop table job;
+
+commit;
+
+`),
+	},
+	"migrations/86_job.up.sql": {
+		name: "86_job.up.sql",
+		bytes: []byte(`
+begin;
+
+-- job records a long-running admin operation, like deleting an org and its
+-- projects, which runs in the background on the controller which started it.
+-- progress_done and progress_total report how far along it is, and once it's
+-- over, result holds its json result or error its error. cancel_requested is
+-- set to ask the controller running the job to stop it.
+create table job (
+  public_id wt_public_id primary key,
+  scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  user_id text not null,
+  type text not null
+    constraint type_must_not_be_empty
+    check(length(trim(type)) > 0),
+  controller_id text not null,
+  status text not null default 'running'
+    constraint only_predefined_job_statuses_allowed
+    check(status in ('running', 'succeeded', 'failed', 'canceled')),
+  progress_done bigint not null default 0
+    constraint progress_done_must_not_be_negative
+    check(progress_done >= 0),
+  progress_total bigint not null default 0
+    constraint progress_total_must_not_be_negative
+    check(progress_total >= 0),
+  result text,
+  error text,
+  cancel_requested boolean not null default false,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  end_time timestamp with time zone,
+  constraint end_time_must_be_set_when_over
+    check((status = 'running') = (end_time is null))
+);
+
+create index job_scope_id_ix on job (scope_id);
+
+commit;
+
+`),
+	},
+	"migrations/87_target_client_certificate.down.sql": {
+		name: "87_target_client_certificate.down.sql",
+		bytes: []byte(`
+begin;
+
+drop table target_client_certificate_library;
+
+commit;
+
+`),
+	},
+	"migrations/87_target_client_certificate.up.sql": {
+		name: "87_target_client_certificate.up.sql",
+		bytes: []byte(`
+begin;
+
+-- target_client_certificate_library issues the short-lived client
+-- certificates a worker presents to a target which requires client TLS. The
+-- certificates are issued when a worker looks up a session to the target, and
+-- are given only to the worker, so end users never handle the keys.
+--
+-- An internal library issues certificates from a CA Boundary generated for the
+-- target, whose private key is encrypted with the scope's database key. A
+-- vault library issues them from the role of a Vault PKI secrets engine, using
+-- a Vault token encrypted the same way.
+create table target_client_certificate_library (
+  target_id wt_public_id primary key
+    references target_tcp(public_id)
+    on delete cascade
+    on update cascade,
+  issuer text not null
+    constraint only_predefined_issuers_allowed
+    check(issuer in ('internal', 'vault')),
+  ttl_seconds integer not null default 300
+    constraint ttl_seconds_must_be_between_1_and_86400
+    check(ttl_seconds between 1 and 86400),
+  common_name text
+    constraint common_name_must_not_be_empty
+    check(length(trim(common_name)) > 0),
+  -- server_name and server_ca_cert verify the target's certificate. If
+  -- server_name isn't set, the host of the session's endpoint is used, and if
+  -- server_ca_cert isn't set, the worker's system roots are.
+  server_name text
+    constraint server_name_must_not_be_empty
+    check(length(trim(server_name)) > 0),
+  server_ca_cert bytea,
+  ca_cert bytea,
+  ct_ca_key bytea,
+  vault_address text,
+  vault_pki_path text,
+  vault_role text,
+  ct_vault_token bytea,
+  key_id text
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  constraint internal_issuer_has_ca
+    check(issuer != 'internal'
+      or (ca_cert is not null and ct_ca_key is not null and key_id is not null)),
+  constraint vault_issuer_has_vault_role
+    check(issuer != 'vault'
+      or (vault_address is not null
+        and vault_pki_path is not null
+        and vault_role is not null
+        and ct_vault_token is not null
+        and key_id is not null))
+);
+
+create trigger
+  immutable_columns
+before
+update on target_client_certificate_library
+  for each row execute procedure immutable_columns('target_id', 'create_time');
+
+create trigger
+  update_time_column
+before update on target_client_certificate_library
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on target_client_certificate_library
+  for each row execute procedure default_create_time();
+
+commit;
+
+`),
+	},
+	"migrations/88_target_banner.down.sql": {
+		name: "88_target_banner.down.sql",
+		bytes: []byte(`
+begin;
+
+drop table target_banner_acknowledgment;
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+alter table target_tcp
+  drop constraint banner_acknowledgment_requires_banner,
+  drop column banner_acknowledgment_required,
+  drop column banner;
+
+commit;
+
+`),
+	},
+	"migrations/88_target_banner.up.sql": {
+		name: "88_target_banner.up.sql",
+		bytes: []byte(`
+begin;
+
+-- A target's banner is shown by the CLI before connecting to it, such as a
+-- usage policy. If banner_acknowledgment_required is set, a session to the
+-- target is only authorized once the user acknowledges the banner.
+alter table target_tcp
+  add column banner text
+    constraint banner_must_not_be_empty
+    check(length(trim(banner)) > 0),
+  add column banner_acknowledgment_required boolean not null default false,
+  add constraint banner_acknowledgment_requires_banner
+    check(not banner_acknowledgment_required or banner is not null);
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  banner,
+  banner_acknowledgment_required,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+-- target_banner_acknowledgment records, for compliance, that the user of a
+-- session acknowledged the banner of its target before the session was
+-- authorized, and the banner as it was then. Like target_recording_exemption
+-- it's append only, and isn't tied to the lifetime of the target, user or
+-- session.
+create table target_banner_acknowledgment (
+  session_id wt_public_id primary key,
+  target_id wt_public_id not null,
+  user_id wt_user_id not null,
+  auth_token_id wt_public_id not null,
+  banner text not null
+    constraint banner_must_not_be_empty
+    check(length(trim(banner)) > 0),
+  create_time wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on target_banner_acknowledgment
+  for each row execute procedure immutable_columns('session_id', 'target_id', 'user_id', 'auth_token_id', 'banner', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on target_banner_acknowledgment
+  for each row execute procedure default_create_time();
+
+commit;
+
+`),
+	},
+	"migrations/89_server_tunable_audit.down.sql": {
+		name: "89_server_tunable_audit.down.sql",
+		bytes: []byte(`
+begin;
+
+drop table server_tunable_audit;
+
+commit;
+
+`),
+	},
+	"migrations/89_server_tunable_audit.up.sql": {
+		name: "89_server_tunable_audit.up.sql",
+		bytes: []byte(`
+begin;
+
+-- server_tunable_audit records each change of a runtime tunable of a
+-- controller, like its log level or maintenance mode, made through the api:
+-- who changed which tunable on which controller, and its values before and
+-- after. It's append only.
+create table server_tunable_audit (
+  id bigint generated always as identity primary key,
+  controller_id text not null
+    constraint controller_id_must_not_be_empty
+    check(length(trim(controller_id)) > 0),
+  user_id wt_user_id not null,
+  name text not null
+    constraint name_must_not_be_empty
+    check(length(trim(name)) > 0),
+  old_value text not null,
+  new_value text not null,
+  create_time wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on server_tunable_audit
+  for each row execute procedure immutable_columns('id', 'controller_id', 'user_id', 'name', 'old_value', 'new_value', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on server_tunable_audit
+  for each row execute procedure default_create_time();
+
+create index server_tunable_audit_create_time_ix on server_tunable_audit (create_time);
+
+commit;
+
+`),
+	},
+	"migrations/90_auth_token_source.down.sql": {
+		name: "90_auth_token_source.down.sql",
+		bytes: []byte(`
+begin;
+
+drop index session_user_ix;
+drop table auth_token_source;
+
+commit;
+
+`),
+	},
+	"migrations/90_auth_token_source.up.sql": {
+		name: "90_auth_token_source.up.sql",
+		bytes: []byte(`
+begin;
+
+-- auth_token_source records the address a client authenticated from when its
+-- auth token was created, so users reviewing their own tokens can recognize
+-- them.
+create table auth_token_source (
+  auth_token_id wt_public_id primary key
+    references auth_token(public_id)
+    on delete cascade
+    on update cascade,
+  source_address text not null
+    constraint source_address_must_not_be_empty
+    check(length(trim(source_address)) > 0),
+  create_time wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on auth_token_source
+  for each row execute procedure immutable_columns('auth_token_id', 'source_address', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on auth_token_source
+  for each row execute procedure default_create_time();
+
+-- session_user_ix speeds up listing the sessions of a user.
+create index session_user_ix on session (user_id, create_time);
+
+commit;
+
+`),
+	},
+	"migrations/91_auth_login_signal.down.sql": {
+		name: "91_auth_login_signal.down.sql",
+		bytes: []byte(`
+begin;
+
+drop table auth_account_login_signal;
+
+commit;
+
+`),
+	},
+	"migrations/91_auth_login_signal.up.sql": {
+		name: "91_auth_login_signal.up.sql",
+		bytes: []byte(`
+begin;
+
+-- auth_account_login_signal records the devices, addresses and locations an
+-- account has logged in from, so logins from new ones can be made to step up
+-- their authentication.
+create table auth_account_login_signal (
+  auth_account_id wt_public_id
+    references auth_account(public_id)
+    on delete cascade
+    on update cascade,
+  signal text not null
+    constraint signal_must_be_known
+    check(signal in ('device', 'address', 'location')),
+  value text not null
+    constraint value_must_not_be_empty
+    check(length(trim(value)) > 0),
+  create_time wt_timestamp,
+  last_seen_time wt_timestamp,
+  primary key(auth_account_id, signal, value)
+);
+
+create trigger
+  immutable_columns
+before
+update on auth_account_login_signal
+  for each row execute procedure immutable_columns('auth_account_id', 'signal', 'value', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on auth_account_login_signal
+  for each row execute procedure default_create_time();
+
+commit;
+
+`),
+	},
+	"migrations/92_iam_role_include_guards.down.sql": {
+		name: "92_iam_role_include_guards.down.sql",
+		bytes: []byte(`
+begin;
+
+drop trigger iam_role_include_scope on iam_role_include;
+drop function iam_role_include_scope;
+
+create or replace function
+  iam_role_include_no_cycle()
+  returns trigger
+as $$
+begin
+  perform from (
+    with recursive reachable (role_id) as (
+      select included_role_id
+        from iam_role_include
+       where role_id = new.included_role_id
+       union
+      select iam_role_include.included_role_id
+        from iam_role_include,
+             reachable
+       where iam_role_include.role_id = reachable.role_id
+    )
+    select role_id from reachable where role_id = new.role_id
+  ) as cycle;
+  if found then
+    raise exception 'role % including % creates a cycle', new.role_id, new.included_role_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+commit;
+
+`),
+	},
+	"migrations/92_iam_role_include_guards.up.sql": {
+		name: "92_iam_role_include_guards.up.sql",
+		bytes: []byte(`
+begin;
+
+-- iam_role_include_no_cycle is replaced to lock iam_role_include before it
+-- looks for a cycle. Without the lock, two transactions adding includes which
+-- only form a cycle together, like a including b and b including a, could
+-- each find no cycle and both commit. The lock is held until the transaction
+-- ends, and each statement of the function sees the rows committed before
+-- it, so the second transaction's check sees the first one's include.
+create or replace function
+  iam_role_include_no_cycle()
+  returns trigger
+as $$
+begin
+  lock table iam_role_include in share row exclusive mode;
+  perform from (
+    with recursive reachable (role_id) as (
+      select included_role_id
+        from iam_role_include
+       where role_id = new.included_role_id
+       union
+      select iam_role_include.included_role_id
+        from iam_role_include,
+             reachable
+       where iam_role_include.role_id = reachable.role_id
+    )
+    select role_id from reachable where role_id = new.role_id
+  ) as cycle;
+  if found then
+    raise exception 'role % including % creates a cycle', new.role_id, new.included_role_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+-- iam_role_include_scope ensures that a role only includes roles in its own
+-- scope, its parent scope or the global scope.
+create or replace function
+  iam_role_include_scope()
+  returns trigger
+as $$
+declare
+  role_scope_id text;
+  role_parent_id text;
+  included_scope_id text;
+begin
+  select iam_role.scope_id, iam_scope.parent_id
+    into role_scope_id, role_parent_id
+    from iam_role
+    join iam_scope on iam_scope.public_id = iam_role.scope_id
+   where iam_role.public_id = new.role_id;
+  select iam_role.scope_id
+    into included_scope_id
+    from iam_role
+   where iam_role.public_id = new.included_role_id;
+  if included_scope_id = role_scope_id
+     or included_scope_id = role_parent_id
+     or included_scope_id = 'global' then
+    return new;
+  end if;
+  raise exception 'role % in scope % cannot include role % in scope %',
+    new.role_id, role_scope_id, new.included_role_id, included_scope_id;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_role_include_scope
+before
+insert on iam_role_include
+  for each row execute procedure iam_role_include_scope();
+
+commit;
+
+`),
+	},
+	"migrations/93_iam_snapshot_import.down.sql": {
+		name: "93_iam_snapshot_import.down.sql",
+		bytes: []byte(`
+begin;
+
+drop table iam_snapshot_import;
+
+commit;
+
+`),
+	},
+	"migrations/93_iam_snapshot_import.up.sql": {
+		name: "93_iam_snapshot_import.up.sql",
+		bytes: []byte(`
+begin;
+
+-- iam_snapshot_import records where an org imported from an iam snapshot came
+-- from: the id of the org in the snapshot, the key id and signer of the
+-- snapshot's signature, and whether the signature verified when the snapshot
+-- was imported. Records are deleted with their org.
+create table iam_snapshot_import (
+  scope_id wt_scope_id primary key
+    references iam_scope_org(scope_id)
+    on delete cascade
+    on update cascade,
+  snapshot_org_id text not null,
+  key_id text,
+  signer text,
+  signed_time timestamp with time zone,
+  verified boolean not null,
+  create_time wt_timestamp
+);
+
+commit;
+
+`),
+	},
+	"migrations/94_session_justification.down.sql": {
+		name: "94_session_justification.down.sql",
+		bytes: []byte(`
+begin;
+
+drop view session_with_state;
+create view session_with_state as
+select
+  s.public_id,
+  s.user_id,
+  s.host_id,
+  s.server_id,
+  s.server_type,
+  s.target_id,
+  s.host_set_id,
+  s.auth_token_id,
+  s.scope_id,
+  s.certificate,
+  s.expiration_time,
+  s.connection_limit,
+  s.resume_window_seconds,
+  s.tofu_token,
+  s.key_id,
+  s.termination_reason,
+  s.version,
+  s.create_time,
+  s.update_time,
+  s.endpoint,
+  ss.state,
+  ss.previous_end_time,
+  ss.start_time,
+  ss.end_time
+from
+  session s,
+  session_state ss
+where
+  s.public_id = ss.session_id;
+
+drop trigger immutable_columns on session;
+create trigger
+  immutable_columns
+before
+update on session
+  for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'resume_window_seconds', 'create_time', 'endpoint');
+
+alter table session
+  drop column justification;
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  banner,
+  banner_acknowledgment_required,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+alter table target_tcp
+  drop column justification_pattern;
+
+commit;
+
+`),
+	},
+	"migrations/94_session_justification.up.sql": {
+		name: "94_session_justification.up.sql",
+		bytes: []byte(`
+begin;
+
+-- A target's justification_pattern is a regular expression the justification
+-- given when authorizing a session to the target must match entirely. A
+-- target with a pattern requires a justification.
+alter table target_tcp
+  add column justification_pattern text
+    constraint justification_pattern_must_not_be_empty
+    check(length(trim(justification_pattern)) > 0);
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  banner,
+  banner_acknowledgment_required,
+  justification_pattern,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+-- justification is the reason the user gave for the session when it was
+-- authorized, such as a ticket number. It's included in the session's audit
+-- event.
+alter table session
+  add column justification text
+    constraint justification_must_not_be_empty
+    check(length(trim(justification)) > 0)
+    constraint justification_must_not_be_too_long
+    check(length(justification) <= 1024);
+
+drop trigger immutable_columns on session;
+create trigger
+  immutable_columns
+before
+update on session
+  for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'resume_window_seconds', 'justification', 'create_time', 'endpoint');
+
+drop view session_with_state;
+create view session_with_state as
+select
+  s.public_id,
+  s.user_id,
+  s.host_id,
+  s.server_id,
+  s.server_type,
+  s.target_id,
+  s.host_set_id,
+  s.auth_token_id,
+  s.scope_id,
+  s.certificate,
+  s.expiration_time,
+  s.connection_limit,
+  s.resume_window_seconds,
+  s.justification,
+  s.tofu_token,
+  s.key_id,
+  s.termination_reason,
+  s.version,
+  s.create_time,
+  s.update_time,
+  s.endpoint,
+  ss.state,
+  ss.previous_end_time,
+  ss.start_time,
+  ss.end_time
+from
+  session s,
+  session_state ss
+where
+  s.public_id = ss.session_id;
+
+commit;
+
+`),
+	},
+	"migrations/95_iam_integrity_backfill.down.sql": {
+		name: "95_iam_integrity_backfill.down.sql",
+		bytes: []byte(`
+begin;
+
+drop trigger immutable_role_principal on iam_group_role;
+create trigger immutable_role_principal
+before
+update on iam_group_role
+  for each row execute procedure iam_immutable_role_principal();
+
+drop trigger immutable_role_principal on iam_user_role;
+create trigger immutable_role_principal
+before
+update on iam_user_role
+  for each row execute procedure iam_immutable_role_principal();
+
+drop trigger immutable_role_grant on iam_role_grant;
+create trigger immutable_role_grant
+before
+update on iam_role_grant
+  for each row execute procedure iam_immutable_role_grant();
+
+drop function iam_immutable_except_integrity;
+
+create table iam_integrity (
+  private_id text primary key
+    constraint only_one_integrity_row_allowed
+    check(
+      private_id = 'iam_integrity'
+    ),
+  signed_since wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on iam_integrity
+  for each row execute procedure immutable_columns('private_id', 'signed_since');
+
+insert into iam_integrity (private_id, signed_since)
+  values ('iam_integrity', now());
+
+commit;
+
+`),
+	},
+	"migrations/95_iam_integrity_backfill.up.sql": {
+		name: "95_iam_integrity_backfill.up.sql",
+		bytes: []byte(`
+begin;
+
+-- The time the controller started signing rows was kept in the database, so
+-- anyone able to write rows without an hmac could also move it to accept
+-- them. Rows without an hmac are now signed by the controller when it starts
+-- without integrity enforcement, and every row must have a valid hmac when
+-- it's enforced.
+drop table iam_integrity;
+
+-- iam_immutable_except_integrity() ensures that only the integrity hmac of a
+-- row can be updated, so the controller can sign rows written before it
+-- started signing them, and re-sign a role's grants when its grant scope
+-- changes.
+create or replace function
+  iam_immutable_except_integrity()
+  returns trigger
+as $$
+begin
+  if (to_jsonb(new) - 'integrity_key_id' - 'integrity_hmac') <> (to_jsonb(old) - 'integrity_key_id' - 'integrity_hmac') then
+    raise exception '% rows are immutable', tg_table_name;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+drop trigger immutable_role_grant on iam_role_grant;
+create trigger immutable_role_grant
+before
+update on iam_role_grant
+  for each row execute procedure iam_immutable_except_integrity();
+
+drop trigger immutable_role_principal on iam_user_role;
+create trigger immutable_role_principal
+before
+update on iam_user_role
+  for each row execute procedure iam_immutable_except_integrity();
+
+drop trigger immutable_role_principal on iam_group_role;
+create trigger immutable_role_principal
+before
+update on iam_group_role
+  for each row execute procedure iam_immutable_except_integrity();
+
+commit;
+
+`),
+	},
+	"migrations/96_iam_banned_grant_pattern_oplog.down.sql": {
+		name: "96_iam_banned_grant_pattern_oplog.down.sql",
+		bytes: []byte(`
+begin;
+
+drop trigger default_create_time_column on iam_banned_grant_pattern;
+drop trigger immutable_columns on iam_banned_grant_pattern;
+
+delete
+  from oplog_ticket
+ where name = 'iam_banned_grant_pattern';
+
+commit;
+
+`),
+	},
+	"migrations/96_iam_banned_grant_pattern_oplog.up.sql": {
+		name: "96_iam_banned_grant_pattern_oplog.up.sql",
+		bytes: []byte(`
+begin;
+
+-- Banned grant patterns are written through the repository with oplog
+-- entries, so they're immutable once created and need an oplog ticket.
+create trigger
+  default_create_time_column
+before
+insert on iam_banned_grant_pattern
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on iam_banned_grant_pattern
+  for each row execute procedure immutable_columns('scope_id', 'name', 'pattern', 'description', 'create_time');
+
+insert into oplog_ticket (name, version)
+values
+  ('iam_banned_grant_pattern', 1);
+
+commit;
+
+`),
+	},
+	"migrations/97_session_banner_acknowledgment.down.sql": {
+		name: "97_session_banner_acknowledgment.down.sql",
+		bytes: []byte(`
+begin;
+
+drop trigger immutable_columns on session_banner_acknowledgment;
+create trigger
+  immutable_columns
+before
+update on session_banner_acknowledgment
+  for each row execute procedure immutable_columns('session_id', 'target_id', 'user_id', 'auth_token_id', 'banner', 'create_time');
+
+alter table session_banner_acknowledgment
+  drop constraint banner_hash_must_match_banner,
+  drop column banner_hash;
+
+alter table session_banner_acknowledgment
+  rename to target_banner_acknowledgment;
+
+commit;
+
+`),
+	},
+	"migrations/97_session_banner_acknowledgment.up.sql": {
+		name: "97_session_banner_acknowledgment.up.sql",
+		bytes: []byte(`
+begin;
+
+-- Banner acknowledgments are written by the session repository, in the
+-- transaction which creates the session they're for.
+alter table target_banner_acknowledgment
+  rename to session_banner_acknowledgment;
+
+-- banner_hash identifies the version of the banner which was acknowledged.
+-- Users acknowledge a banner by its hash, so a banner which changed after it
+-- was shown to them isn't acknowledged by mistake.
+alter table session_banner_acknowledgment
+  add column banner_hash text;
+
+update session_banner_acknowledgment
+   set banner_hash = encode(digest(banner, 'sha256'), 'hex');
+
+alter table session_banner_acknowledgment
+  alter column banner_hash set not null,
+  add constraint banner_hash_must_match_banner
+    check(banner_hash = encode(digest(banner, 'sha256'), 'hex'));
+
+drop trigger immutable_columns on session_banner_acknowledgment;
+create trigger
+  immutable_columns
+before
+update on session_banner_acknowledgment
+  for each row execute procedure immutable_columns('session_id', 'target_id', 'user_id', 'auth_token_id', 'banner', 'banner_hash', 'create_time');
+
+commit;
+
+`),
+	},
+	"migrations/98_change_ticket.down.sql": {
+		name: "98_change_ticket.down.sql",
+		bytes: []byte(`
+begin;
+
+drop view session_with_state;
+create view session_with_state as
+select
+  s.public_id,
+  s.user_id,
+  s.host_id,
+  s.server_id,
+  s.server_type,
+  s.target_id,
+  s.host_set_id,
+  s.auth_token_id,
+  s.scope_id,
+  s.certificate,
+  s.expiration_time,
+  s.connection_limit,
+  s.resume_window_seconds,
+  s.justification,
+  s.tofu_token,
+  s.key_id,
+  s.termination_reason,
+  s.version,
+  s.create_time,
+  s.update_time,
+  s.endpoint,
+  ss.state,
+  ss.previous_end_time,
+  ss.start_time,
+  ss.end_time
+from
+  session s,
+  session_state ss
+where
+  s.public_id = ss.session_id;
+
+drop trigger immutable_columns on session;
+create trigger
+  immutable_columns
+before
+update on session
+  for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'resume_window_seconds', 'justification', 'create_time', 'endpoint');
+
+alter table session
+  drop column change_ticket,
+  drop column change_ticket_system;
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  banner,
+  banner_acknowledgment_required,
+  justification_pattern,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+alter table target_tcp
+  drop column change_ticket_system;
+
+commit;
+
+`),
+	},
+	"migrations/98_change_ticket.up.sql": {
+		name: "98_change_ticket.up.sql",
+		bytes: []byte(`
+begin;
+
+-- A target's change_ticket_system names the change ticket system, registered
+-- on the controller, which must accept the change ticket given when authorizing
+-- a session to the target. A target with a change ticket system requires a
+-- change ticket.
+alter table target_tcp
+  add column change_ticket_system text
+    constraint change_ticket_system_must_not_be_empty
+    check(length(trim(change_ticket_system)) > 0);
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  banner,
+  banner_acknowledgment_required,
+  justification_pattern,
+  change_ticket_system,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+-- change_ticket is the change ticket reference the user gave for the session
+-- when it was authorized, and change_ticket_system the system of the target
+-- which accepted it. They're included in the session's audit event.
+alter table session
+  add column change_ticket_system text
+    constraint change_ticket_system_must_not_be_empty
+    check(length(trim(change_ticket_system)) > 0),
+  add column change_ticket text
+    constraint change_ticket_must_not_be_empty
+    check(length(trim(change_ticket)) > 0)
+    constraint change_ticket_must_not_be_too_long
+    check(length(change_ticket) <= 256);
+
+drop trigger immutable_columns on session;
+create trigger
+  immutable_columns
+before
+update on session
+  for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'resume_window_seconds', 'justification', 'change_ticket_system', 'change_ticket', 'create_time', 'endpoint');
+
+drop view session_with_state;
+create view session_with_state as
+select
+  s.public_id,
+  s.user_id,
+  s.host_id,
+  s.server_id,
+  s.server_type,
+  s.target_id,
+  s.host_set_id,
+  s.auth_token_id,
+  s.scope_id,
+  s.certificate,
+  s.expiration_time,
+  s.connection_limit,
+  s.resume_window_seconds,
+  s.justification,
+  s.change_ticket_system,
+  s.change_ticket,
+  s.tofu_token,
+  s.key_id,
+  s.termination_reason,
+  s.version,
+  s.create_time,
+  s.update_time,
+  s.endpoint,
+  ss.state,
+  ss.previous_end_time,
+  ss.start_time,
+  ss.end_time
+from
+  session s,
+  session_state ss
+where
+  s.public_id = ss.session_id;
+
+commit;
+
+`),
+	},
+	"migrations/99_client_certificate_library.down.sql": {
+		name: "99_client_certificate_library.down.sql",
+		bytes: []byte(`
+begin;
+
+drop table session_client_certificate;
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  banner,
+  banner_acknowledgment_required,
+  justification_pattern,
+  change_ticket_system,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+drop trigger target_client_certificate_library_scope_valid on target_tcp;
+drop function target_client_certificate_library_scope_valid;
+
+alter table target_tcp
+  drop column client_certificate_library_id;
+
+drop table client_certificate_library;
+
+create table target_client_certificate_library (
+  target_id wt_public_id primary key
+    references target_tcp(public_id)
+    on delete cascade
+    on update cascade,
+  issuer text not null
+    constraint only_predefined_issuers_allowed
+    check(issuer in ('internal', 'vault')),
+  ttl_seconds integer not null default 300
+    constraint ttl_seconds_must_be_between_1_and_86400
+    check(ttl_seconds between 1 and 86400),
+  common_name text
+    constraint common_name_must_not_be_empty
+    check(length(trim(common_name)) > 0),
+  -- server_name and server_ca_cert verify the target's certificate. If
+  -- server_name isn't set, the host of the session's endpoint is used, and if
+  -- server_ca_cert isn't set, the worker's system roots are.
+  server_name text
+    constraint server_name_must_not_be_empty
+    check(length(trim(server_name)) > 0),
+  server_ca_cert bytea,
+  ca_cert bytea,
+  ct_ca_key bytea,
+  vault_address text,
+  vault_pki_path text,
+  vault_role text,
+  ct_vault_token bytea,
+  key_id text
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  constraint internal_issuer_has_ca
+    check(issuer != 'internal'
+      or (ca_cert is not null and ct_ca_key is not null and key_id is not null)),
+  constraint vault_issuer_has_vault_role
+    check(issuer != 'vault'
+      or (vault_address is not null
+        and vault_pki_path is not null
+        and vault_role is not null
+        and ct_vault_token is not null
+        and key_id is not null))
+);
+
+create trigger
+  immutable_columns
+before
+update on target_client_certificate_library
+  for each row execute procedure immutable_columns('target_id', 'create_time');
+
+create trigger
+  update_time_column
+before update on target_client_certificate_library
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on target_client_certificate_library
+  for each row execute procedure default_create_time();
+
+commit;
+
+`),
+	},
+	"migrations/99_client_certificate_library.up.sql": {
+		name: "99_client_certificate_library.up.sql",
+		bytes: []byte(`
+begin;
+
+-- Client certificate libraries replace the per target settings of
+-- target_client_certificate_library. A library is a resource of a project,
+-- which is attached to the targets in the project whose workers present client
+-- certificates.
+drop table target_client_certificate_library;
+
+-- client_certificate_library issues the short-lived client certificates a
+-- worker presents to a target which requires client TLS.
+--
+-- An internal library issues certificates from a CA Boundary generated for
+-- it, whose private key is the library's secret. A vault library issues them
+-- from the role of a Vault PKI secrets engine, and its secret is the Vault
+-- token it issues them with. The secret is encrypted with the database key of
+-- the library's scope.
+create table client_certificate_library (
+  public_id wt_public_id primary key,
+  scope_id wt_scope_id not null
+    references iam_scope_project (scope_id)
+    on delete cascade
+    on update cascade,
+  name text,
+  description text,
+  issuer text not null
+    constraint only_predefined_issuers_allowed
+    check(issuer in ('internal', 'vault')),
+  common_name text
+    constraint common_name_must_not_be_empty
+    check(length(trim(common_name)) > 0),
+  -- server_name and server_ca_cert verify the target's certificate. If
+  -- server_name isn't set, the host of the session's endpoint is used, and if
+  -- server_ca_cert isn't set, the worker's system roots are.
+  server_name text
+    constraint server_name_must_not_be_empty
+    check(length(trim(server_name)) > 0),
+  server_ca_cert bytea,
+  ca_cert bytea,
+  vault_address text,
+  vault_pki_path text,
+  vault_role text,
+  secret bytea not null,
+  -- TODO: Make key_id a foreign key once we have DEKs
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  unique(scope_id, name),
+  constraint internal_issuer_has_ca
+    check(issuer != 'internal' or ca_cert is not null),
+  constraint vault_issuer_has_vault_role
+    check(issuer != 'vault'
+      or (vault_address is not null
+        and vault_pki_path is not null
+        and vault_role is not null))
+);
+
+create trigger
+  update_version_column
+after update on client_certificate_library
+  for each row execute procedure update_version_column();
+
+create trigger
+  update_time_column
+before update on client_certificate_library
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on client_certificate_library
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on client_certificate_library
+  for each row execute procedure immutable_columns('public_id', 'scope_id', 'issuer', 'ca_cert', 'create_time');
+
+-- A target's client_certificate_library_id is the library which issues the
+-- client certificates workers present to the target. A target without one
+-- isn't connected to over TLS. Deleting a library detaches it from its
+-- targets.
+alter table target_tcp
+  add column client_certificate_library_id wt_public_id
+    references client_certificate_library (public_id)
+    on delete set null
+    on update cascade;
+
+-- target_client_certificate_library_scope_valid() is a before insert and
+-- update trigger function for target_tcp, which ensures a target's client
+-- certificate library is in the target's project.
+create or replace function
+  target_client_certificate_library_scope_valid()
+  returns trigger
+as $$
+begin
+  if new.client_certificate_library_id is null then
+    return new;
+  end if;
+  perform from
+    client_certificate_library l
+  where
+    l.public_id = new.client_certificate_library_id and
+    l.scope_id = new.scope_id;
+  if not found then
+    raise exception 'client certificate library and target do not belong to the same scope';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  target_client_certificate_library_scope_valid
+before
+insert or update of client_certificate_library_id on target_tcp
+  for each row execute procedure target_client_certificate_library_scope_valid();
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  banner,
+  banner_acknowledgment_required,
+  justification_pattern,
+  change_ticket_system,
+  client_certificate_library_id,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+-- session_client_certificate is the client certificate issued for a session
+-- by its target's client certificate library when the session was
+-- authorized. Workers present it to the target for every connection of the
+-- session. The private key is encrypted with the database key of the
+-- session's scope.
+create table session_client_certificate (
+  session_id wt_public_id primary key
+    references session (public_id)
+    on delete cascade
+    on update cascade,
+  certificate bytea not null,
+  private_key bytea not null,
+  server_name text
+    constraint server_name_must_not_be_empty
+    check(length(trim(server_name)) > 0),
+  server_ca_cert bytea,
+  expiration_time wt_timestamp,
+  -- TODO: Make key_id a foreign key once we have DEKs
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  create_time wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on session_client_certificate
+  for each row execute procedure immutable_columns('session_id', 'certificate', 'private_key', 'server_name', 'server_ca_cert', 'expiration_time', 'key_id', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on session_client_certificate
+  for each row execute procedure default_create_time();
+
+commit;
+
 `),
 	},
 }
@@ -0,0 +1,14 @@
+begin;
+
+drop view iam_org_association;
+drop trigger iam_org_require_root_key on iam_scope;
+drop function iam_org_require_root_key;
+drop trigger iam_enforce_org_isolation on iam_user_role;
+drop trigger iam_enforce_org_isolation on iam_group_role;
+drop trigger iam_enforce_org_isolation on iam_group_member_user;
+drop function iam_enforce_org_isolation;
+drop function iam_org_isolation_enabled;
+drop function iam_scope_org_id;
+drop table iam_org_isolation;
+
+commit;
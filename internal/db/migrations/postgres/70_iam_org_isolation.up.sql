@@ -0,0 +1,203 @@
+begin;
+
+-- iam_org_isolation is a single row table which holds the hard isolation
+-- setting for multi-tenant deployments. When hard_isolation is true, the
+-- triggers below reject any association which references resources from two
+-- different orgs. Principals and resources which belong to the global scope
+-- are never considered to cross an org boundary.
+create table iam_org_isolation (
+  private_id text primary key
+    constraint only_one_org_isolation_row_allowed
+    check(
+      private_id = 'iam_org_isolation'
+    ),
+  hard_isolation boolean not null default false,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on iam_org_isolation
+  for each row execute procedure update_time_column();
+
+create trigger
+  immutable_columns
+before
+update on iam_org_isolation
+  for each row execute procedure immutable_columns('private_id');
+
+insert into iam_org_isolation (private_id, hard_isolation)
+  values ('iam_org_isolation', false);
+
+-- iam_scope_org_id returns the org which contains the scope. The org of an org
+-- is itself, the org of a project is its parent and the org of the global
+-- scope is 'global'.
+create or replace function
+  iam_scope_org_id(scope_id text)
+  returns text
+as $$
+declare scope_type text;
+declare scope_parent_id text;
+begin
+  select isc.type, isc.parent_id
+    from iam_scope isc
+   where isc.public_id = scope_id
+    into scope_type, scope_parent_id;
+  if scope_type = 'project' then
+    return scope_parent_id;
+  end if;
+  if scope_type = 'org' then
+    return scope_id;
+  end if;
+  return 'global';
+end;
+$$ language plpgsql;
+
+create or replace function
+  iam_org_isolation_enabled()
+  returns boolean
+as $$
+begin
+  perform from iam_org_isolation where hard_isolation = true;
+  return found;
+end;
+$$ language plpgsql;
+
+-- iam_enforce_org_isolation rejects inserts which would associate resources
+-- from different orgs when hard isolation is enabled. tg_argv[0] is the column
+-- which references the owning resource (a role or group) and tg_argv[1] is the
+-- column which references the principal (a user or group).
+create or replace function
+  iam_enforce_org_isolation()
+  returns trigger
+as $$
+declare owner_id text;
+declare principal_id text;
+declare owner_org_id text;
+declare principal_org_id text;
+begin
+  -- Take a share lock on the setting so that enabling isolation, which locks
+  -- the row for update before checking for violations, waits for this insert
+  -- to commit and any insert after that sees the new setting.
+  perform from iam_org_isolation where hard_isolation = true for share;
+  if not found then
+    return new;
+  end if;
+  execute format('select $1.%I, $1.%I', tg_argv[0], tg_argv[1]) into owner_id, principal_id using new;
+
+  select iam_scope_org_id(r.scope_id) from iam_role r where r.public_id = owner_id into owner_org_id;
+  if owner_org_id is null then
+    select iam_scope_org_id(g.scope_id) from iam_group g where g.public_id = owner_id into owner_org_id;
+  end if;
+
+  select iam_scope_org_id(u.scope_id) from iam_user u where u.public_id = principal_id into principal_org_id;
+  if principal_org_id is null then
+    select iam_scope_org_id(g.scope_id) from iam_group g where g.public_id = principal_id into principal_org_id;
+  end if;
+
+  if owner_org_id = 'global' or principal_org_id = 'global' then
+    return new;
+  end if;
+  if owner_org_id != principal_org_id then
+    raise exception 'org isolation: % in org % cannot reference % in org %',
+      owner_id, owner_org_id, principal_id, principal_org_id
+      using errcode = 'WTI01';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_enforce_org_isolation
+before
+insert on iam_user_role
+  for each row execute procedure iam_enforce_org_isolation('role_id', 'principal_id');
+
+create trigger
+  iam_enforce_org_isolation
+before
+insert on iam_group_role
+  for each row execute procedure iam_enforce_org_isolation('role_id', 'principal_id');
+
+create trigger
+  iam_enforce_org_isolation
+before
+insert on iam_group_member_user
+  for each row execute procedure iam_enforce_org_isolation('group_id', 'member_id');
+
+-- iam_org_require_root_key ensures that every org created while hard
+-- isolation is enabled has its own root key. It runs as a deferred constraint
+-- trigger since the root key is created after the org in the same transaction.
+create or replace function
+  iam_org_require_root_key()
+  returns trigger
+as $$
+begin
+  if new.type != 'org' then
+    return null;
+  end if;
+  perform from iam_org_isolation where hard_isolation = true for share;
+  if not found then
+    return null;
+  end if;
+  perform from kms_root_key where scope_id = new.public_id;
+  if not found then
+    raise exception 'org isolation: org % does not have its own root key', new.public_id
+      using errcode = 'WTI01';
+  end if;
+  return null;
+end;
+$$ language plpgsql;
+
+create constraint trigger
+  iam_org_require_root_key
+after
+insert on iam_scope
+  deferrable initially deferred
+  for each row execute procedure iam_org_require_root_key();
+
+-- iam_org_association provides a consolidated view of the associations
+-- (principal roles and group members) along with the orgs of both sides, so
+-- existing associations which cross an org boundary can be found before hard
+-- isolation is enabled.
+create view iam_org_association as
+select
+  ur.role_id as owner_id,
+  iam_scope_org_id(r.scope_id) as owner_org_id,
+  ur.principal_id as principal_id,
+  iam_scope_org_id(u.scope_id) as principal_org_id
+from
+  iam_user_role ur,
+  iam_role r,
+  iam_user u
+where
+  ur.role_id = r.public_id and
+  ur.principal_id = u.public_id
+union
+select
+  gr.role_id as owner_id,
+  iam_scope_org_id(r.scope_id) as owner_org_id,
+  gr.principal_id as principal_id,
+  iam_scope_org_id(g.scope_id) as principal_org_id
+from
+  iam_group_role gr,
+  iam_role r,
+  iam_group g
+where
+  gr.role_id = r.public_id and
+  gr.principal_id = g.public_id
+union
+select
+  gm.group_id as owner_id,
+  iam_scope_org_id(g.scope_id) as owner_org_id,
+  gm.member_id as principal_id,
+  iam_scope_org_id(u.scope_id) as principal_org_id
+from
+  iam_group_member_user gm,
+  iam_group g,
+  iam_user u
+where
+  gm.group_id = g.public_id and
+  gm.member_id = u.public_id;
+
+commit;
@@ -0,0 +1,10 @@
+begin;
+
+drop table iam_claim_rule;
+drop function iam_claim_rule_role_in_org;
+
+delete
+  from oplog_ticket
+ where name = 'iam_claim_rule';
+
+commit;
@@ -0,0 +1,88 @@
+begin;
+
+-- iam_claim_rule contains rules which map identity provider claims presented
+-- at login time to roles within an org. When a user logs in with a claim named
+-- claim_name which has the value claim_value, the user is added as a principal
+-- of the rule's role. A claim_value of '*' matches any value of the claim.
+create table iam_claim_rule (
+  public_id wt_public_id primary key,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  name text,
+  description text,
+  scope_id wt_scope_id not null
+    references iam_scope_org(scope_id)
+    on delete cascade
+    on update cascade,
+  claim_name text not null
+    constraint claim_name_must_not_be_empty
+    check(
+      length(trim(claim_name)) > 0
+    ),
+  claim_value text not null
+    constraint claim_value_must_not_be_empty
+    check(
+      length(trim(claim_value)) > 0
+    ),
+  role_id wt_role_id not null
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  unique(scope_id, name),
+  unique(scope_id, claim_name, claim_value, role_id)
+);
+
+-- iam_claim_rule_role_in_org ensures that the role of a claim rule belongs to
+-- the rule's org or to one of the org's projects.
+create or replace function
+  iam_claim_rule_role_in_org()
+  returns trigger
+as $$
+begin
+  perform
+     from iam_role r
+     join iam_scope s
+       on r.scope_id = s.public_id
+    where r.public_id = new.role_id
+      and (s.public_id = new.scope_id or s.parent_id = new.scope_id);
+  if not found then
+    raise exception 'claim rule role % is not in org %', new.role_id, new.scope_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_claim_rule_role_in_org
+before
+insert or update on iam_claim_rule
+  for each row execute procedure iam_claim_rule_role_in_org();
+
+create trigger
+  update_time_column
+before update on iam_claim_rule
+  for each row execute procedure update_time_column();
+
+create trigger
+  update_version_column
+after update on iam_claim_rule
+  for each row execute procedure update_version_column();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_claim_rule
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on iam_claim_rule
+  for each row execute procedure immutable_columns('public_id', 'create_time', 'scope_id');
+
+insert into oplog_ticket (name, version)
+values
+  ('iam_claim_rule', 1);
+
+commit;
@@ -0,0 +1,75 @@
+begin;
+
+-- Role grants and principal roles may be bound to a time window. A row with a
+-- not_before in the future or a not_after in the past still exists but isn't
+-- considered when resolving a user's grants. Rows stay immutable, so the
+-- window can only be changed by removing and re-adding the grant or principal.
+alter table iam_role_grant
+  add column not_before timestamp with time zone,
+  add column not_after timestamp with time zone,
+  add constraint not_before_must_be_before_not_after
+    check(not_before < not_after);
+
+alter table iam_user_role
+  add column not_before timestamp with time zone,
+  add column not_after timestamp with time zone,
+  add constraint not_before_must_be_before_not_after
+    check(not_before < not_after);
+
+alter table iam_group_role
+  add column not_before timestamp with time zone,
+  add column not_after timestamp with time zone,
+  add constraint not_before_must_be_before_not_after
+    check(not_before < not_after);
+
+create index iam_role_grant_not_after_ix on iam_role_grant (not_after) where not_after is not null;
+create index iam_user_role_not_after_ix on iam_user_role (not_after) where not_after is not null;
+create index iam_group_role_not_after_ix on iam_group_role (not_after) where not_after is not null;
+
+-- iam_time_bound_in_effect returns true if now is within the window.
+create or replace function
+  iam_time_bound_in_effect(not_before timestamp with time zone, not_after timestamp with time zone)
+  returns boolean
+as $$
+  select (not_before is null or not_before <= now())
+     and (not_after is null or not_after > now());
+$$ language sql stable;
+
+create or replace view iam_principal_role as
+select
+	ur.create_time,
+	ur.principal_id,
+	ur.role_id,
+	u.scope_id as principal_scope_id,
+	r.scope_id as role_scope_id,
+	get_scoped_principal_id(r.scope_id, u.scope_id, ur.principal_id) as scoped_principal_id,
+	'user' as type,
+	ur.not_before,
+	ur.not_after
+from
+	iam_user_role ur,
+	iam_role r,
+	iam_user u
+where
+	ur.role_id = r.public_id and
+	u.public_id = ur.principal_id
+union
+select
+	gr.create_time,
+	gr.principal_id,
+	gr.role_id,
+	g.scope_id as principal_scope_id,
+	r.scope_id as role_scope_id,
+	get_scoped_principal_id(r.scope_id, g.scope_id, gr.principal_id) as scoped_principal_id,
+	'group' as type,
+	gr.not_before,
+	gr.not_after
+from
+	iam_group_role gr,
+	iam_role r,
+	iam_group g
+where
+	gr.role_id = r.public_id and
+	g.public_id = gr.principal_id;
+
+commit;
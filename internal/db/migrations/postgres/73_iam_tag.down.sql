@@ -0,0 +1,13 @@
+begin;
+
+drop trigger iam_tag_delete_for_resource on iam_role;
+drop trigger iam_tag_delete_for_resource on iam_group;
+drop table iam_tag;
+drop function iam_tag_resource_exists;
+drop function iam_tag_delete_for_resource;
+
+delete
+  from oplog_ticket
+ where name = 'iam_tag';
+
+commit;
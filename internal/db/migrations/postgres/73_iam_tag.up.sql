@@ -0,0 +1,92 @@
+begin;
+
+-- iam_tag contains key/value labels on roles and groups. A resource has at
+-- most one value for a key. Tags are changed as a set through the repository,
+-- which bumps the version of the tagged resource, so rows are immutable.
+create table iam_tag (
+  create_time wt_timestamp,
+  resource_id wt_public_id not null,
+  key text not null
+    constraint key_must_not_be_empty
+    check(
+      length(trim(key)) > 0
+    )
+    constraint key_must_not_be_too_long
+    check(
+      length(key) <= 128
+    ),
+  value text not null
+    constraint value_must_not_be_too_long
+    check(
+      length(value) <= 256
+    ),
+  primary key(resource_id, key)
+);
+
+create index iam_tag_key_value_ix on iam_tag (key, value);
+
+-- iam_tag_resource_exists ensures that a tag's resource is a role or a group,
+-- since a foreign key can only reference one table.
+create or replace function
+  iam_tag_resource_exists()
+  returns trigger
+as $$
+begin
+  perform from iam_role where public_id = new.resource_id;
+  if found then
+    return new;
+  end if;
+  perform from iam_group where public_id = new.resource_id;
+  if found then
+    return new;
+  end if;
+  raise exception 'tag resource % is not a role or group', new.resource_id;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_tag_resource_exists
+before
+insert on iam_tag
+  for each row execute procedure iam_tag_resource_exists();
+
+-- iam_tag_delete_for_resource removes the tags of a deleted role or group.
+create or replace function
+  iam_tag_delete_for_resource()
+  returns trigger
+as $$
+begin
+  delete from iam_tag where resource_id = old.public_id;
+  return old;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_tag_delete_for_resource
+after
+delete on iam_role
+  for each row execute procedure iam_tag_delete_for_resource();
+
+create trigger
+  iam_tag_delete_for_resource
+after
+delete on iam_group
+  for each row execute procedure iam_tag_delete_for_resource();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_tag
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on iam_tag
+  for each row execute procedure immutable_columns('create_time', 'resource_id', 'key', 'value');
+
+insert into oplog_ticket (name, version)
+values
+  ('iam_tag', 1);
+
+commit;
@@ -0,0 +1,17 @@
+begin;
+
+drop table iam_integrity;
+
+alter table iam_role_grant
+  drop column integrity_key_id,
+  drop column integrity_hmac;
+
+alter table iam_user_role
+  drop column integrity_key_id,
+  drop column integrity_hmac;
+
+alter table iam_group_role
+  drop column integrity_key_id,
+  drop column integrity_hmac;
+
+commit;
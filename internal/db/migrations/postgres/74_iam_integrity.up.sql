@@ -0,0 +1,41 @@
+begin;
+
+-- Role grants and principal roles carry an hmac over their columns, computed
+-- by the controller with a key derived from the database key of the role's
+-- scope, so rows written to the database without going through the
+-- controller can be detected. integrity_key_id is the id of the database key
+-- version the hmac was computed with.
+alter table iam_role_grant
+  add column integrity_key_id text,
+  add column integrity_hmac bytea;
+
+alter table iam_user_role
+  add column integrity_key_id text,
+  add column integrity_hmac bytea;
+
+alter table iam_group_role
+  add column integrity_key_id text,
+  add column integrity_hmac bytea;
+
+-- iam_integrity is a single row table which records when the controller
+-- started signing rows. Rows created before then have no hmac and are still
+-- considered valid; rows created since must have one.
+create table iam_integrity (
+  private_id text primary key
+    constraint only_one_integrity_row_allowed
+    check(
+      private_id = 'iam_integrity'
+    ),
+  signed_since wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on iam_integrity
+  for each row execute procedure immutable_columns('private_id', 'signed_since');
+
+insert into iam_integrity (private_id, signed_since)
+  values ('iam_integrity', now());
+
+commit;
@@ -0,0 +1,15 @@
+begin;
+
+drop trigger iam_group_member_user_active on iam_group_member_user;
+drop function iam_group_member_user_active;
+drop trigger iam_user_role_principal_active on iam_user_role;
+drop function iam_user_role_principal_active;
+drop trigger iam_user_deleted_is_final on iam_user;
+drop function iam_user_deleted_is_final;
+
+alter table iam_user
+  drop column state;
+
+drop table iam_user_state_enm;
+
+commit;
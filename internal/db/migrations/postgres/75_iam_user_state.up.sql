@@ -0,0 +1,91 @@
+begin;
+
+-- iam_user_state_enm defines the lifecycle states of a user. Inactive and
+-- deleted users can't authenticate, have no grants of their own and can't be
+-- made role principals or group members. Deleted users are kept so the
+-- history of their access can still be followed, and can't become active
+-- again.
+create table iam_user_state_enm (
+  string text not null primary key
+    constraint only_predefined_user_states_allowed
+    check(string in ('active', 'inactive', 'deleted'))
+);
+
+insert into iam_user_state_enm (string)
+values
+  ('active'),
+  ('inactive'),
+  ('deleted');
+
+create trigger
+  immutable_columns
+before
+update on iam_user_state_enm
+  for each row execute procedure immutable_columns('string');
+
+alter table iam_user
+  add column state text not null default 'active'
+    references iam_user_state_enm(string);
+
+-- iam_user_deleted_is_final ensures that a deleted user stays deleted.
+create or replace function
+  iam_user_deleted_is_final()
+  returns trigger
+as $$
+begin
+  if old.state = 'deleted' and new.state <> 'deleted' then
+    raise exception 'user % is deleted', old.public_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_user_deleted_is_final
+before
+update of state on iam_user
+  for each row execute procedure iam_user_deleted_is_final();
+
+-- iam_user_role_principal_active ensures that only active users are made
+-- role principals.
+create or replace function
+  iam_user_role_principal_active()
+  returns trigger
+as $$
+begin
+  perform from iam_user where public_id = new.principal_id and state <> 'active';
+  if found then
+    raise exception 'user % is not active', new.principal_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_user_role_principal_active
+before
+insert on iam_user_role
+  for each row execute procedure iam_user_role_principal_active();
+
+-- iam_group_member_user_active ensures that only active users are made group
+-- members.
+create or replace function
+  iam_group_member_user_active()
+  returns trigger
+as $$
+begin
+  perform from iam_user where public_id = new.member_id and state <> 'active';
+  if found then
+    raise exception 'user % is not active', new.member_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_group_member_user_active
+before
+insert on iam_group_member_user
+  for each row execute procedure iam_group_member_user_active();
+
+commit;
@@ -0,0 +1,10 @@
+begin;
+
+drop table iam_role_include;
+drop function iam_role_include_no_cycle;
+
+delete
+  from oplog_ticket
+ where name = 'iam_role_include';
+
+commit;
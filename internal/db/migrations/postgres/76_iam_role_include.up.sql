@@ -0,0 +1,75 @@
+begin;
+
+-- iam_role_include contains the roles a role includes. A role receives the
+-- grants of the roles it includes, transitively, so common grant bundles can
+-- be defined once and composed. Includes are changed through the repository,
+-- which bumps the version of the including role, so rows are immutable.
+create table iam_role_include (
+  create_time wt_timestamp,
+  role_id wt_public_id not null
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  included_role_id wt_public_id not null
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  primary key(role_id, included_role_id),
+  constraint role_must_not_include_itself
+    check(
+      role_id <> included_role_id
+    )
+);
+
+create index iam_role_include_included_role_id_ix on iam_role_include (included_role_id);
+
+-- iam_role_include_no_cycle ensures that a role isn't included, directly or
+-- transitively, by a role it includes.
+create or replace function
+  iam_role_include_no_cycle()
+  returns trigger
+as $$
+begin
+  perform from (
+    with recursive reachable (role_id) as (
+      select included_role_id
+        from iam_role_include
+       where role_id = new.included_role_id
+       union
+      select iam_role_include.included_role_id
+        from iam_role_include,
+             reachable
+       where iam_role_include.role_id = reachable.role_id
+    )
+    select role_id from reachable where role_id = new.role_id
+  ) as cycle;
+  if found then
+    raise exception 'role % including % creates a cycle', new.role_id, new.included_role_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_role_include_no_cycle
+before
+insert on iam_role_include
+  for each row execute procedure iam_role_include_no_cycle();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_include
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on iam_role_include
+  for each row execute procedure immutable_columns('create_time', 'role_id', 'included_role_id');
+
+insert into oplog_ticket (name, version)
+values
+  ('iam_role_include', 1);
+
+commit;
@@ -0,0 +1,12 @@
+begin;
+
+drop view iam_length_limit_violation;
+drop trigger iam_role_grant_length_limits on iam_role_grant;
+drop function iam_role_grant_length_limits;
+drop trigger iam_length_limits on iam_role;
+drop trigger iam_length_limits on iam_group;
+drop trigger iam_length_limits on iam_user;
+drop trigger iam_length_limits on iam_scope;
+drop function iam_length_limits;
+
+commit;
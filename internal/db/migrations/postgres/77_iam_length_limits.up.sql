@@ -0,0 +1,97 @@
+begin;
+
+-- iam_length_limits enforces the maximum lengths of names and descriptions.
+-- It only checks the columns being written, rather than being a check
+-- constraint, so rows written before the limits can still be read and have
+-- their other columns updated. The limits match the defaults in
+-- iam.DefaultLengthLimits.
+create or replace function
+  iam_length_limits()
+  returns trigger
+as $$
+begin
+  if length(new.name) > 128 then
+    raise exception 'name is longer than 128 characters';
+  end if;
+  if length(new.description) > 1024 then
+    raise exception 'description is longer than 1024 characters';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_length_limits
+before
+insert or update of name, description on iam_scope
+  for each row execute procedure iam_length_limits();
+
+create trigger
+  iam_length_limits
+before
+insert or update of name, description on iam_user
+  for each row execute procedure iam_length_limits();
+
+create trigger
+  iam_length_limits
+before
+insert or update of name, description on iam_group
+  for each row execute procedure iam_length_limits();
+
+create trigger
+  iam_length_limits
+before
+insert or update of name, description on iam_role
+  for each row execute procedure iam_length_limits();
+
+-- iam_role_grant_length_limits enforces the maximum length of grants. Role
+-- grants are immutable, so only inserts are checked.
+create or replace function
+  iam_role_grant_length_limits()
+  returns trigger
+as $$
+begin
+  if length(new.raw_grant) > 1024 or length(new.canonical_grant) > 1024 then
+    raise exception 'grant is longer than 1024 characters';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_role_grant_length_limits
+before
+insert on iam_role_grant
+  for each row execute procedure iam_role_grant_length_limits();
+
+-- iam_length_limit_violation lists the existing values which are longer than
+-- the limits, so they can be found and shortened.
+create view iam_length_limit_violation as
+select 'iam_scope' as table_name, public_id, 'name' as column_name, length(name) as length
+  from iam_scope where length(name) > 128
+ union all
+select 'iam_scope', public_id, 'description', length(description)
+  from iam_scope where length(description) > 1024
+ union all
+select 'iam_user', public_id, 'name', length(name)
+  from iam_user where length(name) > 128
+ union all
+select 'iam_user', public_id, 'description', length(description)
+  from iam_user where length(description) > 1024
+ union all
+select 'iam_group', public_id, 'name', length(name)
+  from iam_group where length(name) > 128
+ union all
+select 'iam_group', public_id, 'description', length(description)
+  from iam_group where length(description) > 1024
+ union all
+select 'iam_role', public_id, 'name', length(name)
+  from iam_role where length(name) > 128
+ union all
+select 'iam_role', public_id, 'description', length(description)
+  from iam_role where length(description) > 1024
+ union all
+select 'iam_role_grant', role_id, 'raw_grant', length(raw_grant)
+  from iam_role_grant where length(raw_grant) > 1024;
+
+commit;
@@ -0,0 +1,42 @@
+begin;
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+update target_tcp set session_max_seconds = 28800 where session_max_seconds = 0;
+update target_tcp set session_connection_limit = 1 where session_connection_limit = 0;
+
+alter table target_tcp
+  drop column session_recording,
+  drop column session_idle_timeout_seconds,
+  drop constraint session_connection_limit_must_not_be_less_than_negative_1,
+  add constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+    check(session_connection_limit > 0 or session_connection_limit = -1),
+  alter column session_connection_limit set default 1,
+  drop constraint session_max_seconds_must_not_be_negative,
+  add constraint session_max_seconds_must_be_greater_than_0
+    check(session_max_seconds > 0),
+  alter column session_max_seconds set default 28800;
+
+drop table target_session_policy;
+drop function target_session_policy_scope_valid;
+
+delete
+  from oplog_ticket
+ where name = 'target_session_policy';
+
+commit;
@@ -0,0 +1,117 @@
+begin;
+
+-- target_session_policy contains the default session settings of an org or
+-- project, which its targets inherit. For each setting, 0 (or 'inherit' for
+-- session_recording) means the setting isn't set, so it's inherited from the
+-- parent org or, for an org, from the built-in defaults.
+create table target_session_policy (
+  scope_id wt_scope_id primary key
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  -- max duration of a session in seconds.
+  session_max_seconds int not null default 0
+    constraint session_max_seconds_must_not_be_negative
+    check(session_max_seconds >= 0),
+  -- limit on number of session connections allowed. -1 equals no limit
+  session_connection_limit int not null default 0
+    constraint session_connection_limit_must_not_be_less_than_negative_1
+    check(session_connection_limit >= -1),
+  -- seconds a session can be idle before it's closed. -1 equals no timeout
+  session_idle_timeout_seconds int not null default 0
+    constraint session_idle_timeout_seconds_must_not_be_less_than_negative_1
+    check(session_idle_timeout_seconds >= -1),
+  session_recording text not null default 'inherit'
+    constraint only_predefined_session_recordings_allowed
+    check(session_recording in ('inherit', 'required', 'optional')),
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version
+);
+
+-- target_session_policy_scope_valid ensures that a session policy is for an
+-- org or project, the scopes targets are in and inherit from.
+create or replace function
+  target_session_policy_scope_valid()
+  returns trigger
+as $$
+begin
+  perform from iam_scope where public_id = new.scope_id and type in ('org', 'project');
+  if not found then
+    raise exception 'session policy scope % is not an org or project', new.scope_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  target_session_policy_scope_valid
+before insert on target_session_policy
+  for each row execute procedure target_session_policy_scope_valid();
+
+create trigger
+  immutable_columns
+before
+update on target_session_policy
+  for each row execute procedure immutable_columns('scope_id', 'create_time');
+
+create trigger
+  update_version_column
+after update on target_session_policy
+  for each row execute procedure update_version_column();
+
+create trigger
+  update_time_column
+before update on target_session_policy
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on target_session_policy
+  for each row execute procedure default_create_time();
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('target_session_policy', 1);
+
+-- A target's session settings override the policy of its scope. They use
+-- the same zero values, and now default to them so new targets inherit.
+-- Existing targets keep their settings.
+alter table target_tcp
+  drop constraint session_max_seconds_must_be_greater_than_0,
+  add constraint session_max_seconds_must_not_be_negative
+    check(session_max_seconds >= 0),
+  alter column session_max_seconds set default 0,
+  drop constraint session_connection_limit_must_be_greater_than_0_or_negative_1,
+  add constraint session_connection_limit_must_not_be_less_than_negative_1
+    check(session_connection_limit >= -1),
+  alter column session_connection_limit set default 0,
+  add column session_idle_timeout_seconds int not null default 0
+    constraint session_idle_timeout_seconds_must_not_be_less_than_negative_1
+    check(session_idle_timeout_seconds >= -1),
+  add column session_recording text not null default 'inherit'
+    constraint only_predefined_session_recordings_allowed
+    check(session_recording in ('inherit', 'required', 'optional'));
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+drop table target_recording_exemption;
+drop table target_mandatory_recording;
+
+delete from oplog_ticket where name in ('target_mandatory_recording');
+
+commit;
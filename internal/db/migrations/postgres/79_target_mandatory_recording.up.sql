@@ -0,0 +1,68 @@
+begin;
+
+-- target_mandatory_recording makes recording mandatory for the sessions of
+-- the targets of a type in an org or project. Principals can only connect to
+-- those targets without recording if they hold an explicit grant of the
+-- authorize-session-unrecorded action on the target.
+create table target_mandatory_recording (
+  scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  target_type text not null
+    constraint only_predefined_target_types_allowed
+    check(target_type in ('tcp')),
+  create_time wt_timestamp,
+  primary key(scope_id, target_type)
+);
+
+-- target_session_policy_scope_valid ensures the scope is an org or project.
+create trigger
+  target_mandatory_recording_scope_valid
+before insert on target_mandatory_recording
+  for each row execute procedure target_session_policy_scope_valid();
+
+create trigger
+  immutable_columns
+before
+update on target_mandatory_recording
+  for each row execute procedure immutable_columns('scope_id', 'target_type', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on target_mandatory_recording
+  for each row execute procedure default_create_time();
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('target_mandatory_recording', 1);
+
+-- target_recording_exemption is a security event log of the sessions
+-- authorized without recording for targets whose recording is mandatory,
+-- using the exemption of the user. It's append only, and isn't tied to the
+-- lifetime of the target, user or session.
+create table target_recording_exemption (
+  session_id wt_public_id primary key,
+  target_id wt_public_id not null,
+  target_type text not null,
+  scope_id wt_scope_id not null,
+  user_id wt_user_id not null,
+  auth_token_id wt_public_id not null,
+  create_time wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on target_recording_exemption
+  for each row execute procedure immutable_columns('session_id', 'target_id', 'target_type', 'scope_id', 'user_id', 'auth_token_id', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on target_recording_exemption
+  for each row execute procedure default_create_time();
+
+commit;
@@ -0,0 +1,160 @@
+begin;
+
+-- terminate_session_if_possible takes a session id and terminates the session
+-- if the following conditions are met:
+--    * the session is expired and all its connections are closed.
+--    * the session is canceling and all its connections are closed
+--    * the session has exhausted its connection limit and all its connections
+--      are closed.  
+--
+--      Note: this function should align closely with the domain function
+--      TerminateCompletedSessions 
+create or replace function 
+    terminate_session_if_possible(terminate_session_id text)
+    returns void
+  as $$
+  begin 
+    -- is terminate_session_id in a canceling state
+    with canceling_session(session_id) as
+    (
+      select 
+        session_id
+      from
+        session_state ss
+      where 
+        ss.session_id = terminate_session_id and
+        ss.state = 'canceling' and 
+        ss.end_time is null
+    )
+    update session us
+      set termination_reason = 
+      case 
+        -- timed out sessions
+        when now() > us.expiration_time then 'timed out'
+        -- canceling sessions
+        when us.public_id in(
+          select 
+            session_id 
+          from 
+            canceling_session cs 
+          where
+            us.public_id = cs.session_id
+          ) then 'canceled' 
+        -- default: session connection limit reached.
+        else 'connection limit'
+      end
+    where
+      -- limit update to just the terminating_session_id
+      us.public_id = terminate_session_id and
+      termination_reason is null and
+      -- session expired or connection limit reached
+      (
+        -- expired sessions...
+        now() > us.expiration_time or 
+        -- connection limit reached...
+        (
+          -- handle unlimited connections...
+          connection_limit != -1 and
+          (
+            select count (*) 
+              from session_connection sc 
+            where 
+              sc.session_id = us.public_id
+          ) >= connection_limit
+        ) or 
+        -- canceled sessions
+        us.public_id in (
+          select 
+            session_id
+          from
+            canceling_session cs
+          where 
+            us.public_id = cs.session_id 
+        )
+      ) and 
+      -- make sure there are no existing connections
+      us.public_id not in (
+        select 
+          session_id 
+        from 
+            session_connection
+          where public_id in (
+          select 
+            connection_id
+          from 
+            session_connection_state
+          where 
+            state != 'closed' and
+            end_time is null
+        )
+    );
+ end;
+  $$ language plpgsql;
+
+drop function session_connection_count;
+
+drop view session_with_state;
+create view session_with_state as
+select
+  s.public_id,
+  s.user_id,
+  s.host_id,
+  s.server_id,
+  s.server_type,
+  s.target_id,
+  s.host_set_id,
+  s.auth_token_id,
+  s.scope_id,
+  s.certificate,
+  s.expiration_time,
+  s.connection_limit,
+  s.tofu_token,
+  s.key_id,
+  s.termination_reason,
+  s.version,
+  s.create_time,
+  s.update_time,
+  s.endpoint,
+  ss.state,
+  ss.previous_end_time,
+  ss.start_time,
+  ss.end_time
+from  
+  session s,
+  session_state ss
+where 
+  s.public_id = ss.session_id;
+
+drop trigger immutable_columns on session;
+create trigger
+  immutable_columns
+before
+update on session
+  for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint');
+
+alter table session
+  drop column resume_window_seconds;
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+alter table target_tcp
+  drop column session_resume_seconds;
+
+commit;
@@ -0,0 +1,192 @@
+begin;
+
+-- A target's session_resume_seconds is how long after one of its sessions'
+-- connections is lost to a network error the session can be resumed: a
+-- client reconnecting with the same session authorization within the window
+-- re-attaches to the session instead of authorizing a new one. 0 disables
+-- resumption.
+alter table target_tcp
+  add column session_resume_seconds int not null default 0
+    constraint session_resume_seconds_must_not_be_negative
+    check(session_resume_seconds >= 0);
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+-- resume_window_seconds is the session_resume_seconds of the session's target
+-- when the session was created.
+alter table session
+  add column resume_window_seconds int not null default 0
+    constraint resume_window_seconds_must_not_be_negative
+    check(resume_window_seconds >= 0);
+
+drop trigger immutable_columns on session;
+create trigger
+  immutable_columns
+before
+update on session
+  for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'resume_window_seconds', 'create_time', 'endpoint');
+
+drop view session_with_state;
+create view session_with_state as
+select
+  s.public_id,
+  s.user_id,
+  s.host_id,
+  s.server_id,
+  s.server_type,
+  s.target_id,
+  s.host_set_id,
+  s.auth_token_id,
+  s.scope_id,
+  s.certificate,
+  s.expiration_time,
+  s.connection_limit,
+  s.resume_window_seconds,
+  s.tofu_token,
+  s.key_id,
+  s.termination_reason,
+  s.version,
+  s.create_time,
+  s.update_time,
+  s.endpoint,
+  ss.state,
+  ss.previous_end_time,
+  ss.start_time,
+  ss.end_time
+from  
+  session s,
+  session_state ss
+where 
+  s.public_id = ss.session_id;
+
+-- session_connection_count returns the number of connections of a session
+-- which count towards its connection limit. A connection which was closed
+-- because of a network error within the session's resume window doesn't
+-- count, so the session can be resumed by reconnecting.
+create function
+  session_connection_count(sid text)
+  returns bigint
+as $$
+  select count(*)
+    from session_connection sc
+    join session s on s.public_id = sc.session_id
+   where sc.session_id = sid
+     and not (
+       s.resume_window_seconds > 0 and
+       sc.closed_reason = 'network error' and
+       exists (
+         select
+           from session_connection_state cs
+          where cs.connection_id = sc.public_id
+            and cs.state = 'closed'
+            and cs.start_time > now() - make_interval(secs => s.resume_window_seconds)
+       )
+     );
+$$ language sql stable;
+
+-- terminate_session_if_possible takes a session id and terminates the session
+-- if the following conditions are met:
+--    * the session is expired and all its connections are closed.
+--    * the session is canceling and all its connections are closed
+--    * the session has exhausted its connection limit and all its connections
+--      are closed. Connections which can still be resumed don't count
+--      towards the limit.
+--
+--      Note: this function should align closely with the domain function
+--      TerminateCompletedSessions 
+create or replace function 
+    terminate_session_if_possible(terminate_session_id text)
+    returns void
+  as $$
+  begin 
+    -- is terminate_session_id in a canceling state
+    with canceling_session(session_id) as
+    (
+      select 
+        session_id
+      from
+        session_state ss
+      where 
+        ss.session_id = terminate_session_id and
+        ss.state = 'canceling' and 
+        ss.end_time is null
+    )
+    update session us
+      set termination_reason = 
+      case 
+        -- timed out sessions
+        when now() > us.expiration_time then 'timed out'
+        -- canceling sessions
+        when us.public_id in(
+          select 
+            session_id 
+          from 
+            canceling_session cs 
+          where
+            us.public_id = cs.session_id
+          ) then 'canceled' 
+        -- default: session connection limit reached.
+        else 'connection limit'
+      end
+    where
+      -- limit update to just the terminating_session_id
+      us.public_id = terminate_session_id and
+      termination_reason is null and
+      -- session expired or connection limit reached
+      (
+        -- expired sessions...
+        now() > us.expiration_time or 
+        -- connection limit reached...
+        (
+          -- handle unlimited connections...
+          connection_limit != -1 and
+          session_connection_count(us.public_id) >= connection_limit
+        ) or 
+        -- canceled sessions
+        us.public_id in (
+          select 
+            session_id
+          from
+            canceling_session cs
+          where 
+            us.public_id = cs.session_id 
+        )
+      ) and 
+      -- make sure there are no existing connections
+      us.public_id not in (
+        select 
+          session_id 
+        from 
+            session_connection
+          where public_id in (
+          select 
+            connection_id
+          from 
+            session_connection_state
+          where 
+            state != 'closed' and
+            end_time is null
+        )
+    );
+ end;
+  $$ language plpgsql;
+
+commit;
@@ -0,0 +1,32 @@
+begin;
+
+delete
+  from iam_claim_rule
+ where group_id is not null;
+
+alter table iam_claim_rule
+  drop constraint role_or_group_but_not_both,
+  drop constraint iam_claim_rule_role_id_check,
+  drop column group_id,
+  alter column role_id type wt_role_id,
+  alter column role_id set not null;
+
+create or replace function
+  iam_claim_rule_role_in_org()
+  returns trigger
+as $$
+begin
+  perform
+     from iam_role r
+     join iam_scope s
+       on r.scope_id = s.public_id
+    where r.public_id = new.role_id
+      and (s.public_id = new.scope_id or s.parent_id = new.scope_id);
+  if not found then
+    raise exception 'claim rule role % is not in org %', new.role_id, new.scope_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+commit;
@@ -0,0 +1,58 @@
+begin;
+
+-- A claim rule maps a claim either to a role, as before, or to a group. When
+-- a user logs in, they're added to the group of every rule whose claim they
+-- present. Groups which are the target of a rule are managed by the identity
+-- provider: a member who logs in without presenting the claim of any of the
+-- group's rules is removed from the group.
+alter table iam_claim_rule
+  alter column role_id type text,
+  alter column role_id drop not null,
+  add column group_id wt_public_id
+    references iam_group(public_id)
+    on delete cascade
+    on update cascade,
+  add constraint role_or_group_but_not_both
+    check(
+      (role_id is null) <> (group_id is null)
+    ),
+  add constraint iam_claim_rule_role_id_check
+    check(
+      length(trim(role_id)) > 10
+    ),
+  add unique(scope_id, claim_name, claim_value, group_id);
+
+-- iam_claim_rule_role_in_org ensures that the role or group of a claim rule
+-- belongs to the rule's org or to one of the org's projects.
+create or replace function
+  iam_claim_rule_role_in_org()
+  returns trigger
+as $$
+begin
+  if new.role_id is not null then
+    perform
+       from iam_role r
+       join iam_scope s
+         on r.scope_id = s.public_id
+      where r.public_id = new.role_id
+        and (s.public_id = new.scope_id or s.parent_id = new.scope_id);
+    if not found then
+      raise exception 'claim rule role % is not in org %', new.role_id, new.scope_id;
+    end if;
+  end if;
+  if new.group_id is not null then
+    perform
+       from iam_group g
+       join iam_scope s
+         on g.scope_id = s.public_id
+      where g.public_id = new.group_id
+        and (s.public_id = new.scope_id or s.parent_id = new.scope_id);
+    if not found then
+      raise exception 'claim rule group % is not in org %', new.group_id, new.scope_id;
+    end if;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+commit;
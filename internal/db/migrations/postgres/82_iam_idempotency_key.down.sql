@@ -0,0 +1,5 @@
+begin;
+
+drop table iam_idempotency_key;
+
+commit;
@@ -0,0 +1,30 @@
+begin;
+
+-- iam_idempotency_key remembers the client supplied idempotency keys of role
+-- creations and role grant additions, so a retried request returns the result
+-- of the first instead of writing again. Keys are unique within a scope and
+-- are deleted once they expire.
+create table iam_idempotency_key (
+  scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  idempotency_key text not null
+    constraint idempotency_key_must_not_be_empty
+    check(length(trim(idempotency_key)) > 0)
+    constraint idempotency_key_must_not_be_too_long
+    check(length(idempotency_key) <= 255),
+  operation text not null,
+  resource_id wt_public_id not null,
+  -- fingerprint is a hash of the request, so a key reused for a different
+  -- request is refused.
+  fingerprint text not null,
+  create_time wt_timestamp,
+  expiration_time timestamp with time zone not null,
+  primary key(scope_id, idempotency_key)
+);
+
+create index iam_idempotency_key_expiration_time_ix
+  on iam_idempotency_key (expiration_time);
+
+commit;
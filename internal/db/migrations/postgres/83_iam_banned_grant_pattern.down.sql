@@ -0,0 +1,5 @@
+begin;
+
+drop table iam_banned_grant_pattern;
+
+commit;
@@ -0,0 +1,23 @@
+begin;
+
+-- iam_banned_grant_pattern holds the grant patterns, like id=*;actions=*,
+-- which role grants can't be written to match anywhere in the pattern's scope
+-- or the scopes beneath it. The name of the pattern is the policy reported
+-- when a grant is refused.
+create table iam_banned_grant_pattern (
+  scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  name text not null
+    constraint name_must_not_be_empty
+    check(length(trim(name)) > 0),
+  pattern text not null
+    constraint pattern_must_not_be_empty
+    check(length(trim(pattern)) > 0),
+  description text,
+  create_time wt_timestamp,
+  primary key(scope_id, name)
+);
+
+commit;
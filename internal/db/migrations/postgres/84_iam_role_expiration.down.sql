@@ -0,0 +1,10 @@
+begin;
+
+drop function iam_role_in_effect;
+
+alter table iam_role
+  drop constraint archive_time_requires_expiration_time,
+  drop column archive_time,
+  drop column expiration_time;
+
+commit;
@@ -0,0 +1,26 @@
+begin;
+
+-- A role may carry an expiration time, after which it's no longer considered
+-- when resolving grants. Expired roles are archived by setting archive_time,
+-- and can be restored until they've been archived for the retention window,
+-- after which they're deleted.
+alter table iam_role
+  add column expiration_time timestamp with time zone,
+  add column archive_time timestamp with time zone,
+  add constraint archive_time_requires_expiration_time
+    check(archive_time is null or expiration_time is not null);
+
+create index iam_role_expiration_time_ix on iam_role (expiration_time) where expiration_time is not null;
+create index iam_role_archive_time_ix on iam_role (archive_time) where archive_time is not null;
+
+-- iam_role_in_effect returns true if a role with the expiration and archive
+-- times is considered when resolving grants.
+create or replace function
+  iam_role_in_effect(expiration_time timestamp with time zone, archive_time timestamp with time zone)
+  returns boolean
+as $$
+  select archive_time is null
+     and (expiration_time is null or expiration_time > now());
+$$ language sql stable;
+
+commit;
@@ -0,0 +1,7 @@
+begin;
+
+drop function iam_emergency_role_active;
+drop table iam_emergency_role_activation;
+drop table iam_emergency_role;
+
+commit;
@@ -0,0 +1,62 @@
+begin;
+
+-- iam_emergency_role marks the roles which are for emergencies. An emergency
+-- role isn't considered when resolving grants unless it has been activated,
+-- and each activation lasts at most max_duration_seconds.
+create table iam_emergency_role (
+  role_id wt_role_id primary key
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  max_duration_seconds integer not null
+    constraint max_duration_seconds_must_be_positive
+    check(max_duration_seconds > 0),
+  create_time wt_timestamp
+);
+
+-- iam_emergency_role_activation records each activation of an emergency role,
+-- who activated it and why. An activation is in effect until its
+-- expiration_time, or until it's reverted early, whichever comes first.
+-- revert_time is set once the activation is over, and a role can only have
+-- one activation which hasn't been reverted.
+create table iam_emergency_role_activation (
+  id bigint generated always as identity primary key,
+  role_id wt_role_id not null
+    references iam_emergency_role(role_id)
+    on delete cascade
+    on update cascade,
+  user_id wt_user_id not null
+    references iam_user(public_id)
+    on delete cascade
+    on update cascade,
+  justification text not null
+    constraint justification_must_not_be_empty
+    check(length(trim(justification)) > 0),
+  activate_time wt_timestamp,
+  expiration_time timestamp with time zone not null,
+  revert_time timestamp with time zone,
+  constraint activate_time_must_be_before_expiration_time
+    check(activate_time < expiration_time)
+);
+
+create unique index iam_emergency_role_activation_current_uq
+  on iam_emergency_role_activation (role_id)
+  where revert_time is null;
+
+-- iam_emergency_role_active returns true if the role isn't an emergency role,
+-- or it is one with an activation in effect.
+create or replace function
+  iam_emergency_role_active(role_id text)
+  returns boolean
+as $$
+  select not exists (
+           select from iam_emergency_role er
+            where er.role_id = iam_emergency_role_active.role_id)
+      or exists (
+           select from iam_emergency_role_activation a
+            where a.role_id = iam_emergency_role_active.role_id
+              and a.revert_time is null
+              and a.expiration_time > now());
+$$ language sql stable;
+
+commit;
@@ -0,0 +1,40 @@
+begin;
+
+-- job records a long-running admin operation, like deleting an org and its
+-- projects, which runs in the background on the controller which started it.
+-- progress_done and progress_total report how far along it is, and once it's
+-- over, result holds its json result or error its error. cancel_requested is
+-- set to ask the controller running the job to stop it.
+create table job (
+  public_id wt_public_id primary key,
+  scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  user_id text not null,
+  type text not null
+    constraint type_must_not_be_empty
+    check(length(trim(type)) > 0),
+  controller_id text not null,
+  status text not null default 'running'
+    constraint only_predefined_job_statuses_allowed
+    check(status in ('running', 'succeeded', 'failed', 'canceled')),
+  progress_done bigint not null default 0
+    constraint progress_done_must_not_be_negative
+    check(progress_done >= 0),
+  progress_total bigint not null default 0
+    constraint progress_total_must_not_be_negative
+    check(progress_total >= 0),
+  result text,
+  error text,
+  cancel_requested boolean not null default false,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  end_time timestamp with time zone,
+  constraint end_time_must_be_set_when_over
+    check((status = 'running') = (end_time is null))
+);
+
+create index job_scope_id_ix on job (scope_id);
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+drop table target_client_certificate_library;
+
+commit;
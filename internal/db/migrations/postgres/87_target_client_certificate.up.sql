@@ -0,0 +1,74 @@
+begin;
+
+-- target_client_certificate_library issues the short-lived client
+-- certificates a worker presents to a target which requires client TLS. The
+-- certificates are issued when a worker looks up a session to the target, and
+-- are given only to the worker, so end users never handle the keys.
+--
+-- An internal library issues certificates from a CA Boundary generated for the
+-- target, whose private key is encrypted with the scope's database key. A
+-- vault library issues them from the role of a Vault PKI secrets engine, using
+-- a Vault token encrypted the same way.
+create table target_client_certificate_library (
+  target_id wt_public_id primary key
+    references target_tcp(public_id)
+    on delete cascade
+    on update cascade,
+  issuer text not null
+    constraint only_predefined_issuers_allowed
+    check(issuer in ('internal', 'vault')),
+  ttl_seconds integer not null default 300
+    constraint ttl_seconds_must_be_between_1_and_86400
+    check(ttl_seconds between 1 and 86400),
+  common_name text
+    constraint common_name_must_not_be_empty
+    check(length(trim(common_name)) > 0),
+  -- server_name and server_ca_cert verify the target's certificate. If
+  -- server_name isn't set, the host of the session's endpoint is used, and if
+  -- server_ca_cert isn't set, the worker's system roots are.
+  server_name text
+    constraint server_name_must_not_be_empty
+    check(length(trim(server_name)) > 0),
+  server_ca_cert bytea,
+  ca_cert bytea,
+  ct_ca_key bytea,
+  vault_address text,
+  vault_pki_path text,
+  vault_role text,
+  ct_vault_token bytea,
+  key_id text
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  constraint internal_issuer_has_ca
+    check(issuer != 'internal'
+      or (ca_cert is not null and ct_ca_key is not null and key_id is not null)),
+  constraint vault_issuer_has_vault_role
+    check(issuer != 'vault'
+      or (vault_address is not null
+        and vault_pki_path is not null
+        and vault_role is not null
+        and ct_vault_token is not null
+        and key_id is not null))
+);
+
+create trigger
+  immutable_columns
+before
+update on target_client_certificate_library
+  for each row execute procedure immutable_columns('target_id', 'create_time');
+
+create trigger
+  update_time_column
+before update on target_client_certificate_library
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on target_client_certificate_library
+  for each row execute procedure default_create_time();
+
+commit;
@@ -0,0 +1,30 @@
+begin;
+
+drop table target_banner_acknowledgment;
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+alter table target_tcp
+  drop constraint banner_acknowledgment_requires_banner,
+  drop column banner_acknowledgment_required,
+  drop column banner;
+
+commit;
@@ -0,0 +1,64 @@
+begin;
+
+-- A target's banner is shown by the CLI before connecting to it, such as a
+-- usage policy. If banner_acknowledgment_required is set, a session to the
+-- target is only authorized once the user acknowledges the banner.
+alter table target_tcp
+  add column banner text
+    constraint banner_must_not_be_empty
+    check(length(trim(banner)) > 0),
+  add column banner_acknowledgment_required boolean not null default false,
+  add constraint banner_acknowledgment_requires_banner
+    check(not banner_acknowledgment_required or banner is not null);
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  banner,
+  banner_acknowledgment_required,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+-- target_banner_acknowledgment records, for compliance, that the user of a
+-- session acknowledged the banner of its target before the session was
+-- authorized, and the banner as it was then. Like target_recording_exemption
+-- it's append only, and isn't tied to the lifetime of the target, user or
+-- session.
+create table target_banner_acknowledgment (
+  session_id wt_public_id primary key,
+  target_id wt_public_id not null,
+  user_id wt_user_id not null,
+  auth_token_id wt_public_id not null,
+  banner text not null
+    constraint banner_must_not_be_empty
+    check(length(trim(banner)) > 0),
+  create_time wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on target_banner_acknowledgment
+  for each row execute procedure immutable_columns('session_id', 'target_id', 'user_id', 'auth_token_id', 'banner', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on target_banner_acknowledgment
+  for each row execute procedure default_create_time();
+
+commit;
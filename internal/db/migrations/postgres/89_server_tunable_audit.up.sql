@@ -0,0 +1,35 @@
+begin;
+
+-- server_tunable_audit records each change of a runtime tunable of a
+-- controller, like its log level or maintenance mode, made through the api:
+-- who changed which tunable on which controller, and its values before and
+-- after. It's append only.
+create table server_tunable_audit (
+  id bigint generated always as identity primary key,
+  controller_id text not null
+    constraint controller_id_must_not_be_empty
+    check(length(trim(controller_id)) > 0),
+  user_id wt_user_id not null,
+  name text not null
+    constraint name_must_not_be_empty
+    check(length(trim(name)) > 0),
+  old_value text not null,
+  new_value text not null,
+  create_time wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on server_tunable_audit
+  for each row execute procedure immutable_columns('id', 'controller_id', 'user_id', 'name', 'old_value', 'new_value', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on server_tunable_audit
+  for each row execute procedure default_create_time();
+
+create index server_tunable_audit_create_time_ix on server_tunable_audit (create_time);
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+drop index session_user_ix;
+drop table auth_token_source;
+
+commit;
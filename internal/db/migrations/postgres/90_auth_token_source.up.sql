@@ -0,0 +1,32 @@
+begin;
+
+-- auth_token_source records the address a client authenticated from when its
+-- auth token was created, so users reviewing their own tokens can recognize
+-- them.
+create table auth_token_source (
+  auth_token_id wt_public_id primary key
+    references auth_token(public_id)
+    on delete cascade
+    on update cascade,
+  source_address text not null
+    constraint source_address_must_not_be_empty
+    check(length(trim(source_address)) > 0),
+  create_time wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on auth_token_source
+  for each row execute procedure immutable_columns('auth_token_id', 'source_address', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on auth_token_source
+  for each row execute procedure default_create_time();
+
+-- session_user_ix speeds up listing the sessions of a user.
+create index session_user_ix on session (user_id, create_time);
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+drop table auth_account_login_signal;
+
+commit;
@@ -0,0 +1,34 @@
+begin;
+
+-- auth_account_login_signal records the devices, addresses and locations an
+-- account has logged in from, so logins from new ones can be made to step up
+-- their authentication.
+create table auth_account_login_signal (
+  auth_account_id wt_public_id
+    references auth_account(public_id)
+    on delete cascade
+    on update cascade,
+  signal text not null
+    constraint signal_must_be_known
+    check(signal in ('device', 'address', 'location')),
+  value text not null
+    constraint value_must_not_be_empty
+    check(length(trim(value)) > 0),
+  create_time wt_timestamp,
+  last_seen_time wt_timestamp,
+  primary key(auth_account_id, signal, value)
+);
+
+create trigger
+  immutable_columns
+before
+update on auth_account_login_signal
+  for each row execute procedure immutable_columns('auth_account_id', 'signal', 'value', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on auth_account_login_signal
+  for each row execute procedure default_create_time();
+
+commit;
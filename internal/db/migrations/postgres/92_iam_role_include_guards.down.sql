@@ -0,0 +1,31 @@
+begin;
+
+drop trigger iam_role_include_scope on iam_role_include;
+drop function iam_role_include_scope;
+
+create or replace function
+  iam_role_include_no_cycle()
+  returns trigger
+as $$
+begin
+  perform from (
+    with recursive reachable (role_id) as (
+      select included_role_id
+        from iam_role_include
+       where role_id = new.included_role_id
+       union
+      select iam_role_include.included_role_id
+        from iam_role_include,
+             reachable
+       where iam_role_include.role_id = reachable.role_id
+    )
+    select role_id from reachable where role_id = new.role_id
+  ) as cycle;
+  if found then
+    raise exception 'role % including % creates a cycle', new.role_id, new.included_role_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+commit;
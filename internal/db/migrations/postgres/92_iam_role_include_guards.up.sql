@@ -0,0 +1,71 @@
+begin;
+
+-- iam_role_include_no_cycle is replaced to lock iam_role_include before it
+-- looks for a cycle. Without the lock, two transactions adding includes which
+-- only form a cycle together, like a including b and b including a, could
+-- each find no cycle and both commit. The lock is held until the transaction
+-- ends, and each statement of the function sees the rows committed before
+-- it, so the second transaction's check sees the first one's include.
+create or replace function
+  iam_role_include_no_cycle()
+  returns trigger
+as $$
+begin
+  lock table iam_role_include in share row exclusive mode;
+  perform from (
+    with recursive reachable (role_id) as (
+      select included_role_id
+        from iam_role_include
+       where role_id = new.included_role_id
+       union
+      select iam_role_include.included_role_id
+        from iam_role_include,
+             reachable
+       where iam_role_include.role_id = reachable.role_id
+    )
+    select role_id from reachable where role_id = new.role_id
+  ) as cycle;
+  if found then
+    raise exception 'role % including % creates a cycle', new.role_id, new.included_role_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+-- iam_role_include_scope ensures that a role only includes roles in its own
+-- scope, its parent scope or the global scope.
+create or replace function
+  iam_role_include_scope()
+  returns trigger
+as $$
+declare
+  role_scope_id text;
+  role_parent_id text;
+  included_scope_id text;
+begin
+  select iam_role.scope_id, iam_scope.parent_id
+    into role_scope_id, role_parent_id
+    from iam_role
+    join iam_scope on iam_scope.public_id = iam_role.scope_id
+   where iam_role.public_id = new.role_id;
+  select iam_role.scope_id
+    into included_scope_id
+    from iam_role
+   where iam_role.public_id = new.included_role_id;
+  if included_scope_id = role_scope_id
+     or included_scope_id = role_parent_id
+     or included_scope_id = 'global' then
+    return new;
+  end if;
+  raise exception 'role % in scope % cannot include role % in scope %',
+    new.role_id, role_scope_id, new.included_role_id, included_scope_id;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_role_include_scope
+before
+insert on iam_role_include
+  for each row execute procedure iam_role_include_scope();
+
+commit;
@@ -0,0 +1,20 @@
+begin;
+
+-- iam_snapshot_import records where an org imported from an iam snapshot came
+-- from: the id of the org in the snapshot, the key id and signer of the
+-- snapshot's signature, and whether the signature verified when the snapshot
+-- was imported. Records are deleted with their org.
+create table iam_snapshot_import (
+  scope_id wt_scope_id primary key
+    references iam_scope_org(scope_id)
+    on delete cascade
+    on update cascade,
+  snapshot_org_id text not null,
+  key_id text,
+  signer text,
+  signed_time timestamp with time zone,
+  verified boolean not null,
+  create_time wt_timestamp
+);
+
+commit;
@@ -0,0 +1,71 @@
+begin;
+
+drop view session_with_state;
+create view session_with_state as
+select
+  s.public_id,
+  s.user_id,
+  s.host_id,
+  s.server_id,
+  s.server_type,
+  s.target_id,
+  s.host_set_id,
+  s.auth_token_id,
+  s.scope_id,
+  s.certificate,
+  s.expiration_time,
+  s.connection_limit,
+  s.resume_window_seconds,
+  s.tofu_token,
+  s.key_id,
+  s.termination_reason,
+  s.version,
+  s.create_time,
+  s.update_time,
+  s.endpoint,
+  ss.state,
+  ss.previous_end_time,
+  ss.start_time,
+  ss.end_time
+from
+  session s,
+  session_state ss
+where
+  s.public_id = ss.session_id;
+
+drop trigger immutable_columns on session;
+create trigger
+  immutable_columns
+before
+update on session
+  for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'resume_window_seconds', 'create_time', 'endpoint');
+
+alter table session
+  drop column justification;
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  banner,
+  banner_acknowledgment_required,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+alter table target_tcp
+  drop column justification_pattern;
+
+commit;
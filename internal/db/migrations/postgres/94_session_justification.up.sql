@@ -0,0 +1,85 @@
+begin;
+
+-- A target's justification_pattern is a regular expression the justification
+-- given when authorizing a session to the target must match entirely. A
+-- target with a pattern requires a justification.
+alter table target_tcp
+  add column justification_pattern text
+    constraint justification_pattern_must_not_be_empty
+    check(length(trim(justification_pattern)) > 0);
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  banner,
+  banner_acknowledgment_required,
+  justification_pattern,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+-- justification is the reason the user gave for the session when it was
+-- authorized, such as a ticket number. It's included in the session's audit
+-- event.
+alter table session
+  add column justification text
+    constraint justification_must_not_be_empty
+    check(length(trim(justification)) > 0)
+    constraint justification_must_not_be_too_long
+    check(length(justification) <= 1024);
+
+drop trigger immutable_columns on session;
+create trigger
+  immutable_columns
+before
+update on session
+  for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'resume_window_seconds', 'justification', 'create_time', 'endpoint');
+
+drop view session_with_state;
+create view session_with_state as
+select
+  s.public_id,
+  s.user_id,
+  s.host_id,
+  s.server_id,
+  s.server_type,
+  s.target_id,
+  s.host_set_id,
+  s.auth_token_id,
+  s.scope_id,
+  s.certificate,
+  s.expiration_time,
+  s.connection_limit,
+  s.resume_window_seconds,
+  s.justification,
+  s.tofu_token,
+  s.key_id,
+  s.termination_reason,
+  s.version,
+  s.create_time,
+  s.update_time,
+  s.endpoint,
+  ss.state,
+  ss.previous_end_time,
+  ss.start_time,
+  ss.end_time
+from
+  session s,
+  session_state ss
+where
+  s.public_id = ss.session_id;
+
+commit;
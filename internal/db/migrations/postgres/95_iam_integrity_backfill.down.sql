@@ -0,0 +1,41 @@
+begin;
+
+drop trigger immutable_role_principal on iam_group_role;
+create trigger immutable_role_principal
+before
+update on iam_group_role
+  for each row execute procedure iam_immutable_role_principal();
+
+drop trigger immutable_role_principal on iam_user_role;
+create trigger immutable_role_principal
+before
+update on iam_user_role
+  for each row execute procedure iam_immutable_role_principal();
+
+drop trigger immutable_role_grant on iam_role_grant;
+create trigger immutable_role_grant
+before
+update on iam_role_grant
+  for each row execute procedure iam_immutable_role_grant();
+
+drop function iam_immutable_except_integrity;
+
+create table iam_integrity (
+  private_id text primary key
+    constraint only_one_integrity_row_allowed
+    check(
+      private_id = 'iam_integrity'
+    ),
+  signed_since wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on iam_integrity
+  for each row execute procedure immutable_columns('private_id', 'signed_since');
+
+insert into iam_integrity (private_id, signed_since)
+  values ('iam_integrity', now());
+
+commit;
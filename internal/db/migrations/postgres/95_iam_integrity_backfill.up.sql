@@ -0,0 +1,44 @@
+begin;
+
+-- The time the controller started signing rows was kept in the database, so
+-- anyone able to write rows without an hmac could also move it to accept
+-- them. Rows without an hmac are now signed by the controller when it starts
+-- without integrity enforcement, and every row must have a valid hmac when
+-- it's enforced.
+drop table iam_integrity;
+
+-- iam_immutable_except_integrity() ensures that only the integrity hmac of a
+-- row can be updated, so the controller can sign rows written before it
+-- started signing them, and re-sign a role's grants when its grant scope
+-- changes.
+create or replace function
+  iam_immutable_except_integrity()
+  returns trigger
+as $$
+begin
+  if (to_jsonb(new) - 'integrity_key_id' - 'integrity_hmac') <> (to_jsonb(old) - 'integrity_key_id' - 'integrity_hmac') then
+    raise exception '% rows are immutable', tg_table_name;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+drop trigger immutable_role_grant on iam_role_grant;
+create trigger immutable_role_grant
+before
+update on iam_role_grant
+  for each row execute procedure iam_immutable_except_integrity();
+
+drop trigger immutable_role_principal on iam_user_role;
+create trigger immutable_role_principal
+before
+update on iam_user_role
+  for each row execute procedure iam_immutable_except_integrity();
+
+drop trigger immutable_role_principal on iam_group_role;
+create trigger immutable_role_principal
+before
+update on iam_group_role
+  for each row execute procedure iam_immutable_except_integrity();
+
+commit;
@@ -0,0 +1,10 @@
+begin;
+
+drop trigger default_create_time_column on iam_banned_grant_pattern;
+drop trigger immutable_columns on iam_banned_grant_pattern;
+
+delete
+  from oplog_ticket
+ where name = 'iam_banned_grant_pattern';
+
+commit;
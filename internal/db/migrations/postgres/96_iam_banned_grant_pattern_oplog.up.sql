@@ -0,0 +1,21 @@
+begin;
+
+-- Banned grant patterns are written through the repository with oplog
+-- entries, so they're immutable once created and need an oplog ticket.
+create trigger
+  default_create_time_column
+before
+insert on iam_banned_grant_pattern
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on iam_banned_grant_pattern
+  for each row execute procedure immutable_columns('scope_id', 'name', 'pattern', 'description', 'create_time');
+
+insert into oplog_ticket (name, version)
+values
+  ('iam_banned_grant_pattern', 1);
+
+commit;
@@ -0,0 +1,17 @@
+begin;
+
+drop trigger immutable_columns on session_banner_acknowledgment;
+create trigger
+  immutable_columns
+before
+update on session_banner_acknowledgment
+  for each row execute procedure immutable_columns('session_id', 'target_id', 'user_id', 'auth_token_id', 'banner', 'create_time');
+
+alter table session_banner_acknowledgment
+  drop constraint banner_hash_must_match_banner,
+  drop column banner_hash;
+
+alter table session_banner_acknowledgment
+  rename to target_banner_acknowledgment;
+
+commit;
@@ -0,0 +1,29 @@
+begin;
+
+-- Banner acknowledgments are written by the session repository, in the
+-- transaction which creates the session they're for.
+alter table target_banner_acknowledgment
+  rename to session_banner_acknowledgment;
+
+-- banner_hash identifies the version of the banner which was acknowledged.
+-- Users acknowledge a banner by its hash, so a banner which changed after it
+-- was shown to them isn't acknowledged by mistake.
+alter table session_banner_acknowledgment
+  add column banner_hash text;
+
+update session_banner_acknowledgment
+   set banner_hash = encode(digest(banner, 'sha256'), 'hex');
+
+alter table session_banner_acknowledgment
+  alter column banner_hash set not null,
+  add constraint banner_hash_must_match_banner
+    check(banner_hash = encode(digest(banner, 'sha256'), 'hex'));
+
+drop trigger immutable_columns on session_banner_acknowledgment;
+create trigger
+  immutable_columns
+before
+update on session_banner_acknowledgment
+  for each row execute procedure immutable_columns('session_id', 'target_id', 'user_id', 'auth_token_id', 'banner', 'banner_hash', 'create_time');
+
+commit;
@@ -0,0 +1,92 @@
+begin;
+
+-- A target's change_ticket_system names the change ticket system, registered
+-- on the controller, which must accept the change ticket given when authorizing
+-- a session to the target. A target with a change ticket system requires a
+-- change ticket.
+alter table target_tcp
+  add column change_ticket_system text
+    constraint change_ticket_system_must_not_be_empty
+    check(length(trim(change_ticket_system)) > 0);
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  banner,
+  banner_acknowledgment_required,
+  justification_pattern,
+  change_ticket_system,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+-- change_ticket is the change ticket reference the user gave for the session
+-- when it was authorized, and change_ticket_system the system of the target
+-- which accepted it. They're included in the session's audit event.
+alter table session
+  add column change_ticket_system text
+    constraint change_ticket_system_must_not_be_empty
+    check(length(trim(change_ticket_system)) > 0),
+  add column change_ticket text
+    constraint change_ticket_must_not_be_empty
+    check(length(trim(change_ticket)) > 0)
+    constraint change_ticket_must_not_be_too_long
+    check(length(change_ticket) <= 256);
+
+drop trigger immutable_columns on session;
+create trigger
+  immutable_columns
+before
+update on session
+  for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'resume_window_seconds', 'justification', 'change_ticket_system', 'change_ticket', 'create_time', 'endpoint');
+
+drop view session_with_state;
+create view session_with_state as
+select
+  s.public_id,
+  s.user_id,
+  s.host_id,
+  s.server_id,
+  s.server_type,
+  s.target_id,
+  s.host_set_id,
+  s.auth_token_id,
+  s.scope_id,
+  s.certificate,
+  s.expiration_time,
+  s.connection_limit,
+  s.resume_window_seconds,
+  s.justification,
+  s.change_ticket_system,
+  s.change_ticket,
+  s.tofu_token,
+  s.key_id,
+  s.termination_reason,
+  s.version,
+  s.create_time,
+  s.update_time,
+  s.endpoint,
+  ss.state,
+  ss.previous_end_time,
+  ss.start_time,
+  ss.end_time
+from
+  session s,
+  session_state ss
+where
+  s.public_id = ss.session_id;
+
+commit;
@@ -0,0 +1,182 @@
+begin;
+
+-- Client certificate libraries replace the per target settings of
+-- target_client_certificate_library. A library is a resource of a project,
+-- which is attached to the targets in the project whose workers present client
+-- certificates.
+drop table target_client_certificate_library;
+
+-- client_certificate_library issues the short-lived client certificates a
+-- worker presents to a target which requires client TLS.
+--
+-- An internal library issues certificates from a CA Boundary generated for
+-- it, whose private key is the library's secret. A vault library issues them
+-- from the role of a Vault PKI secrets engine, and its secret is the Vault
+-- token it issues them with. The secret is encrypted with the database key of
+-- the library's scope.
+create table client_certificate_library (
+  public_id wt_public_id primary key,
+  scope_id wt_scope_id not null
+    references iam_scope_project (scope_id)
+    on delete cascade
+    on update cascade,
+  name text,
+  description text,
+  issuer text not null
+    constraint only_predefined_issuers_allowed
+    check(issuer in ('internal', 'vault')),
+  common_name text
+    constraint common_name_must_not_be_empty
+    check(length(trim(common_name)) > 0),
+  -- server_name and server_ca_cert verify the target's certificate. If
+  -- server_name isn't set, the host of the session's endpoint is used, and if
+  -- server_ca_cert isn't set, the worker's system roots are.
+  server_name text
+    constraint server_name_must_not_be_empty
+    check(length(trim(server_name)) > 0),
+  server_ca_cert bytea,
+  ca_cert bytea,
+  vault_address text,
+  vault_pki_path text,
+  vault_role text,
+  secret bytea not null,
+  -- TODO: Make key_id a foreign key once we have DEKs
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  unique(scope_id, name),
+  constraint internal_issuer_has_ca
+    check(issuer != 'internal' or ca_cert is not null),
+  constraint vault_issuer_has_vault_role
+    check(issuer != 'vault'
+      or (vault_address is not null
+        and vault_pki_path is not null
+        and vault_role is not null))
+);
+
+create trigger
+  update_version_column
+after update on client_certificate_library
+  for each row execute procedure update_version_column();
+
+create trigger
+  update_time_column
+before update on client_certificate_library
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on client_certificate_library
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on client_certificate_library
+  for each row execute procedure immutable_columns('public_id', 'scope_id', 'issuer', 'ca_cert', 'create_time');
+
+-- A target's client_certificate_library_id is the library which issues the
+-- client certificates workers present to the target. A target without one
+-- isn't connected to over TLS. Deleting a library detaches it from its
+-- targets.
+alter table target_tcp
+  add column client_certificate_library_id wt_public_id
+    references client_certificate_library (public_id)
+    on delete set null
+    on update cascade;
+
+-- target_client_certificate_library_scope_valid() is a before insert and
+-- update trigger function for target_tcp, which ensures a target's client
+-- certificate library is in the target's project.
+create or replace function
+  target_client_certificate_library_scope_valid()
+  returns trigger
+as $$
+begin
+  if new.client_certificate_library_id is null then
+    return new;
+  end if;
+  perform from
+    client_certificate_library l
+  where
+    l.public_id = new.client_certificate_library_id and
+    l.scope_id = new.scope_id;
+  if not found then
+    raise exception 'client certificate library and target do not belong to the same scope';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  target_client_certificate_library_scope_valid
+before
+insert or update of client_certificate_library_id on target_tcp
+  for each row execute procedure target_client_certificate_library_scope_valid();
+
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  session_idle_timeout_seconds,
+  session_recording,
+  session_resume_seconds,
+  banner,
+  banner_acknowledgment_required,
+  justification_pattern,
+  change_ticket_system,
+  client_certificate_library_id,
+  version,
+  create_time,
+  update_time,
+  'tcp' as type
+  from target_tcp;
+
+-- session_client_certificate is the client certificate issued for a session
+-- by its target's client certificate library when the session was
+-- authorized. Workers present it to the target for every connection of the
+-- session. The private key is encrypted with the database key of the
+-- session's scope.
+create table session_client_certificate (
+  session_id wt_public_id primary key
+    references session (public_id)
+    on delete cascade
+    on update cascade,
+  certificate bytea not null,
+  private_key bytea not null,
+  server_name text
+    constraint server_name_must_not_be_empty
+    check(length(trim(server_name)) > 0),
+  server_ca_cert bytea,
+  expiration_time wt_timestamp,
+  -- TODO: Make key_id a foreign key once we have DEKs
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  create_time wt_timestamp
+);
+
+create trigger
+  immutable_columns
+before
+update on session_client_certificate
+  for each row execute procedure immutable_columns('session_id', 'certificate', 'private_key', 'server_name', 'server_ca_cert', 'expiration_time', 'key_id', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on session_client_certificate
+  for each row execute procedure default_create_time();
+
+commit;
@@ -1,6 +1,9 @@
 package db
 
 import (
+	"time"
+
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/boundary/internal/oplog"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
 )
@@ -40,6 +43,22 @@ type Options struct {
 	withWhereClause     string
 	withWhereClauseArgs []interface{}
 	withOrder           string
+
+	withIdGenerator IdGenerator
+
+	withMetrics        *metrics.Metrics
+	withMaxConcurrency int
+
+	withRequireRequestInfo bool
+
+	withMaxOpenConnections  int
+	withMaxIdleConnections  int
+	withConnMaxLifetime     time.Duration
+	withStatementTimeout    time.Duration
+	withRetryTransientError bool
+	withFailover            bool
+	withSrvRecord           string
+	withRequirePrimary      bool
 }
 
 type oplogOpts struct {
@@ -155,3 +174,104 @@ func WithOrder(withOrder string) Option {
 		o.withOrder = withOrder
 	}
 }
+
+// WithIdGenerator provides an option to generate ids with gen instead of the
+// package default set by SetDefaultIdGenerator.
+func WithIdGenerator(gen IdGenerator) Option {
+	return func(o *Options) {
+		o.withIdGenerator = gen
+	}
+}
+
+// WithMetrics provides an option to emit metrics to m instead of the global
+// go-metrics instance.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(o *Options) {
+		o.withMetrics = m
+	}
+}
+
+// WithMaxConcurrency provides an option to limit the number of operations
+// that run at once. Zero means no limit.
+func WithMaxConcurrency(max int) Option {
+	return func(o *Options) {
+		o.withMaxConcurrency = max
+	}
+}
+
+// WithRequireRequestInfo provides an option for a Db to refuse writes whose
+// context has no RequestInfo.
+func WithRequireRequestInfo(require bool) Option {
+	return func(o *Options) {
+		o.withRequireRequestInfo = require
+	}
+}
+
+// WithMaxOpenConnections provides an option for Open to limit the number of
+// open connections in the pool. Zero means no limit.
+func WithMaxOpenConnections(max int) Option {
+	return func(o *Options) {
+		o.withMaxOpenConnections = max
+	}
+}
+
+// WithMaxIdleConnections provides an option for Open to set the number of idle
+// connections kept in the pool. Zero uses the database/sql default.
+func WithMaxIdleConnections(max int) Option {
+	return func(o *Options) {
+		o.withMaxIdleConnections = max
+	}
+}
+
+// WithConnMaxLifetime provides an option for Open to close connections once
+// they have been open for d. Zero means connections are reused forever.
+func WithConnMaxLifetime(d time.Duration) Option {
+	return func(o *Options) {
+		o.withConnMaxLifetime = d
+	}
+}
+
+// WithStatementTimeout provides an option for Open to have the database
+// cancel any statement that runs for longer than d. Zero means no timeout.
+func WithStatementTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		o.withStatementTimeout = d
+	}
+}
+
+// WithRetryTransientErrors provides an option for a Db to retry transactions
+// in DoTx which fail with a transient error, as reported by IsTransientError,
+// in the same way it retries ones which fail to redeem their oplog ticket.
+func WithRetryTransientErrors(enable bool) Option {
+	return func(o *Options) {
+		o.withRetryTransientError = enable
+	}
+}
+
+// WithFailover provides an option for Open to handle the failover of the
+// database: the host of the connection url is resolved again for each new
+// connection, and a connection seeing the server go away or refuse writes
+// makes the pool drop every connection made before it, so the pool is rebuilt
+// against the new primary.
+func WithFailover(enable bool) Option {
+	return func(o *Options) {
+		o.withFailover = enable
+	}
+}
+
+// WithSrvRecord provides an option for Open, with WithFailover, to connect to
+// the servers listed by the DNS SRV record name, in order of priority, instead
+// of the host and port of the connection url.
+func WithSrvRecord(name string) Option {
+	return func(o *Options) {
+		o.withSrvRecord = name
+	}
+}
+
+// WithRequirePrimary provides an option for Open, with WithFailover, to skip
+// servers which refuse writes when connecting, so only the primary is used.
+func WithRequirePrimary(require bool) Option {
+	return func(o *Options) {
+		o.withRequirePrimary = require
+	}
+}
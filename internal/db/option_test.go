@@ -2,6 +2,7 @@ package db
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/stretchr/testify/assert"
@@ -175,4 +176,67 @@ func Test_getOpts(t *testing.T) {
 		testOpts.withOrder = "version desc"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithIdGenerator", func(t *testing.T) {
+		assert := assert.New(t)
+		// test default of nil
+		opts := GetOpts()
+		assert.Nil(opts.withIdGenerator)
+
+		opts = GetOpts(WithIdGenerator(Base62IdGenerator))
+		assert.NotNil(opts.withIdGenerator)
+	})
+	t.Run("WithRequireRequestInfo", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts()
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+
+		opts = GetOpts(WithRequireRequestInfo(true))
+		testOpts.withRequireRequestInfo = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithPool", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts()
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+
+		opts = GetOpts(WithMaxOpenConnections(10), WithMaxIdleConnections(5), WithConnMaxLifetime(time.Minute))
+		testOpts.withMaxOpenConnections = 10
+		testOpts.withMaxIdleConnections = 5
+		testOpts.withConnMaxLifetime = time.Minute
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithStatementTimeout", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts()
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+
+		opts = GetOpts(WithStatementTimeout(time.Second))
+		testOpts.withStatementTimeout = time.Second
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithRetryTransientErrors", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts()
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+
+		opts = GetOpts(WithRetryTransientErrors(true))
+		testOpts.withRetryTransientError = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithFailover", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts()
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+
+		opts = GetOpts(WithFailover(true), WithSrvRecord("_postgresql._tcp.db.example.com"), WithRequirePrimary(true))
+		testOpts.withFailover = true
+		testOpts.withSrvRecord = "_postgresql._tcp.db.example.com"
+		testOpts.withRequirePrimary = true
+		assert.Equal(opts, testOpts)
+	})
 }
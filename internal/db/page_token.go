@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrInvalidPageToken is returned when a page token can't be decoded, was
+// tampered with or was issued for a different listing.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// ErrPageRequired is returned when a listing has more results than the
+// maximum page size and wasn't requested a page at a time.
+var ErrPageRequired = errors.New("listing must be paginated")
+
+// PageOrder is the order of a paginated listing. Ordering by the public id
+// after the create time makes the order total, so a page boundary is never
+// ambiguous.
+const PageOrder = "create_time, public_id"
+
+// PageToken is the position after the last resource of a page of a listing
+// ordered by PageOrder. Since the next page starts after a key rather than at
+// an offset, resources created or deleted while a listing is paged through
+// don't cause other resources to be skipped or returned twice.
+type PageToken struct {
+	// SortKey is the create time of the last resource seen.
+	SortKey time.Time `json:"k"`
+	// LastId is the public id of the last resource seen.
+	LastId string `json:"i"`
+}
+
+// Where returns the where clause and args which select the resources after the
+// token.
+func (t *PageToken) Where() (string, []interface{}) {
+	return "(create_time, public_id) > (?, ?)", []interface{}{t.SortKey, t.LastId}
+}
+
+// EncodePageToken encrypts the token with the wrapper and returns it in an
+// opaque form suitable for returning to clients. The aad binds the token to
+// a listing: DecodePageToken must be given the same aad.
+func EncodePageToken(ctx context.Context, wrapper wrapping.Wrapper, aad []byte, t *PageToken) (string, error) {
+	if wrapper == nil {
+		return "", fmt.Errorf("encode page token: missing wrapper: %w", ErrInvalidParameter)
+	}
+	if t == nil || t.LastId == "" || t.SortKey.IsZero() {
+		return "", fmt.Errorf("encode page token: missing position: %w", ErrInvalidParameter)
+	}
+	marshaled, err := json.Marshal(t)
+	if err != nil {
+		return "", fmt.Errorf("encode page token: %w", err)
+	}
+	blobInfo, err := wrapper.Encrypt(ctx, marshaled, aad)
+	if err != nil {
+		return "", fmt.Errorf("encode page token: %w", err)
+	}
+	marshaledBlob, err := proto.Marshal(blobInfo)
+	if err != nil {
+		return "", fmt.Errorf("encode page token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(marshaledBlob), nil
+}
+
+// DecodePageToken returns the position encoded by EncodePageToken. It returns
+// an error wrapping ErrInvalidPageToken if the token isn't valid for the
+// wrapper and aad.
+func DecodePageToken(ctx context.Context, wrapper wrapping.Wrapper, aad []byte, token string) (*PageToken, error) {
+	if wrapper == nil {
+		return nil, fmt.Errorf("decode page token: missing wrapper: %w", ErrInvalidParameter)
+	}
+	marshaledBlob, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("decode page token: %w: %v", ErrInvalidPageToken, err)
+	}
+	var blobInfo wrapping.EncryptedBlobInfo
+	if err := proto.Unmarshal(marshaledBlob, &blobInfo); err != nil {
+		return nil, fmt.Errorf("decode page token: %w: %v", ErrInvalidPageToken, err)
+	}
+	marshaled, err := wrapper.Decrypt(ctx, &blobInfo, aad)
+	if err != nil {
+		return nil, fmt.Errorf("decode page token: %w: %v", ErrInvalidPageToken, err)
+	}
+	var t PageToken
+	if err := json.Unmarshal(marshaled, &t); err != nil {
+		return nil, fmt.Errorf("decode page token: %w: %v", ErrInvalidPageToken, err)
+	}
+	if t.LastId == "" || t.SortKey.IsZero() {
+		return nil, fmt.Errorf("decode page token: missing position: %w", ErrInvalidPageToken)
+	}
+	return &t, nil
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	wrapper := TestWrapper(t)
+	aad := []byte("scope_id = ?o_1234567890")
+	want := &PageToken{
+		SortKey: time.Date(2020, 10, 1, 12, 0, 0, 123456000, time.UTC),
+		LastId:  "r_1234567890",
+	}
+
+	t.Run("round-trip", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		token, err := EncodePageToken(ctx, wrapper, aad, want)
+		require.NoError(err)
+		assert.NotContains(token, want.LastId)
+
+		got, err := DecodePageToken(ctx, wrapper, aad, token)
+		require.NoError(err)
+		assert.True(want.SortKey.Equal(got.SortKey))
+		assert.Equal(want.LastId, got.LastId)
+
+		where, args := got.Where()
+		assert.Equal("(create_time, public_id) > (?, ?)", where)
+		assert.Len(args, 2)
+	})
+	t.Run("wrong-aad", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		token, err := EncodePageToken(ctx, wrapper, aad, want)
+		require.NoError(err)
+		_, err = DecodePageToken(ctx, wrapper, []byte("scope_id = ?o_0987654321"), token)
+		assert.True(errors.Is(err, ErrInvalidPageToken))
+	})
+	t.Run("wrong-wrapper", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		token, err := EncodePageToken(ctx, wrapper, aad, want)
+		require.NoError(err)
+		_, err = DecodePageToken(ctx, TestWrapper(t), aad, token)
+		assert.True(errors.Is(err, ErrInvalidPageToken))
+	})
+	t.Run("tampered", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		token, err := EncodePageToken(ctx, wrapper, aad, want)
+		require.NoError(err)
+		b := []byte(token)
+		if b[len(b)/2] == 'A' {
+			b[len(b)/2] = 'B'
+		} else {
+			b[len(b)/2] = 'A'
+		}
+		_, err = DecodePageToken(ctx, wrapper, aad, string(b))
+		assert.True(errors.Is(err, ErrInvalidPageToken))
+	})
+	t.Run("garbage", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := DecodePageToken(ctx, wrapper, aad, "not a token!")
+		assert.True(errors.Is(err, ErrInvalidPageToken))
+	})
+	t.Run("missing-position", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := EncodePageToken(ctx, wrapper, aad, &PageToken{LastId: "r_1234567890"})
+		assert.True(errors.Is(err, ErrInvalidParameter))
+		_, err = EncodePageToken(ctx, wrapper, aad, nil)
+		assert.True(errors.Is(err, ErrInvalidParameter))
+	})
+	t.Run("missing-wrapper", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := EncodePageToken(ctx, nil, aad, want)
+		assert.True(errors.Is(err, ErrInvalidParameter))
+		_, err = DecodePageToken(ctx, nil, aad, "token")
+		assert.True(errors.Is(err, ErrInvalidParameter))
+	})
+}
@@ -165,15 +165,28 @@ type VetForWriter interface {
 
 // Db uses a gorm DB connection for read/write
 type Db struct {
-	underlying *gorm.DB
+	underlying           *gorm.DB
+	requireRequestInfo   bool
+	retryTransientErrors bool
+	// inTx is whether the Db is the one of a transaction in DoTx.
+	inTx bool
 }
 
 // ensure that Db implements the interfaces of: Reader and Writer
 var _ Reader = (*Db)(nil)
 var _ Writer = (*Db)(nil)
 
-func New(underlying *gorm.DB) *Db {
-	return &Db{underlying: underlying}
+// New creates a Db. Supports the options WithRequireRequestInfo, which makes
+// every write return an error unless its context was created with
+// NewRequestInfoContext, and WithRetryTransientErrors, which also retries
+// lookups and searches outside transactions.
+func New(underlying *gorm.DB, opt ...Option) *Db {
+	opts := GetOpts(opt...)
+	return &Db{
+		underlying:           underlying,
+		requireRequestInfo:   opts.withRequireRequestInfo,
+		retryTransientErrors: opts.withRetryTransientError,
+	}
 }
 
 // Exec will execute the sql with the values as parameters. The int returned
@@ -240,6 +253,9 @@ func (rw *Db) Create(ctx context.Context, i interface{}, opt ...Option) error {
 	if isNil(i) {
 		return fmt.Errorf("create: interface is missing: %w", ErrInvalidParameter)
 	}
+	if err := rw.checkRequestInfo(ctx); err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
 	opts := GetOpts(opt...)
 	withOplog := opts.withOplog
 	if withOplog && opts.newOplogMsg != nil {
@@ -258,6 +274,9 @@ func (rw *Db) Create(ctx context.Context, i interface{}, opt ...Option) error {
 
 	if !opts.withSkipVetForWrite {
 		if vetter, ok := i.(VetForWriter); ok {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("create: vet for write: %w", err)
+			}
 			if err := vetter.VetForWrite(ctx, rw, CreateOp); err != nil {
 				return fmt.Errorf("create: vet for write failed: %w", err)
 			}
@@ -302,6 +321,9 @@ func (rw *Db) CreateItems(ctx context.Context, createItems []interface{}, opt ..
 	if len(createItems) == 0 {
 		return fmt.Errorf("create items: no interfaces to create: %w", ErrInvalidParameter)
 	}
+	if err := rw.checkRequestInfo(ctx); err != nil {
+		return fmt.Errorf("create items: %w", err)
+	}
 	opts := GetOpts(opt...)
 	if opts.withLookup {
 		return fmt.Errorf("create items: with lookup not a supported option: %w", ErrInvalidParameter)
@@ -381,6 +403,9 @@ func (rw *Db) Update(ctx context.Context, i interface{}, fieldMaskPaths []string
 	if isNil(i) {
 		return NoRowsAffected, fmt.Errorf("update: interface is missing %w", ErrInvalidParameter)
 	}
+	if err := rw.checkRequestInfo(ctx); err != nil {
+		return NoRowsAffected, fmt.Errorf("update: %w", err)
+	}
 	if len(fieldMaskPaths) == 0 && len(setToNullPaths) == 0 {
 		return NoRowsAffected, errors.New("update: both fieldMaskPaths and setToNullPaths are missing")
 	}
@@ -427,6 +452,9 @@ func (rw *Db) Update(ctx context.Context, i interface{}, fieldMaskPaths []string
 	}
 	if !opts.withSkipVetForWrite {
 		if vetter, ok := i.(VetForWriter); ok {
+			if err := ctx.Err(); err != nil {
+				return NoRowsAffected, fmt.Errorf("update: vet for write: %w", err)
+			}
 			if err := vetter.VetForWrite(ctx, rw, UpdateOp, WithFieldMaskPaths(fieldMaskPaths), WithNullPaths(setToNullPaths)); err != nil {
 				return NoRowsAffected, fmt.Errorf("update: vet for write failed: %w", err)
 			}
@@ -515,6 +543,9 @@ func (rw *Db) Delete(ctx context.Context, i interface{}, opt ...Option) (int, er
 	if isNil(i) {
 		return NoRowsAffected, fmt.Errorf("delete: interface is missing %w", ErrInvalidParameter)
 	}
+	if err := rw.checkRequestInfo(ctx); err != nil {
+		return NoRowsAffected, fmt.Errorf("delete: %w", err)
+	}
 	opts := GetOpts(opt...)
 	withOplog := opts.withOplog
 	if withOplog && opts.newOplogMsg != nil {
@@ -578,6 +609,9 @@ func (rw *Db) DeleteItems(ctx context.Context, deleteItems []interface{}, opt ..
 	if len(deleteItems) == 0 {
 		return NoRowsAffected, fmt.Errorf("delete items: no interfaces to delete: %w", ErrInvalidParameter)
 	}
+	if err := rw.checkRequestInfo(ctx); err != nil {
+		return NoRowsAffected, fmt.Errorf("delete items: %w", err)
+	}
 	opts := GetOpts(opt...)
 	if opts.newOplogMsg != nil {
 		return NoRowsAffected, fmt.Errorf("delete items: new oplog msg (singular) is not a supported option: %w", ErrInvalidParameter)
@@ -741,7 +775,7 @@ func (rw *Db) addOplogForItems(ctx context.Context, opType OpType, opts Options,
 	}
 	entry, err := oplog.NewEntry(
 		replayable.TableName(),
-		oplogArgs.metadata,
+		requestInfoMetadata(ctx, oplogArgs.metadata),
 		oplogArgs.wrapper,
 		ticketer,
 	)
@@ -774,7 +808,7 @@ func (rw *Db) addOplog(ctx context.Context, opType OpType, opts Options, ticket
 	}
 	entry, err := oplog.NewEntry(
 		replayable.TableName(),
-		oplogArgs.metadata,
+		requestInfoMetadata(ctx, oplogArgs.metadata),
 		oplogArgs.wrapper,
 		ticketer,
 	)
@@ -798,7 +832,8 @@ func (rw *Db) addOplog(ctx context.Context, opType OpType, opts Options, ticket
 }
 
 // WriteOplogEntryWith will write an oplog entry with the msgs provided for
-// the ticket's aggregateName. No options are currently supported.
+// the ticket's aggregateName. The RequestInfo of the context, if any, is added
+// to the metadata. No options are currently supported.
 func (rw *Db) WriteOplogEntryWith(ctx context.Context, wrapper wrapping.Wrapper, ticket *store.Ticket, metadata oplog.Metadata, msgs []*oplog.Message, opt ...Option) error {
 	if wrapper == nil {
 		return fmt.Errorf("write oplog: wrapper is unset %w", ErrInvalidParameter)
@@ -818,6 +853,9 @@ func (rw *Db) WriteOplogEntryWith(ctx context.Context, wrapper wrapping.Wrapper,
 	if len(metadata) == 0 {
 		return fmt.Errorf("write oplog: metadata is empty %w", ErrInvalidParameter)
 	}
+	if err := rw.checkRequestInfo(ctx); err != nil {
+		return fmt.Errorf("write oplog: %w", err)
+	}
 
 	ticketer, err := oplog.NewGormTicketer(rw.underlying, oplog.WithAggregateNames(true))
 	if err != nil {
@@ -826,7 +864,7 @@ func (rw *Db) WriteOplogEntryWith(ctx context.Context, wrapper wrapping.Wrapper,
 
 	entry, err := oplog.NewEntry(
 		ticket.Name,
-		metadata,
+		requestInfoMetadata(ctx, metadata),
 		wrapper,
 		ticketer,
 	)
@@ -873,42 +911,76 @@ func (rw *Db) newOplogMessage(ctx context.Context, opType OpType, i interface{},
 // DoTx will wrap the Handler func passed within a transaction with retries
 // you should ensure that any objects written to the db in your TxHandler are retryable, which
 // means that the object may be sent to the db several times (retried), so things like the primary key must
-// be reset before retry
+// be reset before retry. Transactions which fail to redeem their oplog ticket
+// are retried, as are ones which fail with a transient error if the Db was
+// created with WithRetryTransientErrors. No attempt is started once the
+// context is done, and the context ending stops the wait between attempts.
+//
+// A DoTx of the Db a TxHandler is given runs the Handler in the transaction
+// the TxHandler is in, once, so repositories made with a transaction's reader
+// and writer can be composed into one transaction. Its errors are left to the
+// outer DoTx to roll back and retry.
 func (w *Db) DoTx(ctx context.Context, retries uint, backOff Backoff, Handler TxHandler) (RetryInfo, error) {
 	if w.underlying == nil {
 		return RetryInfo{}, errors.New("do underlying db is nil")
 	}
+	if w.inTx {
+		return RetryInfo{}, Handler(w, w)
+	}
 	info := RetryInfo{}
 	for attempts := uint(1); ; attempts++ {
 		if attempts > retries+1 {
 			return info, fmt.Errorf("Too many retries: %d of %d", attempts-1, retries+1)
 		}
+		if err := ctx.Err(); err != nil {
+			return info, fmt.Errorf("do tx: %w", err)
+		}
 
 		// step one of this, start a transaction...
 		newTx := w.underlying.BeginTx(ctx, nil)
 
-		rw := &Db{newTx}
-		if err := Handler(rw, rw); err != nil {
+		rw := &Db{underlying: newTx, requireRequestInfo: w.requireRequestInfo, retryTransientErrors: w.retryTransientErrors, inTx: true}
+		err := Handler(rw, rw)
+		if err != nil {
 			if err := newTx.Rollback().Error; err != nil {
 				return info, err
 			}
-			if errors.Is(err, oplog.ErrTicketAlreadyRedeemed) {
-				d := backOff.Duration(attempts)
-				info.Retries++
-				info.Backoff = info.Backoff + d
-				time.Sleep(d)
-				continue
-			}
+		} else if err = newTx.Commit().Error; err == nil {
+			return info, nil // it all worked!!!
+		}
+		if !errors.Is(err, oplog.ErrTicketAlreadyRedeemed) && !(w.retryTransientErrors && IsTransientError(err)) {
 			return info, err
 		}
+		d := backOff.Duration(attempts)
+		info.Retries++
+		info.Backoff = info.Backoff + d
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return info, fmt.Errorf("do tx: %v: %w", err, ctx.Err())
+		}
+	}
+}
 
-		if err := newTx.Commit().Error; err != nil {
-			if err := newTx.Rollback().Error; err != nil {
-				return info, err
-			}
-			return info, err
+// readRetries is how many times a read which fails with a transient error is
+// retried.
+const readRetries = 8
+
+// retryRead runs the read, retrying it if it fails with a transient error and
+// the Db was created with WithRetryTransientErrors. A read has no effect, so
+// it can be retried even when the error leaves it unknown whether the server
+// ran it, but not within a transaction, which the error has aborted.
+func (rw *Db) retryRead(ctx context.Context, read func() error) error {
+	for attempts := uint(1); ; attempts++ {
+		err := read()
+		if err == nil || !rw.retryTransientErrors || rw.inTx || attempts > readRetries || !IsTransientError(err) {
+			return err
+		}
+		select {
+		case <-time.After(ExpBackoff{}.Duration(attempts)):
+		case <-ctx.Done():
+			return err
 		}
-		return info, nil // it all worked!!!
 	}
 }
 
@@ -925,7 +997,12 @@ func (rw *Db) LookupById(ctx context.Context, resourceWithIder interface{}, opt
 	if err != nil {
 		return fmt.Errorf("lookup by id: %w", err)
 	}
-	if err := rw.underlying.Where(where, primaryKey).First(resourceWithIder).Error; err != nil {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("lookup by id: %w", err)
+	}
+	if err := rw.retryRead(ctx, func() error {
+		return rw.underlying.Where(where, primaryKey).First(resourceWithIder).Error
+	}); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return ErrRecordNotFound
 		}
@@ -966,7 +1043,12 @@ func (rw *Db) LookupWhere(ctx context.Context, resource interface{}, where strin
 	if reflect.ValueOf(resource).Kind() != reflect.Ptr {
 		return errors.New("error interface parameter must to be a pointer for lookup by")
 	}
-	if err := rw.underlying.Where(where, args...).First(resource).Error; err != nil {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("lookup where: %w", err)
+	}
+	if err := rw.retryRead(ctx, func() error {
+		return rw.underlying.Where(where, args...).First(resource).Error
+	}); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return ErrRecordNotFound
 		}
@@ -987,6 +1069,9 @@ func (rw *Db) SearchWhere(ctx context.Context, resources interface{}, where stri
 	if reflect.ValueOf(resources).Kind() != reflect.Ptr {
 		return errors.New("error interface parameter must to be a pointer for search by")
 	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("search where: %w", err)
+	}
 	var err error
 	db := rw.underlying.Order(opts.withOrder)
 
@@ -1007,7 +1092,9 @@ func (rw *Db) SearchWhere(ctx context.Context, resources interface{}, where stri
 	}
 
 	// Perform the query
-	err = db.Find(resources).Error
+	err = rw.retryRead(ctx, func() error {
+		return db.Find(resources).Error
+	})
 	if err != nil {
 		// searching with a slice parameter does not return a gorm.ErrRecordNotFound
 		return err
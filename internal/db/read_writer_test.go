@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/vault/sdk/helper/base62"
 	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
@@ -519,6 +520,35 @@ func TestDb_Update(t *testing.T) {
 		require.Error(err)
 		assert.Equal(0, rowsUpdated)
 	})
+	t.Run("nested", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := &Db{underlying: db}
+		id, err := NewPublicId("i")
+		require.NoError(err)
+		_, err = w.DoTx(context.Background(), 0, ExpBackoff{},
+			func(_ Reader, txw Writer) error {
+				_, err := txw.DoTx(context.Background(), 0, ExpBackoff{},
+					func(_ Reader, nestedw Writer) error {
+						user, err := db_test.NewTestUser()
+						if err != nil {
+							return err
+						}
+						user.PublicId = id
+						return nestedw.Create(context.Background(), user)
+					})
+				if err != nil {
+					return err
+				}
+				// Failing the outer transaction rolls back the nested one
+				return errors.New("outer")
+			})
+		require.Error(err)
+		foundUser, err := db_test.NewTestUser()
+		require.NoError(err)
+		foundUser.PublicId = id
+		err = w.LookupByPublicId(context.Background(), foundUser)
+		assert.True(errors.Is(err, ErrRecordNotFound))
+	})
 	t.Run("nil-tx", func(t *testing.T) {
 		assert := assert.New(t)
 		w := Db{underlying: nil}
@@ -859,6 +889,16 @@ func TestDb_LookupWhere(t *testing.T) {
 		require.NoError(err)
 		assert.Equal(foundUser.Id, user.Id)
 	})
+	t.Run("canceled-ctx", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := Db{underlying: db}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var foundUser db_test.TestUser
+		err := w.LookupWhere(ctx, &foundUser, "public_id = ?", "u_1234567890")
+		require.Error(err)
+		assert.True(errors.Is(err, context.Canceled))
+	})
 	t.Run("tx-nil,", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 		w := Db{}
@@ -1109,7 +1149,7 @@ func TestDb_DoTx(t *testing.T) {
 	})
 	t.Run("nil-tx", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
-		w := &Db{nil}
+		w := &Db{underlying: nil}
 		attempts := 0
 		got, err := w.DoTx(context.Background(), 1, ExpBackoff{}, func(Reader, Writer) error { attempts += 1; return nil })
 		require.Error(err)
@@ -1133,6 +1173,52 @@ func TestDb_DoTx(t *testing.T) {
 		assert.Equal(3, got.Retries)
 		assert.Equal("Too many retries: 3 of 3", err.Error())
 	})
+	t.Run("transient-err", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		serializationFailure := &pq.Error{Code: pq.ErrorCode("40001")}
+
+		w := &Db{underlying: db}
+		attempts := 0
+		_, err := w.DoTx(context.Background(), 2, ExpBackoff{}, func(Reader, Writer) error { attempts += 1; return serializationFailure })
+		require.Error(err)
+		assert.Equal(1, attempts)
+
+		w = New(db, WithRetryTransientErrors(true))
+		attempts = 0
+		got, err := w.DoTx(context.Background(), 2, ExpBackoff{},
+			func(Reader, Writer) error {
+				attempts += 1
+				if attempts < 2 {
+					return serializationFailure
+				}
+				return nil
+			})
+		require.NoError(err)
+		assert.Equal(1, got.Retries)
+		assert.Equal(2, attempts)
+	})
+	t.Run("canceled-ctx", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := &Db{underlying: db}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		attempts := 0
+		_, err := w.DoTx(ctx, 2, ExpBackoff{}, func(Reader, Writer) error { attempts += 1; return nil })
+		require.Error(err)
+		assert.True(errors.Is(err, context.Canceled))
+		assert.Equal(0, attempts)
+
+		// The context ending stops the wait for the next attempt.
+		ctx, cancel = context.WithCancel(context.Background())
+		_, err = w.DoTx(ctx, 2, ConstBackoff{DurationMs: 60000}, func(Reader, Writer) error {
+			attempts += 1
+			cancel()
+			return oplog.ErrTicketAlreadyRedeemed
+		})
+		require.Error(err)
+		assert.True(errors.Is(err, context.Canceled))
+		assert.Equal(1, attempts)
+	})
 	t.Run("updating-good-bad-good", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 		rw := Db{underlying: db}
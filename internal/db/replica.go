@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// replicationLagQuery returns how many seconds the server is behind its
+// primary. A replica which has replayed everything it has received isn't
+// behind, however long ago the last transaction was, and a primary is never
+// behind.
+const replicationLagQuery = `
+select
+  case
+    when not pg_is_in_recovery() then 0
+    when pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() then 0
+    else coalesce(extract(epoch from now() - pg_last_xact_replay_timestamp()), 0)
+  end;
+`
+
+// ReplicationLag returns how far the database r reads from is behind its
+// primary, which is zero when r reads from the primary itself.
+func ReplicationLag(ctx context.Context, r Reader) (time.Duration, error) {
+	if r == nil {
+		return 0, fmt.Errorf("replication lag: missing reader: %w", ErrInvalidParameter)
+	}
+	rows, err := r.Query(ctx, replicationLagQuery, nil)
+	if err != nil {
+		return 0, fmt.Errorf("replication lag: %w", err)
+	}
+	defer rows.Close()
+	var secs float64
+	if rows.Next() {
+		if err := rows.Scan(&secs); err != nil {
+			return 0, fmt.Errorf("replication lag: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("replication lag: %w", err)
+	}
+	return time.Duration(secs * float64(time.Second)), nil
+}
@@ -0,0 +1,24 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicationLag(t *testing.T) {
+	conn, _ := TestSetup(t, "postgres")
+	rw := New(conn)
+
+	_, err := ReplicationLag(context.Background(), nil)
+	assert.True(t, errors.Is(err, ErrInvalidParameter))
+
+	// The test database is a primary, so it's never behind.
+	lag, err := ReplicationLag(context.Background(), rw)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), lag)
+}
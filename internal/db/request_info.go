@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// Oplog metadata keys for the RequestInfo of a write.
+const (
+	RequestUserIdKey  = "request-user-id"
+	RequestScopeIdKey = "request-scope-id"
+)
+
+// RequestInfo identifies the user, and the scope of the request, that a write
+// is made for.
+type RequestInfo struct {
+	UserId  string
+	ScopeId string
+}
+
+type requestInfoKey struct{}
+
+// NewRequestInfoContext returns a copy of ctx which carries the user and scope
+// of the request. A Writer adds them to the metadata of the oplog entries it
+// writes with the context and, if created with the WithRequireRequestInfo
+// option, refuses to write without them.
+func NewRequestInfoContext(ctx context.Context, userId, scopeId string) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, &RequestInfo{UserId: userId, ScopeId: scopeId})
+}
+
+// RequestInfoFromContext returns the RequestInfo of the context, if it has one.
+func RequestInfoFromContext(ctx context.Context) (*RequestInfo, bool) {
+	ri, ok := ctx.Value(requestInfoKey{}).(*RequestInfo)
+	return ri, ok && ri != nil
+}
+
+// checkRequestInfo returns an error if the Db requires a RequestInfo for
+// writes and the context doesn't have a complete one.
+func (rw *Db) checkRequestInfo(ctx context.Context) error {
+	if !rw.requireRequestInfo {
+		return nil
+	}
+	ri, ok := RequestInfoFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("missing request info: %w", ErrInvalidParameter)
+	}
+	if ri.UserId == "" {
+		return fmt.Errorf("missing request info user id: %w", ErrInvalidParameter)
+	}
+	if ri.ScopeId == "" {
+		return fmt.Errorf("missing request info scope id: %w", ErrInvalidParameter)
+	}
+	return nil
+}
+
+// requestInfoMetadata returns a copy of the metadata with the RequestInfo of
+// the context added. The metadata is returned as is if the context doesn't
+// have one.
+func requestInfoMetadata(ctx context.Context, metadata oplog.Metadata) oplog.Metadata {
+	ri, ok := RequestInfoFromContext(ctx)
+	if !ok {
+		return metadata
+	}
+	md := make(oplog.Metadata, len(metadata)+2)
+	for k, v := range metadata {
+		md[k] = v
+	}
+	if ri.UserId != "" {
+		md[RequestUserIdKey] = []string{ri.UserId}
+	}
+	if ri.ScopeId != "" {
+		md[RequestScopeIdKey] = []string{ri.ScopeId}
+	}
+	return md
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db/db_test"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestInfoFromContext(t *testing.T) {
+	assert := assert.New(t)
+	_, ok := RequestInfoFromContext(context.Background())
+	assert.False(ok)
+
+	ctx := NewRequestInfoContext(context.Background(), "u_1234567890", "o_1234567890")
+	ri, ok := RequestInfoFromContext(ctx)
+	assert.True(ok)
+	assert.Equal(&RequestInfo{UserId: "u_1234567890", ScopeId: "o_1234567890"}, ri)
+}
+
+func TestDb_RequestInfo(t *testing.T) {
+	conn, _ := TestSetup(t, "postgres")
+	wrapper := TestWrapper(t)
+	ctx := NewRequestInfoContext(context.Background(), "u_1234567890", "global")
+
+	t.Run("required", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := New(conn, WithRequireRequestInfo(true))
+		user, err := db_test.NewTestUser()
+		require.NoError(err)
+		err = w.Create(context.Background(), user)
+		require.Error(err)
+		assert.True(errors.Is(err, ErrInvalidParameter))
+
+		err = w.Create(NewRequestInfoContext(context.Background(), "", "global"), user)
+		require.Error(err)
+		assert.True(errors.Is(err, ErrInvalidParameter))
+
+		_, err = w.DoTx(context.Background(), StdRetryCnt, ExpBackoff{}, func(_ Reader, w Writer) error {
+			return w.Create(context.Background(), user)
+		})
+		require.Error(err)
+		assert.True(errors.Is(err, ErrInvalidParameter))
+
+		require.NoError(w.Create(ctx, user))
+	})
+	t.Run("oplog-metadata", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := New(conn)
+		user, err := db_test.NewTestUser()
+		require.NoError(err)
+		require.NoError(w.Create(ctx, user, WithOplog(wrapper, oplog.Metadata{"resource-public-id": []string{user.PublicId}})))
+
+		var metadata []*store.Metadata
+		require.NoError(w.SearchWhere(context.Background(), &metadata,
+			"entry_id in (select entry_id from oplog_metadata where key = 'resource-public-id' and value = ?)",
+			[]interface{}{user.PublicId}))
+		found := map[string]string{}
+		for _, m := range metadata {
+			found[m.Key] = m.Value
+		}
+		assert.Equal("u_1234567890", found[RequestUserIdKey])
+		assert.Equal("global", found[RequestScopeIdKey])
+	})
+}
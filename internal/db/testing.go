@@ -41,6 +41,17 @@ func TestSetup(t *testing.T, dialect string) (*gorm.DB, string) {
 	return db, url
 }
 
+// TestSeedIds makes NewPublicId and NewPrivateId generate the ids determined
+// by the seed, without the WithIdGenerator option, until the test ends. Since
+// the generator is shared by the package, tests calling it must not run in
+// parallel.
+func TestSeedIds(t *testing.T, seed int64) {
+	SetDefaultIdGenerator(NewSeededIdGenerator(seed))
+	t.Cleanup(func() {
+		SetDefaultIdGenerator(nil)
+	})
+}
+
 // TestWrapper initializes an AEAD wrapping.Wrapper for testing the oplog
 func TestWrapper(t *testing.T) wrapping.Wrapper {
 	rootKey := make([]byte, 32)
@@ -0,0 +1,60 @@
+// Package fuzz supports the go-fuzz targets of the parsers which take user
+// input. Each fuzzed package has:
+//
+//	fuzz.go          the target, an unexported func(data []byte) int
+//	fuzz_gofuzz.go   the go-fuzz entry point, built with the gofuzz tag
+//	fuzz_test.go     replays the corpus and crashers with TestCorpus
+//	testdata/fuzz/   the go-fuzz workdir: corpus/ holds the seeds
+//
+// scripts/fuzz.sh runs a target with go-fuzz using testdata/fuzz as the
+// workdir, so the inputs it finds are added to the corpus and the inputs which
+// crash it are written to testdata/fuzz/crashers. Once a crasher is committed
+// it is replayed by go test until the bug is fixed.
+package fuzz
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCorpus runs target with every input in the corpus and crashers
+// directories of the go-fuzz workdir dir. go-fuzz writes a .output and a
+// .quoted file next to each crasher, which are skipped.
+func TestCorpus(t *testing.T, dir string, target func(data []byte) int) {
+	t.Helper()
+	var ran int
+	for _, sub := range []string{"corpus", "crashers"} {
+		entries, err := ioutil.ReadDir(filepath.Join(dir, sub))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range entries {
+			if e.IsDir() || strings.Contains(e.Name(), ".") {
+				continue
+			}
+			path := filepath.Join(dir, sub, e.Name())
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Run(sub+"/"+e.Name(), func(t *testing.T) {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Fatalf("input %q panicked: %v", data, r)
+					}
+				}()
+				target(data)
+			})
+			ran++
+		}
+	}
+	if ran == 0 {
+		t.Fatalf("no fuzz inputs found in %s", dir)
+	}
+}
@@ -78,6 +78,32 @@ type RoleServiceClient interface {
 	// grants will be removed. If missing, malformed, or references a non-existing
 	// resource, an error is returned.
 	RemoveRoleGrants(ctx context.Context, in *RemoveRoleGrantsRequest, opts ...grpc.CallOption) (*RemoveRoleGrantsResponse, error)
+	// ActivateEmergencyRole activates an emergency Role for the requesting
+	// User, who must be a principal of the Role. The request must include a
+	// justification, and can include how long the activation lasts, which
+	// defaults to the longest allowed for the Role. If the Role isn't an
+	// emergency Role or is already activated, an error is returned.
+	ActivateEmergencyRole(ctx context.Context, in *ActivateEmergencyRoleRequest, opts ...grpc.CallOption) (*ActivateEmergencyRoleResponse, error)
+	// RevertEmergencyRole ends the activation of an emergency Role before it
+	// expires. If the Role isn't activated, an error is returned.
+	RevertEmergencyRole(ctx context.Context, in *RevertEmergencyRoleRequest, opts ...grpc.CallOption) (*RevertEmergencyRoleResponse, error)
+	// DiffRole returns how a Role differs from another Role, given by
+	// from_role_id, or how it changed between from_time and to_time, which
+	// defaults to now. Changes over time are worked out from the Role's history
+	// in the oplog. The diff action must be granted on both Roles when two are
+	// compared.
+	DiffRole(ctx context.Context, in *DiffRoleRequest, opts ...grpc.CallOption) (*DiffRoleResponse, error)
+	// ArchiveRole archives a Role before it expires, so it's no longer
+	// considered when resolving grants. A Role which doesn't expire, or expires
+	// later, expires when it's archived. If the Role is already archived, an
+	// error is returned.
+	ArchiveRole(ctx context.Context, in *ArchiveRoleRequest, opts ...grpc.CallOption) (*ArchiveRoleResponse, error)
+	// RestoreRole restores an archived Role, so it's considered when resolving
+	// grants again. The restored Role expires at expiration_time, which must
+	// be in the future, or never if it isn't set. If the Role isn't archived,
+	// or was archived longer ago than the retention window, an error is
+	// returned.
+	RestoreRole(ctx context.Context, in *RestoreRoleRequest, opts ...grpc.CallOption) (*RestoreRoleResponse, error)
 }
 
 type roleServiceClient struct {
@@ -187,6 +213,51 @@ func (c *roleServiceClient) RemoveRoleGrants(ctx context.Context, in *RemoveRole
 	return out, nil
 }
 
+func (c *roleServiceClient) ActivateEmergencyRole(ctx context.Context, in *ActivateEmergencyRoleRequest, opts ...grpc.CallOption) (*ActivateEmergencyRoleResponse, error) {
+	out := new(ActivateEmergencyRoleResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleService/ActivateEmergencyRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) RevertEmergencyRole(ctx context.Context, in *RevertEmergencyRoleRequest, opts ...grpc.CallOption) (*RevertEmergencyRoleResponse, error) {
+	out := new(RevertEmergencyRoleResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleService/RevertEmergencyRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) DiffRole(ctx context.Context, in *DiffRoleRequest, opts ...grpc.CallOption) (*DiffRoleResponse, error) {
+	out := new(DiffRoleResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleService/DiffRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) ArchiveRole(ctx context.Context, in *ArchiveRoleRequest, opts ...grpc.CallOption) (*ArchiveRoleResponse, error) {
+	out := new(ArchiveRoleResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleService/ArchiveRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) RestoreRole(ctx context.Context, in *RestoreRoleRequest, opts ...grpc.CallOption) (*RestoreRoleResponse, error) {
+	out := new(RestoreRoleResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleService/RestoreRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RoleServiceServer is the server API for RoleService service.
 type RoleServiceServer interface {
 	// GetRole returns a stored Role if present. The provided request must include
@@ -250,6 +321,32 @@ type RoleServiceServer interface {
 	// grants will be removed. If missing, malformed, or references a non-existing
 	// resource, an error is returned.
 	RemoveRoleGrants(context.Context, *RemoveRoleGrantsRequest) (*RemoveRoleGrantsResponse, error)
+	// ActivateEmergencyRole activates an emergency Role for the requesting
+	// User, who must be a principal of the Role. The request must include a
+	// justification, and can include how long the activation lasts, which
+	// defaults to the longest allowed for the Role. If the Role isn't an
+	// emergency Role or is already activated, an error is returned.
+	ActivateEmergencyRole(context.Context, *ActivateEmergencyRoleRequest) (*ActivateEmergencyRoleResponse, error)
+	// RevertEmergencyRole ends the activation of an emergency Role before it
+	// expires. If the Role isn't activated, an error is returned.
+	RevertEmergencyRole(context.Context, *RevertEmergencyRoleRequest) (*RevertEmergencyRoleResponse, error)
+	// DiffRole returns how a Role differs from another Role, given by
+	// from_role_id, or how it changed between from_time and to_time, which
+	// defaults to now. Changes over time are worked out from the Role's history
+	// in the oplog. The diff action must be granted on both Roles when two are
+	// compared.
+	DiffRole(context.Context, *DiffRoleRequest) (*DiffRoleResponse, error)
+	// ArchiveRole archives a Role before it expires, so it's no longer
+	// considered when resolving grants. A Role which doesn't expire, or expires
+	// later, expires when it's archived. If the Role is already archived, an
+	// error is returned.
+	ArchiveRole(context.Context, *ArchiveRoleRequest) (*ArchiveRoleResponse, error)
+	// RestoreRole restores an archived Role, so it's considered when resolving
+	// grants again. The restored Role expires at expiration_time, which must
+	// be in the future, or never if it isn't set. If the Role isn't archived,
+	// or was archived longer ago than the retention window, an error is
+	// returned.
+	RestoreRole(context.Context, *RestoreRoleRequest) (*RestoreRoleResponse, error)
 }
 
 // UnimplementedRoleServiceServer can be embedded to have forward compatible implementations.
@@ -289,6 +386,21 @@ func (*UnimplementedRoleServiceServer) SetRoleGrants(context.Context, *SetRoleGr
 func (*UnimplementedRoleServiceServer) RemoveRoleGrants(context.Context, *RemoveRoleGrantsRequest) (*RemoveRoleGrantsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoleGrants not implemented")
 }
+func (*UnimplementedRoleServiceServer) ActivateEmergencyRole(context.Context, *ActivateEmergencyRoleRequest) (*ActivateEmergencyRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivateEmergencyRole not implemented")
+}
+func (*UnimplementedRoleServiceServer) RevertEmergencyRole(context.Context, *RevertEmergencyRoleRequest) (*RevertEmergencyRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertEmergencyRole not implemented")
+}
+func (*UnimplementedRoleServiceServer) DiffRole(context.Context, *DiffRoleRequest) (*DiffRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffRole not implemented")
+}
+func (*UnimplementedRoleServiceServer) ArchiveRole(context.Context, *ArchiveRoleRequest) (*ArchiveRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveRole not implemented")
+}
+func (*UnimplementedRoleServiceServer) RestoreRole(context.Context, *RestoreRoleRequest) (*RestoreRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRole not implemented")
+}
 
 func RegisterRoleServiceServer(s *grpc.Server, srv RoleServiceServer) {
 	s.RegisterService(&_RoleService_serviceDesc, srv)
@@ -492,6 +604,96 @@ func _RoleService_RemoveRoleGrants_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RoleService_ActivateEmergencyRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateEmergencyRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).ActivateEmergencyRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleService/ActivateEmergencyRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).ActivateEmergencyRole(ctx, req.(*ActivateEmergencyRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_RevertEmergencyRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertEmergencyRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).RevertEmergencyRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleService/RevertEmergencyRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).RevertEmergencyRole(ctx, req.(*RevertEmergencyRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_DiffRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).DiffRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleService/DiffRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).DiffRole(ctx, req.(*DiffRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_ArchiveRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).ArchiveRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleService/ArchiveRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).ArchiveRole(ctx, req.(*ArchiveRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_RestoreRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).RestoreRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleService/RestoreRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).RestoreRole(ctx, req.(*RestoreRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _RoleService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "controller.api.services.v1.RoleService",
 	HandlerType: (*RoleServiceServer)(nil),
@@ -540,6 +742,26 @@ var _RoleService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveRoleGrants",
 			Handler:    _RoleService_RemoveRoleGrants_Handler,
 		},
+		{
+			MethodName: "ActivateEmergencyRole",
+			Handler:    _RoleService_ActivateEmergencyRole_Handler,
+		},
+		{
+			MethodName: "RevertEmergencyRole",
+			Handler:    _RoleService_RevertEmergencyRole_Handler,
+		},
+		{
+			MethodName: "DiffRole",
+			Handler:    _RoleService_DiffRole_Handler,
+		},
+		{
+			MethodName: "ArchiveRole",
+			Handler:    _RoleService_ArchiveRole_Handler,
+		},
+		{
+			MethodName: "RestoreRole",
+			Handler:    _RoleService_RestoreRole_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/role_service.proto",
@@ -43,6 +43,17 @@ type ScopeServiceClient interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(ctx context.Context, in *DeleteScopeRequest, opts ...grpc.CallOption) (*DeleteScopeResponse, error)
+	// ListBannedGrantPatterns lists the grant patterns banned in a Scope. The
+	// patterns banned in the Scopes above it, which are also in effect in it,
+	// aren't included.
+	ListBannedGrantPatterns(ctx context.Context, in *ListBannedGrantPatternsRequest, opts ...grpc.CallOption) (*ListBannedGrantPatternsResponse, error)
+	// AddBannedGrantPattern bans a pattern of grants in a Scope and the Scopes
+	// beneath it. Role grants written after it's added can't match it. If the
+	// pattern can't be parsed or its name is taken, an error is returned.
+	AddBannedGrantPattern(ctx context.Context, in *AddBannedGrantPatternRequest, opts ...grpc.CallOption) (*AddBannedGrantPatternResponse, error)
+	// RemoveBannedGrantPattern removes a banned grant pattern, by name, from a
+	// Scope. If the Scope has no pattern with the name, an error is returned.
+	RemoveBannedGrantPattern(ctx context.Context, in *RemoveBannedGrantPatternRequest, opts ...grpc.CallOption) (*RemoveBannedGrantPatternResponse, error)
 }
 
 type scopeServiceClient struct {
@@ -98,6 +109,33 @@ func (c *scopeServiceClient) DeleteScope(ctx context.Context, in *DeleteScopeReq
 	return out, nil
 }
 
+func (c *scopeServiceClient) ListBannedGrantPatterns(ctx context.Context, in *ListBannedGrantPatternsRequest, opts ...grpc.CallOption) (*ListBannedGrantPatternsResponse, error) {
+	out := new(ListBannedGrantPatternsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/ListBannedGrantPatterns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) AddBannedGrantPattern(ctx context.Context, in *AddBannedGrantPatternRequest, opts ...grpc.CallOption) (*AddBannedGrantPatternResponse, error) {
+	out := new(AddBannedGrantPatternResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/AddBannedGrantPattern", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) RemoveBannedGrantPattern(ctx context.Context, in *RemoveBannedGrantPatternRequest, opts ...grpc.CallOption) (*RemoveBannedGrantPatternResponse, error) {
+	out := new(RemoveBannedGrantPatternResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/RemoveBannedGrantPattern", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ScopeServiceServer is the server API for ScopeService service.
 type ScopeServiceServer interface {
 	// GetScope returns a stored Scope if present.  The provided request
@@ -126,6 +164,17 @@ type ScopeServiceServer interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error)
+	// ListBannedGrantPatterns lists the grant patterns banned in a Scope. The
+	// patterns banned in the Scopes above it, which are also in effect in it,
+	// aren't included.
+	ListBannedGrantPatterns(context.Context, *ListBannedGrantPatternsRequest) (*ListBannedGrantPatternsResponse, error)
+	// AddBannedGrantPattern bans a pattern of grants in a Scope and the Scopes
+	// beneath it. Role grants written after it's added can't match it. If the
+	// pattern can't be parsed or its name is taken, an error is returned.
+	AddBannedGrantPattern(context.Context, *AddBannedGrantPatternRequest) (*AddBannedGrantPatternResponse, error)
+	// RemoveBannedGrantPattern removes a banned grant pattern, by name, from a
+	// Scope. If the Scope has no pattern with the name, an error is returned.
+	RemoveBannedGrantPattern(context.Context, *RemoveBannedGrantPatternRequest) (*RemoveBannedGrantPatternResponse, error)
 }
 
 // UnimplementedScopeServiceServer can be embedded to have forward compatible implementations.
@@ -147,6 +196,15 @@ func (*UnimplementedScopeServiceServer) UpdateScope(context.Context, *UpdateScop
 func (*UnimplementedScopeServiceServer) DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteScope not implemented")
 }
+func (*UnimplementedScopeServiceServer) ListBannedGrantPatterns(context.Context, *ListBannedGrantPatternsRequest) (*ListBannedGrantPatternsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBannedGrantPatterns not implemented")
+}
+func (*UnimplementedScopeServiceServer) AddBannedGrantPattern(context.Context, *AddBannedGrantPatternRequest) (*AddBannedGrantPatternResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBannedGrantPattern not implemented")
+}
+func (*UnimplementedScopeServiceServer) RemoveBannedGrantPattern(context.Context, *RemoveBannedGrantPatternRequest) (*RemoveBannedGrantPatternResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveBannedGrantPattern not implemented")
+}
 
 func RegisterScopeServiceServer(s *grpc.Server, srv ScopeServiceServer) {
 	s.RegisterService(&_ScopeService_serviceDesc, srv)
@@ -242,6 +300,60 @@ func _ScopeService_DeleteScope_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ScopeService_ListBannedGrantPatterns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBannedGrantPatternsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).ListBannedGrantPatterns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/ListBannedGrantPatterns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).ListBannedGrantPatterns(ctx, req.(*ListBannedGrantPatternsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_AddBannedGrantPattern_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBannedGrantPatternRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).AddBannedGrantPattern(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/AddBannedGrantPattern",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).AddBannedGrantPattern(ctx, req.(*AddBannedGrantPatternRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_RemoveBannedGrantPattern_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveBannedGrantPatternRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).RemoveBannedGrantPattern(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/RemoveBannedGrantPattern",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).RemoveBannedGrantPattern(ctx, req.(*RemoveBannedGrantPatternRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ScopeService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "controller.api.services.v1.ScopeService",
 	HandlerType: (*ScopeServiceServer)(nil),
@@ -266,6 +378,18 @@ var _ScopeService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteScope",
 			Handler:    _ScopeService_DeleteScope_Handler,
 		},
+		{
+			MethodName: "ListBannedGrantPatterns",
+			Handler:    _ScopeService_ListBannedGrantPatterns_Handler,
+		},
+		{
+			MethodName: "AddBannedGrantPattern",
+			Handler:    _ScopeService_AddBannedGrantPattern_Handler,
+		},
+		{
+			MethodName: "RemoveBannedGrantPattern",
+			Handler:    _ScopeService_RemoveBannedGrantPattern_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/scope_service.proto",
@@ -0,0 +1,201 @@
+package static
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// MaxHostImportRecords is the most hosts a single import can hold.
+const MaxHostImportRecords = 5000
+
+// A HostImportRecord is a host in an import document. Name identifies the
+// host within its catalog: if the catalog has a host with the name it's
+// updated, otherwise a host is created. HostSets holds the names or ids of
+// sets in the catalog to add the host to. Hosts aren't removed from sets
+// which aren't listed.
+type HostImportRecord struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Address     string   `json:"address"`
+	HostSets    []string `json:"host_sets,omitempty"`
+}
+
+// A HostImportRowError is a problem with one record of an import document.
+// Row is the position of the record in the document, starting at 1 and not
+// counting the header of a CSV document.
+type HostImportRowError struct {
+	Row int
+	Err error
+}
+
+func (e *HostImportRowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// Unwrap returns the problem with the record.
+func (e *HostImportRowError) Unwrap() error {
+	return e.Err
+}
+
+// HostImportErrors are the problems with the records of an import document,
+// which are all reported together so a document can be fixed in one pass.
+type HostImportErrors []*HostImportRowError
+
+func (e HostImportErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// The columns of a CSV import document.
+const (
+	importColumnName        = "name"
+	importColumnAddress     = "address"
+	importColumnDescription = "description"
+	importColumnHostSets    = "host_sets"
+)
+
+// ParseHostImportCSV reads the records of a CSV import document. Its first
+// row is a header naming the columns, which are name, address, description
+// and host_sets, in any order; name and address are required. A host's sets
+// are separated by semicolons.
+func ParseHostImportCSV(r io.Reader) ([]*HostImportRecord, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("parse host import csv: missing header: %w", db.ErrInvalidParameter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse host import csv: %v: %w", err, db.ErrInvalidParameter)
+	}
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		switch h {
+		case importColumnName, importColumnAddress, importColumnDescription, importColumnHostSets:
+		default:
+			return nil, fmt.Errorf("parse host import csv: unknown column %q: %w", h, db.ErrInvalidParameter)
+		}
+		if _, ok := columns[h]; ok {
+			return nil, fmt.Errorf("parse host import csv: duplicate column %q: %w", h, db.ErrInvalidParameter)
+		}
+		columns[h] = i
+	}
+	for _, h := range []string{importColumnName, importColumnAddress} {
+		if _, ok := columns[h]; !ok {
+			return nil, fmt.Errorf("parse host import csv: missing column %q: %w", h, db.ErrInvalidParameter)
+		}
+	}
+	field := func(row []string, column string) string {
+		i, ok := columns[column]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var records []*HostImportRecord
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse host import csv: %v: %w", err, db.ErrInvalidParameter)
+		}
+		if len(records) == MaxHostImportRecords {
+			return nil, fmt.Errorf("parse host import csv: more than %d hosts: %w", MaxHostImportRecords, db.ErrInvalidParameter)
+		}
+		rec := &HostImportRecord{
+			Name:        field(row, importColumnName),
+			Address:     field(row, importColumnAddress),
+			Description: field(row, importColumnDescription),
+		}
+		for _, s := range strings.Split(field(row, importColumnHostSets), ";") {
+			if s = strings.TrimSpace(s); s != "" {
+				rec.HostSets = append(rec.HostSets, s)
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// ParseHostImportJSON reads the records of a JSON import document, which is
+// an array of objects with the fields of a HostImportRecord, or an object
+// with the array in its "hosts" field.
+func ParseHostImportJSON(r io.Reader) ([]*HostImportRecord, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("parse host import json: %v: %w", err, db.ErrInvalidParameter)
+	}
+	var records []*HostImportRecord
+	if b := strings.TrimSpace(string(raw)); strings.HasPrefix(b, "{") {
+		var doc struct {
+			Hosts []*HostImportRecord `json:"hosts"`
+		}
+		if err := decodeStrict(raw, &doc); err != nil {
+			return nil, fmt.Errorf("parse host import json: %v: %w", err, db.ErrInvalidParameter)
+		}
+		records = doc.Hosts
+	} else if err := decodeStrict(raw, &records); err != nil {
+		return nil, fmt.Errorf("parse host import json: %v: %w", err, db.ErrInvalidParameter)
+	}
+	if len(records) > MaxHostImportRecords {
+		return nil, fmt.Errorf("parse host import json: more than %d hosts: %w", MaxHostImportRecords, db.ErrInvalidParameter)
+	}
+	for i, rec := range records {
+		if rec == nil {
+			return nil, fmt.Errorf("parse host import json: host %d is null: %w", i+1, db.ErrInvalidParameter)
+		}
+	}
+	return records, nil
+}
+
+func decodeStrict(raw json.RawMessage, v interface{}) error {
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// normalizeImport trims the fields of the records, and checks each has a
+// name which no other record has and a valid address. It returns the
+// problems with the records, if there are any.
+func normalizeImport(records []*HostImportRecord) HostImportErrors {
+	var errs HostImportErrors
+	names := make(map[string]int, len(records))
+	for i, rec := range records {
+		row := i + 1
+		rec.Name = strings.TrimSpace(rec.Name)
+		rec.Address = strings.TrimSpace(rec.Address)
+		rec.Description = strings.TrimSpace(rec.Description)
+		sets := rec.HostSets[:0]
+		for _, s := range rec.HostSets {
+			if s = strings.TrimSpace(s); s != "" {
+				sets = append(sets, s)
+			}
+		}
+		rec.HostSets = sets
+
+		switch first, dup := names[rec.Name]; {
+		case rec.Name == "":
+			errs = append(errs, &HostImportRowError{Row: row, Err: fmt.Errorf("missing name: %w", db.ErrInvalidParameter)})
+		case dup:
+			errs = append(errs, &HostImportRowError{Row: row, Err: fmt.Errorf("name %q is also used by row %d: %w", rec.Name, first, db.ErrNotUnique)})
+		default:
+			names[rec.Name] = row
+		}
+		if len(rec.Address) < MinHostAddressLength || len(rec.Address) > MaxHostAddressLength {
+			errs = append(errs, &HostImportRowError{Row: row, Err: fmt.Errorf("%q: %w", rec.Address, ErrInvalidAddress)})
+		}
+	}
+	return errs
+}
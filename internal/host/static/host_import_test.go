@@ -0,0 +1,117 @@
+package static
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHostImportCSV(t *testing.T) {
+	var tests = []struct {
+		name    string
+		doc     string
+		want    []*HostImportRecord
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			doc: "name,address,description,host_sets\n" +
+				"jump-1, 10.0.0.1, \"Jump host, east\",east;all\n" +
+				"jump-2,10.0.0.2,,\n",
+			want: []*HostImportRecord{
+				{Name: "jump-1", Address: "10.0.0.1", Description: "Jump host, east", HostSets: []string{"east", "all"}},
+				{Name: "jump-2", Address: "10.0.0.2"},
+			},
+		},
+		{
+			name: "reordered-columns",
+			doc:  "Address,Name\n10.0.0.1,jump-1\n",
+			want: []*HostImportRecord{{Name: "jump-1", Address: "10.0.0.1"}},
+		},
+		{
+			name: "header-only",
+			doc:  "name,address\n",
+		},
+		{name: "empty", doc: "", wantErr: true},
+		{name: "unknown-column", doc: "name,address,port\njump-1,10.0.0.1,22\n", wantErr: true},
+		{name: "duplicate-column", doc: "name,address,name\n", wantErr: true},
+		{name: "missing-address-column", doc: "name\njump-1\n", wantErr: true},
+		{name: "short-row", doc: "name,address\njump-1\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHostImportCSV(strings.NewReader(tt.doc))
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseHostImportJSON(t *testing.T) {
+	want := []*HostImportRecord{
+		{Name: "jump-1", Address: "10.0.0.1", HostSets: []string{"east"}},
+		{Name: "jump-2", Address: "10.0.0.2", Description: "Jump host"},
+	}
+	var tests = []struct {
+		name    string
+		doc     string
+		want    []*HostImportRecord
+		wantErr bool
+	}{
+		{
+			name: "array",
+			doc:  `[{"name":"jump-1","address":"10.0.0.1","host_sets":["east"]},{"name":"jump-2","address":"10.0.0.2","description":"Jump host"}]`,
+			want: want,
+		},
+		{
+			name: "object",
+			doc:  `{"hosts":[{"name":"jump-1","address":"10.0.0.1","host_sets":["east"]},{"name":"jump-2","address":"10.0.0.2","description":"Jump host"}]}`,
+			want: want,
+		},
+		{name: "unknown-field", doc: `[{"name":"jump-1","address":"10.0.0.1","port":22}]`, wantErr: true},
+		{name: "null-host", doc: `[null]`, wantErr: true},
+		{name: "not-json", doc: `name,address`, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHostImportJSON(strings.NewReader(tt.doc))
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizeImport(t *testing.T) {
+	assert := assert.New(t)
+	records := []*HostImportRecord{
+		{Name: " jump-1 ", Address: " 10.0.0.1 ", HostSets: []string{" east ", ""}},
+		{Name: "", Address: "10.0.0.2"},
+		{Name: "jump-1", Address: "10.0.0.3"},
+		{Name: "jump-4", Address: "a"},
+	}
+	errs := normalizeImport(records)
+	assert.Equal(&HostImportRecord{Name: "jump-1", Address: "10.0.0.1", HostSets: []string{"east"}}, records[0])
+	if assert.Len(errs, 3) {
+		assert.Equal(2, errs[0].Row)
+		assert.True(errors.Is(errs[0], db.ErrInvalidParameter))
+		assert.Equal(3, errs[1].Row)
+		assert.True(errors.Is(errs[1], db.ErrNotUnique))
+		assert.Equal(4, errs[2].Row)
+		assert.True(errors.Is(errs[2], ErrInvalidAddress))
+	}
+	assert.Contains(errs.Error(), "row 3: name \"jump-1\" is also used by row 1")
+}
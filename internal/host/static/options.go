@@ -19,6 +19,7 @@ type options struct {
 	withLimit       int
 	withAddress     string
 	withPublicId    string
+	withDryRun      bool
 }
 
 func getDefaultOptions() options {
@@ -65,3 +66,12 @@ func WithPublicId(id string) Option {
 		o.withPublicId = id
 	}
 }
+
+// WithDryRun provides an option to compute the effects of an import without
+// applying them. The import runs all of its checks in a transaction which is
+// then rolled back.
+func WithDryRun(enable bool) Option {
+	return func(o *options) {
+		o.withDryRun = enable
+	}
+}
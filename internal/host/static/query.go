@@ -42,5 +42,19 @@ final (action, host_id) as (
 )
 select * from final
 order by action, host_id;
+`
+
+	hostAddressesQuery = `
+select h.public_id, h.catalog_id, c.scope_id, h.address, h.version
+  from static_host h
+  join static_host_catalog c on c.public_id = h.catalog_id
+order by h.public_id
+for update of h;
+`
+
+	catalogSetMembersQuery = `
+select set_id, host_id
+  from static_host_set_member
+ where catalog_id = $1;
 `
 )
@@ -0,0 +1,261 @@
+package static
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// An ImportAction is what an import did to a host.
+type ImportAction string
+
+const (
+	ImportCreated   ImportAction = "created"
+	ImportUpdated   ImportAction = "updated"
+	ImportUnchanged ImportAction = "unchanged"
+)
+
+// An ImportedHost is a host of an import, what the import did to it, and the
+// ids of the sets the import added it to.
+type ImportedHost struct {
+	*Host
+	Action      ImportAction
+	AddedSetIds []string
+}
+
+// errDryRun is returned from the transaction of an import made with the
+// WithDryRun option, once the import has succeeded, so it's rolled back.
+var errDryRun = errors.New("dry run")
+
+// ImportHosts creates and updates the hosts of catalogId from records, like
+// ones parsed from an import document, and adds them to the sets each record
+// lists. A record updates the address and description of the catalog's host
+// with its name, if there is one, and otherwise creates a host. Every record
+// is imported in a single transaction with an oplog entry for each change,
+// so either the whole document is imported or none of it is. The returned
+// hosts are in the order of records.
+//
+// If any records are invalid, like ones without a name or address or which
+// list a set the catalog doesn't have, the returned error wraps the
+// HostImportErrors of all of them.
+//
+// Supports the WithDryRun option, which checks and makes the changes in a
+// transaction which is rolled back, and returns the hosts as they would have
+// been imported; the ids of hosts it would have created aren't kept.
+func (r *Repository) ImportHosts(ctx context.Context, scopeId, catalogId string, records []*HostImportRecord, opt ...Option) ([]*ImportedHost, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("import: static hosts: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if catalogId == "" {
+		return nil, fmt.Errorf("import: static hosts: missing catalog id: %w", db.ErrInvalidParameter)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("import: static hosts: no hosts: %w", db.ErrInvalidParameter)
+	}
+	if len(records) > MaxHostImportRecords {
+		return nil, fmt.Errorf("import: static hosts: more than %d hosts: %w", MaxHostImportRecords, db.ErrInvalidParameter)
+	}
+	for i, rec := range records {
+		if rec == nil {
+			return nil, fmt.Errorf("import: static hosts: record %d is nil: %w", i+1, db.ErrInvalidParameter)
+		}
+	}
+	if errs := normalizeImport(records); len(errs) > 0 {
+		return nil, fmt.Errorf("import: static hosts: %w", errs)
+	}
+	opts := getOpts(opt...)
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("import: static hosts: unable to get oplog wrapper: %w", err)
+	}
+
+	var imported []*ImportedHost
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			imported = make([]*ImportedHost, 0, len(records))
+			var hosts []*Host
+			if err := reader.SearchWhere(ctx, &hosts, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(unlimited)); err != nil {
+				return fmt.Errorf("unable to list hosts: %w", err)
+			}
+			hostsByName := make(map[string]*Host, len(hosts))
+			for _, h := range hosts {
+				if h.Name != "" {
+					hostsByName[h.Name] = h
+				}
+			}
+			var sets []*HostSet
+			if err := reader.SearchWhere(ctx, &sets, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(unlimited)); err != nil {
+				return fmt.Errorf("unable to list host sets: %w", err)
+			}
+			// Sets are referred to by id or name.
+			setsByRef := make(map[string]*HostSet, 2*len(sets))
+			for _, s := range sets {
+				setsByRef[s.PublicId] = s
+				if s.Name != "" {
+					setsByRef[s.Name] = s
+				}
+			}
+			members, err := catalogSetMembers(ctx, reader, catalogId)
+			if err != nil {
+				return err
+			}
+
+			// Check every set reference before writing anything, so all the
+			// unknown sets are reported together.
+			var errs HostImportErrors
+			for i, rec := range records {
+				for _, ref := range rec.HostSets {
+					if _, ok := setsByRef[ref]; !ok {
+						errs = append(errs, &HostImportRowError{Row: i + 1, Err: fmt.Errorf("unknown host set %q: %w", ref, db.ErrRecordNotFound)})
+					}
+				}
+			}
+			if len(errs) > 0 {
+				return errs
+			}
+
+			additions := make(map[string][]interface{})
+			var changedSets []*HostSet
+			for i, rec := range records {
+				ih, err := importHost(ctx, w, oplogWrapper, catalogId, hostsByName[rec.Name], rec)
+				if err != nil {
+					return HostImportErrors{{Row: i + 1, Err: err}}
+				}
+				for _, ref := range rec.HostSets {
+					s := setsByRef[ref]
+					if members[s.PublicId][ih.PublicId] {
+						continue
+					}
+					if members[s.PublicId] == nil {
+						members[s.PublicId] = make(map[string]bool)
+					}
+					members[s.PublicId][ih.PublicId] = true
+					m, err := NewHostSetMember(s.PublicId, ih.PublicId)
+					if err != nil {
+						return err
+					}
+					if len(additions[s.PublicId]) == 0 {
+						changedSets = append(changedSets, s)
+					}
+					additions[s.PublicId] = append(additions[s.PublicId], m)
+					ih.AddedSetIds = append(ih.AddedSetIds, s.PublicId)
+				}
+				imported = append(imported, ih)
+			}
+
+			for _, s := range changedSets {
+				set := newHostSetForMembers(s.PublicId, s.Version)
+				msgs, err := createMembers(ctx, w, additions[s.PublicId])
+				if err != nil {
+					return err
+				}
+				if err := updateVersion(ctx, w, oplogWrapper, set.oplog(oplog.OpType_OP_TYPE_CREATE), msgs, set, s.Version); err != nil {
+					return err
+				}
+			}
+
+			if opts.withDryRun {
+				return errDryRun
+			}
+			return nil
+		},
+	)
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, fmt.Errorf("import: static hosts: %w", err)
+	}
+	return imported, nil
+}
+
+// importHost creates the host of rec, or updates existing, the catalog's
+// host with the same name, if it isn't nil.
+func importHost(ctx context.Context, w db.Writer, oplogWrapper wrapping.Wrapper, catalogId string, existing *Host, rec *HostImportRecord) (*ImportedHost, error) {
+	if existing == nil {
+		h, err := NewHost(catalogId, WithName(rec.Name), WithAddress(rec.Address), WithDescription(rec.Description))
+		if err != nil {
+			return nil, err
+		}
+		if h.PublicId, err = newHostId(); err != nil {
+			return nil, err
+		}
+		if err := w.Create(ctx, h, db.WithOplog(oplogWrapper, h.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+			return nil, importWriteError(rec, err)
+		}
+		return &ImportedHost{Host: h, Action: ImportCreated}, nil
+	}
+
+	var fieldMask []string
+	if existing.Address != rec.Address {
+		fieldMask = append(fieldMask, "Address")
+	}
+	if existing.Description != rec.Description {
+		fieldMask = append(fieldMask, "Description")
+	}
+	if len(fieldMask) == 0 {
+		return &ImportedHost{Host: existing, Action: ImportUnchanged}, nil
+	}
+	h := existing.clone()
+	h.Address, h.Description = rec.Address, rec.Description
+	dbMask, nullFields := dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"Description": h.Description,
+			"Address":     h.Address,
+		},
+		fieldMask,
+		nil,
+	)
+	version := existing.Version
+	rowsUpdated, err := w.Update(ctx, h, dbMask, nullFields,
+		db.WithOplog(oplogWrapper, h.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+		db.WithVersion(&version))
+	if err != nil {
+		return nil, importWriteError(rec, err)
+	}
+	if rowsUpdated != 1 {
+		return nil, fmt.Errorf("%s: expected to update 1 host, updated %d", h.PublicId, rowsUpdated)
+	}
+	return &ImportedHost{Host: h, Action: ImportUpdated}, nil
+}
+
+// importWriteError returns the error for a host of rec which couldn't be
+// written.
+func importWriteError(rec *HostImportRecord, err error) error {
+	switch {
+	case db.IsUniqueError(err):
+		return fmt.Errorf("name %s already exists: %w", rec.Name, db.ErrNotUnique)
+	case db.IsCheckConstraintError(err) || db.IsNotNullError(err):
+		return fmt.Errorf("%q: %w", rec.Address, ErrInvalidAddress)
+	}
+	return err
+}
+
+// catalogSetMembers returns the ids of the hosts in each set of the catalog,
+// keyed by set id.
+func catalogSetMembers(ctx context.Context, reader db.Reader, catalogId string) (map[string]map[string]bool, error) {
+	rows, err := reader.Query(ctx, catalogSetMembersQuery, []interface{}{catalogId})
+	if err != nil {
+		return nil, fmt.Errorf("unable to query host set members: %w", err)
+	}
+	defer rows.Close()
+	members := make(map[string]map[string]bool)
+	for rows.Next() {
+		var setId, hostId string
+		if err := rows.Scan(&setId, &hostId); err != nil {
+			return nil, fmt.Errorf("unable to scan host set member: %w", err)
+		}
+		if members[setId] == nil {
+			members[setId] = make(map[string]bool)
+		}
+		members[setId][hostId] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read host set members: %w", err)
+	}
+	return members, nil
+}
@@ -0,0 +1,142 @@
+package static
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ImportHosts(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	catalog := TestCatalogs(t, conn, prj.PublicId, 1)[0]
+	sets := TestSets(t, conn, catalog.PublicId, 2)
+	east, all := sets[0], sets[1]
+	east.Name = "east"
+	_, _, _, err = repo.UpdateSet(ctx, prj.PublicId, east, east.Version, []string{"Name"})
+	require.NoError(t, err)
+
+	existing, err := NewHost(catalog.PublicId, WithName("jump-1"), WithAddress("10.0.0.1"))
+	require.NoError(t, err)
+	existing, err = repo.CreateHost(ctx, prj.PublicId, existing)
+	require.NoError(t, err)
+	unchanged, err := NewHost(catalog.PublicId, WithName("jump-3"), WithAddress("10.0.0.3"))
+	require.NoError(t, err)
+	unchanged, err = repo.CreateHost(ctx, prj.PublicId, unchanged)
+	require.NoError(t, err)
+	TestSetMembers(t, conn, all.PublicId, []*Host{unchanged})
+
+	records := func() []*HostImportRecord {
+		return []*HostImportRecord{
+			{Name: "jump-1", Address: "10.1.0.1", Description: "renumbered", HostSets: []string{"east"}},
+			{Name: "jump-2", Address: "10.1.0.2", HostSets: []string{"east", all.PublicId}},
+			{Name: "jump-3", Address: "10.0.0.3", HostSets: []string{all.PublicId}},
+		}
+	}
+	hostCount := func() int {
+		hosts, err := repo.ListHosts(ctx, catalog.PublicId, WithLimit(-1))
+		require.NoError(t, err)
+		return len(hosts)
+	}
+
+	t.Run("invalid-records", func(t *testing.T) {
+		assert := assert.New(t)
+		recs := records()
+		recs[0].Address = ""
+		recs[1].HostSets = []string{"west"}
+		_, err := repo.ImportHosts(ctx, prj.PublicId, catalog.PublicId, recs)
+		var errs HostImportErrors
+		if assert.True(errors.As(err, &errs)) {
+			assert.Len(errs, 1)
+			assert.True(errors.Is(errs[0], ErrInvalidAddress))
+		}
+
+		recs = records()
+		recs[1].HostSets = []string{"west"}
+		recs[2].HostSets = []string{"hsst_1234567890"}
+		_, err = repo.ImportHosts(ctx, prj.PublicId, catalog.PublicId, recs)
+		errs = nil
+		if assert.True(errors.As(err, &errs)) {
+			assert.Len(errs, 2)
+			assert.Equal(2, errs[0].Row)
+			assert.Equal(3, errs[1].Row)
+			assert.True(errors.Is(errs[0], db.ErrRecordNotFound))
+		}
+		assert.Equal(2, hostCount())
+	})
+	t.Run("missing-parameters", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.ImportHosts(ctx, "", catalog.PublicId, records())
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, err = repo.ImportHosts(ctx, prj.PublicId, "", records())
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, err = repo.ImportHosts(ctx, prj.PublicId, catalog.PublicId, nil)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+	})
+	t.Run("dry-run", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ImportHosts(ctx, prj.PublicId, catalog.PublicId, records(), WithDryRun(true))
+		require.NoError(err)
+		require.Len(got, 3)
+		assert.Equal(ImportUpdated, got[0].Action)
+		assert.Equal(ImportCreated, got[1].Action)
+		assert.Equal(ImportUnchanged, got[2].Action)
+		assert.Equal(2, hostCount())
+		h, err := repo.LookupHost(ctx, existing.PublicId)
+		require.NoError(err)
+		assert.Equal("10.0.0.1", h.Address)
+	})
+	t.Run("import", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ImportHosts(ctx, prj.PublicId, catalog.PublicId, records())
+		require.NoError(err)
+		require.Len(got, 3)
+
+		assert.Equal(ImportUpdated, got[0].Action)
+		assert.Equal(existing.PublicId, got[0].PublicId)
+		assert.Equal([]string{east.PublicId}, got[0].AddedSetIds)
+		assert.Equal(ImportCreated, got[1].Action)
+		assert.Equal([]string{east.PublicId, all.PublicId}, got[1].AddedSetIds)
+		assert.Equal(ImportUnchanged, got[2].Action)
+		assert.Empty(got[2].AddedSetIds)
+		assert.Equal(3, hostCount())
+
+		h, err := repo.LookupHost(ctx, existing.PublicId)
+		require.NoError(err)
+		assert.Equal("10.1.0.1", h.Address)
+		assert.Equal("renumbered", h.Description)
+		assert.NoError(db.TestVerifyOplog(t, rw, existing.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second)))
+		assert.NoError(db.TestVerifyOplog(t, rw, got[1].PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
+
+		_, eastHosts, err := repo.LookupSet(ctx, east.PublicId)
+		require.NoError(err)
+		assert.Len(eastHosts, 2)
+		_, allHosts, err := repo.LookupSet(ctx, all.PublicId)
+		require.NoError(err)
+		assert.Len(allHosts, 2)
+
+		// Importing the same document again changes nothing
+		got, err = repo.ImportHosts(ctx, prj.PublicId, catalog.PublicId, records())
+		require.NoError(err)
+		for _, ih := range got {
+			assert.Equal(ImportUnchanged, ih.Action)
+			assert.Empty(ih.AddedSetIds)
+		}
+	})
+}
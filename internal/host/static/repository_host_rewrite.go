@@ -0,0 +1,184 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// An AddressRewriteRule rewrites the address of a host. It returns the new
+// address and true if the rule matches the address, or false if the host
+// should be left alone.
+type AddressRewriteRule func(address string) (string, bool)
+
+// RegexpRewrite returns a rule which rewrites addresses matching pattern,
+// replacing the matches with replacement. Inside replacement, $ signs are
+// interpreted as in regexp.Regexp.Expand, so $1 is the text of the first
+// submatch.
+func RegexpRewrite(pattern, replacement string) (AddressRewriteRule, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("regexp rewrite: %v: %w", err, db.ErrInvalidParameter)
+	}
+	return func(address string) (string, bool) {
+		if !re.MatchString(address) {
+			return "", false
+		}
+		return re.ReplaceAllString(address, replacement), true
+	}, nil
+}
+
+// SubnetRewrite returns a rule which renumbers the IP addresses in the
+// subnet from into the subnet to, keeping their host bits. from and to are
+// CIDR blocks of the same IP version and prefix length, like 10.1.0.0/16 and
+// 10.2.0.0/16. Addresses which aren't IP addresses in from, like DNS names,
+// don't match.
+func SubnetRewrite(from, to string) (AddressRewriteRule, error) {
+	_, fromNet, err := net.ParseCIDR(from)
+	if err != nil {
+		return nil, fmt.Errorf("subnet rewrite: from: %v: %w", err, db.ErrInvalidParameter)
+	}
+	_, toNet, err := net.ParseCIDR(to)
+	if err != nil {
+		return nil, fmt.Errorf("subnet rewrite: to: %v: %w", err, db.ErrInvalidParameter)
+	}
+	fromOnes, fromBits := fromNet.Mask.Size()
+	toOnes, toBits := toNet.Mask.Size()
+	if fromOnes != toOnes || fromBits != toBits {
+		return nil, fmt.Errorf("subnet rewrite: %s and %s are not the same size: %w", from, to, db.ErrInvalidParameter)
+	}
+	return func(address string) (string, bool) {
+		ip := net.ParseIP(address)
+		if ip == nil || !fromNet.Contains(ip) {
+			return "", false
+		}
+		if v4 := ip.To4(); v4 != nil && len(fromNet.IP) == net.IPv4len {
+			ip = v4
+		}
+		out := make(net.IP, len(ip))
+		for i := range ip {
+			out[i] = toNet.IP[i] | ip[i]&^fromNet.Mask[i]
+		}
+		return out.String(), true
+	}, nil
+}
+
+// AddressRewrite is a change of a host's address made by
+// RewriteHostAddresses.
+type AddressRewrite struct {
+	HostId     string
+	CatalogId  string
+	ScopeId    string
+	OldAddress string
+	NewAddress string
+
+	version uint32
+}
+
+// PreviewHostAddressRewrite returns the changes RewriteHostAddresses would
+// make to the addresses of static hosts for rule, without making them. All
+// options are ignored.
+func (r *Repository) PreviewHostAddressRewrite(ctx context.Context, rule AddressRewriteRule, opt ...Option) ([]*AddressRewrite, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("preview address rewrite: static host: missing rule: %w", db.ErrInvalidParameter)
+	}
+	rewrites, err := hostAddressRewrites(ctx, r.reader, rule)
+	if err != nil {
+		return nil, fmt.Errorf("preview address rewrite: static host: %w", err)
+	}
+	return rewrites, nil
+}
+
+// RewriteHostAddresses rewrites the addresses of all the static hosts which
+// rule matches, like when renumbering a subnet, and returns the changes it
+// made. The hosts are updated in a single transaction with an oplog entry
+// for each, so either every matching host is rewritten or none are. All
+// options are ignored.
+func (r *Repository) RewriteHostAddresses(ctx context.Context, rule AddressRewriteRule, opt ...Option) ([]*AddressRewrite, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("rewrite addresses: static host: missing rule: %w", db.ErrInvalidParameter)
+	}
+	var rewrites []*AddressRewrite
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var err error
+			rewrites, err = hostAddressRewrites(ctx, reader, rule)
+			if err != nil {
+				return err
+			}
+			wrappers := make(map[string]wrapping.Wrapper)
+			for _, rewrite := range rewrites {
+				oplogWrapper, ok := wrappers[rewrite.ScopeId]
+				if !ok {
+					oplogWrapper, err = r.kms.GetWrapper(ctx, rewrite.ScopeId, kms.KeyPurposeOplog)
+					if err != nil {
+						return fmt.Errorf("unable to get oplog wrapper: %w", err)
+					}
+					wrappers[rewrite.ScopeId] = oplogWrapper
+				}
+				h := &Host{
+					Host: &store.Host{
+						PublicId:  rewrite.HostId,
+						CatalogId: rewrite.CatalogId,
+						Address:   rewrite.NewAddress,
+					},
+				}
+				version := rewrite.version
+				rowsUpdated, err := w.Update(ctx, h, []string{"Address"}, nil,
+					db.WithOplog(oplogWrapper, h.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+					db.WithVersion(&version))
+				if err != nil {
+					if db.IsCheckConstraintError(err) || db.IsNotNullError(err) {
+						return fmt.Errorf("%s: %q: %w", rewrite.HostId, rewrite.NewAddress, ErrInvalidAddress)
+					}
+					return fmt.Errorf("%s: %w", rewrite.HostId, err)
+				}
+				if rowsUpdated != 1 {
+					return fmt.Errorf("%s: expected to update 1 host, updated %d", rewrite.HostId, rowsUpdated)
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("rewrite addresses: static host: %w", err)
+	}
+	return rewrites, nil
+}
+
+// hostAddressRewrites finds the static hosts which rule matches and the
+// addresses rule rewrites them to. It fails if any new address isn't valid.
+func hostAddressRewrites(ctx context.Context, reader db.Reader, rule AddressRewriteRule) ([]*AddressRewrite, error) {
+	rows, err := reader.Query(ctx, hostAddressesQuery, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query host addresses: %w", err)
+	}
+	defer rows.Close()
+	var rewrites []*AddressRewrite
+	for rows.Next() {
+		rewrite := &AddressRewrite{}
+		if err := rows.Scan(&rewrite.HostId, &rewrite.CatalogId, &rewrite.ScopeId, &rewrite.OldAddress, &rewrite.version); err != nil {
+			return nil, fmt.Errorf("unable to scan host address: %w", err)
+		}
+		newAddress, ok := rule(rewrite.OldAddress)
+		if !ok || newAddress == rewrite.OldAddress {
+			continue
+		}
+		if len(newAddress) < MinHostAddressLength || len(newAddress) > MaxHostAddressLength {
+			return nil, fmt.Errorf("%s: %q: %w", rewrite.HostId, newAddress, ErrInvalidAddress)
+		}
+		rewrite.NewAddress = newAddress
+		rewrites = append(rewrites, rewrite)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read host addresses: %w", err)
+	}
+	return rewrites, nil
+}
@@ -0,0 +1,158 @@
+package static
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubnetRewrite(t *testing.T) {
+	rule, err := SubnetRewrite("10.1.0.0/16", "192.168.0.0/16")
+	require.NoError(t, err)
+	var tests = []struct {
+		address string
+		want    string
+		wantOk  bool
+	}{
+		{address: "10.1.0.5", want: "192.168.0.5", wantOk: true},
+		{address: "10.1.255.254", want: "192.168.255.254", wantOk: true},
+		{address: "10.2.0.5"},
+		{address: "db.example.com"},
+		{address: "fe80::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			got, ok := rule(tt.address)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	rule, err = SubnetRewrite("fd00:1::/32", "fd00:2::/32")
+	require.NoError(t, err)
+	got, ok := rule("fd00:1:abcd::1")
+	assert.True(t, ok)
+	assert.Equal(t, "fd00:2:abcd::1", got)
+
+	_, err = SubnetRewrite("10.1.0.0/16", "10.2.0.0/24")
+	assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+	_, err = SubnetRewrite("10.1.0.0/16", "fd00::/16")
+	assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+	_, err = SubnetRewrite("10.1.0.0", "10.2.0.0/16")
+	assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+}
+
+func TestRegexpRewrite(t *testing.T) {
+	rule, err := RegexpRewrite(`^(.*)\.old\.example\.com$`, "$1.new.example.com")
+	require.NoError(t, err)
+	got, ok := rule("db.old.example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "db.new.example.com", got)
+	_, ok = rule("db.example.com")
+	assert.False(t, ok)
+
+	_, err = RegexpRewrite(`(`, "")
+	assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+}
+
+func TestRepository_RewriteHostAddresses(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, prjA := iam.TestScopes(t, iamRepo)
+	_, prjB := iam.TestScopes(t, iamRepo)
+	catalogA := TestCatalogs(t, conn, prjA.PublicId, 1)[0]
+	catalogB := TestCatalogs(t, conn, prjB.PublicId, 1)[0]
+	newHost := func(scopeId, catalogId, address string) *Host {
+		h, err := NewHost(catalogId, WithAddress(address))
+		require.NoError(t, err)
+		h, err = repo.CreateHost(ctx, scopeId, h)
+		require.NoError(t, err)
+		return h
+	}
+	inA := newHost(prjA.PublicId, catalogA.PublicId, "10.1.0.5")
+	inB := newHost(prjB.PublicId, catalogB.PublicId, "10.1.3.7")
+	outside := newHost(prjA.PublicId, catalogA.PublicId, "10.9.0.1")
+	named := newHost(prjB.PublicId, catalogB.PublicId, "db.example.com")
+
+	assertAddresses := func(t *testing.T, want map[*Host]string) {
+		t.Helper()
+		for h, address := range want {
+			got, err := repo.LookupHost(ctx, h.PublicId)
+			require.NoError(t, err)
+			assert.Equal(t, address, got.Address)
+		}
+	}
+
+	t.Run("invalid-address", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		rule, err := RegexpRewrite(`^10\.1\..*$`, "")
+		require.NoError(err)
+		_, err = repo.PreviewHostAddressRewrite(ctx, rule)
+		assert.True(errors.Is(err, ErrInvalidAddress))
+		_, err = repo.RewriteHostAddresses(ctx, rule)
+		assert.True(errors.Is(err, ErrInvalidAddress))
+		assertAddresses(t, map[*Host]string{inA: "10.1.0.5", inB: "10.1.3.7"})
+	})
+	t.Run("missing-rule", func(t *testing.T) {
+		_, err := repo.PreviewHostAddressRewrite(ctx, nil)
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+		_, err = repo.RewriteHostAddresses(ctx, nil)
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+	})
+	t.Run("renumber", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		rule, err := SubnetRewrite("10.1.0.0/16", "10.2.0.0/16")
+		require.NoError(err)
+		want := []*AddressRewrite{
+			{HostId: inA.PublicId, CatalogId: catalogA.PublicId, ScopeId: prjA.PublicId, OldAddress: "10.1.0.5", NewAddress: "10.2.0.5"},
+			{HostId: inB.PublicId, CatalogId: catalogB.PublicId, ScopeId: prjB.PublicId, OldAddress: "10.1.3.7", NewAddress: "10.2.3.7"},
+		}
+		stripVersions := func(rewrites []*AddressRewrite) []*AddressRewrite {
+			for _, rewrite := range rewrites {
+				rewrite.version = 0
+			}
+			return rewrites
+		}
+
+		preview, err := repo.PreviewHostAddressRewrite(ctx, rule)
+		require.NoError(err)
+		assert.ElementsMatch(want, stripVersions(preview))
+		assertAddresses(t, map[*Host]string{inA: "10.1.0.5", inB: "10.1.3.7"})
+
+		got, err := repo.RewriteHostAddresses(ctx, rule)
+		require.NoError(err)
+		assert.ElementsMatch(want, stripVersions(got))
+		assertAddresses(t, map[*Host]string{
+			inA:     "10.2.0.5",
+			inB:     "10.2.3.7",
+			outside: "10.9.0.1",
+			named:   "db.example.com",
+		})
+		for _, h := range []*Host{inA, inB} {
+			got, err := repo.LookupHost(ctx, h.PublicId)
+			require.NoError(err)
+			assert.Equal(h.Version+1, got.Version)
+			assert.NoError(db.TestVerifyOplog(t, rw, h.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second)))
+		}
+
+		// Nothing is left to rewrite
+		got, err = repo.RewriteHostAddresses(ctx, rule)
+		require.NoError(err)
+		assert.Empty(got)
+	})
+}
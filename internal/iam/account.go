@@ -22,7 +22,6 @@ type authAccount struct {
 	tableName string `gorm:"-"`
 }
 
-var _ Cloneable = (*authAccount)(nil)
 var _ db.VetForWriter = (*authAccount)(nil)
 var _ oplog.ReplayableMessage = (*authAccount)(nil)
 
@@ -33,7 +32,7 @@ func allocAccount() authAccount {
 }
 
 // Clone creates a clone of the auth account.
-func (a *authAccount) Clone() interface{} {
+func (a *authAccount) Clone() *authAccount {
 	cp := proto.Clone(a.Account)
 	return &authAccount{
 		Account: cp.(*authStore.Account),
@@ -30,7 +30,7 @@ func Test_AccountUpdate(t *testing.T) {
 		authMethodPublicId := testAuthMethod(t, conn, org.PublicId)
 		acct := testAccount(t, conn, org.PublicId, authMethodPublicId, "")
 
-		updateAcct := acct.Clone().(*authAccount)
+		updateAcct := acct.Clone()
 		updateAcct.IamUserId = u.PublicId
 		updatedRows, err := rw.Update(context.Background(), updateAcct, []string{"IamUserId"}, nil)
 		require.NoError(err)
@@ -75,7 +75,7 @@ func TestAccount_Clone(t *testing.T) {
 		authMethodPublicId := testAuthMethod(t, conn, org.PublicId)
 		acct := testAccount(t, conn, org.PublicId, authMethodPublicId, u.PublicId)
 		cp := acct.Clone()
-		assert.True(proto.Equal(cp.(*authAccount).Account, acct.Account))
+		assert.True(proto.Equal(cp.Account, acct.Account))
 	})
 	t.Run("not-equal", func(t *testing.T) {
 		assert := assert.New(t)
@@ -86,7 +86,7 @@ func TestAccount_Clone(t *testing.T) {
 		dbassert := dbassert.New(t, conn.DB())
 		dbassert.IsNull(acct2, "IamUserId")
 		cp := acct.Clone()
-		assert.True(!proto.Equal(cp.(*authAccount).Account, acct2.Account))
+		assert.True(!proto.Equal(cp.Account, acct2.Account))
 	})
 }
 
@@ -22,3 +22,29 @@ func CrudlActions() map[string]action.Type {
 		action.List.String():   action.List,
 	}
 }
+
+// CollectionActions returns the actions, like those returned by a resource's
+// Actions(), which are performed on the resource's collection, like list and
+// create. They're authorized against the collection's endpoint.
+func CollectionActions(actions map[string]action.Type) map[string]action.Type {
+	ret := make(map[string]action.Type, len(actions))
+	for k, v := range actions {
+		if v.IsCollection() {
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+// InstanceActions returns the actions, like those returned by a resource's
+// Actions(), which are performed on an instance of the resource, like read,
+// update and delete. They're authorized against the instance's endpoint.
+func InstanceActions(actions map[string]action.Type) map[string]action.Type {
+	ret := make(map[string]action.Type, len(actions))
+	for k, v := range actions {
+		if !v.IsCollection() {
+			ret[k] = v
+		}
+	}
+	return ret
+}
@@ -47,3 +47,18 @@ func Test_CrudlActions(t *testing.T) {
 		assert.Equal(aType, action.Unknown)
 	})
 }
+
+func Test_CollectionAndInstanceActions(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	a := (&Role{}).Actions()
+	assert.Equal(map[string]action.Type{
+		action.List.String():   action.List,
+		action.Create.String(): action.Create,
+	}, CollectionActions(a))
+	instance := InstanceActions(a)
+	assert.Len(instance, len(a)-2)
+	assert.Equal(action.Read, instance[action.Read.String()])
+	assert.Equal(action.AddGrants, instance[action.AddGrants.String()])
+	assert.NotContains(instance, action.List.String())
+}
@@ -0,0 +1,124 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/perms"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultBannedGrantPatternTable = "iam_banned_grant_pattern"
+
+// BannedGrantPattern is a pattern of grants, like "id=*;actions=*", which
+// role grants can't be written to match in its scope or any scope beneath it.
+// Its Name is the policy named when a grant is refused. Patterns are parsed
+// and matched by perms.GrantPattern.
+type BannedGrantPattern struct {
+	*store.BannedGrantPattern
+	tableName string `gorm:"-"`
+}
+
+// ensure that BannedGrantPattern implements the interfaces of: db.VetForWriter
+var _ db.VetForWriter = (*BannedGrantPattern)(nil)
+
+// NewBannedGrantPattern creates a new in memory banned grant pattern for the
+// scope. Supports the WithDescription option.
+func NewBannedGrantPattern(scopeId, name, pattern string, opt ...Option) (*BannedGrantPattern, error) {
+	opts := getOpts(opt...)
+	p := &BannedGrantPattern{
+		BannedGrantPattern: &store.BannedGrantPattern{
+			ScopeId:     scopeId,
+			Name:        name,
+			Pattern:     pattern,
+			Description: opts.withDescription,
+		},
+	}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("new banned grant pattern: %w", err)
+	}
+	return p, nil
+}
+
+func allocBannedGrantPattern() BannedGrantPattern {
+	return BannedGrantPattern{
+		BannedGrantPattern: &store.BannedGrantPattern{},
+	}
+}
+
+// validate checks the scope, name and pattern of the banned grant pattern.
+func (p *BannedGrantPattern) validate() error {
+	if p.ScopeId == "" {
+		return fmt.Errorf("missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("missing name: %w", db.ErrInvalidParameter)
+	}
+	if _, err := perms.ParseGrantPattern(p.Name, p.Pattern); err != nil {
+		return fmt.Errorf("%v: %w", err, db.ErrInvalidParameter)
+	}
+	return nil
+}
+
+// Clone creates a clone of the BannedGrantPattern
+func (p *BannedGrantPattern) Clone() *BannedGrantPattern {
+	cp := proto.Clone(p.BannedGrantPattern)
+	return &BannedGrantPattern{
+		BannedGrantPattern: cp.(*store.BannedGrantPattern),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface
+func (p *BannedGrantPattern) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if opType == db.CreateOp {
+		if err := p.validate(); err != nil {
+			return fmt.Errorf("vet banned grant pattern for writing: %w", err)
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (p *BannedGrantPattern) TableName() string {
+	if p.tableName != "" {
+		return p.tableName
+	}
+	return defaultBannedGrantPatternTable
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (p *BannedGrantPattern) SetTableName(n string) {
+	p.tableName = n
+}
+
+// bannedGrantPatterns returns the banned grant patterns in effect in the scope
+// grants are made to, which are those created in it and in the scopes above
+// it.
+func bannedGrantPatterns(ctx context.Context, r db.Reader, grantScopeId string) ([]*perms.GrantPattern, error) {
+	rows, err := r.Query(ctx, scopeBannedGrantPatterns, []interface{}{grantScopeId})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get banned grant patterns: %w", err)
+	}
+	defer rows.Close()
+	var patterns []*perms.GrantPattern
+	for rows.Next() {
+		var scopeId, name, pattern string
+		if err := rows.Scan(&scopeId, &name, &pattern); err != nil {
+			return nil, fmt.Errorf("unable to scan banned grant pattern: %w", err)
+		}
+		p, err := perms.ParseGrantPattern(fmt.Sprintf("%q of %s", name, scopeId), pattern)
+		if err != nil {
+			return nil, fmt.Errorf("banned grant pattern %q of %s: %w", name, scopeId, err)
+		}
+		patterns = append(patterns, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return patterns, nil
+}
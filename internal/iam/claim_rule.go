@@ -0,0 +1,189 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultClaimRuleTableName = "iam_claim_rule"
+
+	// ClaimRuleAnyValue is the claim value which matches any value of the
+	// rule's claim.
+	ClaimRuleAnyValue = "*"
+)
+
+// ClaimRule maps an identity provider claim presented at login time to a role
+// or a group within an org. Users presenting a matching claim are added as
+// principals of the role, or as members of the group. Groups which are mapped
+// by claim rules are managed by the identity provider: users who log in
+// without presenting a matching claim for any of the group's rules are
+// removed from the group.
+type ClaimRule struct {
+	*store.ClaimRule
+	tableName string `gorm:"-"`
+}
+
+// ensure that ClaimRule implements the interfaces of: Resource, Cloneable, and db.VetForWriter.
+var _ Resource = (*ClaimRule)(nil)
+var _ Cloneable = (*ClaimRule)(nil)
+var _ db.VetForWriter = (*ClaimRule)(nil)
+
+// NewClaimRule creates a new in memory claim rule for an org. Allowed options
+// include: WithName and WithDescription.
+func NewClaimRule(orgId, claimName, claimValue, roleId string, opt ...Option) (*ClaimRule, error) {
+	if orgId == "" {
+		return nil, fmt.Errorf("new claim rule: missing org id: %w", db.ErrInvalidParameter)
+	}
+	if strings.TrimSpace(claimName) == "" {
+		return nil, fmt.Errorf("new claim rule: missing claim name: %w", db.ErrInvalidParameter)
+	}
+	if strings.TrimSpace(claimValue) == "" {
+		return nil, fmt.Errorf("new claim rule: missing claim value: %w", db.ErrInvalidParameter)
+	}
+	if roleId == "" {
+		return nil, fmt.Errorf("new claim rule: missing role id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	return &ClaimRule{
+		ClaimRule: &store.ClaimRule{
+			ScopeId:     orgId,
+			ClaimName:   claimName,
+			ClaimValue:  claimValue,
+			RoleId:      roleId,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+		},
+	}, nil
+}
+
+// NewGroupClaimRule creates a new in memory claim rule for an org which maps
+// the claim to a group. Allowed options include: WithName and
+// WithDescription.
+func NewGroupClaimRule(orgId, claimName, claimValue, groupId string, opt ...Option) (*ClaimRule, error) {
+	if orgId == "" {
+		return nil, fmt.Errorf("new group claim rule: missing org id: %w", db.ErrInvalidParameter)
+	}
+	if strings.TrimSpace(claimName) == "" {
+		return nil, fmt.Errorf("new group claim rule: missing claim name: %w", db.ErrInvalidParameter)
+	}
+	if strings.TrimSpace(claimValue) == "" {
+		return nil, fmt.Errorf("new group claim rule: missing claim value: %w", db.ErrInvalidParameter)
+	}
+	if groupId == "" {
+		return nil, fmt.Errorf("new group claim rule: missing group id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	return &ClaimRule{
+		ClaimRule: &store.ClaimRule{
+			ScopeId:     orgId,
+			ClaimName:   claimName,
+			ClaimValue:  claimValue,
+			GroupId:     groupId,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+		},
+	}, nil
+}
+
+func allocClaimRule() ClaimRule {
+	return ClaimRule{
+		ClaimRule: &store.ClaimRule{},
+	}
+}
+
+// Clone creates a clone of the ClaimRule.
+func (c *ClaimRule) Clone() *ClaimRule {
+	cp := proto.Clone(c.ClaimRule)
+	return &ClaimRule{
+		ClaimRule: cp.(*store.ClaimRule),
+	}
+}
+
+// cloneResource implements Cloneable.
+func (c *ClaimRule) cloneResource() Resource {
+	return c.Clone()
+}
+
+// Matches returns true if the claims contain the rule's claim with the rule's
+// value. Claim names are matched case insensitively since identity providers
+// are inconsistent about the case of claim names; values are matched exactly.
+func (c *ClaimRule) Matches(claims map[string][]string) bool {
+	for name, values := range claims {
+		if !strings.EqualFold(name, c.ClaimName) {
+			continue
+		}
+		for _, v := range values {
+			if c.ClaimValue == ClaimRuleAnyValue || v == c.ClaimValue {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the claim
+// rule before it's written.
+func (c *ClaimRule) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if c.PublicId == "" {
+		return fmt.Errorf("claim rule vet for write: missing public id: %w", db.ErrInvalidParameter)
+	}
+	if opType == db.CreateOp {
+		if strings.TrimSpace(c.ClaimName) == "" {
+			return fmt.Errorf("claim rule vet for write: missing claim name: %w", db.ErrInvalidParameter)
+		}
+		if strings.TrimSpace(c.ClaimValue) == "" {
+			return fmt.Errorf("claim rule vet for write: missing claim value: %w", db.ErrInvalidParameter)
+		}
+		switch {
+		case c.RoleId == "" && c.GroupId == "":
+			return fmt.Errorf("claim rule vet for write: missing role id or group id: %w", db.ErrInvalidParameter)
+		case c.RoleId != "" && c.GroupId != "":
+			return fmt.Errorf("claim rule vet for write: both role id and group id set: %w", db.ErrInvalidParameter)
+		}
+	}
+	if err := validateScopeForWrite(ctx, r, c, opType, opt...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (*ClaimRule) validScopeTypes() []scope.Type {
+	return []scope.Type{scope.Org}
+}
+
+// GetScope returns the scope for the ClaimRule.
+func (c *ClaimRule) GetScope(ctx context.Context, r db.Reader) (*Scope, error) {
+	return LookupScope(ctx, r, c)
+}
+
+// ResourceType returns the type of the ClaimRule.
+func (*ClaimRule) ResourceType() resource.Type { return resource.ClaimRule }
+
+// Actions returns the available actions for ClaimRule
+func (*ClaimRule) Actions() map[string]action.Type {
+	return CrudActions()
+}
+
+// TableName returns the tablename to override the default gorm table name.
+func (c *ClaimRule) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return defaultClaimRuleTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (c *ClaimRule) SetTableName(n string) {
+	c.tableName = n
+}
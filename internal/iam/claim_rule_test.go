@@ -0,0 +1,148 @@
+package iam
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClaimRule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		orgId      string
+		claimName  string
+		claimValue string
+		roleId     string
+		opt        []Option
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      *ClaimRule
+		wantErrIs error
+	}{
+		{
+			name: "valid",
+			args: args{
+				orgId:      "o_1234567890",
+				claimName:  "department",
+				claimValue: "payments",
+				roleId:     "r_1234567890",
+				opt:        []Option{WithName("payments"), WithDescription("payments login")},
+			},
+			want: &ClaimRule{
+				ClaimRule: &store.ClaimRule{
+					ScopeId:     "o_1234567890",
+					ClaimName:   "department",
+					ClaimValue:  "payments",
+					RoleId:      "r_1234567890",
+					Name:        "payments",
+					Description: "payments login",
+				},
+			},
+		},
+		{
+			name: "missing-org",
+			args: args{
+				claimName:  "department",
+				claimValue: "payments",
+				roleId:     "r_1234567890",
+			},
+			wantErrIs: db.ErrInvalidParameter,
+		},
+		{
+			name: "missing-claim-name",
+			args: args{
+				orgId:      "o_1234567890",
+				claimName:  " ",
+				claimValue: "payments",
+				roleId:     "r_1234567890",
+			},
+			wantErrIs: db.ErrInvalidParameter,
+		},
+		{
+			name: "missing-claim-value",
+			args: args{
+				orgId:     "o_1234567890",
+				claimName: "department",
+				roleId:    "r_1234567890",
+			},
+			wantErrIs: db.ErrInvalidParameter,
+		},
+		{
+			name: "missing-role",
+			args: args{
+				orgId:      "o_1234567890",
+				claimName:  "department",
+				claimValue: "payments",
+			},
+			wantErrIs: db.ErrInvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewClaimRule(tt.args.orgId, tt.args.claimName, tt.args.claimValue, tt.args.roleId, tt.args.opt...)
+			if tt.wantErrIs != nil {
+				require.Error(err)
+				assert.True(errors.Is(err, tt.wantErrIs))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestNewGroupClaimRule(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	got, err := NewGroupClaimRule("o_1234567890", "groups", "payments", "g_1234567890", WithName("payments"))
+	require.NoError(err)
+	assert.Equal(&ClaimRule{
+		ClaimRule: &store.ClaimRule{
+			ScopeId:    "o_1234567890",
+			ClaimName:  "groups",
+			ClaimValue: "payments",
+			GroupId:    "g_1234567890",
+			Name:       "payments",
+		},
+	}, got)
+
+	_, err = NewGroupClaimRule("o_1234567890", "groups", "payments", "")
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+	_, err = NewGroupClaimRule("", "groups", "payments", "g_1234567890")
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+}
+
+func TestClaimRule_Matches(t *testing.T) {
+	t.Parallel()
+	claims := map[string][]string{
+		"Department": {"payments", "fraud"},
+		"groups":     {"admins"},
+	}
+	tests := []struct {
+		name       string
+		claimName  string
+		claimValue string
+		want       bool
+	}{
+		{name: "exact", claimName: "department", claimValue: "payments", want: true},
+		{name: "second-value", claimName: "department", claimValue: "fraud", want: true},
+		{name: "any-value", claimName: "groups", claimValue: ClaimRuleAnyValue, want: true},
+		{name: "value-case-sensitive", claimName: "department", claimValue: "Payments", want: false},
+		{name: "no-value", claimName: "department", claimValue: "support", want: false},
+		{name: "no-claim", claimName: "email", claimValue: ClaimRuleAnyValue, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewClaimRule("o_1234567890", tt.claimName, tt.claimValue, "r_1234567890")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, r.Matches(claims))
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Package config provides a declarative description of iam scopes, roles,
+// grants, groups and memberships which can be loaded from an HCL or JSON file
+// and reconciled against the database. This enables access policies to be
+// managed in version control and applied with a plan/apply workflow.
+//
+// An example file:
+//
+//	org "payments" {
+//	  description = "Payments org"
+//
+//	  group "engineers" {
+//	    members = ["u_1234567890"]
+//	  }
+//
+//	  role "engineers" {
+//	    grants     = ["id=*;type=host-catalog;actions=read,list"]
+//	    principals = ["group:engineers"]
+//	  }
+//
+//	  project "production" {
+//	    role "readers" {
+//	      grants = ["id=*;type=*;actions=read"]
+//	    }
+//	  }
+//	}
+//
+// In JSON, blocks are lists of objects keyed by name, like
+// {"org": [{"payments": {"role": [{"readers": {"grants": [...]}}]}}]}, since
+// nested objects with a single key are otherwise flattened into one block.
+//
+// Grants are parsed in the role's grant_scope_id if it sets one and otherwise
+// in the scope the role is declared in, and are checked the way the controller
+// checks them before they're written.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/hcl"
+)
+
+// GroupPrincipalPrefix is the prefix used in a role's principals to reference
+// a group by name within the role's scope instead of by public id.
+const GroupPrincipalPrefix = "group:"
+
+// Config is the declarative description of iam resources
+type Config struct {
+	Orgs []*Org `hcl:"org"`
+}
+
+// Org describes an org and the resources it contains
+type Org struct {
+	Name        string     `hcl:",key"`
+	Description string     `hcl:"description"`
+	Roles       []*Role    `hcl:"role"`
+	Groups      []*Group   `hcl:"group"`
+	Projects    []*Project `hcl:"project"`
+}
+
+// Project describes a project and the resources it contains
+type Project struct {
+	Name        string   `hcl:",key"`
+	Description string   `hcl:"description"`
+	Roles       []*Role  `hcl:"role"`
+	Groups      []*Group `hcl:"group"`
+}
+
+// Role describes a role, its grants and its principals. Principals are either
+// public ids of users and groups or "group:<name>" references to a group
+// declared in the same scope.
+type Role struct {
+	Name         string   `hcl:",key"`
+	Description  string   `hcl:"description"`
+	GrantScopeId string   `hcl:"grant_scope_id"`
+	Grants       []string `hcl:"grants"`
+	Principals   []string `hcl:"principals"`
+}
+
+// Group describes a group and its members, which are user public ids
+type Group struct {
+	Name        string   `hcl:",key"`
+	Description string   `hcl:"description"`
+	Members     []string `hcl:"members"`
+}
+
+// LoadFile loads the declarative configuration from the given file, which may
+// be HCL or JSON.
+func LoadFile(path string) (*Config, error) {
+	d, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(string(d))
+}
+
+// Parse parses and validates the declarative configuration, which may be HCL
+// or JSON.
+func Parse(d string) (*Config, error) {
+	obj, err := hcl.Parse(d)
+	if err != nil {
+		return nil, err
+	}
+	result := &Config{}
+	if err := hcl.DecodeObject(result, obj); err != nil {
+		return nil, err
+	}
+	if err := result.Validate(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Validate ensures that names are unique within their scope, that grants
+// parse and that group references in principals resolve to groups declared in
+// the same scope.
+func (c *Config) Validate() error {
+	orgs := map[string]bool{}
+	for _, o := range c.Orgs {
+		if o.Name == "" {
+			return errors.New("org is missing a name")
+		}
+		if orgs[o.Name] {
+			return fmt.Errorf("org %q is declared more than once", o.Name)
+		}
+		orgs[o.Name] = true
+		if err := validateScope(o.Name, scope.Org, o.Roles, o.Groups); err != nil {
+			return err
+		}
+		projects := map[string]bool{}
+		for _, p := range o.Projects {
+			if p.Name == "" {
+				return fmt.Errorf("project in org %q is missing a name", o.Name)
+			}
+			if projects[p.Name] {
+				return fmt.Errorf("project %q is declared more than once in org %q", p.Name, o.Name)
+			}
+			projects[p.Name] = true
+			if err := validateScope(o.Name+"/"+p.Name, scope.Project, p.Roles, p.Groups); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateScopeIds are the scopes grants are parsed in for roles which don't
+// set a grant scope. Only the type of the scope matters when a grant is
+// parsed, so any valid id of the type will do.
+var validateScopeIds = map[scope.Type]string{
+	scope.Org:     "o_abcd1234",
+	scope.Project: "p_abcd1234",
+}
+
+func validateScope(path string, typ scope.Type, roles []*Role, groups []*Group) error {
+	groupNames := map[string]bool{}
+	for _, g := range groups {
+		if g.Name == "" {
+			return fmt.Errorf("group in %q is missing a name", path)
+		}
+		if groupNames[g.Name] {
+			return fmt.Errorf("group %q is declared more than once in %q", g.Name, path)
+		}
+		groupNames[g.Name] = true
+	}
+	roleNames := map[string]bool{}
+	for _, r := range roles {
+		if r.Name == "" {
+			return fmt.Errorf("role in %q is missing a name", path)
+		}
+		if roleNames[r.Name] {
+			return fmt.Errorf("role %q is declared more than once in %q", r.Name, path)
+		}
+		roleNames[r.Name] = true
+		grantScopeId := r.GrantScopeId
+		if grantScopeId == "" {
+			grantScopeId = validateScopeIds[typ]
+		}
+		for _, g := range r.Grants {
+			parsed, err := perms.Parse(grantScopeId, g)
+			if err != nil {
+				return fmt.Errorf("role %q in %q has an invalid grant %q: %w", r.Name, path, g, err)
+			}
+			if err := perms.ValidateGrant(parsed); err != nil {
+				return fmt.Errorf("role %q in %q has an invalid grant %q: %w", r.Name, path, g, err)
+			}
+		}
+		for _, p := range r.Principals {
+			if !strings.HasPrefix(p, GroupPrincipalPrefix) {
+				continue
+			}
+			if !groupNames[strings.TrimPrefix(p, GroupPrincipalPrefix)] {
+				return fmt.Errorf("role %q in %q references undeclared group %q", r.Name, path, p)
+			}
+		}
+	}
+	return nil
+}
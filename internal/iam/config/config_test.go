@@ -0,0 +1,188 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		in      string
+		want    *Config
+		wantErr string
+	}{
+		{
+			name: "hcl",
+			in: `
+org "payments" {
+  description = "Payments org"
+  group "engineers" {
+    members = ["u_1234567890"]
+  }
+  role "engineers" {
+    grants     = ["id=*;type=host-catalog;actions=read,list"]
+    principals = ["group:engineers"]
+  }
+  project "production" {
+    role "readers" {
+      grants = ["id=*;type=*;actions=read"]
+    }
+  }
+}`,
+			want: &Config{
+				Orgs: []*Org{
+					{
+						Name:        "payments",
+						Description: "Payments org",
+						Groups: []*Group{
+							{Name: "engineers", Members: []string{"u_1234567890"}},
+						},
+						Roles: []*Role{
+							{
+								Name:       "engineers",
+								Grants:     []string{"id=*;type=host-catalog;actions=read,list"},
+								Principals: []string{"group:engineers"},
+							},
+						},
+						Projects: []*Project{
+							{
+								Name: "production",
+								Roles: []*Role{
+									{Name: "readers", Grants: []string{"id=*;type=*;actions=read"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "json",
+			in:   `{"org": [{"payments": {"role": [{"readers": {"grants": ["id=*;type=*;actions=read"]}}]}}]}`,
+			want: &Config{
+				Orgs: []*Org{
+					{
+						Name: "payments",
+						Roles: []*Role{
+							{Name: "readers", Grants: []string{"id=*;type=*;actions=read"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "duplicate-org",
+			in:      `org "a" {} org "a" {}`,
+			wantErr: `org "a" is declared more than once`,
+		},
+		{
+			name:    "duplicate-role",
+			in:      `org "a" { role "r" {} role "r" {} }`,
+			wantErr: `role "r" is declared more than once in "a"`,
+		},
+		{
+			name:    "duplicate-project-group",
+			in:      `org "a" { project "p" { group "g" {} group "g" {} } }`,
+			wantErr: `group "g" is declared more than once in "a/p"`,
+		},
+		{
+			name:    "bad-grant",
+			in:      `org "a" { role "r" { grants = ["id=*;actions=nope"] } }`,
+			wantErr: `role "r" in "a" has an invalid grant`,
+		},
+		{
+			name: "org-grant",
+			in:   `org "a" { role "r" { grants = ["id=*;type=user;actions=read"] } }`,
+			want: &Config{Orgs: []*Org{{Name: "a", Roles: []*Role{{Name: "r", Grants: []string{"id=*;type=user;actions=read"}}}}}},
+		},
+		{
+			name:    "project-grant-for-org-type",
+			in:      `org "a" { project "p" { role "r" { grants = ["id=*;type=user;actions=read"] } } }`,
+			wantErr: `role "r" in "a/p" has an invalid grant`,
+		},
+		{
+			name:    "grant-scope-type",
+			in:      `org "a" { role "r" { grant_scope_id = "p_1234567890" grants = ["id=*;type=user;actions=read"] } }`,
+			wantErr: `role "r" in "a" has an invalid grant`,
+		},
+		{
+			name:    "unsupported-action",
+			in:      `org "a" { project "p" { role "r" { grants = ["id=*;type=host;actions=add-hosts"] } } }`,
+			wantErr: `unknown actions add-hosts for type "host"`,
+		},
+		{
+			name:    "undeclared-group",
+			in:      `org "a" { role "r" { principals = ["group:missing"] } }`,
+			wantErr: `role "r" in "a" references undeclared group "group:missing"`,
+		},
+		{
+			name:    "group-in-other-scope",
+			in:      `org "a" { group "g" {} project "p" { role "r" { principals = ["group:g"] } } }`,
+			wantErr: `role "r" in "a/p" references undeclared group "group:g"`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := Parse(tt.in)
+			if tt.wantErr != "" {
+				require.Error(err)
+				assert.Contains(err.Error(), tt.wantErr)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	dir, err := ioutil.TempDir("", "iam-config")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "iam.json")
+	require.NoError(ioutil.WriteFile(path, []byte(`{
+  "org": {
+    "payments": {
+      "description": "Payments org",
+      "role": {
+        "readers": {
+          "grants": ["id=*;type=*;actions=read"]
+        }
+      }
+    }
+  }
+}`), 0600))
+	got, err := LoadFile(path)
+	require.NoError(err)
+	assert.Equal(&Config{
+		Orgs: []*Org{
+			{
+				Name:        "payments",
+				Description: "Payments org",
+				Roles: []*Role{
+					{
+						Name:   "readers",
+						Grants: []string{"id=*;type=*;actions=read"},
+					},
+				},
+			},
+		},
+	}, got)
+
+	_, err = LoadFile(filepath.Join(dir, "missing.json"))
+	assert.Error(err)
+}
@@ -0,0 +1,11 @@
+package config
+
+// fuzzParse is the fuzz target for declarative configuration parsing.
+// Configurations come from files under version control which anyone proposing
+// a change can edit, so parsing and validating them must never panic.
+func fuzzParse(data []byte) int {
+	if _, err := Parse(string(data)); err != nil {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,9 @@
+// +build gofuzz
+
+package config
+
+// Fuzz is the go-fuzz entry point for declarative configuration parsing, see
+// fuzzParse.
+func Fuzz(data []byte) int {
+	return fuzzParse(data)
+}
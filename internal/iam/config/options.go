@@ -0,0 +1,32 @@
+package config
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withPrune bool
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithPrune provides an option to delete named roles and groups which exist
+// in a declared scope but are not declared in the configuration. This
+// includes the roles created by default with a scope unless they are declared.
+// Scopes are never deleted.
+func WithPrune(enable bool) Option {
+	return func(o *options) {
+		o.withPrune = enable
+	}
+}
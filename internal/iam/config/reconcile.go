@@ -0,0 +1,530 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+// ErrPlanDrift is returned by Apply when the database no longer matches the
+// state the plan was made against.
+var ErrPlanDrift = errors.New("database changed since the plan was made")
+
+// ChangeOp is the kind of change the reconciler makes to a resource
+type ChangeOp string
+
+const (
+	CreateOp ChangeOp = "create"
+	UpdateOp ChangeOp = "update"
+	DeleteOp ChangeOp = "delete"
+)
+
+// Change describes a single change needed to make the database match the
+// declarative configuration.
+type Change struct {
+	Op           ChangeOp
+	ResourceType resource.Type
+	// Path is the scope path of the resource, e.g. "payments/production"
+	Path string
+	// Name is the name of the resource, or the name of the role or group
+	// whose grants, principals or members are changing.
+	Name string
+	// Detail describes what changes, e.g. the grants being added.
+	Detail string
+}
+
+// String returns a human readable description of the change.
+func (c Change) String() string {
+	s := fmt.Sprintf("%s %s %q in %q", c.Op, c.ResourceType, c.Name, c.Path)
+	if c.Detail != "" {
+		s = fmt.Sprintf("%s: %s", s, c.Detail)
+	}
+	return s
+}
+
+// Plan is the ordered set of changes needed to reconcile the database with the
+// declarative configuration.
+type Plan struct {
+	Changes []Change
+}
+
+// Empty returns true if the database already matches the configuration.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Fingerprint returns a digest of the plan's changes. Apply uses it to detect
+// that the database changed after the plan was reviewed.
+func (p *Plan) Fingerprint() string {
+	sum := sha256.Sum256([]byte(p.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// String returns one line per change.
+func (p *Plan) String() string {
+	lines := make([]string, 0, len(p.Changes))
+	for _, c := range p.Changes {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Reconciler reconciles the database with a declarative configuration. Scopes
+// are matched by name under their parent, and roles and groups are matched by
+// name within their scope.
+type Reconciler struct {
+	repo  *iam.Repository
+	prune bool
+}
+
+// NewReconciler creates a new Reconciler. Supported options: WithPrune.
+func NewReconciler(repo *iam.Repository, opt ...Option) (*Reconciler, error) {
+	if repo == nil {
+		return nil, errors.New("new reconciler: missing repository")
+	}
+	opts := getOpts(opt...)
+	return &Reconciler{
+		repo:  repo,
+		prune: opts.withPrune,
+	}, nil
+}
+
+// Plan returns the changes that Apply would make without making them.
+func (r *Reconciler) Plan(ctx context.Context, c *Config) (*Plan, error) {
+	if c == nil {
+		return nil, errors.New("plan: missing config")
+	}
+	w := &walker{repo: r.repo, prune: r.prune, dryRun: true, plan: &Plan{}}
+	if err := w.walk(ctx, c); err != nil {
+		return nil, fmt.Errorf("plan: %w", err)
+	}
+	return w.plan, nil
+}
+
+// Apply makes the database match the configuration and returns the changes
+// that were made. The plan must be the result of Plan for the same
+// configuration: the configuration is planned again against the current
+// state of the database and, if the result differs from the reviewed plan,
+// nothing is changed and ErrPlanDrift is returned.
+//
+// Apply is not atomic. Each change is made through the iam repository, which
+// runs every operation in its own transaction and can't join a caller's
+// transaction. If an error is returned, the changes made before the error are
+// returned along with it; planning again shows what is left and a subsequent
+// Apply picks up where this one left off.
+func (r *Reconciler) Apply(ctx context.Context, c *Config, plan *Plan) (*Plan, error) {
+	if c == nil {
+		return nil, errors.New("apply: missing config")
+	}
+	if plan == nil {
+		return nil, errors.New("apply: missing plan")
+	}
+	current, err := r.Plan(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("apply: %w", err)
+	}
+	if current.Fingerprint() != plan.Fingerprint() {
+		return nil, fmt.Errorf("apply: %w", ErrPlanDrift)
+	}
+	w := &walker{repo: r.repo, prune: r.prune, plan: &Plan{}}
+	if err := w.walk(ctx, c); err != nil {
+		return w.plan, fmt.Errorf("apply: %w", err)
+	}
+	return w.plan, nil
+}
+
+type walker struct {
+	repo   *iam.Repository
+	prune  bool
+	dryRun bool
+	plan   *Plan
+}
+
+func (w *walker) record(op ChangeOp, typ resource.Type, path, name, detail string) {
+	w.plan.Changes = append(w.plan.Changes, Change{
+		Op:           op,
+		ResourceType: typ,
+		Path:         path,
+		Name:         name,
+		Detail:       detail,
+	})
+}
+
+func (w *walker) walk(ctx context.Context, c *Config) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	orgs, err := w.repo.ListOrgs(ctx, iam.WithLimit(-1))
+	if err != nil {
+		return err
+	}
+	for _, oc := range c.Orgs {
+		org, err := w.scope(ctx, scope.Global.String(), scope.Global.String(), oc.Name, oc.Description, orgs)
+		if err != nil {
+			return err
+		}
+		if err := w.resources(ctx, org, oc.Name, oc.Roles, oc.Groups); err != nil {
+			return err
+		}
+		var projects []*iam.Scope
+		if org != nil {
+			if projects, err = w.repo.ListProjects(ctx, org.PublicId, iam.WithLimit(-1)); err != nil {
+				return err
+			}
+		}
+		for _, pc := range oc.Projects {
+			var orgId string
+			if org != nil {
+				orgId = org.PublicId
+			}
+			prj, err := w.scope(ctx, orgId, oc.Name, pc.Name, pc.Description, projects)
+			if err != nil {
+				return err
+			}
+			if err := w.resources(ctx, prj, oc.Name+"/"+pc.Name, pc.Roles, pc.Groups); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// scope finds or creates the named scope under parentId. It returns nil when
+// the scope does not exist yet and the walker is only planning.
+func (w *walker) scope(ctx context.Context, parentId, parentPath, name, description string, existing []*iam.Scope) (*iam.Scope, error) {
+	for _, s := range existing {
+		if s.Name != name {
+			continue
+		}
+		if s.Description == description {
+			return s, nil
+		}
+		w.record(UpdateOp, resource.Scope, parentPath, name, "description")
+		if w.dryRun {
+			return s, nil
+		}
+		s = s.Clone()
+		s.Description = description
+		updated, _, err := w.repo.UpdateScope(ctx, s, s.Version, []string{"Description"})
+		return updated, err
+	}
+
+	w.record(CreateOp, resource.Scope, parentPath, name, "")
+	if w.dryRun {
+		return nil, nil
+	}
+	var s *iam.Scope
+	var err error
+	switch parentId {
+	case scope.Global.String():
+		s, err = iam.NewOrg(iam.WithName(name), iam.WithDescription(description))
+	default:
+		s, err = iam.NewProject(parentId, iam.WithName(name), iam.WithDescription(description))
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Only declared roles are created, so the default login role must be
+	// declared in the configuration if it's wanted.
+	return w.repo.CreateScope(ctx, s, "", iam.WithSkipDefaultRoleCreation(true))
+}
+
+// resources reconciles the roles and groups of a scope. s is nil when the
+// scope does not exist yet, in which case everything is a create.
+func (w *walker) resources(ctx context.Context, s *iam.Scope, path string, roles []*Role, groups []*Group) error {
+	var existingGroups []*iam.Group
+	var existingRoles []*iam.Role
+	if s != nil {
+		var err error
+		if existingGroups, err = w.repo.ListGroups(ctx, s.PublicId, iam.WithLimit(-1)); err != nil {
+			return err
+		}
+		if existingRoles, err = w.repo.ListRoles(ctx, s.PublicId, iam.WithLimit(-1)); err != nil {
+			return err
+		}
+	}
+
+	// Groups first, since roles may reference them as principals
+	groupIds := map[string]string{}
+	for _, gc := range groups {
+		g, err := w.group(ctx, s, path, gc, existingGroups)
+		if err != nil {
+			return err
+		}
+		if g != nil {
+			groupIds[gc.Name] = g.PublicId
+		}
+	}
+	for _, rc := range roles {
+		if err := w.role(ctx, s, path, rc, existingRoles, groupIds); err != nil {
+			return err
+		}
+	}
+
+	if !w.prune || s == nil {
+		return nil
+	}
+	declaredRoles := map[string]bool{}
+	for _, rc := range roles {
+		declaredRoles[rc.Name] = true
+	}
+	for _, r := range existingRoles {
+		if r.Name == "" || declaredRoles[r.Name] {
+			continue
+		}
+		w.record(DeleteOp, resource.Role, path, r.Name, "")
+		if !w.dryRun {
+			if _, err := w.repo.DeleteRole(ctx, r.PublicId); err != nil {
+				return err
+			}
+		}
+	}
+	declaredGroups := map[string]bool{}
+	for _, gc := range groups {
+		declaredGroups[gc.Name] = true
+	}
+	for _, g := range existingGroups {
+		if g.Name == "" || declaredGroups[g.Name] {
+			continue
+		}
+		w.record(DeleteOp, resource.Group, path, g.Name, "")
+		if !w.dryRun {
+			if _, err := w.repo.DeleteGroup(ctx, g.PublicId); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *walker) group(ctx context.Context, s *iam.Scope, path string, gc *Group, existing []*iam.Group) (*iam.Group, error) {
+	var g *iam.Group
+	for _, e := range existing {
+		if e.Name == gc.Name {
+			g = e
+			break
+		}
+	}
+	switch {
+	case g == nil:
+		w.record(CreateOp, resource.Group, path, gc.Name, "")
+		if w.dryRun {
+			if len(gc.Members) > 0 {
+				w.record(UpdateOp, resource.Group, path, gc.Name, fmt.Sprintf("add members %s", strings.Join(gc.Members, ", ")))
+			}
+			return nil, nil
+		}
+		n, err := iam.NewGroup(s.PublicId, iam.WithName(gc.Name), iam.WithDescription(gc.Description))
+		if err != nil {
+			return nil, err
+		}
+		if g, err = w.repo.CreateGroup(ctx, n); err != nil {
+			return nil, err
+		}
+	case g.Description != gc.Description:
+		w.record(UpdateOp, resource.Group, path, gc.Name, "description")
+		if !w.dryRun {
+			u := g.Clone()
+			u.Description = gc.Description
+			var err error
+			if g, _, _, err = w.repo.UpdateGroup(ctx, u, g.Version, []string{"Description"}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var current []string
+	if g.PublicId != "" {
+		members, err := w.repo.ListGroupMembers(ctx, g.PublicId, iam.WithLimit(-1))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			current = append(current, m.MemberId)
+		}
+	}
+	added, removed := diff(current, gc.Members)
+	if len(added) == 0 && len(removed) == 0 {
+		return g, nil
+	}
+	w.record(UpdateOp, resource.Group, path, gc.Name, describe("members", added, removed))
+	if w.dryRun {
+		return g, nil
+	}
+	id := g.PublicId
+	g, _, err := w.repo.LookupGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, fmt.Errorf("group %q in %q: %s: %w", gc.Name, path, id, db.ErrRecordNotFound)
+	}
+	members := gc.Members
+	if members == nil {
+		members = []string{}
+	}
+	if _, _, err := w.repo.SetGroupMembers(ctx, g.PublicId, g.Version, members); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (w *walker) role(ctx context.Context, s *iam.Scope, path string, rc *Role, existing []*iam.Role, groupIds map[string]string) error {
+	var r *iam.Role
+	for _, e := range existing {
+		if e.Name == rc.Name {
+			r = e
+			break
+		}
+	}
+	// A role without a grant scope grants in its own scope, so removing
+	// grant_scope_id from the configuration resets it to that. s is nil when
+	// planning the roles of a scope which would be created, so the grant
+	// scope is left empty: the role would be created, and its grant scope
+	// isn't compared.
+	grantScopeId := rc.GrantScopeId
+	if grantScopeId == "" && s != nil {
+		grantScopeId = s.PublicId
+	}
+	switch {
+	case r == nil:
+		w.record(CreateOp, resource.Role, path, rc.Name, "")
+		if w.dryRun {
+			if len(rc.Grants) > 0 {
+				w.record(UpdateOp, resource.Role, path, rc.Name, fmt.Sprintf("add grants %s", strings.Join(rc.Grants, ", ")))
+			}
+			if len(rc.Principals) > 0 {
+				w.record(UpdateOp, resource.Role, path, rc.Name, fmt.Sprintf("add principals %s", strings.Join(rc.Principals, ", ")))
+			}
+			return nil
+		}
+		n, err := iam.NewRole(s.PublicId, iam.WithName(rc.Name), iam.WithDescription(rc.Description), iam.WithGrantScopeId(grantScopeId))
+		if err != nil {
+			return err
+		}
+		if r, err = w.repo.CreateRole(ctx, n); err != nil {
+			return err
+		}
+	case r.Description != rc.Description || r.GrantScopeId != grantScopeId:
+		w.record(UpdateOp, resource.Role, path, rc.Name, "description or grant scope")
+		if !w.dryRun {
+			u := r.Clone()
+			u.Description = rc.Description
+			u.GrantScopeId = grantScopeId
+			var err error
+			if r, _, _, _, err = w.repo.UpdateRole(ctx, u, r.Version, []string{"Description", "GrantScopeId"}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Grants
+	currentGrants, err := w.repo.ListRoleGrants(ctx, r.PublicId, iam.WithLimit(-1))
+	if err != nil {
+		return err
+	}
+	current := make([]string, 0, len(currentGrants))
+	for _, g := range currentGrants {
+		current = append(current, g.CanonicalGrant)
+	}
+	desired := make([]string, 0, len(rc.Grants))
+	for _, g := range rc.Grants {
+		// Use a fake scope, just want to get out a canonical string
+		perm, err := perms.Parse("o_abcd1234", g, perms.WithSkipFinalValidation(true))
+		if err != nil {
+			return fmt.Errorf("role %q in %q: %w", rc.Name, path, err)
+		}
+		desired = append(desired, perm.CanonicalString())
+	}
+	if added, removed := diff(current, desired); len(added) > 0 || len(removed) > 0 {
+		w.record(UpdateOp, resource.Role, path, rc.Name, describe("grants", added, removed))
+		if !w.dryRun {
+			if r, _, _, err = w.repo.LookupRole(ctx, r.PublicId); err != nil {
+				return err
+			}
+			if _, _, _, err := w.repo.SetRoleGrants(ctx, r.PublicId, r.Version, desired); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Principals
+	principals, err := w.repo.ListPrincipalRoles(ctx, r.PublicId, iam.WithLimit(-1))
+	if err != nil {
+		return err
+	}
+	current = make([]string, 0, len(principals))
+	for _, p := range principals {
+		current = append(current, p.PrincipalId)
+	}
+	desired = make([]string, 0, len(rc.Principals))
+	for _, p := range rc.Principals {
+		if strings.HasPrefix(p, GroupPrincipalPrefix) {
+			id, ok := groupIds[strings.TrimPrefix(p, GroupPrincipalPrefix)]
+			if !ok {
+				// The group will only exist after apply; in a plan it is
+				// always a change.
+				id = p
+			}
+			p = id
+		}
+		desired = append(desired, p)
+	}
+	if added, removed := diff(current, desired); len(added) > 0 || len(removed) > 0 {
+		w.record(UpdateOp, resource.Role, path, rc.Name, describe("principals", added, removed))
+		if !w.dryRun {
+			if r, _, _, err = w.repo.LookupRole(ctx, r.PublicId); err != nil {
+				return err
+			}
+			if _, _, err := w.repo.SetPrincipalRoles(ctx, r.PublicId, r.Version, desired); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// diff returns the values in desired but not in current and the values in
+// current but not in desired, both sorted.
+func diff(current, desired []string) (added, removed []string) {
+	cur := make(map[string]bool, len(current))
+	for _, c := range current {
+		cur[c] = true
+	}
+	des := make(map[string]bool, len(desired))
+	for _, d := range desired {
+		des[d] = true
+		if !cur[d] {
+			added = append(added, d)
+		}
+	}
+	for _, c := range current {
+		if !des[c] {
+			removed = append(removed, c)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+func describe(what string, added, removed []string) string {
+	var parts []string
+	if len(added) > 0 {
+		parts = append(parts, fmt.Sprintf("add %s %s", what, strings.Join(added, ", ")))
+	}
+	if len(removed) > 0 {
+		parts = append(parts, fmt.Sprintf("remove %s %s", what, strings.Join(removed, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
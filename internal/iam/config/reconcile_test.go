@@ -0,0 +1,192 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciler_PlanApply(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := iam.TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	org := iam.TestOrg(t, repo)
+	user := iam.TestUser(t, repo, org.PublicId)
+
+	cfg, err := Parse(`
+org "payments" {
+  description = "Payments org"
+  group "engineers" {
+    members = ["` + user.PublicId + `"]
+  }
+  role "engineers" {
+    grants     = ["id=*;type=host-catalog;actions=read,list"]
+    principals = ["group:engineers"]
+  }
+  project "production" {
+    role "readers" {
+      grants     = ["id=*;type=*;actions=read"]
+      principals = ["` + user.PublicId + `"]
+    }
+  }
+}`)
+	require.NoError(err)
+
+	r, err := NewReconciler(repo)
+	require.NoError(err)
+
+	plan, err := r.Plan(ctx, cfg)
+	require.NoError(err)
+	assert.False(plan.Empty())
+	// The roles of scopes which would be created are planned as creates
+	assert.Contains(plan.Changes, Change{Op: CreateOp, ResourceType: resource.Role, Path: "payments", Name: "engineers"})
+	assert.Contains(plan.Changes, Change{Op: CreateOp, ResourceType: resource.Role, Path: "payments/production", Name: "readers"})
+
+	// Planning must not have created anything
+	orgs, err := repo.ListOrgs(ctx)
+	require.NoError(err)
+	for _, o := range orgs {
+		assert.NotEqual("payments", o.Name)
+	}
+
+	applied, err := r.Apply(ctx, cfg, plan)
+	require.NoError(err)
+	require.Len(applied.Changes, len(plan.Changes))
+	for i := range plan.Changes {
+		assert.Equal(plan.Changes[i].Op, applied.Changes[i].Op)
+		assert.Equal(plan.Changes[i].Name, applied.Changes[i].Name)
+	}
+
+	// A second plan must be empty
+	plan, err = r.Plan(ctx, cfg)
+	require.NoError(err)
+	assert.True(plan.Empty(), plan.String())
+
+	// Drift is detected and corrected
+	cfg.Orgs[0].Roles[0].Grants = []string{"id=*;type=host-catalog;actions=read"}
+	plan, err = r.Plan(ctx, cfg)
+	require.NoError(err)
+	require.Len(plan.Changes, 1)
+	assert.Equal(UpdateOp, plan.Changes[0].Op)
+	assert.Equal("engineers", plan.Changes[0].Name)
+
+	_, err = r.Apply(ctx, cfg, plan)
+	require.NoError(err)
+	plan, err = r.Plan(ctx, cfg)
+	require.NoError(err)
+	assert.True(plan.Empty(), plan.String())
+
+	// Pruning removes undeclared named roles in declared scopes, and only
+	// those: the org was created without a default role
+	cfg.Orgs[0].Roles = nil
+	cfg.Orgs[0].Groups[0].Members = nil
+	r, err = NewReconciler(repo, WithPrune(true))
+	require.NoError(err)
+	plan, err = r.Plan(ctx, cfg)
+	require.NoError(err)
+	applied, err = r.Apply(ctx, cfg, plan)
+	require.NoError(err)
+	var deleted []string
+	for _, c := range applied.Changes {
+		if c.Op == DeleteOp {
+			deleted = append(deleted, c.Name)
+		}
+	}
+	assert.Equal([]string{"engineers"}, deleted)
+	plan, err = r.Plan(ctx, cfg)
+	require.NoError(err)
+	assert.True(plan.Empty(), plan.String())
+}
+
+func TestReconciler_ApplyDrift(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := iam.TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	cfg, err := Parse(`
+org "drift" {
+  role "readers" {
+    grants = ["id=*;type=*;actions=read"]
+  }
+  project "app" {}
+}`)
+	require.NoError(err)
+	r, err := NewReconciler(repo)
+	require.NoError(err)
+
+	_, err = r.Apply(ctx, cfg, nil)
+	require.Error(err)
+
+	plan, err := r.Plan(ctx, cfg)
+	require.NoError(err)
+	_, err = r.Apply(ctx, cfg, plan)
+	require.NoError(err)
+
+	orgs, err := repo.ListOrgs(ctx)
+	require.NoError(err)
+	var orgId string
+	for _, o := range orgs {
+		if o.Name == "drift" {
+			orgId = o.PublicId
+		}
+	}
+	require.NotEmpty(orgId)
+	projects, err := repo.ListProjects(ctx, orgId)
+	require.NoError(err)
+	require.Len(projects, 1)
+	roles, err := repo.ListRoles(ctx, orgId)
+	require.NoError(err)
+	require.Len(roles, 1)
+	assert.Equal(orgId, roles[0].GrantScopeId)
+
+	// A grant scope that isn't in the configuration is reset to the role's
+	// own scope
+	u := roles[0].Clone()
+	u.GrantScopeId = projects[0].PublicId
+	_, _, _, _, err = repo.UpdateRole(ctx, u, roles[0].Version, []string{"GrantScopeId"})
+	require.NoError(err)
+	plan, err = r.Plan(ctx, cfg)
+	require.NoError(err)
+	require.Len(plan.Changes, 1)
+	assert.Equal(UpdateOp, plan.Changes[0].Op)
+
+	// A change made after the plan was reviewed fails the apply and leaves
+	// the database alone
+	intruder := iam.TestRole(t, conn, orgId, iam.WithName("intruder"))
+	r, err = NewReconciler(repo, WithPrune(true))
+	require.NoError(err)
+	_, err = r.Apply(ctx, cfg, plan)
+	assert.True(errors.Is(err, ErrPlanDrift), err)
+	found, _, _, err := repo.LookupRole(ctx, intruder.PublicId)
+	require.NoError(err)
+	assert.NotNil(found)
+	roles, err = repo.ListRoles(ctx, orgId)
+	require.NoError(err)
+	for _, role := range roles {
+		if role.PublicId == u.PublicId {
+			assert.Equal(projects[0].PublicId, role.GrantScopeId)
+		}
+	}
+
+	plan, err = r.Plan(ctx, cfg)
+	require.NoError(err)
+	_, err = r.Apply(ctx, cfg, plan)
+	require.NoError(err)
+	roles, err = repo.ListRoles(ctx, orgId)
+	require.NoError(err)
+	require.Len(roles, 1)
+	assert.Equal(orgId, roles[0].GrantScopeId)
+}
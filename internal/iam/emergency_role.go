@@ -0,0 +1,62 @@
+package iam
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// EmergencyActivation is an activation of an emergency role, which is only
+// considered when resolving grants while it's activated. It records the user
+// who activated the role and their justification. The activation lasts until
+// ExpirationTime unless it's reverted earlier; RevertTime is set once it's
+// over.
+type EmergencyActivation struct {
+	Id             int64      `json:"id"`
+	RoleId         string     `json:"role_id"`
+	UserId         string     `json:"user_id"`
+	Justification  string     `json:"justification"`
+	ActivateTime   time.Time  `json:"activate_time"`
+	ExpirationTime time.Time  `json:"expiration_time"`
+	RevertTime     *time.Time `json:"revert_time,omitempty"`
+}
+
+// EmergencyRoleEventType is the type of an EmergencyRoleEvent.
+type EmergencyRoleEventType string
+
+const (
+	// EmergencyRoleActivated is the event of an emergency role being
+	// activated.
+	EmergencyRoleActivated EmergencyRoleEventType = "activated"
+	// EmergencyRoleReverted is the event of an emergency role activation
+	// ending, either because it expired or because it was reverted early.
+	EmergencyRoleReverted EmergencyRoleEventType = "reverted"
+)
+
+// EmergencyRoleEvent is passed to the function given by the
+// WithEmergencyRoleEvents option when an emergency role is activated or its
+// activation ends, so security can be paged.
+type EmergencyRoleEvent struct {
+	Type       EmergencyRoleEventType
+	Activation *EmergencyActivation
+	// RequestUserId is the user whose request caused the event, if any. It's
+	// empty when an activation is reverted because it expired.
+	RequestUserId string
+}
+
+// scanEmergencyActivations scans the rows of emergencyActivationColumns.
+func scanEmergencyActivations(rows *sql.Rows) ([]*EmergencyActivation, error) {
+	defer rows.Close()
+	var activations []*EmergencyActivation
+	for rows.Next() {
+		var a EmergencyActivation
+		if err := rows.Scan(&a.Id, &a.RoleId, &a.UserId, &a.Justification, &a.ActivateTime, &a.ExpirationTime, &a.RevertTime); err != nil {
+			return nil, fmt.Errorf("unable to scan emergency activation: %w", err)
+		}
+		activations = append(activations, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return activations, nil
+}
@@ -0,0 +1,91 @@
+package iam
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/iam/store"
+)
+
+// The fast reads of a repository created WithFastReads run hand-written sql
+// through db.Reader.Query and scan the rows directly, instead of having gorm
+// build the query and reflect over the resource's fields. They're used for the
+// reads done on every request: looking up scopes, the grants of users and the
+// members of groups.
+
+// fastLookupScope looks up a scope without gorm. If the scope is not found, it
+// will return nil, nil.
+func (r *Repository) fastLookupScope(ctx context.Context, withPublicId string) (*Scope, error) {
+	rows, err := r.reader.Query(ctx, fastLookupScope, []interface{}{withPublicId})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	s := allocScope()
+	s.CreateTime, s.UpdateTime = &timestamp.Timestamp{}, &timestamp.Timestamp{}
+	var name, parentId, description sql.NullString
+	if err := rows.Scan(&s.PublicId, s.CreateTime, s.UpdateTime, &name, &s.Type, &parentId, &description, &s.Version); err != nil {
+		return nil, err
+	}
+	s.Name, s.ParentId, s.Description = name.String, parentId.String, description.String
+	return &s, nil
+}
+
+// fastListGroupMembers lists the members of a group without gorm, honoring the
+// WithLimit option or the repo defaultLimit and max page size like list. It
+// doesn't support paging.
+func (r *Repository) fastListGroupMembers(ctx context.Context, withGroupId string, opt ...Option) ([]*GroupMember, error) {
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var pageRequired bool
+	// A zero limit is the db's default limit, which can be over the max too.
+	if r.maxPageSize > 0 && (limit == 0 || limit > r.maxPageSize) {
+		limit, pageRequired = r.maxPageSize, true
+	}
+	// A null limit returns all the members.
+	var limitArg interface{}
+	switch {
+	case limit == 0:
+		limitArg = db.DefaultLimit
+	case pageRequired:
+		limitArg = limit + 1
+	case limit > 0:
+		limitArg = limit
+	}
+	rows, err := r.reader.Query(ctx, fastListGroupMembers, []interface{}{withGroupId, limitArg})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	members := []*GroupMember{}
+	for rows.Next() {
+		m := &GroupMember{
+			GroupMemberView: &store.GroupMemberView{
+				CreateTime: &timestamp.Timestamp{},
+			},
+		}
+		var memberScopeId, groupScopeId, scopedMemberId sql.NullString
+		if err := rows.Scan(m.CreateTime, &m.GroupId, &m.MemberId, &m.Type, &memberScopeId, &groupScopeId, &scopedMemberId); err != nil {
+			return nil, fmt.Errorf("scan group member: %w", err)
+		}
+		m.MemberScopeId, m.GroupScopeId, m.ScopedMemberId = memberScopeId.String, groupScopeId.String, scopedMemberId.String
+		members = append(members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if pageRequired && len(members) > limit {
+		return nil, fmt.Errorf("more than %d results: %w", limit, db.ErrPageRequired)
+	}
+	return members, nil
+}
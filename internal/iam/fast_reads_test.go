@@ -0,0 +1,219 @@
+package iam
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRepository_FastReads(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	fastRepo := TestRepo(t, conn, wrapper, WithFastReads(true))
+	org, proj := TestScopes(t, repo)
+	ctx := context.Background()
+
+	user := TestUser(t, repo, org.PublicId)
+	grp := TestGroup(t, conn, org.PublicId)
+	TestGroupMember(t, conn, grp.PublicId, user.PublicId)
+	other := TestUser(t, repo, org.PublicId)
+	TestGroupMember(t, conn, grp.PublicId, other.PublicId)
+	userRole := TestRole(t, conn, org.PublicId)
+	TestUserRole(t, conn, userRole.PublicId, user.PublicId)
+	TestRoleGrant(t, conn, userRole.PublicId, "id=*;actions=read")
+	groupRole := TestRole(t, conn, proj.PublicId)
+	TestGroupRole(t, conn, groupRole.PublicId, grp.PublicId)
+	TestRoleGrant(t, conn, groupRole.PublicId, "id=*;actions=update")
+
+	t.Run("lookup-scope", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		for _, id := range []string{scope.Global.String(), org.PublicId, proj.PublicId} {
+			want, err := repo.LookupScope(ctx, id)
+			require.NoError(err)
+			got, err := fastRepo.LookupScope(ctx, id)
+			require.NoError(err)
+			assert.True(proto.Equal(want.Scope, got.Scope))
+		}
+		got, err := fastRepo.LookupScope(ctx, "o_doesnotexist")
+		require.NoError(err)
+		assert.Nil(got)
+	})
+	t.Run("grants-for-user", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		for _, id := range []string{user.PublicId, other.PublicId, "u_anon"} {
+			want, err := repo.GrantsForUser(ctx, id)
+			require.NoError(err)
+			got, err := fastRepo.GrantsForUser(ctx, id)
+			require.NoError(err)
+			assert.ElementsMatch(want, got)
+		}
+	})
+	t.Run("list-group-members", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		want, err := repo.ListGroupMembers(ctx, grp.PublicId)
+		require.NoError(err)
+		got, err := fastRepo.ListGroupMembers(ctx, grp.PublicId)
+		require.NoError(err)
+		require.Len(got, 2)
+		assert.ElementsMatch(memberIds(want), memberIds(got))
+		for _, m := range got {
+			assert.Equal(org.PublicId, m.GroupScopeId)
+			assert.NotNil(m.CreateTime)
+		}
+
+		got, err = fastRepo.ListGroupMembers(ctx, grp.PublicId, WithLimit(1))
+		require.NoError(err)
+		assert.Len(got, 1)
+		got, err = fastRepo.ListGroupMembers(ctx, grp.PublicId, WithLimit(-1))
+		require.NoError(err)
+		assert.Len(got, 2)
+	})
+}
+
+func memberIds(members []*GroupMember) []string {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.MemberId)
+	}
+	return ids
+}
+
+// benchmarkFixture is what the fast read benchmarks read: a user in a group,
+// with roles granted to both.
+type benchmarkFixture struct {
+	repo, fastRepo *Repository
+	scopeId        string
+	userId         string
+	groupId        string
+}
+
+// newBenchmarkFixture sets up the fixture with the repository directly, since
+// the test helpers only take a *testing.T.
+func newBenchmarkFixture(b *testing.B) *benchmarkFixture {
+	b.Helper()
+	require := require.New(b)
+	ctx := context.Background()
+
+	cleanup, url, _, err := db.StartDbInDocker("postgres")
+	require.NoError(err)
+	b.Cleanup(func() { cleanup() })
+	_, err = db.InitStore("postgres", cleanup, url)
+	require.NoError(err)
+	conn, err := gorm.Open("postgres", url)
+	require.NoError(err)
+	b.Cleanup(func() { conn.Close() })
+
+	rootKey := make([]byte, 32)
+	_, err = rand.Read(rootKey)
+	require.NoError(err)
+	root := aead.NewWrapper(nil)
+	_, err = root.SetConfig(map[string]string{"key_id": base64.StdEncoding.EncodeToString(rootKey)})
+	require.NoError(err)
+	require.NoError(root.SetAESGCMKeyBytes(rootKey))
+
+	rw := db.New(conn)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	require.NoError(err)
+	kmsCache, err := kms.NewKms(kmsRepo)
+	require.NoError(err)
+	require.NoError(kmsCache.AddExternalWrappers(kms.WithRootWrapper(root)))
+	_, err = kms.CreateKeysTx(ctx, rw, rw, root, rand.Reader, scope.Global.String())
+	require.NoError(err)
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	fastRepo, err := NewRepository(rw, rw, kmsCache, WithFastReads(true))
+	require.NoError(err)
+
+	org, err := NewOrg()
+	require.NoError(err)
+	org, err = repo.CreateScope(ctx, org, "", WithSkipAdminRoleCreation(true), WithSkipDefaultRoleCreation(true))
+	require.NoError(err)
+	u, err := NewUser(org.PublicId)
+	require.NoError(err)
+	u, err = repo.CreateUser(ctx, u)
+	require.NoError(err)
+	g, err := NewGroup(org.PublicId)
+	require.NoError(err)
+	g, err = repo.CreateGroup(ctx, g)
+	require.NoError(err)
+	_, err = repo.AddGroupMembers(ctx, g.PublicId, g.Version, []string{u.PublicId})
+	require.NoError(err)
+	for _, principalId := range []string{u.PublicId, g.PublicId} {
+		r, err := NewRole(org.PublicId)
+		require.NoError(err)
+		r, err = repo.CreateRole(ctx, r)
+		require.NoError(err)
+		_, err = repo.AddPrincipalRoles(ctx, r.PublicId, r.Version, []string{principalId})
+		require.NoError(err)
+		r, _, _, err = repo.LookupRole(ctx, r.PublicId)
+		require.NoError(err)
+		_, err = repo.AddRoleGrants(ctx, r.PublicId, r.Version, []string{"id=*;actions=read", "id=*;type=host-set;actions=update"})
+		require.NoError(err)
+	}
+	return &benchmarkFixture{
+		repo:     repo,
+		fastRepo: fastRepo,
+		scopeId:  org.PublicId,
+		userId:   u.PublicId,
+		groupId:  g.PublicId,
+	}
+}
+
+func BenchmarkRepository_FastReads(b *testing.B) {
+	f := newBenchmarkFixture(b)
+	ctx := context.Background()
+	reads := []struct {
+		name string
+		read func(r *Repository) error
+	}{
+		{
+			name: "LookupScope",
+			read: func(r *Repository) error {
+				_, err := r.LookupScope(ctx, f.scopeId)
+				return err
+			},
+		},
+		{
+			name: "GrantsForUser",
+			read: func(r *Repository) error {
+				_, err := r.GrantsForUser(ctx, f.userId)
+				return err
+			},
+		},
+		{
+			name: "ListGroupMembers",
+			read: func(r *Repository) error {
+				_, err := r.ListGroupMembers(ctx, f.groupId)
+				return err
+			},
+		},
+	}
+	for _, rd := range reads {
+		for _, repo := range []struct {
+			name string
+			repo *Repository
+		}{{"gorm", f.repo}, {"fast", f.fastRepo}} {
+			b.Run(rd.name+"/"+repo.name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if err := rd.read(repo.repo); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
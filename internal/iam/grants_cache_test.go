@@ -0,0 +1,68 @@
+package iam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_GrantsCache(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	cache := perms.NewCache(time.Hour)
+	repo := TestRepo(t, conn, wrapper, WithGrantsCache(cache))
+	org, proj := TestScopes(t, repo)
+	ctx := context.Background()
+
+	user := TestUser(t, repo, org.PublicId)
+	role := TestRole(t, conn, org.PublicId)
+	TestUserRole(t, conn, role.PublicId, user.PublicId)
+
+	grantStrings := func() []string {
+		t.Helper()
+		pairs, err := repo.GrantsForUser(ctx, user.PublicId, WithGrantScopeId(org.PublicId))
+		require.NoError(err)
+		var grants []string
+		for _, p := range pairs {
+			assert.Equal(org.PublicId, p.ScopeId)
+			grants = append(grants, p.Grant)
+		}
+		return grants
+	}
+	assert.Empty(grantStrings())
+	assert.Equal(perms.CacheStats{Misses: 1}, cache.Stats())
+
+	// Written directly, so the cache isn't invalidated
+	TestRoleGrant(t, conn, role.PublicId, "id=*;actions=read")
+	assert.Empty(grantStrings())
+	assert.Equal(perms.CacheStats{Hits: 1, Misses: 1}, cache.Stats())
+
+	// Written through the repository, which invalidates the cache
+	role, _, _, err := repo.LookupRole(ctx, role.PublicId)
+	require.NoError(err)
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, role.Version, []string{"id=*;actions=update"})
+	require.NoError(err)
+	assert.ElementsMatch([]string{"id=*;actions=read", "id=*;actions=update"}, grantStrings())
+	assert.Equal(perms.CacheStats{Hits: 1, Misses: 2}, cache.Stats())
+
+	// Written to a role whose grants apply in another scope, which leaves the
+	// grants cached for the org alone
+	projRole := TestRole(t, conn, proj.PublicId)
+	_, err = repo.AddRoleGrants(ctx, projRole.PublicId, projRole.Version, []string{"id=*;actions=read"})
+	require.NoError(err)
+	assert.ElementsMatch([]string{"id=*;actions=read", "id=*;actions=update"}, grantStrings())
+	assert.Equal(perms.CacheStats{Hits: 2, Misses: 2}, cache.Stats())
+
+	role, _, _, err = repo.LookupRole(ctx, role.PublicId)
+	require.NoError(err)
+	_, err = repo.DeletePrincipalRoles(ctx, role.PublicId, role.Version, []string{user.PublicId})
+	require.NoError(err)
+	assert.Empty(grantStrings())
+}
@@ -45,13 +45,18 @@ func NewGroup(scopeId string, opt ...Option) (*Group, error) {
 }
 
 // Clone creates a clone of the Group.
-func (g *Group) Clone() interface{} {
+func (g *Group) Clone() *Group {
 	cp := proto.Clone(g.Group)
 	return &Group{
 		Group: cp.(*store.Group),
 	}
 }
 
+// cloneResource implements Cloneable.
+func (g *Group) cloneResource() Resource {
+	return g.Clone()
+}
+
 func allocGroup() Group {
 	return Group{
 		Group: &store.Group{},
@@ -67,6 +72,9 @@ func (g *Group) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType,
 	if err := validateScopeForWrite(ctx, r, g, opType, opt...); err != nil {
 		return err
 	}
+	if err := validateLengthsForWrite(g, DefaultLengthLimits(), opType, opt...); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -84,7 +92,11 @@ func (*Group) ResourceType() resource.Type { return resource.Group }
 
 // Actions returns the  available actions for Group
 func (*Group) Actions() map[string]action.Type {
-	return CrudActions()
+	ret := CrudActions()
+	ret[action.AddMembers.String()] = action.AddMembers
+	ret[action.SetMembers.String()] = action.SetMembers
+	ret[action.RemoveMembers.String()] = action.RemoveMembers
+	return ret
 }
 
 // TableName returns the tablename to override the default gorm table name.
@@ -62,8 +62,7 @@ type GroupMemberUser struct {
 	tableName string `gorm:"-"`
 }
 
-// ensure that GroupMember implements the interfaces of: Cloneable, db.VetForWriter
-var _ Cloneable = (*GroupMemberUser)(nil)
+// ensure that GroupMember implements the interfaces of: db.VetForWriter
 var _ db.VetForWriter = (*GroupMemberUser)(nil)
 
 // NewGroupMemberUser creates a new in memory user member of the group. No
@@ -90,7 +89,7 @@ func allocGroupMember() GroupMemberUser {
 }
 
 // Clone creates a clone of the GroupMember
-func (m *GroupMemberUser) Clone() interface{} {
+func (m *GroupMemberUser) Clone() *GroupMemberUser {
 	cp := proto.Clone(m.GroupMemberUser)
 	return &GroupMemberUser{
 		GroupMemberUser: cp.(*store.GroupMemberUser),
@@ -236,11 +236,11 @@ func Test_GroupMemberCreate(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 			w := db.New(conn)
 			if tt.wantDup {
-				gm := tt.args.gm.Clone().(*GroupMemberUser)
+				gm := tt.args.gm.Clone()
 				err := w.Create(context.Background(), gm)
 				require.NoError(err)
 			}
-			gm := tt.args.gm.Clone().(*GroupMemberUser)
+			gm := tt.args.gm.Clone()
 			err := w.Create(context.Background(), gm)
 			if tt.wantErr {
 				require.Error(err)
@@ -274,7 +274,7 @@ func Test_GroupMemberUpdate(t *testing.T) {
 		u := TestUser(t, repo, org.PublicId)
 		u2 := TestUser(t, repo, org.PublicId)
 		gm := TestGroupMember(t, conn, g.PublicId, u.PublicId)
-		updateGrpMember := gm.Clone().(*GroupMemberUser)
+		updateGrpMember := gm.Clone()
 		updateGrpMember.MemberId = u2.PublicId
 		updatedRows, err := rw.Update(context.Background(), updateGrpMember, []string{"MemberId"}, nil)
 		require.Error(err)
@@ -350,7 +350,7 @@ func TestGroupMember_Clone(t *testing.T) {
 		group := TestGroup(t, conn, org.PublicId)
 		gm := TestGroupMember(t, conn, group.PublicId, user.PublicId)
 		cp := gm.Clone()
-		assert.True(proto.Equal(cp.(*GroupMemberUser).GroupMemberUser, gm.GroupMemberUser))
+		assert.True(proto.Equal(cp.GroupMemberUser, gm.GroupMemberUser))
 	})
 	t.Run("not-equal", func(t *testing.T) {
 		assert := assert.New(t)
@@ -359,7 +359,7 @@ func TestGroupMember_Clone(t *testing.T) {
 		gm := TestGroupMember(t, conn, g.PublicId, user.PublicId)
 		gm2 := TestGroupMember(t, conn, g2.PublicId, user.PublicId)
 		cp := gm.Clone()
-		assert.True(!proto.Equal(cp.(*GroupMemberUser).GroupMemberUser, gm2.GroupMemberUser))
+		assert.True(!proto.Equal(cp.GroupMemberUser, gm2.GroupMemberUser))
 	})
 }
 
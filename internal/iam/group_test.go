@@ -180,14 +180,14 @@ func Test_GroupCreate(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 			w := db.New(conn)
 			if tt.wantDup {
-				g := tt.args.group.Clone().(*Group)
+				g := tt.args.group.Clone()
 				grpId, err := newGroupId()
 				require.NoError(err)
 				g.PublicId = grpId
 				err = w.Create(context.Background(), g)
 				require.NoError(err)
 			}
-			g := tt.args.group.Clone().(*Group)
+			g := tt.args.group.Clone()
 			err := w.Create(context.Background(), g)
 			if tt.wantErr {
 				require.Error(err)
@@ -440,6 +440,9 @@ func TestGroup_Actions(t *testing.T) {
 	assert.Equal(a[action.Update.String()], action.Update)
 	assert.Equal(a[action.Read.String()], action.Read)
 	assert.Equal(a[action.Delete.String()], action.Delete)
+	assert.Equal(a[action.AddMembers.String()], action.AddMembers)
+	assert.Equal(a[action.SetMembers.String()], action.SetMembers)
+	assert.Equal(a[action.RemoveMembers.String()], action.RemoveMembers)
 }
 
 func TestGroup_ResourceType(t *testing.T) {
@@ -485,7 +488,7 @@ func TestGroup_Clone(t *testing.T) {
 		assert := assert.New(t)
 		grp := TestGroup(t, conn, org.PublicId)
 		cp := grp.Clone()
-		assert.True(proto.Equal(cp.(*Group).Group, grp.Group))
+		assert.True(proto.Equal(cp.Group, grp.Group))
 	})
 	t.Run("not-equal", func(t *testing.T) {
 		assert := assert.New(t)
@@ -493,7 +496,18 @@ func TestGroup_Clone(t *testing.T) {
 		grp2 := TestGroup(t, conn, org.PublicId)
 
 		cp := grp.Clone()
-		assert.True(!proto.Equal(cp.(*Group).Group, grp2.Group))
+		assert.True(!proto.Equal(cp.Group, grp2.Group))
+	})
+	t.Run("deep-copy", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		grp := TestGroup(t, conn, org.PublicId, WithName("group"))
+		require.NotNil(grp.CreateTime)
+		createSeconds := grp.CreateTime.Timestamp.Seconds
+		cp := grp.Clone()
+		cp.Name = "clone"
+		cp.CreateTime.Timestamp.Seconds++
+		assert.Equal("group", grp.Name)
+		assert.Equal(createSeconds, grp.CreateTime.Timestamp.Seconds)
 	})
 }
 
@@ -12,6 +12,7 @@ const (
 	GroupPrefix     = "g"
 	RolePrefix      = "r"
 	RoleGrantPrefix = "rg"
+	ClaimRulePrefix = "cr"
 )
 
 func newRoleId() (string, error) {
@@ -48,3 +49,11 @@ func newScopeId(scopeType scope.Type) (string, error) {
 	}
 	return id, nil
 }
+
+func newClaimRuleId() (string, error) {
+	id, err := db.NewPublicId(ClaimRulePrefix)
+	if err != nil {
+		return "", fmt.Errorf("new claim rule id: %w", err)
+	}
+	return id, nil
+}
@@ -31,7 +31,7 @@ func TestScope_ImmutableFields(t *testing.T) {
 		{
 			name: "public_id",
 			update: func() *Scope {
-				c := new.Clone().(*Scope)
+				c := new.Clone()
 				c.PublicId = "o_thisIsNotAValidId"
 				return c
 			}(),
@@ -40,7 +40,7 @@ func TestScope_ImmutableFields(t *testing.T) {
 		{
 			name: "create time",
 			update: func() *Scope {
-				c := new.Clone().(*Scope)
+				c := new.Clone()
 				c.CreateTime = &ts
 				return c
 			}(),
@@ -49,7 +49,7 @@ func TestScope_ImmutableFields(t *testing.T) {
 		{
 			name: "type",
 			update: func() *Scope {
-				c := new.Clone().(*Scope)
+				c := new.Clone()
 				c.Type = "project"
 				return c
 			}(),
@@ -58,7 +58,7 @@ func TestScope_ImmutableFields(t *testing.T) {
 		{
 			name: "parent_id",
 			update: func() *Scope {
-				u := new.Clone().(*Scope)
+				u := new.Clone()
 				u.PublicId = "p_thisIsNotAValidId"
 				return u
 			}(),
@@ -81,7 +81,7 @@ func TestScope_ImmutableFields(t *testing.T) {
 			err = w.LookupById(context.Background(), after)
 			require.NoError(err)
 
-			assert.True(proto.Equal(orig.(*Scope), after.(*Scope)))
+			assert.True(proto.Equal(orig, after))
 
 		})
 	}
@@ -163,7 +163,7 @@ func TestUser_ImmutableFields(t *testing.T) {
 		{
 			name: "public_id",
 			update: func() *User {
-				c := new.Clone().(*User)
+				c := new.Clone()
 				c.PublicId = "o_thisIsNotAValidId"
 				return c
 			}(),
@@ -172,7 +172,7 @@ func TestUser_ImmutableFields(t *testing.T) {
 		{
 			name: "create time",
 			update: func() *User {
-				c := new.Clone().(*User)
+				c := new.Clone()
 				c.CreateTime = &ts
 				return c
 			}(),
@@ -181,7 +181,7 @@ func TestUser_ImmutableFields(t *testing.T) {
 		{
 			name: "scope id",
 			update: func() *User {
-				c := new.Clone().(*User)
+				c := new.Clone()
 				c.ScopeId = proj.PublicId
 				return c
 			}(),
@@ -204,7 +204,7 @@ func TestUser_ImmutableFields(t *testing.T) {
 			err = w.LookupById(context.Background(), after)
 			require.NoError(err)
 
-			assert.True(proto.Equal(orig.(*User), after.(*User)))
+			assert.True(proto.Equal(orig, after))
 
 		})
 	}
@@ -230,7 +230,7 @@ func TestRole_ImmutableFields(t *testing.T) {
 		{
 			name: "public_id",
 			update: func() *Role {
-				c := new.Clone().(*Role)
+				c := new.Clone()
 				c.PublicId = "r_thisIsNotAValidId"
 				return c
 			}(),
@@ -239,7 +239,7 @@ func TestRole_ImmutableFields(t *testing.T) {
 		{
 			name: "create time",
 			update: func() *Role {
-				c := new.Clone().(*Role)
+				c := new.Clone()
 				c.CreateTime = &ts
 				return c
 			}(),
@@ -248,7 +248,7 @@ func TestRole_ImmutableFields(t *testing.T) {
 		{
 			name: "scope id",
 			update: func() *Role {
-				c := new.Clone().(*Role)
+				c := new.Clone()
 				c.ScopeId = proj.PublicId
 				return c
 			}(),
@@ -271,7 +271,7 @@ func TestRole_ImmutableFields(t *testing.T) {
 			err = w.LookupById(context.Background(), after)
 			require.NoError(err)
 
-			assert.True(proto.Equal(orig.(*Role), after.(*Role)))
+			assert.True(proto.Equal(orig, after))
 
 		})
 	}
@@ -297,7 +297,7 @@ func TestGroup_ImmutableFields(t *testing.T) {
 		{
 			name: "public_id",
 			update: func() *Group {
-				c := new.Clone().(*Group)
+				c := new.Clone()
 				c.PublicId = "g_thisIsNotAValidId"
 				return c
 			}(),
@@ -306,7 +306,7 @@ func TestGroup_ImmutableFields(t *testing.T) {
 		{
 			name: "create time",
 			update: func() *Group {
-				c := new.Clone().(*Group)
+				c := new.Clone()
 				c.CreateTime = &ts
 				return c
 			}(),
@@ -315,7 +315,7 @@ func TestGroup_ImmutableFields(t *testing.T) {
 		{
 			name: "scope id",
 			update: func() *Group {
-				c := new.Clone().(*Group)
+				c := new.Clone()
 				c.ScopeId = proj.PublicId
 				return c
 			}(),
@@ -338,7 +338,7 @@ func TestGroup_ImmutableFields(t *testing.T) {
 			err = w.LookupById(context.Background(), after)
 			require.NoError(err)
 
-			assert.True(proto.Equal(orig.(*Group), after.(*Group)))
+			assert.True(proto.Equal(orig, after))
 
 		})
 	}
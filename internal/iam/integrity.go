@@ -0,0 +1,386 @@
+package iam
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+	"golang.org/x/crypto/hkdf"
+)
+
+// ErrIntegrity is returned when a role grant or principal role read from the
+// database doesn't have a valid integrity hmac while integrity enforcement is
+// enabled.
+var ErrIntegrity = errors.New("integrity check failed")
+
+// integrityKeyInfo is the HKDF info used to derive integrity keys from
+// database keys, so they differ from any other key derived from them.
+const integrityKeyInfo = "iam-integrity"
+
+// integrityRow is implemented by the rows which carry an integrity hmac.
+type integrityRow interface {
+	// integrityFields returns the column values covered by the hmac.
+	integrityFields() []string
+	getIntegrity() (keyId string, mac []byte)
+	setIntegrity(keyId string, mac []byte)
+}
+
+func (g *RoleGrant) integrityFields() []string {
+	return []string{"role-grant", g.RoleId, g.grantScopeId, g.CanonicalGrant, g.RawGrant, integrityTime(g.NotBefore), integrityTime(g.NotAfter)}
+}
+
+func (g *RoleGrant) getIntegrity() (string, []byte) { return g.IntegrityKeyId, g.IntegrityHmac }
+
+func (g *RoleGrant) setIntegrity(keyId string, mac []byte) {
+	g.IntegrityKeyId, g.IntegrityHmac = keyId, mac
+}
+
+func (r *UserRole) integrityFields() []string {
+	return []string{"user-role", r.RoleId, r.PrincipalId, integrityTime(r.NotBefore), integrityTime(r.NotAfter)}
+}
+
+func (r *UserRole) getIntegrity() (string, []byte) { return r.IntegrityKeyId, r.IntegrityHmac }
+
+func (r *UserRole) setIntegrity(keyId string, mac []byte) {
+	r.IntegrityKeyId, r.IntegrityHmac = keyId, mac
+}
+
+func (r *GroupRole) integrityFields() []string {
+	return []string{"group-role", r.RoleId, r.PrincipalId, integrityTime(r.NotBefore), integrityTime(r.NotAfter)}
+}
+
+func (r *GroupRole) getIntegrity() (string, []byte) { return r.IntegrityKeyId, r.IntegrityHmac }
+
+func (r *GroupRole) setIntegrity(keyId string, mac []byte) {
+	r.IntegrityKeyId, r.IntegrityHmac = keyId, mac
+}
+
+// roleGrantScope returns the grant scope of the role, which the database sets
+// to the role's scope when it's empty. It's covered by the integrity hmacs of
+// the role's grants, so they don't verify if the role is retargeted without
+// going through the controller.
+func roleGrantScope(role *Role) string {
+	if role.GrantScopeId != "" {
+		return role.GrantScopeId
+	}
+	return role.ScopeId
+}
+
+// setGrantScope sets the grant scope of the role grants in rows to the grant
+// scope of the role, so they can be signed or verified.
+func setGrantScope(role *Role, rows ...interface{}) {
+	for _, row := range rows {
+		if g, ok := row.(*RoleGrant); ok {
+			g.grantScopeId = roleGrantScope(role)
+		}
+	}
+}
+
+// integrityTime formats ts for an hmac. Times are truncated to microseconds
+// when they're set, which is what the database stores.
+func integrityTime(ts *timestamp.Timestamp) string {
+	if ts.GetTimestamp() == nil {
+		return ""
+	}
+	return ts.GetTimestamp().AsTime().UTC().Format(time.RFC3339Nano)
+}
+
+// integrityKey derives the integrity key from the database key version with
+// keyId in w, or from its current version if keyId is empty, and returns the
+// id of the version it used.
+func integrityKey(w wrapping.Wrapper, keyId string) (string, []byte, error) {
+	return derivedKey(w, keyId, integrityKeyInfo)
+}
+
+// derivedKey derives a key for the HKDF info from the database key version
+// with keyId in w, or from its current version if keyId is empty, and returns
+// the id of the version it used.
+func derivedKey(w wrapping.Wrapper, keyId, info string) (string, []byte, error) {
+	var aeadWrapper *aead.Wrapper
+	switch w := w.(type) {
+	case *multiwrapper.MultiWrapper:
+		if keyId == "" {
+			keyId = "__base__"
+		}
+		aeadWrapper, _ = w.WrapperForKeyID(keyId).(*aead.Wrapper)
+	case *aead.Wrapper:
+		if keyId == "" || keyId == w.KeyID() {
+			aeadWrapper = w
+		}
+	}
+	if aeadWrapper == nil {
+		return "", nil, fmt.Errorf("no database key %q to derive the %s key from", keyId, info)
+	}
+	key := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, aeadWrapper.GetKeyBytes(), nil, []byte(info)), key); err != nil {
+		return "", nil, fmt.Errorf("unable to derive %s key: %w", info, err)
+	}
+	return aeadWrapper.KeyID(), key, nil
+}
+
+// integrityHmac returns the hmac of the fields. Each field is length prefixed
+// so different fields can't produce the same input.
+func integrityHmac(key []byte, fields []string) []byte {
+	mac := hmac.New(sha256.New, key)
+	var n [8]byte
+	for _, f := range fields {
+		binary.BigEndian.PutUint64(n[:], uint64(len(f)))
+		mac.Write(n[:])
+		mac.Write([]byte(f))
+	}
+	return mac.Sum(nil)
+}
+
+// signIntegrity sets the integrity hmac of each of the rows, which must be
+// role grants or principal roles, with the current database key in w. Role
+// grants must have their grant scope set with setGrantScope.
+func signIntegrity(w wrapping.Wrapper, rows ...interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	keyId, key, err := integrityKey(w, "")
+	if err != nil {
+		return fmt.Errorf("unable to sign: %w", err)
+	}
+	for _, row := range rows {
+		ir, ok := row.(integrityRow)
+		if !ok {
+			return fmt.Errorf("unable to sign %T", row)
+		}
+		if g, ok := row.(*RoleGrant); ok && g.grantScopeId == "" {
+			return fmt.Errorf("unable to sign grant %q of role %s without its grant scope", g.RawGrant, g.RoleId)
+		}
+		ir.setIntegrity(keyId, integrityHmac(key, ir.integrityFields()))
+	}
+	return nil
+}
+
+// databaseWrapper returns the database wrapper of the scope. It's used for
+// integrity hmacs.
+func (r *Repository) databaseWrapper(ctx context.Context, scopeId string, opt ...kms.Option) (wrapping.Wrapper, error) {
+	w, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, opt...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get database wrapper: %w", err)
+	}
+	return w, nil
+}
+
+// verifyIntegrity checks the integrity hmacs of rows from roles in the scope
+// when integrity enforcement is enabled, and returns an error wrapping
+// ErrIntegrity for the first row with a missing or invalid hmac. Role grants
+// must have their grant scope set with setGrantScope.
+func (r *Repository) verifyIntegrity(ctx context.Context, scopeId string, rows ...integrityRow) error {
+	if !r.enforceIntegrity || len(rows) == 0 {
+		return nil
+	}
+	w, err := r.databaseWrapper(ctx, scopeId)
+	if err != nil {
+		return err
+	}
+	keys := map[string][]byte{}
+	for _, row := range rows {
+		keyId, mac := row.getIntegrity()
+		if len(mac) == 0 {
+			return fmt.Errorf("%v has no integrity hmac: %w", row.integrityFields(), ErrIntegrity)
+		}
+		key, ok := keys[keyId]
+		if !ok {
+			if mw, ok := w.(*multiwrapper.MultiWrapper); ok && mw.WrapperForKeyID(keyId) == nil {
+				// The key may have been rotated in since the wrapper was cached
+				if w, err = r.databaseWrapper(ctx, scopeId, kms.WithKeyId(keyId)); err != nil {
+					return err
+				}
+			}
+			if _, key, err = integrityKey(w, keyId); err != nil {
+				return fmt.Errorf("%v: %v: %w", row.integrityFields(), err, ErrIntegrity)
+			}
+			keys[keyId] = key
+		}
+		if !hmac.Equal(mac, integrityHmac(key, row.integrityFields())) {
+			return fmt.Errorf("%v has an invalid integrity hmac: %w", row.integrityFields(), ErrIntegrity)
+		}
+	}
+	return nil
+}
+
+// verifyRolesIntegrity checks the integrity hmacs of the grants and principal
+// roles of each of the roles when integrity enforcement is enabled.
+func (r *Repository) verifyRolesIntegrity(ctx context.Context, roleIds []string) error {
+	if !r.enforceIntegrity || len(roleIds) == 0 {
+		return nil
+	}
+	var roles []*Role
+	if err := r.reader.SearchWhere(ctx, &roles, "public_id in (?)", []interface{}{roleIds}); err != nil {
+		return fmt.Errorf("unable to look up roles to verify: %w", err)
+	}
+	rolesById := make(map[string]*Role, len(roles))
+	scopes := make(map[string]string, len(roles))
+	for _, role := range roles {
+		rolesById[role.PublicId] = role
+		scopes[role.PublicId] = role.ScopeId
+	}
+	rowsByScope := map[string][]integrityRow{}
+	var grants []*RoleGrant
+	if err := r.reader.SearchWhere(ctx, &grants, "role_id in (?)", []interface{}{roleIds}, db.WithLimit(-1)); err != nil {
+		return fmt.Errorf("unable to look up role grants to verify: %w", err)
+	}
+	for _, g := range grants {
+		if role, ok := rolesById[g.RoleId]; ok {
+			setGrantScope(role, g)
+		}
+		rowsByScope[scopes[g.RoleId]] = append(rowsByScope[scopes[g.RoleId]], g)
+	}
+	var userRoles []*UserRole
+	if err := r.reader.SearchWhere(ctx, &userRoles, "role_id in (?)", []interface{}{roleIds}, db.WithLimit(-1)); err != nil {
+		return fmt.Errorf("unable to look up user roles to verify: %w", err)
+	}
+	for _, ur := range userRoles {
+		rowsByScope[scopes[ur.RoleId]] = append(rowsByScope[scopes[ur.RoleId]], ur)
+	}
+	var groupRoles []*GroupRole
+	if err := r.reader.SearchWhere(ctx, &groupRoles, "role_id in (?)", []interface{}{roleIds}, db.WithLimit(-1)); err != nil {
+		return fmt.Errorf("unable to look up group roles to verify: %w", err)
+	}
+	for _, gr := range groupRoles {
+		rowsByScope[scopes[gr.RoleId]] = append(rowsByScope[scopes[gr.RoleId]], gr)
+	}
+	for scopeId, rows := range rowsByScope {
+		if err := r.verifyIntegrity(ctx, scopeId, rows...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateIntegrity writes the integrity hmac of the row, which is the only
+// column of role grants and principal roles the database lets be updated.
+func updateIntegrity(ctx context.Context, w db.Writer, row integrityRow) error {
+	keyId, mac := row.getIntegrity()
+	var rowsUpdated int
+	var err error
+	switch row := row.(type) {
+	case *RoleGrant:
+		rowsUpdated, err = w.Exec(ctx, updateRoleGrantIntegrity, []interface{}{keyId, mac, row.RoleId, row.CanonicalGrant})
+	case *UserRole:
+		rowsUpdated, err = w.Exec(ctx, updateUserRoleIntegrity, []interface{}{keyId, mac, row.RoleId, row.PrincipalId})
+	case *GroupRole:
+		rowsUpdated, err = w.Exec(ctx, updateGroupRoleIntegrity, []interface{}{keyId, mac, row.RoleId, row.PrincipalId})
+	default:
+		return fmt.Errorf("unable to update the integrity hmac of %T", row)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to update integrity hmac: %w", err)
+	}
+	if rowsUpdated != 1 {
+		return fmt.Errorf("updated integrity hmac but %d rows updated", rowsUpdated)
+	}
+	return nil
+}
+
+// resignRoleGrants signs the grants of the role again with w, its scope's
+// database wrapper. It's used when the role's grant scope changes, which the
+// hmacs of its grants cover.
+func resignRoleGrants(ctx context.Context, read db.Reader, w db.Writer, dbWrapper wrapping.Wrapper, role *Role) error {
+	var grants []*RoleGrant
+	if err := read.SearchWhere(ctx, &grants, "role_id = ?", []interface{}{role.PublicId}, db.WithLimit(-1)); err != nil {
+		return fmt.Errorf("unable to look up role grants to sign: %w", err)
+	}
+	for _, g := range grants {
+		setGrantScope(role, g)
+		if err := signIntegrity(dbWrapper, g); err != nil {
+			return err
+		}
+		if err := updateIntegrity(ctx, w, g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SignUnsignedRows signs the role grants and principal roles which have no
+// integrity hmac, such as the ones written before the controller signed them,
+// and returns how many it signed. The controller calls it when it starts
+// without integrity enforcement, since every row must have a valid hmac once
+// enforcement is enabled. Rows written to the database without an hmac while
+// enforcement is disabled are signed too.
+func (r *Repository) SignUnsignedRows(ctx context.Context) (int, error) {
+	var signed int
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(read db.Reader, w db.Writer) error {
+			signed = 0
+			rowsByRole := map[string][]integrityRow{}
+			var grants []*RoleGrant
+			if err := read.SearchWhere(ctx, &grants, "integrity_hmac is null", nil, db.WithLimit(-1)); err != nil {
+				return fmt.Errorf("unable to look up unsigned role grants: %w", err)
+			}
+			for _, g := range grants {
+				rowsByRole[g.RoleId] = append(rowsByRole[g.RoleId], g)
+			}
+			var userRoles []*UserRole
+			if err := read.SearchWhere(ctx, &userRoles, "integrity_hmac is null", nil, db.WithLimit(-1)); err != nil {
+				return fmt.Errorf("unable to look up unsigned user roles: %w", err)
+			}
+			for _, ur := range userRoles {
+				rowsByRole[ur.RoleId] = append(rowsByRole[ur.RoleId], ur)
+			}
+			var groupRoles []*GroupRole
+			if err := read.SearchWhere(ctx, &groupRoles, "integrity_hmac is null", nil, db.WithLimit(-1)); err != nil {
+				return fmt.Errorf("unable to look up unsigned group roles: %w", err)
+			}
+			for _, gr := range groupRoles {
+				rowsByRole[gr.RoleId] = append(rowsByRole[gr.RoleId], gr)
+			}
+			if len(rowsByRole) == 0 {
+				return nil
+			}
+			roleIds := make([]string, 0, len(rowsByRole))
+			for roleId := range rowsByRole {
+				roleIds = append(roleIds, roleId)
+			}
+			var roles []*Role
+			if err := read.SearchWhere(ctx, &roles, "public_id in (?)", []interface{}{roleIds}, db.WithLimit(-1)); err != nil {
+				return fmt.Errorf("unable to look up roles to sign: %w", err)
+			}
+			wrappers := map[string]wrapping.Wrapper{}
+			for _, role := range roles {
+				dbWrapper, ok := wrappers[role.ScopeId]
+				if !ok {
+					var err error
+					if dbWrapper, err = r.databaseWrapper(ctx, role.ScopeId); err != nil {
+						return err
+					}
+					wrappers[role.ScopeId] = dbWrapper
+				}
+				for _, row := range rowsByRole[role.PublicId] {
+					setGrantScope(role, row)
+					if err := signIntegrity(dbWrapper, row); err != nil {
+						return err
+					}
+					if err := updateIntegrity(ctx, w, row); err != nil {
+						return err
+					}
+					signed++
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("sign unsigned rows: %w", err)
+	}
+	return signed, nil
+}
@@ -0,0 +1,125 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_IntegrityEnforcement(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	repo := TestRepo(t, conn, wrapper, WithIntegrityEnforcement(true))
+	org, _ := TestScopes(t, repo)
+
+	t.Run("signed", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		user := TestUser(t, repo, org.PublicId)
+		role := TestRole(t, conn, org.PublicId)
+		grants, err := repo.AddRoleGrants(ctx, role.PublicId, 1, []string{"id=*;type=*;actions=read"})
+		require.NoError(err)
+		require.Len(grants, 1)
+		assert.NotEmpty(grants[0].IntegrityKeyId)
+		assert.NotEmpty(grants[0].IntegrityHmac)
+		_, err = repo.AddPrincipalRoles(ctx, role.PublicId, 2, []string{user.PublicId})
+		require.NoError(err)
+
+		_, err = repo.ListRoleGrants(ctx, role.PublicId)
+		assert.NoError(err)
+		_, err = repo.GrantsForUser(ctx, user.PublicId)
+		assert.NoError(err)
+	})
+	t.Run("unsigned-grant", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		user := TestUser(t, repo, org.PublicId)
+		role := TestRole(t, conn, org.PublicId)
+		_, err := repo.AddPrincipalRoles(ctx, role.PublicId, 1, []string{user.PublicId})
+		require.NoError(err)
+		// Written without going through the repository, so it isn't signed
+		TestRoleGrant(t, conn, role.PublicId, "id=*;type=*;actions=*")
+
+		_, err = repo.ListRoleGrants(ctx, role.PublicId)
+		assert.True(errors.Is(err, ErrIntegrity))
+		_, err = repo.GrantsForUser(ctx, user.PublicId)
+		assert.True(errors.Is(err, ErrIntegrity))
+
+		unenforced := TestRepo(t, conn, wrapper)
+		_, err = unenforced.GrantsForUser(ctx, user.PublicId)
+		assert.NoError(err)
+	})
+	t.Run("unsigned-principal", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		user := TestUser(t, repo, org.PublicId)
+		role := TestRole(t, conn, org.PublicId)
+		_, err := repo.AddRoleGrants(ctx, role.PublicId, 1, []string{"id=*;type=*;actions=*"})
+		require.NoError(err)
+		TestUserRole(t, conn, role.PublicId, user.PublicId)
+
+		_, err = repo.GrantsForUser(ctx, user.PublicId)
+		assert.True(errors.Is(err, ErrIntegrity))
+	})
+	t.Run("invalid-hmac", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, org.PublicId)
+		grants, err := repo.AddRoleGrants(ctx, role.PublicId, 1, []string{"id=*;type=*;actions=read"})
+		require.NoError(err)
+
+		// A valid hmac copied onto a different grant doesn't verify
+		forged, err := NewRoleGrant(role.PublicId, "id=*;type=*;actions=*")
+		require.NoError(err)
+		forged.IntegrityKeyId = grants[0].IntegrityKeyId
+		forged.IntegrityHmac = grants[0].IntegrityHmac
+		require.NoError(db.New(conn).Create(ctx, forged))
+
+		_, err = repo.ListRoleGrants(ctx, role.PublicId)
+		assert.True(errors.Is(err, ErrIntegrity))
+	})
+	t.Run("retargeted-role", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		org, proj := TestScopes(t, repo)
+		role := TestRole(t, conn, org.PublicId)
+		_, err := repo.AddRoleGrants(ctx, role.PublicId, 1, []string{"id=*;type=*;actions=*"})
+		require.NoError(err)
+
+		// Retargeting the role without going through the repository
+		// invalidates its grants
+		_, err = db.New(conn).Exec(ctx, "update iam_role set grant_scope_id = $1 where public_id = $2", []interface{}{proj.PublicId, role.PublicId})
+		require.NoError(err)
+		_, err = repo.ListRoleGrants(ctx, role.PublicId)
+		assert.True(errors.Is(err, ErrIntegrity))
+
+		// Retargeting it through the repository signs them again
+		updated := allocRole()
+		updated.PublicId = role.PublicId
+		require.NoError(db.New(conn).LookupByPublicId(ctx, &updated))
+		updated.GrantScopeId = proj.PublicId
+		_, _, _, _, err = repo.UpdateRole(ctx, &updated, updated.Version, []string{"GrantScopeId"})
+		require.NoError(err)
+		_, err = repo.ListRoleGrants(ctx, role.PublicId)
+		assert.NoError(err)
+	})
+	t.Run("sign-unsigned", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, org.PublicId)
+		TestRoleGrant(t, conn, role.PublicId, "id=*;type=*;actions=read")
+		_, err := repo.ListRoleGrants(ctx, role.PublicId)
+		assert.True(errors.Is(err, ErrIntegrity))
+
+		unenforced := TestRepo(t, conn, wrapper)
+		signed, err := unenforced.SignUnsignedRows(ctx)
+		require.NoError(err)
+		assert.GreaterOrEqual(signed, 1)
+		_, err = repo.ListRoleGrants(ctx, role.PublicId)
+		assert.NoError(err)
+
+		signed, err = unenforced.SignUnsignedRows(ctx)
+		require.NoError(err)
+		assert.Equal(0, signed)
+	})
+}
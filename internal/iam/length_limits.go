@@ -0,0 +1,125 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+const (
+	// DefaultMaxNameLength, DefaultMaxDescriptionLength and
+	// DefaultMaxGrantLength are the default LengthLimits. They match the
+	// triggers on the iam tables, so configured limits can't be longer.
+	DefaultMaxNameLength        = 128
+	DefaultMaxDescriptionLength = 1024
+	DefaultMaxGrantLength       = 1024
+)
+
+// LengthLimits are the maximum lengths, in characters, of the names and
+// descriptions of scopes, users, groups and roles, and of role grants. Zero
+// means the default.
+type LengthLimits struct {
+	MaxNameLength        int
+	MaxDescriptionLength int
+	MaxGrantLength       int
+}
+
+// DefaultLengthLimits returns the default LengthLimits.
+func DefaultLengthLimits() LengthLimits {
+	return LengthLimits{
+		MaxNameLength:        DefaultMaxNameLength,
+		MaxDescriptionLength: DefaultMaxDescriptionLength,
+		MaxGrantLength:       DefaultMaxGrantLength,
+	}
+}
+
+// withDefaults returns the limits with zero limits set to the defaults, or an
+// error if a limit is negative or longer than its default.
+func (l LengthLimits) withDefaults() (LengthLimits, error) {
+	d := DefaultLengthLimits()
+	for _, f := range []struct {
+		name  string
+		limit *int
+		max   int
+	}{
+		{"max_name_length", &l.MaxNameLength, d.MaxNameLength},
+		{"max_description_length", &l.MaxDescriptionLength, d.MaxDescriptionLength},
+		{"max_grant_length", &l.MaxGrantLength, d.MaxGrantLength},
+	} {
+		switch {
+		case *f.limit < 0:
+			return l, fmt.Errorf("%s is negative", f.name)
+		case *f.limit > f.max:
+			return l, fmt.Errorf("%s of %d is longer than the maximum of %d", f.name, *f.limit, f.max)
+		case *f.limit == 0:
+			*f.limit = f.max
+		}
+	}
+	return l, nil
+}
+
+// validateLengthsForWrite returns an error if the name or description of the
+// resource are longer than the limits. On updates, only the fields in the
+// field mask are checked, so existing values written before the limits can be
+// kept.
+func validateLengthsForWrite(resource Resource, limits LengthLimits, opType db.OpType, opt ...db.Option) error {
+	checkName, checkDescription := true, true
+	if opType == db.UpdateOp {
+		fieldMask := db.GetOpts(opt...).WithFieldMaskPaths
+		checkName = contains(fieldMask, "Name")
+		checkDescription = contains(fieldMask, "Description")
+	}
+	if checkName {
+		if n := utf8.RuneCountInString(resource.GetName()); n > limits.MaxNameLength {
+			return fmt.Errorf("name is %d characters, longer than %d: %w", n, limits.MaxNameLength, db.ErrInvalidParameter)
+		}
+	}
+	if checkDescription {
+		if n := utf8.RuneCountInString(resource.GetDescription()); n > limits.MaxDescriptionLength {
+			return fmt.Errorf("description is %d characters, longer than %d: %w", n, limits.MaxDescriptionLength, db.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+// validateGrantLengths returns an error if a grant is longer than the limits.
+func validateGrantLengths(grants []string, limits LengthLimits) error {
+	for _, g := range grants {
+		if n := utf8.RuneCountInString(g); n > limits.MaxGrantLength {
+			return fmt.Errorf("grant is %d characters, longer than %d: %w", n, limits.MaxGrantLength, db.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+// LengthLimitViolation is an existing value, written before the length
+// limits were enforced, which is longer than the default limits. For grants,
+// the PublicId is the role's.
+type LengthLimitViolation struct {
+	TableName  string
+	PublicId   string
+	ColumnName string
+	Length     int
+}
+
+// ListLengthLimitViolations returns the existing names, descriptions and
+// grants which are longer than the default limits. They can still be read,
+// but must be shortened when they're updated.
+func (r *Repository) ListLengthLimitViolations(ctx context.Context, opt ...Option) ([]*LengthLimitViolation, error) {
+	rows, err := r.reader.Query(ctx, listLengthLimitViolations, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list length limit violations: %w", err)
+	}
+	defer rows.Close()
+	var violations []*LengthLimitViolation
+	for rows.Next() {
+		var v LengthLimitViolation
+		if err := rows.Scan(&v.TableName, &v.PublicId, &v.ColumnName, &v.Length); err != nil {
+			return nil, fmt.Errorf("list length limit violations: %w", err)
+		}
+		violations = append(violations, &v)
+	}
+	return violations, nil
+}
@@ -0,0 +1,93 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLengthLimits_withDefaults(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	l, err := LengthLimits{}.withDefaults()
+	require.NoError(err)
+	assert.Equal(DefaultLengthLimits(), l)
+	l, err = LengthLimits{MaxNameLength: 64}.withDefaults()
+	require.NoError(err)
+	assert.Equal(64, l.MaxNameLength)
+	assert.Equal(DefaultMaxDescriptionLength, l.MaxDescriptionLength)
+	_, err = LengthLimits{MaxGrantLength: DefaultMaxGrantLength + 1}.withDefaults()
+	assert.Error(err)
+	_, err = LengthLimits{MaxDescriptionLength: -1}.withDefaults()
+	assert.Error(err)
+}
+
+func TestRepository_LengthLimits(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	repo := TestRepo(t, conn, wrapper)
+	_, proj := TestScopes(t, repo)
+
+	t.Run("default", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role, err := NewRole(proj.PublicId, WithName(strings.Repeat("n", DefaultMaxNameLength+1)))
+		require.NoError(err)
+		_, err = repo.CreateRole(ctx, role)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+
+		// The database enforces the defaults too
+		role.PublicId, err = newRoleId()
+		require.NoError(err)
+		assert.Error(db.New(conn).Create(ctx, role, db.WithSkipVetForWrite(true)))
+	})
+	t.Run("configured", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		limited, err := NewRepository(db.New(conn), db.New(conn), repo.kms, WithLengthLimits(LengthLimits{MaxNameLength: 8, MaxGrantLength: 24}))
+		require.NoError(err)
+		role := TestRole(t, conn, proj.PublicId)
+		role.Name = "too-long-name"
+		_, _, _, _, err = limited.UpdateRole(ctx, role, role.Version, []string{"Name"})
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		role.Description = "only the description is updated"
+		_, _, _, _, err = limited.UpdateRole(ctx, role, role.Version, []string{"Description"})
+		assert.NoError(err)
+
+		_, err = limited.AddRoleGrants(ctx, role.PublicId, 2, []string{"id=*;type=*;actions=read,update"})
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, err = limited.AddRoleGrants(ctx, role.PublicId, 2, []string{"id=*;type=*;actions=read"})
+		assert.NoError(err)
+
+		_, err = NewRepository(db.New(conn), db.New(conn), repo.kms, WithLengthLimits(LengthLimits{MaxNameLength: DefaultMaxNameLength + 1}))
+		assert.Error(err)
+	})
+	t.Run("violations", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, proj.PublicId)
+		// Simulate a value written before the limits were enforced
+		require.NoError(conn.Exec("alter table iam_role disable trigger iam_length_limits").Error)
+		err := conn.Exec("update iam_role set description = ? where public_id = ?", strings.Repeat("d", DefaultMaxDescriptionLength+1), role.PublicId).Error
+		require.NoError(conn.Exec("alter table iam_role enable trigger iam_length_limits").Error)
+		require.NoError(err)
+
+		violations, err := repo.ListLengthLimitViolations(ctx)
+		require.NoError(err)
+		assert.Contains(violations, &LengthLimitViolation{
+			TableName:  "iam_role",
+			PublicId:   role.PublicId,
+			ColumnName: "description",
+			Length:     DefaultMaxDescriptionLength + 1,
+		})
+
+		// The role can still be changed as long as the description isn't
+		found, _, _, err := repo.LookupRole(ctx, role.PublicId)
+		require.NoError(err)
+		_, err = repo.AddRoleGrants(ctx, role.PublicId, found.Version, []string{"id=*;type=*;actions=read"})
+		assert.NoError(err)
+	})
+}
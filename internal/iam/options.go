@@ -1,6 +1,13 @@
 package iam
 
-import "io"
+import (
+	"io"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/boundary/internal/clock"
+	"github.com/hashicorp/boundary/internal/perms"
+)
 
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
@@ -29,6 +36,37 @@ type options struct {
 	withSkipDefaultRoleCreation bool
 	withUserId                  string
 	withRandomReader            io.Reader
+	withDryRun                  bool
+	withRecursive               bool
+	withListedScopes            map[string]*Scope
+	withNotBefore               time.Time
+	withNotAfter                time.Time
+	withPageToken               string
+	withNextPageToken           *string
+	withTagFilter               map[string]string
+	withReadOnlyRoleCreation    bool
+	withClock                   clock.Clock
+	withWatchInterval           time.Duration
+	withAfterEntryId            *uint32
+	withQuotas                  Quotas
+	withIntegrityEnforcement    bool
+	withUnverifiedSnapshot      bool
+	withAccessReport            bool
+	withIncludedRoles           bool
+	withLengthLimits            LengthLimits
+	withFastReads               bool
+	withGrantsCache             *perms.Cache
+	withQuotaAlerts             func(*QuotaAlert)
+	withMetrics                 *metrics.Metrics
+	withIdempotencyKey          string
+	withMaxPageSize             int
+	withRoleDiff                *RoleDiff
+	withRoleAudit               func(*RoleDiff)
+	withExpirationTime          time.Time
+	withArchived                bool
+	withEmergencyRoleEvents     func(*EmergencyRoleEvent)
+	withSnapshotKeys            []*SnapshotKey
+	withSnapshotSigner          string
 }
 
 func getDefaultOptions() options {
@@ -90,7 +128,8 @@ func WithAutoVivify(enable bool) Option {
 }
 
 // WithGrantScopeId provides an option to specify the scope ID for grants in
-// roles.
+// roles. GrantsForUser uses it to return only the grants which apply in the
+// scope.
 func WithGrantScopeId(id string) Option {
 	return func(o *options) {
 		o.withGrantScopeId = id
@@ -142,3 +181,275 @@ func WithRandomReader(reader io.Reader) Option {
 		o.withRandomReader = reader
 	}
 }
+
+// WithDryRun provides an option to compute the effects of an operation
+// without applying them. Writes made with it run all of their validation,
+// uniqueness and quota checks in a transaction which is then rolled back, and
+// return what they would have written.
+func WithDryRun(enable bool) Option {
+	return func(o *options) {
+		o.withDryRun = enable
+	}
+}
+
+// WithRecursive provides an option to include resources in the child scopes of
+// the given scope when listing. With hard org isolation enabled, only the org of
+// the user in the context's request info is included.
+func WithRecursive(enable bool) Option {
+	return func(o *options) {
+		o.withRecursive = enable
+	}
+}
+
+// WithListedScopes provides an option to get the scopes of the roles, groups
+// or users a listing returns, such as one made with WithRecursive, which
+// includes resources from many scopes. Each resource's scope is added to
+// scopes by its id.
+func WithListedScopes(scopes map[string]*Scope) Option {
+	return func(o *options) {
+		o.withListedScopes = scopes
+	}
+}
+
+// WithNotBefore provides an option to set the time a role grant or principal
+// role starts applying.
+func WithNotBefore(t time.Time) Option {
+	return func(o *options) {
+		o.withNotBefore = t
+	}
+}
+
+// WithNotAfter provides an option to set the time a role grant or principal
+// role stops applying.
+func WithNotAfter(t time.Time) Option {
+	return func(o *options) {
+		o.withNotAfter = t
+	}
+}
+
+// WithPageToken provides an option to list the page of resources after the
+// position in the token, which was returned by a previous listing through the
+// WithNextPageToken option.
+func WithPageToken(token string) Option {
+	return func(o *options) {
+		o.withPageToken = token
+	}
+}
+
+// WithNextPageToken provides an option to paginate a listing. The token for
+// the page after the one returned is set in next, or next is set to "" if
+// there are no more resources to list.
+func WithNextPageToken(next *string) Option {
+	return func(o *options) {
+		o.withNextPageToken = next
+	}
+}
+
+// WithTagFilter provides an option to list only the roles or groups which have
+// every one of the tags.
+func WithTagFilter(tags map[string]string) Option {
+	return func(o *options) {
+		o.withTagFilter = tags
+	}
+}
+
+// WithReadOnlyRoleCreation provides an option to create a read-only role, with
+// no principals, when creating a scope.
+func WithReadOnlyRoleCreation(enable bool) Option {
+	return func(o *options) {
+		o.withReadOnlyRoleCreation = enable
+	}
+}
+
+// WithClock provides an option for a repository to take the current time from
+// c instead of from the system.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.withClock = c
+	}
+}
+
+// WithWatchInterval provides an option to set how often a Watcher checks the
+// oplog for changes.
+func WithWatchInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.withWatchInterval = d
+	}
+}
+
+// WithAfterEntryId provides an option for a Watcher to report the changes
+// written after the oplog entry with the id.
+func WithAfterEntryId(id uint32) Option {
+	return func(o *options) {
+		o.withAfterEntryId = &id
+	}
+}
+
+// WithQuotas provides an option to limit how many roles, grants and group
+// members a repository writes.
+func WithQuotas(q Quotas) Option {
+	return func(o *options) {
+		o.withQuotas = q
+	}
+}
+
+// WithIntegrityEnforcement provides an option for a repository to verify the
+// integrity hmacs of the role grants and principal roles it reads, and fail
+// with ErrIntegrity when one isn't valid.
+func WithIntegrityEnforcement(enable bool) Option {
+	return func(o *options) {
+		o.withIntegrityEnforcement = enable
+	}
+}
+
+// WithUnverifiedSnapshot provides an option to import a snapshot whose
+// signature is missing or doesn't verify, like one exported by a cluster
+// without snapshot keys.
+func WithUnverifiedSnapshot(enable bool) Option {
+	return func(o *options) {
+		o.withUnverifiedSnapshot = enable
+	}
+}
+
+// WithAccessReport provides an option to report the access a user held when
+// its state is changed.
+func WithAccessReport(enable bool) Option {
+	return func(o *options) {
+		o.withAccessReport = enable
+	}
+}
+
+// WithIncludedRoles provides an option to also list the grants of the roles a
+// role includes, transitively.
+func WithIncludedRoles(enable bool) Option {
+	return func(o *options) {
+		o.withIncludedRoles = enable
+	}
+}
+
+// WithLengthLimits provides an option to set the maximum lengths of names,
+// descriptions and grants written by a repository. They can't be longer than
+// the defaults.
+func WithLengthLimits(l LengthLimits) Option {
+	return func(o *options) {
+		o.withLengthLimits = l
+	}
+}
+
+// WithFastReads provides an option for a repository to look up scopes, the
+// grants of users and the members of groups with hand-written sql instead of
+// gorm, avoiding gorm's reflection on these hot read paths.
+func WithFastReads(enable bool) Option {
+	return func(o *options) {
+		o.withFastReads = enable
+	}
+}
+
+// WithGrantsCache provides an option for a repository to look up the grants of
+// users through a cache, and to invalidate it after writes which change them.
+func WithGrantsCache(c *perms.Cache) Option {
+	return func(o *options) {
+		o.withGrantsCache = c
+	}
+}
+
+// WithQuotaAlerts provides an option for a repository to call fn with a
+// QuotaAlert whenever a write takes a resource past one of the
+// QuotaAlertThresholds of a quota.
+func WithQuotaAlerts(fn func(*QuotaAlert)) Option {
+	return func(o *options) {
+		o.withQuotaAlerts = fn
+	}
+}
+
+// WithMetrics sets the go-metrics instance a repository emits the usage of
+// its quotas to instead of the global one.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(o *options) {
+		o.withMetrics = m
+	}
+}
+
+// WithIdempotencyKey provides an option to make a write safe to retry. A write
+// made again with the same key returns the result of the first instead of
+// writing again, and one made with the same key for a different request
+// returns ErrIdempotencyKeyReused. Keys are remembered for
+// IdempotencyKeyLifetime. Supported by CreateRole and AddRoleGrants.
+func WithIdempotencyKey(key string) Option {
+	return func(o *options) {
+		o.withIdempotencyKey = key
+	}
+}
+
+// WithMaxPageSize provides an option to set the maximum number of resources a
+// repository returns from a listing. A listing with more must be requested a
+// page at a time with the WithNextPageToken option, or it fails with
+// db.ErrPageRequired. WithLimit(-1) is exempt. Zero means no maximum.
+func WithMaxPageSize(n int) Option {
+	return func(o *options) {
+		o.withMaxPageSize = n
+	}
+}
+
+// WithRoleDiff provides an option to get the changes a write made to a role.
+// The diff is written to d. Supported by UpdateRole, SetRoleGrants,
+// AddRoleGrants, DeleteRoleGrants, SetPrincipalRoles, AddPrincipalRoles and
+// DeletePrincipalRoles; with WithDryRun, it's the changes UpdateRole or
+// SetRoleGrants would make.
+func WithRoleDiff(d *RoleDiff) Option {
+	return func(o *options) {
+		o.withRoleDiff = d
+	}
+}
+
+// WithRoleAudit provides an option for a repository to call fn with the
+// changes whenever UpdateRole, or a write to a role's grants or principals,
+// changes a role, so they can be written to an audit sink.
+func WithRoleAudit(fn func(*RoleDiff)) Option {
+	return func(o *options) {
+		o.withRoleAudit = fn
+	}
+}
+
+// WithExpirationTime provides an option to set the time a role expires, after
+// which it's no longer considered when resolving grants and is archived.
+// Supported by NewRole and RestoreRole.
+func WithExpirationTime(t time.Time) Option {
+	return func(o *options) {
+		o.withExpirationTime = t
+	}
+}
+
+// WithArchived provides an option to include archived roles when listing
+// roles.
+func WithArchived(archived bool) Option {
+	return func(o *options) {
+		o.withArchived = archived
+	}
+}
+
+// WithEmergencyRoleEvents provides an option for a repository to call fn
+// whenever an emergency role is activated or its activation ends, so security
+// can be paged.
+func WithEmergencyRoleEvents(fn func(*EmergencyRoleEvent)) Option {
+	return func(o *options) {
+		o.withEmergencyRoleEvents = fn
+	}
+}
+
+// WithSnapshotKeys provides an option for a repository to sign the snapshots
+// it exports with the first of the keys, and to verify the snapshots it
+// imports with any of them.
+func WithSnapshotKeys(keys ...*SnapshotKey) Option {
+	return func(o *options) {
+		o.withSnapshotKeys = keys
+	}
+}
+
+// WithSnapshotSigner provides an option to name the signer of the snapshots
+// a repository exports, like the name of its cluster.
+func WithSnapshotSigner(signer string) Option {
+	return func(o *options) {
+		o.withSnapshotSigner = signer
+	}
+}
@@ -2,7 +2,11 @@ package iam
 
 import (
 	"testing"
+	"time"
 
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/boundary/internal/clock"
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -74,4 +78,248 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withDisassociate = true
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithDryRun", func(t *testing.T) {
+		assert := assert.New(t)
+		// test default of false
+		opts := getOpts()
+		testOpts := getDefaultOptions()
+		testOpts.withDryRun = false
+		assert.Equal(opts, testOpts)
+
+		opts = getOpts(WithDryRun(true))
+		testOpts = getDefaultOptions()
+		testOpts.withDryRun = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithRecursive", func(t *testing.T) {
+		assert := assert.New(t)
+		// test default of false
+		opts := getOpts()
+		testOpts := getDefaultOptions()
+		testOpts.withRecursive = false
+		assert.Equal(opts, testOpts)
+
+		opts = getOpts(WithRecursive(true))
+		testOpts = getDefaultOptions()
+		testOpts.withRecursive = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithListedScopes", func(t *testing.T) {
+		assert := assert.New(t)
+		scopes := map[string]*Scope{}
+		opts := getOpts(WithListedScopes(scopes))
+		testOpts := getDefaultOptions()
+		testOpts.withListedScopes = scopes
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithNotBefore", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithNotBefore(now))
+		testOpts := getDefaultOptions()
+		testOpts.withNotBefore = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithNotAfter", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithNotAfter(now))
+		testOpts := getDefaultOptions()
+		testOpts.withNotAfter = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithPageToken", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithPageToken("token"))
+		testOpts := getDefaultOptions()
+		testOpts.withPageToken = "token"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithNextPageToken", func(t *testing.T) {
+		assert := assert.New(t)
+		var next string
+		opts := getOpts(WithNextPageToken(&next))
+		testOpts := getDefaultOptions()
+		testOpts.withNextPageToken = &next
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithTagFilter", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithTagFilter(map[string]string{"team": "eng"}))
+		testOpts := getDefaultOptions()
+		testOpts.withTagFilter = map[string]string{"team": "eng"}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithReadOnlyRoleCreation", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithReadOnlyRoleCreation(true))
+		testOpts := getDefaultOptions()
+		testOpts.withReadOnlyRoleCreation = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithClock", func(t *testing.T) {
+		assert := assert.New(t)
+		c := clock.NewFake(time.Now())
+		opts := getOpts(WithClock(c))
+		testOpts := getDefaultOptions()
+		testOpts.withClock = c
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithWatchInterval", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithWatchInterval(time.Millisecond))
+		testOpts := getDefaultOptions()
+		testOpts.withWatchInterval = time.Millisecond
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithAfterEntryId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAfterEntryId(42))
+		testOpts := getDefaultOptions()
+		id := uint32(42)
+		testOpts.withAfterEntryId = &id
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithQuotas", func(t *testing.T) {
+		assert := assert.New(t)
+		q := Quotas{MaxRolesPerScope: 1, MaxGrantsPerRole: 2, MaxMembersPerGroup: 3}
+		opts := getOpts(WithQuotas(q))
+		testOpts := getDefaultOptions()
+		testOpts.withQuotas = q
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithIntegrityEnforcement", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithIntegrityEnforcement(true))
+		testOpts := getDefaultOptions()
+		testOpts.withIntegrityEnforcement = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithUnverifiedSnapshot", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithUnverifiedSnapshot(true))
+		testOpts := getDefaultOptions()
+		testOpts.withUnverifiedSnapshot = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithAccessReport", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAccessReport(true))
+		testOpts := getDefaultOptions()
+		testOpts.withAccessReport = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithIncludedRoles", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithIncludedRoles(true))
+		testOpts := getDefaultOptions()
+		testOpts.withIncludedRoles = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithLengthLimits", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithLengthLimits(LengthLimits{MaxNameLength: 64}))
+		testOpts := getDefaultOptions()
+		testOpts.withLengthLimits = LengthLimits{MaxNameLength: 64}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithFastReads", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithFastReads(true))
+		testOpts := getDefaultOptions()
+		testOpts.withFastReads = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithGrantsCache", func(t *testing.T) {
+		assert := assert.New(t)
+		c := perms.NewCache(0)
+		opts := getOpts(WithGrantsCache(c))
+		testOpts := getDefaultOptions()
+		testOpts.withGrantsCache = c
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithQuotaAlerts", func(t *testing.T) {
+		assert := assert.New(t)
+		var called bool
+		opts := getOpts(WithQuotaAlerts(func(*QuotaAlert) { called = true }))
+		assert.NotNil(opts.withQuotaAlerts)
+		opts.withQuotaAlerts(&QuotaAlert{})
+		assert.True(called)
+	})
+	t.Run("WithIdempotencyKey", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithIdempotencyKey("retry-1"))
+		testOpts := getDefaultOptions()
+		testOpts.withIdempotencyKey = "retry-1"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithMetrics", func(t *testing.T) {
+		assert := assert.New(t)
+		m := &metrics.Metrics{}
+		opts := getOpts(WithMetrics(m))
+		testOpts := getDefaultOptions()
+		testOpts.withMetrics = m
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithMaxPageSize", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithMaxPageSize(100))
+		testOpts := getDefaultOptions()
+		testOpts.withMaxPageSize = 100
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithRoleDiff", func(t *testing.T) {
+		assert := assert.New(t)
+		d := &RoleDiff{}
+		opts := getOpts(WithRoleDiff(d))
+		testOpts := getDefaultOptions()
+		testOpts.withRoleDiff = d
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithRoleAudit", func(t *testing.T) {
+		assert := assert.New(t)
+		var called bool
+		opts := getOpts(WithRoleAudit(func(*RoleDiff) { called = true }))
+		assert.NotNil(opts.withRoleAudit)
+		opts.withRoleAudit(&RoleDiff{})
+		assert.True(called)
+	})
+	t.Run("WithExpirationTime", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithExpirationTime(now))
+		testOpts := getDefaultOptions()
+		testOpts.withExpirationTime = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithArchived", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithArchived(true))
+		testOpts := getDefaultOptions()
+		testOpts.withArchived = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithEmergencyRoleEvents", func(t *testing.T) {
+		assert := assert.New(t)
+		var called bool
+		opts := getOpts(WithEmergencyRoleEvents(func(*EmergencyRoleEvent) { called = true }))
+		assert.NotNil(opts.withEmergencyRoleEvents)
+		opts.withEmergencyRoleEvents(&EmergencyRoleEvent{})
+		assert.True(called)
+	})
+	t.Run("WithSnapshotKeys", func(t *testing.T) {
+		assert := assert.New(t)
+		key := &SnapshotKey{Id: "k1", Key: []byte("key")}
+		opts := getOpts(WithSnapshotKeys(key))
+		testOpts := getDefaultOptions()
+		testOpts.withSnapshotKeys = []*SnapshotKey{key}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSnapshotSigner", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithSnapshotSigner("east"))
+		testOpts := getDefaultOptions()
+		testOpts.withSnapshotSigner = "east"
+		assert.Equal(opts, testOpts)
+	})
 }
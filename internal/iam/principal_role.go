@@ -65,13 +65,11 @@ type UserRole struct {
 	tableName string `gorm:"-"`
 }
 
-// ensure that UserRole implements the interfaces of:  Cloneable and
-// db.VetForWriter
-var _ Cloneable = (*UserRole)(nil)
+// ensure that UserRole implements the interfaces of: db.VetForWriter
 var _ db.VetForWriter = (*UserRole)(nil)
 
-// NewUserRole creates a new user role in memory. No options are supported
-// currently.
+// NewUserRole creates a new user role in memory. Supports the WithNotBefore
+// and WithNotAfter options.
 func NewUserRole(roleId, userId string, opt ...Option) (*UserRole, error) {
 	if roleId == "" {
 		return nil, fmt.Errorf("new user role: missing role id %w", db.ErrInvalidParameter)
@@ -79,10 +77,16 @@ func NewUserRole(roleId, userId string, opt ...Option) (*UserRole, error) {
 	if userId == "" {
 		return nil, fmt.Errorf("new user role: missing user id %w", db.ErrInvalidParameter)
 	}
+	notBefore, notAfter, err := timeBound(getOpts(opt...))
+	if err != nil {
+		return nil, fmt.Errorf("new user role: %w", err)
+	}
 	return &UserRole{
 		UserRole: &store.UserRole{
 			PrincipalId: userId,
 			RoleId:      roleId,
+			NotBefore:   notBefore,
+			NotAfter:    notAfter,
 		},
 	}, nil
 }
@@ -94,7 +98,7 @@ func allocUserRole() UserRole {
 }
 
 // Clone creates a clone of the UserRole.
-func (r *UserRole) Clone() interface{} {
+func (r *UserRole) Clone() *UserRole {
 	cp := proto.Clone(r.UserRole)
 	return &UserRole{
 		UserRole: cp.(*store.UserRole),
@@ -138,13 +142,11 @@ type GroupRole struct {
 	tableName string `gorm:"-"`
 }
 
-// ensure that GroupRole implements the interfaces of: Cloneable and
-// db.VetForWriter
-var _ Cloneable = (*GroupRole)(nil)
+// ensure that GroupRole implements the interfaces of: db.VetForWriter
 var _ db.VetForWriter = (*GroupRole)(nil)
 
-// NewGroupRole creates a new group role in memory. No options are supported
-// currently.
+// NewGroupRole creates a new group role in memory. Supports the WithNotBefore
+// and WithNotAfter options.
 func NewGroupRole(roleId, groupId string, opt ...Option) (*GroupRole, error) {
 	if roleId == "" {
 		return nil, fmt.Errorf("new group role: missing role id %w", db.ErrInvalidParameter)
@@ -152,10 +154,16 @@ func NewGroupRole(roleId, groupId string, opt ...Option) (*GroupRole, error) {
 	if groupId == "" {
 		return nil, fmt.Errorf("new group role: missing group id %w", db.ErrInvalidParameter)
 	}
+	notBefore, notAfter, err := timeBound(getOpts(opt...))
+	if err != nil {
+		return nil, fmt.Errorf("new group role: %w", err)
+	}
 	return &GroupRole{
 		GroupRole: &store.GroupRole{
 			PrincipalId: groupId,
 			RoleId:      roleId,
+			NotBefore:   notBefore,
+			NotAfter:    notAfter,
 		},
 	}, nil
 }
@@ -167,7 +175,7 @@ func allocGroupRole() GroupRole {
 }
 
 // Clone creates a clone of the GroupRole.
-func (r *GroupRole) Clone() interface{} {
+func (r *GroupRole) Clone() *GroupRole {
 	cp := proto.Clone(r.GroupRole)
 	return &GroupRole{
 		GroupRole: cp.(*store.GroupRole),
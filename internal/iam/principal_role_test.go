@@ -248,11 +248,11 @@ func TestUserRole_Create(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 			w := db.New(conn)
 			if tt.wantDup {
-				r := tt.args.role.Clone().(*UserRole)
+				r := tt.args.role.Clone()
 				err := w.Create(context.Background(), r)
 				require.NoError(err)
 			}
-			r := tt.args.role.Clone().(*UserRole)
+			r := tt.args.role.Clone()
 			err := w.Create(context.Background(), r)
 			if tt.wantErr {
 				require.Error(err)
@@ -286,7 +286,7 @@ func TestUserRole_Update(t *testing.T) {
 		u := TestUser(t, repo, org.PublicId)
 		u2 := TestUser(t, repo, org.PublicId)
 		userRole := TestUserRole(t, conn, r.PublicId, u.PublicId)
-		updateRole := userRole.Clone().(*UserRole)
+		updateRole := userRole.Clone()
 		updateRole.PrincipalId = u2.PublicId
 		updatedRows, err := rw.Update(context.Background(), updateRole, []string{"PrincipalId"}, nil)
 		require.Error(err)
@@ -362,7 +362,7 @@ func TestUserRole_Clone(t *testing.T) {
 		role := TestRole(t, conn, org.PublicId)
 		userRole := TestUserRole(t, conn, role.PublicId, user.PublicId)
 		cp := userRole.Clone()
-		assert.True(proto.Equal(cp.(*UserRole).UserRole, userRole.UserRole))
+		assert.True(proto.Equal(cp.UserRole, userRole.UserRole))
 	})
 	t.Run("not-equal", func(t *testing.T) {
 		assert := assert.New(t)
@@ -371,7 +371,19 @@ func TestUserRole_Clone(t *testing.T) {
 		userRole := TestUserRole(t, conn, role.PublicId, user.PublicId)
 		userRole2 := TestUserRole(t, conn, role2.PublicId, user.PublicId)
 		cp := userRole.Clone()
-		assert.True(!proto.Equal(cp.(*UserRole).UserRole, userRole2.UserRole))
+		assert.True(!proto.Equal(cp.UserRole, userRole2.UserRole))
+	})
+	t.Run("deep-copy", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, org.PublicId)
+		userRole := TestUserRole(t, conn, role.PublicId, user.PublicId)
+		require.NotNil(userRole.CreateTime)
+		createSeconds := userRole.CreateTime.Timestamp.Seconds
+		cp := userRole.Clone()
+		cp.PrincipalId = "u_clone"
+		cp.CreateTime.Timestamp.Seconds++
+		assert.Equal(user.PublicId, userRole.PrincipalId)
+		assert.Equal(createSeconds, userRole.CreateTime.Timestamp.Seconds)
 	})
 }
 
@@ -626,11 +638,11 @@ func TestGroupRole_Create(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 			w := db.New(conn)
 			if tt.wantDup {
-				r := tt.args.role.Clone().(*GroupRole)
+				r := tt.args.role.Clone()
 				err := w.Create(context.Background(), r)
 				require.NoError(err)
 			}
-			r := tt.args.role.Clone().(*GroupRole)
+			r := tt.args.role.Clone()
 			err := w.Create(context.Background(), r)
 			if tt.wantErr {
 				require.Error(err)
@@ -664,7 +676,7 @@ func TestGroupRole_Update(t *testing.T) {
 		g := TestGroup(t, conn, org.PublicId)
 		g2 := TestGroup(t, conn, org.PublicId)
 		groupRole := TestGroupRole(t, conn, r.PublicId, g.PublicId)
-		updateRole := groupRole.Clone().(*GroupRole)
+		updateRole := groupRole.Clone()
 		updateRole.PrincipalId = g2.PublicId
 		updatedRows, err := rw.Update(context.Background(), updateRole, []string{"PrincipalId"}, nil)
 		require.Error(err)
@@ -745,7 +757,7 @@ func TestGroupRole_Clone(t *testing.T) {
 		role := TestRole(t, conn, org.PublicId)
 		grpRole := TestGroupRole(t, conn, role.PublicId, grp.PublicId)
 		cp := grpRole.Clone()
-		assert.True(proto.Equal(cp.(*GroupRole).GroupRole, grpRole.GroupRole))
+		assert.True(proto.Equal(cp.GroupRole, grpRole.GroupRole))
 	})
 	t.Run("not-equal", func(t *testing.T) {
 		assert := assert.New(t)
@@ -756,7 +768,20 @@ func TestGroupRole_Clone(t *testing.T) {
 		grpRole := TestGroupRole(t, conn, role.PublicId, grp.PublicId)
 		grpRole2 := TestGroupRole(t, conn, role2.PublicId, grp2.PublicId)
 		cp := grpRole.Clone()
-		assert.True(!proto.Equal(cp.(*GroupRole).GroupRole, grpRole2.GroupRole))
+		assert.True(!proto.Equal(cp.GroupRole, grpRole2.GroupRole))
+	})
+	t.Run("deep-copy", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		grp := TestGroup(t, conn, org.PublicId)
+		role := TestRole(t, conn, org.PublicId)
+		grpRole := TestGroupRole(t, conn, role.PublicId, grp.PublicId)
+		require.NotNil(grpRole.CreateTime)
+		createSeconds := grpRole.CreateTime.Timestamp.Seconds
+		cp := grpRole.Clone()
+		cp.PrincipalId = "g_clone"
+		cp.CreateTime.Timestamp.Seconds++
+		assert.Equal(grp.PublicId, grpRole.PrincipalId)
+		assert.Equal(createSeconds, grpRole.CreateTime.Timestamp.Seconds)
 	})
 }
 
@@ -108,4 +108,334 @@ const (
 	select * from final
 	order by action, member_id;
 	`
+
+	selectOrgIsolation = `select hard_isolation from iam_org_isolation`
+
+	// lockOrgIsolation - lock the isolation setting so that concurrent
+	// inserts of role principals and group members, which take a share lock
+	// on it, can't slip in between checking for violations and enabling
+	// isolation.
+	lockOrgIsolation = `select hard_isolation from iam_org_isolation for update`
+
+	// principalRoleOrgIsolation - filter the iam_principal_role view so that
+	// principals from another org are never listed while hard org isolation
+	// is enabled.
+	principalRoleOrgIsolation = `
+	(not iam_org_isolation_enabled()
+	  or iam_scope_org_id(role_scope_id) = 'global'
+	  or iam_scope_org_id(principal_scope_id) in ('global', iam_scope_org_id(role_scope_id)))`
+
+	// groupMemberOrgIsolation - filter the iam_group_member view so that
+	// members from another org are never listed while hard org isolation is
+	// enabled.
+	groupMemberOrgIsolation = `
+	(not iam_org_isolation_enabled()
+	  or iam_scope_org_id(group_scope_id) = 'global'
+	  or iam_scope_org_id(member_scope_id) in ('global', iam_scope_org_id(group_scope_id)))`
+
+	updateOrgIsolation = `update iam_org_isolation set hard_isolation = $1`
+
+	selectOrgIsolationViolations = `
+	select owner_id, owner_org_id, principal_id, principal_org_id
+	  from iam_org_association
+	 where owner_org_id != principal_org_id
+	   and owner_org_id != 'global'
+	   and principal_org_id != 'global'
+	order by owner_id, principal_id;
+	`
+
+	selectOrgsWithoutRootKey = `
+	select public_id
+	  from iam_scope
+	 where type = 'org'
+	   and public_id not in (select scope_id from kms_root_key)
+	order by public_id;
+	`
+
+	// scopeSubtree selects rows in a scope or any of its descendants; it
+	// takes the scope id and the id of the user listing. When hard org
+	// isolation is enabled the subtree only descends into the user's own org.
+	scopeSubtree = `scope_id in (
+	with recursive subtree (public_id) as (
+	  select ?::text
+	   union
+	  select iam_scope.public_id
+	    from iam_scope
+	    join subtree
+	      on iam_scope.parent_id = subtree.public_id
+	   where not iam_org_isolation_enabled()
+	      or iam_scope.type != 'org'
+	      or iam_scope.public_id = (select scope_id from iam_user where public_id = ?)
+	)
+	select public_id from subtree
+	)`
+
+	// lockScopeForQuota - lock a scope so that concurrent role creations in
+	// it are counted one at a time.
+	lockScopeForQuota = `select public_id from iam_scope where public_id = $1 for update`
+
+	countScopeRoles = `select count(*) from iam_role where scope_id = $1`
+
+	countRoleGrants = `select count(*) from iam_role_grant where role_id = $1`
+
+	countGroupMembers = `select count(*) from iam_group_member where group_id = $1`
+
+	// The integrity hmacs of role grants and principal roles are the only
+	// columns of them which can be updated.
+	updateRoleGrantIntegrity = `update iam_role_grant set integrity_key_id = $1, integrity_hmac = $2 where role_id = $3 and canonical_grant = $4`
+
+	updateUserRoleIntegrity = `update iam_user_role set integrity_key_id = $1, integrity_hmac = $2 where role_id = $3 and principal_id = $4`
+
+	updateGroupRoleIntegrity = `update iam_group_role set integrity_key_id = $1, integrity_hmac = $2 where role_id = $3 and principal_id = $4`
+
+	// fastLookupScope - look up a scope without gorm, for repositories with
+	// fast reads.
+	fastLookupScope = `
+select public_id, create_time, update_time, name, type, parent_id, description, version
+  from iam_scope
+ where public_id = $1;
+`
+
+	// fastListGroupMembers - list the members of a group without gorm, for
+	// repositories with fast reads. A null limit ($2) means no limit.
+	fastListGroupMembers = `
+select create_time, group_id, member_id, type, member_scope_id, group_scope_id, scoped_member_id
+  from iam_group_member
+ where group_id = $1
+   and ` + groupMemberOrgIsolation + `
+ limit $2;
+`
+
+	listLengthLimitViolations = `
+select table_name, public_id, column_name, length
+  from iam_length_limit_violation
+ order by table_name, public_id, column_name;
+`
+
+	// scopeRoleGrants - the grants in effect for each role whose grants apply
+	// in a scope ($1), including the grants of the roles it includes. Expired
+	// and archived roles, and emergency roles which aren't activated, are left
+	// out along with the grants they include.
+	scopeRoleGrants = `
+with recursive
+included_roles (role_id, grant_role_id, depth) as (
+  select public_id,
+         public_id,
+         0
+    from iam_role
+   where grant_scope_id = $1
+     and iam_role_in_effect(expiration_time, archive_time)
+     and iam_emergency_role_active(public_id)
+   union
+  select included_roles.role_id,
+         iam_role_include.included_role_id,
+         included_roles.depth + 1
+    from iam_role_include,
+         included_roles
+   where iam_role_include.role_id = included_roles.grant_role_id
+     and included_roles.depth < %d -- MaxRoleIncludeDepth
+)
+select distinct
+       included_roles.role_id,
+       iam_role_grant.canonical_grant
+  from included_roles
+ inner
+  join iam_role
+    on included_roles.grant_role_id = iam_role.public_id
+ inner
+  join iam_role_grant
+    on included_roles.grant_role_id = iam_role_grant.role_id
+ where iam_time_bound_in_effect(iam_role_grant.not_before, iam_role_grant.not_after)
+   and iam_role_in_effect(iam_role.expiration_time, iam_role.archive_time)
+   and iam_emergency_role_active(iam_role.public_id);
+`
+
+	// scopeRolePrincipals - the principals of each role whose grants apply in
+	// a scope ($1). Users are returned with the group they're a member of, or
+	// an empty group id if they're a principal themselves. Groups are returned
+	// with an empty user id. Inactive users are left out, since they only have
+	// the grants of u_anon, as are expired and archived roles and emergency
+	// roles which aren't activated.
+	scopeRolePrincipals = `
+select r.public_id, ur.principal_id, ''
+  from iam_role r
+ inner
+  join iam_user_role ur
+    on ur.role_id = r.public_id
+ inner
+  join iam_user u
+    on u.public_id = ur.principal_id
+ where r.grant_scope_id = $1
+   and iam_role_in_effect(r.expiration_time, r.archive_time)
+   and iam_emergency_role_active(r.public_id)
+   and u.state = 'active'
+   and iam_time_bound_in_effect(ur.not_before, ur.not_after)
+ union
+select r.public_id, '', gr.principal_id
+  from iam_role r
+ inner
+  join iam_group_role gr
+    on gr.role_id = r.public_id
+ where r.grant_scope_id = $1
+   and iam_role_in_effect(r.expiration_time, r.archive_time)
+   and iam_emergency_role_active(r.public_id)
+   and iam_time_bound_in_effect(gr.not_before, gr.not_after)
+ union
+select r.public_id, gm.member_id, gr.principal_id
+  from iam_role r
+ inner
+  join iam_group_role gr
+    on gr.role_id = r.public_id
+ inner
+  join iam_group_member_user gm
+    on gm.group_id = gr.principal_id
+ inner
+  join iam_user u
+    on u.public_id = gm.member_id
+ where r.grant_scope_id = $1
+   and iam_role_in_effect(r.expiration_time, r.archive_time)
+   and iam_emergency_role_active(r.public_id)
+   and u.state = 'active'
+   and iam_time_bound_in_effect(gr.not_before, gr.not_after);
+`
+
+	// insertIdempotencyKey - claim an idempotency key, unless it's already
+	// claimed by a write which hasn't expired. The lifetime ($6) is in
+	// seconds.
+	insertIdempotencyKey = `
+insert into iam_idempotency_key
+  (scope_id, idempotency_key, operation, resource_id, fingerprint, expiration_time)
+values
+  ($1, $2, $3, $4, $5, now() + $6 * interval '1 second')
+on conflict (scope_id, idempotency_key) do update
+   set operation       = excluded.operation,
+       resource_id     = excluded.resource_id,
+       fingerprint     = excluded.fingerprint,
+       create_time     = now(),
+       expiration_time = excluded.expiration_time
+ where iam_idempotency_key.expiration_time <= now();
+`
+
+	lookupIdempotencyKey = `
+select operation, resource_id, fingerprint
+  from iam_idempotency_key
+ where scope_id = $1
+   and idempotency_key = $2;
+`
+
+	deleteExpiredIdempotencyKeys = `delete from iam_idempotency_key where expiration_time <= now()`
+
+	insertSnapshotImport = `
+insert into iam_snapshot_import
+  (scope_id, snapshot_org_id, key_id, signer, signed_time, verified)
+values
+  ($1, $2, $3, $4, $5, $6);
+`
+
+	lookupSnapshotImport = `
+select snapshot_org_id, key_id, signer, signed_time, verified, create_time
+  from iam_snapshot_import
+ where scope_id = $1;
+`
+
+	// scopeBannedGrantPatterns - the banned grant patterns in effect in a
+	// scope: its own and those of its parent and of global, since a pattern
+	// applies to the scope it's in and every scope beneath it. Ordered
+	// from global down.
+	scopeBannedGrantPatterns = `
+select scope_id, name, pattern
+  from iam_banned_grant_pattern
+ where scope_id = 'global'
+    or scope_id = $1
+    or scope_id = (select parent_id from iam_scope where public_id = $1)
+ order by scope_id, name;
+`
+
+	upsertEmergencyRole = `
+insert into iam_emergency_role
+  (role_id, max_duration_seconds)
+values
+  ($1, $2)
+on conflict (role_id) do update
+   set max_duration_seconds = excluded.max_duration_seconds;
+`
+
+	deleteEmergencyRole = `delete from iam_emergency_role where role_id = $1;`
+
+	lookupEmergencyRole = `select max_duration_seconds from iam_emergency_role where role_id = $1;`
+
+	// userIsRolePrincipal - whether the active user ($2) is a principal of the
+	// role ($1), directly or through a group.
+	userIsRolePrincipal = `
+select exists (
+  select
+    from iam_user_role ur
+   inner
+    join iam_user u
+      on u.public_id = ur.principal_id
+   where ur.role_id = $1
+     and ur.principal_id = $2
+     and u.state = 'active'
+     and iam_time_bound_in_effect(ur.not_before, ur.not_after)
+   union all
+  select
+    from iam_group_role gr
+   inner
+    join iam_group_member_user gm
+      on gm.group_id = gr.principal_id
+   inner
+    join iam_user u
+      on u.public_id = gm.member_id
+   where gr.role_id = $1
+     and gm.member_id = $2
+     and u.state = 'active'
+     and iam_time_bound_in_effect(gr.not_before, gr.not_after)
+);
+`
+
+	insertEmergencyActivation = `
+insert into iam_emergency_role_activation
+  (role_id, user_id, justification, expiration_time)
+values
+  ($1, $2, $3, $4);
+`
+
+	emergencyActivationColumns = `id, role_id, user_id, justification, activate_time, expiration_time, revert_time`
+
+	// currentEmergencyActivation - the activation of the role ($1) which
+	// hasn't been reverted, if any.
+	currentEmergencyActivation = `
+select ` + emergencyActivationColumns + `
+  from iam_emergency_role_activation
+ where role_id = $1
+   and revert_time is null;
+`
+
+	// expiredEmergencyActivations - the activations which haven't been
+	// reverted and expired at or before a time ($1), of a role ($2) or of all
+	// roles if it's empty.
+	expiredEmergencyActivations = `
+select ` + emergencyActivationColumns + `
+  from iam_emergency_role_activation
+ where revert_time is null
+   and expiration_time <= $1
+   and ($2 = '' or role_id = $2)
+ order by id;
+`
+
+	// revertEmergencyActivation - revert the activation ($1) at a time ($2),
+	// unless it's already reverted.
+	revertEmergencyActivation = `
+update iam_emergency_role_activation
+   set revert_time = $2
+ where id = $1
+   and revert_time is null;
+`
+
+	listEmergencyActivations = `
+select ` + emergencyActivationColumns + `
+  from iam_emergency_role_activation
+ where role_id = $1
+ order by id desc;
+`
 )
@@ -4,16 +4,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
+	"time"
 
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/boundary/internal/clock"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/types/scope"
 )
 
 var (
 	ErrMetadataScopeNotFound = errors.New("scope not found for metadata")
+
+	// ErrOrgIsolation is returned when adding a role principal or group
+	// member would cross an org boundary while hard org isolation is enabled,
+	// and when hard org isolation cannot be enabled.
+	ErrOrgIsolation = errors.New("org isolation violation")
 )
 
 // Repository is the iam database repository
@@ -24,10 +35,61 @@ type Repository struct {
 
 	// defaultLimit provides a default for limiting the number of results returned from the repo
 	defaultLimit int
+
+	// clock is the source of the current time, if it isn't the system's.
+	clock clock.Clock
+
+	// quotas limit how many roles, grants and group members are written.
+	quotas Quotas
+
+	// enforceIntegrity makes reads of role grants and principal roles fail
+	// when a row's integrity hmac isn't valid.
+	enforceIntegrity bool
+
+	// lengthLimits are the maximum lengths of names, descriptions and grants
+	// written. The zero value is the defaults.
+	lengthLimits LengthLimits
+
+	// fastReads makes the reads done on every request use hand-written sql
+	// instead of gorm.
+	fastReads bool
+
+	// grantsCache, if set, caches the grants of users and is invalidated by
+	// writes which change them.
+	grantsCache *perms.Cache
+
+	// quotaAlerts, if set, is called when a write takes a resource past one
+	// of the QuotaAlertThresholds of a quota.
+	quotaAlerts func(*QuotaAlert)
+
+	// metrics is where the usage of quotas is emitted.
+	metrics *metrics.Metrics
+
+	// maxPageSize, if set, is the most resources returned by a listing which
+	// isn't explicitly unlimited.
+	maxPageSize int
+
+	// roleAudit, if set, is called with the changes of writes to roles.
+	roleAudit func(*RoleDiff)
+
+	// emergencyRoleEvents, if set, is called when an emergency role is
+	// activated or its activation ends.
+	emergencyRoleEvents func(*EmergencyRoleEvent)
+
+	// snapshotKeys are the keys snapshots are verified with. The first signs
+	// the snapshots exported.
+	snapshotKeys []*SnapshotKey
+
+	// snapshotSigner names the signer of the snapshots exported.
+	snapshotSigner string
 }
 
 // NewRepository creates a new iam Repository. Supports the options: WithLimit
-// which sets a default limit on results returned by repo operations.
+// which sets a default limit on results returned by repo operations,
+// WithClock, WithQuotas, WithIntegrityEnforcement, WithLengthLimits,
+// WithFastReads, WithGrantsCache, WithQuotaAlerts, WithMetrics,
+// WithMaxPageSize, WithRoleAudit, WithEmergencyRoleEvents, WithSnapshotKeys,
+// and WithSnapshotSigner.
 func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*Repository, error) {
 	if r == nil {
 		return nil, errors.New("error creating db repository with nil reader")
@@ -43,16 +105,216 @@ func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*Repo
 		// zero signals the boundary defaults should be used.
 		opts.withLimit = db.DefaultLimit
 	}
+	q := opts.withQuotas
+	if q.MaxRolesPerScope < 0 || q.MaxGrantsPerRole < 0 || q.MaxMembersPerGroup < 0 {
+		return nil, errors.New("error creating db repository with negative quotas")
+	}
+	if opts.withMaxPageSize < 0 {
+		return nil, errors.New("error creating db repository with negative max page size")
+	}
+	if opts.withMetrics == nil {
+		opts.withMetrics = metrics.Default()
+	}
+	lengthLimits, err := opts.withLengthLimits.withDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("error creating db repository with invalid length limits: %w", err)
+	}
+	if err := validateSnapshotKeys(opts.withSnapshotKeys); err != nil {
+		return nil, fmt.Errorf("error creating db repository with invalid snapshot keys: %w", err)
+	}
 	return &Repository{
-		reader:       r,
-		writer:       w,
-		kms:          kms,
-		defaultLimit: opts.withLimit,
+		reader:              r,
+		writer:              w,
+		kms:                 kms,
+		defaultLimit:        opts.withLimit,
+		clock:               opts.withClock,
+		quotas:              opts.withQuotas,
+		enforceIntegrity:    opts.withIntegrityEnforcement,
+		lengthLimits:        lengthLimits,
+		fastReads:           opts.withFastReads,
+		grantsCache:         opts.withGrantsCache,
+		quotaAlerts:         opts.withQuotaAlerts,
+		metrics:             opts.withMetrics,
+		maxPageSize:         opts.withMaxPageSize,
+		roleAudit:           opts.withRoleAudit,
+		emergencyRoleEvents: opts.withEmergencyRoleEvents,
+		snapshotKeys:        opts.withSnapshotKeys,
+		snapshotSigner:      opts.withSnapshotSigner,
 	}, nil
 }
 
+// maxLengths returns the repository's length limits, with the defaults for
+// any which aren't set.
+func (r *Repository) maxLengths() LengthLimits {
+	limits, err := r.lengthLimits.withDefaults()
+	if err != nil {
+		return DefaultLengthLimits()
+	}
+	return limits
+}
+
+// now returns the current time from the repository's clock.
+func (r *Repository) now() time.Time {
+	if r.clock == nil {
+		return time.Now()
+	}
+	return r.clock.Now()
+}
+
+// invalidateGrantsCache drops all the grants cached for users, if the
+// repository has a grants cache. It's called after writes which may change the
+// grants in effect for any user in any scope have committed.
+func (r *Repository) invalidateGrantsCache() {
+	if r.grantsCache != nil {
+		r.grantsCache.Invalidate()
+	}
+}
+
+// invalidateGrantsCacheUsers drops the grants cached for the users, in every
+// scope, if the repository has a grants cache.
+func (r *Repository) invalidateGrantsCacheUsers(userIds ...string) {
+	if r.grantsCache != nil && len(userIds) > 0 {
+		r.grantsCache.InvalidateUsers(userIds...)
+	}
+}
+
+// invalidateGrantsCacheScopes drops the grants cached for every user in the
+// scopes, if the repository has a grants cache.
+func (r *Repository) invalidateGrantsCacheScopes(scopeIds ...string) {
+	if r.grantsCache != nil && len(scopeIds) > 0 {
+		r.grantsCache.InvalidateScopes(scopeIds...)
+	}
+}
+
+// invalidateGrantsCacheForRoles drops the grants cached for every user in the
+// scopes the grants of the roles apply in, which are the grant scopes of the
+// roles and of the roles including them. It's called after writes to the
+// roles have committed. If the scopes can't be looked up, all the grants
+// cached are dropped.
+func (r *Repository) invalidateGrantsCacheForRoles(ctx context.Context, roleIds ...string) {
+	if r.grantsCache == nil || len(roleIds) == 0 {
+		return
+	}
+	scopeIds, err := r.grantScopesOfRoles(ctx, roleIds...)
+	if err != nil {
+		r.grantsCache.Invalidate()
+		return
+	}
+	r.grantsCache.InvalidateScopes(scopeIds...)
+}
+
+// grantScopesOfRoles returns the scopes the grants of the roles apply in: the
+// grant scopes of the roles and of the roles which include them. It returns
+// nil if the repository has no grants cache, since that's the only use of the
+// scopes.
+func (r *Repository) grantScopesOfRoles(ctx context.Context, roleIds ...string) ([]string, error) {
+	if r.grantsCache == nil || len(roleIds) == 0 {
+		return nil, nil
+	}
+	const query = `
+with recursive
+including_roles (role_id, depth) as (
+  select public_id,
+         0
+    from iam_role
+   where public_id in (%s)
+   union
+  select iam_role_include.role_id,
+         including_roles.depth + 1
+    from iam_role_include,
+         including_roles
+   where iam_role_include.included_role_id = including_roles.role_id
+     and including_roles.depth < %d -- MaxRoleIncludeDepth
+)
+select distinct iam_role.grant_scope_id
+  from iam_role,
+       including_roles
+ where iam_role.public_id = including_roles.role_id;
+`
+	params := make([]string, 0, len(roleIds))
+	args := make([]interface{}, 0, len(roleIds))
+	for i, id := range roleIds {
+		params = append(params, fmt.Sprintf("$%d", i+1))
+		args = append(args, id)
+	}
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(query, strings.Join(params, ", "), MaxRoleIncludeDepth), args)
+	if err != nil {
+		return nil, fmt.Errorf("grant scopes of roles: %w", err)
+	}
+	defer rows.Close()
+	var scopeIds []string
+	for rows.Next() {
+		var scopeId string
+		if err := rows.Scan(&scopeId); err != nil {
+			return nil, fmt.Errorf("grant scopes of roles: %w", err)
+		}
+		scopeIds = append(scopeIds, scopeId)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("grant scopes of roles: %w", err)
+	}
+	return scopeIds, nil
+}
+
+// scopeClause returns the where clause and arguments for listing resources in
+// the scope, or in the scope and all of its descendants if the WithRecursive
+// option is set. With hard org isolation enabled, a recursive listing only
+// descends into the org of the user in the context's request info, so listing
+// from global never reaches into another customer's org.
+func scopeClause(ctx context.Context, scopeId string, opt ...Option) (string, []interface{}) {
+	if getOpts(opt...).withRecursive {
+		var userId string
+		if ri, ok := db.RequestInfoFromContext(ctx); ok {
+			userId = ri.UserId
+		}
+		return scopeSubtree, []interface{}{scopeId, userId}
+	}
+	return "scope_id = ?", []interface{}{scopeId}
+}
+
+// addListedScopes adds the scopes of the listed resources, a slice of
+// resources with a scope id, to the map of the WithListedScopes option if it's
+// set. The scopes are read with one query.
+func (r *Repository) addListedScopes(ctx context.Context, resources interface{}, opt ...Option) error {
+	into := getOpts(opt...).withListedScopes
+	if into == nil {
+		return nil
+	}
+	listed := reflect.ValueOf(resources)
+	seen := make(map[string]bool)
+	var ids []string
+	for i := 0; i < listed.Len(); i++ {
+		res, ok := listed.Index(i).Interface().(interface{ GetScopeId() string })
+		if !ok {
+			return fmt.Errorf("%T has no scope: %w", listed.Index(i).Interface(), db.ErrInvalidParameter)
+		}
+		if id := res.GetScopeId(); !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	var scopes []*Scope
+	if err := r.reader.SearchWhere(ctx, &scopes, "public_id in (?)", []interface{}{ids}, db.WithLimit(-1)); err != nil {
+		return fmt.Errorf("unable to read listed scopes: %w", err)
+	}
+	for _, s := range scopes {
+		into[s.PublicId] = s
+	}
+	return nil
+}
+
 // list will return a listing of resources and honor the WithLimit option or the
-// repo defaultLimit
+// repo defaultLimit. If the WithPageToken or WithNextPageToken option is set,
+// the resources are listed in db.PageOrder a page at a time; this requires
+// resources with a public id and create time.
+//
+// If the repo has a max page size, a limit over it, or the default limit, is
+// lowered to it, and a listing which isn't paginated fails with
+// db.ErrPageRequired if there are more resources than that. A negative limit
+// is unlimited regardless.
 func (r *Repository) list(ctx context.Context, resources interface{}, where string, args []interface{}, opt ...Option) error {
 	opts := getOpts(opt...)
 	limit := r.defaultLimit
@@ -60,10 +322,84 @@ func (r *Repository) list(ctx context.Context, resources interface{}, where stri
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
-	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit))
+	paginated := opts.withPageToken != "" || opts.withNextPageToken != nil
+	// A zero limit is the db's default limit, which can be over the max too.
+	if r.maxPageSize > 0 && (limit == 0 || limit > r.maxPageSize) {
+		limit = r.maxPageSize
+		if !paginated {
+			// One more than the page is enough to tell if it's all of them
+			if err := r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit+1)); err != nil {
+				return err
+			}
+			if reflect.ValueOf(resources).Elem().Len() > limit {
+				return fmt.Errorf("more than %d results: %w", limit, db.ErrPageRequired)
+			}
+			return nil
+		}
+	}
+	if !paginated {
+		return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit))
+	}
+
+	// The token is bound to the listing's query so it can't be used to page
+	// through a different listing.
+	aad := []byte(where + fmt.Sprint(args...))
+	wrapper, err := r.kms.GetWrapper(ctx, scope.Global.String(), kms.KeyPurposeDatabase)
+	if err != nil {
+		return fmt.Errorf("unable to get page token wrapper: %w", err)
+	}
+	if opts.withPageToken != "" {
+		token, err := db.DecodePageToken(ctx, wrapper, aad, opts.withPageToken)
+		if err != nil {
+			return err
+		}
+		tokenWhere, tokenArgs := token.Where()
+		if where != "" {
+			tokenWhere = "(" + where + ") and " + tokenWhere
+		}
+		where = tokenWhere
+		args = append(append(make([]interface{}, 0, len(args)+len(tokenArgs)), args...), tokenArgs...)
+	}
+	if err := r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit), db.WithOrder(db.PageOrder)); err != nil {
+		return err
+	}
+	if opts.withNextPageToken == nil {
+		return nil
+	}
+	*opts.withNextPageToken = ""
+	listed := reflect.ValueOf(resources).Elem()
+	if limit <= 0 || listed.Len() < limit {
+		return nil
+	}
+	last, ok := listed.Index(listed.Len() - 1).Interface().(pageable)
+	if !ok {
+		return fmt.Errorf("%T can't be paginated: %w", last, db.ErrInvalidParameter)
+	}
+	next, err := db.EncodePageToken(ctx, wrapper, aad, &db.PageToken{
+		SortKey: last.GetCreateTime().GetTimestamp().AsTime(),
+		LastId:  last.GetPublicId(),
+	})
+	if err != nil {
+		return err
+	}
+	*opts.withNextPageToken = next
+	return nil
 }
 
-// create will create a new iam resource in the db repository with an oplog entry
+// pageable is a resource which can be listed a page at a time.
+type pageable interface {
+	GetPublicId() string
+	GetCreateTime() *timestamp.Timestamp
+}
+
+// errDryRun is returned from the transaction of a write made with the
+// WithDryRun option, once the write has succeeded, so it's rolled back.
+var errDryRun = errors.New("dry run")
+
+// create will create a new iam resource in the db repository with an oplog
+// entry. Supports the WithDryRun option, and the WithIdempotencyKey option
+// for roles, which returns an idempotentReplayError if the key was used to
+// create a role already.
 func (r *Repository) create(ctx context.Context, resource Resource, opt ...Option) (Resource, error) {
 	if resource == nil {
 		return nil, errors.New("error creating resource that is nil")
@@ -77,6 +413,9 @@ func (r *Repository) create(ctx context.Context, resource Resource, opt ...Optio
 		return nil, fmt.Errorf("error getting metadata for create: %w", err)
 	}
 	metadata["op-type"] = []string{oplog.OpType_OP_TYPE_CREATE.String()}
+	if err := validateLengthsForWrite(resource, r.maxLengths(), db.CreateOp); err != nil {
+		return nil, err
+	}
 
 	scope, err := resource.GetScope(ctx, r.reader)
 	if err != nil {
@@ -87,24 +426,58 @@ func (r *Repository) create(ctx context.Context, resource Resource, opt ...Optio
 		return nil, fmt.Errorf("unable to get oplog wrapper: %w", err)
 	}
 
-	var returnedResource interface{}
+	opts := getOpts(opt...)
+	var claim *idempotencyClaim
+	if role, ok := resource.(*Role); ok && opts.withIdempotencyKey != "" {
+		if claim, err = roleCreateClaim(opts.withIdempotencyKey, role); err != nil {
+			return nil, err
+		}
+		metadata["idempotency-key"] = []string{opts.withIdempotencyKey}
+	}
+	var returnedResource Resource
+	var usage *quotaUsage
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
-		func(_ db.Reader, w db.Writer) error {
-			returnedResource = resourceCloner.Clone()
-			return w.Create(
+		func(reader db.Reader, w db.Writer) error {
+			returnedResource = resourceCloner.cloneResource()
+			if claim != nil {
+				if err := claimIdempotencyKey(ctx, reader, w, claim); err != nil {
+					return err
+				}
+			}
+			if role, ok := resource.(*Role); ok {
+				u, err := r.checkRoleQuota(ctx, reader, role.ScopeId)
+				if err != nil {
+					return err
+				}
+				usage = u
+			}
+			if err := w.Create(
 				ctx,
 				returnedResource,
 				db.WithOplog(oplogWrapper, metadata),
-			)
+			); err != nil {
+				return err
+			}
+			if opts.withDryRun {
+				return errDryRun
+			}
+			return nil
 		},
 	)
-	return returnedResource.(Resource), err
+	switch {
+	case errors.Is(err, errDryRun):
+		err = nil
+	case err == nil:
+		r.observeQuota(usage, scope.GetPublicId(), 1)
+	}
+	return returnedResource, err
 }
 
-// update will update an iam resource in the db repository with an oplog entry
+// update will update an iam resource in the db repository with an oplog
+// entry. Supports the WithDryRun option.
 func (r *Repository) update(ctx context.Context, resource Resource, version uint32, fieldMaskPaths []string, setToNullPaths []string, opt ...Option) (Resource, int, error) {
 	if version == 0 {
 		return nil, db.NoRowsAffected, errors.New("resource version cannot be zero during update")
@@ -121,6 +494,9 @@ func (r *Repository) update(ctx context.Context, resource Resource, version uint
 		return nil, db.NoRowsAffected, fmt.Errorf("error getting metadata for update: %w", err)
 	}
 	metadata["op-type"] = []string{oplog.OpType_OP_TYPE_UPDATE.String()}
+	if err := validateLengthsForWrite(resource, r.maxLengths(), db.UpdateOp, db.WithFieldMaskPaths(fieldMaskPaths)); err != nil {
+		return nil, db.NoRowsAffected, err
+	}
 
 	dbOpts := []db.Option{
 		db.WithVersion(&version),
@@ -147,13 +523,13 @@ func (r *Repository) update(ctx context.Context, resource Resource, version uint
 	dbOpts = append(dbOpts, db.WithOplog(oplogWrapper, metadata))
 
 	var rowsUpdated int
-	var returnedResource interface{}
+	var returnedResource Resource
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(_ db.Reader, w db.Writer) error {
-			returnedResource = resourceCloner.Clone()
+			returnedResource = resourceCloner.cloneResource()
 			rowsUpdated, err = w.Update(
 				ctx,
 				returnedResource,
@@ -165,13 +541,20 @@ func (r *Repository) update(ctx context.Context, resource Resource, version uint
 				// return err, which will result in a rollback of the update
 				return errors.New("error more than 1 resource would have been updated ")
 			}
+			if err == nil && opts.withDryRun {
+				return errDryRun
+			}
 			return err
 		},
 	)
-	return returnedResource.(Resource), rowsUpdated, err
+	if errors.Is(err, errDryRun) {
+		err = nil
+	}
+	return returnedResource, rowsUpdated, err
 }
 
-// delete will delete an iam resource in the db repository with an oplog entry
+// delete will delete an iam resource in the db repository with an oplog
+// entry. Supports the WithDryRun option.
 func (r *Repository) delete(ctx context.Context, resource Resource, opt ...Option) (int, error) {
 	if resource == nil {
 		return db.NoRowsAffected, errors.New("error deleting resource that is nil")
@@ -195,14 +578,15 @@ func (r *Repository) delete(ctx context.Context, resource Resource, opt ...Optio
 		return db.NoRowsAffected, fmt.Errorf("unable to get oplog wrapper: %w", err)
 	}
 
+	opts := getOpts(opt...)
 	var rowsDeleted int
-	var deleteResource interface{}
+	var deleteResource Resource
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(_ db.Reader, w db.Writer) error {
-			deleteResource = resourceCloner.Clone()
+			deleteResource = resourceCloner.cloneResource()
 			rowsDeleted, err = w.Delete(
 				ctx,
 				deleteResource,
@@ -212,9 +596,15 @@ func (r *Repository) delete(ctx context.Context, resource Resource, opt ...Optio
 				// return err, which will result in a rollback of the delete
 				return errors.New("error more than 1 resource would have been deleted ")
 			}
+			if err == nil && opts.withDryRun {
+				return errDryRun
+			}
 			return err
 		},
 	)
+	if errors.Is(err, errDryRun) {
+		err = nil
+	}
 	return rowsDeleted, err
 }
 
@@ -271,3 +661,12 @@ func contains(ss []string, t string) bool {
 	}
 	return false
 }
+
+// WithRequestInfo returns a copy of ctx which attributes the writes made with
+// it to the user (userId) and the scope (scopeId) of a request. The ids are
+// added to the metadata of the oplog entries written with the context, and a
+// db.Writer created with db.WithRequireRequestInfo refuses writes without
+// them.
+func WithRequestInfo(ctx context.Context, userId, scopeId string) context.Context {
+	return db.NewRequestInfoContext(ctx, userId, scopeId)
+}
@@ -0,0 +1,157 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+// ResourceAccess is a role whose grants allow or deny actions on a resource,
+// along with the principals the role is assigned to.
+type ResourceAccess struct {
+	RoleId string
+	// Actions are the actions the role's grants allow on the resource, less
+	// the ones its own deny grants deny.
+	Actions []action.Type
+	// Denied are the actions the role's deny grants deny on the resource.
+	Denied []action.Type
+	// GroupIds are the groups the role is assigned to.
+	GroupIds []string
+	// UserIds are the active users the role is assigned to, directly or as
+	// members of its groups.
+	UserIds []string
+}
+
+// WhoHasAccess returns the roles whose grants allow or deny actions on the
+// resource, sorted by role id, along with who they're assigned to. The
+// resource's ScopeId and Type are required. If its Id is empty, the access
+// returned is to the collection of resources of the type in the scope, as
+// for the list and create actions. The grants of included roles are counted
+// as the grants of the roles including them.
+//
+// A deny grant overrides the grants of every role a user has, so a user
+// reached through a role allowing an action is still denied it if they're
+// also reached through a role denying it. Grant templates can't be resolved
+// without a user, so grants using them are ignored. A grant of all actions is
+// reported as action.All rather than expanded into the actions of the type.
+func (r *Repository) WhoHasAccess(ctx context.Context, res perms.Resource) ([]*ResourceAccess, error) {
+	if res.ScopeId == "" {
+		return nil, fmt.Errorf("who has access: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if res.Type == resource.Unknown {
+		return nil, fmt.Errorf("who has access: missing resource type: %w", db.ErrInvalidParameter)
+	}
+
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(scopeRoleGrants, MaxRoleIncludeDepth), []interface{}{res.ScopeId})
+	if err != nil {
+		return nil, fmt.Errorf("who has access: unable to get role grants: %w", err)
+	}
+	allowed := map[string]map[action.Type]bool{}
+	denied := map[string]map[action.Type]bool{}
+	for rows.Next() {
+		var roleId, grant string
+		if err := rows.Scan(&roleId, &grant); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("who has access: unable to scan role grant: %w", err)
+		}
+		parsed, err := perms.Parse(res.ScopeId, grant, perms.WithSkipFinalValidation(true))
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("who has access: unable to parse grant %q of role %s: %w", grant, roleId, err)
+		}
+		acts := allowed
+		if parsed.Effect() == perms.Deny {
+			acts = denied
+		}
+		typs, _ := parsed.Actions()
+		for _, a := range typs {
+			if !parsed.Matches(res, a) {
+				continue
+			}
+			if acts[roleId] == nil {
+				acts[roleId] = map[action.Type]bool{}
+			}
+			acts[roleId][a] = true
+		}
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("who has access: %w", err)
+	}
+
+	access := map[string]*ResourceAccess{}
+	for _, m := range []map[string]map[action.Type]bool{allowed, denied} {
+		for roleId := range m {
+			if access[roleId] != nil {
+				continue
+			}
+			ra := &ResourceAccess{
+				RoleId: roleId,
+				Denied: sortedActions(denied[roleId], nil),
+			}
+			if !denied[roleId][action.All] {
+				ra.Actions = sortedActions(allowed[roleId], denied[roleId])
+			}
+			access[roleId] = ra
+		}
+	}
+	if len(access) == 0 {
+		return nil, nil
+	}
+
+	rows, err = r.reader.Query(ctx, scopeRolePrincipals, []interface{}{res.ScopeId})
+	if err != nil {
+		return nil, fmt.Errorf("who has access: unable to get role principals: %w", err)
+	}
+	defer rows.Close()
+	seen := map[string]bool{}
+	for rows.Next() {
+		var roleId, userId, groupId string
+		if err := rows.Scan(&roleId, &userId, &groupId); err != nil {
+			return nil, fmt.Errorf("who has access: unable to scan role principal: %w", err)
+		}
+		ra := access[roleId]
+		if ra == nil {
+			continue
+		}
+		switch {
+		case userId == "":
+			ra.GroupIds = append(ra.GroupIds, groupId)
+		case !seen[roleId+userId]:
+			// A user can be a principal directly and through several groups
+			seen[roleId+userId] = true
+			ra.UserIds = append(ra.UserIds, userId)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("who has access: %w", err)
+	}
+
+	results := make([]*ResourceAccess, 0, len(access))
+	for _, ra := range access {
+		sort.Strings(ra.GroupIds)
+		sort.Strings(ra.UserIds)
+		results = append(results, ra)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].RoleId < results[j].RoleId })
+	return results, nil
+}
+
+// sortedActions returns the actions in acts which aren't in except, sorted by
+// name.
+func sortedActions(acts, except map[action.Type]bool) []action.Type {
+	var typs []action.Type
+	for a := range acts {
+		if !except[a] {
+			typs = append(typs, a)
+		}
+	}
+	sort.Slice(typs, func(i, j int) bool { return typs[i].String() < typs[j].String() })
+	return typs
+}
@@ -0,0 +1,113 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_WhoHasAccess(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	org, proj := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	const targetId = "ttcp_1234567890"
+	member := TestUser(t, repo, org.PublicId)
+	direct := TestUser(t, repo, org.PublicId)
+	admin := TestUser(t, repo, org.PublicId)
+	grp := TestGroup(t, conn, org.PublicId)
+	TestGroupMember(t, conn, grp.PublicId, member.PublicId)
+	TestGroupMember(t, conn, grp.PublicId, direct.PublicId)
+
+	connectRole := TestRole(t, conn, proj.PublicId)
+	TestRoleGrant(t, conn, connectRole.PublicId, "id="+targetId+";actions=read,authorize-session")
+	TestGroupRole(t, conn, connectRole.PublicId, grp.PublicId)
+	TestUserRole(t, conn, connectRole.PublicId, direct.PublicId)
+
+	adminRole := TestRole(t, conn, proj.PublicId)
+	TestRoleGrant(t, conn, adminRole.PublicId, "id=*;type=*;actions=*")
+	TestUserRole(t, conn, adminRole.PublicId, admin.PublicId)
+
+	denyRole := TestRole(t, conn, proj.PublicId)
+	TestRoleGrant(t, conn, denyRole.PublicId, "id=*;type=target;actions=authorize-session;effect=deny")
+	TestUserRole(t, conn, denyRole.PublicId, direct.PublicId)
+
+	// The grants of an included role count as the including role's
+	updateRole := TestRole(t, conn, org.PublicId)
+	TestRoleGrant(t, conn, updateRole.PublicId, "id="+targetId+";actions=update")
+	includingRole := TestRole(t, conn, proj.PublicId)
+	_, err := repo.AddRoleIncludes(ctx, includingRole.PublicId, includingRole.Version, []string{updateRole.PublicId})
+	require.NoError(err)
+
+	// Not for targets, or for the collection only
+	hostSetRole := TestRole(t, conn, proj.PublicId)
+	TestRoleGrant(t, conn, hostSetRole.PublicId, "id=*;type=host-set;actions=read")
+	listRole := TestRole(t, conn, proj.PublicId)
+	TestRoleGrant(t, conn, listRole.PublicId, "type=target;actions=list")
+
+	got, err := repo.WhoHasAccess(ctx, perms.Resource{ScopeId: proj.PublicId, Id: targetId, Type: resource.Target})
+	require.NoError(err)
+	want := []*ResourceAccess{
+		{
+			RoleId:   connectRole.PublicId,
+			Actions:  []action.Type{action.AuthorizeSession, action.Read},
+			GroupIds: []string{grp.PublicId},
+			UserIds:  sortedIds(member.PublicId, direct.PublicId),
+		},
+		{
+			RoleId:  adminRole.PublicId,
+			Actions: []action.Type{action.All},
+			UserIds: []string{admin.PublicId},
+		},
+		{
+			RoleId:  denyRole.PublicId,
+			Denied:  []action.Type{action.AuthorizeSession},
+			UserIds: []string{direct.PublicId},
+		},
+		{
+			RoleId:  includingRole.PublicId,
+			Actions: []action.Type{action.Update},
+		},
+	}
+	sortAccess(want)
+	assert.Equal(want, got)
+
+	got, err = repo.WhoHasAccess(ctx, perms.Resource{ScopeId: proj.PublicId, Type: resource.Target})
+	require.NoError(err)
+	var roleIds []string
+	for _, ra := range got {
+		roleIds = append(roleIds, ra.RoleId)
+	}
+	assert.ElementsMatch([]string{adminRole.PublicId, denyRole.PublicId, listRole.PublicId}, roleIds)
+
+	// Only the grants of roles whose grant scope is the resource's scope apply
+	got, err = repo.WhoHasAccess(ctx, perms.Resource{ScopeId: org.PublicId, Id: targetId, Type: resource.Target})
+	require.NoError(err)
+	assert.Equal([]*ResourceAccess{{RoleId: updateRole.PublicId, Actions: []action.Type{action.Update}}}, got)
+
+	_, err = repo.WhoHasAccess(ctx, perms.Resource{Id: targetId, Type: resource.Target})
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+	_, err = repo.WhoHasAccess(ctx, perms.Resource{ScopeId: proj.PublicId, Id: targetId})
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+}
+
+func sortedIds(ids ...string) []string {
+	sort.Strings(ids)
+	return ids
+}
+
+func sortAccess(access []*ResourceAccess) {
+	sort.Slice(access, func(i, j int) bool { return access[i].RoleId < access[j].RoleId })
+}
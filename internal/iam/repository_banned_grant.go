@@ -0,0 +1,117 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// CreateBannedGrantPattern creates a banned grant pattern, which stops role
+// grants matching it from being written in its scope and the scopes beneath
+// it. Grants written before the pattern was created aren't affected.
+func (r *Repository) CreateBannedGrantPattern(ctx context.Context, p *BannedGrantPattern) (*BannedGrantPattern, error) {
+	if p == nil || p.BannedGrantPattern == nil {
+		return nil, fmt.Errorf("create banned grant pattern: missing pattern: %w", db.ErrInvalidParameter)
+	}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("create banned grant pattern: %w", err)
+	}
+	metadata, oplogWrapper, err := r.bannedGrantPatternOplog(ctx, p.ScopeId, oplog.OpType_OP_TYPE_CREATE)
+	if err != nil {
+		return nil, fmt.Errorf("create banned grant pattern: %w", err)
+	}
+
+	var created *BannedGrantPattern
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			created = p.Clone()
+			return w.Create(ctx, created, db.WithOplog(oplogWrapper, metadata))
+		},
+	)
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, fmt.Errorf("create banned grant pattern: pattern %s already exists in %s: %w", p.Name, p.ScopeId, db.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create banned grant pattern: %w", err)
+	}
+	return created, nil
+}
+
+// ListBannedGrantPatterns lists the banned grant patterns created in the
+// scope, sorted by name. Patterns created in the scopes above it, which are
+// also in effect in it, aren't included.
+func (r *Repository) ListBannedGrantPatterns(ctx context.Context, scopeId string) ([]*BannedGrantPattern, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("list banned grant patterns: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	var patterns []*BannedGrantPattern
+	if err := r.reader.SearchWhere(ctx, &patterns, "scope_id = ?", []interface{}{scopeId}, db.WithLimit(-1), db.WithOrder("name")); err != nil {
+		return nil, fmt.Errorf("list banned grant patterns: %w", err)
+	}
+	return patterns, nil
+}
+
+// DeleteBannedGrantPattern deletes the banned grant pattern with the name in
+// the scope and returns the number of patterns deleted.
+func (r *Repository) DeleteBannedGrantPattern(ctx context.Context, scopeId, name string) (int, error) {
+	if scopeId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete banned grant pattern: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if name == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete banned grant pattern: missing name: %w", db.ErrInvalidParameter)
+	}
+	metadata, oplogWrapper, err := r.bannedGrantPatternOplog(ctx, scopeId, oplog.OpType_OP_TYPE_DELETE)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete banned grant pattern: %w", err)
+	}
+	p := allocBannedGrantPattern()
+	p.ScopeId, p.Name = scopeId, name
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			rowsDeleted, err = w.Delete(ctx, p.Clone(), db.WithOplog(oplogWrapper, metadata))
+			if err == nil && rowsDeleted > 1 {
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete banned grant pattern: %w", err)
+	}
+	return rowsDeleted, nil
+}
+
+// bannedGrantPatternOplog returns the oplog metadata and wrapper for a write
+// of a banned grant pattern in the scope.
+func (r *Repository) bannedGrantPatternOplog(ctx context.Context, scopeId string, opType oplog.OpType) (oplog.Metadata, wrapping.Wrapper, error) {
+	scope, err := r.LookupScope(ctx, scopeId)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to look up scope %s: %w", scopeId, err)
+	}
+	if scope == nil {
+		return nil, nil, fmt.Errorf("scope %s not found: %w", scopeId, db.ErrRecordNotFound)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get oplog wrapper: %w", err)
+	}
+	metadata := oplog.Metadata{
+		"op-type":            []string{opType.String()},
+		"scope-id":           []string{scope.PublicId},
+		"scope-type":         []string{scope.Type},
+		"resource-public-id": []string{scopeId},
+	}
+	return metadata, oplogWrapper, nil
+}
@@ -0,0 +1,76 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_BannedGrantPatterns(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	org, proj := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	otherOrg, _ := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+
+	newPattern := func(scopeId, name, pattern string, opt ...Option) *BannedGrantPattern {
+		p, err := NewBannedGrantPattern(scopeId, name, pattern, opt...)
+		require.NoError(err)
+		return p
+	}
+	p, err := repo.CreateBannedGrantPattern(ctx, newPattern(org.PublicId, "no-wildcards", "id=*;actions=*", WithDescription("Wildcard grants are not allowed in this org")))
+	require.NoError(err)
+	assert.Equal("no-wildcards", p.Name)
+	assert.NotNil(p.CreateTime)
+	_, err = repo.CreateBannedGrantPattern(ctx, newPattern(org.PublicId, "no-wildcards", "id=*"))
+	assert.True(errors.Is(err, db.ErrNotUnique))
+	_, err = NewBannedGrantPattern(org.PublicId, "bad", "nope")
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+	_, err = repo.CreateBannedGrantPattern(ctx, newPattern("global", "no-role-deletes", "type=role;actions=delete"))
+	require.NoError(err)
+	assert.NoError(db.TestVerifyOplog(t, repo.reader, "global", db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
+
+	// Banned in the org and its projects, naming the policy
+	for _, scopeId := range []string{org.PublicId, proj.PublicId} {
+		role := TestRole(t, conn, scopeId)
+		_, err = repo.AddRoleGrants(ctx, role.PublicId, role.Version, []string{"id=*;type=*;actions=*"})
+		require.Error(err)
+		assert.True(errors.Is(err, ErrWriteRejected))
+		assert.Contains(err.Error(), `policy "no-wildcards"`)
+
+		_, err = repo.AddRoleGrants(ctx, role.PublicId, role.Version, []string{"id=*;type=role;actions=read", "id=*;type=*;actions=*;effect=deny"})
+		assert.NoError(err)
+	}
+
+	// The global pattern applies everywhere, the org's only in the org
+	role := TestRole(t, conn, otherOrg.PublicId)
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, role.Version, []string{"id=*;type=*;actions=*"})
+	require.NoError(err)
+	_, _, _, err = repo.SetRoleGrants(ctx, role.PublicId, role.Version+1, []string{"id=*;type=role;actions=read,delete"})
+	assert.True(errors.Is(err, ErrWriteRejected))
+	assert.Contains(err.Error(), `policy "no-role-deletes"`)
+
+	patterns, err := repo.ListBannedGrantPatterns(ctx, org.PublicId)
+	require.NoError(err)
+	require.Len(patterns, 1)
+	assert.Equal(p.Pattern, patterns[0].Pattern)
+	assert.Equal(p.Description, patterns[0].Description)
+
+	deleted, err := repo.DeleteBannedGrantPattern(ctx, org.PublicId, "no-wildcards")
+	require.NoError(err)
+	assert.Equal(1, deleted)
+	assert.NoError(db.TestVerifyOplog(t, repo.reader, org.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_DELETE), db.WithCreateNotBefore(10*time.Second)))
+	role = TestRole(t, conn, proj.PublicId)
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, role.Version, []string{"id=*;type=*;actions=*"})
+	assert.NoError(err)
+}
@@ -0,0 +1,361 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+)
+
+// ClaimRuleMatch describes the effect of a claim rule on a user at login
+// time.
+type ClaimRuleMatch struct {
+	// RuleId is the public id of the claim rule which matched.
+	RuleId string `json:"rule_id"`
+	// RoleId is the role the rule maps the user into, if it maps to a role.
+	RoleId string `json:"role_id,omitempty"`
+	// GroupId is the group the rule maps the user into, if it maps to a
+	// group.
+	GroupId string `json:"group_id,omitempty"`
+	// AlreadyPrincipal is true if the user was already a principal of the
+	// role or a member of the group, in which case nothing needed to change.
+	AlreadyPrincipal bool `json:"already_principal"`
+	// Unmatched is true if none of the rules mapping to the group matched,
+	// but the user is a member of it, so they're removed from the group. The
+	// RuleId is then one of the group's rules.
+	Unmatched bool `json:"unmatched,omitempty"`
+	// Applied is true if the user was added as a principal of the role or a
+	// member of the group, or for Unmatched matches, removed from the group.
+	// It is always false when the rules were evaluated with WithDryRun.
+	Applied bool `json:"applied"`
+}
+
+// CreateClaimRule will create a claim rule in the repository and return the
+// written claim rule. Supports the WithDryRun option.
+func (r *Repository) CreateClaimRule(ctx context.Context, rule *ClaimRule, opt ...Option) (*ClaimRule, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("create claim rule: missing claim rule: %w", db.ErrInvalidParameter)
+	}
+	if rule.ClaimRule == nil {
+		return nil, fmt.Errorf("create claim rule: missing claim rule store: %w", db.ErrInvalidParameter)
+	}
+	if rule.PublicId != "" {
+		return nil, fmt.Errorf("create claim rule: public id not empty: %w", db.ErrInvalidParameter)
+	}
+	if rule.ScopeId == "" {
+		return nil, fmt.Errorf("create claim rule: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	id, err := newClaimRuleId()
+	if err != nil {
+		return nil, fmt.Errorf("create claim rule: %w", err)
+	}
+	c := rule.Clone()
+	c.PublicId = id
+	resource, err := r.create(ctx, c, opt...)
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, fmt.Errorf("create claim rule: claim rule %s already exists in org %s: %w", rule.Name, rule.ScopeId, db.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create claim rule: %w for %s", err, c.PublicId)
+	}
+	return resource.(*ClaimRule), nil
+}
+
+// UpdateClaimRule will update a claim rule in the repository and return the
+// written claim rule. fieldMaskPaths provides field_mask.proto paths for
+// fields that should be updated. Fields will be set to NULL if the field is a
+// zero value and included in fieldMask. Name, Description, ClaimName,
+// ClaimValue, RoleId and GroupId are the only updatable fields; a rule
+// mapping to a role can't be changed to map to a group, or the reverse. If no updatable fields
+// are included in the fieldMaskPaths, then an error is returned. Supports the
+// WithDryRun option.
+func (r *Repository) UpdateClaimRule(ctx context.Context, rule *ClaimRule, version uint32, fieldMaskPaths []string, opt ...Option) (*ClaimRule, int, error) {
+	if rule == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update claim rule: missing claim rule %w", db.ErrInvalidParameter)
+	}
+	if rule.ClaimRule == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update claim rule: missing claim rule store %w", db.ErrInvalidParameter)
+	}
+	if rule.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update claim rule: missing claim rule public id %w", db.ErrInvalidParameter)
+	}
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f):
+		case strings.EqualFold("description", f):
+		case strings.EqualFold("claimname", f):
+		case strings.EqualFold("claimvalue", f):
+		case strings.EqualFold("roleid", f):
+		case strings.EqualFold("groupid", f):
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update claim rule: field: %s: %w", f, db.ErrInvalidFieldMask)
+		}
+	}
+	var dbMask, nullFields []string
+	dbMask, nullFields = dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"name":        rule.Name,
+			"description": rule.Description,
+			"claimname":   rule.ClaimName,
+			"claimvalue":  rule.ClaimValue,
+			"roleid":      rule.RoleId,
+			"groupid":     rule.GroupId,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update claim rule: %w", db.ErrEmptyFieldMask)
+	}
+	for _, f := range nullFields {
+		switch {
+		case strings.EqualFold("claimname", f), strings.EqualFold("claimvalue", f), strings.EqualFold("roleid", f), strings.EqualFold("groupid", f):
+			return nil, db.NoRowsAffected, fmt.Errorf("update claim rule: %s cannot be empty: %w", f, db.ErrInvalidParameter)
+		}
+	}
+	c := rule.Clone()
+	resource, rowsUpdated, err := r.update(ctx, c, version, dbMask, nullFields, opt...)
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update claim rule: claim rule %s already exists in org %s: %w", rule.Name, rule.ScopeId, db.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update claim rule: %w for %s", err, rule.PublicId)
+	}
+	return resource.(*ClaimRule), rowsUpdated, nil
+}
+
+// LookupClaimRule will look up a claim rule in the repository. If the claim
+// rule is not found, it will return nil, nil.
+func (r *Repository) LookupClaimRule(ctx context.Context, withPublicId string, opt ...Option) (*ClaimRule, error) {
+	if withPublicId == "" {
+		return nil, fmt.Errorf("lookup claim rule: missing public id %w", db.ErrInvalidParameter)
+	}
+	rule := allocClaimRule()
+	rule.PublicId = withPublicId
+	if err := r.reader.LookupByPublicId(ctx, &rule); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup claim rule: failed %w for %s", err, withPublicId)
+	}
+	return &rule, nil
+}
+
+// DeleteClaimRule will delete a claim rule from the repository. Supports the
+// WithDryRun option.
+func (r *Repository) DeleteClaimRule(ctx context.Context, withPublicId string, opt ...Option) (int, error) {
+	if withPublicId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete claim rule: missing public id %w", db.ErrInvalidParameter)
+	}
+	rule := allocClaimRule()
+	rule.PublicId = withPublicId
+	if err := r.reader.LookupByPublicId(ctx, &rule); err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete claim rule: failed %w for %s", err, withPublicId)
+	}
+	rowsDeleted, err := r.delete(ctx, &rule, opt...)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete claim rule: failed %w for %s", err, withPublicId)
+	}
+	return rowsDeleted, nil
+}
+
+// ListClaimRules in an org and supports the WithLimit, WithPageToken and
+// WithNextPageToken options.
+func (r *Repository) ListClaimRules(ctx context.Context, withOrgId string, opt ...Option) ([]*ClaimRule, error) {
+	if withOrgId == "" {
+		return nil, fmt.Errorf("list claim rules: missing org id %w", db.ErrInvalidParameter)
+	}
+	var rules []*ClaimRule
+	if err := r.list(ctx, &rules, "scope_id = ?", []interface{}{withOrgId}, opt...); err != nil {
+		return nil, fmt.Errorf("list claim rules: %w", err)
+	}
+	return rules, nil
+}
+
+// ApplyClaimRules evaluates the org's claim rules against the claims presented
+// by the user's identity provider at login time. The user is added as a
+// principal of every role, and a member of every group, mapped by a matching
+// rule. The user is removed from the groups mapped by rules when none of the
+// group's rules match, since those groups are managed by the identity
+// provider. It is for auth methods backed by an identity provider, after
+// LookupUserWithLogin, which can auto-create the user; password logins present
+// no claims. The returned matches are sorted by role id, then group id, and
+// describe the effect of each matching rule and each removal.
+//
+// Supports the WithDryRun option, which evaluates the rules and reports the
+// matches without changing any role or group, so operators can preview the
+// effect of their rules with "boundary database claim-rules". Otherwise every
+// match is evaluated as a dry run first, and nothing is changed if that
+// fails. Each role and group is then updated in its own transaction.
+func (r *Repository) ApplyClaimRules(ctx context.Context, orgId, userId string, claims map[string][]string, opt ...Option) ([]*ClaimRuleMatch, error) {
+	if orgId == "" {
+		return nil, fmt.Errorf("apply claim rules: missing org id: %w", db.ErrInvalidParameter)
+	}
+	if userId == "" {
+		return nil, fmt.Errorf("apply claim rules: missing user id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	if !opts.withDryRun {
+		// evaluate every match before changing anything, so a rule which
+		// can't be applied leaves the user's roles and groups as they were
+		if _, err := r.ApplyClaimRules(ctx, orgId, userId, claims, WithDryRun(true)); err != nil {
+			return nil, err
+		}
+	}
+
+	rules, err := r.ListClaimRules(ctx, orgId, WithLimit(-1))
+	if err != nil {
+		return nil, fmt.Errorf("apply claim rules: %w", err)
+	}
+	var matches []*ClaimRuleMatch
+	// the first rule of each group mapped by a rule, which is reported when
+	// none of the group's rules match
+	groupRules := map[string]string{}
+	matchedGroups := map[string]bool{}
+	for _, rule := range rules {
+		if rule.GroupId != "" {
+			if first, ok := groupRules[rule.GroupId]; !ok || rule.PublicId < first {
+				groupRules[rule.GroupId] = rule.PublicId
+			}
+		}
+		if !rule.Matches(claims) {
+			continue
+		}
+		matchedGroups[rule.GroupId] = true
+		matches = append(matches, &ClaimRuleMatch{
+			RuleId:  rule.PublicId,
+			RoleId:  rule.RoleId,
+			GroupId: rule.GroupId,
+		})
+	}
+	for groupId, ruleId := range groupRules {
+		if !matchedGroups[groupId] {
+			matches = append(matches, &ClaimRuleMatch{
+				RuleId:    ruleId,
+				GroupId:   groupId,
+				Unmatched: true,
+			})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		switch {
+		case matches[i].RoleId != matches[j].RoleId:
+			return matches[i].RoleId < matches[j].RoleId
+		case matches[i].GroupId != matches[j].GroupId:
+			return matches[i].GroupId < matches[j].GroupId
+		}
+		return matches[i].RuleId < matches[j].RuleId
+	})
+
+	// several rules may map the user into the same role or group; each is
+	// only handled once and the other matches report the same result
+	type target struct{ roleId, groupId string }
+	handled := map[target]*ClaimRuleMatch{}
+	results := make([]*ClaimRuleMatch, 0, len(matches))
+	for _, m := range matches {
+		t := target{roleId: m.RoleId, groupId: m.GroupId}
+		if first, ok := handled[t]; ok {
+			m.AlreadyPrincipal = first.AlreadyPrincipal
+			m.Applied = first.Applied
+			results = append(results, m)
+			continue
+		}
+		handled[t] = m
+		var err error
+		if m.RoleId != "" {
+			err = r.applyRoleClaimRule(ctx, m, userId, opts.withDryRun)
+		} else {
+			err = r.applyGroupClaimRule(ctx, m, userId, opts.withDryRun)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("apply claim rules: %w", err)
+		}
+		if m.Unmatched && !m.AlreadyPrincipal {
+			// the user isn't a member of the group, so there's nothing to
+			// remove them from
+			continue
+		}
+		results = append(results, m)
+	}
+	return results, nil
+}
+
+// applyRoleClaimRule adds the user as a principal of the role of the match,
+// unless they already are one or dryRun is set. The role is looked up either
+// way, so a dry run fails where applying the match would.
+func (r *Repository) applyRoleClaimRule(ctx context.Context, m *ClaimRuleMatch, userId string, dryRun bool) error {
+	principals, err := r.ListPrincipalRoles(ctx, m.RoleId, WithLimit(-1))
+	if err != nil {
+		return err
+	}
+	for _, p := range principals {
+		if p.PrincipalId == userId {
+			m.AlreadyPrincipal = true
+			break
+		}
+	}
+	if m.AlreadyPrincipal {
+		return nil
+	}
+	role, _, _, err := r.LookupRole(ctx, m.RoleId)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return fmt.Errorf("role %s for rule %s not found: %w", m.RoleId, m.RuleId, db.ErrRecordNotFound)
+	}
+	if dryRun {
+		return nil
+	}
+	if _, err := r.AddPrincipalRoles(ctx, m.RoleId, role.Version, []string{userId}); err != nil {
+		return fmt.Errorf("rule %s: %w", m.RuleId, err)
+	}
+	m.Applied = true
+	return nil
+}
+
+// applyGroupClaimRule adds the user as a member of the group of the match, or
+// for Unmatched matches removes them from it, unless dryRun is set. The group
+// is looked up either way, so a dry run fails where applying the match would.
+func (r *Repository) applyGroupClaimRule(ctx context.Context, m *ClaimRuleMatch, userId string, dryRun bool) error {
+	members, err := r.ListGroupMembers(ctx, m.GroupId, WithLimit(-1))
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if member.MemberId == userId {
+			m.AlreadyPrincipal = true
+			break
+		}
+	}
+	if m.AlreadyPrincipal != m.Unmatched {
+		// already a member of a matched group, or not a member of an
+		// unmatched one
+		return nil
+	}
+	group, _, err := r.LookupGroup(ctx, m.GroupId)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("group %s for rule %s not found: %w", m.GroupId, m.RuleId, db.ErrRecordNotFound)
+	}
+	if dryRun {
+		return nil
+	}
+	if m.Unmatched {
+		if _, err := r.DeleteGroupMembers(ctx, m.GroupId, group.Version, []string{userId}); err != nil {
+			return fmt.Errorf("rule %s: %w", m.RuleId, err)
+		}
+	} else {
+		if _, err := r.AddGroupMembers(ctx, m.GroupId, group.Version, []string{userId}); err != nil {
+			return fmt.Errorf("rule %s: %w", m.RuleId, err)
+		}
+	}
+	m.Applied = true
+	return nil
+}
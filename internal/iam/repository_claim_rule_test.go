@@ -0,0 +1,223 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ClaimRules(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	org, proj := TestScopes(t, repo)
+	otherOrg, _ := TestScopes(t, repo)
+	paymentsRole := TestRole(t, conn, proj.PublicId)
+	loginRole := TestRole(t, conn, org.PublicId)
+
+	newRule := func(claimName, claimValue, roleId string) *ClaimRule {
+		r, err := NewClaimRule(org.PublicId, claimName, claimValue, roleId)
+		require.NoError(err)
+		return r
+	}
+	payments, err := repo.CreateClaimRule(ctx, newRule("department", "payments", paymentsRole.PublicId))
+	require.NoError(err)
+	db.AssertPublicId(t, ClaimRulePrefix, payments.PublicId)
+	_, err = repo.CreateClaimRule(ctx, newRule("email_verified", ClaimRuleAnyValue, loginRole.PublicId))
+	require.NoError(err)
+
+	// a rule must reference a role within its org
+	otherRole := TestRole(t, conn, otherOrg.PublicId)
+	_, err = repo.CreateClaimRule(ctx, newRule("department", "payments", otherRole.PublicId))
+	require.Error(err)
+
+	found, err := repo.LookupClaimRule(ctx, payments.PublicId)
+	require.NoError(err)
+	assert.Equal(payments.RoleId, found.RoleId)
+
+	// a second rule which maps into the same role
+	_, err = repo.CreateClaimRule(ctx, newRule("groups", "payments-eng", paymentsRole.PublicId))
+	require.NoError(err)
+
+	rules, err := repo.ListClaimRules(ctx, org.PublicId)
+	require.NoError(err)
+	assert.Len(rules, 3)
+
+	user := TestUser(t, repo, org.PublicId)
+	claims := map[string][]string{
+		"department":     {"payments"},
+		"email_verified": {"true"},
+		"groups":         {"payments-eng"},
+	}
+
+	matches, err := repo.ApplyClaimRules(ctx, org.PublicId, user.PublicId, claims, WithDryRun(true))
+	require.NoError(err)
+	require.Len(matches, 3)
+	for _, m := range matches {
+		assert.False(m.Applied)
+		assert.False(m.AlreadyPrincipal)
+	}
+	principals, err := repo.ListPrincipalRoles(ctx, paymentsRole.PublicId)
+	require.NoError(err)
+	assert.Empty(principals)
+
+	matches, err = repo.ApplyClaimRules(ctx, org.PublicId, user.PublicId, claims)
+	require.NoError(err)
+	require.Len(matches, 3)
+	for _, m := range matches {
+		assert.True(m.Applied)
+	}
+	principals, err = repo.ListPrincipalRoles(ctx, paymentsRole.PublicId)
+	require.NoError(err)
+	require.Len(principals, 1)
+	assert.Equal(user.PublicId, principals[0].PrincipalId)
+
+	// applying again is a no-op
+	matches, err = repo.ApplyClaimRules(ctx, org.PublicId, user.PublicId, claims)
+	require.NoError(err)
+	require.Len(matches, 3)
+	for _, m := range matches {
+		assert.False(m.Applied)
+		assert.True(m.AlreadyPrincipal)
+	}
+
+	payments.ClaimValue = "fraud"
+	payments.Description = "fraud team"
+	updated, rowsUpdated, err := repo.UpdateClaimRule(ctx, payments, payments.Version, []string{"ClaimValue", "Description"})
+	require.NoError(err)
+	assert.Equal(1, rowsUpdated)
+	assert.Equal("fraud", updated.ClaimValue)
+	assert.Equal(payments.Version+1, updated.Version)
+
+	_, _, err = repo.UpdateClaimRule(ctx, updated, updated.Version, []string{"ScopeId"})
+	require.Error(err)
+	assert.True(errors.Is(err, db.ErrInvalidFieldMask))
+	updated.RoleId = otherRole.PublicId
+	_, _, err = repo.UpdateClaimRule(ctx, updated, updated.Version, []string{"RoleId"})
+	require.Error(err)
+
+	rowsDeleted, err := repo.DeleteClaimRule(ctx, payments.PublicId)
+	require.NoError(err)
+	assert.Equal(1, rowsDeleted)
+	found, err = repo.LookupClaimRule(ctx, payments.PublicId)
+	require.NoError(err)
+	assert.Nil(found)
+}
+
+func TestRepository_GroupClaimRules(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	rw := db.New(conn)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	org, proj := TestScopes(t, repo)
+	otherOrg, _ := TestScopes(t, repo)
+	engGroup := TestGroup(t, conn, proj.PublicId)
+	oncallGroup := TestGroup(t, conn, org.PublicId)
+	manualGroup := TestGroup(t, conn, org.PublicId)
+
+	newRule := func(claimName, claimValue, groupId string) *ClaimRule {
+		r, err := NewGroupClaimRule(org.PublicId, claimName, claimValue, groupId)
+		require.NoError(err)
+		return r
+	}
+	eng, err := repo.CreateClaimRule(ctx, newRule("groups", "eng", engGroup.PublicId))
+	require.NoError(err)
+	assert.Equal(engGroup.PublicId, eng.GroupId)
+	assert.Empty(eng.RoleId)
+	assert.NoError(db.TestVerifyOplog(t, rw, eng.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
+	_, err = repo.CreateClaimRule(ctx, newRule("groups", "oncall", oncallGroup.PublicId))
+	require.NoError(err)
+
+	// a rule must reference a group within its org, and either a role or a
+	// group but not both
+	otherGroup := TestGroup(t, conn, otherOrg.PublicId)
+	_, err = repo.CreateClaimRule(ctx, newRule("groups", "eng", otherGroup.PublicId))
+	require.Error(err)
+	both := newRule("groups", "both", engGroup.PublicId)
+	both.RoleId = TestRole(t, conn, org.PublicId).PublicId
+	_, err = repo.CreateClaimRule(ctx, both)
+	require.Error(err)
+
+	user := TestUser(t, repo, org.PublicId)
+	TestGroupMember(t, conn, oncallGroup.PublicId, user.PublicId)
+	TestGroupMember(t, conn, manualGroup.PublicId, user.PublicId)
+	claims := map[string][]string{"groups": {"eng"}}
+	memberOf := func(groupId string) bool {
+		t.Helper()
+		members, err := repo.ListGroupMembers(ctx, groupId)
+		require.NoError(err)
+		for _, m := range members {
+			if m.MemberId == user.PublicId {
+				return true
+			}
+		}
+		return false
+	}
+
+	matches, err := repo.ApplyClaimRules(ctx, org.PublicId, user.PublicId, claims, WithDryRun(true))
+	require.NoError(err)
+	require.Len(matches, 2)
+	assert.False(memberOf(engGroup.PublicId))
+	assert.True(memberOf(oncallGroup.PublicId))
+
+	matches, err = repo.ApplyClaimRules(ctx, org.PublicId, user.PublicId, claims)
+	require.NoError(err)
+	want := map[string]*ClaimRuleMatch{
+		engGroup.PublicId: {RuleId: eng.PublicId, GroupId: engGroup.PublicId, Applied: true},
+	}
+	require.Len(matches, 2)
+	for _, m := range matches {
+		if m.GroupId == engGroup.PublicId {
+			assert.Equal(want[m.GroupId], m)
+			continue
+		}
+		assert.Equal(oncallGroup.PublicId, m.GroupId)
+		assert.True(m.Unmatched)
+		assert.True(m.AlreadyPrincipal)
+		assert.True(m.Applied)
+	}
+	assert.True(memberOf(engGroup.PublicId))
+	// removed, since the group is managed by a rule which didn't match
+	assert.False(memberOf(oncallGroup.PublicId))
+	// not managed by any rule, so left alone
+	assert.True(memberOf(manualGroup.PublicId))
+
+	// applying again is a no-op
+	matches, err = repo.ApplyClaimRules(ctx, org.PublicId, user.PublicId, claims)
+	require.NoError(err)
+	require.Len(matches, 1)
+	assert.True(matches[0].AlreadyPrincipal)
+	assert.False(matches[0].Applied)
+
+	// a group rule can't be changed to a role rule
+	eng.GroupId = ""
+	_, _, err = repo.UpdateClaimRule(ctx, eng, eng.Version, []string{"GroupId"})
+	require.Error(err)
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+	eng.GroupId = oncallGroup.PublicId
+	updated, rowsUpdated, err := repo.UpdateClaimRule(ctx, eng, eng.Version, []string{"GroupId"})
+	require.NoError(err)
+	assert.Equal(1, rowsUpdated)
+	assert.Equal(oncallGroup.PublicId, updated.GroupId)
+
+	// deleting the group deletes its rules
+	_, err = repo.DeleteGroup(ctx, oncallGroup.PublicId)
+	require.NoError(err)
+	found, err := repo.LookupClaimRule(ctx, eng.PublicId)
+	require.NoError(err)
+	assert.Nil(found)
+}
@@ -0,0 +1,186 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrentWorkers is the number of goroutines each concurrency test runs.
+const concurrentWorkers = 10
+
+// hammer runs fn in workers goroutines which are released at the same time
+// and returns the error of each worker.
+func hammer(workers int, fn func(worker int) error) []error {
+	errs := make([]error, workers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = fn(i)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	return errs
+}
+
+// countSucceeded returns the number of nil errors.
+func countSucceeded(errs []error) int {
+	var n int
+	for _, err := range errs {
+		if err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// testOplogEntries returns the number of oplog entries written for the
+// resource.
+func testOplogEntries(t *testing.T, rw *db.Db, resourcePublicId string) int {
+	t.Helper()
+	require := require.New(t)
+	rows, err := rw.Query(context.Background(),
+		"select count(distinct entry_id) from oplog_metadata where key = 'resource-public-id' and value = ?",
+		[]interface{}{resourcePublicId})
+	require.NoError(err)
+	defer rows.Close()
+	require.True(rows.Next())
+	var count int
+	require.NoError(rows.Scan(&count))
+	return count
+}
+
+func TestRepository_ConcurrentWrites(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	ctx := context.Background()
+
+	t.Run("SetRoleGrants", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, proj.PublicId)
+		grants := func(worker int) []string {
+			return []string{fmt.Sprintf("id=hsc_%010d;actions=read", worker)}
+		}
+
+		// Every worker sets grants for the same version, so exactly one can
+		// succeed.
+		errs := hammer(concurrentWorkers, func(worker int) error {
+			_, _, _, err := repo.SetRoleGrants(ctx, role.PublicId, role.Version, grants(worker))
+			return err
+		})
+		require.Equal(1, countSucceeded(errs), "errors: %v", errs)
+
+		var winner int
+		for i, err := range errs {
+			if err == nil {
+				winner = i
+			}
+		}
+		got, _, _, err := repo.LookupRole(ctx, role.PublicId)
+		require.NoError(err)
+		assert.Equal(role.Version+1, got.Version)
+		roleGrants, err := repo.ListRoleGrants(ctx, role.PublicId)
+		require.NoError(err)
+		require.Len(roleGrants, 1)
+		assert.Equal(grants(winner)[0], roleGrants[0].GetRawGrant())
+		assert.Equal(1, testOplogEntries(t, rw, role.PublicId))
+	})
+
+	t.Run("AddPrincipalRoles", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, proj.PublicId)
+		users := make([]string, concurrentWorkers)
+		for i := range users {
+			users[i] = TestUser(t, repo, org.PublicId).PublicId
+		}
+
+		// Every worker retries with the latest version until its user is
+		// added, so every add must eventually succeed once.
+		const maxAttempts = 3 * concurrentWorkers
+		errs := hammer(concurrentWorkers, func(worker int) error {
+			var err error
+			for attempt := 0; attempt < maxAttempts; attempt++ {
+				var r *Role
+				r, _, _, err = repo.LookupRole(ctx, role.PublicId)
+				if err != nil {
+					return err
+				}
+				if _, err = repo.AddPrincipalRoles(ctx, role.PublicId, r.Version, []string{users[worker]}); err == nil {
+					return nil
+				}
+			}
+			return err
+		})
+		require.Equal(concurrentWorkers, countSucceeded(errs), "errors: %v", errs)
+
+		got, principals, _, err := repo.LookupRole(ctx, role.PublicId)
+		require.NoError(err)
+		assert.Equal(role.Version+concurrentWorkers, got.Version)
+		var gotUsers []string
+		for _, p := range principals {
+			gotUsers = append(gotUsers, p.PrincipalId)
+		}
+		assert.ElementsMatch(users, gotUsers)
+		assert.Equal(concurrentWorkers, testOplogEntries(t, rw, role.PublicId))
+	})
+
+	t.Run("DeleteRole", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, proj.PublicId)
+
+		// Half the workers delete the role and the other half add grants to
+		// it. Only one delete can remove the role, and grant adds can only
+		// succeed before it.
+		deletes := make([]int, concurrentWorkers)
+		errs := hammer(concurrentWorkers, func(worker int) error {
+			if worker%2 == 0 {
+				var err error
+				deletes[worker], err = repo.DeleteRole(ctx, role.PublicId)
+				return err
+			}
+			r, _, _, err := repo.LookupRole(ctx, role.PublicId)
+			if err != nil {
+				return err
+			}
+			if r == nil {
+				return fmt.Errorf("role %s not found", role.PublicId)
+			}
+			_, err = repo.AddRoleGrants(ctx, role.PublicId, r.Version, []string{fmt.Sprintf("id=hsc_%010d;actions=read", worker)})
+			return err
+		})
+		var deleted, added int
+		for i, err := range errs {
+			if err != nil {
+				continue
+			}
+			if i%2 == 0 {
+				deleted += deletes[i]
+			} else {
+				added++
+			}
+		}
+		assert.Equal(1, deleted, "errors: %v", errs)
+
+		got, _, _, err := repo.LookupRole(ctx, role.PublicId)
+		require.NoError(err)
+		assert.Nil(got)
+		roleGrants, err := repo.ListRoleGrants(ctx, role.PublicId)
+		require.NoError(err)
+		assert.Empty(roleGrants)
+		assert.Equal(added+1, testOplogEntries(t, rw, role.PublicId))
+	})
+}
@@ -0,0 +1,104 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_DryRun(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	repo := TestRepo(t, conn, wrapper, WithQuotas(Quotas{MaxGrantsPerRole: 2}))
+	org, proj := TestScopes(t, repo)
+
+	t.Run("create", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role, err := NewRole(proj.PublicId, WithName("dry-run-create"))
+		require.NoError(err)
+		created, err := repo.CreateRole(ctx, role, WithDryRun(true))
+		require.NoError(err)
+		assert.NotEmpty(created.PublicId)
+		assert.NotNil(created.CreateTime)
+		found, _, _, err := repo.LookupRole(ctx, created.PublicId)
+		require.NoError(err)
+		assert.Nil(found)
+
+		// Uniqueness is still checked
+		_, err = repo.CreateRole(ctx, role)
+		require.NoError(err)
+		_, err = repo.CreateRole(ctx, role, WithDryRun(true))
+		assert.True(errors.Is(err, db.ErrNotUnique))
+	})
+	t.Run("update", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, proj.PublicId, WithName("dry-run-update"))
+		role.Name = "updated"
+		updated, _, _, rowsUpdated, err := repo.UpdateRole(ctx, role, 1, []string{"Name"}, WithDryRun(true))
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		assert.Equal("updated", updated.Name)
+		assert.Equal(uint32(2), updated.Version)
+		found, _, _, err := repo.LookupRole(ctx, role.PublicId)
+		require.NoError(err)
+		assert.Equal("dry-run-update", found.Name)
+		assert.Equal(uint32(1), found.Version)
+	})
+	t.Run("delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		user := TestUser(t, repo, org.PublicId)
+		rowsDeleted, err := repo.DeleteUser(ctx, user.PublicId, WithDryRun(true))
+		require.NoError(err)
+		assert.Equal(1, rowsDeleted)
+		found, _, err := repo.LookupUser(ctx, user.PublicId)
+		require.NoError(err)
+		assert.NotNil(found)
+	})
+	t.Run("set-grants", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, proj.PublicId)
+		TestRoleGrant(t, conn, role.PublicId, "id=*;type=*;actions=read")
+		grants, diff, rowsDeleted, err := repo.SetRoleGrants(ctx, role.PublicId, 1, []string{"id=*;type=*;actions=update"}, WithDryRun(true))
+		require.NoError(err)
+		assert.Equal(1, rowsDeleted)
+		require.Len(grants, 1)
+		assert.Equal("id=*;type=*;actions=update", grants[0].CanonicalGrant)
+		assert.Len(diff.Added, 1)
+		assert.Len(diff.Removed, 1)
+		current, err := repo.ListRoleGrants(ctx, role.PublicId)
+		require.NoError(err)
+		require.Len(current, 1)
+		assert.Equal("id=*;type=*;actions=read", current[0].CanonicalGrant)
+
+		// Quotas are still checked
+		_, _, _, err = repo.SetRoleGrants(ctx, role.PublicId, 1, []string{"id=*;type=*;actions=read", "id=*;type=*;actions=update", "id=*;type=*;actions=delete"}, WithDryRun(true))
+		assert.True(errors.Is(err, ErrQuotaExceeded))
+	})
+	t.Run("create-scope", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s, err := NewProject(org.PublicId)
+		require.NoError(err)
+		id, err := newScopeId(scope.Project)
+		require.NoError(err)
+		created, err := repo.CreateScope(ctx, s, "", WithPublicId(id), WithDryRun(true))
+		require.NoError(err)
+		assert.Equal(id, created.PublicId)
+		found, err := repo.LookupScope(ctx, id)
+		require.NoError(err)
+		assert.Nil(found)
+		// The keys read in the dry run were rolled back, so they aren't cached
+		_, ok := repo.kms.GetScopePurposeCache().Load(id + kms.KeyPurposeOplog.String())
+		assert.False(ok)
+
+		_, err = repo.CreateScope(ctx, s, "", WithPublicId(id))
+		require.NoError(err)
+	})
+}
@@ -0,0 +1,300 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// SetEmergencyRole makes the role an emergency role, which isn't considered
+// when resolving grants unless one of its principals has activated it, and
+// sets the longest an activation can last. Activations in effect keep their
+// expiration time.
+func (r *Repository) SetEmergencyRole(ctx context.Context, roleId string, maxDuration time.Duration) error {
+	if roleId == "" {
+		return fmt.Errorf("set emergency role: missing role id: %w", db.ErrInvalidParameter)
+	}
+	if maxDuration < time.Second {
+		return fmt.Errorf("set emergency role: max duration must be at least a second: %w", db.ErrInvalidParameter)
+	}
+	if _, err := r.writer.Exec(ctx, upsertEmergencyRole, []interface{}{roleId, int(maxDuration / time.Second)}); err != nil {
+		return fmt.Errorf("set emergency role: %w for %s", err, roleId)
+	}
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	return nil
+}
+
+// UnsetEmergencyRole makes the emergency role an ordinary role again, which is
+// always considered when resolving grants, and deletes its activations. It
+// returns the number of emergency roles unset.
+func (r *Repository) UnsetEmergencyRole(ctx context.Context, roleId string) (int, error) {
+	if roleId == "" {
+		return db.NoRowsAffected, fmt.Errorf("unset emergency role: missing role id: %w", db.ErrInvalidParameter)
+	}
+	rowsDeleted, err := r.writer.Exec(ctx, deleteEmergencyRole, []interface{}{roleId})
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("unset emergency role: %w for %s", err, roleId)
+	}
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	return rowsDeleted, nil
+}
+
+// ActivateEmergencyRole activates the emergency role for the duration, or for
+// the role's max duration if the duration is zero, and returns the
+// activation. The user activating it must be an active principal of the role,
+// directly or through a group, and must give a justification. The role can't
+// be activated again until the activation is over.
+func (r *Repository) ActivateEmergencyRole(ctx context.Context, roleId, userId, justification string, duration time.Duration) (*EmergencyActivation, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("activate emergency role: missing role id: %w", db.ErrInvalidParameter)
+	}
+	if userId == "" {
+		return nil, fmt.Errorf("activate emergency role: missing user id: %w", db.ErrInvalidParameter)
+	}
+	if strings.TrimSpace(justification) == "" {
+		return nil, fmt.Errorf("activate emergency role: missing justification: %w", db.ErrInvalidParameter)
+	}
+	if duration < 0 {
+		return nil, fmt.Errorf("activate emergency role: negative duration: %w", db.ErrInvalidParameter)
+	}
+	now := r.now()
+	var activation *EmergencyActivation
+	var reverted []*EmergencyActivation
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			maxDuration, err := emergencyRoleMaxDuration(ctx, reader, roleId)
+			if err != nil {
+				return err
+			}
+			d := duration
+			if d == 0 {
+				d = maxDuration
+			}
+			if d > maxDuration {
+				return fmt.Errorf("duration %s is longer than the role's max duration of %s: %w", d, maxDuration, db.ErrInvalidParameter)
+			}
+			rows, err := reader.Query(ctx, userIsRolePrincipal, []interface{}{roleId, userId})
+			if err != nil {
+				return fmt.Errorf("unable to check the role's principals: %w", err)
+			}
+			defer rows.Close()
+			var isPrincipal bool
+			for rows.Next() {
+				if err := rows.Scan(&isPrincipal); err != nil {
+					return fmt.Errorf("unable to scan the role's principals: %w", err)
+				}
+			}
+			if err := rows.Err(); err != nil {
+				return fmt.Errorf("unable to check the role's principals: %w", err)
+			}
+			if !isPrincipal {
+				return fmt.Errorf("user %s is not a principal of role %s: %w", userId, roleId, ErrWriteRejected)
+			}
+			// An activation which has expired but hasn't been reverted yet
+			// would stop the role being activated again.
+			if reverted, err = revertExpiredEmergencyActivations(ctx, reader, w, now, roleId); err != nil {
+				return err
+			}
+			expiration := now.Add(d).Truncate(time.Microsecond)
+			if _, err := w.Exec(ctx, insertEmergencyActivation, []interface{}{roleId, userId, justification, expiration}); err != nil {
+				if db.IsUniqueError(err) {
+					return fmt.Errorf("role %s is already activated: %w", roleId, db.ErrNotUnique)
+				}
+				return err
+			}
+			rows, err = reader.Query(ctx, currentEmergencyActivation, []interface{}{roleId})
+			if err != nil {
+				return fmt.Errorf("unable to lookup activation: %w", err)
+			}
+			activations, err := scanEmergencyActivations(rows)
+			if err != nil {
+				return fmt.Errorf("unable to lookup activation: %w", err)
+			}
+			if len(activations) != 1 {
+				return fmt.Errorf("unable to lookup activation: found %d: %w", len(activations), db.ErrRecordNotFound)
+			}
+			activation = activations[0]
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("activate emergency role: %w", err)
+	}
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	for _, a := range reverted {
+		r.emitEmergencyRoleEvent(EmergencyRoleReverted, a, "")
+	}
+	r.emitEmergencyRoleEvent(EmergencyRoleActivated, activation, userId)
+	return activation, nil
+}
+
+// RevertEmergencyRole ends the activation of the emergency role before it
+// expires and returns the reverted activation. The user of the context's
+// request info, if it has one, is given as the user reverting it.
+func (r *Repository) RevertEmergencyRole(ctx context.Context, roleId string) (*EmergencyActivation, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("revert emergency role: missing role id: %w", db.ErrInvalidParameter)
+	}
+	now := r.now()
+	var activation *EmergencyActivation
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rows, err := reader.Query(ctx, currentEmergencyActivation, []interface{}{roleId})
+			if err != nil {
+				return fmt.Errorf("unable to lookup activation: %w", err)
+			}
+			activations, err := scanEmergencyActivations(rows)
+			if err != nil {
+				return fmt.Errorf("unable to lookup activation: %w", err)
+			}
+			if len(activations) == 0 {
+				return fmt.Errorf("role %s is not activated: %w", roleId, db.ErrRecordNotFound)
+			}
+			activation = activations[0]
+			if err := revertActivation(ctx, w, activation, now); err != nil {
+				return err
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("revert emergency role: %w", err)
+	}
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	var requestUserId string
+	if ri, ok := db.RequestInfoFromContext(ctx); ok {
+		requestUserId = ri.UserId
+	}
+	r.emitEmergencyRoleEvent(EmergencyRoleReverted, activation, requestUserId)
+	return activation, nil
+}
+
+// RevertExpiredEmergencyActivations records the end of the emergency role
+// activations which have expired and returns how many there were. Expired
+// activations stop applying when they expire whether or not they've been
+// reverted; reverting them records it and lets the roles be activated again.
+func (r *Repository) RevertExpiredEmergencyActivations(ctx context.Context) (int, error) {
+	now := r.now()
+	var reverted []*EmergencyActivation
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var err error
+			reverted, err = revertExpiredEmergencyActivations(ctx, reader, w, now, "")
+			return err
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("revert expired emergency activations: %w", err)
+	}
+	if len(reverted) > 0 {
+		roleIds := make([]string, 0, len(reverted))
+		for _, a := range reverted {
+			roleIds = append(roleIds, a.RoleId)
+		}
+		r.invalidateGrantsCacheForRoles(ctx, roleIds...)
+	}
+	for _, a := range reverted {
+		r.emitEmergencyRoleEvent(EmergencyRoleReverted, a, "")
+	}
+	return len(reverted), nil
+}
+
+// ListEmergencyActivations lists the activations of the emergency role, most
+// recent first.
+func (r *Repository) ListEmergencyActivations(ctx context.Context, roleId string) ([]*EmergencyActivation, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("list emergency activations: missing role id: %w", db.ErrInvalidParameter)
+	}
+	rows, err := r.reader.Query(ctx, listEmergencyActivations, []interface{}{roleId})
+	if err != nil {
+		return nil, fmt.Errorf("list emergency activations: %w", err)
+	}
+	activations, err := scanEmergencyActivations(rows)
+	if err != nil {
+		return nil, fmt.Errorf("list emergency activations: %w", err)
+	}
+	return activations, nil
+}
+
+// emergencyRoleMaxDuration returns the max duration of the emergency role's
+// activations, or an error if the role isn't an emergency role.
+func emergencyRoleMaxDuration(ctx context.Context, reader db.Reader, roleId string) (time.Duration, error) {
+	rows, err := reader.Query(ctx, lookupEmergencyRole, []interface{}{roleId})
+	if err != nil {
+		return 0, fmt.Errorf("unable to lookup emergency role: %w", err)
+	}
+	defer rows.Close()
+	var seconds int
+	for rows.Next() {
+		if err := rows.Scan(&seconds); err != nil {
+			return 0, fmt.Errorf("unable to scan emergency role: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("unable to lookup emergency role: %w", err)
+	}
+	if seconds == 0 {
+		return 0, fmt.Errorf("role %s is not an emergency role: %w", roleId, db.ErrInvalidParameter)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// revertExpiredEmergencyActivations reverts the activations of the role, or
+// of all roles if roleId is empty, which expired by now and returns them.
+func revertExpiredEmergencyActivations(ctx context.Context, reader db.Reader, w db.Writer, now time.Time, roleId string) ([]*EmergencyActivation, error) {
+	rows, err := reader.Query(ctx, expiredEmergencyActivations, []interface{}{now, roleId})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list expired activations: %w", err)
+	}
+	expired, err := scanEmergencyActivations(rows)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list expired activations: %w", err)
+	}
+	var reverted []*EmergencyActivation
+	for _, a := range expired {
+		if err := revertActivation(ctx, w, a, now); err != nil {
+			return nil, err
+		}
+		if a.RevertTime != nil {
+			reverted = append(reverted, a)
+		}
+	}
+	return reverted, nil
+}
+
+// revertActivation reverts the activation at now, or when it expired if
+// that's earlier, and sets its RevertTime if it wasn't already reverted.
+func revertActivation(ctx context.Context, w db.Writer, a *EmergencyActivation, now time.Time) error {
+	revertTime := now.Truncate(time.Microsecond)
+	if a.ExpirationTime.Before(revertTime) {
+		revertTime = a.ExpirationTime
+	}
+	rowsUpdated, err := w.Exec(ctx, revertEmergencyActivation, []interface{}{a.Id, revertTime})
+	if err != nil {
+		return fmt.Errorf("unable to revert activation of %s: %w", a.RoleId, err)
+	}
+	if rowsUpdated == 1 {
+		a.RevertTime = &revertTime
+	}
+	return nil
+}
+
+// emitEmergencyRoleEvent passes the event to the repository's emergency role
+// events function, if it has one.
+func (r *Repository) emitEmergencyRoleEvent(typ EmergencyRoleEventType, a *EmergencyActivation, requestUserId string) {
+	if r.emergencyRoleEvents != nil {
+		r.emergencyRoleEvents(&EmergencyRoleEvent{Type: typ, Activation: a, RequestUserId: requestUserId})
+	}
+}
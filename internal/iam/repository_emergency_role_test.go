@@ -0,0 +1,133 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/clock"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_EmergencyRole(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	var events []*EmergencyRoleEvent
+	repo := TestRepo(t, conn, wrapper, WithEmergencyRoleEvents(func(e *EmergencyRoleEvent) { events = append(events, e) }))
+	org, _ := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	ctx := context.Background()
+
+	user := TestUser(t, repo, org.PublicId)
+	other := TestUser(t, repo, org.PublicId)
+	role := TestRole(t, conn, org.PublicId)
+	TestRoleGrant(t, conn, role.PublicId, "id=*;actions=*")
+	TestUserRole(t, conn, role.PublicId, user.PublicId)
+
+	orgGrants := func() []string {
+		t.Helper()
+		grants, err := repo.GrantsForUser(ctx, user.PublicId)
+		require.NoError(err)
+		var got []string
+		for _, g := range grants {
+			if g.ScopeId == org.PublicId {
+				got = append(got, g.Grant)
+			}
+		}
+		return got
+	}
+	assert.Equal([]string{"id=*;actions=*"}, orgGrants())
+
+	_, err := repo.ActivateEmergencyRole(ctx, role.PublicId, user.PublicId, "outage", 0)
+	assert.True(errors.Is(err, db.ErrInvalidParameter), "not an emergency role")
+
+	err = repo.SetEmergencyRole(ctx, role.PublicId, time.Millisecond)
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+	require.NoError(repo.SetEmergencyRole(ctx, role.PublicId, time.Hour))
+	assert.Empty(orgGrants())
+
+	_, err = repo.ActivateEmergencyRole(ctx, role.PublicId, user.PublicId, " ", 0)
+	assert.True(errors.Is(err, db.ErrInvalidParameter), "missing justification")
+	_, err = repo.ActivateEmergencyRole(ctx, role.PublicId, user.PublicId, "outage", 2*time.Hour)
+	assert.True(errors.Is(err, db.ErrInvalidParameter), "longer than max duration")
+	_, err = repo.ActivateEmergencyRole(ctx, role.PublicId, other.PublicId, "outage", 0)
+	assert.True(errors.Is(err, ErrWriteRejected), "not a principal")
+	assert.Empty(events)
+
+	activation, err := repo.ActivateEmergencyRole(ctx, role.PublicId, user.PublicId, "INC-1234 outage", 30*time.Minute)
+	require.NoError(err)
+	assert.Equal(role.PublicId, activation.RoleId)
+	assert.Equal(user.PublicId, activation.UserId)
+	assert.Equal("INC-1234 outage", activation.Justification)
+	assert.Nil(activation.RevertTime)
+	assert.WithinDuration(time.Now().Add(30*time.Minute), activation.ExpirationTime, time.Minute)
+	assert.Equal([]string{"id=*;actions=*"}, orgGrants())
+	require.Len(events, 1)
+	assert.Equal(EmergencyRoleActivated, events[0].Type)
+	assert.Equal(user.PublicId, events[0].RequestUserId)
+
+	_, err = repo.ActivateEmergencyRole(ctx, role.PublicId, user.PublicId, "again", 0)
+	assert.True(errors.Is(err, db.ErrNotUnique))
+
+	reverted, err := repo.RevertEmergencyRole(WithRequestInfo(ctx, other.PublicId, org.PublicId), role.PublicId)
+	require.NoError(err)
+	assert.NotNil(reverted.RevertTime)
+	assert.Empty(orgGrants())
+	require.Len(events, 2)
+	assert.Equal(EmergencyRoleReverted, events[1].Type)
+	assert.Equal(other.PublicId, events[1].RequestUserId)
+
+	_, err = repo.RevertEmergencyRole(ctx, role.PublicId)
+	assert.True(errors.Is(err, db.ErrRecordNotFound))
+
+	activations, err := repo.ListEmergencyActivations(ctx, role.PublicId)
+	require.NoError(err)
+	require.Len(activations, 1)
+	assert.NotNil(activations[0].RevertTime)
+
+	unset, err := repo.UnsetEmergencyRole(ctx, role.PublicId)
+	require.NoError(err)
+	assert.Equal(1, unset)
+	assert.Equal([]string{"id=*;actions=*"}, orgGrants())
+}
+
+func TestRepository_RevertExpiredEmergencyActivations(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	c := clock.NewFake(time.Now())
+	var events []*EmergencyRoleEvent
+	repo := TestRepo(t, conn, wrapper, WithClock(c), WithEmergencyRoleEvents(func(e *EmergencyRoleEvent) { events = append(events, e) }))
+	org, _ := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	ctx := context.Background()
+
+	user := TestUser(t, repo, org.PublicId)
+	grp := TestGroup(t, conn, org.PublicId)
+	TestGroupMember(t, conn, grp.PublicId, user.PublicId)
+	role := TestRole(t, conn, org.PublicId)
+	TestGroupRole(t, conn, role.PublicId, grp.PublicId)
+	require.NoError(repo.SetEmergencyRole(ctx, role.PublicId, time.Hour))
+
+	activation, err := repo.ActivateEmergencyRole(ctx, role.PublicId, user.PublicId, "outage", time.Minute)
+	require.NoError(err)
+
+	reverted, err := repo.RevertExpiredEmergencyActivations(ctx)
+	require.NoError(err)
+	assert.Equal(0, reverted)
+
+	c.Add(2 * time.Minute)
+	reverted, err = repo.RevertExpiredEmergencyActivations(ctx)
+	require.NoError(err)
+	assert.Equal(1, reverted)
+	require.Len(events, 2)
+	assert.Equal(EmergencyRoleReverted, events[1].Type)
+	assert.Equal(activation.ExpirationTime, *events[1].Activation.RevertTime)
+
+	_, err = repo.ActivateEmergencyRole(ctx, role.PublicId, user.PublicId, "still broken", 0)
+	require.NoError(err)
+}
@@ -13,7 +13,7 @@ import (
 )
 
 // CreateGroup will create a group in the repository and return the written
-// group.  No options are currently supported.
+// group.  Supports the WithDryRun option.
 func (r *Repository) CreateGroup(ctx context.Context, group *Group, opt ...Option) (*Group, error) {
 	if group == nil {
 		return nil, fmt.Errorf("create group: missing group %w", db.ErrInvalidParameter)
@@ -31,9 +31,9 @@ func (r *Repository) CreateGroup(ctx context.Context, group *Group, opt ...Optio
 	if err != nil {
 		return nil, fmt.Errorf("create group: %w", err)
 	}
-	g := group.Clone().(*Group)
+	g := group.Clone()
 	g.PublicId = id
-	resource, err := r.create(ctx, g)
+	resource, err := r.create(ctx, g, opt...)
 	if err != nil {
 		if db.IsUniqueError(err) {
 			return nil, fmt.Errorf("create group: group %s already exists in scope %s: %w", group.Name, group.ScopeId, db.ErrNotUnique)
@@ -48,6 +48,7 @@ func (r *Repository) CreateGroup(ctx context.Context, group *Group, opt ...Optio
 // be updated.  Fields will be set to NULL if the field is a zero value and
 // included in fieldMask. Name and Description are the only updatable fields,
 // If no updatable fields are included in the fieldMaskPaths, then an error is returned.
+// Supports the WithDryRun option.
 func (r *Repository) UpdateGroup(ctx context.Context, group *Group, version uint32, fieldMaskPaths []string, opt ...Option) (*Group, []*GroupMember, int, error) {
 	if group == nil {
 		return nil, nil, db.NoRowsAffected, fmt.Errorf("update group: missing group %w", db.ErrInvalidParameter)
@@ -87,8 +88,8 @@ func (r *Repository) UpdateGroup(ctx context.Context, group *Group, version uint
 		db.ExpBackoff{},
 		func(read db.Reader, w db.Writer) error {
 			var err error
-			g := group.Clone().(*Group)
-			resource, rowsUpdated, err = r.update(ctx, g, version, dbMask, nullFields)
+			g := group.Clone()
+			resource, rowsUpdated, err = r.update(ctx, g, version, dbMask, nullFields, opt...)
 			if err != nil {
 				return err
 			}
@@ -149,7 +150,8 @@ func (r *Repository) LookupGroup(ctx context.Context, withPublicId string, opt .
 	return &g, members, nil
 }
 
-// DeleteGroup will delete a group from the repository.
+// DeleteGroup will delete a group from the repository. Supports the
+// WithDryRun option.
 func (r *Repository) DeleteGroup(ctx context.Context, withPublicId string, opt ...Option) (int, error) {
 	if withPublicId == "" {
 		return db.NoRowsAffected, fmt.Errorf("delete group: missing public id %w", db.ErrInvalidParameter)
@@ -159,23 +161,37 @@ func (r *Repository) DeleteGroup(ctx context.Context, withPublicId string, opt .
 	if err := r.reader.LookupByPublicId(ctx, &g); err != nil {
 		return db.NoRowsAffected, fmt.Errorf("delete group: failed %w for %s", err, withPublicId)
 	}
-	rowsDeleted, err := r.delete(ctx, &g)
+	// The group's members are gone once it's deleted, so look them up first.
+	memberIds, membersErr := r.groupMemberUserIds(ctx, withPublicId)
+	rowsDeleted, err := r.delete(ctx, &g, opt...)
 	if err != nil {
 		return db.NoRowsAffected, fmt.Errorf("delete group: failed %w for %s", err, withPublicId)
 	}
+	if membersErr != nil {
+		r.invalidateGrantsCache()
+	} else {
+		r.invalidateGrantsCacheUsers(memberIds...)
+	}
 	return rowsDeleted, nil
 }
 
-// ListGroups in a scope and supports WithLimit option.
+// ListGroups in a scope and supports the WithLimit, WithRecursive,
+// WithTagFilter, WithPageToken, WithNextPageToken and WithListedScopes
+// options.
 func (r *Repository) ListGroups(ctx context.Context, withScopeId string, opt ...Option) ([]*Group, error) {
 	if withScopeId == "" {
 		return nil, fmt.Errorf("list groups: missing scope id %w", db.ErrInvalidParameter)
 	}
 	var grps []*Group
-	err := r.list(ctx, &grps, "scope_id = ?", []interface{}{withScopeId}, opt...)
+	where, args := scopeClause(ctx, withScopeId, opt...)
+	where, args = tagFilterClause(where, args, opt...)
+	err := r.list(ctx, &grps, where, args, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list groups: %w", err)
 	}
+	if err := r.addListedScopes(ctx, grps, opt...); err != nil {
+		return nil, fmt.Errorf("list groups: %w", err)
+	}
 	return grps, nil
 }
 
@@ -184,8 +200,15 @@ func (r *Repository) ListGroupMembers(ctx context.Context, withGroupId string, o
 	if withGroupId == "" {
 		return nil, fmt.Errorf("list group members: missing group id: %w", db.ErrInvalidParameter)
 	}
+	if opts := getOpts(opt...); r.fastReads && opts.withPageToken == "" && opts.withNextPageToken == nil {
+		members, err := r.fastListGroupMembers(ctx, withGroupId, opt...)
+		if err != nil {
+			return nil, fmt.Errorf("list group members: %w", err)
+		}
+		return members, nil
+	}
 	members := []*GroupMember{}
-	if err := r.list(ctx, &members, "group_id = ?", []interface{}{withGroupId}, opt...); err != nil {
+	if err := r.list(ctx, &members, "group_id = ? and "+groupMemberOrgIsolation, []interface{}{withGroupId}, opt...); err != nil {
 		return nil, fmt.Errorf("list group members: %w", err)
 	}
 	return members, nil
@@ -227,6 +250,7 @@ func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupV
 	}
 
 	var currentMembers []*GroupMember
+	var usage *quotaUsage
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
@@ -251,7 +275,10 @@ func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupV
 			msgs = append(msgs, &groupOplogMsg)
 			memberOplogMsgs := make([]*oplog.Message, 0, len(newGroupMembers))
 			if err := w.CreateItems(ctx, newGroupMembers, db.NewOplogMsgs(&memberOplogMsgs)); err != nil {
-				return fmt.Errorf("add group members: unable to add users: %w", err)
+				return fmt.Errorf("add group members: unable to add users: %w", wrapOrgIsolationError(err))
+			}
+			if usage, err = checkQuota(ctx, reader, "max_members_per_group", r.quotas.MaxMembersPerGroup, 0, countGroupMembers, groupId); err != nil {
+				return fmt.Errorf("add group members: %w", err)
 			}
 			msgs = append(msgs, memberOplogMsgs...)
 			metadata := oplog.Metadata{
@@ -281,6 +308,8 @@ func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupV
 	if err != nil {
 		return nil, fmt.Errorf("add group members: error adding members: %w", err)
 	}
+	r.invalidateGrantsCacheUsers(userIds...)
+	r.observeQuota(usage, scope.GetPublicId(), len(newGroupMembers))
 	return currentMembers, nil
 }
 
@@ -366,6 +395,7 @@ func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, gro
 	if err != nil {
 		return db.NoRowsAffected, fmt.Errorf("delete group members: error deleting members: %w", err)
 	}
+	r.invalidateGrantsCacheUsers(userIds...)
 	return totalRowsDeleted, nil
 }
 
@@ -393,6 +423,9 @@ func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupV
 
 	var currentMembers []*GroupMember
 	var totalRowsAffected int
+	var usage *quotaUsage
+	var added int
+	var changedUserIds []string
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
@@ -410,6 +443,13 @@ func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupV
 			if err != nil {
 				return fmt.Errorf("set associated accounts: unable to determine changes: %w", err)
 			}
+			changedUserIds = make([]string, 0, len(addMembers)+len(deleteMembers))
+			for _, m := range addMembers {
+				changedUserIds = append(changedUserIds, m.(*GroupMemberUser).MemberId)
+			}
+			for _, m := range deleteMembers {
+				changedUserIds = append(changedUserIds, m.(*GroupMemberUser).MemberId)
+			}
 			// handle no change to existing group members
 			if len(addMembers) == 0 && len(deleteMembers) == 0 {
 				currentMembers, err = txRepo.ListGroupMembers(ctx, groupId)
@@ -460,12 +500,15 @@ func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupV
 			if len(addMembers) > 0 {
 				userOplogMsgs := make([]*oplog.Message, 0, len(addMembers))
 				if err := w.CreateItems(ctx, addMembers, db.NewOplogMsgs(&userOplogMsgs)); err != nil {
-					return fmt.Errorf("set group members: unable to add users: %w", err)
+					return fmt.Errorf("set group members: unable to add users: %w", wrapOrgIsolationError(err))
 				}
 				totalRowsAffected += len(addMembers)
 				msgs = append(msgs, userOplogMsgs...)
 				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
-
+				if usage, err = checkQuota(ctx, reader, "max_members_per_group", r.quotas.MaxMembersPerGroup, 0, countGroupMembers, groupId); err != nil {
+					return fmt.Errorf("set group members: %w", err)
+				}
+				added = len(addMembers) - len(deleteMembers)
 			}
 			// we're done with all the membership writes, so let's write the
 			// group's update oplog message
@@ -481,9 +524,25 @@ func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupV
 	if err != nil {
 		return nil, db.NoRowsAffected, fmt.Errorf("set group members: unable to set group members: %w", err)
 	}
+	r.invalidateGrantsCacheUsers(changedUserIds...)
+	r.observeQuota(usage, scope.GetPublicId(), added)
 	return currentMembers, totalRowsAffected, nil
 }
 
+// groupMemberUserIds returns the ids of the users which are members of the
+// group.
+func (r *Repository) groupMemberUserIds(ctx context.Context, groupId string) ([]string, error) {
+	var members []*GroupMemberUser
+	if err := r.reader.SearchWhere(ctx, &members, "group_id = ?", []interface{}{groupId}, db.WithLimit(-1)); err != nil {
+		return nil, fmt.Errorf("group member user ids: %w", err)
+	}
+	userIds := make([]string, 0, len(members))
+	for _, m := range members {
+		userIds = append(userIds, m.MemberId)
+	}
+	return userIds, nil
+}
+
 // groupMemberChanges returns two slices: members to add and delete
 func groupMemberChanges(ctx context.Context, reader db.Reader, groupId string, userIds []string) ([]interface{}, []interface{}, error) {
 	var inClauseSpots []string
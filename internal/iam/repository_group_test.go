@@ -420,7 +420,7 @@ func TestRepository_UpdateGroup(t *testing.T) {
 			if tt.directUpdate {
 				g := updateGrp.Clone()
 				var resource interface{}
-				resource, updatedRows, err = repo.update(context.Background(), g.(*Group), updateGrp.Version, tt.args.fieldMaskPaths, nil, tt.args.opt...)
+				resource, updatedRows, err = repo.update(context.Background(), g, updateGrp.Version, tt.args.fieldMaskPaths, nil, tt.args.opt...)
 				if err == nil {
 					groupAfterUpdate = resource.(*Group)
 				}
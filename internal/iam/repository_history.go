@@ -0,0 +1,219 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	oplogstore "github.com/hashicorp/boundary/internal/oplog/store"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// HistoryEntry is an oplog entry which changed a resource. Metadata holds
+// whatever the entry was written with, which includes the user and scope of
+// the request under the db.RequestUserIdKey and db.RequestScopeIdKey keys if
+// the change was made with a context from WithRequestInfo.
+type HistoryEntry struct {
+	Id         uint32
+	CreateTime time.Time
+	Metadata   oplog.Metadata
+	Changes    []*HistoryChange
+}
+
+// HistoryChange is a single row written by an oplog entry. TypeName is the
+// table of the row.
+type HistoryChange struct {
+	TypeName string
+	OpType   oplog.OpType
+	Fields   []*HistoryField
+}
+
+// HistoryField is a field changed by a HistoryChange. Before is empty for
+// creates and After is empty for deletes. For updates Before is the value
+// from the resource's earlier history, which is empty if it isn't known.
+type HistoryField struct {
+	Name   string
+	Before string
+	After  string
+}
+
+// historyTypes maps the table names used as oplog message type names to the
+// store messages they were written from.
+var historyTypes = []oplog.Type{
+	{Interface: new(store.Scope), Name: defaultScopeTableName},
+	{Interface: new(store.User), Name: defaultUserTableName},
+	{Interface: new(store.Group), Name: defaultGroupTableName},
+	{Interface: new(store.GroupMemberUser), Name: groupMemberUserDefaultTable},
+	{Interface: new(store.Role), Name: defaultRoleTableName},
+	{Interface: new(store.RoleGrant), Name: defaultRoleGrantTable},
+	{Interface: new(store.UserRole), Name: userRoleDefaultTable},
+	{Interface: new(store.GroupRole), Name: groupRoleDefaultTable},
+	{Interface: new(store.ClaimRule), Name: defaultClaimRuleTableName},
+	{Interface: new(store.Tag), Name: defaultTagTable},
+}
+
+// History returns the oplog entries written for the resource (publicId),
+// oldest first, decoded into the fields each one changed. Entries written
+// for a resource include changes to its children, like the grants and
+// principals of a role. Supports the WithLimit option, which limits the
+// number of entries returned.
+func (r *Repository) History(ctx context.Context, publicId string, opt ...Option) ([]*HistoryEntry, error) {
+	if publicId == "" {
+		return nil, fmt.Errorf("history: missing public id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		limit = opts.withLimit
+	}
+	types, err := oplog.NewTypeCatalog(historyTypes...)
+	if err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
+
+	const entriesForResource = "select entry_id from oplog_metadata where key = 'resource-public-id' and value = ?"
+	var entries []*oplogstore.Entry
+	if err := r.reader.SearchWhere(ctx, &entries, "id in ("+entriesForResource+")", []interface{}{publicId}, db.WithLimit(limit), db.WithOrder("id")); err != nil {
+		return nil, fmt.Errorf("history: unable to list oplog entries: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	var metadata []*oplogstore.Metadata
+	if err := r.reader.SearchWhere(ctx, &metadata, "entry_id in ("+entriesForResource+")", []interface{}{publicId}, db.WithLimit(-1), db.WithOrder("id")); err != nil {
+		return nil, fmt.Errorf("history: unable to list oplog metadata: %w", err)
+	}
+	metadataByEntry := map[uint32]oplog.Metadata{}
+	for _, m := range metadata {
+		if metadataByEntry[m.EntryId] == nil {
+			metadataByEntry[m.EntryId] = oplog.Metadata{}
+		}
+		metadataByEntry[m.EntryId][m.Key] = append(metadataByEntry[m.EntryId][m.Key], m.Value)
+	}
+
+	// state holds the latest fields of each row seen so far, so updates can
+	// report the value a field had before.
+	state := map[string]protoreflect.Message{}
+	history := make([]*HistoryEntry, 0, len(entries))
+	for _, e := range entries {
+		md := metadataByEntry[e.Id]
+		if len(md["scope-id"]) == 0 {
+			return nil, fmt.Errorf("history: oplog entry %d has no scope id", e.Id)
+		}
+		wrapper, err := r.kms.GetWrapper(ctx, md["scope-id"][0], kms.KeyPurposeOplog)
+		if err != nil {
+			return nil, fmt.Errorf("history: unable to get oplog wrapper for entry %d: %w", e.Id, err)
+		}
+		entry := oplog.Entry{Entry: e, Cipherer: wrapper}
+		if err := entry.DecryptData(ctx); err != nil {
+			return nil, fmt.Errorf("history: entry %d: %w", e.Id, err)
+		}
+		msgs, err := entry.UnmarshalData(types)
+		if err != nil {
+			return nil, fmt.Errorf("history: entry %d: %w", e.Id, err)
+		}
+		h := &HistoryEntry{
+			Id:         e.Id,
+			CreateTime: e.GetCreateTime().GetTimestamp().AsTime(),
+			Metadata:   md,
+			Changes:    make([]*HistoryChange, 0, len(msgs)),
+		}
+		for _, m := range msgs {
+			h.Changes = append(h.Changes, historyChange(state, m))
+		}
+		history = append(history, h)
+	}
+	return history, nil
+}
+
+// historyChange decodes the fields changed by the message and applies them to
+// the state.
+func historyChange(state map[string]protoreflect.Message, m oplog.Message) *HistoryChange {
+	c := &HistoryChange{
+		TypeName: m.TypeName,
+		OpType:   m.OpType,
+	}
+	msg := m.Message.ProtoReflect()
+	key := m.TypeName + "/" + historyRowKey(msg)
+	switch m.OpType {
+	case oplog.OpType_OP_TYPE_CREATE:
+		msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			c.Fields = append(c.Fields, &HistoryField{Name: string(fd.Name()), After: historyValue(fd, v)})
+			return true
+		})
+		state[key] = proto.Clone(m.Message).ProtoReflect()
+	case oplog.OpType_OP_TYPE_DELETE:
+		prev := state[key]
+		if prev == nil {
+			prev = msg
+		}
+		prev.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			c.Fields = append(c.Fields, &HistoryField{Name: string(fd.Name()), Before: historyValue(fd, v)})
+			return true
+		})
+		delete(state, key)
+	case oplog.OpType_OP_TYPE_UPDATE:
+		prev := state[key]
+		for _, path := range append(append([]string{}, m.FieldMaskPaths...), m.SetToNullPaths...) {
+			fd := historyField(msg.Descriptor(), path)
+			if fd == nil {
+				c.Fields = append(c.Fields, &HistoryField{Name: path})
+				continue
+			}
+			f := &HistoryField{Name: string(fd.Name())}
+			if prev != nil && prev.Has(fd) {
+				f.Before = historyValue(fd, prev.Get(fd))
+			}
+			if msg.Has(fd) {
+				f.After = historyValue(fd, msg.Get(fd))
+			}
+			c.Fields = append(c.Fields, f)
+			if prev != nil {
+				if msg.Has(fd) {
+					prev.Set(fd, msg.Get(fd))
+				} else {
+					prev.Clear(fd)
+				}
+			}
+		}
+	}
+	sort.Slice(c.Fields, func(i, j int) bool { return c.Fields[i].Name < c.Fields[j].Name })
+	return c
+}
+
+// historyRowKey identifies the row written by the message: its public id, or
+// for rows without one, the message itself.
+func historyRowKey(msg protoreflect.Message) string {
+	if fd := msg.Descriptor().Fields().ByName("public_id"); fd != nil {
+		return msg.Get(fd).String()
+	}
+	return fmt.Sprint(msg.Interface())
+}
+
+// historyField returns the field of a field mask path, which is the Go name
+// of the field.
+func historyField(desc protoreflect.MessageDescriptor, path string) protoreflect.FieldDescriptor {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if strings.EqualFold(strings.ReplaceAll(string(fd.Name()), "_", ""), path) {
+			return fd
+		}
+	}
+	return nil
+}
+
+// historyValue formats a field value.
+func historyValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if fd.Kind() == protoreflect.MessageKind {
+		return fmt.Sprint(v.Message().Interface())
+	}
+	return v.String()
+}
@@ -0,0 +1,110 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_History(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+	_, proj := TestScopes(t, repo)
+
+	role, err := NewRole(proj.PublicId, WithName("before"))
+	require.NoError(err)
+	role, err = repo.CreateRole(ctx, role)
+	require.NoError(err)
+	role.Name = "after"
+	role, _, _, _, err = repo.UpdateRole(ctx, role, role.Version, []string{"Name"})
+	require.NoError(err)
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, role.Version, []string{"id=*;actions=read"})
+	require.NoError(err)
+
+	field := func(c *HistoryChange, name string) *HistoryField {
+		for _, f := range c.Fields {
+			if f.Name == name {
+				return f
+			}
+		}
+		return nil
+	}
+
+	got, err := repo.History(ctx, role.PublicId)
+	require.NoError(err)
+	require.Len(got, 3)
+	for i := 1; i < len(got); i++ {
+		assert.True(got[i-1].Id < got[i].Id)
+	}
+
+	create := got[0]
+	assert.Equal([]string{role.PublicId}, create.Metadata["resource-public-id"])
+	require.Len(create.Changes, 1)
+	assert.Equal(defaultRoleTableName, create.Changes[0].TypeName)
+	assert.Equal(oplog.OpType_OP_TYPE_CREATE, create.Changes[0].OpType)
+	require.NotNil(field(create.Changes[0], "name"))
+	assert.Equal("before", field(create.Changes[0], "name").After)
+
+	update := got[1]
+	require.Len(update.Changes, 1)
+	assert.Equal(oplog.OpType_OP_TYPE_UPDATE, update.Changes[0].OpType)
+	assert.Equal([]*HistoryField{{Name: "name", Before: "before", After: "after"}}, update.Changes[0].Fields)
+
+	var grant *HistoryChange
+	for _, c := range got[2].Changes {
+		if c.TypeName == defaultRoleGrantTable {
+			grant = c
+		}
+	}
+	require.NotNil(grant)
+	assert.Equal(oplog.OpType_OP_TYPE_CREATE, grant.OpType)
+	require.NotNil(field(grant, "canonical_grant"))
+	assert.Equal("id=*;actions=read", field(grant, "canonical_grant").After)
+
+	limited, err := repo.History(ctx, role.PublicId, WithLimit(1))
+	require.NoError(err)
+	require.Len(limited, 1)
+	assert.Equal(create.Id, limited[0].Id)
+
+	none, err := repo.History(ctx, "r_1234567890")
+	require.NoError(err)
+	assert.Empty(none)
+
+	_, err = repo.History(ctx, "")
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+}
+
+func TestRepository_History_RequestInfo(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	user := TestUser(t, repo, org.PublicId)
+
+	ctx := WithRequestInfo(context.Background(), user.PublicId, org.PublicId)
+	role, err := NewRole(org.PublicId)
+	require.NoError(err)
+	role, err = repo.CreateRole(ctx, role)
+	require.NoError(err)
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, role.Version, []string{"id=*;actions=read"})
+	require.NoError(err)
+
+	got, err := repo.History(context.Background(), role.PublicId)
+	require.NoError(err)
+	require.Len(got, 2)
+	for _, h := range got {
+		assert.Equal([]string{user.PublicId}, h.Metadata[db.RequestUserIdKey])
+		assert.Equal([]string{org.PublicId}, h.Metadata[db.RequestScopeIdKey])
+	}
+}
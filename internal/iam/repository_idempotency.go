@@ -0,0 +1,131 @@
+package iam
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// IdempotencyKeyLifetime is how long the idempotency key of a write is
+// remembered. A request retried after this is written again.
+var IdempotencyKeyLifetime = 24 * time.Hour
+
+// MaxIdempotencyKeyLength is the maximum length of an idempotency key.
+const MaxIdempotencyKeyLength = 255
+
+// ErrIdempotencyKeyReused is returned when an idempotency key is used again
+// for a different request within its lifetime.
+var ErrIdempotencyKeyReused = errors.New("idempotency key reused for a different request")
+
+// idempotencyClaim is the idempotency key of a write and the request it was
+// supplied with.
+type idempotencyClaim struct {
+	scopeId     string
+	key         string
+	operation   string
+	resourceId  string
+	fingerprint string
+}
+
+// newIdempotencyClaim returns the claim of key for the operation on the
+// resource, with a fingerprint of the request's fields.
+func newIdempotencyClaim(scopeId, key, operation, resourceId string, fields ...string) (*idempotencyClaim, error) {
+	if len(key) > MaxIdempotencyKeyLength {
+		return nil, fmt.Errorf("idempotency key longer than %d: %w", MaxIdempotencyKeyLength, db.ErrInvalidParameter)
+	}
+	if strings.TrimSpace(key) == "" {
+		return nil, fmt.Errorf("empty idempotency key: %w", db.ErrInvalidParameter)
+	}
+	h := sha256.New()
+	for _, f := range append([]string{operation}, fields...) {
+		// A separator which can't appear in the fields, so they can't run
+		// into each other
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+	return &idempotencyClaim{
+		scopeId:     scopeId,
+		key:         key,
+		operation:   operation,
+		resourceId:  resourceId,
+		fingerprint: hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
+// roleCreateClaim returns the idempotency claim for creating the role.
+func roleCreateClaim(key string, role *Role) (*idempotencyClaim, error) {
+	return newIdempotencyClaim(role.ScopeId, key, "create-role", role.PublicId, role.ScopeId, role.Name, role.Description, role.GrantScopeId)
+}
+
+// roleGrantsClaim returns the idempotency claim for adding the grants to the
+// role in the scope.
+func roleGrantsClaim(key, scopeId, roleId string, grants []*RoleGrant) (*idempotencyClaim, error) {
+	canonical := make([]string, 0, len(grants))
+	for _, g := range grants {
+		canonical = append(canonical, g.CanonicalGrant)
+	}
+	sort.Strings(canonical)
+	return newIdempotencyClaim(scopeId, key, "add-role-grants", roleId, append([]string{roleId}, canonical...)...)
+}
+
+// idempotentReplayError is returned from the transaction of a write whose
+// idempotency key was claimed by an earlier write of the same request, so the
+// write is rolled back and the result of the earlier one is returned instead.
+type idempotentReplayError struct {
+	resourceId string
+}
+
+// Error implements the error interface.
+func (e *idempotentReplayError) Error() string {
+	return fmt.Sprintf("idempotency key already used to write %s", e.resourceId)
+}
+
+// claimIdempotencyKey claims the key of the write, inside the write's
+// transaction. If the key was claimed by an earlier write which hasn't
+// expired, an idempotentReplayError is returned if it was for the same
+// request and ErrIdempotencyKeyReused if it wasn't. A concurrent write with
+// the same key waits for the first to commit or roll back.
+func claimIdempotencyKey(ctx context.Context, reader db.Reader, w db.Writer, c *idempotencyClaim) error {
+	claimed, err := w.Exec(ctx, insertIdempotencyKey, []interface{}{c.scopeId, c.key, c.operation, c.resourceId, c.fingerprint, IdempotencyKeyLifetime.Seconds()})
+	if err != nil {
+		return fmt.Errorf("unable to claim idempotency key: %w", err)
+	}
+	if claimed == 1 {
+		return nil
+	}
+	rows, err := reader.Query(ctx, lookupIdempotencyKey, []interface{}{c.scopeId, c.key})
+	if err != nil {
+		return fmt.Errorf("unable to lookup idempotency key: %w", err)
+	}
+	defer rows.Close()
+	var operation, resourceId, fingerprint string
+	for rows.Next() {
+		if err := rows.Scan(&operation, &resourceId, &fingerprint); err != nil {
+			return fmt.Errorf("unable to scan idempotency key: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("unable to lookup idempotency key: %w", err)
+	}
+	if operation != c.operation || fingerprint != c.fingerprint {
+		return fmt.Errorf("%s: %w", c.key, ErrIdempotencyKeyReused)
+	}
+	return &idempotentReplayError{resourceId: resourceId}
+}
+
+// DeleteExpiredIdempotencyKeys deletes the idempotency keys which have
+// outlived IdempotencyKeyLifetime and returns how many were deleted.
+func (r *Repository) DeleteExpiredIdempotencyKeys(ctx context.Context) (int, error) {
+	rowsDeleted, err := r.writer.Exec(ctx, deleteExpiredIdempotencyKeys, nil)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+	return rowsDeleted, nil
+}
@@ -0,0 +1,99 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_IdempotencyKeys(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	rw := db.New(conn)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+	org, _ := TestScopes(t, repo)
+
+	countRoles := func(t *testing.T, name string) int {
+		t.Helper()
+		var roles []*Role
+		require.NoError(t, rw.SearchWhere(ctx, &roles, "scope_id = ? and name = ?", []interface{}{org.PublicId, name}))
+		return len(roles)
+	}
+
+	t.Run("create-role", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role, err := NewRole(org.PublicId, WithName("retried"))
+		require.NoError(err)
+		first, err := repo.CreateRole(ctx, role, WithIdempotencyKey("create-1"))
+		require.NoError(err)
+		second, err := repo.CreateRole(ctx, role, WithIdempotencyKey("create-1"))
+		require.NoError(err)
+		assert.Equal(first.PublicId, second.PublicId)
+		assert.Equal(1, countRoles(t, "retried"))
+
+		// The same key for a different request is refused
+		other, err := NewRole(org.PublicId, WithName("other"))
+		require.NoError(err)
+		_, err = repo.CreateRole(ctx, other, WithIdempotencyKey("create-1"))
+		assert.True(errors.Is(err, ErrIdempotencyKeyReused))
+		assert.Equal(0, countRoles(t, "other"))
+
+		// A dry run doesn't claim the key
+		dryRun, err := NewRole(org.PublicId, WithName("dry-run"))
+		require.NoError(err)
+		_, err = repo.CreateRole(ctx, dryRun, WithIdempotencyKey("create-2"), WithDryRun(true))
+		require.NoError(err)
+		created, err := repo.CreateRole(ctx, dryRun, WithIdempotencyKey("create-2"))
+		require.NoError(err)
+		assert.Equal(1, countRoles(t, "dry-run"))
+		assert.Equal("dry-run", created.Name)
+
+		_, err = repo.CreateRole(ctx, other, WithIdempotencyKey(strings.Repeat("k", MaxIdempotencyKeyLength+1)))
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+	})
+
+	t.Run("add-role-grants", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, org.PublicId)
+		grants := []string{"id=*;type=*;actions=read", "id=*;type=*;actions=update"}
+		first, err := repo.AddRoleGrants(ctx, role.PublicId, role.Version, grants, WithIdempotencyKey("grants-1"))
+		require.NoError(err)
+		require.Len(first, 2)
+
+		// A retry with the same, now stale, version returns the same grants
+		second, err := repo.AddRoleGrants(ctx, role.PublicId, role.Version, grants, WithIdempotencyKey("grants-1"))
+		require.NoError(err)
+		assert.ElementsMatch([]string{first[0].CanonicalGrant, first[1].CanonicalGrant}, []string{second[0].CanonicalGrant, second[1].CanonicalGrant})
+		found, _, _, err := repo.LookupRole(ctx, role.PublicId)
+		require.NoError(err)
+		assert.Equal(role.Version+1, found.Version)
+
+		_, err = repo.AddRoleGrants(ctx, role.PublicId, found.Version, []string{"id=*;type=*;actions=delete"}, WithIdempotencyKey("grants-1"))
+		assert.True(errors.Is(err, ErrIdempotencyKeyReused))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := rw.Exec(ctx, "update iam_idempotency_key set expiration_time = now() where scope_id = $1", []interface{}{org.PublicId})
+		require.NoError(err)
+
+		// An expired key can be claimed again
+		other, err := NewRole(org.PublicId, WithName("after-expiry"))
+		require.NoError(err)
+		_, err = repo.CreateRole(ctx, other, WithIdempotencyKey("create-1"))
+		require.NoError(err)
+
+		_, err = rw.Exec(ctx, "update iam_idempotency_key set expiration_time = now() where scope_id = $1", []interface{}{org.PublicId})
+		require.NoError(err)
+		deleted, err := repo.DeleteExpiredIdempotencyKeys(ctx)
+		require.NoError(err)
+		assert.Equal(3, deleted)
+	})
+}
@@ -0,0 +1,157 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/lib/pq"
+)
+
+// orgIsolationErrCode is the SQLSTATE raised by the database when an insert
+// violates hard org isolation.
+const orgIsolationErrCode = "WTI01"
+
+// OrgIsolationViolation describes an existing association between two
+// resources in different orgs which prevents hard org isolation from being
+// enabled.
+type OrgIsolationViolation struct {
+	OwnerId        string
+	OwnerOrgId     string
+	PrincipalId    string
+	PrincipalOrgId string
+}
+
+// String returns a human readable description of the violation.
+func (v OrgIsolationViolation) String() string {
+	return fmt.Sprintf("%s (org %s) references %s (org %s)", v.OwnerId, v.OwnerOrgId, v.PrincipalId, v.PrincipalOrgId)
+}
+
+// OrgIsolation returns whether hard org isolation is enabled. When enabled,
+// the database rejects any role principal or group member which references a
+// resource in a different org, rejects any org without its own root key, and
+// principals and members from a different org are never listed.
+func (r *Repository) OrgIsolation(ctx context.Context) (bool, error) {
+	rows, err := r.reader.Query(ctx, selectOrgIsolation, nil)
+	if err != nil {
+		return false, fmt.Errorf("org isolation: unable to query isolation setting: %w", err)
+	}
+	defer rows.Close()
+	var enabled bool
+	for rows.Next() {
+		if err := rows.Scan(&enabled); err != nil {
+			return false, fmt.Errorf("org isolation: unable to scan isolation setting: %w", err)
+		}
+	}
+	return enabled, nil
+}
+
+// SetOrgIsolation enables or disables hard org isolation. Hard isolation is
+// intended for service providers which host multiple customers in a single
+// deployment. Enabling it will fail with ErrOrgIsolation if any existing
+// association crosses an org boundary or if any org does not have its own root
+// key, since every org must be encrypted with its own key hierarchy.
+func (r *Repository) SetOrgIsolation(ctx context.Context, enabled bool) error {
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			// Lock the setting before checking for violations, so a
+			// concurrent insert either commits before the check or waits
+			// for the new setting.
+			rows, err := reader.Query(ctx, lockOrgIsolation, nil)
+			if err != nil {
+				return fmt.Errorf("unable to lock isolation setting: %w", err)
+			}
+			rows.Close()
+			if enabled {
+				txRepo := &Repository{
+					reader: reader,
+					writer: w,
+					kms:    r.kms,
+				}
+				violations, err := txRepo.ListOrgIsolationViolations(ctx)
+				if err != nil {
+					return err
+				}
+				if len(violations) > 0 {
+					msgs := make([]string, 0, len(violations))
+					for _, v := range violations {
+						msgs = append(msgs, v.String())
+					}
+					return fmt.Errorf("%d existing associations cross an org boundary: %s: %w", len(violations), strings.Join(msgs, ", "), ErrOrgIsolation)
+				}
+				orgIds, err := txRepo.listOrgsWithoutRootKey(ctx)
+				if err != nil {
+					return err
+				}
+				if len(orgIds) > 0 {
+					return fmt.Errorf("orgs without their own root key: %s: %w", strings.Join(orgIds, ", "), ErrOrgIsolation)
+				}
+			}
+			rowsUpdated, err := w.Exec(ctx, updateOrgIsolation, []interface{}{enabled})
+			if err != nil {
+				return fmt.Errorf("unable to update isolation setting: %w", err)
+			}
+			if rowsUpdated != 1 {
+				return fmt.Errorf("updated isolation setting and %d rows updated", rowsUpdated)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("set org isolation: %w", err)
+	}
+	return nil
+}
+
+// ListOrgIsolationViolations returns the existing role principals and group
+// members which reference resources in a different org. Resources in the
+// global scope never violate isolation.
+func (r *Repository) ListOrgIsolationViolations(ctx context.Context) ([]OrgIsolationViolation, error) {
+	rows, err := r.reader.Query(ctx, selectOrgIsolationViolations, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list org isolation violations: %w", err)
+	}
+	defer rows.Close()
+	var violations []OrgIsolationViolation
+	for rows.Next() {
+		var v OrgIsolationViolation
+		if err := rows.Scan(&v.OwnerId, &v.OwnerOrgId, &v.PrincipalId, &v.PrincipalOrgId); err != nil {
+			return nil, fmt.Errorf("list org isolation violations: unable to scan row: %w", err)
+		}
+		violations = append(violations, v)
+	}
+	return violations, nil
+}
+
+func (r *Repository) listOrgsWithoutRootKey(ctx context.Context) ([]string, error) {
+	rows, err := r.reader.Query(ctx, selectOrgsWithoutRootKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query orgs without root keys: %w", err)
+	}
+	defer rows.Close()
+	var orgIds []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("unable to scan org id: %w", err)
+		}
+		orgIds = append(orgIds, id)
+	}
+	return orgIds, nil
+}
+
+// wrapOrgIsolationError maps the database error raised when an insert
+// violates hard org isolation to ErrOrgIsolation. Other errors are returned
+// unchanged.
+func wrapOrgIsolationError(err error) error {
+	var pqError *pq.Error
+	if errors.As(err, &pqError) && string(pqError.Code) == orgIsolationErrCode {
+		return fmt.Errorf("%s: %w", pqError.Message, ErrOrgIsolation)
+	}
+	return err
+}
@@ -0,0 +1,215 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_SetOrgIsolation(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	orgA, projA := TestScopes(t, repo)
+	orgB, _ := TestScopes(t, repo)
+
+	enabled, err := repo.OrgIsolation(ctx)
+	require.NoError(err)
+	assert.False(enabled)
+
+	// a cross org association made before isolation is enabled
+	userB := TestUser(t, repo, orgB.PublicId)
+	roleA := TestRole(t, conn, projA.PublicId)
+	TestUserRole(t, conn, roleA.PublicId, userB.PublicId)
+
+	violations, err := repo.ListOrgIsolationViolations(ctx)
+	require.NoError(err)
+	require.Len(violations, 1)
+	assert.Equal(roleA.PublicId, violations[0].OwnerId)
+	assert.Equal(orgA.PublicId, violations[0].OwnerOrgId)
+	assert.Equal(userB.PublicId, violations[0].PrincipalId)
+	assert.Equal(orgB.PublicId, violations[0].PrincipalOrgId)
+
+	err = repo.SetOrgIsolation(ctx, true)
+	require.Error(err)
+	assert.True(errors.Is(err, ErrOrgIsolation))
+
+	_, err = repo.DeletePrincipalRoles(ctx, roleA.PublicId, roleA.Version, []string{userB.PublicId})
+	require.NoError(err)
+
+	require.NoError(repo.SetOrgIsolation(ctx, true))
+	enabled, err = repo.OrgIsolation(ctx)
+	require.NoError(err)
+	assert.True(enabled)
+
+	// cross org associations are now rejected by the database
+	roleA, _, _, err = repo.LookupRole(ctx, roleA.PublicId)
+	require.NoError(err)
+	_, err = repo.AddPrincipalRoles(ctx, roleA.PublicId, roleA.Version, []string{userB.PublicId})
+	require.Error(err)
+	assert.True(errors.Is(err, ErrOrgIsolation))
+
+	grpA := TestGroup(t, conn, orgA.PublicId)
+	_, err = repo.AddGroupMembers(ctx, grpA.PublicId, grpA.Version, []string{userB.PublicId})
+	require.Error(err)
+	assert.True(errors.Is(err, ErrOrgIsolation))
+
+	// orgs must have their own root key
+	org, err := NewOrg()
+	require.NoError(err)
+	org.PublicId, err = newScopeId(scope.Org)
+	require.NoError(err)
+	err = db.New(conn).Create(ctx, org)
+	require.Error(err)
+	assert.True(errors.Is(wrapOrgIsolationError(err), ErrOrgIsolation))
+	TestOrg(t, repo)
+
+	// associations within an org and with global principals are allowed
+	userA := TestUser(t, repo, orgA.PublicId)
+	_, err = repo.AddPrincipalRoles(ctx, roleA.PublicId, roleA.Version, []string{userA.PublicId, "u_auth"})
+	require.NoError(err)
+
+	require.NoError(repo.SetOrgIsolation(ctx, false))
+	enabled, err = repo.OrgIsolation(ctx)
+	require.NoError(err)
+	assert.False(enabled)
+}
+
+func TestRepository_OrgIsolationList(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	rw := db.New(conn)
+	ctx := context.Background()
+
+	orgA, projA := TestScopes(t, repo)
+	orgB, _ := TestScopes(t, repo)
+	userA := TestUser(t, repo, orgA.PublicId)
+	userB := TestUser(t, repo, orgB.PublicId)
+
+	roleA := TestRole(t, conn, projA.PublicId)
+	TestUserRole(t, conn, roleA.PublicId, userA.PublicId)
+	TestUserRole(t, conn, roleA.PublicId, userB.PublicId)
+	grpA := TestGroup(t, conn, orgA.PublicId)
+	TestGroupMember(t, conn, grpA.PublicId, userA.PublicId)
+	TestGroupMember(t, conn, grpA.PublicId, userB.PublicId)
+
+	principals, err := repo.ListPrincipalRoles(ctx, roleA.PublicId)
+	require.NoError(err)
+	assert.Len(principals, 2)
+	members, err := repo.ListGroupMembers(ctx, grpA.PublicId)
+	require.NoError(err)
+	assert.Len(members, 2)
+
+	// Force isolation on underneath the existing violations, which
+	// SetOrgIsolation would refuse, to show lists never cross orgs.
+	_, err = rw.Exec(ctx, updateOrgIsolation, []interface{}{true})
+	require.NoError(err)
+
+	principals, err = repo.ListPrincipalRoles(ctx, roleA.PublicId)
+	require.NoError(err)
+	require.Len(principals, 1)
+	assert.Equal(userA.PublicId, principals[0].PrincipalId)
+	members, err = repo.ListGroupMembers(ctx, grpA.PublicId)
+	require.NoError(err)
+	require.Len(members, 1)
+	assert.Equal(userA.PublicId, members[0].MemberId)
+}
+
+func TestRepository_OrgIsolationRecursiveList(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	orgA, projA := TestScopes(t, repo)
+	orgB, projB := TestScopes(t, repo)
+	userA := TestUser(t, repo, orgA.PublicId)
+	userB := TestUser(t, repo, orgB.PublicId)
+	roleA := TestRole(t, conn, projA.PublicId)
+	roleB := TestRole(t, conn, projB.PublicId)
+	grpA := TestGroup(t, conn, orgA.PublicId)
+	grpB := TestGroup(t, conn, orgB.PublicId)
+
+	roleIds := func(ctx context.Context) []string {
+		roles, err := repo.ListRoles(ctx, "global", WithLimit(-1), WithRecursive(true))
+		require.NoError(err)
+		var ids []string
+		for _, r := range roles {
+			ids = append(ids, r.PublicId)
+		}
+		return ids
+	}
+	groupIds := func(ctx context.Context) []string {
+		groups, err := repo.ListGroups(ctx, "global", WithLimit(-1), WithRecursive(true))
+		require.NoError(err)
+		var ids []string
+		for _, g := range groups {
+			ids = append(ids, g.PublicId)
+		}
+		return ids
+	}
+	userIds := func(ctx context.Context) []string {
+		users, err := repo.ListUsers(ctx, "global", WithLimit(-1), WithRecursive(true))
+		require.NoError(err)
+		var ids []string
+		for _, u := range users {
+			ids = append(ids, u.PublicId)
+		}
+		return ids
+	}
+
+	ctxA := WithRequestInfo(ctx, userA.PublicId, orgA.PublicId)
+	ctxB := WithRequestInfo(ctx, userB.PublicId, orgB.PublicId)
+
+	// Without isolation a listing from global reaches every org
+	for _, c := range []context.Context{ctx, ctxA, ctxB} {
+		assert.Subset(roleIds(c), []string{roleA.PublicId, roleB.PublicId})
+		assert.Subset(groupIds(c), []string{grpA.PublicId, grpB.PublicId})
+		assert.Subset(userIds(c), []string{userA.PublicId, userB.PublicId})
+	}
+
+	require.NoError(repo.SetOrgIsolation(ctx, true))
+
+	// With it, only the listing user's own org is descended into
+	assert.Contains(roleIds(ctxA), roleA.PublicId)
+	assert.NotContains(roleIds(ctxA), roleB.PublicId)
+	assert.Contains(groupIds(ctxA), grpA.PublicId)
+	assert.NotContains(groupIds(ctxA), grpB.PublicId)
+	assert.Contains(userIds(ctxA), userA.PublicId)
+	assert.NotContains(userIds(ctxA), userB.PublicId)
+
+	assert.Contains(roleIds(ctxB), roleB.PublicId)
+	assert.NotContains(roleIds(ctxB), roleA.PublicId)
+	assert.Contains(groupIds(ctxB), grpB.PublicId)
+	assert.NotContains(groupIds(ctxB), grpA.PublicId)
+	assert.Contains(userIds(ctxB), userB.PublicId)
+	assert.NotContains(userIds(ctxB), userA.PublicId)
+
+	// A listing without a user reaches no org at all, while listing from
+	// within an org is unaffected
+	for _, id := range append(roleIds(ctx), groupIds(ctx)...) {
+		assert.NotContains([]string{roleA.PublicId, roleB.PublicId, grpA.PublicId, grpB.PublicId}, id)
+	}
+	roles, err := repo.ListRoles(ctxB, orgA.PublicId, WithLimit(-1), WithRecursive(true))
+	require.NoError(err)
+	var ids []string
+	for _, r := range roles {
+		ids = append(ids, r.PublicId)
+		assert.Contains([]string{orgA.PublicId, projA.PublicId}, r.ScopeId)
+	}
+	assert.Contains(ids, roleA.PublicId)
+}
@@ -13,8 +13,10 @@ import (
 // AddPrincipalRoles provides the ability to add principals (userIds and
 // groupIds) to a role (roleId).  The role's current db version must match the
 // roleVersion or an error will be returned.  The list of current PrincipalRoles
-// after the adds will be returned on success. Zero is not a valid value for
-// the WithVersion option and will return an error.
+// after the adds will be returned on success. Supports the WithNotBefore and
+// WithNotAfter options, which bound when the added principals have the role,
+// and the WithRoleDiff option. Zero is not a valid value for the WithVersion option and will return an
+// error.
 func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleVersion uint32, principalIds []string, opt ...Option) ([]PrincipalRole, error) {
 	if roleId == "" {
 		return nil, fmt.Errorf("add principal roles: missing role id: %w", db.ErrInvalidParameter)
@@ -32,7 +34,7 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 
 	newUserRoles := make([]interface{}, 0, len(userIds))
 	for _, id := range userIds {
-		usrRole, err := NewUserRole(roleId, id)
+		usrRole, err := NewUserRole(roleId, id, opt...)
 		if err != nil {
 			return nil, fmt.Errorf("add principal roles: unable to create in memory user role: %w", err)
 		}
@@ -40,7 +42,7 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 	}
 	newGrpRoles := make([]interface{}, 0, len(groupIds))
 	for _, id := range groupIds {
-		grpRole, err := NewGroupRole(roleId, id)
+		grpRole, err := NewGroupRole(roleId, id, opt...)
 		if err != nil {
 			return nil, fmt.Errorf("add principal roles: unable to create in memory group role: %w", err)
 		}
@@ -58,6 +60,13 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 	if err != nil {
 		return nil, fmt.Errorf("add principal roles: unable to get oplog wrapper: %w", err)
 	}
+	dbWrapper, err := r.databaseWrapper(ctx, scope.GetPublicId())
+	if err != nil {
+		return nil, fmt.Errorf("add principal roles: %w", err)
+	}
+	if err := signIntegrity(dbWrapper, append(newUserRoles, newGrpRoles...)...); err != nil {
+		return nil, fmt.Errorf("add principal roles: %w", err)
+	}
 
 	var currentPrincipals []PrincipalRole
 	_, err = r.writer.DoTx(
@@ -85,14 +94,14 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 			if len(newUserRoles) > 0 {
 				userOplogMsgs := make([]*oplog.Message, 0, len(newUserRoles))
 				if err := w.CreateItems(ctx, newUserRoles, db.NewOplogMsgs(&userOplogMsgs)); err != nil {
-					return fmt.Errorf("add principal roles: unable to add users: %w", err)
+					return fmt.Errorf("add principal roles: unable to add users: %w", wrapOrgIsolationError(err))
 				}
 				msgs = append(msgs, userOplogMsgs...)
 			}
 			if len(newGrpRoles) > 0 {
 				grpOplogMsgs := make([]*oplog.Message, 0, len(newGrpRoles))
 				if err := w.CreateItems(ctx, newGrpRoles, db.NewOplogMsgs(&grpOplogMsgs)); err != nil {
-					return fmt.Errorf("add principal roles: unable to add groups: %w", err)
+					return fmt.Errorf("add principal roles: unable to add groups: %w", wrapOrgIsolationError(err))
 				}
 				msgs = append(msgs, grpOplogMsgs...)
 			}
@@ -123,6 +132,8 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 	if err != nil {
 		return nil, fmt.Errorf("add principal roles: error creating roles: %w", err)
 	}
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	r.observeRoleChange(rolePrincipalsDiff(roleId, &principalSet{addUserRoles: newUserRoles, addGroupRoles: newGrpRoles}), true, opt...)
 	return currentPrincipals, nil
 }
 
@@ -130,7 +141,7 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 // principals as need to reconcile the existing principals with the principals
 // requested. If both userIds and groupIds are empty, the principal roles will
 // be cleared. Zero is not a valid value for the WithVersion option and will
-// return an error.
+// return an error. Supports the WithRoleDiff option.
 func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleVersion uint32, principalIds []string, opt ...Option) ([]PrincipalRole, int, error) {
 	if roleId == "" {
 		return nil, db.NoRowsAffected, fmt.Errorf("set principal roles: missing role id: %w", db.ErrInvalidParameter)
@@ -153,8 +164,11 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 		return nil, db.NoRowsAffected, fmt.Errorf("set principal roles: unable to determine set: %w", err)
 	}
 
+	roleDiff := rolePrincipalsDiff(roleId, toSet)
+
 	// handle no change to existing principal roles
 	if len(toSet.unchangedPrincipalRoles) > 0 {
+		r.observeRoleChange(roleDiff, false, opt...)
 		return toSet.unchangedPrincipalRoles, db.NoRowsAffected, nil
 	}
 
@@ -166,6 +180,13 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 	if err != nil {
 		return nil, db.NoRowsAffected, fmt.Errorf("set principal roles: unable to get oplog wrapper: %w", err)
 	}
+	dbWrapper, err := r.databaseWrapper(ctx, scope.GetPublicId())
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set principal roles: %w", err)
+	}
+	if err := signIntegrity(dbWrapper, append(toSet.addUserRoles, toSet.addGroupRoles...)...); err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set principal roles: %w", err)
+	}
 
 	var currentPrincipals []PrincipalRole
 	var totalRowsAffected int
@@ -233,7 +254,7 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 				if len(toSet.addUserRoles) > 0 {
 					userOplogMsgs := make([]*oplog.Message, 0, len(toSet.addUserRoles))
 					if err := w.CreateItems(ctx, toSet.addUserRoles, db.NewOplogMsgs(&userOplogMsgs)); err != nil {
-						return fmt.Errorf("set principal roles: unable to add users: %w", err)
+						return fmt.Errorf("set principal roles: unable to add users: %w", wrapOrgIsolationError(err))
 					}
 					totalRowsAffected += len(toSet.addUserRoles)
 					msgs = append(msgs, userOplogMsgs...)
@@ -241,7 +262,7 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 				if len(toSet.addGroupRoles) > 0 {
 					grpOplogMsgs := make([]*oplog.Message, 0, len(toSet.addGroupRoles))
 					if err := w.CreateItems(ctx, toSet.addGroupRoles, db.NewOplogMsgs(&grpOplogMsgs)); err != nil {
-						return fmt.Errorf("set principal roles: unable to add groups: %w", err)
+						return fmt.Errorf("set principal roles: unable to add groups: %w", wrapOrgIsolationError(err))
 					}
 					totalRowsAffected += len(toSet.addGroupRoles)
 					msgs = append(msgs, grpOplogMsgs...)
@@ -267,13 +288,15 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 	if err != nil {
 		return nil, db.NoRowsAffected, fmt.Errorf("set principal roles: unable to set principals: %w", err)
 	}
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	r.observeRoleChange(roleDiff, true, opt...)
 	return currentPrincipals, totalRowsAffected, nil
 }
 
 // DeletePrincipalRoles principals (userIds and/or groupIds) from a role
 // (roleId). The role's current db version must match the roleVersion or an
 // error will be returned. Zero is not a valid value for the WithVersion option
-// and will return an error.
+// and will return an error. Supports the WithRoleDiff option.
 func (r *Repository) DeletePrincipalRoles(ctx context.Context, roleId string, roleVersion uint32, principalIds []string, opt ...Option) (int, error) {
 	if roleId == "" {
 		return db.NoRowsAffected, fmt.Errorf("delete principal roles: missing role id: %w", db.ErrInvalidParameter)
@@ -379,6 +402,8 @@ func (r *Repository) DeletePrincipalRoles(ctx context.Context, roleId string, ro
 	if err != nil {
 		return db.NoRowsAffected, fmt.Errorf("delete principal roles: error deleting principal roles: %w", err)
 	}
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	r.observeRoleChange(rolePrincipalsDiff(roleId, &principalSet{deleteUserRoles: deleteUserRoles, deleteGroupRoles: deleteGrpRoles}), true, opt...)
 	return totalRowsDeleted, nil
 }
 
@@ -388,7 +413,7 @@ func (r *Repository) ListPrincipalRoles(ctx context.Context, roleId string, opt
 		return nil, fmt.Errorf("lookup principal roles: missing role id: %w", db.ErrInvalidParameter)
 	}
 	var roles []PrincipalRole
-	if err := r.list(ctx, &roles, "role_id = ?", []interface{}{roleId}, opt...); err != nil {
+	if err := r.list(ctx, &roles, "role_id = ? and "+principalRoleOrgIsolation, []interface{}{roleId}, opt...); err != nil {
 		return nil, fmt.Errorf("lookup principal role: unable to lookup roles: %w", err)
 	}
 	principals := make([]PrincipalRole, 0, len(roles))
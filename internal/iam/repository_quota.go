@@ -0,0 +1,156 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// ErrQuotaExceeded is matched by every QuotaError.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// Quotas limit how many roles, grants and group members can be written. Zero
+// means no limit.
+type Quotas struct {
+	MaxRolesPerScope   int
+	MaxGrantsPerRole   int
+	MaxMembersPerGroup int
+}
+
+// QuotaError is returned when a write would take a resource past one of the
+// repository's Quotas. errors.Is(err, ErrQuotaExceeded) reports whether an
+// error is a QuotaError.
+type QuotaError struct {
+	// Quota is the name of the exceeded quota, like "max_grants_per_role".
+	Quota string
+	// Id is the public id of the scope, role or group which is limited.
+	Id    string
+	Limit int
+	// Count is the number of resources the write would have left.
+	Count int
+}
+
+// Error implements the error interface.
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s would have %d, over the %s quota of %d", e.Id, e.Count, e.Quota, e.Limit)
+}
+
+// Is reports whether target is ErrQuotaExceeded.
+func (e *QuotaError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// QuotaAlertThresholds are the percentages of a quota at which a QuotaAlert
+// is raised, in ascending order.
+var QuotaAlertThresholds = []int{80, 90, 100}
+
+var quotaMetricsPrefix = []string{"boundary", "iam", "quota"}
+
+// QuotaAlert is raised when a write takes a resource past one of the
+// QuotaAlertThresholds of a quota, so operators are warned before writes
+// start failing with a QuotaError. Only the highest threshold passed by a
+// write is raised.
+type QuotaAlert struct {
+	// Quota is the name of the quota, like "max_grants_per_role".
+	Quota string
+	// ScopeId is the scope of the resource which is limited.
+	ScopeId string
+	// Id is the public id of the scope, role or group which is limited.
+	Id    string
+	Limit int
+	// Count is the number of resources after the write.
+	Count int
+	// Threshold is the percentage of the limit which was passed.
+	Threshold int
+}
+
+// quotaUsage is how much of a quota a write leaves in use.
+type quotaUsage struct {
+	quota string
+	id    string
+	limit int
+	count int
+}
+
+// checkQuota counts the rows of id with query and returns a QuotaError if
+// there are more than limit once adding more are written. It's meant to be
+// called inside the transaction of the write, once the row being added to is
+// locked. The usage it returns, which is nil when there's no limit, should be
+// passed to observeQuota once the write has committed.
+func checkQuota(ctx context.Context, reader db.Reader, quota string, limit, adding int, query, id string) (*quotaUsage, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	rows, err := reader.Query(ctx, query, []interface{}{id})
+	if err != nil {
+		return nil, fmt.Errorf("unable to count for %s quota: %w", quota, err)
+	}
+	defer rows.Close()
+	var count int
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return nil, fmt.Errorf("unable to scan count for %s quota: %w", quota, err)
+		}
+	}
+	count += adding
+	if count > limit {
+		return nil, &QuotaError{Quota: quota, Id: id, Limit: limit, Count: count}
+	}
+	return &quotaUsage{quota: quota, id: id, limit: limit, count: count}, nil
+}
+
+// checkRoleQuota locks the scope and returns a QuotaError if creating a role
+// in it would exceed the MaxRolesPerScope quota.
+func (r *Repository) checkRoleQuota(ctx context.Context, reader db.Reader, scopeId string) (*quotaUsage, error) {
+	if r.quotas.MaxRolesPerScope <= 0 {
+		return nil, nil
+	}
+	rows, err := reader.Query(ctx, lockScopeForQuota, []interface{}{scopeId})
+	if err != nil {
+		return nil, fmt.Errorf("unable to lock scope for max_roles_per_scope quota: %w", err)
+	}
+	rows.Close()
+	return checkQuota(ctx, reader, "max_roles_per_scope", r.quotas.MaxRolesPerScope, 1, countScopeRoles, scopeId)
+}
+
+// observeQuota emits the usage a committed write (which added added
+// resources, or removed them if it's negative) left of a quota in the scope,
+// and raises a QuotaAlert if the write passed one of the
+// QuotaAlertThresholds. It does nothing if u is nil.
+func (r *Repository) observeQuota(u *quotaUsage, scopeId string, added int) {
+	if u == nil {
+		return
+	}
+	labels := []metrics.Label{{Name: "quota", Value: u.quota}, {Name: "scope_id", Value: scopeId}}
+	r.metrics.AddSampleWithLabels(append(quotaMetricsPrefix, "usage"), float32(u.count*100)/float32(u.limit), labels)
+	if added > 0 {
+		r.metrics.IncrCounterWithLabels(append(quotaMetricsPrefix, "added"), float32(added), labels)
+	}
+
+	previous := u.count - added
+	var threshold int
+	for _, t := range QuotaAlertThresholds {
+		if previous*100 < t*u.limit && u.count*100 >= t*u.limit {
+			threshold = t
+		}
+	}
+	if threshold == 0 {
+		return
+	}
+	r.metrics.IncrCounterWithLabels(append(quotaMetricsPrefix, "alerts"), 1,
+		append(labels, metrics.Label{Name: "threshold", Value: strconv.Itoa(threshold)}))
+	if r.quotaAlerts != nil {
+		r.quotaAlerts(&QuotaAlert{
+			Quota:     u.quota,
+			ScopeId:   scopeId,
+			Id:        u.id,
+			Limit:     u.limit,
+			Count:     u.count,
+			Threshold: threshold,
+		})
+	}
+}
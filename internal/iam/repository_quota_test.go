@@ -0,0 +1,166 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Quotas(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	repo := TestRepo(t, conn, wrapper, WithQuotas(Quotas{
+		MaxRolesPerScope:   2,
+		MaxGrantsPerRole:   2,
+		MaxMembersPerGroup: 2,
+	}))
+	org, proj := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+
+	t.Run("negative", func(t *testing.T) {
+		_, err := NewRepository(repo.reader, repo.writer, repo.kms, WithQuotas(Quotas{MaxGrantsPerRole: -1}))
+		assert.Error(t, err)
+	})
+	t.Run("roles-per-scope", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		for i := 0; i < 2; i++ {
+			role, err := NewRole(proj.PublicId)
+			require.NoError(err)
+			_, err = repo.CreateRole(ctx, role)
+			require.NoError(err)
+		}
+		role, err := NewRole(proj.PublicId)
+		require.NoError(err)
+		_, err = repo.CreateRole(ctx, role)
+		require.Error(err)
+		assert.True(errors.Is(err, ErrQuotaExceeded))
+		var qe *QuotaError
+		require.True(errors.As(err, &qe))
+		assert.Equal("max_roles_per_scope", qe.Quota)
+		assert.Equal(proj.PublicId, qe.Id)
+		assert.Equal(2, qe.Limit)
+		assert.Equal(3, qe.Count)
+	})
+	t.Run("grants-per-role", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, org.PublicId)
+		_, err := repo.AddRoleGrants(ctx, role.PublicId, 1, []string{"id=*;type=*;actions=read", "id=*;type=*;actions=update"})
+		require.NoError(err)
+		_, err = repo.AddRoleGrants(ctx, role.PublicId, 2, []string{"id=*;type=*;actions=delete"})
+		assert.True(errors.Is(err, ErrQuotaExceeded))
+		grants, err := repo.ListRoleGrants(ctx, role.PublicId)
+		require.NoError(err)
+		assert.Len(grants, 2)
+
+		// Set replaces the grants, so only the final count matters
+		_, _, _, err = repo.SetRoleGrants(ctx, role.PublicId, 2, []string{"id=*;type=*;actions=create", "id=*;type=*;actions=delete"})
+		require.NoError(err)
+		_, _, _, err = repo.SetRoleGrants(ctx, role.PublicId, 3, []string{"id=*;type=*;actions=read", "id=*;type=*;actions=update", "id=*;type=*;actions=delete"})
+		assert.True(errors.Is(err, ErrQuotaExceeded))
+	})
+	t.Run("members-per-group", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		group := TestGroup(t, conn, org.PublicId)
+		u1, u2, u3 := TestUser(t, repo, org.PublicId), TestUser(t, repo, org.PublicId), TestUser(t, repo, org.PublicId)
+		_, err := repo.AddGroupMembers(ctx, group.PublicId, 1, []string{u1.PublicId, u2.PublicId})
+		require.NoError(err)
+		_, err = repo.AddGroupMembers(ctx, group.PublicId, 2, []string{u3.PublicId})
+		assert.True(errors.Is(err, ErrQuotaExceeded))
+
+		_, _, err = repo.SetGroupMembers(ctx, group.PublicId, 2, []string{u1.PublicId, u2.PublicId, u3.PublicId})
+		assert.True(errors.Is(err, ErrQuotaExceeded))
+		_, _, err = repo.SetGroupMembers(ctx, group.PublicId, 2, []string{u3.PublicId})
+		require.NoError(err)
+	})
+}
+
+func TestRepository_QuotaAlerts(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+
+	sink := metrics.NewInmemSink(time.Minute, time.Minute)
+	conf := metrics.DefaultConfig("test")
+	conf.EnableHostname = false
+	conf.EnableRuntimeMetrics = false
+	m, err := metrics.New(conf, sink)
+	require.NoError(err)
+	var alerts []*QuotaAlert
+	repo := TestRepo(t, conn, wrapper, WithQuotas(Quotas{MaxGrantsPerRole: 10}), WithMetrics(m), WithQuotaAlerts(func(a *QuotaAlert) {
+		alerts = append(alerts, a)
+	}))
+	org, _ := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	role := TestRole(t, conn, org.PublicId)
+
+	grants := func(actions ...string) []string {
+		var g []string
+		for _, a := range actions {
+			g = append(g, "id=*;type=*;actions="+a)
+		}
+		return g
+	}
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, 1, grants("read", "update", "delete", "list", "create", "cancel", "authenticate"))
+	require.NoError(err)
+	assert.Empty(alerts)
+
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, 2, grants("deauthenticate"))
+	require.NoError(err)
+	require.Len(alerts, 1)
+	assert.Equal(&QuotaAlert{
+		Quota:     "max_grants_per_role",
+		ScopeId:   org.PublicId,
+		Id:        role.PublicId,
+		Limit:     10,
+		Count:     8,
+		Threshold: 80,
+	}, alerts[0])
+
+	// A dry run doesn't alert
+	_, _, _, err = repo.SetRoleGrants(ctx, role.PublicId, 3, grants("read", "update", "delete", "list", "create", "cancel", "authenticate", "deauthenticate", "add-grants", "set-grants"), WithDryRun(true))
+	require.NoError(err)
+	assert.Len(alerts, 1)
+
+	// Only the highest threshold passed alerts
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, 3, grants("add-grants", "set-grants"))
+	require.NoError(err)
+	require.Len(alerts, 2)
+	assert.Equal(100, alerts[1].Threshold)
+	assert.Equal(10, alerts[1].Count)
+
+	// Falling back below a threshold and passing it again alerts again
+	_, err = repo.DeleteRoleGrants(ctx, role.PublicId, 4, grants("add-grants", "set-grants"))
+	require.NoError(err)
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, 5, grants("add-grants"))
+	require.NoError(err)
+	require.Len(alerts, 3)
+	assert.Equal(90, alerts[2].Threshold)
+
+	data := sink.Data()
+	require.NotEmpty(data)
+	var keys []string
+	for k := range data[0].Counters {
+		keys = append(keys, k)
+	}
+	for k := range data[0].Samples {
+		keys = append(keys, k)
+	}
+	all := strings.Join(keys, "\n")
+	for _, want := range []string{
+		"boundary.iam.quota.alerts;quota=max_grants_per_role;scope_id=" + org.PublicId + ";threshold=80",
+		"boundary.iam.quota.alerts;quota=max_grants_per_role;scope_id=" + org.PublicId + ";threshold=100",
+		"boundary.iam.quota.added;quota=max_grants_per_role;scope_id=" + org.PublicId,
+		"boundary.iam.quota.usage;quota=max_grants_per_role;scope_id=" + org.PublicId,
+	} {
+		assert.Contains(all, want)
+	}
+}
@@ -11,7 +11,9 @@ import (
 )
 
 // CreateRole will create a role in the repository and return the written
-// role.  No options are currently supported.
+// role.  Supports the WithDryRun and WithIdempotencyKey options. A role
+// created again with the same idempotency key returns the role created the
+// first time.
 func (r *Repository) CreateRole(ctx context.Context, role *Role, opt ...Option) (*Role, error) {
 	if role == nil {
 		return nil, fmt.Errorf("create role: missing role %w", db.ErrInvalidParameter)
@@ -29,9 +31,20 @@ func (r *Repository) CreateRole(ctx context.Context, role *Role, opt ...Option)
 	if err != nil {
 		return nil, fmt.Errorf("create role: %w", err)
 	}
-	c := role.Clone().(*Role)
+	c := role.Clone()
 	c.PublicId = id
-	resource, err := r.create(ctx, c)
+	resource, err := r.create(ctx, c, opt...)
+	var replay *idempotentReplayError
+	if errors.As(err, &replay) {
+		existing, _, _, err := r.LookupRole(ctx, replay.resourceId)
+		if err != nil {
+			return nil, fmt.Errorf("create role: unable to lookup role created with idempotency key: %w", err)
+		}
+		if existing == nil {
+			return nil, fmt.Errorf("create role: role %s created with idempotency key no longer exists: %w", replay.resourceId, db.ErrRecordNotFound)
+		}
+		return existing, nil
+	}
 	if err != nil {
 		if db.IsUniqueError(err) {
 			return nil, fmt.Errorf("create role: role %s already exists in scope %s: %w", role.Name, role.ScopeId, db.ErrNotUnique)
@@ -44,9 +57,10 @@ func (r *Repository) CreateRole(ctx context.Context, role *Role, opt ...Option)
 // UpdateRole will update a role in the repository and return the written role.
 // fieldMaskPaths provides field_mask.proto paths for fields that should be
 // updated.  Fields will be set to NULL if the field is a zero value and
-// included in fieldMask. Name, Description, and GrantScopeId are the only
-// updatable fields, If no updatable fields are included in the fieldMaskPaths,
-// then an error is returned.
+// included in fieldMask. Name, Description, GrantScopeId and ExpirationTime are
+// the only updatable fields, If no updatable fields are included in the
+// fieldMaskPaths, then an error is returned. Archived roles can't be updated
+// until they're restored. Supports the WithDryRun and WithRoleDiff options.
 func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32, fieldMaskPaths []string, opt ...Option) (*Role, []PrincipalRole, []*RoleGrant, int, error) {
 	if role == nil {
 		return nil, nil, nil, db.NoRowsAffected, fmt.Errorf("update role: missing role %w", db.ErrInvalidParameter)
@@ -62,6 +76,7 @@ func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32,
 		case strings.EqualFold("name", f):
 		case strings.EqualFold("description", f):
 		case strings.EqualFold("grantscopeid", f):
+		case strings.EqualFold("expirationtime", f):
 		default:
 			return nil, nil, nil, db.NoRowsAffected, fmt.Errorf("update role: field: %s: %w", f, db.ErrInvalidFieldMask)
 		}
@@ -69,9 +84,10 @@ func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32,
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"name":         role.Name,
-			"description":  role.Description,
-			"GrantScopeId": role.GrantScopeId,
+			"name":           role.Name,
+			"description":    role.Description,
+			"GrantScopeId":   role.GrantScopeId,
+			"ExpirationTime": role.ExpirationTime,
 		},
 		fieldMaskPaths,
 		nil,
@@ -79,21 +95,51 @@ func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32,
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, nil, nil, db.NoRowsAffected, fmt.Errorf("update role: %w", db.ErrEmptyFieldMask)
 	}
+	var grantScopeUpdated bool
+	for _, f := range append(dbMask, nullFields...) {
+		if strings.EqualFold("GrantScopeId", f) {
+			grantScopeUpdated = true
+		}
+	}
 	var resource Resource
 	var rowsUpdated int
 	var pr []PrincipalRole
 	var rg []*RoleGrant
+	before := allocRole()
+	before.PublicId = role.PublicId
 	_, err := r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(read db.Reader, w db.Writer) error {
+			if err := read.LookupByPublicId(ctx, &before); err != nil {
+				return fmt.Errorf("update role: unable to lookup role: %w", err)
+			}
+			if before.ArchiveTime != nil {
+				return fmt.Errorf("update role: role is archived and must be restored first: %w", db.ErrInvalidParameter)
+			}
 			var err error
-			c := role.Clone().(*Role)
-			resource, rowsUpdated, err = r.update(ctx, c, version, dbMask, nullFields)
+			c := role.Clone()
+			resource, rowsUpdated, err = r.update(ctx, c, version, dbMask, nullFields, opt...)
 			if err != nil {
 				return err
 			}
+			if grantScopeUpdated && !getOpts(opt...).withDryRun {
+				// The integrity hmacs of the role's grants cover its grant
+				// scope, so they're signed again
+				updated := allocRole()
+				updated.PublicId = role.PublicId
+				if err := read.LookupByPublicId(ctx, &updated); err != nil {
+					return fmt.Errorf("update role: unable to lookup updated role: %w", err)
+				}
+				dbWrapper, err := r.databaseWrapper(ctx, updated.ScopeId)
+				if err != nil {
+					return fmt.Errorf("update role: %w", err)
+				}
+				if err := resignRoleGrants(ctx, read, w, dbWrapper, &updated); err != nil {
+					return fmt.Errorf("update role: %w", err)
+				}
+			}
 			repo, err := NewRepository(read, w, r.kms)
 			if err != nil {
 				return fmt.Errorf("update role: failed creating inner repo: %w for %s", err, role.PublicId)
@@ -115,6 +161,9 @@ func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32,
 		}
 		return nil, nil, nil, db.NoRowsAffected, fmt.Errorf("update role: %w for %s", err, role.PublicId)
 	}
+	r.invalidateGrantsCacheForRoles(ctx, role.PublicId)
+	r.invalidateGrantsCacheScopes(before.GrantScopeId)
+	r.observeRoleChange(roleFieldsDiff(&before, resource.(*Role)), !getOpts(opt...).withDryRun, opt...)
 	return resource.(*Role), pr, rg, rowsUpdated, err
 }
 
@@ -160,7 +209,8 @@ func (r *Repository) LookupRole(ctx context.Context, withPublicId string, opt ..
 	return &role, pr, rg, nil
 }
 
-// DeleteRole will delete a role from the repository.
+// DeleteRole will delete a role from the repository. Supports the WithDryRun
+// option.
 func (r *Repository) DeleteRole(ctx context.Context, withPublicId string, opt ...Option) (int, error) {
 	if withPublicId == "" {
 		return db.NoRowsAffected, fmt.Errorf("delete role: missing public id %w", db.ErrInvalidParameter)
@@ -170,22 +220,44 @@ func (r *Repository) DeleteRole(ctx context.Context, withPublicId string, opt ..
 	if err := r.reader.LookupByPublicId(ctx, &role); err != nil {
 		return db.NoRowsAffected, fmt.Errorf("delete role: failed %w for %s", err, withPublicId)
 	}
-	rowsDeleted, err := r.delete(ctx, &role)
+	// The role's grants and includes are gone once it's deleted, so look up
+	// the scopes they apply in first.
+	grantScopeIds, scopesErr := r.grantScopesOfRoles(ctx, withPublicId)
+	rowsDeleted, err := r.delete(ctx, &role, opt...)
 	if err != nil {
 		return db.NoRowsAffected, fmt.Errorf("delete role: failed %w for %s", err, withPublicId)
 	}
+	if scopesErr != nil {
+		r.invalidateGrantsCache()
+	} else {
+		r.invalidateGrantsCacheScopes(grantScopeIds...)
+	}
 	return rowsDeleted, nil
 }
 
-// ListRoles in a scope and supports WithLimit option.
+// ListRoles in a scope and supports the WithLimit, WithRecursive,
+// WithTagFilter, WithPageToken, WithNextPageToken, WithArchived and
+// WithListedScopes options. With WithRecursive, roles in the scope's child
+// scopes are included and each role's ScopeId identifies the scope it is in,
+// whose name and type WithListedScopes returns; with hard org isolation
+// enabled, only the child org of the user in the context's request info is
+// included. Archived roles are left out unless WithArchived is set.
 func (r *Repository) ListRoles(ctx context.Context, withScopeId string, opt ...Option) ([]*Role, error) {
 	if withScopeId == "" {
 		return nil, fmt.Errorf("list roles: missing scope id %w", db.ErrInvalidParameter)
 	}
 	var roles []*Role
-	err := r.list(ctx, &roles, "scope_id = ?", []interface{}{withScopeId}, opt...)
+	where, args := scopeClause(ctx, withScopeId, opt...)
+	where, args = tagFilterClause(where, args, opt...)
+	if !getOpts(opt...).withArchived {
+		where = "(" + where + ") and archive_time is null"
+	}
+	err := r.list(ctx, &roles, where, args, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list roles: %w", err)
 	}
+	if err := r.addListedScopes(ctx, roles, opt...); err != nil {
+		return nil, fmt.Errorf("list roles: %w", err)
+	}
 	return roles, nil
 }
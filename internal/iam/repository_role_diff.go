@@ -0,0 +1,181 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// DiffRoles compares two roles and returns the changes which would turn the
+// role fromRoleId into the role toRoleId: the fields which differ, and the
+// grants and principals only one of them has.
+func (r *Repository) DiffRoles(ctx context.Context, fromRoleId, toRoleId string) (*RoleDiff, error) {
+	if fromRoleId == "" || toRoleId == "" {
+		return nil, fmt.Errorf("diff roles: missing role id: %w", db.ErrInvalidParameter)
+	}
+	from, fromPrincipals, fromGrants, err := r.LookupRole(ctx, fromRoleId)
+	if err != nil {
+		return nil, fmt.Errorf("diff roles: %w", err)
+	}
+	if from == nil {
+		return nil, fmt.Errorf("diff roles: role %s not found: %w", fromRoleId, db.ErrRecordNotFound)
+	}
+	to, toPrincipals, toGrants, err := r.LookupRole(ctx, toRoleId)
+	if err != nil {
+		return nil, fmt.Errorf("diff roles: %w", err)
+	}
+	if to == nil {
+		return nil, fmt.Errorf("diff roles: role %s not found: %w", toRoleId, db.ErrRecordNotFound)
+	}
+
+	grants := func(rgs []*RoleGrant) map[string]bool {
+		m := make(map[string]bool, len(rgs))
+		for _, g := range rgs {
+			m[g.CanonicalGrant] = true
+		}
+		return m
+	}
+	principals := func(prs []PrincipalRole) map[string]bool {
+		m := make(map[string]bool, len(prs))
+		for _, p := range prs {
+			m[p.PrincipalId] = true
+		}
+		return m
+	}
+	d := roleFieldsDiff(from, to)
+	d.FromRoleId = from.PublicId
+	d.GrantsAdded, d.GrantsRemoved = setDiff(grants(fromGrants), grants(toGrants))
+	d.PrincipalsAdded, d.PrincipalsRemoved = setDiff(principals(fromPrincipals), principals(toPrincipals))
+	return d, nil
+}
+
+// roleHistoryFields are the fields of a role compared by RoleDiffBetween.
+var roleHistoryFields = []string{"name", "description", "grant_scope_id"}
+
+// roleHistoryState is a role as of a point in its history.
+type roleHistoryState struct {
+	fields     map[string]string
+	grants     map[string]bool
+	principals map[string]bool
+}
+
+func newRoleHistoryState() *roleHistoryState {
+	return &roleHistoryState{
+		fields:     map[string]string{},
+		grants:     map[string]bool{},
+		principals: map[string]bool{},
+	}
+}
+
+// clone returns a copy of the state.
+func (s *roleHistoryState) clone() *roleHistoryState {
+	c := newRoleHistoryState()
+	for k, v := range s.fields {
+		c.fields[k] = v
+	}
+	for k := range s.grants {
+		c.grants[k] = true
+	}
+	for k := range s.principals {
+		c.principals[k] = true
+	}
+	return c
+}
+
+// apply applies a change from the role's history to the state.
+func (s *roleHistoryState) apply(c *HistoryChange) {
+	value := func(name string) string {
+		for _, f := range c.Fields {
+			if f.Name == name {
+				if c.OpType == oplog.OpType_OP_TYPE_DELETE {
+					return f.Before
+				}
+				return f.After
+			}
+		}
+		return ""
+	}
+	switch c.TypeName {
+	case defaultRoleTableName:
+		if c.OpType == oplog.OpType_OP_TYPE_DELETE {
+			*s = *newRoleHistoryState()
+			return
+		}
+		for _, f := range c.Fields {
+			for _, name := range roleHistoryFields {
+				if f.Name == name {
+					s.fields[name] = f.After
+				}
+			}
+		}
+	case defaultRoleGrantTable:
+		switch c.OpType {
+		case oplog.OpType_OP_TYPE_CREATE:
+			s.grants[value("canonical_grant")] = true
+		case oplog.OpType_OP_TYPE_DELETE:
+			delete(s.grants, value("canonical_grant"))
+		}
+	case userRoleDefaultTable, groupRoleDefaultTable:
+		switch c.OpType {
+		case oplog.OpType_OP_TYPE_CREATE:
+			s.principals[value("principal_id")] = true
+		case oplog.OpType_OP_TYPE_DELETE:
+			delete(s.principals, value("principal_id"))
+		}
+	}
+}
+
+// RoleDiffBetween returns the changes made to the role between two points in
+// time, from its history in the oplog: the changes to its name, description
+// and grant scope, and the grants and principals added and removed. Changes
+// which were undone before the later time aren't included. The role can have
+// since been deleted, but changes made before the oldest oplog entry still
+// kept for it aren't known.
+func (r *Repository) RoleDiffBetween(ctx context.Context, roleId string, from, to time.Time) (*RoleDiff, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("role diff between: missing role id: %w", db.ErrInvalidParameter)
+	}
+	if from.IsZero() || to.IsZero() {
+		return nil, fmt.Errorf("role diff between: missing time: %w", db.ErrInvalidParameter)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("role diff between: to time is before from time: %w", db.ErrInvalidParameter)
+	}
+	history, err := r.History(ctx, roleId, WithLimit(-1))
+	if err != nil {
+		return nil, fmt.Errorf("role diff between: %w", err)
+	}
+	if len(history) == 0 {
+		return nil, fmt.Errorf("role diff between: no history for role %s: %w", roleId, db.ErrRecordNotFound)
+	}
+
+	state := newRoleHistoryState()
+	var before *roleHistoryState
+	for _, e := range history {
+		if e.CreateTime.After(to) {
+			break
+		}
+		if before == nil && e.CreateTime.After(from) {
+			before = state.clone()
+		}
+		for _, c := range e.Changes {
+			state.apply(c)
+		}
+	}
+	if before == nil {
+		before = state.clone()
+	}
+
+	d := &RoleDiff{RoleId: roleId, FromTime: &from, ToTime: &to}
+	for _, name := range roleHistoryFields {
+		if before.fields[name] != state.fields[name] {
+			d.Fields = append(d.Fields, &FieldDiff{Field: name, Before: before.fields[name], After: state.fields[name]})
+		}
+	}
+	d.GrantsAdded, d.GrantsRemoved = setDiff(before.grants, state.grants)
+	d.PrincipalsAdded, d.PrincipalsRemoved = setDiff(before.principals, state.principals)
+	return d, nil
+}
@@ -0,0 +1,102 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_DiffRoles(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	ctx := context.Background()
+
+	user := TestUser(t, repo, org.PublicId)
+	grp := TestGroup(t, conn, org.PublicId)
+	from := TestRole(t, conn, org.PublicId, WithName("readers"))
+	TestRoleGrant(t, conn, from.PublicId, "id=*;actions=read")
+	TestRoleGrant(t, conn, from.PublicId, "id=*;actions=list")
+	TestUserRole(t, conn, from.PublicId, user.PublicId)
+	to := TestRole(t, conn, org.PublicId, WithName("writers"))
+	TestRoleGrant(t, conn, to.PublicId, "id=*;actions=read")
+	TestRoleGrant(t, conn, to.PublicId, "id=*;actions=update")
+	TestGroupRole(t, conn, to.PublicId, grp.PublicId)
+
+	diff, err := repo.DiffRoles(ctx, from.PublicId, to.PublicId)
+	require.NoError(err)
+	assert.Equal(&RoleDiff{
+		RoleId:            to.PublicId,
+		FromRoleId:        from.PublicId,
+		Fields:            []*FieldDiff{{Field: "name", Before: "readers", After: "writers"}},
+		GrantsAdded:       []string{"id=*;actions=update"},
+		GrantsRemoved:     []string{"id=*;actions=list"},
+		PrincipalsAdded:   []string{grp.PublicId},
+		PrincipalsRemoved: []string{user.PublicId},
+	}, diff)
+
+	diff, err = repo.DiffRoles(ctx, from.PublicId, from.PublicId)
+	require.NoError(err)
+	assert.True(diff.Empty())
+
+	_, err = repo.DiffRoles(ctx, from.PublicId, "r_doesntexist")
+	assert.True(errors.Is(err, db.ErrRecordNotFound))
+}
+
+func TestRepository_RoleDiffBetween(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	ctx := context.Background()
+	user := TestUser(t, repo, org.PublicId)
+
+	role, err := NewRole(org.PublicId, WithName("before"))
+	require.NoError(err)
+	role, err = repo.CreateRole(ctx, role)
+	require.NoError(err)
+	role.Name = "after"
+	role, _, _, _, err = repo.UpdateRole(ctx, role, role.Version, []string{"Name"})
+	require.NoError(err)
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, role.Version, []string{"id=*;actions=read", "id=*;actions=delete"})
+	require.NoError(err)
+	_, err = repo.AddPrincipalRoles(ctx, role.PublicId, role.Version+1, []string{user.PublicId})
+	require.NoError(err)
+	_, err = repo.DeleteRoleGrants(ctx, role.PublicId, role.Version+2, []string{"id=*;actions=delete"})
+	require.NoError(err)
+
+	history, err := repo.History(ctx, role.PublicId)
+	require.NoError(err)
+	require.Len(history, 5)
+
+	diff, err := repo.RoleDiffBetween(ctx, role.PublicId, history[0].CreateTime, history[4].CreateTime)
+	require.NoError(err)
+	assert.Equal([]*FieldDiff{{Field: "name", Before: "before", After: "after"}}, diff.Fields)
+	assert.Equal([]string{"id=*;actions=read"}, diff.GrantsAdded)
+	assert.Empty(diff.GrantsRemoved)
+	assert.Equal([]string{user.PublicId}, diff.PrincipalsAdded)
+	assert.Empty(diff.PrincipalsRemoved)
+
+	diff, err = repo.RoleDiffBetween(ctx, role.PublicId, history[2].CreateTime, history[4].CreateTime)
+	require.NoError(err)
+	assert.Empty(diff.Fields)
+	assert.Empty(diff.GrantsAdded)
+	assert.Equal([]string{"id=*;actions=delete"}, diff.GrantsRemoved)
+	assert.Equal([]string{user.PublicId}, diff.PrincipalsAdded)
+
+	diff, err = repo.RoleDiffBetween(ctx, role.PublicId, history[4].CreateTime, history[4].CreateTime)
+	require.NoError(err)
+	assert.True(diff.Empty())
+
+	_, err = repo.RoleDiffBetween(ctx, role.PublicId, history[4].CreateTime, history[0].CreateTime)
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+}
@@ -2,17 +2,26 @@ package iam
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
 )
 
 // AddRoleGrant will add role grants associated with the role ID in the
-// repository. No options are currently supported. Zero is not a valid value for
-// the WithVersion option and will return an error.
+// repository. Supports the WithNotBefore and WithNotAfter options, which bound
+// when the grants apply, the WithRoleDiff option, and the WithIdempotencyKey
+// option. Grants added again with the same idempotency key return the grants
+// added the first time, without checking the role version, and aren't audited
+// again. Zero is not a valid value for the
+// WithVersion option and will return an error.
 func (r *Repository) AddRoleGrants(ctx context.Context, roleId string, roleVersion uint32, grants []string, opt ...Option) ([]*RoleGrant, error) {
 	if roleId == "" {
 		return nil, fmt.Errorf("add role grants: missing role id %w", db.ErrInvalidParameter)
@@ -23,12 +32,15 @@ func (r *Repository) AddRoleGrants(ctx context.Context, roleId string, roleVersi
 	if roleVersion == 0 {
 		return nil, fmt.Errorf("add role grants: version cannot be zero: %w", db.ErrInvalidParameter)
 	}
+	if err := validateGrantLengths(grants, r.maxLengths()); err != nil {
+		return nil, fmt.Errorf("add role grants: %w", err)
+	}
 	role := allocRole()
 	role.PublicId = roleId
 
 	newRoleGrants := make([]interface{}, 0, len(grants))
 	for _, grant := range grants {
-		roleGrant, err := NewRoleGrant(roleId, grant)
+		roleGrant, err := NewRoleGrant(roleId, grant, opt...)
 		if err != nil {
 			return nil, fmt.Errorf("add role grants: unable to create in memory role grant: %w", err)
 		}
@@ -43,12 +55,42 @@ func (r *Repository) AddRoleGrants(ctx context.Context, roleId string, roleVersi
 	if err != nil {
 		return nil, fmt.Errorf("add role grants: unable to get oplog wrapper: %w", err)
 	}
+	dbWrapper, err := r.databaseWrapper(ctx, scope.GetPublicId())
+	if err != nil {
+		return nil, fmt.Errorf("add role grants: %w", err)
+	}
+	// The role's version is checked when the grants are written, so its grant
+	// scope can't change before then.
+	if err := r.reader.LookupByPublicId(ctx, &role); err != nil {
+		return nil, fmt.Errorf("add role grants: unable to look up role %s: %w", roleId, err)
+	}
+	setGrantScope(&role, newRoleGrants...)
+	if err := signIntegrity(dbWrapper, newRoleGrants...); err != nil {
+		return nil, fmt.Errorf("add role grants: %w", err)
+	}
+	opts := getOpts(opt...)
+	var claim *idempotencyClaim
+	if opts.withIdempotencyKey != "" {
+		added := make([]*RoleGrant, 0, len(newRoleGrants))
+		for _, g := range newRoleGrants {
+			added = append(added, g.(*RoleGrant))
+		}
+		if claim, err = roleGrantsClaim(opts.withIdempotencyKey, scope.GetPublicId(), roleId, added); err != nil {
+			return nil, fmt.Errorf("add role grants: %w", err)
+		}
+	}
 
+	var usage *quotaUsage
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(reader db.Reader, w db.Writer) error {
+			if claim != nil {
+				if err := claimIdempotencyKey(ctx, reader, w, claim); err != nil {
+					return err
+				}
+			}
 			msgs := make([]*oplog.Message, 0, 2)
 			roleTicket, err := w.GetTicket(&role)
 			if err != nil {
@@ -72,6 +114,9 @@ func (r *Repository) AddRoleGrants(ctx context.Context, roleId string, roleVersi
 			if err := w.CreateItems(ctx, newRoleGrants, db.NewOplogMsgs(&roleGrantOplogMsgs)); err != nil {
 				return fmt.Errorf("unable to add grants: %w", err)
 			}
+			if usage, err = checkQuota(ctx, reader, "max_grants_per_role", r.quotas.MaxGrantsPerRole, 0, countRoleGrants, roleId); err != nil {
+				return err
+			}
 			msgs = append(msgs, roleGrantOplogMsgs...)
 
 			metadata := oplog.Metadata{
@@ -80,6 +125,9 @@ func (r *Repository) AddRoleGrants(ctx context.Context, roleId string, roleVersi
 				"scope-type":         []string{scope.Type},
 				"resource-public-id": []string{roleId},
 			}
+			if claim != nil {
+				metadata["idempotency-key"] = []string{claim.key}
+			}
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, roleTicket, metadata, msgs); err != nil {
 				return fmt.Errorf("unable to write oplog: %w", err)
 			}
@@ -87,9 +135,18 @@ func (r *Repository) AddRoleGrants(ctx context.Context, roleId string, roleVersi
 			return nil
 		},
 	)
+	roleDiff := roleGrantsDiff(roleId, newRoleGrants, nil)
+	var replay *idempotentReplayError
+	if errors.As(err, &replay) {
+		r.observeRoleChange(roleDiff, false, opt...)
+		return r.replayRoleGrants(ctx, roleId, newRoleGrants)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("add role grants: error creating grants: %w", err)
 	}
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	r.observeQuota(usage, scope.GetPublicId(), len(newRoleGrants))
+	r.observeRoleChange(roleDiff, true, opt...)
 	roleGrants := make([]*RoleGrant, 0, len(newRoleGrants))
 	for _, grant := range newRoleGrants {
 		roleGrants = append(roleGrants, grant.(*RoleGrant))
@@ -97,10 +154,30 @@ func (r *Repository) AddRoleGrants(ctx context.Context, roleId string, roleVersi
 	return roleGrants, nil
 }
 
+// replayRoleGrants returns the grants of the role which were added by an
+// earlier AddRoleGrants with the same idempotency key.
+func (r *Repository) replayRoleGrants(ctx context.Context, roleId string, added []interface{}) ([]*RoleGrant, error) {
+	current, err := r.ListRoleGrants(ctx, roleId, WithLimit(-1))
+	if err != nil {
+		return nil, fmt.Errorf("add role grants: unable to list grants added with idempotency key: %w", err)
+	}
+	want := make(map[string]bool, len(added))
+	for _, g := range added {
+		want[g.(*RoleGrant).CanonicalGrant] = true
+	}
+	roleGrants := make([]*RoleGrant, 0, len(added))
+	for _, g := range current {
+		if want[g.CanonicalGrant] {
+			roleGrants = append(roleGrants, g)
+		}
+	}
+	return roleGrants, nil
+}
+
 // DeleteRoleGrants deletes grants (as strings) from a role (roleId). The role's
 // current db version must match the roleVersion or an error will be returned.
 // Zero is not a valid value for the WithVersion option and will return an
-// error.
+// error. Supports the WithRoleDiff option.
 func (r *Repository) DeleteRoleGrants(ctx context.Context, roleId string, roleVersion uint32, grants []string, opt ...Option) (int, error) {
 	if roleId == "" {
 		return 0, fmt.Errorf("delete role grants: missing role id %w", db.ErrInvalidParameter)
@@ -124,11 +201,13 @@ func (r *Repository) DeleteRoleGrants(ctx context.Context, roleId string, roleVe
 	}
 
 	var totalRowsDeleted int
+	var deletedRoleGrants []interface{}
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(reader db.Reader, w db.Writer) error {
+			deletedRoleGrants = nil
 			msgs := make([]*oplog.Message, 0, 2)
 			roleTicket, err := w.GetTicket(&role)
 			if err != nil {
@@ -191,6 +270,7 @@ func (r *Repository) DeleteRoleGrants(ctx context.Context, roleId string, roleVe
 				return fmt.Errorf("delete role grants: role grants deleted %d did not match request for %d", rowsDeleted, len(deleteRoleGrants))
 			}
 			totalRowsDeleted = rowsDeleted
+			deletedRoleGrants = deleteRoleGrants
 			msgs = append(msgs, roleGrantOplogMsgs...)
 
 			metadata := oplog.Metadata{
@@ -209,38 +289,50 @@ func (r *Repository) DeleteRoleGrants(ctx context.Context, roleId string, roleVe
 	if err != nil {
 		return db.NoRowsAffected, fmt.Errorf("delete role grants: error deleting role grants: %w", err)
 	}
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	r.observeRoleChange(roleGrantsDiff(roleId, nil, deletedRoleGrants), true, opt...)
 	return totalRowsDeleted, nil
 }
 
-// SetRoleGrants sets grants on a role (roleId). The role's current db version
-// must match the roleVersion or an error will be returned. Zero is not a valid
-// value for the WithVersion option and will return an error.
-func (r *Repository) SetRoleGrants(ctx context.Context, roleId string, roleVersion uint32, grants []string, opt ...Option) ([]*RoleGrant, int, error) {
+// RoleGrantDiff describes the changes SetRoleGrants made, or would make, to a
+// role's grants. Grants are in canonical form and sorted.
+type RoleGrantDiff struct {
+	Added   []string
+	Removed []string
+}
+
+// Empty returns true if the diff has no changes.
+func (d *RoleGrantDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// DiffRoleGrants returns the grants that SetRoleGrants would add to and remove
+// from the role (roleId) if called with grants. No options are currently
+// supported.
+func (r *Repository) DiffRoleGrants(ctx context.Context, roleId string, grants []string, opt ...Option) (*RoleGrantDiff, error) {
 	if roleId == "" {
-		return nil, db.NoRowsAffected, fmt.Errorf("set role grants: missing role id %w", db.ErrInvalidParameter)
-	}
-	if roleVersion == 0 {
-		return nil, db.NoRowsAffected, fmt.Errorf("set role grants: version cannot be zero: %w", db.ErrInvalidParameter)
+		return nil, fmt.Errorf("diff role grants: missing role id %w", db.ErrInvalidParameter)
 	}
-
-	// Explicitly set to zero clears, but treat nil as a mistake
 	if grants == nil {
-		return nil, db.NoRowsAffected, fmt.Errorf("set role grants: nil grants: %w", db.ErrInvalidParameter)
+		return nil, fmt.Errorf("diff role grants: nil grants: %w", db.ErrInvalidParameter)
 	}
+	add, del, err := r.roleGrantsDelta(ctx, roleId, grants)
+	if err != nil {
+		return nil, fmt.Errorf("diff role grants: %w", err)
+	}
+	return newRoleGrantDiff(add, del), nil
+}
 
-	role := allocRole()
-	role.PublicId = roleId
-
+// roleGrantsDelta returns the role grants that need to be created and deleted
+// for the role's grants to match grants. Duplicate grants, after
+// canonicalization, are only added once.
+func (r *Repository) roleGrantsDelta(ctx context.Context, roleId string, grants []string) (add, del []*RoleGrant, err error) {
 	// TODO(mgaffney) 08/2020: Use SQL to calculate changes.
 
-	// NOTE: Set calculation can safely take place out of the transaction since
-	// we are using roleVersion to ensure that we end up operating on the same
-	// set of data from this query to the final set in the transaction function
-
 	// Find existing grants
 	roleGrants := []*RoleGrant{}
 	if err := r.reader.SearchWhere(ctx, &roleGrants, "role_id = ?", []interface{}{roleId}); err != nil {
-		return nil, db.NoRowsAffected, fmt.Errorf("set role grants: unable to search for grants: %w", err)
+		return nil, nil, fmt.Errorf("unable to search for grants: %w", err)
 	}
 	found := map[string]*RoleGrant{}
 	for _, rg := range roleGrants {
@@ -248,55 +340,137 @@ func (r *Repository) SetRoleGrants(ctx context.Context, roleId string, roleVersi
 	}
 
 	// Check incoming grants to see if they exist and if so act appropriately
-	currentRoleGrants := make([]*RoleGrant, 0, len(grants)+len(found))
-	addRoleGrants := make([]interface{}, 0, len(grants))
-	deleteRoleGrants := make([]interface{}, 0, len(grants))
+	keep := map[string]bool{}
 	for _, grant := range grants {
 		// Use a fake scope, just want to get out a canonical string
 		perm, err := perms.Parse("o_abcd1234", grant, perms.WithSkipFinalValidation(true))
 		if err != nil {
-			return nil, db.NoRowsAffected, fmt.Errorf("set role grants: error parsing grant string: %w", err)
+			return nil, nil, fmt.Errorf("error parsing grant string: %w", err)
 		}
 		canonicalString := perm.CanonicalString()
+		if keep[canonicalString] {
+			continue
+		}
+		keep[canonicalString] = true
 
-		rg, ok := found[canonicalString]
-		if ok {
+		if _, ok := found[canonicalString]; ok {
 			// If we have an exact match, do nothing, we want to keep
 			// it, but remove from found
-			currentRoleGrants = append(currentRoleGrants, rg)
 			delete(found, canonicalString)
 			continue
 		}
 
 		// Not found, so add
-		rg, err = NewRoleGrant(roleId, grant)
+		rg, err := NewRoleGrant(roleId, grant)
 		if err != nil {
-			return nil, db.NoRowsAffected, fmt.Errorf("set role grants: unable to create in memory role grant: %w", err)
+			return nil, nil, fmt.Errorf("unable to create in memory role grant: %w", err)
 		}
-		addRoleGrants = append(addRoleGrants, rg)
-		currentRoleGrants = append(currentRoleGrants, rg)
+		add = append(add, rg)
 	}
 
-	if len(found) > 0 {
-		for _, rg := range found {
-			deleteRoleGrants = append(deleteRoleGrants, rg)
-		}
+	// Anything we didn't take out of found needs to be removed
+	for _, rg := range found {
+		del = append(del, rg)
 	}
+	return add, del, nil
+}
 
-	if len(addRoleGrants) == 0 && len(deleteRoleGrants) == 0 {
-		return currentRoleGrants, db.NoRowsAffected, nil
+func newRoleGrantDiff(add, del []*RoleGrant) *RoleGrantDiff {
+	d := &RoleGrantDiff{
+		Added:   make([]string, 0, len(add)),
+		Removed: make([]string, 0, len(del)),
+	}
+	for _, rg := range add {
+		d.Added = append(d.Added, rg.CanonicalGrant)
+	}
+	for _, rg := range del {
+		d.Removed = append(d.Removed, rg.CanonicalGrant)
+	}
+	sort.Strings(d.Added)
+	sort.Strings(d.Removed)
+	return d
+}
+
+// SetRoleGrants sets grants on a role (roleId). Only the grants that differ
+// from the role's current grants are added or removed, and the changes are
+// returned along with the role's grants after the set. The role's current db
+// version must match the roleVersion or an error will be returned. Zero is not
+// a valid value for the WithVersion option and will return an error. Supports
+// the WithDryRun option, which returns the grants and changes without making
+// them, and the WithRoleDiff option.
+func (r *Repository) SetRoleGrants(ctx context.Context, roleId string, roleVersion uint32, grants []string, opt ...Option) ([]*RoleGrant, *RoleGrantDiff, int, error) {
+	if roleId == "" {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("set role grants: missing role id %w", db.ErrInvalidParameter)
+	}
+	if roleVersion == 0 {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("set role grants: version cannot be zero: %w", db.ErrInvalidParameter)
+	}
+
+	// Explicitly set to zero clears, but treat nil as a mistake
+	if grants == nil {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("set role grants: nil grants: %w", db.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	role := allocRole()
+	role.PublicId = roleId
+
+	// NOTE: Set calculation can safely take place out of the transaction since
+	// we are using roleVersion to ensure that we end up operating on the same
+	// set of data from this query to the final set in the transaction function
+	add, del, err := r.roleGrantsDelta(ctx, roleId, grants)
+	if err != nil {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("set role grants: %w", err)
+	}
+	diff := newRoleGrantDiff(add, del)
+	roleDiff := &RoleDiff{RoleId: roleId, GrantsAdded: diff.Added, GrantsRemoved: diff.Removed}
+	if diff.Empty() {
+		currentRoleGrants, err := r.ListRoleGrants(ctx, roleId)
+		if err != nil {
+			return nil, nil, db.NoRowsAffected, fmt.Errorf("set role grants: unable to retrieve current role grants: %w", err)
+		}
+		r.observeRoleChange(roleDiff, false, opt...)
+		return currentRoleGrants, diff, db.NoRowsAffected, nil
+	}
+	// Only added grants are checked, so grants written before the length
+	// limits can be kept.
+	addRoleGrants := make([]interface{}, 0, len(add))
+	for _, rg := range add {
+		if err := validateGrantLengths([]string{rg.RawGrant}, r.maxLengths()); err != nil {
+			return nil, nil, db.NoRowsAffected, fmt.Errorf("set role grants: %w", err)
+		}
+		addRoleGrants = append(addRoleGrants, rg)
+	}
+	deleteRoleGrants := make([]interface{}, 0, len(del))
+	for _, rg := range del {
+		deleteRoleGrants = append(deleteRoleGrants, rg)
 	}
 
 	scope, err := role.GetScope(ctx, r.reader)
 	if err != nil {
-		return nil, db.NoRowsAffected, fmt.Errorf("set role grants: unable to get role %s scope: %w", roleId, err)
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("set role grants: unable to get role %s scope: %w", roleId, err)
 	}
 	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
 	if err != nil {
-		return nil, db.NoRowsAffected, fmt.Errorf("set role grants: unable to get oplog wrapper: %w", err)
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("set role grants: unable to get oplog wrapper: %w", err)
+	}
+	dbWrapper, err := r.databaseWrapper(ctx, scope.GetPublicId())
+	if err != nil {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("set role grants: %w", err)
+	}
+	// The role's version is checked when the grants are written, so its grant
+	// scope can't change before then.
+	if err := r.reader.LookupByPublicId(ctx, &role); err != nil {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("set role grants: unable to look up role %s: %w", roleId, err)
+	}
+	setGrantScope(&role, addRoleGrants...)
+	if err := signIntegrity(dbWrapper, addRoleGrants...); err != nil {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("set role grants: %w", err)
 	}
 
+	var currentRoleGrants []*RoleGrant
 	var totalRowsDeleted int
+	var usage *quotaUsage
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
@@ -342,6 +516,11 @@ func (r *Repository) SetRoleGrants(ctx context.Context, roleId string, roleVersi
 				totalRowsDeleted = rowsDeleted
 				msgs = append(msgs, roleGrantOplogMsgs...)
 			}
+			if len(addRoleGrants) > 0 {
+				if usage, err = checkQuota(ctx, reader, "max_grants_per_role", r.quotas.MaxGrantsPerRole, 0, countRoleGrants, roleId); err != nil {
+					return fmt.Errorf("set role grants: %w", err)
+				}
+			}
 
 			metadata := oplog.Metadata{
 				"op-type":            []string{oplog.OpType_OP_TYPE_DELETE.String(), oplog.OpType_OP_TYPE_CREATE.String()},
@@ -353,43 +532,137 @@ func (r *Repository) SetRoleGrants(ctx context.Context, roleId string, roleVersi
 				return fmt.Errorf("set role grants: unable to write oplog: %w", err)
 			}
 
-			currentRoleGrants, err = r.ListRoleGrants(ctx, roleId)
+			// we need a new repo, that's using the same reader/writer as this TxHandler
+			txRepo := &Repository{
+				reader: reader,
+				writer: w,
+				kms:    r.kms,
+				// intentionally not setting the defaultLimit, so we'll get all
+				// the role grants without a limit
+			}
+			currentRoleGrants, err = txRepo.ListRoleGrants(ctx, roleId)
 			if err != nil {
 				return fmt.Errorf("set role grants: unable to retrieve current role grants after set: %w", err)
 			}
 
+			if opts.withDryRun {
+				return errDryRun
+			}
 			return nil
 		},
 	)
-	if err != nil {
-		return nil, db.NoRowsAffected, fmt.Errorf("set role grants: error set role grants: %w", err)
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("set role grants: error set role grants: %w", err)
 	}
-	return currentRoleGrants, totalRowsDeleted, nil
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	if err == nil {
+		r.observeQuota(usage, scope.GetPublicId(), len(addRoleGrants)-len(deleteRoleGrants))
+	}
+	r.observeRoleChange(roleDiff, err == nil, opt...)
+	return currentRoleGrants, diff, totalRowsDeleted, nil
 }
 
 // ListRoleGrants returns the grants for the roleId and supports the WithLimit
-// option.
+// option. With the WithIncludedRoles option, the grants of the roles it
+// includes are returned too, up to MaxRoleIncludeDepth levels down, and the
+// RoleId of each grant is the role it belongs to.
 func (r *Repository) ListRoleGrants(ctx context.Context, roleId string, opt ...Option) ([]*RoleGrant, error) {
 	if roleId == "" {
 		return nil, fmt.Errorf("add role grants: missing role id %w", db.ErrInvalidParameter)
 	}
+	opts := getOpts(opt...)
+	if opts.withIncludedRoles {
+		roleIds, err := r.includedRoleIds(ctx, roleId)
+		if err != nil {
+			return nil, fmt.Errorf("lookup role grants: %w", err)
+		}
+		var roleGrants []*RoleGrant
+		if err := r.list(ctx, &roleGrants, "role_id in (?)", []interface{}{roleIds}, opt...); err != nil {
+			return nil, fmt.Errorf("lookup role grants: unable to lookup role grants: %w", err)
+		}
+		if r.enforceIntegrity {
+			if err := r.verifyRolesIntegrity(ctx, roleIds); err != nil {
+				return nil, fmt.Errorf("lookup role grants: %w", err)
+			}
+		}
+		return roleGrants, nil
+	}
 	var roleGrants []*RoleGrant
 	if err := r.list(ctx, &roleGrants, "role_id = ?", []interface{}{roleId}, opt...); err != nil {
 		return nil, fmt.Errorf("lookup role grants: unable to lookup role grants: %w", err)
 	}
+	if r.enforceIntegrity && len(roleGrants) > 0 {
+		role := allocRole()
+		role.PublicId = roleId
+		if err := r.reader.LookupByPublicId(ctx, &role); err != nil {
+			return nil, fmt.Errorf("lookup role grants: unable to lookup role: %w", err)
+		}
+		rows := make([]integrityRow, 0, len(roleGrants))
+		for _, g := range roleGrants {
+			setGrantScope(&role, g)
+			rows = append(rows, g)
+		}
+		if err := r.verifyIntegrity(ctx, role.ScopeId, rows...); err != nil {
+			return nil, fmt.Errorf("lookup role grants: %w", err)
+		}
+	}
 	return roleGrants, nil
 }
 
+// GrantsForUser returns the grants in effect for the user. Supports the
+// WithGrantScopeId option, which returns only the grants which apply in the
+// scope. Those are looked up through the cache if the repository was created
+// WithGrantsCache, since the cache holds the grants of a user in a scope.
 func (r *Repository) GrantsForUser(ctx context.Context, userId string, opt ...Option) ([]perms.GrantPair, error) {
 	if userId == "" {
 		return nil, fmt.Errorf("get grants for user: missing user id: %w", db.ErrInvalidParameter)
 	}
+	scopeId := getOpts(opt...).withGrantScopeId
+	if r.grantsCache != nil && scopeId != "" {
+		return r.grantsCache.GrantsForUser(userId, scopeId, func() ([]perms.GrantPair, error) {
+			return r.loadGrantsForUser(ctx, userId, scopeId)
+		})
+	}
+	return r.loadGrantsForUser(ctx, userId, scopeId)
+}
+
+// loadGrantsForUser reads the grants in effect for the user from the
+// database, only those which apply in the scope if scopeId is set.
+func (r *Repository) loadGrantsForUser(ctx context.Context, userId, scopeId string) ([]perms.GrantPair, error) {
+	roleGrants, err := r.roleGrantsForUser(ctx, userId, scopeId)
+	if err != nil {
+		return nil, err
+	}
+	grants := make([]perms.GrantPair, 0, len(roleGrants))
+	for _, g := range roleGrants {
+		grants = append(grants, perms.GrantPair{ScopeId: g.ScopeId, Grant: g.Grant})
+	}
+	return grants, nil
+}
+
+// userRoleGrant is a grant in effect for a user along with the role it came
+// from.
+type userRoleGrant struct {
+	RoleId  string
+	ScopeId string
+	Grant   string
+}
+
+// roleGrantsForUser returns the grants in effect for the user, including the
+// grants of u_anon and u_auth and of the roles included by the user's roles,
+// along with the role each grant belongs to. Expired and archived roles, and
+// emergency roles which aren't activated, are left out. If scopeId is set,
+// only the grants which apply in the scope are returned.
+func (r *Repository) roleGrantsForUser(ctx context.Context, userId, scopeId string) ([]userRoleGrant, error) {
 
 	const (
-		anonUser    = `where public_id in ($1)`
-		authUser    = `where public_id in ('u_anon', 'u_auth', $1)`
+		anonUser = `where public_id in ($1)`
+		// Inactive and deleted users only have the grants of u_anon
+		authUser = `where public_id = 'u_anon'
+      or (public_id in ('u_auth', $1)
+          and exists (select from iam_user where public_id = $1 and state = 'active'))`
 		grantsQuery = `
-with
+with recursive
 users (id) as (
   select public_id
     from iam_user
@@ -406,12 +679,14 @@ group_roles (role_id) as (
     from iam_group_role,
          user_groups
    where principal_id in (user_groups.id)
+     and iam_time_bound_in_effect(not_before, not_after)
 ),
 user_roles (role_id) as (
   select role_id
     from iam_user_role,
          users
    where principal_id in (users.id)
+     and iam_time_bound_in_effect(not_before, not_after)
 ),
 user_group_roles (role_id) as (
   select role_id
@@ -426,39 +701,243 @@ roles (role_id, grant_scope_id) as (
     from iam_role,
          user_group_roles
    where public_id in (user_group_roles.role_id)
+     and iam_role_in_effect(expiration_time, archive_time)
+     and iam_emergency_role_active(public_id)
 ),
-final (role_scope, role_grant) as (
-  select roles.grant_scope_id,
-         iam_role_grant.canonical_grant
+-- The grants of included roles apply in the grant scope of the role which
+-- includes them.
+included_roles (role_id, grant_scope_id, depth) as (
+  select role_id,
+         grant_scope_id,
+         0
     from roles
+   union
+  select iam_role_include.included_role_id,
+         included_roles.grant_scope_id,
+         included_roles.depth + 1
+    from iam_role_include,
+         included_roles
+   where iam_role_include.role_id = included_roles.role_id
+     and included_roles.depth < %d -- MaxRoleIncludeDepth
+),
+final (role_id, role_scope, role_grant) as (
+  select distinct
+         included_roles.role_id,
+         included_roles.grant_scope_id,
+         iam_role_grant.canonical_grant
+    from included_roles
+   inner
+    join iam_role
+      on included_roles.role_id = iam_role.public_id
    inner
     join iam_role_grant
-      on roles.role_id = iam_role_grant.role_id
+      on included_roles.role_id = iam_role_grant.role_id
+   where iam_time_bound_in_effect(iam_role_grant.not_before, iam_role_grant.not_after)
+     and iam_role_in_effect(iam_role.expiration_time, iam_role.archive_time)
+     and iam_emergency_role_active(iam_role.public_id)
 )
-select role_scope as scope_id, role_grant as grant from final;
+select role_id, role_scope as scope_id, role_grant as grant
+  from final
+ where $2 = '' or role_scope = $2;
 	`
 	)
 
 	var query string
 	switch userId {
 	case "u_anon":
-		query = fmt.Sprintf(grantsQuery, anonUser)
+		query = fmt.Sprintf(grantsQuery, anonUser, MaxRoleIncludeDepth)
 	default:
-		query = fmt.Sprintf(grantsQuery, authUser)
+		query = fmt.Sprintf(grantsQuery, authUser, MaxRoleIncludeDepth)
 	}
 
-	var grants []perms.GrantPair
-	rows, err := r.reader.Query(ctx, query, []interface{}{userId})
+	var grants []userRoleGrant
+	rows, err := r.reader.Query(ctx, query, []interface{}{userId, scopeId})
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var g perms.GrantPair
-		if err := r.reader.ScanRows(rows, &g); err != nil {
+		var g userRoleGrant
+		if r.fastReads {
+			err = rows.Scan(&g.RoleId, &g.ScopeId, &g.Grant)
+		} else {
+			err = r.reader.ScanRows(rows, &g)
+		}
+		if err != nil {
 			return nil, err
 		}
 		grants = append(grants, g)
 	}
+	if r.enforceIntegrity {
+		roleIds := make([]string, 0, len(grants))
+		seen := map[string]bool{}
+		for _, g := range grants {
+			if !seen[g.RoleId] {
+				seen[g.RoleId] = true
+				roleIds = append(roleIds, g.RoleId)
+			}
+		}
+		if err := r.verifyRolesIntegrity(ctx, roleIds); err != nil {
+			return nil, err
+		}
+	}
 	return grants, nil
 }
+
+// GrantTestResult is the result of TestGrants. When the action is allowed,
+// RoleId, ScopeId and Grant identify the first grant found which allows it.
+// When a deny grant overrides the grants allowing it, Denied is set and they
+// identify the deny grant.
+type GrantTestResult struct {
+	Allowed bool
+	Denied  bool
+	RoleId  string
+	ScopeId string
+	Grant   string
+}
+
+// TestGrants reports whether the user's current grants allow the action on a
+// resource of resourceType with the resourceId (which is empty for
+// collection actions like list and create) in the scope, and which role and
+// grant allowed it. It evaluates the same grants that are used to authorize
+// requests, so it can be used to explain why a user does or doesn't have
+// access without making the request. Grant templates that refer to the
+// account id are not resolved.
+func (r *Repository) TestGrants(ctx context.Context, userId, scopeId string, resourceType resource.Type, act action.Type, resourceId string) (*GrantTestResult, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("test grants: missing user id: %w", db.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return nil, fmt.Errorf("test grants: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if resourceType == resource.Unknown {
+		return nil, fmt.Errorf("test grants: missing resource type: %w", db.ErrInvalidParameter)
+	}
+	if act == action.Unknown {
+		return nil, fmt.Errorf("test grants: missing action: %w", db.ErrInvalidParameter)
+	}
+	roleGrants, err := r.roleGrantsForUser(ctx, userId, "")
+	if err != nil {
+		return nil, fmt.Errorf("test grants: unable to get grants for user: %w", err)
+	}
+	res := perms.Resource{
+		ScopeId: scopeId,
+		Id:      resourceId,
+		Type:    resourceType,
+	}
+	// Every grant is checked since a deny grant overrides any allow grant.
+	result := &GrantTestResult{}
+	for _, g := range roleGrants {
+		if g.ScopeId != scopeId {
+			continue
+		}
+		parsed, err := perms.Parse(g.ScopeId, g.Grant, perms.WithUserId(userId), perms.WithSkipFinalValidation(true))
+		if err != nil {
+			return nil, fmt.Errorf("test grants: unable to parse grant %q of role %s: %w", g.Grant, g.RoleId, err)
+		}
+		if !parsed.Matches(res, act) {
+			continue
+		}
+		switch {
+		case parsed.Effect() == perms.Deny:
+			return &GrantTestResult{
+				Denied:  true,
+				RoleId:  g.RoleId,
+				ScopeId: g.ScopeId,
+				Grant:   g.Grant,
+			}, nil
+		case !result.Allowed:
+			result = &GrantTestResult{
+				Allowed: true,
+				RoleId:  g.RoleId,
+				ScopeId: g.ScopeId,
+				Grant:   g.Grant,
+			}
+		}
+	}
+	return result, nil
+}
+
+// ScopeActions is a scope along with the actions a user's grants allow on
+// resources in it.
+type ScopeActions struct {
+	Scope   *Scope
+	Actions []action.Type
+}
+
+// ListScopesForUser returns every scope where the user (userId) has at least
+// one grant which allows an action, with the union of the actions allowed by
+// the user's grants in the scope, sorted by scope id. A deny grant removes its
+// actions from a scope only when it applies to every resource in the scope
+// (id=*;type=*), since otherwise the actions are still allowed on some
+// resources. Grant templates that refer to the account id can't be resolved,
+// so grants using them are ignored.
+func (r *Repository) ListScopesForUser(ctx context.Context, userId string) ([]*ScopeActions, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("list scopes for user: missing user id: %w", db.ErrInvalidParameter)
+	}
+	roleGrants, err := r.roleGrantsForUser(ctx, userId, "")
+	if err != nil {
+		return nil, fmt.Errorf("list scopes for user: unable to get grants for user: %w", err)
+	}
+
+	allowed := map[string]map[action.Type]bool{}
+	denied := map[string]map[action.Type]bool{}
+	for _, g := range roleGrants {
+		parsed, err := perms.Parse(g.ScopeId, g.Grant, perms.WithUserId(userId), perms.WithSkipFinalValidation(true))
+		if err != nil {
+			return nil, fmt.Errorf("list scopes for user: unable to parse grant %q of role %s: %w", g.Grant, g.RoleId, err)
+		}
+		acts := allowed
+		switch {
+		case parsed.Effect() == perms.Deny:
+			if parsed.Id() != "*" || parsed.Type() != resource.All {
+				continue
+			}
+			acts = denied
+		case strings.HasPrefix(parsed.Id(), "{{"):
+			continue
+		}
+		if acts[g.ScopeId] == nil {
+			acts[g.ScopeId] = map[action.Type]bool{}
+		}
+		typs, _ := parsed.Actions()
+		for _, a := range typs {
+			acts[g.ScopeId][a] = true
+		}
+	}
+
+	scopeActions := make(map[string][]action.Type, len(allowed))
+	scopeIds := make([]string, 0, len(allowed))
+	for scopeId, acts := range allowed {
+		if denied[scopeId][action.All] {
+			continue
+		}
+		var typs []action.Type
+		for a := range acts {
+			if !denied[scopeId][a] {
+				typs = append(typs, a)
+			}
+		}
+		if len(typs) == 0 {
+			continue
+		}
+		sort.Slice(typs, func(i, j int) bool { return typs[i].String() < typs[j].String() })
+		scopeActions[scopeId] = typs
+		scopeIds = append(scopeIds, scopeId)
+	}
+	if len(scopeIds) == 0 {
+		return nil, nil
+	}
+
+	var scopes []*Scope
+	if err := r.reader.SearchWhere(ctx, &scopes, "public_id in (?)", []interface{}{scopeIds}, db.WithLimit(-1)); err != nil {
+		return nil, fmt.Errorf("list scopes for user: unable to lookup scopes: %w", err)
+	}
+	sort.Slice(scopes, func(i, j int) bool { return scopes[i].PublicId < scopes[j].PublicId })
+	results := make([]*ScopeActions, 0, len(scopes))
+	for _, s := range scopes {
+		results = append(results, &ScopeActions{Scope: s, Actions: scopeActions[s.PublicId]})
+	}
+	return results, nil
+}
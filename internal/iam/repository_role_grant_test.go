@@ -10,6 +10,8 @@ import (
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -445,13 +447,13 @@ func TestRepository_SetRoleGrants_Randomize(t *testing.T) {
 
 		// First time, run a couple of error conditions
 		if i == 1 {
-			_, _, err := repo.SetRoleGrants(context.Background(), "", 1, []string{})
+			_, _, _, err := repo.SetRoleGrants(context.Background(), "", 1, []string{})
 			require.Error(err)
-			_, _, err = repo.SetRoleGrants(context.Background(), role.PublicId, 1, nil)
+			_, _, _, err = repo.SetRoleGrants(context.Background(), role.PublicId, 1, nil)
 			require.Error(err)
 		}
 
-		_, _, err := repo.SetRoleGrants(context.Background(), role.PublicId, uint32(i), grantsToSet)
+		_, _, _, err := repo.SetRoleGrants(context.Background(), role.PublicId, uint32(i), grantsToSet)
 		require.NoError(err)
 
 		roleGrants, err := repo.ListRoleGrants(context.Background(), role.PublicId)
@@ -465,7 +467,7 @@ func TestRepository_SetRoleGrants_Randomize(t *testing.T) {
 	}
 
 	// At the end, set to explicitly empty and make sure all are cleared out
-	_, _, err := repo.SetRoleGrants(context.Background(), role.PublicId, uint32(totalCnt+1), []string{})
+	_, _, _, err := repo.SetRoleGrants(context.Background(), role.PublicId, uint32(totalCnt+1), []string{})
 	require.NoError(err)
 
 	roleGrants, err := repo.ListRoleGrants(context.Background(), role.PublicId)
@@ -553,7 +555,7 @@ func TestRepository_SetRoleGrants_Parameters(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 			require.NoError(conn.Where("1=1").Delete(allocRoleGrant()).Error)
-			got, gotAffectedRows, err := repo.SetRoleGrants(context.Background(), tt.args.roleId, tt.args.roleVersion, tt.args.grants, tt.args.opt...)
+			got, _, gotAffectedRows, err := repo.SetRoleGrants(context.Background(), tt.args.roleId, tt.args.roleVersion, tt.args.grants, tt.args.opt...)
 			if tt.wantErr {
 				require.Error(err)
 			} else {
@@ -564,3 +566,255 @@ func TestRepository_SetRoleGrants_Parameters(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_SetRoleGrants_Diff(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	role := TestRole(t, conn, org.PublicId)
+	ctx := context.Background()
+
+	_, err := repo.DiffRoleGrants(ctx, "", []string{})
+	require.Error(err)
+	_, err = repo.DiffRoleGrants(ctx, role.PublicId, nil)
+	require.Error(err)
+
+	// Duplicates, after canonicalization, are only added once
+	grants := []string{"id=s_1;actions=read,update", "actions=read,update;id=s_1", "id=s_2;actions=*"}
+	diff, err := repo.DiffRoleGrants(ctx, role.PublicId, grants)
+	require.NoError(err)
+	assert.Equal([]string{"id=s_1;actions=read,update", "id=s_2;actions=*"}, diff.Added)
+	assert.Empty(diff.Removed)
+
+	got, setDiff, deleted, err := repo.SetRoleGrants(ctx, role.PublicId, 1, grants)
+	require.NoError(err)
+	assert.Equal(diff, setDiff)
+	assert.Equal(0, deleted)
+	assert.Len(got, 2)
+
+	// Only the changed grants are touched
+	before, err := repo.ListRoleGrants(ctx, role.PublicId)
+	require.NoError(err)
+	var kept *RoleGrant
+	for _, rg := range before {
+		if rg.CanonicalGrant == "id=s_2;actions=*" {
+			kept = rg
+		}
+	}
+	require.NotNil(kept)
+	got, setDiff, deleted, err = repo.SetRoleGrants(ctx, role.PublicId, 2, []string{"id=s_2;actions=*", "id=s_3;actions=read"})
+	require.NoError(err)
+	assert.Equal([]string{"id=s_3;actions=read"}, setDiff.Added)
+	assert.Equal([]string{"id=s_1;actions=read,update"}, setDiff.Removed)
+	assert.Equal(1, deleted)
+	assert.Len(got, 2)
+	for _, rg := range got {
+		if rg.CanonicalGrant == kept.CanonicalGrant {
+			assert.Equal(kept.CreateTime, rg.CreateTime)
+		}
+	}
+
+	// No changes don't bump the version
+	got, setDiff, deleted, err = repo.SetRoleGrants(ctx, role.PublicId, 3, []string{"id=s_3;actions=read", "id=s_2;actions=*"})
+	require.NoError(err)
+	assert.True(setDiff.Empty())
+	assert.Equal(db.NoRowsAffected, deleted)
+	assert.Len(got, 2)
+	r, _, _, err := repo.LookupRole(ctx, role.PublicId)
+	require.NoError(err)
+	assert.Equal(uint32(3), r.Version)
+}
+
+func TestRepository_TestGrants(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	ctx := context.Background()
+
+	user := TestUser(t, repo, org.PublicId)
+	grp := TestGroup(t, conn, org.PublicId)
+	TestGroupMember(t, conn, grp.PublicId, user.PublicId)
+
+	userRole := TestRole(t, conn, org.PublicId)
+	TestUserRole(t, conn, userRole.PublicId, user.PublicId)
+	TestRoleGrant(t, conn, userRole.PublicId, "id=*;type=group;actions=read")
+
+	groupRole := TestRole(t, conn, proj.PublicId)
+	TestGroupRole(t, conn, groupRole.PublicId, grp.PublicId)
+	TestRoleGrant(t, conn, groupRole.PublicId, "type=role;actions=list")
+
+	TestRoleGrant(t, conn, userRole.PublicId, "id=*;type=group;actions=update")
+	denyRole := TestRole(t, conn, org.PublicId)
+	TestUserRole(t, conn, denyRole.PublicId, user.PublicId)
+	TestRoleGrant(t, conn, denyRole.PublicId, "id=*;type=group;actions=update;effect=deny")
+
+	tests := []struct {
+		name       string
+		userId     string
+		scopeId    string
+		typ        resource.Type
+		act        action.Type
+		resourceId string
+		want       *GrantTestResult
+		wantIsErr  error
+	}{
+		{
+			name:       "user-role",
+			userId:     user.PublicId,
+			scopeId:    org.PublicId,
+			typ:        resource.Group,
+			act:        action.Read,
+			resourceId: grp.PublicId,
+			want: &GrantTestResult{
+				Allowed: true,
+				RoleId:  userRole.PublicId,
+				ScopeId: org.PublicId,
+				Grant:   "id=*;type=group;actions=read",
+			},
+		},
+		{
+			name:    "group-role",
+			userId:  user.PublicId,
+			scopeId: proj.PublicId,
+			typ:     resource.Role,
+			act:     action.List,
+			want: &GrantTestResult{
+				Allowed: true,
+				RoleId:  groupRole.PublicId,
+				ScopeId: proj.PublicId,
+				Grant:   "type=role;actions=list",
+			},
+		},
+		{
+			name:       "deny-overrides",
+			userId:     user.PublicId,
+			scopeId:    org.PublicId,
+			typ:        resource.Group,
+			act:        action.Update,
+			resourceId: grp.PublicId,
+			want: &GrantTestResult{
+				Denied:  true,
+				RoleId:  denyRole.PublicId,
+				ScopeId: org.PublicId,
+				Grant:   "id=*;type=group;actions=update;effect=deny",
+			},
+		},
+		{
+			name:       "wrong-action",
+			userId:     user.PublicId,
+			scopeId:    org.PublicId,
+			typ:        resource.Group,
+			act:        action.Delete,
+			resourceId: grp.PublicId,
+			want:       &GrantTestResult{},
+		},
+		{
+			name:       "wrong-scope",
+			userId:     user.PublicId,
+			scopeId:    proj.PublicId,
+			typ:        resource.Group,
+			act:        action.Read,
+			resourceId: grp.PublicId,
+			want:       &GrantTestResult{},
+		},
+		{
+			name:      "missing-user",
+			scopeId:   org.PublicId,
+			typ:       resource.Group,
+			act:       action.Read,
+			wantIsErr: db.ErrInvalidParameter,
+		},
+		{
+			name:      "missing-scope",
+			userId:    user.PublicId,
+			typ:       resource.Group,
+			act:       action.Read,
+			wantIsErr: db.ErrInvalidParameter,
+		},
+		{
+			name:      "missing-type",
+			userId:    user.PublicId,
+			scopeId:   org.PublicId,
+			act:       action.Read,
+			wantIsErr: db.ErrInvalidParameter,
+		},
+		{
+			name:      "missing-action",
+			userId:    user.PublicId,
+			scopeId:   org.PublicId,
+			typ:       resource.Group,
+			wantIsErr: db.ErrInvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.TestGrants(ctx, tt.userId, tt.scopeId, tt.typ, tt.act, tt.resourceId)
+			if tt.wantIsErr != nil {
+				require.Error(err)
+				assert.True(errors.Is(err, tt.wantIsErr))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestRepository_ListScopesForUser(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	org, proj := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	org2, proj2 := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	user := TestUser(t, repo, org.PublicId)
+	grp := TestGroup(t, conn, org.PublicId)
+	TestGroupMember(t, conn, grp.PublicId, user.PublicId)
+
+	orgRole := TestRole(t, conn, org.PublicId)
+	TestUserRole(t, conn, orgRole.PublicId, user.PublicId)
+	TestRoleGrant(t, conn, orgRole.PublicId, "id=*;type=group;actions=read,update")
+	TestRoleGrant(t, conn, orgRole.PublicId, "id=*;type=*;actions=update;effect=deny")
+	TestRoleGrant(t, conn, orgRole.PublicId, "id=*;type=user;actions=delete;effect=deny")
+
+	projRole := TestRole(t, conn, proj.PublicId)
+	TestGroupRole(t, conn, projRole.PublicId, grp.PublicId)
+	TestRoleGrant(t, conn, projRole.PublicId, "type=role;actions=list")
+	TestRoleGrant(t, conn, projRole.PublicId, "id={{user.id}};actions=read")
+
+	// Everything in proj2 is denied and the grant in org2 can't be resolved.
+	deniedRole := TestRole(t, conn, proj2.PublicId)
+	TestUserRole(t, conn, deniedRole.PublicId, user.PublicId)
+	TestRoleGrant(t, conn, deniedRole.PublicId, "id=*;type=*;actions=read")
+	TestRoleGrant(t, conn, deniedRole.PublicId, "id=*;type=*;actions=*;effect=deny")
+	templateRole := TestRole(t, conn, org2.PublicId)
+	TestUserRole(t, conn, templateRole.PublicId, user.PublicId)
+	TestRoleGrant(t, conn, templateRole.PublicId, "id={{account.id}};actions=read")
+
+	got, err := repo.ListScopesForUser(ctx, user.PublicId)
+	require.NoError(err)
+	actions := map[string][]action.Type{}
+	for _, s := range got {
+		switch s.Scope.PublicId {
+		case org.PublicId, proj.PublicId, org2.PublicId, proj2.PublicId:
+			actions[s.Scope.PublicId] = s.Actions
+		}
+	}
+	assert.Equal(map[string][]action.Type{
+		org.PublicId:  {action.Read},
+		proj.PublicId: {action.List, action.Read},
+	}, actions)
+
+	_, err = repo.ListScopesForUser(ctx, "")
+	require.Error(err)
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+}
@@ -0,0 +1,347 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+// MaxRoleIncludeDepth is how many levels of includes are followed when a
+// role's grants are resolved. Grants of roles included deeper than that are
+// ignored.
+const MaxRoleIncludeDepth = 5
+
+// ErrRoleIncludeCycle is returned when a role would include itself, directly
+// or through the roles it includes.
+var ErrRoleIncludeCycle = errors.New("role include cycle")
+
+const (
+	// roleIncludeReachesQuery returns a row if the role ($2) is reachable by
+	// following the includes of the role ($1), including when they're the
+	// same role.
+	roleIncludeReachesQuery = `
+with recursive
+reachable (role_id) as (
+  select $1::text
+   union
+  select iam_role_include.included_role_id
+    from iam_role_include,
+         reachable
+   where iam_role_include.role_id = reachable.role_id
+)
+select role_id from reachable where role_id = $2;
+`
+
+	// lockRoleIncludesQuery locks the includes of every role, so that only
+	// one transaction at a time can add includes. It conflicts with itself
+	// and with the lock taken by inserts, but not with reads.
+	lockRoleIncludesQuery = `lock table iam_role_include in share row exclusive mode;`
+
+	// includedRolesQuery returns the role ($1) and the roles it includes, up
+	// to MaxRoleIncludeDepth levels down.
+	includedRolesQuery = `
+with recursive
+included (role_id, depth) as (
+  select $1::text, 0
+   union
+  select iam_role_include.included_role_id,
+         included.depth + 1
+    from iam_role_include,
+         included
+   where iam_role_include.role_id = included.role_id
+     and included.depth < $2
+)
+select distinct role_id from included;
+`
+)
+
+// AddRoleIncludes makes the role (roleId) include the roles of
+// includedRoleIds and returns the role's includes after the add. An included
+// role must be in the role's scope or in one of its parent scopes, and can't
+// be the role itself or include it, directly or transitively, which returns
+// ErrRoleIncludeCycle. The role's current db version must match the
+// roleVersion or an error will be returned. No options are currently
+// supported.
+func (r *Repository) AddRoleIncludes(ctx context.Context, roleId string, roleVersion uint32, includedRoleIds []string, opt ...Option) ([]*RoleInclude, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("add role includes: missing role id: %w", db.ErrInvalidParameter)
+	}
+	if roleVersion == 0 {
+		return nil, fmt.Errorf("add role includes: version cannot be zero: %w", db.ErrInvalidParameter)
+	}
+	if len(includedRoleIds) == 0 {
+		return nil, fmt.Errorf("add role includes: missing included roles: %w", db.ErrInvalidParameter)
+	}
+	newIncludes := make([]interface{}, 0, len(includedRoleIds))
+	for _, id := range includedRoleIds {
+		ri, err := NewRoleInclude(roleId, id)
+		if err != nil {
+			return nil, fmt.Errorf("add role includes: %w", err)
+		}
+		newIncludes = append(newIncludes, ri)
+	}
+
+	role := allocRole()
+	role.PublicId = roleId
+	roleScope, err := role.GetScope(ctx, r.reader)
+	if err != nil {
+		return nil, fmt.Errorf("add role includes: unable to get role %s scope: %w", roleId, err)
+	}
+	if err := r.checkIncludedRoleScopes(ctx, roleScope, includedRoleIds); err != nil {
+		return nil, fmt.Errorf("add role includes: %w", err)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, roleScope.GetPublicId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("add role includes: unable to get oplog wrapper: %w", err)
+	}
+
+	var currentIncludes []*RoleInclude
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			// Locking the role by its version update isn't enough: two
+			// transactions adding includes to different roles, which only
+			// form a cycle together, would each find no cycle. So the includes
+			// of every role are locked first, before anything is written, and
+			// can't change until the transaction ends.
+			if _, err := w.Exec(ctx, lockRoleIncludesQuery, nil); err != nil {
+				return fmt.Errorf("unable to lock role includes: %w", err)
+			}
+
+			msgs := make([]*oplog.Message, 0, 2)
+			roleTicket, err := w.GetTicket(&role)
+			if err != nil {
+				return fmt.Errorf("unable to get ticket: %w", err)
+			}
+			updatedRole := allocRole()
+			updatedRole.PublicId = roleId
+			updatedRole.Version = roleVersion + 1
+			var roleOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, &updatedRole, []string{"Version"}, nil, db.NewOplogMsg(&roleOplogMsg), db.WithVersion(&roleVersion))
+			if err != nil {
+				return fmt.Errorf("unable to update role version: %w", err)
+			}
+			if rowsUpdated != 1 {
+				return fmt.Errorf("updated role and %d rows updated", rowsUpdated)
+			}
+			msgs = append(msgs, &roleOplogMsg)
+
+			for _, id := range includedRoleIds {
+				reaches, err := roleIncludeReaches(ctx, reader, id, roleId)
+				if err != nil {
+					return err
+				}
+				if reaches {
+					return fmt.Errorf("role %s includes %s: %w", id, roleId, ErrRoleIncludeCycle)
+				}
+			}
+
+			includeOplogMsgs := make([]*oplog.Message, 0, len(newIncludes))
+			if err := w.CreateItems(ctx, newIncludes, db.NewOplogMsgs(&includeOplogMsgs)); err != nil {
+				return fmt.Errorf("unable to add role includes: %w", err)
+			}
+			msgs = append(msgs, includeOplogMsgs...)
+
+			metadata := oplog.Metadata{
+				"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+				"scope-id":           []string{roleScope.PublicId},
+				"scope-type":         []string{roleScope.Type},
+				"resource-public-id": []string{roleId},
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, roleTicket, metadata, msgs); err != nil {
+				return fmt.Errorf("unable to write oplog: %w", err)
+			}
+
+			// we need a new repo, that's using the same reader/writer as this TxHandler
+			txRepo := &Repository{
+				reader: reader,
+				writer: w,
+				kms:    r.kms,
+				// intentionally not setting the defaultLimit, so we'll get all
+				// the includes without a limit
+			}
+			currentIncludes, err = txRepo.ListRoleIncludes(ctx, roleId)
+			if err != nil {
+				return fmt.Errorf("unable to retrieve current role includes after add: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("add role includes: error adding includes: %w", err)
+	}
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	return currentIncludes, nil
+}
+
+// DeleteRoleIncludes removes the roles of includedRoleIds from the includes
+// of the role (roleId) and returns the number of includes deleted. Roles the
+// role doesn't include are ignored. The role's current db version must match
+// the roleVersion or an error will be returned. No options are currently
+// supported.
+func (r *Repository) DeleteRoleIncludes(ctx context.Context, roleId string, roleVersion uint32, includedRoleIds []string, opt ...Option) (int, error) {
+	if roleId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete role includes: missing role id: %w", db.ErrInvalidParameter)
+	}
+	if roleVersion == 0 {
+		return db.NoRowsAffected, fmt.Errorf("delete role includes: version cannot be zero: %w", db.ErrInvalidParameter)
+	}
+	if len(includedRoleIds) == 0 {
+		return db.NoRowsAffected, fmt.Errorf("delete role includes: missing included roles: %w", db.ErrInvalidParameter)
+	}
+
+	role := allocRole()
+	role.PublicId = roleId
+	roleScope, err := role.GetScope(ctx, r.reader)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete role includes: unable to get role %s scope: %w", roleId, err)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, roleScope.GetPublicId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete role includes: unable to get oplog wrapper: %w", err)
+	}
+
+	var totalRowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, 2)
+			roleTicket, err := w.GetTicket(&role)
+			if err != nil {
+				return fmt.Errorf("unable to get ticket: %w", err)
+			}
+			updatedRole := allocRole()
+			updatedRole.PublicId = roleId
+			updatedRole.Version = roleVersion + 1
+			var roleOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, &updatedRole, []string{"Version"}, nil, db.NewOplogMsg(&roleOplogMsg), db.WithVersion(&roleVersion))
+			if err != nil {
+				return fmt.Errorf("unable to update role version: %w", err)
+			}
+			if rowsUpdated != 1 {
+				return fmt.Errorf("updated role and %d rows updated", rowsUpdated)
+			}
+			msgs = append(msgs, &roleOplogMsg)
+
+			var current []*RoleInclude
+			if err := reader.SearchWhere(ctx, &current, "role_id = ? and included_role_id in (?)", []interface{}{roleId, includedRoleIds}); err != nil {
+				return fmt.Errorf("unable to search for role includes: %w", err)
+			}
+			if len(current) == 0 {
+				return nil
+			}
+			deleteIncludes := make([]interface{}, 0, len(current))
+			for _, ri := range current {
+				deleteIncludes = append(deleteIncludes, ri)
+			}
+			includeOplogMsgs := make([]*oplog.Message, 0, len(deleteIncludes))
+			rowsDeleted, err := w.DeleteItems(ctx, deleteIncludes, db.NewOplogMsgs(&includeOplogMsgs))
+			if err != nil {
+				return fmt.Errorf("unable to delete role includes: %w", err)
+			}
+			if rowsDeleted != len(deleteIncludes) {
+				return fmt.Errorf("role includes deleted %d did not match request for %d", rowsDeleted, len(deleteIncludes))
+			}
+			totalRowsDeleted = rowsDeleted
+			msgs = append(msgs, includeOplogMsgs...)
+
+			metadata := oplog.Metadata{
+				"op-type":            []string{oplog.OpType_OP_TYPE_DELETE.String()},
+				"scope-id":           []string{roleScope.PublicId},
+				"scope-type":         []string{roleScope.Type},
+				"resource-public-id": []string{roleId},
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, roleTicket, metadata, msgs); err != nil {
+				return fmt.Errorf("unable to write oplog: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete role includes: error deleting includes: %w", err)
+	}
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	return totalRowsDeleted, nil
+}
+
+// ListRoleIncludes returns the roles directly included by the role (roleId).
+// Supports the WithLimit option.
+func (r *Repository) ListRoleIncludes(ctx context.Context, roleId string, opt ...Option) ([]*RoleInclude, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("list role includes: missing role id: %w", db.ErrInvalidParameter)
+	}
+	var includes []*RoleInclude
+	if err := r.list(ctx, &includes, "role_id = ?", []interface{}{roleId}, opt...); err != nil {
+		return nil, fmt.Errorf("list role includes: unable to list includes: %w", err)
+	}
+	return includes, nil
+}
+
+// includedRoleIds returns the role (roleId) and the ids of the roles it
+// includes, transitively, up to MaxRoleIncludeDepth levels down.
+func (r *Repository) includedRoleIds(ctx context.Context, roleId string) ([]string, error) {
+	rows, err := r.reader.Query(ctx, includedRolesQuery, []interface{}{roleId, MaxRoleIncludeDepth})
+	if err != nil {
+		return nil, fmt.Errorf("unable to query included roles: %w", err)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("unable to scan included role: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// checkIncludedRoleScopes returns an error unless every included role exists
+// and is in the scope of the including role (roleScope) or one of its parent
+// scopes.
+func (r *Repository) checkIncludedRoleScopes(ctx context.Context, roleScope *Scope, includedRoleIds []string) error {
+	allowed := map[string]bool{
+		roleScope.PublicId:    true,
+		scope.Global.String(): true,
+	}
+	if roleScope.ParentId != "" {
+		allowed[roleScope.ParentId] = true
+	}
+	var included []*Role
+	if err := r.reader.SearchWhere(ctx, &included, "public_id in (?)", []interface{}{includedRoleIds}); err != nil {
+		return fmt.Errorf("unable to search for included roles: %w", err)
+	}
+	found := make(map[string]bool, len(included))
+	for _, role := range included {
+		if !allowed[role.ScopeId] {
+			return fmt.Errorf("included role %s is in scope %s which isn't %s or one of its parents: %w", role.PublicId, role.ScopeId, roleScope.PublicId, db.ErrInvalidParameter)
+		}
+		found[role.PublicId] = true
+	}
+	for _, id := range includedRoleIds {
+		if !found[id] {
+			return fmt.Errorf("included role %s not found: %w", id, db.ErrRecordNotFound)
+		}
+	}
+	return nil
+}
+
+// roleIncludeReaches reports whether the role (toRoleId) is the role
+// (fromRoleId) or one of the roles it includes, transitively.
+func roleIncludeReaches(ctx context.Context, reader db.Reader, fromRoleId, toRoleId string) (bool, error) {
+	rows, err := reader.Query(ctx, roleIncludeReachesQuery, []interface{}{fromRoleId, toRoleId})
+	if err != nil {
+		return false, fmt.Errorf("unable to check for role include cycle: %w", err)
+	}
+	defer rows.Close()
+	return rows.Next(), nil
+}
@@ -0,0 +1,166 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_RoleIncludes(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+
+	t.Run("grants", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		user := TestUser(t, repo, org.PublicId)
+		bundle := TestRole(t, conn, org.PublicId)
+		TestRoleGrant(t, conn, bundle.PublicId, "id=*;type=host-catalog;actions=read")
+		nested := TestRole(t, conn, org.PublicId)
+		TestRoleGrant(t, conn, nested.PublicId, "id=*;type=target;actions=read")
+		role := TestRole(t, conn, proj.PublicId, WithGrantScopeId(proj.PublicId))
+		TestUserRole(t, conn, role.PublicId, user.PublicId)
+
+		_, err := repo.AddRoleIncludes(ctx, bundle.PublicId, bundle.Version, []string{nested.PublicId})
+		require.NoError(err)
+		includes, err := repo.AddRoleIncludes(ctx, role.PublicId, role.Version, []string{bundle.PublicId})
+		require.NoError(err)
+		require.Len(includes, 1)
+		assert.Equal(bundle.PublicId, includes[0].IncludedRoleId)
+
+		// Included grants apply in the including role's grant scope
+		grants, err := repo.GrantsForUser(ctx, user.PublicId)
+		require.NoError(err)
+		var found int
+		for _, g := range grants {
+			switch g.Grant {
+			case "id=*;type=host-catalog;actions=read", "id=*;type=target;actions=read":
+				assert.Equal(proj.PublicId, g.ScopeId)
+				found++
+			}
+		}
+		assert.Equal(2, found)
+
+		roleGrants, err := repo.ListRoleGrants(ctx, role.PublicId)
+		require.NoError(err)
+		assert.Empty(roleGrants)
+		roleGrants, err = repo.ListRoleGrants(ctx, role.PublicId, WithIncludedRoles(true))
+		require.NoError(err)
+		assert.Len(roleGrants, 2)
+
+		rowsDeleted, err := repo.DeleteRoleIncludes(ctx, role.PublicId, role.Version+1, []string{bundle.PublicId})
+		require.NoError(err)
+		assert.Equal(1, rowsDeleted)
+		roleGrants, err = repo.ListRoleGrants(ctx, role.PublicId, WithIncludedRoles(true))
+		require.NoError(err)
+		assert.Empty(roleGrants)
+	})
+	t.Run("cycle", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a := TestRole(t, conn, org.PublicId)
+		b := TestRole(t, conn, org.PublicId)
+		c := TestRole(t, conn, org.PublicId)
+		_, err := repo.AddRoleIncludes(ctx, a.PublicId, a.Version, []string{b.PublicId})
+		require.NoError(err)
+		_, err = repo.AddRoleIncludes(ctx, b.PublicId, b.Version, []string{c.PublicId})
+		require.NoError(err)
+
+		_, err = repo.AddRoleIncludes(ctx, c.PublicId, c.Version, []string{a.PublicId})
+		assert.True(errors.Is(err, ErrRoleIncludeCycle))
+		_, err = repo.AddRoleIncludes(ctx, a.PublicId, a.Version+1, []string{a.PublicId})
+		assert.True(errors.Is(err, ErrRoleIncludeCycle))
+
+		// The database rejects cycles too
+		ri, err := NewRoleInclude(c.PublicId, a.PublicId)
+		require.NoError(err)
+		assert.Error(db.New(conn).Create(ctx, ri))
+	})
+	t.Run("depth", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		user := TestUser(t, repo, org.PublicId)
+		roles := make([]*Role, MaxRoleIncludeDepth+2)
+		for i := range roles {
+			roles[i] = TestRole(t, conn, org.PublicId)
+		}
+		for i := 0; i < len(roles)-1; i++ {
+			_, err := repo.AddRoleIncludes(ctx, roles[i].PublicId, roles[i].Version, []string{roles[i+1].PublicId})
+			require.NoError(err)
+		}
+		TestRoleGrant(t, conn, roles[MaxRoleIncludeDepth].PublicId, "id=*;type=session;actions=read")
+		TestRoleGrant(t, conn, roles[MaxRoleIncludeDepth+1].PublicId, "id=*;type=session;actions=cancel")
+		TestUserRole(t, conn, roles[0].PublicId, user.PublicId)
+
+		grants, err := repo.GrantsForUser(ctx, user.PublicId)
+		require.NoError(err)
+		var got []string
+		for _, g := range grants {
+			got = append(got, g.Grant)
+		}
+		assert.Contains(got, "id=*;type=session;actions=read")
+		assert.NotContains(got, "id=*;type=session;actions=cancel")
+	})
+	t.Run("scope", func(t *testing.T) {
+		assert := assert.New(t)
+		orgRole := TestRole(t, conn, org.PublicId)
+		projRole := TestRole(t, conn, proj.PublicId)
+		// A role can't include a role from a child scope
+		_, err := repo.AddRoleIncludes(ctx, orgRole.PublicId, orgRole.Version, []string{projRole.PublicId})
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, err = repo.AddRoleIncludes(ctx, orgRole.PublicId, orgRole.Version, []string{"r_doesnotexist"})
+		assert.True(errors.Is(err, db.ErrRecordNotFound))
+
+		// The database enforces the scope rule too
+		otherOrg, _ := TestScopes(t, repo)
+		otherRole := TestRole(t, conn, otherOrg.PublicId)
+		rw := db.New(conn)
+		for _, included := range []*Role{projRole, otherRole} {
+			ri, err := NewRoleInclude(orgRole.PublicId, included.PublicId)
+			require.NoError(t, err)
+			assert.Error(rw.Create(ctx, ri))
+		}
+		ri, err := NewRoleInclude(projRole.PublicId, orgRole.PublicId)
+		require.NoError(t, err)
+		assert.NoError(rw.Create(ctx, ri))
+	})
+	t.Run("concurrent-cycle", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		// Each pair of roles is made to include each other at the same time;
+		// only one of the two includes can succeed.
+		for i := 0; i < 10; i++ {
+			a := TestRole(t, conn, org.PublicId)
+			b := TestRole(t, conn, org.PublicId)
+			start := make(chan struct{})
+			errs := make(chan error, 2)
+			var wg sync.WaitGroup
+			for _, pair := range [][2]*Role{{a, b}, {b, a}} {
+				pair := pair
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					_, err := repo.AddRoleIncludes(ctx, pair[0].PublicId, pair[0].Version, []string{pair[1].PublicId})
+					errs <- err
+				}()
+			}
+			close(start)
+			wg.Wait()
+			close(errs)
+			var failed int
+			for err := range errs {
+				if err != nil {
+					failed++
+					assert.True(errors.Is(err, ErrRoleIncludeCycle), err)
+				}
+			}
+			require.Equal(1, failed)
+		}
+	})
+}
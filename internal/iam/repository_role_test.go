@@ -11,6 +11,7 @@ import (
 	dbassert "github.com/hashicorp/boundary/internal/db/assert"
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -638,3 +639,180 @@ func TestRepository_ListRoles(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_ListRoles_Recursive(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+	org, proj := TestScopes(t, repo)
+	otherOrg, otherProj := TestScopes(t, repo)
+
+	TestRole(t, conn, org.PublicId)
+	TestRole(t, conn, proj.PublicId)
+	TestRole(t, conn, proj.PublicId)
+	TestRole(t, conn, otherOrg.PublicId)
+	TestRole(t, conn, otherProj.PublicId)
+
+	orgRoles, err := repo.ListRoles(ctx, org.PublicId, WithLimit(-1))
+	require.NoError(err)
+	projRoles, err := repo.ListRoles(ctx, proj.PublicId, WithLimit(-1))
+	require.NoError(err)
+
+	got, err := repo.ListRoles(ctx, org.PublicId, WithLimit(-1), WithRecursive(true))
+	require.NoError(err)
+	assert.Len(got, len(orgRoles)+len(projRoles))
+	for _, r := range got {
+		assert.Contains([]string{org.PublicId, proj.PublicId}, r.ScopeId)
+	}
+
+	// A project has no children
+	got, err = repo.ListRoles(ctx, proj.PublicId, WithLimit(-1), WithRecursive(true))
+	require.NoError(err)
+	assert.Len(got, len(projRoles))
+
+	// Global includes every scope
+	got, err = repo.ListRoles(ctx, "global", WithLimit(-1), WithRecursive(true))
+	require.NoError(err)
+	scopes := map[string]bool{}
+	for _, r := range got {
+		scopes[r.ScopeId] = true
+	}
+	for _, id := range []string{org.PublicId, proj.PublicId, otherOrg.PublicId, otherProj.PublicId} {
+		assert.True(scopes[id], id)
+	}
+
+	// Groups use the same clause
+	TestGroup(t, conn, org.PublicId)
+	TestGroup(t, conn, proj.PublicId)
+	TestGroup(t, conn, otherProj.PublicId)
+	groups, err := repo.ListGroups(ctx, org.PublicId, WithLimit(-1), WithRecursive(true))
+	require.NoError(err)
+	assert.Len(groups, 2)
+
+	// The scopes of the listed resources are returned with their name and
+	// type
+	listed := map[string]*Scope{}
+	_, err = repo.ListRoles(ctx, org.PublicId, WithLimit(-1), WithRecursive(true), WithListedScopes(listed))
+	require.NoError(err)
+	require.Len(listed, 2)
+	assert.Equal(org.Name, listed[org.PublicId].Name)
+	assert.Equal(scope.Org.String(), listed[org.PublicId].Type)
+	assert.Equal(proj.Name, listed[proj.PublicId].Name)
+	assert.Equal(scope.Project.String(), listed[proj.PublicId].Type)
+
+	listed = map[string]*Scope{}
+	_, err = repo.ListGroups(ctx, org.PublicId, WithLimit(-1), WithRecursive(true), WithListedScopes(listed))
+	require.NoError(err)
+	assert.Len(listed, 2)
+
+	TestUser(t, repo, org.PublicId)
+	listed = map[string]*Scope{}
+	_, err = repo.ListUsers(ctx, org.PublicId, WithLimit(-1), WithRecursive(true), WithListedScopes(listed))
+	require.NoError(err)
+	require.Len(listed, 1)
+	assert.Equal(scope.Org.String(), listed[org.PublicId].Type)
+}
+
+func TestRepository_ListRoles_Paginated(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+	org, proj := TestScopes(t, repo)
+
+	for i := 0; i < 5; i++ {
+		TestRole(t, conn, proj.PublicId)
+	}
+	want, err := repo.ListRoles(ctx, proj.PublicId, WithLimit(-1))
+	require.NoError(err)
+
+	var got []string
+	var next, token string
+	for pages := 0; ; pages++ {
+		require.Less(pages, len(want)+1, "pagination did not end")
+		page, err := repo.ListRoles(ctx, proj.PublicId, WithLimit(2), WithPageToken(token), WithNextPageToken(&next))
+		require.NoError(err)
+		for _, r := range page {
+			got = append(got, r.PublicId)
+		}
+		if pages == 0 {
+			// A role created while paging through is listed on a later page.
+			want = append(want, TestRole(t, conn, proj.PublicId))
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	var wantIds []string
+	for _, r := range want {
+		wantIds = append(wantIds, r.PublicId)
+	}
+	assert.ElementsMatch(wantIds, got)
+
+	// A token can't be used for a different listing.
+	_, err = repo.ListRoles(ctx, proj.PublicId, WithLimit(1), WithNextPageToken(&next))
+	require.NoError(err)
+	require.NotEmpty(next)
+	_, err = repo.ListRoles(ctx, org.PublicId, WithLimit(1), WithPageToken(next))
+	assert.True(errors.Is(err, db.ErrInvalidPageToken))
+
+	_, err = repo.ListRoles(ctx, proj.PublicId, WithPageToken("garbage"))
+	assert.True(errors.Is(err, db.ErrInvalidPageToken))
+}
+
+func TestRepository_ListRoles_MaxPageSize(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper, WithMaxPageSize(2))
+	ctx := context.Background()
+	_, proj := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	for i := 0; i < 3; i++ {
+		TestRole(t, conn, proj.PublicId)
+	}
+
+	// A listing over the max must be paginated
+	_, err := repo.ListRoles(ctx, proj.PublicId)
+	assert.True(errors.Is(err, db.ErrPageRequired))
+	_, err = repo.ListRoles(ctx, proj.PublicId, WithLimit(10))
+	assert.True(errors.Is(err, db.ErrPageRequired))
+
+	// A limit under the max is honored
+	roles, err := repo.ListRoles(ctx, proj.PublicId, WithLimit(1))
+	require.NoError(err)
+	assert.Len(roles, 1)
+
+	// Pages are no bigger than the max
+	var next string
+	roles, err = repo.ListRoles(ctx, proj.PublicId, WithLimit(10), WithNextPageToken(&next))
+	require.NoError(err)
+	assert.Len(roles, 2)
+	require.NotEmpty(next)
+	roles, err = repo.ListRoles(ctx, proj.PublicId, WithPageToken(next), WithNextPageToken(&next))
+	require.NoError(err)
+	assert.Len(roles, 1)
+	assert.Empty(next)
+
+	// As are pages requested without a limit
+	roles, err = repo.ListRoles(ctx, proj.PublicId, WithNextPageToken(&next))
+	require.NoError(err)
+	assert.Len(roles, 2)
+	assert.NotEmpty(next)
+
+	// An unlimited listing is exempt
+	roles, err = repo.ListRoles(ctx, proj.PublicId, WithLimit(-1))
+	require.NoError(err)
+	assert.Len(roles, 3)
+
+	// As is one which isn't over the max
+	roles, err = TestRepo(t, conn, wrapper, WithMaxPageSize(3)).ListRoles(ctx, proj.PublicId)
+	require.NoError(err)
+	assert.Len(roles, 3)
+}
@@ -17,7 +17,14 @@ import (
 )
 
 // CreateScope will create a scope in the repository and return the written
-// scope. Supported options include: WithPublicId and WithRandomReader.
+// scope. In the same transaction it creates the scope's keys, an admin role
+// with userId as its principal (unless userId is a built-in user or the
+// WithSkipAdminRoleCreation option is set) and, for orgs, a default role for
+// login (unless the WithSkipDefaultRoleCreation option is set), so a failure
+// never leaves a partially initialized scope. Supported options include:
+// WithPublicId, WithRandomReader, WithSkipAdminRoleCreation,
+// WithSkipDefaultRoleCreation, WithReadOnlyRoleCreation, which also creates
+// a role granting read and list on everything in the scope, and WithDryRun.
 func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, opt ...Option) (*Scope, error) {
 	if s == nil {
 		return nil, fmt.Errorf("create scope: missing scope %w", db.ErrInvalidParameter)
@@ -28,6 +35,9 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 	if s.PublicId != "" {
 		return nil, fmt.Errorf("create scope: public id not empty: %w", db.ErrInvalidParameter)
 	}
+	if err := validateLengthsForWrite(s, r.maxLengths(), db.CreateOp); err != nil {
+		return nil, fmt.Errorf("create scope: %w", err)
+	}
 
 	var parentOplogWrapper wrapping.Wrapper
 	var externalWrappers *kms.ExternalWrappers
@@ -70,7 +80,7 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 				return nil, fmt.Errorf("create scope: error generating public id for new scope: %w", err)
 			}
 		}
-		sc := s.Clone().(*Scope)
+		sc := s.Clone()
 		sc.PublicId = scopePublicId
 		scopeRaw = sc
 		scopeMetadata, err = r.stdMetadata(ctx, sc)
@@ -147,6 +157,20 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 		}
 	}
 
+	var readOnlyRole *Role
+	if opts.withReadOnlyRoleCreation {
+		readOnlyRole, err = NewRole(scopePublicId)
+		if err != nil {
+			return nil, fmt.Errorf("create scope: error instantiating new read-only role: %w", err)
+		}
+		readOnlyRole.PublicId, err = newRoleId()
+		if err != nil {
+			return nil, fmt.Errorf("create scope: error generating public id for new read-only role: %w", err)
+		}
+		readOnlyRole.Name = "Read Only"
+		readOnlyRole.Description = fmt.Sprintf("Role created for read-only access to scope %s at its creation time", scopePublicId)
+	}
+
 	reader := opts.withRandomReader
 	if reader == nil {
 		reader = rand.Reader
@@ -181,6 +205,10 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 			if err != nil {
 				return fmt.Errorf("error fetching new scope oplog wrapper: %w", err)
 			}
+			childDbWrapper, err := r.kms.GetWrapper(ctx, s.PublicId, kms.KeyPurposeDatabase, kms.WithRepository(kmsRepo))
+			if err != nil {
+				return fmt.Errorf("error fetching new scope database wrapper: %w", err)
+			}
 
 			// We create a new role, then set grants and principals on it. This
 			// turns into a bunch of stuff sadly because the role is the
@@ -218,6 +246,10 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 				if err != nil {
 					return fmt.Errorf("unable to create in memory role grant: %w", err)
 				}
+				setGrantScope(adminRole, roleGrant)
+				if err := signIntegrity(childDbWrapper, roleGrant); err != nil {
+					return fmt.Errorf("unable to add grants: %w", err)
+				}
 				roleGrantOplogMsgs := make([]*oplog.Message, 0, 1)
 				if err := w.CreateItems(ctx, []interface{}{roleGrant}, db.NewOplogMsgs(&roleGrantOplogMsgs)); err != nil {
 					return fmt.Errorf("unable to add grants: %w", err)
@@ -228,6 +260,9 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 				if err != nil {
 					return fmt.Errorf("unable to create in memory role user: %w", err)
 				}
+				if err := signIntegrity(childDbWrapper, rolePrincipal); err != nil {
+					return fmt.Errorf("unable to add grants: %w", err)
+				}
 				roleUserOplogMsgs := make([]*oplog.Message, 0, 1)
 				if err := w.CreateItems(ctx, []interface{}{rolePrincipal}, db.NewOplogMsgs(&roleUserOplogMsgs)); err != nil {
 					return fmt.Errorf("unable to add grants: %w", err)
@@ -295,6 +330,10 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 						return fmt.Errorf("unable to create in memory role grant: %w", err)
 					}
 					grants = append(grants, roleGrant)
+					setGrantScope(defaultRole, grants...)
+					if err := signIntegrity(childDbWrapper, grants...); err != nil {
+						return fmt.Errorf("unable to add grants: %w", err)
+					}
 
 					roleGrantOplogMsgs := make([]*oplog.Message, 0, 3)
 					if err := w.CreateItems(ctx, grants, db.NewOplogMsgs(&roleGrantOplogMsgs)); err != nil {
@@ -311,6 +350,9 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 						return fmt.Errorf("unable to create in memory role user: %w", err)
 					}
 					principals = append(principals, rolePrincipal)
+					if err := signIntegrity(childDbWrapper, principals...); err != nil {
+						return fmt.Errorf("unable to add grants: %w", err)
+					}
 
 					roleUserOplogMsgs := make([]*oplog.Message, 0, 2)
 					if err := w.CreateItems(ctx, principals, db.NewOplogMsgs(&roleUserOplogMsgs)); err != nil {
@@ -330,25 +372,107 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 				}
 			}
 
+			if readOnlyRole != nil {
+				if err := createScopeRole(ctx, w, childOplogWrapper, childDbWrapper, s, readOnlyRole, readOnlyRoleGrants); err != nil {
+					return fmt.Errorf("error creating read-only role: %w", err)
+				}
+			}
+
+			if opts.withDryRun {
+				return errDryRun
+			}
 			return nil
 		},
 	)
 
 	if err != nil {
+		// The scope's keys were rolled back along with it, so the wrappers
+		// cached for them can't be used
+		r.kms.EvictScope(scopePublicId)
+	}
+	if err != nil && !errors.Is(err, errDryRun) {
 		if db.IsUniqueError(err) {
 			return nil, fmt.Errorf("create scope: scope %s/%s already exists: %w", scopePublicId, s.Name, db.ErrNotUnique)
 		}
-		return nil, fmt.Errorf("create scope: id %s got error: %w", scopePublicId, err)
+		return nil, fmt.Errorf("create scope: id %s got error: %w", scopePublicId, wrapOrgIsolationError(err))
 	}
+	// The default roles created with the scope grant its creator and u_anon
+	// access to it, which only changes the grants in effect in the new scope
+	r.invalidateGrantsCacheScopes(scopePublicId)
 	return scopeRaw.(*Scope), nil
 }
 
+// readOnlyRoleGrants are the grants of the role created by the
+// WithReadOnlyRoleCreation option.
+var readOnlyRoleGrants = []string{"id=*;type=*;actions=read,list"}
+
+// createScopeRole creates a role without principals in a new scope (s) along
+// with its grants, within the transaction of the scope's creation. The grants
+// are signed with dbWrapper.
+func createScopeRole(ctx context.Context, w db.Writer, oplogWrapper, dbWrapper wrapping.Wrapper, s *Scope, role *Role, grants []string) error {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{role.PublicId},
+		"scope-id":           []string{s.PublicId},
+		"scope-type":         []string{s.Type},
+		"resource-type":      []string{resource.Role.String()},
+		"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+	}
+	if err := w.Create(ctx, role, db.WithOplog(oplogWrapper, metadata)); err != nil {
+		return fmt.Errorf("error creating role: %w", err)
+	}
+
+	msgs := make([]*oplog.Message, 0, len(grants)+1)
+	roleTicket, err := w.GetTicket(role)
+	if err != nil {
+		return fmt.Errorf("unable to get ticket: %w", err)
+	}
+	// We need to update the role version as that's the aggregate
+	var roleOplogMsg oplog.Message
+	rowsUpdated, err := w.Update(ctx, role, []string{"Version"}, nil, db.NewOplogMsg(&roleOplogMsg), db.WithVersion(&role.Version))
+	if err != nil {
+		return fmt.Errorf("unable to update role version for adding grant: %w", err)
+	}
+	if rowsUpdated != 1 {
+		return fmt.Errorf("updated role but %d rows updated", rowsUpdated)
+	}
+	msgs = append(msgs, &roleOplogMsg)
+
+	roleGrants := make([]interface{}, 0, len(grants))
+	for _, g := range grants {
+		roleGrant, err := NewRoleGrant(role.PublicId, g)
+		if err != nil {
+			return fmt.Errorf("unable to create in memory role grant: %w", err)
+		}
+		roleGrants = append(roleGrants, roleGrant)
+	}
+	setGrantScope(role, roleGrants...)
+	if err := signIntegrity(dbWrapper, roleGrants...); err != nil {
+		return fmt.Errorf("unable to add grants: %w", err)
+	}
+	roleGrantOplogMsgs := make([]*oplog.Message, 0, len(roleGrants))
+	if err := w.CreateItems(ctx, roleGrants, db.NewOplogMsgs(&roleGrantOplogMsgs)); err != nil {
+		return fmt.Errorf("unable to add grants: %w", err)
+	}
+	msgs = append(msgs, roleGrantOplogMsgs...)
+
+	metadata = oplog.Metadata{
+		"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+		"scope-id":           []string{s.PublicId},
+		"scope-type":         []string{s.Type},
+		"resource-public-id": []string{role.PublicId},
+	}
+	if err := w.WriteOplogEntryWith(ctx, oplogWrapper, roleTicket, metadata, msgs); err != nil {
+		return fmt.Errorf("unable to write oplog: %w", err)
+	}
+	return nil
+}
+
 // UpdateScope will update a scope in the repository and return the written
 // scope.  fieldMaskPaths provides field_mask.proto paths for fields that should
 // be updated.  Fields will be set to NULL if the field is a zero value and
 // included in fieldMask. Name and Description are the only updatable fields,
 // and everything else is ignored.  If no updatable fields are included in the
-// fieldMaskPaths, then an error is returned.
+// fieldMaskPaths, then an error is returned. Supports the WithDryRun option.
 func (r *Repository) UpdateScope(ctx context.Context, scope *Scope, version uint32, fieldMaskPaths []string, opt ...Option) (*Scope, int, error) {
 	if scope == nil {
 		return nil, db.NoRowsAffected, fmt.Errorf("update scope: missing scope: %w", db.ErrInvalidParameter)
@@ -373,7 +497,7 @@ func (r *Repository) UpdateScope(ctx context.Context, scope *Scope, version uint
 		return nil, db.NoRowsAffected, fmt.Errorf("update scope: %w", db.ErrEmptyFieldMask)
 	}
 
-	resource, rowsUpdated, err := r.update(ctx, scope, version, dbMask, nullFields)
+	resource, rowsUpdated, err := r.update(ctx, scope, version, dbMask, nullFields, opt...)
 	if err != nil {
 		if db.IsUniqueError(err) {
 			return nil, db.NoRowsAffected, fmt.Errorf("update scope: %s name %s already exists: %w", scope.PublicId, scope.Name, db.ErrNotUnique)
@@ -389,6 +513,13 @@ func (r *Repository) LookupScope(ctx context.Context, withPublicId string, opt .
 	if withPublicId == "" {
 		return nil, fmt.Errorf("lookup scope: missing public id %w", db.ErrInvalidParameter)
 	}
+	if r.fastReads {
+		s, err := r.fastLookupScope(ctx, withPublicId)
+		if err != nil {
+			return nil, fmt.Errorf("lookup scope: failed %w fo %s", err, withPublicId)
+		}
+		return s, nil
+	}
 	scope := allocScope()
 	scope.PublicId = withPublicId
 	if err := r.reader.LookupByPublicId(ctx, &scope); err != nil {
@@ -400,7 +531,8 @@ func (r *Repository) LookupScope(ctx context.Context, withPublicId string, opt .
 	return &scope, nil
 }
 
-// DeleteScope will delete a scope from the repository
+// DeleteScope will delete a scope from the repository. Supports the WithDryRun
+// option.
 func (r *Repository) DeleteScope(ctx context.Context, withPublicId string, opt ...Option) (int, error) {
 	if withPublicId == "" {
 		return db.NoRowsAffected, fmt.Errorf("delete scope: missing public id %w", db.ErrInvalidParameter)
@@ -410,17 +542,21 @@ func (r *Repository) DeleteScope(ctx context.Context, withPublicId string, opt .
 	}
 	scope := allocScope()
 	scope.PublicId = withPublicId
-	rowsDeleted, err := r.delete(ctx, &scope)
+	rowsDeleted, err := r.delete(ctx, &scope, opt...)
 	if err != nil {
 		if errors.Is(err, ErrMetadataScopeNotFound) {
 			return 0, nil
 		}
 		return db.NoRowsAffected, fmt.Errorf("delete scope: failed %w for %s", err, withPublicId)
 	}
+	// The groups deleted with the scope may be principals of roles in other
+	// scopes, so every entry may be affected.
+	r.invalidateGrantsCache()
 	return rowsDeleted, nil
 }
 
-// ListProjects in an org and supports the WithLimit option.
+// ListProjects in an org and supports the WithLimit, WithPageToken and
+// WithNextPageToken options.
 func (r *Repository) ListProjects(ctx context.Context, withOrgId string, opt ...Option) ([]*Scope, error) {
 	if withOrgId == "" {
 		return nil, fmt.Errorf("list projects: missing org id %w", db.ErrInvalidParameter)
@@ -433,7 +569,8 @@ func (r *Repository) ListProjects(ctx context.Context, withOrgId string, opt ...
 	return projects, nil
 }
 
-// ListOrgs and supports the WithLimit option.
+// ListOrgs and supports the WithLimit, WithPageToken and WithNextPageToken
+// options.
 func (r *Repository) ListOrgs(ctx context.Context, opt ...Option) ([]*Scope, error) {
 	var orgs []*Scope
 	err := r.list(ctx, &orgs, "parent_id = ? and type = ?", []interface{}{"global", scope.Org.String()}, opt...)
@@ -129,6 +129,47 @@ func Test_Repository_Scope_Create(t *testing.T) {
 			assert.Len(foundRoles, numFound)
 		})
 	}
+	t.Run("read-only-role-creation", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s, err := NewOrg(WithName(testId(t)))
+		require.NoError(err)
+		s, err = repo.CreateScope(context.Background(), s, user.GetPublicId(), WithReadOnlyRoleCreation(true))
+		require.NoError(err)
+		require.NotNil(s)
+
+		foundRoles, err := repo.ListRoles(context.Background(), s.GetPublicId())
+		require.NoError(err)
+		assert.Len(foundRoles, 3)
+		var readOnly *Role
+		for _, r := range foundRoles {
+			if r.GetName() == "Read Only" {
+				readOnly = r
+			}
+		}
+		require.NotNil(readOnly)
+		grants, err := repo.ListRoleGrants(context.Background(), readOnly.GetPublicId())
+		require.NoError(err)
+		require.Len(grants, 1)
+		assert.Equal(readOnlyRoleGrants[0], grants[0].GetRawGrant())
+		principals, err := repo.ListPrincipalRoles(context.Background(), readOnly.GetPublicId())
+		require.NoError(err)
+		assert.Empty(principals)
+	})
+	t.Run("atomic", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		id := testId(t)
+		s, err := NewOrg(WithName(id))
+		require.NoError(err)
+		// The admin role's principal doesn't exist, so the transaction fails
+		// after the scope and its admin role are written.
+		s, err = repo.CreateScope(context.Background(), s, "u_1234567890", WithReadOnlyRoleCreation(true))
+		require.Error(err)
+		assert.Nil(s)
+
+		var scopes []*Scope
+		require.NoError(rw.SearchWhere(context.Background(), &scopes, "name = ?", []interface{}{id}))
+		assert.Empty(scopes)
+	})
 }
 
 func Test_Repository_Scope_Update(t *testing.T) {
@@ -0,0 +1,677 @@
+package iam
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+// SnapshotVersion is the version of the Snapshot format written by Export.
+const SnapshotVersion = 2
+
+// SnapshotSignatureAlgorithm is the algorithm of the signatures Export adds to
+// snapshots.
+const SnapshotSignatureAlgorithm = "hmac-sha256"
+
+// MinSnapshotKeyLength is the length, in bytes, of the shortest key
+// snapshots can be signed with.
+const MinSnapshotKeyLength = 32
+
+// ErrSnapshotSignature is returned by Import when a snapshot's signature is
+// missing or doesn't verify.
+var ErrSnapshotSignature = errors.New("snapshot signature is missing or invalid")
+
+// SnapshotKey is a key snapshots are signed and verified with. Keys are
+// shared by the clusters snapshots are moved between, and are told apart by
+// their Id, which is in the signatures made with them, so they can be
+// rotated.
+type SnapshotKey struct {
+	Id  string
+	Key []byte
+}
+
+// validateSnapshotKeys returns an error if any of the keys has no id, an id
+// used by another key, or is shorter than MinSnapshotKeyLength.
+func validateSnapshotKeys(keys []*SnapshotKey) error {
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		switch {
+		case k == nil || k.Id == "":
+			return fmt.Errorf("missing key id: %w", db.ErrInvalidParameter)
+		case seen[k.Id]:
+			return fmt.Errorf("key id %s is used more than once: %w", k.Id, db.ErrInvalidParameter)
+		case len(k.Key) < MinSnapshotKeyLength:
+			return fmt.Errorf("key %s is shorter than %d bytes: %w", k.Id, MinSnapshotKeyLength, db.ErrInvalidParameter)
+		}
+		seen[k.Id] = true
+	}
+	return nil
+}
+
+// Snapshot is the portable iam state of an org and its projects: their users,
+// groups with their members, and roles with their grants, principals and
+// included roles, along with the times they're bounded by. Ids are the
+// public ids in the exported cluster. Snapshots don't include accounts or any
+// other secrets.
+type Snapshot struct {
+	Version   int                `json:"version"`
+	Org       *SnapshotScope     `json:"org"`
+	Projects  []*SnapshotScope   `json:"projects,omitempty"`
+	Users     []*SnapshotUser    `json:"users,omitempty"`
+	Groups    []*SnapshotGroup   `json:"groups,omitempty"`
+	Roles     []*SnapshotRole    `json:"roles,omitempty"`
+	Signature *SnapshotSignature `json:"signature,omitempty"`
+}
+
+// SnapshotSignature is the signature of a Snapshot, made with a SnapshotKey
+// the exporting cluster shares with the clusters which import it. It covers
+// everything in the snapshot, including the signature's metadata, except
+// Value.
+type SnapshotSignature struct {
+	Algorithm string `json:"algorithm"`
+	// KeyId is the id of the SnapshotKey the snapshot was signed with.
+	KeyId string `json:"key_id"`
+	// Signer names the cluster which signed the snapshot, if it's named.
+	Signer   string    `json:"signer,omitempty"`
+	SignedAt time.Time `json:"signed_at"`
+	Value    []byte    `json:"value"`
+}
+
+// SnapshotImport is the record of where an org imported from a Snapshot came
+// from. Verified is whether the snapshot's signature verified when it was
+// imported; the key id, signer and signing time of snapshots which didn't
+// verify are as the snapshot claimed.
+type SnapshotImport struct {
+	ScopeId       string
+	SnapshotOrgId string
+	KeyId         string
+	Signer        string
+	SignedAt      time.Time
+	Verified      bool
+	CreateTime    time.Time
+}
+
+// SnapshotScope is a scope in a Snapshot.
+type SnapshotScope struct {
+	Id          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SnapshotUser is a user in a Snapshot.
+type SnapshotUser struct {
+	Id          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SnapshotGroup is a group in a Snapshot. Members are user ids.
+type SnapshotGroup struct {
+	Id          string   `json:"id"`
+	ScopeId     string   `json:"scope_id"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Members     []string `json:"members,omitempty"`
+}
+
+// SnapshotRole is a role in a Snapshot. Includes are the ids of the roles it
+// includes.
+type SnapshotRole struct {
+	Id             string               `json:"id"`
+	ScopeId        string               `json:"scope_id"`
+	GrantScopeId   string               `json:"grant_scope_id"`
+	Name           string               `json:"name,omitempty"`
+	Description    string               `json:"description,omitempty"`
+	ExpirationTime *time.Time           `json:"expiration_time,omitempty"`
+	Grants         []*SnapshotGrant     `json:"grants,omitempty"`
+	Principals     []*SnapshotPrincipal `json:"principals,omitempty"`
+	Includes       []string             `json:"includes,omitempty"`
+}
+
+// SnapshotGrant is a grant of a role in a Snapshot, with the times it
+// applies from and until, if they're bounded.
+type SnapshotGrant struct {
+	Grant     string     `json:"grant"`
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	NotAfter  *time.Time `json:"not_after,omitempty"`
+}
+
+// SnapshotPrincipal is a user or group of a role in a Snapshot, with the
+// times it has the role from and until, if they're bounded.
+type SnapshotPrincipal struct {
+	Id        string     `json:"id"`
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	NotAfter  *time.Time `json:"not_after,omitempty"`
+}
+
+// Export returns a Snapshot of the org (orgId) and its projects, signed with
+// the first of the repository's snapshot keys. Without snapshot keys, the
+// snapshot isn't signed, and can only be imported with the
+// WithUnverifiedSnapshot option.
+func (r *Repository) Export(ctx context.Context, orgId string, opt ...Option) (*Snapshot, error) {
+	if orgId == "" {
+		return nil, fmt.Errorf("export: missing org id: %w", db.ErrInvalidParameter)
+	}
+	org, err := r.LookupScope(ctx, orgId)
+	if err != nil {
+		return nil, fmt.Errorf("export: %w", err)
+	}
+	if org == nil {
+		return nil, fmt.Errorf("export: org %s: %w", orgId, db.ErrRecordNotFound)
+	}
+	if org.Type != scope.Org.String() {
+		return nil, fmt.Errorf("export: %s is not an org: %w", orgId, db.ErrInvalidParameter)
+	}
+
+	s := &Snapshot{
+		Version: SnapshotVersion,
+		Org:     &SnapshotScope{Id: org.PublicId, Name: org.Name, Description: org.Description},
+	}
+	projects, err := r.ListProjects(ctx, orgId, WithLimit(-1))
+	if err != nil {
+		return nil, fmt.Errorf("export: %w", err)
+	}
+	scopeIds := []string{orgId}
+	for _, p := range projects {
+		s.Projects = append(s.Projects, &SnapshotScope{Id: p.PublicId, Name: p.Name, Description: p.Description})
+		scopeIds = append(scopeIds, p.PublicId)
+	}
+
+	users, err := r.ListUsers(ctx, orgId, WithLimit(-1))
+	if err != nil {
+		return nil, fmt.Errorf("export: %w", err)
+	}
+	for _, u := range users {
+		s.Users = append(s.Users, &SnapshotUser{Id: u.PublicId, Name: u.Name, Description: u.Description})
+	}
+
+	for _, scopeId := range scopeIds {
+		groups, err := r.ListGroups(ctx, scopeId, WithLimit(-1))
+		if err != nil {
+			return nil, fmt.Errorf("export: %w", err)
+		}
+		for _, g := range groups {
+			members, err := r.ListGroupMembers(ctx, g.PublicId, WithLimit(-1))
+			if err != nil {
+				return nil, fmt.Errorf("export: %w", err)
+			}
+			sg := &SnapshotGroup{Id: g.PublicId, ScopeId: scopeId, Name: g.Name, Description: g.Description}
+			for _, m := range members {
+				sg.Members = append(sg.Members, m.MemberId)
+			}
+			s.Groups = append(s.Groups, sg)
+		}
+
+		roles, err := r.ListRoles(ctx, scopeId, WithLimit(-1))
+		if err != nil {
+			return nil, fmt.Errorf("export: %w", err)
+		}
+		for _, role := range roles {
+			grants, err := r.ListRoleGrants(ctx, role.PublicId, WithLimit(-1))
+			if err != nil {
+				return nil, fmt.Errorf("export: %w", err)
+			}
+			principals, err := r.ListPrincipalRoles(ctx, role.PublicId, WithLimit(-1))
+			if err != nil {
+				return nil, fmt.Errorf("export: %w", err)
+			}
+			includes, err := r.ListRoleIncludes(ctx, role.PublicId, WithLimit(-1))
+			if err != nil {
+				return nil, fmt.Errorf("export: %w", err)
+			}
+			sr := &SnapshotRole{
+				Id:             role.PublicId,
+				ScopeId:        scopeId,
+				GrantScopeId:   role.GrantScopeId,
+				Name:           role.Name,
+				Description:    role.Description,
+				ExpirationTime: snapshotTime(role.ExpirationTime),
+			}
+			for _, g := range grants {
+				sr.Grants = append(sr.Grants, &SnapshotGrant{
+					Grant:     g.RawGrant,
+					NotBefore: snapshotTime(g.NotBefore),
+					NotAfter:  snapshotTime(g.NotAfter),
+				})
+			}
+			for _, p := range principals {
+				sr.Principals = append(sr.Principals, &SnapshotPrincipal{
+					Id:        p.PrincipalId,
+					NotBefore: snapshotTime(p.NotBefore),
+					NotAfter:  snapshotTime(p.NotAfter),
+				})
+			}
+			for _, i := range includes {
+				sr.Includes = append(sr.Includes, i.IncludedRoleId)
+			}
+			s.Roles = append(s.Roles, sr)
+		}
+	}
+	if err := r.signSnapshot(s); err != nil {
+		return nil, fmt.Errorf("export: %w", err)
+	}
+	return s, nil
+}
+
+// Import creates a new org from the Snapshot, with new public ids, and
+// returns it along with a map from the snapshot's ids to the new ones. Ids in
+// grants, principals and role includes which aren't in the snapshot, like
+// u_anon and u_auth, are kept as they are and must exist. The import is done
+// in one transaction, so if it fails nothing is imported.
+//
+// The snapshot's signature must verify with one of the repository's snapshot
+// keys, or an error wrapping ErrSnapshotSignature is returned, so modified
+// snapshots and snapshots signed with keys this cluster doesn't share are
+// rejected. The signature's key id and signer, and whether it verified, are
+// recorded with the new org; see LookupSnapshotImport.
+//
+// Supports the WithName option, which names the new org instead of the
+// snapshot's org's name, and the WithUnverifiedSnapshot option, which imports
+// the snapshot even if its signature is missing or doesn't verify.
+func (r *Repository) Import(ctx context.Context, s *Snapshot, opt ...Option) (*Scope, map[string]string, error) {
+	if s == nil {
+		return nil, nil, fmt.Errorf("import: missing snapshot: %w", db.ErrInvalidParameter)
+	}
+	if s.Version != SnapshotVersion {
+		return nil, nil, fmt.Errorf("import: unsupported snapshot version %d: %w", s.Version, db.ErrInvalidParameter)
+	}
+	if s.Org == nil {
+		return nil, nil, fmt.Errorf("import: snapshot has no org: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	verifyErr := r.verifySnapshot(s)
+	if verifyErr != nil && !opts.withUnverifiedSnapshot {
+		return nil, nil, fmt.Errorf("import: %w", verifyErr)
+	}
+	name := s.Org.Name
+	if opts.withName != "" {
+		name = opts.withName
+	}
+
+	newOrg, err := NewOrg(WithName(name), WithDescription(s.Org.Description))
+	if err != nil {
+		return nil, nil, fmt.Errorf("import: %w", err)
+	}
+
+	var org *Scope
+	var ids map[string]string
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			txRepo, err := r.inTx(reader, w)
+			if err != nil {
+				return err
+			}
+			org, err = txRepo.CreateScope(ctx, newOrg, "", WithSkipDefaultRoleCreation(true))
+			if err != nil {
+				return err
+			}
+			ids = map[string]string{s.Org.Id: org.PublicId}
+			if err := txRepo.recordSnapshotImport(ctx, org.PublicId, s, verifyErr == nil); err != nil {
+				return err
+			}
+			return txRepo.importSnapshot(ctx, s, ids)
+		},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("import: %w", err)
+	}
+	return org, ids, nil
+}
+
+// inTx returns a copy of the repository which reads and writes with the
+// transaction's reader and writer, and reads keys with them too, so its
+// methods are composed into the transaction.
+func (r *Repository) inTx(reader db.Reader, w db.Writer) (*Repository, error) {
+	kmsRepo, err := kms.NewRepository(reader, w)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create kms repository: %w", err)
+	}
+	txKms, err := r.kms.ForRepository(kmsRepo)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create kms: %w", err)
+	}
+	txRepo := *r
+	txRepo.reader, txRepo.writer, txRepo.kms = reader, w, txKms
+	return &txRepo, nil
+}
+
+// importSnapshot imports everything in the snapshot but its org, which must
+// be in ids, and adds the id of everything it creates to ids.
+func (r *Repository) importSnapshot(ctx context.Context, s *Snapshot, ids map[string]string) error {
+	orgId := ids[s.Org.Id]
+	mapId := func(id string) string {
+		if newId, ok := ids[id]; ok {
+			return newId
+		}
+		return id
+	}
+	mapIds := func(old []string) []string {
+		mapped := make([]string, 0, len(old))
+		for _, id := range old {
+			mapped = append(mapped, mapId(id))
+		}
+		return mapped
+	}
+
+	for _, sp := range s.Projects {
+		p, err := NewProject(orgId, WithName(sp.Name), WithDescription(sp.Description))
+		if err != nil {
+			return err
+		}
+		p, err = r.CreateScope(ctx, p, "")
+		if err != nil {
+			return fmt.Errorf("project %s: %w", sp.Id, err)
+		}
+		ids[sp.Id] = p.PublicId
+	}
+	for _, su := range s.Users {
+		u, err := NewUser(orgId, WithName(su.Name), WithDescription(su.Description))
+		if err != nil {
+			return err
+		}
+		u, err = r.CreateUser(ctx, u)
+		if err != nil {
+			return fmt.Errorf("user %s: %w", su.Id, err)
+		}
+		ids[su.Id] = u.PublicId
+	}
+	for _, sg := range s.Groups {
+		scopeId, ok := ids[sg.ScopeId]
+		if !ok {
+			return fmt.Errorf("group %s: scope %s is not in the snapshot: %w", sg.Id, sg.ScopeId, db.ErrInvalidParameter)
+		}
+		g, err := NewGroup(scopeId, WithName(sg.Name), WithDescription(sg.Description))
+		if err != nil {
+			return err
+		}
+		g, err = r.CreateGroup(ctx, g)
+		if err != nil {
+			return fmt.Errorf("group %s: %w", sg.Id, err)
+		}
+		ids[sg.Id] = g.PublicId
+		if len(sg.Members) > 0 {
+			if _, err := r.AddGroupMembers(ctx, g.PublicId, g.Version, mapIds(sg.Members)); err != nil {
+				return fmt.Errorf("group %s: %w", sg.Id, err)
+			}
+		}
+	}
+
+	// Roles are created before their grants are added so grants on roles in
+	// the snapshot can be remapped.
+	roles := make([]*Role, 0, len(s.Roles))
+	for _, sr := range s.Roles {
+		scopeId, ok := ids[sr.ScopeId]
+		if !ok {
+			return fmt.Errorf("role %s: scope %s is not in the snapshot: %w", sr.Id, sr.ScopeId, db.ErrInvalidParameter)
+		}
+		roleOpts := []Option{WithName(sr.Name), WithDescription(sr.Description), WithGrantScopeId(mapId(sr.GrantScopeId))}
+		if sr.ExpirationTime != nil {
+			roleOpts = append(roleOpts, WithExpirationTime(*sr.ExpirationTime))
+		}
+		role, err := NewRole(scopeId, roleOpts...)
+		if err != nil {
+			return err
+		}
+		role, err = r.CreateRole(ctx, role)
+		if err != nil {
+			return fmt.Errorf("role %s: %w", sr.Id, err)
+		}
+		ids[sr.Id] = role.PublicId
+		roles = append(roles, role)
+	}
+	// Grants and principals are added once for each of their time bounds,
+	// since the bounds are options of the whole add.
+	for i, sr := range s.Roles {
+		role := roles[i]
+		version := role.Version
+		var grants snapshotBounds
+		for _, g := range sr.Grants {
+			grant, err := remapGrant(role.ScopeId, g.Grant, ids)
+			if err != nil {
+				return fmt.Errorf("role %s: %w", sr.Id, err)
+			}
+			grants.add(grant, g.NotBefore, g.NotAfter)
+		}
+		for _, b := range grants {
+			if _, err := r.AddRoleGrants(ctx, role.PublicId, version, b.values, b.options()...); err != nil {
+				return fmt.Errorf("role %s: %w", sr.Id, err)
+			}
+			version++
+		}
+		var principals snapshotBounds
+		for _, p := range sr.Principals {
+			principals.add(mapId(p.Id), p.NotBefore, p.NotAfter)
+		}
+		for _, b := range principals {
+			if _, err := r.AddPrincipalRoles(ctx, role.PublicId, version, b.values, b.options()...); err != nil {
+				return fmt.Errorf("role %s: %w", sr.Id, err)
+			}
+			version++
+		}
+		role.Version = version
+	}
+	// Includes are added once every role exists, since roles can include
+	// roles which come after them in the snapshot.
+	for i, sr := range s.Roles {
+		if len(sr.Includes) == 0 {
+			continue
+		}
+		role := roles[i]
+		if _, err := r.AddRoleIncludes(ctx, role.PublicId, role.Version, mapIds(sr.Includes)); err != nil {
+			return fmt.Errorf("role %s: %w", sr.Id, err)
+		}
+	}
+	return nil
+}
+
+// snapshotBound is the values of a role's grants or principals which have
+// the same time bounds.
+type snapshotBound struct {
+	notBefore, notAfter *time.Time
+	values              []string
+}
+
+// options returns the WithNotBefore and WithNotAfter options for the bound.
+func (b *snapshotBound) options() []Option {
+	var opts []Option
+	if b.notBefore != nil {
+		opts = append(opts, WithNotBefore(*b.notBefore))
+	}
+	if b.notAfter != nil {
+		opts = append(opts, WithNotAfter(*b.notAfter))
+	}
+	return opts
+}
+
+// snapshotBounds groups values by their time bounds, in the order the bounds
+// are first seen.
+type snapshotBounds []*snapshotBound
+
+func (bs *snapshotBounds) add(value string, notBefore, notAfter *time.Time) {
+	for _, b := range *bs {
+		if sameTime(b.notBefore, notBefore) && sameTime(b.notAfter, notAfter) {
+			b.values = append(b.values, value)
+			return
+		}
+	}
+	*bs = append(*bs, &snapshotBound{notBefore: notBefore, notAfter: notAfter, values: []string{value}})
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// snapshotTime returns the time of ts, or nil if it isn't set.
+func snapshotTime(ts *timestamp.Timestamp) *time.Time {
+	if ts.GetTimestamp() == nil {
+		return nil
+	}
+	t := ts.GetTimestamp().AsTime().UTC()
+	return &t
+}
+
+// remapGrant replaces the id field of a grant on a resource in the snapshot
+// with the resource's new id. The rest of the grant is kept as it is.
+func remapGrant(scopeId, grant string, ids map[string]string) (string, error) {
+	g, err := perms.Parse(scopeId, grant, perms.WithSkipFinalValidation(true))
+	if err != nil {
+		return "", fmt.Errorf("parsing grant %q: %w", grant, err)
+	}
+	newId, ok := ids[g.Id()]
+	if !ok {
+		return grant, nil
+	}
+	if grant[0] == '{' {
+		fields := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(grant), &fields); err != nil {
+			return "", fmt.Errorf("parsing grant %q: %w", grant, err)
+		}
+		fields["id"] = newId
+		remapped, err := json.Marshal(fields)
+		if err != nil {
+			return "", fmt.Errorf("remapping grant %q: %w", grant, err)
+		}
+		return string(remapped), nil
+	}
+	segments := strings.Split(grant, ";")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "id=") {
+			segments[i] = "id=" + newId
+		}
+	}
+	return strings.Join(segments, ";"), nil
+}
+
+// signSnapshot signs the snapshot with the first of the repository's
+// snapshot keys, if it has any.
+func (r *Repository) signSnapshot(s *Snapshot) error {
+	if len(r.snapshotKeys) == 0 {
+		return nil
+	}
+	key := r.snapshotKeys[0]
+	s.Signature = &SnapshotSignature{
+		Algorithm: SnapshotSignatureAlgorithm,
+		KeyId:     key.Id,
+		Signer:    r.snapshotSigner,
+		SignedAt:  r.now().UTC().Truncate(time.Second),
+	}
+	mac, err := snapshotHmac(key.Key, s)
+	if err != nil {
+		return fmt.Errorf("unable to sign snapshot: %w", err)
+	}
+	s.Signature.Value = mac
+	return nil
+}
+
+// verifySnapshot returns an error wrapping ErrSnapshotSignature unless the
+// snapshot has a valid signature made with one of the repository's snapshot
+// keys.
+func (r *Repository) verifySnapshot(s *Snapshot) error {
+	sig := s.Signature
+	if sig == nil || len(sig.Value) == 0 {
+		return fmt.Errorf("snapshot is not signed: %w", ErrSnapshotSignature)
+	}
+	if sig.Algorithm != SnapshotSignatureAlgorithm {
+		return fmt.Errorf("unsupported signature algorithm %q: %w", sig.Algorithm, ErrSnapshotSignature)
+	}
+	var key *SnapshotKey
+	for _, k := range r.snapshotKeys {
+		if k.Id == sig.KeyId {
+			key = k
+			break
+		}
+	}
+	if key == nil {
+		return fmt.Errorf("unknown signing key %q: %w", sig.KeyId, ErrSnapshotSignature)
+	}
+	mac, err := snapshotHmac(key.Key, s)
+	if err != nil {
+		return fmt.Errorf("unable to verify snapshot: %w", err)
+	}
+	if !hmac.Equal(sig.Value, mac) {
+		return fmt.Errorf("snapshot was modified after it was signed: %w", ErrSnapshotSignature)
+	}
+	return nil
+}
+
+// recordSnapshotImport records that the org (scopeId) was imported from the
+// snapshot, and whether its signature verified.
+func (r *Repository) recordSnapshotImport(ctx context.Context, scopeId string, s *Snapshot, verified bool) error {
+	var keyId, signer sql.NullString
+	var signedAt sql.NullTime
+	if sig := s.Signature; sig != nil {
+		keyId = sql.NullString{String: sig.KeyId, Valid: sig.KeyId != ""}
+		signer = sql.NullString{String: sig.Signer, Valid: sig.Signer != ""}
+		signedAt = sql.NullTime{Time: sig.SignedAt, Valid: !sig.SignedAt.IsZero()}
+	}
+	if _, err := r.writer.Exec(ctx, insertSnapshotImport, []interface{}{scopeId, s.Org.Id, keyId, signer, signedAt, verified}); err != nil {
+		return fmt.Errorf("unable to record snapshot import: %w", err)
+	}
+	return nil
+}
+
+// LookupSnapshotImport returns the record of the snapshot the org (scopeId)
+// was imported from, or nil if it wasn't imported from one.
+func (r *Repository) LookupSnapshotImport(ctx context.Context, scopeId string) (*SnapshotImport, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("lookup snapshot import: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	rows, err := r.reader.Query(ctx, lookupSnapshotImport, []interface{}{scopeId})
+	if err != nil {
+		return nil, fmt.Errorf("lookup snapshot import: %w", err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("lookup snapshot import: %w", err)
+		}
+		return nil, nil
+	}
+	imp := &SnapshotImport{ScopeId: scopeId}
+	var keyId, signer sql.NullString
+	var signedAt sql.NullTime
+	if err := rows.Scan(&imp.SnapshotOrgId, &keyId, &signer, &signedAt, &imp.Verified, &imp.CreateTime); err != nil {
+		return nil, fmt.Errorf("lookup snapshot import: unable to scan record: %w", err)
+	}
+	imp.KeyId, imp.Signer = keyId.String, signer.String
+	if signedAt.Valid {
+		imp.SignedAt = signedAt.Time.UTC()
+	}
+	return imp, nil
+}
+
+// snapshotHmac returns the hmac of the JSON encoding of the snapshot without
+// its signature's value.
+func snapshotHmac(key []byte, s *Snapshot) ([]byte, error) {
+	unsigned := *s
+	if s.Signature != nil {
+		sig := *s.Signature
+		sig.Value = nil
+		unsigned.Signature = &sig
+	}
+	data, err := json.Marshal(&unsigned)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
@@ -0,0 +1,282 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ExportImport(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	key := &SnapshotKey{Id: "k1", Key: []byte(strings.Repeat("k", MinSnapshotKeyLength))}
+	repo := TestRepo(t, conn, wrapper, WithSnapshotKeys(key), WithSnapshotSigner("east"))
+	ctx := context.Background()
+
+	org, proj := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	user := TestUser(t, repo, org.PublicId, WithName("alice"))
+	grp := TestGroup(t, conn, proj.PublicId, WithName("operators"))
+	TestGroupMember(t, conn, grp.PublicId, user.PublicId)
+	notBefore := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+	notAfter := notBefore.Add(24 * time.Hour)
+	expiration := notAfter.Add(24 * time.Hour)
+	orgRole := TestRole(t, conn, org.PublicId, WithName("org admin"), WithGrantScopeId(proj.PublicId), WithExpirationTime(expiration))
+	TestRoleGrant(t, conn, orgRole.PublicId, "id="+grp.PublicId+";actions=read")
+	TestRoleGrant(t, conn, orgRole.PublicId, `{"id":"`+user.PublicId+`","actions":["read"]}`, WithNotBefore(notBefore), WithNotAfter(notAfter))
+	TestUserRole(t, conn, orgRole.PublicId, user.PublicId, WithNotAfter(notAfter))
+	TestUserRole(t, conn, orgRole.PublicId, "u_anon")
+	projRole := TestRole(t, conn, proj.PublicId, WithName("operators"))
+	TestRoleGrant(t, conn, projRole.PublicId, "id=*;type=*;actions=read")
+	TestGroupRole(t, conn, projRole.PublicId, grp.PublicId)
+	_, err := repo.AddRoleIncludes(ctx, projRole.PublicId, projRole.Version, []string{orgRole.PublicId})
+	require.NoError(t, err)
+
+	t.Run("round-trip", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		exported, err := repo.Export(ctx, org.PublicId)
+		require.NoError(err)
+		assert.Equal(SnapshotVersion, exported.Version)
+		require.Len(exported.Projects, 1)
+		require.Len(exported.Users, 1)
+		require.Len(exported.Groups, 1)
+		assert.Equal([]string{user.PublicId}, exported.Groups[0].Members)
+		require.Len(exported.Roles, 2)
+
+		// The snapshot is portable JSON.
+		data, err := json.Marshal(exported)
+		require.NoError(err)
+		var snapshot Snapshot
+		require.NoError(json.Unmarshal(data, &snapshot))
+		assert.Equal(exported, &snapshot)
+
+		imported, ids, err := repo.Import(ctx, &snapshot, WithName(testId(t)))
+		require.NoError(err)
+		require.NotNil(imported)
+		assert.Equal(imported.PublicId, ids[org.PublicId])
+		record, err := repo.LookupSnapshotImport(ctx, imported.PublicId)
+		require.NoError(err)
+		require.NotNil(record)
+		assert.Equal(org.PublicId, record.SnapshotOrgId)
+		assert.Equal("k1", record.KeyId)
+		assert.Equal("east", record.Signer)
+		assert.True(exported.Signature.SignedAt.Equal(record.SignedAt))
+		assert.True(record.Verified)
+		for _, id := range []string{proj.PublicId, user.PublicId, grp.PublicId, orgRole.PublicId, projRole.PublicId} {
+			assert.NotEmpty(ids[id], id)
+			assert.NotEqual(id, ids[id])
+		}
+
+		reexported, err := repo.Export(ctx, imported.PublicId)
+		require.NoError(err)
+		require.Len(reexported.Groups, 1)
+		assert.Equal(ids[proj.PublicId], reexported.Groups[0].ScopeId)
+		assert.Equal([]string{ids[user.PublicId]}, reexported.Groups[0].Members)
+		roles := map[string]*SnapshotRole{}
+		for _, r := range reexported.Roles {
+			roles[r.Name] = r
+		}
+		require.Len(roles, 2)
+		assert.Equal(ids[proj.PublicId], roles["org admin"].GrantScopeId)
+		require.NotNil(roles["org admin"].ExpirationTime)
+		assert.True(expiration.Equal(*roles["org admin"].ExpirationTime))
+		assert.ElementsMatch([]*SnapshotGrant{
+			{Grant: "id=" + ids[grp.PublicId] + ";actions=read"},
+			{Grant: `{"actions":["read"],"id":"` + ids[user.PublicId] + `"}`, NotBefore: &notBefore, NotAfter: &notAfter},
+		}, roles["org admin"].Grants)
+		assert.ElementsMatch([]*SnapshotPrincipal{
+			{Id: ids[user.PublicId], NotAfter: &notAfter},
+			{Id: "u_anon"},
+		}, roles["org admin"].Principals)
+		assert.Nil(roles["operators"].ExpirationTime)
+		assert.Equal([]*SnapshotGrant{{Grant: "id=*;type=*;actions=read"}}, roles["operators"].Grants)
+		assert.Equal([]*SnapshotPrincipal{{Id: ids[grp.PublicId]}}, roles["operators"].Principals)
+		assert.Equal([]string{ids[orgRole.PublicId]}, roles["operators"].Includes)
+	})
+	t.Run("unsupported-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		exported, err := repo.Export(ctx, org.PublicId)
+		require.NoError(err)
+		exported.Version = SnapshotVersion + 1
+		_, _, err = repo.Import(ctx, exported, WithName(testId(t)))
+		require.Error(err)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+	})
+	t.Run("failed-import-is-rolled-back", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		exported, err := repo.Export(ctx, org.PublicId)
+		require.NoError(err)
+		exported.Roles[0].Principals = append(exported.Roles[0].Principals, &SnapshotPrincipal{Id: "u_1234567890"})
+		name := testId(t)
+		_, _, err = repo.Import(ctx, exported, WithName(name), WithUnverifiedSnapshot(true))
+		require.Error(err)
+		assert.False(errors.Is(err, ErrSnapshotSignature))
+
+		orgs, err := repo.ListOrgs(ctx, WithLimit(-1))
+		require.NoError(err)
+		for _, o := range orgs {
+			assert.NotEqual(name, o.Name)
+		}
+	})
+	t.Run("signature", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		exported, err := repo.Export(ctx, org.PublicId)
+		require.NoError(err)
+		require.NotNil(exported.Signature)
+		assert.Equal(SnapshotSignatureAlgorithm, exported.Signature.Algorithm)
+		assert.Equal("k1", exported.Signature.KeyId)
+		assert.Equal("east", exported.Signature.Signer)
+		assert.False(exported.Signature.SignedAt.IsZero())
+
+		modified := *exported
+		modified.Roles = append([]*SnapshotRole{}, exported.Roles...)
+		modified.Roles[0] = &SnapshotRole{}
+		*modified.Roles[0] = *exported.Roles[0]
+		modified.Roles[0].Grants = []*SnapshotGrant{{Grant: "id=*;type=*;actions=*"}}
+		_, _, err = repo.Import(ctx, &modified, WithName(testId(t)))
+		assert.True(errors.Is(err, ErrSnapshotSignature))
+
+		// The signature's metadata is signed too
+		resigned := *exported
+		sig := *exported.Signature
+		sig.SignedAt = sig.SignedAt.Add(time.Hour)
+		resigned.Signature = &sig
+		_, _, err = repo.Import(ctx, &resigned, WithName(testId(t)))
+		assert.True(errors.Is(err, ErrSnapshotSignature))
+
+		unsigned := *exported
+		unsigned.Signature = nil
+		_, _, err = repo.Import(ctx, &unsigned, WithName(testId(t)))
+		assert.True(errors.Is(err, ErrSnapshotSignature))
+
+		// Another cluster verifies the snapshot with the key it shares, even
+		// once the key is rotated out of signing
+		otherConn, _ := db.TestSetup(t, "postgres")
+		newKey := &SnapshotKey{Id: "k2", Key: []byte(strings.Repeat("n", MinSnapshotKeyLength))}
+		otherRepo := TestRepo(t, otherConn, wrapper, WithSnapshotKeys(newKey, key))
+		imported, _, err := otherRepo.Import(ctx, exported, WithName(testId(t)))
+		require.NoError(err)
+		assert.NotNil(imported)
+
+		// but not with a key of the same id it doesn't share, or without it
+		wrongKey := &SnapshotKey{Id: "k1", Key: []byte(strings.Repeat("w", MinSnapshotKeyLength))}
+		otherRepo = TestRepo(t, otherConn, wrapper, WithSnapshotKeys(wrongKey))
+		_, _, err = otherRepo.Import(ctx, exported, WithName(testId(t)))
+		assert.True(errors.Is(err, ErrSnapshotSignature))
+		otherRepo = TestRepo(t, otherConn, wrapper, WithSnapshotKeys(newKey))
+		_, _, err = otherRepo.Import(ctx, exported, WithName(testId(t)))
+		assert.True(errors.Is(err, ErrSnapshotSignature))
+
+		imported, _, err = repo.Import(ctx, &modified, WithName(testId(t)), WithUnverifiedSnapshot(true))
+		require.NoError(err)
+		assert.NotNil(imported)
+		record, err := repo.LookupSnapshotImport(ctx, imported.PublicId)
+		require.NoError(err)
+		require.NotNil(record)
+		assert.Equal("k1", record.KeyId)
+		assert.False(record.Verified)
+
+		// Without keys, snapshots aren't signed
+		unkeyedRepo := TestRepo(t, conn, wrapper)
+		exported, err = unkeyedRepo.Export(ctx, org.PublicId)
+		require.NoError(err)
+		assert.Nil(exported.Signature)
+		imported, _, err = unkeyedRepo.Import(ctx, exported, WithName(testId(t)), WithUnverifiedSnapshot(true))
+		require.NoError(err)
+		record, err = repo.LookupSnapshotImport(ctx, imported.PublicId)
+		require.NoError(err)
+		require.NotNil(record)
+		assert.Empty(record.KeyId)
+		assert.False(record.Verified)
+
+		record, err = repo.LookupSnapshotImport(ctx, org.PublicId)
+		require.NoError(err)
+		assert.Nil(record)
+	})
+	t.Run("not-an-org", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.Export(ctx, proj.PublicId)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+	})
+}
+
+func TestRemapGrant(t *testing.T) {
+	ids := map[string]string{
+		"g_1234567890": "g_0987654321",
+		"r_1234567890": "r_0987654321",
+	}
+	tests := []struct {
+		name  string
+		grant string
+		want  string
+	}{
+		{
+			name:  "text",
+			grant: "id=g_1234567890;actions=read,update",
+			want:  "id=g_0987654321;actions=read,update",
+		},
+		{
+			name:  "only-the-id-field",
+			grant: "type=group;id=g_1234567890;actions=read",
+			want:  "type=group;id=g_0987654321;actions=read",
+		},
+		{
+			name:  "json",
+			grant: `{"id":"r_1234567890","actions":["read"]}`,
+			want:  `{"actions":["read"],"id":"r_0987654321"}`,
+		},
+		{
+			name:  "not-in-snapshot",
+			grant: "id=u_anon;actions=read",
+			want:  "id=u_anon;actions=read",
+		},
+		{
+			name:  "wildcard",
+			grant: "id=*;type=*;actions=read",
+			want:  "id=*;type=*;actions=read",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := remapGrant("o_1234567890", tt.grant, ids)
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+	_, err := remapGrant("o_1234567890", "id=;actions=read", ids)
+	assert.Error(t, err)
+}
+
+func TestValidateSnapshotKeys(t *testing.T) {
+	long := []byte(strings.Repeat("k", MinSnapshotKeyLength))
+	tests := []struct {
+		name    string
+		keys    []*SnapshotKey
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "valid", keys: []*SnapshotKey{{Id: "k1", Key: long}, {Id: "k2", Key: long}}},
+		{name: "missing-id", keys: []*SnapshotKey{{Key: long}}, wantErr: true},
+		{name: "nil", keys: []*SnapshotKey{nil}, wantErr: true},
+		{name: "duplicate-id", keys: []*SnapshotKey{{Id: "k1", Key: long}, {Id: "k1", Key: long}}, wantErr: true},
+		{name: "short", keys: []*SnapshotKey{{Id: "k1", Key: long[1:]}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSnapshotKeys(tt.keys)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
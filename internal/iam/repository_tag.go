@@ -0,0 +1,210 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// SetTags sets the tags of a role or group (resourceId) to tags, adding,
+// replacing and removing tags as needed. The resource's current db version
+// must match the resourceVersion or an error will be returned. An empty map
+// removes all tags, but a nil map is treated as a mistake. The tags after the
+// set are returned along with the number of tags removed or replaced.
+func (r *Repository) SetTags(ctx context.Context, resourceId string, resourceVersion uint32, tags map[string]string, opt ...Option) ([]*Tag, int, error) {
+	if resourceId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("set tags: missing resource id: %w", db.ErrInvalidParameter)
+	}
+	if resourceVersion == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("set tags: version cannot be zero: %w", db.ErrInvalidParameter)
+	}
+	if tags == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set tags: nil tags: %w", db.ErrInvalidParameter)
+	}
+	resource, updatedResource, err := taggedResource(resourceId, resourceVersion+1)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set tags: %w", err)
+	}
+
+	// NOTE: Set calculation can safely take place out of the transaction since
+	// we are using resourceVersion to ensure that we end up operating on the
+	// same set of data from this query to the final set in the transaction
+	// function
+	current, err := r.ListTags(ctx, resourceId)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set tags: %w", err)
+	}
+	found := make(map[string]*Tag, len(current))
+	for _, t := range current {
+		found[t.Key] = t
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	addTags := make([]interface{}, 0, len(tags))
+	deleteTags := make([]interface{}, 0, len(current))
+	for _, k := range keys {
+		if t, ok := found[k]; ok {
+			delete(found, k)
+			if t.Value == tags[k] {
+				continue
+			}
+			deleteTags = append(deleteTags, t)
+		}
+		t, err := NewTag(resourceId, k, tags[k])
+		if err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("set tags: %w", err)
+		}
+		addTags = append(addTags, t)
+	}
+	for _, t := range current {
+		if _, ok := found[t.Key]; ok {
+			deleteTags = append(deleteTags, t)
+		}
+	}
+	if len(addTags) == 0 && len(deleteTags) == 0 {
+		return current, db.NoRowsAffected, nil
+	}
+
+	scope, err := resource.GetScope(ctx, r.reader)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set tags: unable to get %s scope: %w", resourceId, err)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set tags: unable to get oplog wrapper: %w", err)
+	}
+
+	var currentTags []*Tag
+	var totalRowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, 3)
+			ticket, err := w.GetTicket(resource)
+			if err != nil {
+				return fmt.Errorf("set tags: unable to get ticket: %w", err)
+			}
+			var resourceOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, updatedResource, []string{"Version"}, nil, db.NewOplogMsg(&resourceOplogMsg), db.WithVersion(&resourceVersion))
+			if err != nil {
+				return fmt.Errorf("set tags: unable to update %s version: %w", resourceId, err)
+			}
+			if rowsUpdated != 1 {
+				return fmt.Errorf("set tags: updated %s and %d rows updated", resourceId, rowsUpdated)
+			}
+			msgs = append(msgs, &resourceOplogMsg)
+
+			// Removed and replaced tags are deleted first, since a replaced
+			// tag is deleted and created with the same key.
+			if len(deleteTags) > 0 {
+				tagOplogMsgs := make([]*oplog.Message, 0, len(deleteTags))
+				rowsDeleted, err := w.DeleteItems(ctx, deleteTags, db.NewOplogMsgs(&tagOplogMsgs))
+				if err != nil {
+					return fmt.Errorf("set tags: unable to delete tags: %w", err)
+				}
+				if rowsDeleted != len(deleteTags) {
+					return fmt.Errorf("set tags: tags deleted %d did not match request for %d", rowsDeleted, len(deleteTags))
+				}
+				totalRowsDeleted = rowsDeleted
+				msgs = append(msgs, tagOplogMsgs...)
+			}
+			if len(addTags) > 0 {
+				tagOplogMsgs := make([]*oplog.Message, 0, len(addTags))
+				if err := w.CreateItems(ctx, addTags, db.NewOplogMsgs(&tagOplogMsgs)); err != nil {
+					return fmt.Errorf("set tags: unable to add tags: %w", err)
+				}
+				msgs = append(msgs, tagOplogMsgs...)
+			}
+
+			metadata := oplog.Metadata{
+				"op-type":            []string{oplog.OpType_OP_TYPE_DELETE.String(), oplog.OpType_OP_TYPE_CREATE.String()},
+				"scope-id":           []string{scope.PublicId},
+				"scope-type":         []string{scope.Type},
+				"resource-public-id": []string{resourceId},
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+				return fmt.Errorf("set tags: unable to write oplog: %w", err)
+			}
+
+			// we need a new repo, that's using the same reader/writer as this TxHandler
+			txRepo := &Repository{
+				reader: reader,
+				writer: w,
+				kms:    r.kms,
+				// intentionally not setting the defaultLimit, so we'll get all
+				// the tags without a limit
+			}
+			currentTags, err = txRepo.ListTags(ctx, resourceId)
+			if err != nil {
+				return fmt.Errorf("set tags: unable to retrieve current tags after set: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set tags: error setting tags: %w", err)
+	}
+	return currentTags, totalRowsDeleted, nil
+}
+
+// ListTags returns the tags of a role or group (resourceId) ordered by key.
+func (r *Repository) ListTags(ctx context.Context, resourceId string, opt ...Option) ([]*Tag, error) {
+	if resourceId == "" {
+		return nil, fmt.Errorf("list tags: missing resource id: %w", db.ErrInvalidParameter)
+	}
+	var tags []*Tag
+	if err := r.reader.SearchWhere(ctx, &tags, "resource_id = ?", []interface{}{resourceId}, db.WithLimit(-1), db.WithOrder("key")); err != nil {
+		return nil, fmt.Errorf("list tags: unable to lookup tags: %w", err)
+	}
+	return tags, nil
+}
+
+// taggedResource returns the role or group for the resourceId, along with a
+// copy which has the version set for updating.
+func taggedResource(resourceId string, version uint32) (Resource, interface{}, error) {
+	switch {
+	case strings.HasPrefix(resourceId, RolePrefix+"_"):
+		role, updated := allocRole(), allocRole()
+		role.PublicId, updated.PublicId = resourceId, resourceId
+		updated.Version = version
+		return &role, &updated, nil
+	case strings.HasPrefix(resourceId, GroupPrefix+"_"):
+		grp, updated := allocGroup(), allocGroup()
+		grp.PublicId, updated.PublicId = resourceId, resourceId
+		updated.Version = version
+		return &grp, &updated, nil
+	default:
+		return nil, nil, fmt.Errorf("%s is not a role or group: %w", resourceId, db.ErrInvalidParameter)
+	}
+}
+
+// tagFilterClause adds the WithTagFilter option's tags to the where clause and
+// args of a listing of roles or groups.
+func tagFilterClause(where string, args []interface{}, opt ...Option) (string, []interface{}) {
+	opts := getOpts(opt...)
+	if len(opts.withTagFilter) == 0 {
+		return where, args
+	}
+	keys := make([]string, 0, len(opts.withTagFilter))
+	for k := range opts.withTagFilter {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	clauses := []string{"(" + where + ")"}
+	args = append(make([]interface{}, 0, len(args)+2*len(keys)), args...)
+	for _, k := range keys {
+		clauses = append(clauses, "public_id in (select resource_id from iam_tag where key = ? and value = ?)")
+		args = append(args, k, opts.withTagFilter[k])
+	}
+	return strings.Join(clauses, " and "), args
+}
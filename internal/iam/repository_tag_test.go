@@ -0,0 +1,146 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tagMap(tags []*Tag) map[string]string {
+	m := make(map[string]string, len(tags))
+	for _, t := range tags {
+		m[t.Key] = t.Value
+	}
+	return m
+}
+
+func TestRepository_SetTags(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+	org, proj := TestScopes(t, repo)
+
+	t.Run("role", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, proj.PublicId)
+
+		got, deleted, err := repo.SetTags(ctx, role.PublicId, role.Version, map[string]string{"team": "eng", "env": "prod"})
+		require.NoError(err)
+		assert.Equal(0, deleted)
+		assert.Equal(map[string]string{"team": "eng", "env": "prod"}, tagMap(got))
+		require.NoError(db.TestVerifyOplog(t, db.New(conn), role.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
+
+		// Replace one, remove one, add one
+		got, deleted, err = repo.SetTags(ctx, role.PublicId, role.Version+1, map[string]string{"team": "ops", "tier": "1"})
+		require.NoError(err)
+		assert.Equal(2, deleted)
+		assert.Equal(map[string]string{"team": "ops", "tier": "1"}, tagMap(got))
+
+		// No change doesn't bump the version
+		_, deleted, err = repo.SetTags(ctx, role.PublicId, role.Version+2, map[string]string{"team": "ops", "tier": "1"})
+		require.NoError(err)
+		assert.Equal(0, deleted)
+
+		got, deleted, err = repo.SetTags(ctx, role.PublicId, role.Version+2, map[string]string{})
+		require.NoError(err)
+		assert.Equal(2, deleted)
+		assert.Empty(got)
+	})
+	t.Run("group", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		grp := TestGroup(t, conn, org.PublicId)
+		got, _, err := repo.SetTags(ctx, grp.PublicId, grp.Version, map[string]string{"team": "eng"})
+		require.NoError(err)
+		assert.Equal(map[string]string{"team": "eng"}, tagMap(got))
+	})
+	t.Run("bad-version", func(t *testing.T) {
+		assert := assert.New(t)
+		role := TestRole(t, conn, proj.PublicId)
+		_, _, err := repo.SetTags(ctx, role.PublicId, role.Version+1, map[string]string{"team": "eng"})
+		assert.Error(err)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert := assert.New(t)
+		role := TestRole(t, conn, proj.PublicId)
+		_, _, err := repo.SetTags(ctx, role.PublicId, role.Version, nil)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, _, err = repo.SetTags(ctx, role.PublicId, 0, map[string]string{})
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, _, err = repo.SetTags(ctx, "", role.Version, map[string]string{})
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, _, err = repo.SetTags(ctx, proj.PublicId, 1, map[string]string{})
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, _, err = repo.SetTags(ctx, role.PublicId, role.Version, map[string]string{"": "eng"})
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+	})
+	t.Run("deleted-with-role", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, proj.PublicId)
+		_, _, err := repo.SetTags(ctx, role.PublicId, role.Version, map[string]string{"team": "eng"})
+		require.NoError(err)
+		_, err = repo.DeleteRole(ctx, role.PublicId)
+		require.NoError(err)
+		got, err := repo.ListTags(ctx, role.PublicId)
+		require.NoError(err)
+		assert.Empty(got)
+	})
+}
+
+func TestRepository_ListRoles_WithTagFilter(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+	org, proj := TestScopes(t, repo)
+
+	engProd := TestRole(t, conn, proj.PublicId)
+	_, _, err := repo.SetTags(ctx, engProd.PublicId, engProd.Version, map[string]string{"team": "eng", "env": "prod"})
+	require.NoError(err)
+	engDev := TestRole(t, conn, proj.PublicId)
+	_, _, err = repo.SetTags(ctx, engDev.PublicId, engDev.Version, map[string]string{"team": "eng", "env": "dev"})
+	require.NoError(err)
+	TestRole(t, conn, proj.PublicId)
+
+	ids := func(roles []*Role) []string {
+		var ids []string
+		for _, r := range roles {
+			ids = append(ids, r.PublicId)
+		}
+		return ids
+	}
+
+	got, err := repo.ListRoles(ctx, proj.PublicId, WithTagFilter(map[string]string{"team": "eng"}))
+	require.NoError(err)
+	assert.ElementsMatch([]string{engProd.PublicId, engDev.PublicId}, ids(got))
+
+	got, err = repo.ListRoles(ctx, proj.PublicId, WithTagFilter(map[string]string{"team": "eng", "env": "prod"}))
+	require.NoError(err)
+	assert.ElementsMatch([]string{engProd.PublicId}, ids(got))
+
+	got, err = repo.ListRoles(ctx, org.PublicId, WithRecursive(true), WithTagFilter(map[string]string{"env": "dev"}))
+	require.NoError(err)
+	assert.ElementsMatch([]string{engDev.PublicId}, ids(got))
+
+	got, err = repo.ListRoles(ctx, proj.PublicId, WithTagFilter(map[string]string{"team": "ops"}))
+	require.NoError(err)
+	assert.Empty(got)
+
+	grp := TestGroup(t, conn, proj.PublicId)
+	_, _, err = repo.SetTags(ctx, grp.PublicId, grp.Version, map[string]string{"team": "eng"})
+	require.NoError(err)
+	TestGroup(t, conn, proj.PublicId)
+	grps, err := repo.ListGroups(ctx, proj.PublicId, WithTagFilter(map[string]string{"team": "eng"}))
+	require.NoError(err)
+	require.Len(grps, 1)
+	assert.Equal(grp.PublicId, grps[0].PublicId)
+}
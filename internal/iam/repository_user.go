@@ -13,7 +13,8 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 )
 
-// CreateUser will create a user in the repository and return the written user
+// CreateUser will create a user in the repository and return the written
+// user. Supports the WithPublicId and WithDryRun options.
 func (r *Repository) CreateUser(ctx context.Context, user *User, opt ...Option) (*User, error) {
 	if user == nil {
 		return nil, fmt.Errorf("create user: missing user %w", db.ErrInvalidParameter)
@@ -21,7 +22,7 @@ func (r *Repository) CreateUser(ctx context.Context, user *User, opt ...Option)
 	if user.PublicId != "" {
 		return nil, fmt.Errorf("create user: public id is not empty %w", db.ErrInvalidParameter)
 	}
-	u := user.Clone().(*User)
+	u := user.Clone()
 
 	opts := getOpts(opt...)
 
@@ -38,7 +39,7 @@ func (r *Repository) CreateUser(ctx context.Context, user *User, opt ...Option)
 		u.PublicId = id
 	}
 
-	resource, err := r.create(ctx, u)
+	resource, err := r.create(ctx, u, opt...)
 	if err != nil {
 		if db.IsUniqueError(err) {
 			return nil, fmt.Errorf("create user: user %s already exists in org %s: %w", user.Name, user.ScopeId, err)
@@ -53,7 +54,7 @@ func (r *Repository) CreateUser(ctx context.Context, user *User, opt ...Option)
 // paths for fields that should be updated.  Fields will be set to NULL if the
 // field is a zero value and included in fieldMask. Name and Description are the
 // only updatable fields, if no updatable fields are included in the
-// fieldMaskPaths, then an error is returned.
+// fieldMaskPaths, then an error is returned. Supports the WithDryRun option.
 func (r *Repository) UpdateUser(ctx context.Context, user *User, version uint32, fieldMaskPaths []string, opt ...Option) (*User, []string, int, error) {
 	if user == nil {
 		return nil, nil, db.NoRowsAffected, fmt.Errorf("update user: missing user %w", db.ErrInvalidParameter)
@@ -81,8 +82,11 @@ func (r *Repository) UpdateUser(ctx context.Context, user *User, version uint32,
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, nil, db.NoRowsAffected, fmt.Errorf("update user: %w", db.ErrEmptyFieldMask)
 	}
+	if err := validateLengthsForWrite(user, r.maxLengths(), db.UpdateOp, db.WithFieldMaskPaths(dbMask)); err != nil {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("update user: %w", err)
+	}
 
-	u := user.Clone().(*User)
+	u := user.Clone()
 	metadata, err := r.stdMetadata(ctx, u)
 	if err != nil {
 		return nil, nil, db.NoRowsAffected, fmt.Errorf("update user: error getting metadata for update: %w", err)
@@ -116,7 +120,7 @@ func (r *Repository) UpdateUser(ctx context.Context, user *User, version uint32,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(reader db.Reader, w db.Writer) error {
-			returnedUser = u.Clone().(*User)
+			returnedUser = u.Clone()
 			rowsUpdated, err = w.Update(
 				ctx,
 				returnedUser,
@@ -143,16 +147,19 @@ func (r *Repository) UpdateUser(ctx context.Context, user *User, version uint32,
 			if err != nil {
 				return fmt.Errorf("unable to retrieve current account ids after update: %w", err)
 			}
+			if opts.withDryRun {
+				return errDryRun
+			}
 			return nil
 		},
 	)
-	if err != nil {
+	if err != nil && !errors.Is(err, errDryRun) {
 		if db.IsUniqueError(err) {
 			return nil, nil, db.NoRowsAffected, fmt.Errorf("update user: user %s already exists in org %s", user.Name, user.ScopeId)
 		}
 		return nil, nil, db.NoRowsAffected, fmt.Errorf("update user: %w for %s", err, user.PublicId)
 	}
-	return returnedUser, currentAccountIds, rowsUpdated, err
+	return returnedUser, currentAccountIds, rowsUpdated, nil
 }
 
 // LookupUser will look up a user and its associated account ids in the
@@ -177,7 +184,8 @@ func (r *Repository) LookupUser(ctx context.Context, userId string, opt ...Optio
 	return &user, currentAccountIds, nil
 }
 
-// DeleteUser will delete a user from the repository
+// DeleteUser will delete a user from the repository. Supports the WithDryRun
+// option.
 func (r *Repository) DeleteUser(ctx context.Context, withPublicId string, opt ...Option) (int, error) {
 	if withPublicId == "" {
 		return db.NoRowsAffected, fmt.Errorf("delete user: missing public id %w", db.ErrInvalidParameter)
@@ -187,23 +195,29 @@ func (r *Repository) DeleteUser(ctx context.Context, withPublicId string, opt ..
 	if err := r.reader.LookupByPublicId(ctx, &user); err != nil {
 		return db.NoRowsAffected, fmt.Errorf("delete user: failed %w for %s", err, withPublicId)
 	}
-	rowsDeleted, err := r.delete(ctx, &user)
+	rowsDeleted, err := r.delete(ctx, &user, opt...)
 	if err != nil {
 		return db.NoRowsAffected, fmt.Errorf("delete user: failed %w for %s", err, withPublicId)
 	}
+	r.invalidateGrantsCacheUsers(withPublicId)
 	return rowsDeleted, nil
 }
 
-// ListUsers in an org and supports the WithLimit option.
+// ListUsers in an org and supports the WithLimit, WithRecursive,
+// WithPageToken, WithNextPageToken and WithListedScopes options.
 func (r *Repository) ListUsers(ctx context.Context, withOrgId string, opt ...Option) ([]*User, error) {
 	if withOrgId == "" {
 		return nil, fmt.Errorf("list users: missing org id %w", db.ErrInvalidParameter)
 	}
 	var users []*User
-	err := r.list(ctx, &users, "scope_id = ?", []interface{}{withOrgId}, opt...)
+	where, args := scopeClause(ctx, withOrgId, opt...)
+	err := r.list(ctx, &users, where, args, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list users: %w", err)
 	}
+	if err := r.addListedScopes(ctx, users, opt...); err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
 	return users, nil
 }
 
@@ -223,6 +237,9 @@ func (r *Repository) LookupUserWithLogin(ctx context.Context, accountId string,
 		return nil, fmt.Errorf("lookup user with login: %w", err)
 	}
 	if u != nil {
+		if UserState(u.State) != UserStateActive {
+			return nil, fmt.Errorf("lookup user with login: %s is %s: %w", u.PublicId, u.State, ErrUserNotActive)
+		}
 		return u, nil
 	}
 	if !opts.withAutoVivify {
@@ -278,7 +295,7 @@ func (r *Repository) LookupUserWithLogin(ctx context.Context, accountId string,
 			msgs = append(msgs, &createMsg)
 
 			var updateMsg oplog.Message
-			updateAcct := acct.Clone().(*authAccount)
+			updateAcct := acct.Clone()
 			updateAcct.IamUserId = id
 			updatedRows, err := w.Update(ctx, updateAcct, []string{"IamUserId"}, nil, db.NewOplogMsg(&updateMsg))
 			if err != nil {
@@ -661,7 +678,7 @@ func associateUserWithAccounts(ctx context.Context, repoKms *kms.Kms, reader db.
 			"op-type":            []string{oplog.OpType_OP_TYPE_UPDATE.String()},
 		}
 		var updatedRows int
-		updatedAcct := aa.Clone().(*authAccount)
+		updatedAcct := aa.Clone()
 		updatedAcct.IamUserId = userId
 		updatedRows, err = writer.Update(ctx, updatedAcct, []string{"IamUserId"}, nil, db.WithOplog(oplogWrapper, metadata), db.WithWhere("iam_user_id is NULL or iam_user_id = ?", userId))
 		if err != nil {
@@ -723,7 +740,7 @@ func dissociateUserFromAccounts(ctx context.Context, repoKms *kms.Kms, reader db
 			"op-type":            []string{oplog.OpType_OP_TYPE_UPDATE.String()},
 		}
 		var updatedRows int
-		updatedAcct := aa.Clone().(*authAccount)
+		updatedAcct := aa.Clone()
 		updatedAcct.IamUserId = userId
 		// update IamUserId to null
 		updatedRows, err = writer.Update(ctx, updatedAcct, nil, []string{"IamUserId"}, db.WithOplog(oplogWrapper, metadata), db.WithWhere("iam_user_id is NULL or iam_user_id = ?", userId))
@@ -0,0 +1,267 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// UserState is the lifecycle state of a user.
+type UserState string
+
+const (
+	UserStateActive   UserState = "active"
+	UserStateInactive UserState = "inactive"
+	UserStateDeleted  UserState = "deleted"
+)
+
+// ErrUserNotActive is returned when an inactive or deleted user logs in.
+var ErrUserNotActive = errors.New("user is not active")
+
+// UserAccessReport is the access a user held before it was deactivated.
+type UserAccessReport struct {
+	UserId string
+	// RoleIds are the roles the user was a principal of.
+	RoleIds []string
+	// GroupIds are the groups the user was a member of.
+	GroupIds []string
+	// Grants are the grants which were in effect for the user, including the
+	// grants of its groups and of u_anon and u_auth.
+	Grants []*UserAccessGrant
+}
+
+// UserAccessGrant is a grant in a UserAccessReport.
+type UserAccessGrant struct {
+	RoleId  string
+	ScopeId string
+	Grant   string
+}
+
+// DeactivateUser sets the user's state to inactive. See SetUserState.
+func (r *Repository) DeactivateUser(ctx context.Context, userId string, version uint32, opt ...Option) (*User, *UserAccessReport, error) {
+	return r.SetUserState(ctx, userId, version, UserStateInactive, opt...)
+}
+
+// SetUserState sets the user's state and returns the updated user. When the
+// user is made inactive or deleted, it's removed as a principal from all of
+// its roles and as a member from all of its groups in the same transaction.
+// Those assignments aren't restored if the user is made active again, and a
+// deleted user can't be. The user's current db version must match the version
+// or an error will be returned.
+//
+// Supports the WithAccessReport option, which returns a report of the access
+// the user held before the change, and the WithDryRun option.
+func (r *Repository) SetUserState(ctx context.Context, userId string, version uint32, state UserState, opt ...Option) (*User, *UserAccessReport, error) {
+	if userId == "" {
+		return nil, nil, fmt.Errorf("set user state: missing user id: %w", db.ErrInvalidParameter)
+	}
+	if version == 0 {
+		return nil, nil, fmt.Errorf("set user state: version cannot be zero: %w", db.ErrInvalidParameter)
+	}
+	switch userId {
+	case "u_anon", "u_auth", "u_recovery":
+		return nil, nil, fmt.Errorf("set user state: %s is a built-in user: %w", userId, db.ErrInvalidParameter)
+	}
+	switch state {
+	case UserStateActive, UserStateInactive, UserStateDeleted:
+	default:
+		return nil, nil, fmt.Errorf("set user state: unknown state %q: %w", state, db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+
+	user := allocUser()
+	user.PublicId = userId
+	if err := r.reader.LookupByPublicId(ctx, &user); err != nil {
+		return nil, nil, fmt.Errorf("set user state: failed %w for %s", err, userId)
+	}
+	if UserState(user.State) == UserStateDeleted && state != UserStateDeleted {
+		return nil, nil, fmt.Errorf("set user state: %s is deleted: %w", userId, db.ErrInvalidParameter)
+	}
+	metadata, err := r.stdMetadata(ctx, &user)
+	if err != nil {
+		return nil, nil, fmt.Errorf("set user state: error getting metadata: %w", err)
+	}
+	metadata["op-type"] = []string{oplog.OpType_OP_TYPE_UPDATE.String()}
+	userOplogWrapper, err := r.kms.GetWrapper(ctx, user.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, nil, fmt.Errorf("set user state: unable to get oplog wrapper: %w", err)
+	}
+
+	var updatedUser *User
+	var report *UserAccessReport
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			// we need a new repo, that's using the same reader/writer as this TxHandler
+			txRepo := &Repository{
+				reader: reader,
+				writer: w,
+				kms:    r.kms,
+			}
+			var userRoles []*UserRole
+			if err := reader.SearchWhere(ctx, &userRoles, "principal_id = ?", []interface{}{userId}); err != nil {
+				return fmt.Errorf("unable to list user roles: %w", err)
+			}
+			var members []*GroupMemberUser
+			if err := reader.SearchWhere(ctx, &members, "member_id = ?", []interface{}{userId}); err != nil {
+				return fmt.Errorf("unable to list group memberships: %w", err)
+			}
+			if opts.withAccessReport {
+				report = &UserAccessReport{UserId: userId}
+				for _, ur := range userRoles {
+					report.RoleIds = append(report.RoleIds, ur.RoleId)
+				}
+				for _, m := range members {
+					report.GroupIds = append(report.GroupIds, m.GroupId)
+				}
+				grants, err := txRepo.roleGrantsForUser(ctx, userId, "")
+				if err != nil {
+					return fmt.Errorf("unable to list grants: %w", err)
+				}
+				for _, g := range grants {
+					report.Grants = append(report.Grants, &UserAccessGrant{RoleId: g.RoleId, ScopeId: g.ScopeId, Grant: g.Grant})
+				}
+			}
+
+			if state != UserStateActive {
+				for _, ur := range userRoles {
+					if err := txRepo.removeUserFromRole(ctx, w, ur); err != nil {
+						return err
+					}
+				}
+				for _, m := range members {
+					if err := txRepo.removeUserFromGroup(ctx, w, m); err != nil {
+						return err
+					}
+				}
+			}
+
+			u := allocUser()
+			u.PublicId = userId
+			u.State = string(state)
+			updatedUser = &u
+			rowsUpdated, err := w.Update(ctx, updatedUser, []string{"State"}, nil, db.WithOplog(userOplogWrapper, metadata), db.WithVersion(&version))
+			if err != nil {
+				return fmt.Errorf("unable to update user: %w", err)
+			}
+			if rowsUpdated != 1 {
+				return fmt.Errorf("updated user and %d rows updated", rowsUpdated)
+			}
+			if opts.withDryRun {
+				return errDryRun
+			}
+			return nil
+		},
+	)
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, nil, fmt.Errorf("set user state: %w for %s", err, userId)
+	}
+	r.invalidateGrantsCacheUsers(userId)
+	return updatedUser, report, nil
+}
+
+// removeUserFromRole deletes the user role, within the transaction of w, and
+// bumps the version of its role.
+func (r *Repository) removeUserFromRole(ctx context.Context, w db.Writer, ur *UserRole) error {
+	role := allocRole()
+	role.PublicId = ur.RoleId
+	if err := r.reader.LookupByPublicId(ctx, &role); err != nil {
+		return fmt.Errorf("unable to look up role %s: %w", ur.RoleId, err)
+	}
+	scope, err := role.GetScope(ctx, r.reader)
+	if err != nil {
+		return fmt.Errorf("unable to get role %s scope: %w", ur.RoleId, err)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return fmt.Errorf("unable to get oplog wrapper: %w", err)
+	}
+	roleTicket, err := w.GetTicket(&role)
+	if err != nil {
+		return fmt.Errorf("unable to get ticket: %w", err)
+	}
+	msgs := make([]*oplog.Message, 0, 2)
+	updatedRole := allocRole()
+	updatedRole.PublicId = role.PublicId
+	updatedRole.Version = role.Version + 1
+	var roleOplogMsg oplog.Message
+	rowsUpdated, err := w.Update(ctx, &updatedRole, []string{"Version"}, nil, db.NewOplogMsg(&roleOplogMsg), db.WithVersion(&role.Version))
+	if err != nil {
+		return fmt.Errorf("unable to update role %s version: %w", ur.RoleId, err)
+	}
+	if rowsUpdated != 1 {
+		return fmt.Errorf("updated role %s and %d rows updated", ur.RoleId, rowsUpdated)
+	}
+	msgs = append(msgs, &roleOplogMsg)
+	var deleteMsg oplog.Message
+	if _, err := w.Delete(ctx, ur, db.NewOplogMsg(&deleteMsg)); err != nil {
+		return fmt.Errorf("unable to delete user role: %w", err)
+	}
+	msgs = append(msgs, &deleteMsg)
+	metadata := oplog.Metadata{
+		"op-type":            []string{oplog.OpType_OP_TYPE_DELETE.String()},
+		"scope-id":           []string{scope.PublicId},
+		"scope-type":         []string{scope.Type},
+		"resource-public-id": []string{role.PublicId},
+	}
+	if err := w.WriteOplogEntryWith(ctx, oplogWrapper, roleTicket, metadata, msgs); err != nil {
+		return fmt.Errorf("unable to write oplog: %w", err)
+	}
+	return nil
+}
+
+// removeUserFromGroup deletes the group member, within the transaction of w,
+// and bumps the version of its group.
+func (r *Repository) removeUserFromGroup(ctx context.Context, w db.Writer, m *GroupMemberUser) error {
+	group := allocGroup()
+	group.PublicId = m.GroupId
+	if err := r.reader.LookupByPublicId(ctx, &group); err != nil {
+		return fmt.Errorf("unable to look up group %s: %w", m.GroupId, err)
+	}
+	scope, err := group.GetScope(ctx, r.reader)
+	if err != nil {
+		return fmt.Errorf("unable to get group %s scope: %w", m.GroupId, err)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return fmt.Errorf("unable to get oplog wrapper: %w", err)
+	}
+	groupTicket, err := w.GetTicket(&group)
+	if err != nil {
+		return fmt.Errorf("unable to get ticket: %w", err)
+	}
+	msgs := make([]*oplog.Message, 0, 2)
+	updatedGroup := allocGroup()
+	updatedGroup.PublicId = group.PublicId
+	updatedGroup.Version = group.Version + 1
+	var groupOplogMsg oplog.Message
+	rowsUpdated, err := w.Update(ctx, &updatedGroup, []string{"Version"}, nil, db.NewOplogMsg(&groupOplogMsg), db.WithVersion(&group.Version))
+	if err != nil {
+		return fmt.Errorf("unable to update group %s version: %w", m.GroupId, err)
+	}
+	if rowsUpdated != 1 {
+		return fmt.Errorf("updated group %s and %d rows updated", m.GroupId, rowsUpdated)
+	}
+	msgs = append(msgs, &groupOplogMsg)
+	var deleteMsg oplog.Message
+	if _, err := w.Delete(ctx, m, db.NewOplogMsg(&deleteMsg)); err != nil {
+		return fmt.Errorf("unable to delete group member: %w", err)
+	}
+	msgs = append(msgs, &deleteMsg)
+	metadata := oplog.Metadata{
+		"op-type":            []string{oplog.OpType_OP_TYPE_DELETE.String()},
+		"scope-id":           []string{scope.PublicId},
+		"scope-type":         []string{scope.Type},
+		"resource-public-id": []string{group.PublicId},
+	}
+	if err := w.WriteOplogEntryWith(ctx, oplogWrapper, groupTicket, metadata, msgs); err != nil {
+		return fmt.Errorf("unable to write oplog: %w", err)
+	}
+	return nil
+}
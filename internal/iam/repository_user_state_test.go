@@ -0,0 +1,112 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_SetUserState(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+
+	t.Run("deactivate", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		user := TestUser(t, repo, org.PublicId)
+		assert.Equal(string(UserStateActive), user.State)
+		role := TestRole(t, conn, proj.PublicId)
+		TestRoleGrant(t, conn, role.PublicId, "id=*;type=*;actions=read")
+		TestUserRole(t, conn, role.PublicId, user.PublicId)
+		grp := TestGroup(t, conn, org.PublicId)
+		TestGroupMember(t, conn, grp.PublicId, user.PublicId)
+
+		updated, report, err := repo.DeactivateUser(ctx, user.PublicId, user.Version, WithAccessReport(true))
+		require.NoError(err)
+		assert.Equal(string(UserStateInactive), updated.State)
+		assert.Equal(user.Version+1, updated.Version)
+		require.NotNil(report)
+		assert.Equal([]string{role.PublicId}, report.RoleIds)
+		assert.Equal([]string{grp.PublicId}, report.GroupIds)
+		var found bool
+		for _, g := range report.Grants {
+			if g.RoleId == role.PublicId {
+				found = true
+				assert.Equal("id=*;type=*;actions=read", g.Grant)
+			}
+		}
+		assert.True(found)
+
+		principals, err := repo.ListPrincipalRoles(ctx, role.PublicId)
+		require.NoError(err)
+		assert.Empty(principals)
+		members, err := repo.ListGroupMembers(ctx, grp.PublicId)
+		require.NoError(err)
+		assert.Empty(members)
+
+		// Inactive users have no grants of their own and can't be assigned
+		grants, err := repo.roleGrantsForUser(ctx, user.PublicId, "")
+		require.NoError(err)
+		anonGrants, err := repo.roleGrantsForUser(ctx, "u_anon", "")
+		require.NoError(err)
+		assert.ElementsMatch(anonGrants, grants)
+		_, err = repo.AddPrincipalRoles(ctx, role.PublicId, role.Version+1, []string{user.PublicId})
+		assert.Error(err)
+		_, err = repo.AddGroupMembers(ctx, grp.PublicId, grp.Version+1, []string{user.PublicId})
+		assert.Error(err)
+	})
+	t.Run("dry-run", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		user := TestUser(t, repo, org.PublicId)
+		role := TestRole(t, conn, proj.PublicId)
+		TestUserRole(t, conn, role.PublicId, user.PublicId)
+
+		updated, report, err := repo.DeactivateUser(ctx, user.PublicId, user.Version, WithAccessReport(true), WithDryRun(true))
+		require.NoError(err)
+		assert.Equal(string(UserStateInactive), updated.State)
+		assert.Equal([]string{role.PublicId}, report.RoleIds)
+
+		found, _, err := repo.LookupUser(ctx, user.PublicId)
+		require.NoError(err)
+		assert.Equal(string(UserStateActive), found.State)
+		principals, err := repo.ListPrincipalRoles(ctx, role.PublicId)
+		require.NoError(err)
+		assert.Len(principals, 1)
+	})
+	t.Run("lifecycle", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		user := TestUser(t, repo, org.PublicId)
+		updated, report, err := repo.SetUserState(ctx, user.PublicId, user.Version, UserStateInactive)
+		require.NoError(err)
+		assert.Nil(report)
+		updated, _, err = repo.SetUserState(ctx, user.PublicId, updated.Version, UserStateActive)
+		require.NoError(err)
+		assert.Equal(string(UserStateActive), updated.State)
+		updated, _, err = repo.SetUserState(ctx, user.PublicId, updated.Version, UserStateDeleted)
+		require.NoError(err)
+		assert.Equal(string(UserStateDeleted), updated.State)
+
+		// Deleted is final
+		_, _, err = repo.SetUserState(ctx, user.PublicId, updated.Version, UserStateActive)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert := assert.New(t)
+		user := TestUser(t, repo, org.PublicId)
+		_, _, err := repo.SetUserState(ctx, user.PublicId, user.Version, UserState("suspended"))
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, _, err = repo.SetUserState(ctx, user.PublicId, 0, UserStateInactive)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, _, err = repo.DeactivateUser(ctx, "u_anon", 1)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, _, err = repo.DeactivateUser(ctx, user.PublicId, user.Version+1)
+		assert.Error(err)
+	})
+}
@@ -331,7 +331,7 @@ func TestRepository_UpdateUser(t *testing.T) {
 			if tt.directUpdate {
 				u := updateUser.Clone()
 				var resource interface{}
-				resource, updatedRows, err = repo.update(context.Background(), u.(*User), 1, tt.args.fieldMaskPaths, nil, tt.args.opt...)
+				resource, updatedRows, err = repo.update(context.Background(), u, 1, tt.args.fieldMaskPaths, nil, tt.args.opt...)
 				if err == nil {
 					userAfterUpdate = resource.(*User)
 				}
@@ -35,8 +35,11 @@ type Resource interface {
 	Actions() map[string]action.Type
 }
 
+// Cloneable is implemented by the resources the repository's create, update
+// and delete write, which clone the resource for each try of their
+// transaction. Each resource also has a Clone method returning its own type.
 type Cloneable interface {
-	Clone() interface{}
+	cloneResource() Resource
 }
 
 // ResourceWithScope defines an interface for Resources that have a scope
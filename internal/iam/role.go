@@ -29,18 +29,24 @@ var _ Cloneable = (*Role)(nil)
 var _ db.VetForWriter = (*Role)(nil)
 
 // NewRole creates a new in memory role with a scope (project/org)
-// allowed options include: withDescripion, WithName, withGrantScopeId.
+// allowed options include: withDescripion, WithName, withGrantScopeId,
+// WithExpirationTime.
 func NewRole(scopeId string, opt ...Option) (*Role, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("new role: missing scope id %w", db.ErrInvalidParameter)
 	}
 	opts := getOpts(opt...)
+	expiration, err := expirationTimestamp(opts.withExpirationTime)
+	if err != nil {
+		return nil, fmt.Errorf("new role: %w", err)
+	}
 	r := &Role{
 		Role: &store.Role{
-			ScopeId:      scopeId,
-			Name:         opts.withName,
-			Description:  opts.withDescription,
-			GrantScopeId: opts.withGrantScopeId,
+			ScopeId:        scopeId,
+			Name:           opts.withName,
+			Description:    opts.withDescription,
+			GrantScopeId:   opts.withGrantScopeId,
+			ExpirationTime: expiration,
 		},
 	}
 	return r, nil
@@ -53,13 +59,18 @@ func allocRole() Role {
 }
 
 // Clone creates a clone of the Role.
-func (r *Role) Clone() interface{} {
+func (r *Role) Clone() *Role {
 	cp := proto.Clone(r.Role)
 	return &Role{
 		Role: cp.(*store.Role),
 	}
 }
 
+// cloneResource implements Cloneable.
+func (r *Role) cloneResource() Resource {
+	return r.Clone()
+}
+
 // VetForWrite implements db.VetForWrite() interface.
 func (role *Role) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
 	if role.PublicId == "" {
@@ -68,6 +79,17 @@ func (role *Role) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType
 	if err := validateScopeForWrite(ctx, r, role, opType, opt...); err != nil {
 		return err
 	}
+	if err := validateLengthsForWrite(role, DefaultLengthLimits(), opType, opt...); err != nil {
+		return err
+	}
+	if err := vetWriteHooks(ctx, opType, &WriteHookRequest{
+		RoleId:       role.PublicId,
+		ScopeId:      role.ScopeId,
+		GrantScopeId: role.GrantScopeId,
+		RoleName:     role.Name,
+	}); err != nil {
+		return fmt.Errorf("vet role for writing: %w", err)
+	}
 	return nil
 }
 
@@ -92,6 +114,11 @@ func (*Role) Actions() map[string]action.Type {
 	ret[action.AddPrincipals.String()] = action.AddPrincipals
 	ret[action.RemovePrincipals.String()] = action.RemovePrincipals
 	ret[action.SetPrincipals.String()] = action.SetPrincipals
+	ret[action.ActivateEmergency.String()] = action.ActivateEmergency
+	ret[action.RevertEmergency.String()] = action.RevertEmergency
+	ret[action.Diff.String()] = action.Diff
+	ret[action.Archive.String()] = action.Archive
+	ret[action.Restore.String()] = action.Restore
 	return ret
 }
 
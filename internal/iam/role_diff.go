@@ -0,0 +1,175 @@
+package iam
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+)
+
+// RoleDiff describes the changes a write made, or would make, to a role: the
+// fields it changed and the grants and principals it added and removed. Grants
+// are in canonical form, principals are user and group ids, and all of them
+// are sorted.
+//
+// A RoleDiff can also compare two roles, in which case FromRoleId is the role
+// compared against, or a role at two points in time, in which case FromTime
+// and ToTime are set.
+type RoleDiff struct {
+	RoleId            string       `json:"role_id"`
+	FromRoleId        string       `json:"from_role_id,omitempty"`
+	FromTime          *time.Time   `json:"from_time,omitempty"`
+	ToTime            *time.Time   `json:"to_time,omitempty"`
+	Fields            []*FieldDiff `json:"fields,omitempty"`
+	GrantsAdded       []string     `json:"grants_added,omitempty"`
+	GrantsRemoved     []string     `json:"grants_removed,omitempty"`
+	PrincipalsAdded   []string     `json:"principals_added,omitempty"`
+	PrincipalsRemoved []string     `json:"principals_removed,omitempty"`
+}
+
+// FieldDiff is a field of a resource whose value was changed.
+type FieldDiff struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Empty returns true if the diff has no changes.
+func (d *RoleDiff) Empty() bool {
+	return len(d.Fields) == 0 &&
+		len(d.GrantsAdded) == 0 && len(d.GrantsRemoved) == 0 &&
+		len(d.PrincipalsAdded) == 0 && len(d.PrincipalsRemoved) == 0
+}
+
+// Summary returns a line describing each of the changes, for showing to
+// people.
+func (d *RoleDiff) Summary() []string {
+	var lines []string
+	for _, f := range d.Fields {
+		lines = append(lines, fmt.Sprintf("%s changed from %q to %q", f.Field, f.Before, f.After))
+	}
+	for _, g := range d.GrantsAdded {
+		lines = append(lines, fmt.Sprintf("grant %q added", g))
+	}
+	for _, g := range d.GrantsRemoved {
+		lines = append(lines, fmt.Sprintf("grant %q removed", g))
+	}
+	for _, p := range d.PrincipalsAdded {
+		lines = append(lines, fmt.Sprintf("principal %s added", p))
+	}
+	for _, p := range d.PrincipalsRemoved {
+		lines = append(lines, fmt.Sprintf("principal %s removed", p))
+	}
+	return lines
+}
+
+// String implements fmt.Stringer.
+func (d *RoleDiff) String() string {
+	subject := fmt.Sprintf("role %s", d.RoleId)
+	switch {
+	case d.FromRoleId != "":
+		subject = fmt.Sprintf("role %s to role %s", d.FromRoleId, d.RoleId)
+	case d.FromTime != nil && d.ToTime != nil:
+		subject = fmt.Sprintf("role %s from %s to %s", d.RoleId, d.FromTime.UTC().Format(time.RFC3339), d.ToTime.UTC().Format(time.RFC3339))
+	}
+	if d.Empty() {
+		return fmt.Sprintf("%s: no changes", subject)
+	}
+	return fmt.Sprintf("%s: %s", subject, strings.Join(d.Summary(), "; "))
+}
+
+// roleFieldsDiff returns the diff of the updatable fields of a role from
+// before to after.
+func roleFieldsDiff(before, after *Role) *RoleDiff {
+	d := &RoleDiff{RoleId: after.PublicId}
+	for _, f := range []struct {
+		field         string
+		before, after string
+	}{
+		{"name", before.Name, after.Name},
+		{"description", before.Description, after.Description},
+		{"grant_scope_id", before.GrantScopeId, after.GrantScopeId},
+		{"expiration_time", timestampString(before.ExpirationTime), timestampString(after.ExpirationTime)},
+	} {
+		if f.before != f.after {
+			d.Fields = append(d.Fields, &FieldDiff{Field: f.field, Before: f.before, After: f.after})
+		}
+	}
+	return d
+}
+
+// timestampString returns the timestamp in RFC 3339 format, or an empty string
+// if it's nil.
+func timestampString(ts *timestamp.Timestamp) string {
+	if ts == nil || ts.Timestamp == nil {
+		return ""
+	}
+	return ts.Timestamp.AsTime().UTC().Format(time.RFC3339Nano)
+}
+
+// setDiff returns the sorted members of after which aren't in before, and of
+// before which aren't in after.
+func setDiff(before, after map[string]bool) (added, removed []string) {
+	for k := range after {
+		if !before[k] {
+			added = append(added, k)
+		}
+	}
+	for k := range before {
+		if !after[k] {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// roleGrantsDiff returns the diff of a role's grants from the role grants
+// added and removed.
+func roleGrantsDiff(roleId string, added, removed []interface{}) *RoleDiff {
+	grants := func(roleGrants []interface{}) map[string]bool {
+		m := make(map[string]bool, len(roleGrants))
+		for _, rg := range roleGrants {
+			m[rg.(*RoleGrant).CanonicalGrant] = true
+		}
+		return m
+	}
+	d := &RoleDiff{RoleId: roleId}
+	d.GrantsAdded, _ = setDiff(nil, grants(added))
+	_, d.GrantsRemoved = setDiff(grants(removed), nil)
+	return d
+}
+
+// rolePrincipalsDiff returns the diff of a role's principals from the user
+// and group roles added and removed.
+func rolePrincipalsDiff(roleId string, toSet *principalSet) *RoleDiff {
+	ids := func(principalRoles ...[]interface{}) []string {
+		var ids []string
+		for _, prs := range principalRoles {
+			for _, pr := range prs {
+				ids = append(ids, pr.(interface{ GetPrincipalId() string }).GetPrincipalId())
+			}
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	return &RoleDiff{
+		RoleId:            roleId,
+		PrincipalsAdded:   ids(toSet.addUserRoles, toSet.addGroupRoles),
+		PrincipalsRemoved: ids(toSet.deleteUserRoles, toSet.deleteGroupRoles),
+	}
+}
+
+// observeRoleChange returns the diff through the WithRoleDiff option, and
+// passes it to the repository's role audit sink if the change was written.
+func (r *Repository) observeRoleChange(d *RoleDiff, written bool, opt ...Option) {
+	if out := getOpts(opt...).withRoleDiff; out != nil {
+		*out = *d
+	}
+	if written && r.roleAudit != nil && !d.Empty() {
+		r.roleAudit(d)
+	}
+}
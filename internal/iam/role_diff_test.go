@@ -0,0 +1,131 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleDiff_Summary(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	d := &RoleDiff{RoleId: "r_1234567890"}
+	assert.True(d.Empty())
+	assert.Equal("role r_1234567890: no changes", d.String())
+
+	d.Fields = []*FieldDiff{{Field: "name", Before: "old", After: "new"}}
+	d.GrantsAdded = []string{"id=*;type=role;actions=read"}
+	d.PrincipalsRemoved = []string{"u_1234567890"}
+	assert.False(d.Empty())
+	assert.Equal([]string{
+		`name changed from "old" to "new"`,
+		`grant "id=*;type=role;actions=read" added`,
+		"principal u_1234567890 removed",
+	}, d.Summary())
+	assert.Equal(`role r_1234567890: name changed from "old" to "new"; grant "id=*;type=role;actions=read" added; principal u_1234567890 removed`, d.String())
+}
+
+func TestRepository_RoleDiff(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	var audited []*RoleDiff
+	repo := TestRepo(t, conn, wrapper, WithRoleAudit(func(d *RoleDiff) { audited = append(audited, d) }))
+	ctx := context.Background()
+
+	org, _ := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	role := TestRole(t, conn, org.PublicId, WithName("before"))
+	user := TestUser(t, repo, org.PublicId)
+	grp := TestGroup(t, conn, org.PublicId)
+	version := role.Version
+
+	var diff RoleDiff
+	role.Name, role.Description = "after", "described"
+	_, _, _, _, err := repo.UpdateRole(ctx, role, version, []string{"Name", "Description"}, WithRoleDiff(&diff))
+	require.NoError(err)
+	version++
+	assert.Equal(RoleDiff{
+		RoleId: role.PublicId,
+		Fields: []*FieldDiff{
+			{Field: "name", Before: "before", After: "after"},
+			{Field: "description", Before: "", After: "described"},
+		},
+	}, diff)
+
+	_, _, _, err = repo.SetRoleGrants(ctx, role.PublicId, version, []string{"id=*;type=role;actions=read", "id=*;type=group;actions=read"}, WithRoleDiff(&diff))
+	require.NoError(err)
+	version++
+	assert.Equal(RoleDiff{
+		RoleId:      role.PublicId,
+		GrantsAdded: []string{"id=*;type=group;actions=read", "id=*;type=role;actions=read"},
+	}, diff)
+
+	// A dry run reports the changes it would make, without auditing them
+	diff = RoleDiff{}
+	_, _, _, err = repo.SetRoleGrants(ctx, role.PublicId, version, []string{"id=*;type=role;actions=read"}, WithDryRun(true), WithRoleDiff(&diff))
+	require.NoError(err)
+	assert.Equal([]string{"id=*;type=group;actions=read"}, diff.GrantsRemoved)
+
+	_, _, err = repo.SetPrincipalRoles(ctx, role.PublicId, version, []string{user.PublicId, grp.PublicId}, WithRoleDiff(&diff))
+	require.NoError(err)
+	version++
+	assert.Equal(RoleDiff{
+		RoleId:          role.PublicId,
+		PrincipalsAdded: sortedIds(user.PublicId, grp.PublicId),
+	}, diff)
+	_, _, err = repo.SetPrincipalRoles(ctx, role.PublicId, version, []string{grp.PublicId}, WithRoleDiff(&diff))
+	require.NoError(err)
+	version++
+	assert.Equal([]string{user.PublicId}, diff.PrincipalsRemoved)
+
+	// Setting what's already set changes nothing
+	_, _, err = repo.SetPrincipalRoles(ctx, role.PublicId, version, []string{grp.PublicId}, WithRoleDiff(&diff))
+	require.NoError(err)
+	assert.True(diff.Empty())
+
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, version, []string{"id=*;type=user;actions=read"}, WithRoleDiff(&diff))
+	require.NoError(err)
+	version++
+	assert.Equal(RoleDiff{
+		RoleId:      role.PublicId,
+		GrantsAdded: []string{"id=*;type=user;actions=read"},
+	}, diff)
+
+	// Only the grants the role had are reported as deleted
+	_, err = repo.DeleteRoleGrants(ctx, role.PublicId, version, []string{"id=*;type=user;actions=read", "id=*;type=scope;actions=read"}, WithRoleDiff(&diff))
+	require.NoError(err)
+	version++
+	assert.Equal(RoleDiff{
+		RoleId:        role.PublicId,
+		GrantsRemoved: []string{"id=*;type=user;actions=read"},
+	}, diff)
+
+	_, err = repo.AddPrincipalRoles(ctx, role.PublicId, version, []string{user.PublicId}, WithRoleDiff(&diff))
+	require.NoError(err)
+	version++
+	assert.Equal(RoleDiff{
+		RoleId:          role.PublicId,
+		PrincipalsAdded: []string{user.PublicId},
+	}, diff)
+
+	_, err = repo.DeletePrincipalRoles(ctx, role.PublicId, version, []string{user.PublicId, grp.PublicId}, WithRoleDiff(&diff))
+	require.NoError(err)
+	assert.Equal(RoleDiff{
+		RoleId:            role.PublicId,
+		PrincipalsRemoved: sortedIds(user.PublicId, grp.PublicId),
+	}, diff)
+
+	require.Len(audited, 8)
+	assert.Len(audited[0].Fields, 2)
+	assert.Len(audited[1].GrantsAdded, 2)
+	assert.Len(audited[2].PrincipalsAdded, 2)
+	assert.Len(audited[3].PrincipalsRemoved, 1)
+	assert.Len(audited[4].GrantsAdded, 1)
+	assert.Len(audited[5].GrantsRemoved, 1)
+	assert.Len(audited[6].PrincipalsAdded, 1)
+	assert.Len(audited[7].PrincipalsRemoved, 2)
+}
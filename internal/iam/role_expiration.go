@@ -0,0 +1,172 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+)
+
+// RoleArchiveRetention is how long an archived role can be restored. Roles
+// archived for longer are deleted by PurgeArchivedRoles.
+var RoleArchiveRetention = 30 * 24 * time.Hour
+
+// expirationTimestamp returns the timestamp of the WithExpirationTime option,
+// which is nil when the option isn't set.
+func expirationTimestamp(t time.Time) (*timestamp.Timestamp, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+	// The database stores microseconds, so truncate to them here to keep the
+	// value written the same as the value read back.
+	ts, err := ptypes.TimestampProto(t.Truncate(time.Microsecond))
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiration time: %w", err)
+	}
+	return &timestamp.Timestamp{Timestamp: ts}, nil
+}
+
+// ArchiveExpiredRoles archives the roles whose expiration time has passed and
+// returns the number of roles archived. Expired roles aren't considered when
+// resolving grants whether or not they've been archived yet; archiving them
+// takes them out of role listings and starts their RoleArchiveRetention.
+// Each role is updated through the oplog.
+func (r *Repository) ArchiveExpiredRoles(ctx context.Context) (int, error) {
+	now := r.now()
+	var roles []*Role
+	if err := r.list(ctx, &roles, "expiration_time <= ? and archive_time is null", []interface{}{now}, WithLimit(-1)); err != nil {
+		return db.NoRowsAffected, fmt.Errorf("archive expired roles: unable to list roles: %w", err)
+	}
+	archiveTime, err := expirationTimestamp(now)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("archive expired roles: %w", err)
+	}
+	var totalRowsUpdated int
+	var archivedRoleIds []string
+	for _, role := range roles {
+		c := role.Clone()
+		c.ArchiveTime = archiveTime
+		_, rowsUpdated, err := r.update(ctx, c, role.Version, []string{"ArchiveTime"}, nil)
+		if err != nil {
+			return totalRowsUpdated, fmt.Errorf("archive expired roles: unable to archive role %s: %w", role.PublicId, err)
+		}
+		totalRowsUpdated += rowsUpdated
+		archivedRoleIds = append(archivedRoleIds, role.PublicId)
+	}
+	if totalRowsUpdated > 0 {
+		r.invalidateGrantsCacheForRoles(ctx, archivedRoleIds...)
+	}
+	return totalRowsUpdated, nil
+}
+
+// ArchiveRole archives a role before it expires, so it's no longer
+// considered when resolving grants, and returns the archived role. A role
+// whose expiration time is unset or still to come expires when it's archived.
+// The role can be restored within RoleArchiveRetention, like a role archived
+// after expiring.
+func (r *Repository) ArchiveRole(ctx context.Context, roleId string, version uint32, opt ...Option) (*Role, int, error) {
+	if roleId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("archive role: missing role id: %w", db.ErrInvalidParameter)
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("archive role: missing version: %w", db.ErrInvalidParameter)
+	}
+	now := r.now()
+	archiveTime, err := expirationTimestamp(now)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("archive role: %w", err)
+	}
+	role := allocRole()
+	role.PublicId = roleId
+	if err := r.reader.LookupByPublicId(ctx, &role); err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("archive role: unable to lookup role %s: %w", roleId, err)
+	}
+	if role.ArchiveTime != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("archive role: role %s is already archived: %w", roleId, db.ErrInvalidParameter)
+	}
+	c := role.Clone()
+	c.ArchiveTime = archiveTime
+	fieldMask := []string{"ArchiveTime"}
+	if c.ExpirationTime == nil || c.ExpirationTime.Timestamp.AsTime().After(now) {
+		c.ExpirationTime = archiveTime
+		fieldMask = append(fieldMask, "ExpirationTime")
+	}
+	resource, rowsUpdated, err := r.update(ctx, c, version, fieldMask, nil, opt...)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("archive role: %w for %s", err, roleId)
+	}
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	return resource.(*Role), rowsUpdated, nil
+}
+
+// RestoreRole restores an archived role, so it's considered when resolving
+// grants again, and returns the restored role. A role can only be restored
+// within RoleArchiveRetention of being archived. The restored role expires at
+// the time given by the WithExpirationTime option, which must be in the
+// future, or never if it isn't set.
+func (r *Repository) RestoreRole(ctx context.Context, roleId string, version uint32, opt ...Option) (*Role, int, error) {
+	if roleId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("restore role: missing role id: %w", db.ErrInvalidParameter)
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("restore role: missing version: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	now := r.now()
+	if !opts.withExpirationTime.IsZero() && !opts.withExpirationTime.After(now) {
+		return nil, db.NoRowsAffected, fmt.Errorf("restore role: expiration time must be in the future: %w", db.ErrInvalidParameter)
+	}
+	expiration, err := expirationTimestamp(opts.withExpirationTime)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("restore role: %w", err)
+	}
+	role := allocRole()
+	role.PublicId = roleId
+	if err := r.reader.LookupByPublicId(ctx, &role); err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("restore role: unable to lookup role %s: %w", roleId, err)
+	}
+	if role.ArchiveTime == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("restore role: role %s is not archived: %w", roleId, db.ErrInvalidParameter)
+	}
+	if now.Sub(role.ArchiveTime.Timestamp.AsTime()) > RoleArchiveRetention {
+		return nil, db.NoRowsAffected, fmt.Errorf("restore role: role %s was archived more than %s ago: %w", roleId, RoleArchiveRetention, db.ErrInvalidParameter)
+	}
+	c := role.Clone()
+	c.ArchiveTime = nil
+	c.ExpirationTime = expiration
+	nullFields := []string{"ArchiveTime"}
+	var fieldMask []string
+	if expiration == nil {
+		nullFields = append(nullFields, "ExpirationTime")
+	} else {
+		fieldMask = append(fieldMask, "ExpirationTime")
+	}
+	resource, rowsUpdated, err := r.update(ctx, c, version, fieldMask, nullFields, opt...)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("restore role: %w for %s", err, roleId)
+	}
+	r.invalidateGrantsCacheForRoles(ctx, roleId)
+	return resource.(*Role), rowsUpdated, nil
+}
+
+// PurgeArchivedRoles deletes the roles which have been archived for longer
+// than RoleArchiveRetention and returns the number of roles deleted. Each
+// role is deleted through the oplog.
+func (r *Repository) PurgeArchivedRoles(ctx context.Context) (int, error) {
+	var roles []*Role
+	if err := r.list(ctx, &roles, "archive_time <= ?", []interface{}{r.now().Add(-RoleArchiveRetention)}, WithLimit(-1)); err != nil {
+		return db.NoRowsAffected, fmt.Errorf("purge archived roles: unable to list roles: %w", err)
+	}
+	var totalRowsDeleted int
+	for _, role := range roles {
+		rowsDeleted, err := r.delete(ctx, role)
+		if err != nil {
+			return totalRowsDeleted, fmt.Errorf("purge archived roles: unable to delete role %s: %w", role.PublicId, err)
+		}
+		totalRowsDeleted += rowsDeleted
+	}
+	return totalRowsDeleted, nil
+}
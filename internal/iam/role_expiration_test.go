@@ -0,0 +1,187 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/clock"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_GrantsForUser_RoleExpiration(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	ctx := context.Background()
+	now := time.Now()
+
+	user := TestUser(t, repo, org.PublicId)
+	current := TestRole(t, conn, org.PublicId, WithExpirationTime(now.Add(time.Hour)))
+	TestRoleGrant(t, conn, current.PublicId, "id=*;actions=read")
+	TestUserRole(t, conn, current.PublicId, user.PublicId)
+	expired := TestRole(t, conn, org.PublicId, WithExpirationTime(now.Add(-time.Hour)))
+	TestRoleGrant(t, conn, expired.PublicId, "id=*;actions=update")
+	TestUserRole(t, conn, expired.PublicId, user.PublicId)
+
+	grants, err := repo.GrantsForUser(ctx, user.PublicId)
+	require.NoError(err)
+	var got []string
+	for _, g := range grants {
+		if g.ScopeId == org.PublicId {
+			got = append(got, g.Grant)
+		}
+	}
+	assert.Equal([]string{"id=*;actions=read"}, got)
+}
+
+func TestRepository_ArchiveExpiredRoles(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	c := clock.NewFake(time.Now())
+	repo := TestRepo(t, conn, wrapper, WithClock(c))
+	org, _ := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	ctx := context.Background()
+
+	expired := TestRole(t, conn, org.PublicId, WithExpirationTime(c.Now().Add(-time.Minute)))
+	current := TestRole(t, conn, org.PublicId, WithExpirationTime(c.Now().Add(time.Hour)))
+	unbound := TestRole(t, conn, org.PublicId)
+
+	archived, err := repo.ArchiveExpiredRoles(ctx)
+	require.NoError(err)
+	assert.Equal(1, archived)
+	archived, err = repo.ArchiveExpiredRoles(ctx)
+	require.NoError(err)
+	assert.Equal(0, archived)
+
+	roles, err := repo.ListRoles(ctx, org.PublicId)
+	require.NoError(err)
+	assert.Equal(sortedIds(current.PublicId, unbound.PublicId), roleIds(roles))
+	roles, err = repo.ListRoles(ctx, org.PublicId, WithArchived(true))
+	require.NoError(err)
+	assert.Equal(sortedIds(expired.PublicId, current.PublicId, unbound.PublicId), roleIds(roles))
+
+	got, _, _, err := repo.LookupRole(ctx, expired.PublicId)
+	require.NoError(err)
+	require.NotNil(got.ArchiveTime)
+	got.Name = "renamed"
+	_, _, _, _, err = repo.UpdateRole(ctx, got, got.Version, []string{"Name"})
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+}
+
+func TestRepository_ArchiveRole(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	c := clock.NewFake(time.Now())
+	repo := TestRepo(t, conn, wrapper, WithClock(c))
+	org, _ := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+	ctx := context.Background()
+
+	user := TestUser(t, repo, org.PublicId)
+	role := TestRole(t, conn, org.PublicId, WithExpirationTime(c.Now().Add(time.Hour)))
+	TestRoleGrant(t, conn, role.PublicId, "id=*;actions=read")
+	TestUserRole(t, conn, role.PublicId, user.PublicId)
+
+	archived, updated, err := repo.ArchiveRole(ctx, role.PublicId, role.Version)
+	require.NoError(err)
+	assert.Equal(1, updated)
+	require.NotNil(archived.ArchiveTime)
+	assert.Equal(archived.ArchiveTime.Timestamp.AsTime(), archived.ExpirationTime.Timestamp.AsTime())
+	grants, err := repo.GrantsForUser(ctx, user.PublicId)
+	require.NoError(err)
+	for _, g := range grants {
+		assert.NotEqual(org.PublicId, g.ScopeId)
+	}
+
+	_, _, err = repo.ArchiveRole(ctx, archived.PublicId, archived.Version)
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+
+	restored, _, err := repo.RestoreRole(ctx, archived.PublicId, archived.Version)
+	require.NoError(err)
+	assert.Nil(restored.ArchiveTime)
+	assert.Nil(restored.ExpirationTime)
+}
+
+func TestRepository_RestoreRole(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*Repository, *clock.Fake, *Role) {
+		c := clock.NewFake(time.Now())
+		repo := TestRepo(t, conn, wrapper, WithClock(c))
+		org, _ := TestScopes(t, repo, WithSkipDefaultRoleCreation(true))
+		role := TestRole(t, conn, org.PublicId, WithExpirationTime(c.Now().Add(-time.Minute)))
+		_, err := repo.ArchiveExpiredRoles(ctx)
+		require.NoError(t, err)
+		role, _, _, err = repo.LookupRole(ctx, role.PublicId)
+		require.NoError(t, err)
+		return repo, c, role
+	}
+
+	t.Run("no-expiration", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, _, role := setup(t)
+		restored, updated, err := repo.RestoreRole(ctx, role.PublicId, role.Version)
+		require.NoError(err)
+		assert.Equal(1, updated)
+		assert.Nil(restored.ArchiveTime)
+		assert.Nil(restored.ExpirationTime)
+	})
+	t.Run("new-expiration", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, c, role := setup(t)
+		expiration := c.Now().Add(24 * time.Hour)
+		restored, _, err := repo.RestoreRole(ctx, role.PublicId, role.Version, WithExpirationTime(expiration))
+		require.NoError(err)
+		assert.Nil(restored.ArchiveTime)
+		assert.Equal(expiration.Unix(), restored.ExpirationTime.Timestamp.Seconds)
+	})
+	t.Run("past-expiration", func(t *testing.T) {
+		assert := assert.New(t)
+		repo, c, role := setup(t)
+		_, _, err := repo.RestoreRole(ctx, role.PublicId, role.Version, WithExpirationTime(c.Now().Add(-time.Hour)))
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+	})
+	t.Run("not-archived", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, _, role := setup(t)
+		restored, _, err := repo.RestoreRole(ctx, role.PublicId, role.Version)
+		require.NoError(err)
+		_, _, err = repo.RestoreRole(ctx, restored.PublicId, restored.Version)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+	})
+	t.Run("retention-passed", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, c, role := setup(t)
+		c.Add(RoleArchiveRetention + time.Hour)
+		_, _, err := repo.RestoreRole(ctx, role.PublicId, role.Version)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+
+		purged, err := repo.PurgeArchivedRoles(ctx)
+		require.NoError(err)
+		assert.Equal(1, purged)
+		got, _, _, err := repo.LookupRole(ctx, role.PublicId)
+		require.NoError(err)
+		assert.Nil(got)
+	})
+}
+
+func roleIds(roles []*Role) []string {
+	ids := make([]string, 0, len(roles))
+	for _, r := range roles {
+		ids = append(ids, r.PublicId)
+	}
+	return sortedIds(ids...)
+}
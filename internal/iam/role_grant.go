@@ -2,6 +2,7 @@ package iam
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/boundary/internal/db"
@@ -16,13 +17,17 @@ const defaultRoleGrantTable = "iam_role_grant"
 type RoleGrant struct {
 	*store.RoleGrant
 	tableName string `gorm:"-"`
+
+	// grantScopeId is the grant scope of the grant's role. It isn't stored
+	// with the grant, but is covered by its integrity hmac.
+	grantScopeId string `gorm:"-"`
 }
 
-// ensure that RoleGrant implements the interfaces of: Cloneable and db.VetForWriter
-var _ Cloneable = (*RoleGrant)(nil)
+// ensure that RoleGrant implements the interfaces of: db.VetForWriter
 var _ db.VetForWriter = (*RoleGrant)(nil)
 
-// NewRoleGrant creates a new in memory role grant
+// NewRoleGrant creates a new in memory role grant. Supports the WithNotBefore
+// and WithNotAfter options.
 func NewRoleGrant(roleId string, grant string, opt ...Option) (*RoleGrant, error) {
 	if roleId == "" {
 		return nil, fmt.Errorf("new role grant: role id is not set: %w", db.ErrInvalidParameter)
@@ -38,11 +43,20 @@ func NewRoleGrant(roleId string, grant string, opt ...Option) (*RoleGrant, error
 	if err != nil {
 		return nil, fmt.Errorf("new role grant: error parsing grant string: %w", err)
 	}
+	if err := validateGrant(perm); err != nil {
+		return nil, fmt.Errorf("new role grant: %w", err)
+	}
+	notBefore, notAfter, err := timeBound(getOpts(opt...))
+	if err != nil {
+		return nil, fmt.Errorf("new role grant: %w", err)
+	}
 	rg := &RoleGrant{
 		RoleGrant: &store.RoleGrant{
 			RoleId:         roleId,
 			RawGrant:       grant,
 			CanonicalGrant: perm.CanonicalString(),
+			NotBefore:      notBefore,
+			NotAfter:       notAfter,
 		},
 	}
 	return rg, nil
@@ -55,10 +69,11 @@ func allocRoleGrant() RoleGrant {
 }
 
 // Clone creates a clone of the RoleGrant
-func (g *RoleGrant) Clone() interface{} {
+func (g *RoleGrant) Clone() *RoleGrant {
 	cp := proto.Clone(g.RoleGrant)
 	return &RoleGrant{
-		RoleGrant: cp.(*store.RoleGrant),
+		RoleGrant:    cp.(*store.RoleGrant),
+		grantScopeId: g.grantScopeId,
 	}
 }
 
@@ -67,13 +82,20 @@ func (g *RoleGrant) VetForWrite(ctx context.Context, r db.Reader, opType db.OpTy
 	if g.RawGrant == "" {
 		return fmt.Errorf("vet role grant for writing: grant is empty: %w", db.ErrInvalidParameter)
 	}
+	if err := validateGrantLengths([]string{g.RawGrant}, DefaultLengthLimits()); err != nil {
+		return fmt.Errorf("vet role grant for writing: %w", err)
+	}
 
-	// Validate that the grant parses successfully. Note that we fake the scope
-	// here to avoid a lookup as the scope is only relevant at actual ACL
-	// checking time and we just care that it parses correctly. We may have
-	// already done this in NewRoleGrant, but we re-check and set it here
+	// Validate that the grant parses successfully in the scope the role grants
+	// to, so grants on types which don't exist there are refused. We may have
+	// already parsed it in NewRoleGrant, but we re-check and set it here
 	// anyways because it should still be part of the vetting process.
-	perm, err := perms.Parse("o_abcd1234", g.RawGrant)
+	role := allocRole()
+	role.PublicId = g.RoleId
+	if err := r.LookupByPublicId(ctx, &role); err != nil {
+		return fmt.Errorf("vet role grant for writing: unable to lookup role %s: %w", g.RoleId, err)
+	}
+	perm, err := perms.Parse(role.GrantScopeId, g.RawGrant)
 	if err != nil {
 		return fmt.Errorf("vet role grant for writing: error parsing grant string: %w", err)
 	}
@@ -82,10 +104,42 @@ func (g *RoleGrant) VetForWrite(ctx context.Context, r db.Reader, opType db.OpTy
 		return fmt.Errorf("vet role grant for writing: existing canonical grant and derived one do not match: %w", err)
 	}
 	g.CanonicalGrant = canonical
+	banned, err := bannedGrantPatterns(ctx, r, role.GrantScopeId)
+	if err != nil {
+		return fmt.Errorf("vet role grant for writing: %w", err)
+	}
+	if err := validateGrant(perm, banned...); err != nil {
+		return fmt.Errorf("vet role grant for writing: %w", err)
+	}
+	if err := vetWriteHooks(ctx, opType, &WriteHookRequest{
+		RoleId:       role.PublicId,
+		ScopeId:      role.ScopeId,
+		GrantScopeId: role.GrantScopeId,
+		RoleName:     role.Name,
+		ParsedGrant:  &perm,
+	}); err != nil {
+		return fmt.Errorf("vet role grant for writing: %w", err)
+	}
 
 	return nil
 }
 
+// validateGrant checks the grant with perms.ValidateGrant, which the grant
+// linter shares, and wraps its errors in the repository's. Grants matching a
+// banned pattern are rejected with ErrWriteRejected and grants of actions their
+// type doesn't support with ErrInvalidParameter.
+func validateGrant(perm perms.Grant, banned ...*perms.GrantPattern) error {
+	err := perms.ValidateGrant(perm, banned...)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, perms.ErrGrantBanned):
+		return fmt.Errorf("%v: %w", err, ErrWriteRejected)
+	default:
+		return fmt.Errorf("%v: %w", err, db.ErrInvalidParameter)
+	}
+}
+
 // TableName returns the tablename to override the default gorm table name
 func (g *RoleGrant) TableName() string {
 	if g.tableName != "" {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
@@ -83,6 +84,30 @@ func TestRoleGrant_Create(t *testing.T) {
 			}(),
 			create: true,
 		},
+		{
+			name: "unsupported-action",
+			args: args{
+				roleId: projRole.PublicId,
+				grant:  "id=*;type=group;actions=read,set-grants,add-hosts",
+			},
+			wantErr:   true,
+			wantIsErr: db.ErrInvalidParameter,
+		},
+		{
+			name: "valid-typed-grant",
+			args: args{
+				roleId: projRole.PublicId,
+				grant:  "id=*;type=group;actions=list,add-members",
+			},
+			want: func() *RoleGrant {
+				g := allocRoleGrant()
+				g.RoleId = projRole.PublicId
+				g.RawGrant = "id=*;type=group;actions=list,add-members"
+				g.CanonicalGrant = "id=*;type=group;actions=add-members,list"
+				return &g
+			}(),
+			create: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -107,7 +132,7 @@ func TestRoleGrant_Create(t *testing.T) {
 				assert.Equal(tt.want.CanonicalGrant, got.CanonicalGrant)
 
 				// also ensure duplicate grants aren't allowed
-				g2 := got.Clone().(*RoleGrant)
+				g2 := got.Clone()
 				assert.Error(db.New(conn).Create(context.Background(), g2))
 			}
 		})
@@ -126,7 +151,7 @@ func TestRoleGrant_Update(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 		r := TestRole(t, conn, org.PublicId)
 		roleGrant := TestRoleGrant(t, conn, r.PublicId, "id=*;type=*;actions=*")
-		updateRoleGrant := roleGrant.Clone().(*RoleGrant)
+		updateRoleGrant := roleGrant.Clone()
 		updateRoleGrant.RawGrant = "type=*;actions=*;id=*"
 		updatedRows, err := rw.Update(context.Background(), updateRoleGrant, []string{"RawGrant"}, nil)
 		require.Error(err)
@@ -237,7 +262,7 @@ func TestRoleGrant_Clone(t *testing.T) {
 		assert.Equal(g.RawGrant, "id=*;type=*;actions=*")
 
 		cp := g.Clone()
-		assert.True(proto.Equal(cp.(*RoleGrant).RoleGrant, g.RoleGrant))
+		assert.True(proto.Equal(cp.RoleGrant, g.RoleGrant))
 	})
 	t.Run("not-equal", func(t *testing.T) {
 		assert := assert.New(t)
@@ -257,7 +282,20 @@ func TestRoleGrant_Clone(t *testing.T) {
 		assert.Equal(g2.RawGrant, "id=foo;actions=read")
 
 		cp := g.Clone()
-		assert.True(!proto.Equal(cp.(*RoleGrant).RoleGrant, g2.RoleGrant))
+		assert.True(!proto.Equal(cp.RoleGrant, g2.RoleGrant))
+	})
+	t.Run("deep-copy", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := testOrg(t, repo, "", "")
+		role := TestRole(t, conn, s.PublicId)
+		g := TestRoleGrant(t, conn, role.PublicId, "id=*;type=*;actions=*")
+		require.NotNil(g.CreateTime)
+		createSeconds := g.CreateTime.Timestamp.Seconds
+		cp := g.Clone()
+		cp.RawGrant = "id=foo;actions=read"
+		cp.CreateTime.Timestamp.Seconds++
+		assert.Equal("id=*;type=*;actions=*", g.RawGrant)
+		assert.Equal(createSeconds, g.CreateTime.Timestamp.Seconds)
 	})
 }
 
@@ -296,3 +334,113 @@ func TestRoleGrant_SetTableName(t *testing.T) {
 		})
 	}
 }
+
+func TestRoleGrant_VetForWrite_Actions(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	rw := db.New(conn)
+	org, _ := TestScopes(t, repo)
+	role := TestRole(t, conn, org.PublicId)
+	tests := []struct {
+		name    string
+		grant   string
+		wantErr string
+	}{
+		{name: "all-types", grant: "id=*;type=*;actions=*"},
+		{name: "untyped", grant: "id=u_1234567890;actions=read,set-password"},
+		{name: "all-actions", grant: "id=*;type=role;actions=*"},
+		{name: "role", grant: "id=*;type=role;actions=read,add-grants,set-principals"},
+		{name: "user", grant: "id=*;type=user;actions=list,set-accounts"},
+		{name: "scope", grant: "type=scope;actions=list"},
+		{name: "target", grant: "id=*;type=target;actions=authorize-session,add-host-sets"},
+		{name: "host-set", grant: "id=*;type=host-set;actions=read,add-hosts"},
+		{name: "session", grant: "id=*;type=session;actions=read,cancel"},
+		{name: "list-unpaged", grant: "type=role;actions=list-unpaged"},
+		{
+			name:    "unsupported",
+			grant:   "id=*;type=user;actions=read,cancel,add-members",
+			wantErr: `unknown actions add-members, cancel for type "user"`,
+		},
+		{
+			name:    "unsupported-target",
+			grant:   "id=*;type=target;actions=add-hosts",
+			wantErr: `unknown actions add-hosts for type "target"`,
+		},
+		{
+			name:    "unsupported-session",
+			grant:   "id=*;type=session;actions=update",
+			wantErr: `unknown actions update for type "session"`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert := assert.New(t)
+			g := allocRoleGrant()
+			g.RoleId = role.PublicId
+			g.RawGrant = tt.grant
+			err := g.VetForWrite(context.Background(), rw, db.CreateOp)
+			if tt.wantErr != "" {
+				assert.Error(err)
+				assert.True(errors.Is(err, db.ErrInvalidParameter))
+				assert.Contains(err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestRoleGrant_VetForWrite_Scope(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	rw := db.New(conn)
+	org, proj := TestScopes(t, repo)
+	orgRole := TestRole(t, conn, org.PublicId)
+	projRole := TestRole(t, conn, proj.PublicId)
+	orgRoleForProj := TestRole(t, conn, org.PublicId, WithGrantScopeId(proj.PublicId))
+
+	tests := []struct {
+		name    string
+		roleId  string
+		grant   string
+		wantErr bool
+	}{
+		{name: "org-user", roleId: orgRole.PublicId, grant: "id=*;type=user;actions=read"},
+		{name: "org-scope", roleId: orgRole.PublicId, grant: "type=scope;actions=list"},
+		{name: "project-target", roleId: projRole.PublicId, grant: "id=*;type=target;actions=read"},
+		{name: "project-all", roleId: projRole.PublicId, grant: "id=*;type=*;actions=read"},
+		{name: "project-user", roleId: projRole.PublicId, grant: "id=*;type=user;actions=read", wantErr: true},
+		{name: "project-scope", roleId: projRole.PublicId, grant: "type=scope;actions=list", wantErr: true},
+		{name: "project-auth-method", roleId: projRole.PublicId, grant: "id=*;type=auth-method;actions=authenticate", wantErr: true},
+		{name: "grant-scope-project", roleId: orgRoleForProj.PublicId, grant: "id=*;type=account;actions=read", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert := assert.New(t)
+			g := allocRoleGrant()
+			g.RoleId = tt.roleId
+			g.RawGrant = tt.grant
+			err := g.VetForWrite(context.Background(), rw, db.CreateOp)
+			if tt.wantErr {
+				assert.Error(err)
+				assert.True(errors.Is(err, perms.ErrTypeNotInScope))
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+
+	t.Run("repository", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.AddRoleGrants(context.Background(), projRole.PublicId, projRole.Version, []string{"id=*;type=user;actions=read"})
+		assert.True(errors.Is(err, perms.ErrTypeNotInScope))
+	})
+}
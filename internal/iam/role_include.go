@@ -0,0 +1,82 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultRoleIncludeTable = "iam_role_include"
+
+// RoleInclude is a role included by another role. The including role receives
+// the grants of the included role, and of the roles it includes in turn.
+type RoleInclude struct {
+	*store.RoleInclude
+	tableName string `gorm:"-"`
+}
+
+// ensure that RoleInclude implements the interfaces of: db.VetForWriter
+var _ db.VetForWriter = (*RoleInclude)(nil)
+
+// NewRoleInclude creates a new in memory role include of includedRoleId by
+// roleId. No options are currently supported.
+func NewRoleInclude(roleId, includedRoleId string, opt ...Option) (*RoleInclude, error) {
+	if roleId == "" {
+		return nil, fmt.Errorf("new role include: missing role id: %w", db.ErrInvalidParameter)
+	}
+	if includedRoleId == "" {
+		return nil, fmt.Errorf("new role include: missing included role id: %w", db.ErrInvalidParameter)
+	}
+	if roleId == includedRoleId {
+		return nil, fmt.Errorf("new role include: role %s can't include itself: %w", roleId, ErrRoleIncludeCycle)
+	}
+	return &RoleInclude{
+		RoleInclude: &store.RoleInclude{
+			RoleId:         roleId,
+			IncludedRoleId: includedRoleId,
+		},
+	}, nil
+}
+
+func allocRoleInclude() RoleInclude {
+	return RoleInclude{
+		RoleInclude: &store.RoleInclude{},
+	}
+}
+
+// Clone creates a clone of the RoleInclude
+func (i *RoleInclude) Clone() *RoleInclude {
+	cp := proto.Clone(i.RoleInclude)
+	return &RoleInclude{
+		RoleInclude: cp.(*store.RoleInclude),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface
+func (i *RoleInclude) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if i.RoleId == "" {
+		return fmt.Errorf("vet role include for writing: missing role id: %w", db.ErrInvalidParameter)
+	}
+	if i.IncludedRoleId == "" {
+		return fmt.Errorf("vet role include for writing: missing included role id: %w", db.ErrInvalidParameter)
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (i *RoleInclude) TableName() string {
+	if i.tableName != "" {
+		return i.tableName
+	}
+	return defaultRoleIncludeTable
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (i *RoleInclude) SetTableName(n string) {
+	i.tableName = n
+}
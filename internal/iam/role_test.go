@@ -180,14 +180,14 @@ func Test_RoleCreate(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 			w := db.New(conn)
 			if tt.wantDup {
-				r := tt.args.role.Clone().(*Role)
+				r := tt.args.role.Clone()
 				roleId, err := newRoleId()
 				require.NoError(err)
 				r.PublicId = roleId
 				err = w.Create(context.Background(), r)
 				require.NoError(err)
 			}
-			r := tt.args.role.Clone().(*Role)
+			r := tt.args.role.Clone()
 			err := w.Create(context.Background(), r)
 			if tt.wantErr {
 				require.Error(err)
@@ -545,6 +545,11 @@ func TestRole_Actions(t *testing.T) {
 	assert.Equal(a[action.AddPrincipals.String()], action.AddPrincipals)
 	assert.Equal(a[action.RemovePrincipals.String()], action.RemovePrincipals)
 	assert.Equal(a[action.SetPrincipals.String()], action.SetPrincipals)
+	assert.Equal(a[action.ActivateEmergency.String()], action.ActivateEmergency)
+	assert.Equal(a[action.RevertEmergency.String()], action.RevertEmergency)
+	assert.Equal(a[action.Diff.String()], action.Diff)
+	assert.Equal(a[action.Archive.String()], action.Archive)
+	assert.Equal(a[action.Restore.String()], action.Restore)
 }
 
 func TestRole_ResourceType(t *testing.T) {
@@ -589,14 +594,25 @@ func TestRole_Clone(t *testing.T) {
 		assert := assert.New(t)
 		role := TestRole(t, conn, org.PublicId, WithDescription("this is a test role"))
 		cp := role.Clone()
-		assert.True(proto.Equal(cp.(*Role).Role, role.Role))
+		assert.True(proto.Equal(cp.Role, role.Role))
 	})
 	t.Run("not-equal", func(t *testing.T) {
 		assert := assert.New(t)
 		role := TestRole(t, conn, org.PublicId)
 		role2 := TestRole(t, conn, org.PublicId)
 		cp := role.Clone()
-		assert.True(!proto.Equal(cp.(*Role).Role, role2.Role))
+		assert.True(!proto.Equal(cp.Role, role2.Role))
+	})
+	t.Run("deep-copy", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := TestRole(t, conn, org.PublicId, WithName("role"))
+		require.NotNil(role.CreateTime)
+		createSeconds := role.CreateTime.Timestamp.Seconds
+		cp := role.Clone()
+		cp.Name = "clone"
+		cp.CreateTime.Timestamp.Seconds++
+		assert.Equal("role", role.Name)
+		assert.Equal(createSeconds, role.CreateTime.Timestamp.Seconds)
 	})
 }
 
@@ -88,13 +88,18 @@ func allocScope() Scope {
 }
 
 // Clone creates a clone of the Scope
-func (s *Scope) Clone() interface{} {
+func (s *Scope) Clone() *Scope {
 	cp := proto.Clone(s.Scope)
 	return &Scope{
 		Scope: cp.(*store.Scope),
 	}
 }
 
+// cloneResource implements Cloneable.
+func (s *Scope) cloneResource() Resource {
+	return s.Clone()
+}
+
 // VetForWrite implements db.VetForWrite() interface for scopes
 // this function is intended to be callled by a db.Writer (Create and Update) to validate
 // the scope before writing it to the db.
@@ -137,6 +142,9 @@ func (s *Scope) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType,
 			}
 		}
 	}
+	if err := validateLengthsForWrite(s, DefaultLengthLimits(), opType, opt...); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -147,7 +155,11 @@ func (s *Scope) ResourceType() resource.Type {
 
 // Actions returns the available actions for Scopes
 func (*Scope) Actions() map[string]action.Type {
-	return CrudlActions()
+	ret := CrudlActions()
+	ret[action.ListBannedGrantPatterns.String()] = action.ListBannedGrantPatterns
+	ret[action.AddBannedGrantPattern.String()] = action.AddBannedGrantPattern
+	ret[action.RemoveBannedGrantPattern.String()] = action.RemoveBannedGrantPattern
+	return ret
 }
 
 // GetScope returns the scope for the "scope" if there is one defined
@@ -163,6 +163,9 @@ func TestScope_Actions(t *testing.T) {
 	assert.Equal(a[action.Read.String()], action.Read)
 	assert.Equal(a[action.Delete.String()], action.Delete)
 	assert.Equal(a[action.List.String()], action.List)
+	assert.Equal(a[action.ListBannedGrantPatterns.String()], action.ListBannedGrantPatterns)
+	assert.Equal(a[action.AddBannedGrantPattern.String()], action.AddBannedGrantPattern)
+	assert.Equal(a[action.RemoveBannedGrantPattern.String()], action.RemoveBannedGrantPattern)
 }
 
 func TestScope_ResourceType(t *testing.T) {
@@ -181,14 +184,25 @@ func TestScope_Clone(t *testing.T) {
 		assert := assert.New(t)
 		s, _ := TestScopes(t, repo)
 		cp := s.Clone()
-		assert.True(proto.Equal(cp.(*Scope).Scope, s.Scope))
+		assert.True(proto.Equal(cp.Scope, s.Scope))
 	})
 	t.Run("not-equal", func(t *testing.T) {
 		assert := assert.New(t)
 		s, _ := TestScopes(t, repo)
 		s2, _ := TestScopes(t, repo)
 		cp := s.Clone()
-		assert.True(!proto.Equal(cp.(*Scope).Scope, s2.Scope))
+		assert.True(!proto.Equal(cp.Scope, s2.Scope))
+	})
+	t.Run("deep-copy", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s, _ := TestScopes(t, repo)
+		require.NotNil(s.CreateTime)
+		createSeconds := s.CreateTime.Timestamp.Seconds
+		cp := s.Clone()
+		cp.Name = s.Name + "-clone"
+		cp.CreateTime.Timestamp.Seconds++
+		assert.NotEqual(cp.Name, s.Name)
+		assert.Equal(createSeconds, s.CreateTime.Timestamp.Seconds)
 	})
 }
 
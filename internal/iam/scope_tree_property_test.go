@@ -0,0 +1,402 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// propertyGrants are the grants the scope tree property test adds to roles.
+var propertyGrants = []string{
+	"id=*;type=*;actions=*",
+	"id=*;type=*;actions=read,list",
+	"id=*;type=host-catalog;actions=create,list",
+	"id=*;type=target;actions=authorize-session",
+	"type=group;id=*;actions=read",
+}
+
+// scopeTreeModel is the in memory model of the scopes, principals and roles
+// created by an iteration of the scope tree property test.
+type scopeTreeModel struct {
+	scopes     map[string]*Scope
+	projects   map[string][]string // org id -> project ids
+	users      []string
+	groups     map[string]string          // group id -> scope id
+	roles      map[string]*Role           // role id -> role
+	grants     map[string][]string        // role id -> canonical grants
+	members    map[string]map[string]bool // group id -> user ids
+	principals map[string]map[string]bool // role id -> user and group ids
+}
+
+// expectedGrants returns the grants the model gives the user, sorted.
+func (m *scopeTreeModel) expectedGrants(userId string) []perms.GrantPair {
+	var pairs []perms.GrantPair
+	for roleId, principals := range m.principals {
+		has := principals[userId]
+		for p := range principals {
+			if m.members[p][userId] {
+				has = true
+			}
+		}
+		if !has {
+			continue
+		}
+		for _, g := range m.grants[roleId] {
+			pairs = append(pairs, perms.GrantPair{ScopeId: m.roles[roleId].GrantScopeId, Grant: g})
+		}
+	}
+	sortGrantPairs(pairs)
+	return pairs
+}
+
+// deleteScope removes a project and everything the database deletes with it
+// from the model: its groups and roles, and the roles granting in it.
+func (m *scopeTreeModel) deleteScope(orgId, projectId string) {
+	delete(m.scopes, projectId)
+	for i, id := range m.projects[orgId] {
+		if id == projectId {
+			m.projects[orgId] = append(m.projects[orgId][:i], m.projects[orgId][i+1:]...)
+			break
+		}
+	}
+	for groupId, scopeId := range m.groups {
+		if scopeId == projectId {
+			m.deletePrincipal(groupId)
+			delete(m.groups, groupId)
+			delete(m.members, groupId)
+		}
+	}
+	for roleId, role := range m.roles {
+		if role.ScopeId == projectId || role.GrantScopeId == projectId {
+			m.deleteRole(roleId)
+		}
+	}
+}
+
+func (m *scopeTreeModel) deleteRole(roleId string) {
+	delete(m.roles, roleId)
+	delete(m.grants, roleId)
+	delete(m.principals, roleId)
+}
+
+func (m *scopeTreeModel) deletePrincipal(id string) {
+	for _, principals := range m.principals {
+		delete(principals, id)
+	}
+}
+
+func (m *scopeTreeModel) deleteUser(userId string) {
+	for i, id := range m.users {
+		if id == userId {
+			m.users = append(m.users[:i], m.users[i+1:]...)
+			break
+		}
+	}
+	for _, members := range m.members {
+		delete(members, userId)
+	}
+	m.deletePrincipal(userId)
+}
+
+func sortGrantPairs(pairs []perms.GrantPair) {
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].ScopeId != pairs[j].ScopeId {
+			return pairs[i].ScopeId < pairs[j].ScopeId
+		}
+		return pairs[i].Grant < pairs[j].Grant
+	})
+}
+
+// TestRepository_ScopeTreeProperties generates random scope trees and iam
+// mutations and checks after every mutation that: a role's grants never point
+// outside its scope's subtree, no membership, principal or grant outlives what
+// it refers to, and resolving a user's grants is deterministic and matches the
+// model. Failures log the seed of the iteration.
+func TestRepository_ScopeTreeProperties(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	rw := db.New(conn)
+
+	prop := func(seed int64) bool {
+		return t.Run(fmt.Sprintf("seed-%d", seed), func(t *testing.T) {
+			testScopeTreeProperties(t, repo, rw, rand.New(rand.NewSource(seed)))
+		})
+	}
+	cfg := &quick.Config{
+		MaxCount: 10,
+		Rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if err := quick.Check(prop, cfg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testScopeTreeProperties(t *testing.T, repo *Repository, rw *db.Db, r *rand.Rand) {
+	require := require.New(t)
+	ctx := context.Background()
+	m := &scopeTreeModel{
+		scopes:     map[string]*Scope{},
+		projects:   map[string][]string{},
+		groups:     map[string]string{},
+		roles:      map[string]*Role{},
+		grants:     map[string][]string{},
+		members:    map[string]map[string]bool{},
+		principals: map[string]map[string]bool{},
+	}
+
+	// Scopes, users, groups and roles.
+	var orgIds []string
+	for i := 0; i < 1+r.Intn(3); i++ {
+		org, err := NewOrg()
+		require.NoError(err)
+		org, err = repo.CreateScope(ctx, org, "", WithSkipDefaultRoleCreation(true))
+		require.NoError(err)
+		m.scopes[org.PublicId] = org
+		orgIds = append(orgIds, org.PublicId)
+		for j := 0; j < r.Intn(3); j++ {
+			p, err := NewProject(org.PublicId)
+			require.NoError(err)
+			p, err = repo.CreateScope(ctx, p, "")
+			require.NoError(err)
+			m.scopes[p.PublicId] = p
+			m.projects[org.PublicId] = append(m.projects[org.PublicId], p.PublicId)
+		}
+		for j := 0; j < 1+r.Intn(3); j++ {
+			m.users = append(m.users, TestUser(t, repo, org.PublicId).PublicId)
+		}
+	}
+	randomScope := func() (orgId, scopeId string) {
+		orgId = orgIds[r.Intn(len(orgIds))]
+		if projects := m.projects[orgId]; len(projects) > 0 && r.Intn(2) == 0 {
+			return orgId, projects[r.Intn(len(projects))]
+		}
+		return orgId, orgId
+	}
+	for i := 0; i < 1+r.Intn(3); i++ {
+		_, scopeId := randomScope()
+		grp, err := NewGroup(scopeId)
+		require.NoError(err)
+		grp, err = repo.CreateGroup(ctx, grp)
+		require.NoError(err)
+		m.groups[grp.PublicId] = scopeId
+		m.members[grp.PublicId] = map[string]bool{}
+	}
+	for i := 0; i < 1+r.Intn(4); i++ {
+		orgId, scopeId := randomScope()
+		grantScopeId := scopeId
+		if projects := m.projects[orgId]; scopeId == orgId && len(projects) > 0 && r.Intn(2) == 0 {
+			grantScopeId = projects[r.Intn(len(projects))]
+		}
+		role, err := NewRole(scopeId, WithGrantScopeId(grantScopeId))
+		require.NoError(err)
+		role, err = repo.CreateRole(ctx, role)
+		require.NoError(err)
+		m.roles[role.PublicId] = role
+		m.principals[role.PublicId] = map[string]bool{}
+	}
+
+	// A role can't grant in a scope outside its subtree.
+	for _, orgId := range orgIds {
+		for _, otherOrgId := range orgIds {
+			if orgId == otherOrgId || len(m.projects[otherOrgId]) == 0 {
+				continue
+			}
+			role, err := NewRole(orgId, WithGrantScopeId(m.projects[otherOrgId][0]))
+			require.NoError(err)
+			_, err = repo.CreateRole(ctx, role)
+			require.Error(err, "org %s role granting in project of org %s", orgId, otherOrgId)
+		}
+		for _, projectId := range m.projects[orgId] {
+			role, err := NewRole(projectId, WithGrantScopeId(orgId))
+			require.NoError(err)
+			_, err = repo.CreateRole(ctx, role)
+			require.Error(err, "project %s role granting in its org", projectId)
+		}
+	}
+
+	randomKey := func(keys map[string]bool) string {
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		if len(sorted) == 0 {
+			return ""
+		}
+		sort.Strings(sorted)
+		return sorted[r.Intn(len(sorted))]
+	}
+	roleIds := func() map[string]bool {
+		ids := map[string]bool{}
+		for id := range m.roles {
+			ids[id] = true
+		}
+		return ids
+	}
+	groupIds := func() map[string]bool {
+		ids := map[string]bool{}
+		for id := range m.groups {
+			ids[id] = true
+		}
+		return ids
+	}
+	randomUser := func() string {
+		if len(m.users) == 0 {
+			return ""
+		}
+		return m.users[r.Intn(len(m.users))]
+	}
+
+	checkScopeTreeInvariants(t, repo, rw, m)
+	for step := 0; step < 10+r.Intn(20); step++ {
+		switch r.Intn(7) {
+		case 0:
+			roleId := randomKey(roleIds())
+			if roleId == "" {
+				continue
+			}
+			g := propertyGrants[r.Intn(len(propertyGrants))]
+			rg, err := NewRoleGrant(roleId, g)
+			require.NoError(err)
+			if contains(m.grants[roleId], rg.CanonicalGrant) {
+				continue
+			}
+			role, _, _, err := repo.LookupRole(ctx, roleId)
+			require.NoError(err)
+			added, err := repo.AddRoleGrants(ctx, roleId, role.Version, []string{g})
+			require.NoError(err)
+			for _, rg := range added {
+				m.grants[roleId] = append(m.grants[roleId], rg.CanonicalGrant)
+			}
+			// A grant can only be added once.
+			_, err = repo.AddRoleGrants(ctx, roleId, role.Version+1, []string{g})
+			require.Error(err)
+		case 1:
+			groupId, userId := randomKey(groupIds()), randomUser()
+			if groupId == "" || userId == "" || m.members[groupId][userId] {
+				continue
+			}
+			grp, _, err := repo.LookupGroup(ctx, groupId)
+			require.NoError(err)
+			_, err = repo.AddGroupMembers(ctx, groupId, grp.Version, []string{userId})
+			require.NoError(err)
+			m.members[groupId][userId] = true
+		case 2:
+			roleId := randomKey(roleIds())
+			principalId := randomUser()
+			if r.Intn(2) == 0 {
+				principalId = randomKey(groupIds())
+			}
+			if roleId == "" || principalId == "" || m.principals[roleId][principalId] {
+				continue
+			}
+			role, _, _, err := repo.LookupRole(ctx, roleId)
+			require.NoError(err)
+			_, err = repo.AddPrincipalRoles(ctx, roleId, role.Version, []string{principalId})
+			require.NoError(err)
+			m.principals[roleId][principalId] = true
+		case 3:
+			userId := randomUser()
+			if userId == "" || r.Intn(3) != 0 {
+				continue
+			}
+			_, err := repo.DeleteUser(ctx, userId)
+			require.NoError(err)
+			m.deleteUser(userId)
+		case 4:
+			groupId := randomKey(groupIds())
+			if groupId == "" || r.Intn(3) != 0 {
+				continue
+			}
+			_, err := repo.DeleteGroup(ctx, groupId)
+			require.NoError(err)
+			m.deletePrincipal(groupId)
+			delete(m.groups, groupId)
+			delete(m.members, groupId)
+		case 5:
+			roleId := randomKey(roleIds())
+			if roleId == "" || r.Intn(3) != 0 {
+				continue
+			}
+			_, err := repo.DeleteRole(ctx, roleId)
+			require.NoError(err)
+			m.deleteRole(roleId)
+		case 6:
+			orgId := orgIds[r.Intn(len(orgIds))]
+			projects := m.projects[orgId]
+			if len(projects) == 0 || r.Intn(4) != 0 {
+				continue
+			}
+			projectId := projects[r.Intn(len(projects))]
+			_, err := repo.DeleteScope(ctx, projectId)
+			require.NoError(err)
+			m.deleteScope(orgId, projectId)
+		}
+		checkScopeTreeInvariants(t, repo, rw, m)
+	}
+}
+
+func checkScopeTreeInvariants(t *testing.T, repo *Repository, rw *db.Db, m *scopeTreeModel) {
+	t.Helper()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+
+	// No role grants outside its scope's subtree.
+	var roles []*Role
+	require.NoError(rw.SearchWhere(ctx, &roles, "grant_scope_id <> scope_id", []interface{}{}, db.WithLimit(-1)))
+	for _, role := range roles {
+		if role.GrantScopeId == role.ScopeId {
+			continue
+		}
+		s, err := repo.LookupScope(ctx, role.GrantScopeId)
+		require.NoError(err)
+		assert.Equal(role.ScopeId, s.ParentId, "role %s grants outside its scope", role.PublicId)
+	}
+
+	// No orphans.
+	for _, q := range []string{
+		"select count(*) from iam_group_member_user where group_id not in (select public_id from iam_group) or member_id not in (select public_id from iam_user)",
+		"select count(*) from iam_user_role where role_id not in (select public_id from iam_role) or principal_id not in (select public_id from iam_user)",
+		"select count(*) from iam_group_role where role_id not in (select public_id from iam_role) or principal_id not in (select public_id from iam_group)",
+		"select count(*) from iam_role_grant where role_id not in (select public_id from iam_role)",
+		"select count(*) from iam_role where scope_id not in (select public_id from iam_scope) or grant_scope_id not in (select public_id from iam_scope)",
+		"select count(*) from iam_group where scope_id not in (select public_id from iam_scope)",
+	} {
+		rows, err := rw.Query(ctx, q, nil)
+		require.NoError(err)
+		var count int
+		require.True(rows.Next())
+		require.NoError(rows.Scan(&count))
+		require.NoError(rows.Close())
+		assert.Zero(count, q)
+	}
+
+	// Resolution is deterministic and matches the model.
+	for _, userId := range m.users {
+		var resolved [][]perms.GrantPair
+		for i := 0; i < 2; i++ {
+			got, err := repo.GrantsForUser(ctx, userId)
+			require.NoError(err)
+			var pairs []perms.GrantPair
+			for _, p := range got {
+				if _, ok := m.scopes[p.ScopeId]; ok {
+					pairs = append(pairs, p)
+				}
+			}
+			sortGrantPairs(pairs)
+			resolved = append(resolved, pairs)
+		}
+		assert.Equal(resolved[0], resolved[1], "user %s", userId)
+		assert.Equal(m.expectedGrants(userId), resolved[0], "user %s", userId)
+	}
+}
@@ -0,0 +1,108 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultTagTable = "iam_tag"
+
+	// maxTagKeyLength and maxTagValueLength match the constraints on the
+	// iam_tag table.
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+// Tag is a key/value label on a role or group. A resource has at most one
+// value for a key.
+type Tag struct {
+	*store.Tag
+	tableName string `gorm:"-"`
+}
+
+// ensure that Tag implements the interfaces of: db.VetForWriter
+var _ db.VetForWriter = (*Tag)(nil)
+
+// NewTag creates a new in memory tag for the resource (a role or group). No
+// options are currently supported.
+func NewTag(resourceId, key, value string, opt ...Option) (*Tag, error) {
+	if resourceId == "" {
+		return nil, fmt.Errorf("new tag: missing resource id: %w", db.ErrInvalidParameter)
+	}
+	t := &Tag{
+		Tag: &store.Tag{
+			ResourceId: resourceId,
+			Key:        key,
+			Value:      value,
+		},
+	}
+	if err := t.validate(); err != nil {
+		return nil, fmt.Errorf("new tag: %w", err)
+	}
+	return t, nil
+}
+
+func allocTag() Tag {
+	return Tag{
+		Tag: &store.Tag{},
+	}
+}
+
+// validate checks the key and value of the tag.
+func (t *Tag) validate() error {
+	if strings.TrimSpace(t.Key) == "" {
+		return fmt.Errorf("missing key: %w", db.ErrInvalidParameter)
+	}
+	if t.Key != strings.TrimSpace(t.Key) {
+		return fmt.Errorf("key %q has leading or trailing whitespace: %w", t.Key, db.ErrInvalidParameter)
+	}
+	if len(t.Key) > maxTagKeyLength {
+		return fmt.Errorf("key %q is longer than %d: %w", t.Key, maxTagKeyLength, db.ErrInvalidParameter)
+	}
+	if len(t.Value) > maxTagValueLength {
+		return fmt.Errorf("value of key %q is longer than %d: %w", t.Key, maxTagValueLength, db.ErrInvalidParameter)
+	}
+	return nil
+}
+
+// Clone creates a clone of the Tag
+func (t *Tag) Clone() *Tag {
+	cp := proto.Clone(t.Tag)
+	return &Tag{
+		Tag: cp.(*store.Tag),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface
+func (t *Tag) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if t.ResourceId == "" {
+		return fmt.Errorf("vet tag for writing: missing resource id: %w", db.ErrInvalidParameter)
+	}
+	if opType == db.CreateOp {
+		if err := t.validate(); err != nil {
+			return fmt.Errorf("vet tag for writing: %w", err)
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (t *Tag) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return defaultTagTable
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (t *Tag) SetTableName(n string) {
+	t.tableName = n
+}
@@ -0,0 +1,56 @@
+package iam
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTag(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		resourceId string
+		key        string
+		value      string
+		wantErr    bool
+	}{
+		{name: "valid", resourceId: "r_1234567890", key: "team", value: "eng"},
+		{name: "empty-value", resourceId: "r_1234567890", key: "team"},
+		{name: "missing-resource", key: "team", value: "eng", wantErr: true},
+		{name: "missing-key", resourceId: "r_1234567890", value: "eng", wantErr: true},
+		{name: "blank-key", resourceId: "r_1234567890", key: "  ", value: "eng", wantErr: true},
+		{name: "padded-key", resourceId: "r_1234567890", key: " team", value: "eng", wantErr: true},
+		{name: "long-key", resourceId: "r_1234567890", key: strings.Repeat("k", maxTagKeyLength+1), wantErr: true},
+		{name: "long-value", resourceId: "r_1234567890", key: "team", value: strings.Repeat("v", maxTagValueLength+1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewTag(tt.resourceId, tt.key, tt.value)
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Is(err, db.ErrInvalidParameter))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(&Tag{Tag: &store.Tag{ResourceId: tt.resourceId, Key: tt.key, Value: tt.value}}, got)
+		})
+	}
+}
+
+func TestTag_Clone(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	tag, err := NewTag("r_1234567890", "team", "eng")
+	require.NoError(err)
+	cp := tag.Clone()
+	assert.Equal(tag, cp)
+	cp.Value = "ops"
+	assert.Equal("eng", tag.Value)
+}
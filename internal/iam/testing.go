@@ -39,6 +39,41 @@ func TestRepo(t *testing.T, conn *gorm.DB, rootWrapper wrapping.Wrapper, opt ...
 	return repo
 }
 
+// TestSetupRepo starts a postgres test database in docker, migrated to the
+// latest schema, and returns a connection to it, its root wrapper and a repo
+// created by TestRepo. It lets packages which depend on iam write integration
+// tests without repeating the setup. The database is removed when the test
+// ends.
+func TestSetupRepo(t *testing.T, opt ...Option) (*gorm.DB, wrapping.Wrapper, *Repository) {
+	t.Helper()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	return conn, wrapper, TestRepo(t, conn, wrapper, opt...)
+}
+
+// TestScope creates a scope suitable for testing in the parent scope: an org
+// if the parent is the global scope, or else a project.
+func TestScope(t *testing.T, repo *Repository, parentId string, opt ...Option) *Scope {
+	t.Helper()
+	require := require.New(t)
+
+	opts := getOpts(opt...)
+
+	var s *Scope
+	var err error
+	if parentId == scope.Global.String() {
+		s, err = NewOrg(opt...)
+	} else {
+		s, err = NewProject(parentId, opt...)
+	}
+	require.NoError(err)
+	s, err = repo.CreateScope(context.Background(), s, opts.withUserId, opt...)
+	require.NoError(err)
+	require.NotNil(s)
+	require.NotEmpty(s.GetPublicId())
+	return s
+}
+
 // TestScopes creates an org and project suitable for testing.
 func TestScopes(t *testing.T, repo *Repository, opt ...Option) (org *Scope, prj *Scope) {
 	t.Helper()
@@ -155,6 +190,7 @@ func TestRole(t *testing.T, conn *gorm.DB, scopeId string, opt ...Option) *Role
 	return role
 }
 
+// TestRoleGrant creates a role grant suitable for testing.
 func TestRoleGrant(t *testing.T, conn *gorm.DB, roleId, grant string, opt ...Option) *RoleGrant {
 	t.Helper()
 	require := require.New(t)
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -62,6 +63,35 @@ func Test_TestScopes(t *testing.T) {
 	assert.NotEmpty(prj.GetPublicId())
 }
 
+func Test_TestScope(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	_, _, repo := TestSetupRepo(t)
+	id := testId(t)
+
+	org := TestScope(t, repo, scope.Global.String(), WithName(id))
+	require.NotNil(org)
+	assert.Equal(scope.Org.String(), org.GetType())
+	assert.Equal(scope.Global.String(), org.GetParentId())
+	assert.Equal(id, org.GetName())
+
+	prj := TestScope(t, repo, org.GetPublicId())
+	require.NotNil(prj)
+	assert.Equal(scope.Project.String(), prj.GetType())
+	assert.Equal(org.GetPublicId(), prj.GetParentId())
+}
+
+func Test_TestSetupRepo(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, wrapper, repo := TestSetupRepo(t)
+	require.NotNil(conn)
+	require.NotNil(wrapper)
+	require.NotNil(repo)
+
+	org, _ := TestScopes(t, repo)
+	user := TestUser(t, repo, org.GetPublicId())
+	assert.NotEmpty(user.GetPublicId())
+}
+
 func Test_TestRepo(t *testing.T) {
 	require := require.New(t)
 	conn, _ := db.TestSetup(t, "postgres")
@@ -0,0 +1,112 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+)
+
+// timeBound returns the timestamps for the WithNotBefore and WithNotAfter
+// options, which are nil when the option isn't set.
+func timeBound(opts options) (notBefore, notAfter *timestamp.Timestamp, err error) {
+	if !opts.withNotBefore.IsZero() && !opts.withNotAfter.IsZero() && !opts.withNotBefore.Before(opts.withNotAfter) {
+		return nil, nil, fmt.Errorf("not before must be before not after: %w", db.ErrInvalidParameter)
+	}
+	// The database stores microseconds, so truncate to them here to keep the
+	// values written the same as the values read back.
+	if !opts.withNotBefore.IsZero() {
+		ts, err := ptypes.TimestampProto(opts.withNotBefore.Truncate(time.Microsecond))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid not before: %w", err)
+		}
+		notBefore = &timestamp.Timestamp{Timestamp: ts}
+	}
+	if !opts.withNotAfter.IsZero() {
+		ts, err := ptypes.TimestampProto(opts.withNotAfter.Truncate(time.Microsecond))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid not after: %w", err)
+		}
+		notAfter = &timestamp.Timestamp{Timestamp: ts}
+	}
+	return notBefore, notAfter, nil
+}
+
+// DeleteExpiredGrants removes the role grants and principal roles whose not
+// after time has passed. Deletes are made per role through DeleteRoleGrants
+// and DeletePrincipalRoles so each one is versioned and written to the oplog.
+// It returns the number of grants and principal roles deleted.
+func (r *Repository) DeleteExpiredGrants(ctx context.Context) (int, error) {
+	// SearchWhere ignores a where clause without args, so now is passed in.
+	const expired = "not_after <= ?"
+	now := []interface{}{r.now()}
+
+	var grants []*RoleGrant
+	if err := r.list(ctx, &grants, expired, now, WithLimit(-1)); err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete expired grants: unable to list role grants: %w", err)
+	}
+	var principals []PrincipalRole
+	if err := r.list(ctx, &principals, expired, now, WithLimit(-1)); err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete expired grants: unable to list principal roles: %w", err)
+	}
+
+	var roleIds []string
+	grantsByRole := map[string][]string{}
+	principalsByRole := map[string][]string{}
+	for _, g := range grants {
+		if _, ok := grantsByRole[g.RoleId]; !ok {
+			if _, ok := principalsByRole[g.RoleId]; !ok {
+				roleIds = append(roleIds, g.RoleId)
+			}
+		}
+		grantsByRole[g.RoleId] = append(grantsByRole[g.RoleId], g.CanonicalGrant)
+	}
+	for _, p := range principals {
+		if _, ok := grantsByRole[p.RoleId]; !ok {
+			if _, ok := principalsByRole[p.RoleId]; !ok {
+				roleIds = append(roleIds, p.RoleId)
+			}
+		}
+		principalsByRole[p.RoleId] = append(principalsByRole[p.RoleId], p.PrincipalId)
+	}
+
+	var totalRowsDeleted int
+	for _, roleId := range roleIds {
+		if g := grantsByRole[roleId]; len(g) > 0 {
+			version, err := r.roleVersion(ctx, roleId)
+			if err != nil {
+				return totalRowsDeleted, fmt.Errorf("delete expired grants: %w", err)
+			}
+			rowsDeleted, err := r.DeleteRoleGrants(ctx, roleId, version, g)
+			if err != nil {
+				return totalRowsDeleted, fmt.Errorf("delete expired grants: role %s: %w", roleId, err)
+			}
+			totalRowsDeleted += rowsDeleted
+		}
+		if p := principalsByRole[roleId]; len(p) > 0 {
+			version, err := r.roleVersion(ctx, roleId)
+			if err != nil {
+				return totalRowsDeleted, fmt.Errorf("delete expired grants: %w", err)
+			}
+			rowsDeleted, err := r.DeletePrincipalRoles(ctx, roleId, version, p)
+			if err != nil {
+				return totalRowsDeleted, fmt.Errorf("delete expired grants: role %s: %w", roleId, err)
+			}
+			totalRowsDeleted += rowsDeleted
+		}
+	}
+	return totalRowsDeleted, nil
+}
+
+// roleVersion returns the current version of the role.
+func (r *Repository) roleVersion(ctx context.Context, roleId string) (uint32, error) {
+	role := allocRole()
+	role.PublicId = roleId
+	if err := r.reader.LookupByPublicId(ctx, &role); err != nil {
+		return 0, fmt.Errorf("unable to lookup role %s: %w", roleId, err)
+	}
+	return role.Version, nil
+}
@@ -0,0 +1,143 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/clock"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TimeBound(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	t.Run("unbound", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		nb, na, err := timeBound(getOpts())
+		require.NoError(err)
+		assert.Nil(nb)
+		assert.Nil(na)
+	})
+	t.Run("window", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		nb, na, err := timeBound(getOpts(WithNotBefore(now), WithNotAfter(now.Add(time.Hour))))
+		require.NoError(err)
+		assert.Equal(now.Unix(), nb.Timestamp.Seconds)
+		assert.Equal(now.Add(time.Hour).Unix(), na.Timestamp.Seconds)
+	})
+	t.Run("empty-window", func(t *testing.T) {
+		assert := assert.New(t)
+		_, _, err := timeBound(getOpts(WithNotBefore(now), WithNotAfter(now)))
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+	})
+	t.Run("constructors", func(t *testing.T) {
+		assert := assert.New(t)
+		opt := []Option{WithNotBefore(now.Add(time.Hour)), WithNotAfter(now)}
+		_, err := NewRoleGrant("r_1234567890", "id=*;actions=read", opt...)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, err = NewUserRole("r_1234567890", "u_1234567890", opt...)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, err = NewGroupRole("r_1234567890", "g_1234567890", opt...)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+	})
+}
+
+func TestRepository_GrantsForUser_TimeBound(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	now := time.Now()
+
+	user := TestUser(t, repo, org.PublicId)
+	grp := TestGroup(t, conn, org.PublicId)
+	TestGroupMember(t, conn, grp.PublicId, user.PublicId)
+
+	current := TestRole(t, conn, org.PublicId)
+	TestUserRole(t, conn, current.PublicId, user.PublicId, WithNotAfter(now.Add(time.Hour)))
+	TestRoleGrant(t, conn, current.PublicId, "id=*;actions=read")
+	TestRoleGrant(t, conn, current.PublicId, "id=*;actions=update", WithNotAfter(now.Add(-time.Hour)))
+
+	future := TestRole(t, conn, org.PublicId)
+	TestUserRole(t, conn, future.PublicId, user.PublicId, WithNotBefore(now.Add(time.Hour)))
+	TestRoleGrant(t, conn, future.PublicId, "id=*;actions=delete")
+
+	expired := TestRole(t, conn, org.PublicId)
+	TestGroupRole(t, conn, expired.PublicId, grp.PublicId, WithNotBefore(now.Add(-2*time.Hour)), WithNotAfter(now.Add(-time.Hour)))
+	TestRoleGrant(t, conn, expired.PublicId, "id=*;actions=create")
+
+	got, err := repo.GrantsForUser(context.Background(), user.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, []perms.GrantPair{{ScopeId: org.PublicId, Grant: "id=*;actions=read"}}, got)
+}
+
+func TestRepository_DeleteExpiredGrants(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	ctx := context.Background()
+	now := time.Now()
+
+	user := TestUser(t, repo, org.PublicId)
+	grp := TestGroup(t, conn, org.PublicId)
+	role := TestRole(t, conn, org.PublicId)
+	TestRoleGrant(t, conn, role.PublicId, "id=*;actions=read")
+	TestRoleGrant(t, conn, role.PublicId, "id=*;actions=update", WithNotAfter(now.Add(-time.Hour)))
+	TestRoleGrant(t, conn, role.PublicId, "id=*;actions=delete", WithNotAfter(now.Add(time.Hour)))
+	TestUserRole(t, conn, role.PublicId, user.PublicId, WithNotAfter(now.Add(-time.Hour)))
+	TestGroupRole(t, conn, role.PublicId, grp.PublicId)
+
+	deleted, err := repo.DeleteExpiredGrants(ctx)
+	require.NoError(err)
+	assert.Equal(2, deleted)
+
+	_, principals, grants, err := repo.LookupRole(ctx, role.PublicId)
+	require.NoError(err)
+	require.Len(principals, 1)
+	assert.Equal(grp.PublicId, principals[0].PrincipalId)
+	var gotGrants []string
+	for _, g := range grants {
+		gotGrants = append(gotGrants, g.CanonicalGrant)
+	}
+	assert.ElementsMatch([]string{"id=*;actions=read", "id=*;actions=delete"}, gotGrants)
+
+	deleted, err = repo.DeleteExpiredGrants(ctx)
+	require.NoError(err)
+	assert.Equal(0, deleted)
+}
+
+func TestRepository_DeleteExpiredGrants_clock(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	ctx := context.Background()
+	c := clock.NewFake(time.Now())
+	clockRepo := TestRepo(t, conn, wrapper, WithClock(c))
+
+	role := TestRole(t, conn, org.PublicId)
+	TestRoleGrant(t, conn, role.PublicId, "id=*;actions=read", WithNotAfter(c.Now().Add(time.Hour)))
+
+	deleted, err := clockRepo.DeleteExpiredGrants(ctx)
+	require.NoError(err)
+	assert.Equal(0, deleted)
+
+	c.Add(2 * time.Hour)
+	deleted, err = clockRepo.DeleteExpiredGrants(ctx)
+	require.NoError(err)
+	assert.Equal(1, deleted)
+	grants, err := repo.ListRoleGrants(ctx, role.PublicId)
+	require.NoError(err)
+	assert.Empty(grants)
+}
@@ -52,13 +52,18 @@ func allocUser() User {
 }
 
 // Clone creates a clone of the User
-func (u *User) Clone() interface{} {
+func (u *User) Clone() *User {
 	cp := proto.Clone(u.User)
 	return &User{
 		User: cp.(*store.User),
 	}
 }
 
+// cloneResource implements Cloneable.
+func (u *User) cloneResource() Resource {
+	return u.Clone()
+}
+
 // VetForWrite implements db.VetForWrite() interface and validates the user
 // before it's written.
 func (u *User) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
@@ -68,6 +73,9 @@ func (u *User) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, o
 	if err := validateScopeForWrite(ctx, r, u, opType, opt...); err != nil {
 		return err
 	}
+	if err := validateLengthsForWrite(u, DefaultLengthLimits(), opType, opt...); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -85,7 +93,11 @@ func (*User) ResourceType() resource.Type { return resource.User }
 
 // Actions returns the  available actions for Users
 func (*User) Actions() map[string]action.Type {
-	return CrudActions()
+	ret := CrudActions()
+	ret[action.AddAccounts.String()] = action.AddAccounts
+	ret[action.SetAccounts.String()] = action.SetAccounts
+	ret[action.RemoveAccounts.String()] = action.RemoveAccounts
+	return ret
 }
 
 // TableName returns the tablename to override the default gorm table name
@@ -326,7 +326,7 @@ func TestUser_Clone(t *testing.T) {
 		assert := assert.New(t)
 		user := TestUser(t, repo, org.PublicId)
 		cp := user.Clone()
-		assert.True(proto.Equal(cp.(*User).User, user.User))
+		assert.True(proto.Equal(cp.User, user.User))
 	})
 	t.Run("not-equal-test", func(t *testing.T) {
 		assert := assert.New(t)
@@ -349,7 +349,18 @@ func TestUser_Clone(t *testing.T) {
 		assert.NoError(err)
 
 		cp := user.Clone()
-		assert.True(!proto.Equal(cp.(*User).User, user2.User))
+		assert.True(!proto.Equal(cp.User, user2.User))
+	})
+	t.Run("deep-copy", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		user := TestUser(t, repo, org.PublicId, WithName("user"))
+		require.NotNil(user.CreateTime)
+		createSeconds := user.CreateTime.Timestamp.Seconds
+		cp := user.Clone()
+		cp.Name = "clone"
+		cp.CreateTime.Timestamp.Seconds++
+		assert.Equal("user", user.Name)
+		assert.Equal(createSeconds, user.CreateTime.Timestamp.Seconds)
 	})
 }
 
@@ -361,6 +372,9 @@ func TestUser_Actions(t *testing.T) {
 	assert.Equal(a[action.Update.String()], action.Update)
 	assert.Equal(a[action.Read.String()], action.Read)
 	assert.Equal(a[action.Delete.String()], action.Delete)
+	assert.Equal(a[action.AddAccounts.String()], action.AddAccounts)
+	assert.Equal(a[action.SetAccounts.String()], action.SetAccounts)
+	assert.Equal(a[action.RemoveAccounts.String()], action.RemoveAccounts)
 
 	if _, ok := a[action.List.String()]; ok {
 		t.Errorf("users should not include %s as an action", action.List.String())
@@ -0,0 +1,231 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	oplogstore "github.com/hashicorp/boundary/internal/oplog/store"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+const (
+	// DefaultWatchInterval is how often a Watcher checks the oplog for new
+	// entries.
+	DefaultWatchInterval = time.Second
+
+	// watchBatchSize is the most entries read from the oplog at once.
+	watchBatchSize = 500
+
+	// watchGapTimeout is how long a Watcher waits for a skipped entry id to
+	// show up. Entry ids are allocated when a transaction writes its entry,
+	// so a transaction that commits after a later one leaves a gap for a
+	// while; one that rolls back leaves it forever.
+	watchGapTimeout = time.Minute
+)
+
+// watchAggregates maps the oplog aggregates a Watcher reports changes to onto
+// their resource types.
+var watchAggregates = map[string]resource.Type{
+	defaultScopeTableName:     resource.Scope,
+	defaultUserTableName:      resource.User,
+	defaultGroupTableName:     resource.Group,
+	defaultRoleTableName:      resource.Role,
+	defaultClaimRuleTableName: resource.ClaimRule,
+}
+
+// ChangeEvent is a change to an iam resource, read from an oplog entry. Changes
+// to a resource's children, like the grants and principals of a role, are
+// reported as changes to the resource. A single entry can have several
+// OpTypes, like one which sets a role's grants by deleting some and creating
+// others.
+type ChangeEvent struct {
+	EntryId      uint32
+	CreateTime   time.Time
+	ResourceType resource.Type
+	PublicId     string
+	ScopeId      string
+	OpTypes      []oplog.OpType
+}
+
+// Watcher tails the oplog for changes to iam resources.
+type Watcher struct {
+	reader   db.Reader
+	interval time.Duration
+	after    uint32
+	startAt  bool
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewWatcher creates a Watcher which reads the oplog with r. Supports the
+// options: WithWatchInterval and WithAfterEntryId, which makes the watcher
+// report changes after the entry instead of ones made after Watch is called.
+func NewWatcher(r db.Reader, opt ...Option) (*Watcher, error) {
+	if r == nil {
+		return nil, fmt.Errorf("new watcher: nil reader: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	w := &Watcher{
+		reader:   r,
+		interval: opts.withWatchInterval,
+	}
+	switch {
+	case w.interval < 0:
+		return nil, fmt.Errorf("new watcher: negative interval: %w", db.ErrInvalidParameter)
+	case w.interval == 0:
+		w.interval = DefaultWatchInterval
+	}
+	if opts.withAfterEntryId != nil {
+		w.after = *opts.withAfterEntryId
+		w.startAt = true
+	}
+	return w, nil
+}
+
+// Watch starts tailing the oplog and returns the channel changes are sent on,
+// in the order their entries were written. The channel is closed when ctx is
+// done or reading the oplog fails, after which Err returns the failure.
+func (w *Watcher) Watch(ctx context.Context) (<-chan *ChangeEvent, error) {
+	if !w.startAt {
+		last, err := w.lastEntryId(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("watch: %w", err)
+		}
+		w.after = last
+	}
+	events := make(chan *ChangeEvent)
+	go w.run(ctx, events)
+	return events, nil
+}
+
+// Err returns the error which stopped the last Watch, or nil if it stopped
+// because its context was done.
+func (w *Watcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+func (w *Watcher) run(ctx context.Context, events chan<- *ChangeEvent) {
+	defer close(events)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	// gaps holds the entry ids skipped so far, with when each was noticed.
+	gaps := map[uint32]time.Time{}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		changes, err := w.poll(ctx, gaps)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.mu.Lock()
+				w.err = fmt.Errorf("watch: %w", err)
+				w.mu.Unlock()
+			}
+			return
+		}
+		for _, c := range changes {
+			select {
+			case events <- c:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// poll returns the changes in entries written since the last poll, and in
+// the gaps which have since been filled.
+func (w *Watcher) poll(ctx context.Context, gaps map[uint32]time.Time) ([]*ChangeEvent, error) {
+	now := time.Now()
+	gapIds := make([]interface{}, 0, len(gaps))
+	for id, noticed := range gaps {
+		if now.Sub(noticed) > watchGapTimeout {
+			delete(gaps, id)
+			continue
+		}
+		gapIds = append(gapIds, id)
+	}
+
+	where, args := "id > ?", []interface{}{w.after}
+	if len(gapIds) > 0 {
+		where, args = "(id > ? or id in (?))", []interface{}{w.after, gapIds}
+	}
+	var entries []*oplogstore.Entry
+	if err := w.reader.SearchWhere(ctx, &entries, where, args, db.WithLimit(watchBatchSize), db.WithOrder("id")); err != nil {
+		return nil, fmt.Errorf("unable to list oplog entries: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	entryIds := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		entryIds = append(entryIds, e.Id)
+	}
+	var metadata []*oplogstore.Metadata
+	if err := w.reader.SearchWhere(ctx, &metadata, "entry_id in (?)", []interface{}{entryIds}, db.WithLimit(-1), db.WithOrder("id")); err != nil {
+		return nil, fmt.Errorf("unable to list oplog metadata: %w", err)
+	}
+	metadataByEntry := map[uint32]oplog.Metadata{}
+	for _, m := range metadata {
+		if metadataByEntry[m.EntryId] == nil {
+			metadataByEntry[m.EntryId] = oplog.Metadata{}
+		}
+		metadataByEntry[m.EntryId][m.Key] = append(metadataByEntry[m.EntryId][m.Key], m.Value)
+	}
+
+	var changes []*ChangeEvent
+	for _, e := range entries {
+		if _, ok := gaps[e.Id]; ok {
+			delete(gaps, e.Id)
+		} else {
+			for id := w.after + 1; id < e.Id; id++ {
+				gaps[id] = now
+			}
+			w.after = e.Id
+		}
+		typ, ok := watchAggregates[e.AggregateName]
+		if !ok {
+			continue
+		}
+		md := metadataByEntry[e.Id]
+		c := &ChangeEvent{
+			EntryId:      e.Id,
+			ResourceType: typ,
+		}
+		if e.CreateTime != nil {
+			c.CreateTime = e.CreateTime.GetTimestamp().AsTime()
+		}
+		if ids := md["resource-public-id"]; len(ids) > 0 {
+			c.PublicId = ids[0]
+		}
+		if ids := md["scope-id"]; len(ids) > 0 {
+			c.ScopeId = ids[0]
+		}
+		for _, op := range md["op-type"] {
+			c.OpTypes = append(c.OpTypes, oplog.OpType(oplog.OpType_value[op]))
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+func (w *Watcher) lastEntryId(ctx context.Context) (uint32, error) {
+	var entries []*oplogstore.Entry
+	if err := w.reader.SearchWhere(ctx, &entries, "id > ?", []interface{}{0}, db.WithLimit(1), db.WithOrder("id desc")); err != nil {
+		return 0, fmt.Errorf("unable to find the last oplog entry: %w", err)
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	return entries[0].Id, nil
+}
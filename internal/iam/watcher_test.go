@@ -0,0 +1,92 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWatcher(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+
+	t.Run("nil-reader", func(t *testing.T) {
+		assert := assert.New(t)
+		w, err := NewWatcher(nil)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		assert.Nil(w)
+	})
+	t.Run("negative-interval", func(t *testing.T) {
+		assert := assert.New(t)
+		w, err := NewWatcher(rw, WithWatchInterval(-time.Second))
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		assert.Nil(w)
+	})
+	t.Run("default-interval", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w, err := NewWatcher(rw)
+		require.NoError(err)
+		assert.Equal(DefaultWatchInterval, w.interval)
+	})
+}
+
+func TestWatcher_Watch(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w, err := NewWatcher(db.New(conn), WithWatchInterval(10*time.Millisecond))
+	require.NoError(err)
+	events, err := w.Watch(ctx)
+	require.NoError(err)
+
+	user := TestUser(t, repo, org.PublicId)
+	role := TestRole(t, conn, proj.PublicId)
+	_, err = repo.AddRoleGrants(context.Background(), role.PublicId, role.Version, []string{"id=*;type=*;actions=read"})
+	require.NoError(err)
+
+	next := func() *ChangeEvent {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(5 * time.Second):
+			require.FailNow("timed out waiting for a change")
+			return nil
+		}
+	}
+	var got []*ChangeEvent
+	for len(got) < 2 {
+		e := next()
+		require.NotNil(e)
+		got = append(got, e)
+	}
+	assert.Equal(resource.User, got[0].ResourceType)
+	assert.Equal(user.PublicId, got[0].PublicId)
+	assert.Equal(org.PublicId, got[0].ScopeId)
+	assert.Equal([]oplog.OpType{oplog.OpType_OP_TYPE_CREATE}, got[0].OpTypes)
+	assert.False(got[0].CreateTime.IsZero())
+
+	assert.Equal(resource.Role, got[1].ResourceType)
+	assert.Equal(role.PublicId, got[1].PublicId)
+	assert.Equal(proj.PublicId, got[1].ScopeId)
+	assert.Equal([]oplog.OpType{oplog.OpType_OP_TYPE_CREATE}, got[1].OpTypes)
+	assert.True(got[1].EntryId > got[0].EntryId)
+
+	cancel()
+	for range events {
+	}
+	assert.NoError(w.Err())
+}
@@ -0,0 +1,196 @@
+package iam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/perms"
+)
+
+// ErrWriteRejected is returned when a write hook rejects the write of a role
+// or role grant.
+var ErrWriteRejected = errors.New("write rejected by policy")
+
+// WriteHookRequest describes a role or role grant about to be written, for
+// write hooks to check against policy.
+type WriteHookRequest struct {
+	// Operation is "create" or "update".
+	Operation string `json:"operation"`
+
+	// RoleId, ScopeId, GrantScopeId and RoleName describe the role being
+	// written, or the role of the grant being written. For role updates,
+	// they're the values being written, so unchanged fields may be empty.
+	RoleId       string `json:"role_id"`
+	ScopeId      string `json:"scope_id,omitempty"`
+	GrantScopeId string `json:"grant_scope_id,omitempty"`
+	RoleName     string `json:"role_name,omitempty"`
+
+	// Grant is the canonical grant being written, and is empty for role
+	// writes. GrantId, GrantType and GrantActions are its parts.
+	Grant        string   `json:"grant,omitempty"`
+	GrantId      string   `json:"grant_id,omitempty"`
+	GrantType    string   `json:"grant_type,omitempty"`
+	GrantActions []string `json:"grant_actions,omitempty"`
+
+	// ParsedGrant is the grant being written, parsed in the grant scope of
+	// its role, or nil for role writes.
+	ParsedGrant *perms.Grant `json:"-"`
+}
+
+// WriteHook is consulted before roles and role grants are written, and can
+// reject writes which violate a deployment's policy, like wildcard grants in
+// production scopes. Returning an error rejects the write; the error is
+// returned to the caller wrapping ErrWriteRejected.
+type WriteHook interface {
+	VetWrite(ctx context.Context, req *WriteHookRequest) error
+}
+
+// WriteHookFunc is a function which can be registered as a WriteHook.
+type WriteHookFunc func(ctx context.Context, req *WriteHookRequest) error
+
+// VetWrite calls f.
+func (f WriteHookFunc) VetWrite(ctx context.Context, req *WriteHookRequest) error {
+	return f(ctx, req)
+}
+
+var writeHooks struct {
+	sync.RWMutex
+	nextId int
+	hooks  map[int]WriteHook
+}
+
+// RegisterWriteHook registers a hook to be consulted before every write of a
+// role or role grant, in the order they're registered. It returns a function
+// which unregisters the hook.
+func RegisterWriteHook(h WriteHook) (unregister func()) {
+	writeHooks.Lock()
+	defer writeHooks.Unlock()
+	if writeHooks.hooks == nil {
+		writeHooks.hooks = make(map[int]WriteHook)
+	}
+	id := writeHooks.nextId
+	writeHooks.nextId++
+	writeHooks.hooks[id] = h
+	return func() {
+		writeHooks.Lock()
+		defer writeHooks.Unlock()
+		delete(writeHooks.hooks, id)
+	}
+}
+
+// vetWriteHooks consults the registered write hooks, returning the error of
+// the first one to reject the write.
+func vetWriteHooks(ctx context.Context, opType db.OpType, req *WriteHookRequest) error {
+	writeHooks.RLock()
+	ids := make([]int, 0, len(writeHooks.hooks))
+	for id := range writeHooks.hooks {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	hooks := make([]WriteHook, 0, len(ids))
+	for _, id := range ids {
+		hooks = append(hooks, writeHooks.hooks[id])
+	}
+	writeHooks.RUnlock()
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	switch opType {
+	case db.CreateOp:
+		req.Operation = "create"
+	case db.UpdateOp:
+		req.Operation = "update"
+	}
+	if g := req.ParsedGrant; g != nil {
+		req.Grant = g.CanonicalString()
+		req.GrantId = g.Id()
+		req.GrantType = g.Type().String()
+		_, req.GrantActions = g.Actions()
+		sort.Strings(req.GrantActions)
+	}
+	for _, h := range hooks {
+		if err := h.VetWrite(ctx, req); err != nil {
+			return fmt.Errorf("%v: %w", err, ErrWriteRejected)
+		}
+	}
+	return nil
+}
+
+// DefaultHTTPWriteHookTimeout is how long an HTTPWriteHook waits for its
+// endpoint, unless its Client sets a timeout.
+const DefaultHTTPWriteHookTimeout = 10 * time.Second
+
+// HTTPWriteHook is a WriteHook which asks an external endpoint, like an Open
+// Policy Agent decision, whether to allow writes. It POSTs the
+// WriteHookRequest as JSON wrapped in an "input" object, and expects a 200
+// response whose body is an object with an "allow" boolean and an optional
+// "reason" string, either at the top level or wrapped in a "result" object
+// as OPA's data API returns them. Writes are rejected if the endpoint can't be
+// reached or doesn't allow them.
+type HTTPWriteHook struct {
+	// Url is the endpoint to POST requests to.
+	Url string
+
+	// Client is used to make requests. If it's nil, a client with the
+	// DefaultHTTPWriteHookTimeout is used.
+	Client *http.Client
+}
+
+type httpWriteHookDecision struct {
+	Allow  bool   `json:"allow"`
+	Reason string `json:"reason"`
+}
+
+// VetWrite asks the hook's endpoint whether to allow the write.
+func (h *HTTPWriteHook) VetWrite(ctx context.Context, req *WriteHookRequest) error {
+	body, err := json.Marshal(struct {
+		Input *WriteHookRequest `json:"input"`
+	}{Input: req})
+	if err != nil {
+		return fmt.Errorf("http write hook: unable to encode request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.Url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("http write hook: unable to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	client := h.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPWriteHookTimeout}
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("http write hook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http write hook: unexpected status %s", resp.Status)
+	}
+	var decision struct {
+		httpWriteHookDecision
+		Result *httpWriteHookDecision `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return fmt.Errorf("http write hook: unable to decode response: %w", err)
+	}
+	d := decision.httpWriteHookDecision
+	if decision.Result != nil {
+		d = *decision.Result
+	}
+	if !d.Allow {
+		if d.Reason == "" {
+			d.Reason = "not allowed"
+		}
+		return fmt.Errorf("http write hook: %s", d.Reason)
+	}
+	return nil
+}
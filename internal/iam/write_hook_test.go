@@ -0,0 +1,109 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteHooks(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	ctx := context.Background()
+
+	// Hooks are consulted for every write in the process, so only police the
+	// org created by this test.
+	var requests []WriteHookRequest
+	unregister := RegisterWriteHook(WriteHookFunc(func(ctx context.Context, req *WriteHookRequest) error {
+		if req.ScopeId != org.PublicId {
+			return nil
+		}
+		requests = append(requests, *req)
+		if req.RoleName == "forbidden" {
+			return errors.New("forbidden role name")
+		}
+		if req.ParsedGrant != nil && req.ParsedGrant.Id() == "*" {
+			return errors.New("no wildcard grants in production")
+		}
+		return nil
+	}))
+	defer unregister()
+
+	role, err := NewRole(org.PublicId, WithName("forbidden"))
+	require.NoError(t, err)
+	_, err = repo.CreateRole(ctx, role)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrWriteRejected))
+	assert.Contains(t, err.Error(), "forbidden role name")
+
+	role = TestRole(t, conn, org.PublicId)
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, role.Version, []string{"id=*;type=target;actions=read"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrWriteRejected))
+
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, role.Version, []string{"id=ttcp_1234567890;actions=read,authorize-session"})
+	require.NoError(t, err)
+
+	var last WriteHookRequest
+	for _, r := range requests {
+		if r.ParsedGrant != nil {
+			last = r
+		}
+	}
+	assert.Equal(t, "create", last.Operation)
+	assert.Equal(t, role.PublicId, last.RoleId)
+	assert.Equal(t, "id=ttcp_1234567890;actions=authorize-session,read", last.Grant)
+	assert.Equal(t, "ttcp_1234567890", last.GrantId)
+	assert.Equal(t, []string{"authorize-session", "read"}, last.GrantActions)
+
+	// Once unregistered, the hook isn't consulted
+	unregister()
+	_, err = repo.AddRoleGrants(ctx, role.PublicId, role.Version+1, []string{"id=*;type=target;actions=read"})
+	require.NoError(t, err)
+}
+
+func TestHTTPWriteHook(t *testing.T) {
+	t.Parallel()
+	var got struct {
+		Input WriteHookRequest `json:"input"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		switch got.Input.GrantId {
+		case "*":
+			w.Write([]byte(`{"result": {"allow": false, "reason": "no wildcard grants"}}`))
+		case "error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte(`{"allow": true}`))
+		}
+	}))
+	defer srv.Close()
+	h := &HTTPWriteHook{Url: srv.URL}
+	ctx := context.Background()
+
+	req := &WriteHookRequest{Operation: "create", RoleId: "r_1234567890", ScopeId: "o_1234567890", GrantId: "ttcp_1234567890"}
+	require.NoError(t, h.VetWrite(ctx, req))
+	assert.Equal(t, *req, got.Input)
+
+	req.GrantId = "*"
+	err := h.VetWrite(ctx, req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no wildcard grants")
+
+	req.GrantId = "error"
+	assert.Error(t, h.VetWrite(ctx, req))
+
+	h.Url = "http://127.0.0.1:1"
+	assert.Error(t, h.VetWrite(ctx, req))
+}
@@ -0,0 +1,73 @@
+// Package jobs runs long-running admin operations, like deleting an org and
+// its projects, in the background instead of within an HTTP request which
+// can time out. Each run is recorded as a job, whose status, progress and
+// result can be looked up, and which can be canceled.
+package jobs
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// JobPrefix is the prefix of the public ids of jobs.
+const JobPrefix = "job"
+
+// Status is the status of a job.
+type Status string
+
+const (
+	// Running is the status of a job which hasn't finished yet.
+	Running Status = "running"
+	// Succeeded is the status of a job which finished without an error.
+	Succeeded Status = "succeeded"
+	// Failed is the status of a job which finished with an error, or whose
+	// controller stopped running it.
+	Failed Status = "failed"
+	// Canceled is the status of a job which stopped because it was canceled.
+	Canceled Status = "canceled"
+)
+
+// Job is a run of a long-running operation. ProgressDone and ProgressTotal
+// report how far along it is, in units of the operation's choosing, like the
+// number of scopes deleted out of the number to delete. Once it's over,
+// Result holds its json result, or Error its error.
+type Job struct {
+	PublicId        string          `json:"id"`
+	ScopeId         string          `json:"scope_id"`
+	UserId          string          `json:"user_id"`
+	Type            string          `json:"type"`
+	ControllerId    string          `json:"controller_id"`
+	Status          Status          `json:"status"`
+	ProgressDone    int64           `json:"progress_done"`
+	ProgressTotal   int64           `json:"progress_total"`
+	Result          json.RawMessage `json:"result,omitempty"`
+	Error           string          `json:"error,omitempty"`
+	CancelRequested bool            `json:"cancel_requested"`
+	CreateTime      time.Time       `json:"create_time"`
+	UpdateTime      time.Time       `json:"update_time"`
+	EndTime         *time.Time      `json:"end_time,omitempty"`
+}
+
+// scanJobs scans the rows of jobColumns.
+func scanJobs(rows *sql.Rows) ([]*Job, error) {
+	defer rows.Close()
+	var jobs []*Job
+	for rows.Next() {
+		var j Job
+		var result, jobErr sql.NullString
+		if err := rows.Scan(&j.PublicId, &j.ScopeId, &j.UserId, &j.Type, &j.ControllerId, &j.Status, &j.ProgressDone, &j.ProgressTotal, &result, &jobErr, &j.CancelRequested, &j.CreateTime, &j.UpdateTime, &j.EndTime); err != nil {
+			return nil, fmt.Errorf("unable to scan job: %w", err)
+		}
+		if result.Valid {
+			j.Result = json.RawMessage(result.String)
+		}
+		j.Error = jobErr.String
+		jobs = append(jobs, &j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
@@ -0,0 +1,73 @@
+package jobs
+
+const (
+	jobColumns = `public_id, scope_id, user_id, type, controller_id, status, progress_done, progress_total, result, error, cancel_requested, create_time, update_time, end_time`
+
+	insertJob = `
+insert into job
+  (public_id, scope_id, user_id, type, controller_id)
+values
+  ($1, $2, $3, $4, $5);
+`
+
+	lookupJob = `select ` + jobColumns + ` from job where public_id = $1;`
+
+	listJobs = `
+select ` + jobColumns + `
+  from job
+ where scope_id = $1
+ order by create_time desc, public_id;
+`
+
+	// updateJobProgress also serves as the heartbeat of the job, by which
+	// stale jobs are found.
+	updateJobProgress = `
+update job
+   set progress_done = $2,
+       progress_total = $3,
+       update_time = now()
+ where public_id = $1
+   and status = 'running';
+`
+
+	touchJob = `
+update job
+   set update_time = now()
+ where public_id = $1
+   and status = 'running';
+`
+
+	lookupJobCancelRequested = `select cancel_requested from job where public_id = $1;`
+
+	finishJob = `
+update job
+   set status = $2,
+       result = $3,
+       error = $4,
+       update_time = now(),
+       end_time = now()
+ where public_id = $1
+   and status = 'running';
+`
+
+	requestJobCancel = `
+update job
+   set cancel_requested = true,
+       update_time = now()
+ where public_id = $1
+   and status = 'running';
+`
+
+	// failStaleJobs fails the running jobs which haven't reported progress or
+	// a heartbeat for $1 seconds, because the controller running them has
+	// gone.
+	failStaleJobs = `
+update job
+   set status = 'failed',
+       error = 'job stopped reporting progress',
+       update_time = now(),
+       end_time = now()
+ where status = 'running'
+   and update_time < now() - make_interval(secs => $1);
+`
+)
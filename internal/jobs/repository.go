@@ -0,0 +1,196 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// A Repository stores and retrieves jobs.
+type Repository struct {
+	reader db.Reader
+	writer db.Writer
+}
+
+// NewRepository creates a new Repository.
+func NewRepository(r db.Reader, w db.Writer) (*Repository, error) {
+	switch {
+	case r == nil:
+		return nil, fmt.Errorf("new job repository: missing reader: %w", db.ErrInvalidParameter)
+	case w == nil:
+		return nil, fmt.Errorf("new job repository: missing writer: %w", db.ErrInvalidParameter)
+	}
+	return &Repository{
+		reader: r,
+		writer: w,
+	}, nil
+}
+
+// CreateJob records a running job of the type, in the scope, started by the
+// user on the controller, and returns it.
+func (r *Repository) CreateJob(ctx context.Context, scopeId, userId, jobType, controllerId string) (*Job, error) {
+	switch {
+	case scopeId == "":
+		return nil, fmt.Errorf("create job: missing scope id: %w", db.ErrInvalidParameter)
+	case userId == "":
+		return nil, fmt.Errorf("create job: missing user id: %w", db.ErrInvalidParameter)
+	case jobType == "":
+		return nil, fmt.Errorf("create job: missing type: %w", db.ErrInvalidParameter)
+	case controllerId == "":
+		return nil, fmt.Errorf("create job: missing controller id: %w", db.ErrInvalidParameter)
+	}
+	id, err := db.NewPublicId(JobPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("create job: %w", err)
+	}
+	if _, err := r.writer.Exec(ctx, insertJob, []interface{}{id, scopeId, userId, jobType, controllerId}); err != nil {
+		return nil, fmt.Errorf("create job: %w", err)
+	}
+	j, err := r.LookupJob(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("create job: %w", err)
+	}
+	return j, nil
+}
+
+// LookupJob returns the job, or nil if it isn't found.
+func (r *Repository) LookupJob(ctx context.Context, id string) (*Job, error) {
+	if id == "" {
+		return nil, fmt.Errorf("lookup job: missing id: %w", db.ErrInvalidParameter)
+	}
+	rows, err := r.reader.Query(ctx, lookupJob, []interface{}{id})
+	if err != nil {
+		return nil, fmt.Errorf("lookup job: %w for %s", err, id)
+	}
+	jobs, err := scanJobs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("lookup job: %w for %s", err, id)
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return jobs[0], nil
+}
+
+// ListJobs returns the jobs in the scope, newest first.
+func (r *Repository) ListJobs(ctx context.Context, scopeId string) ([]*Job, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("list jobs: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	rows, err := r.reader.Query(ctx, listJobs, []interface{}{scopeId})
+	if err != nil {
+		return nil, fmt.Errorf("list jobs: %w for %s", err, scopeId)
+	}
+	jobs, err := scanJobs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("list jobs: %w for %s", err, scopeId)
+	}
+	return jobs, nil
+}
+
+// CancelJob asks the controller running the job to stop it and returns the
+// job. A job which is already over is returned unchanged. It returns nil if
+// the job isn't found.
+func (r *Repository) CancelJob(ctx context.Context, id string) (*Job, error) {
+	if id == "" {
+		return nil, fmt.Errorf("cancel job: missing id: %w", db.ErrInvalidParameter)
+	}
+	if _, err := r.writer.Exec(ctx, requestJobCancel, []interface{}{id}); err != nil {
+		return nil, fmt.Errorf("cancel job: %w for %s", err, id)
+	}
+	j, err := r.LookupJob(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cancel job: %w", err)
+	}
+	return j, nil
+}
+
+// SetJobProgress records how far along the running job is, and returns
+// whether it has been asked to stop.
+func (r *Repository) SetJobProgress(ctx context.Context, id string, done, total int64) (bool, error) {
+	switch {
+	case id == "":
+		return false, fmt.Errorf("set job progress: missing id: %w", db.ErrInvalidParameter)
+	case done < 0 || total < 0:
+		return false, fmt.Errorf("set job progress: negative progress: %w", db.ErrInvalidParameter)
+	}
+	if _, err := r.writer.Exec(ctx, updateJobProgress, []interface{}{id, done, total}); err != nil {
+		return false, fmt.Errorf("set job progress: %w for %s", err, id)
+	}
+	cancelRequested, err := r.cancelRequested(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("set job progress: %w for %s", err, id)
+	}
+	return cancelRequested, nil
+}
+
+// touchJob records that the running job is still running, and returns
+// whether it has been asked to stop.
+func (r *Repository) touchJob(ctx context.Context, id string) (bool, error) {
+	if _, err := r.writer.Exec(ctx, touchJob, []interface{}{id}); err != nil {
+		return false, fmt.Errorf("touch job: %w for %s", err, id)
+	}
+	cancelRequested, err := r.cancelRequested(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("touch job: %w for %s", err, id)
+	}
+	return cancelRequested, nil
+}
+
+func (r *Repository) cancelRequested(ctx context.Context, id string) (bool, error) {
+	rows, err := r.reader.Query(ctx, lookupJobCancelRequested, []interface{}{id})
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	var cancelRequested bool
+	if rows.Next() {
+		if err := rows.Scan(&cancelRequested); err != nil {
+			return false, err
+		}
+	}
+	return cancelRequested, rows.Err()
+}
+
+// FinishJob records that the running job is over, with the status, and the
+// result, which is marshaled to json, or the error.
+func (r *Repository) FinishJob(ctx context.Context, id string, status Status, result interface{}, jobErr error) error {
+	switch {
+	case id == "":
+		return fmt.Errorf("finish job: missing id: %w", db.ErrInvalidParameter)
+	case status == Running || status == "":
+		return fmt.Errorf("finish job: invalid status %q: %w", status, db.ErrInvalidParameter)
+	}
+	var resultArg, errArg interface{}
+	if result != nil {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("finish job: unable to marshal result: %w", err)
+		}
+		resultArg = string(b)
+	}
+	if jobErr != nil {
+		errArg = jobErr.Error()
+	}
+	if _, err := r.writer.Exec(ctx, finishJob, []interface{}{id, string(status), resultArg, errArg}); err != nil {
+		return fmt.Errorf("finish job: %w for %s", err, id)
+	}
+	return nil
+}
+
+// FailStaleJobs fails the running jobs which haven't reported progress for
+// longer than staleness, because the controller running them has gone, and
+// returns the number failed.
+func (r *Repository) FailStaleJobs(ctx context.Context, staleness time.Duration) (int, error) {
+	if staleness <= 0 {
+		return db.NoRowsAffected, fmt.Errorf("fail stale jobs: staleness must be positive: %w", db.ErrInvalidParameter)
+	}
+	rowsUpdated, err := r.writer.Exec(ctx, failStaleJobs, []interface{}{staleness.Seconds()})
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("fail stale jobs: %w", err)
+	}
+	return rowsUpdated, nil
+}
@@ -0,0 +1,79 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Jobs(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	rw := db.New(conn)
+	repo, err := NewRepository(rw, rw)
+	require.NoError(err)
+	ctx := context.Background()
+
+	_, err = repo.CreateJob(ctx, "", "u_auth", "delete-scope", "controller")
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+
+	j, err := repo.CreateJob(ctx, org.PublicId, "u_auth", "delete-scope", "controller")
+	require.NoError(err)
+	assert.Equal(Running, j.Status)
+	assert.Nil(j.EndTime)
+
+	cancelRequested, err := repo.SetJobProgress(ctx, j.PublicId, 1, 3)
+	require.NoError(err)
+	assert.False(cancelRequested)
+
+	j, err = repo.CancelJob(ctx, j.PublicId)
+	require.NoError(err)
+	assert.True(j.CancelRequested)
+	cancelRequested, err = repo.SetJobProgress(ctx, j.PublicId, 2, 3)
+	require.NoError(err)
+	assert.True(cancelRequested)
+
+	require.NoError(repo.FinishJob(ctx, j.PublicId, Canceled, map[string]int{"deleted": 2}, context.Canceled))
+	j, err = repo.LookupJob(ctx, j.PublicId)
+	require.NoError(err)
+	assert.Equal(Canceled, j.Status)
+	assert.Equal(int64(2), j.ProgressDone)
+	assert.Equal(int64(3), j.ProgressTotal)
+	assert.JSONEq(`{"deleted":2}`, string(j.Result))
+	assert.Equal(context.Canceled.Error(), j.Error)
+	assert.NotNil(j.EndTime)
+
+	// A job which is over can't be finished again.
+	require.NoError(repo.FinishJob(ctx, j.PublicId, Succeeded, nil, nil))
+	j, err = repo.LookupJob(ctx, j.PublicId)
+	require.NoError(err)
+	assert.Equal(Canceled, j.Status)
+
+	stale, err := repo.CreateJob(ctx, org.PublicId, "u_auth", "import-snapshot", "gone")
+	require.NoError(err)
+	_, err = rw.Exec(ctx, "update job set update_time = now() - interval '1 hour' where public_id = $1", []interface{}{stale.PublicId})
+	require.NoError(err)
+	failed, err := repo.FailStaleJobs(ctx, time.Minute)
+	require.NoError(err)
+	assert.Equal(1, failed)
+	stale, err = repo.LookupJob(ctx, stale.PublicId)
+	require.NoError(err)
+	assert.Equal(Failed, stale.Status)
+
+	jobs, err := repo.ListJobs(ctx, org.PublicId)
+	require.NoError(err)
+	assert.Len(jobs, 2)
+
+	missing, err := repo.LookupJob(ctx, "job_1234567890")
+	require.NoError(err)
+	assert.Nil(missing)
+}
@@ -0,0 +1,203 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/go-hclog"
+	ua "go.uber.org/atomic"
+)
+
+// HeartbeatInterval is how often a runner records that the jobs it's running
+// are still running, and checks whether they've been asked to stop. This is
+// exported so it can be tweaked in tests.
+var HeartbeatInterval = 30 * time.Second
+
+// RunFunc runs a job's operation, reporting its progress to p, and returns
+// its result, which is marshaled to json. It must return when ctx is done,
+// which happens when the job is canceled.
+type RunFunc func(ctx context.Context, p *Progress) (interface{}, error)
+
+// Progress reports the progress of a running job.
+type Progress struct {
+	repoFn   func() (*Repository, error)
+	jobId    string
+	cancel   context.CancelFunc
+	canceled *ua.Bool
+}
+
+// Set records that done out of total units of the job's work are done. If
+// the job has been asked to stop, its context is canceled.
+func (p *Progress) Set(ctx context.Context, done, total int64) error {
+	repo, err := p.repoFn()
+	if err != nil {
+		return fmt.Errorf("set progress: %w", err)
+	}
+	cancelRequested, err := repo.SetJobProgress(ctx, p.jobId, done, total)
+	if err != nil {
+		return fmt.Errorf("set progress: %w", err)
+	}
+	if cancelRequested {
+		p.canceled.Store(true)
+		p.cancel()
+	}
+	return nil
+}
+
+// A Runner runs jobs in the background of a controller. Jobs which are still
+// running when the runner's context is done are stopped and failed.
+type Runner struct {
+	baseCtx      context.Context
+	repoFn       func() (*Repository, error)
+	controllerId string
+	logger       hclog.Logger
+
+	mu      sync.Mutex
+	running map[string]*Progress
+	wg      sync.WaitGroup
+}
+
+// NewRunner creates a Runner for the controller, which runs jobs until ctx is
+// done.
+func NewRunner(ctx context.Context, repoFn func() (*Repository, error), controllerId string, logger hclog.Logger) (*Runner, error) {
+	switch {
+	case ctx == nil:
+		return nil, fmt.Errorf("new job runner: missing context: %w", db.ErrInvalidParameter)
+	case repoFn == nil:
+		return nil, fmt.Errorf("new job runner: missing repository: %w", db.ErrInvalidParameter)
+	case controllerId == "":
+		return nil, fmt.Errorf("new job runner: missing controller id: %w", db.ErrInvalidParameter)
+	case logger == nil:
+		return nil, fmt.Errorf("new job runner: missing logger: %w", db.ErrInvalidParameter)
+	}
+	return &Runner{
+		baseCtx:      ctx,
+		repoFn:       repoFn,
+		controllerId: controllerId,
+		logger:       logger,
+		running:      make(map[string]*Progress),
+	}, nil
+}
+
+// Start records a job of the type, in the scope, started by the user, runs
+// fn for it in the background and returns the job without waiting for it.
+func (r *Runner) Start(ctx context.Context, scopeId, userId, jobType string, fn RunFunc) (*Job, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("start job: missing run func: %w", db.ErrInvalidParameter)
+	}
+	repo, err := r.repoFn()
+	if err != nil {
+		return nil, fmt.Errorf("start job: %w", err)
+	}
+	j, err := repo.CreateJob(ctx, scopeId, userId, jobType, r.controllerId)
+	if err != nil {
+		return nil, fmt.Errorf("start job: %w", err)
+	}
+	jobCtx, cancel := context.WithCancel(r.baseCtx)
+	p := &Progress{
+		repoFn:   r.repoFn,
+		jobId:    j.PublicId,
+		cancel:   cancel,
+		canceled: ua.NewBool(false),
+	}
+	r.mu.Lock()
+	r.running[j.PublicId] = p
+	r.mu.Unlock()
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer cancel()
+		go r.heartbeat(jobCtx, p)
+
+		result, err := fn(jobCtx, p)
+		status := Succeeded
+		switch {
+		case err == nil:
+		case p.canceled.Load():
+			status = Canceled
+		default:
+			status = Failed
+		}
+
+		r.mu.Lock()
+		delete(r.running, p.jobId)
+		r.mu.Unlock()
+
+		// The job's context may be done, so record how it ended with one
+		// which isn't.
+		finishCtx, finishCancel := context.WithTimeout(context.Background(), time.Minute)
+		defer finishCancel()
+		repo, repoErr := r.repoFn()
+		if repoErr == nil {
+			repoErr = repo.FinishJob(finishCtx, p.jobId, status, result, err)
+		}
+		if repoErr != nil {
+			r.logger.Error("error recording end of job", "job_id", p.jobId, "status", status, "error", repoErr)
+			return
+		}
+		r.logger.Info("job finished", "job_id", p.jobId, "type", jobType, "status", status)
+	}()
+	return j, nil
+}
+
+// heartbeat records that the job is still running until its context is done,
+// and cancels it if it has been asked to stop, which may have been done
+// through another controller.
+func (r *Runner) heartbeat(ctx context.Context, p *Progress) {
+	timer := time.NewTimer(HeartbeatInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			repo, err := r.repoFn()
+			if err == nil {
+				var cancelRequested bool
+				cancelRequested, err = repo.touchJob(ctx, p.jobId)
+				if cancelRequested {
+					p.canceled.Store(true)
+					p.cancel()
+					return
+				}
+			}
+			if err != nil && !errors.Is(err, context.Canceled) {
+				r.logger.Error("error recording job heartbeat", "job_id", p.jobId, "error", err)
+			}
+			timer.Reset(HeartbeatInterval)
+		}
+	}
+}
+
+// Cancel asks the job to stop and returns it. If this runner is running the
+// job it's stopped right away; otherwise the runner running it stops it at
+// its next heartbeat or progress report. It returns nil if the job isn't
+// found.
+func (r *Runner) Cancel(ctx context.Context, id string) (*Job, error) {
+	repo, err := r.repoFn()
+	if err != nil {
+		return nil, fmt.Errorf("cancel job: %w", err)
+	}
+	j, err := repo.CancelJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	p, ok := r.running[id]
+	r.mu.Unlock()
+	if ok {
+		p.canceled.Store(true)
+		p.cancel()
+	}
+	return j, nil
+}
+
+// Wait waits for the jobs the runner is running to finish.
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
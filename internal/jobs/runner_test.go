@@ -0,0 +1,95 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	rw := db.New(conn)
+	repoFn := func() (*Repository, error) {
+		return NewRepository(rw, rw)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runner, err := NewRunner(ctx, repoFn, "controller", hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	t.Run("succeeds", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		j, err := runner.Start(ctx, org.PublicId, "u_auth", "test", func(ctx context.Context, p *Progress) (interface{}, error) {
+			if err := p.Set(ctx, 1, 1); err != nil {
+				return nil, err
+			}
+			return "done", nil
+		})
+		require.NoError(err)
+		assert.Equal(Running, j.Status)
+		runner.Wait()
+
+		repo, err := repoFn()
+		require.NoError(err)
+		j, err = repo.LookupJob(ctx, j.PublicId)
+		require.NoError(err)
+		assert.Equal(Succeeded, j.Status)
+		assert.Equal(int64(1), j.ProgressDone)
+		assert.JSONEq(`"done"`, string(j.Result))
+	})
+	t.Run("fails", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		j, err := runner.Start(ctx, org.PublicId, "u_auth", "test", func(ctx context.Context, p *Progress) (interface{}, error) {
+			return nil, errors.New("broken")
+		})
+		require.NoError(err)
+		runner.Wait()
+
+		repo, err := repoFn()
+		require.NoError(err)
+		j, err = repo.LookupJob(ctx, j.PublicId)
+		require.NoError(err)
+		assert.Equal(Failed, j.Status)
+		assert.Equal("broken", j.Error)
+	})
+	t.Run("canceled", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		started := make(chan struct{})
+		j, err := runner.Start(ctx, org.PublicId, "u_auth", "test", func(ctx context.Context, p *Progress) (interface{}, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		require.NoError(err)
+		<-started
+		_, err = runner.Cancel(ctx, j.PublicId)
+		require.NoError(err)
+
+		done := make(chan struct{})
+		go func() {
+			runner.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Fatal("canceled job didn't stop")
+		}
+
+		repo, err := repoFn()
+		require.NoError(err)
+		j, err = repo.LookupJob(ctx, j.PublicId)
+		require.NoError(err)
+		assert.Equal(Canceled, j.Status)
+	})
+}
@@ -0,0 +1,154 @@
+package kms
+
+import (
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+)
+
+const (
+	// DefaultCacheLifetime is how long a Kms keeps the unwrapped keys of a
+	// scope and purpose when WithCacheLifetime isn't set.
+	DefaultCacheLifetime = 15 * time.Minute
+
+	// retiredKeyGrace is how long the keys of an evicted wrapper are kept
+	// before they're zeroed, so callers that got the wrapper just before it
+	// was evicted can finish using it.
+	retiredKeyGrace = time.Minute
+)
+
+var metricsPrefix = []string{"boundary", "kms"}
+
+// cachedDek is a wrapper in the scope-purpose cache along with the key bytes
+// it was built from.
+type cachedDek struct {
+	wrapper *multiwrapper.MultiWrapper
+	keys    [][]byte
+	expires time.Time
+}
+
+// retiredKeys are key bytes waiting to be zeroed.
+type retiredKeys struct {
+	keys    [][]byte
+	retired time.Time
+}
+
+// cachedWrapper returns the cached wrapper for the scope-purpose key, if it
+// hasn't expired.
+func (k *Kms) cachedWrapper(cacheKey string) (*multiwrapper.MultiWrapper, bool) {
+	val, ok := k.scopePurposeCache.Load(cacheKey)
+	if !ok {
+		return nil, false
+	}
+	k.dekMutex.Lock()
+	defer k.dekMutex.Unlock()
+	e := k.dekEntries[cacheKey]
+	if e == nil || e.wrapper != val || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.wrapper, true
+}
+
+// storeWrapper caches the wrapper for the scope-purpose key, retiring the keys
+// of the wrapper it replaces.
+func (k *Kms) storeWrapper(cacheKey string, wrapper *multiwrapper.MultiWrapper, keys [][]byte) {
+	k.dekMutex.Lock()
+	defer k.dekMutex.Unlock()
+	now := time.Now()
+	if old := k.dekEntries[cacheKey]; old != nil {
+		k.retired = append(k.retired, retiredKeys{keys: old.keys, retired: now})
+	}
+	k.dekEntries[cacheKey] = &cachedDek{
+		wrapper: wrapper,
+		keys:    keys,
+		expires: now.Add(k.cacheLifetime),
+	}
+	k.scopePurposeCache.Store(cacheKey, wrapper)
+}
+
+// EvictExpiredKeys removes the wrappers which have outlived the cache lifetime,
+// so their keys are unwrapped from the database on next use, and zeroes the
+// keys of wrappers evicted more than a minute ago. It returns the number of
+// wrappers evicted.
+func (k *Kms) EvictExpiredKeys() int {
+	k.dekMutex.Lock()
+	defer k.dekMutex.Unlock()
+	now := time.Now()
+	var evicted int
+	for cacheKey, e := range k.dekEntries {
+		if !now.After(e.expires) {
+			continue
+		}
+		k.scopePurposeCache.Delete(cacheKey)
+		delete(k.dekEntries, cacheKey)
+		k.retired = append(k.retired, retiredKeys{keys: e.keys, retired: now})
+		evicted++
+	}
+	kept := k.retired[:0]
+	for _, r := range k.retired {
+		if now.Sub(r.retired) < retiredKeyGrace {
+			kept = append(kept, r)
+			continue
+		}
+		zeroKeys(r.keys)
+	}
+	k.retired = kept
+	if evicted > 0 {
+		k.metrics.IncrCounter(append(metricsPrefix, "cache", "evictions"), float32(evicted))
+	}
+	return evicted
+}
+
+// ClearCache removes every wrapper from the cache and zeroes their keys. It's
+// meant to be called on shutdown; wrappers returned by GetWrapper before the
+// call must not be used after it.
+func (k *Kms) ClearCache() {
+	k.dekMutex.Lock()
+	defer k.dekMutex.Unlock()
+	for cacheKey, e := range k.dekEntries {
+		k.scopePurposeCache.Delete(cacheKey)
+		zeroKeys(e.keys)
+	}
+	for _, r := range k.retired {
+		zeroKeys(r.keys)
+	}
+	k.dekEntries = make(map[string]*cachedDek)
+	k.retired = nil
+}
+
+// observeUnwrap emits the metrics for unwrapping the keys of a purpose, which
+// started at start.
+func (k *Kms) observeUnwrap(purpose string, start time.Time, err error) {
+	labels := []metrics.Label{{Name: "purpose", Value: purpose}}
+	k.metrics.IncrCounterWithLabels(append(metricsPrefix, "unwrap"), 1, labels)
+	k.metrics.MeasureSinceWithLabels(append(metricsPrefix, "unwrap", "latency"), start, labels)
+	if err != nil {
+		k.metrics.IncrCounterWithLabels(append(metricsPrefix, "unwrap", "errors"), 1, labels)
+	}
+}
+
+func zeroKeys(keys [][]byte) {
+	for _, key := range keys {
+		for i := range key {
+			key[i] = 0
+		}
+	}
+}
+
+// EvictScope removes the wrappers of the scope from the cache, so its keys are
+// unwrapped from the database on next use. It's meant to be called when keys
+// were read in a transaction which was rolled back.
+func (k *Kms) EvictScope(scopeId string) {
+	k.dekMutex.Lock()
+	defer k.dekMutex.Unlock()
+	now := time.Now()
+	for _, purpose := range []KeyPurpose{KeyPurposeOplog, KeyPurposeDatabase, KeyPurposeTokens, KeyPurposeSessions} {
+		cacheKey := scopeId + purpose.String()
+		k.scopePurposeCache.Delete(cacheKey)
+		if e := k.dekEntries[cacheKey]; e != nil {
+			delete(k.dekEntries, cacheKey)
+			k.retired = append(k.retired, retiredKeys{keys: e.keys, retired: now})
+		}
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-hclog"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
@@ -45,35 +47,82 @@ func (e *ExternalWrappers) Recovery() wrapping.Wrapper {
 
 // Kms is a way to access wrappers for a given scope and purpose. Since keys can
 // never change, only be added or (eventually) removed, it opportunistically
-// caches, going to the database as needed.
+// caches, going to the database as needed. Cached keys are unwrapped again once
+// they outlive the cache lifetime, which bounds how long unwrapped key material
+// stays in memory.
 type Kms struct {
-	logger hclog.Logger
+	logger  hclog.Logger
+	metrics *metrics.Metrics
 
 	// scopePurposeCache holds a per-scope-purpose multiwrapper containing the
 	// current encrypting key and all previous key versions, for decryption
 	scopePurposeCache sync.Map
 
+	// dekEntries tracks when each wrapper in scopePurposeCache expires and the
+	// key bytes to zero when it does; retired holds the keys of evicted
+	// wrappers until they're zeroed
+	cacheLifetime time.Duration
+	dekEntries    map[string]*cachedDek
+	retired       []retiredKeys
+	dekMutex      sync.Mutex
+
 	externalScopeCache      map[string]*ExternalWrappers
 	externalScopeCacheMutex sync.RWMutex
 
 	repo *Repository
 }
 
-// NewKms takes in a repo and returns a Kms. Supported options: WithLogger.
+// NewKms takes in a repo and returns a Kms. Supported options: WithLogger,
+// WithCacheLifetime, and WithMetrics.
 func NewKms(repo *Repository, opt ...Option) (*Kms, error) {
 	if repo == nil {
 		return nil, errors.New("new kms created without an underlying repo")
 	}
 
 	opts := getOpts(opt...)
+	if opts.withCacheLifetime < 0 {
+		return nil, errors.New("new kms created with a negative cache lifetime")
+	}
+	if opts.withCacheLifetime == 0 {
+		opts.withCacheLifetime = DefaultCacheLifetime
+	}
+	if opts.withMetrics == nil {
+		opts.withMetrics = metrics.Default()
+	}
 
 	return &Kms{
 		logger:             opts.withLogger,
+		metrics:            opts.withMetrics,
+		cacheLifetime:      opts.withCacheLifetime,
+		dekEntries:         make(map[string]*cachedDek),
 		externalScopeCache: make(map[string]*ExternalWrappers),
 		repo:               repo,
 	}, nil
 }
 
+// ForRepository returns a Kms with the same external wrappers which reads
+// keys with the repository, like one made with a transaction's reader and
+// writer, so the keys of scopes created in the transaction can be used before
+// it commits. It has its own cache, so the keys it reads aren't cached by k.
+func (k *Kms) ForRepository(repo *Repository) (*Kms, error) {
+	txKms, err := NewKms(repo, WithLogger(k.logger), WithCacheLifetime(k.cacheLifetime), WithMetrics(k.metrics))
+	if err != nil {
+		return nil, err
+	}
+	k.externalScopeCacheMutex.RLock()
+	defer k.externalScopeCacheMutex.RUnlock()
+	for id, ext := range k.externalScopeCache {
+		ext.m.RLock()
+		txKms.externalScopeCache[id] = &ExternalWrappers{
+			root:       ext.root,
+			workerAuth: ext.workerAuth,
+			recovery:   ext.recovery,
+		}
+		ext.m.RUnlock()
+	}
+	return txKms, nil
+}
+
 // GetScopePurposeCache is used in test functions for validation. Since the
 // tests need to be in a different package to avoid circular dependencies, this
 // is exported.
@@ -159,23 +208,26 @@ func (k *Kms) GetWrapper(ctx context.Context, scopeId string, purpose KeyPurpose
 	}
 
 	opts := getOpts(opt...)
-	// Fast-path: we have a valid key at the scope/purpose. Verify the key with
-	// that ID is in the multiwrapper; if not, fall through to reload from the
-	// DB.
-	val, ok := k.scopePurposeCache.Load(scopeId + purpose.String())
+	// Fast-path: we have a valid, unexpired key at the scope/purpose. Verify
+	// the key with that ID is in the multiwrapper; if not, fall through to
+	// reload from the DB.
+	wrapper, ok := k.cachedWrapper(scopeId + purpose.String())
 	if ok {
-		wrapper := val.(*multiwrapper.MultiWrapper)
 		if opts.withKeyId == "" || wrapper.WrapperForKeyID(opts.withKeyId) != nil {
+			k.metrics.IncrCounterWithLabels(append(metricsPrefix, "cache", "hits"), 1, []metrics.Label{{Name: "purpose", Value: purpose.String()}})
 			return wrapper, nil
 		}
 		// Fall through to refresh our multiwrapper for this scope/purpose from the DB
 	}
+	k.metrics.IncrCounterWithLabels(append(metricsPrefix, "cache", "misses"), 1, []metrics.Label{{Name: "purpose", Value: purpose.String()}})
 
 	// We don't have it cached, so we'll need to read from the database. Get the
 	// root for the scope as we'll need it to decrypt the value coming from the
 	// DB. We don't cache the roots as we expect that after a few calls the
 	// scope-purpose cache will catch everything in steady-state.
-	rootWrapper, rootKeyId, err := k.loadRoot(ctx, scopeId, opt...)
+	rootWrapper, rootKeyId, rootKeys, err := k.loadRoot(ctx, scopeId, opt...)
+	// The root keys are only needed to unwrap the DEKs
+	defer zeroKeys(rootKeys)
 	if err != nil {
 		return nil, fmt.Errorf("error loading root key for scope %s: %w", scopeId, err)
 	}
@@ -183,16 +235,17 @@ func (k *Kms) GetWrapper(ctx context.Context, scopeId string, purpose KeyPurpose
 		return nil, fmt.Errorf("got nil root wrapper for scope %s", scopeId)
 	}
 
-	wrapper, err := k.loadDek(ctx, scopeId, purpose, rootWrapper, rootKeyId, opt...)
+	wrapper, keys, err := k.loadDek(ctx, scopeId, purpose, rootWrapper, rootKeyId, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("error loading %s for scope %s: %w", purpose.String(), scopeId, err)
 	}
-	k.scopePurposeCache.Store(scopeId+purpose.String(), wrapper)
+	k.storeWrapper(scopeId+purpose.String(), wrapper, keys)
 
 	return wrapper, nil
 }
 
-func (k *Kms) loadRoot(ctx context.Context, scopeId string, opt ...Option) (*multiwrapper.MultiWrapper, string, error) {
+func (k *Kms) loadRoot(ctx context.Context, scopeId string, opt ...Option) (*multiwrapper.MultiWrapper, string, [][]byte, error) {
+	var keys [][]byte
 	opts := getOpts(opt...)
 	repo := opts.withRepository
 	if repo == nil {
@@ -200,7 +253,7 @@ func (k *Kms) loadRoot(ctx context.Context, scopeId string, opt ...Option) (*mul
 	}
 	rootKeys, err := repo.ListRootKeys(ctx)
 	if err != nil {
-		return nil, "", fmt.Errorf("error listing root keys: %w", err)
+		return nil, "", keys, fmt.Errorf("error listing root keys: %w", err)
 	}
 	var rootKeyId string
 	for _, k := range rootKeys {
@@ -210,7 +263,7 @@ func (k *Kms) loadRoot(ctx context.Context, scopeId string, opt ...Option) (*mul
 		}
 	}
 	if rootKeyId == "" {
-		return nil, "", fmt.Errorf("error finding root key for scope %s", scopeId)
+		return nil, "", keys, fmt.Errorf("error finding root key for scope %s", scopeId)
 	}
 
 	// Now: find the external KMS that can be used to decrypt the root values
@@ -219,33 +272,36 @@ func (k *Kms) loadRoot(ctx context.Context, scopeId string, opt ...Option) (*mul
 	externalWrappers := k.externalScopeCache[scope.Global.String()]
 	k.externalScopeCacheMutex.Unlock()
 	if externalWrappers == nil {
-		return nil, "", errors.New("could not find kms information at either the needed scope or global fallback")
+		return nil, "", keys, errors.New("could not find kms information at either the needed scope or global fallback")
 	}
 
 	externalWrappers.m.RLock()
 	defer externalWrappers.m.RUnlock()
 
 	if externalWrappers.root == nil {
-		return nil, "", fmt.Errorf("root key wrapper for scope %s is nil", scopeId)
+		return nil, "", keys, fmt.Errorf("root key wrapper for scope %s is nil", scopeId)
 	}
+	start := time.Now()
 	rootKeyVersions, err := repo.ListRootKeyVersions(ctx, externalWrappers.root, rootKeyId, WithOrder("version desc"))
+	k.observeUnwrap("root", start, err)
 	if err != nil {
-		return nil, "", fmt.Errorf("error looking up root key versions for scope %s with key ID %s: %w", scopeId, externalWrappers.root.KeyID(), err)
+		return nil, "", keys, fmt.Errorf("error looking up root key versions for scope %s with key ID %s: %w", scopeId, externalWrappers.root.KeyID(), err)
 	}
 	if len(rootKeyVersions) == 0 {
-		return nil, "", fmt.Errorf("no root key versions found for scope %s", scopeId)
+		return nil, "", keys, fmt.Errorf("no root key versions found for scope %s", scopeId)
 	}
 
 	var multi *multiwrapper.MultiWrapper
 	for i, key := range rootKeyVersions {
+		keys = append(keys, key.GetKey())
 		wrapper := aead.NewWrapper(nil)
 		if _, err := wrapper.SetConfig(map[string]string{
 			"key_id": key.GetPrivateId(),
 		}); err != nil {
-			return nil, "", fmt.Errorf("error setting config on aead root wrapper in scope %s: %w", scopeId, err)
+			return nil, "", keys, fmt.Errorf("error setting config on aead root wrapper in scope %s: %w", scopeId, err)
 		}
 		if err := wrapper.SetAESGCMKeyBytes(key.GetKey()); err != nil {
-			return nil, "", fmt.Errorf("error setting key bytes on aead root wrapper in scope %s: %w", scopeId, err)
+			return nil, "", keys, fmt.Errorf("error setting key bytes on aead root wrapper in scope %s: %w", scopeId, err)
 		}
 		if i == 0 {
 			multi = multiwrapper.NewMultiWrapper(wrapper)
@@ -254,7 +310,7 @@ func (k *Kms) loadRoot(ctx context.Context, scopeId string, opt ...Option) (*mul
 		}
 	}
 
-	return multi, rootKeyId, err
+	return multi, rootKeyId, keys, err
 }
 
 // Dek is an interface wrapping dek types to allow a lot less switching in loadDek
@@ -269,12 +325,12 @@ type DekVersion interface {
 	GetKey() []byte
 }
 
-func (k *Kms) loadDek(ctx context.Context, scopeId string, purpose KeyPurpose, rootWrapper wrapping.Wrapper, rootKeyId string, opt ...Option) (*multiwrapper.MultiWrapper, error) {
+func (k *Kms) loadDek(ctx context.Context, scopeId string, purpose KeyPurpose, rootWrapper wrapping.Wrapper, rootKeyId string, opt ...Option) (*multiwrapper.MultiWrapper, [][]byte, error) {
 	if rootWrapper == nil {
-		return nil, fmt.Errorf("got nil root wrapper in loadDek for scope %s", scopeId)
+		return nil, nil, fmt.Errorf("got nil root wrapper in loadDek for scope %s", scopeId)
 	}
 	if rootKeyId == "" {
-		return nil, fmt.Errorf("no root key ID provided for scope %s", scopeId)
+		return nil, nil, fmt.Errorf("no root key ID provided for scope %s", scopeId)
 	}
 
 	opts := getOpts(opt...)
@@ -296,7 +352,7 @@ func (k *Kms) loadDek(ctx context.Context, scopeId string, purpose KeyPurpose, r
 		keys, err = repo.ListSessionKeys(ctx)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error listing root keys: %w", err)
+		return nil, nil, fmt.Errorf("error listing root keys: %w", err)
 	}
 	var keyId string
 	for _, k := range keys {
@@ -306,10 +362,11 @@ func (k *Kms) loadDek(ctx context.Context, scopeId string, purpose KeyPurpose, r
 		}
 	}
 	if keyId == "" {
-		return nil, fmt.Errorf("error finding %s key for scope %s", purpose.String(), scopeId)
+		return nil, nil, fmt.Errorf("error finding %s key for scope %s", purpose.String(), scopeId)
 	}
 
 	var keyVersions []DekVersion
+	start := time.Now()
 	switch purpose {
 	case KeyPurposeDatabase:
 		keyVersions, err = repo.ListDatabaseKeyVersions(ctx, rootWrapper, keyId, WithOrder("version desc"))
@@ -320,23 +377,26 @@ func (k *Kms) loadDek(ctx context.Context, scopeId string, purpose KeyPurpose, r
 	case KeyPurposeSessions:
 		keyVersions, err = repo.ListSessionKeyVersions(ctx, rootWrapper, keyId, WithOrder("version desc"))
 	}
+	k.observeUnwrap(purpose.String(), start, err)
 	if err != nil {
-		return nil, fmt.Errorf("error looking up %s key versions for scope %s with key ID %s: %w", purpose.String(), scopeId, rootWrapper.KeyID(), err)
+		return nil, nil, fmt.Errorf("error looking up %s key versions for scope %s with key ID %s: %w", purpose.String(), scopeId, rootWrapper.KeyID(), err)
 	}
 	if len(keyVersions) == 0 {
-		return nil, fmt.Errorf("no %s key versions found for scope %s", purpose.String(), scopeId)
+		return nil, nil, fmt.Errorf("no %s key versions found for scope %s", purpose.String(), scopeId)
 	}
 
 	var multi *multiwrapper.MultiWrapper
+	keyBytes := make([][]byte, 0, len(keyVersions))
 	for i, keyVersion := range keyVersions {
+		keyBytes = append(keyBytes, keyVersion.GetKey())
 		wrapper := aead.NewWrapper(nil)
 		if _, err := wrapper.SetConfig(map[string]string{
 			"key_id": keyVersion.GetPrivateId(),
 		}); err != nil {
-			return nil, fmt.Errorf("error setting config on aead %s wrapper in scope %s: %w", purpose.String(), scopeId, err)
+			return nil, nil, fmt.Errorf("error setting config on aead %s wrapper in scope %s: %w", purpose.String(), scopeId, err)
 		}
 		if err := wrapper.SetAESGCMKeyBytes(keyVersion.GetKey()); err != nil {
-			return nil, fmt.Errorf("error setting key bytes on aead %s wrapper in scope %s: %w", purpose.String(), scopeId, err)
+			return nil, nil, fmt.Errorf("error setting key bytes on aead %s wrapper in scope %s: %w", purpose.String(), scopeId, err)
 		}
 		if i == 0 {
 			multi = multiwrapper.NewMultiWrapper(wrapper)
@@ -345,5 +405,5 @@ func (k *Kms) loadDek(ctx context.Context, scopeId string, purpose KeyPurpose, r
 		}
 	}
 
-	return multi, err
+	return multi, keyBytes, err
 }
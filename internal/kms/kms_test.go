@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -125,3 +127,110 @@ func TestKms(t *testing.T) {
 		})
 	}
 }
+
+func TestKms_CacheLifetime(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	sink := metrics.NewInmemSink(time.Minute, time.Minute)
+	conf := metrics.DefaultConfig("test")
+	conf.EnableHostname = false
+	conf.EnableRuntimeMetrics = false
+	m, err := metrics.New(conf, sink)
+	require.NoError(err)
+
+	repo, err := kms.NewRepository(rw, rw)
+	require.NoError(err)
+	_, err = kms.NewKms(repo, kms.WithCacheLifetime(-time.Second))
+	require.Error(err)
+	kmsCache, err := kms.NewKms(repo, kms.WithCacheLifetime(50*time.Millisecond), kms.WithMetrics(m))
+	require.NoError(err)
+	require.NoError(kmsCache.AddExternalWrappers(kms.WithRootWrapper(wrapper)))
+
+	first, err := kmsCache.GetWrapper(ctx, proj.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(err)
+	cached, err := kmsCache.GetWrapper(ctx, proj.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(err)
+	assert.Same(first, cached)
+	assert.Equal(0, kmsCache.EvictExpiredKeys())
+
+	// Once expired the keys are unwrapped again, even before being evicted
+	time.Sleep(100 * time.Millisecond)
+	second, err := kmsCache.GetWrapper(ctx, proj.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(err)
+	assert.NotSame(first, second)
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(1, kmsCache.EvictExpiredKeys())
+	var count int
+	kmsCache.GetScopePurposeCache().Range(func(key interface{}, value interface{}) bool {
+		count++
+		return true
+	})
+	assert.Equal(0, count)
+
+	third, err := kmsCache.GetWrapper(ctx, proj.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(err)
+	multi := third.(*multiwrapper.MultiWrapper)
+	aeadWrapper := multi.WrapperForKeyID(multi.KeyID()).(*aead.Wrapper)
+	kmsCache.ClearCache()
+	assert.Equal(make([]byte, 32), aeadWrapper.GetKeyBytes())
+
+	data := sink.Data()
+	require.NotEmpty(data)
+	var keys []string
+	for k := range data[0].Counters {
+		keys = append(keys, k)
+	}
+	all := strings.Join(keys, "\n")
+	for _, want := range []string{
+		"boundary.kms.unwrap;purpose=root",
+		"boundary.kms.unwrap;purpose=database",
+		"boundary.kms.cache.hits;purpose=database",
+		"boundary.kms.cache.misses;purpose=database",
+		"boundary.kms.cache.evictions",
+	} {
+		assert.Contains(all, want)
+	}
+}
+
+func TestKms_ForRepository(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+
+	var orgId string
+	_, err := rw.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, func(r db.Reader, w db.Writer) error {
+		kmsRepo, err := kms.NewRepository(r, w)
+		require.NoError(err)
+		txKms, err := kmsCache.ForRepository(kmsRepo)
+		require.NoError(err)
+		iamRepo, err := iam.NewRepository(r, w, txKms)
+		require.NoError(err)
+		org, err := iam.NewOrg()
+		require.NoError(err)
+		org, err = iamRepo.CreateScope(ctx, org, "")
+		require.NoError(err)
+		orgId = org.PublicId
+
+		// The keys of the org can be used before the transaction commits
+		_, err = txKms.GetWrapper(ctx, orgId, kms.KeyPurposeDatabase)
+		assert.NoError(err)
+		return fmt.Errorf("roll back")
+	})
+	require.Error(err)
+
+	// The keys read in the transaction weren't cached by the kms it was made
+	// from, and were rolled back with it.
+	_, err = kmsCache.GetWrapper(ctx, orgId, kms.KeyPurposeDatabase)
+	assert.Error(err)
+}
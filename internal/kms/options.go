@@ -1,6 +1,9 @@
 package kms
 
 import (
+	"time"
+
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
 )
@@ -27,6 +30,8 @@ type options struct {
 	withRepository        *Repository
 	withOrder             string
 	withKeyId             string
+	withCacheLifetime     time.Duration
+	withMetrics           *metrics.Metrics
 }
 
 func getDefaultOptions() options {
@@ -93,3 +98,19 @@ func WithKeyId(keyId string) Option {
 		o.withKeyId = keyId
 	}
 }
+
+// WithCacheLifetime sets how long a Kms keeps the keys of a scope and purpose
+// in memory before unwrapping them from the database again
+func WithCacheLifetime(d time.Duration) Option {
+	return func(o *options) {
+		o.withCacheLifetime = d
+	}
+}
+
+// WithMetrics sets the go-metrics instance a Kms emits to instead of the global
+// one
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(o *options) {
+		o.withMetrics = m
+	}
+}
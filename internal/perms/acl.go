@@ -30,6 +30,10 @@ type ACL struct {
 type ACLResults struct {
 	Allowed bool
 
+	// Denied is true when a deny grant matched, which overrides any grant
+	// allowing the action.
+	Denied bool
+
 	// This is included but unexported for testing/debugging
 	scopeMap map[string][]Grant
 }
@@ -65,70 +69,112 @@ func NewACL(grants ...Grant) ACL {
 }
 
 // Allowed determines if the grants for an ACL allow an action for a resource.
+// A matching deny grant overrides any matching allow grants.
 func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
 	// First, get the grants within the specified scope
 	grants := a.scopeMap[r.ScopeId]
 	results.scopeMap = a.scopeMap
 
-	// Now, go through and check the cases indicated above
 	for _, grant := range grants {
-		if !(grant.actions[aType] || grant.actions[action.All]) {
+		if !grant.Matches(r, aType) {
 			continue
 		}
-		switch {
-		// id=<resource.id>;actions=<action> where ID cannot be a wildcard
-		case grant.id == r.Id &&
-			grant.id != "" &&
-			grant.id != "*" &&
-			grant.typ == resource.Unknown:
-
-			results.Allowed = true
+		if grant.effect == Deny {
+			results.Allowed = false
+			results.Denied = true
 			return
+		}
+		results.Allowed = true
+	}
+	return
+}
 
-		// type=<resource.type>;actions=<action> when action is list or create.
-		// Must be a top level collection, otherwise must be one of the two
-		// formats specified below.
-		case grant.id == "" &&
-			r.Id == "" &&
-			grant.typ == r.Type &&
-			grant.typ != resource.Unknown &&
-			topLevelType(r.Type) &&
-			(aType == action.List || aType == action.Create):
-
-			results.Allowed = true
-			return
+// AllowedActions returns the actions, like those returned by a resource's
+// Actions(), that the ACL allows on the resource. An action on a collection is
+// only authorized by the collection's endpoint and an action on an instance by
+// the instance's, so when r has no Id only collection actions are considered,
+// and otherwise only instance actions are.
+func (a ACL) AllowedActions(r Resource, actions map[string]action.Type) map[string]action.Type {
+	ret := make(map[string]action.Type, len(actions))
+	for k, v := range actions {
+		if v.IsCollection() != (r.Id == "") {
+			continue
+		}
+		if a.Allowed(r, v).Allowed {
+			ret[k] = v
+		}
+	}
+	return ret
+}
 
-		// id=*;type=<resource.type>;actions=<action> where type cannot be
-		// unknown but can be a wildcard to allow any resource at all
-		case grant.id == "*" &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type ||
-				grant.typ == resource.All):
+// explicitActions are actions which a grant allowing all actions doesn't
+// allow, so they must be granted by name. A grant denying all actions still
+// denies them.
+var explicitActions = map[action.Type]bool{
+	action.AuthorizeSessionUnrecorded: true,
+	action.ListUnpaged:                true,
+}
 
-			results.Allowed = true
-			return
+// Matches determines if the grant applies to an action on a resource,
+// regardless of its effect.
+func (g Grant) Matches(r Resource, aType action.Type) bool {
+	allActions := g.actions[action.All] && (g.effect == Deny || !explicitActions[aType])
+	if !(g.actions[aType] || allActions) {
+		return false
+	}
+	switch {
+	// id=<resource.id>;actions=<action> where ID cannot be a wildcard
+	case g.id == r.Id &&
+		g.id != "" &&
+		g.id != "*" &&
+		g.typ == resource.Unknown:
 
-		// id=<pin>;type=<resource.type>;actions=<action> where type can be a
-		// wildcard and this this is operating on a non-top-level type
-		case grant.id != "" &&
-			grant.id == r.Pin &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type || grant.typ == resource.All) &&
-			!topLevelType(r.Type):
+		return true
 
-			results.Allowed = true
-			return
-		}
+	// type=<resource.type>;actions=<action> when action is list or create.
+	// This is a type-level grant on the collections of the type in the scope,
+	// so for a non-top-level type it matches the collections within every
+	// pin, like the host sets of every host catalog.
+	case g.id == "" &&
+		r.Id == "" &&
+		g.typ == r.Type &&
+		g.typ != resource.Unknown &&
+		g.typ != resource.All &&
+		aType.IsCollection():
+
+		return true
+
+	// id=*;type=<resource.type>;actions=<action> where type cannot be
+	// unknown but can be a wildcard to allow any resource at all
+	case g.id == "*" &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type ||
+			g.typ == resource.All):
+
+		return true
+
+	// id=<pin>;type=<resource.type>;actions=<action> where type can be a
+	// wildcard and this this is operating on a non-top-level type
+	case g.id != "" &&
+		g.id == r.Pin &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type || g.typ == resource.All) &&
+		!topLevelType(r.Type):
+
+		return true
 	}
-	return
+	return false
 }
 
 func topLevelType(typ resource.Type) bool {
 	switch typ {
 	case resource.AuthMethod,
 		resource.AuthToken,
+		resource.ClientCertificateLibrary,
+		resource.Controller,
 		resource.Group,
 		resource.HostCatalog,
+		resource.Job,
 		resource.Role,
 		resource.Scope,
 		resource.Session,
@@ -61,6 +61,14 @@ func Test_ACLAllowed(t *testing.T) {
 				"id=*;type=*;actions=create,update",
 			},
 		},
+		{
+			scope: "o_e",
+			grants: []string{
+				"id=*;type=*;actions=read,update",
+				"id=*;type=target;actions=update;effect=deny",
+				"id=ttcp_secret;actions=*;effect=deny",
+			},
+		},
 	}
 
 	// See acl.go for expected allowed formats. The goal here is to basically
@@ -252,6 +260,33 @@ func Test_ACLAllowed(t *testing.T) {
 			},
 			userId: "u_abcd1234",
 		},
+		{
+			name:        "deny type overrides allow",
+			resource:    Resource{ScopeId: "o_e", Id: "ttcp_1234", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAllowed: []actionAllowed{
+				{action: action.Read, allowed: true},
+				{action: action.Update},
+			},
+		},
+		{
+			name:        "deny id overrides allow",
+			resource:    Resource{ScopeId: "o_e", Id: "ttcp_secret", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAllowed: []actionAllowed{
+				{action: action.Read},
+				{action: action.Update},
+			},
+		},
+		{
+			name:        "deny does not apply to other types",
+			resource:    Resource{ScopeId: "o_e", Id: "hcst_1234", Type: resource.HostCatalog},
+			scopeGrants: commonGrants,
+			actionsAllowed: []actionAllowed{
+				{action: action.Read, allowed: true},
+				{action: action.Update, allowed: true},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -271,3 +306,125 @@ func Test_ACLAllowed(t *testing.T) {
 		})
 	}
 }
+
+func Test_ACLDenyOverrides(t *testing.T) {
+	t.Parallel()
+	allow, err := Parse("o_a", "id=*;type=*;actions=*")
+	require.NoError(t, err)
+	deny, err := Parse("o_a", "id=*;type=host-set;actions=delete;effect=deny")
+	require.NoError(t, err)
+	r := Resource{ScopeId: "o_a", Id: "hsst_1234", Type: resource.HostSet, Pin: "hcst_1234"}
+
+	// The order of the grants doesn't matter.
+	for _, acl := range []ACL{NewACL(allow, deny), NewACL(deny, allow)} {
+		results := acl.Allowed(r, action.Delete)
+		assert.False(t, results.Allowed)
+		assert.True(t, results.Denied)
+
+		results = acl.Allowed(r, action.Read)
+		assert.True(t, results.Allowed)
+		assert.False(t, results.Denied)
+	}
+
+	// A deny grant alone allows nothing.
+	results := NewACL(deny).Allowed(r, action.Read)
+	assert.False(t, results.Allowed)
+	assert.False(t, results.Denied)
+}
+
+func Test_ACLExplicitActions(t *testing.T) {
+	t.Parallel()
+	r := Resource{ScopeId: "o_a", Id: "ttcp_1234", Type: resource.Target}
+
+	all, err := Parse("o_a", "id=*;type=*;actions=*")
+	require.NoError(t, err)
+	assert.True(t, NewACL(all).Allowed(r, action.AuthorizeSession).Allowed)
+	assert.False(t, NewACL(all).Allowed(r, action.AuthorizeSessionUnrecorded).Allowed)
+
+	explicit, err := Parse("o_a", "id=ttcp_1234;actions=authorize-session-unrecorded")
+	require.NoError(t, err)
+	assert.True(t, NewACL(explicit).Allowed(r, action.AuthorizeSessionUnrecorded).Allowed)
+
+	// A grant denying all actions still denies explicit ones.
+	denyAll, err := Parse("o_a", "id=ttcp_1234;actions=*;effect=deny")
+	require.NoError(t, err)
+	results := NewACL(explicit, denyAll).Allowed(r, action.AuthorizeSessionUnrecorded)
+	assert.False(t, results.Allowed)
+	assert.True(t, results.Denied)
+
+	roles := Resource{ScopeId: "o_a", Type: resource.Role}
+	allRoles, err := Parse("o_a", "id=*;type=role;actions=*")
+	require.NoError(t, err)
+	assert.True(t, NewACL(allRoles).Allowed(roles, action.List).Allowed)
+	assert.False(t, NewACL(allRoles).Allowed(roles, action.ListUnpaged).Allowed)
+	unpaged, err := Parse("o_a", "type=role;actions=list,list-unpaged")
+	require.NoError(t, err)
+	assert.True(t, NewACL(unpaged).Allowed(roles, action.ListUnpaged).Allowed)
+}
+
+func Test_ACLTypeLevelGrants(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+
+	g, err := Parse("o_a", "type=host-set;actions=list,create")
+	require.NoError(err)
+	assert.True(g.IsCollection())
+	acl := NewACL(g)
+
+	// The grant applies to the host set collections of every host catalog in
+	// the scope, but not to the host sets themselves.
+	for _, pin := range []string{"hc_1234", "hc_5678"} {
+		collection := Resource{ScopeId: "o_a", Pin: pin, Type: resource.HostSet}
+		assert.True(acl.Allowed(collection, action.List).Allowed)
+		assert.True(acl.Allowed(collection, action.Create).Allowed)
+		instance := Resource{ScopeId: "o_a", Id: "hs_1234", Pin: pin, Type: resource.HostSet}
+		assert.False(acl.Allowed(instance, action.Read).Allowed)
+	}
+	assert.False(acl.Allowed(Resource{ScopeId: "o_b", Pin: "hc_1234", Type: resource.HostSet}, action.List).Allowed)
+	assert.False(acl.Allowed(Resource{ScopeId: "o_a", Pin: "hc_1234", Type: resource.Host}, action.List).Allowed)
+
+	// Instance actions can't be granted on a type without an id
+	_, err = Parse("o_a", "type=host-set;actions=read")
+	assert.Error(err)
+
+	for _, grant := range []string{"id=*;type=host-set;actions=list", "id=hc_1234;type=host-set;actions=list", "id=hs_1234;actions=read"} {
+		g, err := Parse("o_a", grant)
+		require.NoError(err)
+		assert.False(g.IsCollection(), grant)
+	}
+}
+
+func Test_ACLAllowedActions(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+
+	var grants []Grant
+	for _, grant := range []string{"type=role;actions=list", "id=r_1234;actions=read,add-grants", "id=*;type=role;actions=delete"} {
+		g, err := Parse("o_a", grant)
+		require.NoError(err)
+		grants = append(grants, g)
+	}
+	acl := NewACL(grants...)
+	actions := map[string]action.Type{
+		action.List.String():      action.List,
+		action.Create.String():    action.Create,
+		action.Read.String():      action.Read,
+		action.Delete.String():    action.Delete,
+		action.AddGrants.String(): action.AddGrants,
+	}
+
+	// The collection endpoint only authorizes collection actions
+	assert.Equal(map[string]action.Type{
+		action.List.String(): action.List,
+	}, acl.AllowedActions(Resource{ScopeId: "o_a", Type: resource.Role}, actions))
+
+	// An instance endpoint only authorizes instance actions
+	assert.Equal(map[string]action.Type{
+		action.Read.String():      action.Read,
+		action.Delete.String():    action.Delete,
+		action.AddGrants.String(): action.AddGrants,
+	}, acl.AllowedActions(Resource{ScopeId: "o_a", Id: "r_1234", Type: resource.Role}, actions))
+	assert.Equal(map[string]action.Type{
+		action.Delete.String(): action.Delete,
+	}, acl.AllowedActions(Resource{ScopeId: "o_a", Id: "r_5678", Type: resource.Role}, actions))
+}
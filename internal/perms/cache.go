@@ -0,0 +1,251 @@
+package perms
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/armon/go-metrics"
+)
+
+// DefaultCacheTTL is how long a Cache keeps the grants of a user in a scope
+// when NewCache is given no TTL.
+const DefaultCacheTTL = 30 * time.Second
+
+var cacheMetricsPrefix = []string{"boundary", "perms", "cache"}
+
+// Cache memoizes the grants users have in scopes, so authorizing a request
+// doesn't query the database each time. Each entry is the grants of a user
+// in one scope, which are all an ACL needs to authorize an action on a
+// resource in the scope.
+//
+// Entries expire after the cache's TTL. Writes which change whose grants are
+// what should invalidate the entries they affect once they've committed:
+// changes to a user, or to the groups they're a member of, with
+// InvalidateUsers, and changes to roles, their grants or their principals with
+// InvalidateScopes, given the scopes the roles' grants apply in. Invalidate
+// drops every entry, for writes whose effects aren't known. The TTL bounds how
+// stale the grants cached by one controller can be after a write through
+// another.
+//
+// A Cache is safe for concurrent use.
+type Cache struct {
+	ttl     time.Duration
+	metrics *metrics.Metrics
+
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+	// maxEntries bounds how many entries are cached, if it's set.
+	maxEntries int
+	// generation is incremented by every invalidation, so grants loaded
+	// before an invalidation aren't cached after it, whichever entries it
+	// dropped.
+	generation uint64
+
+	hits   uint64
+	misses uint64
+}
+
+type cacheKey struct {
+	userId  string
+	scopeId string
+}
+
+type cacheEntry struct {
+	grants  []GrantPair
+	expires time.Time
+}
+
+// CacheStats are the hits and misses of a Cache since it was created.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// HitRate is the fraction of lookups which were hits, or 0 if there have been
+// none.
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// NewCache creates a Cache whose entries expire after ttl, or after
+// DefaultCacheTTL if ttl is 0. Supports the option WithMetrics, which sets
+// the go-metrics instance the cache's hit and miss counters are emitted to
+// instead of the global one.
+func NewCache(ttl time.Duration, opt ...Option) *Cache {
+	opts := getOpts(opt...)
+	if ttl == 0 {
+		ttl = DefaultCacheTTL
+	}
+	if opts.withMetrics == nil {
+		opts.withMetrics = metrics.Default()
+	}
+	return &Cache{
+		ttl:     ttl,
+		metrics: opts.withMetrics,
+		entries: make(map[cacheKey]*cacheEntry),
+	}
+}
+
+// GrantsForUser returns the cached grants of the user in the scope. If they
+// aren't cached or have expired, they're loaded with load and cached, unless
+// the cache is invalidated while they're being loaded. Errors from load are
+// returned and not cached. The returned slice is shared and must not be
+// modified.
+func (c *Cache) GrantsForUser(userId, scopeId string, load func() ([]GrantPair, error)) ([]GrantPair, error) {
+	key := cacheKey{userId: userId, scopeId: scopeId}
+	c.mu.Lock()
+	e := c.entries[key]
+	generation := c.generation
+	c.mu.Unlock()
+	if e != nil && time.Now().Before(e.expires) {
+		atomic.AddUint64(&c.hits, 1)
+		c.metrics.IncrCounter(append(cacheMetricsPrefix, "hits"), 1)
+		return e.grants, nil
+	}
+	atomic.AddUint64(&c.misses, 1)
+	c.metrics.IncrCounter(append(cacheMetricsPrefix, "misses"), 1)
+
+	grants, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation == generation {
+		if _, ok := c.entries[key]; !ok && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+			c.evictLocked(len(c.entries) - c.maxEntries + 1)
+		}
+		c.entries[key] = &cacheEntry{
+			grants:  grants,
+			expires: time.Now().Add(c.ttl),
+		}
+	}
+	return grants, nil
+}
+
+// Invalidate drops the grants of every user from the cache, including any
+// being loaded, so they're read from the database on next use.
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	if len(c.entries) > 0 {
+		c.entries = make(map[cacheKey]*cacheEntry)
+	}
+	c.metrics.IncrCounter(append(cacheMetricsPrefix, "invalidations"), 1)
+}
+
+// InvalidateUsers drops the grants of the users in every scope from the
+// cache, along with any being loaded.
+func (c *Cache) InvalidateUsers(userIds ...string) {
+	if len(userIds) == 0 {
+		return
+	}
+	users := make(map[string]bool, len(userIds))
+	for _, id := range userIds {
+		users[id] = true
+	}
+	c.invalidate(func(k cacheKey) bool { return users[k.userId] })
+}
+
+// InvalidateScopes drops the grants of every user in the scopes from the
+// cache, along with any being loaded.
+func (c *Cache) InvalidateScopes(scopeIds ...string) {
+	if len(scopeIds) == 0 {
+		return
+	}
+	scopes := make(map[string]bool, len(scopeIds))
+	for _, id := range scopeIds {
+		scopes[id] = true
+	}
+	c.invalidate(func(k cacheKey) bool { return scopes[k.scopeId] })
+}
+
+// invalidate drops the entries whose keys match.
+func (c *Cache) invalidate(match func(cacheKey) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for k := range c.entries {
+		if match(k) {
+			delete(c.entries, k)
+		}
+	}
+	c.metrics.IncrCounter(append(cacheMetricsPrefix, "invalidations"), 1)
+}
+
+// EvictExpired removes the entries which have outlived the cache's TTL and
+// returns how many were removed. Expired entries are never returned, so this
+// only frees their memory.
+func (c *Cache) EvictExpired() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	var evicted int
+	for k, e := range c.entries {
+		if now.Before(e.expires) {
+			continue
+		}
+		delete(c.entries, k)
+		evicted++
+	}
+	return evicted
+}
+
+// SetMaxEntries bounds how many entries, each the grants of a user in a scope,
+// the cache holds, evicting entries if it holds more. If max is 0 or less, the
+// cache is unbounded. When a full cache loads another entry, an expired entry
+// is evicted if there is one, or else an arbitrary one.
+func (c *Cache) SetMaxEntries(max int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if max < 0 {
+		max = 0
+	}
+	c.maxEntries = max
+	if max > 0 && len(c.entries) > max {
+		c.evictLocked(len(c.entries) - max)
+	}
+}
+
+// MaxEntries returns how many entries the cache holds at most, or 0 if it's
+// unbounded.
+func (c *Cache) MaxEntries() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.maxEntries
+}
+
+// evictLocked evicts n entries, expired ones first. c.mu must be held.
+func (c *Cache) evictLocked(n int) {
+	now := time.Now()
+	for k, e := range c.entries {
+		if n == 0 {
+			return
+		}
+		if now.Before(e.expires) {
+			continue
+		}
+		delete(c.entries, k)
+		n--
+	}
+	for k := range c.entries {
+		if n == 0 {
+			return
+		}
+		delete(c.entries, k)
+		n--
+	}
+}
+
+// Stats returns the hits and misses of the cache since it was created.
+func (c *Cache) Stats() CacheStats {
+	return CacheStats{
+		Hits:   atomic.LoadUint64(&c.hits),
+		Misses: atomic.LoadUint64(&c.misses),
+	}
+}
@@ -0,0 +1,176 @@
+package perms
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+	grants := []GrantPair{{ScopeId: "o_1234567890", Grant: "id=*;actions=read"}}
+	var loads int
+	load := func() ([]GrantPair, error) {
+		loads++
+		return grants, nil
+	}
+
+	t.Run("hit", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		loads = 0
+		c := NewCache(time.Minute)
+		got, err := c.GrantsForUser("u_1234567890", "o_1234567890", load)
+		require.NoError(err)
+		assert.Equal(grants, got)
+		got, err = c.GrantsForUser("u_1234567890", "o_1234567890", load)
+		require.NoError(err)
+		assert.Equal(grants, got)
+		assert.Equal(1, loads)
+		assert.Equal(CacheStats{Hits: 1, Misses: 1}, c.Stats())
+		assert.Equal(0.5, c.Stats().HitRate())
+
+		_, err = c.GrantsForUser("u_0987654321", "o_1234567890", load)
+		require.NoError(err)
+		assert.Equal(2, loads)
+		_, err = c.GrantsForUser("u_1234567890", "p_1234567890", load)
+		require.NoError(err)
+		assert.Equal(3, loads)
+	})
+	t.Run("expired", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		loads = 0
+		c := NewCache(time.Nanosecond)
+		_, err := c.GrantsForUser("u_1234567890", "o_1234567890", load)
+		require.NoError(err)
+		time.Sleep(time.Millisecond)
+		_, err = c.GrantsForUser("u_1234567890", "o_1234567890", load)
+		require.NoError(err)
+		assert.Equal(2, loads)
+		assert.Equal(1, c.EvictExpired())
+	})
+	t.Run("max-entries", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c := NewCache(time.Minute)
+		for _, id := range []string{"u_1", "u_2", "u_3"} {
+			_, err := c.GrantsForUser(id, "o_1234567890", load)
+			require.NoError(err)
+		}
+		c.SetMaxEntries(2)
+		assert.Equal(2, c.MaxEntries())
+		assert.Len(c.entries, 2)
+		_, err := c.GrantsForUser("u_4", "o_1234567890", load)
+		require.NoError(err)
+		assert.Len(c.entries, 2)
+		assert.Contains(c.entries, cacheKey{userId: "u_4", scopeId: "o_1234567890"})
+
+		c.SetMaxEntries(0)
+		_, err = c.GrantsForUser("u_5", "o_1234567890", load)
+		require.NoError(err)
+		assert.Len(c.entries, 3)
+	})
+	t.Run("invalidate", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		loads = 0
+		c := NewCache(time.Minute)
+		_, err := c.GrantsForUser("u_1234567890", "o_1234567890", load)
+		require.NoError(err)
+		c.Invalidate()
+		_, err = c.GrantsForUser("u_1234567890", "o_1234567890", load)
+		require.NoError(err)
+		assert.Equal(2, loads)
+	})
+	t.Run("invalidate-users", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		loads = 0
+		c := NewCache(time.Minute)
+		for _, userId := range []string{"u_1", "u_2"} {
+			for _, scopeId := range []string{"o_1", "p_1"} {
+				_, err := c.GrantsForUser(userId, scopeId, load)
+				require.NoError(err)
+			}
+		}
+		c.InvalidateUsers("u_1")
+		assert.Len(c.entries, 2)
+		assert.Contains(c.entries, cacheKey{userId: "u_2", scopeId: "o_1"})
+		assert.Contains(c.entries, cacheKey{userId: "u_2", scopeId: "p_1"})
+		_, err := c.GrantsForUser("u_2", "o_1", load)
+		require.NoError(err)
+		assert.Equal(4, loads)
+	})
+	t.Run("invalidate-scopes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		loads = 0
+		c := NewCache(time.Minute)
+		for _, userId := range []string{"u_1", "u_2"} {
+			for _, scopeId := range []string{"o_1", "p_1"} {
+				_, err := c.GrantsForUser(userId, scopeId, load)
+				require.NoError(err)
+			}
+		}
+		c.InvalidateScopes("p_1")
+		assert.Len(c.entries, 2)
+		assert.Contains(c.entries, cacheKey{userId: "u_1", scopeId: "o_1"})
+		assert.Contains(c.entries, cacheKey{userId: "u_2", scopeId: "o_1"})
+		_, err := c.GrantsForUser("u_1", "p_1", load)
+		require.NoError(err)
+		assert.Equal(5, loads)
+	})
+	t.Run("invalidate-during-load", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		loads = 0
+		c := NewCache(time.Minute)
+		_, err := c.GrantsForUser("u_1234567890", "o_1234567890", func() ([]GrantPair, error) {
+			// A write committed while the stale grants were being read
+			c.Invalidate()
+			return load()
+		})
+		require.NoError(err)
+		_, err = c.GrantsForUser("u_1234567890", "o_1234567890", load)
+		require.NoError(err)
+		assert.Equal(2, loads)
+	})
+	t.Run("error", func(t *testing.T) {
+		assert := assert.New(t)
+		c := NewCache(time.Minute)
+		loadErr := errors.New("load failed")
+		_, err := c.GrantsForUser("u_1234567890", "o_1234567890", func() ([]GrantPair, error) {
+			return nil, loadErr
+		})
+		assert.True(errors.Is(err, loadErr))
+		assert.Zero(c.EvictExpired())
+		var called bool
+		_, err = c.GrantsForUser("u_1234567890", "o_1234567890", func() ([]GrantPair, error) {
+			called = true
+			return grants, nil
+		})
+		assert.NoError(err)
+		assert.True(called)
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		c := NewCache(time.Minute)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					got, err := c.GrantsForUser("u_1234567890", "o_1234567890", func() ([]GrantPair, error) {
+						return grants, nil
+					})
+					assert.NoError(t, err)
+					assert.Equal(t, grants, got)
+					if j%10 == 0 {
+						c.Invalidate()
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		s := c.Stats()
+		assert.Equal(t, uint64(1000), s.Hits+s.Misses)
+	})
+}
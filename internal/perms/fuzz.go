@@ -0,0 +1,37 @@
+package perms
+
+import "fmt"
+
+// fuzzScopeId is the scope grants are parsed in when fuzzing.
+const fuzzScopeId = "o_abcd1234"
+
+// fuzzParse is the fuzz target for the grant grammar. Grant strings come from
+// API callers, so they must never panic the parser, and a grant that parses
+// must round trip through its canonical string and JSON forms. It panics when
+// a round trip doesn't hold so the fuzzer records a crasher.
+func fuzzParse(data []byte) int {
+	g, err := Parse(fuzzScopeId, string(data))
+	if err != nil {
+		return 0
+	}
+	canonical := g.CanonicalString()
+	fromCanonical, err := Parse(fuzzScopeId, canonical)
+	if err != nil {
+		panic(fmt.Sprintf("canonical form %q of %q does not parse: %v", canonical, data, err))
+	}
+	if got := fromCanonical.CanonicalString(); got != canonical {
+		panic(fmt.Sprintf("canonical form %q of %q is not stable, got %q", canonical, data, got))
+	}
+	j, err := g.MarshalJSON()
+	if err != nil {
+		panic(fmt.Sprintf("unable to marshal %q to JSON: %v", data, err))
+	}
+	fromJSON, err := Parse(fuzzScopeId, string(j))
+	if err != nil {
+		panic(fmt.Sprintf("JSON form %s of %q does not parse: %v", j, data, err))
+	}
+	if got := fromJSON.CanonicalString(); got != canonical {
+		panic(fmt.Sprintf("JSON form %s of %q has canonical form %q, want %q", j, data, got, canonical))
+	}
+	return 1
+}
@@ -0,0 +1,8 @@
+// +build gofuzz
+
+package perms
+
+// Fuzz is the go-fuzz entry point for the grant grammar, see fuzzParse.
+func Fuzz(data []byte) int {
+	return fuzzParse(data)
+}
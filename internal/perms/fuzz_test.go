@@ -0,0 +1,12 @@
+package perms
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/fuzz"
+)
+
+func TestFuzzParse_Corpus(t *testing.T) {
+	fuzz.TestCorpus(t, filepath.Join("testdata", "fuzz"), fuzzParse)
+}
@@ -12,6 +12,11 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 )
 
+// ErrTypeNotInScope is returned by Parse when a grant names a resource type
+// which can't exist in the grant's scope, like users in a project. Such a
+// grant would never match a resource.
+var ErrTypeNotInScope = errors.New("resource type not in scope")
+
 // GrantPair is simply a struct that can be reference from other code to return
 // a set of scopes and grants to parse
 type GrantPair struct {
@@ -29,6 +34,25 @@ type Scope struct {
 	Type scope.Type
 }
 
+// Effect is whether a grant allows or denies the actions it matches.
+type Effect uint
+
+const (
+	// Allow is the default effect of a grant.
+	Allow Effect = iota
+	// Deny overrides any grant which allows the same action on a resource.
+	Deny
+)
+
+func (e Effect) String() string {
+	switch e {
+	case Deny:
+		return "deny"
+	default:
+		return "allow"
+	}
+}
+
 // Grant is a Go representation of a parsed grant
 type Grant struct {
 	// The scope ID, which will be a project ID or an org ID
@@ -43,6 +67,9 @@ type Grant struct {
 	// The set of actions being granted
 	actions map[action.Type]bool
 
+	// Whether the actions are allowed or denied
+	effect Effect
+
 	// This is used as a temporary staging area before validating permissions to
 	// allow the same validation code across grant string formats
 	actionsBeingParsed []string
@@ -56,6 +83,18 @@ func (g Grant) Type() resource.Type {
 	return g.typ
 }
 
+func (g Grant) Effect() Effect {
+	return g.effect
+}
+
+// IsCollection reports whether the grant is a type-level grant, which targets
+// the collections of all the resources of its type in its scope, like
+// type=target;actions=list,create, rather than specific resources by id or
+// all of the resources of its type with id=*.
+func (g Grant) IsCollection() bool {
+	return g.id == "" && g.typ != resource.Unknown
+}
+
 func (g Grant) Actions() (typs []action.Type, strs []string) {
 	typs = make([]action.Type, 0, len(g.actions))
 	strs = make([]string, 0, len(g.actions))
@@ -72,9 +111,10 @@ func (g Grant) Actions() (typs []action.Type, strs []string) {
 
 func (g Grant) clone() *Grant {
 	ret := &Grant{
-		scope: g.scope,
-		id:    g.id,
-		typ:   g.typ,
+		scope:  g.scope,
+		id:     g.id,
+		typ:    g.typ,
+		effect: g.effect,
 	}
 	if g.actionsBeingParsed != nil {
 		ret.actionsBeingParsed = append(ret.actionsBeingParsed, g.actionsBeingParsed...)
@@ -109,6 +149,11 @@ func (g Grant) CanonicalString() string {
 		builder = append(builder, fmt.Sprintf("actions=%s", strings.Join(actions, ",")))
 	}
 
+	// allow is the default, so it's left out to keep existing grants canonical
+	if g.effect == Deny {
+		builder = append(builder, fmt.Sprintf("effect=%s", g.effect.String()))
+	}
+
 	return strings.Join(builder, ";")
 }
 
@@ -129,6 +174,9 @@ func (g Grant) MarshalJSON() ([]byte, error) {
 		sort.Strings(actions)
 		res["actions"] = actions
 	}
+	if g.effect == Deny {
+		res["effect"] = g.effect.String()
+	}
 	return json.Marshal(res)
 }
 
@@ -157,6 +205,15 @@ func (g *Grant) unmarshalJSON(data []byte) error {
 			return fmt.Errorf("unknown type specifier %q", typ)
 		}
 	}
+	if rawEffect, ok := raw["effect"]; ok {
+		effect, ok := rawEffect.(string)
+		if !ok {
+			return fmt.Errorf("unable to interpret %q as string", "effect")
+		}
+		if err := g.setEffect(effect); err != nil {
+			return err
+		}
+	}
 	if rawActions, ok := raw["actions"]; ok {
 		interfaceActions, ok := rawActions.([]interface{})
 		if !ok {
@@ -206,6 +263,11 @@ func (g *Grant) unmarshalText(grantString string) error {
 				return fmt.Errorf("unknown type specifier %q", typeString)
 			}
 
+		case "effect":
+			if err := g.setEffect(kv[1]); err != nil {
+				return err
+			}
+
 		case "actions":
 			actions := strings.Split(kv[1], ",")
 			if len(actions) > 0 {
@@ -223,6 +285,18 @@ func (g *Grant) unmarshalText(grantString string) error {
 	return nil
 }
 
+func (g *Grant) setEffect(effect string) error {
+	switch strings.ToLower(effect) {
+	case "allow":
+		g.effect = Allow
+	case "deny":
+		g.effect = Deny
+	default:
+		return fmt.Errorf("unknown effect %q", effect)
+	}
+	return nil
+}
+
 // Parse parses a grant string. Note that this does not do checking
 // of the validity of IDs and such; that's left for other parts of the system.
 // We may not check at all (e.g. let it be an authz-time failure) or could check
@@ -296,9 +370,12 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 	}
 
 	if !opts.withSkipFinalValidation {
-		// Validate the grant. Create a dummy resource and pass it through
-		// Allowed and ensure that we get allowed.
-		acl := NewACL(grant)
+		if err := grant.validateScopeType(); err != nil {
+			return Grant{}, err
+		}
+
+		// Validate the grant. Create a dummy resource and ensure that the
+		// grant matches it, which for an allow grant means it's allowed.
 		r := Resource{
 			ScopeId: scopeId,
 			Id:      grant.id,
@@ -307,14 +384,13 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 		if !topLevelType(grant.typ) {
 			r.Pin = grant.id
 		}
-		var allowed bool
+		var matched bool
 		for k := range grant.actions {
-			results := acl.Allowed(r, k)
-			if results.Allowed {
-				allowed = true
+			if grant.Matches(r, k) {
+				matched = true
 			}
 		}
-		if !allowed {
+		if !matched {
 			return Grant{}, errors.New("parsed grant string would not result in any action being authorized")
 		}
 	}
@@ -336,12 +412,34 @@ func (g Grant) validateType() error {
 		resource.HostSet,
 		resource.Host,
 		resource.Target,
-		resource.Session:
+		resource.Session,
+		resource.Job,
+		resource.ClientCertificateLibrary,
+		resource.Controller:
 		return nil
 	}
 	return fmt.Errorf("unknown type specifier %q", g.typ)
 }
 
+// validateScopeType returns an error if the grant's type can't exist in its
+// scope. Scopes, users, auth methods and their accounts and tokens only exist
+// in orgs and the global scope, and controllers are only managed from them.
+func (g Grant) validateScopeType() error {
+	if g.scope.Type != scope.Project {
+		return nil
+	}
+	switch g.typ {
+	case resource.Scope,
+		resource.User,
+		resource.AuthMethod,
+		resource.Account,
+		resource.AuthToken,
+		resource.Controller:
+		return fmt.Errorf("type %q cannot be granted in project %s: %w", g.typ.String(), g.scope.Id, ErrTypeNotInScope)
+	}
+	return nil
+}
+
 func (g *Grant) parseAndValidateActions() error {
 	if len(g.actionsBeingParsed) == 0 {
 		return errors.New("no actions specified")
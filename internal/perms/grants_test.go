@@ -175,6 +175,22 @@ func Test_MarshalingAndCloning(t *testing.T) {
 			jsonOutput:      `{"actions":["create","read"],"id":"baz","type":"group"}`,
 			canonicalString: `id=baz;type=group;actions=create,read`,
 		},
+		{
+			name: "deny",
+			input: Grant{
+				id: "baz",
+				scope: Scope{
+					Type: scope.Project,
+				},
+				typ: resource.Group,
+				actions: map[action.Type]bool{
+					action.Delete: true,
+				},
+				effect: Deny,
+			},
+			jsonOutput:      `{"actions":["delete"],"effect":"deny","id":"baz","type":"group"}`,
+			canonicalString: `id=baz;type=group;actions=delete;effect=deny`,
+		},
 	}
 
 	for _, test := range tests {
@@ -381,6 +397,58 @@ func Test_Parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "bad effect",
+			input: "id=foobar;actions=read;effect=maybe",
+			err:   `unknown effect "maybe"`,
+		},
+		{
+			name:  "good json deny",
+			input: `{"id":"foobar","actions":["read"],"effect":"deny"}`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id:  "foobar",
+				typ: resource.Unknown,
+				actions: map[action.Type]bool{
+					action.Read: true,
+				},
+				effect: Deny,
+			},
+		},
+		{
+			name:  "good text deny",
+			input: `id=*;type=target;actions=update;effect=deny`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id:  "*",
+				typ: resource.Target,
+				actions: map[action.Type]bool{
+					action.Update: true,
+				},
+				effect: Deny,
+			},
+		},
+		{
+			name:  "good text explicit allow",
+			input: `id=foobar;actions=read;effect=allow`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id:  "foobar",
+				typ: resource.Unknown,
+				actions: map[action.Type]bool{
+					action.Read: true,
+				},
+			},
+		},
 		{
 			name:  "good text type",
 			input: `type=host-catalog;actions=create`,
@@ -410,6 +478,18 @@ func Test_Parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:          "org type in project",
+			input:         `id=*;type=user;actions=read`,
+			scopeOverride: "p_1234",
+			err:           `type "user" cannot be granted in project p_1234`,
+		},
+		{
+			name:          "controller type in project",
+			input:         `id=*;type=controller;actions=update`,
+			scopeOverride: "p_1234",
+			err:           `type "controller" cannot be granted in project p_1234`,
+		},
 		{
 			name:          "default project scope",
 			input:         `id=foobar;actions=read`,
@@ -1,5 +1,7 @@
 package perms
 
+import "github.com/armon/go-metrics"
+
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -17,6 +19,7 @@ type options struct {
 	withUserId              string
 	withAccountId           string
 	withSkipFinalValidation bool
+	withMetrics             *metrics.Metrics
 }
 
 func getDefaultOptions() options {
@@ -45,3 +48,11 @@ func WithSkipFinalValidation(skipFinalValidation bool) Option {
 		o.withSkipFinalValidation = skipFinalValidation
 	}
 }
+
+// WithMetrics sets the go-metrics instance a Cache emits to instead of the
+// global one.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(o *options) {
+		o.withMetrics = m
+	}
+}
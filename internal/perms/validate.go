@@ -0,0 +1,145 @@
+package perms
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+var (
+	// ErrUnsupportedAction is returned by ValidateGrant when a grant names
+	// actions which its resource type doesn't support.
+	ErrUnsupportedAction = errors.New("unsupported action")
+
+	// ErrGrantBanned is returned by ValidateGrant when a grant matches a
+	// banned pattern.
+	ErrGrantBanned = errors.New("grant is banned")
+
+	// ErrInvalidPattern is returned by ParseGrantPattern when a pattern can't
+	// be parsed.
+	ErrInvalidPattern = errors.New("invalid grant pattern")
+)
+
+// GrantPattern is a pattern of grants, like "id=*;actions=*", which grants are
+// banned from matching.
+//
+// A pattern has the form of a grant with any of its id, type and actions
+// left out. A grant matches it if the grant has the pattern's id and type, if
+// they're given, and allows at least the pattern's actions, so a grant of all
+// actions matches a pattern of any actions. Deny grants never match.
+type GrantPattern struct {
+	// Policy describes the policy which bans the pattern, like
+	// `"no-wildcards" of global`, in the error returned when a grant matches
+	// it.
+	Policy string
+
+	pattern string
+	id      string
+	typ     resource.Type
+	actions []action.Type
+}
+
+// ParseGrantPattern parses a grant pattern banned by the described policy.
+func ParseGrantPattern(policy, pattern string) (*GrantPattern, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return nil, fmt.Errorf("empty grant pattern: %w", ErrInvalidPattern)
+	}
+	p := &GrantPattern{Policy: policy, pattern: pattern}
+	seen := map[string]bool{}
+	for _, segment := range strings.Split(pattern, ";") {
+		kv := strings.SplitN(segment, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, fmt.Errorf("segment %q of grant pattern %q is not in the form key=value: %w", segment, pattern, ErrInvalidPattern)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+		if seen[key] {
+			return nil, fmt.Errorf("grant pattern %q has %s more than once: %w", pattern, key, ErrInvalidPattern)
+		}
+		seen[key] = true
+		switch key {
+		case "id":
+			p.id = value
+		case "type":
+			typ, ok := resource.Map[value]
+			if !ok {
+				return nil, fmt.Errorf("unknown type %q in grant pattern %q: %w", value, pattern, ErrInvalidPattern)
+			}
+			p.typ = typ
+		case "actions":
+			for _, a := range strings.Split(value, ",") {
+				at, ok := action.Map[a]
+				if !ok {
+					return nil, fmt.Errorf("unknown action %q in grant pattern %q: %w", a, pattern, ErrInvalidPattern)
+				}
+				p.actions = append(p.actions, at)
+			}
+		default:
+			return nil, fmt.Errorf("unknown key %q in grant pattern %q: %w", key, pattern, ErrInvalidPattern)
+		}
+	}
+	return p, nil
+}
+
+// String returns the pattern as it was parsed.
+func (p *GrantPattern) String() string {
+	return p.pattern
+}
+
+// Matches reports whether the grant matches the pattern.
+func (p *GrantPattern) Matches(g Grant) bool {
+	if g.Effect() == Deny {
+		return false
+	}
+	if p.id != "" && p.id != g.Id() {
+		return false
+	}
+	if p.typ != resource.Unknown && p.typ != g.Type() {
+		return false
+	}
+	typs, _ := g.Actions()
+	granted := make(map[action.Type]bool, len(typs))
+	for _, a := range typs {
+		granted[a] = true
+	}
+	for _, a := range p.actions {
+		if !granted[a] && !granted[action.All] {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateGrant checks a parsed grant the way the controller does before a
+// role grant is written. The grant's actions must be ones its resource type
+// supports, which isn't checked for grants on all types or on an id without a
+// type, and the grant must not match any of the banned patterns. It needs no
+// database, so policy documents can be checked with it before they're
+// applied.
+func ValidateGrant(g Grant, banned ...*GrantPattern) error {
+	if supported, ok := action.ForResource(g.Type()); ok {
+		_, actions := g.Actions()
+		var unknown []string
+		for _, a := range actions {
+			if a == action.All.String() {
+				continue
+			}
+			if _, ok := supported[a]; !ok {
+				unknown = append(unknown, a)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return fmt.Errorf("unknown actions %s for type %q: %w", strings.Join(unknown, ", "), g.Type().String(), ErrUnsupportedAction)
+		}
+	}
+	for _, p := range banned {
+		if p.Matches(g) {
+			return fmt.Errorf("grant %q is banned by policy %s, which bans grants matching %q: %w", g.CanonicalString(), p.Policy, p.pattern, ErrGrantBanned)
+		}
+	}
+	return nil
+}
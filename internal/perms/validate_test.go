@@ -0,0 +1,105 @@
+package perms
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGrantPattern(t *testing.T) {
+	t.Parallel()
+	for _, bad := range []string{
+		"",
+		"  ",
+		"id",
+		"id=",
+		"id=*;id=*",
+		"type=nope",
+		"actions=read,nope",
+		"scope=*",
+	} {
+		_, err := ParseGrantPattern("policy", bad)
+		assert.Truef(t, errors.Is(err, ErrInvalidPattern), "pattern %q", bad)
+	}
+}
+
+func TestGrantPattern_Matches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pattern string
+		grant   string
+		want    bool
+	}{
+		{"id=*;actions=*", "id=*;type=*;actions=*", true},
+		{"id=*;actions=*", "id=*;type=target;actions=*", true},
+		{"id=*;actions=*", "id=*;type=target;actions=read", false},
+		{"id=*;actions=*", "id=ttcp_1234567890;actions=*", false},
+		{"type=*;actions=delete", "id=*;type=*;actions=*", true},
+		{"type=*;actions=delete", "id=*;type=*;actions=read,delete", true},
+		{"type=*;actions=delete", "id=*;type=target;actions=delete", false},
+		{"actions=set-grants", "id=*;type=role;actions=set-grants,add-grants", true},
+		{"actions=set-grants", "id=*;type=role;actions=add-grants", false},
+		{"id=*", "id=*;type=role;actions=read", true},
+		// Deny grants only take permissions away
+		{"id=*;actions=*", "id=*;type=*;actions=*;effect=deny", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.grant, func(t *testing.T) {
+			p, err := ParseGrantPattern("policy", tt.pattern)
+			require.NoError(t, err)
+			g, err := Parse("p_1234567890", tt.grant)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, p.Matches(g))
+		})
+	}
+}
+
+func TestValidateGrant(t *testing.T) {
+	t.Parallel()
+	banned, err := ParseGrantPattern(`"no-wildcards" of global`, "id=*;actions=*")
+	require.NoError(t, err)
+	tests := []struct {
+		name    string
+		scopeId string
+		grant   string
+		wantErr error
+		wantMsg string
+	}{
+		{name: "all-types", scopeId: "o_1234567890", grant: "id=*;type=*;actions=read"},
+		{name: "untyped", scopeId: "o_1234567890", grant: "id=u_1234567890;actions=read,set-password"},
+		{name: "role", scopeId: "o_1234567890", grant: "id=*;type=role;actions=read,add-grants,set-principals"},
+		{name: "target", scopeId: "p_1234567890", grant: "id=*;type=target;actions=authorize-session"},
+		{
+			name:    "unsupported",
+			scopeId: "o_1234567890",
+			grant:   "id=*;type=user;actions=read,cancel,add-members",
+			wantErr: ErrUnsupportedAction,
+			wantMsg: `unknown actions add-members, cancel for type "user"`,
+		},
+		{
+			name:    "banned",
+			scopeId: "p_1234567890",
+			grant:   "id=*;type=target;actions=*",
+			wantErr: ErrGrantBanned,
+			wantMsg: `banned by policy "no-wildcards" of global`,
+		},
+		{name: "deny", scopeId: "p_1234567890", grant: "id=*;type=target;actions=*;effect=deny"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			g, err := Parse(tt.scopeId, tt.grant)
+			require.NoError(err)
+			err = ValidateGrant(g, banned)
+			if tt.wantErr != nil {
+				require.Error(err)
+				assert.True(errors.Is(err, tt.wantErr))
+				assert.Contains(err.Error(), tt.wantMsg)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
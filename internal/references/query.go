@@ -0,0 +1,66 @@
+package references
+
+const (
+	// grantIdExpr extracts the id a canonical grant names, if any.
+	grantIdExpr = `substring(canonical_grant from '^id=([^;]+)')`
+
+	// sessionNotTerminated restricts the sessions table s to the sessions
+	// which haven't terminated.
+	sessionNotTerminated = `
+exists (
+	select 1
+	  from session_state ss
+	 where ss.session_id = s.public_id
+	   and ss.end_time is null
+	   and ss.state != 'terminated'
+)`
+
+	existsQuery = `select 1 from %s where public_id = $1`
+
+	scopeParentQuery = `select parent_id from iam_scope where public_id = $1 and parent_id is not null`
+	scopeChildQuery  = `select public_id from iam_scope where parent_id = $1`
+
+	roleGrantScopeQuery = `select grant_scope_id from iam_role where public_id = $1 and grant_scope_id != scope_id`
+	roleGrantedInQuery  = `select public_id from iam_role where grant_scope_id = $1 and scope_id != $1`
+	roleGrantIdsQuery   = `
+select distinct ` + grantIdExpr + `
+  from iam_role_grant
+ where role_id = $1
+   and ` + grantIdExpr + ` != '*'
+   and ` + grantIdExpr + ` not like '{{%'`
+	grantedByQuery = `select distinct role_id from iam_role_grant where ` + grantIdExpr + ` = $1`
+
+	roleUserQuery       = `select principal_id from iam_user_role where role_id = $1`
+	roleGroupQuery      = `select principal_id from iam_group_role where role_id = $1`
+	principalRoleQuery  = `select role_id from iam_user_role where principal_id = $1 union select role_id from iam_group_role where principal_id = $1`
+	roleIncludesQuery   = `select included_role_id from iam_role_include where role_id = $1`
+	roleIncludedByQuery = `select role_id from iam_role_include where included_role_id = $1`
+
+	groupMemberQuery  = `select member_id from iam_group_member_user where group_id = $1`
+	memberGroupsQuery = `select group_id from iam_group_member_user where member_id = $1`
+
+	userAccountQuery    = `select public_id from auth_account where iam_user_id = $1`
+	accountUserQuery    = `select iam_user_id from auth_account where public_id = $1 and iam_user_id is not null`
+	accountMethodQuery  = `select auth_method_id from auth_account where public_id = $1`
+	methodAccountsQuery = `select public_id from auth_account where auth_method_id = $1`
+
+	catalogHostsQuery = `select public_id from host where catalog_id = $1`
+	catalogSetsQuery  = `select public_id from host_set where catalog_id = $1`
+	hostCatalogQuery  = `select catalog_id from host where public_id = $1`
+	setCatalogQuery   = `select catalog_id from host_set where public_id = $1`
+	setHostsQuery     = `select host_id from static_host_set_member where set_id = $1`
+	hostSetsQuery     = `select set_id from static_host_set_member where host_id = $1`
+
+	targetSetsQuery = `select host_set_id from target_host_set where target_id = $1`
+	setTargetsQuery = `select target_id from target_host_set where host_set_id = $1`
+
+	claimRuleRoleQuery  = `select role_id from iam_claim_rule where public_id = $1 and role_id is not null`
+	claimRuleGroupQuery = `select group_id from iam_claim_rule where public_id = $1 and group_id is not null`
+	roleClaimRuleQuery  = `select public_id from iam_claim_rule where role_id = $1`
+	groupClaimRuleQuery = `select public_id from iam_claim_rule where group_id = $1`
+
+	sessionTargetQuery = `select target_id from session where public_id = $1 and target_id is not null`
+	sessionSetQuery    = `select host_set_id from session where public_id = $1 and host_set_id is not null`
+	sessionHostQuery   = `select host_id from session where public_id = $1 and host_id is not null`
+	sessionUserQuery   = `select user_id from session where public_id = $1 and user_id is not null`
+)
@@ -0,0 +1,383 @@
+// Package references reports what a resource references and what references
+// it, so the impact of deleting or changing a resource can be seen before
+// it's made.
+package references
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+// Relation describes how one resource references another.
+type Relation string
+
+const (
+	// RelationScope is the scope a resource belongs to.
+	RelationScope Relation = "scope"
+
+	// RelationParent is the parent of a scope.
+	RelationParent Relation = "parent"
+
+	// RelationGrantScope is the scope a role's grants apply to, when it
+	// isn't the role's own scope.
+	RelationGrantScope Relation = "grant scope"
+
+	// RelationGrant is a resource named by the id of one of a role's grants.
+	RelationGrant Relation = "grant"
+
+	// RelationPrincipal is a user or group a role is assigned to.
+	RelationPrincipal Relation = "principal"
+
+	// RelationInclude is a role whose grants another role includes.
+	RelationInclude Relation = "include"
+
+	// RelationMember is a user in a group, or a host in a host set.
+	RelationMember Relation = "member"
+
+	// RelationAccount is the user an account is linked to.
+	RelationAccount Relation = "account"
+
+	// RelationAuthMethod is the auth method an account belongs to.
+	RelationAuthMethod Relation = "auth method"
+
+	// RelationCatalog is the host catalog a host or host set belongs to.
+	RelationCatalog Relation = "catalog"
+
+	// RelationHostSet is a host set a target connects to.
+	RelationHostSet Relation = "host set"
+
+	// RelationClaimRule is the role or group a claim rule maps users into.
+	RelationClaimRule Relation = "claim rule"
+
+	// RelationSession is a resource a session which hasn't terminated was
+	// established with.
+	RelationSession Relation = "session"
+)
+
+// Edge is a resource at the other end of a reference.
+type Edge struct {
+	Id       string        `json:"id"`
+	Type     resource.Type `json:"-"`
+	TypeName string        `json:"type"`
+	Relation Relation      `json:"relation"`
+}
+
+// Node is a resource along with what it references and what references it.
+// Deleting a resource cascades to, or invalidates, the resources which
+// reference it.
+type Node struct {
+	Id           string        `json:"id"`
+	Type         resource.Type `json:"-"`
+	TypeName     string        `json:"type"`
+	References   []*Edge       `json:"references,omitempty"`
+	ReferencedBy []*Edge       `json:"referenced_by,omitempty"`
+}
+
+// Repository looks up the references between resources.
+type Repository struct {
+	reader db.Reader
+}
+
+// NewRepository creates a new references Repository.
+func NewRepository(r db.Reader) (*Repository, error) {
+	if r == nil {
+		return nil, errors.New("error creating db repository with nil reader")
+	}
+	return &Repository{reader: r}, nil
+}
+
+// edgeQuery finds the ids at the other end of one kind of reference, given the
+// id of the resource being looked up as $1.
+type edgeQuery struct {
+	query    string
+	relation Relation
+}
+
+// kind is the table a type of resource is stored in and the queries for its
+// references in each direction.
+type kind struct {
+	typ          resource.Type
+	table        string
+	references   []edgeQuery
+	referencedBy []edgeQuery
+}
+
+// scopedQuery finds the resources in table which belong to the scope $1.
+func scopedQuery(table string) edgeQuery {
+	return edgeQuery{fmt.Sprintf(`select public_id from %s where scope_id = $1`, table), RelationScope}
+}
+
+// scopeOfQuery finds the scope of the resource $1 in table.
+func scopeOfQuery(table string) edgeQuery {
+	return edgeQuery{fmt.Sprintf(`select scope_id from %s where public_id = $1`, table), RelationScope}
+}
+
+// sessionsQuery finds the sessions which haven't terminated whose column is $1.
+func sessionsQuery(column string) edgeQuery {
+	return edgeQuery{fmt.Sprintf(`select s.public_id from session s where s.%s = $1 and %s`, column, sessionNotTerminated), RelationSession}
+}
+
+var (
+	scopeKind = &kind{
+		typ:        resource.Scope,
+		table:      "iam_scope",
+		references: []edgeQuery{{scopeParentQuery, RelationParent}},
+		referencedBy: []edgeQuery{
+			{scopeChildQuery, RelationParent},
+			scopedQuery("iam_user"),
+			scopedQuery("iam_group"),
+			scopedQuery("iam_role"),
+			{roleGrantedInQuery, RelationGrantScope},
+			scopedQuery("auth_method"),
+			scopedQuery("host_catalog"),
+			scopedQuery("target"),
+			scopedQuery("iam_claim_rule"),
+		},
+	}
+	userKind = &kind{
+		typ:        resource.User,
+		table:      "iam_user",
+		references: []edgeQuery{scopeOfQuery("iam_user")},
+		referencedBy: []edgeQuery{
+			{userAccountQuery, RelationAccount},
+			{memberGroupsQuery, RelationMember},
+			{principalRoleQuery, RelationPrincipal},
+			sessionsQuery("user_id"),
+		},
+	}
+	groupKind = &kind{
+		typ:        resource.Group,
+		table:      "iam_group",
+		references: []edgeQuery{scopeOfQuery("iam_group"), {groupMemberQuery, RelationMember}},
+		referencedBy: []edgeQuery{
+			{principalRoleQuery, RelationPrincipal},
+			{groupClaimRuleQuery, RelationClaimRule},
+		},
+	}
+	roleKind = &kind{
+		typ:   resource.Role,
+		table: "iam_role",
+		references: []edgeQuery{
+			scopeOfQuery("iam_role"),
+			{roleGrantScopeQuery, RelationGrantScope},
+			{roleGrantIdsQuery, RelationGrant},
+			{roleUserQuery, RelationPrincipal},
+			{roleGroupQuery, RelationPrincipal},
+			{roleIncludesQuery, RelationInclude},
+		},
+		referencedBy: []edgeQuery{
+			{roleIncludedByQuery, RelationInclude},
+			{roleClaimRuleQuery, RelationClaimRule},
+		},
+	}
+	authMethodKind = &kind{
+		typ:          resource.AuthMethod,
+		table:        "auth_method",
+		references:   []edgeQuery{scopeOfQuery("auth_method")},
+		referencedBy: []edgeQuery{{methodAccountsQuery, RelationAuthMethod}},
+	}
+	accountKind = &kind{
+		typ:        resource.Account,
+		table:      "auth_account",
+		references: []edgeQuery{{accountMethodQuery, RelationAuthMethod}, {accountUserQuery, RelationAccount}},
+	}
+	hostCatalogKind = &kind{
+		typ:        resource.HostCatalog,
+		table:      "host_catalog",
+		references: []edgeQuery{scopeOfQuery("host_catalog")},
+		referencedBy: []edgeQuery{
+			{catalogHostsQuery, RelationCatalog},
+			{catalogSetsQuery, RelationCatalog},
+		},
+	}
+	hostSetKind = &kind{
+		typ:        resource.HostSet,
+		table:      "host_set",
+		references: []edgeQuery{{setCatalogQuery, RelationCatalog}, {setHostsQuery, RelationMember}},
+		referencedBy: []edgeQuery{
+			{setTargetsQuery, RelationHostSet},
+			sessionsQuery("host_set_id"),
+		},
+	}
+	hostKind = &kind{
+		typ:        resource.Host,
+		table:      "host",
+		references: []edgeQuery{{hostCatalogQuery, RelationCatalog}},
+		referencedBy: []edgeQuery{
+			{hostSetsQuery, RelationMember},
+			sessionsQuery("host_id"),
+		},
+	}
+	targetKind = &kind{
+		typ:          resource.Target,
+		table:        "target",
+		references:   []edgeQuery{scopeOfQuery("target"), {targetSetsQuery, RelationHostSet}},
+		referencedBy: []edgeQuery{sessionsQuery("target_id")},
+	}
+	sessionKind = &kind{
+		typ:   resource.Session,
+		table: "session",
+		references: []edgeQuery{
+			scopeOfQuery("session"),
+			{sessionTargetQuery, RelationSession},
+			{sessionSetQuery, RelationSession},
+			{sessionHostQuery, RelationSession},
+			{sessionUserQuery, RelationSession},
+		},
+	}
+	claimRuleKind = &kind{
+		typ:   resource.ClaimRule,
+		table: "iam_claim_rule",
+		references: []edgeQuery{
+			scopeOfQuery("iam_claim_rule"),
+			{claimRuleRoleQuery, RelationClaimRule},
+			{claimRuleGroupQuery, RelationClaimRule},
+		},
+	}
+)
+
+// kindOf returns the kind of resource id is, from its prefix, or nil if it's
+// not a kind of resource references are known for.
+func kindOf(id string) *kind {
+	if id == scope.Global.String() {
+		return scopeKind
+	}
+	i := strings.LastIndex(id, "_")
+	if i < 0 {
+		return nil
+	}
+	switch id[:i] {
+	case scope.Org.Prefix(), scope.Project.Prefix():
+		return scopeKind
+	case iam.UserPrefix:
+		return userKind
+	case iam.GroupPrefix:
+		return groupKind
+	case iam.RolePrefix:
+		return roleKind
+	case iam.ClaimRulePrefix:
+		return claimRuleKind
+	case password.AuthMethodPrefix:
+		return authMethodKind
+	case password.AccountPrefix:
+		return accountKind
+	case static.HostCatalogPrefix:
+		return hostCatalogKind
+	case static.HostSetPrefix:
+		return hostSetKind
+	case static.HostPrefix:
+		return hostKind
+	case target.TcpTargetPrefix:
+		return targetKind
+	case session.SessionPrefix:
+		return sessionKind
+	}
+	return nil
+}
+
+// typeOf returns the type of resource id is, or resource.Unknown.
+func typeOf(id string) resource.Type {
+	if k := kindOf(id); k != nil {
+		return k.typ
+	}
+	return resource.Unknown
+}
+
+// Lookup returns the resource with the public id along with what it references
+// and what references it. Since grants name resources by id, a grant is
+// reported as a reference from its role to whatever the id names, even if
+// that resource no longer exists. Sessions are only reported while they
+// haven't terminated. Returns nil, nil if the resource doesn't exist.
+func (r *Repository) Lookup(ctx context.Context, publicId string) (*Node, error) {
+	if publicId == "" {
+		return nil, fmt.Errorf("lookup references: missing public id: %w", db.ErrInvalidParameter)
+	}
+	k := kindOf(publicId)
+	if k == nil {
+		return nil, fmt.Errorf("lookup references: unsupported resource %q: %w", publicId, db.ErrInvalidParameter)
+	}
+	found, err := r.exists(ctx, k, publicId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup references: %w", err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	n := &Node{
+		Id:       publicId,
+		Type:     k.typ,
+		TypeName: k.typ.String(),
+	}
+	if n.References, err = r.edges(ctx, publicId, k.references); err != nil {
+		return nil, fmt.Errorf("lookup references: %w", err)
+	}
+	referencedBy := append([]edgeQuery{{grantedByQuery, RelationGrant}}, k.referencedBy...)
+	if n.ReferencedBy, err = r.edges(ctx, publicId, referencedBy); err != nil {
+		return nil, fmt.Errorf("lookup references: %w", err)
+	}
+	return n, nil
+}
+
+func (r *Repository) exists(ctx context.Context, k *kind, publicId string) (bool, error) {
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(existsQuery, k.table), []interface{}{publicId})
+	if err != nil {
+		return false, fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
+	found := rows.Next()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	return found, nil
+}
+
+// edges runs each query for publicId and returns the edges they find, sorted
+// by relation and id.
+func (r *Repository) edges(ctx context.Context, publicId string, queries []edgeQuery) ([]*Edge, error) {
+	var edges []*Edge
+	for _, q := range queries {
+		rows, err := r.reader.Query(ctx, q.query, []interface{}{publicId})
+		if err != nil {
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("scan row failed: %w", err)
+			}
+			t := typeOf(id)
+			edges = append(edges, &Edge{
+				Id:       id,
+				Type:     t,
+				TypeName: t.String(),
+				Relation: q.relation,
+			})
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Relation != edges[j].Relation {
+			return edges[i].Relation < edges[j].Relation
+		}
+		return edges[i].Id < edges[j].Id
+	})
+	return edges, nil
+}
@@ -0,0 +1,128 @@
+package references
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Lookup(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(db.New(conn))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	catalog := static.TestCatalogs(t, conn, proj.PublicId, 1)[0]
+	hosts := static.TestHosts(t, conn, catalog.PublicId, 2)
+	set := static.TestSets(t, conn, catalog.PublicId, 1)[0]
+	static.TestSetMembers(t, conn, set.PublicId, hosts[:1])
+	tar := target.TestTcpTarget(t, conn, proj.PublicId, "test", target.WithHostSets([]string{set.PublicId}))
+
+	user := iam.TestUser(t, iamRepo, org.PublicId)
+	grp := iam.TestGroup(t, conn, org.PublicId)
+	iam.TestGroupMember(t, conn, grp.PublicId, user.PublicId)
+	role := iam.TestRole(t, conn, org.PublicId, iam.WithGrantScopeId(proj.PublicId))
+	iam.TestRoleGrant(t, conn, role.PublicId, "id="+tar.PublicId+";actions=authorize-session")
+	iam.TestRoleGrant(t, conn, role.PublicId, "id=*;type=host-set;actions=read")
+	iam.TestGroupRole(t, conn, role.PublicId, grp.PublicId)
+
+	edge := func(id string, typ resource.Type, rel Relation) *Edge {
+		return &Edge{Id: id, Type: typ, TypeName: typ.String(), Relation: rel}
+	}
+
+	tests := []struct {
+		name             string
+		id               string
+		wantType         resource.Type
+		wantReferences   []*Edge
+		wantReferencedBy []*Edge
+	}{
+		{
+			name:     "role",
+			id:       role.PublicId,
+			wantType: resource.Role,
+			wantReferences: []*Edge{
+				edge(tar.PublicId, resource.Target, RelationGrant),
+				edge(proj.PublicId, resource.Scope, RelationGrantScope),
+				edge(grp.PublicId, resource.Group, RelationPrincipal),
+				edge(org.PublicId, resource.Scope, RelationScope),
+			},
+		},
+		{
+			name:     "target",
+			id:       tar.PublicId,
+			wantType: resource.Target,
+			wantReferences: []*Edge{
+				edge(set.PublicId, resource.HostSet, RelationHostSet),
+				edge(proj.PublicId, resource.Scope, RelationScope),
+			},
+			wantReferencedBy: []*Edge{
+				edge(role.PublicId, resource.Role, RelationGrant),
+			},
+		},
+		{
+			name:     "host-set",
+			id:       set.PublicId,
+			wantType: resource.HostSet,
+			wantReferences: []*Edge{
+				edge(catalog.PublicId, resource.HostCatalog, RelationCatalog),
+				edge(hosts[0].PublicId, resource.Host, RelationMember),
+			},
+			wantReferencedBy: []*Edge{
+				edge(tar.PublicId, resource.Target, RelationHostSet),
+			},
+		},
+		{
+			name:     "host-not-in-a-set",
+			id:       hosts[1].PublicId,
+			wantType: resource.Host,
+			wantReferences: []*Edge{
+				edge(catalog.PublicId, resource.HostCatalog, RelationCatalog),
+			},
+		},
+		{
+			name:     "user",
+			id:       user.PublicId,
+			wantType: resource.User,
+			wantReferences: []*Edge{
+				edge(org.PublicId, resource.Scope, RelationScope),
+			},
+			wantReferencedBy: []*Edge{
+				edge(grp.PublicId, resource.Group, RelationMember),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.Lookup(ctx, tt.id)
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(tt.wantType, got.Type)
+			assert.Equal(tt.wantReferences, got.References)
+			assert.Equal(tt.wantReferencedBy, got.ReferencedBy)
+		})
+	}
+
+	t.Run("not-found", func(t *testing.T) {
+		got, err := repo.Lookup(ctx, "r_1234567890")
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := repo.Lookup(ctx, "at_1234567890")
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+	})
+}
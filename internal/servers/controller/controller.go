@@ -3,16 +3,25 @@ package controller
 import (
 	"context"
 	"crypto/rand"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/boundary/internal/auth/adaptive"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/cmd/config"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/jobs"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/session"
@@ -22,6 +31,7 @@ import (
 	"github.com/hashicorp/vault/sdk/helper/mlock"
 	"github.com/patrickmn/go-cache"
 	ua "go.uber.org/atomic"
+	"google.golang.org/grpc/health"
 )
 
 type Controller struct {
@@ -38,17 +48,64 @@ type Controller struct {
 	workerStatusUpdateTimes *sync.Map
 
 	// Repo factory methods
+	AdaptiveAuthRepoFn common.AdaptiveAuthRepoFactory
 	AuthTokenRepoFn    common.AuthTokenRepoFactory
 	IamRepoFn          common.IamRepoFactory
+	JobRepoFn          common.JobRepoFactory
 	PasswordAuthRepoFn common.PasswordAuthRepoFactory
 	ServersRepoFn      common.ServersRepoFactory
 	SessionRepoFn      common.SessionRepoFactory
 	StaticHostRepoFn   common.StaticRepoFactory
 	TargetRepoFn       common.TargetRepoFactory
 
+	// Repo factory methods of the api services, whose writes must carry the
+	// user and scope of their request; see iam.WithRequestInfo.
+	apiAuthTokenRepoFn    common.AuthTokenRepoFactory
+	apiIamRepoFn          common.IamRepoFactory
+	apiPasswordAuthRepoFn common.PasswordAuthRepoFactory
+	apiSessionRepoFn      common.SessionRepoFactory
+	apiStaticHostRepoFn   common.StaticRepoFactory
+	apiTargetRepoFn       common.TargetRepoFactory
+
 	kms *kms.Kms
 
+	// jobRunner runs the jobs started through this controller in the
+	// background.
+	jobRunner *jobs.Runner
+
+	// grantsCache caches the grants of users for authorizing requests, if
+	// it's enabled.
+	grantsCache *perms.Cache
+
+	// tunables are the runtime tunables of the controller, changed through
+	// the tunables api.
+	tunables *tunables
+
+	// follower is whether the controller is a read-only follower, serving
+	// only reads from a replica which is at most maxStaleness behind, if
+	// that's set.
+	follower     bool
+	maxStaleness time.Duration
+	replica      *replicaStatus
+	// leaderAddr is the api address of a controller using the primary, to
+	// which a follower proxies authentication, if it's set.
+	leaderAddr *url.URL
+
+	// unregisterWriteHook unregisters the configured write hook, if any.
+	unregisterWriteHook func()
+
+	// adaptiveAuthPolicy is the sensitivity of each auth method to unusual
+	// logins, and unregisterStepUpHook unregisters the configured step-up
+	// hook, if any.
+	adaptiveAuthPolicy   *adaptive.Policy
+	unregisterStepUpHook func()
+
+	// unregisterChangeTicketSystems unregisters the configured change ticket
+	// systems.
+	unregisterChangeTicketSystems []func()
+
 	clusterAddress string
+	clusterHealth  *health.Server
 }
 
 func New(conf *Config) (*Controller, error) {
@@ -56,6 +113,8 @@ func New(conf *Config) (*Controller, error) {
 		conf:                    conf,
 		logger:                  conf.Logger.Named("controller"),
 		workerStatusUpdateTimes: new(sync.Map),
+		replica:                 new(replicaStatus),
+		tunables:                newTunables(conf.LogLevel),
 	}
 
 	c.started.Store(false)
@@ -90,8 +149,26 @@ func New(conf *Config) (*Controller, error) {
 		}
 	}
 
+	if f := conf.RawConfig.Controller.Follower; f != nil {
+		c.follower = true
+		if f.MaxStaleness != "" {
+			if c.maxStaleness, err = time.ParseDuration(f.MaxStaleness); err != nil {
+				return nil, fmt.Errorf("error parsing follower max staleness: %w", err)
+			}
+		}
+		if f.LeaderAddr != "" {
+			if c.leaderAddr, err = url.Parse(f.LeaderAddr); err != nil {
+				return nil, fmt.Errorf("error parsing follower leader address: %w", err)
+			}
+			if c.leaderAddr.Scheme == "" || c.leaderAddr.Host == "" {
+				return nil, fmt.Errorf("follower leader address %q must be an absolute url", f.LeaderAddr)
+			}
+		}
+		c.replica.set(0, errReplicaLagUnknown)
+	}
+
 	// Set up repo stuff
-	dbase := db.New(c.conf.Database)
+	dbase := db.New(c.conf.Database, db.WithRetryTransientErrors(true))
 	kmsRepo, err := kms.NewRepository(dbase, dbase)
 	if err != nil {
 		return nil, fmt.Errorf("error creating kms repository: %w", err)
@@ -107,26 +184,124 @@ func New(conf *Config) (*Controller, error) {
 	); err != nil {
 		return nil, fmt.Errorf("error adding config keys to kms: %w", err)
 	}
-	c.IamRepoFn = func() (*iam.Repository, error) {
-		return iam.NewRepository(dbase, dbase, c.kms, iam.WithRandomReader(c.conf.SecureRandomReader))
+	var quotas iam.Quotas
+	if q := c.conf.RawConfig.Controller.Quotas; q != nil {
+		quotas = iam.Quotas{
+			MaxRolesPerScope:   q.MaxRolesPerScope,
+			MaxGrantsPerRole:   q.MaxGrantsPerRole,
+			MaxMembersPerGroup: q.MaxMembersPerGroup,
+		}
+	}
+	var lengthLimits iam.LengthLimits
+	if l := c.conf.RawConfig.Controller.LengthLimits; l != nil {
+		lengthLimits = iam.LengthLimits{
+			MaxNameLength:        l.MaxNameLength,
+			MaxDescriptionLength: l.MaxDescriptionLength,
+			MaxGrantLength:       l.MaxGrantLength,
+		}
+	}
+	snapshotKeys, err := loadSnapshotKeys(c.conf.RawConfig.Controller.SnapshotSigning)
+	if err != nil {
+		return nil, fmt.Errorf("error loading snapshot signing keys: %w", err)
+	}
+	snapshotSigner := c.conf.RawConfig.Controller.Name
+	if s := c.conf.RawConfig.Controller.SnapshotSigning; s != nil && s.Signer != "" {
+		snapshotSigner = s.Signer
+	}
+	// Check the length limits, max page size and snapshot keys here, so
+	// invalid ones fail startup rather than every request.
+	maxPageSize := c.conf.RawConfig.Controller.MaxPageSize
+	if _, err := iam.NewRepository(dbase, dbase, c.kms, iam.WithLengthLimits(lengthLimits), iam.WithMaxPageSize(maxPageSize), iam.WithSnapshotKeys(snapshotKeys...)); err != nil {
+		return nil, fmt.Errorf("error checking iam repository limits: %w", err)
+	}
+	if a := c.conf.RawConfig.Controller.AdaptiveAuth; a != nil {
+		if c.adaptiveAuthPolicy, err = adaptive.NewPolicy(a.Sensitivity, a.AuthMethodSensitivity); err != nil {
+			return nil, fmt.Errorf("error parsing adaptive auth config: %w", err)
+		}
+	}
+	for _, cts := range c.conf.RawConfig.Controller.ChangeTicketSystems {
+		switch {
+		case target.ValidChangeTicketSystem(cts.Name) != nil || cts.Name == "":
+			return nil, fmt.Errorf("change ticket system %q must have a name without leading or trailing spaces", cts.Name)
+		case cts.ValidateUrl == "":
+			return nil, fmt.Errorf("change ticket system %q must have a validate_url", cts.Name)
+		}
+	}
+	if secs := c.conf.RawConfig.Controller.GrantsCacheSeconds; secs > 0 {
+		c.grantsCache = perms.NewCache(time.Duration(secs) * time.Second)
+	}
+	quotaLogger := c.logger.Named("quota")
+	quotaAlerts := func(a *iam.QuotaAlert) {
+		quotaLogger.Warn("quota threshold reached", "quota", a.Quota, "scope_id", a.ScopeId, "id", a.Id, "count", a.Count, "limit", a.Limit, "threshold_percent", a.Threshold)
+	}
+	auditLogger := c.logger.Named("audit")
+	roleAudit := func(d *iam.RoleDiff) {
+		auditLogger.Info("role changed", "role_id", d.RoleId, "changes", d.Summary())
+	}
+	sessionAudit := func(s *session.Session) {
+		auditLogger.Info("session authorized", "session_id", s.PublicId, "user_id", s.UserId, "target_id", s.TargetId, "host_id", s.HostId, "scope_id", s.ScopeId, "justification", s.Justification, "change_ticket_system", s.ChangeTicketSystem, "change_ticket", s.ChangeTicket)
 	}
-	c.StaticHostRepoFn = func() (*static.Repository, error) {
-		return static.NewRepository(dbase, dbase, c.kms)
+	securityLogger := c.logger.Named("security")
+	emergencyRoleEvents := func(e *iam.EmergencyRoleEvent) {
+		a := e.Activation
+		securityLogger.Warn("emergency role "+string(e.Type), "role_id", a.RoleId, "user_id", a.UserId, "justification", a.Justification, "activate_time", a.ActivateTime, "expiration_time", a.ExpirationTime, "request_user_id", e.RequestUserId)
 	}
-	c.AuthTokenRepoFn = func() (*authtoken.Repository, error) {
-		return authtoken.NewRepository(dbase, dbase, c.kms)
+	iamRepoFn := func(d *db.Db) common.IamRepoFactory {
+		return func() (*iam.Repository, error) {
+			return iam.NewRepository(d, d, c.kms, iam.WithRandomReader(c.conf.SecureRandomReader), iam.WithQuotas(quotas), iam.WithIntegrityEnforcement(c.conf.RawConfig.Controller.IntegrityEnforcement), iam.WithLengthLimits(lengthLimits), iam.WithFastReads(c.conf.RawConfig.Controller.FastReads), iam.WithGrantsCache(c.grantsCache), iam.WithQuotaAlerts(quotaAlerts), iam.WithMaxPageSize(maxPageSize), iam.WithRoleAudit(roleAudit), iam.WithEmergencyRoleEvents(emergencyRoleEvents), iam.WithSnapshotKeys(snapshotKeys...), iam.WithSnapshotSigner(snapshotSigner))
+		}
+	}
+	staticHostRepoFn := func(d *db.Db) common.StaticRepoFactory {
+		return func() (*static.Repository, error) {
+			return static.NewRepository(d, d, c.kms)
+		}
+	}
+	authTokenRepoFn := func(d *db.Db) common.AuthTokenRepoFactory {
+		return func() (*authtoken.Repository, error) {
+			return authtoken.NewRepository(d, d, c.kms, authtoken.WithReadOnly(c.follower))
+		}
 	}
+	passwordAuthRepoFn := func(d *db.Db) common.PasswordAuthRepoFactory {
+		return func() (*password.Repository, error) {
+			return password.NewRepository(d, d, c.kms)
+		}
+	}
+	targetRepoFn := func(d *db.Db) common.TargetRepoFactory {
+		return func() (*target.Repository, error) {
+			return target.NewRepository(d, d, c.kms)
+		}
+	}
+	sessionRepoFn := func(d *db.Db) common.SessionRepoFactory {
+		return func() (*session.Repository, error) {
+			return session.NewRepository(d, d, c.kms, session.WithSessionAudit(sessionAudit))
+		}
+	}
+	c.IamRepoFn = iamRepoFn(dbase)
+	c.StaticHostRepoFn = staticHostRepoFn(dbase)
+	c.AuthTokenRepoFn = authTokenRepoFn(dbase)
 	c.ServersRepoFn = func() (*servers.Repository, error) {
 		return servers.NewRepository(dbase, dbase, c.kms)
 	}
-	c.PasswordAuthRepoFn = func() (*password.Repository, error) {
-		return password.NewRepository(dbase, dbase, c.kms)
-	}
-	c.TargetRepoFn = func() (*target.Repository, error) {
-		return target.NewRepository(dbase, dbase, c.kms)
+	c.PasswordAuthRepoFn = passwordAuthRepoFn(dbase)
+	c.TargetRepoFn = targetRepoFn(dbase)
+	c.SessionRepoFn = sessionRepoFn(dbase)
+
+	// The writes of the api services are attributed to the user and scope
+	// of their requests, so their repos refuse writes without them. The
+	// controller's own writes, like those of its tickers, jobs and worker
+	// service, and the writes made verifying requests, use dbase.
+	apiDbase := db.New(c.conf.Database, db.WithRetryTransientErrors(true), db.WithRequireRequestInfo(true))
+	c.apiIamRepoFn = iamRepoFn(apiDbase)
+	c.apiStaticHostRepoFn = staticHostRepoFn(apiDbase)
+	c.apiAuthTokenRepoFn = authTokenRepoFn(apiDbase)
+	c.apiPasswordAuthRepoFn = passwordAuthRepoFn(apiDbase)
+	c.apiTargetRepoFn = targetRepoFn(apiDbase)
+	c.apiSessionRepoFn = sessionRepoFn(apiDbase)
+	c.JobRepoFn = func() (*jobs.Repository, error) {
+		return jobs.NewRepository(dbase, dbase)
 	}
-	c.SessionRepoFn = func() (*session.Repository, error) {
-		return session.NewRepository(dbase, dbase, c.kms)
+	c.AdaptiveAuthRepoFn = func() (*adaptive.Repository, error) {
+		return adaptive.NewRepository(dbase, dbase)
 	}
 
 	c.workerAuthCache = cache.New(0, 0)
@@ -140,19 +315,101 @@ func (c *Controller) Start() error {
 		return nil
 	}
 	c.baseContext, c.baseCancel = context.WithCancel(context.Background())
+	var err error
+	if c.jobRunner, err = jobs.NewRunner(c.baseContext, c.JobRepoFn, c.conf.RawConfig.Controller.Name, c.logger.Named("jobs")); err != nil {
+		return fmt.Errorf("error creating job runner: %w", err)
+	}
+
+	// Hard org isolation is set before the listeners start, so no requests
+	// are served with the wrong setting. A follower's replica can't be
+	// written, so it's left to the other controllers.
+	if iso := c.conf.RawConfig.Controller.OrgIsolation; iso != nil && !c.follower {
+		if err := c.setOrgIsolation(c.baseContext, *iso); err != nil {
+			return fmt.Errorf("error setting org isolation: %w", err)
+		}
+	}
 
 	if err := c.startListeners(); err != nil {
 		return fmt.Errorf("error starting controller listeners: %w", err)
 	}
 
-	c.startStatusTicking(c.baseContext)
-	c.startRecoveryNonceCleanupTicking(c.baseContext)
-	c.startTerminateCompletedSessionsTicking(c.baseContext)
+	if c.follower {
+		// A follower's replica can't be written, so leave the cleanup and
+		// status updates to the other controllers.
+		c.startReplicaLagTicking(c.baseContext)
+	} else {
+		// Every role grant and principal role must have a valid integrity
+		// hmac once integrity enforcement is enabled, so the ones without one
+		// are signed while it isn't.
+		if !c.conf.RawConfig.Controller.IntegrityEnforcement {
+			if err := c.signUnsignedIamRows(c.baseContext); err != nil {
+				return fmt.Errorf("error signing role grants and principal roles: %w", err)
+			}
+		}
+		c.startStatusTicking(c.baseContext)
+		c.startRecoveryNonceCleanupTicking(c.baseContext)
+		c.startIdempotencyKeyCleanupTicking(c.baseContext)
+		c.startRoleArchivalTicking(c.baseContext)
+		c.startEmergencyRoleRevertTicking(c.baseContext)
+		c.startTerminateCompletedSessionsTicking(c.baseContext)
+		c.startStaleJobTicking(c.baseContext)
+	}
+	c.startKmsCacheEvictionTicking(c.baseContext)
+	if u := c.conf.RawConfig.Controller.WriteHookUrl; u != "" {
+		c.unregisterWriteHook = iam.RegisterWriteHook(&iam.HTTPWriteHook{Url: u})
+	}
+	if a := c.conf.RawConfig.Controller.AdaptiveAuth; a != nil && a.StepUpHookUrl != "" {
+		c.unregisterStepUpHook = adaptive.RegisterStepUpHook(&adaptive.HTTPStepUpHook{Url: a.StepUpHookUrl})
+	}
+	for _, cts := range c.conf.RawConfig.Controller.ChangeTicketSystems {
+		c.unregisterChangeTicketSystems = append(c.unregisterChangeTicketSystems, target.RegisterChangeTicketSystem(cts.Name, &target.HTTPChangeTicketSystem{
+			ValidateUrl: cts.ValidateUrl,
+			SessionUrl:  cts.SessionUrl,
+			Logger:      c.logger.Named("change-tickets"),
+		}))
+	}
 	c.started.Store(true)
 
 	return nil
 }
 
+// signUnsignedIamRows signs the role grants and principal roles which have no
+// integrity hmac.
+func (c *Controller) signUnsignedIamRows(ctx context.Context) error {
+	repo, err := c.IamRepoFn()
+	if err != nil {
+		return err
+	}
+	signed, err := repo.SignUnsignedRows(ctx)
+	if err != nil {
+		return err
+	}
+	if signed > 0 {
+		c.logger.Info("signed role grants and principal roles without an integrity hmac", "count", signed)
+	}
+	return nil
+}
+
+// setOrgIsolation turns hard org isolation on or off, if it isn't already.
+func (c *Controller) setOrgIsolation(ctx context.Context, enabled bool) error {
+	repo, err := c.IamRepoFn()
+	if err != nil {
+		return err
+	}
+	current, err := repo.OrgIsolation(ctx)
+	if err != nil {
+		return err
+	}
+	if current == enabled {
+		return nil
+	}
+	if err := repo.SetOrgIsolation(ctx, enabled); err != nil {
+		return err
+	}
+	c.logger.Info("set hard org isolation", "enabled", enabled)
+	return nil
+}
+
 func (c *Controller) Shutdown(serversOnly bool) error {
 	if !c.started.Load() {
 		c.logger.Info("already shut down, skipping")
@@ -162,6 +419,22 @@ func (c *Controller) Shutdown(serversOnly bool) error {
 	if err := c.stopListeners(serversOnly); err != nil {
 		return fmt.Errorf("error stopping controller listeners: %w", err)
 	}
+	// Canceling the base context stops the running jobs; wait for them to
+	// record that they failed.
+	c.jobRunner.Wait()
+	c.kms.ClearCache()
+	if c.unregisterWriteHook != nil {
+		c.unregisterWriteHook()
+		c.unregisterWriteHook = nil
+	}
+	if c.unregisterStepUpHook != nil {
+		c.unregisterStepUpHook()
+		c.unregisterStepUpHook = nil
+	}
+	for _, unregister := range c.unregisterChangeTicketSystems {
+		unregister()
+	}
+	c.unregisterChangeTicketSystems = nil
 	c.clusterAddress = ""
 	c.started.Store(false)
 	return nil
@@ -174,3 +447,44 @@ func (c *Controller) Shutdown(serversOnly bool) error {
 func (c *Controller) WorkerStatusUpdateTimes() *sync.Map {
 	return c.workerStatusUpdateTimes
 }
+
+// loadSnapshotKeys returns the snapshot keys of the config, with the signing
+// key first, reading the keys from the files or environment variables they
+// name.
+func loadSnapshotKeys(s *config.SnapshotSigning) ([]*iam.SnapshotKey, error) {
+	if s == nil {
+		return nil, nil
+	}
+	if s.Key == "" {
+		return nil, errors.New("missing key")
+	}
+	ids := make([]string, 0, len(s.VerifyKeys))
+	for id := range s.VerifyKeys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	load := func(id, raw string) (*iam.SnapshotKey, error) {
+		encoded, err := config.ParseAddress(raw)
+		if err != nil && !errors.Is(err, config.ErrNotAUrl) {
+			return nil, fmt.Errorf("key %s: %w", id, err)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("key %s is not base64 encoded: %w", id, err)
+		}
+		return &iam.SnapshotKey{Id: id, Key: key}, nil
+	}
+	signing, err := load(s.KeyId, s.Key)
+	if err != nil {
+		return nil, err
+	}
+	keys := []*iam.SnapshotKey{signing}
+	for _, id := range ids {
+		k, err := load(id, s.VerifyKeys[id])
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// ReplicaLagHeader is the response header in which a read-only follower
+// reports how many seconds its replica was behind the primary, so clients
+// know how stale what they read may be.
+const ReplicaLagHeader = "Boundary-Replica-Lag"
+
+// errReplicaLagUnknown is the replica status of a follower which hasn't yet
+// checked how far behind its replica is.
+var errReplicaLagUnknown = errors.New("replication lag not yet checked")
+
+// replicaStatus is how far behind its primary a follower's replica was the
+// last time it was checked, or why that couldn't be checked.
+type replicaStatus struct {
+	mu  sync.RWMutex
+	lag time.Duration
+	err error
+}
+
+func (s *replicaStatus) set(lag time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lag, s.err = lag, err
+}
+
+func (s *replicaStatus) get() (time.Duration, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lag, s.err
+}
+
+// isAuthenticate reports whether r authenticates to an auth method.
+func isAuthenticate(r *http.Request) bool {
+	return r.Method == http.MethodPost &&
+		strings.HasPrefix(r.URL.Path, "/v1/auth-methods/") &&
+		strings.HasSuffix(r.URL.Path, ":authenticate")
+}
+
+// wrapHandlerWithFollower refuses every request to a read-only follower
+// except reads, and refuses reads too when its replica is further behind than
+// its maximum staleness or can't be checked. Reads it serves are labeled with
+// the replica's lag in ReplicaLagHeader. Authentication requests are proxied
+// to the leader, if the follower has one, since the auth tokens they create
+// can't be written to the replica.
+func wrapHandlerWithFollower(h http.Handler, c *Controller) http.Handler {
+	if !c.follower {
+		return h
+	}
+	var leader *httputil.ReverseProxy
+	if c.leaderAddr != nil {
+		leader = httputil.NewSingleHostReverseProxy(c.leaderAddr)
+		leader.ErrorLog = c.logger.StandardLogger(nil)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet, r.Method == http.MethodHead, r.Method == http.MethodOptions:
+		case isAuthenticate(r) && leader != nil:
+			leader.ServeHTTP(w, r)
+			return
+		default:
+			writeFollowerError(w, http.StatusMethodNotAllowed, "read-only follower")
+			return
+		}
+		lag, err := c.replica.get()
+		if err == nil && c.maxStaleness > 0 && lag > c.maxStaleness {
+			err = fmt.Errorf("replica is %s behind, more than the maximum staleness of %s", lag, c.maxStaleness)
+		}
+		if err != nil {
+			c.logger.Warn("refusing read from stale replica", "error", err)
+			writeFollowerError(w, http.StatusServiceUnavailable, "replica unavailable")
+			return
+		}
+		w.Header().Set(ReplicaLagHeader, strconv.FormatFloat(lag.Seconds(), 'f', 3, 64))
+		h.ServeHTTP(w, r)
+	})
+}
+
+func writeFollowerError(w http.ResponseWriter, status int, code string) {
+	writeApiError(w, status, code, "")
+}
+
+// writeApiError writes an api error for requests refused before they reach
+// the api handlers.
+func writeApiError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.Encode(&api.Error{
+		Status:  int32(status),
+		Code:    code,
+		Message: message,
+	})
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapHandlerWithFollower(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	leader := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer leader.Close()
+	leaderAddr, err := url.Parse(leader.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		follower   bool
+		method     string
+		path       string
+		leader     bool
+		lag        time.Duration
+		lagErr     error
+		wantStatus int
+		wantLag    string
+	}{
+		{
+			name:       "not-follower",
+			method:     http.MethodPost,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "read",
+			follower:   true,
+			method:     http.MethodGet,
+			lag:        1500 * time.Millisecond,
+			wantStatus: http.StatusOK,
+			wantLag:    "1.500",
+		},
+		{
+			name:       "write",
+			follower:   true,
+			method:     http.MethodPatch,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "authenticate",
+			follower:   true,
+			method:     http.MethodPost,
+			path:       "/v1/auth-methods/ampw_1234567890:authenticate",
+			leader:     true,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "authenticate-no-leader",
+			follower:   true,
+			method:     http.MethodPost,
+			path:       "/v1/auth-methods/ampw_1234567890:authenticate",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "too-stale",
+			follower:   true,
+			method:     http.MethodGet,
+			lag:        2 * time.Minute,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "lag-unknown",
+			follower:   true,
+			method:     http.MethodGet,
+			lagErr:     errReplicaLagUnknown,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			c := &Controller{
+				logger:       hclog.NewNullLogger(),
+				follower:     tt.follower,
+				maxStaleness: time.Minute,
+				replica:      new(replicaStatus),
+			}
+			if tt.leader {
+				c.leaderAddr = leaderAddr
+			}
+			c.replica.set(tt.lag, tt.lagErr)
+			path := tt.path
+			if path == "" {
+				path = "/v1/roles"
+			}
+			w := httptest.NewRecorder()
+			wrapHandlerWithFollower(ok, c).ServeHTTP(w, httptest.NewRequest(tt.method, path, nil))
+			assert.Equal(tt.wantStatus, w.Code)
+			assert.Equal(tt.wantLag, w.Header().Get(ReplicaLagHeader))
+		})
+	}
+}
@@ -24,11 +24,14 @@ import (
 
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authtokens"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/client_certificate_libraries"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/groups"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/host_catalogs"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/hosts"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/jobs"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roles"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/scopes"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/self"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/users"
 	"google.golang.org/protobuf/encoding/protojson"
 )
@@ -48,7 +51,12 @@ func (c *Controller) handler(props HandlerProperties) (http.Handler, error) {
 	if err != nil {
 		return nil, err
 	}
-	mux.Handle("/v1/", h)
+	mux.Handle("/v1/", wrapHandlerWithTunables(wrapHandlerWithFollower(h, c), c))
+	// The tunables api isn't rate limited or refused in maintenance mode, so
+	// they can always be lifted.
+	th := wrapHandlerWithFollower(handleTunables(c), c)
+	mux.Handle(tunablesPath, th)
+	mux.Handle(tunablesPath+"/", th)
 	mux.Handle("/", handleUi(c))
 
 	corsWrappedHandler := wrapHandlerWithCors(mux, props)
@@ -78,57 +86,58 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		}),
 		runtime.WithErrorHandler(handlers.ErrorHandler(c.logger)),
 		runtime.WithForwardResponseOption(handlers.OutgoingInterceptor),
+		runtime.WithIncomingHeaderMatcher(handlers.IncomingHeaderMatcher),
 	)
-	hcs, err := host_catalogs.NewService(c.StaticHostRepoFn, c.IamRepoFn)
+	hcs, err := host_catalogs.NewService(c.apiStaticHostRepoFn, c.apiIamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create host catalog handler service: %w", err)
 	}
 	if err := services.RegisterHostCatalogServiceHandlerServer(ctx, mux, hcs); err != nil {
 		return nil, fmt.Errorf("failed to register host catalog service handler: %w", err)
 	}
-	hss, err := host_sets.NewService(c.StaticHostRepoFn)
+	hss, err := host_sets.NewService(c.apiStaticHostRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create host set handler service: %w", err)
 	}
 	if err := services.RegisterHostSetServiceHandlerServer(ctx, mux, hss); err != nil {
 		return nil, fmt.Errorf("failed to register host set service handler: %w", err)
 	}
-	hs, err := hosts.NewService(c.StaticHostRepoFn)
+	hs, err := hosts.NewService(c.apiStaticHostRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create host handler service: %w", err)
 	}
 	if err := services.RegisterHostServiceHandlerServer(ctx, mux, hs); err != nil {
 		return nil, fmt.Errorf("failed to register host service handler: %w", err)
 	}
-	accts, err := accounts.NewService(c.PasswordAuthRepoFn)
+	accts, err := accounts.NewService(c.apiPasswordAuthRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create account handler service: %w", err)
 	}
 	if err := services.RegisterAccountServiceHandlerServer(ctx, mux, accts); err != nil {
 		return nil, fmt.Errorf("failed to register account service handler: %w", err)
 	}
-	authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.IamRepoFn, c.AuthTokenRepoFn)
+	authMethods, err := authmethods.NewService(c.kms, c.apiPasswordAuthRepoFn, c.apiIamRepoFn, c.apiAuthTokenRepoFn, authmethods.WithAdaptiveAuth(c.AdaptiveAuthRepoFn, c.adaptiveAuthPolicy))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth method handler service: %w", err)
 	}
 	if err := services.RegisterAuthMethodServiceHandlerServer(ctx, mux, authMethods); err != nil {
 		return nil, fmt.Errorf("failed to register auth method service handler: %w", err)
 	}
-	authtoks, err := authtokens.NewService(c.AuthTokenRepoFn, c.IamRepoFn)
+	authtoks, err := authtokens.NewService(c.apiAuthTokenRepoFn, c.apiIamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth token handler service: %w", err)
 	}
 	if err := services.RegisterAuthTokenServiceHandlerServer(ctx, mux, authtoks); err != nil {
 		return nil, fmt.Errorf("failed to register auth token service handler: %w", err)
 	}
-	os, err := scopes.NewService(c.IamRepoFn)
+	os, err := scopes.NewService(c.apiIamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create scope handler service: %w", err)
 	}
 	if err := services.RegisterScopeServiceHandlerServer(ctx, mux, os); err != nil {
 		return nil, fmt.Errorf("failed to register scope service handler: %w", err)
 	}
-	us, err := users.NewService(c.IamRepoFn)
+	us, err := users.NewService(c.apiIamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user handler service: %w", err)
 	}
@@ -137,38 +146,59 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	}
 	ts, err := targets.NewService(
 		c.kms,
-		c.TargetRepoFn,
-		c.IamRepoFn,
+		c.apiTargetRepoFn,
+		c.apiIamRepoFn,
 		c.ServersRepoFn,
-		c.SessionRepoFn,
-		c.StaticHostRepoFn)
+		c.apiSessionRepoFn,
+		c.apiStaticHostRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create target handler service: %w", err)
 	}
 	if err := services.RegisterTargetServiceHandlerServer(ctx, mux, ts); err != nil {
 		return nil, fmt.Errorf("failed to register target service handler: %w", err)
 	}
-	gs, err := groups.NewService(c.IamRepoFn)
+	ccls, err := client_certificate_libraries.NewService(c.apiTargetRepoFn, c.apiIamRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client certificate library handler service: %w", err)
+	}
+	if err := services.RegisterClientCertificateLibraryServiceHandlerServer(ctx, mux, ccls); err != nil {
+		return nil, fmt.Errorf("failed to register client certificate library service handler: %w", err)
+	}
+	gs, err := groups.NewService(c.apiIamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create group handler service: %w", err)
 	}
 	if err := services.RegisterGroupServiceHandlerServer(ctx, mux, gs); err != nil {
 		return nil, fmt.Errorf("failed to register group service handler: %w", err)
 	}
-	rs, err := roles.NewService(c.IamRepoFn)
+	rs, err := roles.NewService(c.apiIamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create role handler service: %w", err)
 	}
 	if err := services.RegisterRoleServiceHandlerServer(ctx, mux, rs); err != nil {
 		return nil, fmt.Errorf("failed to register role service handler: %w", err)
 	}
-	ss, err := sessions.NewService(c.SessionRepoFn, c.IamRepoFn)
+	ss, err := sessions.NewService(c.apiSessionRepoFn, c.apiIamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create session handler service: %w", err)
 	}
 	if err := services.RegisterSessionServiceHandlerServer(ctx, mux, ss); err != nil {
 		return nil, fmt.Errorf("failed to register session service handler: %w", err)
 	}
+	js, err := jobs.NewService(c.jobRunner, c.JobRepoFn, c.IamRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job handler service: %w", err)
+	}
+	if err := services.RegisterJobServiceHandlerServer(ctx, mux, js); err != nil {
+		return nil, fmt.Errorf("failed to register job service handler: %w", err)
+	}
+	selfs, err := self.NewService(c.apiSessionRepoFn, c.apiAuthTokenRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create self handler service: %w", err)
+	}
+	if err := services.RegisterSelfServiceHandlerServer(ctx, mux, selfs); err != nil {
+		return nil, fmt.Errorf("failed to register self service handler: %w", err)
+	}
 
 	return mux, nil
 }
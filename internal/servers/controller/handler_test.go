@@ -100,6 +100,8 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/auth-methods/someid",
 			"v1/auth-tokens",
 			"v1/auth-tokens/someid",
+			"v1/client-certificate-libraries",
+			"v1/client-certificate-libraries/someid",
 			"v1/groups",
 			"v1/groups/someid",
 			"v1/host-catalogs",
@@ -123,6 +125,7 @@ func TestHandleImplementedPaths(t *testing.T) {
 			// Creation end points
 			"v1/accounts",
 			"v1/auth-methods",
+			"v1/client-certificate-libraries",
 			"v1/groups",
 			"v1/host-catalogs",
 			"v1/host-sets",
@@ -161,6 +164,7 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/accounts/someid",
 			"v1/auth-methods/someid",
 			"v1/auth-tokens/someid",
+			"v1/client-certificate-libraries/someid",
 			"v1/groups/someid",
 			"v1/host-catalogs/someid",
 			"v1/host-sets/someid",
@@ -173,6 +177,7 @@ func TestHandleImplementedPaths(t *testing.T) {
 		"PATCH": {
 			"v1/accounts/someid",
 			"v1/auth-methods/someid",
+			"v1/client-certificate-libraries/someid",
 			"v1/groups/someid",
 			"v1/host-catalogs/someid",
 			"v1/host-sets/someid",
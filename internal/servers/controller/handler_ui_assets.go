@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+)
+
+// defaultUiContentSecurityPolicy only lets the admin UI load its own assets
+// and call the api of the controller serving it, on the same origin.
+const defaultUiContentSecurityPolicy = "default-src 'none'; script-src 'self'; style-src 'self'; img-src 'self' data:; " +
+	"font-src 'self'; connect-src 'self'; manifest-src 'self'; base-uri 'self'; form-action 'self'; frame-ancestors 'none'"
+
+// fingerprintedUiAsset matches the assets whose names include a hash of their
+// contents, which the UI build adds, so browsers can cache them forever.
+var fingerprintedUiAsset = regexp.MustCompile(`^/assets/.+-[0-9a-f]{16,}\.[A-Za-z0-9]+$`)
+
+// uiHandler serves the admin UI from the directory set in the controller's ui
+// config, or the dev passthrough directory, or else from bundled, the assets
+// bundled into the binary, if there are any. Requests get a 404 response if
+// there's no UI to serve or it's disabled.
+func uiHandler(c *Controller, bundled http.FileSystem) http.Handler {
+	var conf config.Ui
+	if c.conf.RawConfig.Controller != nil && c.conf.RawConfig.Controller.Ui != nil {
+		conf = *c.conf.RawConfig.Controller.Ui
+	}
+	fs := bundled
+	switch {
+	case conf.Directory != "":
+		fs = http.Dir(conf.Directory)
+	case c.conf.RawConfig.PassthroughDirectory != "":
+		dir := c.conf.RawConfig.PassthroughDirectory
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		c.logger.Warn("serving passthrough files at /", "path", dir)
+		fs = http.Dir(dir)
+	}
+	if conf.Disable || fs == nil {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+	}
+	if conf.ContentSecurityPolicy == "" {
+		conf.ContentSecurityPolicy = defaultUiContentSecurityPolicy
+	}
+	return &uiAssets{fs: fs, csp: conf.ContentSecurityPolicy, etags: make(map[string]uiEtag)}
+}
+
+// uiAssets serves the assets of the admin UI with a Content-Security-Policy
+// header, and an ETag of the hash of their contents, so browsers revalidate
+// them cheaply. Fingerprinted assets are cached forever, and everything else,
+// like index.html, is revalidated on every use.
+type uiAssets struct {
+	fs  http.FileSystem
+	csp string
+
+	mu    sync.Mutex
+	etags map[string]uiEtag
+}
+
+// uiEtag is the ETag of an asset, which is computed again if the asset's
+// modification time or size changes.
+type uiEtag struct {
+	modTime time.Time
+	size    int64
+	etag    string
+}
+
+func (u *uiAssets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	name := uiAssetPath(r.URL.Path)
+	f, err := u.fs.Open(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil || fi.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+	etag, err := u.etag(name, f, fi)
+	if err != nil {
+		http.Error(w, "unable to read asset", http.StatusInternalServerError)
+		return
+	}
+	h := w.Header()
+	h.Set("Content-Security-Policy", u.csp)
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("X-Frame-Options", "DENY")
+	h.Set("Referrer-Policy", "same-origin")
+	h.Set("ETag", etag)
+	if fingerprintedUiAsset.MatchString(name) {
+		h.Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		h.Set("Cache-Control", "no-cache")
+	}
+	http.ServeContent(w, r, name, fi.ModTime(), f)
+}
+
+// etag returns the ETag of the asset, hashing it if it hasn't been yet. The
+// asset is left at its start.
+func (u *uiAssets) etag(name string, f http.File, fi os.FileInfo) (string, error) {
+	u.mu.Lock()
+	e, ok := u.etags[name]
+	u.mu.Unlock()
+	if ok && e.modTime.Equal(fi.ModTime()) && e.size == fi.Size() {
+		return e.etag, nil
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	etag := fmt.Sprintf(`"%s"`, hex.EncodeToString(hash.Sum(nil)[:16]))
+	u.mu.Lock()
+	u.etags[name] = uiEtag{modTime: fi.ModTime(), size: fi.Size(), etag: etag}
+	u.mu.Unlock()
+	return etag, nil
+}
+
+// uiAssetPath returns the asset to serve for a request path. The UI routes
+// its pages in the browser, so paths which don't name a file, with an
+// extension of only letters and digits, are served index.html.
+func uiAssetPath(p string) string {
+	const index = "/index.html"
+	p = path.Clean("/" + p)
+	dot := strings.LastIndex(p, ".")
+	if dot == -1 || dot < strings.LastIndex(p, "/") || dot == len(p)-1 {
+		return index
+	}
+	for _, c := range p[dot+1:] {
+		if (c < '0' || c > '9') && (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') {
+			return index
+		}
+	}
+	return p
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUiAssetPath(t *testing.T) {
+	for path, want := range map[string]string{
+		"":                         "/index.html",
+		"/":                        "/index.html",
+		"/orgs/o_1234567890":       "/index.html",
+		"/index.html":              "/index.html",
+		"/favicon.png":             "/favicon.png",
+		"/assets/styles.css":       "/assets/styles.css",
+		"/v1.2/orgs":               "/index.html",
+		"/foo.bāb":                 "/index.html",
+		"/foo.":                    "/index.html",
+		"/../../etc/passwd.txt":    "/etc/passwd.txt",
+		"/assets/../index.htm":     "/index.htm",
+		"/orgs/o_1234567890/users": "/index.html",
+	} {
+		assert.Equal(t, want, uiAssetPath(path), path)
+	}
+}
+
+func TestUiHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boundary-ui-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for name, contents := range map[string]string{
+		"index.html": "index",
+		"assets/vendor-0123456789abcdef0123456789abcdef.js": "vendor",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644))
+	}
+	newController := func(ui *config.Ui) *Controller {
+		return &Controller{
+			conf: &Config{
+				RawConfig: &config.Config{Controller: &config.Controller{Ui: ui}},
+			},
+			logger: hclog.NewNullLogger(),
+		}
+	}
+
+	t.Run("index", func(t *testing.T) {
+		assert := assert.New(t)
+		h := uiHandler(newController(&config.Ui{Directory: dir}), nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orgs", nil))
+		assert.Equal(http.StatusOK, w.Code)
+		assert.Equal("index", w.Body.String())
+		assert.Equal(defaultUiContentSecurityPolicy, w.Header().Get("Content-Security-Policy"))
+		assert.Equal("nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Equal("no-cache", w.Header().Get("Cache-Control"))
+		etag := w.Header().Get("ETag")
+		assert.NotEmpty(etag)
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("If-None-Match", etag)
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		assert.Equal(http.StatusNotModified, w.Code)
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+		assert.Equal(http.StatusMethodNotAllowed, w.Code)
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing.js", nil))
+		assert.Equal(http.StatusNotFound, w.Code)
+	})
+	t.Run("fingerprinted", func(t *testing.T) {
+		assert := assert.New(t)
+		h := uiHandler(newController(&config.Ui{Directory: dir, ContentSecurityPolicy: "default-src 'self'"}), nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/assets/vendor-0123456789abcdef0123456789abcdef.js", nil))
+		assert.Equal(http.StatusOK, w.Code)
+		assert.Equal("vendor", w.Body.String())
+		assert.Equal("public, max-age=31536000, immutable", w.Header().Get("Cache-Control"))
+		assert.Equal("default-src 'self'", w.Header().Get("Content-Security-Policy"))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		assert := assert.New(t)
+		h := uiHandler(newController(&config.Ui{Directory: dir, Disable: true}), nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(http.StatusNotFound, w.Code)
+	})
+	t.Run("bundled", func(t *testing.T) {
+		assert := assert.New(t)
+		h := uiHandler(newController(nil), http.Dir(dir))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(http.StatusOK, w.Code)
+
+		h = uiHandler(newController(nil), nil)
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(http.StatusNotFound, w.Code)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/accounts"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -90,6 +91,7 @@ func (s Service) CreateAccount(ctx context.Context, req *pbs.CreateAccountReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.createInRepo(ctx, authMeth.GetPublicId(), authResults.Scope.GetId(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -107,6 +109,7 @@ func (s Service) UpdateAccount(ctx context.Context, req *pbs.UpdateAccountReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), authMeth.GetPublicId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -124,6 +127,7 @@ func (s Service) DeleteAccount(ctx context.Context, req *pbs.DeleteAccountReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	_, err := s.deleteFromRepo(ctx, authResults.Scope.GetId(), req.GetId())
 	if err != nil {
 		return nil, err
@@ -140,6 +144,7 @@ func (s Service) ChangePassword(ctx context.Context, req *pbs.ChangePasswordRequ
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.changePasswordInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetVersion(), req.GetCurrentPassword(), req.GetNewPassword())
 	if err != nil {
 		return nil, err
@@ -157,6 +162,7 @@ func (s Service) SetPassword(ctx context.Context, req *pbs.SetPasswordRequest) (
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.setPasswordInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetVersion(), req.GetPassword())
 	if err != nil {
 		return nil, err
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/adaptive"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/auth/password/store"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -23,12 +24,14 @@ import (
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 const (
 	loginNameKey = "login_name"
 	pwKey        = "password"
+	stepUpKey    = "step_up_code"
 )
 
 var (
@@ -48,10 +51,14 @@ type Service struct {
 	pwRepoFn  common.PasswordAuthRepoFactory
 	iamRepoFn common.IamRepoFactory
 	atRepoFn  common.AuthTokenRepoFactory
+
+	adaptiveRepoFn common.AdaptiveAuthRepoFactory
+	adaptivePolicy *adaptive.Policy
 }
 
 // NewService returns a auth method service which handles auth method related requests to boundary.
-func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory) (Service, error) {
+// Supports the WithAdaptiveAuth option.
+func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory, opt ...Option) (Service, error) {
 	if kms == nil {
 		return Service{}, errors.New("nil kms provided")
 	}
@@ -61,7 +68,15 @@ func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, iamRepoFn
 	if iamRepoFn == nil {
 		return Service{}, fmt.Errorf("nil iam repository provided")
 	}
-	return Service{kms: kms, pwRepoFn: pwRepoFn, iamRepoFn: iamRepoFn, atRepoFn: atRepoFn}, nil
+	opts := getOpts(opt...)
+	return Service{
+		kms:            kms,
+		pwRepoFn:       pwRepoFn,
+		iamRepoFn:      iamRepoFn,
+		atRepoFn:       atRepoFn,
+		adaptiveRepoFn: opts.withAdaptiveAuthRepoFn,
+		adaptivePolicy: opts.withAdaptiveAuthPolicy,
+	}, nil
 }
 
 var _ pbs.AuthMethodServiceServer = Service{}
@@ -111,6 +126,7 @@ func (s Service) CreateAuthMethod(ctx context.Context, req *pbs.CreateAuthMethod
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -128,6 +144,7 @@ func (s Service) UpdateAuthMethod(ctx context.Context, req *pbs.UpdateAuthMethod
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -145,6 +162,7 @@ func (s Service) DeleteAuthMethod(ctx context.Context, req *pbs.DeleteAuthMethod
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	_, err := s.deleteFromRepo(ctx, authResults.Scope.GetId(), req.GetId())
 	if err != nil {
 		return nil, err
@@ -161,8 +179,9 @@ func (s Service) Authenticate(ctx context.Context, req *pbs.AuthenticateRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	creds := req.GetCredentials().GetFields()
-	tok, err := s.authenticateWithRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), creds[loginNameKey].GetStringValue(), creds[pwKey].GetStringValue())
+	tok, err := s.authenticateWithRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), creds[loginNameKey].GetStringValue(), creds[pwKey].GetStringValue(), creds[stepUpKey].GetStringValue())
 	if err != nil {
 		return nil, err
 	}
@@ -293,7 +312,7 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	return rows > 0, nil
 }
 
-func (s Service) authenticateWithRepo(ctx context.Context, scopeId, authMethodId, loginName, pw string) (*pba.AuthToken, error) {
+func (s Service) authenticateWithRepo(ctx context.Context, scopeId, authMethodId, loginName, pw, stepUpCode string) (*pba.AuthToken, error) {
 	iamRepo, err := s.iamRepoFn()
 	if err != nil {
 		return nil, err
@@ -317,9 +336,19 @@ func (s Service) authenticateWithRepo(ctx context.Context, scopeId, authMethodId
 
 	u, err := iamRepo.LookupUserWithLogin(ctx, acct.GetPublicId(), iam.WithAutoVivify(true))
 	if err != nil {
+		if errors.Is(err, iam.ErrUserNotActive) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+		}
 		return nil, err
 	}
-	tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId())
+	if err := s.checkLogin(ctx, authMethodId, acct.GetPublicId(), u.GetPublicId(), stepUpCode); err != nil {
+		return nil, err
+	}
+	var tokOpts []authtoken.Option
+	if addr := handlers.ClientAddress(ctx); addr != "" {
+		tokOpts = append(tokOpts, authtoken.WithSourceAddress(addr))
+	}
+	tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId(), tokOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -348,6 +377,43 @@ func (s Service) authenticateWithRepo(ctx context.Context, scopeId, authMethodId
 	return prot, nil
 }
 
+// checkLogin checks the login of the account against its earlier logins, if
+// adaptive authentication is configured, and refuses it if it requires
+// step-up authentication which the client didn't give.
+func (s Service) checkLogin(ctx context.Context, authMethodId, accountId, userId, stepUpCode string) error {
+	sensitivity := s.adaptivePolicy.Sensitivity(authMethodId)
+	if s.adaptiveRepoFn == nil || sensitivity == adaptive.Off {
+		return nil
+	}
+	repo, err := s.adaptiveRepoFn()
+	if err != nil {
+		return err
+	}
+	var userAgent, acceptLanguage string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("grpcgateway-user-agent"); len(v) > 0 {
+			userAgent = v[0]
+		}
+		if v := md.Get("grpcgateway-accept-language"); len(v) > 0 {
+			acceptLanguage = v[0]
+		}
+	}
+	login := &adaptive.Login{
+		AuthMethodId: authMethodId,
+		AccountId:    accountId,
+		UserId:       userId,
+		Device:       adaptive.DeviceFingerprint(userAgent, acceptLanguage),
+		Address:      handlers.ClientAddress(ctx),
+	}
+	if err := repo.CheckLogin(ctx, login, sensitivity, stepUpCode); err != nil {
+		if errors.Is(err, adaptive.ErrStepUpRequired) {
+			return handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Step-up authentication is required for this login; retry with a %q credential.", stepUpKey)
+		}
+		return err
+	}
+	return nil
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
 	res := auth.VerifyResults{}
 
@@ -10,6 +10,7 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/adaptive"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/db"
@@ -23,6 +24,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -1108,3 +1110,129 @@ func TestAuthenticate_AuthAccountConnectedToIamUser(t *testing.T) {
 	assert.NotEmpty(aToken.GetToken())
 	assert.True(strings.HasPrefix(aToken.GetToken(), aToken.GetId()))
 }
+
+func TestAuthenticate_ClaimRules(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, p := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	acct, err := password.NewAccount(am.GetPublicId(), password.WithLoginName(testLoginName))
+	require.NoError(err)
+	pwRepo, err := pwRepoFn()
+	require.NoError(err)
+	_, err = pwRepo.CreateAccount(context.Background(), o.GetPublicId(), acct, password.WithPassword(testPassword))
+	require.NoError(err)
+
+	iamRepo, err := iamRepoFn()
+	require.NoError(err)
+	role := iam.TestRole(t, conn, p.GetPublicId())
+	rule, err := iam.NewClaimRule(o.GetPublicId(), "auth_method_id", am.GetPublicId(), role.GetPublicId())
+	require.NoError(err)
+	_, err = iamRepo.CreateClaimRule(context.Background(), rule)
+	require.NoError(err)
+
+	s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+	require.NoError(err)
+	resp, err := s.Authenticate(auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId())), &pbs.AuthenticateRequest{
+		AuthMethodId: am.GetPublicId(),
+		Credentials: func() *structpb.Struct {
+			creds := map[string]*structpb.Value{
+				"login_name": {Kind: &structpb.Value_StringValue{StringValue: testLoginName}},
+				"password":   {Kind: &structpb.Value_StringValue{StringValue: testPassword}},
+			}
+			return &structpb.Struct{Fields: creds}
+		}(),
+	})
+	require.NoError(err)
+	assert.NotEmpty(resp.GetItem().GetToken())
+
+	// Password logins present no identity provider claims, so the rule,
+	// which would match the account's auth method, isn't applied
+	principals, err := iamRepo.ListPrincipalRoles(context.Background(), role.GetPublicId())
+	require.NoError(err)
+	assert.Empty(principals)
+}
+
+func TestAuthenticate_AdaptiveAuth(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	adaptiveRepoFn := func() (*adaptive.Repository, error) {
+		return adaptive.NewRepository(rw, rw)
+	}
+
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	acct, err := password.NewAccount(am.GetPublicId(), password.WithLoginName(testLoginName))
+	require.NoError(err)
+	pwRepo, err := pwRepoFn()
+	require.NoError(err)
+	_, err = pwRepo.CreateAccount(context.Background(), o.GetPublicId(), acct, password.WithPassword(testPassword))
+	require.NoError(err)
+
+	policy, err := adaptive.NewPolicy("off", map[string]string{am.GetPublicId(): "high"})
+	require.NoError(err)
+	s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn, authmethods.WithAdaptiveAuth(adaptiveRepoFn, policy))
+	require.NoError(err)
+	authenticate := func(addr, stepUpCode string) error {
+		ctx := auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId()))
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", addr, "grpcgateway-user-agent", "boundary-cli"))
+		creds := map[string]*structpb.Value{
+			"login_name": {Kind: &structpb.Value_StringValue{StringValue: testLoginName}},
+			"password":   {Kind: &structpb.Value_StringValue{StringValue: testPassword}},
+		}
+		if stepUpCode != "" {
+			creds["step_up_code"] = &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: stepUpCode}}
+		}
+		_, err := s.Authenticate(ctx, &pbs.AuthenticateRequest{
+			AuthMethodId: am.GetPublicId(),
+			Credentials:  &structpb.Struct{Fields: creds},
+		})
+		return err
+	}
+
+	require.NoError(authenticate("192.0.2.1", ""))
+	require.NoError(authenticate("192.0.2.1", ""))
+
+	// A login from a new address has to step up.
+	err = authenticate("198.51.100.1", "")
+	require.Error(err)
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.Unauthenticated)), err.Error())
+
+	unregister := adaptive.RegisterStepUpHook(adaptive.StepUpHookFunc(func(_ context.Context, req *adaptive.StepUpRequest) error {
+		if req.Code != "123456" {
+			return errors.New("wrong code")
+		}
+		return nil
+	}))
+	defer unregister()
+	assert.Error(authenticate("198.51.100.1", "000000"))
+	require.NoError(authenticate("198.51.100.1", "123456"))
+	require.NoError(authenticate("198.51.100.1", ""))
+}
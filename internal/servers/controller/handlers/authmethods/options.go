@@ -0,0 +1,33 @@
+package authmethods
+
+import (
+	"github.com/hashicorp/boundary/internal/auth/adaptive"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+)
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withAdaptiveAuthRepoFn common.AdaptiveAuthRepoFactory
+	withAdaptiveAuthPolicy *adaptive.Policy
+}
+
+func getOpts(opt ...Option) options {
+	var opts options
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// WithAdaptiveAuth provides an option to check logins against the earlier
+// logins of their accounts, at the sensitivity the policy sets for their auth
+// method, and require step-up authentication for unusual ones.
+func WithAdaptiveAuth(repoFn common.AdaptiveAuthRepoFactory, policy *adaptive.Policy) Option {
+	return func(o *options) {
+		o.withAdaptiveAuthRepoFn = repoFn
+		o.withAdaptiveAuthPolicy = policy
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/authtokens"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -81,6 +82,7 @@ func (s Service) DeleteAuthToken(ctx context.Context, req *pbs.DeleteAuthTokenRe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	_, err := s.deleteFromRepo(ctx, req.GetId())
 	if err != nil {
 		return nil, err
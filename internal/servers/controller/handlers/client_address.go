@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ClientAddress returns the address of the client which made the request, or
+// an empty string if it isn't known. The grpc gateway appends the address the
+// request came from to the x-forwarded-for metadata, so the last entry is
+// used; the entries before it are set by the client and can't be trusted.
+func ClientAddress(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	v := md.Get("x-forwarded-for")
+	if len(v) == 0 {
+		return ""
+	}
+	addrs := strings.Split(v[len(v)-1], ",")
+	return strings.TrimSpace(addrs[len(addrs)-1])
+}
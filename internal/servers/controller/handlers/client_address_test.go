@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestClientAddress(t *testing.T) {
+	assert := assert.New(t)
+	assert.Empty(ClientAddress(context.Background()))
+	assert.Empty(ClientAddress(metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-unknown", "1"))))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "203.0.113.7"))
+	assert.Equal("203.0.113.7", ClientAddress(ctx))
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "198.51.100.1, 203.0.113.7"))
+	assert.Equal("203.0.113.7", ClientAddress(ctx))
+}
@@ -0,0 +1,480 @@
+package client_certificate_libraries
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/clientcertificatelibraries"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	maskManager handlers.MaskManager
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(&store.ClientCertificateLibrary{}, &pb.ClientCertificateLibrary{}); err != nil {
+		panic(err)
+	}
+}
+
+type Service struct {
+	repoFn    common.TargetRepoFactory
+	iamRepoFn common.IamRepoFactory
+}
+
+var _ pbs.ClientCertificateLibraryServiceServer = Service{}
+
+// NewService returns a client certificate library Service which handles client certificate library related requests to
+// boundary and uses the provided repositories for storage and retrieval.
+func NewService(repoFn common.TargetRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+	if repoFn == nil {
+		return Service{}, fmt.Errorf("nil target repository provided")
+	}
+	if iamRepoFn == nil {
+		return Service{}, fmt.Errorf("nil iam repository provided")
+	}
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+}
+
+// ListClientCertificateLibraries implements the interface pbs.ClientCertificateLibraryServiceServer.
+func (s Service) ListClientCertificateLibraries(ctx context.Context, req *pbs.ListClientCertificateLibrariesRequest) (*pbs.ListClientCertificateLibrariesResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ul, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range ul {
+		item.Scope = authResults.Scope
+	}
+	return &pbs.ListClientCertificateLibrariesResponse{Items: ul}, nil
+}
+
+// GetClientCertificateLibrary implements the interface pbs.ClientCertificateLibraryServiceServer.
+func (s Service) GetClientCertificateLibrary(ctx context.Context, req *pbs.GetClientCertificateLibraryRequest) (*pbs.GetClientCertificateLibraryResponse, error) {
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	l, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	l.Scope = authResults.Scope
+	return &pbs.GetClientCertificateLibraryResponse{Item: l}, nil
+}
+
+// CreateClientCertificateLibrary implements the interface pbs.ClientCertificateLibraryServiceServer.
+func (s Service) CreateClientCertificateLibrary(ctx context.Context, req *pbs.CreateClientCertificateLibraryRequest) (*pbs.CreateClientCertificateLibraryResponse, error) {
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
+	l, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+	l.Scope = authResults.Scope
+	return &pbs.CreateClientCertificateLibraryResponse{
+		Item: l,
+		Uri:  fmt.Sprintf("client-certificate-libraries/%s", l.GetId()),
+	}, nil
+}
+
+// UpdateClientCertificateLibrary implements the interface pbs.ClientCertificateLibraryServiceServer.
+func (s Service) UpdateClientCertificateLibrary(ctx context.Context, req *pbs.UpdateClientCertificateLibraryRequest) (*pbs.UpdateClientCertificateLibraryResponse, error) {
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
+	l, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+	l.Scope = authResults.Scope
+	return &pbs.UpdateClientCertificateLibraryResponse{Item: l}, nil
+}
+
+// DeleteClientCertificateLibrary implements the interface pbs.ClientCertificateLibraryServiceServer.
+func (s Service) DeleteClientCertificateLibrary(ctx context.Context, req *pbs.DeleteClientCertificateLibraryRequest) (*pbs.DeleteClientCertificateLibraryResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.DeleteClientCertificateLibraryResponse{}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*pb.ClientCertificateLibrary, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	l, err := repo.LookupClientCertificateLibrary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if l == nil {
+		return nil, handlers.NotFoundErrorf("Client Certificate Library %q doesn't exist.", id)
+	}
+	return toProto(l), nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.ClientCertificateLibrary, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	ul, err := repo.ListClientCertificateLibraries(ctx, scopeId)
+	if err != nil {
+		return nil, err
+	}
+	var outUl []*pb.ClientCertificateLibrary
+	for _, u := range ul {
+		outUl = append(outUl, toProto(u))
+	}
+	return outUl, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, projId string, item *pb.ClientCertificateLibrary) (*pb.ClientCertificateLibrary, error) {
+	var opts []target.Option
+	if item.GetName() != nil {
+		opts = append(opts, target.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, target.WithDescription(item.GetDescription().GetValue()))
+	}
+	if item.GetCommonName() != nil {
+		opts = append(opts, target.WithCommonName(item.GetCommonName().GetValue()))
+	}
+	if item.GetServerName() != nil {
+		opts = append(opts, target.WithServerName(item.GetServerName().GetValue()))
+	}
+	if item.GetServerCaCert() != nil {
+		opts = append(opts, target.WithServerCaCert([]byte(item.GetServerCaCert().GetValue())))
+	}
+	if item.GetVaultAddress() != nil {
+		opts = append(opts, target.WithVaultAddress(item.GetVaultAddress().GetValue()))
+	}
+	if item.GetVaultPkiPath() != nil {
+		opts = append(opts, target.WithVaultPkiPath(item.GetVaultPkiPath().GetValue()))
+	}
+	if item.GetVaultRole() != nil {
+		opts = append(opts, target.WithVaultRole(item.GetVaultRole().GetValue()))
+	}
+	if item.GetVaultToken() != nil {
+		opts = append(opts, target.WithVaultToken(item.GetVaultToken().GetValue()))
+	}
+	l, err := target.NewClientCertificateLibrary(projId, target.ClientCertificateIssuer(item.GetType()), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build client certificate library for creation: %v.", err)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateClientCertificateLibrary(ctx, l)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client certificate library: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create client certificate library but no error returned from repository.")
+	}
+	return toProto(out), nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []string, item *pb.ClientCertificateLibrary) (*pb.ClientCertificateLibrary, error) {
+	// The library's issuer can't be changed, so only its settings are set
+	// here, and the repository checks them against its issuer.
+	l := &target.ClientCertificateLibrary{
+		ClientCertificateLibrary: &store.ClientCertificateLibrary{
+			PublicId:     id,
+			ScopeId:      projId,
+			Name:         item.GetName().GetValue(),
+			Description:  item.GetDescription().GetValue(),
+			CommonName:   item.GetCommonName().GetValue(),
+			ServerName:   item.GetServerName().GetValue(),
+			VaultAddress: item.GetVaultAddress().GetValue(),
+			VaultPkiPath: item.GetVaultPkiPath().GetValue(),
+			VaultRole:    item.GetVaultRole().GetValue(),
+		},
+	}
+	if item.GetServerCaCert() != nil {
+		l.ServerCaCert = []byte(item.GetServerCaCert().GetValue())
+	}
+	if item.GetVaultToken() != nil {
+		l.Secret = []byte(item.GetVaultToken().GetValue())
+	}
+	version := item.GetVersion()
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	existing, err := repo.LookupClientCertificateLibrary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, handlers.NotFoundErrorf("Client Certificate Library %q doesn't exist.", id)
+	}
+	if badFields := validateIssuerFields(target.ClientCertificateIssuer(existing.Issuer), mask, item); len(badFields) > 0 {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	out, rowsUpdated, err := repo.UpdateClientCertificateLibrary(ctx, l, version, dbMask)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update client certificate library: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Client Certificate Library %q doesn't exist or incorrect version provided.", id)
+	}
+	return toProto(out), nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteClientCertificateLibrary(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("unable to delete client certificate library: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.ClientCertificateLibrary), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		repo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		l, err := repo.LookupClientCertificateLibrary(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if l == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = l.GetScopeId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+// toProto converts the library to its API resource. The library's secret is
+// never returned.
+func toProto(in *target.ClientCertificateLibrary) *pb.ClientCertificateLibrary {
+	out := pb.ClientCertificateLibrary{
+		Id:          in.GetPublicId(),
+		ScopeId:     in.GetScopeId(),
+		CreatedTime: in.GetCreateTime().GetTimestamp(),
+		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
+		Version:     in.GetVersion(),
+		Type:        in.GetIssuer(),
+		CaCert:      string(in.GetCaCert()),
+	}
+	if in.GetDescription() != "" {
+		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
+	}
+	if in.GetName() != "" {
+		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
+	}
+	if in.GetCommonName() != "" {
+		out.CommonName = &wrapperspb.StringValue{Value: in.GetCommonName()}
+	}
+	if in.GetServerName() != "" {
+		out.ServerName = &wrapperspb.StringValue{Value: in.GetServerName()}
+	}
+	if len(in.GetServerCaCert()) > 0 {
+		out.ServerCaCert = &wrapperspb.StringValue{Value: string(in.GetServerCaCert())}
+	}
+	if in.GetVaultAddress() != "" {
+		out.VaultAddress = &wrapperspb.StringValue{Value: in.GetVaultAddress()}
+	}
+	if in.GetVaultPkiPath() != "" {
+		out.VaultPkiPath = &wrapperspb.StringValue{Value: in.GetVaultPkiPath()}
+	}
+	if in.GetVaultRole() != "" {
+		out.VaultRole = &wrapperspb.StringValue{Value: in.GetVaultRole()}
+	}
+	return &out
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+//  * The type asserted by the ID and/or field is known
+func validateGetRequest(req *pbs.GetClientCertificateLibraryRequest) error {
+	return handlers.ValidateGetRequest(target.ClientCertificateLibraryPrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateCreateRequest(req *pbs.CreateClientCertificateLibraryRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if !handlers.ValidId(scope.Project.Prefix(), item.GetScopeId()) {
+			badFields["scope_id"] = "This field must be a valid project scope id."
+		}
+		if item.GetCaCert() != "" {
+			badFields["ca_cert"] = "This is a read only field."
+		}
+		issuer := target.ClientCertificateIssuer(item.GetType())
+		switch issuer {
+		case target.ClientCertificateIssuerInternal, target.ClientCertificateIssuerVault:
+			for f, bad := range validateIssuerFields(issuer, nil, item) {
+				badFields[f] = bad
+			}
+		default:
+			badFields["type"] = fmt.Sprintf("This is a required field and must be %q or %q.", target.ClientCertificateIssuerInternal, target.ClientCertificateIssuerVault)
+		}
+		if issuer == target.ClientCertificateIssuerVault {
+			for f, v := range map[string]*wrapperspb.StringValue{
+				"vault_address":  item.GetVaultAddress(),
+				"vault_pki_path": item.GetVaultPkiPath(),
+				"vault_role":     item.GetVaultRole(),
+				"vault_token":    item.GetVaultToken(),
+			} {
+				if v == nil {
+					badFields[f] = "This field is required for a vault library."
+				}
+			}
+		}
+		validateServerCaCert(item, badFields)
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateClientCertificateLibraryRequest) error {
+	return handlers.ValidateUpdateRequest(target.ClientCertificateLibraryPrefix, req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if req.GetItem().GetType() != "" {
+			badFields["type"] = "Cannot modify the resource type."
+		}
+		if req.GetItem().GetCaCert() != "" {
+			badFields["ca_cert"] = "This is a read only field."
+		}
+		validateServerCaCert(req.GetItem(), badFields)
+		return badFields
+	})
+}
+
+// validateIssuerFields returns the problems with the vault settings of a
+// request for a library with the issuer. For an update, mask is the update
+// mask of the request, and fields it clears are checked too.
+func validateIssuerFields(issuer target.ClientCertificateIssuer, mask []string, item *pb.ClientCertificateLibrary) map[string]string {
+	badFields := map[string]string{}
+	vaultFields := map[string]*wrapperspb.StringValue{
+		"vault_address":  item.GetVaultAddress(),
+		"vault_pki_path": item.GetVaultPkiPath(),
+		"vault_role":     item.GetVaultRole(),
+		"vault_token":    item.GetVaultToken(),
+	}
+	for f, v := range vaultFields {
+		switch {
+		case issuer != target.ClientCertificateIssuerVault && (v != nil || handlers.MaskContains(mask, f)):
+			badFields[f] = "This field can only be set for a vault library."
+		case issuer == target.ClientCertificateIssuerVault && v.GetValue() == "" && (v != nil || handlers.MaskContains(mask, f)):
+			badFields[f] = "This field cannot be set to empty."
+		}
+	}
+	if addr := item.GetVaultAddress().GetValue(); issuer == target.ClientCertificateIssuerVault && addr != "" {
+		if u, err := url.Parse(addr); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			badFields["vault_address"] = "This must be the http or https URL of a Vault server."
+		}
+	}
+	return badFields
+}
+
+// validateServerCaCert adds the problem with the server CA certificates of a
+// request, if any, to badFields.
+func validateServerCaCert(item *pb.ClientCertificateLibrary, badFields map[string]string) {
+	if item.GetServerCaCert().GetValue() == "" {
+		return
+	}
+	if err := target.ValidServerCaCert([]byte(item.GetServerCaCert().GetValue())); err != nil {
+		badFields["server_ca_cert"] = "This must be PEM encoded certificates."
+	}
+}
+
+func validateDeleteRequest(req *pbs.DeleteClientCertificateLibraryRequest) error {
+	return handlers.ValidateDeleteRequest(target.ClientCertificateLibraryPrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateListRequest(req *pbs.ListClientCertificateLibrariesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
+		badFields["scope_id"] = "This field must be a valid project scope id."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package client_certificate_libraries_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/db"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/clientcertificatelibraries"
+	scopepb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/client_certificate_libraries"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createDefaultLibraryAndRepo(t *testing.T) (*target.ClientCertificateLibrary, *iam.Scope, common.TargetRepoFactory, common.IamRepoFactory) {
+	t.Helper()
+	require := require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	_, pRes := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*target.Repository, error) {
+		return target.NewRepository(rw, rw, kms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+
+	l, err := target.NewClientCertificateLibrary(pRes.GetPublicId(), target.ClientCertificateIssuerInternal, target.WithName("default"), target.WithDescription("default"))
+	require.NoError(err)
+	repo, err := repoFn()
+	require.NoError(err)
+	lRes, err := repo.CreateClientCertificateLibrary(context.Background(), l)
+	require.NoError(err)
+
+	return lRes, pRes, repoFn, iamRepoFn
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+	l, proj, repoFn, iamRepoFn := createDefaultLibraryAndRepo(t)
+
+	cases := []struct {
+		name string
+		req  *pbs.GetClientCertificateLibraryRequest
+		res  *pbs.GetClientCertificateLibraryResponse
+		err  error
+	}{
+		{
+			name: "Get an existing library",
+			req:  &pbs.GetClientCertificateLibraryRequest{Id: l.GetPublicId()},
+			res: &pbs.GetClientCertificateLibraryResponse{Item: &pb.ClientCertificateLibrary{
+				Id:          l.GetPublicId(),
+				ScopeId:     l.GetScopeId(),
+				Scope:       &scopepb.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+				Name:        &wrappers.StringValue{Value: l.GetName()},
+				Description: &wrappers.StringValue{Value: l.GetDescription()},
+				CreatedTime: l.CreateTime.GetTimestamp(),
+				UpdatedTime: l.UpdateTime.GetTimestamp(),
+				Version:     1,
+				Type:        "internal",
+				CaCert:      string(l.GetCaCert()),
+			}},
+		},
+		{
+			name: "Get a non existing library",
+			req:  &pbs.GetClientCertificateLibraryRequest{Id: target.ClientCertificateLibraryPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetClientCertificateLibraryRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := client_certificate_libraries.NewService(repoFn, iamRepoFn)
+			require.NoError(err)
+
+			got, gErr := s.GetClientCertificateLibrary(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetClientCertificateLibrary(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			}
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "GetClientCertificateLibrary(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	l, proj, repoFn, iamRepoFn := createDefaultLibraryAndRepo(t)
+	s, err := client_certificate_libraries.NewService(repoFn, iamRepoFn)
+	require.NoError(err)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	got, err := s.ListClientCertificateLibraries(ctx, &pbs.ListClientCertificateLibrariesRequest{ScopeId: proj.GetPublicId()})
+	require.NoError(err)
+	require.Len(got.GetItems(), 1)
+	assert.Equal(l.GetPublicId(), got.GetItems()[0].GetId())
+	assert.Equal(proj.GetPublicId(), got.GetItems()[0].GetScope().GetId())
+
+	_, err = s.ListClientCertificateLibraries(ctx, &pbs.ListClientCertificateLibrariesRequest{ScopeId: "o_1234567890"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+}
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+	_, proj, repoFn, iamRepoFn := createDefaultLibraryAndRepo(t)
+
+	cases := []struct {
+		name string
+		item *pb.ClientCertificateLibrary
+		err  error
+	}{
+		{
+			name: "Create an internal library",
+			item: &pb.ClientCertificateLibrary{
+				ScopeId:    proj.GetPublicId(),
+				Name:       &wrappers.StringValue{Value: "internal"},
+				Type:       "internal",
+				CommonName: &wrappers.StringValue{Value: "boundary"},
+				ServerName: &wrappers.StringValue{Value: "db.internal"},
+			},
+		},
+		{
+			name: "Create a vault library",
+			item: &pb.ClientCertificateLibrary{
+				ScopeId:      proj.GetPublicId(),
+				Name:         &wrappers.StringValue{Value: "vault"},
+				Type:         "vault",
+				VaultAddress: &wrappers.StringValue{Value: "https://vault.example.com:8200"},
+				VaultPkiPath: &wrappers.StringValue{Value: "pki"},
+				VaultRole:    &wrappers.StringValue{Value: "boundary"},
+				VaultToken:   &wrappers.StringValue{Value: "s.token"},
+			},
+		},
+		{
+			name: "Unknown type",
+			item: &pb.ClientCertificateLibrary{ScopeId: proj.GetPublicId(), Type: "acme"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Vault library without a token",
+			item: &pb.ClientCertificateLibrary{
+				ScopeId:      proj.GetPublicId(),
+				Type:         "vault",
+				VaultAddress: &wrappers.StringValue{Value: "https://vault.example.com:8200"},
+				VaultPkiPath: &wrappers.StringValue{Value: "pki"},
+				VaultRole:    &wrappers.StringValue{Value: "boundary"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Internal library with a vault token",
+			item: &pb.ClientCertificateLibrary{
+				ScopeId:    proj.GetPublicId(),
+				Type:       "internal",
+				VaultToken: &wrappers.StringValue{Value: "s.token"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad server ca cert",
+			item: &pb.ClientCertificateLibrary{
+				ScopeId:      proj.GetPublicId(),
+				Type:         "internal",
+				ServerCaCert: &wrappers.StringValue{Value: "nope"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify the ca cert",
+			item: &pb.ClientCertificateLibrary{
+				ScopeId: proj.GetPublicId(),
+				Type:    "internal",
+				CaCert:  "cert",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Org scope",
+			item: &pb.ClientCertificateLibrary{ScopeId: proj.GetParentId(), Type: "internal"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := client_certificate_libraries.NewService(repoFn, iamRepoFn)
+			require.NoError(err)
+
+			got, gErr := s.CreateClientCertificateLibrary(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), &pbs.CreateClientCertificateLibraryRequest{Item: tc.item})
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateClientCertificateLibrary(%+v) got error %v, wanted %v", tc.item, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(fmt.Sprintf("client-certificate-libraries/%s", got.GetItem().GetId()), got.GetUri())
+			assert.Equal(tc.item.GetType(), got.GetItem().GetType())
+			assert.Equal(tc.item.GetName(), got.GetItem().GetName())
+			assert.Nil(got.GetItem().GetVaultToken(), "the vault token must never be returned")
+			if tc.item.GetType() == "internal" {
+				assert.NotEmpty(got.GetItem().GetCaCert())
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+	l, proj, repoFn, iamRepoFn := createDefaultLibraryAndRepo(t)
+	s, err := client_certificate_libraries.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	cases := []struct {
+		name string
+		req  *pbs.UpdateClientCertificateLibraryRequest
+		err  error
+	}{
+		{
+			name: "Update the common name",
+			req: &pbs.UpdateClientCertificateLibraryRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"common_name"}},
+				Item:       &pb.ClientCertificateLibrary{CommonName: &wrappers.StringValue{Value: "boundary"}},
+			},
+		},
+		{
+			name: "Can't set a vault token on an internal library",
+			req: &pbs.UpdateClientCertificateLibraryRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"vault_token"}},
+				Item:       &pb.ClientCertificateLibrary{VaultToken: &wrappers.StringValue{Value: "s.token"}},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't change the type",
+			req: &pbs.UpdateClientCertificateLibraryRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+				Item:       &pb.ClientCertificateLibrary{Name: &wrappers.StringValue{Value: "vault"}, Type: "vault"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "No valid fields",
+			req: &pbs.UpdateClientCertificateLibraryRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"ca_cert"}},
+				Item:       &pb.ClientCertificateLibrary{},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	version := l.GetVersion()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			tc.req.Id = l.GetPublicId()
+			tc.req.Item.Version = version
+			got, gErr := s.UpdateClientCertificateLibrary(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "UpdateClientCertificateLibrary(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			version = got.GetItem().GetVersion()
+			assert.Equal("boundary", got.GetItem().GetCommonName().GetValue())
+			assert.Equal(string(l.GetCaCert()), got.GetItem().GetCaCert())
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	l, proj, repoFn, iamRepoFn := createDefaultLibraryAndRepo(t)
+	s, err := client_certificate_libraries.NewService(repoFn, iamRepoFn)
+	require.NoError(err)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	_, err = s.DeleteClientCertificateLibrary(ctx, &pbs.DeleteClientCertificateLibraryRequest{Id: l.GetPublicId()})
+	require.NoError(err)
+	_, err = s.DeleteClientCertificateLibrary(ctx, &pbs.DeleteClientCertificateLibraryRequest{Id: l.GetPublicId()})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)))
+}
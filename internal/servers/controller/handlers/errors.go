@@ -19,8 +19,9 @@ import (
 )
 
 const (
-	genericUniquenessMsg = "Invalid request.  Request attempted to make second resource with the same field value that must be unique."
-	genericNotFoundMsg   = "Unable to find requested resource."
+	genericUniquenessMsg   = "Invalid request.  Request attempted to make second resource with the same field value that must be unique."
+	genericNotFoundMsg     = "Unable to find requested resource."
+	genericPageRequiredMsg = "Too many results to list at once. List them a page at a time with page_size and page_token; listing them all at once requires the list-unpaged action."
 )
 
 type apiError struct {
@@ -118,6 +119,18 @@ func InvalidArgumentErrorf(msg string, fields map[string]string) error {
 	return apiErr
 }
 
+// BannerAcknowledgmentRequiredErrorf returns an ApiError indicating a target's
+// banner must be acknowledged, with the banner and its hash in the error's
+// details so it can be shown and then acknowledged.
+func BannerAcknowledgmentRequiredErrorf(banner, hash string, msg string, a ...interface{}) error {
+	return &apiError{&pb.Error{
+		Status:  int32(runtime.HTTPStatusFromCode(codes.FailedPrecondition)),
+		Code:    codes.FailedPrecondition.String(),
+		Message: fmt.Sprintf(msg, a...),
+		Details: &pb.ErrorDetails{Banner: &pb.Banner{Text: banner, Hash: hash}},
+	}}
+}
+
 // Converts a known errors into an error that can presented to an end user over the API.
 func backendErrorToApiError(inErr error) error {
 	stErr := status.Convert(inErr)
@@ -144,6 +157,10 @@ func backendErrorToApiError(inErr error) error {
 		return InvalidArgumentErrorf("Error in provided request", map[string]string{"update_mask": "Invalid update mask provided."})
 	case db.IsUniqueError(inErr), errors.Is(inErr, db.ErrNotUnique):
 		return InvalidArgumentErrorf(genericUniquenessMsg, nil)
+	case errors.Is(inErr, db.ErrPageRequired):
+		return ApiErrorWithCodeAndMessage(codes.FailedPrecondition, genericPageRequiredMsg)
+	case errors.Is(inErr, db.ErrInvalidPageToken):
+		return InvalidArgumentErrorf("Error in provided request", map[string]string{"page_token": "Invalid page token provided."})
 	}
 	return nil
 }
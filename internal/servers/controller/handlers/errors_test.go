@@ -68,6 +68,21 @@ func TestApiErrorHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Banner Acknowledgment Required",
+			err:  BannerAcknowledgmentRequiredErrorf("Authorized use only.", "abc123", "Test"),
+			expected: &pb.Error{
+				Status:  http.StatusBadRequest,
+				Code:    "FailedPrecondition",
+				Message: "Test",
+				Details: &pb.ErrorDetails{
+					Banner: &pb.Banner{
+						Text: "Authorized use only.",
+						Hash: "abc123",
+					},
+				},
+			},
+		},
 		{
 			name: "GrpcGateway Routing Error",
 			err:  runtime.ErrNotMatch,
@@ -151,6 +166,25 @@ func TestApiErrorHandler(t *testing.T) {
 				Message: genericNotFoundMsg,
 			},
 		},
+		{
+			name: "Db page required",
+			err:  fmt.Errorf("test error: %w", db.ErrPageRequired),
+			expected: &pb.Error{
+				Status:  http.StatusBadRequest,
+				Code:    "FailedPrecondition",
+				Message: genericPageRequiredMsg,
+			},
+		},
+		{
+			name: "Db invalid page token",
+			err:  fmt.Errorf("test error: %w", db.ErrInvalidPageToken),
+			expected: &pb.Error{
+				Status:  http.StatusBadRequest,
+				Code:    "InvalidArgument",
+				Message: "Error in provided request",
+				Details: &pb.ErrorDetails{RequestFields: []*pb.FieldError{{Name: "page_token", Description: "Invalid page token provided."}}},
+			},
+		},
 		{
 			name: "Db multiple records",
 			err:  fmt.Errorf("test error: %w", db.ErrMultipleRecords),
@@ -56,14 +56,25 @@ func (s Service) ListGroups(ctx context.Context, req *pbs.ListGroupsRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	gl, err := s.listFromRepo(ctx, req.GetScopeId())
+	// A listing requested a page at a time is bounded by the max page size,
+	// and one that isn't fails if it has more results than that, unless the
+	// principal can list them unpaged.
+	var listOpts []iam.Option
+	var next string
+	switch {
+	case req.GetPageToken() != "" || req.GetPageSize() > 0:
+		listOpts = append(listOpts, iam.WithLimit(int(req.GetPageSize())), iam.WithPageToken(req.GetPageToken()), iam.WithNextPageToken(&next))
+	case authResults.ListUnpaged(ctx, resource.Group):
+		listOpts = append(listOpts, iam.WithLimit(-1))
+	}
+	gl, err := s.listFromRepo(ctx, req.GetScopeId(), listOpts...)
 	if err != nil {
 		return nil, err
 	}
 	for _, item := range gl {
 		item.Scope = authResults.Scope
 	}
-	return &pbs.ListGroupsResponse{Items: gl}, nil
+	return &pbs.ListGroupsResponse{Items: gl, NextPageToken: next}, nil
 }
 
 // GetGroups implements the interface pbs.GroupServiceServer.
@@ -92,6 +103,7 @@ func (s Service) CreateGroup(ctx context.Context, req *pbs.CreateGroupRequest) (
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -109,6 +121,7 @@ func (s Service) UpdateGroup(ctx context.Context, req *pbs.UpdateGroupRequest) (
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -126,6 +139,7 @@ func (s Service) DeleteGroup(ctx context.Context, req *pbs.DeleteGroupRequest) (
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	_, err := s.deleteFromRepo(ctx, req.GetId())
 	if err != nil {
 		return nil, err
@@ -142,6 +156,7 @@ func (s Service) AddGroupMembers(ctx context.Context, req *pbs.AddGroupMembersRe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	g, err := s.addMembersInRepo(ctx, req.GetId(), req.GetMemberIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -159,6 +174,7 @@ func (s Service) SetGroupMembers(ctx context.Context, req *pbs.SetGroupMembersRe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	g, err := s.setMembersInRepo(ctx, req.GetId(), req.GetMemberIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -176,6 +192,7 @@ func (s Service) RemoveGroupMembers(ctx context.Context, req *pbs.RemoveGroupMem
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	g, err := s.removeMembersInRepo(ctx, req.GetId(), req.GetMemberIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -275,12 +292,12 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Group, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId string, opt ...iam.Option) ([]*pb.Group, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	gl, err := repo.ListGroups(ctx, scopeId)
+	gl, err := repo.ListGroups(ctx, scopeId, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to list groups: %w", err)
 	}
@@ -298,6 +315,9 @@ func (s Service) addMembersInRepo(ctx context.Context, groupId string, userIds [
 	}
 	_, err = repo.AddGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(userIds, false))
 	if err != nil {
+		if errors.Is(err, iam.ErrQuotaExceeded) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "Unable to add members to group: %v.", err)
+		}
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add members to group: %v.", err)
 	}
@@ -318,6 +338,9 @@ func (s Service) setMembersInRepo(ctx context.Context, groupId string, userIds [
 	}
 	_, _, err = repo.SetGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(userIds, false))
 	if err != nil {
+		if errors.Is(err, iam.ErrQuotaExceeded) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "Unable to set members on group: %v.", err)
+		}
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set members on group: %v.", err)
 	}
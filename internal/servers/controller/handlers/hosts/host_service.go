@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/boundary/internal/host"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -92,6 +93,7 @@ func (s Service) CreateHost(ctx context.Context, req *pbs.CreateHostRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	h, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem().GetHostCatalogId(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -112,6 +114,7 @@ func (s Service) UpdateHost(ctx context.Context, req *pbs.UpdateHostRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	hc, err := s.updateInRepo(ctx, authResults.Scope.GetId(), cat.GetPublicId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -129,6 +132,7 @@ func (s Service) DeleteHost(ctx context.Context, req *pbs.DeleteHostRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	_, err := s.deleteFromRepo(ctx, authResults.Scope.GetId(), req.GetId())
 	if err != nil {
 		return nil, err
@@ -136,6 +140,44 @@ func (s Service) DeleteHost(ctx context.Context, req *pbs.DeleteHostRequest) (*p
 	return &pbs.DeleteHostResponse{}, nil
 }
 
+// ImportHosts implements the interface pbs.HostServiceServer.
+func (s Service) ImportHosts(ctx context.Context, req *pbs.ImportHostsRequest) (*pbs.ImportHostsResponse, error) {
+	if err := validateImportRequest(req); err != nil {
+		return nil, err
+	}
+	records, err := parseImportDocument(req)
+	if err != nil {
+		return nil, err
+	}
+	cat, authResults := s.parentAndAuthResult(ctx, req.GetHostCatalogId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	authOpts := []auth.Option{auth.WithScopeId(cat.GetScopeId()), auth.WithPin(cat.GetPublicId())}
+	if res := authResults.AdditionalVerification(ctx, append(authOpts, auth.WithType(resource.Host), auth.WithAction(action.Update))...); res.Error != nil {
+		return nil, res.Error
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	setIds, err := importSetIds(ctx, repo, cat.GetPublicId(), records)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range setIds {
+		if res := authResults.AdditionalVerification(ctx, append(authOpts, auth.WithId(id), auth.WithType(resource.HostSet), auth.WithAction(action.AddHosts))...); res.Error != nil {
+			return nil, res.Error
+		}
+	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
+	hi, err := s.importInRepo(ctx, cat.GetScopeId(), cat.GetPublicId(), records, req.GetDryRun())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ImportHostsResponse{Item: hi}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Host, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
@@ -259,6 +301,48 @@ func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]*pb.Host
 	return outHl, nil
 }
 
+func (s Service) importInRepo(ctx context.Context, scopeId, catalogId string, records []*static.HostImportRecord, dryRun bool) (*pb.HostImport, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	imported, err := repo.ImportHosts(ctx, scopeId, catalogId, records, static.WithDryRun(dryRun))
+	if err != nil {
+		var rowErrs static.HostImportErrors
+		if errors.As(err, &rowErrs) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided document.", importErrorFields(rowErrs))
+		}
+		if errors.Is(err, db.ErrInvalidParameter) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"document": err.Error()})
+		}
+		return nil, fmt.Errorf("unable to import hosts: %w", err)
+	}
+	out := &pb.HostImport{DryRun: dryRun, Items: make([]*pb.ImportedHost, 0, len(imported))}
+	for _, ih := range imported {
+		item := &pb.ImportedHost{
+			Id:              ih.GetPublicId(),
+			Name:            ih.GetName(),
+			Address:         ih.GetAddress(),
+			Description:     ih.GetDescription(),
+			Action:          string(ih.Action),
+			AddedHostSetIds: ih.AddedSetIds,
+		}
+		switch ih.Action {
+		case static.ImportCreated:
+			out.Created++
+			if dryRun {
+				item.Id = ""
+			}
+		case static.ImportUpdated:
+			out.Updated++
+		case static.ImportUnchanged:
+			out.Unchanged++
+		}
+		out.Items = append(out.Items, item)
+	}
+	return out, nil
+}
+
 func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (*static.HostCatalog, auth.VerifyResults) {
 	res := auth.VerifyResults{}
 	repo, err := s.staticRepoFn()
@@ -419,3 +503,25 @@ func validateListRequest(req *pbs.ListHostsRequest) error {
 	}
 	return nil
 }
+
+func validateImportRequest(req *pbs.ImportHostsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(static.HostCatalogPrefix, req.GetHostCatalogId()) {
+		badFields["host_catalog_id"] = "The field is incorrectly formatted."
+	}
+	switch strings.ToLower(req.GetFormat()) {
+	case importFormatCSV, importFormatJSON:
+	default:
+		badFields["format"] = fmt.Sprintf("Must be %q or %q.", importFormatCSV, importFormatJSON)
+	}
+	switch {
+	case strings.TrimSpace(req.GetDocument()) == "":
+		badFields["document"] = "Must hold at least one host."
+	case len(req.GetDocument()) > maxImportDocumentSize:
+		badFields["document"] = fmt.Sprintf("Must be at most %d bytes.", maxImportDocumentSize)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
@@ -821,3 +821,92 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestImport(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+
+	s, err := hosts.NewService(repoFn)
+	require.NoError(t, err, "Couldn't create a new host service.")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+	doc := fmt.Sprintf("name,address,host_sets\nweb,10.0.0.1,%s\ndb,10.0.0.2,\n", hs.GetPublicId())
+
+	cases := []struct {
+		name string
+		req  *pbs.ImportHostsRequest
+		err  error
+	}{
+		{
+			name: "Bad catalog id",
+			req:  &pbs.ImportHostsRequest{HostCatalogId: "hc_bad", Format: "csv", Document: doc},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Unknown format",
+			req:  &pbs.ImportHostsRequest{HostCatalogId: hc.GetPublicId(), Format: "xml", Document: doc},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Empty document",
+			req:  &pbs.ImportHostsRequest{HostCatalogId: hc.GetPublicId(), Format: "csv"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad row",
+			req:  &pbs.ImportHostsRequest{HostCatalogId: hc.GetPublicId(), Format: "csv", Document: "name,address\nno-address,\n"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gErr := s.ImportHosts(ctx, tc.req)
+			require.Error(t, gErr)
+			assert.Nil(t, got)
+			assert.True(t, errors.Is(gErr, tc.err), "ImportHosts(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+		})
+	}
+
+	t.Run("Dry run", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ImportHosts(ctx, &pbs.ImportHostsRequest{HostCatalogId: hc.GetPublicId(), Format: "csv", Document: doc, DryRun: true})
+		require.NoError(err)
+		assert.True(got.GetItem().GetDryRun())
+		assert.Equal(uint32(2), got.GetItem().GetCreated())
+		require.Len(got.GetItem().GetItems(), 2)
+		assert.Empty(got.GetItem().GetItems()[0].GetId())
+
+		hl, err := s.ListHosts(ctx, &pbs.ListHostsRequest{HostCatalogId: hc.GetPublicId()})
+		require.NoError(err)
+		assert.Empty(hl.GetItems())
+	})
+	t.Run("Import", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ImportHosts(ctx, &pbs.ImportHostsRequest{HostCatalogId: hc.GetPublicId(), Format: "csv", Document: doc})
+		require.NoError(err)
+		assert.False(got.GetItem().GetDryRun())
+		assert.Equal(uint32(2), got.GetItem().GetCreated())
+		require.Len(got.GetItem().GetItems(), 2)
+		created := got.GetItem().GetItems()[0]
+		assert.Equal("created", created.GetAction())
+		assert.NotEmpty(created.GetId())
+		assert.Equal([]string{hs.GetPublicId()}, created.GetAddedHostSetIds())
+
+		hl, err := s.ListHosts(ctx, &pbs.ListHostsRequest{HostCatalogId: hc.GetPublicId()})
+		require.NoError(err)
+		assert.Len(hl.GetItems(), 2)
+
+		got, err = s.ImportHosts(ctx, &pbs.ImportHostsRequest{HostCatalogId: hc.GetPublicId(), Format: "csv", Document: doc})
+		require.NoError(err)
+		assert.Equal(uint32(2), got.GetItem().GetUnchanged())
+	})
+}
@@ -0,0 +1,83 @@
+package hosts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+)
+
+// The formats of host import documents.
+const (
+	importFormatCSV  = "csv"
+	importFormatJSON = "json"
+)
+
+// maxImportDocumentSize is the largest document ImportHosts reads.
+const maxImportDocumentSize = 10 << 20
+
+// parseImportDocument parses the records of the request's document.
+func parseImportDocument(req *pbs.ImportHostsRequest) ([]*static.HostImportRecord, error) {
+	var records []*static.HostImportRecord
+	var err error
+	switch strings.ToLower(req.GetFormat()) {
+	case importFormatCSV:
+		records, err = static.ParseHostImportCSV(strings.NewReader(req.GetDocument()))
+	case importFormatJSON:
+		records, err = static.ParseHostImportJSON(strings.NewReader(req.GetDocument()))
+	}
+	if err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"document": err.Error()})
+	}
+	if len(records) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"document": "Must hold at least one host."})
+	}
+	return records, nil
+}
+
+// importSetIds returns the ids of the catalog's sets the records refer to,
+// by id or name. References to sets the catalog doesn't have are left for
+// the import to report.
+func importSetIds(ctx context.Context, repo *static.Repository, catalogId string, records []*static.HostImportRecord) ([]string, error) {
+	sets, err := repo.ListSets(ctx, catalogId, static.WithLimit(-1))
+	if err != nil {
+		return nil, err
+	}
+	byRef := make(map[string]string, 2*len(sets))
+	for _, s := range sets {
+		byRef[s.GetPublicId()] = s.GetPublicId()
+		if s.GetName() != "" {
+			byRef[s.GetName()] = s.GetPublicId()
+		}
+	}
+	seen := make(map[string]bool)
+	var ids []string
+	for _, rec := range records {
+		for _, ref := range rec.HostSets {
+			id, ok := byRef[strings.TrimSpace(ref)]
+			if ok && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// importErrorFields returns the problems with the rows of a document as the
+// fields of an invalid argument error.
+func importErrorFields(errs static.HostImportErrors) map[string]string {
+	fields := make(map[string]string, len(errs))
+	for _, e := range errs {
+		key := fmt.Sprintf("document.row[%d]", e.Row)
+		if msg, ok := fields[key]; ok {
+			fields[key] = msg + "; " + e.Err.Error()
+			continue
+		}
+		fields[key] = e.Err.Error()
+	}
+	return fields
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+)
+
+// IdempotencyKeyHeader is the HTTP header a client sets on a request which
+// creates a role or adds grants to one, so the request can be retried, after
+// a timeout for instance, without writing twice.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyKeyMetadata is the grpc metadata key IdempotencyKeyHeader is
+// forwarded as.
+const idempotencyKeyMetadata = "idempotency-key"
+
+// IncomingHeaderMatcher forwards IdempotencyKeyHeader to the handlers along
+// with the headers runtime.DefaultHeaderMatcher forwards.
+func IncomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, IdempotencyKeyHeader) {
+		return idempotencyKeyMetadata, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// IdempotencyKey returns the idempotency key of the request, or an empty
+// string if it doesn't have one.
+func IdempotencyKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if v := md.Get(idempotencyKeyMetadata); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
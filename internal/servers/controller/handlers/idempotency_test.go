@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	assert := assert.New(t)
+
+	key, ok := IncomingHeaderMatcher("idempotency-key")
+	assert.True(ok)
+	assert.Equal(idempotencyKeyMetadata, key)
+	_, ok = IncomingHeaderMatcher("X-Unknown")
+	assert.False(ok)
+
+	assert.Empty(IdempotencyKey(context.Background()))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyMetadata, "retry-1"))
+	assert.Equal("retry-1", IdempotencyKey(ctx))
+}
@@ -0,0 +1,289 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/jobs"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/jobs"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The types of jobs which can be started through the api.
+const (
+	// deleteScopeJob deletes a project, or an org after each of its
+	// projects.
+	deleteScopeJob = "delete-scope"
+	// importSnapshotJob imports an iam.Snapshot as a new org.
+	importSnapshotJob = "import-snapshot"
+)
+
+// Service handles request as described by the pbs.JobServiceServer interface.
+type Service struct {
+	runner    *jobs.Runner
+	repoFn    common.JobRepoFactory
+	iamRepoFn common.IamRepoFactory
+}
+
+// NewService returns a job service which handles job related requests to
+// boundary. The jobs it starts are run by the runner.
+func NewService(runner *jobs.Runner, repoFn common.JobRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+	if runner == nil {
+		return Service{}, fmt.Errorf("nil job runner provided")
+	}
+	if repoFn == nil {
+		return Service{}, fmt.Errorf("nil job repository provided")
+	}
+	if iamRepoFn == nil {
+		return Service{}, fmt.Errorf("nil iam repository provided")
+	}
+	return Service{runner: runner, repoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+}
+
+var _ pbs.JobServiceServer = Service{}
+
+// GetJob implements the interface pbs.JobServiceServer.
+func (s Service) GetJob(ctx context.Context, req *pbs.GetJobRequest) (*pbs.GetJobResponse, error) {
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	j, authResults := s.jobAndAuthResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	item, err := toProto(j)
+	if err != nil {
+		return nil, err
+	}
+	item.Scope = authResults.Scope
+	return &pbs.GetJobResponse{Item: item}, nil
+}
+
+// ListJobs implements the interface pbs.JobServiceServer.
+func (s Service) ListJobs(ctx context.Context, req *pbs.ListJobsRequest) (*pbs.ListJobsResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := auth.Verify(ctx, auth.WithScopeId(req.GetScopeId()), auth.WithType(resource.Job), auth.WithAction(action.List))
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	js, err := repo.ListJobs(ctx, req.GetScopeId())
+	if err != nil {
+		return nil, err
+	}
+	var items []*pb.Job
+	for _, j := range js {
+		item, err := toProto(j)
+		if err != nil {
+			return nil, err
+		}
+		item.Scope = authResults.Scope
+		items = append(items, item)
+	}
+	return &pbs.ListJobsResponse{Items: items}, nil
+}
+
+// StartJob implements the interface pbs.JobServiceServer.
+func (s Service) StartJob(ctx context.Context, req *pbs.StartJobRequest) (*pbs.StartJobResponse, error) {
+	if err := validateStartRequest(req); err != nil {
+		return nil, err
+	}
+	var scopeId string
+	var fn jobs.RunFunc
+	var authResults auth.VerifyResults
+	switch req.GetType() {
+	case deleteScopeJob:
+		repo, err := s.iamRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		sc, err := repo.LookupScope(ctx, req.GetScopeId())
+		if err != nil {
+			return nil, err
+		}
+		if sc == nil {
+			return nil, handlers.NotFoundErrorf("Scope %q doesn't exist.", req.GetScopeId())
+		}
+		scopeId = sc.GetParentId()
+		authResults = auth.Verify(ctx, auth.WithScopeId(scopeId), auth.WithId(sc.GetPublicId()), auth.WithType(resource.Scope), auth.WithAction(action.Delete))
+		fn = deleteScopeJobFn(s.iamRepoFn, sc)
+
+	case importSnapshotJob:
+		b, err := req.GetSnapshot().MarshalJSON()
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to read snapshot: %v.", err)
+		}
+		snapshot := new(iam.Snapshot)
+		if err := json.Unmarshal(b, snapshot); err != nil {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"snapshot": fmt.Sprintf("Not a snapshot: %v.", err)})
+		}
+		scopeId = scope.Global.String()
+		authResults = auth.Verify(ctx, auth.WithScopeId(scopeId), auth.WithType(resource.Scope), auth.WithAction(action.Create))
+		fn = importSnapshotJobFn(s.iamRepoFn, snapshot)
+	}
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	j, err := s.runner.Start(ctx, scopeId, authResults.UserId, req.GetType(), fn)
+	if err != nil {
+		return nil, err
+	}
+	item, err := toProto(j)
+	if err != nil {
+		return nil, err
+	}
+	item.Scope = authResults.Scope
+	return &pbs.StartJobResponse{Item: item}, nil
+}
+
+// CancelJob implements the interface pbs.JobServiceServer.
+func (s Service) CancelJob(ctx context.Context, req *pbs.CancelJobRequest) (*pbs.CancelJobResponse, error) {
+	if err := validateCancelRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.jobAndAuthResult(ctx, req.GetId(), action.Cancel)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	j, err := s.runner.Cancel(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := toProto(j)
+	if err != nil {
+		return nil, err
+	}
+	item.Scope = authResults.Scope
+	return &pbs.CancelJobResponse{Item: item}, nil
+}
+
+// jobAndAuthResult returns the job and whether the request is authorized to
+// take the action on it.
+func (s Service) jobAndAuthResult(ctx context.Context, id string, a action.Type) (*jobs.Job, auth.VerifyResults) {
+	res := auth.VerifyResults{}
+	repo, err := s.repoFn()
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
+	j, err := repo.LookupJob(ctx, id)
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
+	if j == nil {
+		res.Error = handlers.NotFoundErrorf("Job %q doesn't exist.", id)
+		return nil, res
+	}
+	return j, auth.Verify(ctx, auth.WithScopeId(j.ScopeId), auth.WithId(j.PublicId), auth.WithType(resource.Job), auth.WithAction(a))
+}
+
+func toProto(in *jobs.Job) (*pb.Job, error) {
+	out := pb.Job{
+		Id:              in.PublicId,
+		ScopeId:         in.ScopeId,
+		UserId:          in.UserId,
+		Type:            in.Type,
+		ControllerId:    in.ControllerId,
+		Status:          string(in.Status),
+		ProgressDone:    uint32(in.ProgressDone),
+		ProgressTotal:   uint32(in.ProgressTotal),
+		Error:           in.Error,
+		CancelRequested: in.CancelRequested,
+		CreatedTime:     timestamppb.New(in.CreateTime),
+		UpdatedTime:     timestamppb.New(in.UpdateTime),
+	}
+	if in.EndTime != nil {
+		out.EndTime = timestamppb.New(*in.EndTime)
+	}
+	if len(in.Result) > 0 {
+		out.Result = new(structpb.Struct)
+		if err := out.Result.UnmarshalJSON(in.Result); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to convert job result to struct: %v.", err)
+		}
+	}
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetJobRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(jobs.JobPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateListRequest(req *pbs.ListJobsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) &&
+		!handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Improperly formatted field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateStartRequest(req *pbs.StartJobRequest) error {
+	badFields := map[string]string{}
+	switch req.GetType() {
+	case deleteScopeJob:
+		if !handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) &&
+			!handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
+			badFields["scope_id"] = "Must be the id of an org or project."
+		}
+		if req.GetSnapshot() != nil {
+			badFields["snapshot"] = fmt.Sprintf("Can't be set for a %q job.", deleteScopeJob)
+		}
+	case importSnapshotJob:
+		if req.GetSnapshot() == nil {
+			badFields["snapshot"] = "Must be provided."
+		}
+		if req.GetScopeId() != "" {
+			badFields["scope_id"] = fmt.Sprintf("Can't be set for an %q job.", importSnapshotJob)
+		}
+	default:
+		badFields["type"] = fmt.Sprintf("Must be %q or %q.", deleteScopeJob, importSnapshotJob)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateCancelRequest(req *pbs.CancelJobRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(jobs.JobPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
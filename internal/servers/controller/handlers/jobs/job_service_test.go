@@ -0,0 +1,87 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/jobs"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	handler "github.com/hashicorp/boundary/internal/servers/controller/handlers/jobs"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestStartJob(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	rw := db.New(conn)
+	repoFn := func() (*jobs.Repository, error) {
+		return jobs.NewRepository(rw, rw)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runner, err := jobs.NewRunner(ctx, repoFn, "controller", hclog.NewNullLogger())
+	require.NoError(t, err)
+	s, err := handler.NewService(runner, repoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create new job service.")
+
+	o, p := iam.TestScopes(t, iamRepo)
+
+	t.Run("delete-scope", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		reqCtx := auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId()), auth.WithUserId("u_auth"))
+		got, err := s.StartJob(reqCtx, &pbs.StartJobRequest{Type: "delete-scope", ScopeId: p.GetPublicId()})
+		require.NoError(err)
+		assert.Equal(o.GetPublicId(), got.GetItem().GetScopeId())
+		assert.Equal("delete-scope", got.GetItem().GetType())
+		assert.Equal(string(jobs.Running), got.GetItem().GetStatus())
+		runner.Wait()
+
+		read, err := s.GetJob(reqCtx, &pbs.GetJobRequest{Id: got.GetItem().GetId()})
+		require.NoError(err)
+		assert.Equal(string(jobs.Succeeded), read.GetItem().GetStatus())
+		assert.Equal(uint32(1), read.GetItem().GetProgressDone())
+		assert.Equal(uint32(1), read.GetItem().GetProgressTotal())
+		deleted := read.GetItem().GetResult().GetFields()["deleted_scopes"].GetListValue().GetValues()
+		require.Len(deleted, 1)
+		assert.Equal(p.GetPublicId(), deleted[0].GetStringValue())
+
+		list, err := s.ListJobs(reqCtx, &pbs.ListJobsRequest{ScopeId: o.GetPublicId()})
+		require.NoError(err)
+		require.Len(list.GetItems(), 1)
+		assert.Equal(got.GetItem().GetId(), list.GetItems()[0].GetId())
+
+		missing, err := s.StartJob(reqCtx, &pbs.StartJobRequest{Type: "delete-scope", ScopeId: p.GetPublicId()})
+		require.Error(err)
+		assert.Nil(missing)
+		assert.True(errors.Is(err, handlers.NotFoundError()), "got error %v, wanted not found", err)
+	})
+
+	invalid := map[string]*pbs.StartJobRequest{
+		"unknown type":            {Type: "unknown", ScopeId: o.GetPublicId()},
+		"delete global":           {Type: "delete-scope", ScopeId: "global"},
+		"delete with snapshot":    {Type: "delete-scope", ScopeId: o.GetPublicId(), Snapshot: &structpb.Struct{}},
+		"import without snapshot": {Type: "import-snapshot"},
+		"import with scope":       {Type: "import-snapshot", ScopeId: o.GetPublicId(), Snapshot: &structpb.Struct{}},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			got, err := s.StartJob(auth.DisabledAuthTestContext(auth.WithScopeId("global")), req)
+			require.Error(t, err)
+			assert.Nil(t, got)
+			assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v, wanted invalid argument", err)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/jobs"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+// deleteScopeJobFn deletes the scope. An org's projects are deleted one at a
+// time before it, so a large org reports its progress and can be canceled
+// between projects.
+func deleteScopeJobFn(repoFn common.IamRepoFactory, s *iam.Scope) jobs.RunFunc {
+	return func(ctx context.Context, p *jobs.Progress) (interface{}, error) {
+		repo, err := repoFn()
+		if err != nil {
+			return nil, err
+		}
+		var ids []string
+		if s.GetType() == scope.Org.String() {
+			projects, err := repo.ListProjects(ctx, s.GetPublicId(), iam.WithLimit(-1))
+			if err != nil {
+				return nil, err
+			}
+			for _, proj := range projects {
+				ids = append(ids, proj.GetPublicId())
+			}
+		}
+		ids = append(ids, s.GetPublicId())
+		total := int64(len(ids))
+		if err := p.Set(ctx, 0, total); err != nil {
+			return nil, err
+		}
+		var result struct {
+			DeletedScopes []string `json:"deleted_scopes"`
+		}
+		for i, id := range ids {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			if _, err := repo.DeleteScope(ctx, id); err != nil {
+				return result, err
+			}
+			result.DeletedScopes = append(result.DeletedScopes, id)
+			if err := p.Set(ctx, int64(i+1), total); err != nil {
+				return result, err
+			}
+		}
+		return result, nil
+	}
+}
+
+// importSnapshotJobFn imports the snapshot as a new org.
+func importSnapshotJobFn(repoFn common.IamRepoFactory, snapshot *iam.Snapshot) jobs.RunFunc {
+	return func(ctx context.Context, p *jobs.Progress) (interface{}, error) {
+		repo, err := repoFn()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.Set(ctx, 0, 1); err != nil {
+			return nil, err
+		}
+		org, ids, err := repo.Import(ctx, snapshot)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.Set(ctx, 1, 1); err != nil {
+			return nil, err
+		}
+		return struct {
+			OrgId string            `json:"org_id"`
+			Ids   map[string]string `json:"ids"`
+		}{OrgId: org.GetPublicId(), Ids: ids}, nil
+	}
+}
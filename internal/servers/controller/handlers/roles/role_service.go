@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/db"
@@ -19,6 +21,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/strutil"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -57,14 +60,28 @@ func (s Service) ListRoles(ctx context.Context, req *pbs.ListRolesRequest) (*pbs
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	gl, err := s.listFromRepo(ctx, req.GetScopeId())
+	// A listing requested a page at a time is bounded by the max page size,
+	// and one that isn't fails if it has more results than that, unless the
+	// principal can list them unpaged.
+	var listOpts []iam.Option
+	var next string
+	switch {
+	case req.GetPageToken() != "" || req.GetPageSize() > 0:
+		listOpts = append(listOpts, iam.WithLimit(int(req.GetPageSize())), iam.WithPageToken(req.GetPageToken()), iam.WithNextPageToken(&next))
+	case authResults.ListUnpaged(ctx, resource.Role):
+		listOpts = append(listOpts, iam.WithLimit(-1))
+	}
+	if req.GetIncludeArchived() {
+		listOpts = append(listOpts, iam.WithArchived(true))
+	}
+	gl, err := s.listFromRepo(ctx, req.GetScopeId(), listOpts...)
 	if err != nil {
 		return nil, err
 	}
 	for _, item := range gl {
 		item.Scope = authResults.Scope
 	}
-	return &pbs.ListRolesResponse{Items: gl}, nil
+	return &pbs.ListRolesResponse{Items: gl, NextPageToken: next}, nil
 }
 
 // GetRoles implements the interface pbs.RoleServiceServer.
@@ -93,6 +110,7 @@ func (s Service) CreateRole(ctx context.Context, req *pbs.CreateRoleRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	r, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -110,6 +128,7 @@ func (s Service) UpdateRole(ctx context.Context, req *pbs.UpdateRoleRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -127,6 +146,7 @@ func (s Service) DeleteRole(ctx context.Context, req *pbs.DeleteRoleRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	_, err := s.deleteFromRepo(ctx, req.GetId())
 	if err != nil {
 		return nil, err
@@ -143,6 +163,7 @@ func (s Service) AddRolePrincipals(ctx context.Context, req *pbs.AddRolePrincipa
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	r, err := s.addPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -160,6 +181,7 @@ func (s Service) SetRolePrincipals(ctx context.Context, req *pbs.SetRolePrincipa
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	r, err := s.setPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -177,6 +199,7 @@ func (s Service) RemoveRolePrincipals(ctx context.Context, req *pbs.RemoveRolePr
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	r, err := s.removePrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -194,6 +217,7 @@ func (s Service) AddRoleGrants(ctx context.Context, req *pbs.AddRoleGrantsReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	r, err := s.addGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -211,6 +235,7 @@ func (s Service) SetRoleGrants(ctx context.Context, req *pbs.SetRoleGrantsReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	r, err := s.setGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -228,6 +253,7 @@ func (s Service) RemoveRoleGrants(ctx context.Context, req *pbs.RemoveRoleGrants
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	r, err := s.removeGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -236,6 +262,103 @@ func (s Service) RemoveRoleGrants(ctx context.Context, req *pbs.RemoveRoleGrants
 	return &pbs.RemoveRoleGrantsResponse{Item: r}, nil
 }
 
+// ActivateEmergencyRole implements the interface pbs.RoleServiceServer. The
+// role is activated for the user of the request's auth token.
+func (s Service) ActivateEmergencyRole(ctx context.Context, req *pbs.ActivateEmergencyRoleRequest) (*pbs.ActivateEmergencyRoleResponse, error) {
+	if err := validateActivateEmergencyRoleRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ActivateEmergency)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if authResults.UserId == "u_anon" {
+		// Only an authenticated user can be held to the activation.
+		return nil, handlers.UnauthenticatedError()
+	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
+	a, err := s.activateEmergencyInRepo(ctx, req.GetId(), authResults.UserId, req.GetJustification(), time.Duration(req.GetDurationSeconds())*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ActivateEmergencyRoleResponse{Item: a}, nil
+}
+
+// RevertEmergencyRole implements the interface pbs.RoleServiceServer.
+func (s Service) RevertEmergencyRole(ctx context.Context, req *pbs.RevertEmergencyRoleRequest) (*pbs.RevertEmergencyRoleResponse, error) {
+	if err := validateRevertEmergencyRoleRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RevertEmergency)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
+	a, err := s.revertEmergencyInRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.RevertEmergencyRoleResponse{Item: a}, nil
+}
+
+// DiffRole implements the interface pbs.RoleServiceServer. When two roles are
+// compared, the diff action must be granted on both of them.
+func (s Service) DiffRole(ctx context.Context, req *pbs.DiffRoleRequest) (*pbs.DiffRoleResponse, error) {
+	if err := validateDiffRoleRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Diff)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if req.GetFromRoleId() != "" {
+		if fromResults := s.authResult(ctx, req.GetFromRoleId(), action.Diff); fromResults.Error != nil {
+			return nil, fromResults.Error
+		}
+	}
+	d, err := s.diffInRepo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.DiffRoleResponse{Item: d}, nil
+}
+
+// ArchiveRole implements the interface pbs.RoleServiceServer.
+func (s Service) ArchiveRole(ctx context.Context, req *pbs.ArchiveRoleRequest) (*pbs.ArchiveRoleResponse, error) {
+	if err := validateArchiveRoleRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Archive)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
+	r, err := s.archiveInRepo(ctx, req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	r.Scope = authResults.Scope
+	return &pbs.ArchiveRoleResponse{Item: r}, nil
+}
+
+// RestoreRole implements the interface pbs.RoleServiceServer.
+func (s Service) RestoreRole(ctx context.Context, req *pbs.RestoreRoleRequest) (*pbs.RestoreRoleResponse, error) {
+	if err := validateRestoreRoleRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Restore)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
+	r, err := s.restoreInRepo(ctx, req.GetId(), req.GetVersion(), req.GetExpirationTime())
+	if err != nil {
+		return nil, err
+	}
+	r.Scope = authResults.Scope
+	return &pbs.RestoreRoleResponse{Item: r}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Role, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -265,6 +388,9 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Role
 	if item.GetGrantScopeId() != nil {
 		opts = append(opts, iam.WithGrantScopeId(item.GetGrantScopeId().GetValue()))
 	}
+	if item.GetExpirationTime() != nil {
+		opts = append(opts, iam.WithExpirationTime(item.GetExpirationTime().AsTime()))
+	}
 	u, err := iam.NewRole(scopeId, opts...)
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build role for creation: %v.", err)
@@ -273,8 +399,21 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Role
 	if err != nil {
 		return nil, err
 	}
-	out, err := repo.CreateRole(ctx, u)
+	idemOpts, err := idempotencyOpts(ctx)
 	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateRole(ctx, u, idemOpts...)
+	if err != nil {
+		if errors.Is(err, iam.ErrQuotaExceeded) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "Unable to create role: %v.", err)
+		}
+		if errors.Is(err, iam.ErrIdempotencyKeyReused) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Unable to create role: %v.", err)
+		}
+		if errors.Is(err, iam.ErrWriteRejected) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Unable to create role: %v.", err)
+		}
 		return nil, fmt.Errorf("unable to create role: %w", err)
 	}
 	if out == nil {
@@ -283,6 +422,21 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Role
 	return toProto(out, nil, nil), nil
 }
 
+// idempotencyOpts returns the option for the idempotency key of the request,
+// if it has one.
+func idempotencyOpts(ctx context.Context) ([]iam.Option, error) {
+	key := handlers.IdempotencyKey(ctx)
+	switch {
+	case key == "":
+		return nil, nil
+	case len(key) > iam.MaxIdempotencyKeyLength:
+		return nil, handlers.InvalidArgumentErrorf("Invalid idempotency key.", map[string]string{
+			handlers.IdempotencyKeyHeader: fmt.Sprintf("Must be at most %d characters.", iam.MaxIdempotencyKeyLength),
+		})
+	}
+	return []iam.Option{iam.WithIdempotencyKey(key)}, nil
+}
+
 func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Role) (*pb.Role, error) {
 	var opts []iam.Option
 	if desc := item.GetDescription(); desc != nil {
@@ -294,6 +448,9 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if grantScopeId := item.GetGrantScopeId(); grantScopeId != nil {
 		opts = append(opts, iam.WithGrantScopeId(grantScopeId.GetValue()))
 	}
+	if expiration := item.GetExpirationTime(); expiration != nil {
+		opts = append(opts, iam.WithExpirationTime(expiration.AsTime()))
+	}
 	version := item.GetVersion()
 
 	u, err := iam.NewRole(scopeId, opts...)
@@ -311,6 +468,12 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	}
 	out, pr, gr, rowsUpdated, err := repo.UpdateRole(ctx, u, version, dbMask)
 	if err != nil {
+		if errors.Is(err, iam.ErrWriteRejected) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Unable to update role: %v.", err)
+		}
+		if errors.Is(err, db.ErrInvalidParameter) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Unable to update role: %v.", err)
+		}
 		return nil, fmt.Errorf("unable to update role: %w", err)
 	}
 	if rowsUpdated == 0 {
@@ -334,12 +497,12 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Role, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId string, opt ...iam.Option) ([]*pb.Role, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	rl, err := repo.ListRoles(ctx, scopeId)
+	rl, err := repo.ListRoles(ctx, scopeId, opt...)
 	if err != nil {
 		return nil, err
 	}
@@ -416,8 +579,24 @@ func (s Service) addGrantsInRepo(ctx context.Context, roleId string, grants []st
 	if err != nil {
 		return nil, err
 	}
-	_, err = repo.AddRoleGrants(ctx, roleId, version, strutil.RemoveDuplicates(grants, false))
+	idemOpts, err := idempotencyOpts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, err = repo.AddRoleGrants(ctx, roleId, version, strutil.RemoveDuplicates(grants, false), idemOpts...)
 	if err != nil {
+		if errors.Is(err, iam.ErrIdempotencyKeyReused) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Unable to add grants to role: %v.", err)
+		}
+		if errors.Is(err, perms.ErrTypeNotInScope) {
+			return nil, handlers.InvalidArgumentErrorf("Errors in provided fields.", map[string]string{"grant_strings": err.Error()})
+		}
+		if errors.Is(err, iam.ErrQuotaExceeded) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "Unable to add grants to role: %v.", err)
+		}
+		if errors.Is(err, iam.ErrWriteRejected) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Unable to add grants to role: %v.", err)
+		}
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add grants to role: %v.", err)
 	}
@@ -440,8 +619,17 @@ func (s Service) setGrantsInRepo(ctx context.Context, roleId string, grants []st
 	if grants == nil {
 		grants = []string{}
 	}
-	_, _, err = repo.SetRoleGrants(ctx, roleId, version, strutil.RemoveDuplicates(grants, false))
+	_, _, _, err = repo.SetRoleGrants(ctx, roleId, version, strutil.RemoveDuplicates(grants, false))
 	if err != nil {
+		if errors.Is(err, perms.ErrTypeNotInScope) {
+			return nil, handlers.InvalidArgumentErrorf("Errors in provided fields.", map[string]string{"grant_strings": err.Error()})
+		}
+		if errors.Is(err, iam.ErrQuotaExceeded) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "Unable to set grants on role: %v.", err)
+		}
+		if errors.Is(err, iam.ErrWriteRejected) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Unable to set grants on role: %v.", err)
+		}
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set grants on role: %v.", err)
 	}
@@ -475,6 +663,127 @@ func (s Service) removeGrantsInRepo(ctx context.Context, roleId string, grants [
 	return toProto(out, pr, roleGrants), nil
 }
 
+func (s Service) activateEmergencyInRepo(ctx context.Context, roleId, userId, justification string, duration time.Duration) (*pb.EmergencyActivation, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	a, err := repo.ActivateEmergencyRole(ctx, roleId, userId, justification, duration)
+	if err != nil {
+		switch {
+		case errors.Is(err, iam.ErrWriteRejected):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Unable to activate emergency role: %v.", err)
+		case errors.Is(err, db.ErrNotUnique):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Unable to activate emergency role: %v.", err)
+		case errors.Is(err, db.ErrInvalidParameter):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Unable to activate emergency role: %v.", err)
+		}
+		return nil, fmt.Errorf("unable to activate emergency role: %w", err)
+	}
+	return toEmergencyActivationProto(a), nil
+}
+
+func (s Service) revertEmergencyInRepo(ctx context.Context, roleId string) (*pb.EmergencyActivation, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	a, err := repo.RevertEmergencyRole(ctx, roleId)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Unable to revert emergency role: %v.", err)
+		}
+		return nil, fmt.Errorf("unable to revert emergency role: %w", err)
+	}
+	return toEmergencyActivationProto(a), nil
+}
+
+func (s Service) diffInRepo(ctx context.Context, req *pbs.DiffRoleRequest) (*pb.RoleDiff, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	var d *iam.RoleDiff
+	if req.GetFromRoleId() != "" {
+		d, err = repo.DiffRoles(ctx, req.GetFromRoleId(), req.GetId())
+	} else {
+		to := time.Now()
+		if req.GetToTime() != nil {
+			to = req.GetToTime().AsTime()
+		}
+		d, err = repo.RoleDiffBetween(ctx, req.GetId(), req.GetFromTime().AsTime(), to)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrRecordNotFound):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.NotFound, "Unable to diff role: %v.", err)
+		case errors.Is(err, db.ErrInvalidParameter):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Unable to diff role: %v.", err)
+		}
+		return nil, fmt.Errorf("unable to diff role: %w", err)
+	}
+	return toRoleDiffProto(d), nil
+}
+
+func (s Service) archiveInRepo(ctx context.Context, roleId string, version uint32) (*pb.Role, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.ArchiveRole(ctx, roleId, version)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, handlers.NotFoundErrorf("Role %q doesn't exist.", roleId)
+		}
+		if errors.Is(err, db.ErrInvalidParameter) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Unable to archive role: %v.", err)
+		}
+		return nil, fmt.Errorf("unable to archive role: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Role %q doesn't exist or incorrect version provided.", roleId)
+	}
+	return lookupUpdated(ctx, repo, out)
+}
+
+func (s Service) restoreInRepo(ctx context.Context, roleId string, version uint32, expiration *timestamppb.Timestamp) (*pb.Role, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	var opts []iam.Option
+	if expiration != nil {
+		opts = append(opts, iam.WithExpirationTime(expiration.AsTime()))
+	}
+	out, rowsUpdated, err := repo.RestoreRole(ctx, roleId, version, opts...)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, handlers.NotFoundErrorf("Role %q doesn't exist.", roleId)
+		}
+		if errors.Is(err, db.ErrInvalidParameter) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Unable to restore role: %v.", err)
+		}
+		return nil, fmt.Errorf("unable to restore role: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Role %q doesn't exist or incorrect version provided.", roleId)
+	}
+	return lookupUpdated(ctx, repo, out)
+}
+
+// lookupUpdated returns the role after an update which doesn't return its
+// principals and grants, along with them.
+func lookupUpdated(ctx context.Context, repo *iam.Repository, updated *iam.Role) (*pb.Role, error) {
+	out, pr, roleGrants, err := repo.LookupRole(ctx, updated.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		return nil, handlers.NotFoundErrorf("Role %q doesn't exist.", updated.GetPublicId())
+	}
+	return toProto(out, pr, roleGrants), nil
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
 	res := auth.VerifyResults{}
 	repo, err := s.repoFn()
@@ -564,6 +873,48 @@ func toProto(in *iam.Role, principals []iam.PrincipalRole, grants []*iam.RoleGra
 	if in.GetGrantScopeId() != "" {
 		out.GrantScopeId = &wrapperspb.StringValue{Value: in.GetGrantScopeId()}
 	}
+	if in.GetExpirationTime() != nil {
+		out.ExpirationTime = in.GetExpirationTime().GetTimestamp()
+	}
+	if in.GetArchiveTime() != nil {
+		out.ArchivedTime = in.GetArchiveTime().GetTimestamp()
+	}
+	return &out
+}
+
+func toEmergencyActivationProto(in *iam.EmergencyActivation) *pb.EmergencyActivation {
+	out := pb.EmergencyActivation{
+		RoleId:         in.RoleId,
+		UserId:         in.UserId,
+		Justification:  in.Justification,
+		ActivateTime:   timestamppb.New(in.ActivateTime),
+		ExpirationTime: timestamppb.New(in.ExpirationTime),
+	}
+	if in.RevertTime != nil {
+		out.RevertTime = timestamppb.New(*in.RevertTime)
+	}
+	return &out
+}
+
+func toRoleDiffProto(in *iam.RoleDiff) *pb.RoleDiff {
+	out := pb.RoleDiff{
+		RoleId:            in.RoleId,
+		FromRoleId:        in.FromRoleId,
+		GrantsAdded:       in.GrantsAdded,
+		GrantsRemoved:     in.GrantsRemoved,
+		PrincipalsAdded:   in.PrincipalsAdded,
+		PrincipalsRemoved: in.PrincipalsRemoved,
+		Summary:           in.Summary(),
+	}
+	if in.FromTime != nil {
+		out.FromTime = timestamppb.New(*in.FromTime)
+	}
+	if in.ToTime != nil {
+		out.ToTime = timestamppb.New(*in.ToTime)
+	}
+	for _, f := range in.Fields {
+		out.Fields = append(out.Fields, &pb.RoleFieldDiff{Field: f.Field, Before: f.Before, After: f.After})
+	}
 	return &out
 }
 
@@ -596,6 +947,12 @@ func validateCreateRequest(req *pbs.CreateRoleRequest) error {
 		if item.GetGrants() != nil {
 			badFields["grant_strings"] = "This is a read only field."
 		}
+		if item.GetExpirationTime() != nil && !item.GetExpirationTime().AsTime().After(time.Now()) {
+			badFields["expiration_time"] = "Must be in the future."
+		}
+		if item.GetArchivedTime() != nil {
+			badFields["archived_time"] = "This is a read only field."
+		}
 		return badFields
 	})
 }
@@ -620,6 +977,12 @@ func validateUpdateRequest(req *pbs.UpdateRoleRequest) error {
 				badFields["grant_scope_id"] = "When the role is in a project scope this value must be that project's scope ID"
 			}
 		}
+		if req.GetItem().GetExpirationTime() != nil && !req.GetItem().GetExpirationTime().AsTime().After(time.Now()) {
+			badFields["expiration_time"] = "Must be in the future."
+		}
+		if req.GetItem().GetArchivedTime() != nil {
+			badFields["archived_time"] = "This is a read only field and cannot be specified in an update request."
+		}
 		return badFields
 	})
 }
@@ -733,7 +1096,7 @@ func validateAddRoleGrantsRequest(req *pbs.AddRoleGrantsRequest) error {
 			badFields["grant_strings"] = "Grant strings must not be empty."
 			break
 		}
-		if _, err := perms.Parse("p_anything", v); err != nil {
+		if _, err := perms.Parse("o_anything", v); err != nil {
 			badFields["grant_strings"] = fmt.Sprintf("Improperly formatted grant %q.", v)
 			break
 		}
@@ -757,7 +1120,7 @@ func validateSetRoleGrantsRequest(req *pbs.SetRoleGrantsRequest) error {
 			badFields["grant_strings"] = "Grant strings must not be empty."
 			break
 		}
-		if _, err := perms.Parse("p_anything", v); err != nil {
+		if _, err := perms.Parse("o_anything", v); err != nil {
 			badFields["grant_strings"] = fmt.Sprintf("Improperly formatted grant %q.", v)
 			break
 		}
@@ -784,7 +1147,7 @@ func validateRemoveRoleGrantsRequest(req *pbs.RemoveRoleGrantsRequest) error {
 			badFields["grant_strings"] = "Grant strings must not be empty."
 			break
 		}
-		if _, err := perms.Parse("p_anything", v); err != nil {
+		if _, err := perms.Parse("o_anything", v); err != nil {
 			badFields["grant_strings"] = fmt.Sprintf("Improperly formatted grant %q.", v)
 			break
 		}
@@ -794,3 +1157,86 @@ func validateRemoveRoleGrantsRequest(req *pbs.RemoveRoleGrantsRequest) error {
 	}
 	return nil
 }
+
+func validateActivateEmergencyRoleRequest(req *pbs.ActivateEmergencyRoleRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(iam.RolePrefix, req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if strings.TrimSpace(req.GetJustification()) == "" {
+		badFields["justification"] = "Required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRevertEmergencyRoleRequest(req *pbs.RevertEmergencyRoleRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(iam.RolePrefix, req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateDiffRoleRequest(req *pbs.DiffRoleRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(iam.RolePrefix, req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	switch {
+	case req.GetFromRoleId() != "":
+		if !handlers.ValidId(iam.RolePrefix, req.GetFromRoleId()) {
+			badFields["from_role_id"] = "Incorrectly formatted identifier."
+		}
+		if req.GetFromTime() != nil {
+			badFields["from_time"] = "Can't be set with from_role_id."
+		}
+		if req.GetToTime() != nil {
+			badFields["to_time"] = "Can't be set with from_role_id."
+		}
+	case req.GetFromTime() == nil:
+		badFields["from_role_id"] = "Either from_role_id or from_time is required."
+	case req.GetToTime() != nil && req.GetToTime().AsTime().Before(req.GetFromTime().AsTime()):
+		badFields["to_time"] = "Can't be before from_time."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateArchiveRoleRequest(req *pbs.ArchiveRoleRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(iam.RolePrefix, req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRestoreRoleRequest(req *pbs.RestoreRoleRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(iam.RolePrefix, req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if req.GetExpirationTime() != nil && !req.GetExpirationTime().AsTime().After(time.Now()) {
+		badFields["expiration_time"] = "Must be in the future."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
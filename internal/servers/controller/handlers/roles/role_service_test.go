@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
@@ -250,6 +251,45 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_Paginated(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap, iam.WithMaxPageSize(2))
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	o, _ := iam.TestScopes(t, iamRepo, iam.WithSkipDefaultRoleCreation(true))
+	var want []string
+	for i := 0; i < 5; i++ {
+		want = append(want, iam.TestRole(t, conn, o.GetPublicId()).GetPublicId())
+	}
+	s, err := roles.NewService(repoFn)
+	require.NoError(err, "Couldn't create new role service.")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId()))
+
+	// Pages are no bigger than the max page size, even if a bigger one is
+	// requested.
+	var got []string
+	req := &pbs.ListRolesRequest{ScopeId: o.GetPublicId(), PageSize: 10}
+	for {
+		res, err := s.ListRoles(ctx, req)
+		require.NoError(err)
+		assert.LessOrEqual(len(res.GetItems()), 2)
+		for _, r := range res.GetItems() {
+			got = append(got, r.GetId())
+		}
+		if res.GetNextPageToken() == "" {
+			break
+		}
+		req = &pbs.ListRolesRequest{ScopeId: o.GetPublicId(), PageToken: res.GetNextPageToken()}
+	}
+	assert.ElementsMatch(want, got)
+
+	_, err = s.ListRoles(ctx, &pbs.ListRolesRequest{ScopeId: o.GetPublicId(), PageToken: "garbage"})
+	assert.True(errors.Is(err, db.ErrInvalidPageToken))
+}
+
 func TestDelete(t *testing.T) {
 	or, pr, repo := createDefaultRolesAndRepo(t)
 
@@ -1821,3 +1861,275 @@ func TestRemoveGrants(t *testing.T) {
 		})
 	}
 }
+
+func TestEmergencyRole(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	var events []*iam.EmergencyRoleEvent
+	iamRepo := iam.TestRepo(t, conn, wrap, iam.WithEmergencyRoleEvents(func(e *iam.EmergencyRoleEvent) { events = append(events, e) }))
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := roles.NewService(repoFn)
+	require.NoError(err, "Error when getting new role service.")
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	user := iam.TestUser(t, iamRepo, o.GetPublicId())
+	other := iam.TestUser(t, iamRepo, o.GetPublicId())
+	role := iam.TestRole(t, conn, o.GetPublicId())
+	_ = iam.TestUserRole(t, conn, role.GetPublicId(), user.GetPublicId())
+	require.NoError(iamRepo.SetEmergencyRole(context.Background(), role.GetPublicId(), time.Hour))
+
+	ctxFor := func(userId string) context.Context {
+		return auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId()), auth.WithUserId(userId))
+	}
+
+	failCases := []struct {
+		name   string
+		userId string
+		req    *pbs.ActivateEmergencyRoleRequest
+		err    error
+	}{
+		{
+			name:   "Bad Role Id",
+			userId: user.GetPublicId(),
+			req:    &pbs.ActivateEmergencyRoleRequest{Id: "bad id", Justification: "outage"},
+			err:    handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:   "No Justification",
+			userId: user.GetPublicId(),
+			req:    &pbs.ActivateEmergencyRoleRequest{Id: role.GetPublicId(), Justification: " "},
+			err:    handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:   "Too Long",
+			userId: user.GetPublicId(),
+			req:    &pbs.ActivateEmergencyRoleRequest{Id: role.GetPublicId(), Justification: "outage", DurationSeconds: 7200},
+			err:    handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:   "Not A Principal",
+			userId: other.GetPublicId(),
+			req:    &pbs.ActivateEmergencyRoleRequest{Id: role.GetPublicId(), Justification: "outage"},
+			err:    handlers.ApiErrorWithCode(codes.PermissionDenied),
+		},
+		{
+			name:   "Anonymous",
+			userId: "u_anon",
+			req:    &pbs.ActivateEmergencyRoleRequest{Id: role.GetPublicId(), Justification: "outage"},
+			err:    handlers.UnauthenticatedError(),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, gErr := s.ActivateEmergencyRole(ctxFor(tc.userId), tc.req)
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, tc.err), "ActivateEmergencyRole(%+v) got error %#v, wanted %#v", tc.req, gErr, tc.err)
+		})
+	}
+	assert.Empty(events)
+
+	// The activating user is the user of the request, who is paged to
+	// security through the repository's events.
+	got, err := s.ActivateEmergencyRole(ctxFor(user.GetPublicId()), &pbs.ActivateEmergencyRoleRequest{Id: role.GetPublicId(), Justification: "INC-1234 outage", DurationSeconds: 600})
+	require.NoError(err)
+	assert.Equal(role.GetPublicId(), got.GetItem().GetRoleId())
+	assert.Equal(user.GetPublicId(), got.GetItem().GetUserId())
+	assert.Equal("INC-1234 outage", got.GetItem().GetJustification())
+	assert.Nil(got.GetItem().GetRevertTime())
+	require.Len(events, 1)
+	assert.Equal(iam.EmergencyRoleActivated, events[0].Type)
+	assert.Equal(user.GetPublicId(), events[0].Activation.UserId)
+
+	_, err = s.ActivateEmergencyRole(ctxFor(user.GetPublicId()), &pbs.ActivateEmergencyRoleRequest{Id: role.GetPublicId(), Justification: "again"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.FailedPrecondition)))
+
+	reverted, err := s.RevertEmergencyRole(ctxFor(other.GetPublicId()), &pbs.RevertEmergencyRoleRequest{Id: role.GetPublicId()})
+	require.NoError(err)
+	assert.NotNil(reverted.GetItem().GetRevertTime())
+	require.Len(events, 2)
+	assert.Equal(iam.EmergencyRoleReverted, events[1].Type)
+	assert.Equal(other.GetPublicId(), events[1].RequestUserId)
+
+	_, err = s.RevertEmergencyRole(ctxFor(other.GetPublicId()), &pbs.RevertEmergencyRoleRequest{Id: role.GetPublicId()})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.FailedPrecondition)))
+}
+
+func TestDiffRole(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := roles.NewService(repoFn)
+	require.NoError(err, "Error when getting new role service.")
+	ctx := context.Background()
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	from := iam.TestRole(t, conn, o.GetPublicId(), iam.WithName("readers"))
+	iam.TestRoleGrant(t, conn, from.GetPublicId(), "id=*;type=*;actions=read")
+	to := iam.TestRole(t, conn, o.GetPublicId(), iam.WithName("writers"))
+	iam.TestRoleGrant(t, conn, to.GetPublicId(), "id=*;type=*;actions=update")
+
+	role, err := iam.NewRole(o.GetPublicId(), iam.WithName("before"))
+	require.NoError(err)
+	role, err = iamRepo.CreateRole(ctx, role)
+	require.NoError(err)
+	created := time.Now()
+	_, err = iamRepo.AddRoleGrants(ctx, role.GetPublicId(), role.GetVersion(), []string{"id=*;type=*;actions=read"})
+	require.NoError(err)
+
+	diffCtx := auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId()))
+	earlier, err := ptypes.TimestampProto(created.Add(-time.Hour))
+	require.NoError(err)
+	later, err := ptypes.TimestampProto(created.Add(time.Hour))
+	require.NoError(err)
+
+	failCases := []struct {
+		name string
+		req  *pbs.DiffRoleRequest
+		err  error
+	}{
+		{
+			name: "Bad Role Id",
+			req:  &pbs.DiffRoleRequest{Id: "bad id", FromRoleId: from.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad From Role Id",
+			req:  &pbs.DiffRoleRequest{Id: to.GetPublicId(), FromRoleId: "bad id"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Nothing To Compare",
+			req:  &pbs.DiffRoleRequest{Id: to.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Role And Time",
+			req:  &pbs.DiffRoleRequest{Id: to.GetPublicId(), FromRoleId: from.GetPublicId(), FromTime: earlier},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "To Before From",
+			req:  &pbs.DiffRoleRequest{Id: role.GetPublicId(), FromTime: later, ToTime: earlier},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Missing From Role",
+			req:  &pbs.DiffRoleRequest{Id: to.GetPublicId(), FromRoleId: iam.RolePrefix + "_doesntexis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, gErr := s.DiffRole(diffCtx, tc.req)
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, tc.err), "DiffRole(%+v) got error %#v, wanted %#v", tc.req, gErr, tc.err)
+		})
+	}
+
+	got, err := s.DiffRole(diffCtx, &pbs.DiffRoleRequest{Id: to.GetPublicId(), FromRoleId: from.GetPublicId()})
+	require.NoError(err)
+	assert.Empty(cmp.Diff(&pb.RoleDiff{
+		RoleId:        to.GetPublicId(),
+		FromRoleId:    from.GetPublicId(),
+		Fields:        []*pb.RoleFieldDiff{{Field: "name", Before: "readers", After: "writers"}},
+		GrantsAdded:   []string{"id=*;type=*;actions=update"},
+		GrantsRemoved: []string{"id=*;type=*;actions=read"},
+		Summary: []string{
+			`name changed from "readers" to "writers"`,
+			`grant "id=*;type=*;actions=update" added`,
+			`grant "id=*;type=*;actions=read" removed`,
+		},
+	}, got.GetItem(), protocmp.Transform()))
+
+	// Over time, to defaults to now
+	got, err = s.DiffRole(diffCtx, &pbs.DiffRoleRequest{Id: role.GetPublicId(), FromTime: earlier})
+	require.NoError(err)
+	assert.Equal(role.GetPublicId(), got.GetItem().GetRoleId())
+	assert.NotNil(got.GetItem().GetFromTime())
+	assert.NotNil(got.GetItem().GetToTime())
+	assert.Equal([]string{"id=*;type=*;actions=read"}, got.GetItem().GetGrantsAdded())
+}
+
+func TestArchiveRestoreRole(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := roles.NewService(repoFn)
+	require.NoError(err, "Error when getting new role service.")
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId()))
+
+	expiration, err := ptypes.TimestampProto(time.Now().Add(time.Hour).Truncate(time.Microsecond))
+	require.NoError(err)
+	created, err := s.CreateRole(ctx, &pbs.CreateRoleRequest{Item: &pb.Role{
+		ScopeId:        o.GetPublicId(),
+		ExpirationTime: expiration,
+	}})
+	require.NoError(err)
+	role := created.GetItem()
+	assert.Empty(cmp.Diff(expiration, role.GetExpirationTime(), protocmp.Transform()))
+	assert.Nil(role.GetArchivedTime())
+
+	past, err := ptypes.TimestampProto(time.Now().Add(-time.Hour))
+	require.NoError(err)
+	_, err = s.CreateRole(ctx, &pbs.CreateRoleRequest{Item: &pb.Role{ScopeId: o.GetPublicId(), ExpirationTime: past}})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %v, wanted invalid argument", err)
+	_, err = s.ArchiveRole(ctx, &pbs.ArchiveRoleRequest{Id: role.GetId()})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %v, wanted invalid argument", err)
+
+	archived, err := s.ArchiveRole(ctx, &pbs.ArchiveRoleRequest{Id: role.GetId(), Version: role.GetVersion()})
+	require.NoError(err)
+	require.NotNil(archived.GetItem().GetArchivedTime())
+	assert.Empty(cmp.Diff(archived.GetItem().GetArchivedTime(), archived.GetItem().GetExpirationTime(), protocmp.Transform()))
+	_, err = s.ArchiveRole(ctx, &pbs.ArchiveRoleRequest{Id: role.GetId(), Version: archived.GetItem().GetVersion()})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.FailedPrecondition)), "Got %v, wanted failed precondition", err)
+
+	// Archived roles are only listed when asked for
+	listed, err := s.ListRoles(ctx, &pbs.ListRolesRequest{ScopeId: o.GetPublicId()})
+	require.NoError(err)
+	for _, r := range listed.GetItems() {
+		assert.NotEqual(role.GetId(), r.GetId())
+	}
+	listed, err = s.ListRoles(ctx, &pbs.ListRolesRequest{ScopeId: o.GetPublicId(), IncludeArchived: true})
+	require.NoError(err)
+	var found bool
+	for _, r := range listed.GetItems() {
+		found = found || r.GetId() == role.GetId()
+	}
+	assert.True(found)
+
+	_, err = s.UpdateRole(ctx, &pbs.UpdateRoleRequest{
+		Id:         role.GetId(),
+		Item:       &pb.Role{Name: &wrapperspb.StringValue{Value: "renamed"}, Version: archived.GetItem().GetVersion()},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+	})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.FailedPrecondition)), "Got %v, wanted failed precondition", err)
+
+	restored, err := s.RestoreRole(ctx, &pbs.RestoreRoleRequest{Id: role.GetId(), Version: archived.GetItem().GetVersion(), ExpirationTime: expiration})
+	require.NoError(err)
+	assert.Nil(restored.GetItem().GetArchivedTime())
+	assert.Empty(cmp.Diff(expiration, restored.GetItem().GetExpirationTime(), protocmp.Transform()))
+	_, err = s.RestoreRole(ctx, &pbs.RestoreRoleRequest{Id: role.GetId(), Version: restored.GetItem().GetVersion()})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.FailedPrecondition)), "Got %v, wanted failed precondition", err)
+
+	// The expiration can be cleared by an update
+	updated, err := s.UpdateRole(ctx, &pbs.UpdateRoleRequest{
+		Id:         role.GetId(),
+		Item:       &pb.Role{Version: restored.GetItem().GetVersion()},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"expiration_time"}},
+	})
+	require.NoError(err)
+	assert.Nil(updated.GetItem().GetExpirationTime())
+}
@@ -2,16 +2,19 @@ package scopes
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -59,7 +62,18 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	pl, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	// A listing requested a page at a time is bounded by the max page size,
+	// and one that isn't fails if it has more results than that, unless the
+	// principal can list them unpaged.
+	var listOpts []iam.Option
+	var next string
+	switch {
+	case req.GetPageToken() != "" || req.GetPageSize() > 0:
+		listOpts = append(listOpts, iam.WithLimit(int(req.GetPageSize())), iam.WithPageToken(req.GetPageToken()), iam.WithNextPageToken(&next))
+	case authResults.ListUnpaged(ctx, resource.Scope):
+		listOpts = append(listOpts, iam.WithLimit(-1))
+	}
+	pl, err := s.listFromRepo(ctx, authResults.Scope.GetId(), listOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +82,7 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 		item.Scope = authResults.Scope
 	}
 
-	return &pbs.ListScopesResponse{Items: pl}, nil
+	return &pbs.ListScopesResponse{Items: pl, NextPageToken: next}, nil
 }
 
 // GetScopes implements the interface pbs.ScopeServiceServer.
@@ -97,6 +111,7 @@ func (s Service) CreateScope(ctx context.Context, req *pbs.CreateScopeRequest) (
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	p, err := s.createInRepo(ctx, authResults, req)
 	if err != nil {
 		return nil, err
@@ -114,6 +129,7 @@ func (s Service) UpdateScope(ctx context.Context, req *pbs.UpdateScopeRequest) (
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	p, err := s.updateInRepo(ctx, authResults.Scope, req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -131,6 +147,7 @@ func (s Service) DeleteScope(ctx context.Context, req *pbs.DeleteScopeRequest) (
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	_, err := s.deleteFromRepo(ctx, req.GetId())
 	if err != nil {
 		return nil, err
@@ -138,6 +155,55 @@ func (s Service) DeleteScope(ctx context.Context, req *pbs.DeleteScopeRequest) (
 	return &pbs.DeleteScopeResponse{}, nil
 }
 
+// ListBannedGrantPatterns implements the interface pbs.ScopeServiceServer.
+func (s Service) ListBannedGrantPatterns(ctx context.Context, req *pbs.ListBannedGrantPatternsRequest) (*pbs.ListBannedGrantPatternsResponse, error) {
+	if err := validateListBannedGrantPatternsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListBannedGrantPatterns)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	pl, err := s.listBannedGrantPatternsFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListBannedGrantPatternsResponse{Items: pl}, nil
+}
+
+// AddBannedGrantPattern implements the interface pbs.ScopeServiceServer.
+func (s Service) AddBannedGrantPattern(ctx context.Context, req *pbs.AddBannedGrantPatternRequest) (*pbs.AddBannedGrantPatternResponse, error) {
+	if err := validateAddBannedGrantPatternRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddBannedGrantPattern)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
+	p, err := s.addBannedGrantPatternInRepo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.AddBannedGrantPatternResponse{Item: p}, nil
+}
+
+// RemoveBannedGrantPattern implements the interface pbs.ScopeServiceServer.
+func (s Service) RemoveBannedGrantPattern(ctx context.Context, req *pbs.RemoveBannedGrantPatternRequest) (*pbs.RemoveBannedGrantPatternResponse, error) {
+	if err := validateRemoveBannedGrantPatternRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveBannedGrantPattern)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
+	if err := s.removeBannedGrantPatternFromRepo(ctx, req.GetId(), req.GetName()); err != nil {
+		return nil, err
+	}
+	return &pbs.RemoveBannedGrantPatternResponse{}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -243,6 +309,59 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId string) (bool, erro
 	return rows > 0, nil
 }
 
+func (s Service) listBannedGrantPatternsFromRepo(ctx context.Context, scopeId string) ([]*pb.BannedGrantPattern, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	patterns, err := repo.ListBannedGrantPatterns(ctx, scopeId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list banned grant patterns: %w", err)
+	}
+	var outPl []*pb.BannedGrantPattern
+	for _, p := range patterns {
+		outPl = append(outPl, toBannedGrantPatternProto(p))
+	}
+	return outPl, nil
+}
+
+func (s Service) addBannedGrantPatternInRepo(ctx context.Context, req *pbs.AddBannedGrantPatternRequest) (*pb.BannedGrantPattern, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	p, err := iam.NewBannedGrantPattern(req.GetId(), req.GetName(), req.GetPattern(), iam.WithDescription(req.GetDescription()))
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Unable to add banned grant pattern: %v.", err)
+	}
+	out, err := repo.CreateBannedGrantPattern(ctx, p)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrNotUnique):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.AlreadyExists, "Unable to add banned grant pattern: %v.", err)
+		case errors.Is(err, db.ErrInvalidParameter):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Unable to add banned grant pattern: %v.", err)
+		}
+		return nil, fmt.Errorf("unable to add banned grant pattern: %w", err)
+	}
+	return toBannedGrantPatternProto(out), nil
+}
+
+func (s Service) removeBannedGrantPatternFromRepo(ctx context.Context, scopeId, name string) error {
+	repo, err := s.repoFn()
+	if err != nil {
+		return err
+	}
+	rows, err := repo.DeleteBannedGrantPattern(ctx, scopeId, name)
+	if err != nil {
+		return fmt.Errorf("unable to remove banned grant pattern: %w", err)
+	}
+	if rows == 0 {
+		return handlers.NotFoundErrorf("Banned grant pattern %q doesn't exist in scope %q.", name, scopeId)
+	}
+	return nil
+}
+
 func SortScopes(scps []*pb.Scope) {
 	// We stable sort here even though the database may not return things in
 	// sorted order, still nice to have them as consistent as possible.
@@ -251,7 +370,7 @@ func SortScopes(scps []*pb.Scope) {
 	})
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Scope, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId string, opt ...iam.Option) ([]*pb.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -260,13 +379,16 @@ func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Scope,
 	var scps []*iam.Scope
 	switch {
 	case scopeId == "global":
-		scps, err = repo.ListOrgs(ctx)
+		scps, err = repo.ListOrgs(ctx, opt...)
 	case strings.HasPrefix(scopeId, scope.Org.Prefix()):
-		scps, err = repo.ListProjects(ctx, scopeId)
+		scps, err = repo.ListProjects(ctx, scopeId, opt...)
 	default:
 		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
 			map[string]string{"scope_id": "This field must be 'global' or a valid org scope id."})
 	}
+	if errors.Is(err, db.ErrPageRequired) || errors.Is(err, db.ErrInvalidPageToken) {
+		return nil, err
+	}
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to list scopes: %v", err)
 	}
@@ -336,6 +458,16 @@ func ToProto(in *iam.Scope) *pb.Scope {
 	return &out
 }
 
+func toBannedGrantPatternProto(in *iam.BannedGrantPattern) *pb.BannedGrantPattern {
+	return &pb.BannedGrantPattern{
+		ScopeId:     in.GetScopeId(),
+		Name:        in.GetName(),
+		Pattern:     in.GetPattern(),
+		Description: in.GetDescription(),
+		CreatedTime: in.GetCreateTime().GetTimestamp(),
+	}
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //  * The path passed in is correctly formatted
@@ -486,3 +618,61 @@ func validateListRequest(req *pbs.ListScopesRequest) error {
 	}
 	return nil
 }
+
+// validScopeId reports whether the id is global or a well formatted org or
+// project scope id.
+func validScopeId(id string) bool {
+	switch {
+	case id == scope.Global.String():
+		return true
+	case strings.HasPrefix(id, scope.Org.Prefix()):
+		return handlers.ValidId(scope.Org.Prefix(), id)
+	case strings.HasPrefix(id, scope.Project.Prefix()):
+		return handlers.ValidId(scope.Project.Prefix(), id)
+	}
+	return false
+}
+
+func validateListBannedGrantPatternsRequest(req *pbs.ListBannedGrantPatternsRequest) error {
+	badFields := map[string]string{}
+	if !validScopeId(req.GetId()) {
+		badFields["id"] = "Invalidly formatted scope id."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateAddBannedGrantPatternRequest(req *pbs.AddBannedGrantPatternRequest) error {
+	badFields := map[string]string{}
+	if !validScopeId(req.GetId()) {
+		badFields["id"] = "Invalidly formatted scope id."
+	}
+	if strings.TrimSpace(req.GetName()) == "" {
+		badFields["name"] = "Must be set."
+	}
+	if req.GetPattern() == "" {
+		badFields["pattern"] = "Must be set."
+	} else if _, err := perms.ParseGrantPattern(req.GetName(), req.GetPattern()); err != nil {
+		badFields["pattern"] = fmt.Sprintf("Unable to parse pattern: %v.", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveBannedGrantPatternRequest(req *pbs.RemoveBannedGrantPatternRequest) error {
+	badFields := map[string]string{}
+	if !validScopeId(req.GetId()) {
+		badFields["id"] = "Invalidly formatted scope id."
+	}
+	if req.GetName() == "" {
+		badFields["name"] = "Must be set."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
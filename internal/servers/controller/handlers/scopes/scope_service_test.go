@@ -395,6 +395,49 @@ func TestDelete_twice(t *testing.T) {
 	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.NotFound)), "Expected not found for the second delete.")
 }
 
+func TestBannedGrantPatterns(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	org, _, repo := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(repo)
+	require.NoError(err, "Error when getting new scopes service")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(scope.Global.String()))
+
+	got, err := s.AddBannedGrantPattern(ctx, &pbs.AddBannedGrantPatternRequest{
+		Id:          scope.Global.String(),
+		Name:        "no-wildcards",
+		Pattern:     "id=*;actions=*",
+		Description: "Wildcard grants are not allowed",
+	})
+	require.NoError(err)
+	assert.Equal(scope.Global.String(), got.GetItem().GetScopeId())
+	assert.Equal("id=*;actions=*", got.GetItem().GetPattern())
+	assert.NotNil(got.GetItem().GetCreatedTime())
+
+	_, err = s.AddBannedGrantPattern(ctx, &pbs.AddBannedGrantPatternRequest{Id: scope.Global.String(), Name: "no-wildcards", Pattern: "id=*"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.AlreadyExists)), "Got %v, wanted already exists", err)
+	_, err = s.AddBannedGrantPattern(ctx, &pbs.AddBannedGrantPatternRequest{Id: scope.Global.String(), Name: "bad", Pattern: "nope"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %v, wanted invalid argument", err)
+	_, err = s.AddBannedGrantPattern(ctx, &pbs.AddBannedGrantPatternRequest{Id: "o_1234", Name: "bad", Pattern: "id=*"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %v, wanted invalid argument", err)
+
+	// Patterns are listed in the scope they were added in
+	list, err := s.ListBannedGrantPatterns(ctx, &pbs.ListBannedGrantPatternsRequest{Id: scope.Global.String()})
+	require.NoError(err)
+	require.Len(list.GetItems(), 1)
+	assert.Empty(cmp.Diff(got.GetItem(), list.GetItems()[0], protocmp.Transform()))
+	list, err = s.ListBannedGrantPatterns(ctx, &pbs.ListBannedGrantPatternsRequest{Id: org.GetPublicId()})
+	require.NoError(err)
+	assert.Empty(list.GetItems())
+
+	_, err = s.RemoveBannedGrantPattern(ctx, &pbs.RemoveBannedGrantPatternRequest{Id: scope.Global.String(), Name: "no-wildcards"})
+	require.NoError(err)
+	_, err = s.RemoveBannedGrantPattern(ctx, &pbs.RemoveBannedGrantPatternRequest{Id: scope.Global.String(), Name: "no-wildcards"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "Got %v, wanted not found", err)
+	_, err = s.RemoveBannedGrantPattern(ctx, &pbs.RemoveBannedGrantPatternRequest{Id: scope.Global.String()})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %v, wanted invalid argument", err)
+}
+
 func TestCreate(t *testing.T) {
 	ctx := context.Background()
 	defaultOrg, defaultProj, repoFn := createDefaultScopesAndRepo(t)
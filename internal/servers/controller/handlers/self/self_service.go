@@ -0,0 +1,157 @@
+package self
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/self"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Service handles request as described by the pbs.SelfServiceServer
+// interface. Its requests only need the user to be authenticated; no grants
+// are checked.
+type Service struct {
+	sessionRepoFn   common.SessionRepoFactory
+	authTokenRepoFn common.AuthTokenRepoFactory
+}
+
+// NewService returns a self service which handles the requests of users
+// about their own sessions and auth tokens.
+func NewService(sessionRepoFn common.SessionRepoFactory, authTokenRepoFn common.AuthTokenRepoFactory) (Service, error) {
+	if sessionRepoFn == nil {
+		return Service{}, fmt.Errorf("nil session repository provided")
+	}
+	if authTokenRepoFn == nil {
+		return Service{}, fmt.Errorf("nil auth token repository provided")
+	}
+	return Service{sessionRepoFn: sessionRepoFn, authTokenRepoFn: authTokenRepoFn}, nil
+}
+
+var _ pbs.SelfServiceServer = Service{}
+
+// ListSelfSessions implements the interface pbs.SelfServiceServer.
+func (s Service) ListSelfSessions(ctx context.Context, req *pbs.ListSelfSessionsRequest) (*pbs.ListSelfSessionsResponse, error) {
+	authResults := authResult(ctx)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.sessionRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	ss, err := repo.ListUserSessions(ctx, authResults.UserId)
+	if err != nil {
+		return nil, err
+	}
+	var items []*pb.UserSession
+	for _, us := range ss {
+		items = append(items, toSessionProto(us))
+	}
+	return &pbs.ListSelfSessionsResponse{Items: items}, nil
+}
+
+// ListSelfAuthTokens implements the interface pbs.SelfServiceServer.
+func (s Service) ListSelfAuthTokens(ctx context.Context, req *pbs.ListSelfAuthTokensRequest) (*pbs.ListSelfAuthTokensResponse, error) {
+	authResults := authResult(ctx)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.authTokenRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	ts, err := repo.ListUserAuthTokens(ctx, authResults.UserId)
+	if err != nil {
+		return nil, err
+	}
+	var items []*pb.UserAuthToken
+	for _, t := range ts {
+		items = append(items, toAuthTokenProto(t, authResults.AuthTokenId))
+	}
+	return &pbs.ListSelfAuthTokensResponse{Items: items}, nil
+}
+
+// RevokeSelfAuthToken implements the interface pbs.SelfServiceServer. The
+// auth tokens of other users are reported as not found, so their ids can't
+// be probed.
+func (s Service) RevokeSelfAuthToken(ctx context.Context, req *pbs.RevokeSelfAuthTokenRequest) (*pbs.RevokeSelfAuthTokenResponse, error) {
+	if err := validateRevokeRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := authResult(ctx)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.authTokenRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	at, err := repo.LookupAuthToken(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if at == nil || at.GetIamUserId() != authResults.UserId {
+		return nil, handlers.NotFoundErrorf("Auth token %q doesn't exist.", req.GetId())
+	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
+	if _, err := repo.DeleteAuthToken(ctx, req.GetId()); err != nil {
+		return nil, fmt.Errorf("unable to revoke auth token: %w", err)
+	}
+	return &pbs.RevokeSelfAuthTokenResponse{}, nil
+}
+
+// authResult only checks that the request is made by an authenticated
+// user.
+func authResult(ctx context.Context) auth.VerifyResults {
+	res := auth.Verify(ctx, auth.WithScopeId(scope.Global.String()), auth.WithSelfService(true))
+	if res.Error == nil && res.UserId == "" {
+		res.Error = handlers.UnauthenticatedError()
+	}
+	return res
+}
+
+func toSessionProto(in *session.UserSession) *pb.UserSession {
+	return &pb.UserSession{
+		Id:                in.SessionId,
+		ScopeId:           in.ScopeId,
+		TargetId:          in.TargetId,
+		AuthTokenId:       in.AuthTokenId,
+		Status:            in.Status.String(),
+		TerminationReason: in.TerminationReason,
+		CreatedTime:       timestamppb.New(in.CreateTime),
+		ClientAddresses:   in.ClientAddresses,
+	}
+}
+
+func toAuthTokenProto(in *authtoken.UserAuthToken, currentTokenId string) *pb.UserAuthToken {
+	return &pb.UserAuthToken{
+		Id:                      in.GetPublicId(),
+		ScopeId:                 in.GetScopeId(),
+		AuthMethodId:            in.GetAuthMethodId(),
+		SourceAddress:           in.SourceAddress,
+		CreatedTime:             in.GetCreateTime().GetTimestamp(),
+		ApproximateLastUsedTime: in.GetApproximateLastAccessTime().GetTimestamp(),
+		ExpirationTime:          in.GetExpirationTime().GetTimestamp(),
+		Current:                 in.GetPublicId() == currentTokenId,
+	}
+}
+
+func validateRevokeRequest(req *pbs.RevokeSelfAuthTokenRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(authtoken.AuthTokenPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package self_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/self"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestSelf(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	rw := db.New(conn)
+	sessRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	s, err := self.NewService(sessRepoFn, atRepoFn)
+	require.NoError(t, err, "Couldn't create new self service.")
+
+	params := session.TestSessionParams(t, conn, wrap, iamRepo)
+	sess := session.TestSession(t, conn, wrap, params)
+	_ = session.TestDefaultSession(t, conn, wrap, iamRepo)
+	o, _ := iam.TestScopes(t, iamRepo)
+	other := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId("global"), auth.WithUserId(params.UserId))
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := s.ListSelfSessions(auth.DisabledAuthTestContext(auth.WithScopeId("global")), &pbs.ListSelfSessionsRequest{})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.UnauthenticatedError()), "got error %v, wanted unauthenticated", err)
+	})
+	t.Run("sessions", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListSelfSessions(ctx, &pbs.ListSelfSessionsRequest{})
+		require.NoError(err)
+		require.Len(got.GetItems(), 1)
+		assert.Equal(sess.GetPublicId(), got.GetItems()[0].GetId())
+		assert.Equal(params.AuthTokenId, got.GetItems()[0].GetAuthTokenId())
+		assert.Equal(session.StatusPending.String(), got.GetItems()[0].GetStatus())
+	})
+	t.Run("auth tokens", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListSelfAuthTokens(ctx, &pbs.ListSelfAuthTokensRequest{})
+		require.NoError(err)
+		require.Len(got.GetItems(), 1)
+		assert.Equal(params.AuthTokenId, got.GetItems()[0].GetId())
+
+		_, err = s.RevokeSelfAuthToken(ctx, &pbs.RevokeSelfAuthTokenRequest{Id: other.GetPublicId()})
+		require.Error(err)
+		assert.True(errors.Is(err, handlers.NotFoundError()), "got error %v, wanted not found", err)
+
+		_, err = s.RevokeSelfAuthToken(ctx, &pbs.RevokeSelfAuthTokenRequest{Id: "bad_id"})
+		require.Error(err)
+		assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v, wanted invalid argument", err)
+
+		_, err = s.RevokeSelfAuthToken(ctx, &pbs.RevokeSelfAuthTokenRequest{Id: params.AuthTokenId})
+		require.NoError(err)
+		got, err = s.ListSelfAuthTokens(ctx, &pbs.ListSelfAuthTokensRequest{})
+		require.NoError(err)
+		assert.Empty(got.GetItems())
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/sessions"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/session"
@@ -82,6 +83,7 @@ func (s Service) CancelSession(ctx context.Context, req *pbs.CancelSessionReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	ses, err := s.cancelInRepo(ctx, req.GetId(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -197,11 +199,14 @@ func toProto(in *session.Session) *pb.Session {
 		Type:        target.SubtypeFromId(in.TargetId).String(),
 		// TODO: Provide the ServerType and the ServerId when that information becomes relevant in the API.
 
-		CreatedTime:    in.CreateTime.GetTimestamp(),
-		UpdatedTime:    in.UpdateTime.GetTimestamp(),
-		ExpirationTime: in.ExpirationTime.GetTimestamp(),
-		Certificate:    in.Certificate,
-		TerminationReason: in.TerminationReason,
+		CreatedTime:        in.CreateTime.GetTimestamp(),
+		UpdatedTime:        in.UpdateTime.GetTimestamp(),
+		ExpirationTime:     in.ExpirationTime.GetTimestamp(),
+		Certificate:        in.Certificate,
+		TerminationReason:  in.TerminationReason,
+		Justification:      in.Justification,
+		ChangeTicketSystem: in.ChangeTicketSystem,
+		ChangeTicket:       in.ChangeTicket,
 	}
 	if len(in.States) > 0 {
 		out.Status = in.States[0].Status.String()
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net/url"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/hashicorp/boundary/internal/auth"
@@ -15,6 +16,7 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
 	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
@@ -134,6 +136,7 @@ func (s Service) CreateTarget(ctx context.Context, req *pbs.CreateTargetRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.createInRepo(ctx, req.GetItem())
 	if err != nil {
 		return nil, err
@@ -151,6 +154,7 @@ func (s Service) UpdateTarget(ctx context.Context, req *pbs.UpdateTargetRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -168,6 +172,7 @@ func (s Service) DeleteTarget(ctx context.Context, req *pbs.DeleteTargetRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	_, err := s.deleteFromRepo(ctx, req.GetId())
 	if err != nil {
 		return nil, err
@@ -184,6 +189,7 @@ func (s Service) AddTargetHostSets(ctx context.Context, req *pbs.AddTargetHostSe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.addInRepo(ctx, req.GetId(), req.GetHostSetIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -201,6 +207,7 @@ func (s Service) SetTargetHostSets(ctx context.Context, req *pbs.SetTargetHostSe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.setInRepo(ctx, req.GetId(), req.GetHostSetIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -218,6 +225,7 @@ func (s Service) RemoveTargetHostSets(ctx context.Context, req *pbs.RemoveTarget
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.removeInRepo(ctx, req.GetId(), req.GetHostSetIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -234,6 +242,7 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	// This could happen if, say, u_recovery was used or u_anon was granted. But
 	// don't allow it. It's one thing if grants give access to resources within
 	// Boundary, even if those could eventually be used to provide an unintended
@@ -267,6 +276,77 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	if t == nil {
 		return nil, handlers.NotFoundErrorf("Target %q not found.", req.GetId())
 	}
+	// The banner is returned in the error's details so the CLI can show it
+	// before asking the user to acknowledge it. A banner is acknowledged by
+	// its hash, so one which changed after it was shown isn't acknowledged.
+	var acknowledgedBanner string
+	if t.GetBanner() != "" {
+		bannerHash := session.BannerHash(t.GetBanner())
+		switch req.GetBannerHash() {
+		case bannerHash:
+			acknowledgedBanner = t.GetBanner()
+		case "":
+			if t.GetBannerAcknowledgmentRequired() {
+				return nil, handlers.BannerAcknowledgmentRequiredErrorf(t.GetBanner(), bannerHash, "The target's banner must be acknowledged.")
+			}
+		default:
+			return nil, handlers.BannerAcknowledgmentRequiredErrorf(t.GetBanner(), bannerHash, "The target's banner has changed since it was acknowledged.")
+		}
+	}
+	if err := target.CheckJustification(t, req.GetJustification()); err != nil {
+		switch {
+		case errors.Is(err, target.ErrJustificationRequired):
+			return nil, handlers.InvalidArgumentErrorf("The target requires a justification.",
+				map[string]string{"justification": fmt.Sprintf("This field is required, and must match %q.", t.GetJustificationPattern())})
+		case errors.Is(err, target.ErrJustificationMismatch):
+			return nil, handlers.InvalidArgumentErrorf("The justification doesn't match the target's justification pattern.",
+				map[string]string{"justification": fmt.Sprintf("Must match %q.", t.GetJustificationPattern())})
+		case errors.Is(err, db.ErrInvalidParameter) && t.GetJustificationPattern() == "":
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"justification": fmt.Sprintf("Must be at most %d characters.", target.MaxJustificationLength)})
+		}
+		return nil, err
+	}
+	if err := target.CheckChangeTicket(ctx, t, authResults.UserId, req.GetChangeTicket()); err != nil {
+		switch {
+		case errors.Is(err, target.ErrChangeTicketRequired):
+			return nil, handlers.InvalidArgumentErrorf("The target requires a change ticket.",
+				map[string]string{"change_ticket": fmt.Sprintf("This field is required, and must be accepted by %q.", t.GetChangeTicketSystem())})
+		case errors.Is(err, target.ErrChangeTicketRejected):
+			return nil, handlers.InvalidArgumentErrorf("The change ticket was rejected by the target's change ticket system.",
+				map[string]string{"change_ticket": fmt.Sprintf("Must be accepted by %q.", t.GetChangeTicketSystem())})
+		case errors.Is(err, target.ErrChangeTicketSystemUnknown):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "The target's change ticket system %q is not configured on the controller.", t.GetChangeTicketSystem())
+		case errors.Is(err, db.ErrInvalidParameter):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"change_ticket": fmt.Sprintf("Must be at most %d characters.", target.MaxChangeTicketLength)})
+		}
+		return nil, err
+	}
+	// Resolve the session settings the target inherits from its scopes
+	policy, err := repo.EffectiveSessionPolicy(ctx, t.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	// If the target's scopes make its recording mandatory, its sessions are
+	// recorded even though its settings don't require it, unless the user
+	// holds an explicit exemption.
+	var exempt bool
+	if !policy.RecordingRequired {
+		mandatory, err := repo.RecordingMandatory(ctx, t.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		if mandatory {
+			exemptResults := authResults.AdditionalVerification(ctx,
+				auth.WithId(t.GetPublicId()),
+				auth.WithScopeId(t.GetScopeId()),
+				auth.WithType(resource.Target),
+				auth.WithAction(action.AuthorizeSessionUnrecorded))
+			exempt = exemptResults.Error == nil
+			policy.RecordingRequired = !exempt
+		}
+	}
 
 	// Instantiate some repos
 	sessionRepo, err := s.sessionRepoFn()
@@ -353,17 +433,23 @@ HostSetIterationLoop:
 	}
 
 	expTime := timestamppb.Now()
-	expTime.Seconds += int64(t.GetSessionMaxSeconds())
+	expTime.Seconds += int64(policy.SessionMaxSeconds)
 	sessionComposition := session.ComposedOf{
-		UserId:          authResults.UserId,
-		HostId:          chosenId.hostId,
-		TargetId:        t.GetPublicId(),
-		HostSetId:       chosenId.hostSetId,
-		AuthTokenId:     authResults.AuthTokenId,
-		ScopeId:         authResults.Scope.Id,
-		Endpoint:        endpointUrl.String(),
-		ExpirationTime:  &timestamp.Timestamp{Timestamp: expTime},
-		ConnectionLimit: t.GetSessionConnectionLimit(),
+		UserId:              authResults.UserId,
+		HostId:              chosenId.hostId,
+		TargetId:            t.GetPublicId(),
+		HostSetId:           chosenId.hostSetId,
+		AuthTokenId:         authResults.AuthTokenId,
+		ScopeId:             authResults.Scope.Id,
+		Endpoint:            endpointUrl.String(),
+		ExpirationTime:      &timestamp.Timestamp{Timestamp: expTime},
+		ConnectionLimit:     policy.SessionConnectionLimit,
+		ResumeWindowSeconds: policy.SessionResumeSeconds,
+		Justification:       strings.TrimSpace(req.GetJustification()),
+	}
+	if t.GetChangeTicketSystem() != "" {
+		sessionComposition.ChangeTicketSystem = t.GetChangeTicketSystem()
+		sessionComposition.ChangeTicket = strings.TrimSpace(req.GetChangeTicket())
 	}
 
 	sess, err := session.New(sessionComposition)
@@ -374,10 +460,58 @@ HostSetIterationLoop:
 	if err != nil {
 		return nil, err
 	}
-	sess, privKey, err := sessionRepo.CreateSession(ctx, wrapper, sess)
+	var sessOpts []session.Option
+	if acknowledgedBanner != "" {
+		// Record the acknowledgment with the session for compliance
+		sessOpts = append(sessOpts, session.WithBannerAcknowledgment(acknowledgedBanner))
+	}
+	// The client certificate is issued once for the session, and stored with
+	// it for the worker to present on every connection
+	clientCert, err := repo.IssueClientCertificate(ctx, t, authResults.UserId, expTime.AsTime())
 	if err != nil {
 		return nil, err
 	}
+	if clientCert != nil {
+		sessOpts = append(sessOpts, session.WithClientCertificate(&session.ClientCertificate{
+			Certificate:    clientCert.Certificate,
+			PrivateKey:     clientCert.PrivateKey,
+			ServerName:     clientCert.ServerName,
+			ServerCaCert:   clientCert.ServerCaCert,
+			ExpirationTime: &timestamp.Timestamp{Timestamp: timestamppb.New(clientCert.ExpirationTime)},
+		}))
+	}
+	sess, privKey, err := sessionRepo.CreateSession(ctx, wrapper, sess, sessOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if exempt {
+		// Log the use of the exemption as a security event
+		if err := repo.CreateRecordingExemption(ctx, &target.RecordingExemption{
+			SessionId:   sess.PublicId,
+			TargetId:    t.GetPublicId(),
+			TargetType:  t.GetType(),
+			ScopeId:     t.GetScopeId(),
+			UserId:      authResults.UserId,
+			AuthTokenId: authResults.AuthTokenId,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if sess.ChangeTicketSystem != "" {
+		target.RecordChangeTicketSession(ctx, &target.ChangeTicketSession{
+			ChangeTicketRequest: target.ChangeTicketRequest{
+				System:   sess.ChangeTicketSystem,
+				Ticket:   sess.ChangeTicket,
+				TargetId: t.GetPublicId(),
+				ScopeId:  t.GetScopeId(),
+				UserId:   authResults.UserId,
+			},
+			SessionId:      sess.PublicId,
+			HostId:         chosenId.hostId,
+			Endpoint:       sess.Endpoint,
+			ExpirationTime: sess.ExpirationTime.GetTimestamp().AsTime(),
+		})
+	}
 
 	var workers []*pb.WorkerInfo
 	servers, err := serversRepo.ListServers(ctx, servers.ServerTypeWorker)
@@ -398,7 +532,7 @@ HostSetIterationLoop:
 		PrivateKey:      privKey,
 		HostId:          chosenId.hostId,
 		WorkerInfo:      workers,
-		ConnectionLimit: t.GetSessionConnectionLimit(),
+		ConnectionLimit: policy.SessionConnectionLimit,
 	}
 	marshaledSad, err := proto.Marshal(sad)
 	if err != nil {
@@ -416,6 +550,7 @@ HostSetIterationLoop:
 		UserId:             authResults.UserId,
 		HostId:             chosenId.hostId,
 		HostSetId:          chosenId.hostSetId,
+		Banner:             t.GetBanner(),
 	}
 	return &pbs.AuthorizeSessionResponse{Item: ret}, nil
 }
@@ -456,6 +591,21 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (*pb.Target,
 	if tcpAttrs.GetDefaultPort().GetValue() != 0 {
 		opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
 	}
+	if tcpAttrs.GetBanner() != nil {
+		opts = append(opts, target.WithBanner(tcpAttrs.GetBanner().GetValue()))
+	}
+	if tcpAttrs.GetBannerAcknowledgmentRequired() != nil {
+		opts = append(opts, target.WithBannerAcknowledgmentRequired(tcpAttrs.GetBannerAcknowledgmentRequired().GetValue()))
+	}
+	if tcpAttrs.GetJustificationPattern() != nil {
+		opts = append(opts, target.WithJustificationPattern(tcpAttrs.GetJustificationPattern().GetValue()))
+	}
+	if tcpAttrs.GetChangeTicketSystem() != nil {
+		opts = append(opts, target.WithChangeTicketSystem(tcpAttrs.GetChangeTicketSystem().GetValue()))
+	}
+	if tcpAttrs.GetClientCertificateLibraryId() != nil {
+		opts = append(opts, target.WithClientCertificateLibraryId(tcpAttrs.GetClientCertificateLibraryId().GetValue()))
+	}
 	u, err := target.NewTcpTarget(item.GetScopeId(), opts...)
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
@@ -464,6 +614,9 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (*pb.Target,
 	if err != nil {
 		return nil, err
 	}
+	if err := checkClientCertificateLibrary(ctx, repo, item.GetScopeId(), u.ClientCertificateLibraryId); err != nil {
+		return nil, err
+	}
 	out, m, err := repo.CreateTcpTarget(ctx, u)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create target: %w", err)
@@ -495,6 +648,21 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if tcpAttrs.GetDefaultPort().GetValue() != 0 {
 		opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
 	}
+	if tcpAttrs.GetBanner() != nil {
+		opts = append(opts, target.WithBanner(tcpAttrs.GetBanner().GetValue()))
+	}
+	if tcpAttrs.GetBannerAcknowledgmentRequired() != nil {
+		opts = append(opts, target.WithBannerAcknowledgmentRequired(tcpAttrs.GetBannerAcknowledgmentRequired().GetValue()))
+	}
+	if tcpAttrs.GetJustificationPattern() != nil {
+		opts = append(opts, target.WithJustificationPattern(tcpAttrs.GetJustificationPattern().GetValue()))
+	}
+	if tcpAttrs.GetChangeTicketSystem() != nil {
+		opts = append(opts, target.WithChangeTicketSystem(tcpAttrs.GetChangeTicketSystem().GetValue()))
+	}
+	if tcpAttrs.GetClientCertificateLibraryId() != nil {
+		opts = append(opts, target.WithClientCertificateLibraryId(tcpAttrs.GetClientCertificateLibraryId().GetValue()))
+	}
 	version := item.GetVersion()
 	u, err := target.NewTcpTarget(scopeId, opts...)
 	if err != nil {
@@ -509,6 +677,9 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if err != nil {
 		return nil, err
 	}
+	if err := checkClientCertificateLibrary(ctx, repo, scopeId, u.ClientCertificateLibraryId); err != nil {
+		return nil, err
+	}
 	out, m, rowsUpdated, err := repo.UpdateTcpTarget(ctx, u, version, dbMask)
 	if err != nil {
 		return nil, fmt.Errorf("unable to update target: %w", err)
@@ -519,6 +690,23 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	return toProto(out, m)
 }
 
+// checkClientCertificateLibrary returns an invalid argument error if the
+// client certificate library set on a target isn't in the target's project.
+func checkClientCertificateLibrary(ctx context.Context, repo *target.Repository, scopeId, libraryId string) error {
+	if libraryId == "" {
+		return nil
+	}
+	l, err := repo.LookupClientCertificateLibrary(ctx, libraryId)
+	if err != nil {
+		return err
+	}
+	if l == nil || l.ScopeId != scopeId {
+		return handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes.client_certificate_library_id": "This must be the id of a client certificate library in the target's project."})
+	}
+	return nil
+}
+
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -657,14 +845,19 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 
 func toProto(in target.Target, m []*target.TargetSet) (*pb.Target, error) {
 	out := pb.Target{
-		Id:                     in.GetPublicId(),
-		ScopeId:                in.GetScopeId(),
-		CreatedTime:            in.GetCreateTime().GetTimestamp(),
-		UpdatedTime:            in.GetUpdateTime().GetTimestamp(),
-		Version:                in.GetVersion(),
-		Type:                   target.TcpTargetType.String(),
-		SessionMaxSeconds:      wrapperspb.UInt32(in.GetSessionMaxSeconds()),
-		SessionConnectionLimit: wrapperspb.Int32(in.GetSessionConnectionLimit()),
+		Id:          in.GetPublicId(),
+		ScopeId:     in.GetScopeId(),
+		CreatedTime: in.GetCreateTime().GetTimestamp(),
+		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
+		Version:     in.GetVersion(),
+		Type:        target.TcpTargetType.String(),
+	}
+	// Unset session settings are inherited from the target's scopes
+	if in.GetSessionMaxSeconds() != 0 {
+		out.SessionMaxSeconds = wrapperspb.UInt32(in.GetSessionMaxSeconds())
+	}
+	if in.GetSessionConnectionLimit() != 0 {
+		out.SessionConnectionLimit = wrapperspb.Int32(in.GetSessionConnectionLimit())
 	}
 	if in.GetDescription() != "" {
 		out.Description = wrapperspb.String(in.GetDescription())
@@ -676,6 +869,21 @@ func toProto(in target.Target, m []*target.TargetSet) (*pb.Target, error) {
 	if in.GetDefaultPort() > 0 {
 		attrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
 	}
+	if in.GetBanner() != "" {
+		attrs.Banner = wrapperspb.String(in.GetBanner())
+	}
+	if in.GetBannerAcknowledgmentRequired() {
+		attrs.BannerAcknowledgmentRequired = wrapperspb.Bool(true)
+	}
+	if in.GetJustificationPattern() != "" {
+		attrs.JustificationPattern = wrapperspb.String(in.GetJustificationPattern())
+	}
+	if in.GetChangeTicketSystem() != "" {
+		attrs.ChangeTicketSystem = wrapperspb.String(in.GetChangeTicketSystem())
+	}
+	if in.GetClientCertificateLibraryId() != "" {
+		attrs.ClientCertificateLibraryId = wrapperspb.String(in.GetClientCertificateLibraryId())
+	}
 	st, err := handlers.ProtoToStruct(attrs)
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building password attribute struct: %v", err)
@@ -693,9 +901,9 @@ func toProto(in target.Target, m []*target.TargetSet) (*pb.Target, error) {
 
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetTargetRequest) error {
 	return handlers.ValidateGetRequest(target.TcpTargetPrefix, req, handlers.NoopValidatorFn)
 }
@@ -730,6 +938,18 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 			if tcpAttrs.GetDefaultPort() != nil && tcpAttrs.GetDefaultPort().GetValue() == 0 {
 				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
 			}
+			if tcpAttrs.GetBannerAcknowledgmentRequired().GetValue() && tcpAttrs.GetBanner().GetValue() == "" {
+				badFields["attributes.banner_acknowledgment_required"] = "Acknowledgment can only be required of a banner."
+			}
+			if tcpAttrs.GetJustificationPattern() != nil {
+				validateJustificationPattern(tcpAttrs.GetJustificationPattern().GetValue(), badFields)
+			}
+			if tcpAttrs.GetChangeTicketSystem() != nil {
+				validateChangeTicketSystem(tcpAttrs.GetChangeTicketSystem().GetValue(), badFields)
+			}
+			if tcpAttrs.GetClientCertificateLibraryId() != nil && !handlers.ValidId(target.ClientCertificateLibraryPrefix, tcpAttrs.GetClientCertificateLibraryId().GetValue()) {
+				badFields["attributes.client_certificate_library_id"] = "This must be a properly formatted client certificate library id."
+			}
 		}
 		switch req.GetItem().GetType() {
 		case target.TcpTargetType.String():
@@ -772,11 +992,45 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 			if tcpAttrs.GetDefaultPort() != nil && tcpAttrs.GetDefaultPort().GetValue() == 0 {
 				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
 			}
+			if tcpAttrs.GetBanner() != nil && tcpAttrs.GetBanner().GetValue() == "" {
+				badFields["attributes.banner"] = "This optional field cannot be set to empty."
+			}
+			if tcpAttrs.GetJustificationPattern() != nil {
+				validateJustificationPattern(tcpAttrs.GetJustificationPattern().GetValue(), badFields)
+			}
+			if tcpAttrs.GetChangeTicketSystem() != nil {
+				validateChangeTicketSystem(tcpAttrs.GetChangeTicketSystem().GetValue(), badFields)
+			}
+			if tcpAttrs.GetClientCertificateLibraryId() != nil && !handlers.ValidId(target.ClientCertificateLibraryPrefix, tcpAttrs.GetClientCertificateLibraryId().GetValue()) {
+				badFields["attributes.client_certificate_library_id"] = "This must be a properly formatted client certificate library id."
+			}
 		}
 		return badFields
 	})
 }
 
+// validateJustificationPattern adds the problem with a justification pattern
+// set in a request, if any, to badFields.
+func validateJustificationPattern(pattern string, badFields map[string]string) {
+	switch {
+	case pattern == "":
+		badFields["attributes.justification_pattern"] = "This optional field cannot be set to empty."
+	case target.ValidJustificationPattern(pattern) != nil:
+		badFields["attributes.justification_pattern"] = "This must be a valid regular expression."
+	}
+}
+
+// validateChangeTicketSystem adds the problem with a change ticket system set
+// in a request, if any, to badFields.
+func validateChangeTicketSystem(name string, badFields map[string]string) {
+	switch {
+	case name == "":
+		badFields["attributes.change_ticket_system"] = "This optional field cannot be set to empty."
+	case target.ValidChangeTicketSystem(name) != nil:
+		badFields["attributes.change_ticket_system"] = "This cannot have leading or trailing spaces."
+	}
+}
+
 func validateDeleteRequest(req *pbs.DeleteTargetRequest) error {
 	return handlers.ValidateDeleteRequest(target.TcpTargetPrefix, req, handlers.NoopValidatorFn)
 }
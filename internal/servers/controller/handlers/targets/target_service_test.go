@@ -70,17 +70,15 @@ func TestGet(t *testing.T) {
 	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test", target.WithHostSets([]string{hs[0].GetPublicId(), hs[1].GetPublicId()}))
 
 	pTar := &pb.Target{
-		Id:                     tar.GetPublicId(),
-		ScopeId:                proj.GetPublicId(),
-		Name:                   wrapperspb.String("test"),
-		CreatedTime:            tar.CreateTime.GetTimestamp(),
-		UpdatedTime:            tar.UpdateTime.GetTimestamp(),
-		Scope:                  &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
-		Type:                   target.TcpTargetType.String(),
-		HostSetIds:             []string{hs[0].GetPublicId(), hs[1].GetPublicId()},
-		Attributes:             new(structpb.Struct),
-		SessionMaxSeconds:      wrapperspb.UInt32(28800),
-		SessionConnectionLimit: wrapperspb.Int32(1),
+		Id:          tar.GetPublicId(),
+		ScopeId:     proj.GetPublicId(),
+		Name:        wrapperspb.String("test"),
+		CreatedTime: tar.CreateTime.GetTimestamp(),
+		UpdatedTime: tar.UpdateTime.GetTimestamp(),
+		Scope:       &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+		Type:        target.TcpTargetType.String(),
+		HostSetIds:  []string{hs[0].GetPublicId(), hs[1].GetPublicId()},
+		Attributes:  new(structpb.Struct),
 	}
 	for _, ihs := range hs {
 		pTar.HostSets = append(pTar.HostSets, &pb.HostSet{Id: ihs.GetPublicId(), HostCatalogId: ihs.GetCatalogId()})
@@ -152,17 +150,15 @@ func TestList(t *testing.T) {
 		name := fmt.Sprintf("tar%d", i)
 		tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), name, target.WithHostSets([]string{hss[0].GetPublicId(), hss[1].GetPublicId()}))
 		wantTars = append(wantTars, &pb.Target{
-			Id:                     tar.GetPublicId(),
-			ScopeId:                proj.GetPublicId(),
-			Name:                   wrapperspb.String(name),
-			Scope:                  &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
-			CreatedTime:            tar.GetCreateTime().GetTimestamp(),
-			UpdatedTime:            tar.GetUpdateTime().GetTimestamp(),
-			Version:                tar.GetVersion(),
-			Type:                   target.TcpTargetType.String(),
-			Attributes:             new(structpb.Struct),
-			SessionMaxSeconds:      wrapperspb.UInt32(28800),
-			SessionConnectionLimit: wrapperspb.Int32(1),
+			Id:          tar.GetPublicId(),
+			ScopeId:     proj.GetPublicId(),
+			Name:        wrapperspb.String(name),
+			Scope:       &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+			CreatedTime: tar.GetCreateTime().GetTimestamp(),
+			UpdatedTime: tar.GetUpdateTime().GetTimestamp(),
+			Version:     tar.GetVersion(),
+			Type:        target.TcpTargetType.String(),
+			Attributes:  new(structpb.Struct),
 		})
 	}
 
@@ -316,8 +312,6 @@ func TestCreate(t *testing.T) {
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
 						"default_port": structpb.NewNumberValue(2),
 					}},
-					SessionMaxSeconds:      wrapperspb.UInt32(28800),
-					SessionConnectionLimit: wrapperspb.Int32(1),
 				},
 			},
 		},
@@ -333,6 +327,137 @@ func TestCreate(t *testing.T) {
 			}},
 			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name: "Create with a banner requiring acknowledgment",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("banner"),
+				Type:    target.TcpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"banner":                         structpb.NewStringValue("Authorized use only."),
+					"banner_acknowledgment_required": structpb.NewBoolValue(true),
+				}},
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.TcpTargetPrefix),
+				Item: &pb.Target{
+					ScopeId: proj.GetPublicId(),
+					Scope:   &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:    wrapperspb.String("banner"),
+					Type:    target.TcpTargetType.String(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"banner":                         structpb.NewStringValue("Authorized use only."),
+						"banner_acknowledgment_required": structpb.NewBoolValue(true),
+					}},
+				},
+			},
+		},
+		{
+			name: "Create requiring acknowledgment without a banner",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("no banner"),
+				Type:    target.TcpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"banner_acknowledgment_required": structpb.NewBoolValue(true),
+				}},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create with a justification pattern",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("justified"),
+				Type:    target.TcpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"justification_pattern": structpb.NewStringValue("INC-[0-9]+: .+"),
+				}},
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.TcpTargetPrefix),
+				Item: &pb.Target{
+					ScopeId: proj.GetPublicId(),
+					Scope:   &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:    wrapperspb.String("justified"),
+					Type:    target.TcpTargetType.String(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"justification_pattern": structpb.NewStringValue("INC-[0-9]+: .+"),
+					}},
+				},
+			},
+		},
+		{
+			name: "Create with an invalid justification pattern",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("unjustified"),
+				Type:    target.TcpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"justification_pattern": structpb.NewStringValue("INC-(0-9"),
+				}},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create with a change ticket system",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("ticketed"),
+				Type:    target.TcpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"change_ticket_system": structpb.NewStringValue("jira"),
+				}},
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.TcpTargetPrefix),
+				Item: &pb.Target{
+					ScopeId: proj.GetPublicId(),
+					Scope:   &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:    wrapperspb.String("ticketed"),
+					Type:    target.TcpTargetType.String(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"change_ticket_system": structpb.NewStringValue("jira"),
+					}},
+				},
+			},
+		},
+		{
+			name: "Create with a change ticket system with trailing spaces",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("unticketed"),
+				Type:    target.TcpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"change_ticket_system": structpb.NewStringValue("jira "),
+				}},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create with a malformed client certificate library id",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("certified"),
+				Type:    target.TcpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"client_certificate_library_id": structpb.NewStringValue("ttcp_1234567890"),
+				}},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create with an unknown client certificate library",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("certified"),
+				Type:    target.TcpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"client_certificate_library_id": structpb.NewStringValue(target.ClientCertificateLibraryPrefix + "_1234567890"),
+				}},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 		{
 			name: "Create with unknown type",
 			req: &pbs.CreateTargetRequest{Item: &pb.Target{
@@ -57,14 +57,25 @@ func (s Service) ListUsers(ctx context.Context, req *pbs.ListUsersRequest) (*pbs
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	ul, err := s.listFromRepo(ctx, req.GetScopeId())
+	// A listing requested a page at a time is bounded by the max page size,
+	// and one that isn't fails if it has more results than that, unless the
+	// principal can list them unpaged.
+	var listOpts []iam.Option
+	var next string
+	switch {
+	case req.GetPageToken() != "" || req.GetPageSize() > 0:
+		listOpts = append(listOpts, iam.WithLimit(int(req.GetPageSize())), iam.WithPageToken(req.GetPageToken()), iam.WithNextPageToken(&next))
+	case authResults.ListUnpaged(ctx, resource.User):
+		listOpts = append(listOpts, iam.WithLimit(-1))
+	}
+	ul, err := s.listFromRepo(ctx, req.GetScopeId(), listOpts...)
 	if err != nil {
 		return nil, err
 	}
 	for _, item := range ul {
 		item.Scope = authResults.Scope
 	}
-	return &pbs.ListUsersResponse{Items: ul}, nil
+	return &pbs.ListUsersResponse{Items: ul, NextPageToken: next}, nil
 }
 
 // GetUsers implements the interface pbs.UserServiceServer.
@@ -93,6 +104,7 @@ func (s Service) CreateUser(ctx context.Context, req *pbs.CreateUserRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -110,6 +122,7 @@ func (s Service) UpdateUser(ctx context.Context, req *pbs.UpdateUserRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
 	if err != nil {
 		return nil, err
@@ -127,6 +140,7 @@ func (s Service) DeleteUser(ctx context.Context, req *pbs.DeleteUserRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	_, err := s.deleteFromRepo(ctx, req.GetId())
 	if err != nil {
 		return nil, err
@@ -143,6 +157,7 @@ func (s Service) AddUserAccounts(ctx context.Context, req *pbs.AddUserAccountsRe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.addInRepo(ctx, req.GetId(), req.GetAccountIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -160,6 +175,7 @@ func (s Service) SetUserAccounts(ctx context.Context, req *pbs.SetUserAccountsRe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.setInRepo(ctx, req.GetId(), req.GetAccountIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -177,6 +193,7 @@ func (s Service) RemoveUserAccounts(ctx context.Context, req *pbs.RemoveUserAcco
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
+	ctx = iam.WithRequestInfo(ctx, authResults.UserId, authResults.Scope.GetId())
 	u, err := s.removeInRepo(ctx, req.GetId(), req.GetAccountIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
@@ -276,12 +293,12 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, orgId string) ([]*pb.User, error) {
+func (s Service) listFromRepo(ctx context.Context, orgId string, opt ...iam.Option) ([]*pb.User, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListUsers(ctx, orgId)
+	ul, err := repo.ListUsers(ctx, orgId, opt...)
 	if err != nil {
 		return nil, err
 	}
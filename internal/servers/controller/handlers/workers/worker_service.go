@@ -177,6 +177,19 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 		return nil, status.Errorf(codes.Internal, "Error deriving session key: %v", err)
 	}
 
+	// The client certificate issued for the session when it was authorized,
+	// if its target requires client TLS, for the worker to present.
+	clientCert, err := sessRepo.LookupClientCertificate(ctx, sessionInfo.GetPublicId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error looking up client certificate: %v", err)
+	}
+	if clientCert != nil {
+		resp.ClientCertificate = clientCert.Certificate
+		resp.ClientPrivateKey = clientCert.PrivateKey
+		resp.TargetServerName = clientCert.ServerName
+		resp.TargetCaCertificate = clientCert.ServerCaCert
+	}
+
 	return resp, nil
 }
 
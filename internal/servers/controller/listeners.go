@@ -17,8 +17,18 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/workers"
 	"github.com/hashicorp/go-multierror"
 	"google.golang.org/grpc"
+	channelz "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// clusterServices are the services on the cluster listener whose health is
+// reported by its gRPC health service.
+var clusterServices = []string{
+	"controller.servers.services.v1.ServerCoordinationService",
+	"controller.servers.services.v1.SessionService",
+}
+
 func (c *Controller) startListeners() error {
 	servers := make([]func(), 0, len(c.conf.Listeners))
 
@@ -124,6 +134,15 @@ func (c *Controller) startListeners() error {
 		pbs.RegisterServerCoordinationServiceServer(workerServer, workerService)
 		pbs.RegisterSessionServiceServer(workerServer, workerService)
 
+		// The standard health and channelz services let load balancers check
+		// the listener and operators inspect controller-worker connections.
+		c.clusterHealth = health.NewServer()
+		for _, svc := range clusterServices {
+			c.clusterHealth.SetServingStatus(svc, healthpb.HealthCheckResponse_SERVING)
+		}
+		healthpb.RegisterHealthServer(workerServer, c.clusterHealth)
+		channelz.RegisterChannelzServiceToServer(workerServer)
+
 		interceptor := newInterceptingListener(c, l)
 		ln.ALPNListener = interceptor
 		ln.GrpcServer = workerServer
@@ -141,6 +160,12 @@ func (c *Controller) startListeners() error {
 			case "api":
 				err = configureForAPI(ln)
 			case "cluster":
+				if c.follower {
+					// Workers report to controllers which can write
+					// sessions, which a follower can't.
+					c.logger.Info("read-only follower, not serving cluster listener")
+					continue
+				}
 				if c.clusterAddress != "" {
 					err = errors.New("more than one cluster listener found")
 				} else {
@@ -165,6 +190,11 @@ func (c *Controller) startListeners() error {
 }
 
 func (c *Controller) stopListeners(serversOnly bool) error {
+	if c.clusterHealth != nil {
+		// Report not serving first so load balancers stop sending new
+		// connections while existing ones drain.
+		c.clusterHealth.Shutdown()
+	}
 	serverWg := new(sync.WaitGroup)
 	for _, ln := range c.conf.Listeners {
 		localLn := ln
@@ -0,0 +1,61 @@
+package controller_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/api/users"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/servers/controller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgIsolationConfig(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	withOrgIsolation := func(enabled *bool) *config.Config {
+		conf, err := config.DevController()
+		require.NoError(err)
+		conf.Controller.OrgIsolation = enabled
+		return conf
+	}
+	enabled, disabled := true, false
+
+	c1 := controller.NewTestController(t, &controller.TestControllerOpts{Config: withOrgIsolation(&enabled)})
+	defer c1.Shutdown()
+	iamRepo, err := c1.Controller().IamRepoFn()
+	require.NoError(err)
+	isolated, err := iamRepo.OrgIsolation(c1.Context())
+	require.NoError(err)
+	assert.True(isolated)
+
+	// Through the api, a user of one org can't be made a principal of a role
+	// in another.
+	client := c1.Client()
+	client.SetToken(c1.Token().Token)
+	org1, err := scopes.NewClient(client).Create(c1.Context(), "global")
+	require.NoError(err)
+	org2, err := scopes.NewClient(client).Create(c1.Context(), "global")
+	require.NoError(err)
+	u, err := users.NewClient(client).Create(c1.Context(), org1.Item.Id)
+	require.NoError(err)
+	r, err := roles.NewClient(client).Create(c1.Context(), org2.Item.Id)
+	require.NoError(err)
+	_, err = roles.NewClient(client).AddPrincipals(c1.Context(), r.Item.Id, r.Item.Version, []string{u.Item.Id})
+	assert.Error(err)
+
+	// A controller which doesn't set it leaves it as it is
+	c2 := c1.AddClusterControllerMember(t, &controller.TestControllerOpts{Config: withOrgIsolation(nil)})
+	defer c2.Shutdown()
+	isolated, err = iamRepo.OrgIsolation(c1.Context())
+	require.NoError(err)
+	assert.True(isolated)
+
+	// And one which sets it to false turns it off
+	c3 := c1.AddClusterControllerMember(t, &controller.TestControllerOpts{Config: withOrgIsolation(&disabled)})
+	defer c3.Shutdown()
+	isolated, err = iamRepo.OrgIsolation(c1.Context())
+	require.NoError(err)
+	assert.False(isolated)
+}
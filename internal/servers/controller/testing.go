@@ -468,6 +468,9 @@ func (tc *TestController) AddClusterControllerMember(t *testing.T, opts *TestCon
 		DisableKmsKeyCreation:     true,
 		DisableAuthMethodCreation: true,
 	}
+	if opts.Config != nil {
+		nextOpts.Config = opts.Config
+	}
 	if opts.Logger != nil {
 		nextOpts.Logger = opts.Logger
 	}
@@ -1,7 +1,12 @@
 package controller
 
 import (
+	"context"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func Test_TestController(t *testing.T) {
@@ -11,3 +16,23 @@ func Test_TestController(t *testing.T) {
 		defer tc.Shutdown()
 	})
 }
+
+func TestController_ClusterHealth(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	tc := NewTestController(t, nil)
+	defer tc.Shutdown()
+	ctx := context.Background()
+
+	require.NotNil(tc.Controller().clusterHealth)
+	for _, svc := range append([]string{""}, clusterServices...) {
+		resp, err := tc.Controller().clusterHealth.Check(ctx, &healthpb.HealthCheckRequest{Service: svc})
+		require.NoError(err)
+		assert.Equal(healthpb.HealthCheckResponse_SERVING, resp.GetStatus(), svc)
+	}
+
+	require.NoError(tc.Controller().Shutdown(false))
+	resp, err := tc.Controller().clusterHealth.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(err)
+	assert.Equal(healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+}
@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/types/resource"
 )
@@ -18,6 +19,35 @@ const (
 // This is exported so it can be tweaked in tests
 var RecoveryNonceCleanupInterval = 2 * time.Minute
 
+// KmsCacheEvictionInterval is how often expired keys are evicted from the kms
+// cache. This is exported so it can be tweaked in tests.
+var KmsCacheEvictionInterval = time.Minute
+
+// IdempotencyKeyCleanupInterval is how often expired idempotency keys are
+// deleted. This is exported so it can be tweaked in tests.
+var IdempotencyKeyCleanupInterval = 10 * time.Minute
+
+// RoleArchivalInterval is how often expired roles are archived and roles
+// archived for longer than iam.RoleArchiveRetention are deleted. This is
+// exported so it can be tweaked in tests.
+var RoleArchivalInterval = 5 * time.Minute
+
+// EmergencyRoleRevertInterval is how often expired emergency role activations
+// are reverted. This is exported so it can be tweaked in tests.
+var EmergencyRoleRevertInterval = time.Minute
+
+// ReplicaLagInterval is how often a read-only follower checks how far behind
+// its replica is. This is exported so it can be tweaked in tests.
+var ReplicaLagInterval = 5 * time.Second
+
+// StaleJobInterval is how often jobs whose controller has stopped running them
+// are failed. This is exported so it can be tweaked in tests.
+var StaleJobInterval = time.Minute
+
+// staleJobTimeout is how long a running job can go without a heartbeat before
+// it's failed.
+const staleJobTimeout = 5 * time.Minute
+
 func (c *Controller) startStatusTicking(cancelCtx context.Context) {
 	go func() {
 		timer := time.NewTimer(0)
@@ -79,6 +109,93 @@ func (c *Controller) startRecoveryNonceCleanupTicking(cancelCtx context.Context)
 	}()
 }
 
+func (c *Controller) startIdempotencyKeyCleanupTicking(cancelCtx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("idempotency key cleanup ticking shutting down")
+				return
+
+			case <-timer.C:
+				repo, err := c.IamRepoFn()
+				if err != nil {
+					c.logger.Error("error fetching repository for idempotency key cleanup", "error", err)
+				} else {
+					keyCount, err := repo.DeleteExpiredIdempotencyKeys(cancelCtx)
+					if err != nil {
+						c.logger.Error("error performing idempotency key cleanup", "error", err)
+					} else if keyCount > 0 {
+						c.logger.Info("idempotency key cleanup successful", "keys_cleaned", keyCount)
+					}
+				}
+				timer.Reset(IdempotencyKeyCleanupInterval)
+			}
+		}
+	}()
+}
+
+func (c *Controller) startRoleArchivalTicking(cancelCtx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("role archival ticking shutting down")
+				return
+
+			case <-timer.C:
+				repo, err := c.IamRepoFn()
+				if err != nil {
+					c.logger.Error("error fetching repository for role archival", "error", err)
+				} else {
+					archived, err := repo.ArchiveExpiredRoles(cancelCtx)
+					if err != nil {
+						c.logger.Error("error archiving expired roles", "error", err)
+					} else if archived > 0 {
+						c.logger.Info("expired roles archived", "roles_archived", archived)
+					}
+					purged, err := repo.PurgeArchivedRoles(cancelCtx)
+					if err != nil {
+						c.logger.Error("error purging archived roles", "error", err)
+					} else if purged > 0 {
+						c.logger.Info("archived roles purged", "roles_purged", purged)
+					}
+				}
+				timer.Reset(RoleArchivalInterval)
+			}
+		}
+	}()
+}
+
+func (c *Controller) startEmergencyRoleRevertTicking(cancelCtx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("emergency role revert ticking shutting down")
+				return
+
+			case <-timer.C:
+				repo, err := c.IamRepoFn()
+				if err != nil {
+					c.logger.Error("error fetching repository for emergency role revert", "error", err)
+				} else {
+					reverted, err := repo.RevertExpiredEmergencyActivations(cancelCtx)
+					if err != nil {
+						c.logger.Error("error reverting expired emergency role activations", "error", err)
+					} else if reverted > 0 {
+						c.logger.Info("expired emergency role activations reverted", "activations_reverted", reverted)
+					}
+				}
+				timer.Reset(EmergencyRoleRevertInterval)
+			}
+		}
+	}()
+}
+
 func (c *Controller) startTerminateCompletedSessionsTicking(cancelCtx context.Context) {
 	go func() {
 		r := rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -116,3 +233,77 @@ func (c *Controller) startTerminateCompletedSessionsTicking(cancelCtx context.Co
 		}
 	}()
 }
+
+func (c *Controller) startKmsCacheEvictionTicking(cancelCtx context.Context) {
+	go func() {
+		timer := time.NewTimer(KmsCacheEvictionInterval)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("kms cache eviction ticking shutting down")
+				return
+
+			case <-timer.C:
+				if evicted := c.kms.EvictExpiredKeys(); evicted > 0 {
+					c.logger.Trace("kms cache eviction successful", "wrappers_evicted", evicted)
+				}
+				if c.grantsCache != nil {
+					if evicted := c.grantsCache.EvictExpired(); evicted > 0 {
+						c.logger.Trace("grants cache eviction successful", "users_evicted", evicted)
+					}
+				}
+				timer.Reset(KmsCacheEvictionInterval)
+			}
+		}
+	}()
+}
+
+func (c *Controller) startReplicaLagTicking(cancelCtx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("replica lag ticking shutting down")
+				return
+
+			case <-timer.C:
+				lag, err := db.ReplicationLag(cancelCtx, db.New(c.conf.Database))
+				if err != nil {
+					c.logger.Error("error checking replica lag", "error", err)
+				} else {
+					c.logger.Trace("replica lag checked", "lag", lag)
+				}
+				c.replica.set(lag, err)
+				timer.Reset(ReplicaLagInterval)
+			}
+		}
+	}()
+}
+
+func (c *Controller) startStaleJobTicking(cancelCtx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("stale job ticking shutting down")
+				return
+
+			case <-timer.C:
+				repo, err := c.JobRepoFn()
+				if err != nil {
+					c.logger.Error("error fetching repository for stale job cleanup", "error", err)
+				} else {
+					failed, err := repo.FailStaleJobs(cancelCtx, staleJobTimeout)
+					if err != nil {
+						c.logger.Error("error performing stale job cleanup", "error", err)
+					} else if failed > 0 {
+						c.logger.Info("stale jobs failed", "count", failed)
+					}
+				}
+				timer.Reset(StaleJobInterval)
+			}
+		}
+	}()
+}
@@ -0,0 +1,333 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+)
+
+// tunablesPath is the path of the api serving the runtime tunables of the
+// controller which receives the request. Tunables aren't shared between
+// controllers, and are reset to their configured values by a restart.
+const tunablesPath = "/v1/controller/tunables"
+
+// tunableSettings are the runtime tunables of a controller.
+type tunableSettings struct {
+	// LogLevel is the level of the controller's logs.
+	LogLevel string `json:"log_level"`
+	// RateLimit is how many api requests per second the controller serves,
+	// with bursts of up to RateLimitBurst requests. If it's 0, requests
+	// aren't limited.
+	RateLimit      float64 `json:"rate_limit"`
+	RateLimitBurst int     `json:"rate_limit_burst"`
+	// GrantsCacheMaxEntries is how many entries, each the grants of a user in
+	// a scope, the grants cache holds at most, if it's enabled. If it's 0, the
+	// cache is unbounded.
+	GrantsCacheMaxEntries int `json:"grants_cache_max_entries"`
+	// MaintenanceMode is whether the controller refuses every api request
+	// except reads and authentication, with MaintenanceMessage.
+	MaintenanceMode    bool   `json:"maintenance_mode"`
+	MaintenanceMessage string `json:"maintenance_message,omitempty"`
+}
+
+// updateTunablesRequest is the body of a request to update the tunables. Only
+// the tunables which are set are changed.
+type updateTunablesRequest struct {
+	LogLevel              *string  `json:"log_level"`
+	RateLimit             *float64 `json:"rate_limit"`
+	RateLimitBurst        *int     `json:"rate_limit_burst"`
+	GrantsCacheMaxEntries *int     `json:"grants_cache_max_entries"`
+	MaintenanceMode       *bool    `json:"maintenance_mode"`
+	MaintenanceMessage    *string  `json:"maintenance_message"`
+}
+
+// tunables are the current runtime tunables of a controller.
+type tunables struct {
+	// mu guards settings, and serializes updates so the audit records of
+	// concurrent updates are consistent with the order they're applied in.
+	mu       sync.RWMutex
+	settings tunableSettings
+	limiter  rateLimiter
+}
+
+func newTunables(logLevel hclog.Level) *tunables {
+	return &tunables{
+		settings: tunableSettings{LogLevel: logLevel.String()},
+	}
+}
+
+func (t *tunables) get() tunableSettings {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.settings
+}
+
+// maintenance returns whether the controller is in maintenance mode, and its
+// message.
+func (t *tunables) maintenance() (bool, string) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.settings.MaintenanceMode, t.settings.MaintenanceMessage
+}
+
+// rateLimiter is a token bucket allowing rate events per second, with bursts
+// of up to burst events. If rate is 0, every event is allowed.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  int
+	tokens float64
+	last   time.Time
+}
+
+// set changes the rate and burst of the limiter, refilling its bucket.
+func (l *rateLimiter) set(rate float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate, l.burst = rate, burst
+	l.tokens = float64(burst)
+	l.last = time.Time{}
+}
+
+// allow takes a token from the bucket, if there is one.
+func (l *rateLimiter) allow(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate <= 0 {
+		return true
+	}
+	if !l.last.IsZero() {
+		l.tokens = math.Min(float64(l.burst), l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// handleTunables serves the runtime tunables api of the controller:
+//
+//	GET   /v1/controller/tunables          reads the tunables
+//	PATCH /v1/controller/tunables          updates the tunables
+//	GET   /v1/controller/tunables/audit    lists the changes made to the
+//	                                       tunables of every controller
+func handleTunables(c *Controller) http.Handler {
+	marshaler := &runtime.JSONPb{}
+	writeErr := func(w http.ResponseWriter, r *http.Request, err error) {
+		handlers.ErrorHandler(c.logger)(r.Context(), nil, marshaler, w, r, err)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var out interface{}
+		var err error
+		switch {
+		case r.URL.Path == tunablesPath && r.Method == http.MethodGet:
+			out, err = c.readTunables(r)
+		case r.URL.Path == tunablesPath && r.Method == http.MethodPatch:
+			out, err = c.updateTunables(r)
+		case r.URL.Path == tunablesPath+"/audit" && r.Method == http.MethodGet:
+			out, err = c.listTunableAudits(r)
+		default:
+			err = handlers.ApiErrorWithCode(codes.Unimplemented)
+		}
+		if err != nil {
+			writeErr(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(out); err != nil {
+			c.logger.Error("failed to send tunables response", "error", err)
+		}
+	})
+}
+
+func (c *Controller) readTunables(r *http.Request) (*tunableSettings, error) {
+	authResults := auth.Verify(r.Context(), auth.WithScopeId(scope.Global.String()), auth.WithType(resource.Controller), auth.WithAction(action.Read))
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	s := c.tunables.get()
+	return &s, nil
+}
+
+// updateTunables validates every tunable of the request and records their
+// changes before applying any of them, so a change is only applied if it's
+// audited.
+func (c *Controller) updateTunables(r *http.Request) (*tunableSettings, error) {
+	ctx := r.Context()
+	authResults := auth.Verify(ctx, auth.WithScopeId(scope.Global.String()), auth.WithType(resource.Controller), auth.WithAction(action.Update))
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	var req updateTunablesRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"body": err.Error()})
+	}
+
+	c.tunables.mu.Lock()
+	defer c.tunables.mu.Unlock()
+	old := c.tunables.settings
+	updated := old
+	badFields := map[string]string{}
+	if req.LogLevel != nil {
+		if level := hclog.LevelFromString(*req.LogLevel); level == hclog.NoLevel {
+			badFields["log_level"] = "Must be trace, debug, info, warn or error."
+		} else {
+			updated.LogLevel = level.String()
+		}
+	}
+	if req.RateLimit != nil {
+		if *req.RateLimit < 0 {
+			badFields["rate_limit"] = "Must be 0 or more."
+		}
+		updated.RateLimit = *req.RateLimit
+	}
+	if req.RateLimitBurst != nil {
+		if *req.RateLimitBurst < 0 {
+			badFields["rate_limit_burst"] = "Must be 0 or more."
+		}
+		updated.RateLimitBurst = *req.RateLimitBurst
+	}
+	if updated.RateLimit > 0 && updated.RateLimitBurst == 0 {
+		// Without a burst, a limit would refuse every request.
+		updated.RateLimitBurst = int(math.Ceil(updated.RateLimit))
+	}
+	if req.GrantsCacheMaxEntries != nil {
+		switch {
+		case c.grantsCache == nil:
+			badFields["grants_cache_max_entries"] = "The grants cache isn't enabled."
+		case *req.GrantsCacheMaxEntries < 0:
+			badFields["grants_cache_max_entries"] = "Must be 0 or more."
+		}
+		updated.GrantsCacheMaxEntries = *req.GrantsCacheMaxEntries
+	}
+	if req.MaintenanceMode != nil {
+		updated.MaintenanceMode = *req.MaintenanceMode
+	}
+	if req.MaintenanceMessage != nil {
+		updated.MaintenanceMessage = *req.MaintenanceMessage
+	}
+	if len(badFields) > 0 {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+
+	changes := tunableChanges(old, updated)
+	if len(changes) == 0 {
+		return &old, nil
+	}
+	repo, err := c.ServersRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.CreateTunableAudit(ctx, c.conf.RawConfig.Controller.Name, authResults.UserId, changes); err != nil {
+		return nil, err
+	}
+
+	if updated.LogLevel != old.LogLevel {
+		c.conf.Logger.SetLevel(hclog.LevelFromString(updated.LogLevel))
+	}
+	if updated.RateLimit != old.RateLimit || updated.RateLimitBurst != old.RateLimitBurst {
+		c.tunables.limiter.set(updated.RateLimit, updated.RateLimitBurst)
+	}
+	if updated.GrantsCacheMaxEntries != old.GrantsCacheMaxEntries {
+		c.grantsCache.SetMaxEntries(updated.GrantsCacheMaxEntries)
+	}
+	c.tunables.settings = updated
+	auditLogger := c.logger.Named("audit")
+	for _, ch := range changes {
+		auditLogger.Info("tunable changed", "name", ch.Name, "old_value", ch.OldValue, "new_value", ch.NewValue, "user_id", authResults.UserId)
+	}
+	return &updated, nil
+}
+
+// tunableChanges returns the tunables which differ between old and updated.
+func tunableChanges(old, updated tunableSettings) []*servers.TunableChange {
+	var changes []*servers.TunableChange
+	add := func(name, o, u string) {
+		if o != u {
+			changes = append(changes, &servers.TunableChange{Name: name, OldValue: o, NewValue: u})
+		}
+	}
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	add("log_level", old.LogLevel, updated.LogLevel)
+	add("rate_limit", formatFloat(old.RateLimit), formatFloat(updated.RateLimit))
+	add("rate_limit_burst", strconv.Itoa(old.RateLimitBurst), strconv.Itoa(updated.RateLimitBurst))
+	add("grants_cache_max_entries", strconv.Itoa(old.GrantsCacheMaxEntries), strconv.Itoa(updated.GrantsCacheMaxEntries))
+	add("maintenance_mode", strconv.FormatBool(old.MaintenanceMode), strconv.FormatBool(updated.MaintenanceMode))
+	add("maintenance_message", old.MaintenanceMessage, updated.MaintenanceMessage)
+	return changes
+}
+
+func (c *Controller) listTunableAudits(r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	authResults := auth.Verify(ctx, auth.WithScopeId(scope.Global.String()), auth.WithType(resource.Controller), auth.WithAction(action.Read))
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	var opts []servers.Option
+	if l := r.URL.Query().Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit < 1 {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"limit": "Must be a positive number."})
+		}
+		opts = append(opts, servers.WithLimit(limit))
+	}
+	repo, err := c.ServersRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	audits, err := repo.ListTunableAudits(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		Items []*servers.TunableAudit `json:"items"`
+	}{Items: audits}, nil
+}
+
+// wrapHandlerWithTunables applies the rate limit and maintenance mode of the
+// controller to api requests. Requests over the limit get a 429 response, and
+// in maintenance mode, every request except reads and authentication gets a
+// 503 response with the maintenance message.
+func wrapHandlerWithTunables(h http.Handler, c *Controller) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !c.tunables.limiter.allow(time.Now()) {
+			w.Header().Set("Retry-After", "1")
+			writeApiError(w, http.StatusTooManyRequests, "rate limited", "")
+			return
+		}
+		if on, msg := c.tunables.maintenance(); on {
+			switch {
+			case r.Method == http.MethodGet, r.Method == http.MethodHead, r.Method == http.MethodOptions:
+			case isAuthenticate(r):
+			default:
+				if msg == "" {
+					msg = fmt.Sprintf("The controller is in maintenance mode; %s requests are refused.", strings.ToUpper(r.Method))
+				}
+				writeApiError(w, http.StatusServiceUnavailable, "maintenance mode", msg)
+				return
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
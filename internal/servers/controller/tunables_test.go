@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	assert := assert.New(t)
+	var l rateLimiter
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		assert.True(l.allow(now), "an unset limiter allows every event")
+	}
+
+	l.set(2, 3)
+	for i := 0; i < 3; i++ {
+		assert.True(l.allow(now))
+	}
+	assert.False(l.allow(now))
+	assert.False(l.allow(now.Add(400 * time.Millisecond)))
+	assert.True(l.allow(now.Add(500 * time.Millisecond)))
+	assert.False(l.allow(now.Add(500 * time.Millisecond)))
+	// The bucket refills up to the burst.
+	later := now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.True(l.allow(later))
+	}
+	assert.False(l.allow(later))
+
+	l.set(0, 0)
+	assert.True(l.allow(later))
+}
+
+func TestTunableChanges(t *testing.T) {
+	assert := assert.New(t)
+	old := tunableSettings{LogLevel: "info"}
+	assert.Empty(tunableChanges(old, old))
+
+	updated := old
+	updated.LogLevel = "debug"
+	updated.RateLimit = 2.5
+	updated.MaintenanceMode = true
+	assert.Equal([]*servers.TunableChange{
+		{Name: "log_level", OldValue: "info", NewValue: "debug"},
+		{Name: "rate_limit", OldValue: "0", NewValue: "2.5"},
+		{Name: "maintenance_mode", OldValue: "false", NewValue: "true"},
+	}, tunableChanges(old, updated))
+}
+
+func TestWrapHandlerWithTunables(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	tests := []struct {
+		name        string
+		maintenance bool
+		rateLimit   float64
+		method      string
+		path        string
+		wantStatus  []int
+	}{
+		{
+			name:       "no-tunables",
+			method:     http.MethodPost,
+			wantStatus: []int{http.StatusOK, http.StatusOK},
+		},
+		{
+			name:        "maintenance-read",
+			maintenance: true,
+			method:      http.MethodGet,
+			wantStatus:  []int{http.StatusOK},
+		},
+		{
+			name:        "maintenance-write",
+			maintenance: true,
+			method:      http.MethodDelete,
+			wantStatus:  []int{http.StatusServiceUnavailable},
+		},
+		{
+			name:        "maintenance-authenticate",
+			maintenance: true,
+			method:      http.MethodPost,
+			path:        "/v1/auth-methods/ampw_1234567890:authenticate",
+			wantStatus:  []int{http.StatusOK},
+		},
+		{
+			name:       "rate-limited",
+			rateLimit:  1,
+			method:     http.MethodGet,
+			wantStatus: []int{http.StatusOK, http.StatusTooManyRequests},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			c := &Controller{
+				logger:   hclog.NewNullLogger(),
+				tunables: newTunables(hclog.Info),
+			}
+			c.tunables.settings.MaintenanceMode = tt.maintenance
+			c.tunables.limiter.set(tt.rateLimit, 1)
+			path := tt.path
+			if path == "" {
+				path = "/v1/roles"
+			}
+			h := wrapHandlerWithTunables(ok, c)
+			for _, want := range tt.wantStatus {
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, httptest.NewRequest(tt.method, path, nil))
+				assert.Equal(want, w.Code)
+			}
+		})
+	}
+}
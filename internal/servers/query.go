@@ -3,3 +3,19 @@ package servers
 const (
 	deleteWhereSql = `create_time < $1`
 )
+
+const (
+	insertTunableAudit = `
+insert into server_tunable_audit
+  (controller_id, user_id, name, old_value, new_value)
+values
+  ($1, $2, $3, $4, $5);
+`
+
+	listTunableAudits = `
+select id, controller_id, user_id, name, old_value, new_value, create_time
+  from server_tunable_audit
+ order by create_time desc, id desc
+ limit $1;
+`
+)
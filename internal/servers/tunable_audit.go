@@ -0,0 +1,95 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// defaultTunableAuditLimit is how many audit records ListTunableAudits returns
+// when it's given no limit.
+const defaultTunableAuditLimit = 100
+
+// TunableChange is the change of a runtime tunable of a controller, like its
+// log level, from OldValue to NewValue.
+type TunableChange struct {
+	Name     string `json:"name"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// TunableAudit records who changed a runtime tunable of which controller, and
+// when.
+type TunableAudit struct {
+	Id           int64     `json:"id"`
+	ControllerId string    `json:"controller_id"`
+	UserId       string    `json:"user_id"`
+	CreateTime   time.Time `json:"create_time"`
+	TunableChange
+}
+
+// CreateTunableAudit records the changes the user made to the runtime
+// tunables of the controller, in a single transaction.
+func (r *Repository) CreateTunableAudit(ctx context.Context, controllerId, userId string, changes []*TunableChange) error {
+	switch {
+	case controllerId == "":
+		return fmt.Errorf("create tunable audit: missing controller id: %w", db.ErrInvalidParameter)
+	case userId == "":
+		return fmt.Errorf("create tunable audit: missing user id: %w", db.ErrInvalidParameter)
+	case len(changes) == 0:
+		return fmt.Errorf("create tunable audit: missing changes: %w", db.ErrInvalidParameter)
+	}
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			for _, c := range changes {
+				if c.Name == "" {
+					return fmt.Errorf("missing tunable name: %w", db.ErrInvalidParameter)
+				}
+				if _, err := w.Exec(ctx, insertTunableAudit, []interface{}{controllerId, userId, c.Name, c.OldValue, c.NewValue}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("create tunable audit: %w", err)
+	}
+	return nil
+}
+
+// ListTunableAudits returns the changes made to the runtime tunables of all
+// controllers, newest first. Supports the WithLimit option, which defaults to
+// 100 records; a negative limit returns every record.
+func (r *Repository) ListTunableAudits(ctx context.Context, opt ...Option) ([]*TunableAudit, error) {
+	opts := getOpts(opt...)
+	var limit interface{} = opts.withLimit
+	switch {
+	case opts.withLimit == 0:
+		limit = defaultTunableAuditLimit
+	case opts.withLimit < 0:
+		limit = nil
+	}
+	rows, err := r.reader.Query(ctx, listTunableAudits, []interface{}{limit})
+	if err != nil {
+		return nil, fmt.Errorf("list tunable audits: %w", err)
+	}
+	defer rows.Close()
+	var audits []*TunableAudit
+	for rows.Next() {
+		var a TunableAudit
+		if err := rows.Scan(&a.Id, &a.ControllerId, &a.UserId, &a.Name, &a.OldValue, &a.NewValue, &a.CreateTime); err != nil {
+			return nil, fmt.Errorf("list tunable audits: %w", err)
+		}
+		audits = append(audits, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list tunable audits: %w", err)
+	}
+	return audits, nil
+}
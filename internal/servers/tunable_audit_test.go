@@ -0,0 +1,54 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_TunableAudit(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo, err := NewRepository(rw, rw, kms.TestKms(t, conn, wrapper))
+	require.NoError(err)
+	ctx := context.Background()
+
+	err = repo.CreateTunableAudit(ctx, "c1", "u_1234567890", nil)
+	require.Error(err)
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+
+	// A change without a name rolls back the others
+	err = repo.CreateTunableAudit(ctx, "c1", "u_1234567890", []*TunableChange{
+		{Name: "log_level", OldValue: "info", NewValue: "debug"},
+		{OldValue: "false", NewValue: "true"},
+	})
+	require.Error(err)
+	audits, err := repo.ListTunableAudits(ctx)
+	require.NoError(err)
+	assert.Empty(audits)
+
+	require.NoError(repo.CreateTunableAudit(ctx, "c1", "u_1234567890", []*TunableChange{
+		{Name: "log_level", OldValue: "info", NewValue: "debug"},
+		{Name: "maintenance_mode", OldValue: "false", NewValue: "true"},
+	}))
+	require.NoError(repo.CreateTunableAudit(ctx, "c2", "u_1234567890", []*TunableChange{
+		{Name: "log_level", OldValue: "info", NewValue: "trace"},
+	}))
+	audits, err = repo.ListTunableAudits(ctx)
+	require.NoError(err)
+	require.Len(audits, 3)
+	assert.Equal("c2", audits[0].ControllerId)
+	assert.Equal("trace", audits[0].NewValue)
+	assert.False(audits[0].CreateTime.IsZero())
+
+	audits, err = repo.ListTunableAudits(ctx, WithLimit(1))
+	require.NoError(err)
+	assert.Len(audits, 1)
+}
@@ -25,6 +25,10 @@ type connInfo struct {
 	connCancel context.CancelFunc
 	status     pbs.CONNECTIONSTATUS
 	closeTime  time.Time
+
+	// closedReason is why the connection ended, if the worker knows. If
+	// it's empty, the connection is closed for an unknown reason.
+	closedReason session.ClosedReason
 }
 
 type sessionInfo struct {
@@ -231,10 +235,19 @@ func (w *Worker) closeConnections(ctx context.Context, closeMap map[string]strin
 	w.logger.Trace("marking connections as closed", "session_and_connection_ids", fmt.Sprintf("%#v", closeMap))
 
 	closeData := make([]*pbs.CloseConnectionRequestData, 0, len(closeMap))
-	for connId := range closeMap {
+	for connId, sessionId := range closeMap {
+		reason := session.UnknownReason
+		if siRaw, ok := w.sessionInfoMap.Load(sessionId); ok {
+			si := siRaw.(*sessionInfo)
+			si.RLock()
+			if ci := si.connInfoMap[connId]; ci != nil && ci.closedReason != "" {
+				reason = ci.closedReason
+			}
+			si.RUnlock()
+		}
 		closeData = append(closeData, &pbs.CloseConnectionRequestData{
 			ConnectionId: connId,
-			Reason:       session.UnknownReason.String(),
+			Reason:       reason.String(),
 		})
 	}
 	closeInfo := &pbs.CloseConnectionRequest{
@@ -2,19 +2,30 @@ package worker
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/url"
 	"sync"
+	"time"
 
 	"nhooyr.io/websocket"
 
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/session"
 )
 
+// endpointHandshakeTimeout is how long the TLS handshake with an endpoint
+// which requires client TLS can take.
+const endpointHandshakeTimeout = 30 * time.Second
+
 func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn, si *sessionInfo, connectionId, endpoint string) {
 	si.RLock()
 	sessionId := si.lookupSessionResponse.GetAuthorization().GetSessionId()
+	lookupResp := si.lookupSessionResponse
 	si.RUnlock()
 
 	sessionUrl, err := url.Parse(endpoint)
@@ -37,6 +48,31 @@ func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAd
 	// Assert this for better Go 1.11 splice support
 	tcpRemoteConn := remoteConn.(*net.TCPConn)
 
+	// If the target requires client TLS, wrap the connection to it using the
+	// client certificate issued for the session, so the client never
+	// handles the key.
+	var endpointConn net.Conn = tcpRemoteConn
+	if len(lookupResp.GetClientCertificate()) > 0 {
+		tlsConf, err := targetTlsConfig(lookupResp, sessionUrl.Hostname())
+		if err != nil {
+			w.logger.Error("error configuring tls to endpoint", "error", err, "session_id", sessionId, "endpoint", endpoint)
+			tcpRemoteConn.Close()
+			conn.Close(websocket.StatusInternalError, "endpoint tls configuration failed")
+			return
+		}
+		tlsConn := tls.Client(tcpRemoteConn, tlsConf)
+		_ = tcpRemoteConn.SetDeadline(time.Now().Add(endpointHandshakeTimeout))
+		err = tlsConn.Handshake()
+		_ = tcpRemoteConn.SetDeadline(time.Time{})
+		if err != nil {
+			w.logger.Error("error in tls handshake with endpoint", "error", err, "session_id", sessionId, "endpoint", endpoint)
+			tcpRemoteConn.Close()
+			conn.Close(websocket.StatusInternalError, "endpoint tls handshake failed")
+			return
+		}
+		endpointConn = tlsConn
+	}
+
 	endpointAddr := tcpRemoteConn.RemoteAddr().(*net.TCPAddr)
 	connectionInfo := &pbs.ConnectConnectionRequest{
 		ConnectionId:       connectionId,
@@ -64,14 +100,64 @@ func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAd
 	connWg.Add(2)
 	go func() {
 		defer connWg.Done()
-		_, err := io.Copy(netConn, tcpRemoteConn)
+		_, err := io.Copy(netConn, endpointConn)
 		w.logger.Debug("copy from client to endpoint done", "error", err)
 	}()
 	go func() {
 		defer connWg.Done()
-		_, err := io.Copy(tcpRemoteConn, netConn)
+		_, err := io.Copy(endpointConn, netConn)
 		w.logger.Debug("copy from endpoint to client done", "error", err)
+		if clientConnLost(connCtx, err) {
+			// Mark the connection as lost to a network error, so the
+			// session can be resumed if its target allows it
+			si.Lock()
+			si.connInfoMap[connectionId].closedReason = session.ConnectionNetworkError
+			si.Unlock()
+		}
 	}()
 	connWg.Wait()
 
 }
+
+// targetTlsConfig returns the TLS config for connecting to the endpoint, whose
+// host is host, with the client certificate in the session's lookup response.
+func targetTlsConfig(resp *pbs.LookupSessionResponse, host string) (*tls.Config, error) {
+	cert, err := tls.X509KeyPair(resp.GetClientCertificate(), resp.GetClientPrivateKey())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing client certificate: %w", err)
+	}
+	conf := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ServerName:   host,
+		MinVersion:   tls.VersionTLS12,
+	}
+	if resp.GetTargetServerName() != "" {
+		conf.ServerName = resp.GetTargetServerName()
+	}
+	if len(resp.GetTargetCaCertificate()) > 0 {
+		conf.RootCAs = x509.NewCertPool()
+		if !conf.RootCAs.AppendCertsFromPEM(resp.GetTargetCaCertificate()) {
+			return nil, errors.New("no certificates found in target ca certificate")
+		}
+	}
+	return conf, nil
+}
+
+// clientConnLost reports whether err, returned copying from the client to the
+// endpoint, means the connection to the client was lost rather than closed by
+// the client or the worker.
+func clientConnLost(connCtx context.Context, err error) bool {
+	if err == nil || connCtx.Err() != nil {
+		return false
+	}
+	if websocket.CloseStatus(err) != -1 {
+		// The client closed the websocket
+		return false
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "write" {
+		// Writing to the endpoint failed
+		return false
+	}
+	return true
+}
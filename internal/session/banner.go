@@ -0,0 +1,40 @@
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+)
+
+const defaultBannerAcknowledgmentTableName = "session_banner_acknowledgment"
+
+// BannerAcknowledgment records, for compliance, that the user of a session
+// acknowledged the banner of its target before the session was authorized,
+// and the banner as it was then. It's written with the session by
+// CreateSession WithBannerAcknowledgment, and kept after the session is
+// deleted. Acknowledgments are append only.
+type BannerAcknowledgment struct {
+	SessionId   string `gorm:"primary_key"`
+	TargetId    string
+	UserId      string
+	AuthTokenId string
+	Banner      string
+	// BannerHash is the BannerHash of Banner, which identifies the version of
+	// the banner that was acknowledged.
+	BannerHash string
+	CreateTime *timestamp.Timestamp `gorm:"default:current_timestamp"`
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (a *BannerAcknowledgment) TableName() string {
+	return defaultBannerAcknowledgmentTableName
+}
+
+// BannerHash returns the hash which identifies a version of a target's
+// banner. A user acknowledges a banner by its hash, so a banner which changed
+// after it was shown to them isn't acknowledged by mistake.
+func BannerHash(banner string) string {
+	h := sha256.Sum256([]byte(banner))
+	return hex.EncodeToString(h[:])
+}
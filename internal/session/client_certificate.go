@@ -0,0 +1,56 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+)
+
+const defaultClientCertificateTableName = "session_client_certificate"
+
+// ClientCertificate is the client certificate issued for a session by its
+// target's client certificate library when the session was authorized. The
+// worker presents it to the target for every connection of the session. It's
+// written with the session by CreateSession WithClientCertificate, and can't
+// be changed.
+type ClientCertificate struct {
+	SessionId string `gorm:"primary_key"`
+	// Certificate is the PEM encoded certificate, followed by the
+	// certificates of its issuers, if any.
+	Certificate []byte
+	// CtPrivateKey is the ciphertext of the private key stored in the database
+	CtPrivateKey []byte `gorm:"column:private_key;not_null" wrapping:"ct,private_key"`
+	// PrivateKey is the PEM encoded private key of the certificate, which is
+	// not stored in the database
+	PrivateKey []byte `gorm:"-" wrapping:"pt,private_key"`
+	// ServerName and ServerCaCert verify the target's certificate
+	ServerName     string `gorm:"default:null"`
+	ServerCaCert   []byte `gorm:"default:null"`
+	ExpirationTime *timestamp.Timestamp
+	// KeyId is the key ID that was used to encrypt the private key
+	KeyId      string
+	CreateTime *timestamp.Timestamp `gorm:"default:current_timestamp"`
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (c *ClientCertificate) TableName() string {
+	return defaultClientCertificateTableName
+}
+
+func (c *ClientCertificate) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	if err := structwrapping.WrapStruct(ctx, cipher, c, nil); err != nil {
+		return fmt.Errorf("error encrypting client certificate: %w", err)
+	}
+	c.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (c *ClientCertificate) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	if err := structwrapping.UnwrapStruct(ctx, cipher, c, nil); err != nil {
+		return fmt.Errorf("error decrypting client certificate: %w", err)
+	}
+	return nil
+}
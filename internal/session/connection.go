@@ -47,7 +47,6 @@ func (c *Connection) GetPublicId() string {
 	return c.PublicId
 }
 
-var _ Cloneable = (*Connection)(nil)
 var _ db.VetForWriter = (*Connection)(nil)
 
 // New creates a new in memory session.  No options
@@ -72,7 +71,7 @@ func AllocConnection() Connection {
 }
 
 // Clone creates a clone of the Session
-func (c *Connection) Clone() interface{} {
+func (c *Connection) Clone() *Connection {
 	clone := &Connection{
 		PublicId:           c.PublicId,
 		SessionId:          c.SessionId,
@@ -58,7 +58,6 @@ type ConnectionState struct {
 	tableName string `gorm:"-"`
 }
 
-var _ Cloneable = (*ConnectionState)(nil)
 var _ db.VetForWriter = (*ConnectionState)(nil)
 
 // NewConnectionState creates a new in memory connection state.  No options
@@ -80,7 +79,7 @@ func allocConnectionState() ConnectionState {
 }
 
 // Clone creates a clone of the State
-func (s *ConnectionState) Clone() interface{} {
+func (s *ConnectionState) Clone() *ConnectionState {
 	clone := &ConnectionState{
 		ConnectionId: s.ConnectionId,
 		Status:       s.Status,
@@ -166,7 +166,7 @@ func TestConnectionState_Clone(t *testing.T) {
 		c := TestConnection(t, conn, s.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
 		state := TestConnectionState(t, conn, c.PublicId, StatusConnected)
 		cp := state.Clone()
-		assert.Equal(cp.(*ConnectionState), state)
+		assert.Equal(cp, state)
 	})
 	t.Run("not-equal", func(t *testing.T) {
 		assert := assert.New(t)
@@ -176,7 +176,7 @@ func TestConnectionState_Clone(t *testing.T) {
 		state2 := TestConnectionState(t, conn, c.PublicId, StatusConnected)
 
 		cp := state.Clone()
-		assert.NotEqual(cp.(*ConnectionState), state2)
+		assert.NotEqual(cp, state2)
 	})
 }
 
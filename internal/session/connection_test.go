@@ -210,7 +210,7 @@ func TestConnection_Clone(t *testing.T) {
 		s := TestDefaultSession(t, conn, wrapper, iamRepo)
 		c := TestConnection(t, conn, s.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
 		cp := c.Clone()
-		assert.Equal(cp.(*Connection), c)
+		assert.Equal(cp, c)
 	})
 	t.Run("not-equal", func(t *testing.T) {
 		assert := assert.New(t)
@@ -219,7 +219,7 @@ func TestConnection_Clone(t *testing.T) {
 		c2 := TestConnection(t, conn, s.PublicId, "127.0.0.1", 80, "127.0.0.1", 8080)
 
 		cp := c.Clone()
-		assert.NotEqual(cp.(*Connection), c2)
+		assert.NotEqual(cp, c2)
 	})
 }
 
@@ -32,7 +32,7 @@ func TestSession_ImmutableFields(t *testing.T) {
 		{
 			name: "public_id",
 			update: func() *Session {
-				s := new.Clone().(*Session)
+				s := new.Clone()
 				s.PublicId = "o_thisIsNotAValidId"
 				return s
 			}(),
@@ -41,7 +41,7 @@ func TestSession_ImmutableFields(t *testing.T) {
 		{
 			name: "certificate",
 			update: func() *Session {
-				s := new.Clone().(*Session)
+				s := new.Clone()
 				s.Certificate = []byte("fake cert for test")
 				return s
 			}(),
@@ -50,7 +50,7 @@ func TestSession_ImmutableFields(t *testing.T) {
 		{
 			name: "expiration time",
 			update: func() *Session {
-				s := new.Clone().(*Session)
+				s := new.Clone()
 				s.ExpirationTime = &ts
 				return s
 			}(),
@@ -59,7 +59,7 @@ func TestSession_ImmutableFields(t *testing.T) {
 		{
 			name: "create time",
 			update: func() *Session {
-				s := new.Clone().(*Session)
+				s := new.Clone()
 				s.CreateTime = &ts
 				return s
 			}(),
@@ -82,7 +82,7 @@ func TestSession_ImmutableFields(t *testing.T) {
 			err = rw.LookupById(context.Background(), after)
 			require.NoError(err)
 
-			assert.Equal(orig.(*Session), after)
+			assert.Equal(orig, after)
 
 		})
 	}
@@ -113,7 +113,7 @@ func TestState_ImmutableFields(t *testing.T) {
 		{
 			name: "session_id",
 			update: func() *State {
-				s := new.Clone().(*State)
+				s := new.Clone()
 				s.SessionId = "s_thisIsNotAValidId"
 				return s
 			}(),
@@ -122,7 +122,7 @@ func TestState_ImmutableFields(t *testing.T) {
 		{
 			name: "status",
 			update: func() *State {
-				s := new.Clone().(*State)
+				s := new.Clone()
 				s.Status = "active"
 				return s
 			}(),
@@ -131,7 +131,7 @@ func TestState_ImmutableFields(t *testing.T) {
 		{
 			name: "start time",
 			update: func() *State {
-				s := new.Clone().(*State)
+				s := new.Clone()
 				s.StartTime = &ts
 				return s
 			}(),
@@ -140,7 +140,7 @@ func TestState_ImmutableFields(t *testing.T) {
 		{
 			name: "previous_end_time",
 			update: func() *State {
-				s := new.Clone().(*State)
+				s := new.Clone()
 				s.PreviousEndTime = &ts
 				return s
 			}(),
@@ -162,7 +162,7 @@ func TestState_ImmutableFields(t *testing.T) {
 			after := new.Clone()
 			err = rw.LookupWhere(context.Background(), after, "session_id = ? and start_time = ?", new.SessionId, new.StartTime)
 			require.NoError(err)
-			assert.Equal(orig.(*State), after)
+			assert.Equal(orig, after)
 		})
 	}
 }
@@ -188,7 +188,7 @@ func TestConnection_ImmutableFields(t *testing.T) {
 		{
 			name: "public_id",
 			update: func() *Connection {
-				c := new.Clone().(*Connection)
+				c := new.Clone()
 				c.PublicId = "sc_thisIsNotAValidId"
 				return c
 			}(),
@@ -197,7 +197,7 @@ func TestConnection_ImmutableFields(t *testing.T) {
 		{
 			name: "session_id",
 			update: func() *Connection {
-				c := new.Clone().(*Connection)
+				c := new.Clone()
 				c.SessionId = "s_thisIsNotAValidId"
 				return c
 			}(),
@@ -206,7 +206,7 @@ func TestConnection_ImmutableFields(t *testing.T) {
 		{
 			name: "create time",
 			update: func() *Connection {
-				s := new.Clone().(*Connection)
+				s := new.Clone()
 				s.CreateTime = &ts
 				return s
 			}(),
@@ -229,7 +229,7 @@ func TestConnection_ImmutableFields(t *testing.T) {
 			err = rw.LookupById(context.Background(), after)
 			require.NoError(err)
 
-			assert.Equal(orig.(*Connection), after)
+			assert.Equal(orig, after)
 
 		})
 	}
@@ -261,7 +261,7 @@ func TestConnectionState_ImmutableFields(t *testing.T) {
 		{
 			name: "session_id",
 			update: func() *ConnectionState {
-				s := new.Clone().(*ConnectionState)
+				s := new.Clone()
 				s.ConnectionId = "sc_thisIsNotAValidId"
 				return s
 			}(),
@@ -270,7 +270,7 @@ func TestConnectionState_ImmutableFields(t *testing.T) {
 		{
 			name: "status",
 			update: func() *ConnectionState {
-				s := new.Clone().(*ConnectionState)
+				s := new.Clone()
 				s.Status = "closed"
 				return s
 			}(),
@@ -279,7 +279,7 @@ func TestConnectionState_ImmutableFields(t *testing.T) {
 		{
 			name: "start time",
 			update: func() *ConnectionState {
-				s := new.Clone().(*ConnectionState)
+				s := new.Clone()
 				s.StartTime = &ts
 				return s
 			}(),
@@ -288,7 +288,7 @@ func TestConnectionState_ImmutableFields(t *testing.T) {
 		{
 			name: "previous_end_time",
 			update: func() *ConnectionState {
-				s := new.Clone().(*ConnectionState)
+				s := new.Clone()
 				s.PreviousEndTime = &ts
 				return s
 			}(),
@@ -310,7 +310,7 @@ func TestConnectionState_ImmutableFields(t *testing.T) {
 			after := new.Clone()
 			err = rw.LookupWhere(context.Background(), after, "connection_id = ? and start_time = ?", new.ConnectionId, new.StartTime)
 			require.NoError(err)
-			assert.Equal(orig.(*ConnectionState), after)
+			assert.Equal(orig, after)
 		})
 	}
 }
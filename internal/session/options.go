@@ -26,6 +26,9 @@ type options struct {
 	withTestTofu       []byte
 	withListingConvert bool
 	withSessionIds     []string
+	withSessionAudit   func(*Session)
+	withBanner         string
+	withClientCert     *ClientCertificate
 }
 
 func getDefaultOptions() options {
@@ -84,6 +87,34 @@ func WithSessionIds(ids ...string) Option {
 	}
 }
 
+// WithSessionAudit provides an option for a repository to call fn with every
+// session CreateSession creates, once it's committed, so it can be written to
+// an audit sink.
+func WithSessionAudit(fn func(*Session)) Option {
+	return func(o *options) {
+		o.withSessionAudit = fn
+	}
+}
+
+// WithBannerAcknowledgment provides an option for CreateSession to record that
+// the user acknowledged the banner of the session's target, in the same
+// transaction as the session.
+func WithBannerAcknowledgment(banner string) Option {
+	return func(o *options) {
+		o.withBanner = banner
+	}
+}
+
+// WithClientCertificate provides an option for CreateSession to store the
+// client certificate issued for the session, in the same transaction as the
+// session. Its private key is encrypted with the database key of the
+// session's scope.
+func WithClientCertificate(c *ClientCertificate) Option {
+	return func(o *options) {
+		o.withClientCert = c
+	}
+}
+
 func withListingConvert(withListingConvert bool) Option {
 	return func(o *options) {
 		o.withListingConvert = withListingConvert
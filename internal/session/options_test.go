@@ -77,4 +77,27 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withSessionIds = []string{"s_1", "s_2", "s_3"}
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithSessionAudit", func(t *testing.T) {
+		assert := assert.New(t)
+		var called bool
+		opts := getOpts(WithSessionAudit(func(*Session) { called = true }))
+		assert.NotNil(opts.withSessionAudit)
+		opts.withSessionAudit(&Session{})
+		assert.True(called)
+	})
+	t.Run("WithBannerAcknowledgment", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithBannerAcknowledgment("Authorized use only."))
+		testOpts := getDefaultOptions()
+		testOpts.withBanner = "Authorized use only."
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithClientCertificate", func(t *testing.T) {
+		assert := assert.New(t)
+		cert := &ClientCertificate{ServerName: "db.internal"}
+		opts := getOpts(WithClientCertificate(cert))
+		testOpts := getDefaultOptions()
+		testOpts.withClientCert = cert
+		assert.Equal(opts, testOpts)
+	})
 }
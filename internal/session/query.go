@@ -100,11 +100,12 @@ with active_session as (
 	where
 		-- check that the session hasn't expired.
 		s.expiration_time > now() and
-		-- check that there are still connections available. connection_limit of -1 equals unlimited connections
+		-- check that there are still connections available. connection_limit of -1 equals unlimited connections.
+		-- connections which can still be resumed don't count towards the limit.
 		(
 			s.connection_limit = -1
 				or 
-			s.connection_limit > (select count(*) from session_connection sc where sc.session_id = $1)
+			s.connection_limit > session_connection_count($1)
 		) and
 		-- check that there's a state of active
 		s.public_id in (
@@ -125,11 +126,7 @@ select * from active_session;
 	remainingConnectionsCte = `
 with
 session_connection_count(current_connection_count) as (
-	select count(*) 
-	from 
-		session_connection sc
-	where
-		sc.session_id = $1
+	select session_connection_count($1)
 ),
 session_connection_limit(expiration_time, connection_limit) as (
 	select 
@@ -160,6 +157,7 @@ where
 	//	* sessions that are expired and all their connections are closed.
 	// 	* sessions that are canceling and all their connections are closed
 	//  * sessions that have exhausted their connection limit and all their connections are closed.
+	//    Connections which can still be resumed don't count towards the limit.
 	termSessionsUpdate = `
 with canceling_session(session_id) as
 (
@@ -198,12 +196,7 @@ where
 		(
 			-- handle unlimited connections...
 			connection_limit != -1 and
-			(
-			select count (*) 
-				from session_connection sc 
-			where 
-				sc.session_id = us.public_id
-			) >= connection_limit
+			session_connection_count(us.public_id) >= connection_limit
 		) or 
 		-- canceled sessions
 		us.public_id in (
@@ -233,3 +226,72 @@ where
 )
 `
 )
+
+const (
+	// sessionHostDriftTemplate selects the sessions which haven't terminated
+	// whose host was deleted, whose host set was deleted, or whose host is no
+	// longer a member of its host set. It's formatted with an optional where
+	// clause and a limit.
+	sessionHostDriftTemplate = `
+select
+	s.public_id,
+	s.scope_id,
+	s.target_id,
+	s.host_set_id,
+	s.host_id,
+	s.user_id,
+	ss.state
+from
+	session s
+	join session_state ss
+		on ss.session_id = s.public_id
+		and ss.end_time is null
+where
+	ss.state != 'terminated' and
+	(
+		s.host_id is null or
+		s.host_set_id is null or
+		not exists (
+			select 1
+			from static_host_set_member m
+			where
+				m.set_id = s.host_set_id and
+				m.host_id = s.host_id
+		)
+	)
+	%s
+order by s.create_time, s.public_id
+%s;
+`
+
+	// userSessionsTemplate selects the sessions of a user, newest first, with
+	// their current state and the addresses of the clients which connected
+	// through them. It's formatted with a limit.
+	userSessionsTemplate = `
+select
+	s.public_id,
+	s.scope_id,
+	s.target_id,
+	s.auth_token_id,
+	ss.state,
+	s.termination_reason,
+	s.create_time,
+	array_to_string(array(
+		select distinct host(c.client_tcp_address)
+		from session_connection c
+		where
+			c.session_id = s.public_id and
+			c.client_tcp_address is not null
+		order by 1
+	), ',')
+from
+	session s
+	join session_state ss
+		on ss.session_id = s.public_id
+		and ss.end_time is null
+where
+	s.user_id = $1
+order by s.create_time desc, s.public_id
+%s;
+`
+)
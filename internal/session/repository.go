@@ -10,11 +10,6 @@ import (
 	"github.com/hashicorp/boundary/internal/kms"
 )
 
-// Clonable provides a cloning interface
-type Cloneable interface {
-	Clone() interface{}
-}
-
 // Repository is the session database repository
 type Repository struct {
 	reader db.Reader
@@ -23,10 +18,14 @@ type Repository struct {
 
 	// defaultLimit provides a default for limiting the number of results returned from the repo
 	defaultLimit int
+
+	// sessionAudit, if set, is called with the sessions CreateSession creates.
+	sessionAudit func(*Session)
 }
 
 // NewRepository creates a new session Repository. Supports the options: WithLimit
-// which sets a default limit on results returned by repo operations.
+// which sets a default limit on results returned by repo operations, and
+// WithSessionAudit.
 func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*Repository, error) {
 	if r == nil {
 		return nil, errors.New("error creating db repository with nil reader")
@@ -47,6 +46,7 @@ func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*Repo
 		writer:       w,
 		kms:          kms,
 		defaultLimit: opts.withLimit,
+		sessionAudit: opts.withSessionAudit,
 	}, nil
 }
 
@@ -85,26 +85,29 @@ func (r *Repository) convertToSessions(ctx context.Context, sessionsWithState []
 			}
 			prevSessionId = sv.PublicId
 			workingSession = &Session{
-				PublicId:          sv.PublicId,
-				UserId:            sv.UserId,
-				HostId:            sv.HostId,
-				ServerId:          sv.ServerId,
-				ServerType:        sv.ServerType,
-				TargetId:          sv.TargetId,
-				HostSetId:         sv.HostSetId,
-				AuthTokenId:       sv.AuthTokenId,
-				ScopeId:           sv.ScopeId,
-				Certificate:       sv.Certificate,
-				ExpirationTime:    sv.ExpirationTime,
-				CtTofuToken:       sv.CtTofuToken,
-				TofuToken:         sv.TofuToken, // will always be nil since it's not stored in the database.
-				TerminationReason: sv.TerminationReason,
-				CreateTime:        sv.CreateTime,
-				UpdateTime:        sv.UpdateTime,
-				Version:           sv.Version,
-				Endpoint:          sv.Endpoint,
-				ConnectionLimit:   sv.ConnectionLimit,
-				KeyId:             sv.KeyId}
+				PublicId:           sv.PublicId,
+				UserId:             sv.UserId,
+				HostId:             sv.HostId,
+				ServerId:           sv.ServerId,
+				ServerType:         sv.ServerType,
+				TargetId:           sv.TargetId,
+				HostSetId:          sv.HostSetId,
+				AuthTokenId:        sv.AuthTokenId,
+				ScopeId:            sv.ScopeId,
+				Certificate:        sv.Certificate,
+				ExpirationTime:     sv.ExpirationTime,
+				CtTofuToken:        sv.CtTofuToken,
+				TofuToken:          sv.TofuToken, // will always be nil since it's not stored in the database.
+				TerminationReason:  sv.TerminationReason,
+				CreateTime:         sv.CreateTime,
+				UpdateTime:         sv.UpdateTime,
+				Version:            sv.Version,
+				Endpoint:           sv.Endpoint,
+				ConnectionLimit:    sv.ConnectionLimit,
+				Justification:      sv.Justification,
+				ChangeTicketSystem: sv.ChangeTicketSystem,
+				ChangeTicket:       sv.ChangeTicket,
+				KeyId:              sv.KeyId}
 			if opts.withListingConvert {
 				workingSession.CtTofuToken = nil // CtTofuToken should not returned in lists
 				workingSession.TofuToken = nil   // TofuToken should not returned in lists
@@ -0,0 +1,91 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// HostDriftReason is why a session's host no longer matches its host catalog.
+type HostDriftReason string
+
+const (
+	// HostDeleted means the session's host was deleted.
+	HostDeleted HostDriftReason = "host deleted"
+
+	// HostSetDeleted means the host set the session's host was chosen from
+	// was deleted.
+	HostSetDeleted HostDriftReason = "host set deleted"
+
+	// HostRemovedFromSet means the session's host was removed from the host
+	// set it was chosen from.
+	HostRemovedFromSet HostDriftReason = "host removed from set"
+)
+
+// HostDrift is a session which hasn't terminated whose host is no longer in
+// the host set it was chosen from.
+type HostDrift struct {
+	SessionId string
+	Status    Status
+	ScopeId   string
+	TargetId  string
+	HostSetId string
+	HostId    string
+	UserId    string
+	Reason    HostDriftReason
+}
+
+// ReconcileHostMembership cross-references the sessions which haven't
+// terminated against the current membership of host sets, and reports the
+// sessions whose host has since been deleted or removed from the host set it
+// was chosen from, oldest first. It's meant for verifying changes to host
+// catalogs; nothing is changed. Supports the WithLimit and WithScopeId
+// options.
+func (r *Repository) ReconcileHostMembership(ctx context.Context, opt ...Option) ([]*HostDrift, error) {
+	opts := getOpts(opt...)
+	var where string
+	var args []interface{}
+	if opts.withScopeId != "" {
+		where, args = "and s.scope_id = $1", append(args, opts.withScopeId)
+	}
+
+	var limit string
+	switch {
+	case opts.withLimit < 0: // any negative number signals unlimited results
+	case opts.withLimit == 0: // zero signals the default value and default limits
+		limit = fmt.Sprintf("limit %d", r.defaultLimit)
+	default:
+		// non-zero signals an override of the default limit for the repo.
+		limit = fmt.Sprintf("limit %d", opts.withLimit)
+	}
+
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(sessionHostDriftTemplate, where, limit), args)
+	if err != nil {
+		return nil, fmt.Errorf("reconcile host membership: query failed: %w", err)
+	}
+	defer rows.Close()
+	var drifts []*HostDrift
+	for rows.Next() {
+		var targetId, hostSetId, hostId, userId sql.NullString
+		var status string
+		d := &HostDrift{}
+		if err := rows.Scan(&d.SessionId, &d.ScopeId, &targetId, &hostSetId, &hostId, &userId, &status); err != nil {
+			return nil, fmt.Errorf("reconcile host membership: scan row failed: %w", err)
+		}
+		d.TargetId, d.HostSetId, d.HostId, d.UserId = targetId.String, hostSetId.String, hostId.String, userId.String
+		d.Status = Status(status)
+		switch {
+		case d.HostId == "":
+			d.Reason = HostDeleted
+		case d.HostSetId == "":
+			d.Reason = HostSetDeleted
+		default:
+			d.Reason = HostRemovedFromSet
+		}
+		drifts = append(drifts, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reconcile host membership: %w", err)
+	}
+	return drifts, nil
+}
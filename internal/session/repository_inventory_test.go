@@ -0,0 +1,90 @@
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ReconcileHostMembership(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	rw := db.New(conn)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+
+	exec := func(sql string, args ...interface{}) {
+		t.Helper()
+		_, err := rw.Exec(ctx, sql, args)
+		require.NoError(err)
+	}
+
+	unchanged := TestDefaultSession(t, conn, wrapper, iamRepo)
+	removed := TestDefaultSession(t, conn, wrapper, iamRepo)
+	exec("delete from static_host_set_member where set_id = ? and host_id = ?", removed.HostSetId, removed.HostId)
+	hostDeleted := TestDefaultSession(t, conn, wrapper, iamRepo)
+	exec("delete from static_host where public_id = ?", hostDeleted.HostId)
+	setDeleted := TestDefaultSession(t, conn, wrapper, iamRepo)
+	exec("delete from static_host_set where public_id = ?", setDeleted.HostSetId)
+	terminated := TestDefaultSession(t, conn, wrapper, iamRepo)
+	exec("delete from static_host where public_id = ?", terminated.HostId)
+	_, err = repo.TerminateSession(ctx, terminated.PublicId, terminated.Version, ClosedByUser)
+	require.NoError(err)
+
+	// Deleting a host or host set cancels its sessions, but removing a host
+	// from a set doesn't
+	got, err := repo.ReconcileHostMembership(ctx)
+	require.NoError(err)
+	want := []*HostDrift{
+		{
+			SessionId: removed.PublicId,
+			Status:    StatusPending,
+			ScopeId:   removed.ScopeId,
+			TargetId:  removed.TargetId,
+			HostSetId: removed.HostSetId,
+			HostId:    removed.HostId,
+			UserId:    removed.UserId,
+			Reason:    HostRemovedFromSet,
+		},
+		{
+			SessionId: hostDeleted.PublicId,
+			Status:    StatusCanceling,
+			ScopeId:   hostDeleted.ScopeId,
+			TargetId:  hostDeleted.TargetId,
+			HostSetId: hostDeleted.HostSetId,
+			UserId:    hostDeleted.UserId,
+			Reason:    HostDeleted,
+		},
+		{
+			SessionId: setDeleted.PublicId,
+			Status:    StatusCanceling,
+			ScopeId:   setDeleted.ScopeId,
+			TargetId:  setDeleted.TargetId,
+			HostId:    setDeleted.HostId,
+			UserId:    setDeleted.UserId,
+			Reason:    HostSetDeleted,
+		},
+	}
+	assert.Equal(want, got)
+	for _, d := range got {
+		assert.NotEqual(unchanged.PublicId, d.SessionId)
+	}
+
+	got, err = repo.ReconcileHostMembership(ctx, WithScopeId(removed.ScopeId))
+	require.NoError(err)
+	assert.Equal(want[:1], got)
+
+	got, err = repo.ReconcileHostMembership(ctx, WithLimit(2))
+	require.NoError(err)
+	assert.Equal(want[:2], got)
+}
@@ -18,8 +18,9 @@ import (
 
 // CreateSession inserts into the repository and returns the new Session with
 // its State of "Pending".  The following fields must be empty when creating a
-// session: ServerId, ServerType, and PublicId.  No options are
-// currently supported.
+// session: ServerId, ServerType, and PublicId.  Supports the option
+// WithBannerAcknowledgment, which records the user's acknowledgment of the
+// target's banner with the session.
 func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.Wrapper, newSession *Session, opt ...Option) (*Session, ed25519.PrivateKey, error) {
 	if newSession == nil {
 		return nil, nil, fmt.Errorf("create session: missing session: %w", db.ErrInvalidParameter)
@@ -63,6 +64,7 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 	if newSession.ExpirationTime == nil || newSession.ExpirationTime.Timestamp.AsTime().IsZero() {
 		return nil, nil, fmt.Errorf("create session: expiration is empty: %w", db.ErrInvalidParameter)
 	}
+	opts := getOpts(opt...)
 
 	id, err := newId()
 	if err != nil {
@@ -76,13 +78,35 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 	newSession.Certificate = certBytes
 	newSession.PublicId = id
 
+	var clientCert *ClientCertificate
+	if opts.withClientCert != nil {
+		if len(opts.withClientCert.Certificate) == 0 || len(opts.withClientCert.PrivateKey) == 0 {
+			return nil, nil, fmt.Errorf("create session: client certificate is missing its certificate or private key: %w", db.ErrInvalidParameter)
+		}
+		databaseWrapper, err := r.kms.GetWrapper(ctx, newSession.ScopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create session: unable to get database wrapper: %w", err)
+		}
+		clientCert = &ClientCertificate{
+			SessionId:      id,
+			Certificate:    opts.withClientCert.Certificate,
+			PrivateKey:     opts.withClientCert.PrivateKey,
+			ServerName:     opts.withClientCert.ServerName,
+			ServerCaCert:   opts.withClientCert.ServerCaCert,
+			ExpirationTime: opts.withClientCert.ExpirationTime,
+		}
+		if err := clientCert.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, nil, fmt.Errorf("create session: %w", err)
+		}
+	}
+
 	var returnedSession *Session
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(read db.Reader, w db.Writer) error {
-			returnedSession = newSession.Clone().(*Session)
+			returnedSession = newSession.Clone()
 			if err = w.Create(ctx, returnedSession); err != nil {
 				return err
 			}
@@ -101,15 +125,83 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 			if returnedSession.States[0].Status != StatusPending {
 				return fmt.Errorf("new session %s state is not valid: %s", returnedSession.PublicId, returnedSession.States[0].Status)
 			}
+			if opts.withBanner != "" {
+				ack := &BannerAcknowledgment{
+					SessionId:   returnedSession.PublicId,
+					TargetId:    returnedSession.TargetId,
+					UserId:      returnedSession.UserId,
+					AuthTokenId: returnedSession.AuthTokenId,
+					Banner:      opts.withBanner,
+					BannerHash:  BannerHash(opts.withBanner),
+				}
+				if err := w.Create(ctx, ack); err != nil {
+					return fmt.Errorf("unable to record banner acknowledgment: %w", err)
+				}
+			}
+			if clientCert != nil {
+				if err := w.Create(ctx, clientCert); err != nil {
+					return fmt.Errorf("unable to store client certificate: %w", err)
+				}
+			}
 			return nil
 		},
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("create session: %w", err)
 	}
+	if r.sessionAudit != nil {
+		r.sessionAudit(returnedSession.Clone())
+	}
 	return returnedSession, privKey, err
 }
 
+// LookupBannerAcknowledgment returns the acknowledgment of the banner recorded
+// for the session. If the banner wasn't acknowledged, nil is returned with no
+// error. No options are currently supported.
+func (r *Repository) LookupBannerAcknowledgment(ctx context.Context, sessionId string, opt ...Option) (*BannerAcknowledgment, error) {
+	if sessionId == "" {
+		return nil, fmt.Errorf("lookup banner acknowledgment: missing session id: %w", db.ErrInvalidParameter)
+	}
+	var ack BannerAcknowledgment
+	if err := r.reader.LookupWhere(ctx, &ack, "session_id = ?", sessionId); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup banner acknowledgment: %w for %s", err, sessionId)
+	}
+	return &ack, nil
+}
+
+// LookupClientCertificate returns the client certificate issued for the
+// session, with its private key decrypted. If the session has no client
+// certificate, nil is returned with no error. No options are currently
+// supported.
+func (r *Repository) LookupClientCertificate(ctx context.Context, sessionId string, opt ...Option) (*ClientCertificate, error) {
+	if sessionId == "" {
+		return nil, fmt.Errorf("lookup client certificate: missing session id: %w", db.ErrInvalidParameter)
+	}
+	var cert ClientCertificate
+	if err := r.reader.LookupWhere(ctx, &cert, "session_id = ?", sessionId); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup client certificate: %w for %s", err, sessionId)
+	}
+	s := AllocSession()
+	s.PublicId = sessionId
+	if err := r.reader.LookupById(ctx, &s); err != nil {
+		return nil, fmt.Errorf("lookup client certificate: unable to look up session: %w for %s", err, sessionId)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, s.ScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(cert.KeyId))
+	if err != nil {
+		return nil, fmt.Errorf("lookup client certificate: unable to get database wrapper: %w", err)
+	}
+	if err := cert.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, fmt.Errorf("lookup client certificate: %w", err)
+	}
+	return &cert, nil
+}
+
 // LookupSession will look up a session in the repository and return the session
 // with its states.  Returned States are ordered by start time descending.  If the
 // session is not found, it will return nil, nil, nil. No options are currently
@@ -314,6 +314,190 @@ func TestRepository_CreateSession(t *testing.T) {
 	}
 }
 
+func TestRepository_CreateSession_Justification(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	var audited []*Session
+	repo, err := NewRepository(rw, rw, kms, WithSessionAudit(func(s *Session) { audited = append(audited, s) }))
+	require.NoError(err)
+	ctx := context.Background()
+
+	c := TestSessionParams(t, conn, wrapper, iamRepo)
+	c.Justification = "INC-1234: checking the disk"
+	s, err := New(c)
+	require.NoError(err)
+	ses, _, err := repo.CreateSession(ctx, wrapper, s)
+	require.NoError(err)
+	assert.Equal(c.Justification, ses.Justification)
+
+	require.Len(audited, 1)
+	assert.Equal(ses.PublicId, audited[0].PublicId)
+	assert.Equal(c.Justification, audited[0].Justification)
+
+	found, _, err := repo.LookupSession(ctx, ses.PublicId)
+	require.NoError(err)
+	assert.Equal(c.Justification, found.Justification)
+	listed, err := repo.ListSessions(ctx, WithScopeId(c.ScopeId))
+	require.NoError(err)
+	require.Len(listed, 1)
+	assert.Equal(c.Justification, listed[0].Justification)
+
+	// The justification can't be changed
+	found.Justification = "changed"
+	_, err = rw.Update(ctx, found, []string{"Justification"}, nil)
+	assert.Error(err)
+}
+
+func TestRepository_CreateSession_ChangeTicket(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+
+	c := TestSessionParams(t, conn, wrapper, iamRepo)
+	c.ChangeTicketSystem = "jira"
+	c.ChangeTicket = "CHG-1234"
+	s, err := New(c)
+	require.NoError(err)
+	ses, _, err := repo.CreateSession(ctx, wrapper, s)
+	require.NoError(err)
+	assert.Equal("jira", ses.ChangeTicketSystem)
+	assert.Equal("CHG-1234", ses.ChangeTicket)
+
+	found, _, err := repo.LookupSession(ctx, ses.PublicId)
+	require.NoError(err)
+	assert.Equal("jira", found.ChangeTicketSystem)
+	assert.Equal("CHG-1234", found.ChangeTicket)
+	listed, err := repo.ListSessions(ctx, WithScopeId(c.ScopeId))
+	require.NoError(err)
+	require.Len(listed, 1)
+	assert.Equal("CHG-1234", listed[0].ChangeTicket)
+
+	// The change ticket can't be changed
+	found.ChangeTicket = "CHG-5678"
+	_, err = rw.Update(ctx, found, []string{"ChangeTicket"}, nil)
+	assert.Error(err)
+}
+
+func TestRepository_CreateSession_BannerAcknowledgment(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+
+	_, err = repo.LookupBannerAcknowledgment(ctx, "")
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+
+	// A session created without an acknowledgment has none
+	s, err := New(TestSessionParams(t, conn, wrapper, iamRepo))
+	require.NoError(err)
+	ses, _, err := repo.CreateSession(ctx, wrapper, s)
+	require.NoError(err)
+	got, err := repo.LookupBannerAcknowledgment(ctx, ses.PublicId)
+	require.NoError(err)
+	assert.Nil(got)
+
+	const banner = "Authorized use only."
+	s, err = New(TestSessionParams(t, conn, wrapper, iamRepo))
+	require.NoError(err)
+	ses, _, err = repo.CreateSession(ctx, wrapper, s, WithBannerAcknowledgment(banner))
+	require.NoError(err)
+	got, err = repo.LookupBannerAcknowledgment(ctx, ses.PublicId)
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal(ses.TargetId, got.TargetId)
+	assert.Equal(ses.UserId, got.UserId)
+	assert.Equal(ses.AuthTokenId, got.AuthTokenId)
+	assert.Equal(banner, got.Banner)
+	assert.Equal(BannerHash(banner), got.BannerHash)
+	assert.NotNil(got.CreateTime)
+
+	// The hash must be the banner's
+	got.SessionId, got.BannerHash = "s_1234567890", BannerHash("Changed.")
+	assert.Error(rw.Create(ctx, got))
+
+	// The acknowledgment is kept after the session is deleted
+	_, err = repo.DeleteSession(ctx, ses.PublicId)
+	require.NoError(err)
+	got, err = repo.LookupBannerAcknowledgment(ctx, ses.PublicId)
+	require.NoError(err)
+	assert.NotNil(got)
+}
+
+func TestRepository_CreateSession_ClientCertificate(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+
+	_, err = repo.LookupClientCertificate(ctx, "")
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+
+	// A session created without a client certificate has none
+	s, err := New(TestSessionParams(t, conn, wrapper, iamRepo))
+	require.NoError(err)
+	ses, _, err := repo.CreateSession(ctx, wrapper, s)
+	require.NoError(err)
+	got, err := repo.LookupClientCertificate(ctx, ses.PublicId)
+	require.NoError(err)
+	assert.Nil(got)
+
+	s, err = New(TestSessionParams(t, conn, wrapper, iamRepo))
+	require.NoError(err)
+	_, _, err = repo.CreateSession(ctx, wrapper, s, WithClientCertificate(&ClientCertificate{Certificate: []byte("cert")}))
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+
+	cert := &ClientCertificate{
+		Certificate:    []byte("cert"),
+		PrivateKey:     []byte("key"),
+		ServerName:     "db.internal",
+		ExpirationTime: s.ExpirationTime,
+	}
+	s, err = New(TestSessionParams(t, conn, wrapper, iamRepo))
+	require.NoError(err)
+	ses, _, err = repo.CreateSession(ctx, wrapper, s, WithClientCertificate(cert))
+	require.NoError(err)
+	got, err = repo.LookupClientCertificate(ctx, ses.PublicId)
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal(ses.PublicId, got.SessionId)
+	assert.Equal(cert.Certificate, got.Certificate)
+	assert.Equal(cert.PrivateKey, got.PrivateKey)
+	assert.NotEqual(cert.PrivateKey, got.CtPrivateKey)
+	assert.Equal(cert.ServerName, got.ServerName)
+	assert.NotEmpty(got.KeyId)
+
+	// The certificate is deleted with its session
+	_, err = repo.DeleteSession(ctx, ses.PublicId)
+	require.NoError(err)
+	got, err = repo.LookupClientCertificate(ctx, ses.PublicId)
+	require.NoError(err)
+	assert.Nil(got)
+}
+
 func TestRepository_updateState(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -1558,3 +1742,81 @@ func TestRepository_DeleteSession(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_ResumeSession(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	setupFn := func(resumeWindow uint32) *Session {
+		composedOf := TestSessionParams(t, conn, wrapper, iamRepo)
+		composedOf.ConnectionLimit = 1
+		composedOf.ResumeWindowSeconds = resumeWindow
+		s := TestSession(t, conn, wrapper, composedOf)
+		srv := TestWorker(t, conn, wrapper)
+		tofu := TestTofu(t)
+		s, _, err := repo.ActivateSession(ctx, s.PublicId, s.Version, srv.PrivateId, srv.Type, tofu)
+		require.NoError(t, err)
+		return s
+	}
+	connectFn := func(s *Session, reason ClosedReason) error {
+		c, _, _, err := repo.AuthorizeConnection(ctx, s.PublicId)
+		if err != nil {
+			return err
+		}
+		_, _, err = repo.ConnectConnection(ctx, ConnectWith{
+			ConnectionId:       c.PublicId,
+			ClientTcpAddress:   "127.0.0.1",
+			ClientTcpPort:      22,
+			EndpointTcpAddress: "127.0.0.1",
+			EndpointTcpPort:    2222,
+		})
+		require.NoError(t, err)
+		_, err = repo.CloseConnections(ctx, []CloseWith{{
+			ConnectionId: c.PublicId,
+			BytesUp:      1,
+			BytesDown:    1,
+			ClosedReason: reason,
+		}})
+		require.NoError(t, err)
+		return nil
+	}
+
+	t.Run("resumable", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := setupFn(60)
+		require.NoError(connectFn(s, ConnectionNetworkError))
+
+		// The lost connection doesn't count towards the limit within the
+		// window, so the session isn't terminated and can be resumed.
+		_, err := repo.TerminateCompletedSessions(ctx)
+		require.NoError(err)
+		found, _, err := repo.LookupSession(ctx, s.PublicId)
+		require.NoError(err)
+		assert.Empty(found.TerminationReason)
+		assert.Equal(uint32(60), found.ResumeWindowSeconds)
+
+		require.NoError(connectFn(s, ConnectionClosedByUser))
+		err = connectFn(s, ConnectionClosedByUser)
+		require.Error(err)
+		found, _, err = repo.LookupSession(ctx, s.PublicId)
+		require.NoError(err)
+		assert.Equal(ConnectionLimit.String(), found.TerminationReason)
+	})
+	t.Run("not-resumable", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := setupFn(0)
+		require.NoError(connectFn(s, ConnectionNetworkError))
+		err := connectFn(s, ConnectionNetworkError)
+		require.Error(err)
+		found, _, err := repo.LookupSession(ctx, s.PublicId)
+		require.NoError(err)
+		assert.Equal(ConnectionLimit.String(), found.TerminationReason)
+	})
+}
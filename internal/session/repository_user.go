@@ -0,0 +1,68 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// UserSession is a session as its user reviews it: when it was made, to which
+// target, with which auth token, its status, and the addresses of the clients
+// which connected through it.
+type UserSession struct {
+	SessionId         string
+	ScopeId           string
+	TargetId          string
+	AuthTokenId       string
+	Status            Status
+	TerminationReason string
+	CreateTime        time.Time
+	ClientAddresses   []string
+}
+
+// ListUserSessions returns the sessions of a user, newest first. Supports the
+// WithLimit option.
+func (r *Repository) ListUserSessions(ctx context.Context, userId string, opt ...Option) ([]*UserSession, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("list user sessions: missing user id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	var limit string
+	switch {
+	case opts.withLimit < 0: // any negative number signals unlimited results
+	case opts.withLimit == 0: // zero signals the default value and default limits
+		limit = fmt.Sprintf("limit %d", r.defaultLimit)
+	default:
+		// non-zero signals an override of the default limit for the repo.
+		limit = fmt.Sprintf("limit %d", opts.withLimit)
+	}
+
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(userSessionsTemplate, limit), []interface{}{userId})
+	if err != nil {
+		return nil, fmt.Errorf("list user sessions: query failed: %w", err)
+	}
+	defer rows.Close()
+	sessions := []*UserSession{}
+	for rows.Next() {
+		var targetId, authTokenId, reason sql.NullString
+		var status, addrs string
+		s := &UserSession{}
+		if err := rows.Scan(&s.SessionId, &s.ScopeId, &targetId, &authTokenId, &status, &reason, &s.CreateTime, &addrs); err != nil {
+			return nil, fmt.Errorf("list user sessions: scan row failed: %w", err)
+		}
+		s.TargetId, s.AuthTokenId, s.TerminationReason = targetId.String, authTokenId.String, reason.String
+		s.Status = Status(status)
+		if addrs != "" {
+			s.ClientAddresses = strings.Split(addrs, ",")
+		}
+		sessions = append(sessions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list user sessions: %w", err)
+	}
+	return sessions, nil
+}
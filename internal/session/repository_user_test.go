@@ -0,0 +1,59 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ListUserSessions(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	rw := db.New(conn)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+
+	params := TestSessionParams(t, conn, wrapper, iamRepo)
+	older := TestSession(t, conn, wrapper, params)
+	newer := TestSession(t, conn, wrapper, params)
+	_ = TestConnection(t, conn, newer.PublicId, "127.0.0.2", 22, "127.0.0.1", 2222)
+	_ = TestConnection(t, conn, newer.PublicId, "127.0.0.1", 23, "127.0.0.1", 2222)
+	_ = TestConnection(t, conn, newer.PublicId, "127.0.0.1", 24, "127.0.0.1", 2222)
+	other := TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	got, err := repo.ListUserSessions(ctx, params.UserId)
+	require.NoError(err)
+	require.Len(got, 2)
+	assert.Equal(newer.PublicId, got[0].SessionId)
+	assert.Equal(params.TargetId, got[0].TargetId)
+	assert.Equal(params.ScopeId, got[0].ScopeId)
+	assert.Equal(params.AuthTokenId, got[0].AuthTokenId)
+	assert.Equal(StatusPending, got[0].Status)
+	assert.Equal([]string{"127.0.0.1", "127.0.0.2"}, got[0].ClientAddresses)
+	assert.Equal(older.PublicId, got[1].SessionId)
+	assert.Empty(got[1].ClientAddresses)
+
+	got, err = repo.ListUserSessions(ctx, params.UserId, WithLimit(1))
+	require.NoError(err)
+	require.Len(got, 1)
+	assert.Equal(newer.PublicId, got[0].SessionId)
+
+	got, err = repo.ListUserSessions(ctx, other.UserId)
+	require.NoError(err)
+	require.Len(got, 1)
+	assert.Equal(other.PublicId, got[0].SessionId)
+
+	_, err = repo.ListUserSessions(ctx, "")
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+}
@@ -44,6 +44,17 @@ type ComposedOf struct {
 	ExpirationTime *timestamp.Timestamp
 	// Max connections for the session
 	ConnectionLimit int32
+	// Seconds after a connection is lost to a network error during which the
+	// session can be resumed. 0 disables resumption
+	ResumeWindowSeconds uint32
+	// Justification the user gave for the session, such as a ticket number
+	Justification string
+	// ChangeTicketSystem is the change ticket system of the target which
+	// validated the session's ChangeTicket
+	ChangeTicketSystem string
+	// ChangeTicket is the change ticket reference the user gave for the
+	// session
+	ChangeTicket string
 }
 
 // Session contains information about a user's session with a target
@@ -88,6 +99,17 @@ type Session struct {
 	Endpoint string `json:"-" gorm:"default:null"`
 	// Maximum number of connections in a session
 	ConnectionLimit int32 `json:"connection_limit,omitempty" gorm:"default:null"`
+	// Seconds after a connection is lost to a network error during which the
+	// session can be resumed by reconnecting
+	ResumeWindowSeconds uint32 `json:"resume_window_seconds,omitempty" gorm:"default:null"`
+	// Justification the user gave for the session, such as a ticket number
+	Justification string `json:"justification,omitempty" gorm:"default:null"`
+	// ChangeTicketSystem is the change ticket system of the target which
+	// validated the session's ChangeTicket
+	ChangeTicketSystem string `json:"change_ticket_system,omitempty" gorm:"default:null"`
+	// ChangeTicket is the change ticket reference the user gave for the
+	// session
+	ChangeTicket string `json:"change_ticket,omitempty" gorm:"default:null"`
 
 	// key_id is the key ID that was used for the encryption operation. It can be
 	// used to identify a specific version of the key needed to decrypt the value,
@@ -105,21 +127,24 @@ func (s *Session) GetPublicId() string {
 	return s.PublicId
 }
 
-var _ Cloneable = (*Session)(nil)
 var _ db.VetForWriter = (*Session)(nil)
 
 // New creates a new in memory session.
 func New(c ComposedOf, opt ...Option) (*Session, error) {
 	s := Session{
-		UserId:          c.UserId,
-		HostId:          c.HostId,
-		TargetId:        c.TargetId,
-		HostSetId:       c.HostSetId,
-		AuthTokenId:     c.AuthTokenId,
-		ScopeId:         c.ScopeId,
-		Endpoint:        c.Endpoint,
-		ExpirationTime:  c.ExpirationTime,
-		ConnectionLimit: c.ConnectionLimit,
+		UserId:              c.UserId,
+		HostId:              c.HostId,
+		TargetId:            c.TargetId,
+		HostSetId:           c.HostSetId,
+		AuthTokenId:         c.AuthTokenId,
+		ScopeId:             c.ScopeId,
+		Endpoint:            c.Endpoint,
+		ExpirationTime:      c.ExpirationTime,
+		ConnectionLimit:     c.ConnectionLimit,
+		ResumeWindowSeconds: c.ResumeWindowSeconds,
+		Justification:       c.Justification,
+		ChangeTicketSystem:  c.ChangeTicketSystem,
+		ChangeTicket:        c.ChangeTicket,
 	}
 	if err := s.validateNewSession("new session:"); err != nil {
 		return nil, err
@@ -133,26 +158,30 @@ func AllocSession() Session {
 }
 
 // Clone creates a clone of the Session
-func (s *Session) Clone() interface{} {
+func (s *Session) Clone() *Session {
 	clone := &Session{
-		PublicId:          s.PublicId,
-		UserId:            s.UserId,
-		HostId:            s.HostId,
-		ServerId:          s.ServerId,
-		ServerType:        s.ServerType,
-		TargetId:          s.TargetId,
-		HostSetId:         s.HostSetId,
-		AuthTokenId:       s.AuthTokenId,
-		ScopeId:           s.ScopeId,
-		TerminationReason: s.TerminationReason,
-		Version:           s.Version,
-		Endpoint:          s.Endpoint,
-		ConnectionLimit:   s.ConnectionLimit,
+		PublicId:            s.PublicId,
+		UserId:              s.UserId,
+		HostId:              s.HostId,
+		ServerId:            s.ServerId,
+		ServerType:          s.ServerType,
+		TargetId:            s.TargetId,
+		HostSetId:           s.HostSetId,
+		AuthTokenId:         s.AuthTokenId,
+		ScopeId:             s.ScopeId,
+		TerminationReason:   s.TerminationReason,
+		Version:             s.Version,
+		Endpoint:            s.Endpoint,
+		ConnectionLimit:     s.ConnectionLimit,
+		ResumeWindowSeconds: s.ResumeWindowSeconds,
+		Justification:       s.Justification,
+		ChangeTicketSystem:  s.ChangeTicketSystem,
+		ChangeTicket:        s.ChangeTicket,
 	}
 	if len(s.States) > 0 {
 		clone.States = make([]*State, 0, len(s.States))
 		for _, ss := range s.States {
-			cp := ss.Clone().(*State)
+			cp := ss.Clone()
 			clone.States = append(clone.States, cp)
 		}
 	}
@@ -236,6 +265,14 @@ func (s *Session) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType
 			return fmt.Errorf("session vet for write: expiration time is immutable: %w", db.ErrInvalidParameter)
 		case contains(opts.WithFieldMaskPaths, "ConnectionLimit"):
 			return fmt.Errorf("session vet for write: connection limit is immutable: %w", db.ErrInvalidParameter)
+		case contains(opts.WithFieldMaskPaths, "ResumeWindowSeconds"):
+			return fmt.Errorf("session vet for write: resume window is immutable: %w", db.ErrInvalidParameter)
+		case contains(opts.WithFieldMaskPaths, "Justification"):
+			return fmt.Errorf("session vet for write: justification is immutable: %w", db.ErrInvalidParameter)
+		case contains(opts.WithFieldMaskPaths, "ChangeTicketSystem"):
+			return fmt.Errorf("session vet for write: change ticket system is immutable: %w", db.ErrInvalidParameter)
+		case contains(opts.WithFieldMaskPaths, "ChangeTicket"):
+			return fmt.Errorf("session vet for write: change ticket is immutable: %w", db.ErrInvalidParameter)
 		case contains(opts.WithFieldMaskPaths, "TerminationReason"):
 			if _, err := convertToReason(s.TerminationReason); err != nil {
 				return fmt.Errorf("session vet for write: termination reason '%s' is invalid: %w", s.TerminationReason, db.ErrInvalidParameter)
@@ -365,26 +402,29 @@ func (s *Session) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
 
 type sessionView struct {
 	// Session fields
-	PublicId          string               `json:"public_id,omitempty" gorm:"primary_key"`
-	UserId            string               `json:"user_id,omitempty" gorm:"default:null"`
-	HostId            string               `json:"host_id,omitempty" gorm:"default:null"`
-	ServerId          string               `json:"server_id,omitempty" gorm:"default:null"`
-	ServerType        string               `json:"server_type,omitempty" gorm:"default:null"`
-	TargetId          string               `json:"target_id,omitempty" gorm:"default:null"`
-	HostSetId         string               `json:"host_set_id,omitempty" gorm:"default:null"`
-	AuthTokenId       string               `json:"auth_token_id,omitempty" gorm:"default:null"`
-	ScopeId           string               `json:"scope_id,omitempty" gorm:"default:null"`
-	Certificate       []byte               `json:"certificate,omitempty" gorm:"default:null"`
-	ExpirationTime    *timestamp.Timestamp `json:"expiration_time,omitempty" gorm:"default:null"`
-	CtTofuToken       []byte               `json:"ct_tofu_token,omitempty" gorm:"column:tofu_token;default:null" wrapping:"ct,tofu_token"`
-	TofuToken         []byte               `json:"tofu_token,omitempty" gorm:"-" wrapping:"pt,tofu_token"`
-	TerminationReason string               `json:"termination_reason,omitempty" gorm:"default:null"`
-	CreateTime        *timestamp.Timestamp `json:"create_time,omitempty" gorm:"default:current_timestamp"`
-	UpdateTime        *timestamp.Timestamp `json:"update_time,omitempty" gorm:"default:current_timestamp"`
-	Version           uint32               `json:"version,omitempty" gorm:"default:null"`
-	Endpoint          string               `json:"-" gorm:"default:null"`
-	ConnectionLimit   int32                `json:"connection_limit,omitempty" gorm:"default:null"`
-	KeyId             string               `json:"key_id,omitempty" gorm:"not_null"`
+	PublicId           string               `json:"public_id,omitempty" gorm:"primary_key"`
+	UserId             string               `json:"user_id,omitempty" gorm:"default:null"`
+	HostId             string               `json:"host_id,omitempty" gorm:"default:null"`
+	ServerId           string               `json:"server_id,omitempty" gorm:"default:null"`
+	ServerType         string               `json:"server_type,omitempty" gorm:"default:null"`
+	TargetId           string               `json:"target_id,omitempty" gorm:"default:null"`
+	HostSetId          string               `json:"host_set_id,omitempty" gorm:"default:null"`
+	AuthTokenId        string               `json:"auth_token_id,omitempty" gorm:"default:null"`
+	ScopeId            string               `json:"scope_id,omitempty" gorm:"default:null"`
+	Certificate        []byte               `json:"certificate,omitempty" gorm:"default:null"`
+	ExpirationTime     *timestamp.Timestamp `json:"expiration_time,omitempty" gorm:"default:null"`
+	CtTofuToken        []byte               `json:"ct_tofu_token,omitempty" gorm:"column:tofu_token;default:null" wrapping:"ct,tofu_token"`
+	TofuToken          []byte               `json:"tofu_token,omitempty" gorm:"-" wrapping:"pt,tofu_token"`
+	TerminationReason  string               `json:"termination_reason,omitempty" gorm:"default:null"`
+	CreateTime         *timestamp.Timestamp `json:"create_time,omitempty" gorm:"default:current_timestamp"`
+	UpdateTime         *timestamp.Timestamp `json:"update_time,omitempty" gorm:"default:current_timestamp"`
+	Version            uint32               `json:"version,omitempty" gorm:"default:null"`
+	Endpoint           string               `json:"-" gorm:"default:null"`
+	ConnectionLimit    int32                `json:"connection_limit,omitempty" gorm:"default:null"`
+	Justification      string               `json:"justification,omitempty" gorm:"default:null"`
+	ChangeTicketSystem string               `json:"change_ticket_system,omitempty" gorm:"default:null"`
+	ChangeTicket       string               `json:"change_ticket,omitempty" gorm:"default:null"`
+	KeyId              string               `json:"key_id,omitempty" gorm:"not_null"`
 
 	// State fields
 	Status          string               `json:"state,omitempty" gorm:"column:state"`
@@ -227,7 +227,7 @@ func TestSession_Clone(t *testing.T) {
 		assert := assert.New(t)
 		s := TestDefaultSession(t, conn, wrapper, iamRepo)
 		cp := s.Clone()
-		assert.Equal(cp.(*Session), s)
+		assert.Equal(cp, s)
 	})
 	t.Run("not-equal", func(t *testing.T) {
 		assert := assert.New(t)
@@ -235,7 +235,7 @@ func TestSession_Clone(t *testing.T) {
 		s2 := TestDefaultSession(t, conn, wrapper, iamRepo)
 
 		cp := s.Clone()
-		assert.NotEqual(cp.(*Session), s2)
+		assert.NotEqual(cp, s2)
 	})
 }
 
@@ -61,7 +61,6 @@ type State struct {
 	tableName string `gorm:"-"`
 }
 
-var _ Cloneable = (*State)(nil)
 var _ db.VetForWriter = (*State)(nil)
 
 // NewState creates a new in memory session state.  No options
@@ -84,7 +83,7 @@ func allocState() State {
 }
 
 // Clone creates a clone of the State
-func (s *State) Clone() interface{} {
+func (s *State) Clone() *State {
 	clone := &State{
 		SessionId: s.SessionId,
 		Status:    s.Status,
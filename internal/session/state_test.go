@@ -163,7 +163,7 @@ func TestState_Clone(t *testing.T) {
 		s := TestDefaultSession(t, conn, wrapper, iamRepo)
 		state := TestState(t, conn, s.PublicId, StatusPending)
 		cp := state.Clone()
-		assert.Equal(cp.(*State), state)
+		assert.Equal(cp, state)
 	})
 	t.Run("not-equal", func(t *testing.T) {
 		assert := assert.New(t)
@@ -173,7 +173,7 @@ func TestState_Clone(t *testing.T) {
 		state2 := TestState(t, conn, s2.PublicId, StatusPending)
 
 		cp := state.Clone()
-		assert.NotEqual(cp.(*State), state2)
+		assert.NotEqual(cp, state2)
 	})
 }
 
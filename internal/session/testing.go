@@ -146,8 +146,11 @@ func TestSessionParams(t *testing.T, conn *gorm.DB, wrapper wrapping.Wrapper, ia
 	at, err := authTokenRepo.CreateAuthToken(ctx, user, acct.GetPublicId())
 	require.NoError(err)
 
+	policy, err := targetRepo.EffectiveSessionPolicy(ctx, tcpTarget.GetPublicId())
+	require.NoError(err)
+
 	expTime := timestamppb.Now()
-	expTime.Seconds += int64(tcpTarget.GetSessionMaxSeconds())
+	expTime.Seconds += int64(policy.SessionMaxSeconds)
 	return ComposedOf{
 		UserId:          user.PublicId,
 		HostId:          hosts[0].PublicId,
@@ -157,7 +160,7 @@ func TestSessionParams(t *testing.T, conn *gorm.DB, wrapper wrapping.Wrapper, ia
 		ScopeId:         tcpTarget.ScopeId,
 		Endpoint:        "tcp://127.0.0.1:22",
 		ExpirationTime:  &timestamp.Timestamp{Timestamp: expTime},
-		ConnectionLimit: tcpTarget.GetSessionConnectionLimit(),
+		ConnectionLimit: policy.SessionConnectionLimit,
 	}
 }
 
@@ -0,0 +1,278 @@
+package target
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/go-hclog"
+)
+
+// MaxChangeTicketLength is the most characters the change ticket reference
+// of a session can have.
+const MaxChangeTicketLength = 256
+
+var (
+	// ErrChangeTicketRequired is returned when a session to a target with a
+	// change ticket system is authorized without a change ticket.
+	ErrChangeTicketRequired = errors.New("change ticket required")
+
+	// ErrChangeTicketRejected is returned when a target's change ticket
+	// system doesn't accept the change ticket of a session.
+	ErrChangeTicketRejected = errors.New("change ticket rejected")
+
+	// ErrChangeTicketSystemUnknown is returned when a target's change ticket
+	// system isn't registered on the controller.
+	ErrChangeTicketSystemUnknown = errors.New("unknown change ticket system")
+)
+
+// ChangeTicketRequest describes a session being authorized with a change
+// ticket, for the target's change ticket system to validate.
+type ChangeTicketRequest struct {
+	// System is the name the change ticket system is registered with, and
+	// Ticket the reference of the change ticket the user gave, like
+	// "CHG-1234".
+	System string `json:"system"`
+	Ticket string `json:"ticket"`
+
+	TargetId string `json:"target_id"`
+	ScopeId  string `json:"scope_id"`
+	UserId   string `json:"user_id"`
+}
+
+// ChangeTicketSession describes a session authorized with a change ticket,
+// for the change ticket system to record, like by commenting on the ticket.
+type ChangeTicketSession struct {
+	ChangeTicketRequest
+
+	SessionId      string    `json:"session_id"`
+	HostId         string    `json:"host_id"`
+	Endpoint       string    `json:"endpoint"`
+	ExpirationTime time.Time `json:"expiration_time"`
+}
+
+// ChangeTicketSystem validates the change tickets of sessions to the targets
+// which name it, against an external ticketing system like Jira or
+// ServiceNow. Returning nil accepts the ticket; returning an error refuses
+// the session, and the error is returned to the caller wrapping
+// ErrChangeTicketRejected.
+type ChangeTicketSystem interface {
+	ValidateChangeTicket(ctx context.Context, req *ChangeTicketRequest) error
+}
+
+// ChangeTicketSystemFunc is a function which can be registered as a
+// ChangeTicketSystem.
+type ChangeTicketSystemFunc func(ctx context.Context, req *ChangeTicketRequest) error
+
+// ValidateChangeTicket calls f.
+func (f ChangeTicketSystemFunc) ValidateChangeTicket(ctx context.Context, req *ChangeTicketRequest) error {
+	return f(ctx, req)
+}
+
+// ChangeTicketRecorder is implemented by change ticket systems which record
+// the sessions authorized with their tickets. Sessions are recorded once
+// they're created, so a recorder can't refuse them, and reporting its
+// failures is up to it.
+type ChangeTicketRecorder interface {
+	RecordChangeTicketSession(ctx context.Context, s *ChangeTicketSession)
+}
+
+var changeTicketSystems struct {
+	sync.RWMutex
+	nextId  int
+	systems map[string]registeredChangeTicketSystem
+}
+
+type registeredChangeTicketSystem struct {
+	id     int
+	system ChangeTicketSystem
+}
+
+// RegisterChangeTicketSystem registers a change ticket system under the
+// name targets refer to it by, replacing any registered with the name
+// before. It returns a function which unregisters the system.
+func RegisterChangeTicketSystem(name string, s ChangeTicketSystem) (unregister func()) {
+	changeTicketSystems.Lock()
+	defer changeTicketSystems.Unlock()
+	if changeTicketSystems.systems == nil {
+		changeTicketSystems.systems = make(map[string]registeredChangeTicketSystem)
+	}
+	id := changeTicketSystems.nextId
+	changeTicketSystems.nextId++
+	changeTicketSystems.systems[name] = registeredChangeTicketSystem{id: id, system: s}
+	return func() {
+		changeTicketSystems.Lock()
+		defer changeTicketSystems.Unlock()
+		if changeTicketSystems.systems[name].id == id {
+			delete(changeTicketSystems.systems, name)
+		}
+	}
+}
+
+// lookupChangeTicketSystem returns the change ticket system registered with
+// the name, or nil if there isn't one.
+func lookupChangeTicketSystem(name string) ChangeTicketSystem {
+	changeTicketSystems.RLock()
+	defer changeTicketSystems.RUnlock()
+	return changeTicketSystems.systems[name].system
+}
+
+// ValidChangeTicketSystem returns an error if the name can't be the change
+// ticket system of a target.
+func ValidChangeTicketSystem(name string) error {
+	if name != "" && strings.TrimSpace(name) != name {
+		return fmt.Errorf("change ticket system %q has leading or trailing spaces: %w", name, db.ErrInvalidParameter)
+	}
+	return nil
+}
+
+// CheckChangeTicket checks the change ticket given by the user when
+// authorizing a session to the target. A target with a change ticket system
+// requires a ticket which the system accepts; a target without one accepts
+// any ticket, or none. A ticket can't be longer than MaxChangeTicketLength.
+func CheckChangeTicket(ctx context.Context, t Target, userId, ticket string) error {
+	ticket = strings.TrimSpace(ticket)
+	if len([]rune(ticket)) > MaxChangeTicketLength {
+		return fmt.Errorf("change ticket is longer than %d characters: %w", MaxChangeTicketLength, db.ErrInvalidParameter)
+	}
+	name := t.GetChangeTicketSystem()
+	switch {
+	case name == "":
+		return nil
+	case ticket == "":
+		return ErrChangeTicketRequired
+	}
+	s := lookupChangeTicketSystem(name)
+	if s == nil {
+		return fmt.Errorf("%q: %w", name, ErrChangeTicketSystemUnknown)
+	}
+	req := &ChangeTicketRequest{
+		System:   name,
+		Ticket:   ticket,
+		TargetId: t.GetPublicId(),
+		ScopeId:  t.GetScopeId(),
+		UserId:   userId,
+	}
+	if err := s.ValidateChangeTicket(ctx, req); err != nil {
+		return fmt.Errorf("%v: %w", err, ErrChangeTicketRejected)
+	}
+	return nil
+}
+
+// RecordChangeTicketSession tells the session's change ticket system about
+// it, if the system records sessions.
+func RecordChangeTicketSession(ctx context.Context, s *ChangeTicketSession) {
+	if s == nil || s.System == "" {
+		return
+	}
+	if r, ok := lookupChangeTicketSystem(s.System).(ChangeTicketRecorder); ok {
+		r.RecordChangeTicketSession(ctx, s)
+	}
+}
+
+// DefaultHTTPChangeTicketSystemTimeout is how long an HTTPChangeTicketSystem
+// waits for its endpoints, unless its Client sets a timeout.
+const DefaultHTTPChangeTicketSystemTimeout = 10 * time.Second
+
+// HTTPChangeTicketSystem is a ChangeTicketSystem which asks an external
+// endpoint, like a small service in front of Jira or ServiceNow, whether to
+// accept change tickets. It POSTs the ChangeTicketRequest as JSON to
+// ValidateUrl, and expects a 200 response whose body is an object with an
+// "allow" boolean and an optional "reason" string. Tickets are refused if
+// the endpoint can't be reached or doesn't allow them.
+//
+// If SessionUrl is set, the ChangeTicketSession of every session authorized
+// with one of its tickets is POSTed to it as JSON.
+type HTTPChangeTicketSystem struct {
+	// ValidateUrl is the endpoint to POST change ticket requests to.
+	ValidateUrl string
+
+	// SessionUrl, if set, is the endpoint to POST authorized sessions to.
+	SessionUrl string
+
+	// Client is used to make requests. If it's nil, a client with the
+	// DefaultHTTPChangeTicketSystemTimeout is used.
+	Client *http.Client
+
+	// Logger is where the failures to record sessions are logged.
+	Logger hclog.Logger
+}
+
+var (
+	_ ChangeTicketSystem   = (*HTTPChangeTicketSystem)(nil)
+	_ ChangeTicketRecorder = (*HTTPChangeTicketSystem)(nil)
+)
+
+// ValidateChangeTicket asks the system's endpoint whether to accept the
+// change ticket.
+func (h *HTTPChangeTicketSystem) ValidateChangeTicket(ctx context.Context, req *ChangeTicketRequest) error {
+	resp, err := h.post(ctx, h.ValidateUrl, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var decision struct {
+		Allow  bool   `json:"allow"`
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return fmt.Errorf("http change ticket system: unable to decode response: %w", err)
+	}
+	if !decision.Allow {
+		if decision.Reason == "" {
+			decision.Reason = "not allowed"
+		}
+		return fmt.Errorf("http change ticket system: %s", decision.Reason)
+	}
+	return nil
+}
+
+// RecordChangeTicketSession POSTs the session to the system's SessionUrl, if
+// it has one.
+func (h *HTTPChangeTicketSystem) RecordChangeTicketSession(ctx context.Context, s *ChangeTicketSession) {
+	if h.SessionUrl == "" {
+		return
+	}
+	resp, err := h.post(ctx, h.SessionUrl, s)
+	if err != nil {
+		if h.Logger != nil {
+			h.Logger.Error("unable to record session with change ticket system", "system", s.System, "ticket", s.Ticket, "session_id", s.SessionId, "error", err)
+		}
+		return
+	}
+	resp.Body.Close()
+}
+
+// post POSTs body to url as JSON, and returns the response if its status is
+// 200.
+func (h *HTTPChangeTicketSystem) post(ctx context.Context, url string, body interface{}) (*http.Response, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("http change ticket system: unable to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("http change ticket system: unable to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := h.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPChangeTicketSystemTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http change ticket system: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("http change ticket system: unexpected status %s", resp.Status)
+	}
+	return resp, nil
+}
@@ -0,0 +1,188 @@
+package target
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckChangeTicket(t *testing.T) {
+	ctx := context.Background()
+	var got *ChangeTicketRequest
+	unregister := RegisterChangeTicketSystem("test-check", ChangeTicketSystemFunc(func(_ context.Context, req *ChangeTicketRequest) error {
+		got = req
+		if !strings.HasPrefix(req.Ticket, "CHG-") {
+			return errors.New("no such change")
+		}
+		return nil
+	}))
+	defer unregister()
+
+	tests := []struct {
+		name    string
+		system  string
+		ticket  string
+		wantErr error
+	}{
+		{
+			name: "no-system-no-ticket",
+		},
+		{
+			name:   "no-system",
+			ticket: "CHG-1234",
+		},
+		{
+			name:   "accepted",
+			system: "test-check",
+			ticket: " CHG-1234 ",
+		},
+		{
+			name:    "missing",
+			system:  "test-check",
+			wantErr: ErrChangeTicketRequired,
+		},
+		{
+			name:    "blank",
+			system:  "test-check",
+			ticket:  "  ",
+			wantErr: ErrChangeTicketRequired,
+		},
+		{
+			name:    "rejected",
+			system:  "test-check",
+			ticket:  "INC-1234",
+			wantErr: ErrChangeTicketRejected,
+		},
+		{
+			name:    "unknown-system",
+			system:  "test-unknown",
+			ticket:  "CHG-1234",
+			wantErr: ErrChangeTicketSystemUnknown,
+		},
+		{
+			name:    "too-long",
+			ticket:  strings.Repeat("a", MaxChangeTicketLength+1),
+			wantErr: db.ErrInvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got = nil
+			tgt := &TcpTarget{TcpTarget: &store.TcpTarget{
+				PublicId:           "ttcp_1234567890",
+				ScopeId:            "p_1234567890",
+				ChangeTicketSystem: tt.system,
+			}}
+			err := CheckChangeTicket(ctx, tgt, "u_1234567890", tt.ticket)
+			if tt.wantErr != nil {
+				assert.True(errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+				return
+			}
+			assert.NoError(err)
+			if tt.system != "" {
+				assert.Equal(&ChangeTicketRequest{
+					System:   tt.system,
+					Ticket:   strings.TrimSpace(tt.ticket),
+					TargetId: "ttcp_1234567890",
+					ScopeId:  "p_1234567890",
+					UserId:   "u_1234567890",
+				}, got)
+			}
+		})
+	}
+}
+
+func TestRegisterChangeTicketSystem(t *testing.T) {
+	assert := assert.New(t)
+	first := ChangeTicketSystemFunc(func(context.Context, *ChangeTicketRequest) error { return nil })
+	second := ChangeTicketSystemFunc(func(context.Context, *ChangeTicketRequest) error { return errors.New("second") })
+
+	unregisterFirst := RegisterChangeTicketSystem("test-register", first)
+	assert.NotNil(lookupChangeTicketSystem("test-register"))
+	unregisterSecond := RegisterChangeTicketSystem("test-register", second)
+	assert.Error(lookupChangeTicketSystem("test-register").ValidateChangeTicket(context.Background(), &ChangeTicketRequest{}))
+
+	// Unregistering a replaced system leaves its replacement registered.
+	unregisterFirst()
+	assert.NotNil(lookupChangeTicketSystem("test-register"))
+	unregisterSecond()
+	assert.Nil(lookupChangeTicketSystem("test-register"))
+}
+
+func TestValidChangeTicketSystem(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	assert.NoError(ValidChangeTicketSystem(""))
+	assert.NoError(ValidChangeTicketSystem("jira"))
+	assert.True(errors.Is(ValidChangeTicketSystem(" jira"), db.ErrInvalidParameter))
+	assert.True(errors.Is(ValidChangeTicketSystem("jira "), db.ErrInvalidParameter))
+}
+
+func TestHTTPChangeTicketSystem(t *testing.T) {
+	ctx := context.Background()
+	var recorded []*ChangeTicketSession
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/validate":
+			var req ChangeTicketRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			switch req.Ticket {
+			case "CHG-1234":
+				w.Write([]byte(`{"allow":true}`))
+			case "CHG-5678":
+				w.Write([]byte(`{"allow":false,"reason":"change is closed"}`))
+			default:
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		case "/session":
+			var s ChangeTicketSession
+			if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			recorded = append(recorded, &s)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	h := &HTTPChangeTicketSystem{ValidateUrl: srv.URL + "/validate", SessionUrl: srv.URL + "/session"}
+	t.Run("allowed", func(t *testing.T) {
+		assert.NoError(t, h.ValidateChangeTicket(ctx, &ChangeTicketRequest{System: "jira", Ticket: "CHG-1234"}))
+	})
+	t.Run("refused", func(t *testing.T) {
+		err := h.ValidateChangeTicket(ctx, &ChangeTicketRequest{System: "jira", Ticket: "CHG-5678"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "change is closed")
+	})
+	t.Run("unavailable", func(t *testing.T) {
+		assert.Error(t, h.ValidateChangeTicket(ctx, &ChangeTicketRequest{System: "jira", Ticket: "CHG-0000"}))
+	})
+	t.Run("record", func(t *testing.T) {
+		assert := assert.New(t)
+		s := &ChangeTicketSession{
+			ChangeTicketRequest: ChangeTicketRequest{System: "jira", Ticket: "CHG-1234"},
+			SessionId:           "s_1234567890",
+		}
+		unregister := RegisterChangeTicketSystem("jira", h)
+		defer unregister()
+		RecordChangeTicketSession(ctx, s)
+		require.Len(t, recorded, 1)
+		assert.Equal("CHG-1234", recorded[0].Ticket)
+		assert.Equal("s_1234567890", recorded[0].SessionId)
+	})
+}
@@ -0,0 +1,319 @@
+package target
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// ClientCertificateIssuer is where a client certificate library issues
+// certificates from.
+type ClientCertificateIssuer string
+
+const (
+	// ClientCertificateIssuerInternal issues certificates from a CA Boundary
+	// generates for the library.
+	ClientCertificateIssuerInternal ClientCertificateIssuer = "internal"
+	// ClientCertificateIssuerVault issues certificates from the role of a
+	// Vault PKI secrets engine.
+	ClientCertificateIssuerVault ClientCertificateIssuer = "vault"
+)
+
+const (
+	defaultClientCertificateLibraryTableName = "client_certificate_library"
+
+	// clientCaValidity is how long the CA of an internal library is valid.
+	clientCaValidity = 10 * 365 * 24 * time.Hour
+)
+
+// ClientCertificateLibrary issues the short-lived client certificates a worker
+// presents to the targets it's attached to, for targets which require client
+// TLS. It's owned by a project, and can be attached to any of the project's
+// targets. A certificate is issued for each session when it's authorized, so
+// end users never handle the keys.
+//
+// An internal library issues certificates from a CA Boundary generates for it,
+// whose certificate is the library's CaCert; a vault library issues them from
+// the role of a Vault PKI secrets engine. The library's Secret, the CA's
+// private key or the Vault token, is encrypted with the database key of the
+// library's scope.
+type ClientCertificateLibrary struct {
+	*store.ClientCertificateLibrary
+	tableName string `gorm:"-"`
+}
+
+var _ db.VetForWriter = (*ClientCertificateLibrary)(nil)
+var _ oplog.ReplayableMessage = (*ClientCertificateLibrary)(nil)
+
+// NewClientCertificateLibrary creates a new in memory client certificate
+// library in the project (scopeId), which issues certificates from the
+// issuer. WithName, WithDescription, WithCommonName, WithServerName and
+// WithServerCaCert are supported, and WithVaultAddress, WithVaultPkiPath,
+// WithVaultRole and WithVaultToken are required for a vault library.
+func NewClientCertificateLibrary(scopeId string, issuer ClientCertificateIssuer, opt ...Option) (*ClientCertificateLibrary, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("new client certificate library: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	l := &ClientCertificateLibrary{
+		ClientCertificateLibrary: &store.ClientCertificateLibrary{
+			ScopeId:      scopeId,
+			Name:         opts.withName,
+			Description:  opts.withDescription,
+			Issuer:       string(issuer),
+			CommonName:   opts.withCommonName,
+			ServerName:   opts.withServerName,
+			ServerCaCert: opts.withServerCaCert,
+			VaultAddress: opts.withVaultAddress,
+			VaultPkiPath: opts.withVaultPkiPath,
+			VaultRole:    opts.withVaultRole,
+		},
+	}
+	if opts.withVaultToken != "" {
+		l.Secret = []byte(opts.withVaultToken)
+	}
+	if err := l.validate(); err != nil {
+		return nil, fmt.Errorf("new client certificate library: %s: %w", err, db.ErrInvalidParameter)
+	}
+	return l, nil
+}
+
+// allocClientCertificateLibrary will allocate a client certificate library
+func allocClientCertificateLibrary() *ClientCertificateLibrary {
+	return &ClientCertificateLibrary{
+		ClientCertificateLibrary: &store.ClientCertificateLibrary{},
+	}
+}
+
+func (l *ClientCertificateLibrary) clone() *ClientCertificateLibrary {
+	cp := proto.Clone(l.ClientCertificateLibrary)
+	return &ClientCertificateLibrary{
+		ClientCertificateLibrary: cp.(*store.ClientCertificateLibrary),
+	}
+}
+
+// ClientCertificate is a client certificate issued by a library for a
+// session, and what the worker needs to verify the target.
+type ClientCertificate struct {
+	// Certificate is the PEM encoded certificate, followed by the
+	// certificates of its issuers, if any.
+	Certificate []byte
+	// PrivateKey is the PEM encoded private key of the certificate.
+	PrivateKey     []byte
+	ServerName     string
+	ServerCaCert   []byte
+	ExpirationTime time.Time
+}
+
+// validate checks the settings of the library, which don't depend on its
+// secret.
+func (l *ClientCertificateLibrary) validate() error {
+	switch ClientCertificateIssuer(l.Issuer) {
+	case ClientCertificateIssuerInternal:
+		if l.VaultAddress != "" || l.VaultPkiPath != "" || l.VaultRole != "" {
+			return errors.New("only vault libraries have a vault address, pki path and role")
+		}
+	case ClientCertificateIssuerVault:
+		if l.VaultAddress == "" || l.VaultPkiPath == "" || l.VaultRole == "" {
+			return errors.New("vault libraries need a vault address, pki path and role")
+		}
+	default:
+		return fmt.Errorf("unknown issuer %q", l.Issuer)
+	}
+	if len(l.ServerCaCert) > 0 {
+		if _, err := parseCertificatesPem(l.ServerCaCert); err != nil {
+			return fmt.Errorf("invalid server ca cert: %w", err)
+		}
+	}
+	return nil
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the client
+// certificate library before it's written.
+func (l *ClientCertificateLibrary) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if l.PublicId == "" {
+		return fmt.Errorf("client certificate library vet for write: missing public id: %w", db.ErrInvalidParameter)
+	}
+	if opType == db.CreateOp {
+		if l.ScopeId == "" {
+			return fmt.Errorf("client certificate library vet for write: missing scope id: %w", db.ErrInvalidParameter)
+		}
+		if len(l.CtSecret) == 0 {
+			return fmt.Errorf("client certificate library vet for write: missing secret: %w", db.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (l *ClientCertificateLibrary) TableName() string {
+	if l.tableName != "" {
+		return l.tableName
+	}
+	return defaultClientCertificateLibraryTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (l *ClientCertificateLibrary) SetTableName(n string) {
+	l.tableName = n
+}
+
+func (l *ClientCertificateLibrary) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	if err := structwrapping.WrapStruct(ctx, cipher, l.ClientCertificateLibrary, nil); err != nil {
+		return fmt.Errorf("error encrypting client certificate library: %w", err)
+	}
+	l.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (l *ClientCertificateLibrary) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	if err := structwrapping.UnwrapStruct(ctx, cipher, l.ClientCertificateLibrary, nil); err != nil {
+		return fmt.Errorf("error decrypting client certificate library: %w", err)
+	}
+	return nil
+}
+
+func (l *ClientCertificateLibrary) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{l.PublicId},
+		"resource-type":      []string{"client certificate library"},
+		"op-type":            []string{op.String()},
+		"scope-id":           []string{l.ScopeId},
+	}
+	return metadata
+}
+
+// generateClientCa generates the CA of an internal library, returning the PEM
+// encoded certificate and the DER encoded private key.
+func generateClientCa(libraryId string) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to generate ca key: %w", err)
+	}
+	serial, err := randomSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: fmt.Sprintf("Boundary client CA for %s", libraryId)},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(clientCaValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create ca certificate: %w", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to marshal ca key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), keyDer, nil
+}
+
+// issueInternalClientCertificate issues a client certificate for commonName
+// valid until notAfter from the CA of an internal library.
+func issueInternalClientCertificate(caCertPem, caKeyDer []byte, commonName string, notAfter time.Time) (*ClientCertificate, error) {
+	caCerts, err := parseCertificatesPem(caCertPem)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse ca certificate: %w", err)
+	}
+	caKey, err := x509.ParseECPrivateKey(caKeyDer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse ca key: %w", err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate key: %w", err)
+	}
+	serial, err := randomSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCerts[0], &key.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create certificate: %w", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal key: %w", err)
+	}
+	return &ClientCertificate{
+		Certificate:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		PrivateKey:     pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+		ExpirationTime: template.NotAfter,
+	}, nil
+}
+
+// ValidServerCaCert returns an error if the PEM encoded certificates can't be
+// the server CA certificates of a client certificate library.
+func ValidServerCaCert(b []byte) error {
+	if _, err := parseCertificatesPem(b); err != nil {
+		return fmt.Errorf("invalid server ca cert: %s: %w", err, db.ErrInvalidParameter)
+	}
+	return nil
+}
+
+// parseCertificatesPem parses the PEM encoded certificates, of which there
+// must be at least one.
+func parseCertificatesPem(b []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, b = pem.Decode(b)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificates found")
+	}
+	return certs, nil
+}
+
+func randomSerialNumber() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate serial number: %w", err)
+	}
+	return serial, nil
+}
@@ -7,7 +7,8 @@ import (
 )
 
 const (
-	TcpTargetPrefix = "ttcp"
+	TcpTargetPrefix                = "ttcp"
+	ClientCertificateLibraryPrefix = "ccl"
 )
 
 func newTcpTargetId() (string, error) {
@@ -17,3 +18,11 @@ func newTcpTargetId() (string, error) {
 	}
 	return id, nil
 }
+
+func newClientCertificateLibraryId() (string, error) {
+	id, err := db.NewPublicId(ClientCertificateLibraryPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new client certificate library id: %w", err)
+	}
+	return id, nil
+}
@@ -15,4 +15,9 @@ func Test_Ids(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, strings.HasPrefix(id, TcpTargetPrefix+"_"))
 	})
+	t.Run("client-certificate-library", func(t *testing.T) {
+		id, err := newClientCertificateLibraryId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, ClientCertificateLibraryPrefix+"_"))
+	})
 }
@@ -34,7 +34,7 @@ func TestTarget_ImmutableFields(t *testing.T) {
 		{
 			name: "public_id",
 			update: func() *TcpTarget {
-				target := new.Clone().(*TcpTarget)
+				target := new.Clone()
 				target.PublicId = "p_thisIsNotAValidId"
 				return target
 			}(),
@@ -43,7 +43,7 @@ func TestTarget_ImmutableFields(t *testing.T) {
 		{
 			name: "create time",
 			update: func() *TcpTarget {
-				target := new.Clone().(*TcpTarget)
+				target := new.Clone()
 				target.CreateTime = &ts
 				return target
 			}(),
@@ -52,7 +52,7 @@ func TestTarget_ImmutableFields(t *testing.T) {
 		{
 			name: "scope_id",
 			update: func() *TcpTarget {
-				target := new.Clone().(*TcpTarget)
+				target := new.Clone()
 				target.ScopeId = "o_thisIsNotAValidId"
 				return target
 			}(),
@@ -65,7 +65,7 @@ func TestTarget_ImmutableFields(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
 			orig := new.Clone()
-			orig.(*TcpTarget).SetTableName("target")
+			orig.SetTableName("target")
 			err := rw.LookupById(context.Background(), orig)
 			require.NoError(err)
 
@@ -75,11 +75,11 @@ func TestTarget_ImmutableFields(t *testing.T) {
 			assert.Equal(0, rowsUpdated)
 
 			after := new.Clone()
-			after.(*TcpTarget).SetTableName("target")
+			after.SetTableName("target")
 			err = rw.LookupById(context.Background(), after)
 			require.NoError(err)
 
-			assert.True(proto.Equal(orig.(*TcpTarget), after.(*TcpTarget)))
+			assert.True(proto.Equal(orig, after))
 
 		})
 	}
@@ -104,7 +104,7 @@ func TestTcpTarget_ImmutableFields(t *testing.T) {
 		{
 			name: "public_id",
 			update: func() *TcpTarget {
-				target := new.Clone().(*TcpTarget)
+				target := new.Clone()
 				target.PublicId = "p_thisIsNotAValidId"
 				return target
 			}(),
@@ -113,7 +113,7 @@ func TestTcpTarget_ImmutableFields(t *testing.T) {
 		{
 			name: "create time",
 			update: func() *TcpTarget {
-				target := new.Clone().(*TcpTarget)
+				target := new.Clone()
 				target.CreateTime = &ts
 				return target
 			}(),
@@ -122,7 +122,7 @@ func TestTcpTarget_ImmutableFields(t *testing.T) {
 		{
 			name: "scope_id",
 			update: func() *TcpTarget {
-				target := new.Clone().(*TcpTarget)
+				target := new.Clone()
 				target.ScopeId = "o_thisIsNotAValidId"
 				return target
 			}(),
@@ -145,7 +145,7 @@ func TestTcpTarget_ImmutableFields(t *testing.T) {
 			err = rw.LookupById(context.Background(), after)
 			require.NoError(err)
 
-			assert.True(proto.Equal(orig.(*TcpTarget), after.(*TcpTarget)))
+			assert.True(proto.Equal(orig, after))
 
 		})
 	}
@@ -185,7 +185,7 @@ func TestTargetHostSet_ImmutableFields(t *testing.T) {
 		{
 			name: "target_id",
 			update: func() *TargetHostSet {
-				target := new.Clone().(*TargetHostSet)
+				target := new.Clone()
 				target.TargetId = updateTarget.PublicId
 				return target
 			}(),
@@ -194,7 +194,7 @@ func TestTargetHostSet_ImmutableFields(t *testing.T) {
 		{
 			name: "create time",
 			update: func() *TargetHostSet {
-				target := new.Clone().(*TargetHostSet)
+				target := new.Clone()
 				target.CreateTime = &ts
 				return target
 			}(),
@@ -203,7 +203,7 @@ func TestTargetHostSet_ImmutableFields(t *testing.T) {
 		{
 			name: "host_set_id",
 			update: func() *TargetHostSet {
-				target := new.Clone().(*TargetHostSet)
+				target := new.Clone()
 				target.HostSetId = updateHset.PublicId
 				return target
 			}(),
@@ -225,7 +225,7 @@ func TestTargetHostSet_ImmutableFields(t *testing.T) {
 			after := new.Clone()
 			err = rw.LookupWhere(context.Background(), after, "target_id = ? and host_set_id = ?", new.TargetId, new.HostSetId)
 			require.NoError(err)
-			assert.True(proto.Equal(orig.(*TargetHostSet), after.(*TargetHostSet)))
+			assert.True(proto.Equal(orig, after))
 		})
 	}
 }
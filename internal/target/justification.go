@@ -0,0 +1,73 @@
+package target
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// MaxJustificationLength is the most characters the justification of a
+// session can have.
+const MaxJustificationLength = 1024
+
+var (
+	// ErrJustificationRequired is returned when a session to a target with a
+	// justification pattern is authorized without a justification.
+	ErrJustificationRequired = errors.New("justification required")
+
+	// ErrJustificationMismatch is returned when the justification of a
+	// session doesn't match the justification pattern of its target.
+	ErrJustificationMismatch = errors.New("justification doesn't match the target's justification pattern")
+)
+
+// ValidJustificationPattern returns an error if the pattern isn't a valid
+// regular expression, or is blank.
+func ValidJustificationPattern(pattern string) error {
+	_, err := compileJustificationPattern(pattern)
+	if err == nil && pattern != "" && strings.TrimSpace(pattern) == "" {
+		err = fmt.Errorf("blank justification pattern: %w", db.ErrInvalidParameter)
+	}
+	return err
+}
+
+// compileJustificationPattern compiles a justification pattern so it has to
+// match a justification entirely. An empty pattern compiles to nil.
+func compileJustificationPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	// The pattern is compiled on its own first, so one which isn't valid
+	// can't become valid, and escape the anchors, once it's wrapped.
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, fmt.Errorf("invalid justification pattern: %s: %w", err, db.ErrInvalidParameter)
+	}
+	return regexp.MustCompile(`^(?:` + pattern + `)$`), nil
+}
+
+// CheckJustification checks the justification given when authorizing a
+// session to the target. A target with a justification pattern requires a
+// justification which matches it entirely; a target without one accepts any
+// justification, or none. A justification can't be longer than
+// MaxJustificationLength.
+func CheckJustification(t Target, justification string) error {
+	if utf8.RuneCountInString(justification) > MaxJustificationLength {
+		return fmt.Errorf("justification is longer than %d characters: %w", MaxJustificationLength, db.ErrInvalidParameter)
+	}
+	re, err := compileJustificationPattern(t.GetJustificationPattern())
+	if err != nil {
+		return err
+	}
+	switch {
+	case re == nil:
+		return nil
+	case strings.TrimSpace(justification) == "":
+		return ErrJustificationRequired
+	case !re.MatchString(justification):
+		return ErrJustificationMismatch
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package target
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckJustification(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		pattern       string
+		justification string
+		wantErr       error
+	}{
+		{
+			name: "no-pattern-no-justification",
+		},
+		{
+			name:          "no-pattern",
+			justification: "checking the disk",
+		},
+		{
+			name:          "match",
+			pattern:       `INC-[0-9]+: .+`,
+			justification: "INC-1234: checking the disk",
+		},
+		{
+			name:    "missing",
+			pattern: `INC-[0-9]+: .+`,
+			wantErr: ErrJustificationRequired,
+		},
+		{
+			name:          "blank",
+			pattern:       `.*`,
+			justification: "  ",
+			wantErr:       ErrJustificationRequired,
+		},
+		{
+			name:          "mismatch",
+			pattern:       `INC-[0-9]+: .+`,
+			justification: "checking the disk",
+			wantErr:       ErrJustificationMismatch,
+		},
+		{
+			name:          "partial-match",
+			pattern:       `INC-[0-9]+`,
+			justification: "see INC-1234",
+			wantErr:       ErrJustificationMismatch,
+		},
+		{
+			name:          "alternation-is-anchored",
+			pattern:       `INC-[0-9]+|CHG-[0-9]+`,
+			justification: "INC-1234 and more",
+			wantErr:       ErrJustificationMismatch,
+		},
+		{
+			name:          "invalid-pattern",
+			pattern:       `INC-[0-9`,
+			justification: "INC-1234",
+			wantErr:       db.ErrInvalidParameter,
+		},
+		{
+			name:          "invalid-pattern-valid-once-wrapped",
+			pattern:       `a)|(b`,
+			justification: "a",
+			wantErr:       db.ErrInvalidParameter,
+		},
+		{
+			name:          "too-long",
+			justification: strings.Repeat("a", MaxJustificationLength+1),
+			wantErr:       db.ErrInvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tgt := &TcpTarget{TcpTarget: &store.TcpTarget{JustificationPattern: tt.pattern}}
+			err := CheckJustification(tgt, tt.justification)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestValidJustificationPattern(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	assert.NoError(ValidJustificationPattern(""))
+	assert.NoError(ValidJustificationPattern(`INC-[0-9]+: .+`))
+	assert.True(errors.Is(ValidJustificationPattern(`INC-[0-9`), db.ErrInvalidParameter))
+	assert.True(errors.Is(ValidJustificationPattern(`a)|(b`), db.ErrInvalidParameter))
+	assert.True(errors.Is(ValidJustificationPattern("  "), db.ErrInvalidParameter))
+}
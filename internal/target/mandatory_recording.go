@@ -0,0 +1,118 @@
+package target
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultMandatoryRecordingTableName = "target_mandatory_recording"
+	defaultRecordingExemptionTableName = "target_recording_exemption"
+)
+
+// MandatoryRecording makes recording mandatory for the sessions of the targets
+// of a type in an org or project, regardless of their session recording
+// settings. Only principals granted the authorize-session-unrecorded action on
+// a target are exempt.
+type MandatoryRecording struct {
+	*store.MandatoryRecording
+	tableName string `gorm:"-"`
+}
+
+var _ db.VetForWriter = (*MandatoryRecording)(nil)
+var _ oplog.ReplayableMessage = (*MandatoryRecording)(nil)
+
+// NewMandatoryRecording creates a new in memory mandatory recording of the
+// targets of targetType in the org or project (scopeId). No options are
+// currently supported.
+func NewMandatoryRecording(scopeId string, targetType TargetType, opt ...Option) (*MandatoryRecording, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("new mandatory recording: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if targetType == UnknownTargetType {
+		return nil, fmt.Errorf("new mandatory recording: unknown target type: %w", db.ErrInvalidParameter)
+	}
+	return &MandatoryRecording{
+		MandatoryRecording: &store.MandatoryRecording{
+			ScopeId:    scopeId,
+			TargetType: targetType.String(),
+		},
+	}, nil
+}
+
+// allocMandatoryRecording will allocate a mandatory recording
+func allocMandatoryRecording() MandatoryRecording {
+	return MandatoryRecording{
+		MandatoryRecording: &store.MandatoryRecording{},
+	}
+}
+
+// Clone creates a clone of the MandatoryRecording
+func (m *MandatoryRecording) Clone() *MandatoryRecording {
+	cp := proto.Clone(m.MandatoryRecording)
+	return &MandatoryRecording{
+		MandatoryRecording: cp.(*store.MandatoryRecording),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the
+// mandatory recording before it's written.
+func (m *MandatoryRecording) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if m.ScopeId == "" {
+		return fmt.Errorf("mandatory recording vet for write: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if m.TargetType == "" {
+		return fmt.Errorf("mandatory recording vet for write: missing target type: %w", db.ErrInvalidParameter)
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (m *MandatoryRecording) TableName() string {
+	if m.tableName != "" {
+		return m.tableName
+	}
+	return defaultMandatoryRecordingTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (m *MandatoryRecording) SetTableName(n string) {
+	m.tableName = n
+}
+
+func (m *MandatoryRecording) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{m.ScopeId},
+		"resource-type":      []string{"mandatory recording"},
+		"op-type":            []string{op.String()},
+		"scope-id":           []string{m.ScopeId},
+		"target-type":        []string{m.TargetType},
+	}
+	return metadata
+}
+
+// RecordingExemption is a security event recording that a session was
+// authorized without recording for a target whose recording is mandatory,
+// because the user holds an exemption grant. Exemptions are append only.
+type RecordingExemption struct {
+	SessionId   string `gorm:"primary_key"`
+	TargetId    string
+	TargetType  string
+	ScopeId     string
+	UserId      string
+	AuthTokenId string
+	CreateTime  *timestamp.Timestamp `gorm:"default:current_timestamp"`
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (e *RecordingExemption) TableName() string {
+	return defaultRecordingExemptionTableName
+}
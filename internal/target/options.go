@@ -1,7 +1,5 @@
 package target
 
-import "time"
-
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -16,32 +14,62 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withName                   string
-	withDescription            string
-	withDefaultPort            uint32
-	withLimit                  int
-	withScopeId                string
-	withUserId                 string
-	withTargetType             *TargetType
-	withHostSets               []string
-	withSessionMaxSeconds      uint32
-	withSessionConnectionLimit int32
-	withPublicId               string
+	withName                         string
+	withDescription                  string
+	withDefaultPort                  uint32
+	withLimit                        int
+	withScopeId                      string
+	withUserId                       string
+	withTargetType                   *TargetType
+	withHostSets                     []string
+	withSessionMaxSeconds            uint32
+	withSessionConnectionLimit       int32
+	withPublicId                     string
+	withSessionIdleTimeout           int32
+	withSessionRecording             SessionRecording
+	withSessionResumeSeconds         uint32
+	withBanner                       string
+	withBannerAcknowledgmentRequired bool
+	withJustificationPattern         string
+	withChangeTicketSystem           string
+	withClientCertificateLibraryId   string
+	withCommonName                   string
+	withServerName                   string
+	withServerCaCert                 []byte
+	withVaultAddress                 string
+	withVaultPkiPath                 string
+	withVaultRole                    string
+	withVaultToken                   string
 }
 
 func getDefaultOptions() options {
 	return options{
-		withName:                   "",
-		withDescription:            "",
-		withLimit:                  0,
-		withDefaultPort:            0,
-		withScopeId:                "",
-		withUserId:                 "",
-		withTargetType:             nil,
-		withHostSets:               nil,
-		withSessionMaxSeconds:      uint32((8 * time.Hour).Seconds()),
-		withSessionConnectionLimit: 1,
-		withPublicId:               "",
+		withName:                         "",
+		withDescription:                  "",
+		withLimit:                        0,
+		withDefaultPort:                  0,
+		withScopeId:                      "",
+		withUserId:                       "",
+		withTargetType:                   nil,
+		withHostSets:                     nil,
+		withSessionMaxSeconds:            0,
+		withSessionConnectionLimit:       0,
+		withPublicId:                     "",
+		withSessionIdleTimeout:           0,
+		withSessionRecording:             SessionRecordingInherit,
+		withSessionResumeSeconds:         0,
+		withBanner:                       "",
+		withBannerAcknowledgmentRequired: false,
+		withJustificationPattern:         "",
+		withChangeTicketSystem:           "",
+		withClientCertificateLibraryId:   "",
+		withCommonName:                   "",
+		withServerName:                   "",
+		withServerCaCert:                 nil,
+		withVaultAddress:                 "",
+		withVaultPkiPath:                 "",
+		withVaultRole:                    "",
+		withVaultToken:                   "",
 	}
 }
 
@@ -121,3 +149,128 @@ func WithPublicId(id string) Option {
 		o.withPublicId = id
 	}
 }
+
+// WithSessionIdleTimeoutSeconds provides an option to set how many seconds a
+// session can be idle before it's closed. -1 means no timeout, and 0 means
+// it's inherited.
+func WithSessionIdleTimeoutSeconds(seconds int32) Option {
+	return func(o *options) {
+		o.withSessionIdleTimeout = seconds
+	}
+}
+
+// WithSessionRecording provides an option to set whether sessions must be
+// recorded.
+func WithSessionRecording(r SessionRecording) Option {
+	return func(o *options) {
+		o.withSessionRecording = r
+	}
+}
+
+// WithSessionResumeSeconds provides an option to set how many seconds after a
+// connection is lost to a network error the session can be resumed by
+// reconnecting. 0 disables resumption.
+func WithSessionResumeSeconds(seconds uint32) Option {
+	return func(o *options) {
+		o.withSessionResumeSeconds = seconds
+	}
+}
+
+// WithBanner provides an option to set the banner the CLI shows before
+// connecting to a target, such as a usage policy.
+func WithBanner(banner string) Option {
+	return func(o *options) {
+		o.withBanner = banner
+	}
+}
+
+// WithBannerAcknowledgmentRequired provides an option to require users to
+// acknowledge a target's banner before a session to it is authorized.
+func WithBannerAcknowledgmentRequired(required bool) Option {
+	return func(o *options) {
+		o.withBannerAcknowledgmentRequired = required
+	}
+}
+
+// WithJustificationPattern provides an option to set the regular expression
+// the justification given when authorizing a session to a target must match.
+// A target with a pattern requires a justification.
+func WithJustificationPattern(pattern string) Option {
+	return func(o *options) {
+		o.withJustificationPattern = pattern
+	}
+}
+
+// WithChangeTicketSystem provides an option to set the name of the change
+// ticket system which must accept the change ticket given when authorizing a
+// session to a target. A target with a system requires a change ticket.
+func WithChangeTicketSystem(name string) Option {
+	return func(o *options) {
+		o.withChangeTicketSystem = name
+	}
+}
+
+// WithClientCertificateLibraryId provides an option to set the client
+// certificate library which issues the client certificates workers present to
+// a target. A target with a library is connected to over TLS.
+func WithClientCertificateLibraryId(id string) Option {
+	return func(o *options) {
+		o.withClientCertificateLibraryId = id
+	}
+}
+
+// WithCommonName provides an option to set the common name of the client
+// certificates a library issues.
+func WithCommonName(name string) Option {
+	return func(o *options) {
+		o.withCommonName = name
+	}
+}
+
+// WithServerName provides an option to set the server name which verifies the
+// certificates of a library's targets.
+func WithServerName(name string) Option {
+	return func(o *options) {
+		o.withServerName = name
+	}
+}
+
+// WithServerCaCert provides an option to set the PEM encoded CA certificates
+// which verify the certificates of a library's targets.
+func WithServerCaCert(cert []byte) Option {
+	return func(o *options) {
+		o.withServerCaCert = cert
+	}
+}
+
+// WithVaultAddress provides an option to set the address of the Vault server
+// of a vault library.
+func WithVaultAddress(address string) Option {
+	return func(o *options) {
+		o.withVaultAddress = address
+	}
+}
+
+// WithVaultPkiPath provides an option to set the path the PKI secrets engine
+// of a vault library is mounted at.
+func WithVaultPkiPath(path string) Option {
+	return func(o *options) {
+		o.withVaultPkiPath = path
+	}
+}
+
+// WithVaultRole provides an option to set the PKI role which issues the
+// certificates of a vault library.
+func WithVaultRole(role string) Option {
+	return func(o *options) {
+		o.withVaultRole = role
+	}
+}
+
+// WithVaultToken provides an option to set the Vault token a vault library
+// issues certificates with.
+func WithVaultToken(token string) Option {
+	return func(o *options) {
+		o.withVaultToken = token
+	}
+}
@@ -90,4 +90,68 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withHostSets = []string{"alice", "bob"}
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithSessionIdleTimeoutSeconds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithSessionIdleTimeoutSeconds(300))
+		testOpts := getDefaultOptions()
+		testOpts.withSessionIdleTimeout = 300
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSessionRecording", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithSessionRecording(SessionRecordingRequired))
+		testOpts := getDefaultOptions()
+		testOpts.withSessionRecording = SessionRecordingRequired
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSessionResumeSeconds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithSessionResumeSeconds(30))
+		testOpts := getDefaultOptions()
+		testOpts.withSessionResumeSeconds = 30
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithBanner", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithBanner("Authorized use only"), WithBannerAcknowledgmentRequired(true))
+		testOpts := getDefaultOptions()
+		testOpts.withBanner = "Authorized use only"
+		testOpts.withBannerAcknowledgmentRequired = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithChangeTicketSystem", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithChangeTicketSystem("jira"))
+		testOpts := getDefaultOptions()
+		testOpts.withChangeTicketSystem = "jira"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithClientCertificateLibraryId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithClientCertificateLibraryId("ccl_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withClientCertificateLibraryId = "ccl_1234567890"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithClientCertificateLibrarySettings", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(
+			WithCommonName("boundary"),
+			WithServerName("db.internal"),
+			WithServerCaCert([]byte("ca")),
+			WithVaultAddress("https://vault:8200"),
+			WithVaultPkiPath("pki"),
+			WithVaultRole("boundary"),
+			WithVaultToken("s.token"),
+		)
+		testOpts := getDefaultOptions()
+		testOpts.withCommonName = "boundary"
+		testOpts.withServerName = "db.internal"
+		testOpts.withServerCaCert = []byte("ca")
+		testOpts.withVaultAddress = "https://vault:8200"
+		testOpts.withVaultPkiPath = "pki"
+		testOpts.withVaultRole = "boundary"
+		testOpts.withVaultToken = "s.token"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -15,9 +15,11 @@ var (
 	ErrMetadataScopeNotFound = errors.New("scope not found for metadata")
 )
 
-// Clonable provides a cloning interface
+// Cloneable is implemented by the targets the repository writes, which clone
+// the target for each try of their transaction. Each target also has a Clone
+// method returning its own type.
 type Cloneable interface {
-	Clone() interface{}
+	cloneTarget() Target
 }
 
 // Repository is the target database repository
@@ -163,7 +165,7 @@ func (r *Repository) DeleteTarget(ctx context.Context, publicId string, opt ...O
 		return db.NoRowsAffected, fmt.Errorf("delete target: failed %w for %s", err, publicId)
 	}
 	var metadata oplog.Metadata
-	var deleteTarget interface{}
+	var deleteTarget Cloneable
 	switch t.Type {
 	case TcpTargetType.String():
 		tcpT := allocTcpTarget()
@@ -180,13 +182,13 @@ func (r *Repository) DeleteTarget(ctx context.Context, publicId string, opt ...O
 	}
 
 	var rowsDeleted int
-	var deleteResource interface{}
+	var deleteResource Target
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(_ db.Reader, w db.Writer) error {
-			deleteResource = deleteTarget.(Cloneable).Clone()
+			deleteResource = deleteTarget.cloneTarget()
 			rowsDeleted, err = w.Delete(
 				ctx,
 				deleteResource,
@@ -235,14 +237,14 @@ func (r *Repository) update(ctx context.Context, target Target, version uint32,
 	dbOpts = append(dbOpts, db.WithOplog(oplogWrapper, metadata))
 
 	var rowsUpdated int
-	var returnedTarget interface{}
+	var returnedTarget Target
 	var hostSets []*TargetSet
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(reader db.Reader, w db.Writer) error {
-			returnedTarget = cloner.Clone()
+			returnedTarget = cloner.cloneTarget()
 			rowsUpdated, err = w.Update(
 				ctx,
 				returnedTarget,
@@ -264,7 +266,7 @@ func (r *Repository) update(ctx context.Context, target Target, version uint32,
 			return err
 		},
 	)
-	return returnedTarget.(Target), hostSets, rowsUpdated, err
+	return returnedTarget, hostSets, rowsUpdated, err
 }
 
 // AddTargetHostSets provides the ability to add host sets (hostSetIds) to a
@@ -296,7 +298,7 @@ func (r *Repository) AddTargetHostSets(ctx context.Context, targetId string, tar
 		return nil, nil, fmt.Errorf("add target host sets: failed %w for %s", err, targetId)
 	}
 	var metadata oplog.Metadata
-	var target interface{}
+	var target Cloneable
 	switch t.Type {
 	case TcpTargetType.String():
 		tcpT := allocTcpTarget()
@@ -313,7 +315,7 @@ func (r *Repository) AddTargetHostSets(ctx context.Context, targetId string, tar
 		return nil, nil, fmt.Errorf("add target host sets: unable to get oplog wrapper: %w", err)
 	}
 	var currentHostSets []*TargetSet
-	var updatedTarget interface{}
+	var updatedTarget Target
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
@@ -324,7 +326,7 @@ func (r *Repository) AddTargetHostSets(ctx context.Context, targetId string, tar
 			if err != nil {
 				return fmt.Errorf("add target host sets: unable to get ticket: %w", err)
 			}
-			updatedTarget = target.(Cloneable).Clone()
+			updatedTarget = target.cloneTarget()
 			var targetOplogMsg oplog.Message
 			rowsUpdated, err := w.Update(ctx, updatedTarget, []string{"Version"}, nil, db.NewOplogMsg(&targetOplogMsg), db.WithVersion(&targetVersion))
 			if err != nil {
@@ -354,7 +356,7 @@ func (r *Repository) AddTargetHostSets(ctx context.Context, targetId string, tar
 	if err != nil {
 		return nil, nil, fmt.Errorf("add target host sets: error creating sets: %w", err)
 	}
-	return updatedTarget.(Target), currentHostSets, nil
+	return updatedTarget, currentHostSets, nil
 }
 
 // DeleteTargeHostSets deletes host sets from a target (targetId). The target's
@@ -387,7 +389,7 @@ func (r *Repository) DeleteTargeHostSets(ctx context.Context, targetId string, t
 	}
 
 	var metadata oplog.Metadata
-	var target interface{}
+	var target Cloneable
 	switch t.Type {
 	case TcpTargetType.String():
 		tcpT := allocTcpTarget()
@@ -415,7 +417,7 @@ func (r *Repository) DeleteTargeHostSets(ctx context.Context, targetId string, t
 			if err != nil {
 				return fmt.Errorf("delete target host sets: unable to get ticket: %w", err)
 			}
-			updatedTarget := target.(Cloneable).Clone()
+			updatedTarget := target.cloneTarget()
 			var targetOplogMsg oplog.Message
 			rowsUpdated, err := w.Update(ctx, updatedTarget, []string{"Version"}, nil, db.NewOplogMsg(&targetOplogMsg), db.WithVersion(&targetVersion))
 			if err != nil {
@@ -509,7 +511,7 @@ func (r *Repository) SetTargetHostSets(ctx context.Context, targetId string, tar
 	}
 
 	var metadata oplog.Metadata
-	var target interface{}
+	var target Cloneable
 	switch t.Type {
 	case TcpTargetType.String():
 		tcpT := allocTcpTarget()
@@ -537,7 +539,7 @@ func (r *Repository) SetTargetHostSets(ctx context.Context, targetId string, tar
 			if err != nil {
 				return fmt.Errorf("set target host sets: unable to get ticket: %w", err)
 			}
-			updatedTarget := target.(Cloneable).Clone()
+			updatedTarget := target.cloneTarget()
 			var targetOplogMsg oplog.Message
 			rowsUpdated, err := w.Update(ctx, updatedTarget, []string{"Version"}, nil, db.NewOplogMsg(&targetOplogMsg), db.WithVersion(&targetVersion))
 			if err != nil {
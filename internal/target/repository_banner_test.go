@@ -0,0 +1,53 @@
+package target
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Banner(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+
+	t.Run("requires-banner", func(t *testing.T) {
+		tar, err := NewTcpTarget(proj.PublicId, WithName("no-banner"), WithBannerAcknowledgmentRequired(true))
+		require.NoError(t, err)
+		_, _, err = repo.CreateTcpTarget(ctx, tar)
+		assert.Error(t, err)
+	})
+	t.Run("update", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tar, err := NewTcpTarget(proj.PublicId, WithName("banner"), WithBanner("Authorized use only."), WithBannerAcknowledgmentRequired(true))
+		require.NoError(err)
+		created, _, err := repo.CreateTcpTarget(ctx, tar)
+		require.NoError(err)
+		assert.Equal("Authorized use only.", created.GetBanner())
+		assert.True(created.GetBannerAcknowledgmentRequired())
+
+		// Acknowledgment can't be required of a cleared banner
+		tar.PublicId = created.GetPublicId()
+		tar.Banner = ""
+		_, _, _, err = repo.UpdateTcpTarget(ctx, tar, created.GetVersion(), []string{"Banner"})
+		require.Error(err)
+
+		tar.BannerAcknowledgmentRequired = false
+		updated, _, n, err := repo.UpdateTcpTarget(ctx, tar, created.GetVersion(), []string{"Banner", "BannerAcknowledgmentRequired"})
+		require.NoError(err)
+		assert.Equal(1, n)
+		assert.Empty(updated.GetBanner())
+		assert.False(updated.GetBannerAcknowledgmentRequired())
+	})
+}
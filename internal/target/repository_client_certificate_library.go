@@ -0,0 +1,357 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateClientCertificateLibrary inserts the client certificate library into
+// the repository and returns a new library containing its PublicId. library
+// is not changed. It must contain a valid ScopeId and must not contain a
+// PublicId. A vault library must contain its Vault token as its Secret. An
+// internal library must not contain a Secret; a CA is generated for it, and
+// its certificate is returned in the library's CaCert. The Secret is
+// encrypted with the database key of the library's scope, and isn't
+// returned. WithPublicId is the only option supported.
+func (r *Repository) CreateClientCertificateLibrary(ctx context.Context, library *ClientCertificateLibrary, opt ...Option) (*ClientCertificateLibrary, error) {
+	if library == nil || library.ClientCertificateLibrary == nil {
+		return nil, fmt.Errorf("create client certificate library: missing library: %w", db.ErrInvalidParameter)
+	}
+	if library.ScopeId == "" {
+		return nil, fmt.Errorf("create client certificate library: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if library.PublicId != "" {
+		return nil, fmt.Errorf("create client certificate library: public id not empty: %w", db.ErrInvalidParameter)
+	}
+	if err := library.validate(); err != nil {
+		return nil, fmt.Errorf("create client certificate library: %s: %w", err, db.ErrInvalidParameter)
+	}
+	l := library.clone()
+	l.CaCert, l.CtSecret, l.KeyId = nil, nil, ""
+
+	opts := getOpts(opt...)
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, ClientCertificateLibraryPrefix+"_") {
+			return nil, fmt.Errorf("create client certificate library: passed-in public ID %q has wrong prefix, should be %q: %w", opts.withPublicId, ClientCertificateLibraryPrefix, db.ErrInvalidPublicId)
+		}
+		l.PublicId = opts.withPublicId
+	} else {
+		id, err := newClientCertificateLibraryId()
+		if err != nil {
+			return nil, fmt.Errorf("create client certificate library: %w", err)
+		}
+		l.PublicId = id
+	}
+
+	switch ClientCertificateIssuer(l.Issuer) {
+	case ClientCertificateIssuerVault:
+		if len(l.Secret) == 0 {
+			return nil, fmt.Errorf("create client certificate library: missing vault token: %w", db.ErrInvalidParameter)
+		}
+	default:
+		if len(l.Secret) != 0 {
+			return nil, fmt.Errorf("create client certificate library: only vault libraries have a vault token: %w", db.ErrInvalidParameter)
+		}
+		caCert, caKey, err := generateClientCa(l.PublicId)
+		if err != nil {
+			return nil, fmt.Errorf("create client certificate library: %w", err)
+		}
+		l.CaCert, l.Secret = caCert, caKey
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, l.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, fmt.Errorf("create client certificate library: unable to get database wrapper: %w", err)
+	}
+	if err := l.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, fmt.Errorf("create client certificate library: %w", err)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, l.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create client certificate library: unable to get oplog wrapper: %w", err)
+	}
+
+	var returnedLibrary *ClientCertificateLibrary
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedLibrary = l.clone()
+			return w.Create(ctx, returnedLibrary, db.WithOplog(oplogWrapper, l.oplog(oplog.OpType_OP_TYPE_CREATE)))
+		},
+	)
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, fmt.Errorf("create client certificate library: in scope: %s: name %s already exists: %w", l.ScopeId, l.Name, db.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create client certificate library: %w for %s", err, l.ScopeId)
+	}
+	returnedLibrary.Secret = nil
+	return returnedLibrary, nil
+}
+
+// UpdateClientCertificateLibrary will update a client certificate library in
+// the repository and return the written library. fieldMaskPaths provides
+// field_mask.proto paths for fields that should be updated. Fields will be
+// set to NULL if the field is a zero value and included in fieldMask. Name,
+// Description, CommonName, ServerName, ServerCaCert and, for vault libraries,
+// the Vault settings and the Secret, which is the Vault token, are the only
+// updatable fields. The issuer of a library can't be changed. No options are
+// currently supported.
+func (r *Repository) UpdateClientCertificateLibrary(ctx context.Context, library *ClientCertificateLibrary, version uint32, fieldMaskPaths []string, opt ...Option) (*ClientCertificateLibrary, int, error) {
+	if library == nil || library.ClientCertificateLibrary == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: missing library: %w", db.ErrInvalidParameter)
+	}
+	if library.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: missing public id: %w", db.ErrInvalidParameter)
+	}
+	var updateSecret bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f):
+		case strings.EqualFold("description", f):
+		case strings.EqualFold("commonname", f):
+		case strings.EqualFold("servername", f):
+		case strings.EqualFold("servercacert", f):
+		case strings.EqualFold("vaultaddress", f):
+		case strings.EqualFold("vaultpkipath", f):
+		case strings.EqualFold("vaultrole", f):
+		case strings.EqualFold("secret", f):
+			updateSecret = true
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: field: %s: %w", f, db.ErrInvalidFieldMask)
+		}
+	}
+	l := library.clone()
+	dbMask, nullFields := dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"Name":         l.Name,
+			"Description":  l.Description,
+			"CommonName":   l.CommonName,
+			"ServerName":   l.ServerName,
+			"ServerCaCert": l.ServerCaCert,
+			"VaultAddress": l.VaultAddress,
+			"VaultPkiPath": l.VaultPkiPath,
+			"VaultRole":    l.VaultRole,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 && !updateSecret {
+		return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: %w", db.ErrEmptyFieldMask)
+	}
+
+	existing, err := r.LookupClientCertificateLibrary(ctx, l.PublicId)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: %w", err)
+	}
+	if existing == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: %s: %w", l.PublicId, db.ErrRecordNotFound)
+	}
+	l.ScopeId, l.Issuer = existing.ScopeId, existing.Issuer
+	switch ClientCertificateIssuer(l.Issuer) {
+	case ClientCertificateIssuerVault:
+		for _, f := range nullFields {
+			if strings.HasPrefix(f, "Vault") {
+				return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: vault libraries need a vault address, pki path and role: %w", db.ErrInvalidParameter)
+			}
+		}
+	default:
+		for _, f := range dbMask {
+			if strings.HasPrefix(f, "Vault") {
+				return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: only vault libraries have a vault address, pki path and role: %w", db.ErrInvalidParameter)
+			}
+		}
+		if updateSecret {
+			return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: only vault libraries have a vault token: %w", db.ErrInvalidParameter)
+		}
+	}
+	if len(l.ServerCaCert) > 0 {
+		if _, err := parseCertificatesPem(l.ServerCaCert); err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: invalid server ca cert: %s: %w", err, db.ErrInvalidParameter)
+		}
+	}
+	if updateSecret {
+		if len(l.Secret) == 0 {
+			return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: missing vault token: %w", db.ErrInvalidParameter)
+		}
+		databaseWrapper, err := r.kms.GetWrapper(ctx, l.ScopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: unable to get database wrapper: %w", err)
+		}
+		if err := l.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: %w", err)
+		}
+		dbMask = append(dbMask, "CtSecret", "KeyId")
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, l.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: unable to get oplog wrapper: %w", err)
+	}
+
+	var rowsUpdated int
+	var returnedLibrary *ClientCertificateLibrary
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedLibrary = l.clone()
+			var err error
+			rowsUpdated, err = w.Update(
+				ctx,
+				returnedLibrary,
+				dbMask,
+				nullFields,
+				db.WithOplog(oplogWrapper, l.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version),
+			)
+			if err == nil && rowsUpdated > 1 {
+				// return err, which will result in a rollback of the update
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: %s: name %s already exists: %w", l.PublicId, l.Name, db.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update client certificate library: %w for %s", err, l.PublicId)
+	}
+	returnedLibrary.Secret = nil
+	return returnedLibrary, rowsUpdated, nil
+}
+
+// LookupClientCertificateLibrary will look up a client certificate library in
+// the repository. Its Secret isn't decrypted. If the library is not found, it
+// will return nil, nil. No options are currently supported.
+func (r *Repository) LookupClientCertificateLibrary(ctx context.Context, publicId string, opt ...Option) (*ClientCertificateLibrary, error) {
+	if publicId == "" {
+		return nil, fmt.Errorf("lookup client certificate library: missing public id: %w", db.ErrInvalidParameter)
+	}
+	l := allocClientCertificateLibrary()
+	l.PublicId = publicId
+	if err := r.reader.LookupByPublicId(ctx, l); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup client certificate library: %w for %s", err, publicId)
+	}
+	return l, nil
+}
+
+// ListClientCertificateLibraries returns the client certificate libraries in
+// the project (scopeId). WithLimit is the only option supported.
+func (r *Repository) ListClientCertificateLibraries(ctx context.Context, scopeId string, opt ...Option) ([]*ClientCertificateLibrary, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("list client certificate libraries: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var libraries []*ClientCertificateLibrary
+	if err := r.reader.SearchWhere(ctx, &libraries, "scope_id = ?", []interface{}{scopeId}, db.WithLimit(limit)); err != nil {
+		return nil, fmt.Errorf("list client certificate libraries: %w", err)
+	}
+	return libraries, nil
+}
+
+// DeleteClientCertificateLibrary will delete a client certificate library from
+// the repository, which detaches it from its targets. No options are
+// currently supported.
+func (r *Repository) DeleteClientCertificateLibrary(ctx context.Context, publicId string, opt ...Option) (int, error) {
+	if publicId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete client certificate library: missing public id: %w", db.ErrInvalidParameter)
+	}
+	l, err := r.LookupClientCertificateLibrary(ctx, publicId)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete client certificate library: %w", err)
+	}
+	if l == nil {
+		return db.NoRowsAffected, nil
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, l.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete client certificate library: unable to get oplog wrapper: %w", err)
+	}
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			rowsDeleted, err = w.Delete(ctx, l.clone(), db.WithOplog(oplogWrapper, l.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err == nil && rowsDeleted > 1 {
+				// return err, which will result in a rollback of the delete
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete client certificate library: %w for %s", err, publicId)
+	}
+	return rowsDeleted, nil
+}
+
+// IssueClientCertificate issues a client certificate for a session of the user
+// to the target, valid until the session expires, from the target's client
+// certificate library. If the target has no library, it will return nil, nil.
+// The certificate is issued once, when the session is authorized, and is
+// stored with the session. No options are currently supported.
+func (r *Repository) IssueClientCertificate(ctx context.Context, t Target, userId string, expirationTime time.Time, opt ...Option) (*ClientCertificate, error) {
+	if t == nil {
+		return nil, fmt.Errorf("issue client certificate: missing target: %w", db.ErrInvalidParameter)
+	}
+	libraryId := t.GetClientCertificateLibraryId()
+	if libraryId == "" {
+		return nil, nil
+	}
+	l, err := r.LookupClientCertificateLibrary(ctx, libraryId)
+	if err != nil {
+		return nil, fmt.Errorf("issue client certificate: %w", err)
+	}
+	if l == nil {
+		return nil, fmt.Errorf("issue client certificate: client certificate library %s: %w", libraryId, db.ErrRecordNotFound)
+	}
+	commonName := l.CommonName
+	if commonName == "" {
+		commonName = userId
+	}
+	if commonName == "" {
+		return nil, fmt.Errorf("issue client certificate: missing user id for the common name: %w", db.ErrInvalidParameter)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, l.ScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(l.KeyId))
+	if err != nil {
+		return nil, fmt.Errorf("issue client certificate: unable to get database wrapper: %w", err)
+	}
+	if err := l.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, fmt.Errorf("issue client certificate: %w", err)
+	}
+	var cert *ClientCertificate
+	switch ClientCertificateIssuer(l.Issuer) {
+	case ClientCertificateIssuerVault:
+		cert, err = issueVaultClientCertificate(ctx, l.VaultAddress, l.VaultPkiPath, l.VaultRole, string(l.Secret), commonName, expirationTime)
+	default:
+		cert, err = issueInternalClientCertificate(l.CaCert, l.Secret, commonName, expirationTime)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("issue client certificate: %w for %s", err, libraryId)
+	}
+	cert.ServerName = l.ServerName
+	cert.ServerCaCert = l.ServerCaCert
+	return cert, nil
+}
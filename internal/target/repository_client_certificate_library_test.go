@@ -0,0 +1,192 @@
+package target
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ClientCertificateLibrary(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewClientCertificateLibrary(proj.PublicId, "acme")
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+		_, err = NewClientCertificateLibrary(proj.PublicId, ClientCertificateIssuerInternal, WithServerCaCert([]byte("nope")))
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+		_, err = NewClientCertificateLibrary(proj.PublicId, ClientCertificateIssuerVault, WithVaultAddress("http://vault"), WithVaultPkiPath("pki"))
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+		_, err = NewClientCertificateLibrary(proj.PublicId, ClientCertificateIssuerInternal, WithVaultRole("r"))
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+
+		l, err := NewClientCertificateLibrary(proj.PublicId, ClientCertificateIssuerVault, WithVaultAddress("http://vault"), WithVaultPkiPath("pki"), WithVaultRole("r"))
+		require.NoError(t, err)
+		_, err = repo.CreateClientCertificateLibrary(ctx, l)
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter), "a vault library needs a token")
+
+		l, err = NewClientCertificateLibrary(proj.PublicId, ClientCertificateIssuerInternal)
+		require.NoError(t, err)
+		_, err = repo.CreateClientCertificateLibrary(ctx, l, WithPublicId("ttcp_1234567890"))
+		assert.True(t, errors.Is(err, db.ErrInvalidPublicId))
+
+		tar, err := NewTcpTarget(proj.PublicId, WithName("bad-library"), WithClientCertificateLibraryId("ttcp_1234567890"))
+		require.NoError(t, err)
+		_, _, err = repo.CreateTcpTarget(ctx, tar)
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+	})
+	t.Run("internal", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tar := TestTcpTarget(t, conn, proj.PublicId, "internal-library")
+		cert, err := repo.IssueClientCertificate(ctx, tar, "u_1234567890", time.Now().Add(time.Hour))
+		require.NoError(err)
+		assert.Nil(cert)
+
+		l, err := NewClientCertificateLibrary(proj.PublicId, ClientCertificateIssuerInternal, WithName("internal"), WithServerName("db.internal"))
+		require.NoError(err)
+		l, err = repo.CreateClientCertificateLibrary(ctx, l)
+		require.NoError(err)
+		require.NotEmpty(l.PublicId)
+		require.NotEmpty(l.CaCert)
+		assert.Empty(l.Secret)
+
+		_, err = repo.CreateClientCertificateLibrary(ctx, func() *ClientCertificateLibrary {
+			dup, err := NewClientCertificateLibrary(proj.PublicId, ClientCertificateIssuerInternal, WithName("internal"))
+			require.NoError(err)
+			return dup
+		}())
+		assert.True(errors.Is(err, db.ErrNotUnique))
+
+		tar.ClientCertificateLibraryId = l.PublicId
+		updatedTarget, _, _, err := repo.UpdateTcpTarget(ctx, tar, tar.Version, []string{"ClientCertificateLibraryId"})
+		require.NoError(err)
+		assert.Equal(l.PublicId, updatedTarget.GetClientCertificateLibraryId())
+
+		expiration := time.Now().Add(time.Hour)
+		cert, err = repo.IssueClientCertificate(ctx, updatedTarget, "u_1234567890", expiration)
+		require.NoError(err)
+		require.NotNil(cert)
+		assert.Equal("db.internal", cert.ServerName)
+		keyPair, err := tls.X509KeyPair(cert.Certificate, cert.PrivateKey)
+		require.NoError(err)
+		leaf, err := x509.ParseCertificate(keyPair.Certificate[0])
+		require.NoError(err)
+		assert.Equal("u_1234567890", leaf.Subject.CommonName)
+		assert.WithinDuration(expiration, leaf.NotAfter, time.Minute)
+		roots := x509.NewCertPool()
+		require.True(roots.AppendCertsFromPEM(l.CaCert))
+		_, err = leaf.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+		assert.NoError(err)
+
+		// Changing the settings keeps the CA
+		l.CommonName = "boundary"
+		updated, rowsUpdated, err := repo.UpdateClientCertificateLibrary(ctx, l, l.Version, []string{"CommonName"})
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		assert.Equal(l.CaCert, updated.CaCert)
+		assert.Equal(l.Version+1, updated.Version)
+		cert, err = repo.IssueClientCertificate(ctx, updatedTarget, "u_1234567890", expiration)
+		require.NoError(err)
+		keyPair, err = tls.X509KeyPair(cert.Certificate, cert.PrivateKey)
+		require.NoError(err)
+		leaf, err = x509.ParseCertificate(keyPair.Certificate[0])
+		require.NoError(err)
+		assert.Equal("boundary", leaf.Subject.CommonName)
+
+		updated.VaultRole = "r"
+		_, _, err = repo.UpdateClientCertificateLibrary(ctx, updated, updated.Version, []string{"VaultRole"})
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		updated.Secret = []byte("s.token")
+		_, _, err = repo.UpdateClientCertificateLibrary(ctx, updated, updated.Version, []string{"Secret"})
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, _, err = repo.UpdateClientCertificateLibrary(ctx, updated, updated.Version, []string{"Issuer"})
+		assert.True(errors.Is(err, db.ErrInvalidFieldMask))
+
+		libraries, err := repo.ListClientCertificateLibraries(ctx, proj.PublicId)
+		require.NoError(err)
+		require.Len(libraries, 1)
+		assert.Equal(l.PublicId, libraries[0].PublicId)
+
+		// Deleting the library detaches it from its targets
+		deleted, err := repo.DeleteClientCertificateLibrary(ctx, l.PublicId)
+		require.NoError(err)
+		assert.Equal(1, deleted)
+		got, err := repo.LookupClientCertificateLibrary(ctx, l.PublicId)
+		require.NoError(err)
+		assert.Nil(got)
+		gotTarget, _, err := repo.LookupTarget(ctx, tar.PublicId)
+		require.NoError(err)
+		assert.Empty(gotTarget.GetClientCertificateLibraryId())
+	})
+	t.Run("vault", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ca, caKey, err := generateClientCa("vault")
+		require.NoError(err)
+		vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Vault-Token") != "s.token" || r.URL.Path != "/v1/pki/issue/boundary" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+			var req map[string]string
+			require.NoError(json.NewDecoder(r.Body).Decode(&req))
+			ttl, err := time.ParseDuration(req["ttl"])
+			require.NoError(err)
+			cert, err := issueInternalClientCertificate(ca, caKey, req["common_name"], time.Now().Add(ttl))
+			require.NoError(err)
+			var resp vaultIssueResponse
+			resp.Data.Certificate = string(cert.Certificate)
+			resp.Data.PrivateKey = string(cert.PrivateKey)
+			resp.Data.IssuingCa = string(ca)
+			resp.Data.Expiration = cert.ExpirationTime.Unix()
+			require.NoError(json.NewEncoder(w).Encode(resp))
+		}))
+		defer vault.Close()
+
+		l, err := NewClientCertificateLibrary(proj.PublicId, ClientCertificateIssuerVault,
+			WithVaultAddress(vault.URL),
+			WithVaultPkiPath("/pki/"),
+			WithVaultRole("boundary"),
+			WithVaultToken("s.token"),
+		)
+		require.NoError(err)
+		l, err = repo.CreateClientCertificateLibrary(ctx, l)
+		require.NoError(err)
+		assert.Empty(l.CaCert)
+		assert.Empty(l.Secret)
+		tar := TestTcpTarget(t, conn, proj.PublicId, "vault-library", WithClientCertificateLibraryId(l.PublicId))
+
+		cert, err := repo.IssueClientCertificate(ctx, tar, "u_1234567890", time.Now().Add(time.Hour))
+		require.NoError(err)
+		keyPair, err := tls.X509KeyPair(cert.Certificate, cert.PrivateKey)
+		require.NoError(err)
+		assert.Len(keyPair.Certificate, 2)
+
+		l.Secret = []byte("s.wrong")
+		_, _, err = repo.UpdateClientCertificateLibrary(ctx, l, l.Version, []string{"Secret"})
+		require.NoError(err)
+		_, err = repo.IssueClientCertificate(ctx, tar, "u_1234567890", time.Now().Add(time.Hour))
+		require.Error(err)
+		assert.Contains(err.Error(), "permission denied")
+	})
+}
@@ -0,0 +1,156 @@
+package target
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateMandatoryRecording inserts the mandatory recording of the targets of a
+// type in an org or project into the repository and returns it. No options are
+// currently supported.
+func (r *Repository) CreateMandatoryRecording(ctx context.Context, recording *MandatoryRecording, opt ...Option) (*MandatoryRecording, error) {
+	if recording == nil {
+		return nil, fmt.Errorf("create mandatory recording: missing recording: %w", db.ErrInvalidParameter)
+	}
+	if recording.MandatoryRecording == nil {
+		return nil, fmt.Errorf("create mandatory recording: missing recording store: %w", db.ErrInvalidParameter)
+	}
+	if recording.ScopeId == "" {
+		return nil, fmt.Errorf("create mandatory recording: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if recording.TargetType == "" {
+		return nil, fmt.Errorf("create mandatory recording: missing target type: %w", db.ErrInvalidParameter)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, recording.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create mandatory recording: unable to get oplog wrapper: %w", err)
+	}
+	m := recording.Clone()
+	var returnedRecording *MandatoryRecording
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedRecording = m.Clone()
+			return w.Create(ctx, returnedRecording, db.WithOplog(oplogWrapper, m.oplog(oplog.OpType_OP_TYPE_CREATE)))
+		},
+	)
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, fmt.Errorf("create mandatory recording: recording of %s targets is already mandatory in scope %s: %w", recording.TargetType, recording.ScopeId, db.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create mandatory recording: %w for %s", err, recording.ScopeId)
+	}
+	return returnedRecording, nil
+}
+
+// ListMandatoryRecordings lists the mandatory recordings of an org or project.
+// No options are currently supported.
+func (r *Repository) ListMandatoryRecordings(ctx context.Context, scopeId string, opt ...Option) ([]*MandatoryRecording, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("list mandatory recordings: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	var recordings []*MandatoryRecording
+	if err := r.reader.SearchWhere(ctx, &recordings, "scope_id = ?", []interface{}{scopeId}); err != nil {
+		return nil, fmt.Errorf("list mandatory recordings: %w", err)
+	}
+	return recordings, nil
+}
+
+// DeleteMandatoryRecording will delete the mandatory recording of the targets
+// of a type in an org or project from the repository. No options are
+// currently supported.
+func (r *Repository) DeleteMandatoryRecording(ctx context.Context, scopeId string, targetType TargetType, opt ...Option) (int, error) {
+	if scopeId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete mandatory recording: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if targetType == UnknownTargetType {
+		return db.NoRowsAffected, fmt.Errorf("delete mandatory recording: unknown target type: %w", db.ErrInvalidParameter)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete mandatory recording: unable to get oplog wrapper: %w", err)
+	}
+	m := allocMandatoryRecording()
+	m.ScopeId = scopeId
+	m.TargetType = targetType.String()
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			deleteRecording := m.Clone()
+			var err error
+			rowsDeleted, err = w.Delete(ctx, deleteRecording, db.WithOplog(oplogWrapper, m.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err == nil && rowsDeleted > 1 {
+				// return err, which will result in a rollback of the delete
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete mandatory recording: %w for %s", err, scopeId)
+	}
+	return rowsDeleted, nil
+}
+
+// RecordingMandatory reports whether the recording of the target's sessions is
+// mandatory, because its project or org makes the recording of its type of
+// targets mandatory. No options are currently supported.
+func (r *Repository) RecordingMandatory(ctx context.Context, targetId string, opt ...Option) (bool, error) {
+	if targetId == "" {
+		return false, fmt.Errorf("recording mandatory: missing target id: %w", db.ErrInvalidParameter)
+	}
+	t, _, err := r.LookupTarget(ctx, targetId)
+	if err != nil {
+		return false, fmt.Errorf("recording mandatory: %w", err)
+	}
+	if t == nil {
+		return false, fmt.Errorf("recording mandatory: target %s: %w", targetId, db.ErrRecordNotFound)
+	}
+	var recordings []*MandatoryRecording
+	where := "target_type = ? and (" + sessionPoliciesForScope + ")"
+	if err := r.reader.SearchWhere(ctx, &recordings, where, []interface{}{t.GetType(), t.GetScopeId(), t.GetScopeId()}); err != nil {
+		return false, fmt.Errorf("recording mandatory: unable to search for mandatory recordings: %w", err)
+	}
+	return len(recordings) > 0, nil
+}
+
+// CreateRecordingExemption logs that a session was authorized without
+// recording for a target whose recording is mandatory, because the user holds
+// an exemption grant. No options are currently supported.
+func (r *Repository) CreateRecordingExemption(ctx context.Context, exemption *RecordingExemption, opt ...Option) error {
+	if exemption == nil {
+		return fmt.Errorf("create recording exemption: missing exemption: %w", db.ErrInvalidParameter)
+	}
+	switch {
+	case exemption.SessionId == "":
+		return fmt.Errorf("create recording exemption: missing session id: %w", db.ErrInvalidParameter)
+	case exemption.TargetId == "":
+		return fmt.Errorf("create recording exemption: missing target id: %w", db.ErrInvalidParameter)
+	case exemption.UserId == "":
+		return fmt.Errorf("create recording exemption: missing user id: %w", db.ErrInvalidParameter)
+	case exemption.AuthTokenId == "":
+		return fmt.Errorf("create recording exemption: missing auth token id: %w", db.ErrInvalidParameter)
+	}
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			e := *exemption
+			return w.Create(ctx, &e)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("create recording exemption: %w for %s", err, exemption.SessionId)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_MandatoryRecording(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+
+	t.Run("unknown-type", func(t *testing.T) {
+		_, err := NewMandatoryRecording(org.PublicId, UnknownTargetType)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+	})
+	t.Run("global-scope", func(t *testing.T) {
+		m, err := NewMandatoryRecording("global", TcpTargetType)
+		require.NoError(t, err)
+		_, err = repo.CreateMandatoryRecording(ctx, m)
+		assert.Error(t, err)
+	})
+	t.Run("crud", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tar := TestTcpTarget(t, conn, proj.PublicId, "mandatory")
+		mandatory, err := repo.RecordingMandatory(ctx, tar.PublicId)
+		require.NoError(err)
+		assert.False(mandatory)
+
+		m, err := NewMandatoryRecording(org.PublicId, TcpTargetType)
+		require.NoError(err)
+		created, err := repo.CreateMandatoryRecording(ctx, m)
+		require.NoError(err)
+		assert.Equal(TcpTargetType.String(), created.TargetType)
+		assert.NotNil(created.CreateTime)
+
+		_, err = repo.CreateMandatoryRecording(ctx, m)
+		require.Error(err)
+		assert.True(errors.Is(err, db.ErrNotUnique))
+
+		got, err := repo.ListMandatoryRecordings(ctx, org.PublicId)
+		require.NoError(err)
+		assert.Len(got, 1)
+
+		// The org's mandatory recording applies to the targets in its projects
+		mandatory, err = repo.RecordingMandatory(ctx, tar.PublicId)
+		require.NoError(err)
+		assert.True(mandatory)
+
+		// But not to the targets in other orgs
+		_, other := iam.TestScopes(t, iamRepo)
+		otherTar := TestTcpTarget(t, conn, other.PublicId, "other")
+		mandatory, err = repo.RecordingMandatory(ctx, otherTar.PublicId)
+		require.NoError(err)
+		assert.False(mandatory)
+
+		n, err := repo.DeleteMandatoryRecording(ctx, org.PublicId, TcpTargetType)
+		require.NoError(err)
+		assert.Equal(1, n)
+		mandatory, err = repo.RecordingMandatory(ctx, tar.PublicId)
+		require.NoError(err)
+		assert.False(mandatory)
+	})
+	t.Run("exemption", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		err := repo.CreateRecordingExemption(ctx, &RecordingExemption{SessionId: "s_1234567890"})
+		require.Error(err)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+
+		e := &RecordingExemption{
+			SessionId:   "s_1234567890",
+			TargetId:    "ttcp_1234567890",
+			TargetType:  TcpTargetType.String(),
+			ScopeId:     proj.PublicId,
+			UserId:      "u_1234567890",
+			AuthTokenId: "at_1234567890",
+		}
+		require.NoError(repo.CreateRecordingExemption(ctx, e))
+		var got RecordingExemption
+		require.NoError(rw.LookupWhere(ctx, &got, "session_id = ?", e.SessionId))
+		assert.Equal(e.UserId, got.UserId)
+		assert.NotNil(got.CreateTime)
+	})
+}
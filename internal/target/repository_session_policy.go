@@ -0,0 +1,292 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+)
+
+// SessionPolicySourceDefault is the source of an effective session setting
+// which no target or session policy sets.
+const SessionPolicySourceDefault = "default"
+
+// sessionPoliciesForScope finds the session policies of a project and its org.
+const sessionPoliciesForScope = "scope_id = ? or scope_id = (select parent_id from iam_scope where public_id = ?)"
+
+// EffectiveSessionPolicy is the session settings in effect for a target. A
+// setting comes from the target if it sets it, or else from the policy of its
+// project, then of its org, and then from the defaults.
+type EffectiveSessionPolicy struct {
+	TargetId                  string
+	SessionMaxSeconds         uint32
+	SessionConnectionLimit    int32
+	SessionIdleTimeoutSeconds int32
+	RecordingRequired         bool
+
+	// SessionResumeSeconds is how long a session can be resumed after a
+	// connection is lost to a network error. It's set per target and isn't
+	// inherited.
+	SessionResumeSeconds uint32
+
+	// Sources is where each setting came from, keyed by the setting's
+	// column name, like session_max_seconds. It's the id of the target or
+	// scope which sets it, or SessionPolicySourceDefault.
+	Sources map[string]string
+}
+
+// CreateSessionPolicy inserts the session policy of an org or project into
+// the repository and returns it. A scope has at most one session policy. No
+// options are currently supported.
+func (r *Repository) CreateSessionPolicy(ctx context.Context, policy *SessionPolicy, opt ...Option) (*SessionPolicy, error) {
+	if policy == nil {
+		return nil, fmt.Errorf("create session policy: missing policy: %w", db.ErrInvalidParameter)
+	}
+	if policy.SessionPolicy == nil {
+		return nil, fmt.Errorf("create session policy: missing policy store: %w", db.ErrInvalidParameter)
+	}
+	if policy.ScopeId == "" {
+		return nil, fmt.Errorf("create session policy: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, policy.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create session policy: unable to get oplog wrapper: %w", err)
+	}
+	p := policy.Clone()
+	if p.SessionRecording == "" {
+		p.SessionRecording = string(SessionRecordingInherit)
+	}
+	var returnedPolicy *SessionPolicy
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedPolicy = p.Clone()
+			return w.Create(ctx, returnedPolicy, db.WithOplog(oplogWrapper, p.oplog(oplog.OpType_OP_TYPE_CREATE)))
+		},
+	)
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, fmt.Errorf("create session policy: scope %s already has a session policy: %w", policy.ScopeId, db.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create session policy: %w for %s", err, policy.ScopeId)
+	}
+	return returnedPolicy, nil
+}
+
+// UpdateSessionPolicy will update the session policy of an org or project in
+// the repository and return the written policy. fieldMaskPaths provides
+// field_mask.proto paths for the settings that should be updated, and
+// settings set to their zero values are inherited. The policy's current db
+// version must match the version or an error will be returned. No options are
+// currently supported.
+func (r *Repository) UpdateSessionPolicy(ctx context.Context, policy *SessionPolicy, version uint32, fieldMaskPaths []string, opt ...Option) (*SessionPolicy, int, error) {
+	if policy == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update session policy: missing policy: %w", db.ErrInvalidParameter)
+	}
+	if policy.SessionPolicy == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update session policy: missing policy store: %w", db.ErrInvalidParameter)
+	}
+	if policy.ScopeId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update session policy: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update session policy: version cannot be zero: %w", db.ErrInvalidParameter)
+	}
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("sessionmaxseconds", f):
+		case strings.EqualFold("sessionconnectionlimit", f):
+		case strings.EqualFold("sessionidletimeoutseconds", f):
+		case strings.EqualFold("sessionrecording", f):
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update session policy: field: %s: %w", f, db.ErrInvalidFieldMask)
+		}
+	}
+	p := policy.Clone()
+	if p.SessionRecording == "" {
+		// Clearing the session recording inherits it
+		p.SessionRecording = string(SessionRecordingInherit)
+	}
+	dbMask, nullFields := dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"SessionMaxSeconds":         p.SessionMaxSeconds,
+			"SessionConnectionLimit":    p.SessionConnectionLimit,
+			"SessionIdleTimeoutSeconds": p.SessionIdleTimeoutSeconds,
+			"SessionRecording":          p.SessionRecording,
+		},
+		fieldMaskPaths,
+		[]string{"SessionMaxSeconds", "SessionConnectionLimit", "SessionIdleTimeoutSeconds"},
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update session policy: %w", db.ErrEmptyFieldMask)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, policy.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update session policy: unable to get oplog wrapper: %w", err)
+	}
+	var rowsUpdated int
+	var returnedPolicy *SessionPolicy
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedPolicy = p.Clone()
+			var err error
+			rowsUpdated, err = w.Update(
+				ctx,
+				returnedPolicy,
+				dbMask,
+				nullFields,
+				db.WithOplog(oplogWrapper, p.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version),
+			)
+			if err == nil && rowsUpdated > 1 {
+				// return err, which will result in a rollback of the update
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update session policy: %w for %s", err, policy.ScopeId)
+	}
+	return returnedPolicy, rowsUpdated, nil
+}
+
+// LookupSessionPolicy will look up the session policy of an org or project in
+// the repository. If the scope has no session policy, it will return nil, nil.
+// No options are currently supported.
+func (r *Repository) LookupSessionPolicy(ctx context.Context, scopeId string, opt ...Option) (*SessionPolicy, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("lookup session policy: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	p := allocSessionPolicy()
+	if err := r.reader.LookupWhere(ctx, &p, "scope_id = ?", scopeId); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup session policy: failed %w for %s", err, scopeId)
+	}
+	return &p, nil
+}
+
+// DeleteSessionPolicy will delete the session policy of an org or project
+// from the repository, so its targets inherit the settings it set. No options
+// are currently supported.
+func (r *Repository) DeleteSessionPolicy(ctx context.Context, scopeId string, opt ...Option) (int, error) {
+	if scopeId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete session policy: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete session policy: unable to get oplog wrapper: %w", err)
+	}
+	p := allocSessionPolicy()
+	p.ScopeId = scopeId
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			deletePolicy := p.Clone()
+			var err error
+			rowsDeleted, err = w.Delete(ctx, deletePolicy, db.WithOplog(oplogWrapper, p.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err == nil && rowsDeleted > 1 {
+				// return err, which will result in a rollback of the delete
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete session policy: %w for %s", err, scopeId)
+	}
+	return rowsDeleted, nil
+}
+
+// EffectiveSessionPolicy returns the session settings in effect for the
+// target, resolving the settings it doesn't set from the session policies of
+// its project and org, and then the defaults. No options are currently
+// supported.
+func (r *Repository) EffectiveSessionPolicy(ctx context.Context, targetId string, opt ...Option) (*EffectiveSessionPolicy, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("effective session policy: missing target id: %w", db.ErrInvalidParameter)
+	}
+	t, _, err := r.LookupTarget(ctx, targetId)
+	if err != nil {
+		return nil, fmt.Errorf("effective session policy: %w", err)
+	}
+	if t == nil {
+		return nil, fmt.Errorf("effective session policy: target %s: %w", targetId, db.ErrRecordNotFound)
+	}
+	var policies []*SessionPolicy
+	if err := r.reader.SearchWhere(ctx, &policies, sessionPoliciesForScope, []interface{}{t.GetScopeId(), t.GetScopeId()}); err != nil {
+		return nil, fmt.Errorf("effective session policy: unable to search for session policies: %w", err)
+	}
+	// The target's own settings come first, then its project's policy, then
+	// its org's.
+	chain := []*store.SessionPolicy{{
+		ScopeId:                   t.GetPublicId(),
+		SessionMaxSeconds:         t.GetSessionMaxSeconds(),
+		SessionConnectionLimit:    t.GetSessionConnectionLimit(),
+		SessionIdleTimeoutSeconds: t.GetSessionIdleTimeoutSeconds(),
+		SessionRecording:          t.GetSessionRecording(),
+	}}
+	for _, p := range policies {
+		if p.ScopeId == t.GetScopeId() {
+			chain = append(chain, p.SessionPolicy)
+		}
+	}
+	for _, p := range policies {
+		if p.ScopeId != t.GetScopeId() {
+			chain = append(chain, p.SessionPolicy)
+		}
+	}
+
+	e := &EffectiveSessionPolicy{
+		TargetId:                  targetId,
+		SessionMaxSeconds:         DefaultSessionMaxSeconds,
+		SessionConnectionLimit:    DefaultSessionConnectionLimit,
+		SessionIdleTimeoutSeconds: DefaultSessionIdleTimeoutSeconds,
+		SessionResumeSeconds:      t.GetSessionResumeSeconds(),
+		Sources: map[string]string{
+			"session_max_seconds":          SessionPolicySourceDefault,
+			"session_connection_limit":     SessionPolicySourceDefault,
+			"session_idle_timeout_seconds": SessionPolicySourceDefault,
+			"session_recording":            SessionPolicySourceDefault,
+		},
+	}
+	// Walk from the least to the most specific, so the most specific setting
+	// wins.
+	for i := len(chain) - 1; i >= 0; i-- {
+		p := chain[i]
+		if p.SessionMaxSeconds != 0 {
+			e.SessionMaxSeconds = p.SessionMaxSeconds
+			e.Sources["session_max_seconds"] = p.ScopeId
+		}
+		if p.SessionConnectionLimit != 0 {
+			e.SessionConnectionLimit = p.SessionConnectionLimit
+			e.Sources["session_connection_limit"] = p.ScopeId
+		}
+		if p.SessionIdleTimeoutSeconds != 0 {
+			e.SessionIdleTimeoutSeconds = p.SessionIdleTimeoutSeconds
+			e.Sources["session_idle_timeout_seconds"] = p.ScopeId
+		}
+		switch SessionRecording(p.SessionRecording) {
+		case SessionRecordingRequired, SessionRecordingOptional:
+			e.RecordingRequired = SessionRecording(p.SessionRecording) == SessionRecordingRequired
+			e.Sources["session_recording"] = p.ScopeId
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,152 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_SessionPolicy(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	org, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+
+	t.Run("global-scope", func(t *testing.T) {
+		p, err := NewSessionPolicy("global", WithSessionMaxSeconds(60))
+		require.NoError(t, err)
+		_, err = repo.CreateSessionPolicy(ctx, p)
+		assert.Error(t, err)
+	})
+	t.Run("bad-recording", func(t *testing.T) {
+		_, err := NewSessionPolicy(org.PublicId, WithSessionRecording("sometimes"))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+	})
+	t.Run("crud", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.LookupSessionPolicy(ctx, proj.PublicId)
+		require.NoError(err)
+		assert.Nil(got)
+
+		p, err := NewSessionPolicy(proj.PublicId, WithSessionMaxSeconds(60))
+		require.NoError(err)
+		created, err := repo.CreateSessionPolicy(ctx, p)
+		require.NoError(err)
+		assert.Equal(uint32(60), created.SessionMaxSeconds)
+		assert.Equal(string(SessionRecordingInherit), created.SessionRecording)
+
+		_, err = repo.CreateSessionPolicy(ctx, p)
+		require.Error(err)
+		assert.True(errors.Is(err, db.ErrNotUnique))
+
+		created.SessionMaxSeconds = 0
+		created.SessionConnectionLimit = 2
+		updated, n, err := repo.UpdateSessionPolicy(ctx, created, created.Version, []string{"SessionMaxSeconds", "SessionConnectionLimit"})
+		require.NoError(err)
+		assert.Equal(1, n)
+		got, err = repo.LookupSessionPolicy(ctx, proj.PublicId)
+		require.NoError(err)
+		assert.Equal(uint32(0), got.SessionMaxSeconds)
+		assert.Equal(int32(2), got.SessionConnectionLimit)
+		assert.Equal(updated.Version, got.Version)
+
+		_, _, err = repo.UpdateSessionPolicy(ctx, got, got.Version, []string{"ScopeId"})
+		require.Error(err)
+		assert.True(errors.Is(err, db.ErrInvalidFieldMask))
+
+		n, err = repo.DeleteSessionPolicy(ctx, proj.PublicId)
+		require.NoError(err)
+		assert.Equal(1, n)
+		got, err = repo.LookupSessionPolicy(ctx, proj.PublicId)
+		require.NoError(err)
+		assert.Nil(got)
+	})
+}
+
+func TestRepository_EffectiveSessionPolicy(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+
+	assert, require := assert.New(t), require.New(t)
+	tar := TestTcpTarget(t, conn, proj.PublicId, "inherits")
+	got, err := repo.EffectiveSessionPolicy(ctx, tar.PublicId)
+	require.NoError(err)
+	assert.Equal(&EffectiveSessionPolicy{
+		TargetId:                  tar.PublicId,
+		SessionMaxSeconds:         DefaultSessionMaxSeconds,
+		SessionConnectionLimit:    DefaultSessionConnectionLimit,
+		SessionIdleTimeoutSeconds: DefaultSessionIdleTimeoutSeconds,
+		Sources: map[string]string{
+			"session_max_seconds":          SessionPolicySourceDefault,
+			"session_connection_limit":     SessionPolicySourceDefault,
+			"session_idle_timeout_seconds": SessionPolicySourceDefault,
+			"session_recording":            SessionPolicySourceDefault,
+		},
+	}, got)
+
+	orgPolicy, err := NewSessionPolicy(org.PublicId,
+		WithSessionMaxSeconds(600),
+		WithSessionConnectionLimit(3),
+		WithSessionRecording(SessionRecordingRequired))
+	require.NoError(err)
+	_, err = repo.CreateSessionPolicy(ctx, orgPolicy)
+	require.NoError(err)
+	projPolicy, err := NewSessionPolicy(proj.PublicId,
+		WithSessionMaxSeconds(300),
+		WithSessionIdleTimeoutSeconds(30))
+	require.NoError(err)
+	_, err = repo.CreateSessionPolicy(ctx, projPolicy)
+	require.NoError(err)
+	explicit := TestTcpTarget(t, conn, proj.PublicId, "explicit", WithSessionConnectionLimit(-1))
+
+	got, err = repo.EffectiveSessionPolicy(ctx, tar.PublicId)
+	require.NoError(err)
+	assert.Equal(uint32(300), got.SessionMaxSeconds)
+	assert.Equal(int32(3), got.SessionConnectionLimit)
+	assert.Equal(int32(30), got.SessionIdleTimeoutSeconds)
+	assert.True(got.RecordingRequired)
+	assert.Equal(map[string]string{
+		"session_max_seconds":          proj.PublicId,
+		"session_connection_limit":     org.PublicId,
+		"session_idle_timeout_seconds": proj.PublicId,
+		"session_recording":            org.PublicId,
+	}, got.Sources)
+
+	got, err = repo.EffectiveSessionPolicy(ctx, explicit.PublicId)
+	require.NoError(err)
+	assert.Equal(int32(-1), got.SessionConnectionLimit)
+	assert.Equal(explicit.PublicId, got.Sources["session_connection_limit"])
+	assert.Equal(uint32(300), got.SessionMaxSeconds)
+
+	// The policy of another project doesn't apply
+	_, other := iam.TestScopes(t, iamRepo)
+	otherTar := TestTcpTarget(t, conn, other.PublicId, "other")
+	got, err = repo.EffectiveSessionPolicy(ctx, otherTar.PublicId)
+	require.NoError(err)
+	assert.Equal(DefaultSessionMaxSeconds, got.SessionMaxSeconds)
+	assert.False(got.RecordingRequired)
+
+	_, err = repo.EffectiveSessionPolicy(ctx, "ttcp_doesnotexist")
+	require.Error(err)
+	assert.True(errors.Is(err, db.ErrRecordNotFound))
+}
@@ -32,7 +32,7 @@ func (r *Repository) CreateTcpTarget(ctx context.Context, target *TcpTarget, opt
 		return nil, nil, fmt.Errorf("create tcp target: public id not empty: %w", db.ErrInvalidParameter)
 	}
 
-	t := target.Clone().(*TcpTarget)
+	t := target.Clone()
 
 	if opts.withPublicId != "" {
 		if !strings.HasPrefix(opts.withPublicId, TcpTargetPrefix+"_") {
@@ -63,7 +63,7 @@ func (r *Repository) CreateTcpTarget(ctx context.Context, target *TcpTarget, opt
 	}
 
 	metadata := t.oplog(oplog.OpType_OP_TYPE_CREATE)
-	var returnedTarget interface{}
+	var returnedTarget *TcpTarget
 	var returnedHostSet []*TargetSet
 	_, err = r.writer.DoTx(
 		ctx,
@@ -101,15 +101,17 @@ func (r *Repository) CreateTcpTarget(ctx context.Context, target *TcpTarget, opt
 	if err != nil {
 		return nil, nil, fmt.Errorf("create tcp target: %w for %s target id id", err, t.PublicId)
 	}
-	return returnedTarget.(*TcpTarget), returnedHostSet, err
+	return returnedTarget, returnedHostSet, err
 }
 
 // UpdateTcpTarget will update a target in the repository and return the written
 // target. fieldMaskPaths provides field_mask.proto paths for fields that should
 // be updated.  Fields will be set to NULL if the field is a zero value and
-// included in fieldMask. Name and Description are the only updatable fields,
-// If no updatable fields are included in the fieldMaskPaths, then an error is
-// returned.
+// included in fieldMask. Name, Description, DefaultPort, the banner settings,
+// JustificationPattern, ChangeTicketSystem, ClientCertificateLibraryId and the
+// session settings are the only updatable fields. Session settings set to their zero values are
+// inherited. If no updatable fields are included in the fieldMaskPaths, then
+// an error is returned.
 func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, version uint32, fieldMaskPaths []string, opt ...Option) (Target, []*TargetSet, int, error) {
 	if target == nil {
 		return nil, nil, db.NoRowsAffected, fmt.Errorf("update tcp target: missing target %w", db.ErrInvalidParameter)
@@ -127,21 +129,42 @@ func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, ver
 		case strings.EqualFold("defaultport", f):
 		case strings.EqualFold("sessionmaxseconds", f):
 		case strings.EqualFold("sessionconnectionlimit", f):
+		case strings.EqualFold("sessionidletimeoutseconds", f):
+		case strings.EqualFold("sessionrecording", f):
+		case strings.EqualFold("sessionresumeseconds", f):
+		case strings.EqualFold("banner", f):
+		case strings.EqualFold("banneracknowledgmentrequired", f):
+		case strings.EqualFold("justificationpattern", f):
+		case strings.EqualFold("changeticketsystem", f):
+		case strings.EqualFold("clientcertificatelibraryid", f):
 		default:
 			return nil, nil, db.NoRowsAffected, fmt.Errorf("update tcp target: field: %s: %w", f, db.ErrInvalidFieldMask)
 		}
 	}
+	sessionRecording := target.SessionRecording
+	if sessionRecording == "" {
+		// Clearing the session recording inherits it
+		sessionRecording = string(SessionRecordingInherit)
+	}
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"Name":                   target.Name,
-			"Description":            target.Description,
-			"DefaultPort":            target.DefaultPort,
-			"SessionMaxSeconds":      target.SessionMaxSeconds,
-			"SessionConnectionLimit": target.SessionConnectionLimit,
+			"Name":                         target.Name,
+			"Description":                  target.Description,
+			"DefaultPort":                  target.DefaultPort,
+			"SessionMaxSeconds":            target.SessionMaxSeconds,
+			"SessionConnectionLimit":       target.SessionConnectionLimit,
+			"SessionIdleTimeoutSeconds":    target.SessionIdleTimeoutSeconds,
+			"SessionRecording":             sessionRecording,
+			"SessionResumeSeconds":         target.SessionResumeSeconds,
+			"Banner":                       target.Banner,
+			"BannerAcknowledgmentRequired": target.BannerAcknowledgmentRequired,
+			"JustificationPattern":         target.JustificationPattern,
+			"ChangeTicketSystem":           target.ChangeTicketSystem,
+			"ClientCertificateLibraryId":   target.ClientCertificateLibraryId,
 		},
 		fieldMaskPaths,
-		[]string{"SessionMaxSeconds", "SessionConnectionLimit"},
+		[]string{"SessionMaxSeconds", "SessionConnectionLimit", "SessionIdleTimeoutSeconds", "SessionResumeSeconds", "BannerAcknowledgmentRequired"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, nil, db.NoRowsAffected, fmt.Errorf("update tcp target: %w", db.ErrEmptyFieldMask)
@@ -155,7 +178,8 @@ func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, ver
 		db.ExpBackoff{},
 		func(read db.Reader, w db.Writer) error {
 			var err error
-			t := target.Clone().(*TcpTarget)
+			t := target.Clone()
+			t.SessionRecording = sessionRecording
 			returnedTarget, targetSets, rowsUpdated, err = r.update(ctx, t, version, dbMask, nullFields)
 			if err != nil {
 				return err
@@ -169,5 +193,5 @@ func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, ver
 		}
 		return nil, nil, db.NoRowsAffected, fmt.Errorf("update tcp target: %w for %s", err, target.PublicId)
 	}
-	return returnedTarget.(Target), targetSets, rowsUpdated, err
+	return returnedTarget, targetSets, rowsUpdated, err
 }
@@ -0,0 +1,129 @@
+package target
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultSessionPolicyTableName = "target_session_policy"
+
+	// DefaultSessionMaxSeconds, DefaultSessionConnectionLimit and
+	// DefaultSessionIdleTimeoutSeconds are the session settings of targets
+	// which don't set them and whose scopes' policies don't either.
+	DefaultSessionMaxSeconds         = uint32(8 * time.Hour / time.Second)
+	DefaultSessionConnectionLimit    = int32(1)
+	DefaultSessionIdleTimeoutSeconds = int32(-1)
+)
+
+// SessionRecording is whether the sessions of a target must be recorded.
+type SessionRecording string
+
+const (
+	SessionRecordingInherit  SessionRecording = "inherit"
+	SessionRecordingRequired SessionRecording = "required"
+	SessionRecordingOptional SessionRecording = "optional"
+)
+
+// validSessionRecording reports whether r is a SessionRecording. Empty means
+// the default, which is inherit.
+func validSessionRecording(r string) bool {
+	switch SessionRecording(r) {
+	case "", SessionRecordingInherit, SessionRecordingRequired, SessionRecordingOptional:
+		return true
+	}
+	return false
+}
+
+// SessionPolicy is the default session settings of an org or project, which
+// the targets in it inherit unless they set their own. A project's policy
+// inherits the settings it doesn't set from its org's policy.
+type SessionPolicy struct {
+	*store.SessionPolicy
+	tableName string `gorm:"-"`
+}
+
+var _ db.VetForWriter = (*SessionPolicy)(nil)
+var _ oplog.ReplayableMessage = (*SessionPolicy)(nil)
+
+// NewSessionPolicy creates a new in memory session policy for the org or
+// project (scopeId). The WithSessionMaxSeconds, WithSessionConnectionLimit,
+// WithSessionIdleTimeoutSeconds and WithSessionRecording options are
+// supported, and settings which aren't set are inherited.
+func NewSessionPolicy(scopeId string, opt ...Option) (*SessionPolicy, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("new session policy: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	p := &SessionPolicy{
+		SessionPolicy: &store.SessionPolicy{
+			ScopeId:                   scopeId,
+			SessionMaxSeconds:         opts.withSessionMaxSeconds,
+			SessionConnectionLimit:    opts.withSessionConnectionLimit,
+			SessionIdleTimeoutSeconds: opts.withSessionIdleTimeout,
+			SessionRecording:          string(opts.withSessionRecording),
+		},
+	}
+	if !validSessionRecording(p.SessionRecording) {
+		return nil, fmt.Errorf("new session policy: unknown session recording %q: %w", p.SessionRecording, db.ErrInvalidParameter)
+	}
+	return p, nil
+}
+
+// allocSessionPolicy will allocate a session policy
+func allocSessionPolicy() SessionPolicy {
+	return SessionPolicy{
+		SessionPolicy: &store.SessionPolicy{},
+	}
+}
+
+// Clone creates a clone of the SessionPolicy
+func (p *SessionPolicy) Clone() *SessionPolicy {
+	cp := proto.Clone(p.SessionPolicy)
+	return &SessionPolicy{
+		SessionPolicy: cp.(*store.SessionPolicy),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the session
+// policy before it's written.
+func (p *SessionPolicy) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if p.ScopeId == "" {
+		return fmt.Errorf("session policy vet for write: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if !validSessionRecording(p.SessionRecording) {
+		return fmt.Errorf("session policy vet for write: unknown session recording %q: %w", p.SessionRecording, db.ErrInvalidParameter)
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (p *SessionPolicy) TableName() string {
+	if p.tableName != "" {
+		return p.tableName
+	}
+	return defaultSessionPolicyTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (p *SessionPolicy) SetTableName(n string) {
+	p.tableName = n
+}
+
+func (p *SessionPolicy) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{p.ScopeId},
+		"resource-type":      []string{"session policy"},
+		"op-type":            []string{op.String()},
+		"scope-id":           []string{p.ScopeId},
+	}
+	return metadata
+}
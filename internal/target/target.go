@@ -21,6 +21,14 @@ type Target interface {
 	GetUpdateTime() *timestamp.Timestamp
 	GetSessionMaxSeconds() uint32
 	GetSessionConnectionLimit() int32
+	GetSessionIdleTimeoutSeconds() int32
+	GetSessionRecording() string
+	GetSessionResumeSeconds() uint32
+	GetBanner() string
+	GetBannerAcknowledgmentRequired() bool
+	GetJustificationPattern() string
+	GetChangeTicketSystem() string
+	GetClientCertificateLibraryId() string
 	oplog(op oplog.OpType) oplog.Metadata
 }
 
@@ -92,6 +100,14 @@ func (t *targetView) targetSubType() (Target, error) {
 		tcpTarget.Version = t.Version
 		tcpTarget.SessionMaxSeconds = t.SessionMaxSeconds
 		tcpTarget.SessionConnectionLimit = t.SessionConnectionLimit
+		tcpTarget.SessionIdleTimeoutSeconds = t.SessionIdleTimeoutSeconds
+		tcpTarget.SessionRecording = t.SessionRecording
+		tcpTarget.SessionResumeSeconds = t.SessionResumeSeconds
+		tcpTarget.Banner = t.Banner
+		tcpTarget.BannerAcknowledgmentRequired = t.BannerAcknowledgmentRequired
+		tcpTarget.JustificationPattern = t.JustificationPattern
+		tcpTarget.ChangeTicketSystem = t.ChangeTicketSystem
+		tcpTarget.ClientCertificateLibraryId = t.ClientCertificateLibraryId
 		return &tcpTarget, nil
 	}
 	return nil, fmt.Errorf("%s is an unknown target subtype of %s", t.PublicId, t.Type)
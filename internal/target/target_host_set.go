@@ -48,7 +48,7 @@ func allocTargetHostSet() TargetHostSet {
 }
 
 // Clone creates a clone of the target host set
-func (t *TargetHostSet) Clone() interface{} {
+func (t *TargetHostSet) Clone() *TargetHostSet {
 	cp := proto.Clone(t.TargetHostSet)
 	return &TargetHostSet{
 		TargetHostSet: cp.(*store.TargetHostSet),
@@ -3,6 +3,7 @@ package target
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/oplog"
@@ -23,8 +24,9 @@ var _ Target = (*TcpTarget)(nil)
 var _ db.VetForWriter = (*TcpTarget)(nil)
 var _ oplog.ReplayableMessage = (*TcpTarget)(nil)
 
-// NewTcpTarget creates a new in memory tcp target.  WithName, WithDescription and
-// WithDefaultPort options are supported
+// NewTcpTarget creates a new in memory tcp target.  WithName, WithDescription,
+// WithDefaultPort and the session options are supported. Session settings
+// which aren't set are inherited from the target's SessionPolicy.
 func NewTcpTarget(scopeId string, opt ...Option) (*TcpTarget, error) {
 	opts := getOpts(opt...)
 	if scopeId == "" {
@@ -32,12 +34,20 @@ func NewTcpTarget(scopeId string, opt ...Option) (*TcpTarget, error) {
 	}
 	t := &TcpTarget{
 		TcpTarget: &store.TcpTarget{
-			ScopeId:                scopeId,
-			Name:                   opts.withName,
-			Description:            opts.withDescription,
-			DefaultPort:            opts.withDefaultPort,
-			SessionConnectionLimit: opts.withSessionConnectionLimit,
-			SessionMaxSeconds:      opts.withSessionMaxSeconds,
+			ScopeId:                      scopeId,
+			Name:                         opts.withName,
+			Description:                  opts.withDescription,
+			DefaultPort:                  opts.withDefaultPort,
+			SessionConnectionLimit:       opts.withSessionConnectionLimit,
+			SessionMaxSeconds:            opts.withSessionMaxSeconds,
+			SessionIdleTimeoutSeconds:    opts.withSessionIdleTimeout,
+			SessionRecording:             string(opts.withSessionRecording),
+			SessionResumeSeconds:         opts.withSessionResumeSeconds,
+			Banner:                       opts.withBanner,
+			BannerAcknowledgmentRequired: opts.withBannerAcknowledgmentRequired,
+			JustificationPattern:         opts.withJustificationPattern,
+			ChangeTicketSystem:           opts.withChangeTicketSystem,
+			ClientCertificateLibraryId:   opts.withClientCertificateLibraryId,
 		},
 	}
 	return t, nil
@@ -51,13 +61,18 @@ func allocTcpTarget() TcpTarget {
 }
 
 // Clone creates a clone of the TcpTarget
-func (t *TcpTarget) Clone() interface{} {
+func (t *TcpTarget) Clone() *TcpTarget {
 	cp := proto.Clone(t.TcpTarget)
 	return &TcpTarget{
 		TcpTarget: cp.(*store.TcpTarget),
 	}
 }
 
+// cloneTarget implements Cloneable.
+func (t *TcpTarget) cloneTarget() Target {
+	return t.Clone()
+}
+
 // VetForWrite implements db.VetForWrite() interface and validates the tcp target
 // before it's written.
 func (t *TcpTarget) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
@@ -72,6 +87,18 @@ func (t *TcpTarget) VetForWrite(ctx context.Context, r db.Reader, opType db.OpTy
 			return fmt.Errorf("tcp target vet for write: missing name id: %w", db.ErrInvalidParameter)
 		}
 	}
+	if !validSessionRecording(t.SessionRecording) {
+		return fmt.Errorf("tcp target vet for write: unknown session recording %q: %w", t.SessionRecording, db.ErrInvalidParameter)
+	}
+	if err := ValidJustificationPattern(t.JustificationPattern); err != nil {
+		return fmt.Errorf("tcp target vet for write: %w", err)
+	}
+	if err := ValidChangeTicketSystem(t.ChangeTicketSystem); err != nil {
+		return fmt.Errorf("tcp target vet for write: %w", err)
+	}
+	if t.ClientCertificateLibraryId != "" && !strings.HasPrefix(t.ClientCertificateLibraryId, ClientCertificateLibraryPrefix+"_") {
+		return fmt.Errorf("tcp target vet for write: client certificate library id %q has wrong prefix, should be %q: %w", t.ClientCertificateLibraryId, ClientCertificateLibraryPrefix, db.ErrInvalidParameter)
+	}
 	return nil
 }
 
@@ -49,8 +49,7 @@ func TestTcpTarget_Create(t *testing.T) {
 				t := allocTcpTarget()
 				t.ScopeId = prj.PublicId
 				t.Name = "valid-proj-scope"
-				t.SessionMaxSeconds = uint32((8 * time.Hour).Seconds())
-				t.SessionConnectionLimit = 1
+				t.SessionRecording = string(SessionRecordingInherit)
 				return &t
 			}(),
 			create: true,
@@ -314,7 +313,7 @@ func TestTcpTarget_Clone(t *testing.T) {
 		_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
 		target := TestTcpTarget(t, conn, proj.PublicId, testTargetName(t, proj.PublicId))
 		cp := target.Clone()
-		assert.True(proto.Equal(cp.(*TcpTarget).TcpTarget, target.TcpTarget))
+		assert.True(proto.Equal(cp.TcpTarget, target.TcpTarget))
 	})
 	t.Run("not-equal", func(t *testing.T) {
 		assert := assert.New(t)
@@ -324,7 +323,7 @@ func TestTcpTarget_Clone(t *testing.T) {
 		target2 := TestTcpTarget(t, conn, proj2.PublicId, testTargetName(t, proj2.PublicId))
 
 		cp := target.Clone()
-		assert.True(!proto.Equal(cp.(*TcpTarget).TcpTarget, target2.TcpTarget))
+		assert.True(!proto.Equal(cp.TcpTarget, target2.TcpTarget))
 	})
 }
 
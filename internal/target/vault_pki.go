@@ -0,0 +1,90 @@
+package target
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// vaultRequestTimeout is how long a request to Vault can take.
+const vaultRequestTimeout = 30 * time.Second
+
+// vaultIssueResponse is the response of Vault's PKI issue endpoint.
+type vaultIssueResponse struct {
+	Data struct {
+		Certificate string   `json:"certificate"`
+		PrivateKey  string   `json:"private_key"`
+		IssuingCa   string   `json:"issuing_ca"`
+		CaChain     []string `json:"ca_chain"`
+		Expiration  int64    `json:"expiration"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+// issueVaultClientCertificate issues a client certificate for commonName valid
+// until notAfter from the role of the Vault PKI secrets engine mounted at
+// pkiPath. The role's max ttl may make it expire sooner.
+func issueVaultClientCertificate(ctx context.Context, address, pkiPath, role, token, commonName string, notAfter time.Time) (*ClientCertificate, error) {
+	ttl := time.Until(notAfter).Round(time.Second)
+	if ttl < time.Second {
+		return nil, fmt.Errorf("certificate would expire at %s, which has passed", notAfter)
+	}
+	body, err := json.Marshal(map[string]string{
+		"common_name": commonName,
+		"ttl":         fmt.Sprintf("%ds", int64(ttl/time.Second)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal vault request: %w", err)
+	}
+	url := fmt.Sprintf("%s/v1/%s/issue/%s", strings.TrimRight(address, "/"), strings.Trim(pkiPath, "/"), role)
+	ctx, cancel := context.WithTimeout(ctx, vaultRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := cleanhttp.DefaultClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to issue certificate from vault: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read vault response: %w", err)
+	}
+	var issued vaultIssueResponse
+	if err := json.Unmarshal(respBody, &issued); err != nil {
+		return nil, fmt.Errorf("unable to parse vault response with status %d: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d: %s", resp.StatusCode, strings.Join(issued.Errors, "; "))
+	}
+	if issued.Data.Certificate == "" || issued.Data.PrivateKey == "" {
+		return nil, fmt.Errorf("vault response is missing the certificate or private key")
+	}
+	// Follow the certificate with its issuers, so the target can build the
+	// chain to the root it trusts.
+	chain := []string{strings.TrimSpace(issued.Data.Certificate)}
+	switch {
+	case len(issued.Data.CaChain) > 0:
+		for _, c := range issued.Data.CaChain {
+			chain = append(chain, strings.TrimSpace(c))
+		}
+	case issued.Data.IssuingCa != "":
+		chain = append(chain, strings.TrimSpace(issued.Data.IssuingCa))
+	}
+	return &ClientCertificate{
+		Certificate:    []byte(strings.Join(chain, "\n") + "\n"),
+		PrivateKey:     []byte(strings.TrimSpace(issued.Data.PrivateKey) + "\n"),
+		ExpirationTime: time.Unix(issued.Data.Expiration, 0),
+	}, nil
+}
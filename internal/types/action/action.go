@@ -1,5 +1,7 @@
 package action
 
+import "github.com/hashicorp/boundary/internal/types/resource"
+
 // Type defines a type for the Actions of Resources
 // actions are also stored as a lookup db table named iam_action
 type Type int
@@ -37,6 +39,28 @@ const (
 	AddAccounts      Type = 28
 	SetAccounts      Type = 29
 	RemoveAccounts   Type = 30
+
+	// AuthorizeSessionUnrecorded exempts a principal from the mandatory
+	// recording of a target's sessions. It must be granted explicitly; a
+	// grant of all actions doesn't include it.
+	AuthorizeSessionUnrecorded Type = 31
+
+	ActivateEmergency Type = 32
+	RevertEmergency   Type = 33
+
+	// ListUnpaged exempts a principal from the maximum page size of a
+	// listing, for batch and export use. It must be granted explicitly; a
+	// grant of all actions doesn't include it.
+	ListUnpaged Type = 34
+
+	Diff Type = 35
+
+	ListBannedGrantPatterns  Type = 36
+	AddBannedGrantPattern    Type = 37
+	RemoveBannedGrantPattern Type = 38
+
+	Archive Type = 39
+	Restore Type = 40
 )
 
 var Map = map[string]Type{
@@ -70,6 +94,20 @@ var Map = map[string]Type{
 	AddAccounts.String():      AddAccounts,
 	SetAccounts.String():      SetAccounts,
 	RemoveAccounts.String():   RemoveAccounts,
+
+	AuthorizeSessionUnrecorded.String(): AuthorizeSessionUnrecorded,
+
+	ActivateEmergency.String(): ActivateEmergency,
+	RevertEmergency.String():   RevertEmergency,
+	ListUnpaged.String():       ListUnpaged,
+	Diff.String():              Diff,
+
+	ListBannedGrantPatterns.String():  ListBannedGrantPatterns,
+	AddBannedGrantPattern.String():    AddBannedGrantPattern,
+	RemoveBannedGrantPattern.String(): RemoveBannedGrantPattern,
+
+	Archive.String(): Archive,
+	Restore.String(): Restore,
 }
 
 func (a Type) String() string {
@@ -105,5 +143,79 @@ func (a Type) String() string {
 		"add-accounts",
 		"set-accounts",
 		"remove-accounts",
+		"authorize-session-unrecorded",
+		"activate-emergency",
+		"revert-emergency",
+		"list-unpaged",
+		"diff",
+		"list-banned-grant-patterns",
+		"add-banned-grant-pattern",
+		"remove-banned-grant-pattern",
+		"archive",
+		"restore",
 	}[a]
 }
+
+// IsCollection reports whether the action is performed on a collection of
+// resources, like list and create, rather than on an instance of a resource,
+// like read, update and delete.
+func (a Type) IsCollection() bool {
+	switch a {
+	case List, Create, ListUnpaged:
+		return true
+	}
+	return false
+}
+
+// resourceActions are the actions which can be granted on each type of
+// resource. List is supported by every type with a collection.
+var resourceActions = map[resource.Type][]Type{
+	resource.Scope:       {Create, Read, Update, Delete, List, ListUnpaged, ListBannedGrantPatterns, AddBannedGrantPattern, RemoveBannedGrantPattern},
+	resource.User:        {Create, Read, Update, Delete, List, ListUnpaged, AddAccounts, SetAccounts, RemoveAccounts},
+	resource.Group:       {Create, Read, Update, Delete, List, ListUnpaged, AddMembers, SetMembers, RemoveMembers},
+	resource.Role:        {Create, Read, Update, Delete, List, ListUnpaged, AddGrants, SetGrants, RemoveGrants, AddPrincipals, SetPrincipals, RemovePrincipals, ActivateEmergency, RevertEmergency, Diff, Archive, Restore},
+	resource.AuthMethod:  {Create, Read, Update, Delete, List, Authenticate},
+	resource.Account:     {Create, Read, Update, Delete, List, SetPassword, ChangePassword},
+	resource.AuthToken:   {Read, Delete, List},
+	resource.HostCatalog: {Create, Read, Update, Delete, List},
+	resource.HostSet:     {Create, Read, Update, Delete, List, AddHosts, SetHosts, RemoveHosts},
+	resource.Host:        {Create, Read, Update, Delete, List},
+	resource.Target:      {Create, Read, Update, Delete, List, AddHostSets, SetHostSets, RemoveHostSets, AuthorizeSession, AuthorizeSessionUnrecorded},
+	resource.Session:     {Read, List, Cancel},
+	resource.Job:         {Read, List, Cancel},
+
+	resource.ClientCertificateLibrary: {Create, Read, Update, Delete, List},
+}
+
+// ForResource returns the actions which can be granted on the type of
+// resource, keyed by their string. It returns false if the actions of the
+// type aren't known, as for the all type.
+func ForResource(typ resource.Type) (map[string]Type, bool) {
+	actions, ok := resourceActions[typ]
+	if !ok {
+		return nil, false
+	}
+	ret := make(map[string]Type, len(actions))
+	for _, a := range actions {
+		ret[a.String()] = a
+	}
+	return ret, true
+}
+
+// ValidForResource reports whether the action can be granted on the type of
+// resource. The all action is valid for every type whose actions are known.
+func ValidForResource(typ resource.Type, a Type) bool {
+	actions, ok := resourceActions[typ]
+	if !ok {
+		return false
+	}
+	if a == All {
+		return true
+	}
+	for _, v := range actions {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
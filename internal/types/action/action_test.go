@@ -3,6 +3,7 @@ package action
 import (
 	"testing"
 
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -75,6 +76,46 @@ func TestAction(t *testing.T) {
 			action: Deauthenticate,
 			want:   "deauthenticate",
 		},
+		{
+			action: AuthorizeSessionUnrecorded,
+			want:   "authorize-session-unrecorded",
+		},
+		{
+			action: ActivateEmergency,
+			want:   "activate-emergency",
+		},
+		{
+			action: RevertEmergency,
+			want:   "revert-emergency",
+		},
+		{
+			action: ListUnpaged,
+			want:   "list-unpaged",
+		},
+		{
+			action: Diff,
+			want:   "diff",
+		},
+		{
+			action: ListBannedGrantPatterns,
+			want:   "list-banned-grant-patterns",
+		},
+		{
+			action: AddBannedGrantPattern,
+			want:   "add-banned-grant-pattern",
+		},
+		{
+			action: RemoveBannedGrantPattern,
+			want:   "remove-banned-grant-pattern",
+		},
+		{
+			action: Archive,
+			want:   "archive",
+		},
+		{
+			action: Restore,
+			want:   "restore",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
@@ -83,3 +124,37 @@ func TestAction(t *testing.T) {
 		})
 	}
 }
+
+func TestAction_IsCollection(t *testing.T) {
+	for _, a := range []Type{List, Create, ListUnpaged} {
+		assert.Truef(t, a.IsCollection(), "%s should be a collection action", a)
+	}
+	for _, a := range []Type{Read, Update, Delete, AuthorizeSession, AddMembers, All, Unknown} {
+		assert.Falsef(t, a.IsCollection(), "%s should be an instance action", a)
+	}
+}
+
+func TestAction_ForResource(t *testing.T) {
+	actions, ok := ForResource(resource.HostSet)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]Type{
+		"create":       Create,
+		"read":         Read,
+		"update":       Update,
+		"delete":       Delete,
+		"list":         List,
+		"add-hosts":    AddHosts,
+		"set-hosts":    SetHosts,
+		"remove-hosts": RemoveHosts,
+	}, actions)
+
+	_, ok = ForResource(resource.All)
+	assert.False(t, ok)
+
+	assert.True(t, ValidForResource(resource.Target, AuthorizeSession))
+	assert.True(t, ValidForResource(resource.Session, All))
+	assert.True(t, ValidForResource(resource.Role, ListUnpaged))
+	assert.False(t, ValidForResource(resource.Session, Create))
+	assert.False(t, ValidForResource(resource.Host, AddHosts))
+	assert.False(t, ValidForResource(resource.All, Read))
+}
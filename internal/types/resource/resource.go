@@ -20,6 +20,10 @@ const (
 	Controller  Type = 13
 	Worker      Type = 14
 	Session     Type = 15
+	ClaimRule   Type = 16
+	Job         Type = 17
+
+	ClientCertificateLibrary Type = 18
 )
 
 func (r Type) String() string {
@@ -40,6 +44,9 @@ func (r Type) String() string {
 		"controller",
 		"worker",
 		"session",
+		"claim-rule",
+		"job",
+		"client-certificate-library",
 	}[r]
 }
 
@@ -60,4 +67,8 @@ var Map = map[string]Type{
 	Controller.String():  Controller,
 	Worker.String():      Worker,
 	Session.String():     Session,
+	ClaimRule.String():   ClaimRule,
+	Job.String():         Job,
+
+	ClientCertificateLibrary.String(): ClientCertificateLibrary,
 }
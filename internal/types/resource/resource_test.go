@@ -76,6 +76,18 @@ func Test_Resource(t *testing.T) {
 			typeString: "session",
 			want:       Session,
 		},
+		{
+			typeString: "claim-rule",
+			want:       ClaimRule,
+		},
+		{
+			typeString: "job",
+			want:       Job,
+		},
+		{
+			typeString: "client-certificate-library",
+			want:       ClientCertificateLibrary,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.typeString, func(t *testing.T) {
@@ -66,6 +66,7 @@ func main() {
 		account,
 		authMethod,
 		authToken,
+		clientCertificateLibrary,
 		group,
 		host,
 		hostCatalog,
@@ -270,6 +271,18 @@ func clActions(typ string) []*Action {
 	}
 }
 
+// listUnpagedAction is the action of collections whose listings are bounded by
+// the controller's maximum page size.
+func listUnpagedAction(typ string) *Action {
+	return &Action{
+		Name:        "list-unpaged",
+		Description: fmt.Sprintf("List all %ss at once, regardless of the controller's maximum page size. It must be granted by name", typ),
+		Examples: []string{
+			"type=<type>;actions=list,list-unpaged",
+		},
+	}
+}
+
 func rudActions(typ string, pin bool) []*Action {
 	ret := []*Action{
 		{
@@ -429,7 +442,7 @@ var group = &Resource{
 			Params: map[string]string{
 				"Type": "group",
 			},
-			Actions: clActions("a group"),
+			Actions: append(clActions("a group"), listUnpagedAction("group")),
 		},
 		{
 			Path: "/groups/<id>",
@@ -488,6 +501,28 @@ var host = &Resource{
 	},
 }
 
+var clientCertificateLibrary = &Resource{
+	Type:   "Client Certificate Library",
+	Scopes: infraScope,
+	Endpoints: []*Endpoint{
+		{
+			Path: "/client-certificate-libraries",
+			Params: map[string]string{
+				"Type": "client-certificate-library",
+			},
+			Actions: clActions("a client certificate library"),
+		},
+		{
+			Path: "/client-certificate-libraries/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "client-certificate-library",
+			},
+			Actions: rudActions("a client certificate library", false),
+		},
+	},
+}
+
 var hostCatalog = &Resource{
 	Type:   "Host Catalog",
 	Scopes: infraScope,
@@ -568,7 +603,7 @@ var role = &Resource{
 			Params: map[string]string{
 				"Type": "role",
 			},
-			Actions: clActions("a role"),
+			Actions: append(clActions("a role"), listUnpagedAction("role")),
 		},
 		{
 			Path: "/roles/<id>",
@@ -620,6 +655,41 @@ var role = &Resource{
 						"id=<id>;actions=remove-grants",
 					},
 				},
+				&Action{
+					Name:        "activate-emergency",
+					Description: "Activate an emergency role for the requesting user, who must be a principal of it",
+					Examples: []string{
+						"id=<id>;actions=activate-emergency",
+					},
+				},
+				&Action{
+					Name:        "revert-emergency",
+					Description: "Revert the activation of an emergency role",
+					Examples: []string{
+						"id=<id>;actions=revert-emergency",
+					},
+				},
+				&Action{
+					Name:        "diff",
+					Description: "Show how a role differs from another role, on which diff must also be granted, or how it changed over time",
+					Examples: []string{
+						"id=<id>;actions=diff",
+					},
+				},
+				&Action{
+					Name:        "archive",
+					Description: "Archive a role, ending its grants",
+					Examples: []string{
+						"id=<id>;actions=archive",
+					},
+				},
+				&Action{
+					Name:        "restore",
+					Description: "Restore an archived role",
+					Examples: []string{
+						"id=<id>;actions=restore",
+					},
+				},
 			),
 		},
 	},
@@ -634,7 +704,7 @@ var scope = &Resource{
 			Params: map[string]string{
 				"Type": "scope",
 			},
-			Actions: clActions("a scope"),
+			Actions: append(clActions("a scope"), listUnpagedAction("scope")),
 		},
 		{
 			Path: "/scopes/<id>",
@@ -642,7 +712,29 @@ var scope = &Resource{
 				"ID":   "<id>",
 				"Type": "scope",
 			},
-			Actions: rudActions("a scope", false),
+			Actions: append(rudActions("a scope", false),
+				&Action{
+					Name:        "list-banned-grant-patterns",
+					Description: "List the grant patterns banned in a scope",
+					Examples: []string{
+						"id=<id>;actions=list-banned-grant-patterns",
+					},
+				},
+				&Action{
+					Name:        "add-banned-grant-pattern",
+					Description: "Ban a pattern of grants in a scope and the scopes beneath it",
+					Examples: []string{
+						"id=<id>;actions=add-banned-grant-pattern",
+					},
+				},
+				&Action{
+					Name:        "remove-banned-grant-pattern",
+					Description: "Remove a banned grant pattern from a scope",
+					Examples: []string{
+						"id=<id>;actions=remove-banned-grant-pattern",
+					},
+				},
+			),
 		},
 	},
 }
@@ -753,7 +845,7 @@ var user = &Resource{
 			Params: map[string]string{
 				"Type": "user",
 			},
-			Actions: clActions("a user"),
+			Actions: append(clActions("a user"), listUnpagedAction("user")),
 		},
 		{
 			Path: "/users/<id>",
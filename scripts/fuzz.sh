@@ -0,0 +1,29 @@
+#!/usr/bin/env bash
+#
+# This script runs a go-fuzz target. The package's testdata/fuzz directory is
+# used as the go-fuzz workdir, so new corpus inputs and crashers are written
+# there; commit crashers so go test replays them.
+#
+# Usage: scripts/fuzz.sh <package dir> [go-fuzz args...]
+#   e.g. scripts/fuzz.sh internal/perms -procs=4
+set -e
+
+# Get the parent directory of where this script is.
+SOURCE="${BASH_SOURCE[0]}"
+while [ -h "$SOURCE" ] ; do SOURCE="$(readlink "$SOURCE")"; done
+DIR="$( cd -P "$( dirname "$SOURCE" )/.." && pwd )"
+
+PKG="${1:?usage: $0 <package dir> [go-fuzz args...]}"
+shift
+
+if ! command -v go-fuzz-build >/dev/null || ! command -v go-fuzz >/dev/null; then
+    echo "==> go-fuzz is required: go get github.com/dvyukov/go-fuzz/go-fuzz github.com/dvyukov/go-fuzz/go-fuzz-build" >&2
+    exit 1
+fi
+
+cd "$DIR/$PKG"
+ARCHIVE="$(mktemp -d)/fuzz.zip"
+echo "==> Building fuzz target for ${PKG}"
+go-fuzz-build -o "$ARCHIVE" .
+echo "==> Fuzzing ${PKG}, crashers are written to ${PKG}/testdata/fuzz/crashers"
+go-fuzz -bin "$ARCHIVE" -workdir testdata/fuzz "$@"
@@ -35,10 +35,12 @@ export default [
         content: [
           'accounts',
           'auth-methods',
+          'client-certificate-libraries',
           'groups',
           'hosts',
           'host-catalogs',
           'host-sets',
+          'jobs',
           'scopes',
           'sessions',
           'targets',